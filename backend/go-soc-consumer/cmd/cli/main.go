@@ -0,0 +1,184 @@
+// Command cli is a field-debugging tool calling the admin API of a running server.
+//
+// device diagnose prints what the server knows about a device: its stored record, the latest reading
+// of every stream, its latest status transitions and the result of a health check run right away:
+//
+//	ADMIN_TOKEN=... go run ./cmd/cli -url http://localhost:8080 device diagnose AA:BB:CC:DD:EE:FF
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/handlers"
+)
+
+const usage = `usage: cli [flags] <command>
+
+commands:
+  device diagnose <mac>   print the record, telemetry, status transitions and health check of a device
+
+flags:
+`
+
+func main() {
+	var (
+		baseURL = flag.String("url", "http://localhost:8080", "base URL of the server")
+		token   = flag.String("token", os.Getenv("ADMIN_TOKEN"), "admin token (default $ADMIN_TOKEN)")
+	)
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	c := &client{baseURL: strings.TrimRight(*baseURL, "/"), token: *token, http: &http.Client{Timeout: 30 * time.Second}}
+	if err := run(ctx, c, flag.Args(), os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "cli: %v\n", err)
+		if _, ok := err.(usageError); ok {
+			flag.Usage()
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+// usageError reports a command line that names no known command
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+// run executes the command named by args
+func run(ctx context.Context, c *client, args []string, out io.Writer) error {
+	switch {
+	case len(args) == 3 && args[0] == "device" && args[1] == "diagnose":
+		diagnostics, err := c.diagnose(ctx, args[2])
+		if err != nil {
+			return err
+		}
+		return printDiagnostics(out, diagnostics, time.Now())
+	default:
+		return usageError(fmt.Sprintf("unknown command %q", strings.Join(args, " ")))
+	}
+}
+
+// client calls the admin endpoints of the server
+type client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// diagnose reads the diagnostics of a device
+func (c *client) diagnose(ctx context.Context, macAddress string) (*handlers.DeviceDiagnosticsResponse, error) {
+	var diagnostics handlers.DeviceDiagnosticsResponse
+	if err := c.do(ctx, http.MethodGet, "/admin/devices/"+url.PathEscape(macAddress)+"/diagnostics", &diagnostics); err != nil {
+		return nil, err
+	}
+	return &diagnostics, nil
+}
+
+func (c *client) do(ctx context.Context, method, path string, response any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// printDiagnostics writes the diagnostics of a device as aligned sections, with times relative to now
+func printDiagnostics(out io.Writer, diagnostics *handlers.DeviceDiagnosticsResponse, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	device := diagnostics.Device
+
+	fmt.Fprintf(w, "Device %s\n", device.MACAddress)
+	fmt.Fprintf(w, "  name\t%s\n", device.DeviceName)
+	fmt.Fprintf(w, "  ip address\t%s\n", device.IPAddress)
+	fmt.Fprintf(w, "  location\t%s\n", device.LocationDescription)
+	fmt.Fprintf(w, "  status\t%s\n", device.Status)
+	fmt.Fprintf(w, "  registered\t%s\n", stamp(device.RegisteredAt, now))
+	fmt.Fprintf(w, "  last seen\t%s\n", stamp(device.LastSeen, now))
+	if device.FarmID != "" {
+		fmt.Fprintf(w, "  farm\t%s\n", device.FarmID)
+	}
+	if device.HasCertificate {
+		fmt.Fprintln(w, "  certificate\tprovisioned")
+	}
+	if device.CalibratedAt != nil {
+		fmt.Fprintf(w, "  calibrated\t%s\n", stamp(*device.CalibratedAt, now))
+	}
+	if registration := device.LastRegistration; registration != nil {
+		retained := ""
+		if registration.Retained {
+			retained = ", retained"
+		}
+		fmt.Fprintf(w, "  broker\t%s qos %d%s\n", registration.Topic, registration.QoS, retained)
+		if registration.ClientID != "" {
+			fmt.Fprintf(w, "  client id\t%s\n", registration.ClientID)
+		}
+	}
+
+	fmt.Fprintln(w, "\nLast telemetry")
+	if len(diagnostics.Telemetry) == 0 {
+		fmt.Fprintln(w, "  no readings")
+	}
+	for _, reading := range diagnostics.Telemetry {
+		fmt.Fprintf(w, "  %s/%d\t%g %s\t%s\t%s\n", reading.Stream, reading.Channel, reading.Value, reading.Unit, reading.Quality, stamp(reading.Timestamp, now))
+	}
+
+	fmt.Fprintln(w, "\nStatus transitions")
+	if len(diagnostics.Transitions) == 0 {
+		fmt.Fprintln(w, "  none since the server started")
+	}
+	for _, change := range diagnostics.Transitions {
+		fmt.Fprintf(w, "  %s\t%s\n", stamp(change.ChangedAt, now), change.Status)
+	}
+
+	probe := diagnostics.Probe
+	fmt.Fprintln(w, "\nHealth check")
+	switch {
+	case probe.Error != "":
+		fmt.Fprintf(w, "  %s\tfailed after %dms: %s\n", probe.IPAddress, probe.LatencyMS, probe.Error)
+	case probe.Alive:
+		fmt.Fprintf(w, "  %s\talive, answered in %dms\n", probe.IPAddress, probe.LatencyMS)
+	default:
+		fmt.Fprintf(w, "  %s\tnot alive, answered in %dms\n", probe.IPAddress, probe.LatencyMS)
+	}
+	return w.Flush()
+}
+
+// stamp formats a time along with how long ago it was
+func stamp(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return fmt.Sprintf("%s (%s ago)", t.UTC().Format(time.RFC3339), now.Sub(t).Truncate(time.Second))
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/handlers"
+)
+
+func TestRun_DeviceDiagnose(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/devices/{mac}/diagnostics", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", r.PathValue("mac"))
+		_ = json.NewEncoder(w).Encode(handlers.DeviceDiagnosticsResponse{
+			GeneratedAt: now,
+			Device: handlers.DeviceRecordResponse{
+				MACAddress: "AA:BB:CC:DD:EE:FF",
+				DeviceName: "probe",
+				IPAddress:  "192.168.1.10",
+				Status:     "online",
+				LastSeen:   now.Add(-time.Minute),
+				LastRegistration: &handlers.BrokerMetadataResponse{
+					Topic: "/liwaisi/iot/smart-irrigation/device/registration", QoS: 1, Retained: true, ClientID: "probe-7",
+				},
+			},
+			Telemetry: []handlers.TelemetryReadingResponse{
+				{Stream: "soil_moisture", Channel: 1, Value: 31.5, Unit: "percent", Quality: "good", Timestamp: now.Add(-time.Minute)},
+			},
+			Transitions: []handlers.DeviceChangeResponse{{Status: "online", ChangedAt: now.Add(-time.Hour)}},
+			Probe:       handlers.DeviceProbeResponse{IPAddress: "192.168.1.10", Alive: true, LatencyMS: 42},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := &client{baseURL: server.URL, token: "secret", http: server.Client()}
+	var out bytes.Buffer
+	require.NoError(t, run(context.Background(), c, []string{"device", "diagnose", "AA:BB:CC:DD:EE:FF"}, &out))
+
+	assert.Contains(t, out.String(), "Device AA:BB:CC:DD:EE:FF\n")
+	assert.Contains(t, out.String(), "  ip address  192.168.1.10\n")
+	assert.Contains(t, out.String(), "  broker      /liwaisi/iot/smart-irrigation/device/registration qos 1, retained\n")
+	assert.Contains(t, out.String(), "  client id   probe-7\n")
+	assert.Contains(t, out.String(), "  soil_moisture/1  31.5 percent  good")
+	assert.Contains(t, out.String(), "online\n")
+	assert.Contains(t, out.String(), "  192.168.1.10  alive, answered in 42ms\n")
+}
+
+func TestRun_ReportsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "device not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := &client{baseURL: server.URL, token: "secret", http: server.Client()}
+	err := run(context.Background(), c, []string{"device", "diagnose", "AA:BB:CC:DD:EE:FF"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "status 404: device not found")
+
+	err = run(context.Background(), c, []string{"device", "reboot"}, &bytes.Buffer{})
+	assert.IsType(t, usageError(""), err)
+}
+
+func TestPrintDiagnostics_FailedHealthCheck(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, printDiagnostics(&out, &handlers.DeviceDiagnosticsResponse{
+		Device: handlers.DeviceRecordResponse{MACAddress: "AA:BB:CC:DD:EE:FF"},
+		Probe:  handlers.DeviceProbeResponse{IPAddress: "192.168.1.10", LatencyMS: 5000, Error: "context deadline exceeded"},
+	}, time.Now()))
+
+	assert.Contains(t, out.String(), "  last seen   never\n")
+	assert.Contains(t, out.String(), "  no readings\n")
+	assert.Contains(t, out.String(), "  none since the server started\n")
+	assert.Contains(t, out.String(), "failed after 5000ms: context deadline exceeded\n")
+}
@@ -0,0 +1,487 @@
+// Command eventgen generates the mappers and validators of the events published on NATS.
+//
+// Every payload struct of the DTOs package annotated with an eventgen directive gets a mapper from
+// its domain entity and a validator of its required fields, and is added to ToEventDTO, which the
+// publisher maps events through:
+//
+//	//eventgen:event type=alert version=1 from=Alert skip=LocalizedDescriptions
+//
+// type and version name the payload schema; from is the entity it is mapped from, the payload
+// name by default. Fields are copied by name and must have the same type in both structs, and an
+// entity field missing from the payload must be listed in skip, so a field added to an entity
+// fails generation instead of being silently left out of the published event. Payloads that need
+// conversions name a hand-written mapper=Func instead and are only validated. Fields without
+// omitempty in their JSON tag are required: strings must not be empty and times not zero. It is
+// run through go:generate from the package the mappers are written to:
+//
+//	//go:generate go run ../../../../../cmd/eventgen -dtos ../dtos -entities ../../../../domain/entities -out events_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const directive = "//eventgen:event "
+
+// options configures one generated file
+type options struct {
+	DTOsDir     string // directory of the payload package
+	EntitiesDir string // directory of the entities package
+	Package     string // package of the generated file
+	Source      string // payload directory as recorded in the header
+}
+
+func main() {
+	var (
+		dtosDir     = flag.String("dtos", "", "directory of the payload package")
+		entitiesDir = flag.String("entities", "", "directory of the entities package")
+		pkg         = flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file")
+		out         = flag.String("out", "", "output file")
+	)
+	flag.Parse()
+
+	if *dtosDir == "" || *entitiesDir == "" || *out == "" || *pkg == "" {
+		fmt.Fprintln(os.Stderr, "eventgen: -dtos, -entities, -out and -package are required")
+		os.Exit(2)
+	}
+
+	code, err := generate(options{DTOsDir: *dtosDir, EntitiesDir: *entitiesDir, Package: *pkg, Source: filepath.ToSlash(*dtosDir)})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "eventgen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "eventgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// event is a payload annotated with an eventgen directive
+type event struct {
+	Name    string // payload struct
+	Type    string // event_type of the payload
+	Version string
+	From    string // entity struct
+	Mapper  string // hand-written mapper, empty when generated
+	Skip    map[string]bool
+	Fields  []field
+}
+
+// field is a field of a payload or entity struct
+type field struct {
+	Name     string
+	Type     string // type as seen from the generated package
+	JSONName string
+	Optional bool // omitempty payload field
+}
+
+// generate returns the formatted source of the mappers of the annotated payloads
+func generate(opts options) ([]byte, error) {
+	dtosPath, err := packageImportPath(opts.DTOsDir)
+	if err != nil {
+		return nil, err
+	}
+	entitiesPath, err := packageImportPath(opts.EntitiesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	dtoFiles, err := parsePackage(fset, opts.DTOsDir)
+	if err != nil {
+		return nil, err
+	}
+	entityFiles, err := parsePackage(fset, opts.EntitiesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := annotatedEvents(dtoFiles, filepath.Base(dtosPath))
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("no payloads annotated with %q in %s", strings.TrimSpace(directive), opts.DTOsDir)
+	}
+	entities := structFields(entityFiles, filepath.Base(entitiesPath))
+
+	var body bytes.Buffer
+	writeDispatcher(&body, events)
+	for _, ev := range events {
+		if ev.Mapper == "" {
+			fields, ok := entities[ev.From]
+			if !ok {
+				return nil, fmt.Errorf("%s: entity %s not found", ev.Name, ev.From)
+			}
+			if err := checkFields(ev, fields); err != nil {
+				return nil, fmt.Errorf("%s: %w", ev.Name, err)
+			}
+			writeMapper(&body, ev)
+		}
+		if err := writeValidator(&body, ev); err != nil {
+			return nil, fmt.Errorf("%s: %w", ev.Name, err)
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by eventgen from %s. DO NOT EDIT.\n\n", opts.Source)
+	fmt.Fprintf(&src, "package %s\n\nimport (\n\t\"fmt\"\n\n", opts.Package)
+	imports := []string{entitiesPath, dtosPath}
+	sort.Strings(imports)
+	for _, path := range imports {
+		fmt.Fprintf(&src, "\t%s\n", strconv.Quote(path))
+	}
+	src.WriteString(")\n")
+	src.Write(body.Bytes())
+
+	code, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return code, nil
+}
+
+// annotatedEvents returns the payload structs carrying an eventgen directive, sorted by name
+func annotatedEvents(files []*ast.File, pkg string) ([]*event, error) {
+	var events []*event
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				doc := typeSpec.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+				args, ok := directiveArgs(doc)
+				if !ok {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					return nil, fmt.Errorf("%s: only structs can be events", typeSpec.Name.Name)
+				}
+				ev, err := newEvent(typeSpec.Name.Name, args)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", typeSpec.Name.Name, err)
+				}
+				if ev.Fields, err = fieldsOf(structType, localTypes(files), pkg); err != nil {
+					return nil, fmt.Errorf("%s: %w", typeSpec.Name.Name, err)
+				}
+				events = append(events, ev)
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Name < events[j].Name })
+	return events, nil
+}
+
+// directiveArgs returns the arguments of the eventgen directive of a doc comment
+func directiveArgs(doc *ast.CommentGroup) (string, bool) {
+	if doc == nil {
+		return "", false
+	}
+	for _, comment := range doc.List {
+		if args, ok := strings.CutPrefix(comment.Text, directive); ok {
+			return args, true
+		}
+	}
+	return "", false
+}
+
+// newEvent parses the key=value arguments of a directive
+func newEvent(name, args string) (*event, error) {
+	ev := &event{Name: name, From: name, Skip: map[string]bool{}}
+	for _, arg := range strings.Fields(args) {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid directive argument %q", arg)
+		}
+		switch key {
+		case "type":
+			ev.Type = value
+		case "version":
+			ev.Version = value
+		case "from":
+			ev.From = value
+		case "mapper":
+			ev.Mapper = value
+		case "skip":
+			for _, skipped := range strings.Split(value, ",") {
+				ev.Skip[skipped] = true
+			}
+		default:
+			return nil, fmt.Errorf("unknown directive argument %q", key)
+		}
+	}
+	if ev.Type == "" || ev.Version == "" {
+		return nil, fmt.Errorf("directive requires type and version")
+	}
+	return ev, nil
+}
+
+// structFields returns the fields of every struct of a package by struct name
+func structFields(files []*ast.File, pkg string) map[string][]field {
+	local := localTypes(files)
+	structs := map[string][]field{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok || !typeSpec.Name.IsExported() {
+					continue
+				}
+				fields, err := fieldsOf(structType, local, pkg)
+				if err != nil {
+					// Only the entities events are mapped from have to be supported
+					continue
+				}
+				structs[typeSpec.Name.Name] = fields
+			}
+		}
+	}
+	return structs
+}
+
+// fieldsOf returns the exported fields of a struct, with local types qualified by the package name
+func fieldsOf(structType *ast.StructType, local map[string]bool, pkg string) ([]field, error) {
+	var fields []field
+	for _, f := range structType.Fields.List {
+		if len(f.Names) == 0 {
+			return nil, fmt.Errorf("embedded fields are not supported")
+		}
+		typ, err := typeString(f.Type, local, pkg)
+		if err != nil {
+			return nil, err
+		}
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			unquoted, _ := strconv.Unquote(f.Tag.Value)
+			tag = reflect.StructTag(unquoted)
+		}
+		jsonName, jsonOptions, _ := strings.Cut(tag.Get("json"), ",")
+		for _, name := range f.Names {
+			if !name.IsExported() {
+				continue
+			}
+			fields = append(fields, field{
+				Name:     name.Name,
+				Type:     typ,
+				JSONName: jsonName,
+				Optional: strings.Contains(jsonOptions, "omitempty"),
+			})
+		}
+	}
+	return fields, nil
+}
+
+// checkFields ensures the payload publishes every field of the entity it is copied from
+func checkFields(ev *event, entityFields []field) error {
+	byName := map[string]field{}
+	for _, f := range entityFields {
+		byName[f.Name] = f
+	}
+
+	published := map[string]bool{}
+	for _, f := range ev.Fields {
+		source, ok := byName[f.Name]
+		if !ok {
+			return fmt.Errorf("field %s has no counterpart in %s", f.Name, ev.From)
+		}
+		if source.Type != f.Type {
+			return fmt.Errorf("field %s is %s in the payload but %s in %s", f.Name, f.Type, source.Type, ev.From)
+		}
+		published[f.Name] = true
+	}
+	for _, f := range entityFields {
+		if !published[f.Name] && !ev.Skip[f.Name] {
+			return fmt.Errorf("field %s of %s is not published; add it to the payload or to skip", f.Name, ev.From)
+		}
+	}
+	return nil
+}
+
+func writeDispatcher(w *bytes.Buffer, events []*event) {
+	fmt.Fprintf(w, "\n// ToEventDTO maps a domain event to the payload published for it and validates the payload\n")
+	fmt.Fprintf(w, "func ToEventDTO(event interface{}) (interface{}, EventSchema, error) {\n\tswitch event := event.(type) {\n")
+	for _, ev := range events {
+		mapper := ev.Mapper
+		if mapper == "" {
+			mapper = "To" + ev.Name + "DTO"
+		}
+		fmt.Fprintf(w, "\tcase *entities.%s:\n", ev.From)
+		fmt.Fprintf(w, "\t\tdto := %s(event)\n", mapper)
+		fmt.Fprintf(w, "\t\tif err := Validate%s(dto); err != nil {\n\t\t\treturn nil, EventSchema{}, err\n\t\t}\n", ev.Name)
+		fmt.Fprintf(w, "\t\treturn dto, EventSchema{Type: %q, Version: %q}, nil\n", ev.Type, ev.Version)
+	}
+	fmt.Fprintf(w, "\tdefault:\n\t\treturn nil, EventSchema{}, fmt.Errorf(\"unsupported event type: %%T\", event)\n\t}\n}\n")
+}
+
+func writeMapper(w *bytes.Buffer, ev *event) {
+	fmt.Fprintf(w, "\n// To%sDTO maps the entity to version %s of the %s payload\n", ev.Name, ev.Version, ev.Type)
+	fmt.Fprintf(w, "func To%sDTO(event *entities.%s) *dtos.%s {\n", ev.Name, ev.From, ev.Name)
+	fmt.Fprintf(w, "\tif event == nil {\n\t\treturn nil\n\t}\n\treturn &dtos.%s{\n", ev.Name)
+	for _, f := range ev.Fields {
+		fmt.Fprintf(w, "\t\t%s: event.%s,\n", f.Name, f.Name)
+	}
+	fmt.Fprintf(w, "\t}\n}\n")
+}
+
+func writeValidator(w *bytes.Buffer, ev *event) error {
+	var eventType *field
+	for i, f := range ev.Fields {
+		if f.Name == "EventType" {
+			eventType = &ev.Fields[i]
+		}
+	}
+	if eventType == nil {
+		return fmt.Errorf("payload has no EventType field")
+	}
+
+	fmt.Fprintf(w, "\n// Validate%s checks the fields required by version %s of the %s payload\n", ev.Name, ev.Version, ev.Type)
+	fmt.Fprintf(w, "func Validate%s(dto *dtos.%s) error {\n", ev.Name, ev.Name)
+	fmt.Fprintf(w, "\tif dto == nil {\n\t\treturn fmt.Errorf(\"%s: payload is missing\")\n\t}\n", ev.Type)
+	fmt.Fprintf(w, "\tif dto.EventType != %q {\n\t\treturn fmt.Errorf(\"%s: %s must be %%q, got %%q\", %q, dto.EventType)\n\t}\n",
+		ev.Type, ev.Type, eventType.JSONName, ev.Type)
+	for _, f := range ev.Fields {
+		if f.Optional || f.Name == "EventType" {
+			continue
+		}
+		var check string
+		switch f.Type {
+		case "string":
+			check = fmt.Sprintf("dto.%s == \"\"", f.Name)
+		case "time.Time":
+			check = fmt.Sprintf("dto.%s.IsZero()", f.Name)
+		default:
+			continue
+		}
+		fmt.Fprintf(w, "\tif %s {\n\t\treturn fmt.Errorf(\"%s: %s is required\")\n\t}\n", check, ev.Type, f.JSONName)
+	}
+	fmt.Fprintf(w, "\treturn nil\n}\n")
+	return nil
+}
+
+// localTypes returns the names of the types declared by a package
+func localTypes(files []*ast.File) map[string]bool {
+	local := map[string]bool{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				local[spec.(*ast.TypeSpec).Name.Name] = true
+			}
+		}
+	}
+	return local
+}
+
+// typeString prints a type expression as seen from the generated package, so the types of a
+// payload and an entity field can be compared
+func typeString(expr ast.Expr, local map[string]bool, pkg string) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if local[t.Name] {
+			return pkg + "." + t.Name, nil
+		}
+		return t.Name, nil
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			return x.Name + "." + t.Sel.Name, nil
+		}
+	case *ast.StarExpr:
+		elem, err := typeString(t.X, local, pkg)
+		return "*" + elem, err
+	case *ast.ArrayType:
+		if t.Len == nil {
+			elem, err := typeString(t.Elt, local, pkg)
+			return "[]" + elem, err
+		}
+	case *ast.MapType:
+		key, err := typeString(t.Key, local, pkg)
+		if err != nil {
+			return "", err
+		}
+		value, err := typeString(t.Value, local, pkg)
+		return "map[" + key + "]" + value, err
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return "interface{}", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %T", expr)
+}
+
+// parsePackage parses the non-test Go files of a directory
+func parsePackage(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, path := range matches {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return files, nil
+}
+
+// packageImportPath derives the import path of a directory from the enclosing go.mod
+func packageImportPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for root := abs; ; root = filepath.Dir(root) {
+		data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					rel, err := filepath.Rel(root, abs)
+					if err != nil {
+						return "", err
+					}
+					return strings.TrimSpace(module) + "/" + filepath.ToSlash(rel), nil
+				}
+			}
+			return "", fmt.Errorf("no module directive in %s", filepath.Join(root, "go.mod"))
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("no go.mod above %s", abs)
+		}
+	}
+}
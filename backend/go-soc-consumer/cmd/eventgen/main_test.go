@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	const mappersDir = "../../internal/infrastructure/messaging/nats/mappers"
+	code, err := generate(options{
+		DTOsDir:     filepath.Join(mappersDir, "../dtos"),
+		EntitiesDir: filepath.Join(mappersDir, "../../../../domain/entities"),
+		Package:     "mappers",
+		Source:      "../dtos",
+	})
+	require.NoError(t, err)
+
+	committed, err := os.ReadFile(filepath.Join(mappersDir, "events_gen.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(committed), string(code), "run go generate ./internal/infrastructure/messaging/nats/mappers/...")
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0o644))
+	write := func(pkg, src string) string {
+		pkgDir := filepath.Join(dir, pkg)
+		require.NoError(t, os.MkdirAll(pkgDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(pkgDir, pkg+".go"), []byte(src), 0o644))
+		return pkgDir
+	}
+	entitiesDir := write("entities", `package entities
+
+import "time"
+
+type Level int
+
+type Reading struct {
+	EventType string
+	Sensor    string
+	Level     Level
+	Note      string
+	TakenAt   time.Time
+}
+`)
+	dtos := `package dtos
+
+import "time"
+
+// Reading is published for every reading
+//
+//eventgen:event type=reading version=2 skip=Level
+type Reading struct {
+	EventType string    ` + "`json:\"event_type\"`" + `
+	Sensor    string    ` + "`json:\"sensor\"`" + `
+	Note      string    ` + "`json:\"note,omitempty\"`" + `
+	TakenAt   time.Time ` + "`json:\"taken_at\"`" + `
+}
+
+type NotAnEvent struct{ Ignored string }
+`
+	dtosDir := write("dtos", dtos)
+
+	code, err := generate(options{DTOsDir: dtosDir, EntitiesDir: entitiesDir, Package: "mappers", Source: "dtos"})
+	require.NoError(t, err)
+
+	src := string(code)
+	assert.Contains(t, src, "case *entities.Reading:\n\t\tdto := ToReadingDTO(event)")
+	assert.Contains(t, src, `return dto, EventSchema{Type: "reading", Version: "2"}, nil`)
+	assert.Contains(t, src, "\t\tSensor:    event.Sensor,\n")
+	assert.NotContains(t, src, "Level:")
+	assert.Contains(t, src, "if dto.Sensor == \"\" {\n\t\treturn fmt.Errorf(\"reading: sensor is required\")")
+	assert.Contains(t, src, "if dto.TakenAt.IsZero() {")
+	assert.NotContains(t, src, "dto.Note ==", "omitempty fields are optional")
+	assert.NotContains(t, src, "NotAnEvent")
+
+	t.Run("should fail when an entity field is not published", func(t *testing.T) {
+		write("dtos", strings.Replace(dtos, " skip=Level", "", 1))
+
+		_, err := generate(options{DTOsDir: dtosDir, EntitiesDir: entitiesDir, Package: "mappers"})
+		assert.ErrorContains(t, err, "field Level of Reading is not published")
+	})
+
+	t.Run("should fail when a field type differs", func(t *testing.T) {
+		write("dtos", strings.Replace(dtos, "Sensor    string ", "Sensor    int    ", 1))
+
+		_, err := generate(options{DTOsDir: dtosDir, EntitiesDir: entitiesDir, Package: "mappers"})
+		assert.ErrorContains(t, err, "field Sensor is int in the payload but string in Reading")
+	})
+}
@@ -0,0 +1,171 @@
+// Command loadtest drives synthetic device traffic against a running server over MQTT and
+// prints a report of the throughput, publish latency and errors, together with the ingestion
+// latency the server recorded for the traffic when its metrics endpoint is given:
+//
+//	go run ./cmd/loadtest -broker tcp://localhost:1883 -devices 200 -rate 500 -duration 2m -metrics-url http://localhost:8080/metrics
+//
+// With -compare it instead checks `go test -bench` output against a baseline and exits with an
+// error when a benchmark regressed beyond the tolerance:
+//
+//	go test -run '^$' -bench . -benchmem -count 3 ./internal/infrastructure/messaging/mqtt/handlers/ > bench.txt
+//	go run ./cmd/loadtest -compare tools/loadtest/testdata/baseline.txt bench.txt
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/tools/loadtest"
+)
+
+func main() {
+	defaults := loadtest.DefaultConfig()
+	var (
+		broker     = flag.String("broker", "tcp://localhost:1883", "MQTT broker URL")
+		clientID   = flag.String("client-id", "loadtest", "MQTT client ID")
+		username   = flag.String("username", os.Getenv("MQTT_USERNAME"), "MQTT username (default $MQTT_USERNAME)")
+		password   = flag.String("password", os.Getenv("MQTT_PASSWORD"), "MQTT password (default $MQTT_PASSWORD)")
+		qos        = flag.Uint("qos", 1, "MQTT QoS of the published messages")
+		metricsURL = flag.String("metrics-url", "", "server metrics endpoint to read the ingestion latency from, e.g. http://localhost:8080/metrics")
+		compare    = flag.String("compare", "", "baseline `go test -bench` output to compare the benchmark output given as argument against")
+		tolerance  = flag.Float64("tolerance", 0.25, "relative growth of ns/op or allocs/op over the baseline reported as a regression")
+		config     = *defaults
+	)
+	flag.IntVar(&config.Devices, "devices", defaults.Devices, "synthetic devices")
+	flag.Float64Var(&config.Rate, "rate", defaults.Rate, "messages per second across all devices")
+	flag.DurationVar(&config.Duration, "duration", defaults.Duration, "how long traffic is sent")
+	flag.IntVar(&config.Concurrency, "concurrency", defaults.Concurrency, "publishes awaiting their acknowledgement at once")
+	flag.Float64Var(&config.RegistrationRatio, "registration-ratio", defaults.RegistrationRatio, "share of messages re-registering a device")
+	flag.Float64Var(&config.MeasurementRatio, "measurement-ratio", defaults.MeasurementRatio, "share of telemetry sent as measurement messages")
+	flag.StringVar(&config.FarmID, "farm", "", "publish on the topics of this farm")
+	flag.Int64Var(&config.Seed, "seed", defaults.Seed, "seed of the generated values")
+	flag.Parse()
+
+	if *compare != "" {
+		if flag.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "loadtest: -compare takes the benchmark output to check as argument")
+			os.Exit(2)
+		}
+		regressed, err := compareFiles(*compare, flag.Arg(0), *tolerance, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "loadtest: %v\n", err)
+			os.Exit(1)
+		}
+		if regressed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := config.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "loadtest: %v\n", err)
+		os.Exit(2)
+	}
+	if *qos > 2 {
+		fmt.Fprintln(os.Stderr, "loadtest: qos must be 0, 1 or 2")
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	publisher, err := loadtest.NewMQTTPublisher(loadtest.MQTTConfig{
+		BrokerURL: *broker,
+		ClientID:  *clientID,
+		Username:  *username,
+		Password:  *password,
+		QoS:       byte(*qos),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loadtest: %v\n", err)
+		os.Exit(1)
+	}
+	defer publisher.Close()
+
+	report, err := run(ctx, &config, publisher, *metricsURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loadtest: %v\n", err)
+		os.Exit(1)
+	}
+	if err := report.WriteText(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "loadtest: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run sends the traffic and, when a metrics endpoint is given, adds the ingestion latency the
+// server recorded meanwhile. Messages still in flight at the end of the run are given a few
+// seconds to be persisted before the metrics are read again.
+func run(ctx context.Context, config *loadtest.Config, publisher loadtest.Publisher, metricsURL string) (*loadtest.Report, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	var before loadtest.LatencySnapshot
+	if metricsURL != "" {
+		var err error
+		if before, err = loadtest.ScrapeIngestionLatency(ctx, client, metricsURL); err != nil {
+			return nil, fmt.Errorf("failed to read server metrics: %w", err)
+		}
+	}
+
+	report, err := loadtest.Run(ctx, config, publisher)
+	if err != nil || metricsURL == "" {
+		return report, err
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(settleDelay):
+	}
+	after, err := loadtest.ScrapeIngestionLatency(context.WithoutCancel(ctx), client, metricsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server metrics: %w", err)
+	}
+	report.Server = after.Since(before)
+	return report, nil
+}
+
+// settleDelay is how long the server is given to persist the last messages
+var settleDelay = 3 * time.Second
+
+// compareFiles compares benchmark output against a baseline, printing the regressions
+func compareFiles(baselinePath, currentPath string, tolerance float64, out io.Writer) (bool, error) {
+	baseline, err := parseFile(baselinePath)
+	if err != nil {
+		return false, err
+	}
+	current, err := parseFile(currentPath)
+	if err != nil {
+		return false, err
+	}
+	if len(current) == 0 {
+		return false, fmt.Errorf("no benchmarks in %s", currentPath)
+	}
+
+	regressions := loadtest.CompareBenchmarks(baseline, current, tolerance)
+	for _, regression := range regressions {
+		fmt.Fprintf(out, "%s: %s %.0f -> %.0f (+%.0f%%)\n", regression.Name, regression.Unit, regression.Baseline, regression.Current, regression.Change*100)
+	}
+	if len(regressions) == 0 {
+		fmt.Fprintf(out, "no regressions beyond %.0f%% in %d benchmarks\n", tolerance*100, len(current))
+	}
+	return len(regressions) > 0, nil
+}
+
+func parseFile(path string) (map[string]map[string]float64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	results, err := loadtest.ParseBenchmarks(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return results, nil
+}
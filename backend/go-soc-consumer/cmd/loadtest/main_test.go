@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/tools/loadtest"
+)
+
+type countingPublisher struct {
+	published atomic.Int64
+}
+
+func (p *countingPublisher) Publish(context.Context, string, []byte) error {
+	p.published.Add(1)
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	settleDelay = 0
+	var scrapes atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := 10 * scrapes.Add(1)
+		fmt.Fprintf(w, "ingestion_latency_seconds_bucket{le=\"0.1\",stage=\"receipt_to_commit\",topic=\"t\"} %d\n", count)
+		fmt.Fprintf(w, "ingestion_latency_seconds_bucket{le=\"+Inf\",stage=\"receipt_to_commit\",topic=\"t\"} %d\n", count)
+	}))
+	defer server.Close()
+
+	config := loadtest.DefaultConfig()
+	config.Rate = 200
+	config.Duration = 100 * time.Millisecond
+	publisher := &countingPublisher{}
+
+	report, err := run(context.Background(), config, publisher, server.URL)
+	require.NoError(t, err)
+
+	assert.Equal(t, int(publisher.published.Load()), report.Sent())
+	require.Len(t, report.Server, 1)
+	assert.Equal(t, uint64(10), report.Server[0].Count, "only the observations of the run count")
+}
+
+func TestCompareFiles(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "bench.txt")
+	require.NoError(t, os.WriteFile(current, []byte(
+		"BenchmarkSensorDataHandler_HandleMessage-8 \t 100 \t 99999999 ns/op \t 119 allocs/op\n"), 0o600))
+
+	var out bytes.Buffer
+	regressed, err := compareFiles("../../tools/loadtest/testdata/baseline.txt", current, 0.25, &out)
+	require.NoError(t, err)
+	assert.True(t, regressed)
+	assert.Contains(t, out.String(), "BenchmarkSensorDataHandler_HandleMessage: ns/op")
+
+	regressed, err = compareFiles("../../tools/loadtest/testdata/baseline.txt", "../../tools/loadtest/testdata/baseline.txt", 0.25, &out)
+	require.NoError(t, err)
+	assert.False(t, regressed)
+
+	_, err = compareFiles("../../tools/loadtest/testdata/baseline.txt", filepath.Join(dir, "missing.txt"), 0.25, &out)
+	assert.Error(t, err)
+}
@@ -0,0 +1,410 @@
+// Command portgen generates observed decorators for the interfaces of a ports package.
+//
+// Each decorator wraps a port implementation and reports every call that takes a context to an
+// observability.Recorder, so ports get metrics, tracing and slow-call logging without hand-written
+// timing. Methods without a context are delegated unobserved. It is run through go:generate from
+// the package the decorators are written to:
+//
+//	//go:generate go run ../../../cmd/portgen -ports ../../domain/ports/repositories -alias repositoryports -out repositories_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// options configures one generated file
+type options struct {
+	PortsDir string   // directory of the ports package
+	Alias    string   // name the ports package is imported as
+	Include  []string // interfaces to decorate, all exported interfaces when empty
+	Package  string   // package of the generated file
+	Source   string   // ports directory as recorded in the header
+}
+
+func main() {
+	var (
+		portsDir = flag.String("ports", "", "directory of the ports package")
+		alias    = flag.String("alias", "ports", "name the ports package is imported as")
+		include  = flag.String("include", "", "comma-separated interfaces to decorate (default all)")
+		pkg      = flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file")
+		out      = flag.String("out", "", "output file")
+	)
+	flag.Parse()
+
+	if *portsDir == "" || *out == "" || *pkg == "" {
+		fmt.Fprintln(os.Stderr, "portgen: -ports, -out and -package are required")
+		os.Exit(2)
+	}
+
+	opts := options{PortsDir: *portsDir, Alias: *alias, Package: *pkg, Source: filepath.ToSlash(*portsDir)}
+	if *include != "" {
+		opts.Include = strings.Split(*include, ",")
+	}
+
+	code, err := generate(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "portgen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "portgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the formatted source of the decorators for the ports package
+func generate(opts options) ([]byte, error) {
+	importPath, err := packageImportPath(opts.PortsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	files, err := parsePackage(fset, opts.PortsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Types declared by the ports package must be qualified in the generated file
+	local := map[string]bool{}
+	imports := map[string]string{} // package name -> import path
+	var ifaces []*ast.TypeSpec
+	for _, file := range files {
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := filepath.Base(path)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			imports[name] = path
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				local[typeSpec.Name.Name] = true
+				if _, ok := typeSpec.Type.(*ast.InterfaceType); ok && typeSpec.Name.IsExported() {
+					ifaces = append(ifaces, typeSpec)
+				}
+			}
+		}
+	}
+	sort.Slice(ifaces, func(i, j int) bool { return ifaces[i].Name.Name < ifaces[j].Name.Name })
+
+	ifaces, err = selectInterfaces(ifaces, opts.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{fset: fset, alias: opts.Alias, local: local, used: map[string]bool{"context": true}}
+	var body bytes.Buffer
+	for _, iface := range ifaces {
+		if err := g.writeDecorator(&body, iface); err != nil {
+			return nil, err
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by portgen from %s. DO NOT EDIT.\n\n", opts.Source)
+	fmt.Fprintf(&src, "package %s\n\nimport (\n", opts.Package)
+	names := make([]string, 0, len(g.used))
+	for name := range g.used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Standard library imports come first, then the module's own packages
+	var std, module []string
+	for _, name := range names {
+		path, ok := imports[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown package %s", name)
+		}
+		spec := strconv.Quote(path)
+		if filepath.Base(path) != name {
+			spec = name + " " + spec
+		}
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			module = append(module, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	module = append(module, opts.Alias+" "+strconv.Quote(importPath))
+	sort.Slice(module, func(i, j int) bool { return importPathOf(module[i]) < importPathOf(module[j]) })
+	fmt.Fprintf(&src, "\t%s\n\n\t%s\n)\n", strings.Join(std, "\n\t"), strings.Join(module, "\n\t"))
+	src.Write(body.Bytes())
+
+	code, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return code, nil
+}
+
+// selectInterfaces keeps the included interfaces, or all of them when none are listed
+func selectInterfaces(ifaces []*ast.TypeSpec, include []string) ([]*ast.TypeSpec, error) {
+	if len(include) == 0 {
+		return ifaces, nil
+	}
+
+	byName := map[string]*ast.TypeSpec{}
+	for _, iface := range ifaces {
+		byName[iface.Name.Name] = iface
+	}
+
+	selected := make([]*ast.TypeSpec, 0, len(include))
+	for _, name := range include {
+		iface, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("interface %s not found", name)
+		}
+		selected = append(selected, iface)
+	}
+	return selected, nil
+}
+
+// parsePackage parses the non-test Go files of a directory
+func parsePackage(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, path := range matches {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return files, nil
+}
+
+// packageImportPath derives the import path of a directory from the enclosing go.mod
+func packageImportPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for root := abs; ; root = filepath.Dir(root) {
+		data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					rel, err := filepath.Rel(root, abs)
+					if err != nil {
+						return "", err
+					}
+					return strings.TrimSpace(module) + "/" + filepath.ToSlash(rel), nil
+				}
+			}
+			return "", fmt.Errorf("no module directive in %s", filepath.Join(root, "go.mod"))
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("no go.mod above %s", abs)
+		}
+	}
+}
+
+// generator writes the decorators of one ports package
+type generator struct {
+	fset  *token.FileSet
+	alias string
+	local map[string]bool
+	used  map[string]bool // imported package names referenced by the decorators
+}
+
+func (g *generator) writeDecorator(w *bytes.Buffer, iface *ast.TypeSpec) error {
+	name := iface.Name.Name
+	impl := "observed" + name
+	portType := g.alias + "." + name
+
+	fmt.Fprintf(w, "\n// %s reports the calls made through the wrapped %s to a Recorder\n", impl, name)
+	fmt.Fprintf(w, "type %s struct {\n\tinner %s\n\trecorder *Recorder\n}\n", impl, portType)
+	fmt.Fprintf(w, "\n// NewObserved%s wraps the %s with call metrics, tracing and slow-call logging\n", name, name)
+	fmt.Fprintf(w, "func NewObserved%s(inner %s, recorder *Recorder) %s {\n", name, portType, portType)
+	fmt.Fprintf(w, "\treturn &%s{inner: inner, recorder: recorder}\n}\n", impl)
+
+	for _, field := range iface.Type.(*ast.InterfaceType).Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return fmt.Errorf("%s: embedded interfaces are not supported", name)
+		}
+		if err := g.writeMethod(w, impl, name, field.Names[0].Name, fn); err != nil {
+			return fmt.Errorf("%s.%s: %w", name, field.Names[0].Name, err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) writeMethod(w *bytes.Buffer, impl, port, method string, fn *ast.FuncType) error {
+	var params, args []string
+	observed := false
+	index := 0
+	for _, field := range fieldList(fn.Params) {
+		typ, err := g.typeString(field.Type)
+		if err != nil {
+			return err
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{nil}
+		}
+		for _, ident := range names {
+			arg := fmt.Sprintf("p%d", index)
+			if ident != nil && ident.Name != "_" {
+				arg = ident.Name
+			}
+			if index == 0 && typ == "context.Context" {
+				arg, observed = "ctx", true
+			}
+			index++
+			params = append(params, arg+" "+typ)
+			if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+				arg += "..."
+			}
+			args = append(args, arg)
+		}
+	}
+
+	var results, returns []string
+	returnsError := false
+	fields := fieldList(fn.Results)
+	for _, field := range fields {
+		typ, err := g.typeString(field.Type)
+		if err != nil {
+			return err
+		}
+		for range max(len(field.Names), 1) {
+			results = append(results, typ)
+			returns = append(returns, fmt.Sprintf("r%d", len(returns)))
+		}
+	}
+	if len(results) > 0 && results[len(results)-1] == "error" {
+		returns[len(returns)-1] = "err"
+		returnsError = true
+	}
+
+	signature := fmt.Sprintf("func (o *%s) %s(%s)", impl, method, strings.Join(params, ", "))
+	switch len(results) {
+	case 0:
+	case 1:
+		signature += " " + results[0]
+	default:
+		signature += " (" + strings.Join(results, ", ") + ")"
+	}
+	call := fmt.Sprintf("o.inner.%s(%s)", method, strings.Join(args, ", "))
+
+	fmt.Fprintf(w, "\n%s {\n", signature)
+	switch {
+	case !observed && len(results) == 0:
+		fmt.Fprintf(w, "\t%s\n", call)
+	case !observed:
+		fmt.Fprintf(w, "\treturn %s\n", call)
+	default:
+		fmt.Fprintf(w, "\tctx, call := o.recorder.Start(ctx, %q, %q)\n", port, method)
+		if len(results) > 0 {
+			fmt.Fprintf(w, "\t%s := %s\n", strings.Join(returns, ", "), call)
+		} else {
+			fmt.Fprintf(w, "\t%s\n", call)
+		}
+		if returnsError {
+			fmt.Fprintf(w, "\tcall.End(err)\n")
+		} else {
+			fmt.Fprintf(w, "\tcall.End(nil)\n")
+		}
+		if len(results) > 0 {
+			fmt.Fprintf(w, "\treturn %s\n", strings.Join(returns, ", "))
+		}
+	}
+	fmt.Fprintf(w, "}\n")
+	return nil
+}
+
+// typeString prints a type expression as seen from the generated package
+func (g *generator) typeString(expr ast.Expr) (string, error) {
+	var err error
+	qualified := g.qualify(expr, &err)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, g.fset, qualified); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// qualify returns a copy of the type expression with local types prefixed by the ports alias,
+// recording the imported packages it references
+func (g *generator) qualify(expr ast.Expr, err *error) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if g.local[t.Name] {
+			return &ast.SelectorExpr{X: ast.NewIdent(g.alias), Sel: ast.NewIdent(t.Name)}
+		}
+		return t
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			g.used[pkg.Name] = true
+		}
+		return t
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: g.qualify(t.X, err)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: g.qualify(t.Elt, err)}
+	case *ast.MapType:
+		return &ast.MapType{Key: g.qualify(t.Key, err), Value: g.qualify(t.Value, err)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: g.qualify(t.Elt, err)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: t.Dir, Value: g.qualify(t.Value, err)}
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return t
+		}
+	case *ast.FuncType:
+		return t
+	}
+	if *err == nil {
+		*err = fmt.Errorf("unsupported type %T", expr)
+	}
+	return expr
+}
+
+// importPathOf returns the path of an import spec with an optional name
+func importPathOf(spec string) string {
+	return spec[strings.Index(spec, "\""):]
+}
+
+func fieldList(list *ast.FieldList) []*ast.Field {
+	if list == nil {
+		return nil
+	}
+	return list.List
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	tests := []struct {
+		out  string
+		opts options
+	}{
+		{
+			out:  "repositories_gen.go",
+			opts: options{PortsDir: "../../domain/ports/repositories", Alias: "repositoryports"},
+		},
+		{
+			out:  "events_gen.go",
+			opts: options{PortsDir: "../../domain/ports/events", Alias: "eventports", Include: []string{"EventPublisher"}},
+		},
+	}
+
+	const observabilityDir = "../../internal/infrastructure/observability"
+	for _, tt := range tests {
+		t.Run(tt.out, func(t *testing.T) {
+			tt.opts.Package = "observability"
+			tt.opts.Source = tt.opts.PortsDir
+			tt.opts.PortsDir = filepath.Join(observabilityDir, tt.opts.PortsDir)
+
+			code, err := generate(tt.opts)
+			require.NoError(t, err)
+
+			committed, err := os.ReadFile(filepath.Join(observabilityDir, tt.out))
+			require.NoError(t, err)
+			assert.Equal(t, string(committed), string(code), "run go generate ./internal/infrastructure/observability/...")
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0o644))
+	portsDir := filepath.Join(dir, "ports")
+	require.NoError(t, os.Mkdir(portsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(portsDir, "store.go"), []byte(`package ports
+
+import (
+	"context"
+	"time"
+)
+
+type Filter struct{ Since time.Time }
+
+type Store interface {
+	Find(context.Context, *Filter, ...string) (map[string][]Filter, int, error)
+	Touch(ctx context.Context)
+	Name() string
+}
+
+type unexported interface{ Ignored() }
+`), 0o644))
+
+	code, err := generate(options{PortsDir: portsDir, Alias: "storeports", Package: "observed", Source: "ports"})
+	require.NoError(t, err)
+
+	src := string(code)
+	assert.Contains(t, src, `storeports "example.com/app/ports"`)
+	assert.NotContains(t, src, `"time"`, "imports only referenced packages")
+	assert.Contains(t, src, "func (o *observedStore) Find(ctx context.Context, p1 *storeports.Filter, p2 ...string) (map[string][]storeports.Filter, int, error) {")
+	assert.Contains(t, src, "r0, r1, err := o.inner.Find(ctx, p1, p2...)")
+	assert.Contains(t, src, "\to.inner.Touch(ctx)\n\tcall.End(nil)\n")
+	assert.Contains(t, src, "func (o *observedStore) Name() string {\n\treturn o.inner.Name()\n}")
+	assert.NotContains(t, src, "Ignored")
+
+	_, err = generate(options{PortsDir: portsDir, Alias: "storeports", Package: "observed", Include: []string{"Missing"}})
+	assert.ErrorContains(t, err, "interface Missing not found")
+}
@@ -0,0 +1,164 @@
+// Command reprocess replays the raw MQTT messages archived for a time range through the running
+// server's handlers, to backfill readings and registrations after a decoding bug is fixed.
+//
+// It starts a reprocessing job through the admin API and follows it until it finishes:
+//
+//	ADMIN_TOKEN=... go run ./cmd/reprocess -url http://localhost:8080 -from 2025-03-01T00:00:00Z -to 2025-03-02T00:00:00Z
+//
+// Replays are idempotent, so a range can be reprocessed again after another fix.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/handlers"
+)
+
+func main() {
+	var (
+		baseURL  = flag.String("url", "http://localhost:8080", "base URL of the server")
+		token    = flag.String("token", os.Getenv("ADMIN_TOKEN"), "admin token (default $ADMIN_TOKEN)")
+		from     = flag.String("from", "", "start of the range, RFC 3339")
+		to       = flag.String("to", "", "end of the range (exclusive), RFC 3339")
+		interval = flag.Duration("poll", 2*time.Second, "how often the job is polled")
+		detach   = flag.Bool("detach", false, "print the job and exit without following it")
+	)
+	flag.Parse()
+
+	request, err := parseRange(*from, *to)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reprocess: %v\n", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	c := &client{baseURL: strings.TrimRight(*baseURL, "/"), token: *token, http: &http.Client{Timeout: 30 * time.Second}}
+	job, err := c.start(ctx, request)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reprocess: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("job %s queued\n", job.ID)
+	if *detach {
+		return
+	}
+
+	job, err = follow(ctx, c, job.ID, *interval, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reprocess: %v\n", err)
+		os.Exit(1)
+	}
+	if job.Status != entities.JobStatusSucceeded {
+		fmt.Fprintf(os.Stderr, "reprocess: job %s %s: %s\n", job.ID, job.Status, job.Error)
+		os.Exit(1)
+	}
+	fmt.Printf("job %s succeeded: %s\n", job.ID, job.Result)
+}
+
+// parseRange parses the RFC 3339 bounds of the range to replay
+func parseRange(from, to string) (handlers.ReprocessRequest, error) {
+	var request handlers.ReprocessRequest
+	if from == "" || to == "" {
+		return request, fmt.Errorf("-from and -to are required")
+	}
+
+	var err error
+	if request.From, err = time.Parse(time.RFC3339, from); err != nil {
+		return request, fmt.Errorf("invalid -from: %w", err)
+	}
+	if request.To, err = time.Parse(time.RFC3339, to); err != nil {
+		return request, fmt.Errorf("invalid -to: %w", err)
+	}
+	return request, nil
+}
+
+// client calls the admin and job endpoints of the server
+type client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// start queues a reprocessing job
+func (c *client) start(ctx context.Context, request handlers.ReprocessRequest) (*handlers.JobResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, http.MethodPost, "/admin/reprocess", body)
+}
+
+// job reads the state of a job
+func (c *client) job(ctx context.Context, id string) (*handlers.JobResponse, error) {
+	return c.do(ctx, http.MethodGet, "/api/v1/jobs/"+id, nil)
+}
+
+func (c *client) do(ctx context.Context, method, path string, body []byte) (*handlers.JobResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var job handlers.JobResponse
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return nil, fmt.Errorf("failed to decode job: %w", err)
+	}
+	return &job, nil
+}
+
+// follow polls the job until it finishes, printing its progress whenever it changes
+func follow(ctx context.Context, c *client, id string, interval time.Duration, out io.Writer) (*handlers.JobResponse, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastProgress := ""
+	for {
+		job, err := c.job(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		progress := fmt.Sprintf("%s %d%% %s", job.Status, job.Progress, job.ProgressMessage)
+		if progress != lastProgress {
+			fmt.Fprintln(out, strings.TrimSpace(progress))
+			lastProgress = progress
+		}
+		if (&entities.Job{Status: job.Status}).IsFinished() {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
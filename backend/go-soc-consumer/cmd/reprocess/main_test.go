@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/handlers"
+)
+
+func TestParseRange(t *testing.T) {
+	request, err := parseRange("2025-03-01T00:00:00Z", "2025-03-02T00:00:00+02:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), request.From)
+	assert.True(t, request.To.Equal(time.Date(2025, 3, 1, 22, 0, 0, 0, time.UTC)))
+
+	_, err = parseRange("", "2025-03-02T00:00:00Z")
+	assert.Error(t, err)
+	_, err = parseRange("2025-03-01", "2025-03-02T00:00:00Z")
+	assert.Error(t, err)
+}
+
+func TestStartAndFollow(t *testing.T) {
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/reprocess", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var request handlers.ReprocessRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), request.From)
+
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(handlers.JobResponse{ID: "job-1", Status: entities.JobStatusQueued})
+	})
+	mux.HandleFunc("GET /api/v1/jobs/job-1", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		job := handlers.JobResponse{ID: "job-1", Status: entities.JobStatusRunning, Progress: 50, ProgressMessage: "replayed 10 of 10 messages"}
+		if polls > 2 {
+			job.Status, job.Progress, job.Result = entities.JobStatusSucceeded, 100, json.RawMessage(`{"replayed":20}`)
+		}
+		_ = json.NewEncoder(w).Encode(job)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := &client{baseURL: server.URL, token: "secret", http: server.Client()}
+	request, err := parseRange("2025-03-01T00:00:00Z", "2025-03-02T00:00:00Z")
+	require.NoError(t, err)
+
+	job, err := c.start(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", job.ID)
+
+	var out bytes.Buffer
+	job, err = follow(context.Background(), c, job.ID, time.Millisecond, &out)
+	require.NoError(t, err)
+	assert.Equal(t, entities.JobStatusSucceeded, job.Status)
+	assert.JSONEq(t, `{"replayed":20}`, string(job.Result))
+	assert.Equal(t, "running 50% replayed 10 of 10 messages\nsucceeded 100% replayed 10 of 10 messages\n", out.String())
+}
+
+func TestStart_ReportsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	c := &client{baseURL: server.URL, token: "wrong", http: server.Client()}
+	_, err := c.start(context.Background(), handlers.ReprocessRequest{})
+
+	assert.ErrorContains(t, err, "status 401: unauthorized")
+}
@@ -4,18 +4,75 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"go.uber.org/zap"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
 	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/deadline"
+	infrahttp "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/http"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/pipeline"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/observability"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/handlers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/alerting"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/blacklist"
+	brokerauth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/broker_auth"
+	brokerstats "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/broker_stats"
+	crashreports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/crash_reports"
+	declarativeconfig "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/declarative_config"
+	derivedsensors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/derived_sensors"
+	devicearchival "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_archival"
+	devicebundle "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_bundle"
+	devicechanges "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_changes"
+	devicecommands "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_commands"
+	deviceconnections "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_connections"
+	devicediagnostics "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_diagnostics"
 	devicehealth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_health"
+	deviceidentity "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_identity"
+	devicemanagement "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_management"
+	devicemigration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_migration"
+	deviceonboarding "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_onboarding"
 	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
+	devicestatus "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_status"
+	devicetransfers "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_transfers"
+	driftreconciliation "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/drift_reconciliation"
+	edgesync "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/edge_sync"
+	eventpolicies "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/event_policies"
+	farmisolation "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/farm_isolation"
+	farmquotas "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/farm_quotas"
+	farmzones "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/farm_zones"
+	fleetversions "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/fleet_versions"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/handover"
+	ingestionlatency "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/ingestion_latency"
+	irrigationefficiency "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/irrigation_efficiency"
+	irrigationexperiments "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/irrigation_experiments"
+	irrigationscheduling "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/irrigation_scheduling"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/jobs"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	messagetracing "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/message_tracing"
+	moistureprediction "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/moisture_prediction"
+	notificationinbox "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/notification_inbox"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/notifications"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/ping"
+	rawarchive "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/raw_archive"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/reprocessing"
+	researchsharing "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/research_sharing"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/retention"
+	securitymonitoring "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/security_monitoring"
 	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+	sensorhealth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_health"
+	sensorreadings "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_readings"
+	telemetrycompaction "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/telemetry_compaction"
+	telemetryforwarding "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/telemetry_forwarding"
+	usagemetering "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/usage_metering"
+	userauth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/user_auth"
+	valvecontrol "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/valve_control"
+	wifidiagnostics "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/wifi_diagnostics"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/config"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
 )
 
 // Application represents the complete application with all its dependencies
@@ -24,7 +81,11 @@ type Application struct {
 	loggerFactory logger.LoggerFactory
 	services      *Services
 	server        *http.Server
-	cleanup       func() error
+	shutdown      *shutdownSequence
+
+	// Background workers run until the drain workers phase of the shutdown
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
 }
 
 // Services holds all the business logic services
@@ -32,13 +93,103 @@ type Services struct {
 	DeviceRepository                    repositoryports.DeviceRepository
 	SensorTemperatureHumidityRepository repositoryports.SensorTemperatureHumidityRepository
 	DeviceRegistrationUseCase           deviceregistration.DeviceRegistrationUseCase
+	DeviceIdentityUseCase               deviceidentity.DeviceIdentityUseCase
+	DeviceBundleUseCase                 devicebundle.DeviceBundleUseCase
+	FarmIsolationUseCase                farmisolation.FarmIsolationUseCase
+	FarmQuotasUseCase                   farmquotas.FarmQuotasUseCase
+	UsageRepository                     repositoryports.UsageRepository
+	UsageMeteringUseCase                usagemetering.UsageMeteringUseCase
 	DeviceHealthUseCase                 devicehealth.DeviceHealthUseCase
+	DeviceStatusUseCase                 devicestatus.DeviceStatusUseCase
+	DeviceManagementUseCase             devicemanagement.DeviceManagementUseCase
+	FarmRepository                      repositoryports.FarmRepository
+	ZoneRepository                      repositoryports.ZoneRepository
+	FarmZonesUseCase                    farmzones.FarmZonesUseCase
+	DeviceShadowRepository              repositoryports.DeviceShadowRepository
+	DeclarativeConfigUseCase            declarativeconfig.DeclarativeConfigUseCase
+	DriftReconciliationUseCase          driftreconciliation.DriftReconciliationUseCase
+	DeviceChangesUseCase                devicechanges.DeviceChangesUseCase
+	DeviceDiagnosticsUseCase            devicediagnostics.DeviceDiagnosticsUseCase
+	DeviceCommandRepository             repositoryports.DeviceCommandRepository
+	DeviceCommandsUseCase               devicecommands.DeviceCommandsUseCase
+	ValveStateRepository                repositoryports.ValveStateRepository
+	ValveControlUseCase                 valvecontrol.ValveControlUseCase
+	IrrigationScheduleRepository        repositoryports.IrrigationScheduleRepository
+	IrrigationOverrideRepository        repositoryports.IrrigationOverrideRepository
+	IrrigationSchedulingUseCase         irrigationscheduling.IrrigationSchedulingUseCase
+	IrrigationEfficiencyUseCase         irrigationefficiency.IrrigationEfficiencyUseCase
+	IrrigationExperimentRepository      repositoryports.IrrigationExperimentRepository
+	IrrigationExperimentsUseCase        irrigationexperiments.IrrigationExperimentsUseCase
+	UserRepository                      repositoryports.UserRepository
+	UserAuthUseCase                     userauth.UserAuthUseCase // nil without AUTH_JWT_SECRET
+	ResearchTokenRepository             repositoryports.ResearchTokenRepository
+	ResearchSharingUseCase              researchsharing.ResearchSharingUseCase
+	DeviceTransferRepository            repositoryports.DeviceTransferRepository
+	DeviceTransfersUseCase              devicetransfers.DeviceTransfersUseCase
+	DeviceArchiveRepository             repositoryports.DeviceArchiveRepository
+	DeviceArchiveStore                  ports.DeviceArchiveStore // nil keeps archives in the database
+	DeviceArchivalUseCase               devicearchival.DeviceArchivalUseCase
+	DeviceMigrationRepository           repositoryports.DeviceMigrationRepository
+	DeviceMigrationUseCase              devicemigration.DeviceMigrationUseCase
+	MoisturePredictionUseCase           moistureprediction.MoisturePredictionUseCase
+	MessageTracingUseCase               messagetracing.MessageTracingUseCase
+	DeviceOnboardingRepository          repositoryports.DeviceOnboardingRepository
+	DeviceOnboardingUseCase             deviceonboarding.DeviceOnboardingUseCase
+	CrashReportRepository               repositoryports.CrashReportRepository
+	CrashReportsUseCase                 crashreports.CrashReportsUseCase
+	WifiDiagnosticsUseCase              wifidiagnostics.WifiDiagnosticsUseCase
+	BrokerAuthUseCase                   brokerauth.BrokerAuthUseCase
+	BrokerStatsConsumer                 eventports.MessageConsumer // the consumer without forwarding nor pausing, nil with the embedded broker
+	BrokerStatsUseCase                  brokerstats.BrokerStatsUseCase
+	FleetVersionsUseCase                fleetversions.FleetVersionsUseCase
+	EventPoliciesUseCase                eventpolicies.EventPoliciesUseCase
+	SecurityMonitorUseCase              securitymonitoring.SecurityMonitorUseCase
+	DeviceConnectionsUseCase            deviceconnections.DeviceConnectionsUseCase
 	PingUseCase                         ping.PingUseCase
 	SensorDataUseCase                   sensordata.SensorDataUseCase
+	SensorReadingsUseCase               sensorreadings.SensorReadingsUseCase
+	MeasurementRepository               repositoryports.MeasurementRepository
+	SensorChannelRepository             repositoryports.SensorChannelRepository
+	MeasurementUseCase                  measurements.MeasurementUseCase
+	IngestionLatencyUseCase             ingestionlatency.IngestionLatencyUseCase
+	DerivedSensorUseCase                derivedsensors.DerivedSensorUseCase
+	AlertingUseCase                     alerting.AlertingUseCase
+	NotificationDispatcherUseCase       notifications.NotificationDispatcherUseCase
+	NotificationInboxRepository         repositoryports.NotificationInboxRepository
+	NotificationInboxUseCase            notificationinbox.NotificationInboxUseCase
+	HandoverUseCase                     handover.HandoverUseCase
+	SyncOutboxRepository                repositoryports.SyncOutboxRepository
+	SyncRemote                          ports.SyncRemote
+	EdgeSyncUseCase                     edgesync.EdgeSyncUseCase
+	SyncApplyUseCase                    edgesync.SyncApplyUseCase
+	TelemetryArchiveRepository          repositoryports.TelemetryArchiveRepository
+	TelemetryCompactionUseCase          telemetrycompaction.TelemetryCompactionUseCase
+	RetentionRepository                 repositoryports.RetentionRepository
+	RetentionUseCase                    retention.RetentionUseCase
+	TelemetryForwardingUseCase          telemetryforwarding.TelemetryForwardingUseCase
+	RawArchiveUseCase                   rawarchive.RawArchiveUseCase
+	RawMessageArchive                   ports.RawMessageArchive
+	ReprocessingUseCase                 reprocessing.ReprocessingUseCase
+	SensorHealthUseCase                 sensorhealth.SensorHealthUseCase
+	JobRepository                       repositoryports.JobRepository
+	JobQueueUseCase                     jobs.JobQueueUseCase
+	BlacklistRepository                 repositoryports.BlacklistRepository
+	BlacklistUseCase                    blacklist.BlacklistUseCase
 	MQTTConsumer                        eventports.MessageConsumer
+	MQTTConsumerControl                 handlers.ConsumerController
+	IngestionPipeline                   *pipeline.Pipeline
 	NATSPublisher                       eventports.EventPublisher
 	NATSSubscriber                      eventports.EventSubscriber
+	DeviceStatusHub                     *infrahttp.DeviceStatusHub
 	HealthChecker                       ports.DeviceHealthChecker
+	CommandSigner                       ports.CommandSigner
+	CommandPublisher                    ports.CommandPublisher
+	DeadlinePolicy                      *deadline.Policy
+	PortRecorder                        *observability.Recorder
+	Metrics                             *metrics.Registry
+	ReadinessChecks                     []handlers.ReadinessCheck
+	HealthChecks                        []ping.DependencyCheck
+	OverrideSources                     []ports.OverrideSource
 }
 
 // New creates a new application instance
@@ -105,36 +256,18 @@ func (a *Application) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop gracefully shuts down all application services
+// Stop gracefully shuts down all application services, phase by phase. Failures are logged
+// and do not stop the later phases.
 func (a *Application) Stop(ctx context.Context) error {
 	a.loggerFactory.Application().LogApplicationEvent("application_services_stopping", "application")
 
-	// Stop message consumers
-	if err := a.stopMessageConsumers(ctx); err != nil {
-		a.loggerFactory.Core().Error("message_consumers_stop_error",
-			zap.Error(err),
-			zap.String("component", "application"),
-		)
-	}
-
-	// Stop HTTP server
-	if err := a.stopHTTPServer(ctx); err != nil {
-		a.loggerFactory.Core().Error("http_server_stop_error",
+	if err := a.shutdown.run(ctx); err != nil {
+		a.loggerFactory.Core().Error("application_shutdown_incomplete",
 			zap.Error(err),
 			zap.String("component", "application"),
 		)
 	}
 
-	// Clean up resources
-	if a.cleanup != nil {
-		if err := a.cleanup(); err != nil {
-			a.loggerFactory.Core().Error("cleanup_error",
-				zap.Error(err),
-				zap.String("component", "application"),
-			)
-		}
-	}
-
 	a.loggerFactory.Application().LogApplicationEvent("application_services_stopped", "application")
 	return nil
 }
@@ -8,9 +8,11 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	messaginghandlers "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/mqtt/handlers"
 	natshandlers "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/nats/handlers"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/handlers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/middleware"
 )
 
 // initializeServices initializes all application services using the container
@@ -22,9 +24,22 @@ func (a *Application) initializeServices() error {
 
 	a.services = container.GetServices()
 
-	// Store cleanup function
-	a.cleanup = func() error {
-		return container.Cleanup()
+	// The container registered how its resources are closed; add the application's own steps
+	a.shutdown = container.shutdown
+	a.shutdown.add(phaseStopIntake, "message_consumers", a.stopMessageConsumers)
+	a.shutdown.add(phaseStopIntake, "http_server", a.stopHTTPServer)
+	a.shutdown.add(phaseDrainWorkers, "background_services", a.stopBackgroundServices)
+	if a.services.EdgeSyncUseCase != nil {
+		a.shutdown.add(phaseFlush, "edge_sync_outbox", a.flushEdgeSync)
+	}
+	if a.services.TelemetryForwardingUseCase != nil {
+		a.shutdown.add(phaseFlush, "telemetry_forwarding", a.services.TelemetryForwardingUseCase.Flush)
+	}
+	if a.services.RawArchiveUseCase != nil {
+		a.shutdown.add(phaseFlush, "raw_archive", a.services.RawArchiveUseCase.Flush)
+	}
+	if a.services.UsageMeteringUseCase != nil {
+		a.shutdown.add(phaseFlush, "usage_metering", a.services.UsageMeteringUseCase.Flush)
 	}
 
 	return nil
@@ -34,15 +49,312 @@ func (a *Application) initializeServices() error {
 func (a *Application) initializeHTTPServer() error {
 	// Initialize HTTP handlers
 	pingHandler := handlers.NewPingHandler(a.services.PingUseCase)
+	readinessHandler := handlers.NewReadinessHandler(a.services.ReadinessChecks...)
 
 	// Setup routes
 	mux := http.NewServeMux()
+
+	// Administration routes take the admin token or the access token of an admin user, viewers are refused
+	var authenticate middleware.AuthenticateFunc
+	if a.services.UserAuthUseCase != nil {
+		authenticate = a.services.UserAuthUseCase.Authenticate
+	}
+	requireAdmin := middleware.RequireAdmin(authenticate, a.config.Server.AdminToken)
+	handleAdmin := func(pattern string, handler http.HandlerFunc) {
+		mux.Handle(pattern, requireAdmin(handler))
+	}
+
 	mux.HandleFunc("/ping", pingHandler.Ping)
+	mux.HandleFunc("/ready", readinessHandler.Ready)
+	mux.HandleFunc("GET /health", pingHandler.Health)
+	mux.Handle("/metrics", a.services.Metrics.Handler())
+
+	docsHandler, err := handlers.NewDocsHandler()
+	if err != nil {
+		return fmt.Errorf("failed to generate API docs: %w", err)
+	}
+	mux.HandleFunc("GET /api/v1/docs", docsHandler.OpenAPI)
+
+	jobsHandler := handlers.NewJobsHandler(a.services.JobQueueUseCase)
+	mux.HandleFunc("GET /api/v1/jobs/{id}", jobsHandler.GetJob)
+	mux.HandleFunc("POST /api/v1/jobs/{id}/cancel", jobsHandler.CancelJob)
+
+	devicesHandler := handlers.NewDevicesHandler(a.services.DeviceManagementUseCase, a.config.GetPaginationPolicy(), a.config.Server.AdminToken)
+	mux.HandleFunc("GET /api/v1/devices", devicesHandler.List)
+	mux.HandleFunc("GET /api/v1/devices/{mac}", devicesHandler.Get)
+	if a.config.Server.AdminToken != "" {
+		handleAdmin("POST /api/v1/devices", devicesHandler.Create)
+		handleAdmin("PUT /api/v1/devices/{mac}", devicesHandler.Update)
+		handleAdmin("DELETE /api/v1/devices/{mac}", devicesHandler.Delete)
+
+		declarationHandler := handlers.NewDeclarationHandler(a.services.DeclarativeConfigUseCase, a.config.Server.AdminToken)
+		handleAdmin("PUT /api/v1/config/declare", declarationHandler.Declare)
+	}
+
+	farmsHandler := handlers.NewFarmsHandler(a.services.FarmZonesUseCase, a.config.Server.AdminToken)
+	mux.HandleFunc("GET /api/v1/farms", farmsHandler.ListFarms)
+	mux.HandleFunc("GET /api/v1/farms/{farm}", farmsHandler.GetFarm)
+	mux.HandleFunc("GET /api/v1/zones/{id}", farmsHandler.GetZone)
+	mux.HandleFunc("GET /api/v1/zones/{id}/measurements", farmsHandler.Measurements)
+	if a.config.Server.AdminToken != "" {
+		handleAdmin("POST /admin/farms", farmsHandler.CreateFarm)
+		handleAdmin("PUT /admin/farms/{farm}", farmsHandler.RenameFarm)
+		handleAdmin("DELETE /admin/farms/{farm}", farmsHandler.DeleteFarm)
+		handleAdmin("POST /admin/farms/{farm}/zones", farmsHandler.CreateZone)
+		handleAdmin("PUT /admin/zones/{id}", farmsHandler.RenameZone)
+		handleAdmin("DELETE /admin/zones/{id}", farmsHandler.DeleteZone)
+		handleAdmin("PUT /admin/zones/{id}/devices/{mac}", farmsHandler.AssignDevice)
+		handleAdmin("DELETE /admin/zones/{id}/devices/{mac}", farmsHandler.UnassignDevice)
+	}
+
+	deviceStatusHandler := handlers.NewDeviceStatusHandler(a.services.DeviceStatusUseCase)
+	mux.HandleFunc("POST /api/v1/devices/status-query", deviceStatusHandler.QueryStatus)
+
+	deviceHealthHandler := handlers.NewDeviceHealthHandler(a.services.DeviceHealthUseCase)
+	mux.HandleFunc("POST /api/v1/devices/{mac}/health-check", deviceHealthHandler.CheckHealth)
+
+	deviceChangesHandler := handlers.NewDeviceChangesHandler(a.services.DeviceChangesUseCase, a.config.DeviceChanges.DefaultWait, a.config.DeviceChanges.MaxWait)
+	mux.HandleFunc("GET /api/v1/devices/changes", deviceChangesHandler.ListChanges)
+
+	fleetVersionsHandler := handlers.NewFleetVersionsHandler(a.services.FleetVersionsUseCase)
+	mux.HandleFunc("GET /api/v1/devices/versions", fleetVersionsHandler.Report)
+
+	if a.services.DeviceStatusHub != nil {
+		mux.Handle("GET /api/v1/devices/status/ws", a.services.DeviceStatusHub)
+	}
+
+	sensorChannelsHandler := handlers.NewSensorChannelsHandler(a.services.MeasurementUseCase, a.config.Server.AdminToken)
+	mux.HandleFunc("GET /api/v1/devices/{mac}/channels", sensorChannelsHandler.ListChannels)
+	if a.config.Server.AdminToken != "" {
+		handleAdmin("PUT /admin/devices/{mac}/channels/{type}/{channel}", sensorChannelsHandler.ConfigureChannel)
+	}
+
+	sensorReadingsHandler := handlers.NewSensorReadingsHandler(a.services.SensorReadingsUseCase, a.config.GetPaginationPolicy())
+	mux.HandleFunc("GET /api/v1/devices/{mac}/readings", sensorReadingsHandler.List)
+	mux.HandleFunc("GET /api/v1/devices/readings/latest", sensorReadingsHandler.Latest)
+
+	valveHandler := handlers.NewValveHandler(a.services.ValveControlUseCase, a.config.GetPaginationPolicy(), a.config.Server.AdminToken)
+	mux.HandleFunc("GET /api/v1/devices/{mac}/valve", valveHandler.State)
+	mux.HandleFunc("GET /api/v1/devices/{mac}/valve/history", valveHandler.History)
+	if a.config.Server.AdminToken != "" {
+		handleAdmin("POST /admin/devices/{mac}/valve/open", valveHandler.Open)
+		handleAdmin("POST /admin/devices/{mac}/valve/close", valveHandler.Close)
+	}
+
+	if a.services.IrrigationSchedulingUseCase != nil {
+		scheduleHandler := handlers.NewIrrigationScheduleHandler(a.services.IrrigationSchedulingUseCase, a.config.Server.AdminToken)
+		mux.HandleFunc("GET /api/v1/devices/{mac}/schedules", scheduleHandler.List)
+		if a.config.Server.AdminToken != "" {
+			handleAdmin("POST /admin/devices/{mac}/schedules", scheduleHandler.Create)
+			handleAdmin("PUT /admin/schedules/{id}", scheduleHandler.Update)
+			handleAdmin("DELETE /admin/schedules/{id}", scheduleHandler.Delete)
+			handleAdmin("GET /admin/irrigation/overrides", scheduleHandler.Overrides)
+			handleAdmin("PUT /admin/devices/{mac}/irrigation/override", scheduleHandler.SetOverride)
+			handleAdmin("DELETE /admin/devices/{mac}/irrigation/override", scheduleHandler.ClearOverride)
+		}
+	}
+
+	analyticsHandler := handlers.NewIrrigationAnalyticsHandler(a.services.IrrigationEfficiencyUseCase)
+	mux.HandleFunc("GET /api/v1/analytics/irrigation/zones", analyticsHandler.ListZones)
+	mux.HandleFunc("GET /api/v1/analytics/irrigation/zones/{zone}", analyticsHandler.GetZone)
+
+	experimentsHandler := handlers.NewIrrigationExperimentsHandler(a.services.IrrigationExperimentsUseCase, a.config.Server.AdminToken)
+	mux.HandleFunc("GET /api/v1/analytics/irrigation/experiments", experimentsHandler.List)
+	mux.HandleFunc("GET /api/v1/analytics/irrigation/experiments/{id}", experimentsHandler.Get)
+	mux.HandleFunc("GET /api/v1/analytics/irrigation/experiments/{id}/report", experimentsHandler.Report)
+	if a.config.Server.AdminToken != "" {
+		handleAdmin("POST /admin/irrigation/experiments", experimentsHandler.Create)
+		handleAdmin("POST /admin/irrigation/experiments/{id}/end", experimentsHandler.End)
+	}
+
+	if a.services.MoisturePredictionUseCase != nil {
+		predictionHandler := handlers.NewMoisturePredictionHandler(a.services.MoisturePredictionUseCase)
+		mux.HandleFunc("GET /api/v1/analytics/irrigation/predictions", predictionHandler.ListPredictions)
+	}
+
+	if a.services.AlertingUseCase != nil {
+		alertsHandler := handlers.NewAlertsHandler(a.services.AlertingUseCase, a.config.GetPaginationPolicy())
+		mux.HandleFunc("GET /api/v1/alerts/active", alertsHandler.ListActive)
+		mux.HandleFunc("GET /api/v1/alerts/recent", alertsHandler.ListRecent)
+	}
+
+	handoverHandler := handlers.NewHandoverHandler(a.services.HandoverUseCase)
+	mux.HandleFunc("GET /api/v1/handover", handoverHandler.Report)
+
+	crashReportHandler := handlers.NewCrashReportHandler(a.services.CrashReportsUseCase)
+	mux.HandleFunc("GET /api/v1/firmware/crash-report", crashReportHandler.Report)
+
+	wifiHandler := handlers.NewWifiDiagnosticsHandler(a.services.WifiDiagnosticsUseCase)
+	mux.HandleFunc("GET /api/v1/wifi/locations", wifiHandler.Locations)
+	mux.HandleFunc("GET /api/v1/wifi/advisories", wifiHandler.Advisories)
+
+	if a.services.BrokerAuthUseCase != nil {
+		brokerAuthHandler := handlers.NewBrokerAuthHandler(a.services.BrokerAuthUseCase, a.config.BrokerAuth.Token)
+		mux.HandleFunc("POST /api/v1/broker/auth", brokerAuthHandler.Authenticate)
+		mux.HandleFunc("POST /api/v1/broker/acl", brokerAuthHandler.Authorize)
+	}
+
+	if a.services.NotificationInboxUseCase != nil {
+		inboxHandler := handlers.NewNotificationInboxHandler(a.services.NotificationInboxUseCase, a.config.GetPaginationPolicy())
+		mux.HandleFunc("GET /api/v1/users/{user}/notifications", inboxHandler.List)
+		mux.HandleFunc("GET /api/v1/users/{user}/notifications/unread-count", inboxHandler.UnreadCount)
+		mux.HandleFunc("POST /api/v1/users/{user}/notifications/read-all", inboxHandler.MarkAllRead)
+		mux.HandleFunc("POST /api/v1/users/{user}/notifications/{id}/read", inboxHandler.MarkRead)
+	}
+
+	if a.services.SensorHealthUseCase != nil {
+		sensorHealthHandler := handlers.NewSensorHealthHandler(a.services.SensorHealthUseCase, a.config.Server.AdminToken)
+		mux.HandleFunc("GET /api/v1/sensors/health", sensorHealthHandler.ListStreams)
+		mux.HandleFunc("GET /api/v1/sensors/degraded", sensorHealthHandler.ListDegraded)
+		if a.config.Server.AdminToken != "" {
+			handleAdmin("PUT /admin/devices/{mac}/calibration", sensorHealthHandler.RecordCalibration)
+		}
+	}
+
+	// Operational endpoints are only exposed when an admin token is configured
+	if a.config.Server.AdminToken != "" {
+		adminHandler := handlers.NewConsumerAdminHandler(a.services.MQTTConsumerControl, a.config.Server.AdminToken)
+		handleAdmin("GET /admin/consumers/mqtt", adminHandler.Status)
+		handleAdmin("POST /admin/consumers/mqtt/pause", adminHandler.Pause)
+		handleAdmin("POST /admin/consumers/mqtt/resume", adminHandler.Resume)
+
+		identityHandler := handlers.NewDeviceIdentityHandler(a.services.DeviceIdentityUseCase, a.config.Server.AdminToken)
+		handleAdmin("PUT /admin/devices/{mac}/certificate", identityHandler.ProvisionCertificate)
+
+		bundleHandler := handlers.NewDeviceBundleHandler(a.services.DeviceBundleUseCase, a.config.Server.AdminToken)
+		handleAdmin("GET /admin/devices/bundle", bundleHandler.Export)
+		handleAdmin("POST /admin/devices/bundle", bundleHandler.Import)
+
+		diagnosticsHandler := handlers.NewDeviceDiagnosticsHandler(a.services.DeviceDiagnosticsUseCase, a.config.Server.AdminToken)
+		handleAdmin("GET /admin/devices/{mac}/diagnostics", diagnosticsHandler.Diagnose)
+
+		connectionHandler := handlers.NewDeviceConnectionHandler(a.services.DeviceConnectionsUseCase, a.config.Server.AdminToken)
+		handleAdmin("GET /admin/devices/{mac}/connection", connectionHandler.Get)
+		handleAdmin("GET /admin/devices/connections/mismatches", connectionHandler.ListMismatches)
+
+		commandHandler := handlers.NewDeviceCommandHandler(a.services.DeviceCommandsUseCase, a.config.GetPaginationPolicy(), a.config.Server.AdminToken)
+		handleAdmin("POST /admin/devices/{mac}/commands", commandHandler.Send)
+		handleAdmin("GET /admin/devices/{mac}/commands", commandHandler.List)
+		handleAdmin("GET /admin/devices/{mac}/commands/{id}", commandHandler.Get)
+
+		transfersHandler := handlers.NewDeviceTransfersHandler(a.services.DeviceTransfersUseCase, a.config.GetPaginationPolicy(), a.config.Server.AdminToken)
+		handleAdmin("POST /admin/devices/{mac}/transfers", transfersHandler.Initiate)
+		handleAdmin("GET /admin/transfers", transfersHandler.List)
+		handleAdmin("GET /admin/transfers/{id}", transfersHandler.Get)
+		handleAdmin("POST /admin/transfers/{id}/accept", transfersHandler.Accept)
+		handleAdmin("POST /admin/transfers/{id}/complete", transfersHandler.Complete)
+		handleAdmin("POST /admin/transfers/{id}/cancel", transfersHandler.Cancel)
+
+		archivesHandler := handlers.NewDeviceArchivesHandler(a.services.DeviceArchivalUseCase, a.config.GetPaginationPolicy(), a.config.Server.AdminToken)
+		handleAdmin("POST /admin/devices/{mac}/decommission", archivesHandler.Decommission)
+		handleAdmin("GET /admin/archives", archivesHandler.List)
+		handleAdmin("GET /admin/archives/{id}", archivesHandler.Get)
+		handleAdmin("POST /admin/archives/{id}/restore", archivesHandler.Restore)
+
+		migrationsHandler := handlers.NewDeviceMigrationsHandler(a.services.DeviceMigrationUseCase, a.config.GetPaginationPolicy(), a.config.Server.AdminToken)
+		handleAdmin("POST /admin/migrations", migrationsHandler.Start)
+		handleAdmin("GET /admin/migrations", migrationsHandler.List)
+		handleAdmin("GET /admin/migrations/{id}", migrationsHandler.Get)
+		handleAdmin("POST /admin/migrations/{id}/cancel", migrationsHandler.Cancel)
+
+		eventPoliciesHandler := handlers.NewEventPoliciesHandler(a.services.EventPoliciesUseCase, a.config.Server.AdminToken)
+		handleAdmin("GET /admin/events/policies", eventPoliciesHandler.List)
+		handleAdmin("PUT /admin/events/policies/{type}", eventPoliciesHandler.Set)
+		handleAdmin("DELETE /admin/events/policies/{type}", eventPoliciesHandler.Reset)
+
+		blacklistHandler := handlers.NewBlacklistHandler(a.services.BlacklistUseCase, a.config.Server.AdminToken)
+		handleAdmin("GET /admin/blacklist", blacklistHandler.List)
+		handleAdmin("POST /admin/blacklist", blacklistHandler.Add)
+		handleAdmin("DELETE /admin/blacklist/{id}", blacklistHandler.Remove)
+
+		if a.services.SecurityMonitorUseCase != nil {
+			securityHandler := handlers.NewSecurityAlertsHandler(a.services.SecurityMonitorUseCase, a.config.GetPaginationPolicy(), a.config.Server.AdminToken)
+			handleAdmin("GET /admin/security/alerts", securityHandler.ListAlerts)
+		}
+
+		if a.services.FarmQuotasUseCase != nil {
+			farmQuotasHandler := handlers.NewFarmQuotasHandler(a.services.FarmQuotasUseCase, a.config.Server.AdminToken)
+			handleAdmin("GET /admin/farms/{farm}/quota", farmQuotasHandler.Get)
+			handleAdmin("PUT /admin/farms/{farm}/quota", farmQuotasHandler.Set)
+			handleAdmin("DELETE /admin/farms/{farm}/quota", farmQuotasHandler.Reset)
+		}
+
+		traceHandler := handlers.NewMessageTraceHandler(a.services.MessageTracingUseCase, a.config.Server.AdminToken)
+		handleAdmin("POST /admin/traces", traceHandler.Start)
+		handleAdmin("GET /admin/traces", traceHandler.List)
+		handleAdmin("GET /admin/traces/{id}", traceHandler.Get)
+		handleAdmin("POST /admin/traces/{id}/stop", traceHandler.Stop)
+		handleAdmin("DELETE /admin/traces/{id}", traceHandler.Delete)
+
+		onboardingHandler := handlers.NewDeviceOnboardingHandler(a.services.DeviceOnboardingUseCase, a.config.Server.AdminToken)
+		handleAdmin("POST /admin/onboarding", onboardingHandler.Create)
+		handleAdmin("GET /admin/onboarding", onboardingHandler.List)
+		handleAdmin("POST /admin/onboarding/{id}/credentials-delivered", onboardingHandler.MarkCredentialsDelivered)
+		handleAdmin("POST /admin/onboarding/{id}/token", onboardingHandler.RenewToken)
+		handleAdmin("POST /admin/onboarding/{id}/qr", onboardingHandler.QRCode)
+		handleAdmin("POST /admin/onboarding/{id}/assign", onboardingHandler.Assign)
+		handleAdmin("DELETE /admin/onboarding/{id}", onboardingHandler.Delete)
+		mux.HandleFunc("GET /api/v1/onboarding/{id}", onboardingHandler.Progress)
+
+		if a.services.DriftReconciliationUseCase != nil {
+			driftHandler := handlers.NewDriftHandler(a.services.DriftReconciliationUseCase, a.config.Server.AdminToken)
+			handleAdmin("GET /admin/devices/drift", driftHandler.List)
+		}
+
+		if a.services.UsageMeteringUseCase != nil {
+			usageHandler := handlers.NewUsageHandler(a.services.UsageMeteringUseCase, a.config.Server.AdminToken)
+			handleAdmin("GET /admin/usage", usageHandler.Export)
+		}
+
+		if a.services.ReprocessingUseCase != nil {
+			reprocessingHandler := handlers.NewReprocessingHandler(a.services.ReprocessingUseCase, a.config.Server.AdminToken)
+			handleAdmin("POST /admin/reprocess", reprocessingHandler.Reprocess)
+		}
+	}
+
+	// Edge instances expose their replication state, cloud instances accept replicated batches
+	syncHandler := handlers.NewSyncHandler(a.services.EdgeSyncUseCase, a.services.SyncApplyUseCase, a.config.Sync.Token)
+	if a.services.EdgeSyncUseCase != nil {
+		mux.HandleFunc("/api/v1/sync/status", syncHandler.Status)
+	}
+	if a.services.SyncApplyUseCase != nil {
+		mux.HandleFunc("/api/v1/sync/batch", syncHandler.ApplyBatch)
+	}
+
+	// Research partners read anonymized telemetry with their own read-only tokens
+	researchHandler := handlers.NewResearchHandler(a.services.ResearchSharingUseCase, a.config.Server.AdminToken)
+	mux.HandleFunc("GET /api/v1/research/zones", researchHandler.Zones)
+	mux.HandleFunc("GET /api/v1/research/zones/{id}/telemetry", researchHandler.Telemetry)
+	mux.HandleFunc("GET /api/v1/research/usage", researchHandler.OwnUsage)
+	if a.config.Server.AdminToken != "" {
+		handleAdmin("GET /admin/research/tokens", researchHandler.ListTokens)
+		handleAdmin("POST /admin/research/tokens", researchHandler.CreateToken)
+		handleAdmin("DELETE /admin/research/tokens/{id}", researchHandler.RevokeToken)
+		handleAdmin("GET /admin/research/tokens/{id}/usage", researchHandler.TokenUsage)
+	}
+
+	// Users log in for an access token, which the /api/ routes then require; the routes of other
+	// services keep their own tokens
+	var handler http.Handler = mux
+	if a.services.UserAuthUseCase != nil {
+		authHandler := handlers.NewAuthHandler(a.services.UserAuthUseCase, a.config.Server.AdminToken)
+		mux.HandleFunc("POST /auth/login", authHandler.Login)
+		mux.HandleFunc("GET /api/v1/auth/me", authHandler.Me)
+		if a.config.Server.AdminToken != "" {
+			handleAdmin("GET /admin/users", authHandler.ListUsers)
+			handleAdmin("POST /admin/users", authHandler.CreateUser)
+			handleAdmin("DELETE /admin/users/{user}", authHandler.DeleteUser)
+		}
+		handler = middleware.Authentication(a.services.UserAuthUseCase.Authenticate, a.config.Server.AdminToken,
+			"/api/v1/docs", "/api/v1/sync/batch", "/api/v1/broker/", "/api/v1/research/")(mux)
+		// Browsers cannot send the Authorization header when opening a WebSocket
+		handler = middleware.WebSocketToken("/api/v1/devices/status/ws")(handler)
+	}
 
 	// Create HTTP server
 	a.server = &http.Server{
 		Addr:         a.config.GetServerAddress(),
-		Handler:      mux,
+		Handler:      middleware.Language(a.config.GetDefaultLanguage())(handler),
 		ReadTimeout:  a.config.Server.ReadTimeout,
 		WriteTimeout: a.config.Server.WriteTimeout,
 		IdleTimeout:  a.config.Server.IdleTimeout,
@@ -51,6 +363,19 @@ func (a *Application) initializeHTTPServer() error {
 	return nil
 }
 
+// deviceTopics returns the topics a device message handler subscribes to: the shared topic, or the
+// per-farm topics with farm namespaces, joined by the shared topic while devices migrate to them
+func (a *Application) deviceTopics(sharedTopic, farmTopic string) []string {
+	if !a.config.MQTT.FarmNamespaces {
+		return []string{sharedTopic}
+	}
+	topics := []string{messaginghandlers.FarmTopicFilter(farmTopic)}
+	if a.config.MQTT.LegacyTopics {
+		topics = append(topics, sharedTopic)
+	}
+	return topics
+}
+
 // startMessageConsumers starts all message consumers and subscribes to topics
 func (a *Application) startMessageConsumers(ctx context.Context) error {
 	// Start MQTT consumer
@@ -65,36 +390,138 @@ func (a *Application) startMessageConsumers(ctx context.Context) error {
 
 	// Subscribe to device registration topic
 	deviceRegistrationHandler := messaginghandlers.NewDeviceRegistrationHandler(a.loggerFactory, a.services.DeviceRegistrationUseCase)
-	deviceRegistrationTopic := "/liwaisi/iot/smart-irrigation/device/registration"
-
-	a.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribing", "application",
-		zap.String("topic", deviceRegistrationTopic),
-		zap.String("handler", "device_registration"),
-	)
-	if err := a.services.MQTTConsumer.Subscribe(ctx, deviceRegistrationTopic, deviceRegistrationHandler.HandleMessage); err != nil {
-		a.loggerFactory.Core().Error("mqtt_topic_subscription_failed",
-			zap.Error(err),
+	for _, deviceRegistrationTopic := range a.deviceTopics(messaginghandlers.DeviceRegistrationTopic, messaginghandlers.FarmDeviceRegistrationTopic) {
+		a.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribing", "application",
 			zap.String("topic", deviceRegistrationTopic),
-			zap.String("component", "application"),
+			zap.String("handler", "device_registration"),
 		)
-		return fmt.Errorf("failed to subscribe to device registration topic: %w", err)
+		if err := a.services.MQTTConsumer.Subscribe(ctx, deviceRegistrationTopic, a.services.DeadlinePolicy.Handler(a.services.IngestionPipeline.Handler(deviceRegistrationHandler.HandleMessage))); err != nil {
+			a.loggerFactory.Core().Error("mqtt_topic_subscription_failed",
+				zap.Error(err),
+				zap.String("topic", deviceRegistrationTopic),
+				zap.String("component", "application"),
+			)
+			return fmt.Errorf("failed to subscribe to device registration topic: %w", err)
+		}
 	}
 
 	// Subscribe to temperature and humidity sensor data topic
-	sensorDataHandler := messaginghandlers.NewSensorDataHandler(a.loggerFactory, a.services.SensorDataUseCase)
-	sensorDataTopic := "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity"
-
-	a.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribing", "application",
-		zap.String("topic", sensorDataTopic),
-		zap.String("handler", "sensor_data"),
-	)
-	if err := a.services.MQTTConsumer.Subscribe(ctx, sensorDataTopic, sensorDataHandler.HandleMessage); err != nil {
-		a.loggerFactory.Core().Error("mqtt_topic_subscription_failed",
-			zap.Error(err),
+	sensorDataHandler := messaginghandlers.NewSensorDataHandler(a.loggerFactory, a.services.SensorDataUseCase, a.services.IngestionLatencyUseCase)
+	for _, sensorDataTopic := range a.deviceTopics(messaginghandlers.SensorDataTopic, messaginghandlers.FarmSensorDataTopic) {
+		a.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribing", "application",
 			zap.String("topic", sensorDataTopic),
-			zap.String("component", "application"),
+			zap.String("handler", "sensor_data"),
+		)
+		if err := a.services.MQTTConsumer.Subscribe(ctx, sensorDataTopic, a.services.DeadlinePolicy.Handler(a.services.IngestionPipeline.Handler(sensorDataHandler.HandleMessage))); err != nil {
+			a.loggerFactory.Core().Error("mqtt_topic_subscription_failed",
+				zap.Error(err),
+				zap.String("topic", sensorDataTopic),
+				zap.String("component", "application"),
+			)
+			return fmt.Errorf("failed to subscribe to sensor data topic: %w", err)
+		}
+	}
+
+	// Subscribe to measurement topic carrying values of registered sensor types
+	measurementHandler := messaginghandlers.NewMeasurementHandler(a.loggerFactory, a.services.MeasurementUseCase, a.services.IngestionLatencyUseCase)
+	for _, measurementTopic := range a.deviceTopics(messaginghandlers.MeasurementTopic, messaginghandlers.FarmMeasurementTopic) {
+		a.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribing", "application",
+			zap.String("topic", measurementTopic),
+			zap.String("handler", "measurement"),
 		)
-		return fmt.Errorf("failed to subscribe to sensor data topic: %w", err)
+		if err := a.services.MQTTConsumer.Subscribe(ctx, measurementTopic, a.services.DeadlinePolicy.Handler(a.services.IngestionPipeline.Handler(measurementHandler.HandleMessage))); err != nil {
+			a.loggerFactory.Core().Error("mqtt_topic_subscription_failed",
+				zap.Error(err),
+				zap.String("topic", measurementTopic),
+				zap.String("component", "application"),
+			)
+			return fmt.Errorf("failed to subscribe to measurement topic: %w", err)
+		}
+	}
+
+	// Subscribe to acknowledgements of the commands sent to devices
+	commandAckHandler := messaginghandlers.NewCommandAckHandler(a.loggerFactory, a.services.DeviceCommandsUseCase)
+	for _, commandAckTopic := range a.deviceTopics(messaginghandlers.CommandAckTopic, messaginghandlers.FarmCommandAckTopic) {
+		a.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribing", "application",
+			zap.String("topic", commandAckTopic),
+			zap.String("handler", "command_ack"),
+		)
+		if err := a.services.MQTTConsumer.Subscribe(ctx, commandAckTopic, a.services.DeadlinePolicy.Handler(commandAckHandler.HandleMessage)); err != nil {
+			a.loggerFactory.Core().Error("mqtt_topic_subscription_failed",
+				zap.Error(err),
+				zap.String("topic", commandAckTopic),
+				zap.String("component", "application"),
+			)
+			return fmt.Errorf("failed to subscribe to command acknowledgement topic: %w", err)
+		}
+	}
+
+	// Subscribe to crash reports published by devices after restarting from a crash
+	crashReportHandler := messaginghandlers.NewCrashReportHandler(a.loggerFactory, a.services.CrashReportsUseCase)
+	for _, crashReportTopic := range a.deviceTopics(messaginghandlers.CrashReportTopic, messaginghandlers.FarmCrashReportTopic) {
+		a.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribing", "application",
+			zap.String("topic", crashReportTopic),
+			zap.String("handler", "crash_report"),
+		)
+		if err := a.services.MQTTConsumer.Subscribe(ctx, crashReportTopic, a.services.DeadlinePolicy.Handler(crashReportHandler.HandleMessage)); err != nil {
+			a.loggerFactory.Core().Error("mqtt_topic_subscription_failed",
+				zap.Error(err),
+				zap.String("topic", crashReportTopic),
+				zap.String("component", "application"),
+			)
+			return fmt.Errorf("failed to subscribe to crash report topic: %w", err)
+		}
+	}
+
+	// Subscribe to the Wi-Fi diagnostics devices report periodically
+	wifiDiagnosticsHandler := messaginghandlers.NewWifiDiagnosticsHandler(a.loggerFactory, a.services.WifiDiagnosticsUseCase)
+	for _, wifiDiagnosticsTopic := range a.deviceTopics(messaginghandlers.WifiDiagnosticsTopic, messaginghandlers.FarmWifiDiagnosticsTopic) {
+		a.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribing", "application",
+			zap.String("topic", wifiDiagnosticsTopic),
+			zap.String("handler", "wifi_diagnostics"),
+		)
+		if err := a.services.MQTTConsumer.Subscribe(ctx, wifiDiagnosticsTopic, a.services.DeadlinePolicy.Handler(wifiDiagnosticsHandler.HandleMessage)); err != nil {
+			a.loggerFactory.Core().Error("mqtt_topic_subscription_failed",
+				zap.Error(err),
+				zap.String("topic", wifiDiagnosticsTopic),
+				zap.String("component", "application"),
+			)
+			return fmt.Errorf("failed to subscribe to wifi diagnostics topic: %w", err)
+		}
+	}
+
+	// Read the statistics the broker publishes about itself; brokers may deny $SYS to the consumer,
+	// which then only loses the broker metrics
+	if a.services.BrokerStatsUseCase != nil {
+		brokerStatsHandler := messaginghandlers.NewBrokerStatsHandler(a.loggerFactory, a.services.BrokerStatsUseCase)
+		a.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribing", "application",
+			zap.String("topic", a.config.BrokerStats.Topic),
+			zap.String("handler", "broker_stats"),
+		)
+		if err := a.services.BrokerStatsConsumer.Subscribe(ctx, a.config.BrokerStats.Topic, brokerStatsHandler.HandleMessage); err != nil {
+			a.loggerFactory.Core().Error("mqtt_topic_subscription_failed",
+				zap.Error(err),
+				zap.String("topic", a.config.BrokerStats.Topic),
+				zap.String("component", "application"),
+			)
+		}
+	}
+
+	// Watch command topics for publishers other than the server
+	if a.services.SecurityMonitorUseCase != nil {
+		for _, commandTopic := range a.config.Security.CommandTopics {
+			a.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribing", "application",
+				zap.String("topic", commandTopic),
+				zap.String("handler", "security_monitoring"),
+			)
+			if err := a.services.MQTTConsumer.Subscribe(ctx, commandTopic, a.services.DeadlinePolicy.Handler(a.services.SecurityMonitorUseCase.HandleCommandMessage)); err != nil {
+				a.loggerFactory.Core().Error("mqtt_topic_subscription_failed",
+					zap.Error(err),
+					zap.String("topic", commandTopic),
+					zap.String("component", "application"),
+				)
+			}
+		}
 	}
 
 	// Start NATS subscriber if available
@@ -106,21 +533,42 @@ func (a *Application) startMessageConsumers(ctx context.Context) error {
 				zap.String("component", "application"),
 			)
 		} else {
-			// Subscribe to device detected events
+			// Subscribe to device detected events, also pushed to the device status dashboards
 			deviceHealthHandler := natshandlers.NewDeviceHealthHandler(a.services.DeviceHealthUseCase)
 			deviceDetectedSubject := events.DeviceDetectedSubject
+			deviceDetectedHandlers := []eventports.MessageHandler{deviceHealthHandler.HandleMessage}
+			if a.services.DeviceStatusHub != nil {
+				deviceDetectedHandlers = append(deviceDetectedHandlers, a.services.DeviceStatusHub.HandleMessage)
+			}
 
 			a.loggerFactory.Application().LogApplicationEvent("nats_subject_subscribing", "application",
 				zap.String("subject", deviceDetectedSubject),
 				zap.String("handler", "device_health"),
 			)
-			if err := a.services.NATSSubscriber.Subscribe(ctx, deviceDetectedSubject, deviceHealthHandler.HandleMessage); err != nil {
+			if err := a.services.NATSSubscriber.Subscribe(ctx, deviceDetectedSubject, a.services.DeadlinePolicy.Handler(eventports.FanOut(eventports.ContinueOnError, deviceDetectedHandlers...))); err != nil {
 				a.loggerFactory.Core().Error("nats_subject_subscription_failed",
 					zap.Error(err),
 					zap.String("subject", deviceDetectedSubject),
 					zap.String("component", "application"),
 				)
 			}
+
+			// Subscribe to the online and offline transitions pushed to the device status dashboards
+			if a.services.DeviceStatusHub != nil {
+				for _, subject := range []string{events.DeviceOnlineSubject, events.DeviceOfflineSubject} {
+					a.loggerFactory.Application().LogApplicationEvent("nats_subject_subscribing", "application",
+						zap.String("subject", subject),
+						zap.String("handler", "device_status_hub"),
+					)
+					if err := a.services.NATSSubscriber.Subscribe(ctx, subject, a.services.DeviceStatusHub.HandleMessage); err != nil {
+						a.loggerFactory.Core().Error("nats_subject_subscription_failed",
+							zap.Error(err),
+							zap.String("subject", subject),
+							zap.String("component", "application"),
+						)
+					}
+				}
+			}
 		}
 	}
 
@@ -135,6 +583,8 @@ func (a *Application) startHTTPServer() error {
 		)
 		a.loggerFactory.Core().Info("http_server_endpoints_available",
 			zap.String("ping_url", fmt.Sprintf("http://%s/ping", a.server.Addr)),
+			zap.String("ready_url", fmt.Sprintf("http://%s/ready", a.server.Addr)),
+			zap.String("metrics_url", fmt.Sprintf("http://%s/metrics", a.server.Addr)),
 			zap.String("component", "application"),
 		)
 
@@ -150,13 +600,148 @@ func (a *Application) startHTTPServer() error {
 	return nil
 }
 
-// startBackgroundServices starts any background services like health monitoring
+// startBackgroundServices starts any background services like health monitoring.
+// They keep running after ctx is cancelled, until the drain workers phase of the shutdown,
+// so that intake has stopped before the workers that process its output.
 func (a *Application) startBackgroundServices(ctx context.Context) error {
+	ctx, a.stopWorkers = context.WithCancel(context.WithoutCancel(ctx))
+	run := func(worker func(ctx context.Context)) {
+		a.workers.Add(1)
+		go func() {
+			defer a.workers.Done()
+			worker(ctx)
+		}()
+	}
+
 	// Start health monitoring if NATS subscriber is available
 	if a.services.NATSSubscriber != nil && a.services.DeviceHealthUseCase != nil {
 		a.loggerFactory.Application().LogApplicationEvent("background_health_monitoring_starting", "application")
 	}
 
+	// Start edge to cloud replication
+	if a.services.EdgeSyncUseCase != nil {
+		run(a.services.EdgeSyncUseCase.Run)
+	}
+
+	// Start blacklist cache refresh
+	run(a.services.BlacklistUseCase.Run)
+
+	// Start background job workers
+	run(a.services.JobQueueUseCase.Run)
+
+	// Start periodic health report publishing
+	run(a.services.PingUseCase.Run)
+
+	// Start marking offline the devices that stopped reporting
+	run(a.services.DeviceHealthUseCase.Run)
+
+	// Start stale sensor detection
+	if a.services.SensorHealthUseCase != nil {
+		run(a.services.SensorHealthUseCase.Run)
+	}
+
+	// Start evaluation of scheduled derived sensors
+	if a.services.DerivedSensorUseCase != nil {
+		run(a.services.DerivedSensorUseCase.Run)
+	}
+
+	// Start evaluation of composite alert conditions
+	if a.services.AlertingUseCase != nil {
+		run(a.services.AlertingUseCase.Run)
+	}
+
+	// Start delivery of alert notifications
+	if a.services.NotificationDispatcherUseCase != nil {
+		run(a.services.NotificationDispatcherUseCase.Run)
+	}
+
+	// Start expiry of unacknowledged device commands
+	run(a.services.DeviceCommandsUseCase.Run)
+
+	// Start pruning of old inbox notifications
+	if a.services.NotificationInboxUseCase != nil {
+		run(a.services.NotificationInboxUseCase.Run)
+	}
+
+	// Start compression of old telemetry
+	if a.services.TelemetryCompactionUseCase != nil {
+		run(a.services.TelemetryCompactionUseCase.Run)
+	}
+
+	// Start pruning of readings past the retention policy
+	if a.services.RetentionUseCase != nil {
+		run(a.services.RetentionUseCase.Run)
+	}
+
+	// Start forwarding of telemetry to external time-series databases
+	if a.services.TelemetryForwardingUseCase != nil {
+		run(a.services.TelemetryForwardingUseCase.Run)
+	}
+
+	// Start upload and expiry of raw message bundles
+	if a.services.RawArchiveUseCase != nil {
+		run(a.services.RawArchiveUseCase.Run)
+	}
+
+	// Start pushing declared configurations to drifting devices
+	if a.services.DriftReconciliationUseCase != nil {
+		run(a.services.DriftReconciliationUseCase.Run)
+	}
+
+	// Start predicting the soil moisture of the irrigation zones
+	if a.services.MoisturePredictionUseCase != nil {
+		run(a.services.MoisturePredictionUseCase.Run)
+	}
+
+	// Start opening and closing valves on the irrigation schedules
+	if a.services.IrrigationSchedulingUseCase != nil {
+		run(a.services.IrrigationSchedulingUseCase.Run)
+	}
+
+	// Start reloading the pending device onboardings
+	run(a.services.DeviceOnboardingUseCase.Run)
+
+	// Start raising Wi-Fi advisories from the diagnostics of the devices
+	run(a.services.WifiDiagnosticsUseCase.Run)
+
+	// Start storing metered farm usage and counting the devices of each farm
+	if a.services.UsageMeteringUseCase != nil {
+		run(a.services.UsageMeteringUseCase.Run)
+	}
+
+	return nil
+}
+
+// stopBackgroundServices cancels the background services and waits for them to return
+func (a *Application) stopBackgroundServices(ctx context.Context) error {
+	if a.stopWorkers == nil {
+		return nil
+	}
+	a.stopWorkers()
+
+	done := make(chan struct{})
+	go func() {
+		a.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("background services did not stop: %w", ctx.Err())
+	}
+}
+
+// flushEdgeSync pushes what is left in the edge sync outbox before the process exits
+func (a *Application) flushEdgeSync(ctx context.Context) error {
+	synced, err := a.services.EdgeSyncUseCase.SyncOnce(ctx)
+	a.loggerFactory.Application().LogApplicationEvent("edge_sync_outbox_flushed", "application",
+		zap.Int("synced_items", synced),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to flush edge sync outbox: %w", err)
+	}
 	return nil
 }
 
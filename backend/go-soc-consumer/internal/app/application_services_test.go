@@ -0,0 +1,40 @@
+package app
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/handlers"
+)
+
+// TestInitializeHTTPServer_APIDocumented keeps the OpenAPI document in step with the routes
+func TestInitializeHTTPServer_APIDocumented(t *testing.T) {
+	source, err := os.ReadFile("application_services.go")
+	require.NoError(t, err)
+	paths, ok := handlers.NewOpenAPIDocument()["paths"].(map[string]any)
+	require.True(t, ok)
+
+	routes := regexp.MustCompile(`(?:mux\.Handle(?:Func)?|handleAdmin)\("(?:([A-Z]+) )?(/api/v1/[^"]*)"`).FindAllStringSubmatch(string(source), -1)
+	require.NotEmpty(t, routes)
+	routed := map[string]bool{}
+	for _, route := range routes {
+		method, path := strings.ToLower(route[1]), route[2]
+		routed[path] = true
+		if path == "/api/v1/docs" {
+			continue
+		}
+		item, ok := paths[path].(map[string]any)
+		if !assert.True(t, ok, "%s is not documented", path) || method == "" {
+			continue
+		}
+		assert.Contains(t, item, method, "%s %s is not documented", route[1], path)
+	}
+	for path := range paths {
+		assert.True(t, routed[path], "%s is documented but not served", path)
+	}
+}
@@ -3,20 +3,85 @@ package app
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/deadline"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/email"
 	infrahttp "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/http"
 	messagingmqtt "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/mqtt"
+	mqttbroker "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/mqtt/broker"
+	messaginghandlers "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/mqtt/handlers"
 	messagingnats "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/nats"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/pipeline"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/observability"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/security"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/handlers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/alerting"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/blacklist"
+	brokerauth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/broker_auth"
+	brokerstats "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/broker_stats"
+	crashreports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/crash_reports"
+	declarativeconfig "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/declarative_config"
+	derivedsensors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/derived_sensors"
+	devicearchival "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_archival"
+	devicebundle "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_bundle"
+	devicechanges "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_changes"
+	devicecommands "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_commands"
+	deviceconnections "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_connections"
+	devicediagnostics "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_diagnostics"
 	devicehealth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_health"
+	deviceidentity "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_identity"
+	devicemanagement "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_management"
+	devicemigration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_migration"
+	deviceonboarding "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_onboarding"
 	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
+	devicestatus "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_status"
+	devicetransfers "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_transfers"
+	driftreconciliation "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/drift_reconciliation"
+	edgesync "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/edge_sync"
+	eventpolicies "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/event_policies"
+	farmisolation "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/farm_isolation"
+	farmquotas "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/farm_quotas"
+	farmzones "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/farm_zones"
+	fleetversions "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/fleet_versions"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/handover"
+	ingestionlatency "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/ingestion_latency"
+	irrigationefficiency "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/irrigation_efficiency"
+	irrigationexperiments "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/irrigation_experiments"
+	irrigationscheduling "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/irrigation_scheduling"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/jobs"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	messagetracing "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/message_tracing"
+	moistureprediction "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/moisture_prediction"
+	notificationinbox "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/notification_inbox"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/notifications"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/ping"
+	rawarchive "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/raw_archive"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/reprocessing"
+	researchsharing "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/research_sharing"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/retention"
+	securitymonitoring "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/security_monitoring"
 	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+	sensorhealth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_health"
+	sensorreadings "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_readings"
+	telemetrycompaction "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/telemetry_compaction"
+	telemetryforwarding "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/telemetry_forwarding"
+	usagemetering "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/usage_metering"
+	userauth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/user_auth"
+	valvecontrol "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/valve_control"
+	wifidiagnostics "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/wifi_diagnostics"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/config"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/version"
 )
 
 // Container holds all the application dependencies
@@ -24,7 +89,7 @@ type Container struct {
 	config        *config.AppConfig
 	loggerFactory logger.LoggerFactory
 	services      *Services
-	cleanup       []func() error
+	shutdown      *shutdownSequence
 }
 
 // NewContainer creates a new dependency injection container
@@ -32,7 +97,13 @@ func NewContainer(cfg *config.AppConfig, loggerFactory logger.LoggerFactory) (*C
 	container := &Container{
 		config:        cfg,
 		loggerFactory: loggerFactory,
-		cleanup:       make([]func() error, 0),
+		shutdown: newShutdownSequence(map[shutdownPhase]time.Duration{
+			phaseStopIntake:      cfg.Shutdown.StopIntake,
+			phaseDrainWorkers:    cfg.Shutdown.DrainWorkers,
+			phaseFlush:           cfg.Shutdown.Flush,
+			phaseClosePublishers: cfg.Shutdown.ClosePublishers,
+			phaseCloseDatabase:   cfg.Shutdown.CloseDatabase,
+		}, loggerFactory),
 	}
 
 	services, err := container.buildServices()
@@ -54,28 +125,11 @@ func (c *Container) GetServices() *Services {
 	return c.services
 }
 
-// Cleanup runs all cleanup functions
-func (c *Container) Cleanup() error {
-	c.loggerFactory.Application().LogApplicationEvent("container_cleanup_starting", "container")
-
-	for i := len(c.cleanup) - 1; i >= 0; i-- {
-		if err := c.cleanup[i](); err != nil {
-			c.loggerFactory.Core().Error("container_cleanup_error",
-				zap.Error(err),
-				zap.Int("cleanup_step", i),
-				zap.String("component", "container"),
-			)
-			return err
-		}
-	}
-
-	c.loggerFactory.Application().LogApplicationEvent("container_cleanup_completed", "container")
-	return nil
-}
-
 // buildServices constructs all the application services with proper dependency injection
 func (c *Container) buildServices() (*Services, error) {
-	services := &Services{}
+	services := &Services{
+		Metrics: metrics.NewRegistry(),
+	}
 
 	// Build infrastructure dependencies first
 	if err := c.buildInfrastructure(services); err != nil {
@@ -87,11 +141,35 @@ func (c *Container) buildServices() (*Services, error) {
 		return nil, fmt.Errorf("failed to build use cases: %w", err)
 	}
 
+	if err := c.buildPipeline(services); err != nil {
+		return nil, fmt.Errorf("failed to build ingestion pipeline: %w", err)
+	}
+
+	// Build Reprocessing Use Case once the handlers it replays through are complete
+	if services.RawMessageArchive != nil {
+		c.buildReprocessing(services)
+	}
+
 	return services, nil
 }
 
 // buildInfrastructure builds all infrastructure-layer dependencies
 func (c *Container) buildInfrastructure(services *Services) error {
+	// Build the deadline policy bounding database, messaging and publishing calls
+	services.DeadlinePolicy = deadline.NewPolicy(map[deadline.Class]time.Duration{
+		deadline.ClassMessage:  c.config.Deadlines.Message,
+		deadline.ClassDatabase: c.config.Deadlines.Database,
+		deadline.ClassPublish:  c.config.Deadlines.Publish,
+	}, c.loggerFactory)
+	services.Metrics.Register(services.DeadlinePolicy)
+
+	// Build the recorder behind the observed repository and publisher ports
+	services.PortRecorder = observability.NewRecorder(c.config.Observability.SlowCallThreshold, c.loggerFactory)
+	services.Metrics.Register(services.PortRecorder)
+	if collector := logger.MetricsCollector(c.loggerFactory); collector != nil {
+		services.Metrics.Register(collector)
+	}
+
 	// Build database repository
 	if err := c.buildRepository(services); err != nil {
 		return fmt.Errorf("failed to build repository: %w", err)
@@ -136,13 +214,66 @@ func (c *Container) buildRepository(services *Services) error {
 		gormDB.Close()
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
+	if gormDB.Timescale() {
+		c.loggerFactory.Application().LogApplicationEvent("database_timescale_enabling", "container")
+		if err := gormDB.EnableTimescale(context.Background()); err != nil {
+			c.loggerFactory.Core().Error("database_timescale_failed",
+				zap.Error(err),
+				zap.String("component", "container"),
+			)
+			gormDB.Close()
+			return fmt.Errorf("failed to enable timescale: %w", err)
+		}
+	}
 
-	// Initialize repository with logger factory
-	services.DeviceRepository = postgres.NewDeviceRepository(gormDB, c.loggerFactory)
-	services.SensorTemperatureHumidityRepository = postgres.NewSensorTemperatureHumidityRepository(gormDB, c.loggerFactory)
+	// Bound every statement, whatever context the repositories are called with
+	if err := gormDB.GetDB().Use(database.NewDeadlinePlugin(services.DeadlinePolicy)); err != nil {
+		gormDB.Close()
+		return fmt.Errorf("failed to register database deadline plugin: %w", err)
+	}
+	services.Metrics.Register(gormDB)
+
+	// Initialize repositories with logger factory, observed through the port recorder
+	recorder := services.PortRecorder
+	services.DeviceRepository = observability.NewObservedDeviceRepository(postgres.NewDeviceRepository(gormDB, c.config.GetPaginationPolicy(), c.loggerFactory), recorder)
+	services.SensorTemperatureHumidityRepository = observability.NewObservedSensorTemperatureHumidityRepository(postgres.NewSensorTemperatureHumidityRepository(gormDB, c.loggerFactory), recorder)
+	services.MeasurementRepository = observability.NewObservedMeasurementRepository(postgres.NewMeasurementRepository(gormDB, c.loggerFactory), recorder)
+	services.SensorChannelRepository = observability.NewObservedSensorChannelRepository(postgres.NewSensorChannelRepository(gormDB, c.loggerFactory), recorder)
+	services.JobRepository = observability.NewObservedJobRepository(postgres.NewJobRepository(gormDB, c.loggerFactory), recorder)
+	services.BlacklistRepository = observability.NewObservedBlacklistRepository(postgres.NewBlacklistRepository(gormDB, c.loggerFactory), recorder)
+	services.NotificationInboxRepository = observability.NewObservedNotificationInboxRepository(postgres.NewNotificationInboxRepository(gormDB, c.loggerFactory), recorder)
+	services.DeviceCommandRepository = observability.NewObservedDeviceCommandRepository(postgres.NewDeviceCommandRepository(gormDB, c.loggerFactory), recorder)
+	services.CrashReportRepository = observability.NewObservedCrashReportRepository(postgres.NewCrashReportRepository(gormDB, c.loggerFactory), recorder)
+	services.ValveStateRepository = observability.NewObservedValveStateRepository(postgres.NewValveStateRepository(gormDB, c.loggerFactory), recorder)
+	services.DeviceShadowRepository = observability.NewObservedDeviceShadowRepository(postgres.NewDeviceShadowRepository(gormDB, c.loggerFactory), recorder)
+	services.IrrigationScheduleRepository = observability.NewObservedIrrigationScheduleRepository(postgres.NewIrrigationScheduleRepository(gormDB, c.loggerFactory), recorder)
+	services.IrrigationOverrideRepository = observability.NewObservedIrrigationOverrideRepository(postgres.NewIrrigationOverrideRepository(gormDB, c.loggerFactory), recorder)
+	services.DeviceOnboardingRepository = observability.NewObservedDeviceOnboardingRepository(postgres.NewDeviceOnboardingRepository(gormDB, c.loggerFactory), recorder)
+	services.FarmRepository = observability.NewObservedFarmRepository(postgres.NewFarmRepository(gormDB, c.loggerFactory), recorder)
+	services.ZoneRepository = observability.NewObservedZoneRepository(postgres.NewZoneRepository(gormDB, c.loggerFactory), recorder)
+	services.IrrigationExperimentRepository = observability.NewObservedIrrigationExperimentRepository(postgres.NewIrrigationExperimentRepository(gormDB, c.loggerFactory), recorder)
+	services.UserRepository = observability.NewObservedUserRepository(postgres.NewUserRepository(gormDB, c.loggerFactory), recorder)
+	services.ResearchTokenRepository = observability.NewObservedResearchTokenRepository(postgres.NewResearchTokenRepository(gormDB, c.loggerFactory), recorder)
+	services.DeviceTransferRepository = observability.NewObservedDeviceTransferRepository(postgres.NewDeviceTransferRepository(gormDB, c.loggerFactory), recorder)
+	services.DeviceArchiveRepository = observability.NewObservedDeviceArchiveRepository(postgres.NewDeviceArchiveRepository(gormDB, c.loggerFactory), recorder)
+	services.DeviceMigrationRepository = observability.NewObservedDeviceMigrationRepository(postgres.NewDeviceMigrationRepository(gormDB, c.loggerFactory), recorder)
+	if c.config.Usage.Enabled {
+		services.UsageRepository = observability.NewObservedUsageRepository(postgres.NewUsageRepository(gormDB, c.loggerFactory), recorder)
+	}
+	if c.config.Sync.Mode == edgesync.ModeEdge {
+		services.SyncOutboxRepository = observability.NewObservedSyncOutboxRepository(postgres.NewSyncOutboxRepository(gormDB, c.config.GetPaginationPolicy(), c.loggerFactory), recorder)
+	}
+	if c.config.Telemetry.CompressionEnabled {
+		services.TelemetryArchiveRepository = observability.NewObservedTelemetryArchiveRepository(postgres.NewTelemetryArchiveRepository(gormDB, c.config.GetPaginationPolicy(), c.loggerFactory), recorder)
+	}
+	if c.config.Retention.Enabled {
+		services.RetentionRepository = observability.NewObservedRetentionRepository(postgres.NewRetentionRepository(gormDB, c.loggerFactory), recorder)
+	}
+
+	services.HealthChecks = append(services.HealthChecks, ping.DependencyCheck{Name: "database", Check: gormDB.HealthCheck})
 
-	// Register cleanup
-	c.cleanup = append(c.cleanup, func() error {
+	// Close the database last, once nothing writes to it anymore
+	c.shutdown.add(phaseCloseDatabase, "database", func(ctx context.Context) error {
 		c.loggerFactory.Application().LogApplicationEvent("database_connection_closing", "container")
 		return gormDB.Close()
 	})
@@ -166,13 +297,19 @@ func (c *Container) buildMessaging(services *Services) error {
 
 // buildMQTTConsumer builds the MQTT consumer
 func (c *Container) buildMQTTConsumer(services *Services) error {
+	// All-in-one edge deployments run the broker in-process
+	if c.config.MQTT.Embedded.Enabled {
+		return c.buildEmbeddedMQTTConsumer(services)
+	}
+
 	c.loggerFactory.Application().LogApplicationEvent("mqtt_consumer_initializing", "container",
-		zap.String("broker_url", c.config.MQTT.BrokerURL),
+		zap.Strings("broker_urls", c.config.GetMQTTBrokerURLs()),
 		zap.String("client_id", c.config.MQTT.ClientID),
 	)
 
 	mqttConfig := messagingmqtt.MQTTConsumerConfig{
 		BrokerURL:            c.config.MQTT.BrokerURL,
+		BrokerURLs:           c.config.GetMQTTBrokerURLs(),
 		ClientID:             c.config.MQTT.ClientID,
 		Username:             c.config.MQTT.Username,
 		Password:             c.config.MQTT.Password,
@@ -181,13 +318,125 @@ func (c *Container) buildMQTTConsumer(services *Services) error {
 		ConnectTimeout:       c.config.MQTT.ConnectTimeout,
 		KeepAlive:            c.config.MQTT.KeepAlive,
 		MaxReconnectInterval: c.config.MQTT.MaxReconnectInterval,
+		FailbackInterval:     c.config.MQTT.FailbackInterval,
+	}
+	if tlsConfig := c.config.MQTT.TLS; tlsConfig.Enabled() {
+		mqttConfig.TLS = &messagingmqtt.TLSClientConfig{
+			CAFile:             tlsConfig.CAFile,
+			CertFile:           tlsConfig.CertFile,
+			KeyFile:            tlsConfig.KeyFile,
+			InsecureSkipVerify: tlsConfig.InsecureSkipVerify,
+		}
+		if tlsConfig.InsecureSkipVerify {
+			c.loggerFactory.Core().Warn("mqtt_tls_verification_disabled",
+				zap.String("component", "container"),
+			)
+		}
 	}
 
-	services.MQTTConsumer = messagingmqtt.NewMQTTConsumer(mqttConfig, c.loggerFactory)
+	mqttConsumer := messagingmqtt.NewMQTTConsumer(mqttConfig, c.loggerFactory)
+	var consumer eventports.MessageConsumer = mqttConsumer
+	if c.config.MQTT.IdentityForwardPrefix != "" {
+		// The broker republishes device messages with the authenticated publisher identity
+		consumer = messagingmqtt.NewForwardedIdentityConsumer(mqttConsumer, c.config.MQTT.IdentityForwardPrefix)
+	}
+	pausableConsumer := messagingmqtt.NewPausableConsumer(consumer, c.loggerFactory)
+	services.MQTTConsumer = pausableConsumer
+	services.MQTTConsumerControl = pausableConsumer
+	services.CommandPublisher = messagingmqtt.NewCommandPublisher(mqttConsumer, c.config.MQTT.LegacyTopics)
+	if c.config.BrokerStats.Enabled {
+		// $SYS topics are read as published, neither forwarded with an identity nor paused
+		services.BrokerStatsConsumer = mqttConsumer
+		services.BrokerStatsUseCase = brokerstats.NewBrokerStatsUseCase(c.loggerFactory)
+		services.Metrics.Register(brokerstats.MetricsCollector(services.BrokerStatsUseCase))
+	}
+	services.OverrideSources = append(services.OverrideSources, pausableConsumer)
+	services.Metrics.Register(mqttConsumer.MetricsCollector())
+	services.Metrics.Register(pausableConsumer)
+	services.ReadinessChecks = append(services.ReadinessChecks, handlers.ReadinessCheck{
+		Name:  "mqtt",
+		Check: pausableConsumer.WrapReadiness(mqttConsumer.CheckReadiness),
+	})
+	services.HealthChecks = append(services.HealthChecks, mqttHealthCheck(mqttConsumer.CheckReadiness))
 	c.loggerFactory.Application().LogApplicationEvent("mqtt_consumer_initialized", "container")
 	return nil
 }
 
+// buildEmbeddedMQTTConsumer builds the embedded broker and attaches an in-process consumer to it
+func (c *Container) buildEmbeddedMQTTConsumer(services *Services) error {
+	embedded := c.config.MQTT.Embedded
+	c.loggerFactory.Application().LogApplicationEvent("embedded_broker_initializing", "container",
+		zap.String("address", embedded.Address),
+		zap.Bool("bridge_enabled", embedded.BridgeURL != ""),
+	)
+
+	users, err := c.config.GetEmbeddedBrokerUsers()
+	if err != nil {
+		return fmt.Errorf("invalid embedded broker users: %w", err)
+	}
+	if len(users) == 0 {
+		c.loggerFactory.Core().Warn("embedded_broker_anonymous_access",
+			zap.String("address", embedded.Address),
+			zap.String("component", "container"),
+		)
+	}
+
+	brokerConfig := mqttbroker.EmbeddedBrokerConfig{
+		Address: embedded.Address,
+		Users:   users,
+	}
+	if embedded.TLSAddress != "" {
+		brokerConfig.TLS = &mqttbroker.TLSListenerConfig{
+			Address:      embedded.TLSAddress,
+			CertFile:     embedded.TLSCertFile,
+			KeyFile:      embedded.TLSKeyFile,
+			ClientCAFile: embedded.TLSClientCA,
+		}
+	}
+	if embedded.BridgeURL != "" {
+		brokerConfig.Bridge = &mqttbroker.BridgeConfig{
+			UpstreamURL:    embedded.BridgeURL,
+			ClientID:       embedded.BridgeClientID,
+			Username:       embedded.BridgeUsername,
+			Password:       embedded.BridgePassword,
+			Topics:         embedded.BridgeTopics,
+			ConnectTimeout: c.config.MQTT.ConnectTimeout,
+		}
+	}
+
+	broker, err := mqttbroker.NewEmbeddedBroker(brokerConfig, c.loggerFactory)
+	if err != nil {
+		c.loggerFactory.Core().Error("embedded_broker_initialization_failed",
+			zap.Error(err),
+			zap.String("address", embedded.Address),
+			zap.String("component", "container"),
+		)
+		return fmt.Errorf("failed to create embedded broker: %w", err)
+	}
+
+	consumer := mqttbroker.NewInProcessConsumer(broker, c.loggerFactory)
+	pausableConsumer := messagingmqtt.NewPausableConsumer(consumer, c.loggerFactory)
+	services.MQTTConsumer = pausableConsumer
+	services.MQTTConsumerControl = pausableConsumer
+	services.CommandPublisher = messagingmqtt.NewCommandPublisher(consumer, c.config.MQTT.LegacyTopics)
+	services.OverrideSources = append(services.OverrideSources, pausableConsumer)
+	services.Metrics.Register(broker)
+	services.Metrics.Register(pausableConsumer)
+	services.ReadinessChecks = append(services.ReadinessChecks, handlers.ReadinessCheck{
+		Name:  "mqtt",
+		Check: pausableConsumer.WrapReadiness(consumer.CheckReadiness),
+	})
+	services.HealthChecks = append(services.HealthChecks, mqttHealthCheck(consumer.CheckReadiness))
+
+	c.shutdown.add(phaseClosePublishers, "embedded_broker", func(ctx context.Context) error {
+		c.loggerFactory.Application().LogApplicationEvent("embedded_broker_closing", "container")
+		return broker.Close()
+	})
+
+	c.loggerFactory.Application().LogApplicationEvent("embedded_broker_initialized", "container")
+	return nil
+}
+
 // buildNATSComponents builds NATS publisher and subscriber (optional)
 func (c *Container) buildNATSComponents(services *Services) {
 	// Use existing NATS config with defaults
@@ -203,6 +452,15 @@ func (c *Container) buildNATSComponents(services *Services) {
 	natsConfig.ConnectTimeout = c.config.NATS.Timeout
 	natsConfig.PingInterval = c.config.NATS.PingInterval
 	natsConfig.MaxPingsOutstanding = c.config.NATS.MaxPingsOut
+	natsConfig.ReconnectBufSize = c.config.NATS.ReconnectBufSize
+	natsConfig.Publisher = messagingnats.Credentials{
+		CredsFile:    c.config.NATS.Publisher.CredsFile,
+		NKeySeedFile: c.config.NATS.Publisher.NKeySeedFile,
+	}
+	natsConfig.Subscriber = messagingnats.Credentials{
+		CredsFile:    c.config.NATS.Subscriber.CredsFile,
+		NKeySeedFile: c.config.NATS.Subscriber.NKeySeedFile,
+	}
 
 	// Build NATS Publisher
 	if natsPublisher, err := messagingnats.NewNATSPublisher(natsConfig, c.loggerFactory); err != nil {
@@ -212,11 +470,18 @@ func (c *Container) buildNATSComponents(services *Services) {
 			zap.String("component", "container"),
 		)
 		services.NATSPublisher = nil
+		services.HealthChecks = append(services.HealthChecks, ping.DependencyCheck{Name: "nats", Check: func(ctx context.Context) error {
+			return fmt.Errorf("NATS publisher unavailable: %w", err)
+		}})
 	} else {
-		services.NATSPublisher = natsPublisher
-		c.cleanup = append(c.cleanup, func() error {
-			return natsPublisher.Close(context.TODO())
-		})
+		services.NATSPublisher = deadline.NewBoundedPublisher(observability.NewObservedEventPublisher(natsPublisher, services.PortRecorder), services.DeadlinePolicy)
+		services.HealthChecks = append(services.HealthChecks, ping.DependencyCheck{Name: "nats", Check: func(ctx context.Context) error {
+			if !natsPublisher.IsConnected() {
+				return fmt.Errorf("NATS publisher is not connected")
+			}
+			return nil
+		}})
+		c.shutdown.add(phaseClosePublishers, "nats_publisher", natsPublisher.Close)
 		c.loggerFactory.Application().LogApplicationEvent("nats_publisher_initialized", "container",
 			zap.String("url", natsConfig.URL),
 		)
@@ -235,6 +500,17 @@ func (c *Container) buildNATSComponents(services *Services) {
 		c.loggerFactory.Application().LogApplicationEvent("nats_subscriber_initialized", "container",
 			zap.String("url", natsConfig.URL),
 		)
+
+		// Push the device events received to the dashboards connected over WebSocket
+		services.DeviceStatusHub = infrahttp.NewDeviceStatusHub(&infrahttp.DeviceStatusHubConfig{
+			MaxClients:     c.config.DeviceStatus.MaxClients,
+			SendBuffer:     c.config.DeviceStatus.SendBuffer,
+			WriteTimeout:   10 * time.Second,
+			PingInterval:   c.config.DeviceStatus.PingInterval,
+			AllowedOrigins: c.config.DeviceStatus.AllowedOrigins,
+		}, c.loggerFactory)
+		services.Metrics.Register(services.DeviceStatusHub)
+		c.shutdown.add(phaseStopIntake, "device_status_hub", services.DeviceStatusHub.Close)
 	}
 }
 
@@ -256,6 +532,41 @@ func (c *Container) buildExternalDependencies(services *Services) error {
 		zap.Int("retry_attempts", c.config.HealthCheck.RetryAttempts),
 	)
 
+	// Build cloud sync client for edge instances
+	if c.config.Sync.Mode == edgesync.ModeEdge {
+		services.SyncRemote = infrahttp.NewSyncClient(&infrahttp.SyncClientConfig{
+			CloudURL:   c.config.Sync.CloudURL,
+			Token:      c.config.Sync.Token,
+			Timeout:    c.config.Sync.Timeout,
+			InstanceID: c.config.Sync.InstanceID,
+		}, c.loggerFactory)
+		c.loggerFactory.Application().LogApplicationEvent("sync_client_initialized", "container",
+			zap.String("cloud_url", c.config.Sync.CloudURL),
+			zap.String("instance_id", c.config.Sync.InstanceID),
+		)
+	}
+
+	// Build command signer used to protect commands published to devices against replay
+	if len(c.config.Commands.SigningKeys) > 0 {
+		keys, err := c.config.GetCommandSigningKeys()
+		if err != nil {
+			return fmt.Errorf("failed to load command signing keys: %w", err)
+		}
+		signer, err := security.NewHMACCommandSigner(security.HMACCommandSignerConfig{
+			Keys:         keys,
+			ActiveKeyID:  c.config.Commands.SigningKeyID,
+			ReplayWindow: c.config.Commands.ReplayWindow,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create command signer: %w", err)
+		}
+		services.CommandSigner = signer
+		c.loggerFactory.Application().LogApplicationEvent("command_signer_initialized", "container",
+			zap.String("active_key_id", c.config.Commands.SigningKeyID),
+			zap.Int("keys", len(keys)),
+		)
+	}
+
 	return nil
 }
 
@@ -263,8 +574,45 @@ func (c *Container) buildExternalDependencies(services *Services) error {
 func (c *Container) buildUseCases(services *Services) error {
 	c.loggerFactory.Application().LogApplicationEvent("use_cases_initializing", "container")
 
-	// Build Ping Use Case
-	services.PingUseCase = ping.NewUseCase()
+	// Build Event Policies Use Case first so every use case publishes through the policies
+	if err := c.buildEventPolicies(services); err != nil {
+		return err
+	}
+
+	// Build Ping Use Case, reporting the health of the dependencies built with the infrastructure
+	services.PingUseCase = ping.NewUseCase(&ping.ReportConfig{
+		Version:         version.Version,
+		CheckTimeout:    c.config.HealthReport.CheckTimeout,
+		PublishInterval: c.config.HealthReport.PublishInterval,
+	}, services.HealthChecks, services.NATSPublisher, c.loggerFactory)
+
+	// Build Job Queue Use Case; long-running use cases register their job handlers on it
+	services.JobQueueUseCase = jobs.NewJobQueueUseCase(
+		services.JobRepository,
+		&jobs.JobQueueConfig{
+			Workers:       c.config.Jobs.Workers,
+			PollInterval:  c.config.Jobs.PollInterval,
+			LeaseDuration: c.config.Jobs.LeaseDuration,
+			MaxAttempts:   c.config.Jobs.MaxAttempts,
+			RetryBackoff:  c.config.Jobs.RetryBackoff,
+		},
+		c.loggerFactory,
+	)
+	services.Metrics.Register(jobs.MetricsCollector(services.JobQueueUseCase))
+
+	// Build Sync Use Cases before the others so they use the replicating repositories
+	c.buildSyncUseCases(services)
+
+	// Build Raw Archive Use Case; the ingestion pipeline records every inbound message in it
+	if c.config.RawArchive.Enabled {
+		c.buildRawArchive(services)
+	}
+
+	// Build Device Changes Use Case; every device write from here on is journaled for long-polling clients
+	services.DeviceChangesUseCase = devicechanges.NewDeviceChangesUseCase(&devicechanges.JournalConfig{
+		Size: c.config.DeviceChanges.JournalSize,
+	})
+	services.DeviceRepository = devicechanges.NewJournalingDeviceRepository(services.DeviceRepository, services.DeviceChangesUseCase)
 
 	// Build Device Registration Use Case
 	services.DeviceRegistrationUseCase = deviceregistration.NewDeviceRegistrationUseCase(
@@ -275,16 +623,1019 @@ func (c *Container) buildUseCases(services *Services) error {
 
 	// Build Device Health Use Case
 	healthCheckConfig := devicehealth.DefaultHealthCheckConfig()
+	healthCheckConfig.CheckTimeout = c.config.HealthCheck.OnDemandTimeout
+	healthCheckConfig.OfflineThreshold = c.config.HealthCheck.OfflineThreshold
+	healthCheckConfig.SweepInterval = c.config.HealthCheck.OfflineSweepInterval
 	services.DeviceHealthUseCase = devicehealth.NewDeviceHealthUseCase(
 		services.DeviceRepository,
 		services.HealthChecker,
+		services.NATSPublisher,
 		healthCheckConfig,
 		c.loggerFactory,
 	)
+	services.Metrics.Register(devicehealth.MetricsCollector(services.DeviceHealthUseCase))
+
+	// Build Device Status Use Case
+	services.DeviceStatusUseCase = devicestatus.NewDeviceStatusUseCase(
+		services.DeviceRepository,
+		c.config.Server.StatusQueryLimit,
+		c.loggerFactory,
+	)
 
 	// Build Sensor Data Use Case
 	services.SensorDataUseCase = sensordata.NewSensorDataUseCase(c.loggerFactory, services.SensorTemperatureHumidityRepository)
+	services.SensorReadingsUseCase = sensorreadings.NewSensorReadingsUseCase(services.SensorTemperatureHumidityRepository, nil)
+
+	// Build Usage Metering Use Case; stored readings and delivered notifications are charged to their farm
+	if c.config.Usage.Enabled {
+		services.UsageMeteringUseCase = usagemetering.NewUsageMeteringUseCase(
+			services.UsageRepository,
+			services.DeviceRepository,
+			&usagemetering.MeteringConfig{
+				FlushInterval:       c.config.Usage.FlushInterval,
+				DeviceCountInterval: c.config.Usage.DeviceCountInterval,
+				StorageBytesPerRow:  c.config.Usage.StorageBytesPerRow,
+			},
+			c.loggerFactory,
+		)
+		services.Metrics.Register(usagemetering.MetricsCollector(services.UsageMeteringUseCase))
+		services.SensorDataUseCase = usagemetering.NewMeteredSensorDataUseCase(services.SensorDataUseCase, services.UsageMeteringUseCase)
+	}
+
+	// Build Sensor Health Use Case; it observes readings once they are stored
+	if c.config.SensorHealth.Enabled {
+		healthConfig := sensorhealth.DefaultHealthConfig()
+		healthConfig.StaleFactor = c.config.SensorHealth.StaleFactor
+		healthConfig.StaleMinimum = c.config.SensorHealth.StaleMinimum
+		healthConfig.CalibrationMaxAge = time.Duration(c.config.SensorHealth.CalibrationMaxAgeDays) * 24 * time.Hour
+		healthConfig.DegradedScore = c.config.SensorHealth.DegradedScore
+		services.SensorHealthUseCase = sensorhealth.NewSensorHealthUseCase(services.DeviceRepository, healthConfig, c.loggerFactory)
+		services.Metrics.Register(sensorhealth.MetricsCollector(services.SensorHealthUseCase))
+		services.SensorDataUseCase = sensorhealth.NewObservedSensorDataUseCase(services.SensorDataUseCase, services.SensorHealthUseCase)
+	}
+
+	// Build Notification Dispatcher Use Case; users are notified of alerts following their preferences
+	if err := c.buildNotifications(services); err != nil {
+		return fmt.Errorf("failed to build notifications: %w", err)
+	}
+
+	// Build Alerting Use Case from the configured alert rules
+	if err := c.buildAlerting(services); err != nil {
+		return fmt.Errorf("failed to build alerting: %w", err)
+	}
+
+	// Build Measurement Use Case and create the typed view of every registered sensor type; derived
+	// sensors are computed from stored measurements and readings, and alert rules evaluated on both
+	if err := c.buildMeasurements(services); err != nil {
+		return fmt.Errorf("failed to build measurements: %w", err)
+	}
+	// Derived values are stored through the inner use case, so only ingested measurements are metered
+	if services.UsageMeteringUseCase != nil {
+		services.MeasurementUseCase = usagemetering.NewMeteredMeasurementUseCase(services.MeasurementUseCase, services.UsageMeteringUseCase)
+	}
+
+	// Build Device Diagnostics Use Case; field debugging gathers the stored state and probes the device
+	services.DeviceDiagnosticsUseCase = devicediagnostics.NewDeviceDiagnosticsUseCase(
+		services.DeviceRepository,
+		services.SensorTemperatureHumidityRepository,
+		services.MeasurementRepository,
+		services.DeviceChangesUseCase,
+		services.DeviceHealthUseCase,
+		devicediagnostics.DefaultDiagnosticsConfig(),
+		c.loggerFactory,
+	)
+
+	// Build Device Commands Use Case; remote troubleshooting commands go out over the MQTT connection
+	// and are acknowledged by the devices on their own topic
+	commandsConfig := devicecommands.DefaultCommandsConfig()
+	commandsConfig.AckTimeout = c.config.Commands.AckTimeout
+	commandsConfig.MaxAttempts = c.config.Commands.MaxAttempts
+	services.DeviceCommandsUseCase = devicecommands.NewDeviceCommandsUseCase(
+		services.DeviceRepository,
+		services.DeviceCommandRepository,
+		services.CommandPublisher,
+		services.CommandSigner,
+		commandsConfig,
+		c.loggerFactory,
+	)
+	services.Metrics.Register(devicecommands.MetricsCollector(services.DeviceCommandsUseCase))
+
+	// Build Valve Control Use Case; valve commands are device commands, and their acknowledgements
+	// move the valve state
+	services.ValveControlUseCase = valvecontrol.NewValveControlUseCase(
+		services.DeviceRepository,
+		services.DeviceCommandRepository,
+		services.ValveStateRepository,
+		services.DeviceCommandsUseCase,
+		c.loggerFactory,
+	)
+	services.DeviceCommandsUseCase = valvecontrol.NewValveTrackingCommandsUseCase(services.DeviceCommandsUseCase, services.ValveControlUseCase)
+
+	// Build Crash Reports Use Case; devices report firmware crashes after restarting
+	services.CrashReportsUseCase = crashreports.NewCrashReportsUseCase(
+		services.DeviceRepository,
+		services.CrashReportRepository,
+		crashreports.DefaultCrashReportsConfig(),
+		c.loggerFactory,
+	)
+	services.Metrics.Register(crashreports.MetricsCollector(services.CrashReportsUseCase))
+
+	// Build Wi-Fi Diagnostics Use Case; devices report their Wi-Fi link and advisories are raised as
+	// alerts per location to guide access point placement
+	services.WifiDiagnosticsUseCase = wifidiagnostics.NewWifiDiagnosticsUseCase(
+		services.DeviceRepository,
+		&wifidiagnostics.WifiDiagnosticsConfig{
+			RSSIThreshold:      c.config.Wifi.RSSIThreshold,
+			ReconnectThreshold: c.config.Wifi.ReconnectThreshold,
+			MinDevices:         c.config.Wifi.MinDevices,
+			MaxAge:             c.config.Wifi.MaxAge,
+			Interval:           c.config.Wifi.Interval,
+		},
+		services.NATSPublisher,
+		services.NotificationDispatcherUseCase,
+		c.loggerFactory,
+	)
+	services.Metrics.Register(wifidiagnostics.MetricsCollector(services.WifiDiagnosticsUseCase))
+
+	// Build Ingestion Latency Use Case; the MQTT handlers record how long device messages take to be persisted
+	latencyConfig := ingestionlatency.DefaultIngestionLatencyConfig()
+	latencyConfig.ClockDriftTolerance = c.config.Pipeline.ClockDriftTolerance
+	services.IngestionLatencyUseCase = ingestionlatency.NewIngestionLatencyUseCase(latencyConfig, c.loggerFactory)
+	services.Metrics.Register(ingestionlatency.MetricsCollector(services.IngestionLatencyUseCase))
+
+	// Build Message Tracing Use Case; operators trace a topic or a device for a limited time, and the
+	// active sessions are reported in the handover report
+	services.MessageTracingUseCase = messagetracing.NewMessageTracingUseCase(messagetracing.DefaultTracingConfig(), c.loggerFactory)
+	services.Metrics.Register(messagetracing.MetricsCollector(services.MessageTracingUseCase))
+	services.OverrideSources = append(services.OverrideSources, services.MessageTracingUseCase)
+
+	// Build Fleet Versions Use Case; firmware and hardware versions are reported at registration
+	services.FleetVersionsUseCase = fleetversions.NewFleetVersionsUseCase(services.DeviceRepository, c.loggerFactory)
+
+	// Build Telemetry Forwarding Use Case; stored readings are mirrored to the configured sinks
+	if c.config.ForwardingEnabled() {
+		forwardingConfig := telemetryforwarding.DefaultForwardingConfig()
+		forwardingConfig.BufferSize = c.config.Forwarding.BufferSize
+		forwardingConfig.BatchSize = c.config.Forwarding.BatchSize
+		forwardingConfig.FlushInterval = c.config.Forwarding.FlushInterval
+		forwardingConfig.MaxBackoff = c.config.Forwarding.MaxBackoff
+		services.TelemetryForwardingUseCase = telemetryforwarding.NewTelemetryForwardingUseCase(c.buildTelemetrySinks(), forwardingConfig, c.loggerFactory)
+		services.Metrics.Register(telemetryforwarding.MetricsCollector(services.TelemetryForwardingUseCase))
+		services.SensorDataUseCase = telemetryforwarding.NewForwardingSensorDataUseCase(services.SensorDataUseCase, services.TelemetryForwardingUseCase)
+	}
+
+	// Build Device Identity Use Case; verification wraps the use cases fed by MQTT
+	services.DeviceIdentityUseCase = deviceidentity.NewDeviceIdentityUseCase(services.DeviceRepository, c.loggerFactory)
+	services.Metrics.Register(deviceidentity.MetricsCollector(services.DeviceIdentityUseCase))
+	if c.config.MQTT.VerifyDeviceIdentity {
+		services.DeviceRegistrationUseCase = deviceidentity.NewVerifyingDeviceRegistrationUseCase(services.DeviceRegistrationUseCase, services.DeviceIdentityUseCase)
+		services.SensorDataUseCase = deviceidentity.NewVerifyingSensorDataUseCase(services.SensorDataUseCase, services.DeviceIdentityUseCase)
+		services.MeasurementUseCase = deviceidentity.NewVerifyingMeasurementUseCase(services.MeasurementUseCase, services.DeviceIdentityUseCase)
+		c.loggerFactory.Application().LogApplicationEvent("device_identity_verification_enabled", "container")
+	}
+
+	// Build Device Onboarding Use Case; registrations and readings that pass the identity check
+	// complete the steps of the devices being onboarded
+	onboardingConfig := deviceonboarding.DefaultOnboardingConfig()
+	onboardingConfig.BrokerURL = c.config.GetOnboardingBrokerURL()
+	onboardingConfig.TokenTTL = c.config.Onboarding.TokenTTL
+	services.DeviceOnboardingUseCase = deviceonboarding.NewDeviceOnboardingUseCase(services.DeviceOnboardingRepository, services.DeviceRepository, onboardingConfig, c.loggerFactory)
+	services.Metrics.Register(deviceonboarding.MetricsCollector(services.DeviceOnboardingUseCase))
+	services.DeviceRegistrationUseCase = deviceonboarding.NewOnboardingDeviceRegistrationUseCase(services.DeviceRegistrationUseCase, services.DeviceOnboardingUseCase, c.loggerFactory)
+	services.SensorDataUseCase = deviceonboarding.NewOnboardingSensorDataUseCase(services.SensorDataUseCase, services.DeviceOnboardingUseCase, c.loggerFactory)
+	services.MeasurementUseCase = deviceonboarding.NewOnboardingMeasurementUseCase(services.MeasurementUseCase, services.DeviceOnboardingUseCase, c.loggerFactory)
+
+	// Build Broker Auth Use Case; external brokers authenticate clients and authorize their topics
+	// against the device registry and the pending onboardings
+	if c.config.BrokerAuth.Token != "" {
+		services.BrokerAuthUseCase = brokerauth.NewBrokerAuthUseCase(
+			services.DeviceRepository,
+			services.DeviceOnboardingRepository,
+			c.brokerAuthConfig(),
+			c.loggerFactory,
+		)
+		services.Metrics.Register(brokerauth.MetricsCollector(services.BrokerAuthUseCase))
+	}
+
+	// Build Device Bundle Use Case; imports go through the journaling and replicating repositories
+	services.DeviceBundleUseCase = devicebundle.NewDeviceBundleUseCase(services.DeviceRepository, c.loggerFactory)
+
+	// Build Device Management Use Case; API writes go through the same repositories as imports
+	services.DeviceManagementUseCase = devicemanagement.NewDeviceManagementUseCase(services.DeviceRepository, services.ZoneRepository, c.loggerFactory)
+
+	// Build Farm Zones Use Case; the zones group devices for the API and are independent of the
+	// irrigation zones of the analytics
+	services.FarmZonesUseCase = farmzones.NewFarmZonesUseCase(
+		services.FarmRepository,
+		services.ZoneRepository,
+		services.DeviceRepository,
+		services.MeasurementRepository,
+		c.loggerFactory,
+	)
+
+	// Build Declarative Config Use Case; reconciled creates and updates are journaled and replicated too
+	services.DeclarativeConfigUseCase = declarativeconfig.NewDeclarativeConfigUseCase(services.DeviceRepository, services.DeviceShadowRepository, c.loggerFactory)
+
+	// Build Drift Reconciliation Use Case; declared configurations are pushed again as configure
+	// commands to the devices that report another one
+	if c.config.Drift.Enabled {
+		driftConfig := driftreconciliation.DefaultDriftConfig()
+		driftConfig.Interval = c.config.Drift.Interval
+		driftConfig.PushBackoff = c.config.Drift.PushBackoff
+		driftConfig.AlertAfter = c.config.Drift.AlertAfter
+		services.DriftReconciliationUseCase = driftreconciliation.NewDriftReconciliationUseCase(
+			services.DeviceRepository,
+			services.DeviceShadowRepository,
+			services.DeviceCommandsUseCase,
+			driftConfig,
+			c.loggerFactory,
+		)
+		services.Metrics.Register(driftreconciliation.MetricsCollector(services.DriftReconciliationUseCase))
+	}
+
+	// Irrigation zones group the devices watering the same area for the predictions and the analytics
+	zoneDefinitions, err := c.config.GetIrrigationZones()
+	if err != nil {
+		return err
+	}
+	zones := make([]entities.IrrigationZone, 0, len(zoneDefinitions))
+	for _, definition := range zoneDefinitions {
+		zones = append(zones, entities.IrrigationZone{Name: definition.Name, MACAddresses: definition.MACAddresses, FlowRate: definition.FlowRate})
+	}
+
+	// Build Moisture Prediction Use Case; zones are predicted by the external model when configured,
+	// and by the baseline regression otherwise or when it fails
+	var forecast irrigationscheduling.MoistureForecast
+	if c.config.Prediction.Enabled {
+		var predictor ports.MoisturePredictor
+		if c.config.Prediction.ModelURL != "" {
+			predictor = infrahttp.NewMoistureModelClient(&infrahttp.MoistureModelClientConfig{
+				URL:         c.config.Prediction.ModelURL,
+				BearerToken: c.config.Prediction.ModelToken,
+				Timeout:     c.config.Prediction.ModelTimeout,
+			})
+		}
+		predictionConfig := moistureprediction.DefaultPredictionConfig()
+		predictionConfig.SensorType = c.config.Analytics.MoistureSensor
+		predictionConfig.Threshold = c.config.Prediction.Threshold
+		predictionConfig.Window = c.config.Prediction.Window
+		predictionConfig.Horizon = c.config.Prediction.Horizon
+		predictionConfig.Interval = c.config.Prediction.Interval
+		services.MoisturePredictionUseCase = moistureprediction.NewMoisturePredictionUseCase(
+			services.MeasurementRepository,
+			predictor,
+			zones,
+			predictionConfig,
+			c.loggerFactory,
+		)
+		services.Metrics.Register(moistureprediction.MetricsCollector(services.MoisturePredictionUseCase))
+		forecast = services.MoisturePredictionUseCase
+	}
+
+	// Build Irrigation Scheduling Use Case; schedules open and close valves through valve control, and
+	// devices under manual control are reported in the handover report
+	if c.config.Scheduler.Enabled {
+		location, err := c.config.GetSchedulerLocation()
+		if err != nil {
+			return err
+		}
+		schedulerConfig := irrigationscheduling.DefaultSchedulerConfig()
+		schedulerConfig.Interval = c.config.Scheduler.Interval
+		schedulerConfig.Location = location
+		schedulerConfig.ProactiveLead = c.config.Scheduler.ProactiveLead
+		services.IrrigationSchedulingUseCase = irrigationscheduling.NewIrrigationSchedulingUseCase(
+			services.DeviceRepository,
+			services.IrrigationScheduleRepository,
+			services.IrrigationOverrideRepository,
+			services.ValveControlUseCase,
+			forecast,
+			schedulerConfig,
+			c.loggerFactory,
+		)
+		services.Metrics.Register(irrigationscheduling.MetricsCollector(services.IrrigationSchedulingUseCase))
+		services.OverrideSources = append(services.OverrideSources, services.IrrigationSchedulingUseCase)
+	}
+
+	// Build Irrigation Efficiency Use Case; the irrigations of each zone are rebuilt from the recorded
+	// valve transitions and the soil moisture readings of its devices
+	efficiencyConfig := irrigationefficiency.DefaultEfficiencyConfig()
+	efficiencyConfig.SensorType = c.config.Analytics.MoistureSensor
+	efficiencyConfig.TargetMoisture = c.config.Analytics.TargetMoisture
+	efficiencyConfig.SettleWindow = c.config.Analytics.SettleWindow
+	efficiencyConfig.DeclineThreshold = c.config.Analytics.DeclineThreshold
+	efficiencyConfig.MaxRange = c.config.Analytics.MaxRange
+	efficiencyConfig.DefaultWindow = min(efficiencyConfig.DefaultWindow, efficiencyConfig.MaxRange)
+	services.IrrigationEfficiencyUseCase = irrigationefficiency.NewIrrigationEfficiencyUseCase(
+		services.ValveStateRepository,
+		services.MeasurementRepository,
+		zones,
+		efficiencyConfig,
+	)
+
+	// Build Irrigation Experiments Use Case; the variants of an experiment are compared by the
+	// efficiency of their zones
+	services.IrrigationExperimentsUseCase = irrigationexperiments.NewIrrigationExperimentsUseCase(
+		services.IrrigationExperimentRepository,
+		services.IrrigationEfficiencyUseCase,
+		zones,
+		c.loggerFactory,
+	)
+
+	// Build User Auth Use Case; the HTTP API requires access tokens once a signing key is configured
+	if c.config.Auth.JWTSecret != "" {
+		tokenIssuer, err := security.NewJWTTokenIssuer(security.JWTTokenIssuerConfig{
+			Secret: []byte(c.config.Auth.JWTSecret),
+			Issuer: c.config.Auth.Issuer,
+			TTL:    c.config.Auth.TokenTTL,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create token issuer: %w", err)
+		}
+		services.UserAuthUseCase, err = userauth.NewUserAuthUseCase(services.UserRepository, tokenIssuer, c.loggerFactory)
+		if err != nil {
+			return fmt.Errorf("failed to create user auth use case: %w", err)
+		}
+		c.loggerFactory.Application().LogApplicationEvent("api_authentication_enabled", "container",
+			zap.String("issuer", c.config.Auth.Issuer),
+		)
+	}
+
+	// Build Research Sharing Use Case; partners read the anonymized telemetry of the zones of their token
+	services.ResearchSharingUseCase = researchsharing.NewResearchSharingUseCase(
+		services.ResearchTokenRepository,
+		services.ZoneRepository,
+		services.MeasurementRepository,
+		&researchsharing.SharingConfig{
+			MaxTokenTTL:   c.config.Research.MaxTokenTTL,
+			MaxRange:      c.config.Research.MaxRange,
+			DefaultWindow: researchsharing.DefaultSharingConfig().DefaultWindow,
+		},
+		c.loggerFactory,
+	)
+
+	// Build Device Transfers Use Case; devices move between farms through an audited workflow
+	services.DeviceTransfersUseCase = devicetransfers.NewDeviceTransfersUseCase(
+		services.DeviceTransferRepository,
+		services.DeviceRepository,
+		services.FarmRepository,
+		services.ZoneRepository,
+		services.NATSPublisher,
+		c.loggerFactory,
+	)
+
+	// Build Device Archival Use Case; decommissioned devices keep their measurements apart from the
+	// active tables until they are restored
+	services.DeviceArchivalUseCase = devicearchival.NewDeviceArchivalUseCase(
+		services.DeviceArchiveRepository,
+		services.DeviceRepository,
+		services.DeviceArchiveStore,
+		c.loggerFactory,
+	)
+
+	// Build Handover Use Case; the shift handover report summarizes the state of every feature above
+	services.HandoverUseCase = handover.NewHandoverUseCase(
+		services.AlertingUseCase,
+		services.SensorHealthUseCase,
+		services.DeviceRepository,
+		services.JobRepository,
+		services.OverrideSources,
+		c.loggerFactory,
+	)
+
+	// Build Farm Isolation and Farm Quotas Use Cases; devices registered in a farm namespace cannot
+	// publish in another, and the quotas only count what passed isolation
+	if c.config.MQTT.FarmNamespaces {
+		if err := c.buildFarmQuotas(services); err != nil {
+			return err
+		}
+		services.FarmIsolationUseCase = farmisolation.NewFarmIsolationUseCase(services.DeviceRepository, c.loggerFactory)
+		services.Metrics.Register(farmisolation.MetricsCollector(services.FarmIsolationUseCase))
+		services.DeviceRegistrationUseCase = farmisolation.NewFarmScopedDeviceRegistrationUseCase(services.DeviceRegistrationUseCase, services.FarmIsolationUseCase)
+		services.SensorDataUseCase = farmisolation.NewFarmScopedSensorDataUseCase(services.SensorDataUseCase, services.FarmIsolationUseCase)
+		services.MeasurementUseCase = farmisolation.NewFarmScopedMeasurementUseCase(services.MeasurementUseCase, services.FarmIsolationUseCase)
+		c.loggerFactory.Application().LogApplicationEvent("farm_namespaces_enabled", "container")
+	}
+
+	// Build Blacklist Use Case and load its cache before any message is consumed
+	services.BlacklistUseCase = blacklist.NewBlacklistUseCase(services.BlacklistRepository, c.config.Security.BlacklistRefreshInterval, c.loggerFactory)
+	if err := services.BlacklistUseCase.Refresh(context.Background()); err != nil {
+		return fmt.Errorf("failed to load blacklist: %w", err)
+	}
+
+	// Build Security Monitor Use Case; it wraps the verifiers so rejected spoofing attempts are observed too
+	if c.config.Security.MonitoringEnabled {
+		trustedClients := append([]string{c.config.MQTT.ClientID}, c.config.Security.TrustedCommandClients...)
+		if c.config.MQTT.Embedded.Enabled {
+			trustedClients = append(trustedClients, mqttbroker.InlineClientID)
+		}
+		services.SecurityMonitorUseCase = securitymonitoring.NewSecurityMonitorUseCase(
+			&securitymonitoring.MonitorConfig{
+				RegistrationWindow:    c.config.Security.RegistrationWindow,
+				MaxMACsPerIP:          c.config.Security.MaxMACsPerIP,
+				TrustedCommandClients: trustedClients,
+				TelemetryWindow:       c.config.Security.TelemetryWindow,
+				TelemetryBurstFactor:  c.config.Security.TelemetryBurstFactor,
+				TelemetryBurstMinimum: c.config.Security.TelemetryBurstMinimum,
+				AlertHistory:          c.config.Security.AlertHistory,
+				MaxTracked:            c.config.Security.MaxTracked,
+			},
+			services.NATSPublisher,
+			c.loggerFactory,
+		)
+		services.Metrics.Register(securitymonitoring.MetricsCollector(services.SecurityMonitorUseCase))
+		services.DeviceRegistrationUseCase = securitymonitoring.NewMonitoredDeviceRegistrationUseCase(services.DeviceRegistrationUseCase, services.SecurityMonitorUseCase)
+		services.SensorDataUseCase = securitymonitoring.NewMonitoredSensorDataUseCase(services.SensorDataUseCase, services.SecurityMonitorUseCase)
+	}
+
+	// Build Device Connections Use Case; mismatches feed the clone detection of the security monitor
+	var mismatchObserver deviceconnections.MismatchObserver
+	if services.SecurityMonitorUseCase != nil {
+		mismatchObserver = services.SecurityMonitorUseCase
+	}
+	services.DeviceConnectionsUseCase = deviceconnections.NewDeviceConnectionsUseCase(
+		&deviceconnections.ConnectionsConfig{
+			MismatchWindow: c.config.Security.ClientMismatchWindow,
+			MaxTracked:     c.config.Security.MaxTracked,
+		},
+		mismatchObserver,
+		c.loggerFactory,
+	)
+	services.Metrics.Register(deviceconnections.MetricsCollector(services.DeviceConnectionsUseCase))
+	services.DeviceRegistrationUseCase = deviceconnections.NewTrackedDeviceRegistrationUseCase(services.DeviceRegistrationUseCase, services.DeviceConnectionsUseCase)
+	services.SensorDataUseCase = deviceconnections.NewTrackedSensorDataUseCase(services.SensorDataUseCase, services.DeviceConnectionsUseCase)
+
+	// Build Device Migration Use Case; every message tells whether its device reconnected under the
+	// layout of the running migration
+	services.DeviceMigrationUseCase = devicemigration.NewDeviceMigrationUseCase(
+		services.DeviceMigrationRepository,
+		services.DeviceRepository,
+		services.DeviceCommandsUseCase,
+		services.JobQueueUseCase,
+		&devicemigration.MigrationConfig{
+			StragglerAfter:  c.config.Migration.StragglerAfter,
+			PublishInterval: c.config.Migration.PublishInterval,
+		},
+		c.loggerFactory,
+	)
+	services.DeviceRegistrationUseCase = devicemigration.NewTrackedDeviceRegistrationUseCase(services.DeviceRegistrationUseCase, services.DeviceMigrationUseCase)
+	services.SensorDataUseCase = devicemigration.NewTrackedSensorDataUseCase(services.SensorDataUseCase, services.DeviceMigrationUseCase)
+	services.MeasurementUseCase = devicemigration.NewTrackedMeasurementUseCase(services.MeasurementUseCase, services.DeviceMigrationUseCase)
+
+	// Build Telemetry Compaction Use Case
+	if services.TelemetryArchiveRepository != nil {
+		services.TelemetryCompactionUseCase = telemetrycompaction.NewTelemetryCompactionUseCase(
+			services.TelemetryArchiveRepository,
+			&telemetrycompaction.CompactionConfig{
+				CompressAfter: time.Duration(c.config.Telemetry.CompressAfterDays) * 24 * time.Hour,
+				Interval:      c.config.Telemetry.CompactionInterval,
+				BatchDays:     c.config.Telemetry.CompactionBatchDays,
+			},
+			c.loggerFactory,
+		)
+	}
+
+	// Build Retention Use Case
+	if services.RetentionRepository != nil {
+		services.RetentionUseCase = retention.NewRetentionUseCase(
+			services.RetentionRepository,
+			&retention.RetentionConfig{
+				ReadingsMaxAge: c.config.Retention.ReadingsMaxAge,
+				Interval:       c.config.Retention.Interval,
+				BatchSize:      c.config.Retention.BatchSize,
+			},
+			c.loggerFactory,
+		)
+		services.Metrics.Register(retention.MetricsCollector(services.RetentionUseCase))
+	}
 
 	c.loggerFactory.Application().LogApplicationEvent("use_cases_initialized", "container")
 	return nil
 }
+
+// buildMeasurements builds the sensor type registry from the built-in, configured and derived types
+// brokerAuthConfig returns the service users and the device topics broker hooks are answered with;
+// the server's own MQTT account is a service user
+func (c *Container) brokerAuthConfig() *brokerauth.BrokerAuthConfig {
+	config := brokerauth.DefaultBrokerAuthConfig()
+	config.RequireCertificate = c.config.BrokerAuth.RequireCertificate
+	config.AllowUnknownDevices = c.config.BrokerAuth.AllowUnknownDevices
+	for _, user := range c.config.BrokerAuth.ServiceUsers {
+		username, password, _ := strings.Cut(user, ":")
+		config.ServiceUsers[username] = password
+	}
+	if c.config.MQTT.Username != "" {
+		config.ServiceUsers[c.config.MQTT.Username] = c.config.MQTT.Password
+	}
+
+	sharedACL := brokerauth.TopicACL{
+		Publish: []string{
+			messaginghandlers.DeviceRegistrationTopic,
+			messaginghandlers.SensorDataTopic,
+			messaginghandlers.MeasurementTopic,
+			messaginghandlers.CommandAckTopic,
+			messaginghandlers.CrashReportTopic,
+			messaginghandlers.WifiDiagnosticsTopic,
+		},
+		Subscribe: []string{messaginghandlers.CommandTopicPrefix + brokerauth.MACAddressPlaceholder},
+	}
+	if !c.config.MQTT.FarmNamespaces {
+		config.ACL = sharedACL
+		return config
+	}
+
+	config.ACL = brokerauth.TopicACL{
+		Publish: []string{
+			messaginghandlers.FarmDeviceRegistrationTopic,
+			messaginghandlers.FarmSensorDataTopic,
+			messaginghandlers.FarmMeasurementTopic,
+			messaginghandlers.FarmCommandAckTopic,
+			messaginghandlers.FarmCrashReportTopic,
+			messaginghandlers.FarmWifiDiagnosticsTopic,
+		},
+		Subscribe: []string{messaginghandlers.FarmCommandTopicPrefix + brokerauth.MACAddressPlaceholder},
+	}
+	if c.config.MQTT.LegacyTopics {
+		// Devices that have not migrated to their farm namespace yet keep their shared topics
+		config.ACL.Publish = append(config.ACL.Publish, sharedACL.Publish...)
+		config.ACL.Subscribe = append(config.ACL.Subscribe, sharedACL.Subscribe...)
+	}
+	return config
+}
+
+func (c *Container) buildMeasurements(services *Services) error {
+	definitions, err := c.config.GetSensorTypes()
+	if err != nil {
+		return fmt.Errorf("failed to load sensor types: %w", err)
+	}
+	sensorTypes := make([]*entities.SensorType, 0, len(definitions))
+	for _, definition := range definitions {
+		sensorTypes = append(sensorTypes, &entities.SensorType{
+			Name:     definition.Name,
+			Unit:     definition.Unit,
+			MinValue: definition.MinValue,
+			MaxValue: definition.MaxValue,
+		})
+	}
+
+	derivedDefinitions, err := c.config.GetDerivedSensors()
+	if err != nil {
+		return fmt.Errorf("failed to load derived sensors: %w", err)
+	}
+	derivedSensors := make([]*entities.DerivedSensor, 0, len(derivedDefinitions))
+	for _, definition := range derivedDefinitions {
+		sensorType := &entities.SensorType{
+			Name:     definition.Name,
+			Unit:     definition.Unit,
+			MinValue: definition.MinValue,
+			MaxValue: definition.MaxValue,
+		}
+		derived, err := entities.NewDerivedSensor(sensorType, definition.Expression, definition.Mode)
+		if err != nil {
+			return err
+		}
+		derivedSensors = append(derivedSensors, derived)
+		sensorTypes = append(sensorTypes, sensorType)
+	}
+
+	useCase, err := measurements.NewMeasurementUseCase(services.MeasurementRepository, services.SensorChannelRepository, sensorTypes, c.loggerFactory)
+	if err != nil {
+		return err
+	}
+	if err := useCase.EnsureViews(context.Background()); err != nil {
+		return err
+	}
+	services.MeasurementUseCase = useCase
+
+	// Derived values are stored through the alerting wrapper so rules can watch derived sensors too
+	if services.AlertingUseCase != nil {
+		services.MeasurementUseCase = alerting.NewObservedMeasurementUseCase(services.MeasurementUseCase, services.AlertingUseCase)
+		services.SensorDataUseCase = alerting.NewObservedSensorDataUseCase(services.SensorDataUseCase, services.AlertingUseCase)
+	}
+
+	if len(derivedSensors) > 0 {
+		services.DerivedSensorUseCase = derivedsensors.NewDerivedSensorUseCase(
+			derivedSensors,
+			services.MeasurementUseCase,
+			services.MeasurementRepository,
+			&derivedsensors.DerivedConfig{
+				Interval: c.config.Measurements.DerivedInterval,
+				MaxAge:   c.config.Measurements.DerivedMaxAge,
+			},
+			c.loggerFactory,
+		)
+		services.MeasurementUseCase = derivedsensors.NewDerivingMeasurementUseCase(services.MeasurementUseCase, services.DerivedSensorUseCase)
+		services.SensorDataUseCase = derivedsensors.NewDerivingSensorDataUseCase(services.SensorDataUseCase, services.DerivedSensorUseCase)
+	}
+	return nil
+}
+
+// buildAlerting builds the alerting use case when alert rules or conditions are configured
+// buildFarmQuotas builds the farm quotas and enforces them on the use cases fed by MQTT
+func (c *Container) buildFarmQuotas(services *Services) error {
+	definitions, err := c.config.GetFarmQuotas()
+	if err != nil {
+		return fmt.Errorf("failed to load farm quotas: %w", err)
+	}
+
+	config := &farmquotas.Config{
+		Default: entities.FarmQuota{
+			MaxDevices:           c.config.FarmQuotas.MaxDevices,
+			MaxMessagesPerMinute: c.config.FarmQuotas.MaxMessagesPerMinute,
+		},
+		Configured: make([]*entities.FarmQuota, 0, len(definitions)),
+	}
+	for _, definition := range definitions {
+		config.Configured = append(config.Configured, &entities.FarmQuota{
+			FarmID:               definition.FarmID,
+			MaxDevices:           definition.MaxDevices,
+			MaxMessagesPerMinute: definition.MaxMessagesPerMinute,
+		})
+	}
+
+	services.FarmQuotasUseCase, err = farmquotas.NewFarmQuotasUseCase(config, services.DeviceRepository, c.loggerFactory)
+	if err != nil {
+		return err
+	}
+	services.Metrics.Register(farmquotas.MetricsCollector(services.FarmQuotasUseCase))
+	services.DeviceRegistrationUseCase = farmquotas.NewQuotaDeviceRegistrationUseCase(services.DeviceRegistrationUseCase, services.FarmQuotasUseCase)
+	services.SensorDataUseCase = farmquotas.NewQuotaSensorDataUseCase(services.SensorDataUseCase, services.FarmQuotasUseCase)
+	services.MeasurementUseCase = farmquotas.NewQuotaMeasurementUseCase(services.MeasurementUseCase, services.FarmQuotasUseCase)
+	return nil
+}
+
+// buildEventPolicies builds the publishing policies of outbound events and applies them to the NATS publisher
+func (c *Container) buildEventPolicies(services *Services) error {
+	definitions, err := c.config.GetEventPolicies()
+	if err != nil {
+		return fmt.Errorf("failed to load event policies: %w", err)
+	}
+
+	policies := make([]*entities.EventPolicy, 0, len(definitions))
+	for _, definition := range definitions {
+		policies = append(policies, &entities.EventPolicy{
+			EventType:   definition.EventType,
+			Enabled:     definition.Enabled,
+			SampleRate:  definition.SampleRate,
+			MinInterval: definition.MinInterval,
+		})
+	}
+
+	services.EventPoliciesUseCase, err = eventpolicies.NewEventPoliciesUseCase(policies, c.loggerFactory)
+	if err != nil {
+		return err
+	}
+	services.Metrics.Register(eventpolicies.MetricsCollector(services.EventPoliciesUseCase))
+	if services.NATSPublisher != nil {
+		services.NATSPublisher = eventpolicies.NewPolicyEventPublisher(services.NATSPublisher, services.EventPoliciesUseCase, c.loggerFactory)
+	}
+	return nil
+}
+
+func (c *Container) buildAlerting(services *Services) error {
+	definitions, err := c.config.GetAlertRules()
+	if err != nil {
+		return fmt.Errorf("failed to load alert rules: %w", err)
+	}
+	conditions, err := c.config.GetAlertConditions()
+	if err != nil {
+		return fmt.Errorf("failed to load alert conditions: %w", err)
+	}
+	if len(definitions) == 0 && len(conditions) == 0 {
+		return nil
+	}
+
+	rules := make([]*entities.AlertRule, 0, len(definitions))
+	for _, definition := range definitions {
+		rule, err := entities.NewAlertRule(
+			definition.Name,
+			definition.SensorType,
+			definition.Operator,
+			definition.Threshold,
+			definition.Hysteresis,
+			definition.For,
+			definition.Cooldown,
+			definition.Severity,
+		)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+
+	groups, err := c.config.GetAlertDeviceGroups()
+	if err != nil {
+		return fmt.Errorf("failed to load alert device groups: %w", err)
+	}
+	composites := make([]*entities.CompositeAlertRule, 0, len(conditions))
+	for _, definition := range conditions {
+		rule, err := entities.NewCompositeAlertRule(
+			definition.Name,
+			definition.Condition,
+			groups,
+			definition.For,
+			definition.Cooldown,
+			definition.Severity,
+		)
+		if err != nil {
+			return err
+		}
+		composites = append(composites, rule)
+	}
+
+	services.AlertingUseCase = alerting.NewAlertingUseCase(
+		rules,
+		composites,
+		services.MeasurementRepository,
+		services.DeviceRepository,
+		&alerting.AlertingConfig{
+			AlertHistory: c.config.Alerts.AlertHistory,
+			Interval:     c.config.Alerts.ConditionsInterval,
+			MaxAge:       c.config.Alerts.ConditionsMaxAge,
+		},
+		services.NATSPublisher,
+		services.NotificationDispatcherUseCase,
+		c.loggerFactory,
+	)
+	services.Metrics.Register(alerting.MetricsCollector(services.AlertingUseCase))
+	c.loggerFactory.Application().LogApplicationEvent("alerting_enabled", "container",
+		zap.Int("rules", len(rules)),
+		zap.Int("conditions", len(composites)),
+	)
+	return nil
+}
+
+// buildNotifications builds the notification dispatcher and the in-app inbox when notification users are configured
+func (c *Container) buildNotifications(services *Services) error {
+	definitions, err := c.config.GetNotificationUsers()
+	if err != nil {
+		return fmt.Errorf("failed to load notification users: %w", err)
+	}
+	if len(definitions) == 0 {
+		return nil
+	}
+	location, err := c.config.GetNotificationLocation()
+	if err != nil {
+		return err
+	}
+
+	users := make([]*entities.NotificationPreferences, 0, len(definitions))
+	for _, definition := range definitions {
+		user := &entities.NotificationPreferences{
+			User:          definition.User,
+			Language:      definition.Language,
+			Email:         definition.Email,
+			Phone:         definition.Phone,
+			Digest:        definition.Digest,
+			QuietOverride: definition.QuietOverride,
+			Routes:        definition.Routes,
+		}
+		if user.Language == "" {
+			user.Language = string(c.config.GetDefaultLanguage())
+		}
+		if definition.HasQuietHours {
+			user.QuietHours = &entities.QuietHours{Start: definition.QuietStart, End: definition.QuietEnd}
+		}
+		user.Normalize()
+		if err := user.Validate(); err != nil {
+			return err
+		}
+		users = append(users, user)
+	}
+
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user.User)
+	}
+	inboxConfig := notificationinbox.DefaultInboxConfig()
+	inboxConfig.Retention = c.config.Notifications.InboxRetention
+	services.NotificationInboxUseCase = notificationinbox.NewNotificationInboxUseCase(names, services.NotificationInboxRepository, inboxConfig, c.loggerFactory)
+
+	senders := []ports.NotificationSender{services.NotificationInboxUseCase}
+	if c.config.Notifications.SMTPHost != "" {
+		senders = append(senders, email.NewSMTPSender(&email.SMTPSenderConfig{
+			Host:     c.config.Notifications.SMTPHost,
+			Port:     c.config.Notifications.SMTPPort,
+			Username: c.config.Notifications.SMTPUsername,
+			Password: c.config.Notifications.SMTPPassword,
+			From:     c.config.Notifications.SMTPFrom,
+			Timeout:  c.config.Notifications.Timeout,
+		}))
+	}
+	if c.config.Notifications.SMSGatewayURL != "" {
+		senders = append(senders, infrahttp.NewSMSGatewaySender(&infrahttp.SMSGatewaySenderConfig{
+			URL:         c.config.Notifications.SMSGatewayURL,
+			BearerToken: c.config.Notifications.SMSGatewayToken,
+			Timeout:     c.config.Notifications.Timeout,
+		}))
+	}
+
+	// The inbox costs nothing to deliver; email and SMS are metered per farm
+	if services.UsageMeteringUseCase != nil {
+		for i := 1; i < len(senders); i++ {
+			senders[i] = usagemetering.NewMeteredNotificationSender(senders[i], services.UsageMeteringUseCase)
+		}
+	}
+
+	services.NotificationDispatcherUseCase = notifications.NewNotificationDispatcherUseCase(
+		users,
+		senders,
+		&notifications.DispatcherConfig{
+			Location:      location,
+			DailyDigestAt: c.config.Notifications.DailyDigestAt,
+			Interval:      c.config.Notifications.Interval,
+			QueueSize:     c.config.Notifications.QueueSize,
+			MaxHeldAlerts: c.config.Notifications.MaxHeldAlerts,
+		},
+		c.loggerFactory,
+	)
+	services.Metrics.Register(notifications.MetricsCollector(services.NotificationDispatcherUseCase))
+	c.loggerFactory.Application().LogApplicationEvent("notifications_enabled", "container",
+		zap.Int("users", len(users)),
+		zap.Int("channels", len(senders)),
+		zap.String("time_zone", location.String()),
+	)
+	return nil
+}
+
+// buildRawArchive builds the archive of raw inbound messages in S3 compatible storage
+func (c *Container) buildRawArchive(services *Services) {
+	s3Client := infrahttp.NewS3Client(&infrahttp.S3ClientConfig{
+		Endpoint:             c.config.RawArchive.Endpoint,
+		Region:               c.config.RawArchive.Region,
+		Bucket:               c.config.RawArchive.Bucket,
+		AccessKeyID:          c.config.RawArchive.AccessKeyID,
+		SecretAccessKey:      c.config.RawArchive.SecretAccessKey,
+		ServerSideEncryption: c.config.RawArchive.ServerSideEncryption,
+		KMSKeyID:             c.config.RawArchive.KMSKeyID,
+		Timeout:              c.config.RawArchive.Timeout,
+	})
+
+	archiveConfig := rawarchive.DefaultArchiveConfig()
+	archiveConfig.MaxBundleBytes = c.config.RawArchive.MaxBundleBytes
+	archiveConfig.MaxPendingBundles = c.config.RawArchive.MaxPendingBundles
+	archiveConfig.MaxPendingBytes = c.config.RawArchive.MaxPendingBytes
+	archiveConfig.UploadInterval = c.config.RawArchive.UploadInterval
+	archiveConfig.Retention = time.Duration(c.config.RawArchive.RetentionDays) * 24 * time.Hour
+
+	services.RawMessageArchive = infrahttp.NewRawMessageArchive(s3Client, c.config.RawArchive.Prefix)
+	if c.config.DeviceArchive.ObjectStorage {
+		services.DeviceArchiveStore = infrahttp.NewDeviceArchiveStore(s3Client, c.config.DeviceArchive.Prefix)
+	}
+	services.RawArchiveUseCase = rawarchive.NewRawArchiveUseCase(
+		services.RawMessageArchive,
+		archiveConfig,
+		c.loggerFactory,
+	)
+	services.Metrics.Register(rawarchive.MetricsCollector(services.RawArchiveUseCase))
+	c.loggerFactory.Application().LogApplicationEvent("raw_archive_enabled", "container",
+		zap.String("bucket", c.config.RawArchive.Bucket),
+		zap.String("prefix", c.config.RawArchive.Prefix),
+	)
+}
+
+// buildTelemetrySinks creates a sink for every forwarding destination with a configured URL
+func (c *Container) buildTelemetrySinks() []ports.TelemetrySink {
+	var sinks []ports.TelemetrySink
+	if c.config.Forwarding.InfluxURL != "" {
+		sinks = append(sinks, infrahttp.NewInfluxSink(&infrahttp.InfluxSinkConfig{
+			WriteURL: c.config.Forwarding.InfluxURL,
+			Token:    c.config.Forwarding.InfluxToken,
+			Timeout:  c.config.Forwarding.RequestTimeout,
+		}))
+	}
+	if c.config.Forwarding.RemoteWriteURL != "" {
+		sinks = append(sinks, infrahttp.NewRemoteWriteSink(&infrahttp.RemoteWriteSinkConfig{
+			URL:         c.config.Forwarding.RemoteWriteURL,
+			BearerToken: c.config.Forwarding.RemoteWriteToken,
+			Timeout:     c.config.Forwarding.RequestTimeout,
+		}))
+	}
+	if c.config.Forwarding.MQTTBrokerURL != "" {
+		mqttSink := messagingmqtt.NewTelemetrySink(messagingmqtt.TelemetrySinkConfig{
+			BrokerURL:   c.config.Forwarding.MQTTBrokerURL,
+			ClientID:    c.config.Forwarding.MQTTClientID,
+			Username:    c.config.Forwarding.MQTTUsername,
+			Password:    c.config.Forwarding.MQTTPassword,
+			TopicPrefix: c.config.Forwarding.MQTTTopicPrefix,
+			QoS:         c.config.Forwarding.MQTTQoS,
+		}, c.loggerFactory)
+		c.shutdown.add(phaseClosePublishers, "mqtt_telemetry_sink", func(ctx context.Context) error {
+			return mqttSink.Close()
+		})
+		sinks = append(sinks, mqttSink)
+	}
+
+	for _, sink := range sinks {
+		c.loggerFactory.Application().LogApplicationEvent("telemetry_forwarding_sink_enabled", "container",
+			zap.String("sink", sink.Name()),
+		)
+	}
+	return sinks
+}
+
+// buildPipeline chains the configured ingestion stages in front of the MQTT topic handlers
+func (c *Container) buildPipeline(services *Services) error {
+	stages := make([]pipeline.Stage, 0, len(c.config.Pipeline.Stages))
+	for _, name := range c.config.Pipeline.Stages {
+		switch name {
+		case pipeline.StageDedup:
+			stages = append(stages, pipeline.NewDedupStage(c.config.Pipeline.DedupWindow, c.config.Pipeline.DedupMaxEntries))
+		case pipeline.StageBlacklist:
+			stages = append(stages, pipeline.NewBlacklistStage(c.loggerFactory, services.BlacklistUseCase))
+		case pipeline.StageSchema:
+			stages = append(stages, pipeline.NewSchemaStage(c.loggerFactory, pipeline.DefaultSchemas()))
+		case pipeline.StageRateLimit:
+			stages = append(stages, pipeline.NewRateLimitStage(c.config.Pipeline.RateLimitPerMinute, c.config.Pipeline.RateLimitBurst, c.config.Pipeline.RateLimitMaxDevices))
+		default:
+			return fmt.Errorf("unknown pipeline stage: %s", name)
+		}
+	}
+
+	// The archive runs first so it also keeps the messages the other stages drop
+	if services.RawArchiveUseCase != nil {
+		stages = append([]pipeline.Stage{pipeline.NewArchiveStage(services.RawArchiveUseCase)}, stages...)
+	}
+	// Tracing runs before the archive so traced messages record every stage they reach
+	stages = append([]pipeline.Stage{pipeline.NewTraceStage(services.MessageTracingUseCase)}, stages...)
+
+	services.IngestionPipeline = pipeline.NewPipeline(c.loggerFactory, stages...)
+	services.Metrics.Register(services.IngestionPipeline)
+	c.loggerFactory.Application().LogApplicationEvent("ingestion_pipeline_configured", "container",
+		zap.Strings("stages", services.IngestionPipeline.StageNames()),
+	)
+	return nil
+}
+
+// buildReprocessing builds the replay of the raw archive through the MQTT topic handlers. Replays pass
+// only the blacklist and schema stages: deduplication and rate limiting already applied when the
+// messages were first received, and the archive stage would archive them again.
+func (c *Container) buildReprocessing(services *Services) {
+	stages := make([]pipeline.Stage, 0, len(c.config.Pipeline.Stages))
+	for _, name := range c.config.Pipeline.Stages {
+		switch name {
+		case pipeline.StageBlacklist:
+			stages = append(stages, pipeline.NewBlacklistStage(c.loggerFactory, services.BlacklistUseCase))
+		case pipeline.StageSchema:
+			stages = append(stages, pipeline.NewSchemaStage(c.loggerFactory, pipeline.DefaultSchemas()))
+		}
+	}
+
+	router := messaginghandlers.NewTopicRouter(
+		messaginghandlers.NewDeviceRegistrationHandler(c.loggerFactory, services.DeviceRegistrationUseCase).HandleMessage,
+		messaginghandlers.NewSensorDataHandler(c.loggerFactory, services.SensorDataUseCase, services.IngestionLatencyUseCase).HandleMessage,
+		messaginghandlers.NewMeasurementHandler(c.loggerFactory, services.MeasurementUseCase, services.IngestionLatencyUseCase).HandleMessage,
+	)
+	replayPipeline := pipeline.NewPipeline(c.loggerFactory, stages...)
+
+	services.ReprocessingUseCase = reprocessing.NewReprocessingUseCase(
+		services.RawMessageArchive,
+		eventports.Recovering(services.DeadlinePolicy.Handler(replayPipeline.Handler(router.HandleMessage))),
+		services.JobQueueUseCase,
+		reprocessing.DefaultReprocessingConfig(),
+		c.loggerFactory,
+	)
+}
+
+// buildSyncUseCases builds edge/cloud replication according to the configured sync mode
+func (c *Container) buildSyncUseCases(services *Services) {
+	switch c.config.Sync.Mode {
+	case edgesync.ModeEdge:
+		services.EdgeSyncUseCase = edgesync.NewEdgeSyncUseCase(
+			services.SyncOutboxRepository,
+			services.SyncRemote,
+			&edgesync.EdgeSyncConfig{
+				BatchSize:  c.config.Sync.BatchSize,
+				Interval:   c.config.Sync.Interval,
+				MaxBackoff: c.config.Sync.MaxBackoff,
+			},
+			c.loggerFactory,
+		)
+
+		// Record local writes in the outbox
+		services.DeviceRepository = edgesync.NewSyncingDeviceRepository(services.DeviceRepository, services.EdgeSyncUseCase, c.loggerFactory)
+		services.SensorTemperatureHumidityRepository = edgesync.NewSyncingSensorRepository(services.SensorTemperatureHumidityRepository, services.EdgeSyncUseCase, c.loggerFactory)
+		if services.NATSPublisher != nil {
+			services.NATSPublisher = edgesync.NewSyncingEventPublisher(services.NATSPublisher, services.EdgeSyncUseCase, c.loggerFactory)
+		}
+	case edgesync.ModeCloud:
+		services.SyncApplyUseCase = edgesync.NewSyncApplyUseCase(
+			services.DeviceRepository,
+			services.SensorTemperatureHumidityRepository,
+			c.loggerFactory,
+		)
+	default:
+		return
+	}
+
+	c.loggerFactory.Application().LogApplicationEvent("sync_use_cases_initialized", "container",
+		zap.String("mode", c.config.Sync.Mode),
+	)
+}
+
+// mqttHealthCheck reports the MQTT connection itself, ignoring whether consumption is paused
+func mqttHealthCheck(checkReadiness func(ctx context.Context) (interface{}, error)) ping.DependencyCheck {
+	return ping.DependencyCheck{Name: "mqtt", Check: func(ctx context.Context) error {
+		_, err := checkReadiness(ctx)
+		return err
+	}}
+}
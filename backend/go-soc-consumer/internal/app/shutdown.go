@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// shutdownPhase is one step of the graceful shutdown. Phases run in declaration order so that
+// nothing is closed while an earlier step may still need it.
+type shutdownPhase int
+
+const (
+	// phaseStopIntake stops accepting MQTT, NATS and HTTP traffic and drains in-flight messages and requests
+	phaseStopIntake shutdownPhase = iota
+	// phaseDrainWorkers stops the background workers and waits for them to hand back their work
+	phaseDrainWorkers
+	// phaseFlush pushes buffered data, such as the edge sync outbox, to its destination
+	phaseFlush
+	// phaseClosePublishers closes the connections events and commands are published on
+	phaseClosePublishers
+	// phaseCloseDatabase closes the database once nothing writes to it anymore
+	phaseCloseDatabase
+)
+
+// shutdownPhases lists the phases in execution order
+var shutdownPhases = []shutdownPhase{phaseStopIntake, phaseDrainWorkers, phaseFlush, phaseClosePublishers, phaseCloseDatabase}
+
+func (p shutdownPhase) String() string {
+	switch p {
+	case phaseStopIntake:
+		return "stop_intake"
+	case phaseDrainWorkers:
+		return "drain_workers"
+	case phaseFlush:
+		return "flush"
+	case phaseClosePublishers:
+		return "close_publishers"
+	case phaseCloseDatabase:
+		return "close_database"
+	default:
+		return fmt.Sprintf("phase_%d", int(p))
+	}
+}
+
+// shutdownHook is a named step run during a shutdown phase
+type shutdownHook struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// shutdownSequence runs the registered hooks phase by phase, each phase bounded by its own timeout
+type shutdownSequence struct {
+	timeouts      map[shutdownPhase]time.Duration
+	hooks         map[shutdownPhase][]shutdownHook
+	loggerFactory logger.LoggerFactory
+}
+
+func newShutdownSequence(timeouts map[shutdownPhase]time.Duration, loggerFactory logger.LoggerFactory) *shutdownSequence {
+	return &shutdownSequence{
+		timeouts:      timeouts,
+		hooks:         make(map[shutdownPhase][]shutdownHook),
+		loggerFactory: loggerFactory,
+	}
+}
+
+// add registers a hook; hooks of the same phase run in registration order
+func (s *shutdownSequence) add(phase shutdownPhase, name string, run func(ctx context.Context) error) {
+	s.hooks[phase] = append(s.hooks[phase], shutdownHook{name: name, run: run})
+}
+
+// run executes every phase. A phase that fails or runs out of time is logged and the shutdown
+// moves on, so a stuck consumer cannot keep the database open forever. The caller's deadline is
+// not applied: each phase has its own budget.
+func (s *shutdownSequence) run(ctx context.Context) error {
+	var errs []error
+	for _, phase := range shutdownPhases {
+		hooks := s.hooks[phase]
+		if len(hooks) == 0 {
+			continue
+		}
+
+		start := time.Now()
+		s.loggerFactory.Application().LogApplicationEvent("shutdown_phase_starting", "application",
+			zap.String("phase", phase.String()),
+			zap.Duration("timeout", s.timeouts[phase]),
+			zap.Int("steps", len(hooks)),
+		)
+
+		phaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeouts[phase])
+		for _, hook := range hooks {
+			if err := hook.run(phaseCtx); err != nil {
+				s.loggerFactory.Core().Error("shutdown_step_failed",
+					zap.Error(err),
+					zap.String("phase", phase.String()),
+					zap.String("step", hook.name),
+					zap.String("component", "application"),
+				)
+				errs = append(errs, fmt.Errorf("%s: %s: %w", phase, hook.name, err))
+			}
+		}
+		timedOut := phaseCtx.Err() != nil
+		cancel()
+
+		if timedOut {
+			s.loggerFactory.Core().Warn("shutdown_phase_timeout",
+				zap.String("phase", phase.String()),
+				zap.Duration("timeout", s.timeouts[phase]),
+				zap.String("component", "application"),
+			)
+		}
+		s.loggerFactory.Application().LogShutdownEvent("application", time.Since(start),
+			zap.String("phase", phase.String()),
+			zap.Bool("timed_out", timedOut),
+		)
+	}
+	return errors.Join(errs...)
+}
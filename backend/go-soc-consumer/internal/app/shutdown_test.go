@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func newTestShutdownSequence(t *testing.T, timeout time.Duration) *shutdownSequence {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	timeouts := make(map[shutdownPhase]time.Duration, len(shutdownPhases))
+	for _, phase := range shutdownPhases {
+		timeouts[phase] = timeout
+	}
+	return newShutdownSequence(timeouts, loggerFactory)
+}
+
+func TestShutdownSequence_Run(t *testing.T) {
+	t.Run("should run phases in order whatever the registration order", func(t *testing.T) {
+		sequence := newTestShutdownSequence(t, time.Second)
+		var steps []string
+		step := func(name string) func(context.Context) error {
+			return func(context.Context) error {
+				steps = append(steps, name)
+				return nil
+			}
+		}
+
+		sequence.add(phaseCloseDatabase, "database", step("database"))
+		sequence.add(phaseClosePublishers, "nats_publisher", step("nats_publisher"))
+		sequence.add(phaseStopIntake, "message_consumers", step("message_consumers"))
+		sequence.add(phaseStopIntake, "http_server", step("http_server"))
+		sequence.add(phaseFlush, "edge_sync_outbox", step("edge_sync_outbox"))
+		sequence.add(phaseDrainWorkers, "background_services", step("background_services"))
+
+		require.NoError(t, sequence.run(context.Background()))
+		assert.Equal(t, []string{"message_consumers", "http_server", "background_services", "edge_sync_outbox", "nats_publisher", "database"}, steps)
+	})
+
+	t.Run("should move on when a phase fails or times out", func(t *testing.T) {
+		sequence := newTestShutdownSequence(t, 20*time.Millisecond)
+		closed := false
+
+		sequence.add(phaseStopIntake, "message_consumers", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		sequence.add(phaseFlush, "edge_sync_outbox", func(context.Context) error {
+			return errors.New("cloud unreachable")
+		})
+		sequence.add(phaseCloseDatabase, "database", func(ctx context.Context) error {
+			closed = ctx.Err() == nil
+			return nil
+		})
+
+		err := sequence.run(context.Background())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "flush: edge_sync_outbox: cloud unreachable")
+		assert.True(t, closed, "later phases get their own budget")
+	})
+
+	t.Run("should not inherit the caller's cancellation", func(t *testing.T) {
+		sequence := newTestShutdownSequence(t, time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		flushed := false
+		sequence.add(phaseFlush, "edge_sync_outbox", func(ctx context.Context) error {
+			flushed = ctx.Err() == nil
+			return nil
+		})
+
+		require.NoError(t, sequence.run(ctx))
+		assert.True(t, flushed)
+	})
+}
@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+)
+
+// Alert statuses
+const (
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+// Alert is raised when an alert rule starts firing, and again when it resolves. Alerts of threshold
+// rules are about a stream; alerts of composite rules carry their condition instead.
+type Alert struct {
+	EventID     string
+	EventType   string
+	Rule        string
+	Status      string
+	Severity    string
+	MACAddress  string
+	SensorType  string
+	Channel     int
+	Value       float64 // the value that changed the alert state
+	Threshold   float64
+	Condition   string // the condition of a composite rule
+	Description string
+	// LocalizedDescriptions holds the description in every supported language, keyed by language code
+	LocalizedDescriptions map[string]string
+	Since                 time.Time // when the breach started
+	RaisedAt              time.Time
+}
+
+// NewAlert creates an alert of the rule on a stream with validation
+func NewAlert(rule *AlertRule, status, macAddress string, channel int, value float64, since, raisedAt time.Time, description string) (*Alert, error) {
+	eventID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate event ID: %w", err)
+	}
+
+	alert := &Alert{
+		EventID:     eventID.String(),
+		EventType:   events.AlertEventType,
+		Rule:        rule.Name,
+		Status:      status,
+		Severity:    rule.Severity,
+		MACAddress:  macAddress,
+		SensorType:  rule.SensorType,
+		Channel:     channel,
+		Value:       value,
+		Threshold:   rule.Threshold,
+		Description: description,
+		Since:       since.UTC(),
+		RaisedAt:    raisedAt.UTC(),
+	}
+	if err := alert.Validate(); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+// NewCompositeAlert creates an alert of a composite rule with validation
+func NewCompositeAlert(rule *CompositeAlertRule, status string, since, raisedAt time.Time, description string) (*Alert, error) {
+	eventID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate event ID: %w", err)
+	}
+
+	alert := &Alert{
+		EventID:     eventID.String(),
+		EventType:   events.AlertEventType,
+		Rule:        rule.Name,
+		Status:      status,
+		Severity:    rule.Severity,
+		Condition:   rule.Condition.String(),
+		Description: description,
+		Since:       since.UTC(),
+		RaisedAt:    raisedAt.UTC(),
+	}
+	if err := alert.Validate(); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+// NewWifiAdvisoryAlert creates an alert of a Wi-Fi advisory, carrying the condition of the advisory
+func NewWifiAdvisoryAlert(advisory *WifiAdvisory, status, severity string, raisedAt time.Time, description string) (*Alert, error) {
+	eventID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate event ID: %w", err)
+	}
+
+	alert := &Alert{
+		EventID:     eventID.String(),
+		EventType:   events.AlertEventType,
+		Rule:        "wifi_" + advisory.Kind,
+		Status:      status,
+		Severity:    severity,
+		Condition:   advisory.Condition,
+		Description: description,
+		Since:       advisory.Since.UTC(),
+		RaisedAt:    raisedAt.UTC(),
+	}
+	if err := alert.Validate(); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+// Validate ensures the alert has all required fields
+func (a *Alert) Validate() error {
+	if a.Rule == "" {
+		return fmt.Errorf("alert rule is required")
+	}
+	if a.Status != AlertStatusFiring && a.Status != AlertStatusResolved {
+		return fmt.Errorf("unknown alert status: %q", a.Status)
+	}
+	if a.MACAddress == "" && a.Condition == "" {
+		return fmt.Errorf("alert mac address or condition is required")
+	}
+	return nil
+}
+
+// GetSubject returns the NATS subject for this event type
+func (a *Alert) GetSubject() string {
+	return events.AlertSubject
+}
+
+// SeverityRank orders alerts by severity, higher is more severe
+func (a *Alert) SeverityRank() int {
+	return alertSeverityRanks[a.Severity]
+}
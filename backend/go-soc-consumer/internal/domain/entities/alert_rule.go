@@ -0,0 +1,179 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Alert rule operators
+const (
+	AlertOperatorBelow = "below"
+	AlertOperatorAbove = "above"
+)
+
+// Alert severities
+const (
+	AlertSeverityInfo     = "info"
+	AlertSeverityWarning  = "warning"
+	AlertSeverityCritical = "critical"
+)
+
+// AlertTransition is the outcome of evaluating a rule against a value
+type AlertTransition int
+
+const (
+	AlertTransitionNone     AlertTransition = iota // the alert state did not change
+	AlertTransitionFired                           // the rule started firing
+	AlertTransitionResolved                        // a firing rule recovered
+)
+
+// AlertRule raises an alert when the values of a sensor type cross a threshold, such as
+// "soil moisture below 20% for 15 minutes". It is evaluated on each stream, a channel of a device,
+// separately.
+type AlertRule struct {
+	Name       string
+	SensorType string
+	Operator   string // AlertOperatorBelow or AlertOperatorAbove
+	Threshold  float64
+	// Hysteresis is how far back past the threshold a value must go before a breach clears, so values
+	// hovering around the threshold do not toggle the alert
+	Hysteresis float64
+	// MinDuration is how long the breach must last before the rule fires
+	MinDuration time.Duration
+	// Cooldown is the minimum time between two firings of the rule on the same stream
+	Cooldown time.Duration
+	Severity string
+}
+
+// AlertRuleState is the state of a rule on one stream, updated by Evaluate
+type AlertRuleState struct {
+	Breached      bool
+	BreachedSince time.Time // when the current breach started
+	Firing        bool
+	LastFiredAt   time.Time // zero until the rule first fires
+}
+
+// NewAlertRule creates a rule with validation; an empty severity defaults to warning
+func NewAlertRule(name, sensorType, operator string, threshold, hysteresis float64, minDuration, cooldown time.Duration, severity string) (*AlertRule, error) {
+	rule := &AlertRule{
+		Name:        name,
+		SensorType:  sensorType,
+		Operator:    operator,
+		Threshold:   threshold,
+		Hysteresis:  hysteresis,
+		MinDuration: minDuration,
+		Cooldown:    cooldown,
+		Severity:    severity,
+	}
+	rule.Normalize()
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Normalize trims the name and lowercases the sensor type, operator and severity
+func (r *AlertRule) Normalize() {
+	r.Name = strings.TrimSpace(r.Name)
+	r.SensorType = strings.ToLower(strings.TrimSpace(r.SensorType))
+	r.Operator = strings.ToLower(strings.TrimSpace(r.Operator))
+	r.Severity = strings.ToLower(strings.TrimSpace(r.Severity))
+	if r.Severity == "" {
+		r.Severity = AlertSeverityWarning
+	}
+}
+
+// Validate checks the rule condition and timings
+func (r *AlertRule) Validate() error {
+	if r.Name == "" || len(r.Name) > 64 {
+		return fmt.Errorf("alert rule name must be between 1 and 64 characters")
+	}
+	if !sensorTypeNamePattern.MatchString(r.SensorType) {
+		return fmt.Errorf("alert rule %s: invalid sensor type %q", r.Name, r.SensorType)
+	}
+	if r.Operator != AlertOperatorBelow && r.Operator != AlertOperatorAbove {
+		return fmt.Errorf("alert rule %s: operator must be %s or %s", r.Name, AlertOperatorBelow, AlertOperatorAbove)
+	}
+	if math.IsNaN(r.Threshold) || math.IsInf(r.Threshold, 0) {
+		return fmt.Errorf("alert rule %s: threshold must be a finite number", r.Name)
+	}
+	if r.Hysteresis < 0 || math.IsNaN(r.Hysteresis) || math.IsInf(r.Hysteresis, 0) {
+		return fmt.Errorf("alert rule %s: hysteresis must be a non-negative finite number", r.Name)
+	}
+	if r.MinDuration < 0 || r.Cooldown < 0 {
+		return fmt.Errorf("alert rule %s: duration and cooldown cannot be negative", r.Name)
+	}
+	return validateAlertSeverity(r.Name, r.Severity)
+}
+
+// validateAlertSeverity checks the severity of a rule
+func validateAlertSeverity(rule, severity string) error {
+	switch severity {
+	case AlertSeverityInfo, AlertSeverityWarning, AlertSeverityCritical:
+		return nil
+	}
+	return fmt.Errorf("alert rule %s: unknown severity %q", rule, severity)
+}
+
+// Evaluate updates the state of the rule on a stream with a value observed at the given time.
+// A breach starts when the value crosses the threshold and only clears once the value is back past
+// the hysteresis band, so a value inside the band keeps the breach and its timer going. The rule fires
+// once the breach has lasted MinDuration and Cooldown has elapsed since it last fired; a breach held
+// back by the cooldown fires on the first value after the cooldown ends.
+func (r *AlertRule) Evaluate(state *AlertRuleState, value float64, at time.Time) AlertTransition {
+	return state.advance(r.breaches(value), r.recovered(value), at, r.MinDuration, r.Cooldown)
+}
+
+// String describes the condition of the rule, such as "soil_moisture below 20 for 15m0s"
+func (r *AlertRule) String() string {
+	condition := fmt.Sprintf("%s %s %v", r.SensorType, r.Operator, r.Threshold)
+	if r.MinDuration > 0 {
+		condition += " for " + r.MinDuration.String()
+	}
+	return condition
+}
+
+// advance moves the state forward with whether the condition of a rule is breached or recovered at
+// the given time; a value that is neither keeps the current breach
+func (s *AlertRuleState) advance(breaching, recovered bool, at time.Time, minDuration, cooldown time.Duration) AlertTransition {
+	if !s.Breached {
+		if !breaching {
+			return AlertTransitionNone
+		}
+		s.Breached, s.BreachedSince = true, at
+	} else if recovered {
+		firing := s.Firing
+		s.Breached, s.BreachedSince, s.Firing = false, time.Time{}, false
+		if firing {
+			return AlertTransitionResolved
+		}
+		return AlertTransitionNone
+	}
+
+	if s.Firing || at.Sub(s.BreachedSince) < minDuration {
+		return AlertTransitionNone
+	}
+	if !s.LastFiredAt.IsZero() && at.Sub(s.LastFiredAt) < cooldown {
+		return AlertTransitionNone
+	}
+	s.Firing, s.LastFiredAt = true, at
+	return AlertTransitionFired
+}
+
+// breaches reports whether the value crosses the threshold
+func (r *AlertRule) breaches(value float64) bool {
+	if r.Operator == AlertOperatorBelow {
+		return value < r.Threshold
+	}
+	return value > r.Threshold
+}
+
+// recovered reports whether the value is back past the hysteresis band
+func (r *AlertRule) recovered(value float64) bool {
+	if r.Operator == AlertOperatorBelow {
+		return value >= r.Threshold+r.Hysteresis
+	}
+	return value <= r.Threshold-r.Hysteresis
+}
@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAlertRule(t *testing.T) {
+	t.Run("creates the rule", func(t *testing.T) {
+		rule, err := NewAlertRule(" dry_soil ", "Soil_Moisture", "Below", 20, 5, 15*time.Minute, time.Hour, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "dry_soil", rule.Name)
+		assert.Equal(t, "soil_moisture", rule.SensorType)
+		assert.Equal(t, AlertOperatorBelow, rule.Operator)
+		assert.Equal(t, AlertSeverityWarning, rule.Severity)
+		assert.Equal(t, "soil_moisture below 20 for 15m0s", rule.String())
+	})
+
+	tests := []struct {
+		name       string
+		sensorType string
+		operator   string
+		hysteresis float64
+		cooldown   time.Duration
+		severity   string
+	}{
+		{name: "invalid sensor type", sensorType: "soil moisture", operator: AlertOperatorBelow},
+		{name: "unknown operator", sensorType: "soil_moisture", operator: "equals"},
+		{name: "negative hysteresis", sensorType: "soil_moisture", operator: AlertOperatorBelow, hysteresis: -1},
+		{name: "negative cooldown", sensorType: "soil_moisture", operator: AlertOperatorBelow, cooldown: -time.Minute},
+		{name: "unknown severity", sensorType: "soil_moisture", operator: AlertOperatorBelow, severity: "urgent"},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			_, err := NewAlertRule("dry_soil", tt.sensorType, tt.operator, 20, tt.hysteresis, 0, tt.cooldown, tt.severity)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAlertRule_Evaluate(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// evaluate feeds values one minute apart and returns the transitions
+	evaluate := func(rule *AlertRule, state *AlertRuleState, values ...float64) []AlertTransition {
+		transitions := make([]AlertTransition, 0, len(values))
+		for i, value := range values {
+			transitions = append(transitions, rule.Evaluate(state, value, start.Add(time.Duration(i)*time.Minute)))
+		}
+		return transitions
+	}
+	const (
+		none     = AlertTransitionNone
+		fired    = AlertTransitionFired
+		resolved = AlertTransitionResolved
+	)
+
+	t.Run("fires and resolves on the threshold without hysteresis", func(t *testing.T) {
+		rule, err := NewAlertRule("dry_soil", "soil_moisture", AlertOperatorBelow, 20, 0, 0, 0, "")
+		require.NoError(t, err)
+
+		transitions := evaluate(rule, &AlertRuleState{}, 25, 19, 18, 20, 19)
+
+		assert.Equal(t, []AlertTransition{none, fired, none, resolved, fired}, transitions)
+	})
+
+	t.Run("values inside the hysteresis band keep the alert firing", func(t *testing.T) {
+		rule, err := NewAlertRule("dry_soil", "soil_moisture", AlertOperatorBelow, 20, 5, 0, 0, "")
+		require.NoError(t, err)
+
+		transitions := evaluate(rule, &AlertRuleState{}, 19, 21, 19, 24.9, 25, 19)
+
+		assert.Equal(t, []AlertTransition{fired, none, none, none, resolved, fired}, transitions)
+	})
+
+	t.Run("fires once the breach lasted the minimum duration", func(t *testing.T) {
+		rule, err := NewAlertRule("dry_soil", "soil_moisture", AlertOperatorBelow, 20, 2, 3*time.Minute, 0, "")
+		require.NoError(t, err)
+		state := &AlertRuleState{}
+
+		// The value back inside the band at minute 2 does not restart the breach
+		transitions := evaluate(rule, state, 19, 19, 21, 19, 19)
+
+		assert.Equal(t, []AlertTransition{none, none, none, fired, none}, transitions)
+		assert.Equal(t, start, state.BreachedSince)
+	})
+
+	t.Run("a recovery before the minimum duration restarts the breach", func(t *testing.T) {
+		rule, err := NewAlertRule("dry_soil", "soil_moisture", AlertOperatorBelow, 20, 0, 3*time.Minute, 0, "")
+		require.NoError(t, err)
+
+		transitions := evaluate(rule, &AlertRuleState{}, 19, 19, 20, 19, 19, 19, 19)
+
+		assert.Equal(t, []AlertTransition{none, none, none, none, none, none, fired}, transitions)
+	})
+
+	t.Run("cooldown holds back firing until it elapses", func(t *testing.T) {
+		rule, err := NewAlertRule("heat", "temperature", AlertOperatorAbove, 35, 0, 0, 4*time.Minute, AlertSeverityCritical)
+		require.NoError(t, err)
+
+		transitions := evaluate(rule, &AlertRuleState{}, 36, 30, 36, 36, 36, 30)
+
+		assert.Equal(t, []AlertTransition{fired, resolved, none, none, fired, resolved}, transitions)
+	})
+
+	t.Run("a breach that clears during the cooldown never fires", func(t *testing.T) {
+		rule, err := NewAlertRule("heat", "temperature", AlertOperatorAbove, 35, 0, 0, 10*time.Minute, AlertSeverityCritical)
+		require.NoError(t, err)
+		state := &AlertRuleState{}
+
+		transitions := evaluate(rule, state, 36, 30, 36, 30)
+
+		assert.Equal(t, []AlertTransition{fired, resolved, none, none}, transitions)
+		assert.False(t, state.Breached)
+		assert.Equal(t, start, state.LastFiredAt)
+	})
+}
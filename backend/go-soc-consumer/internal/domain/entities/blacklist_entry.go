@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Blacklist entry kinds
+const (
+	BlacklistKindMAC     = "mac"
+	BlacklistKindIPRange = "ip_range"
+)
+
+var blacklistMACPattern = regexp.MustCompile(`^([0-9A-F]{2}:){5}[0-9A-F]{2}$`)
+
+// BlacklistEntry blocks messages from a decommissioned or compromised device by MAC address or IP range
+type BlacklistEntry struct {
+	ID        string
+	Kind      string
+	Value     string // uppercase MAC address with colons, or an IP range in CIDR notation
+	Reason    string
+	CreatedAt time.Time
+}
+
+// NewBlacklistEntry creates a blacklist entry; a single IP address is stored as a one address range
+func NewBlacklistEntry(kind, value, reason string) (*BlacklistEntry, error) {
+	entry := &BlacklistEntry{
+		ID:        uuid.New().String(),
+		Kind:      strings.TrimSpace(kind),
+		Value:     strings.TrimSpace(value),
+		Reason:    strings.TrimSpace(reason),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	switch entry.Kind {
+	case BlacklistKindMAC:
+		entry.Value = strings.ToUpper(strings.ReplaceAll(entry.Value, "-", ":"))
+	case BlacklistKindIPRange:
+		if ip := net.ParseIP(entry.Value); ip != nil {
+			bits := 128
+			if ip.To4() != nil {
+				bits = 32
+			}
+			entry.Value = fmt.Sprintf("%s/%d", ip.String(), bits)
+		}
+		if _, network, err := net.ParseCIDR(entry.Value); err == nil {
+			entry.Value = network.String()
+		}
+	}
+
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Validate validates the blacklist entry fields
+func (e *BlacklistEntry) Validate() error {
+	switch e.Kind {
+	case BlacklistKindMAC:
+		if !blacklistMACPattern.MatchString(e.Value) {
+			return fmt.Errorf("invalid mac address format: %s", e.Value)
+		}
+	case BlacklistKindIPRange:
+		if _, _, err := net.ParseCIDR(e.Value); err != nil {
+			return fmt.Errorf("invalid ip range: %s", e.Value)
+		}
+	default:
+		return fmt.Errorf("blacklist kind must be %q or %q", BlacklistKindMAC, BlacklistKindIPRange)
+	}
+
+	if len(e.Reason) > 255 {
+		return fmt.Errorf("reason cannot exceed 255 characters")
+	}
+
+	return nil
+}
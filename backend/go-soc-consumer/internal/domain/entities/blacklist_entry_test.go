@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlacklistEntry(t *testing.T) {
+	tests := []struct {
+		name          string
+		kind          string
+		value         string
+		expectedValue string
+		expectError   bool
+	}{
+		{name: "mac address is normalized", kind: BlacklistKindMAC, value: " aa-bb-cc-dd-ee-ff ", expectedValue: "AA:BB:CC:DD:EE:FF"},
+		{name: "ipv4 address becomes a single address range", kind: BlacklistKindIPRange, value: "192.168.1.20", expectedValue: "192.168.1.20/32"},
+		{name: "ipv6 address becomes a single address range", kind: BlacklistKindIPRange, value: "fd00::1", expectedValue: "fd00::1/128"},
+		{name: "cidr range is canonicalized", kind: BlacklistKindIPRange, value: "10.1.2.3/16", expectedValue: "10.1.0.0/16"},
+		{name: "invalid mac address", kind: BlacklistKindMAC, value: "AA:BB:CC", expectError: true},
+		{name: "invalid ip range", kind: BlacklistKindIPRange, value: "10.0.0.0/40", expectError: true},
+		{name: "unknown kind", kind: "hostname", value: "sensor.local", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := NewBlacklistEntry(tt.kind, tt.value, "decommissioned")
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedValue, entry.Value)
+			assert.NotEmpty(t, entry.ID)
+			assert.Equal(t, "decommissioned", entry.Reason)
+		})
+	}
+}
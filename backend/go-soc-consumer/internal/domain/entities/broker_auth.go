@@ -0,0 +1,28 @@
+package entities
+
+// Actions a broker asks the server to authorize
+const (
+	BrokerActionPublish   = "publish"
+	BrokerActionSubscribe = "subscribe"
+)
+
+// BrokerAuthRequest is a connection an external broker asks the server to authenticate
+type BrokerAuthRequest struct {
+	Identity ClientIdentity // the username of a device is its MAC address
+	Password string         // the provisioning token for devices being onboarded
+}
+
+// BrokerACLRequest is a publish or subscribe of a connected client an external broker asks the server to authorize
+type BrokerACLRequest struct {
+	ClientID string
+	Username string
+	Action   string // publish or subscribe
+	Topic    string // topic published on, or filter subscribed to
+}
+
+// BrokerAuthDecision is the answer to a broker authentication or authorization request
+type BrokerAuthDecision struct {
+	Allow     bool
+	Superuser bool   // service accounts may use every topic
+	Reason    string // why the request was allowed or denied, for logs
+}
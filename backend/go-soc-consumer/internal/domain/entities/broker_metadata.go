@@ -0,0 +1,35 @@
+package entities
+
+// Bounds of the broker metadata kept for a registration; longer values are truncated
+const (
+	MaxBrokerTopicLength    = 255
+	MaxBrokerClientIDLength = 255
+)
+
+// BrokerMetadata describes how the broker delivered a registration, to debug devices publishing
+// to the wrong topic or with a misconfigured QoS
+type BrokerMetadata struct {
+	Topic    string // topic the registration was received on
+	QoS      byte
+	Retained bool
+	ClientID string // MQTT client ID of the publisher, empty when the broker does not report it
+}
+
+// NewBrokerMetadata creates the broker metadata of a delivered message, truncating values beyond
+// their bounds so a misbehaving client cannot fail its own registration
+func NewBrokerMetadata(topic string, qos byte, retained bool, clientID string) *BrokerMetadata {
+	return &BrokerMetadata{
+		Topic:    truncate(topic, MaxBrokerTopicLength),
+		QoS:      qos,
+		Retained: retained,
+		ClientID: truncate(clientID, MaxBrokerClientIDLength),
+	}
+}
+
+// truncate shortens the value to at most length bytes
+func truncate(value string, length int) string {
+	if len(value) > length {
+		return value[:length]
+	}
+	return value
+}
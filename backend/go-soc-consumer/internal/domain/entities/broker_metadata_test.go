@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBrokerMetadata(t *testing.T) {
+	metadata := NewBrokerMetadata("/liwaisi/iot/smart-irrigation/device/registration", 1, true, "probe-7")
+	assert.Equal(t, BrokerMetadata{Topic: "/liwaisi/iot/smart-irrigation/device/registration", QoS: 1, Retained: true, ClientID: "probe-7"}, *metadata)
+
+	long := NewBrokerMetadata(strings.Repeat("t", 300), 0, false, strings.Repeat("c", 300))
+	assert.Len(t, long.Topic, MaxBrokerTopicLength)
+	assert.Len(t, long.ClientID, MaxBrokerClientIDLength)
+}
+
+func TestDeviceRegistrationMessage_ToDevice_BrokerMetadata(t *testing.T) {
+	message, err := NewDeviceRegistrationMessage("AA:BB:CC:DD:EE:FF", "Probe", "192.168.1.10", "Field")
+	require.NoError(t, err)
+	message.Broker = NewBrokerMetadata("farms/farm-7/devices/registration", 0, true, "")
+
+	device, err := message.ToDevice()
+	require.NoError(t, err)
+	require.NotNil(t, device.LastRegistration)
+	assert.Equal(t, *message.Broker, *device.LastRegistration)
+
+	message.Broker.QoS = 2
+	assert.Equal(t, byte(0), device.LastRegistration.QoS, "the device keeps its own copy")
+}
@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// Statistics read from the $SYS topics of the MQTT broker
+const (
+	BrokerStatConnectedClients = "connected_clients"
+	BrokerStatDroppedMessages  = "dropped_messages" // messages dropped since the broker started
+	BrokerStatQueuedMessages   = "queued_messages"  // messages stored by the broker for delivery
+	BrokerStatInflightMessages = "inflight_messages"
+	BrokerStatMessagesReceived = "messages_received" // since the broker started
+	BrokerStatMessagesSent     = "messages_sent"     // since the broker started
+	BrokerStatSubscriptions    = "subscriptions"
+)
+
+// BrokerStat is the latest value of a statistic published by the broker
+type BrokerStat struct {
+	Node       string // node of a clustered broker, empty for single node brokers
+	Name       string
+	Value      float64
+	ReceivedAt time.Time
+}
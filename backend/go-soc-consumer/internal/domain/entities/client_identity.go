@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ClientIdentity describes the MQTT client that published a message, as reported by the broker
+type ClientIdentity struct {
+	ClientID               string
+	Username               string
+	CertificateFingerprint string // SHA-256 of the client certificate (DER), lowercase hex without separators
+	RemoteAddress          string // IP address the client connected from, when the broker reports it
+}
+
+// HasCertificate reports whether the client authenticated with a certificate
+func (i *ClientIdentity) HasCertificate() bool {
+	return i != nil && i.CertificateFingerprint != ""
+}
+
+// CertificateFingerprintFromDER returns the SHA-256 fingerprint of a DER encoded certificate
+func CertificateFingerprintFromDER(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeCertificateFingerprint accepts a SHA-256 fingerprint with or without colon separators,
+// as printed by openssl, and returns it as lowercase hex
+func NormalizeCertificateFingerprint(fingerprint string) (string, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+	if len(normalized) != sha256.Size*2 {
+		return "", fmt.Errorf("certificate fingerprint must be a SHA-256 digest")
+	}
+	if _, err := hex.DecodeString(normalized); err != nil {
+		return "", fmt.Errorf("certificate fingerprint must be hex encoded")
+	}
+	return normalized, nil
+}
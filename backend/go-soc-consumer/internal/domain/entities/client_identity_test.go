@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFingerprint = "5d41402abc4b2a76b9719d911017c5925d41402abc4b2a76b9719d911017c592"
+
+func TestNormalizeCertificateFingerprint(t *testing.T) {
+	t.Run("should accept openssl colon format", func(t *testing.T) {
+		var pairs []string
+		for i := 0; i < len(testFingerprint); i += 2 {
+			pairs = append(pairs, strings.ToUpper(testFingerprint[i:i+2]))
+		}
+
+		normalized, err := NormalizeCertificateFingerprint(strings.Join(pairs, ":"))
+		require.NoError(t, err)
+		assert.Equal(t, testFingerprint, normalized)
+	})
+
+	t.Run("should reject digests that are not SHA-256", func(t *testing.T) {
+		_, err := NormalizeCertificateFingerprint("5d41402abc4b2a76b9719d911017c592")
+		assert.Error(t, err)
+
+		_, err = NormalizeCertificateFingerprint(strings.Repeat("zz", 32))
+		assert.Error(t, err)
+	})
+}
+
+func TestDevice_VerifyIdentity(t *testing.T) {
+	device, err := NewDevice("AA:BB:CC:DD:EE:FF", "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+
+	assert.NoError(t, device.VerifyIdentity(nil), "devices without a certificate accept any publisher")
+
+	require.NoError(t, device.SetCertificateFingerprint(strings.ToUpper(testFingerprint)))
+	assert.Equal(t, testFingerprint, device.CertificateFingerprint)
+
+	assert.NoError(t, device.VerifyIdentity(&ClientIdentity{CertificateFingerprint: testFingerprint}))
+	assert.Error(t, device.VerifyIdentity(nil))
+	assert.Error(t, device.VerifyIdentity(&ClientIdentity{ClientID: "spoofer"}))
+	assert.Error(t, device.VerifyIdentity(&ClientIdentity{CertificateFingerprint: strings.Repeat("ab", 32)}))
+
+	require.NoError(t, device.SetCertificateFingerprint(""))
+	assert.NoError(t, device.VerifyIdentity(nil))
+}
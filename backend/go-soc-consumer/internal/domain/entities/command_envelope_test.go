@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandEnvelope_SigningInput(t *testing.T) {
+	envelope := &CommandEnvelope{
+		Version:  CommandEnvelopeVersion,
+		KeyID:    "k1",
+		Target:   "AA:BB:CC:DD:EE:FF",
+		Command:  "reboot",
+		IssuedAt: 1718000000,
+		Nonce:    "abc",
+		Payload:  json.RawMessage(`{"delay_seconds":5}`),
+	}
+
+	assert.Equal(t, "v1\nk1\nAA:BB:CC:DD:EE:FF\nreboot\n1718000000\nabc\n{\"delay_seconds\":5}", string(envelope.SigningInput()))
+
+	envelope.Payload = nil
+	assert.Equal(t, "v1\nk1\nAA:BB:CC:DD:EE:FF\nreboot\n1718000000\nabc\n", string(envelope.SigningInput()))
+}
+
+func TestCommandEnvelope_Validate(t *testing.T) {
+	valid := func() *CommandEnvelope {
+		return &CommandEnvelope{Version: 1, KeyID: "k1", Target: "AA:BB:CC:DD:EE:FF", Command: "reboot", IssuedAt: 1, Nonce: "abc"}
+	}
+
+	assert.NoError(t, valid().Validate())
+
+	unsupported := valid()
+	unsupported.Version = 2
+	assert.Error(t, unsupported.Validate())
+
+	missing := valid()
+	missing.Nonce = ""
+	assert.Error(t, missing.Validate())
+
+	injected := valid()
+	injected.Command = "reboot\nfactory-reset"
+	assert.Error(t, injected.Validate())
+}
@@ -0,0 +1,212 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/expression"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// OnlineSensorType is the pseudo sensor type composite conditions read the connection state of
+// devices from: 1 while a device is online, 0 otherwise
+const OnlineSensorType = "online"
+
+// CompositeAlertRule raises an alert when a condition over the streams of several devices holds, such
+// as "all(soil_moisture@zone_a < 20)" or "all(online@gateway == 0) and any(pump_running@pump == 1)".
+// Every variable of the condition names a device group; a variable without a channel reads channel 0.
+type CompositeAlertRule struct {
+	Name        string
+	Condition   *expression.Expression
+	Groups      map[string][]string // the device groups the condition reads, by name
+	MinDuration time.Duration       // how long the condition must hold before the rule fires
+	Cooldown    time.Duration       // minimum time between two firings of the rule
+	Severity    string
+}
+
+// NewCompositeAlertRule creates a rule over the given device groups with validation; an empty
+// severity defaults to warning
+func NewCompositeAlertRule(name, condition string, groups map[string][]string, minDuration, cooldown time.Duration, severity string) (*CompositeAlertRule, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 64 {
+		return nil, fmt.Errorf("alert rule name must be between 1 and 64 characters")
+	}
+	severity = strings.ToLower(strings.TrimSpace(severity))
+	if severity == "" {
+		severity = AlertSeverityWarning
+	}
+	if err := validateAlertSeverity(name, severity); err != nil {
+		return nil, err
+	}
+	if minDuration < 0 || cooldown < 0 {
+		return nil, fmt.Errorf("alert rule %s: duration and cooldown cannot be negative", name)
+	}
+
+	compiled, err := expression.Parse(condition)
+	if err != nil {
+		return nil, fmt.Errorf("alert rule %s: invalid condition: %w", name, err)
+	}
+	if !compiled.IsCondition() {
+		return nil, fmt.Errorf("alert rule %s: %s is not a condition, use a comparison, and, or, not, all or any", name, compiled)
+	}
+
+	used := make(map[string][]string)
+	for _, variable := range compiled.Variables() {
+		if variable.Group == "" {
+			return nil, fmt.Errorf("alert rule %s: %s must name a device group, such as %s@zone_a", name, variable, variable.Name)
+		}
+		if variable.Channel > MaxSensorChannel {
+			return nil, fmt.Errorf("alert rule %s: channel of %s must be at most %d", name, variable, MaxSensorChannel)
+		}
+		members, ok := groups[variable.Group]
+		if !ok || len(members) == 0 {
+			return nil, fmt.Errorf("alert rule %s: unknown device group %q", name, variable.Group)
+		}
+		if _, done := used[variable.Group]; done {
+			continue
+		}
+		for _, macAddress := range members {
+			macAddress = strings.ToUpper(strings.TrimSpace(macAddress))
+			if err := validation.ValidateMACAddress(macAddress); err != nil {
+				return nil, fmt.Errorf("device group %s: invalid mac address: %w", variable.Group, err)
+			}
+			used[variable.Group] = append(used[variable.Group], macAddress)
+		}
+	}
+
+	return &CompositeAlertRule{
+		Name:        name,
+		Condition:   compiled,
+		Groups:      used,
+		MinDuration: minDuration,
+		Cooldown:    cooldown,
+		Severity:    severity,
+	}, nil
+}
+
+// SensorTypes returns the sensor types the condition reads, without the online pseudo sensor type
+func (r *CompositeAlertRule) SensorTypes() []string {
+	var sensorTypes []string
+	for _, variable := range r.Condition.Variables() {
+		if variable.Name == OnlineSensorType {
+			continue
+		}
+		if len(sensorTypes) == 0 || sensorTypes[len(sensorTypes)-1] != variable.Name {
+			sensorTypes = append(sensorTypes, variable.Name)
+		}
+	}
+	return sensorTypes
+}
+
+// ReadsOnline reports whether the condition reads the connection state of devices
+func (r *CompositeAlertRule) ReadsOnline() bool {
+	for _, variable := range r.Condition.Variables() {
+		if variable.Name == OnlineSensorType {
+			return true
+		}
+	}
+	return false
+}
+
+// MACAddresses returns the devices of the groups the condition reads, sorted
+func (r *CompositeAlertRule) MACAddresses() []string {
+	seen := make(map[string]bool)
+	var macAddresses []string
+	for _, members := range r.Groups {
+		for _, macAddress := range members {
+			if !seen[macAddress] {
+				seen[macAddress] = true
+				macAddresses = append(macAddresses, macAddress)
+			}
+		}
+	}
+	sort.Strings(macAddresses)
+	return macAddresses
+}
+
+// Evaluate updates the state of the rule with the latest values at the given time. The rule fires once
+// the condition has held for MinDuration and Cooldown has elapsed since it last fired, and resolves
+// as soon as the condition no longer holds. A condition that needs a stream without a recent value
+// does not hold; other evaluation errors leave the state unchanged.
+func (r *CompositeAlertRule) Evaluate(state *AlertRuleState, snapshot *StreamSnapshot, at time.Time) (AlertTransition, error) {
+	holds, err := r.Condition.EvaluateCondition(func(variable expression.Variable) ([]float64, bool) {
+		var values []float64
+		for _, macAddress := range r.Groups[variable.Group] {
+			values = append(values, snapshot.values(macAddress, variable)...)
+		}
+		return values, len(values) > 0
+	})
+	if err != nil && !errors.Is(err, expression.ErrMissingVariable) {
+		return AlertTransitionNone, fmt.Errorf("alert rule %s: %w", r.Name, err)
+	}
+	return state.advance(holds, !holds, at, r.MinDuration, r.Cooldown), nil
+}
+
+// StreamSnapshot holds the latest value of device streams and the connection state of devices, as
+// read by composite alert rules
+type StreamSnapshot struct {
+	streams map[string]map[string]map[int]float64 // MAC address to sensor type to channel
+	online  map[string]bool
+}
+
+// NewStreamSnapshot indexes the latest measurements and the given devices
+func NewStreamSnapshot(latest []*Measurement, devices []*Device) *StreamSnapshot {
+	snapshot := &StreamSnapshot{
+		streams: make(map[string]map[string]map[int]float64),
+		online:  make(map[string]bool, len(devices)),
+	}
+	for _, measurement := range latest {
+		byType, ok := snapshot.streams[measurement.MACAddress]
+		if !ok {
+			byType = make(map[string]map[int]float64)
+			snapshot.streams[measurement.MACAddress] = byType
+		}
+		if byType[measurement.SensorType] == nil {
+			byType[measurement.SensorType] = make(map[int]float64)
+		}
+		byType[measurement.SensorType][measurement.Channel] = measurement.Value
+	}
+	for _, device := range devices {
+		snapshot.online[device.MACAddress] = device.IsOnline()
+	}
+	return snapshot
+}
+
+// values returns the values of a variable on one device: the selected channel, channel 0 when none
+// is selected, or every channel sorted for a wildcard
+func (s *StreamSnapshot) values(macAddress string, variable expression.Variable) []float64 {
+	if variable.Name == OnlineSensorType {
+		online, ok := s.online[macAddress]
+		if !ok {
+			return nil
+		}
+		if online {
+			return []float64{1}
+		}
+		return []float64{0}
+	}
+
+	byChannel := s.streams[macAddress][variable.Name]
+	switch variable.Channel {
+	case expression.AllChannels:
+		channels := make([]int, 0, len(byChannel))
+		for channel := range byChannel {
+			channels = append(channels, channel)
+		}
+		sort.Ints(channels)
+		values := make([]float64, 0, len(channels))
+		for _, channel := range channels {
+			values = append(values, byChannel[channel])
+		}
+		return values
+	case expression.CurrentChannel:
+		variable.Channel = 0
+	}
+	if value, ok := byChannel[variable.Channel]; ok {
+		return []float64{value}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var compositeTestGroups = map[string][]string{
+	"zone_a":  {"aa:bb:cc:dd:ee:02", "AA:BB:CC:DD:EE:01"},
+	"gateway": {"AA:BB:CC:DD:EE:10"},
+	"pump":    {"AA:BB:CC:DD:EE:20"},
+}
+
+func TestNewCompositeAlertRule(t *testing.T) {
+	t.Run("creates the rule over the groups it reads", func(t *testing.T) {
+		rule, err := NewCompositeAlertRule(" gateway_down ", "all(online@gateway == 0) and any(pump_running@pump == 1)", compositeTestGroups, time.Minute, 0, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "gateway_down", rule.Name)
+		assert.Equal(t, AlertSeverityWarning, rule.Severity)
+		assert.NotContains(t, rule.Groups, "zone_a")
+		assert.Equal(t, []string{"pump_running"}, rule.SensorTypes())
+		assert.True(t, rule.ReadsOnline())
+		assert.Equal(t, []string{"AA:BB:CC:DD:EE:10", "AA:BB:CC:DD:EE:20"}, rule.MACAddresses())
+	})
+
+	tests := []struct {
+		name      string
+		condition string
+		groups    map[string][]string
+		severity  string
+	}{
+		{name: "an invalid condition", condition: "soil_moisture@zone_a <", groups: compositeTestGroups},
+		{name: "an expression that is not a condition", condition: "avg(soil_moisture@zone_a)", groups: compositeTestGroups},
+		{name: "a variable without a group", condition: "soil_moisture < 20", groups: compositeTestGroups},
+		{name: "an unknown group", condition: "all(soil_moisture@zone_b < 20)", groups: compositeTestGroups},
+		{name: "an invalid mac address", condition: "all(soil_moisture@zone_a < 20)", groups: map[string][]string{"zone_a": {"not-a-mac"}}},
+		{name: "an unknown severity", condition: "all(soil_moisture@zone_a < 20)", groups: compositeTestGroups, severity: "urgent"},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			_, err := NewCompositeAlertRule("dry_zone_a", tt.condition, tt.groups, 0, 0, tt.severity)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompositeAlertRule_Evaluate(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	moisture := func(t *testing.T, values ...float64) *StreamSnapshot {
+		var latest []*Measurement
+		for i, value := range values {
+			measurement, err := NewMeasurement(compositeTestGroups["zone_a"][i], "soil_moisture", 0, value, MeasurementQualityGood, start)
+			require.NoError(t, err)
+			latest = append(latest, measurement)
+		}
+		return NewStreamSnapshot(latest, nil)
+	}
+
+	t.Run("fires once the condition held for the minimum duration", func(t *testing.T) {
+		rule, err := NewCompositeAlertRule("dry_zone_a", "all(soil_moisture@zone_a < 20) and count(soil_moisture@zone_a) == 2", compositeTestGroups, 2*time.Minute, 0, "")
+		require.NoError(t, err)
+		state := &AlertRuleState{}
+
+		var transitions []AlertTransition
+		for i, snapshot := range []*StreamSnapshot{moisture(t, 15, 18), moisture(t, 15, 18), moisture(t, 15, 18), moisture(t, 15, 25)} {
+			transition, err := rule.Evaluate(state, snapshot, start.Add(time.Duration(i)*time.Minute))
+			require.NoError(t, err)
+			transitions = append(transitions, transition)
+		}
+
+		assert.Equal(t, []AlertTransition{AlertTransitionNone, AlertTransitionNone, AlertTransitionFired, AlertTransitionResolved}, transitions)
+	})
+
+	t.Run("a group without recent values does not hold", func(t *testing.T) {
+		rule, err := NewCompositeAlertRule("dry_zone_a", "all(soil_moisture@zone_a < 20)", compositeTestGroups, 0, 0, "")
+		require.NoError(t, err)
+		state := &AlertRuleState{}
+
+		transition, err := rule.Evaluate(state, NewStreamSnapshot(nil, nil), start)
+
+		require.NoError(t, err)
+		assert.Equal(t, AlertTransitionNone, transition)
+		assert.False(t, state.Breached)
+	})
+
+	t.Run("reads the connection state of devices", func(t *testing.T) {
+		rule, err := NewCompositeAlertRule("gateway_down", "all(online@gateway == 0)", compositeTestGroups, 0, 0, "")
+		require.NoError(t, err)
+		gateway, err := NewDevice("AA:BB:CC:DD:EE:10", "Gateway", "192.168.1.10", "Shed")
+		require.NoError(t, err)
+
+		gateway.MarkOnline()
+		transition, err := rule.Evaluate(&AlertRuleState{}, NewStreamSnapshot(nil, []*Device{gateway}), start)
+		require.NoError(t, err)
+		assert.Equal(t, AlertTransitionNone, transition)
+
+		gateway.MarkOffline()
+		transition, err = rule.Evaluate(&AlertRuleState{}, NewStreamSnapshot(nil, []*Device{gateway}), start)
+		require.NoError(t, err)
+		assert.Equal(t, AlertTransitionFired, transition)
+	})
+
+	t.Run("a wildcard channel reads every channel of the group", func(t *testing.T) {
+		rule, err := NewCompositeAlertRule("any_dry_probe", "any(soil_moisture.*@zone_a < 20)", compositeTestGroups, 0, 0, "")
+		require.NoError(t, err)
+		probe, err := NewMeasurement("AA:BB:CC:DD:EE:01", "soil_moisture", 3, 12, MeasurementQualityGood, start)
+		require.NoError(t, err)
+
+		transition, err := rule.Evaluate(&AlertRuleState{}, NewStreamSnapshot([]*Measurement{probe}, nil), start)
+
+		require.NoError(t, err)
+		assert.Equal(t, AlertTransitionFired, transition)
+	})
+}
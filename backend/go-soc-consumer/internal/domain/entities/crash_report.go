@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// Bounds of a crash report
+const (
+	MaxCrashReasonLength    = 128
+	MaxCrashBacktraceFrames = 64
+)
+
+// CrashReport is a crash or diagnostic report sent by a device after it restarted
+type CrashReport struct {
+	ID              string
+	MACAddress      string
+	FirmwareVersion string
+	Reason          string   // exception or reset reason, e.g. "LoadProhibited" or "task_wdt"
+	Backtrace       []string // program counters of the crashed task, innermost first
+	UptimeSeconds   int64    // how long the device ran before crashing
+	Signature       string   // identifies the same crash across reports, see CrashSignatureOf
+	ReportedAt      time.Time
+}
+
+// NewCrashReport creates a validated crash report and computes its signature
+func NewCrashReport(macAddress, firmwareVersion, reason string, backtrace []string, uptimeSeconds int64, reportedAt time.Time) (*CrashReport, error) {
+	report := &CrashReport{
+		ID:              uuid.New().String(),
+		MACAddress:      strings.ToUpper(strings.TrimSpace(macAddress)),
+		FirmwareVersion: strings.TrimSpace(firmwareVersion),
+		Reason:          strings.TrimSpace(reason),
+		Backtrace:       make([]string, 0, len(backtrace)),
+		UptimeSeconds:   uptimeSeconds,
+		ReportedAt:      reportedAt.UTC(),
+	}
+	for _, frame := range backtrace {
+		if frame = strings.ToLower(strings.TrimSpace(frame)); frame != "" {
+			report.Backtrace = append(report.Backtrace, frame)
+		}
+	}
+
+	if err := validation.ValidateMACAddress(report.MACAddress); err != nil {
+		return nil, fmt.Errorf("invalid mac address: %w", err)
+	}
+	if report.FirmwareVersion == "" || len(report.FirmwareVersion) > MaxFirmwareVersionLength {
+		return nil, fmt.Errorf("firmware version is required and must be at most %d characters", MaxFirmwareVersionLength)
+	}
+	if len(report.Reason) > MaxCrashReasonLength {
+		return nil, fmt.Errorf("reason must be at most %d characters", MaxCrashReasonLength)
+	}
+	if report.Reason == "" && len(report.Backtrace) == 0 {
+		return nil, fmt.Errorf("crash report requires a reason or a backtrace")
+	}
+	if len(report.Backtrace) > MaxCrashBacktraceFrames {
+		return nil, fmt.Errorf("backtrace must have at most %d frames", MaxCrashBacktraceFrames)
+	}
+	if report.UptimeSeconds < 0 {
+		return nil, fmt.Errorf("uptime cannot be negative")
+	}
+
+	report.Signature = CrashSignatureOf(report.FirmwareVersion, report.Reason, report.Backtrace)
+	return report, nil
+}
+
+// CrashSignatureOf hashes the firmware version, reason and backtrace of a crash, so the same crash
+// reported by many devices or many times is stored once. The same code path crashing on another
+// firmware version is a different signature, as its program counters differ.
+func CrashSignatureOf(firmwareVersion, reason string, backtrace []string) string {
+	sum := sha256.Sum256([]byte(firmwareVersion + "\n" + reason + "\n" + strings.Join(backtrace, " ")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// CrashSignature is a distinct crash along with how often it occurred in a report window
+type CrashSignature struct {
+	Signature       string
+	FirmwareVersion string
+	Reason          string
+	Backtrace       []string
+	FirstSeen       time.Time // first report of the crash ever
+	LastSeen        time.Time // last report of the crash in the window
+	Occurrences     int
+	Devices         int
+}
+
+// FirmwareCrashStats summarizes the crashes reported by devices running a firmware version in a
+// report window, with its most frequent crash signatures
+type FirmwareCrashStats struct {
+	FirmwareVersion string
+	Crashes         int
+	Devices         int // distinct devices that crashed
+	Signatures      int // distinct crashes
+	LastCrashAt     time.Time
+	TopSignatures   []*CrashSignature
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCrashReport(t *testing.T) {
+	reportedAt := time.Date(2025, 6, 1, 7, 0, 0, 0, time.FixedZone("COT", -5*3600))
+
+	t.Run("normalizes the fields and signs the crash", func(t *testing.T) {
+		report, err := NewCrashReport(" aa:bb:cc:dd:ee:ff ", " 1.4.2 ", "LoadProhibited", []string{"0x400D1234", " ", "0x400d5678"}, 3600, reportedAt)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, report.ID)
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", report.MACAddress)
+		assert.Equal(t, "1.4.2", report.FirmwareVersion)
+		assert.Equal(t, []string{"0x400d1234", "0x400d5678"}, report.Backtrace)
+		assert.Equal(t, time.UTC, report.ReportedAt.Location())
+		assert.Len(t, report.Signature, 32)
+	})
+
+	t.Run("gives the same crash the same signature on every device", func(t *testing.T) {
+		first, err := NewCrashReport("AA:BB:CC:DD:EE:01", "1.4.2", "LoadProhibited", []string{"0x400d1234"}, 10, reportedAt)
+		require.NoError(t, err)
+		second, err := NewCrashReport("AA:BB:CC:DD:EE:02", "1.4.2", "LoadProhibited", []string{"0x400D1234"}, 99, reportedAt.Add(time.Hour))
+		require.NoError(t, err)
+		upgraded, err := NewCrashReport("AA:BB:CC:DD:EE:01", "1.4.3", "LoadProhibited", []string{"0x400d1234"}, 10, reportedAt)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.Signature, second.Signature)
+		assert.NotEqual(t, first.Signature, upgraded.Signature)
+	})
+
+	tests := []struct {
+		name            string
+		macAddress      string
+		firmwareVersion string
+		reason          string
+		backtrace       []string
+		uptime          int64
+	}{
+		{name: "invalid mac address", macAddress: "not-a-mac", firmwareVersion: "1.4.2", reason: "panic"},
+		{name: "missing firmware version", macAddress: "AA:BB:CC:DD:EE:FF", reason: "panic"},
+		{name: "nothing to sign", macAddress: "AA:BB:CC:DD:EE:FF", firmwareVersion: "1.4.2"},
+		{name: "too many frames", macAddress: "AA:BB:CC:DD:EE:FF", firmwareVersion: "1.4.2", backtrace: strings.Split(strings.Repeat("0x1 ", MaxCrashBacktraceFrames+1), " ")},
+		{name: "negative uptime", macAddress: "AA:BB:CC:DD:EE:FF", firmwareVersion: "1.4.2", reason: "panic", uptime: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCrashReport(tt.macAddress, tt.firmwareVersion, tt.reason, tt.backtrace, tt.uptime, reportedAt)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears bounds the search for the next time of an expression, so expressions that never
+// match, such as February 30, end the search
+const cronSearchYears = 5
+
+// cronField is the name and range of the values of a field of a cron expression
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// CronExpression is a parsed five-field cron expression: minute, hour, day of month, month and day
+// of week. Fields take *, values, ranges, lists and steps such as */15, 1-5 or 6,18. Days of the
+// week run from 0 (Sunday) to 6, and 7 is Sunday too. As in cron, when both day fields are
+// restricted a day matching either one matches.
+type CronExpression struct {
+	minutes, hours, days, months, weekdays uint64 // bit i is set when value i matches
+	daysRestricted, weekdaysRestricted     bool
+}
+
+// ParseCronExpression parses a five-field cron expression
+func ParseCronExpression(expression string) (*CronExpression, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression must have %d fields, got %d", len(cronFields), len(fields))
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", cronFields[i].name, field, err)
+		}
+		sets[i] = set
+	}
+
+	weekdays := sets[4]
+	if weekdays&(1<<7) != 0 {
+		weekdays = weekdays&^(1<<7) | 1
+	}
+	return &CronExpression{
+		minutes:            sets[0],
+		hours:              sets[1],
+		days:               sets[2],
+		months:             sets[3],
+		weekdays:           weekdays,
+		daysRestricted:     !strings.HasPrefix(fields[2], "*"),
+		weekdaysRestricted: !strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses the comma-separated parts of a field into the set of values they match
+func parseCronField(field string, bounds cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			parsed, err := strconv.Atoi(stepPart)
+			if err != nil || parsed < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = parsed
+		}
+
+		low, high := bounds.min, bounds.max
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+			value, err := parseCronValue(first, bounds)
+			if err != nil {
+				return 0, err
+			}
+			low, high = value, value
+			if isRange {
+				if high, err = parseCronValue(last, bounds); err != nil {
+					return 0, err
+				}
+				if high < low {
+					return 0, fmt.Errorf("range %q is reversed", rangePart)
+				}
+			} else if hasStep {
+				high = bounds.max
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			set |= 1 << uint(value)
+		}
+	}
+	return set, nil
+}
+
+// parseCronValue parses a single value of a field
+func parseCronValue(value string, bounds cronField) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if parsed < bounds.min || parsed > bounds.max {
+		return 0, fmt.Errorf("value %d is out of range %d-%d", parsed, bounds.min, bounds.max)
+	}
+	return parsed, nil
+}
+
+// Next returns the first minute after the given time that matches the expression, in the location
+// of the given time. It returns the zero time when nothing matches within the next years.
+func (e *CronExpression) Next(after time.Time) time.Time {
+	location := after.Location()
+	t := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, location).Add(time.Minute)
+	limit := after.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		switch {
+		case e.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location)
+		case !e.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+		case e.hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, location)
+		case e.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay reports whether the day of the time matches the day of month and day of week fields
+func (e *CronExpression) matchesDay(t time.Time) bool {
+	day := e.days&(1<<uint(t.Day())) != 0
+	weekday := e.weekdays&(1<<uint(t.Weekday())) != 0
+	if e.daysRestricted && e.weekdaysRestricted {
+		return day || weekday
+	}
+	return day && weekday
+}
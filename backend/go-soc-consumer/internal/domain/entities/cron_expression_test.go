@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronExpression(t *testing.T) {
+	for _, expression := range []string{"* * * * *", "0 6 * * *", "*/15 5-7 * * 1-5", "30 18 1,15 * *", "0 6 * * 7", "5/20 * * 3-9/2 *"} {
+		_, err := ParseCronExpression(expression)
+		assert.NoError(t, err, expression)
+	}
+
+	tests := []struct {
+		expression string
+		wantErr    string
+	}{
+		{expression: "0 6 * *", wantErr: "must have 5 fields"},
+		{expression: "60 6 * * *", wantErr: "invalid minute"},
+		{expression: "0 24 * * *", wantErr: "invalid hour"},
+		{expression: "0 6 0 * *", wantErr: "invalid day of month"},
+		{expression: "0 6 * 13 *", wantErr: "invalid month"},
+		{expression: "0 6 * * 8", wantErr: "invalid day of week"},
+		{expression: "0 7-6 * * *", wantErr: "reversed"},
+		{expression: "*/0 6 * * *", wantErr: "invalid step"},
+		{expression: "a 6 * * *", wantErr: "invalid value"},
+	}
+	for _, tt := range tests {
+		_, err := ParseCronExpression(tt.expression)
+		assert.ErrorContains(t, err, tt.wantErr, tt.expression)
+	}
+}
+
+func TestCronExpression_Next(t *testing.T) {
+	// Wednesday
+	after := time.Date(2025, 6, 4, 6, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{expression: "* * * * *", expected: time.Date(2025, 6, 4, 6, 8, 0, 0, time.UTC)},
+		{expression: "*/15 * * * *", expected: time.Date(2025, 6, 4, 6, 15, 0, 0, time.UTC)},
+		{expression: "0 6 * * *", expected: time.Date(2025, 6, 5, 6, 0, 0, 0, time.UTC)},
+		{expression: "30 5-7 * * *", expected: time.Date(2025, 6, 4, 6, 30, 0, 0, time.UTC)},
+		{expression: "0 6 * * 0", expected: time.Date(2025, 6, 8, 6, 0, 0, 0, time.UTC)},
+		{expression: "0 6 * * 7", expected: time.Date(2025, 6, 8, 6, 0, 0, 0, time.UTC)},
+		{expression: "0 6 1 * *", expected: time.Date(2025, 7, 1, 6, 0, 0, 0, time.UTC)},
+		{expression: "0 6 29 2 *", expected: time.Date(2028, 2, 29, 6, 0, 0, 0, time.UTC)},
+		// Either restricted day field matches: the 10th or any Friday
+		{expression: "0 6 10 * 5", expected: time.Date(2025, 6, 6, 6, 0, 0, 0, time.UTC)},
+		{expression: "0 6 30 2 *", expected: time.Time{}},
+	}
+	for _, tt := range tests {
+		expression, err := ParseCronExpression(tt.expression)
+		require.NoError(t, err, tt.expression)
+		assert.Equal(t, tt.expected, expression.Next(after), tt.expression)
+	}
+
+	bogota := time.FixedZone("COT", -5*3600)
+	expression, err := ParseCronExpression("0 6 * * *")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 4, 11, 0, 0, 0, time.UTC), expression.Next(after.In(bogota)).UTC(), "times are matched in the location of the given time")
+}
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// Actions of the changes that reconcile a declaration
+const (
+	DeclarationCreate = "create"
+	DeclarationUpdate = "update"
+	DeclarationDelete = "delete"
+)
+
+// Declaration is the desired configuration of the devices, kept in infrastructure-as-code tools.
+// Reconciling it creates, updates and deletes devices until the stored configuration matches.
+// With a farm ID only the devices of that farm are reconciled and the declared devices are
+// assigned to it; without one every device is.
+type Declaration struct {
+	FarmID  string
+	Devices []DeviceConfiguration
+}
+
+// DeclarationChange is one change that reconciles a declaration
+type DeclarationChange struct {
+	Action     string
+	MACAddress string
+	Fields     []string // configuration fields an update changes
+}
+
+// DeclarationPlan lists the changes that reconcile a declaration, in the order they are applied
+type DeclarationPlan struct {
+	DryRun    bool
+	Changes   []DeclarationChange
+	Unchanged int // declared devices already matching their declaration
+}
+
+// Validate checks the farm ID and that every device has a valid MAC address, is listed once and
+// does not name another farm than the declaration
+func (d *Declaration) Validate() error {
+	if d.FarmID != "" {
+		if err := ValidateFarmID(d.FarmID); err != nil {
+			return err
+		}
+	}
+
+	seen := make(map[string]bool, len(d.Devices))
+	for i, configuration := range d.Devices {
+		if err := validation.ValidateMACAddress(configuration.MACAddress); err != nil {
+			return fmt.Errorf("device %d: %w", i, err)
+		}
+		macAddress := strings.ToUpper(strings.TrimSpace(configuration.MACAddress))
+		if seen[macAddress] {
+			return fmt.Errorf("device %s is declared more than once", macAddress)
+		}
+		seen[macAddress] = true
+		if d.FarmID != "" && configuration.FarmID != "" && configuration.FarmID != d.FarmID {
+			return fmt.Errorf("device %s is declared in farm %s, not %s", macAddress, configuration.FarmID, d.FarmID)
+		}
+	}
+	return nil
+}
+
+// ChangedConfigurationFields returns the fields whose value differs between two device configurations
+func ChangedConfigurationFields(current, desired DeviceConfiguration) []string {
+	var fields []string
+	if current.DeviceName != desired.DeviceName {
+		fields = append(fields, "device_name")
+	}
+	if current.IPAddress != desired.IPAddress {
+		fields = append(fields, "ip_address")
+	}
+	if current.LocationDescription != desired.LocationDescription {
+		fields = append(fields, "location_description")
+	}
+	if current.FarmID != desired.FarmID {
+		fields = append(fields, "farm_id")
+	}
+	if current.CertificateFingerprint != desired.CertificateFingerprint {
+		fields = append(fields, "certificate_fingerprint")
+	}
+	switch {
+	case current.CalibratedAt == nil && desired.CalibratedAt == nil:
+	case current.CalibratedAt == nil || desired.CalibratedAt == nil || !current.CalibratedAt.Equal(*desired.CalibratedAt):
+		fields = append(fields, "calibrated_at")
+	}
+	return fields
+}
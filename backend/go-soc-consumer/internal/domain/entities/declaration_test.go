@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeclaration_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		declaration Declaration
+		wantErr     string
+	}{
+		{name: "empty", declaration: Declaration{}},
+		{
+			name: "farm scoped",
+			declaration: Declaration{FarmID: "farm-a", Devices: []DeviceConfiguration{
+				{MACAddress: "AA:BB:CC:DD:EE:01"},
+				{MACAddress: "AA:BB:CC:DD:EE:02", FarmID: "farm-a"},
+			}},
+		},
+		{name: "invalid farm", declaration: Declaration{FarmID: "farm a"}, wantErr: "invalid farm id"},
+		{name: "invalid mac", declaration: Declaration{Devices: []DeviceConfiguration{{MACAddress: "nope"}}}, wantErr: "device 0"},
+		{
+			name: "duplicate",
+			declaration: Declaration{Devices: []DeviceConfiguration{
+				{MACAddress: "AA:BB:CC:DD:EE:01"},
+				{MACAddress: "aa:bb:cc:dd:ee:01"},
+			}},
+			wantErr: "declared more than once",
+		},
+		{
+			name:        "other farm",
+			declaration: Declaration{FarmID: "farm-a", Devices: []DeviceConfiguration{{MACAddress: "AA:BB:CC:DD:EE:01", FarmID: "farm-b"}}},
+			wantErr:     "declared in farm farm-b, not farm-a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.declaration.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestChangedConfigurationFields(t *testing.T) {
+	calibratedAt := time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)
+	current := DeviceConfiguration{MACAddress: "AA:BB:CC:DD:EE:01", DeviceName: "Sensor", IPAddress: "10.0.0.1", LocationDescription: "Greenhouse", CalibratedAt: &calibratedAt}
+
+	assert.Empty(t, ChangedConfigurationFields(current, current))
+
+	sameInstant := calibratedAt.In(time.FixedZone("COT", -5*3600))
+	desired := current
+	desired.CalibratedAt = &sameInstant
+	assert.Empty(t, ChangedConfigurationFields(current, desired), "calibration times are compared as instants")
+
+	desired = current
+	desired.DeviceName = "Valve"
+	desired.FarmID = "farm-a"
+	desired.CalibratedAt = nil
+	assert.Equal(t, []string{"device_name", "farm_id", "calibrated_at"}, ChangedConfigurationFields(current, desired))
+}
@@ -0,0 +1,185 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/expression"
+)
+
+// Derived sensor evaluation modes
+const (
+	DerivedSensorModeIngestion = "ingestion" // evaluated over the values of each stored message
+	DerivedSensorModeSchedule  = "schedule"  // evaluated periodically over the latest value of every input
+)
+
+// DerivedSensor is a virtual sensor type whose values are computed from other sensor types of the
+// same device, such as the dew point from temperature and humidity
+type DerivedSensor struct {
+	SensorType *SensorType
+	Expression *expression.Expression
+	Mode       string
+}
+
+// NewDerivedSensor creates a derived sensor of the given type computed by the expression
+func NewDerivedSensor(sensorType *SensorType, source, mode string) (*DerivedSensor, error) {
+	sensorType.Normalize()
+	if err := sensorType.Validate(); err != nil {
+		return nil, err
+	}
+
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode != DerivedSensorModeIngestion && mode != DerivedSensorModeSchedule {
+		return nil, fmt.Errorf("derived sensor %s: mode must be %s or %s", sensorType.Name, DerivedSensorModeIngestion, DerivedSensorModeSchedule)
+	}
+
+	compiled, err := expression.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("derived sensor %s: invalid expression: %w", sensorType.Name, err)
+	}
+	for _, variable := range compiled.Variables() {
+		if variable.Name == sensorType.Name {
+			return nil, fmt.Errorf("derived sensor %s cannot use itself as input", sensorType.Name)
+		}
+		if variable.Group != "" {
+			return nil, fmt.Errorf("derived sensor %s: %s cannot read other devices", sensorType.Name, variable)
+		}
+		if variable.Channel > MaxSensorChannel {
+			return nil, fmt.Errorf("derived sensor %s: channel of %s must be at most %d", sensorType.Name, variable, MaxSensorChannel)
+		}
+	}
+
+	return &DerivedSensor{SensorType: sensorType, Expression: compiled, Mode: mode}, nil
+}
+
+// Inputs returns the names of the sensor types the expression reads
+func (d *DerivedSensor) Inputs() []string {
+	var inputs []string
+	for _, variable := range d.Expression.Variables() {
+		if len(inputs) == 0 || inputs[len(inputs)-1] != variable.Name {
+			inputs = append(inputs, variable.Name)
+		}
+	}
+	return inputs
+}
+
+// Derive computes the derived measurements of every device among the given measurements, stamped at
+// the given time. A bare input is read from the channel being evaluated, so a value is derived for
+// each channel on which the device reported all bare inputs, or on channel 0 when there are none.
+// Missing inputs skip the value silently; values that are not finite or outside the range of the
+// type are skipped and reported in the returned error.
+func (d *DerivedSensor) Derive(measurements []*Measurement, at time.Time) ([]*Measurement, error) {
+	devices := make(map[string]map[string]map[int]*Measurement)
+	var order []string
+	for _, measurement := range measurements {
+		streams, ok := devices[measurement.MACAddress]
+		if !ok {
+			streams = make(map[string]map[int]*Measurement)
+			devices[measurement.MACAddress] = streams
+			order = append(order, measurement.MACAddress)
+		}
+		if streams[measurement.SensorType] == nil {
+			streams[measurement.SensorType] = make(map[int]*Measurement)
+		}
+		streams[measurement.SensorType][measurement.Channel] = measurement
+	}
+
+	var derived []*Measurement
+	var errs []error
+	for _, macAddress := range order {
+		streams := devices[macAddress]
+		for _, channel := range d.channels(streams) {
+			measurement, err := d.deriveChannel(macAddress, streams, channel, at)
+			if err != nil {
+				if !errors.Is(err, expression.ErrMissingVariable) {
+					errs = append(errs, fmt.Errorf("%s channel %d of %s: %w", d.SensorType.Name, channel, macAddress, err))
+				}
+				continue
+			}
+			derived = append(derived, measurement)
+		}
+	}
+	return derived, errors.Join(errs...)
+}
+
+// channels returns the channels on which the device reported every bare input, sorted
+func (d *DerivedSensor) channels(streams map[string]map[int]*Measurement) []int {
+	var bare []string
+	for _, variable := range d.Expression.Variables() {
+		if variable.Channel == expression.CurrentChannel {
+			bare = append(bare, variable.Name)
+		}
+	}
+	if len(bare) == 0 {
+		return []int{0}
+	}
+
+	var channels []int
+	for channel := range streams[bare[0]] {
+		complete := true
+		for _, name := range bare[1:] {
+			if _, ok := streams[name][channel]; !ok {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			channels = append(channels, channel)
+		}
+	}
+	sort.Ints(channels)
+	return channels
+}
+
+// deriveChannel evaluates the expression for one channel of a device. The derived measurement takes
+// the worst quality of its inputs.
+func (d *DerivedSensor) deriveChannel(macAddress string, streams map[string]map[int]*Measurement, channel int, at time.Time) (*Measurement, error) {
+	quality := MeasurementQualityGood
+	use := func(measurement *Measurement) float64 {
+		if measurementQualityRank[measurement.Quality] > measurementQualityRank[quality] {
+			quality = measurement.Quality
+		}
+		return measurement.Value
+	}
+
+	value, err := d.Expression.Evaluate(func(variable expression.Variable) ([]float64, bool) {
+		byChannel := streams[variable.Name]
+		switch variable.Channel {
+		case expression.AllChannels:
+			channels := make([]int, 0, len(byChannel))
+			for inputChannel := range byChannel {
+				channels = append(channels, inputChannel)
+			}
+			sort.Ints(channels)
+			values := make([]float64, 0, len(channels))
+			for _, inputChannel := range channels {
+				values = append(values, use(byChannel[inputChannel]))
+			}
+			return values, len(values) > 0
+		case expression.CurrentChannel:
+			variable.Channel = channel
+		}
+		measurement, ok := byChannel[variable.Channel]
+		if !ok {
+			return nil, false
+		}
+		return []float64{use(measurement)}, true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !d.SensorType.Accepts(value) {
+		return nil, fmt.Errorf("value %v is outside [%v, %v]", value, d.SensorType.MinValue, d.SensorType.MaxValue)
+	}
+	return NewMeasurement(macAddress, d.SensorType.Name, channel, value, quality, at)
+}
+
+// measurementQualityRank orders qualities from best to worst
+var measurementQualityRank = map[string]int{
+	MeasurementQualityGood:    0,
+	MeasurementQualitySuspect: 1,
+	MeasurementQualityBad:     2,
+}
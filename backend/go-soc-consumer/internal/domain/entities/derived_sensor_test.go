@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDerivedTestMeasurement(t *testing.T, macAddress, sensorType string, channel int, value float64, quality string) *Measurement {
+	measurement, err := NewMeasurement(macAddress, sensorType, channel, value, quality, time.Now())
+	require.NoError(t, err)
+	return measurement
+}
+
+func TestNewDerivedSensor(t *testing.T) {
+	t.Run("creates the sensor", func(t *testing.T) {
+		derived, err := NewDerivedSensor(&SensorType{Name: "Dew_Point", Unit: "celsius", MinValue: -60, MaxValue: 60}, "dew_point(temperature, humidity)", "Ingestion")
+
+		require.NoError(t, err)
+		assert.Equal(t, "dew_point", derived.SensorType.Name)
+		assert.Equal(t, DerivedSensorModeIngestion, derived.Mode)
+		assert.Equal(t, []string{"humidity", "temperature"}, derived.Inputs())
+	})
+
+	tests := []struct {
+		name       string
+		sensorType *SensorType
+		expression string
+		mode       string
+	}{
+		{name: "invalid type", sensorType: &SensorType{Name: "dew point", Unit: "celsius", MinValue: -60, MaxValue: 60}, expression: "temperature", mode: DerivedSensorModeIngestion},
+		{name: "unknown mode", sensorType: &SensorType{Name: "dew_point", Unit: "celsius", MinValue: -60, MaxValue: 60}, expression: "temperature", mode: "hourly"},
+		{name: "invalid expression", sensorType: &SensorType{Name: "dew_point", Unit: "celsius", MinValue: -60, MaxValue: 60}, expression: "temperature +", mode: DerivedSensorModeIngestion},
+		{name: "self reference", sensorType: &SensorType{Name: "dew_point", Unit: "celsius", MinValue: -60, MaxValue: 60}, expression: "dew_point + 1", mode: DerivedSensorModeIngestion},
+		{name: "device group", sensorType: &SensorType{Name: "dew_point", Unit: "celsius", MinValue: -60, MaxValue: 60}, expression: "avg(temperature@zone_a)", mode: DerivedSensorModeIngestion},
+		{name: "channel out of range", sensorType: &SensorType{Name: "dew_point", Unit: "celsius", MinValue: -60, MaxValue: 60}, expression: "temperature.64", mode: DerivedSensorModeIngestion},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			_, err := NewDerivedSensor(tt.sensorType, tt.expression, tt.mode)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDerivedSensor_Derive(t *testing.T) {
+	const first, second = "AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("derives per device and channel with the worst input quality", func(t *testing.T) {
+		derived, err := NewDerivedSensor(&SensorType{Name: "spread", Unit: "celsius", MinValue: -100, MaxValue: 100}, "temperature - soil_temperature", DerivedSensorModeIngestion)
+		require.NoError(t, err)
+
+		values, err := derived.Derive([]*Measurement{
+			newDerivedTestMeasurement(t, first, "temperature", 0, 25, ""),
+			newDerivedTestMeasurement(t, first, "soil_temperature", 0, 20, MeasurementQualitySuspect),
+			newDerivedTestMeasurement(t, first, "temperature", 1, 24, ""),
+			newDerivedTestMeasurement(t, first, "soil_temperature", 1, 21, ""),
+			newDerivedTestMeasurement(t, first, "temperature", 2, 23, ""),
+			newDerivedTestMeasurement(t, second, "temperature", 0, 30, ""),
+		}, at)
+
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+		assert.Equal(t, first, values[0].MACAddress)
+		assert.Equal(t, "spread", values[0].SensorType)
+		assert.Equal(t, 0, values[0].Channel)
+		assert.Equal(t, 5.0, values[0].Value)
+		assert.Equal(t, MeasurementQualitySuspect, values[0].Quality)
+		assert.Equal(t, at, values[0].Timestamp)
+		assert.Equal(t, 1, values[1].Channel)
+		assert.Equal(t, MeasurementQualityGood, values[1].Quality)
+	})
+
+	t.Run("aggregates over channels on channel 0", func(t *testing.T) {
+		derived, err := NewDerivedSensor(&SensorType{Name: "zone_moisture", Unit: "percent", MinValue: 0, MaxValue: 100}, "avg(soil_moisture.*)", DerivedSensorModeSchedule)
+		require.NoError(t, err)
+
+		values, err := derived.Derive([]*Measurement{
+			newDerivedTestMeasurement(t, first, "soil_moisture", 1, 30, ""),
+			newDerivedTestMeasurement(t, first, "soil_moisture", 2, 40, MeasurementQualityBad),
+		}, at)
+
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		assert.Equal(t, 0, values[0].Channel)
+		assert.Equal(t, 35.0, values[0].Value)
+		assert.Equal(t, MeasurementQualityBad, values[0].Quality)
+	})
+
+	t.Run("reports values outside the range of the type", func(t *testing.T) {
+		derived, err := NewDerivedSensor(&SensorType{Name: "doubled", Unit: "percent", MinValue: 0, MaxValue: 100}, "humidity * 2", DerivedSensorModeIngestion)
+		require.NoError(t, err)
+
+		values, err := derived.Derive([]*Measurement{
+			newDerivedTestMeasurement(t, first, "humidity", 0, 80, ""),
+			newDerivedTestMeasurement(t, second, "humidity", 0, 40, ""),
+		}, at)
+
+		assert.ErrorContains(t, err, first)
+		require.Len(t, values, 1)
+		assert.Equal(t, second, values[0].MACAddress)
+	})
+}
@@ -1,8 +1,10 @@
 package entities
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"net"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -10,7 +12,12 @@ import (
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
 )
 
-// Device represents an IoT device in the smart irrigation system
+// Device represents an IoT device in the smart irrigation system.
+//
+// A device may be shared between goroutines once it is read from a repository, so use cases do
+// not modify it in place: they derive an updated copy with WithChanges and persist that copy.
+// The setters only lock the device they are called on and are meant for devices still owned by
+// a single goroutine, such as a copy inside WithChanges or a device being built.
 type Device struct {
 	mu                  sync.RWMutex
 	MACAddress          string
@@ -20,6 +27,46 @@ type Device struct {
 	RegisteredAt        time.Time
 	LastSeen            time.Time
 	Status              string // "registered", "online", "offline"
+
+	// CertificateFingerprint is the SHA-256 fingerprint of the client certificate provisioned for the device.
+	// When set, messages for this MAC are only accepted from a client presenting that certificate.
+	CertificateFingerprint string
+
+	// CredentialHash is the hex SHA-256 of the password the device authenticates to the broker with,
+	// the provisioning token it was onboarded with. Empty for devices not onboarded.
+	CredentialHash string
+
+	// FarmID is the farm namespace the device registered under, empty for devices on the shared topics
+	FarmID string
+
+	// CalibratedAt is when the device's sensors were last calibrated, nil when unknown
+	CalibratedAt *time.Time
+
+	// FirmwareVersion and HardwareVersion are reported by the device when it registers, empty
+	// for devices whose firmware does not report them
+	FirmwareVersion string
+	HardwareVersion string
+
+	// LastRegistration is how the broker delivered the device's latest live registration, nil
+	// until one is recorded
+	LastRegistration *BrokerMetadata
+}
+
+// Bounds of the versions reported by devices
+const (
+	MaxFirmwareVersionLength = 32
+	MaxHardwareVersionLength = 32
+)
+
+// farmIDPattern restricts farm IDs to a single MQTT topic level without wildcards
+var farmIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// ValidateFarmID validates a farm ID taken from a topic
+func ValidateFarmID(farmID string) error {
+	if !farmIDPattern.MatchString(farmID) {
+		return fmt.Errorf("invalid farm id: %q", farmID)
+	}
+	return nil
 }
 
 // NewDevice creates a new device with validation and normalization
@@ -42,6 +89,50 @@ func NewDevice(macAddress, deviceName, ipAddress, locationDescription string) (*
 	return device, nil
 }
 
+// Clone returns a deep copy of the device that shares no state with it
+func (d *Device) Clone() *Device {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	clone := &Device{
+		MACAddress:             d.MACAddress,
+		DeviceName:             d.DeviceName,
+		IPAddress:              d.IPAddress,
+		LocationDescription:    d.LocationDescription,
+		RegisteredAt:           d.RegisteredAt,
+		LastSeen:               d.LastSeen,
+		Status:                 d.Status,
+		CertificateFingerprint: d.CertificateFingerprint,
+		CredentialHash:         d.CredentialHash,
+		FarmID:                 d.FarmID,
+		FirmwareVersion:        d.FirmwareVersion,
+		HardwareVersion:        d.HardwareVersion,
+	}
+	if d.CalibratedAt != nil {
+		calibratedAt := *d.CalibratedAt
+		clone.CalibratedAt = &calibratedAt
+	}
+	if d.LastRegistration != nil {
+		lastRegistration := *d.LastRegistration
+		clone.LastRegistration = &lastRegistration
+	}
+	return clone
+}
+
+// WithChanges applies the changes to a copy of the device and returns the copy once it validates.
+// The device itself is left untouched, so a device shared between use cases never exposes a
+// partial update and a failed change needs no rollback. Errors of the changes are returned as is.
+func (d *Device) WithChanges(change func(updated *Device) error) (*Device, error) {
+	updated := d.Clone()
+	if err := change(updated); err != nil {
+		return nil, err
+	}
+	if err := updated.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid device: %w", err)
+	}
+	return updated, nil
+}
+
 // Normalize ensures all fields are properly formatted and trimmed
 func (d *Device) Normalize() {
 	d.mu.Lock()
@@ -51,6 +142,8 @@ func (d *Device) Normalize() {
 	d.DeviceName = strings.TrimSpace(d.DeviceName)
 	d.IPAddress = strings.TrimSpace(d.IPAddress)
 	d.LocationDescription = strings.TrimSpace(d.LocationDescription)
+	d.FirmwareVersion = strings.TrimSpace(d.FirmwareVersion)
+	d.HardwareVersion = strings.TrimSpace(d.HardwareVersion)
 }
 
 // Validate validates the device fields
@@ -75,6 +168,10 @@ func (d *Device) Validate() error {
 		return err
 	}
 
+	if err := d.validateVersions(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -134,19 +231,35 @@ func (d *Device) validateLocationDescription() error {
 
 // validateStatus validates the device status
 func (d *Device) validateStatus() error {
+	return ValidateDeviceStatus(d.Status)
+}
+
+// ValidateDeviceStatus checks that the status is registered, online or offline
+func ValidateDeviceStatus(status string) error {
 	validStatuses := map[string]bool{
 		"registered": true,
 		"online":     true,
 		"offline":    true,
 	}
 
-	if !validStatuses[d.Status] {
-		return fmt.Errorf("invalid status: %s. Valid statuses: registered, online, offline", d.Status)
+	if !validStatuses[status] {
+		return fmt.Errorf("invalid status: %s. Valid statuses: registered, online, offline", status)
 	}
 
 	return nil
 }
 
+// validateVersions validates the firmware and hardware versions, which are optional
+func (d *Device) validateVersions() error {
+	if len(d.FirmwareVersion) > MaxFirmwareVersionLength {
+		return fmt.Errorf("firmware version cannot exceed %d characters", MaxFirmwareVersionLength)
+	}
+	if len(d.HardwareVersion) > MaxHardwareVersionLength {
+		return fmt.Errorf("hardware version cannot exceed %d characters", MaxHardwareVersionLength)
+	}
+	return nil
+}
+
 // UpdateStatus updates the device status and last seen timestamp
 func (d *Device) UpdateStatus(status string) error {
 	d.mu.Lock()
@@ -254,3 +367,104 @@ func (d *Device) GetLastSeen() time.Time {
 	defer d.mu.RUnlock()
 	return d.LastSeen
 }
+
+// SetCertificateFingerprint provisions the certificate fingerprint; an empty value removes it
+func (d *Device) SetCertificateFingerprint(fingerprint string) error {
+	if strings.TrimSpace(fingerprint) == "" {
+		d.mu.Lock()
+		d.CertificateFingerprint = ""
+		d.mu.Unlock()
+		return nil
+	}
+
+	normalized, err := NormalizeCertificateFingerprint(fingerprint)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.CertificateFingerprint = normalized
+	return nil
+}
+
+// VerifyIdentity checks that a message for this device was published by the provisioned certificate.
+// Devices without a provisioned certificate accept any identity.
+func (d *Device) VerifyIdentity(identity *ClientIdentity) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.CertificateFingerprint == "" {
+		return nil
+	}
+	if !identity.HasCertificate() {
+		return fmt.Errorf("device %s requires a client certificate", d.MACAddress)
+	}
+	if identity.CertificateFingerprint != d.CertificateFingerprint {
+		return fmt.Errorf("device %s presented an unexpected client certificate", d.MACAddress)
+	}
+	return nil
+}
+
+// VerifyCredential reports whether the password is the broker credential of the device
+func (d *Device) VerifyCredential(password string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if password == "" || d.CredentialHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashProvisioningToken(password)), []byte(d.CredentialHash)) == 1
+}
+
+// AssignFarm binds the device to a farm. Devices already bound to another farm are rejected.
+func (d *Device) AssignFarm(farmID string) error {
+	if err := ValidateFarmID(farmID); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.FarmID != "" && d.FarmID != farmID {
+		return fmt.Errorf("device %s belongs to farm %s, not %s", d.MACAddress, d.FarmID, farmID)
+	}
+	d.FarmID = farmID
+	return nil
+}
+
+// TransferFarm rebinds the device to the farm it was transferred to, whichever farm it was bound to
+func (d *Device) TransferFarm(farmID string) error {
+	if err := ValidateFarmID(farmID); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.FarmID = farmID
+	return nil
+}
+
+// VerifyFarm checks that a message published in a farm namespace belongs to the device's farm.
+// Messages outside a farm namespace and devices not bound to a farm are accepted.
+func (d *Device) VerifyFarm(farmID string) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if farmID == "" || d.FarmID == "" || d.FarmID == farmID {
+		return nil
+	}
+	return fmt.Errorf("device %s belongs to farm %s, not %s", d.MACAddress, d.FarmID, farmID)
+}
+
+// RecordCalibration sets when the device's sensors were last calibrated
+func (d *Device) RecordCalibration(calibratedAt time.Time) error {
+	if calibratedAt.After(time.Now()) {
+		return fmt.Errorf("calibration time cannot be in the future")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	calibratedAt = calibratedAt.UTC()
+	d.CalibratedAt = &calibratedAt
+	return nil
+}
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of a device archive: the data of a decommissioned device is archived until it is restored
+const (
+	DeviceArchiveArchived = "archived"
+	DeviceArchiveRestored = "restored"
+)
+
+// Where the archived readings of a device are kept
+const (
+	ArchiveStorageDatabase = "database"       // the archive tables, apart from the active tables
+	ArchiveStorageObject   = "object_storage" // one compressed object per archive in object storage
+)
+
+// maxArchiveReasonLen bounds the reason a device was decommissioned for
+const maxArchiveReasonLen = 500
+
+// DeviceArchive records the decommissioning of a device. Its readings are moved out of the active
+// tables instead of being deleted, so queries on active devices do not scan them, and the device
+// can be restored with its readings.
+type DeviceArchive struct {
+	ID           string
+	MACAddress   string
+	DeviceName   string
+	FarmID       string
+	Reason       string
+	Actor        string
+	Status       string
+	Storage      string
+	ObjectKey    string // key of the archive in object storage, empty while kept in the database
+	Measurements int    // readings moved out of the measurements table
+	Readings     int    // temperature and humidity readings moved, raw and compacted
+	ArchivedAt   time.Time
+	RestoredAt   *time.Time
+	RestoredBy   string
+}
+
+// NewDeviceArchive creates the archive of a device being decommissioned; its readings are first
+// kept in the archive tables of the database
+func NewDeviceArchive(device *Device, reason, actor string, now time.Time) (*DeviceArchive, error) {
+	reason = strings.TrimSpace(reason)
+	if len(reason) > maxArchiveReasonLen {
+		return nil, fmt.Errorf("reason cannot exceed %d characters", maxArchiveReasonLen)
+	}
+
+	device.mu.RLock()
+	defer device.mu.RUnlock()
+	return &DeviceArchive{
+		ID:         uuid.New().String(),
+		MACAddress: device.MACAddress,
+		DeviceName: device.DeviceName,
+		FarmID:     device.FarmID,
+		Reason:     reason,
+		Actor:      archiveActor(actor),
+		Status:     DeviceArchiveArchived,
+		Storage:    ArchiveStorageDatabase,
+		ArchivedAt: now.UTC(),
+	}, nil
+}
+
+// Offloaded records the readings having moved to the object with the key
+func (a *DeviceArchive) Offloaded(objectKey string) {
+	a.Storage = ArchiveStorageObject
+	a.ObjectKey = objectKey
+}
+
+// Restore records the device being put back into service with its readings
+func (a *DeviceArchive) Restore(actor string, at time.Time) error {
+	if a.Status != DeviceArchiveArchived {
+		return fmt.Errorf("archive is already %s", a.Status)
+	}
+	restoredAt := at.UTC()
+	a.Status = DeviceArchiveRestored
+	a.RestoredAt = &restoredAt
+	a.RestoredBy = archiveActor(actor)
+	return nil
+}
+
+func archiveActor(actor string) string {
+	if actor = strings.TrimSpace(actor); actor == "" {
+		return "unknown"
+	}
+	return actor
+}
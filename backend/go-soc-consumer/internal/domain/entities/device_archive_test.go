@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeviceArchive(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	device := &Device{MACAddress: "AA:BB:CC:DD:EE:FF", DeviceName: "Sensor invernadero", FarmID: "finca-norte"}
+
+	t.Run("should archive in the database", func(t *testing.T) {
+		archive, err := NewDeviceArchive(device, " water damage ", "", now)
+		require.NoError(t, err)
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", archive.MACAddress)
+		assert.Equal(t, "Sensor invernadero", archive.DeviceName)
+		assert.Equal(t, "finca-norte", archive.FarmID)
+		assert.Equal(t, "water damage", archive.Reason)
+		assert.Equal(t, "unknown", archive.Actor)
+		assert.Equal(t, DeviceArchiveArchived, archive.Status)
+		assert.Equal(t, ArchiveStorageDatabase, archive.Storage)
+	})
+
+	t.Run("should bound the reason", func(t *testing.T) {
+		_, err := NewDeviceArchive(device, strings.Repeat("x", 501), "maria", now)
+		assert.ErrorContains(t, err, "reason cannot exceed")
+	})
+}
+
+func TestDeviceArchive_Restore(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	archive := &DeviceArchive{ID: "archive-1", Status: DeviceArchiveArchived, Storage: ArchiveStorageDatabase}
+
+	archive.Offloaded("devices/AA-BB-CC-DD-EE-FF/archive-1.jsonl.gz")
+	assert.Equal(t, ArchiveStorageObject, archive.Storage)
+
+	require.NoError(t, archive.Restore("pedro", now))
+	assert.Equal(t, DeviceArchiveRestored, archive.Status)
+	assert.Equal(t, "pedro", archive.RestoredBy)
+	assert.Equal(t, now, *archive.RestoredAt)
+
+	assert.ErrorContains(t, archive.Restore("pedro", now), "already restored")
+}
@@ -0,0 +1,176 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// DeviceBundleVersion is the version of the device bundle format written by exports.
+// Imports reject bundles of any other version.
+const DeviceBundleVersion = 1
+
+// Conflict strategies decide what an import does with devices that already exist
+const (
+	BundleConflictSkip      = "skip"      // keep the existing device untouched
+	BundleConflictOverwrite = "overwrite" // replace the device configuration with the bundle's
+	BundleConflictMerge     = "merge"     // take the bundle's non-empty fields and keep the others
+)
+
+// DeviceBundle is a portable copy of the device configuration, used to clone an environment or
+// restore it after a disaster. Telemetry and runtime state such as status and last seen are not included.
+type DeviceBundle struct {
+	Version    int
+	ExportedAt time.Time
+	Devices    []DeviceConfiguration
+}
+
+// DeviceConfiguration is the configuration of one device in a bundle
+type DeviceConfiguration struct {
+	MACAddress             string
+	DeviceName             string
+	IPAddress              string
+	LocationDescription    string
+	FarmID                 string
+	CertificateFingerprint string
+	CalibratedAt           *time.Time
+}
+
+// DeviceBundleImportResult lists the MAC addresses an import created, updated and skipped
+type DeviceBundleImportResult struct {
+	Created []string
+	Updated []string
+	Skipped []string
+}
+
+// NewDeviceBundle creates a bundle with the configuration of the given devices
+func NewDeviceBundle(devices []*Device, exportedAt time.Time) *DeviceBundle {
+	bundle := &DeviceBundle{
+		Version:    DeviceBundleVersion,
+		ExportedAt: exportedAt.UTC(),
+		Devices:    make([]DeviceConfiguration, 0, len(devices)),
+	}
+	for _, device := range devices {
+		bundle.Devices = append(bundle.Devices, device.Configuration())
+	}
+	return bundle
+}
+
+// ValidateBundleConflictStrategy checks that the strategy is skip, overwrite or merge
+func ValidateBundleConflictStrategy(strategy string) error {
+	switch strategy {
+	case BundleConflictSkip, BundleConflictOverwrite, BundleConflictMerge:
+		return nil
+	default:
+		return fmt.Errorf("conflict strategy must be %s, %s or %s", BundleConflictSkip, BundleConflictOverwrite, BundleConflictMerge)
+	}
+}
+
+// Validate checks the bundle version and that every device has a valid MAC address and is listed once.
+// Whether the other fields are complete depends on the device existing, so they are checked on import.
+func (b *DeviceBundle) Validate() error {
+	if b.Version != DeviceBundleVersion {
+		return fmt.Errorf("unsupported bundle version %d, expected %d", b.Version, DeviceBundleVersion)
+	}
+
+	seen := make(map[string]bool, len(b.Devices))
+	for i, configuration := range b.Devices {
+		if err := validation.ValidateMACAddress(configuration.MACAddress); err != nil {
+			return fmt.Errorf("device %d: %w", i, err)
+		}
+		macAddress := strings.ToUpper(strings.TrimSpace(configuration.MACAddress))
+		if seen[macAddress] {
+			return fmt.Errorf("device %s is listed more than once", macAddress)
+		}
+		seen[macAddress] = true
+	}
+	return nil
+}
+
+// NewDevice creates a registered device with this configuration
+func (c DeviceConfiguration) NewDevice() (*Device, error) {
+	device, err := NewDevice(c.MACAddress, c.DeviceName, c.IPAddress, c.LocationDescription)
+	if err != nil {
+		return nil, err
+	}
+	if err := device.applyExtendedConfiguration(c, false); err != nil {
+		return nil, fmt.Errorf("invalid device: %w", err)
+	}
+	return device, nil
+}
+
+// Configuration returns the portable configuration of the device
+func (d *Device) Configuration() DeviceConfiguration {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	configuration := DeviceConfiguration{
+		MACAddress:             d.MACAddress,
+		DeviceName:             d.DeviceName,
+		IPAddress:              d.IPAddress,
+		LocationDescription:    d.LocationDescription,
+		FarmID:                 d.FarmID,
+		CertificateFingerprint: d.CertificateFingerprint,
+	}
+	if d.CalibratedAt != nil {
+		calibratedAt := *d.CalibratedAt
+		configuration.CalibratedAt = &calibratedAt
+	}
+	return configuration
+}
+
+// ApplyConfiguration updates the device with the bundle configuration. When merging, empty fields
+// of the configuration keep the device's current values; otherwise they clear them.
+// Status, registration time and last seen are left as they are.
+func (d *Device) ApplyConfiguration(configuration DeviceConfiguration, merge bool) error {
+	d.mu.Lock()
+	if !merge || strings.TrimSpace(configuration.DeviceName) != "" {
+		d.DeviceName = strings.TrimSpace(configuration.DeviceName)
+	}
+	if !merge || strings.TrimSpace(configuration.IPAddress) != "" {
+		d.IPAddress = strings.TrimSpace(configuration.IPAddress)
+	}
+	if !merge || strings.TrimSpace(configuration.LocationDescription) != "" {
+		d.LocationDescription = strings.TrimSpace(configuration.LocationDescription)
+	}
+	d.mu.Unlock()
+
+	if err := d.applyExtendedConfiguration(configuration, merge); err != nil {
+		return err
+	}
+	return d.Validate()
+}
+
+// applyExtendedConfiguration applies the farm, certificate and calibration settings
+func (d *Device) applyExtendedConfiguration(configuration DeviceConfiguration, merge bool) error {
+	if configuration.FarmID != "" {
+		if err := ValidateFarmID(configuration.FarmID); err != nil {
+			return err
+		}
+	}
+	if !merge || configuration.FarmID != "" {
+		d.mu.Lock()
+		d.FarmID = configuration.FarmID
+		d.mu.Unlock()
+	}
+
+	if !merge || configuration.CertificateFingerprint != "" {
+		if err := d.SetCertificateFingerprint(configuration.CertificateFingerprint); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case configuration.CalibratedAt != nil:
+		if err := d.RecordCalibration(*configuration.CalibratedAt); err != nil {
+			return err
+		}
+	case !merge:
+		d.mu.Lock()
+		d.CalibratedAt = nil
+		d.mu.Unlock()
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceBundle_RoundTrip(t *testing.T) {
+	device, err := NewDevice("AA:BB:CC:DD:EE:01", "Greenhouse sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	require.NoError(t, device.AssignFarm("farm-1"))
+	require.NoError(t, device.RecordCalibration(time.Now().Add(-time.Hour)))
+
+	bundle := NewDeviceBundle([]*Device{device}, time.Now())
+	require.NoError(t, bundle.Validate())
+	assert.Equal(t, DeviceBundleVersion, bundle.Version)
+
+	restored, err := bundle.Devices[0].NewDevice()
+	require.NoError(t, err)
+	assert.Equal(t, device.Configuration(), restored.Configuration())
+}
+
+func TestDeviceBundle_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		bundle  DeviceBundle
+		wantErr bool
+	}{
+		{
+			name:   "partial configurations are accepted",
+			bundle: DeviceBundle{Version: DeviceBundleVersion, Devices: []DeviceConfiguration{{MACAddress: "AA:BB:CC:DD:EE:01"}}},
+		},
+		{
+			name:    "unsupported version",
+			bundle:  DeviceBundle{Version: DeviceBundleVersion + 1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid MAC address",
+			bundle:  DeviceBundle{Version: DeviceBundleVersion, Devices: []DeviceConfiguration{{MACAddress: "not-a-mac"}}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate device",
+			bundle: DeviceBundle{Version: DeviceBundleVersion, Devices: []DeviceConfiguration{
+				{MACAddress: "AA:BB:CC:DD:EE:01"},
+				{MACAddress: "aa:bb:cc:dd:ee:01"},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bundle.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDevice_ApplyConfiguration(t *testing.T) {
+	newDevice := func(t *testing.T) *Device {
+		device, err := NewDevice("AA:BB:CC:DD:EE:01", "Greenhouse sensor", "192.168.1.10", "Greenhouse")
+		require.NoError(t, err)
+		require.NoError(t, device.AssignFarm("farm-1"))
+		device.MarkOnline()
+		return device
+	}
+	configuration := DeviceConfiguration{MACAddress: "AA:BB:CC:DD:EE:01", DeviceName: "Renamed", IPAddress: "192.168.1.20", LocationDescription: "Nursery"}
+
+	t.Run("merge keeps the fields the configuration leaves empty", func(t *testing.T) {
+		device := newDevice(t)
+		require.NoError(t, device.ApplyConfiguration(configuration, true))
+		assert.Equal(t, "Renamed", device.DeviceName)
+		assert.Equal(t, "farm-1", device.FarmID)
+		assert.Equal(t, "online", device.Status)
+	})
+
+	t.Run("overwrite clears the fields the configuration leaves empty", func(t *testing.T) {
+		device := newDevice(t)
+		require.NoError(t, device.ApplyConfiguration(configuration, false))
+		assert.Equal(t, "Nursery", device.LocationDescription)
+		assert.Empty(t, device.FarmID)
+		assert.Equal(t, "online", device.Status)
+	})
+
+	t.Run("invalid settings are rejected", func(t *testing.T) {
+		invalid := configuration
+		invalid.CertificateFingerprint = "not-a-fingerprint"
+		assert.Error(t, newDevice(t).ApplyConfiguration(invalid, true))
+	})
+}
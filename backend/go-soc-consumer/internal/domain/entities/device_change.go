@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// DeviceChange is an entry of the device change journal, recorded whenever a device is created or updated
+type DeviceChange struct {
+	Cursor     uint64
+	MACAddress string
+	Status     string
+	LastSeen   time.Time
+	ChangedAt  time.Time
+}
+
+// NewDeviceChange captures the current state of a device
+func NewDeviceChange(device *Device, changedAt time.Time) DeviceChange {
+	return DeviceChange{
+		MACAddress: device.GetID(),
+		Status:     device.Status,
+		LastSeen:   device.LastSeen,
+		ChangedAt:  changedAt,
+	}
+}
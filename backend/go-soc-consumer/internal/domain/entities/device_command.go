@@ -0,0 +1,128 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Remote commands understood by the device firmware
+const (
+	DeviceCommandReboot       = "reboot"        // restart the device, optionally after a delay
+	DeviceCommandIdentify     = "identify"      // blink the status LED so the device can be found in the field
+	DeviceCommandFactoryReset = "factory_reset" // wipe the device configuration, requires confirmation
+	DeviceCommandValveOpen    = "valve_open"    // open the irrigation valve of the device
+	DeviceCommandValveClose   = "valve_close"   // close the irrigation valve of the device
+	DeviceCommandConfigure    = "configure"     // apply a new name and location on the device
+	DeviceCommandMigrate      = "migrate"       // reconnect to another broker and publish under another topic prefix
+)
+
+// Delivery status of a device command
+const (
+	DeviceCommandStatusSent         = "sent"         // published, waiting for the device to acknowledge it
+	DeviceCommandStatusAcknowledged = "acknowledged" // the device reported it carried the command out
+	DeviceCommandStatusFailed       = "failed"       // the device reported it could not carry the command out
+	DeviceCommandStatusExpired      = "expired"      // no acknowledgement arrived in time
+)
+
+// Bounds of the command parameters
+const (
+	MaxRebootDelay          = time.Hour
+	DefaultIdentifyDuration = 30 * time.Second
+	MaxIdentifyDuration     = 10 * time.Minute
+	MaxTopicPrefixLen       = 128
+)
+
+// DeviceCommandRequest is a command to send to a device along with its parameters
+type DeviceCommandRequest struct {
+	Command         string
+	DelaySeconds    int    // reboot: seconds to wait before restarting
+	DurationSeconds int    // identify: seconds to blink, DefaultIdentifyDuration when zero
+	Confirm         string // factory_reset: must repeat the MAC address of the device
+
+	// configure: the name and location the device reports from now on
+	DeviceName          string
+	LocationDescription string
+
+	// migrate: the broker and topic prefix the device uses from now on
+	BrokerURL   string
+	TopicPrefix string
+}
+
+// Payload validates the request for the given device and returns the parameters sent along with the command
+func (r DeviceCommandRequest) Payload(macAddress string) (json.RawMessage, error) {
+	var params interface{}
+	switch r.Command {
+	case DeviceCommandReboot:
+		if r.DelaySeconds < 0 || time.Duration(r.DelaySeconds)*time.Second > MaxRebootDelay {
+			return nil, fmt.Errorf("reboot delay must be between 0 and %d seconds", int(MaxRebootDelay.Seconds()))
+		}
+		params = map[string]int{"delay_seconds": r.DelaySeconds}
+	case DeviceCommandIdentify:
+		duration := r.DurationSeconds
+		if duration == 0 {
+			duration = int(DefaultIdentifyDuration.Seconds())
+		}
+		if duration < 0 || time.Duration(duration)*time.Second > MaxIdentifyDuration {
+			return nil, fmt.Errorf("identify duration must be between 1 and %d seconds", int(MaxIdentifyDuration.Seconds()))
+		}
+		params = map[string]int{"duration_seconds": duration}
+	case DeviceCommandFactoryReset:
+		if !strings.EqualFold(strings.TrimSpace(r.Confirm), macAddress) {
+			return nil, fmt.Errorf("factory reset must be confirmed with the MAC address of the device")
+		}
+		params = map[string]string{}
+	case DeviceCommandValveOpen, DeviceCommandValveClose:
+		params = map[string]string{}
+	case DeviceCommandConfigure:
+		configured := &Device{DeviceName: strings.TrimSpace(r.DeviceName), LocationDescription: strings.TrimSpace(r.LocationDescription)}
+		if err := configured.validateDeviceName(); err != nil {
+			return nil, err
+		}
+		if err := configured.validateLocationDescription(); err != nil {
+			return nil, err
+		}
+		params = map[string]string{"device_name": configured.DeviceName, "location_description": configured.LocationDescription}
+	case DeviceCommandMigrate:
+		brokerURL, topicPrefix := strings.TrimSpace(r.BrokerURL), strings.TrimSpace(r.TopicPrefix)
+		if err := ValidateBrokerURL(brokerURL); err != nil {
+			return nil, err
+		}
+		if topicPrefix == "" || len(topicPrefix) > MaxTopicPrefixLen || strings.ContainsAny(topicPrefix, "+#") {
+			return nil, fmt.Errorf("topic prefix must be 1 to %d characters without wildcards", MaxTopicPrefixLen)
+		}
+		params = map[string]string{"broker_url": brokerURL, "topic_prefix": topicPrefix}
+	default:
+		return nil, fmt.Errorf("unknown command: %q", r.Command)
+	}
+	return json.Marshal(params)
+}
+
+// DeviceCommand is a command published to a device and the acknowledgement it got back
+type DeviceCommand struct {
+	ID         string // nonce of the published envelope, echoed back by the device in its acknowledgement
+	MACAddress string
+	Command    string
+	Payload    json.RawMessage
+	Status     string
+	Message    string // reason given by the device when the command failed
+	IssuedAt   time.Time
+	ExpiresAt  time.Time  // the command expires unless acknowledged by then
+	AckedAt    *time.Time // nil until the device acknowledges the command
+	Attempts   int        // times the command was published; each retry carries a fresh nonce
+}
+
+// Pending reports whether the command still waits for an acknowledgement
+func (c *DeviceCommand) Pending() bool {
+	return c.Status == DeviceCommandStatusSent
+}
+
+// DeviceCommandAck is the acknowledgement of a command reported by a device
+type DeviceCommandAck struct {
+	CommandID  string
+	MACAddress string
+	Success    bool
+	Message    string
+	ReceivedAt time.Time
+}
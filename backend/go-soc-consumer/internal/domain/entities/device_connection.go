@@ -0,0 +1,40 @@
+package entities
+
+import "time"
+
+// DeviceClient is an MQTT client that published messages of a device
+type DeviceClient struct {
+	ClientID      string
+	Username      string
+	RemoteAddress string // IP address of the last message, when the broker reports it
+	FirstSeenAt   time.Time
+	LastSeenAt    time.Time
+}
+
+// DeviceConnection maps a device to the MQTT clients that recently published its messages, the
+// client that published last first
+type DeviceConnection struct {
+	MACAddress string
+	Clients    []DeviceClient
+}
+
+// Current returns the client that published last
+func (c *DeviceConnection) Current() DeviceClient {
+	if len(c.Clients) == 0 {
+		return DeviceClient{}
+	}
+	return c.Clients[0]
+}
+
+// Mismatch reports whether several client IDs published messages of the device, as happens when
+// the device was cloned or its credentials are used elsewhere
+func (c *DeviceConnection) Mismatch() bool {
+	return len(c.Clients) > 1
+}
+
+// Clone returns a copy of the connection that shares no state with it
+func (c *DeviceConnection) Clone() *DeviceConnection {
+	clone := &DeviceConnection{MACAddress: c.MACAddress, Clients: make([]DeviceClient, len(c.Clients))}
+	copy(clone.Clients, c.Clients)
+	return clone
+}
@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+// TelemetryReading is the latest value of one measurement stream of a device
+type TelemetryReading struct {
+	Stream    string // measurement or sensor type name
+	Channel   int
+	Value     float64
+	Unit      string
+	Quality   string
+	Timestamp time.Time
+}
+
+// DeviceProbe is the outcome of an on-demand health check of a device
+type DeviceProbe struct {
+	IPAddress string
+	Alive     bool
+	Latency   time.Duration
+	Error     string // why the check failed, empty when it completed
+	CheckedAt time.Time
+}
+
+// DeviceDiagnostics gathers what is known about a device for field debugging
+type DeviceDiagnostics struct {
+	GeneratedAt time.Time
+	Device      *Device
+	Telemetry   []*TelemetryReading // latest reading of every stream, ordered by stream and channel
+	Transitions []DeviceChange      // latest status changes still in the change journal, newest first
+	Probe       *DeviceProbe
+}
@@ -0,0 +1,184 @@
+package entities
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Topic layouts devices publish and listen in
+const (
+	TopicLayoutShared = "shared" // the legacy topics shared by every farm
+	TopicLayoutFarm   = "farm"   // the topics of the farm namespace of the device
+)
+
+// Topic prefixes handed to devices with the layout they move to
+const (
+	sharedTopicPrefix = "/liwaisi/iot/smart-irrigation"
+	farmTopicPrefix   = "farms/%s/devices"
+)
+
+// Statuses of a device migration
+const (
+	DeviceMigrationRunning   = "running"   // devices are being told to reconnect, or have not all reconnected yet
+	DeviceMigrationCompleted = "completed" // every device told to reconnect did
+	DeviceMigrationCancelled = "cancelled"
+)
+
+// Statuses of a device taking part in a migration
+const (
+	MigrationTargetPending     = "pending"     // the new configuration was not published yet
+	MigrationTargetSent        = "sent"        // the new configuration was published, the device has not reconnected yet
+	MigrationTargetReconnected = "reconnected" // the device published under the new layout since
+	MigrationTargetFailed      = "failed"      // the new configuration could not be published
+	MigrationTargetSkipped     = "skipped"     // the device has no farm to move its topics to
+)
+
+// brokerURLSchemes lists the broker URL schemes understood by the device firmware
+var brokerURLSchemes = []string{"tcp", "mqtt", "ssl", "tls", "mqtts", "ws", "wss"}
+
+// DeviceMigration moves the fleet, or the devices of one farm, to another broker or topic layout by
+// publishing the new configuration to every device and tracking which devices reconnected with it
+type DeviceMigration struct {
+	ID         string
+	BrokerURL  string // broker the devices connect to from now on
+	Layout     string // topic layout the devices publish in from now on
+	FarmID     string // farm whose devices move, every device when empty
+	Actor      string
+	Status     string
+	JobID      string // background job publishing the configuration
+	Devices    int    // devices taking part, skipped ones included
+	StartedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// NewDeviceMigration creates a running migration to the broker and topic layout
+func NewDeviceMigration(brokerURL, layout, farmID, actor string, now time.Time) (*DeviceMigration, error) {
+	brokerURL = strings.TrimSpace(brokerURL)
+	if err := ValidateBrokerURL(brokerURL); err != nil {
+		return nil, err
+	}
+	layout = strings.ToLower(strings.TrimSpace(layout))
+	if layout != TopicLayoutShared && layout != TopicLayoutFarm {
+		return nil, fmt.Errorf("layout must be %s or %s", TopicLayoutShared, TopicLayoutFarm)
+	}
+	farmID = strings.TrimSpace(farmID)
+	if farmID != "" {
+		if err := ValidateFarmID(farmID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &DeviceMigration{
+		ID:        uuid.New().String(),
+		BrokerURL: brokerURL,
+		Layout:    layout,
+		FarmID:    farmID,
+		Actor:     archiveActor(actor),
+		Status:    DeviceMigrationRunning,
+		StartedAt: now.UTC(),
+	}, nil
+}
+
+// Target returns the part the device takes in the migration. Devices without a farm cannot move to
+// the farm layout and are skipped.
+func (m *DeviceMigration) Target(device *Device) *DeviceMigrationTarget {
+	device.mu.RLock()
+	defer device.mu.RUnlock()
+
+	target := &DeviceMigrationTarget{
+		MigrationID: m.ID,
+		MACAddress:  device.MACAddress,
+		FarmID:      device.FarmID,
+		Status:      MigrationTargetPending,
+	}
+	if m.Layout == TopicLayoutFarm && device.FarmID == "" {
+		target.Status = MigrationTargetSkipped
+	}
+	return target
+}
+
+// TopicPrefix returns the topic prefix handed to a device of the farm
+func (m *DeviceMigration) TopicPrefix(farmID string) string {
+	if m.Layout == TopicLayoutFarm {
+		return fmt.Sprintf(farmTopicPrefix, farmID)
+	}
+	return sharedTopicPrefix
+}
+
+// Finish ends a running migration as completed or cancelled
+func (m *DeviceMigration) Finish(status string, at time.Time) error {
+	if m.Status != DeviceMigrationRunning {
+		return fmt.Errorf("migration is already %s", m.Status)
+	}
+	finishedAt := at.UTC()
+	m.Status = status
+	m.FinishedAt = &finishedAt
+	return nil
+}
+
+// DeviceMigrationTarget is a device taking part in a migration
+type DeviceMigrationTarget struct {
+	MigrationID   string
+	MACAddress    string
+	FarmID        string
+	Status        string
+	CommandID     string // command carrying the new configuration, set once published
+	Error         string // why the configuration could not be published
+	SentAt        *time.Time
+	ReconnectedAt *time.Time
+}
+
+// Sent records the configuration having been published to the device with the command
+func (t *DeviceMigrationTarget) Sent(commandID string, at time.Time) {
+	sentAt := at.UTC()
+	t.Status = MigrationTargetSent
+	t.CommandID = commandID
+	t.SentAt = &sentAt
+}
+
+// Failed records the configuration not having been published to the device
+func (t *DeviceMigrationTarget) Failed(err error) {
+	t.Status = MigrationTargetFailed
+	t.Error = err.Error()
+}
+
+// ReconnectedUnder reports whether a message of the device published in the farm namespace, empty
+// for the shared topics, shows it reconnected under the layout
+func (t *DeviceMigrationTarget) ReconnectedUnder(layout, farmID string) bool {
+	if layout == TopicLayoutFarm {
+		return farmID != "" && farmID == t.FarmID
+	}
+	return farmID == ""
+}
+
+// Straggling reports whether the device has not reconnected within the grace period of being sent
+// the configuration
+func (t *DeviceMigrationTarget) Straggling(grace time.Duration, now time.Time) bool {
+	return t.Status == MigrationTargetSent && t.SentAt != nil && now.Sub(*t.SentAt) >= grace
+}
+
+// DeviceMigrationReport sums up the progress of a migration
+type DeviceMigrationReport struct {
+	Migration  *DeviceMigration
+	Counts     map[string]int           // devices by target status
+	Stragglers []*DeviceMigrationTarget // devices that have not reconnected within the grace period
+	Failed     []*DeviceMigrationTarget
+}
+
+// ValidateBrokerURL checks that the URL names a broker the device firmware can connect to
+func ValidateBrokerURL(brokerURL string) error {
+	parsed, err := url.Parse(brokerURL)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("invalid broker url: %q", brokerURL)
+	}
+	for _, scheme := range brokerURLSchemes {
+		if strings.EqualFold(parsed.Scheme, scheme) {
+			return nil
+		}
+	}
+	return fmt.Errorf("broker url scheme must be one of %s", strings.Join(brokerURLSchemes, ", "))
+}
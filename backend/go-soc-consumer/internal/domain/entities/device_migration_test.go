@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeviceMigration(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should move to the farm layout", func(t *testing.T) {
+		migration, err := NewDeviceMigration(" tcp://broker.example.com:1883 ", "FARM", "", "", now)
+		require.NoError(t, err)
+		assert.Equal(t, "tcp://broker.example.com:1883", migration.BrokerURL)
+		assert.Equal(t, TopicLayoutFarm, migration.Layout)
+		assert.Equal(t, "unknown", migration.Actor)
+		assert.Equal(t, DeviceMigrationRunning, migration.Status)
+		assert.Equal(t, "farms/finca-norte/devices", migration.TopicPrefix("finca-norte"))
+	})
+
+	t.Run("should reject unknown layouts and brokers", func(t *testing.T) {
+		_, err := NewDeviceMigration("tcp://broker:1883", "tenant", "", "maria", now)
+		assert.ErrorContains(t, err, "layout must be")
+		_, err = NewDeviceMigration("http://broker:1883", "shared", "", "maria", now)
+		assert.ErrorContains(t, err, "scheme must be one of")
+		_, err = NewDeviceMigration("broker:1883", "shared", "", "maria", now)
+		assert.Error(t, err)
+	})
+}
+
+func TestDeviceMigration_Target(t *testing.T) {
+	migration := &DeviceMigration{ID: "migration-1", Layout: TopicLayoutFarm}
+
+	target := migration.Target(&Device{MACAddress: "AA:BB:CC:DD:EE:01", FarmID: "finca-norte"})
+	assert.Equal(t, MigrationTargetPending, target.Status)
+	assert.Equal(t, "finca-norte", target.FarmID)
+
+	target = migration.Target(&Device{MACAddress: "AA:BB:CC:DD:EE:02"})
+	assert.Equal(t, MigrationTargetSkipped, target.Status)
+
+	migration.Layout = TopicLayoutShared
+	target = migration.Target(&Device{MACAddress: "AA:BB:CC:DD:EE:02"})
+	assert.Equal(t, MigrationTargetPending, target.Status)
+	assert.Equal(t, "/liwaisi/iot/smart-irrigation", migration.TopicPrefix("finca-norte"))
+}
+
+func TestDeviceMigrationTarget(t *testing.T) {
+	sentAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	target := &DeviceMigrationTarget{MACAddress: "AA:BB:CC:DD:EE:01", FarmID: "finca-norte", Status: MigrationTargetPending}
+
+	target.Sent("cmd-1", sentAt)
+	assert.Equal(t, MigrationTargetSent, target.Status)
+	assert.False(t, target.Straggling(time.Hour, sentAt.Add(59*time.Minute)))
+	assert.True(t, target.Straggling(time.Hour, sentAt.Add(time.Hour)))
+
+	assert.True(t, target.ReconnectedUnder(TopicLayoutFarm, "finca-norte"))
+	assert.False(t, target.ReconnectedUnder(TopicLayoutFarm, ""))
+	assert.False(t, target.ReconnectedUnder(TopicLayoutFarm, "finca-sur"))
+	assert.True(t, target.ReconnectedUnder(TopicLayoutShared, ""))
+
+	target.Failed(errors.New("device is offline"))
+	assert.Equal(t, MigrationTargetFailed, target.Status)
+	assert.False(t, target.Straggling(time.Hour, sentAt.Add(2*time.Hour)))
+}
+
+func TestDeviceMigration_Finish(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	migration := &DeviceMigration{Status: DeviceMigrationRunning}
+
+	require.NoError(t, migration.Finish(DeviceMigrationCompleted, now))
+	assert.Equal(t, now, *migration.FinishedAt)
+	assert.ErrorContains(t, migration.Finish(DeviceMigrationCancelled, now), "already completed")
+}
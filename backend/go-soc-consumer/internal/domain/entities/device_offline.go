@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+)
+
+// DeviceOfflineEvent represents an event triggered when a device silent for too long is marked offline
+type DeviceOfflineEvent struct {
+	MACAddress string
+	FarmID     string
+	LastSeen   time.Time // when the device was last heard from
+	DetectedAt time.Time
+	EventID    string
+	EventType  string
+}
+
+// NewDeviceOfflineEvent creates a new device offline event with validation
+func NewDeviceOfflineEvent(macAddress, farmID string, lastSeen, detectedAt time.Time) (*DeviceOfflineEvent, error) {
+	if macAddress == "" {
+		return nil, fmt.Errorf("mac address is required")
+	}
+
+	eventID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate event ID: %w", err)
+	}
+
+	return &DeviceOfflineEvent{
+		MACAddress: macAddress,
+		FarmID:     farmID,
+		LastSeen:   lastSeen.UTC(),
+		DetectedAt: detectedAt.UTC(),
+		EventID:    eventID.String(),
+		EventType:  events.DeviceOfflineEventType,
+	}, nil
+}
+
+// GetSubject returns the NATS subject for this event type
+func (e *DeviceOfflineEvent) GetSubject() string {
+	return events.DeviceOfflineSubject
+}
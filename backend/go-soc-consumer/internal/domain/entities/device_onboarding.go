@@ -0,0 +1,192 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// Onboarding steps, in the order an installer goes through them
+const (
+	OnboardingStepCredentialsDelivered = "credentials_delivered" // the installer handed the provisioning payload to the device
+	OnboardingStepFirstConnection      = "first_connection"      // the device registered presenting its provisioning token
+	OnboardingStepFirstTelemetry       = "first_telemetry"       // the device sent its first readings
+	OnboardingStepAssigned             = "assigned"              // the installer confirmed where the device was placed
+)
+
+// ProvisioningPayloadVersion is the version of the payload format read by the device firmware
+const ProvisioningPayloadVersion = 1
+
+// provisioningTokenBytes is the entropy of a provisioning token
+const provisioningTokenBytes = 16
+
+// DeviceOnboarding tracks a device from its creation by an installer until it is connected,
+// reporting and placed. The device only exists in the device registry once it registers.
+type DeviceOnboarding struct {
+	ID                     string
+	MACAddress             string
+	DeviceName             string
+	LocationDescription    string
+	FarmID                 string // farm namespace the device publishes in, empty for the shared topics
+	TokenHash              string // hex SHA-256 of the provisioning token, which is never stored
+	TokenExpiresAt         time.Time
+	CredentialsDeliveredAt *time.Time
+	FirstConnectedAt       *time.Time
+	FirstTelemetryAt       *time.Time
+	AssignedAt             *time.Time
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// OnboardingRequest holds the fields of an onboarding set by the installer
+type OnboardingRequest struct {
+	MACAddress          string
+	DeviceName          string
+	LocationDescription string
+	FarmID              string
+}
+
+// OnboardingStep is a step of an onboarding, with when it was completed
+type OnboardingStep struct {
+	Name        string
+	CompletedAt *time.Time // nil while pending
+}
+
+// ProvisioningPayload is what an installer hands to a device being onboarded, as JSON
+type ProvisioningPayload struct {
+	Version      int    `json:"v"`
+	OnboardingID string `json:"id"`
+	MACAddress   string `json:"mac"`
+	BrokerURL    string `json:"broker"`
+	TopicPrefix  string `json:"prefix"` // registration and readings are published under it
+	Token        string `json:"token"`  // presented as provisioning_token in the first registration
+}
+
+// NewDeviceOnboarding creates the onboarding of a device and returns it with its provisioning token
+func NewDeviceOnboarding(request OnboardingRequest, tokenTTL time.Duration, now time.Time) (*DeviceOnboarding, string, error) {
+	onboarding := &DeviceOnboarding{
+		ID:                  uuid.New().String(),
+		MACAddress:          strings.ToUpper(strings.TrimSpace(request.MACAddress)),
+		DeviceName:          strings.TrimSpace(request.DeviceName),
+		LocationDescription: strings.TrimSpace(request.LocationDescription),
+		FarmID:              strings.TrimSpace(request.FarmID),
+		CreatedAt:           now.UTC(),
+	}
+	if err := onboarding.Validate(); err != nil {
+		return nil, "", err
+	}
+	token, err := onboarding.RenewToken(tokenTTL, now)
+	if err != nil {
+		return nil, "", err
+	}
+	return onboarding, token, nil
+}
+
+// Validate validates the onboarding fields
+func (o *DeviceOnboarding) Validate() error {
+	if err := validation.ValidateMACAddress(o.MACAddress); err != nil {
+		return err
+	}
+	if o.DeviceName == "" {
+		return fmt.Errorf("device name is required")
+	}
+	if len(o.DeviceName) > 100 {
+		return fmt.Errorf("device name cannot exceed 100 characters")
+	}
+	if len(o.LocationDescription) > 255 {
+		return fmt.Errorf("location description cannot exceed 255 characters")
+	}
+	if o.FarmID != "" {
+		if err := ValidateFarmID(o.FarmID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenewToken replaces the provisioning token, invalidating the previous one, and returns the new one
+func (o *DeviceOnboarding) RenewToken(ttl time.Duration, now time.Time) (string, error) {
+	raw := make([]byte, provisioningTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate provisioning token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+	o.TokenHash = hashProvisioningToken(token)
+	o.TokenExpiresAt = now.Add(ttl).UTC()
+	o.UpdatedAt = now.UTC()
+	return token, nil
+}
+
+// VerifyToken reports whether the token is the unexpired provisioning token of the onboarding
+func (o *DeviceOnboarding) VerifyToken(token string, now time.Time) bool {
+	if token == "" || !now.Before(o.TokenExpiresAt) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashProvisioningToken(token)), []byte(o.TokenHash)) == 1
+}
+
+// Steps returns the steps of the onboarding in order
+func (o *DeviceOnboarding) Steps() []OnboardingStep {
+	return []OnboardingStep{
+		{Name: OnboardingStepCredentialsDelivered, CompletedAt: o.CredentialsDeliveredAt},
+		{Name: OnboardingStepFirstConnection, CompletedAt: o.FirstConnectedAt},
+		{Name: OnboardingStepFirstTelemetry, CompletedAt: o.FirstTelemetryAt},
+		{Name: OnboardingStepAssigned, CompletedAt: o.AssignedAt},
+	}
+}
+
+// CurrentStep returns the first pending step, empty once the onboarding is completed
+func (o *DeviceOnboarding) CurrentStep() string {
+	for _, step := range o.Steps() {
+		if step.CompletedAt == nil {
+			return step.Name
+		}
+	}
+	return ""
+}
+
+// Completed reports whether every step is completed
+func (o *DeviceOnboarding) Completed() bool {
+	return o.CurrentStep() == ""
+}
+
+// TopicPrefix returns the prefix of the topics the device publishes on
+func (o *DeviceOnboarding) TopicPrefix() string {
+	if o.FarmID != "" {
+		return "farms/" + o.FarmID + "/devices"
+	}
+	return "/liwaisi/iot/smart-irrigation"
+}
+
+// ProvisioningPayload returns the payload handed to the device with the token
+func (o *DeviceOnboarding) ProvisioningPayload(brokerURL, token string) *ProvisioningPayload {
+	return &ProvisioningPayload{
+		Version:      ProvisioningPayloadVersion,
+		OnboardingID: o.ID,
+		MACAddress:   o.MACAddress,
+		BrokerURL:    brokerURL,
+		TopicPrefix:  o.TopicPrefix(),
+		Token:        token,
+	}
+}
+
+// Encode returns the payload as compact JSON, short enough to fit in a QR code
+func (p *ProvisioningPayload) Encode() string {
+	encoded, _ := json.Marshal(p)
+	return string(encoded)
+}
+
+// hashProvisioningToken returns the hex SHA-256 of a provisioning token
+func hashProvisioningToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+)
+
+// DeviceOnlineEvent represents an event triggered when a health check finds a device alive that was not online
+type DeviceOnlineEvent struct {
+	MACAddress string
+	FarmID     string
+	IPAddress  string
+	DetectedAt time.Time
+	EventID    string
+	EventType  string
+}
+
+// NewDeviceOnlineEvent creates a new device online event with validation
+func NewDeviceOnlineEvent(macAddress, farmID, ipAddress string, detectedAt time.Time) (*DeviceOnlineEvent, error) {
+	if macAddress == "" {
+		return nil, fmt.Errorf("mac address is required")
+	}
+
+	eventID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate event ID: %w", err)
+	}
+
+	return &DeviceOnlineEvent{
+		MACAddress: macAddress,
+		FarmID:     farmID,
+		IPAddress:  ipAddress,
+		DetectedAt: detectedAt.UTC(),
+		EventID:    eventID.String(),
+		EventType:  events.DeviceOnlineEventType,
+	}, nil
+}
+
+// GetSubject returns the NATS subject for this event type
+func (e *DeviceOnlineEvent) GetSubject() string {
+	return events.DeviceOnlineSubject
+}
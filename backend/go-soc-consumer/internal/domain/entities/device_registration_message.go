@@ -15,6 +15,11 @@ type DeviceRegistrationMessage struct {
 	IPAddress           string
 	LocationDescription string
 	ReceivedAt          time.Time
+	FarmID              string          // farm namespace the message was published in, empty on the shared topic
+	FirmwareVersion     string          // optional, empty when the firmware does not report it
+	HardwareVersion     string          // optional, empty when the firmware does not report it
+	ProvisioningToken   string          // optional, presented by a device being onboarded
+	Broker              *BrokerMetadata // how the broker delivered the message, nil when not delivered live by a broker
 }
 
 // NewDeviceRegistrationMessage creates a new device registration message with validation
@@ -125,17 +130,27 @@ func (m *DeviceRegistrationMessage) ToDevice() (*Device, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid device created from registration message: %w", err)
 	}
-	
+
 	// Update the timestamps to match the received time
 	device.mu.Lock()
 	device.RegisteredAt = m.ReceivedAt
 	device.LastSeen = m.ReceivedAt
+	device.FarmID = m.FarmID
+	device.FirmwareVersion = m.FirmwareVersion
+	device.HardwareVersion = m.HardwareVersion
+	if m.Broker != nil {
+		broker := *m.Broker
+		device.LastRegistration = &broker
+	}
 	device.mu.Unlock()
-	
+	if err := device.validateVersions(); err != nil {
+		return nil, fmt.Errorf("invalid device created from registration message: %w", err)
+	}
+
 	return device, nil
 }
 
 // GetDeviceIdentifier returns the device identifier (MAC address)
 func (m *DeviceRegistrationMessage) GetDeviceIdentifier() string {
 	return m.MACAddress
-}
\ No newline at end of file
+}
@@ -0,0 +1,55 @@
+package entities
+
+import "time"
+
+// DeviceShadow is the configuration declared for a device, which the device itself should report
+// back when it registers
+type DeviceShadow struct {
+	MACAddress          string
+	DeviceName          string
+	LocationDescription string
+	DeclaredAt          time.Time
+}
+
+// NewDeviceShadow returns the shadow declaring the current configuration of the device
+func NewDeviceShadow(device *Device, declaredAt time.Time) *DeviceShadow {
+	configuration := device.Configuration()
+	return &DeviceShadow{
+		MACAddress:          configuration.MACAddress,
+		DeviceName:          configuration.DeviceName,
+		LocationDescription: configuration.LocationDescription,
+		DeclaredAt:          declaredAt.UTC(),
+	}
+}
+
+// Drift returns the fields the device reports differently from the shadow, empty when it matches
+func (s *DeviceShadow) Drift(device *Device) []string {
+	configuration := device.Configuration()
+	var fields []string
+	if configuration.DeviceName != s.DeviceName {
+		fields = append(fields, "device_name")
+	}
+	if configuration.LocationDescription != s.LocationDescription {
+		fields = append(fields, "location_description")
+	}
+	return fields
+}
+
+// ConfigureRequest returns the configure command that pushes the shadow to the device
+func (s *DeviceShadow) ConfigureRequest() DeviceCommandRequest {
+	return DeviceCommandRequest{
+		Command:             DeviceCommandConfigure,
+		DeviceName:          s.DeviceName,
+		LocationDescription: s.LocationDescription,
+	}
+}
+
+// DeviceDrift is a device whose reported configuration differs from its shadow
+type DeviceDrift struct {
+	MACAddress string
+	Fields     []string
+	Since      time.Time  // when the drift was first detected
+	Pushes     int        // configure commands sent since
+	LastPushAt *time.Time // nil until the shadow is pushed
+	Persistent bool       // the drift outlasted the alert threshold
+}
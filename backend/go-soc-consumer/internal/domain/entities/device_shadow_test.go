@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceShadow_Drift(t *testing.T) {
+	device, err := NewDevice("AA:BB:CC:DD:EE:FF", "North valve", "10.0.0.1", "Greenhouse 2")
+	require.NoError(t, err)
+	shadow := NewDeviceShadow(device, time.Now())
+
+	assert.Empty(t, shadow.Drift(device))
+
+	device.SetDeviceName("ESP32-1234")
+	assert.Equal(t, []string{"device_name"}, shadow.Drift(device))
+
+	device.LocationDescription = "Unknown"
+	assert.Equal(t, []string{"device_name", "location_description"}, shadow.Drift(device))
+}
+
+func TestDeviceShadow_ConfigureRequest(t *testing.T) {
+	shadow := &DeviceShadow{MACAddress: "AA:BB:CC:DD:EE:FF", DeviceName: "North valve", LocationDescription: "Greenhouse 2"}
+
+	payload, err := shadow.ConfigureRequest().Payload("AA:BB:CC:DD:EE:FF")
+	require.NoError(t, err)
+	var params map[string]string
+	require.NoError(t, json.Unmarshal(payload, &params))
+	assert.Equal(t, map[string]string{"device_name": "North valve", "location_description": "Greenhouse 2"}, params)
+
+	_, err = DeviceCommandRequest{Command: DeviceCommandConfigure, DeviceName: " ", LocationDescription: "Greenhouse"}.Payload("AA:BB:CC:DD:EE:FF")
+	assert.Error(t, err)
+}
@@ -716,3 +716,90 @@ func TestDevice_Getters_ThreadSafety(t *testing.T) {
 	assert.Equal(t, "Test Device", device.GetDeviceName())
 	assert.Equal(t, "192.168.1.100", device.GetIPAddress())
 }
+
+func TestDevice_Farm(t *testing.T) {
+	device, err := NewDevice("AA:BB:CC:DD:EE:FF", "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+
+	assert.NoError(t, device.VerifyFarm("farm-a"), "unbound devices accept any farm")
+	assert.Error(t, device.AssignFarm("farm/a"), "farm ids cannot span topic levels")
+	require.NoError(t, device.AssignFarm("farm-a"))
+	assert.Error(t, device.AssignFarm("farm-b"))
+	assert.Error(t, device.VerifyFarm("farm-b"))
+	assert.NoError(t, device.VerifyFarm("farm-a"))
+	assert.NoError(t, device.VerifyFarm(""), "shared topics are not scoped")
+}
+
+func TestDevice_Clone(t *testing.T) {
+	device, err := NewDevice("AA:BB:CC:DD:EE:FF", "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	require.NoError(t, device.RecordCalibration(time.Now().Add(-time.Hour)))
+	device.LastRegistration = NewBrokerMetadata("/liwaisi/iot/smart-irrigation/device/registration", 1, false, "probe-7")
+
+	clone := device.Clone()
+	assert.Equal(t, device.Configuration(), clone.Configuration())
+	assert.Equal(t, device.LastRegistration, clone.LastRegistration)
+	assert.NotSame(t, device.LastRegistration, clone.LastRegistration)
+
+	clone.SetDeviceName("Renamed")
+	*clone.CalibratedAt = time.Time{}
+	assert.Equal(t, "Sensor", device.GetDeviceName())
+	assert.False(t, device.CalibratedAt.IsZero(), "the calibration time is not shared")
+}
+
+func TestDevice_WithChanges(t *testing.T) {
+	device, err := NewDevice("AA:BB:CC:DD:EE:FF", "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+
+	t.Run("should return an updated copy", func(t *testing.T) {
+		updated, err := device.WithChanges(func(updated *Device) error {
+			updated.SetIPAddress("192.168.1.20")
+			updated.MarkOnline()
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "192.168.1.20", updated.GetIPAddress())
+		assert.True(t, updated.IsOnline())
+		assert.Equal(t, "192.168.1.10", device.GetIPAddress())
+		assert.Equal(t, "registered", device.GetStatus())
+	})
+
+	t.Run("should return the errors of the changes", func(t *testing.T) {
+		updated, err := device.WithChanges(func(updated *Device) error {
+			return updated.AssignFarm("farm/a")
+		})
+		assert.Error(t, err)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("should reject invalid copies", func(t *testing.T) {
+		updated, err := device.WithChanges(func(updated *Device) error {
+			updated.SetIPAddress("not-an-ip")
+			return nil
+		})
+		assert.ErrorContains(t, err, "invalid ip address format")
+		assert.Nil(t, updated)
+		assert.Equal(t, "192.168.1.10", device.GetIPAddress())
+	})
+
+	t.Run("should not race with readers of the original", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, _ = device.WithChanges(func(updated *Device) error {
+					updated.MarkOffline()
+					return nil
+				})
+			}()
+			go func() {
+				defer wg.Done()
+				device.GetStatus()
+				device.Configuration()
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, "registered", device.GetStatus())
+	})
+}
@@ -0,0 +1,213 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+)
+
+// Statuses of a device transfer: initiated by the source farm, accepted by the target farm, and
+// completed once the hardware moved; open transfers can be cancelled
+const (
+	DeviceTransferPending   = "pending"
+	DeviceTransferAccepted  = "accepted"
+	DeviceTransferCompleted = "completed"
+	DeviceTransferCancelled = "cancelled"
+)
+
+// Policies for the history of a transferred device
+const (
+	TransferHistoryKeep    = "keep"    // the readings follow the device to the target farm
+	TransferHistoryArchive = "archive" // the readings taken before the transfer are archived under the source farm
+)
+
+// DeviceTransferInitiated is the audit action of initiating a transfer; the other actions are named
+// after the status they move the transfer to
+const DeviceTransferInitiated = "initiated"
+
+// maxTransferNoteLen bounds the notes of transfers and their audit entries
+const maxTransferNoteLen = 500
+
+// DeviceTransfer moves a device from the farm it is bound to to another farm, for when hardware
+// moves between sites. The device keeps its MAC address; its farm, zone and, depending on the
+// history policy, its readings change when the transfer completes.
+type DeviceTransfer struct {
+	ID               string
+	MACAddress       string
+	FromFarmID       string // empty for devices not bound to a farm
+	ToFarmID         string
+	ToZoneID         string // zone of the target farm the device is assigned to, optional
+	HistoryPolicy    string
+	Status           string
+	Note             string
+	ArchivedReadings int // readings archived on completion
+	CreatedAt        time.Time
+	AcceptedAt       *time.Time
+	CompletedAt      *time.Time
+	CancelledAt      *time.Time
+	UpdatedAt        time.Time
+}
+
+// DeviceTransferRequest holds the fields of a transfer set when it is initiated
+type DeviceTransferRequest struct {
+	ToFarmID      string
+	ToZoneID      string
+	HistoryPolicy string // defaults to keep
+	Note          string
+}
+
+// DeviceTransferAuditEntry records who moved a transfer to a status, and when
+type DeviceTransferAuditEntry struct {
+	TransferID string
+	Action     string // initiated, accepted, completed or cancelled
+	Actor      string
+	Note       string
+	At         time.Time
+}
+
+// DeviceTransferEvent is published whenever a device transfer changes status
+type DeviceTransferEvent struct {
+	TransferID    string
+	MACAddress    string
+	FromFarmID    string
+	ToFarmID      string
+	Status        string
+	HistoryPolicy string
+	Actor         string
+	OccurredAt    time.Time
+	EventID       string
+	EventType     string
+}
+
+// NewDeviceTransfer initiates the transfer of the device to another farm, returning it with its
+// audit entry
+func NewDeviceTransfer(device *Device, request DeviceTransferRequest, actor string, now time.Time) (*DeviceTransfer, DeviceTransferAuditEntry, error) {
+	toFarmID := strings.TrimSpace(request.ToFarmID)
+	if err := ValidateFarmID(toFarmID); err != nil {
+		return nil, DeviceTransferAuditEntry{}, err
+	}
+
+	device.mu.RLock()
+	fromFarmID := device.FarmID
+	device.mu.RUnlock()
+	if fromFarmID == toFarmID {
+		return nil, DeviceTransferAuditEntry{}, fmt.Errorf("device %s already belongs to farm %s", device.MACAddress, toFarmID)
+	}
+
+	policy := strings.ToLower(strings.TrimSpace(request.HistoryPolicy))
+	if policy == "" {
+		policy = TransferHistoryKeep
+	}
+	if policy != TransferHistoryKeep && policy != TransferHistoryArchive {
+		return nil, DeviceTransferAuditEntry{}, fmt.Errorf("history policy must be %s or %s", TransferHistoryKeep, TransferHistoryArchive)
+	}
+
+	note := strings.TrimSpace(request.Note)
+	if len(note) > maxTransferNoteLen {
+		return nil, DeviceTransferAuditEntry{}, fmt.Errorf("note cannot exceed %d characters", maxTransferNoteLen)
+	}
+
+	transfer := &DeviceTransfer{
+		ID:            uuid.New().String(),
+		MACAddress:    device.MACAddress,
+		FromFarmID:    fromFarmID,
+		ToFarmID:      toFarmID,
+		ToZoneID:      strings.TrimSpace(request.ToZoneID),
+		HistoryPolicy: policy,
+		Status:        DeviceTransferPending,
+		Note:          note,
+		CreatedAt:     now.UTC(),
+		UpdatedAt:     now.UTC(),
+	}
+	return transfer, transfer.auditEntry(DeviceTransferInitiated, actor, note, now), nil
+}
+
+// Open reports whether the transfer can still be accepted, completed or cancelled
+func (t *DeviceTransfer) Open() bool {
+	return t.Status == DeviceTransferPending || t.Status == DeviceTransferAccepted
+}
+
+// Accept records the target farm agreeing to take the device
+func (t *DeviceTransfer) Accept(actor string, at time.Time) (DeviceTransferAuditEntry, error) {
+	if t.Status != DeviceTransferPending {
+		return DeviceTransferAuditEntry{}, fmt.Errorf("only pending transfers can be accepted, transfer is %s", t.Status)
+	}
+	acceptedAt := at.UTC()
+	t.Status = DeviceTransferAccepted
+	t.AcceptedAt = &acceptedAt
+	t.UpdatedAt = acceptedAt
+	return t.auditEntry(DeviceTransferAccepted, actor, "", at), nil
+}
+
+// Complete records the device having moved to the target farm, with the readings archived under
+// the source farm
+func (t *DeviceTransfer) Complete(actor string, archivedReadings int, at time.Time) (DeviceTransferAuditEntry, error) {
+	if t.Status != DeviceTransferAccepted {
+		return DeviceTransferAuditEntry{}, fmt.Errorf("only accepted transfers can be completed, transfer is %s", t.Status)
+	}
+	completedAt := at.UTC()
+	t.Status = DeviceTransferCompleted
+	t.ArchivedReadings = archivedReadings
+	t.CompletedAt = &completedAt
+	t.UpdatedAt = completedAt
+
+	note := ""
+	if t.HistoryPolicy == TransferHistoryArchive {
+		note = fmt.Sprintf("%d readings archived", archivedReadings)
+	}
+	return t.auditEntry(DeviceTransferCompleted, actor, note, at), nil
+}
+
+// Cancel abandons an open transfer, leaving the device in its farm
+func (t *DeviceTransfer) Cancel(actor, reason string, at time.Time) (DeviceTransferAuditEntry, error) {
+	if !t.Open() {
+		return DeviceTransferAuditEntry{}, fmt.Errorf("transfer is already %s", t.Status)
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) > maxTransferNoteLen {
+		return DeviceTransferAuditEntry{}, fmt.Errorf("reason cannot exceed %d characters", maxTransferNoteLen)
+	}
+	cancelledAt := at.UTC()
+	t.Status = DeviceTransferCancelled
+	t.CancelledAt = &cancelledAt
+	t.UpdatedAt = cancelledAt
+	return t.auditEntry(DeviceTransferCancelled, actor, reason, at), nil
+}
+
+// Event returns the event published for the entry moving the transfer to its status
+func (t *DeviceTransfer) Event(entry DeviceTransferAuditEntry) (*DeviceTransferEvent, error) {
+	eventID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate event ID: %w", err)
+	}
+
+	return &DeviceTransferEvent{
+		TransferID:    t.ID,
+		MACAddress:    t.MACAddress,
+		FromFarmID:    t.FromFarmID,
+		ToFarmID:      t.ToFarmID,
+		Status:        t.Status,
+		HistoryPolicy: t.HistoryPolicy,
+		Actor:         entry.Actor,
+		OccurredAt:    entry.At,
+		EventID:       eventID.String(),
+		EventType:     events.DeviceTransferEventType,
+	}, nil
+}
+
+func (t *DeviceTransfer) auditEntry(action, actor, note string, at time.Time) DeviceTransferAuditEntry {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		actor = "unknown"
+	}
+	return DeviceTransferAuditEntry{TransferID: t.ID, Action: action, Actor: actor, Note: note, At: at.UTC()}
+}
+
+// GetSubject returns the NATS subject for this event type
+func (e *DeviceTransferEvent) GetSubject() string {
+	return events.DeviceTransferSubject
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeviceTransfer(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	device := &Device{MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "finca-norte"}
+
+	t.Run("should start a pending transfer keeping the history by default", func(t *testing.T) {
+		transfer, entry, err := NewDeviceTransfer(device, DeviceTransferRequest{ToFarmID: " finca-sur ", Note: "moved to the south greenhouse"}, "maria", now)
+		require.NoError(t, err)
+		assert.Equal(t, "finca-norte", transfer.FromFarmID)
+		assert.Equal(t, "finca-sur", transfer.ToFarmID)
+		assert.Equal(t, TransferHistoryKeep, transfer.HistoryPolicy)
+		assert.Equal(t, DeviceTransferPending, transfer.Status)
+		assert.Equal(t, DeviceTransferAuditEntry{TransferID: transfer.ID, Action: DeviceTransferInitiated, Actor: "maria", Note: "moved to the south greenhouse", At: now}, entry)
+	})
+
+	t.Run("should reject invalid transfers", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			request DeviceTransferRequest
+			wantErr string
+		}{
+			{name: "same farm", request: DeviceTransferRequest{ToFarmID: "finca-norte"}, wantErr: "already belongs"},
+			{name: "invalid farm", request: DeviceTransferRequest{ToFarmID: "finca/sur"}, wantErr: "invalid farm id"},
+			{name: "unknown policy", request: DeviceTransferRequest{ToFarmID: "finca-sur", HistoryPolicy: "delete"}, wantErr: "history policy"},
+		}
+		for _, tt := range tests {
+			_, _, err := NewDeviceTransfer(device, tt.request, "maria", now)
+			assert.ErrorContains(t, err, tt.wantErr, tt.name)
+		}
+	})
+}
+
+func TestDeviceTransfer_Lifecycle(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	transfer := &DeviceTransfer{ID: "transfer-1", HistoryPolicy: TransferHistoryArchive, Status: DeviceTransferPending}
+
+	_, err := transfer.Complete("pedro", 0, now)
+	assert.ErrorContains(t, err, "only accepted transfers")
+
+	entry, err := transfer.Accept("pedro", now)
+	require.NoError(t, err)
+	assert.Equal(t, DeviceTransferAccepted, entry.Action)
+	assert.True(t, transfer.Open())
+
+	entry, err = transfer.Complete("", 1440, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "unknown", entry.Actor)
+	assert.Equal(t, "1440 readings archived", entry.Note)
+	assert.Equal(t, 1440, transfer.ArchivedReadings)
+	assert.False(t, transfer.Open())
+
+	_, err = transfer.Cancel("maria", "", now)
+	assert.ErrorContains(t, err, "already completed")
+
+	event, err := transfer.Event(entry)
+	require.NoError(t, err)
+	assert.Equal(t, DeviceTransferCompleted, event.Status)
+	assert.Equal(t, "liwaisi.iot.smart-irrigation.device.transfer", event.GetSubject())
+}
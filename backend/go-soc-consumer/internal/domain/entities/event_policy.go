@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Outcomes of applying a publishing policy to an event
+const (
+	EventPolicyPublished  = "published"   // the event was handed to the publisher
+	EventPolicyDisabled   = "disabled"    // the event type is not published
+	EventPolicySampledOut = "sampled_out" // the event was left out of the sample
+	EventPolicyThrottled  = "throttled"   // the device published the same event type too recently
+)
+
+// EventPolicy controls how events of one type are published, to keep high-frequency events from
+// overwhelming downstream consumers
+type EventPolicy struct {
+	EventType   string
+	Enabled     bool
+	SampleRate  float64       // fraction of the events published, from 0 to 1
+	MinInterval time.Duration // minimum time between two events of a device, zero for none
+}
+
+// DefaultEventPolicy returns the policy of event types without one: every event is published
+func DefaultEventPolicy(eventType string) *EventPolicy {
+	return &EventPolicy{EventType: eventType, Enabled: true, SampleRate: 1}
+}
+
+// Validate ensures the policy is applicable
+func (p *EventPolicy) Validate() error {
+	if strings.TrimSpace(p.EventType) == "" {
+		return fmt.Errorf("event type is required")
+	}
+	if p.SampleRate < 0 || p.SampleRate > 1 {
+		return fmt.Errorf("sample rate must be between 0 and 1, got %g", p.SampleRate)
+	}
+	if p.MinInterval < 0 {
+		return fmt.Errorf("minimum interval cannot be negative")
+	}
+	return nil
+}
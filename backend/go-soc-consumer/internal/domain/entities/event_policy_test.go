@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  EventPolicy
+		wantErr string
+	}{
+		{name: "default policy", policy: *DefaultEventPolicy("alert")},
+		{name: "sampled and throttled", policy: EventPolicy{EventType: "device.detected", Enabled: true, SampleRate: 0.25, MinInterval: time.Minute}},
+		{name: "missing event type", policy: EventPolicy{SampleRate: 1}, wantErr: "event type is required"},
+		{name: "sample rate above one", policy: EventPolicy{EventType: "alert", SampleRate: 1.5}, wantErr: "sample rate must be between 0 and 1"},
+		{name: "negative interval", policy: EventPolicy{EventType: "alert", SampleRate: 1, MinInterval: -time.Second}, wantErr: "minimum interval cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Zone kinds of the farm → field → zone hierarchy
+const (
+	ZoneKindField = "field" // division of a farm, holding zones
+	ZoneKindZone  = "zone"  // area of a field, holding devices
+)
+
+// maxFarmNameLen bounds the names of farms, fields and zones
+const maxFarmNameLen = 100
+
+// Farm is the top of the hierarchy devices are grouped in. Its ID is the farm namespace its devices
+// register under, when they do.
+type Farm struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Zone is a field of a farm, or a zone of a field. Devices are assigned to zones, so a field groups
+// the devices of its zones.
+type Zone struct {
+	ID        string
+	FarmID    string
+	ParentID  string // field of a zone, empty for a field
+	Kind      string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ZoneRequest holds the fields of a field or zone set when it is created
+type ZoneRequest struct {
+	Name     string
+	Kind     string
+	ParentID string // field of a zone
+}
+
+// NewFarm creates a farm with the given farm namespace
+func NewFarm(id, name string, now time.Time) (*Farm, error) {
+	id = strings.TrimSpace(id)
+	if err := ValidateFarmID(id); err != nil {
+		return nil, err
+	}
+
+	farm := &Farm{ID: id, CreatedAt: now.UTC()}
+	if err := farm.Rename(name, now); err != nil {
+		return nil, err
+	}
+	return farm, nil
+}
+
+// Rename changes the name of the farm
+func (f *Farm) Rename(name string, at time.Time) error {
+	name = strings.TrimSpace(name)
+	if err := validateFarmName("farm", name); err != nil {
+		return err
+	}
+	f.Name = name
+	f.UpdatedAt = at.UTC()
+	return nil
+}
+
+// NewZone creates a field of the farm, or a zone of the parent field. The parent is nil for a field.
+func NewZone(farmID string, request ZoneRequest, parent *Zone, now time.Time) (*Zone, error) {
+	zone := &Zone{
+		ID:        uuid.New().String(),
+		FarmID:    farmID,
+		Kind:      strings.ToLower(strings.TrimSpace(request.Kind)),
+		CreatedAt: now.UTC(),
+	}
+
+	switch zone.Kind {
+	case ZoneKindField:
+		if parent != nil {
+			return nil, fmt.Errorf("a field belongs to its farm and has no parent")
+		}
+	case ZoneKindZone:
+		if parent == nil {
+			return nil, fmt.Errorf("a zone needs a parent field")
+		}
+		if parent.Kind != ZoneKindField || parent.FarmID != farmID {
+			return nil, fmt.Errorf("the parent of a zone must be a field of farm %s", farmID)
+		}
+		zone.ParentID = parent.ID
+	default:
+		return nil, fmt.Errorf("kind must be %s or %s", ZoneKindField, ZoneKindZone)
+	}
+
+	if err := zone.Rename(request.Name, now); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// Rename changes the name of the field or zone
+func (z *Zone) Rename(name string, at time.Time) error {
+	name = strings.TrimSpace(name)
+	if err := validateFarmName(z.Kind, name); err != nil {
+		return err
+	}
+	z.Name = name
+	z.UpdatedAt = at.UTC()
+	return nil
+}
+
+// HoldsDevices reports whether devices may be assigned to the zone
+func (z *Zone) HoldsDevices() bool {
+	return z.Kind == ZoneKindZone
+}
+
+func validateFarmName(kind, name string) error {
+	if name == "" {
+		return fmt.Errorf("%s name is required", kind)
+	}
+	if len(name) > maxFarmNameLen {
+		return fmt.Errorf("%s name cannot exceed %d characters", kind, maxFarmNameLen)
+	}
+	return nil
+}
+
+// ZoneSensorSummary aggregates the latest measurements of a sensor type of the devices of a zone
+type ZoneSensorSummary struct {
+	SensorType string
+	Unit       string
+	Devices    int // devices with a measurement of the type
+	Readings   int // latest measurement of every channel of those devices
+	Min        float64
+	Max        float64
+	Average    float64
+	LatestAt   time.Time
+}
+
+// SummarizeZoneMeasurements aggregates the latest measurements of the devices of a zone by sensor
+// type, sorted by type. Measurements of bad quality are left out.
+func SummarizeZoneMeasurements(measurements []*Measurement) []*ZoneSensorSummary {
+	byType := make(map[string]*ZoneSensorSummary)
+	devices := make(map[string]map[string]bool)
+	sums := make(map[string]float64)
+
+	for _, measurement := range measurements {
+		if measurement.Quality == MeasurementQualityBad {
+			continue
+		}
+		summary, ok := byType[measurement.SensorType]
+		if !ok {
+			summary = &ZoneSensorSummary{SensorType: measurement.SensorType, Unit: measurement.Unit, Min: math.Inf(1), Max: math.Inf(-1)}
+			byType[measurement.SensorType] = summary
+			devices[measurement.SensorType] = make(map[string]bool)
+		}
+		devices[measurement.SensorType][measurement.MACAddress] = true
+		summary.Readings++
+		summary.Min = math.Min(summary.Min, measurement.Value)
+		summary.Max = math.Max(summary.Max, measurement.Value)
+		sums[measurement.SensorType] += measurement.Value
+		if measurement.Timestamp.After(summary.LatestAt) {
+			summary.LatestAt = measurement.Timestamp
+		}
+	}
+
+	summaries := make([]*ZoneSensorSummary, 0, len(byType))
+	for sensorType, summary := range byType {
+		summary.Devices = len(devices[sensorType])
+		summary.Average = sums[sensorType] / float64(summary.Readings)
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].SensorType < summaries[j].SensorType })
+	return summaries
+}
@@ -0,0 +1,45 @@
+package entities
+
+import "fmt"
+
+// Quotas of a farm, named in rejections and metrics
+const (
+	FarmQuotaDevices  = "devices"
+	FarmQuotaMessages = "messages_per_minute"
+)
+
+// Sources of the quota applied to a farm
+const (
+	FarmQuotaSourceDefault    = "default"    // the farm has no quota of its own
+	FarmQuotaSourceConfigured = "configured" // the quota comes from the configuration
+	FarmQuotaSourceOverride   = "override"   // the quota was set at runtime
+)
+
+// FarmQuota limits what one farm may use of a shared server. A zero limit is unlimited.
+type FarmQuota struct {
+	FarmID               string
+	MaxDevices           int // devices registered under the farm
+	MaxMessagesPerMinute int // telemetry messages published in the farm namespace per minute
+}
+
+// Validate ensures the quota is applicable
+func (q *FarmQuota) Validate() error {
+	if err := ValidateFarmID(q.FarmID); err != nil {
+		return err
+	}
+	if q.MaxDevices < 0 {
+		return fmt.Errorf("maximum devices cannot be negative")
+	}
+	if q.MaxMessagesPerMinute < 0 {
+		return fmt.Errorf("maximum messages per minute cannot be negative")
+	}
+	return nil
+}
+
+// FarmQuotaUsage is the quota applied to a farm and what the farm currently uses of it
+type FarmQuotaUsage struct {
+	Quota              *FarmQuota
+	Source             string
+	Devices            int
+	MessagesThisMinute int // telemetry messages received since the start of the minute, rejected ones included
+}
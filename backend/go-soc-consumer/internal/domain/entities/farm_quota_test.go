@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFarmQuota_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		quota   FarmQuota
+		wantErr string
+	}{
+		{name: "unlimited", quota: FarmQuota{FarmID: "farm-a"}},
+		{name: "limited", quota: FarmQuota{FarmID: "farm-a", MaxDevices: 50, MaxMessagesPerMinute: 600}},
+		{name: "invalid farm", quota: FarmQuota{FarmID: "farm a"}, wantErr: "invalid farm id"},
+		{name: "negative devices", quota: FarmQuota{FarmID: "farm-a", MaxDevices: -1}, wantErr: "maximum devices cannot be negative"},
+		{name: "negative messages", quota: FarmQuota{FarmID: "farm-a", MaxMessagesPerMinute: -1}, wantErr: "maximum messages per minute cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.quota.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFarm(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	farm, err := NewFarm(" la-esperanza ", " La Esperanza ", now)
+	require.NoError(t, err)
+	assert.Equal(t, "la-esperanza", farm.ID)
+	assert.Equal(t, "La Esperanza", farm.Name)
+
+	_, err = NewFarm("la/esperanza", "La Esperanza", now)
+	assert.ErrorContains(t, err, "invalid farm id")
+	_, err = NewFarm("la-esperanza", " ", now)
+	assert.ErrorContains(t, err, "farm name is required")
+}
+
+func TestNewZone(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	field, err := NewZone("la-esperanza", ZoneRequest{Name: "North field", Kind: "Field"}, nil, now)
+	require.NoError(t, err)
+	assert.NotEmpty(t, field.ID)
+	assert.Equal(t, ZoneKindField, field.Kind)
+	assert.False(t, field.HoldsDevices())
+
+	zone, err := NewZone("la-esperanza", ZoneRequest{Name: "Tomatoes", Kind: ZoneKindZone}, field, now)
+	require.NoError(t, err)
+	assert.Equal(t, field.ID, zone.ParentID)
+	assert.True(t, zone.HoldsDevices())
+
+	tests := []struct {
+		name    string
+		farmID  string
+		request ZoneRequest
+		parent  *Zone
+		wantErr string
+	}{
+		{name: "field with a parent", farmID: "la-esperanza", request: ZoneRequest{Name: "a", Kind: ZoneKindField}, parent: field, wantErr: "has no parent"},
+		{name: "zone without a field", farmID: "la-esperanza", request: ZoneRequest{Name: "a", Kind: ZoneKindZone}, wantErr: "needs a parent field"},
+		{name: "zone in a zone", farmID: "la-esperanza", request: ZoneRequest{Name: "a", Kind: ZoneKindZone}, parent: zone, wantErr: "must be a field"},
+		{name: "field of another farm", farmID: "el-paraiso", request: ZoneRequest{Name: "a", Kind: ZoneKindZone}, parent: field, wantErr: "must be a field of farm el-paraiso"},
+		{name: "unknown kind", farmID: "la-esperanza", request: ZoneRequest{Name: "a", Kind: "plot"}, wantErr: "kind must be"},
+		{name: "missing name", farmID: "la-esperanza", request: ZoneRequest{Kind: ZoneKindField}, wantErr: "field name is required"},
+	}
+	for _, tt := range tests {
+		_, err := NewZone(tt.farmID, tt.request, tt.parent, now)
+		assert.ErrorContains(t, err, tt.wantErr, tt.name)
+	}
+}
+
+func TestSummarizeZoneMeasurements(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	summaries := SummarizeZoneMeasurements([]*Measurement{
+		{MACAddress: "AA:BB:CC:DD:EE:01", SensorType: "soil_moisture", Channel: 0, Value: 30, Unit: "%", Quality: MeasurementQualityGood, Timestamp: at},
+		{MACAddress: "AA:BB:CC:DD:EE:01", SensorType: "soil_moisture", Channel: 1, Value: 40, Unit: "%", Quality: MeasurementQualityGood, Timestamp: at.Add(time.Minute)},
+		{MACAddress: "AA:BB:CC:DD:EE:02", SensorType: "soil_moisture", Value: 20, Unit: "%", Quality: MeasurementQualitySuspect, Timestamp: at},
+		{MACAddress: "AA:BB:CC:DD:EE:02", SensorType: "soil_moisture", Channel: 1, Value: 0, Unit: "%", Quality: MeasurementQualityBad, Timestamp: at},
+		{MACAddress: "AA:BB:CC:DD:EE:02", SensorType: "air_temperature", Value: 24.5, Unit: "°C", Quality: MeasurementQualityGood, Timestamp: at},
+	})
+
+	require.Len(t, summaries, 2)
+	assert.Equal(t, &ZoneSensorSummary{SensorType: "air_temperature", Unit: "°C", Devices: 1, Readings: 1, Min: 24.5, Max: 24.5, Average: 24.5, LatestAt: at}, summaries[0])
+	assert.Equal(t, &ZoneSensorSummary{SensorType: "soil_moisture", Unit: "%", Devices: 2, Readings: 3, Min: 20, Max: 40, Average: 30, LatestAt: at.Add(time.Minute)}, summaries[1])
+}
@@ -0,0 +1,54 @@
+package entities
+
+// FleetVersionCount counts the devices of a farm running a firmware and hardware version. Versions
+// are empty for devices that never reported them.
+type FleetVersionCount struct {
+	FarmID          string
+	FirmwareVersion string
+	HardwareVersion string
+	Devices         int
+	Online          int
+}
+
+// VersionCount counts the devices running a version
+type VersionCount struct {
+	Version string
+	Devices int
+	Online  int
+}
+
+// FarmVersions breaks down the devices of a farm by firmware version
+type FarmVersions struct {
+	FarmID   string
+	Devices  int
+	Upgraded int // devices running the target version, zero without a target
+	Firmware []VersionCount
+}
+
+// UpgradeProgress compares the fleet against a target firmware version
+type UpgradeProgress struct {
+	TargetVersion string
+	Upgraded      int // devices running the target version
+	Pending       int // devices running another reported version
+	Unknown       int // devices that never reported their firmware version
+}
+
+// Percent returns the share of devices running the target version, from 0 to 100
+func (p UpgradeProgress) Percent() float64 {
+	total := p.Upgraded + p.Pending + p.Unknown
+	if total == 0 {
+		return 0
+	}
+	return float64(p.Upgraded) * 100 / float64(total)
+}
+
+// FleetVersionReport summarizes the firmware and hardware versions the fleet runs. Versions are
+// listed with the most devices first.
+type FleetVersionReport struct {
+	Devices  int
+	Online   int
+	Firmware []VersionCount
+	Hardware []VersionCount
+	Farms    []FarmVersions   // devices on the shared topics are listed under an empty farm
+	Progress *UpgradeProgress // nil without a target version
+}
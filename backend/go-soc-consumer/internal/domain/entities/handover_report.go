@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+// Operator override kinds
+const (
+	OverrideKindConsumptionPaused = "consumption_paused" // MQTT consumption was paused by an operator
+	OverrideKindIrrigationManual  = "irrigation_manual"  // scheduled irrigation of a device was suspended by an operator
+	OverrideKindMessageTracing    = "message_tracing"    // full messages are captured by a trace session started by an operator
+)
+
+// OperatorOverride is a manual change to the normal operation of the server that is still in effect
+type OperatorOverride struct {
+	Kind        string
+	Description string
+	Since       *time.Time // nil when unknown
+}
+
+// HandoverReport summarizes the operational state handed over at a shift change
+type HandoverReport struct {
+	GeneratedAt     time.Time
+	OpenAlerts      []*Alert              // firing alerts, most severe first
+	OfflineDevices  []*Device             // longest silent first
+	DegradedSensors []*SensorStreamHealth // least healthy first
+	Overrides       []*OperatorOverride
+	// ScheduledJobs are the queued and running background jobs, such as reprocessing or imports,
+	// earliest first
+	ScheduledJobs []*Job
+}
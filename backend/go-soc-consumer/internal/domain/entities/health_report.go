@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+)
+
+// Health report statuses
+const (
+	// HealthStatusOK means every dependency check passed
+	HealthStatusOK = "ok"
+	// HealthStatusDegraded means at least one dependency check failed
+	HealthStatusDegraded = "degraded"
+)
+
+// Dependency check statuses
+const (
+	DependencyStatusOK      = "ok"
+	DependencyStatusFailing = "failing"
+)
+
+// DependencyHealth is the outcome of checking one dependency
+type DependencyHealth struct {
+	Status  string
+	Latency time.Duration
+	Error   string
+}
+
+// HealthReport describes the health of the service and of the dependencies it relies on
+type HealthReport struct {
+	EventType    string
+	Status       string
+	Version      string
+	StartedAt    time.Time
+	Uptime       time.Duration
+	CheckedAt    time.Time
+	Dependencies map[string]DependencyHealth
+}
+
+// NewHealthReport creates a report from the dependency outcomes; it is degraded when any of them failed
+func NewHealthReport(version string, startedAt, checkedAt time.Time, dependencies map[string]DependencyHealth) *HealthReport {
+	status := HealthStatusOK
+	for _, dependency := range dependencies {
+		if dependency.Status != DependencyStatusOK {
+			status = HealthStatusDegraded
+			break
+		}
+	}
+
+	return &HealthReport{
+		EventType:    events.SystemHealthEventType,
+		Status:       status,
+		Version:      version,
+		StartedAt:    startedAt,
+		Uptime:       checkedAt.Sub(startedAt),
+		CheckedAt:    checkedAt,
+		Dependencies: dependencies,
+	}
+}
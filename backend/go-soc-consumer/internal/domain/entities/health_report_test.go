@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+)
+
+func TestNewHealthReport(t *testing.T) {
+	startedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	checkedAt := startedAt.Add(90 * time.Minute)
+
+	tests := []struct {
+		name           string
+		dependencies   map[string]DependencyHealth
+		expectedStatus string
+	}{
+		{
+			name:           "no dependencies",
+			expectedStatus: HealthStatusOK,
+		},
+		{
+			name: "all dependencies healthy",
+			dependencies: map[string]DependencyHealth{
+				"database": {Status: DependencyStatusOK, Latency: 2 * time.Millisecond},
+				"nats":     {Status: DependencyStatusOK},
+			},
+			expectedStatus: HealthStatusOK,
+		},
+		{
+			name: "one dependency failing",
+			dependencies: map[string]DependencyHealth{
+				"database": {Status: DependencyStatusOK},
+				"mqtt":     {Status: DependencyStatusFailing, Error: "MQTT client is not connected"},
+			},
+			expectedStatus: HealthStatusDegraded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewHealthReport("1.4.0", startedAt, checkedAt, tt.dependencies)
+
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			assert.Equal(t, events.SystemHealthEventType, report.EventType)
+			assert.Equal(t, "1.4.0", report.Version)
+			assert.Equal(t, 90*time.Minute, report.Uptime)
+			assert.Equal(t, checkedAt, report.CheckedAt)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InboxNotification is a notification kept in the in-app inbox of a user until it is pruned
+type InboxNotification struct {
+	ID        string
+	User      string
+	AlertID   string // event ID of the alert the notification is about
+	Rule      string
+	Severity  string
+	Status    string // status of the alert
+	Subject   string
+	Body      string
+	CreatedAt time.Time
+	ReadAt    *time.Time // nil while unread
+}
+
+// NewInboxNotification creates the inbox entry of a notification about a single alert
+func NewInboxNotification(notification *Notification) (*InboxNotification, error) {
+	if notification == nil || len(notification.Alerts) != 1 {
+		return nil, fmt.Errorf("inbox notifications must be about exactly one alert")
+	}
+	if notification.User == "" {
+		return nil, fmt.Errorf("inbox notification user is required")
+	}
+
+	alert := notification.Alerts[0]
+	return &InboxNotification{
+		ID:        uuid.New().String(),
+		User:      notification.User,
+		AlertID:   alert.EventID,
+		Rule:      alert.Rule,
+		Severity:  alert.Severity,
+		Status:    alert.Status,
+		Subject:   notification.Subject,
+		Body:      notification.Body,
+		CreatedAt: notification.CreatedAt.UTC(),
+	}, nil
+}
+
+// Read reports whether the user has read the notification
+func (n *InboxNotification) Read() bool {
+	return n.ReadAt != nil
+}
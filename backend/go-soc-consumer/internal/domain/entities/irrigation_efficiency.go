@@ -0,0 +1,185 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// IrrigationZone is a set of devices irrigated together. FlowRate is the liters per minute delivered
+// by the valve of each device, 0 when unknown.
+type IrrigationZone struct {
+	Name         string
+	MACAddresses []string
+	FlowRate     float64
+}
+
+// IrrigationEvent is one irrigation by the valve of a device, from its opening to its closing, with
+// the soil moisture the device measured around it. Moisture values are nil when the device reported
+// none in the windows considered.
+type IrrigationEvent struct {
+	MACAddress     string
+	StartedAt      time.Time
+	EndedAt        time.Time
+	Liters         float64        // 0 when the zone has no flow rate
+	MoistureBefore *float64       // last reading at or before the opening
+	MoistureAfter  *float64       // highest reading after the opening, until the settle window after the closing ends
+	TimeToTarget   *time.Duration // from the opening to the first reading at or above the target
+}
+
+// Duration returns how long the valve stayed open
+func (e *IrrigationEvent) Duration() time.Duration {
+	return e.EndedAt.Sub(e.StartedAt)
+}
+
+// MoistureGain returns how much the moisture rose, and false when either reading is missing
+func (e *IrrigationEvent) MoistureGain() (float64, bool) {
+	if e.MoistureBefore == nil || e.MoistureAfter == nil {
+		return 0, false
+	}
+	return *e.MoistureAfter - *e.MoistureBefore, true
+}
+
+// GainPerLiter returns the moisture gain per liter applied, and false when the gain or the liters
+// are unknown
+func (e *IrrigationEvent) GainPerLiter() (float64, bool) {
+	gain, ok := e.MoistureGain()
+	if !ok || e.Liters <= 0 {
+		return 0, false
+	}
+	return gain / e.Liters, true
+}
+
+// IrrigationEventsFrom pairs every opening of a valve with the closing following it and measures
+// the moisture around each irrigation. Transitions and readings are those of one device, in any
+// order; an opening without a closing, such as a valve still open, yields no event. Readings are
+// looked for up to settle before the opening and after the closing.
+func IrrigationEventsFrom(transitions []*ValveTransition, readings []*Measurement, flowRate, target float64, settle time.Duration) []*IrrigationEvent {
+	transitions = append([]*ValveTransition(nil), transitions...)
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].ChangedAt.Before(transitions[j].ChangedAt) })
+	readings = append([]*Measurement(nil), readings...)
+	sort.SliceStable(readings, func(i, j int) bool { return readings[i].Timestamp.Before(readings[j].Timestamp) })
+
+	var events []*IrrigationEvent
+	var opened *ValveTransition
+	for _, transition := range transitions {
+		switch transition.State {
+		case ValveStateOpen:
+			// A repeated opening keeps the first, the valve did not close in between
+			if opened == nil {
+				opened = transition
+			}
+		case ValveStateClosed:
+			if opened == nil {
+				continue
+			}
+			event := &IrrigationEvent{
+				MACAddress: transition.MACAddress,
+				StartedAt:  opened.ChangedAt,
+				EndedAt:    transition.ChangedAt,
+				Liters:     flowRate * transition.ChangedAt.Sub(opened.ChangedAt).Minutes(),
+			}
+			event.measure(readings, target, settle)
+			events = append(events, event)
+			opened = nil
+		}
+	}
+	return events
+}
+
+// measure sets the moisture of the event from the readings, sorted oldest first
+func (e *IrrigationEvent) measure(readings []*Measurement, target float64, settle time.Duration) {
+	settledAt := e.EndedAt.Add(settle)
+	for _, reading := range readings {
+		switch {
+		case reading.Timestamp.Before(e.StartedAt.Add(-settle)):
+			continue
+		case !reading.Timestamp.After(e.StartedAt):
+			value := reading.Value
+			e.MoistureBefore = &value
+		case !reading.Timestamp.After(settledAt):
+			if e.MoistureAfter == nil || reading.Value > *e.MoistureAfter {
+				value := reading.Value
+				e.MoistureAfter = &value
+			}
+			// Soil already at the target before the irrigation has no time to target
+			if e.TimeToTarget == nil && reading.Value >= target && (e.MoistureBefore == nil || *e.MoistureBefore < target) {
+				elapsed := reading.Timestamp.Sub(e.StartedAt)
+				e.TimeToTarget = &elapsed
+			}
+		}
+	}
+}
+
+// IrrigationZoneEfficiency summarizes the irrigations of a zone over a range of time
+type IrrigationZoneEfficiency struct {
+	Zone                string
+	From                time.Time
+	To                  time.Time
+	Events              []*IrrigationEvent // oldest first
+	TotalLiters         float64
+	AverageGain         *float64       // over the events with a known gain
+	AverageGainPerLiter *float64       // over the events with a known gain per liter
+	AverageTimeToTarget *time.Duration // over the events reaching the target
+	TargetReached       int            // events whose moisture reached the target
+	// GainPerLiterChange is the relative change of the average gain per liter of the later half of
+	// the events compared to the earlier half, nil with fewer than two such events in each half
+	GainPerLiterChange *float64
+	// Declining is set when GainPerLiterChange fell below the negated decline threshold, a sign of
+	// clogged emitters or of a change of the soil
+	Declining bool
+}
+
+// NewIrrigationZoneEfficiency summarizes the events of a zone; declineThreshold is the relative drop
+// of the gain per liter flagged as a decline, such as 0.3 for 30%
+func NewIrrigationZoneEfficiency(zone string, from, to time.Time, events []*IrrigationEvent, declineThreshold float64) *IrrigationZoneEfficiency {
+	events = append([]*IrrigationEvent(nil), events...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].StartedAt.Before(events[j].StartedAt) })
+
+	efficiency := &IrrigationZoneEfficiency{Zone: zone, From: from, To: to, Events: events}
+	var gains, gainsPerLiter []float64
+	var timeToTarget time.Duration
+	for _, event := range events {
+		efficiency.TotalLiters += event.Liters
+		if gain, ok := event.MoistureGain(); ok {
+			gains = append(gains, gain)
+		}
+		if gainPerLiter, ok := event.GainPerLiter(); ok {
+			gainsPerLiter = append(gainsPerLiter, gainPerLiter)
+		}
+		if event.TimeToTarget != nil {
+			efficiency.TargetReached++
+			timeToTarget += *event.TimeToTarget
+		}
+	}
+
+	efficiency.AverageGain = average(gains)
+	efficiency.AverageGainPerLiter = average(gainsPerLiter)
+	if efficiency.TargetReached > 0 {
+		averageTimeToTarget := timeToTarget / time.Duration(efficiency.TargetReached)
+		efficiency.AverageTimeToTarget = &averageTimeToTarget
+	}
+
+	half := len(gainsPerLiter) / 2
+	if half >= 2 {
+		earlier, later := average(gainsPerLiter[:half]), average(gainsPerLiter[len(gainsPerLiter)-half:])
+		if *earlier > 0 {
+			change := (*later - *earlier) / *earlier
+			efficiency.GainPerLiterChange = &change
+			efficiency.Declining = change < -declineThreshold
+		}
+	}
+	return efficiency
+}
+
+// average returns the mean of the values, nil when there are none
+func average(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	return &mean
+}
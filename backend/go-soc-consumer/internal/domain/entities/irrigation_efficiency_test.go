@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIrrigationEventsFrom(t *testing.T) {
+	const mac = "AA:BB:CC:DD:EE:01"
+	start := time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC)
+	transition := func(state string, at time.Duration) *ValveTransition {
+		return &ValveTransition{MACAddress: mac, State: state, ChangedAt: start.Add(at)}
+	}
+	reading := func(value float64, at time.Duration) *Measurement {
+		return &Measurement{MACAddress: mac, SensorType: "soil_moisture", Value: value, Timestamp: start.Add(at)}
+	}
+
+	transitions := []*ValveTransition{
+		transition(ValveStateClosed, -time.Hour), // closing without an opening in the range
+		transition(ValveStateOpen, 0),
+		transition(ValveStateOpen, 5*time.Minute),
+		transition(ValveStateClosed, 20*time.Minute),
+		transition(ValveStateOpen, 6*time.Hour), // still open
+	}
+	readings := []*Measurement{
+		reading(28, 50*time.Minute),
+		reading(18, -3*time.Hour), // before the settle window
+		reading(22, -10*time.Minute),
+		reading(20, -5*time.Minute),
+		reading(27, 15*time.Minute),
+		reading(30, 40*time.Minute),
+		reading(40, 3*time.Hour), // after the settle window
+	}
+
+	events := IrrigationEventsFrom(transitions, readings, 12, 25, time.Hour)
+	require.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, start, event.StartedAt)
+	assert.Equal(t, 20*time.Minute, event.Duration())
+	assert.InDelta(t, 240, event.Liters, 1e-9)
+	require.NotNil(t, event.MoistureBefore)
+	require.NotNil(t, event.MoistureAfter)
+	assert.Equal(t, 20.0, *event.MoistureBefore)
+	assert.Equal(t, 30.0, *event.MoistureAfter)
+	require.NotNil(t, event.TimeToTarget)
+	assert.Equal(t, 15*time.Minute, *event.TimeToTarget)
+
+	gain, ok := event.MoistureGain()
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, gain)
+	gainPerLiter, ok := event.GainPerLiter()
+	assert.True(t, ok)
+	assert.InDelta(t, 10.0/240, gainPerLiter, 1e-9)
+
+	t.Run("should leave the moisture unknown without readings or flow rate", func(t *testing.T) {
+		events := IrrigationEventsFrom(transitions, nil, 0, 25, time.Hour)
+		require.Len(t, events, 1)
+		assert.Zero(t, events[0].Liters)
+		assert.Nil(t, events[0].MoistureBefore)
+		assert.Nil(t, events[0].TimeToTarget)
+		_, ok := events[0].GainPerLiter()
+		assert.False(t, ok)
+	})
+
+	t.Run("should not time soil already at the target", func(t *testing.T) {
+		events := IrrigationEventsFrom(transitions, []*Measurement{reading(26, -time.Minute), reading(31, 30*time.Minute)}, 12, 25, time.Hour)
+		require.Len(t, events, 1)
+		assert.Nil(t, events[0].TimeToTarget)
+	})
+}
+
+func TestNewIrrigationZoneEfficiency(t *testing.T) {
+	start := time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC)
+	event := func(day int, before, after, liters float64) *IrrigationEvent {
+		startedAt := start.AddDate(0, 0, day)
+		return &IrrigationEvent{StartedAt: startedAt, EndedAt: startedAt.Add(20 * time.Minute), Liters: liters, MoistureBefore: &before, MoistureAfter: &after}
+	}
+
+	t.Run("should flag a declining gain per liter", func(t *testing.T) {
+		reached := 30 * time.Minute
+		events := []*IrrigationEvent{
+			event(3, 20, 24, 200),
+			event(0, 20, 30, 200),
+			event(1, 20, 30, 200),
+			event(2, 20, 24, 200),
+		}
+		events[1].TimeToTarget = &reached
+
+		efficiency := NewIrrigationZoneEfficiency("zone_a", start, start.AddDate(0, 0, 4), events, 0.3)
+		assert.Equal(t, start, efficiency.Events[0].StartedAt, "events are sorted oldest first")
+		assert.Equal(t, 800.0, efficiency.TotalLiters)
+		require.NotNil(t, efficiency.AverageGain)
+		assert.Equal(t, 7.0, *efficiency.AverageGain)
+		require.NotNil(t, efficiency.AverageGainPerLiter)
+		assert.InDelta(t, 0.035, *efficiency.AverageGainPerLiter, 1e-9)
+		assert.Equal(t, 1, efficiency.TargetReached)
+		assert.Equal(t, &reached, efficiency.AverageTimeToTarget)
+		require.NotNil(t, efficiency.GainPerLiterChange)
+		assert.InDelta(t, -0.6, *efficiency.GainPerLiterChange, 1e-9)
+		assert.True(t, efficiency.Declining)
+	})
+
+	t.Run("should not compare halves of fewer than two events", func(t *testing.T) {
+		efficiency := NewIrrigationZoneEfficiency("zone_a", start, start.AddDate(0, 0, 4), []*IrrigationEvent{event(0, 20, 30, 200), event(1, 20, 22, 200), event(2, 20, 21, 200)}, 0.3)
+		assert.Nil(t, efficiency.GainPerLiterChange)
+		assert.False(t, efficiency.Declining)
+	})
+
+	t.Run("should leave averages unknown without events", func(t *testing.T) {
+		efficiency := NewIrrigationZoneEfficiency("zone_a", start, start.AddDate(0, 0, 4), nil, 0.3)
+		assert.Zero(t, efficiency.TotalLiters)
+		assert.Nil(t, efficiency.AverageGain)
+		assert.Nil(t, efficiency.AverageGainPerLiter)
+		assert.Nil(t, efficiency.AverageTimeToTarget)
+	})
+}
@@ -0,0 +1,262 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Irrigation strategies compared by the variants of an experiment
+const (
+	IrrigationStrategyRuleBased = "rule_based" // fixed schedules and moisture thresholds
+	IrrigationStrategyETBased   = "et_based"   // water balance from the reference evapotranspiration
+	IrrigationStrategyML        = "ml"         // predictions of a moisture model
+)
+
+// Limits of the experiment fields set by API clients
+const (
+	maxExperimentNameLen       = 100
+	maxExperimentHypothesisLen = 1000
+	maxExperimentVariants      = 8
+	maxExperimentMetadata      = 20
+	maxExperimentMetadataKey   = 64
+	maxExperimentMetadataValue = 256
+)
+
+// ExperimentVariant is an irrigation strategy applied to a set of irrigation zones during an
+// experiment
+type ExperimentVariant struct {
+	Name     string
+	Strategy string
+	Zones    []string // irrigation zone names, lowercased
+}
+
+// IrrigationExperiment compares irrigation strategies by assigning irrigation zones to variants.
+// The first variant is the baseline the others are compared to. The experiment only records which
+// strategy irrigates each zone; the schedules and models applying it are configured as usual.
+type IrrigationExperiment struct {
+	ID         string
+	Name       string
+	Hypothesis string
+	Metadata   map[string]string // free-form, such as the crop, season or researcher
+	Variants   []ExperimentVariant
+	StartedAt  time.Time
+	EndedAt    *time.Time // nil while the experiment runs
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IrrigationExperimentRequest holds the fields of an experiment set when it is created
+type IrrigationExperimentRequest struct {
+	Name       string
+	Hypothesis string
+	Metadata   map[string]string
+	Variants   []ExperimentVariant
+	StartAt    time.Time // zero starts the experiment when it is created
+}
+
+// NewIrrigationExperiment creates an experiment from the request. Variant names are unique, and a
+// zone belongs to a single variant.
+func NewIrrigationExperiment(request IrrigationExperimentRequest, now time.Time) (*IrrigationExperiment, error) {
+	experiment := &IrrigationExperiment{
+		ID:         uuid.New().String(),
+		Name:       strings.TrimSpace(request.Name),
+		Hypothesis: strings.TrimSpace(request.Hypothesis),
+		Metadata:   make(map[string]string, len(request.Metadata)),
+		StartedAt:  request.StartAt.UTC(),
+		CreatedAt:  now.UTC(),
+		UpdatedAt:  now.UTC(),
+	}
+	if request.StartAt.IsZero() {
+		experiment.StartedAt = now.UTC()
+	}
+
+	switch {
+	case experiment.Name == "":
+		return nil, fmt.Errorf("experiment name is required")
+	case len(experiment.Name) > maxExperimentNameLen:
+		return nil, fmt.Errorf("experiment name cannot exceed %d characters", maxExperimentNameLen)
+	case len(experiment.Hypothesis) > maxExperimentHypothesisLen:
+		return nil, fmt.Errorf("hypothesis cannot exceed %d characters", maxExperimentHypothesisLen)
+	case len(request.Metadata) > maxExperimentMetadata:
+		return nil, fmt.Errorf("metadata cannot have more than %d entries", maxExperimentMetadata)
+	case len(request.Variants) < 2:
+		return nil, fmt.Errorf("an experiment compares at least 2 variants")
+	case len(request.Variants) > maxExperimentVariants:
+		return nil, fmt.Errorf("an experiment compares at most %d variants", maxExperimentVariants)
+	}
+
+	for key, value := range request.Metadata {
+		key = strings.TrimSpace(key)
+		if key == "" || len(key) > maxExperimentMetadataKey {
+			return nil, fmt.Errorf("metadata keys must have 1 to %d characters", maxExperimentMetadataKey)
+		}
+		if len(value) > maxExperimentMetadataValue {
+			return nil, fmt.Errorf("metadata value of %s cannot exceed %d characters", key, maxExperimentMetadataValue)
+		}
+		experiment.Metadata[key] = value
+	}
+
+	names := make(map[string]bool, len(request.Variants))
+	zones := make(map[string]string)
+	for _, requested := range request.Variants {
+		variant := ExperimentVariant{
+			Name:     strings.TrimSpace(requested.Name),
+			Strategy: strings.ToLower(strings.TrimSpace(requested.Strategy)),
+		}
+		if variant.Name == "" {
+			return nil, fmt.Errorf("variant name is required")
+		}
+		if names[strings.ToLower(variant.Name)] {
+			return nil, fmt.Errorf("variant %s is defined twice", variant.Name)
+		}
+		names[strings.ToLower(variant.Name)] = true
+		if err := ValidateIrrigationStrategy(variant.Strategy); err != nil {
+			return nil, fmt.Errorf("variant %s: %w", variant.Name, err)
+		}
+		if len(requested.Zones) == 0 {
+			return nil, fmt.Errorf("variant %s has no zones", variant.Name)
+		}
+		for _, zone := range requested.Zones {
+			zone = strings.ToLower(strings.TrimSpace(zone))
+			if zone == "" {
+				return nil, fmt.Errorf("variant %s has an empty zone name", variant.Name)
+			}
+			if other, ok := zones[zone]; ok {
+				return nil, fmt.Errorf("zone %s is assigned to variants %s and %s", zone, other, variant.Name)
+			}
+			zones[zone] = variant.Name
+			variant.Zones = append(variant.Zones, zone)
+		}
+		experiment.Variants = append(experiment.Variants, variant)
+	}
+	return experiment, nil
+}
+
+// ValidateIrrigationStrategy checks that the strategy is one of the compared strategies
+func ValidateIrrigationStrategy(strategy string) error {
+	switch strategy {
+	case IrrigationStrategyRuleBased, IrrigationStrategyETBased, IrrigationStrategyML:
+		return nil
+	}
+	return fmt.Errorf("strategy must be %s, %s or %s", IrrigationStrategyRuleBased, IrrigationStrategyETBased, IrrigationStrategyML)
+}
+
+// Zones returns the zones of every variant, in the order of the variants
+func (e *IrrigationExperiment) Zones() []string {
+	var zones []string
+	for _, variant := range e.Variants {
+		zones = append(zones, variant.Zones...)
+	}
+	return zones
+}
+
+// Ended reports whether the experiment was ended
+func (e *IrrigationExperiment) Ended() bool {
+	return e.EndedAt != nil
+}
+
+// End stops the experiment at the given time, which cannot precede its start
+func (e *IrrigationExperiment) End(at time.Time) error {
+	if e.EndedAt != nil {
+		return fmt.Errorf("experiment %s already ended at %s", e.Name, e.EndedAt.Format(time.RFC3339))
+	}
+	if at.Before(e.StartedAt) {
+		return fmt.Errorf("experiment %s starts at %s and cannot end before", e.Name, e.StartedAt.Format(time.RFC3339))
+	}
+	endedAt := at.UTC()
+	e.EndedAt = &endedAt
+	e.UpdatedAt = endedAt
+	return nil
+}
+
+// ExperimentVariantOutcome aggregates the irrigations of the zones of a variant over the range of a
+// report. The comparisons to the baseline are relative differences, such as 0.2 for 20% more, nil
+// for the baseline itself or when either value is unknown.
+type ExperimentVariantOutcome struct {
+	Variant             string
+	Strategy            string
+	Zones               []string
+	Irrigations         int
+	TotalLiters         float64
+	LitersPerZone       float64
+	AverageGain         *float64       // over the irrigations with a known gain
+	AverageGainPerLiter *float64       // over the irrigations with a known gain per liter
+	AverageTimeToTarget *time.Duration // over the irrigations reaching the target
+	TargetReachedRate   *float64       // share of the irrigations reaching the target, nil without irrigations
+
+	GainPerLiterVsBaseline  *float64
+	LitersPerZoneVsBaseline *float64
+}
+
+// IrrigationExperimentReport compares the outcomes of the variants of an experiment over [From, To)
+type IrrigationExperimentReport struct {
+	Experiment *IrrigationExperiment
+	From       time.Time
+	To         time.Time
+	Outcomes   []*ExperimentVariantOutcome // in the order of the variants, baseline first
+	// Leader is the variant with the highest average gain per liter, empty when none is known
+	Leader string
+}
+
+// NewIrrigationExperimentReport aggregates the efficiency of each zone of the experiment, keyed by
+// zone name, into the outcomes of the variants. Zones without an efficiency count as not irrigated.
+func NewIrrigationExperimentReport(experiment *IrrigationExperiment, from, to time.Time, efficiencies map[string]*IrrigationZoneEfficiency) *IrrigationExperimentReport {
+	report := &IrrigationExperimentReport{Experiment: experiment, From: from, To: to}
+
+	var leaderGain float64
+	for _, variant := range experiment.Variants {
+		var events []*IrrigationEvent
+		for _, zone := range variant.Zones {
+			if efficiency, ok := efficiencies[zone]; ok {
+				events = append(events, efficiency.Events...)
+			}
+		}
+		summary := NewIrrigationZoneEfficiency(variant.Name, from, to, events, 0)
+
+		outcome := &ExperimentVariantOutcome{
+			Variant:             variant.Name,
+			Strategy:            variant.Strategy,
+			Zones:               variant.Zones,
+			Irrigations:         len(summary.Events),
+			TotalLiters:         summary.TotalLiters,
+			LitersPerZone:       summary.TotalLiters / float64(len(variant.Zones)),
+			AverageGain:         summary.AverageGain,
+			AverageGainPerLiter: summary.AverageGainPerLiter,
+			AverageTimeToTarget: summary.AverageTimeToTarget,
+		}
+		if outcome.Irrigations > 0 {
+			rate := float64(summary.TargetReached) / float64(outcome.Irrigations)
+			outcome.TargetReachedRate = &rate
+		}
+		if gain := outcome.AverageGainPerLiter; gain != nil && (report.Leader == "" || *gain > leaderGain) {
+			report.Leader, leaderGain = variant.Name, *gain
+		}
+		report.Outcomes = append(report.Outcomes, outcome)
+	}
+
+	if len(report.Outcomes) == 0 {
+		return report
+	}
+	baseline := report.Outcomes[0]
+	for _, outcome := range report.Outcomes[1:] {
+		if baseline.AverageGainPerLiter != nil && outcome.AverageGainPerLiter != nil {
+			outcome.GainPerLiterVsBaseline = relativeDifference(*outcome.AverageGainPerLiter, *baseline.AverageGainPerLiter)
+		}
+		if baseline.Irrigations > 0 && outcome.Irrigations > 0 {
+			outcome.LitersPerZoneVsBaseline = relativeDifference(outcome.LitersPerZone, baseline.LitersPerZone)
+		}
+	}
+	return report
+}
+
+// relativeDifference returns (value - baseline) / baseline, nil for a baseline that is not positive
+func relativeDifference(value, baseline float64) *float64 {
+	if baseline <= 0 {
+		return nil
+	}
+	difference := (value - baseline) / baseline
+	return &difference
+}
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testExperimentRequest() IrrigationExperimentRequest {
+	return IrrigationExperimentRequest{
+		Name:       "Tomato season 2025",
+		Hypothesis: "ET-based irrigation saves water without drying the soil",
+		Metadata:   map[string]string{"crop": "tomato"},
+		Variants: []ExperimentVariant{
+			{Name: "Control", Strategy: "rule_based", Zones: []string{"North", "south"}},
+			{Name: "ET", Strategy: "ET_based", Zones: []string{" east "}},
+		},
+	}
+}
+
+func TestNewIrrigationExperiment(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should normalize the variants", func(t *testing.T) {
+		experiment, err := NewIrrigationExperiment(testExperimentRequest(), now)
+		require.NoError(t, err)
+		assert.NotEmpty(t, experiment.ID)
+		assert.Equal(t, now, experiment.StartedAt)
+		assert.Equal(t, IrrigationStrategyETBased, experiment.Variants[1].Strategy)
+		assert.Equal(t, []string{"north", "south", "east"}, experiment.Zones())
+		assert.False(t, experiment.Ended())
+	})
+
+	t.Run("should reject invalid experiments", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			mutate  func(*IrrigationExperimentRequest)
+			wantErr string
+		}{
+			{name: "missing name", mutate: func(r *IrrigationExperimentRequest) { r.Name = " " }, wantErr: "name is required"},
+			{name: "single variant", mutate: func(r *IrrigationExperimentRequest) { r.Variants = r.Variants[:1] }, wantErr: "at least 2 variants"},
+			{name: "unknown strategy", mutate: func(r *IrrigationExperimentRequest) { r.Variants[1].Strategy = "manual" }, wantErr: "strategy must be"},
+			{name: "duplicate variant", mutate: func(r *IrrigationExperimentRequest) { r.Variants[1].Name = "control" }, wantErr: "defined twice"},
+			{name: "shared zone", mutate: func(r *IrrigationExperimentRequest) { r.Variants[1].Zones = []string{"NORTH"} }, wantErr: "assigned to variants Control and ET"},
+			{name: "variant without zones", mutate: func(r *IrrigationExperimentRequest) { r.Variants[1].Zones = nil }, wantErr: "has no zones"},
+			{name: "empty metadata key", mutate: func(r *IrrigationExperimentRequest) { r.Metadata = map[string]string{"": "x"} }, wantErr: "metadata keys"},
+		}
+		for _, tt := range tests {
+			request := testExperimentRequest()
+			tt.mutate(&request)
+			_, err := NewIrrigationExperiment(request, now)
+			assert.ErrorContains(t, err, tt.wantErr, tt.name)
+		}
+	})
+}
+
+func TestIrrigationExperiment_End(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	experiment, err := NewIrrigationExperiment(testExperimentRequest(), now)
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, experiment.End(now.Add(-time.Hour)), "cannot end before")
+	require.NoError(t, experiment.End(now.Add(time.Hour)))
+	assert.True(t, experiment.Ended())
+	assert.ErrorContains(t, experiment.End(now.Add(2*time.Hour)), "already ended")
+}
+
+func TestNewIrrigationExperimentReport(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	experiment, err := NewIrrigationExperiment(testExperimentRequest(), now)
+	require.NoError(t, err)
+
+	moisture := func(value float64) *float64 { return &value }
+	toTarget := 30 * time.Minute
+	event := func(liters, before, after float64, reached bool) *IrrigationEvent {
+		event := &IrrigationEvent{StartedAt: now, EndedAt: now.Add(time.Hour), Liters: liters, MoistureBefore: moisture(before), MoistureAfter: moisture(after)}
+		if reached {
+			event.TimeToTarget = &toTarget
+		}
+		return event
+	}
+
+	report := NewIrrigationExperimentReport(experiment, now, now.Add(24*time.Hour), map[string]*IrrigationZoneEfficiency{
+		"north": {Zone: "north", Events: []*IrrigationEvent{event(100, 20, 30, true)}},
+		"south": {Zone: "south", Events: []*IrrigationEvent{event(100, 20, 30, false)}},
+		"east":  {Zone: "east", Events: []*IrrigationEvent{event(80, 20, 32, true)}},
+	})
+
+	require.Len(t, report.Outcomes, 2)
+	control, et := report.Outcomes[0], report.Outcomes[1]
+	assert.Equal(t, 2, control.Irrigations)
+	assert.Equal(t, float64(100), control.LitersPerZone)
+	assert.Equal(t, 0.5, *control.TargetReachedRate)
+	assert.Nil(t, control.GainPerLiterVsBaseline)
+
+	assert.Equal(t, float64(80), et.LitersPerZone)
+	assert.InDelta(t, -0.2, *et.LitersPerZoneVsBaseline, 1e-9)
+	assert.InDelta(t, 0.5, *et.GainPerLiterVsBaseline, 1e-9)
+	assert.Equal(t, "ET", report.Leader)
+}
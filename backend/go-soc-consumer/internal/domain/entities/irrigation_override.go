@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxIrrigationOverrideReasonLen bounds the reason an operator gives for an override
+const maxIrrigationOverrideReasonLen = 255
+
+// IrrigationOverride puts the valve of a device under manual control until it expires: the
+// scheduler neither opens nor closes the valve while it is in effect
+type IrrigationOverride struct {
+	MACAddress string
+	Reason     string
+	Until      time.Time
+	CreatedAt  time.Time
+}
+
+// NewIrrigationOverride creates an override of the device lasting until the given time
+func NewIrrigationOverride(macAddress, reason string, until, now time.Time) (*IrrigationOverride, error) {
+	override := &IrrigationOverride{
+		MACAddress: macAddress,
+		Reason:     strings.TrimSpace(reason),
+		Until:      until.UTC(),
+		CreatedAt:  now.UTC(),
+	}
+	if !override.Active(now) {
+		return nil, fmt.Errorf("override must last until a future time")
+	}
+	if len(override.Reason) > maxIrrigationOverrideReasonLen {
+		return nil, fmt.Errorf("override reason cannot exceed %d characters", maxIrrigationOverrideReasonLen)
+	}
+	return override, nil
+}
+
+// Active reports whether the override is still in effect at the given time
+func (o *IrrigationOverride) Active(now time.Time) bool {
+	return now.Before(o.Until)
+}
+
+// OperatorOverride returns the override as reported in the shift handover
+func (o *IrrigationOverride) OperatorOverride() *OperatorOverride {
+	description := fmt.Sprintf("Irrigation of %s is under manual control until %s", o.MACAddress, o.Until.Format(time.RFC3339))
+	if o.Reason != "" {
+		description += ": " + o.Reason
+	}
+	since := o.CreatedAt
+	return &OperatorOverride{
+		Kind:        OverrideKindIrrigationManual,
+		Description: description,
+		Since:       &since,
+	}
+}
@@ -0,0 +1,182 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Irrigation schedule limits
+const (
+	MinIrrigationDuration        = time.Minute
+	MaxIrrigationDuration        = 12 * time.Hour
+	maxIrrigationScheduleNameLen = 100
+)
+
+// IrrigationSchedule opens the valve of a device at the times of a cron expression and closes it
+// once the duration elapsed
+type IrrigationSchedule struct {
+	ID         string
+	MACAddress string
+	Name       string
+	Cron       string // five-field cron expression, evaluated in the scheduler time zone
+	Duration   time.Duration
+	Enabled    bool
+	LastRunAt  *time.Time // start of the last run, nil before the first one
+	RunEndsAt  *time.Time // when the valve opened by the running run is closed, nil when not running
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IrrigationScheduleRequest holds the fields of a schedule set when it is created or updated
+type IrrigationScheduleRequest struct {
+	Name     string
+	Cron     string
+	Duration time.Duration
+	Enabled  bool
+}
+
+// IrrigationRun is a run of a schedule, from the valve opening to its closing
+type IrrigationRun struct {
+	Schedule *IrrigationSchedule
+	Start    time.Time
+	End      time.Time
+}
+
+// NewIrrigationSchedule creates a schedule of the device
+func NewIrrigationSchedule(macAddress string, request IrrigationScheduleRequest) (*IrrigationSchedule, error) {
+	now := time.Now().UTC()
+	schedule := &IrrigationSchedule{
+		ID:         uuid.New().String(),
+		MACAddress: macAddress,
+		CreatedAt:  now,
+	}
+	if err := schedule.Apply(request, now); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// Apply replaces the fields of the schedule with the request. The run state is kept, so a changed
+// expression continues after the last run.
+func (s *IrrigationSchedule) Apply(request IrrigationScheduleRequest, at time.Time) error {
+	s.Name = strings.TrimSpace(request.Name)
+	s.Cron = strings.Join(strings.Fields(request.Cron), " ")
+	s.Duration = request.Duration
+	s.Enabled = request.Enabled
+	s.UpdatedAt = at.UTC()
+	return s.Validate()
+}
+
+// Validate validates the schedule fields
+func (s *IrrigationSchedule) Validate() error {
+	if s.MACAddress == "" {
+		return fmt.Errorf("mac address is required")
+	}
+	if s.Name == "" {
+		return fmt.Errorf("schedule name is required")
+	}
+	if len(s.Name) > maxIrrigationScheduleNameLen {
+		return fmt.Errorf("schedule name cannot exceed %d characters", maxIrrigationScheduleNameLen)
+	}
+	if s.Duration < MinIrrigationDuration || s.Duration > MaxIrrigationDuration {
+		return fmt.Errorf("duration must be between %s and %s", MinIrrigationDuration, MaxIrrigationDuration)
+	}
+	expression, err := ParseCronExpression(s.Cron)
+	if err != nil {
+		return err
+	}
+	if expression.Next(time.Now()).IsZero() {
+		return fmt.Errorf("cron expression %q never runs", s.Cron)
+	}
+	return nil
+}
+
+// Running reports whether the schedule opened the valve and did not close it yet
+func (s *IrrigationSchedule) Running() bool {
+	return s.RunEndsAt != nil
+}
+
+// DueRun returns the start of the run the schedule should be in at now, with the expression
+// evaluated in the location, and false when none is. A run is due from its start until its duration
+// elapsed, unless it is the last run or an earlier one.
+func (s *IrrigationSchedule) DueRun(now time.Time, location *time.Location) (time.Time, bool) {
+	expression, err := ParseCronExpression(s.Cron)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	from := s.CreatedAt
+	if s.LastRunAt != nil {
+		from = *s.LastRunAt
+	}
+	// Runs that started a whole duration ago are over, so there is no need to look before them
+	if earliest := now.Add(-s.Duration); from.Before(earliest) {
+		from = earliest
+	}
+
+	start := expression.Next(from.In(location))
+	if start.IsZero() || start.After(now) {
+		return time.Time{}, false
+	}
+	for next := expression.Next(start); !next.IsZero() && !next.After(now); next = expression.Next(next) {
+		start = next
+	}
+	return start.UTC(), true
+}
+
+// NextRun returns the first run of the schedule starting after the given time, with the
+// expression evaluated in the location, and false when there is none
+func (s *IrrigationSchedule) NextRun(after time.Time, location *time.Location) (IrrigationRun, bool) {
+	expression, err := ParseCronExpression(s.Cron)
+	if err != nil {
+		return IrrigationRun{}, false
+	}
+	start := expression.Next(after.In(location))
+	if start.IsZero() {
+		return IrrigationRun{}, false
+	}
+	return IrrigationRun{Schedule: s, Start: start.UTC(), End: start.Add(s.Duration).UTC()}, true
+}
+
+// Runs returns the runs of the schedule starting in [from, to), with the expression evaluated in
+// the location
+func (s *IrrigationSchedule) Runs(from, to time.Time, location *time.Location) []IrrigationRun {
+	expression, err := ParseCronExpression(s.Cron)
+	if err != nil {
+		return nil
+	}
+
+	var runs []IrrigationRun
+	for start := expression.Next(from.Add(-time.Nanosecond).In(location)); !start.IsZero() && start.Before(to); start = expression.Next(start) {
+		runs = append(runs, IrrigationRun{Schedule: s, Start: start.UTC(), End: start.Add(s.Duration).UTC()})
+	}
+	return runs
+}
+
+// FindScheduleOverlap returns the first two runs of the enabled schedules that overlap, among
+// the runs starting in [from, to), and false when none do. Both runs may belong to the same schedule
+// when its duration outlasts the time between two of its runs.
+func FindScheduleOverlap(schedules []*IrrigationSchedule, from, to time.Time, location *time.Location) (IrrigationRun, IrrigationRun, bool) {
+	var runs []IrrigationRun
+	for _, schedule := range schedules {
+		if schedule.Enabled {
+			runs = append(runs, schedule.Runs(from, to, location)...)
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].Start.Before(runs[j].Start) })
+
+	var latest IrrigationRun
+	for i, run := range runs {
+		if i > 0 && run.Start.Before(latest.End) {
+			return latest, run, true
+		}
+		if i == 0 || run.End.After(latest.End) {
+			latest = run
+		}
+	}
+	return IrrigationRun{}, IrrigationRun{}, false
+}
@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIrrigationSchedule(t *testing.T) {
+	schedule, err := NewIrrigationSchedule("AA:BB:CC:DD:EE:FF", IrrigationScheduleRequest{Name: " Morning ", Cron: "0  6 * * *", Duration: 30 * time.Minute, Enabled: true})
+	require.NoError(t, err)
+	assert.NotEmpty(t, schedule.ID)
+	assert.Equal(t, "Morning", schedule.Name)
+	assert.Equal(t, "0 6 * * *", schedule.Cron)
+	assert.False(t, schedule.Running())
+
+	tests := []struct {
+		name    string
+		request IrrigationScheduleRequest
+		wantErr string
+	}{
+		{name: "missing name", request: IrrigationScheduleRequest{Cron: "0 6 * * *", Duration: time.Minute}, wantErr: "name is required"},
+		{name: "short", request: IrrigationScheduleRequest{Name: "a", Cron: "0 6 * * *", Duration: time.Second}, wantErr: "duration must be between"},
+		{name: "long", request: IrrigationScheduleRequest{Name: "a", Cron: "0 6 * * *", Duration: 13 * time.Hour}, wantErr: "duration must be between"},
+		{name: "invalid cron", request: IrrigationScheduleRequest{Name: "a", Cron: "0 6 * *", Duration: time.Minute}, wantErr: "must have 5 fields"},
+		{name: "never runs", request: IrrigationScheduleRequest{Name: "a", Cron: "0 6 31 2 *", Duration: time.Minute}, wantErr: "never runs"},
+	}
+	for _, tt := range tests {
+		_, err := NewIrrigationSchedule("AA:BB:CC:DD:EE:FF", tt.request)
+		assert.ErrorContains(t, err, tt.wantErr, tt.name)
+	}
+}
+
+func TestIrrigationSchedule_DueRun(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(day, hour, minute int) time.Time { return time.Date(2025, 6, day, hour, minute, 0, 0, time.UTC) }
+	schedule := func(lastRunAt *time.Time) *IrrigationSchedule {
+		return &IrrigationSchedule{Cron: "0 6 * * *", Duration: 30 * time.Minute, CreatedAt: createdAt, LastRunAt: lastRunAt}
+	}
+
+	start, due := schedule(nil).DueRun(at(2, 6, 10), time.UTC)
+	assert.True(t, due)
+	assert.Equal(t, at(2, 6, 0), start)
+
+	_, due = schedule(nil).DueRun(at(2, 5, 59), time.UTC)
+	assert.False(t, due, "not started yet")
+	_, due = schedule(nil).DueRun(at(2, 6, 30), time.UTC)
+	assert.False(t, due, "over")
+	lastRunAt := at(2, 6, 0)
+	_, due = schedule(&lastRunAt).DueRun(at(2, 6, 10), time.UTC)
+	assert.False(t, due, "already run")
+
+	start, due = schedule(&lastRunAt).DueRun(at(9, 6, 5), time.UTC)
+	assert.True(t, due, "the runs missed in between are skipped")
+	assert.Equal(t, at(9, 6, 0), start)
+
+	_, due = schedule(nil).DueRun(at(1, 6, 10), time.UTC)
+	assert.False(t, due, "runs before the schedule was created are not due")
+
+	bogota := time.FixedZone("COT", -5*3600)
+	start, due = schedule(nil).DueRun(at(2, 11, 10), bogota)
+	assert.True(t, due)
+	assert.Equal(t, at(2, 11, 0), start)
+}
+
+func TestIrrigationSchedule_NextRun(t *testing.T) {
+	schedule := &IrrigationSchedule{Cron: "0 6 * * *", Duration: 30 * time.Minute}
+
+	run, ok := schedule.NextRun(time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC), time.UTC)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 6, 3, 6, 0, 0, 0, time.UTC), run.Start, "a run starting at the given time is not after it")
+	assert.Equal(t, time.Date(2025, 6, 3, 6, 30, 0, 0, time.UTC), run.End)
+
+	run, ok = schedule.NextRun(time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC), time.FixedZone("COT", -5*3600))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 6, 2, 11, 0, 0, 0, time.UTC), run.Start)
+}
+
+func TestFindScheduleOverlap(t *testing.T) {
+	from := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+	newSchedule := func(name, cron string, duration time.Duration) *IrrigationSchedule {
+		return &IrrigationSchedule{Name: name, Cron: cron, Duration: duration, Enabled: true}
+	}
+
+	morning := newSchedule("morning", "0 6 * * *", 30*time.Minute)
+	evening := newSchedule("evening", "0 18 * * *", time.Hour)
+	back := newSchedule("back to back", "30 6 * * *", 15*time.Minute)
+	_, _, found := FindScheduleOverlap([]*IrrigationSchedule{morning, evening, back}, from, to, time.UTC)
+	assert.False(t, found, "a run may start as another one ends")
+
+	saturday := newSchedule("saturday", "15 6 * * 6", 10*time.Minute)
+	first, second, found := FindScheduleOverlap([]*IrrigationSchedule{morning, evening, saturday}, from, to, time.UTC)
+	require.True(t, found)
+	assert.Equal(t, morning, first.Schedule)
+	assert.Equal(t, saturday, second.Schedule)
+	assert.Equal(t, time.Date(2025, 6, 7, 6, 15, 0, 0, time.UTC), second.Start)
+
+	saturday.Enabled = false
+	_, _, found = FindScheduleOverlap([]*IrrigationSchedule{morning, saturday}, from, to, time.UTC)
+	assert.False(t, found, "disabled schedules do not run")
+
+	frequent := newSchedule("frequent", "*/10 * * * *", 15*time.Minute)
+	first, second, found = FindScheduleOverlap([]*IrrigationSchedule{frequent}, from, to, time.UTC)
+	require.True(t, found)
+	assert.Equal(t, first.Schedule, second.Schedule, "a schedule overlaps its own runs")
+}
+
+func TestIrrigationOverride(t *testing.T) {
+	now := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+	override, err := NewIrrigationOverride("AA:BB:CC:DD:EE:FF", " Rain ", now.Add(time.Hour), now)
+	require.NoError(t, err)
+	assert.True(t, override.Active(now))
+	assert.False(t, override.Active(now.Add(time.Hour)))
+
+	operatorOverride := override.OperatorOverride()
+	assert.Equal(t, OverrideKindIrrigationManual, operatorOverride.Kind)
+	assert.Equal(t, "Irrigation of AA:BB:CC:DD:EE:FF is under manual control until 2025-06-02T07:00:00Z: Rain", operatorOverride.Description)
+
+	_, err = NewIrrigationOverride("AA:BB:CC:DD:EE:FF", "", now, now)
+	assert.ErrorContains(t, err, "future")
+}
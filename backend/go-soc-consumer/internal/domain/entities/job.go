@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Job statuses
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// Job is a unit of long-running work executed in the background by the job queue
+type Job struct {
+	ID              string
+	Type            string
+	Payload         json.RawMessage
+	Status          string
+	Attempts        int
+	MaxAttempts     int
+	Progress        int // percent, 0-100
+	ProgressMessage string
+	Result          json.RawMessage
+	LastError       string
+	CancelRequested bool
+	RunAt           time.Time // earliest time the job may run, pushed back on retries
+	LeaseUntil      *time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// NewJob creates a queued job with the given payload
+func NewJob(jobType string, payload interface{}, maxAttempts int) (*Job, error) {
+	jobType = strings.TrimSpace(jobType)
+	if jobType == "" {
+		return nil, fmt.Errorf("job type is required")
+	}
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("job max attempts must be at least 1")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	now := time.Now().UTC()
+	return &Job{
+		ID:          uuid.New().String(),
+		Type:        jobType,
+		Payload:     data,
+		Status:      JobStatusQueued,
+		MaxAttempts: maxAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// IsFinished returns true once the job reached a terminal status
+func (j *Job) IsFinished() bool {
+	switch j.Status {
+	case JobStatusSucceeded, JobStatusFailed, JobStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CanRetry returns true if another attempt is allowed after a failure
+func (j *Job) CanRetry() bool {
+	return j.Attempts < j.MaxAttempts
+}
+
+// DecodePayload unmarshals the job payload into v
+func (j *Job) DecodePayload(v interface{}) error {
+	if err := json.Unmarshal(j.Payload, v); err != nil {
+		return fmt.Errorf("invalid payload for job %s: %w", j.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// Measurement qualities reported by devices
+const (
+	MeasurementQualityGood    = "good"
+	MeasurementQualitySuspect = "suspect" // e.g. the sensor is warming up or out of calibration
+	MeasurementQualityBad     = "bad"     // e.g. the probe is disconnected
+)
+
+// Measurement is a single value of a registered sensor type reported by a device
+type Measurement struct {
+	MACAddress string
+	SensorType string
+	Channel    int // probe index on devices carrying several probes, 0 on single-probe devices
+	Value      float64
+	Unit       string // unit of the sensor type, set when the measurement is recorded
+	Quality    string
+	Timestamp  time.Time
+}
+
+// NewMeasurement creates a measurement taken at the given time; an empty quality means good
+func NewMeasurement(macAddress, sensorType string, channel int, value float64, quality string, timestamp time.Time) (*Measurement, error) {
+	measurement := &Measurement{
+		MACAddress: macAddress,
+		SensorType: sensorType,
+		Channel:    channel,
+		Value:      value,
+		Quality:    quality,
+		Timestamp:  timestamp.UTC(),
+	}
+	measurement.Normalize()
+
+	if err := measurement.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	return measurement, nil
+}
+
+// Normalize uppercases the MAC address, lowercases the type and quality and rounds the value to 4 decimals
+func (m *Measurement) Normalize() {
+	m.MACAddress = strings.ToUpper(strings.TrimSpace(m.MACAddress))
+	m.SensorType = strings.ToLower(strings.TrimSpace(m.SensorType))
+	m.Quality = strings.ToLower(strings.TrimSpace(m.Quality))
+	if m.Quality == "" {
+		m.Quality = MeasurementQualityGood
+	}
+	m.Value = math.Round(m.Value*10000) / 10000
+}
+
+// Validate checks the fields that do not depend on the sensor type registry
+func (m *Measurement) Validate() error {
+	if err := validation.ValidateMACAddress(m.MACAddress); err != nil {
+		return fmt.Errorf("invalid mac address: %w", err)
+	}
+	if m.SensorType == "" {
+		return fmt.Errorf("sensor type is required")
+	}
+	if m.Channel < 0 || m.Channel > MaxSensorChannel {
+		return fmt.Errorf("channel must be between 0 and %d", MaxSensorChannel)
+	}
+	if math.IsNaN(m.Value) || math.IsInf(m.Value, 0) {
+		return fmt.Errorf("value must be a finite number")
+	}
+	switch m.Quality {
+	case MeasurementQualityGood, MeasurementQualitySuspect, MeasurementQualityBad:
+	default:
+		return fmt.Errorf("quality must be %s, %s or %s", MeasurementQualityGood, MeasurementQualitySuspect, MeasurementQualityBad)
+	}
+	if m.Timestamp.IsZero() {
+		return fmt.Errorf("timestamp cannot be zero")
+	}
+	if m.Timestamp.After(time.Now().Add(5 * time.Minute)) {
+		return fmt.Errorf("timestamp cannot be in the future")
+	}
+	return nil
+}
+
+// Resolutions of measurement averages
+const (
+	MeasurementResolutionHour = "hour"
+	MeasurementResolutionDay  = "day" // days start at midnight UTC
+)
+
+// MeasurementAverage summarizes the measurements of one probe over an hour or a day, leaving out
+// bad quality measurements
+type MeasurementAverage struct {
+	MACAddress string
+	SensorType string
+	Channel    int
+	Start      time.Time // start of the hour or day
+	Average    float64
+	Minimum    float64
+	Maximum    float64
+	Samples    int
+}
+
+// MeasurementResolutionStep returns the length of the buckets averaged at the resolution
+func MeasurementResolutionStep(resolution string) (time.Duration, error) {
+	switch resolution {
+	case MeasurementResolutionHour:
+		return time.Hour, nil
+	case MeasurementResolutionDay:
+		return 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("resolution must be %s or %s", MeasurementResolutionHour, MeasurementResolutionDay)
+	}
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMeasurement(t *testing.T) {
+	takenAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.FixedZone("COT", -5*3600))
+
+	t.Run("normalizes the fields", func(t *testing.T) {
+		measurement, err := NewMeasurement(" aa:bb:cc:dd:ee:ff ", " PH ", 2, 6.512345, "", takenAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", measurement.MACAddress)
+		assert.Equal(t, "ph", measurement.SensorType)
+		assert.Equal(t, 2, measurement.Channel)
+		assert.Equal(t, 6.5123, measurement.Value)
+		assert.Equal(t, MeasurementQualityGood, measurement.Quality)
+		assert.Equal(t, time.UTC, measurement.Timestamp.Location())
+	})
+
+	tests := []struct {
+		name       string
+		macAddress string
+		sensorType string
+		channel    int
+		value      float64
+		quality    string
+		timestamp  time.Time
+	}{
+		{name: "invalid mac address", macAddress: "AA:BB", sensorType: "ph", value: 7, timestamp: takenAt},
+		{name: "missing sensor type", macAddress: "AA:BB:CC:DD:EE:FF", value: 7, timestamp: takenAt},
+		{name: "negative channel", macAddress: "AA:BB:CC:DD:EE:FF", sensorType: "ph", channel: -1, value: 7, timestamp: takenAt},
+		{name: "channel out of range", macAddress: "AA:BB:CC:DD:EE:FF", sensorType: "ph", channel: MaxSensorChannel + 1, value: 7, timestamp: takenAt},
+		{name: "not a number", macAddress: "AA:BB:CC:DD:EE:FF", sensorType: "ph", value: math.NaN(), timestamp: takenAt},
+		{name: "unknown quality", macAddress: "AA:BB:CC:DD:EE:FF", sensorType: "ph", value: 7, quality: "excellent", timestamp: takenAt},
+		{name: "future timestamp", macAddress: "AA:BB:CC:DD:EE:FF", sensorType: "ph", value: 7, timestamp: time.Now().Add(time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			_, err := NewMeasurement(tt.macAddress, tt.sensorType, tt.channel, tt.value, tt.quality, tt.timestamp)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSensorType_Validate(t *testing.T) {
+	for _, sensorType := range DefaultSensorTypes() {
+		assert.NoError(t, sensorType.Validate(), sensorType.Name)
+	}
+
+	sensorType := &SensorType{Name: " EC ", Unit: " mS/cm ", MinValue: 0, MaxValue: 20}
+	sensorType.Normalize()
+	require.NoError(t, sensorType.Validate())
+	assert.Equal(t, "ec", sensorType.Name)
+	assert.Equal(t, "measurements_ec", sensorType.ViewName())
+	assert.True(t, sensorType.Accepts(1.5))
+	assert.False(t, sensorType.Accepts(25))
+
+	invalid := []*SensorType{
+		{Name: "soil-moisture", Unit: "percent", MinValue: 0, MaxValue: 100},
+		{Name: "ph; drop table", Unit: "pH", MinValue: 0, MaxValue: 14},
+		{Name: "1wire", Unit: "celsius", MinValue: 0, MaxValue: 1},
+		{Name: "ph", MinValue: 0, MaxValue: 14},
+		{Name: "ph", Unit: "pH", MinValue: 14, MaxValue: 0},
+	}
+	for _, sensorType := range invalid {
+		assert.Error(t, sensorType.Validate(), sensorType.Name)
+	}
+}
+
+func TestMeasurementResolutionStep(t *testing.T) {
+	step, err := MeasurementResolutionStep(MeasurementResolutionHour)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, step)
+
+	step, err = MeasurementResolutionStep(MeasurementResolutionDay)
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, step)
+
+	_, err = MeasurementResolutionStep("week")
+	assert.Error(t, err)
+}
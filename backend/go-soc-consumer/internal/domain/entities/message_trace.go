@@ -0,0 +1,171 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Trace session limits
+const (
+	MaxTraceDuration        = time.Hour
+	DefaultTraceMaxMessages = 100
+	MaxTraceMessages        = 1000
+	MaxTracePayloadBytes    = 4 << 10
+)
+
+// Message trace outcomes
+const (
+	TraceOutcomeHandled = "handled" // every stage passed the message and the topic handler succeeded
+	TraceOutcomeDropped = "dropped" // a pipeline stage dropped the message
+	TraceOutcomeFailed  = "failed"  // the topic handler, or a stage, returned an error
+)
+
+// TraceStageHandler is the step name of the topic handler, which runs after the pipeline stages
+const TraceStageHandler = "handler"
+
+// TraceSessionRequest holds the fields of a trace session set by an operator
+type TraceSessionRequest struct {
+	Topic       string // MQTT topic filter, empty for every topic
+	MACAddress  string // empty for every device
+	Duration    time.Duration
+	SampleRate  float64 // share of the matching messages captured, zero for all of them
+	MaxMessages int     // zero for DefaultTraceMaxMessages
+}
+
+// TraceSession captures the messages matching its topic filter and device until it expires or has
+// captured its maximum number of messages
+type TraceSession struct {
+	ID          string
+	Topic       string
+	MACAddress  string
+	SampleRate  float64
+	MaxMessages int
+	Matched     int // messages matching the session, sampled or not
+	Captured    int
+	StartedAt   time.Time
+	ExpiresAt   time.Time
+	StoppedAt   *time.Time // set when stopped by an operator before it expired
+}
+
+// NewTraceSession creates a trace session starting now
+func NewTraceSession(request TraceSessionRequest, now time.Time) (*TraceSession, error) {
+	session := &TraceSession{
+		ID:          uuid.New().String(),
+		Topic:       strings.TrimSpace(request.Topic),
+		MACAddress:  strings.ToUpper(strings.TrimSpace(request.MACAddress)),
+		SampleRate:  request.SampleRate,
+		MaxMessages: request.MaxMessages,
+		StartedAt:   now.UTC(),
+		ExpiresAt:   now.Add(request.Duration).UTC(),
+	}
+	if session.SampleRate == 0 {
+		session.SampleRate = 1
+	}
+	if session.MaxMessages == 0 {
+		session.MaxMessages = DefaultTraceMaxMessages
+	}
+
+	if session.Topic == "" && session.MACAddress == "" {
+		return nil, fmt.Errorf("a topic filter or a mac address is required")
+	}
+	if request.Duration <= 0 || request.Duration > MaxTraceDuration {
+		return nil, fmt.Errorf("duration must be positive and at most %s", MaxTraceDuration)
+	}
+	if session.SampleRate < 0 || session.SampleRate > 1 {
+		return nil, fmt.Errorf("sample rate must be between 0 and 1")
+	}
+	if session.MaxMessages < 0 || session.MaxMessages > MaxTraceMessages {
+		return nil, fmt.Errorf("max messages must be between 1 and %d", MaxTraceMessages)
+	}
+	return session, nil
+}
+
+// Active reports whether the session still captures messages at the given time
+func (s *TraceSession) Active(now time.Time) bool {
+	return s.StoppedAt == nil && now.Before(s.ExpiresAt) && s.Captured < s.MaxMessages
+}
+
+// Matches reports whether a message of the topic, published by the device, is traced by the
+// session. Messages without a mac address only match sessions of every device.
+func (s *TraceSession) Matches(topic, macAddress string) bool {
+	if s.Topic != "" && !MQTTTopicMatches(s.Topic, topic) {
+		return false
+	}
+	return s.MACAddress == "" || strings.EqualFold(s.MACAddress, macAddress)
+}
+
+// OperatorOverride returns the session as reported in the shift handover
+func (s *TraceSession) OperatorOverride() *OperatorOverride {
+	var target []string
+	if s.Topic != "" {
+		target = append(target, "topic "+s.Topic)
+	}
+	if s.MACAddress != "" {
+		target = append(target, "device "+s.MACAddress)
+	}
+	since := s.StartedAt
+	return &OperatorOverride{
+		Kind:        OverrideKindMessageTracing,
+		Description: fmt.Sprintf("Messages of %s are traced until %s", strings.Join(target, " and "), s.ExpiresAt.Format(time.RFC3339)),
+		Since:       &since,
+	}
+}
+
+// TraceStep records when a message reached a pipeline stage, or the topic handler
+type TraceStep struct {
+	Stage   string
+	Elapsed time.Duration // since the message was received
+}
+
+// MessageTrace is a message captured by a trace session, with the path it took through the
+// ingestion pipeline
+type MessageTrace struct {
+	ID               string
+	SessionID        string
+	Topic            string
+	MACAddress       string
+	Payload          []byte // truncated to MaxTracePayloadBytes
+	PayloadTruncated bool
+	ReceivedAt       time.Time
+	Steps            []TraceStep
+	Outcome          string
+	Stage            string // stage that dropped the message
+	Reason           string // drop reason, or the error of a failed message
+	Duration         time.Duration
+}
+
+// NewMessageTrace captures a message for the session, copying the payload
+func NewMessageTrace(sessionID, topic, macAddress string, payload []byte, receivedAt time.Time) *MessageTrace {
+	trace := &MessageTrace{
+		ID:         uuid.New().String(),
+		SessionID:  sessionID,
+		Topic:      topic,
+		MACAddress: strings.ToUpper(macAddress),
+		ReceivedAt: receivedAt.UTC(),
+	}
+	if len(payload) > MaxTracePayloadBytes {
+		payload = payload[:MaxTracePayloadBytes]
+		trace.PayloadTruncated = true
+	}
+	trace.Payload = append([]byte(nil), payload...)
+	return trace
+}
+
+// MQTTTopicMatches reports whether a topic matches an MQTT topic filter, with + matching one level
+// and a trailing # any number of levels
+func MQTTTopicMatches(filter, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+		if i >= len(topicLevels) || (level != "+" && level != topicLevels[i]) {
+			return false
+		}
+	}
+	return len(filterLevels) == len(topicLevels)
+}
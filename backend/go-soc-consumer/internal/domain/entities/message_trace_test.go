@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceSession_Matches(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	session, err := NewTraceSession(TraceSessionRequest{Topic: "farms/+/devices/#", MACAddress: "aa:bb:cc:dd:ee:ff", Duration: time.Minute}, now)
+	require.NoError(t, err)
+
+	assert.True(t, session.Matches("farms/farm-1/devices/sensors/measurements", "AA:BB:CC:DD:EE:FF"))
+	assert.False(t, session.Matches("farms/farm-1/devices/sensors/measurements", "11:22:33:44:55:66"))
+	assert.False(t, session.Matches("/liwaisi/iot/smart-irrigation/sensors/measurements", "AA:BB:CC:DD:EE:FF"))
+	assert.True(t, session.Active(now))
+	assert.False(t, session.Active(now.Add(time.Minute)))
+	assert.Equal(t, "Messages of topic farms/+/devices/# and device AA:BB:CC:DD:EE:FF are traced until 2025-06-01T12:01:00Z", session.OperatorOverride().Description)
+}
+
+func TestMQTTTopicMatches(t *testing.T) {
+	tests := []struct {
+		filter, topic string
+		want          bool
+	}{
+		{filter: "farms/+/devices/registration", topic: "farms/farm-1/devices/registration", want: true},
+		{filter: "farms/+/devices/registration", topic: "farms/farm-1/devices/command-ack", want: false},
+		{filter: "farms/#", topic: "farms/farm-1/devices/registration", want: true},
+		{filter: "farms/+", topic: "farms/farm-1/devices", want: false},
+		{filter: "/liwaisi/iot/smart-irrigation/device/registration", topic: "/liwaisi/iot/smart-irrigation/device/registration", want: true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MQTTTopicMatches(tt.filter, tt.topic), tt.filter+" "+tt.topic)
+	}
+}
+
+func TestNewMessageTrace(t *testing.T) {
+	payload := []byte(strings.Repeat("a", MaxTracePayloadBytes+1))
+	trace := NewMessageTrace("session-1", "topic", "aa:bb:cc:dd:ee:ff", payload, time.Now())
+
+	assert.Len(t, trace.Payload, MaxTracePayloadBytes)
+	assert.True(t, trace.PayloadTruncated)
+	assert.Equal(t, "AA:BB:CC:DD:EE:FF", trace.MACAddress)
+
+	payload[0] = 'b'
+	assert.Equal(t, byte('a'), trace.Payload[0])
+}
@@ -0,0 +1,44 @@
+package entities
+
+import "time"
+
+// MoisturePredictionRequest holds what a model is given to predict the soil moisture of a zone
+type MoisturePredictionRequest struct {
+	Zone         string
+	MACAddresses []string
+	SensorType   string
+	Threshold    float64       // moisture below which the soil needs irrigation
+	Horizon      time.Duration // how far ahead the moisture is forecast
+	At           time.Time     // time the prediction is made for
+	Readings     []*Measurement
+}
+
+// MoistureForecastPoint is the moisture predicted at a time
+type MoistureForecastPoint struct {
+	At       time.Time
+	Moisture float64
+}
+
+// MoisturePrediction is the predicted soil moisture of a zone. The zone dries as fast as its driest
+// device, so a prediction follows the device expected to reach the threshold first.
+type MoisturePrediction struct {
+	Zone        string
+	Model       string // model that made the prediction
+	MACAddress  string // device the prediction follows, empty when the model does not tell
+	PredictedAt time.Time
+	Moisture    float64 // estimated moisture at PredictedAt
+	DryingRate  float64 // moisture lost per hour, negative while the soil gets wetter
+	// HoursUntilThreshold is nil when the moisture is not expected to reach the threshold within
+	// the horizon, and 0 when it already did
+	HoursUntilThreshold *float64
+	Forecast            []MoistureForecastPoint // oldest first
+}
+
+// ThresholdAt returns when the moisture is predicted to reach the threshold, and false when it is
+// not expected to within the horizon
+func (p *MoisturePrediction) ThresholdAt() (time.Time, bool) {
+	if p.HoursUntilThreshold == nil {
+		return time.Time{}, false
+	}
+	return p.PredictedAt.Add(time.Duration(*p.HoursUntilThreshold * float64(time.Hour))), true
+}
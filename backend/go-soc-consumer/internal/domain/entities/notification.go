@@ -0,0 +1,234 @@
+package entities
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Notification channels
+const (
+	NotificationChannelEmail = "email"
+	NotificationChannelSMS   = "sms"
+	// NotificationChannelInbox is the in-app inbox every user receives every alert in, regardless of
+	// routes, digests and quiet hours
+	NotificationChannelInbox = "inbox"
+)
+
+// Notification digest modes
+const (
+	NotificationDigestOff    = "off"    // every alert is delivered as it is raised
+	NotificationDigestHourly = "hourly" // non-critical alerts are batched until the next hour
+	NotificationDigestDaily  = "daily"  // non-critical alerts are batched until the daily digest time
+)
+
+// NotificationQuietOverrideNone disables delivery of any alert during quiet hours
+const NotificationQuietOverrideNone = "none"
+
+// phoneNumberPattern matches E.164 phone numbers such as +573001234567
+var phoneNumberPattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// alertSeverityRanks orders the alert severities
+var alertSeverityRanks = map[string]int{
+	AlertSeverityInfo:     1,
+	AlertSeverityWarning:  2,
+	AlertSeverityCritical: 3,
+}
+
+// Notification is a message to a user on one channel, about a single alert or a digest of alerts
+type Notification struct {
+	User      string
+	Channel   string
+	Address   string
+	Subject   string
+	Body      string
+	Alerts    []*Alert
+	CreatedAt time.Time
+}
+
+// QuietHours is a daily period, as offsets from local midnight, during which a user is not disturbed.
+// A period whose end is before its start spans midnight, such as 22h to 6h.
+type QuietHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Contains reports whether the local time falls within the quiet hours
+func (q QuietHours) Contains(local time.Time) bool {
+	offset := sinceMidnight(local)
+	if q.Start <= q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	return offset >= q.Start || offset < q.End
+}
+
+// NextEnd returns the first end of the quiet hours after the local time
+func (q QuietHours) NextEnd(local time.Time) time.Time {
+	return nextTimeOfDay(local, q.End)
+}
+
+// NotificationPreferences are how a user is notified of alerts: on which channels for each severity,
+// batched in a digest or not, and when not to be disturbed
+type NotificationPreferences struct {
+	User     string
+	Language string // ISO 639-1 code notifications are written in
+	Email    string
+	Phone    string // E.164 number SMS are sent to
+	// Digest batches non-critical alerts; critical alerts are always delivered as they are raised
+	Digest     string
+	QuietHours *QuietHours // nil when the user is never in quiet hours
+	// QuietOverride is the lowest severity still delivered during quiet hours, or
+	// NotificationQuietOverrideNone; alerts below it are held until the quiet hours end
+	QuietOverride string
+	// Routes lists the channels of each severity; severities without a route use the default routes
+	Routes map[string][]string
+}
+
+// Normalize trims and lowercases the preferences and fills in the defaults: no digest, critical
+// alerts override quiet hours, critical alerts on SMS and email and the others on email only, each
+// limited to the channels the user has an address for
+func (p *NotificationPreferences) Normalize() {
+	p.User = strings.TrimSpace(p.User)
+	p.Language = strings.ToLower(strings.TrimSpace(p.Language))
+	p.Email = strings.TrimSpace(p.Email)
+	p.Phone = strings.TrimSpace(p.Phone)
+	p.Digest = strings.ToLower(strings.TrimSpace(p.Digest))
+	if p.Digest == "" {
+		p.Digest = NotificationDigestOff
+	}
+	p.QuietOverride = strings.ToLower(strings.TrimSpace(p.QuietOverride))
+	if p.QuietOverride == "" {
+		p.QuietOverride = AlertSeverityCritical
+	}
+
+	routes := make(map[string][]string, len(alertSeverityRanks))
+	for severity, channels := range p.Routes {
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		for _, channel := range channels {
+			routes[severity] = append(routes[severity], strings.ToLower(strings.TrimSpace(channel)))
+		}
+	}
+	for severity := range alertSeverityRanks {
+		if _, ok := routes[severity]; ok {
+			continue
+		}
+		defaults := []string{NotificationChannelEmail}
+		if severity == AlertSeverityCritical {
+			defaults = []string{NotificationChannelSMS, NotificationChannelEmail}
+		}
+		for _, channel := range defaults {
+			if p.Address(channel) != "" {
+				routes[severity] = append(routes[severity], channel)
+			}
+		}
+	}
+	p.Routes = routes
+}
+
+// Validate checks the addresses, digest, quiet hours and routes
+func (p *NotificationPreferences) Validate() error {
+	if p.User == "" || len(p.User) > 64 {
+		return fmt.Errorf("notification user must be between 1 and 64 characters")
+	}
+	if p.Email != "" {
+		if _, err := mail.ParseAddress(p.Email); err != nil {
+			return fmt.Errorf("notification user %s: invalid email address: %w", p.User, err)
+		}
+	}
+	if p.Phone != "" && !phoneNumberPattern.MatchString(p.Phone) {
+		return fmt.Errorf("notification user %s: phone number must be in E.164 format, such as +573001234567", p.User)
+	}
+	switch p.Digest {
+	case NotificationDigestOff, NotificationDigestHourly, NotificationDigestDaily:
+	default:
+		return fmt.Errorf("notification user %s: digest must be %s, %s or %s", p.User, NotificationDigestOff, NotificationDigestHourly, NotificationDigestDaily)
+	}
+	if p.QuietHours != nil {
+		if !validTimeOfDay(p.QuietHours.Start) || !validTimeOfDay(p.QuietHours.End) || p.QuietHours.Start == p.QuietHours.End {
+			return fmt.Errorf("notification user %s: quiet hours must start and end at different times of day", p.User)
+		}
+	}
+	if _, ok := alertSeverityRanks[p.QuietOverride]; !ok && p.QuietOverride != NotificationQuietOverrideNone {
+		return fmt.Errorf("notification user %s: quiet hours override must be a severity or %s", p.User, NotificationQuietOverrideNone)
+	}
+	for severity, channels := range p.Routes {
+		if _, ok := alertSeverityRanks[severity]; !ok {
+			return fmt.Errorf("notification user %s: unknown severity %q", p.User, severity)
+		}
+		for _, channel := range channels {
+			if channel != NotificationChannelEmail && channel != NotificationChannelSMS {
+				return fmt.Errorf("notification user %s: unknown channel %q", p.User, channel)
+			}
+			if p.Address(channel) == "" {
+				return fmt.Errorf("notification user %s: %s alerts are routed to %s without an address", p.User, severity, channel)
+			}
+		}
+	}
+	return nil
+}
+
+// Channels returns the channels alerts of the severity are delivered on
+func (p *NotificationPreferences) Channels(severity string) []string {
+	return p.Routes[severity]
+}
+
+// Address returns the address of the user on a channel, empty when the user has none
+func (p *NotificationPreferences) Address(channel string) string {
+	switch channel {
+	case NotificationChannelEmail:
+		return p.Email
+	case NotificationChannelSMS:
+		return p.Phone
+	case NotificationChannelInbox:
+		return p.User
+	}
+	return ""
+}
+
+// Batches reports whether alerts of the severity go in the digest
+func (p *NotificationPreferences) Batches(severity string) bool {
+	return p.Digest != NotificationDigestOff && severity != AlertSeverityCritical
+}
+
+// Quiet reports whether an alert of the severity must be held at the local time
+func (p *NotificationPreferences) Quiet(severity string, local time.Time) bool {
+	if p.QuietHours == nil || !p.QuietHours.Contains(local) {
+		return false
+	}
+	if p.QuietOverride == NotificationQuietOverrideNone {
+		return true
+	}
+	return alertSeverityRanks[severity] < alertSeverityRanks[p.QuietOverride]
+}
+
+// NextDigest returns when the digest started at the local time is sent: at the next hour for hourly
+// digests, at the next dailyAt time of day for daily digests
+func (p *NotificationPreferences) NextDigest(local time.Time, dailyAt time.Duration) time.Time {
+	if p.Digest == NotificationDigestDaily {
+		return nextTimeOfDay(local, dailyAt)
+	}
+	return nextTimeOfDay(local, sinceMidnight(local).Truncate(time.Hour)+time.Hour)
+}
+
+// sinceMidnight returns the offset of the local time from its midnight
+func sinceMidnight(local time.Time) time.Duration {
+	hour, minute, second := local.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+}
+
+// nextTimeOfDay returns the first time after local at the given offset from midnight
+func nextTimeOfDay(local time.Time, offset time.Duration) time.Time {
+	year, month, day := local.Date()
+	next := time.Date(year, month, day, 0, 0, 0, 0, local.Location()).Add(offset)
+	if !next.After(local) {
+		next = time.Date(year, month, day+1, 0, 0, 0, 0, local.Location()).Add(offset)
+	}
+	return next
+}
+
+// validTimeOfDay reports whether the offset is within a day
+func validTimeOfDay(offset time.Duration) bool {
+	return offset >= 0 && offset < 24*time.Hour
+}
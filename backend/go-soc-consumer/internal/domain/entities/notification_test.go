@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreferences_Validate(t *testing.T) {
+	t.Run("defaults the routes to the channels the user has an address for", func(t *testing.T) {
+		preferences := &NotificationPreferences{User: " ana ", Email: "ana@example.com", Digest: "Hourly"}
+		preferences.Normalize()
+
+		require.NoError(t, preferences.Validate())
+		assert.Equal(t, "ana", preferences.User)
+		assert.Equal(t, NotificationDigestHourly, preferences.Digest)
+		assert.Equal(t, AlertSeverityCritical, preferences.QuietOverride)
+		assert.Equal(t, []string{NotificationChannelEmail}, preferences.Channels(AlertSeverityCritical))
+		assert.Equal(t, []string{NotificationChannelEmail}, preferences.Channels(AlertSeverityInfo))
+	})
+
+	t.Run("accepts inbox-only users", func(t *testing.T) {
+		preferences := &NotificationPreferences{User: "ana"}
+		preferences.Normalize()
+
+		require.NoError(t, preferences.Validate())
+		assert.Empty(t, preferences.Channels(AlertSeverityCritical))
+		assert.Equal(t, "ana", preferences.Address(NotificationChannelInbox))
+	})
+
+	t.Run("keeps explicit routes", func(t *testing.T) {
+		preferences := &NotificationPreferences{
+			User:   "ana",
+			Email:  "ana@example.com",
+			Phone:  "+573001234567",
+			Routes: map[string][]string{"Critical": {"SMS"}},
+		}
+		preferences.Normalize()
+
+		require.NoError(t, preferences.Validate())
+		assert.Equal(t, []string{NotificationChannelSMS}, preferences.Channels(AlertSeverityCritical))
+		assert.Equal(t, []string{NotificationChannelEmail}, preferences.Channels(AlertSeverityWarning))
+	})
+
+	tests := []struct {
+		name        string
+		preferences NotificationPreferences
+	}{
+		{name: "a user without a name", preferences: NotificationPreferences{Email: "ana@example.com"}},
+		{name: "an invalid email address", preferences: NotificationPreferences{User: "ana", Email: "ana"}},
+		{name: "a phone number not in E.164 format", preferences: NotificationPreferences{User: "ana", Phone: "3001234567"}},
+		{name: "an unknown digest", preferences: NotificationPreferences{User: "ana", Email: "ana@example.com", Digest: "weekly"}},
+		{name: "empty quiet hours", preferences: NotificationPreferences{User: "ana", Email: "ana@example.com", QuietHours: &QuietHours{Start: time.Hour, End: time.Hour}}},
+		{name: "quiet hours past the end of the day", preferences: NotificationPreferences{User: "ana", Email: "ana@example.com", QuietHours: &QuietHours{Start: 22 * time.Hour, End: 30 * time.Hour}}},
+		{name: "an unknown quiet hours override", preferences: NotificationPreferences{User: "ana", Email: "ana@example.com", QuietOverride: "urgent"}},
+		{name: "a route to an unknown channel", preferences: NotificationPreferences{User: "ana", Email: "ana@example.com", Routes: map[string][]string{"info": {"pager"}}}},
+		{name: "a route to a channel without an address", preferences: NotificationPreferences{User: "ana", Email: "ana@example.com", Routes: map[string][]string{"critical": {"sms"}}}},
+		{name: "a route to the inbox", preferences: NotificationPreferences{User: "ana", Routes: map[string][]string{"info": {"inbox"}}}},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			tt.preferences.Normalize()
+
+			assert.Error(t, tt.preferences.Validate())
+		})
+	}
+}
+
+func TestNotificationPreferences_Quiet(t *testing.T) {
+	preferences := &NotificationPreferences{
+		User:          "ana",
+		Email:         "ana@example.com",
+		QuietHours:    &QuietHours{Start: 22 * time.Hour, End: 6*time.Hour + 30*time.Minute},
+		QuietOverride: AlertSeverityWarning,
+	}
+	preferences.Normalize()
+	require.NoError(t, preferences.Validate())
+	at := func(hour, minute int) time.Time { return time.Date(2025, 6, 1, hour, minute, 0, 0, time.UTC) }
+
+	assert.False(t, preferences.Quiet(AlertSeverityInfo, at(21, 59)))
+	assert.True(t, preferences.Quiet(AlertSeverityInfo, at(22, 0)))
+	assert.True(t, preferences.Quiet(AlertSeverityInfo, at(6, 29)))
+	assert.False(t, preferences.Quiet(AlertSeverityInfo, at(6, 30)))
+	assert.False(t, preferences.Quiet(AlertSeverityWarning, at(23, 0)), "warning overrides the quiet hours")
+	assert.Equal(t, time.Date(2025, 6, 2, 6, 30, 0, 0, time.UTC), preferences.QuietHours.NextEnd(at(23, 0)))
+	assert.Equal(t, at(6, 30), preferences.QuietHours.NextEnd(at(1, 0)))
+}
+
+func TestNotificationPreferences_NextDigest(t *testing.T) {
+	at := time.Date(2025, 6, 1, 23, 15, 0, 0, time.UTC)
+
+	hourly := &NotificationPreferences{Digest: NotificationDigestHourly}
+	assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), hourly.NextDigest(at, 7*time.Hour))
+
+	daily := &NotificationPreferences{Digest: NotificationDigestDaily}
+	assert.Equal(t, time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC), daily.NextDigest(at, 7*time.Hour))
+	assert.Equal(t, time.Date(2025, 6, 1, 7, 0, 0, 0, time.UTC), daily.NextDigest(time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC), 7*time.Hour))
+}
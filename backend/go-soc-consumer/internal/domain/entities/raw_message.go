@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// RawMessage is an inbound MQTT message exactly as it was received, kept so it can be
+// reprocessed after a bug in decoding or handling is fixed
+type RawMessage struct {
+	Topic      string
+	Payload    []byte
+	ReceivedAt time.Time
+	Identity   *ClientIdentity // publisher reported by the broker, nil when it reported none
+}
+
+// NewRawMessage creates a raw message, copying the payload because MQTT clients may reuse its buffer
+func NewRawMessage(topic string, payload []byte, receivedAt time.Time) (*RawMessage, error) {
+	if topic == "" {
+		return nil, fmt.Errorf("topic is required")
+	}
+
+	return &RawMessage{
+		Topic:      topic,
+		Payload:    append([]byte(nil), payload...),
+		ReceivedAt: receivedAt.UTC(),
+	}, nil
+}
+
+// Hour returns the start of the hour the message was received in, which is the bundle it is archived in
+func (m *RawMessage) Hour() time.Time {
+	return m.ReceivedAt.Truncate(time.Hour)
+}
+
+// ReprocessResult summarises a replay of archived raw messages; it is stored as the result of the reprocessing job
+type ReprocessResult struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Messages int       `json:"messages"` // archived messages read
+	Replayed int       `json:"replayed"` // messages the handlers accepted
+	Failed   int       `json:"failed"`   // messages the handlers rejected
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRawMessage(t *testing.T) {
+	t.Run("should copy the payload", func(t *testing.T) {
+		payload := []byte(`{"mac_address":"AA:BB:CC:DD:EE:FF"}`)
+		receivedAt := time.Date(2025, 3, 1, 14, 35, 10, 0, time.FixedZone("COT", -5*3600))
+
+		message, err := NewRawMessage("/liwaisi/iot/smart-irrigation/device/registration", payload, receivedAt)
+		require.NoError(t, err)
+		payload[0] = 'x'
+
+		assert.Equal(t, `{"mac_address":"AA:BB:CC:DD:EE:FF"}`, string(message.Payload))
+		assert.Equal(t, time.UTC, message.ReceivedAt.Location())
+		assert.Equal(t, time.Date(2025, 3, 1, 19, 0, 0, 0, time.UTC), message.Hour())
+	})
+
+	t.Run("should require a topic", func(t *testing.T) {
+		_, err := NewRawMessage("", []byte("{}"), time.Now())
+		assert.Error(t, err)
+	})
+}
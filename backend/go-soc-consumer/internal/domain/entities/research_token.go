@@ -0,0 +1,192 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResearchTokenPrefix starts every research token, so leaked tokens are easy to recognize
+const ResearchTokenPrefix = "rt_"
+
+// researchTokenBytes is the entropy of a research token
+const researchTokenBytes = 32
+
+// pseudonymKeyBytes is the entropy of the key the pseudonyms of a research token are derived with
+const pseudonymKeyBytes = 32
+
+// ResearchToken is a read-only API token handed to a research partner. It exposes the anonymized
+// telemetry of the selected fields and zones until it expires or is revoked. Only the SHA-256 of
+// the token is kept.
+type ResearchToken struct {
+	ID        string
+	Name      string // partner the token was handed to
+	TokenHash string
+	Zones     []string // IDs of the readable fields and zones
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// PseudonymKey keys the pseudonyms of the devices. It is random and never leaves the server,
+	// so partners cannot recompute a pseudonym from the MAC addresses they may know.
+	PseudonymKey string
+}
+
+// ResearchTokenRequest holds the fields of a research token set when it is created
+type ResearchTokenRequest struct {
+	Name      string
+	Zones     []string
+	ExpiresAt time.Time
+}
+
+// ResearchTokenUsage counts the requests made and the readings served with a token on a day
+type ResearchTokenUsage struct {
+	TokenID  string
+	Day      time.Time // UTC midnight
+	Requests int64
+	Readings int64
+}
+
+// AnonymizedReading is a measurement with its device replaced by a pseudonym
+type AnonymizedReading struct {
+	Device     string // pseudonym of the device, stable for one token
+	SensorType string
+	Channel    int
+	Value      float64
+	Unit       string
+	Quality    string
+	Timestamp  time.Time
+}
+
+// NewResearchToken creates a research token and returns it with its secret, which is not kept.
+// Tokens cannot outlive maxTTL.
+func NewResearchToken(request ResearchTokenRequest, now time.Time, maxTTL time.Duration) (*ResearchToken, string, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, "", fmt.Errorf("name is required")
+	}
+	if len(name) > 100 {
+		return nil, "", fmt.Errorf("name cannot exceed 100 characters")
+	}
+
+	seen := make(map[string]bool, len(request.Zones))
+	zones := make([]string, 0, len(request.Zones))
+	for _, zone := range request.Zones {
+		zone = strings.TrimSpace(zone)
+		if zone == "" || seen[zone] {
+			continue
+		}
+		seen[zone] = true
+		zones = append(zones, zone)
+	}
+	if len(zones) == 0 {
+		return nil, "", fmt.Errorf("at least one zone is required")
+	}
+	sort.Strings(zones)
+
+	if !request.ExpiresAt.After(now) {
+		return nil, "", fmt.Errorf("expires_at must be in the future")
+	}
+	if request.ExpiresAt.After(now.Add(maxTTL)) {
+		return nil, "", fmt.Errorf("expires_at cannot be more than %s away", maxTTL)
+	}
+
+	raw := make([]byte, researchTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", fmt.Errorf("failed to generate research token: %w", err)
+	}
+	secret := ResearchTokenPrefix + hex.EncodeToString(raw)
+
+	pseudonymKey := make([]byte, pseudonymKeyBytes)
+	if _, err := rand.Read(pseudonymKey); err != nil {
+		return nil, "", fmt.Errorf("failed to generate pseudonym key: %w", err)
+	}
+
+	return &ResearchToken{
+		ID:           uuid.New().String(),
+		Name:         name,
+		TokenHash:    HashResearchToken(secret),
+		PseudonymKey: hex.EncodeToString(pseudonymKey),
+		Zones:        zones,
+		ExpiresAt:    request.ExpiresAt.UTC(),
+		CreatedAt:    now.UTC(),
+		UpdatedAt:    now.UTC(),
+	}, secret, nil
+}
+
+// HashResearchToken returns the hex SHA-256 a research token is looked up by
+func HashResearchToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Revoked reports whether the token was revoked
+func (t *ResearchToken) Revoked() bool {
+	return t.RevokedAt != nil
+}
+
+// Expired reports whether the token expired at the given time
+func (t *ResearchToken) Expired(at time.Time) bool {
+	return !at.Before(t.ExpiresAt)
+}
+
+// Revoke stops the token from being accepted
+func (t *ResearchToken) Revoke(at time.Time) error {
+	if t.Revoked() {
+		return fmt.Errorf("token was already revoked at %s", t.RevokedAt.Format(time.RFC3339))
+	}
+	revokedAt := at.UTC()
+	t.RevokedAt = &revokedAt
+	t.UpdatedAt = revokedAt
+	return nil
+}
+
+// AllowsZone reports whether the token may read the field or zone
+func (t *ResearchToken) AllowsZone(zoneID string) bool {
+	for _, zone := range t.Zones {
+		if zone == zoneID {
+			return true
+		}
+	}
+	return false
+}
+
+// Pseudonym returns the name the device is shown under to the token: an HMAC of the MAC address
+// keyed by the pseudonym key. Pseudonyms are stable for one token but differ between tokens, so
+// partners cannot match devices across their datasets, and without the key they cannot tell
+// which device is behind one.
+func (t *ResearchToken) Pseudonym(macAddress string) string {
+	mac := hmac.New(sha256.New, []byte(t.PseudonymKey))
+	mac.Write([]byte(strings.ToUpper(macAddress)))
+	return "device-" + hex.EncodeToString(mac.Sum(nil)[:6])
+}
+
+// Anonymize returns the measurements with their devices replaced by pseudonyms
+func (t *ResearchToken) Anonymize(measurements []*Measurement) []*AnonymizedReading {
+	readings := make([]*AnonymizedReading, 0, len(measurements))
+	for _, measurement := range measurements {
+		readings = append(readings, &AnonymizedReading{
+			Device:     t.Pseudonym(measurement.MACAddress),
+			SensorType: measurement.SensorType,
+			Channel:    measurement.Channel,
+			Value:      measurement.Value,
+			Unit:       measurement.Unit,
+			Quality:    measurement.Quality,
+			Timestamp:  measurement.Timestamp,
+		})
+	}
+	return readings
+}
+
+// ResearchUsageDay returns the day usage is accounted on
+func ResearchUsageDay(at time.Time) time.Time {
+	return at.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResearchToken(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	maxTTL := 365 * 24 * time.Hour
+	request := ResearchTokenRequest{Name: " Universidad Nacional ", Zones: []string{"zone-b", "zone-a", "zone-b", " "}, ExpiresAt: now.Add(90 * 24 * time.Hour)}
+
+	t.Run("should keep only the hash of the secret", func(t *testing.T) {
+		token, secret, err := NewResearchToken(request, now, maxTTL)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(secret, ResearchTokenPrefix))
+		assert.Equal(t, HashResearchToken(secret), token.TokenHash)
+		assert.NotContains(t, token.TokenHash, secret)
+		assert.Equal(t, "Universidad Nacional", token.Name)
+		assert.Equal(t, []string{"zone-a", "zone-b"}, token.Zones)
+		assert.True(t, token.AllowsZone("zone-a"))
+		assert.False(t, token.AllowsZone("zone-c"))
+	})
+
+	t.Run("should reject invalid tokens", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			mutate  func(*ResearchTokenRequest)
+			wantErr string
+		}{
+			{name: "missing name", mutate: func(r *ResearchTokenRequest) { r.Name = "" }, wantErr: "name is required"},
+			{name: "no zones", mutate: func(r *ResearchTokenRequest) { r.Zones = []string{" "} }, wantErr: "at least one zone"},
+			{name: "past expiry", mutate: func(r *ResearchTokenRequest) { r.ExpiresAt = now }, wantErr: "in the future"},
+			{name: "expiry too far", mutate: func(r *ResearchTokenRequest) { r.ExpiresAt = now.Add(2 * maxTTL) }, wantErr: "cannot be more than"},
+		}
+		for _, tt := range tests {
+			candidate := request
+			tt.mutate(&candidate)
+			_, _, err := NewResearchToken(candidate, now, maxTTL)
+			assert.ErrorContains(t, err, tt.wantErr, tt.name)
+		}
+	})
+}
+
+func TestResearchToken_Anonymize(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	first := &ResearchToken{ID: "token-1", PseudonymKey: "key-1"}
+	second := &ResearchToken{ID: "token-2", PseudonymKey: "key-2"}
+	measurements := []*Measurement{
+		{MACAddress: "AA:BB:CC:DD:EE:01", SensorType: "soil_moisture", Value: 31.5, Unit: "%", Timestamp: now},
+		{MACAddress: "aa:bb:cc:dd:ee:01", SensorType: "soil_moisture", Value: 30.9, Unit: "%", Timestamp: now.Add(time.Hour)},
+	}
+
+	readings := first.Anonymize(measurements)
+	require.Len(t, readings, 2)
+	assert.Equal(t, readings[0].Device, readings[1].Device)
+	assert.NotContains(t, readings[0].Device, "AA:BB")
+	assert.Equal(t, 31.5, readings[0].Value)
+	assert.NotEqual(t, readings[0].Device, second.Pseudonym("AA:BB:CC:DD:EE:01"))
+}
+
+func TestResearchToken_PseudonymNeedsTheKey(t *testing.T) {
+	token, _, err := NewResearchToken(ResearchTokenRequest{Name: "Universidad Nacional", Zones: []string{"zone-a"}, ExpiresAt: time.Now().Add(time.Hour)}, time.Now(), 24*time.Hour)
+	require.NoError(t, err)
+	other, _, err := NewResearchToken(ResearchTokenRequest{Name: "Universidad Nacional", Zones: []string{"zone-a"}, ExpiresAt: time.Now().Add(time.Hour)}, time.Now(), 24*time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, token.PseudonymKey, 64)
+	assert.NotEqual(t, token.PseudonymKey, other.PseudonymKey)
+
+	// A partner knows its token ID, as the usage endpoints return it, and may know the MAC address
+	pseudonym := token.Pseudonym("AA:BB:CC:DD:EE:01")
+	sum := sha256.Sum256([]byte(token.ID + ":AA:BB:CC:DD:EE:01"))
+	assert.NotEqual(t, "device-"+hex.EncodeToString(sum[:6]), pseudonym)
+	assert.NotEqual(t, pseudonym, (&ResearchToken{ID: token.ID, TokenHash: token.TokenHash}).Pseudonym("AA:BB:CC:DD:EE:01"))
+	assert.Equal(t, pseudonym, (&ResearchToken{ID: "elsewhere", PseudonymKey: token.PseudonymKey}).Pseudonym("aa:bb:cc:dd:ee:01"))
+}
+
+func TestResearchToken_Revoke(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := &ResearchToken{ExpiresAt: now.Add(time.Hour)}
+
+	assert.False(t, token.Expired(now))
+	assert.True(t, token.Expired(now.Add(time.Hour)))
+	require.NoError(t, token.Revoke(now))
+	assert.True(t, token.Revoked())
+	assert.ErrorContains(t, token.Revoke(now), "already revoked")
+}
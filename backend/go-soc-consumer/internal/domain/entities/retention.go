@@ -0,0 +1,11 @@
+package entities
+
+// Datasets pruned by the retention policy once they are older than the readings retention
+const (
+	RetentionTemperatureHumidity = "temperature_humidity" // raw temperature and humidity readings
+	RetentionMeasurements        = "measurements"         // readings of the registered sensor types
+	RetentionTelemetryArchive    = "telemetry_archive"    // compressed device-days of temperature and humidity readings
+)
+
+// RetentionDatasets lists the datasets in the order they are pruned
+var RetentionDatasets = []string{RetentionTemperatureHumidity, RetentionMeasurements, RetentionTelemetryArchive}
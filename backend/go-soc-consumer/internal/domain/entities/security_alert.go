@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+)
+
+// Security alert kinds
+const (
+	// SecurityAlertRegistrationFanIn is raised when many MAC addresses register from one IP address
+	SecurityAlertRegistrationFanIn = "registration_fan_in"
+	// SecurityAlertUntrustedCommandPublisher is raised when a command topic is published by a client other than the server
+	SecurityAlertUntrustedCommandPublisher = "untrusted_command_publisher"
+	// SecurityAlertTelemetryBurst is raised when a device sends far more readings than it usually does
+	SecurityAlertTelemetryBurst = "telemetry_burst"
+	// SecurityAlertDeviceCloneSuspected is raised when several client IDs publish messages of one device
+	SecurityAlertDeviceCloneSuspected = "device_clone_suspected"
+)
+
+// Security alert severities
+const (
+	SecuritySeverityWarning  = "warning"
+	SecuritySeverityCritical = "critical"
+)
+
+// SecurityAlert describes a suspicious pattern detected in device traffic
+type SecurityAlert struct {
+	EventID     string
+	EventType   string
+	Kind        string
+	Severity    string
+	Subject     string // what the alert is about: an IP address, MAC address or client ID
+	Description string
+	// LocalizedDescriptions holds the description in every supported language, keyed by language code
+	LocalizedDescriptions map[string]string
+	Context               map[string]interface{}
+	DetectedAt            time.Time
+}
+
+// NewSecurityAlert creates a new security alert with validation
+func NewSecurityAlert(kind, severity, subject, description string, context map[string]interface{}) (*SecurityAlert, error) {
+	eventID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate event ID: %w", err)
+	}
+
+	alert := &SecurityAlert{
+		EventID:     eventID.String(),
+		EventType:   events.SecurityAlertEventType,
+		Kind:        kind,
+		Severity:    severity,
+		Subject:     subject,
+		Description: description,
+		Context:     context,
+		DetectedAt:  time.Now().UTC(),
+	}
+	if err := alert.Validate(); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+// Validate ensures the alert has all required fields
+func (a *SecurityAlert) Validate() error {
+	switch a.Kind {
+	case SecurityAlertRegistrationFanIn, SecurityAlertUntrustedCommandPublisher, SecurityAlertTelemetryBurst, SecurityAlertDeviceCloneSuspected:
+	default:
+		return fmt.Errorf("unknown security alert kind: %q", a.Kind)
+	}
+
+	if a.Severity != SecuritySeverityWarning && a.Severity != SecuritySeverityCritical {
+		return fmt.Errorf("unknown security alert severity: %q", a.Severity)
+	}
+
+	if a.Subject == "" {
+		return fmt.Errorf("alert subject is required")
+	}
+
+	return nil
+}
+
+// GetSubject returns the NATS subject for this event type
+func (a *SecurityAlert) GetSubject() string {
+	return events.SecurityAlertSubject
+}
@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// MaxSensorChannel is the highest probe index a device may report
+const MaxSensorChannel = 63
+
+// SensorChannel is one probe of a sensor type on a device; devices carrying a single probe of a type
+// report it on channel 0
+type SensorChannel struct {
+	MACAddress   string
+	SensorType   string
+	Channel      int
+	Name         string     // operator given name, such as "bed 3 shallow"
+	Scale        float64    // factor raw values are multiplied by, 1 when uncalibrated
+	Offset       float64    // added to raw values after scaling
+	CalibratedAt *time.Time // nil until the channel is calibrated
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewSensorChannel creates an uncalibrated channel, as registered the first time a device reports it
+func NewSensorChannel(macAddress, sensorType string, channel int) (*SensorChannel, error) {
+	now := time.Now().UTC()
+	sensorChannel := &SensorChannel{
+		MACAddress: macAddress,
+		SensorType: sensorType,
+		Channel:    channel,
+		Scale:      1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	sensorChannel.Normalize()
+	if err := sensorChannel.Validate(); err != nil {
+		return nil, err
+	}
+	return sensorChannel, nil
+}
+
+// Normalize uppercases the MAC address, lowercases the sensor type and trims the name
+func (c *SensorChannel) Normalize() {
+	c.MACAddress = strings.ToUpper(strings.TrimSpace(c.MACAddress))
+	c.SensorType = strings.ToLower(strings.TrimSpace(c.SensorType))
+	c.Name = strings.TrimSpace(c.Name)
+}
+
+// Validate checks the channel addressing and calibration
+func (c *SensorChannel) Validate() error {
+	if err := validation.ValidateMACAddress(c.MACAddress); err != nil {
+		return fmt.Errorf("invalid mac address: %w", err)
+	}
+	if !sensorTypeNamePattern.MatchString(c.SensorType) {
+		return fmt.Errorf("invalid sensor type %q", c.SensorType)
+	}
+	if c.Channel < 0 || c.Channel > MaxSensorChannel {
+		return fmt.Errorf("channel must be between 0 and %d", MaxSensorChannel)
+	}
+	if len(c.Name) > 64 {
+		return fmt.Errorf("channel name must be at most 64 characters")
+	}
+	if c.Scale == 0 || math.IsNaN(c.Scale) || math.IsInf(c.Scale, 0) {
+		return fmt.Errorf("scale must be a non-zero finite number")
+	}
+	if math.IsNaN(c.Offset) || math.IsInf(c.Offset, 0) {
+		return fmt.Errorf("offset must be a finite number")
+	}
+	return nil
+}
+
+// Calibrate sets the scale and offset applied to the values of the channel
+func (c *SensorChannel) Calibrate(scale, offset float64, at time.Time) error {
+	previousScale, previousOffset := c.Scale, c.Offset
+	c.Scale, c.Offset = scale, offset
+	if err := c.Validate(); err != nil {
+		c.Scale, c.Offset = previousScale, previousOffset
+		return err
+	}
+	calibratedAt := at.UTC()
+	c.CalibratedAt = &calibratedAt
+	c.UpdatedAt = calibratedAt
+	return nil
+}
+
+// Apply returns the calibrated value of a raw value reported on the channel, rounded like measurements
+func (c *SensorChannel) Apply(value float64) float64 {
+	return math.Round((value*c.Scale+c.Offset)*10000) / 10000
+}
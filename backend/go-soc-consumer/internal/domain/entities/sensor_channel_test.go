@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSensorChannel(t *testing.T) {
+	t.Run("creates an uncalibrated channel", func(t *testing.T) {
+		channel, err := NewSensorChannel(" aa:bb:cc:dd:ee:ff ", " PH ", 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", channel.MACAddress)
+		assert.Equal(t, "ph", channel.SensorType)
+		assert.Equal(t, 3, channel.Channel)
+		assert.Equal(t, 1.0, channel.Scale)
+		assert.Nil(t, channel.CalibratedAt)
+		assert.Equal(t, 6.5, channel.Apply(6.5))
+	})
+
+	tests := []struct {
+		name       string
+		macAddress string
+		sensorType string
+		channel    int
+	}{
+		{name: "invalid mac address", macAddress: "AA:BB", sensorType: "ph"},
+		{name: "invalid sensor type", macAddress: "AA:BB:CC:DD:EE:FF", sensorType: "p h"},
+		{name: "negative channel", macAddress: "AA:BB:CC:DD:EE:FF", sensorType: "ph", channel: -1},
+		{name: "channel out of range", macAddress: "AA:BB:CC:DD:EE:FF", sensorType: "ph", channel: MaxSensorChannel + 1},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			_, err := NewSensorChannel(tt.macAddress, tt.sensorType, tt.channel)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSensorChannel_Calibrate(t *testing.T) {
+	calibratedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.FixedZone("COT", -5*3600))
+
+	t.Run("applies scale and offset", func(t *testing.T) {
+		channel, err := NewSensorChannel("AA:BB:CC:DD:EE:FF", "ph", 1)
+		require.NoError(t, err)
+
+		require.NoError(t, channel.Calibrate(1.1, -0.25, calibratedAt))
+
+		assert.Equal(t, 6.9, channel.Apply(6.5))
+		require.NotNil(t, channel.CalibratedAt)
+		assert.Equal(t, calibratedAt.UTC(), *channel.CalibratedAt)
+	})
+
+	for _, scale := range []float64{0, math.NaN(), math.Inf(1)} {
+		t.Run("rejects an invalid scale", func(t *testing.T) {
+			channel, err := NewSensorChannel("AA:BB:CC:DD:EE:FF", "ph", 1)
+			require.NoError(t, err)
+
+			assert.Error(t, channel.Calibrate(scale, 0, calibratedAt))
+			assert.Equal(t, 1.0, channel.Scale)
+			assert.Nil(t, channel.CalibratedAt)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package entities
+
+import "time"
+
+// SensorReadingsQuery selects a page of the readings of a device in [From, To). A zero To is the
+// current time and a zero From is the default window before To.
+type SensorReadingsQuery struct {
+	From   time.Time
+	To     time.Time
+	Offset int
+	Limit  int // as resolved by the pagination policy
+}
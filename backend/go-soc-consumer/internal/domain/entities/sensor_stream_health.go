@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+// Measurements reported by temperature and humidity sensors; each is tracked as its own stream
+const (
+	MeasurementTemperature = "temperature"
+	MeasurementHumidity    = "humidity"
+)
+
+// Issues lowering the health of a sensor stream
+const (
+	SensorIssueStale              = "stale"               // no reading within the expected interval
+	SensorIssueIrregular          = "irregular"           // readings arrive at uneven intervals
+	SensorIssueFlatline           = "flatline"            // recent readings do not vary at all
+	SensorIssueUncalibrated       = "uncalibrated"        // the device has no recorded calibration
+	SensorIssueCalibrationOverdue = "calibration_overdue" // the last calibration is older than allowed
+)
+
+// SensorStreamHealth is the health of one measurement stream of a device
+type SensorStreamHealth struct {
+	MACAddress       string
+	Measurement      string
+	Score            int // 0 to 100, higher is healthier
+	Stale            bool
+	Degraded         bool // stale or scored below the degraded threshold
+	Issues           []string
+	Readings         int
+	LastReadingAt    time.Time
+	ExpectedInterval time.Duration // learned reporting interval, 0 until two readings were seen
+	CalibratedAt     *time.Time
+}
+
+// HasIssue returns true if the stream reports the given issue
+func (h *SensorStreamHealth) HasIssue(issue string) bool {
+	for _, existing := range h.Issues {
+		if existing == issue {
+			return true
+		}
+	}
+	return false
+}
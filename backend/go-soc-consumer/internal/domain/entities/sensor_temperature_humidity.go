@@ -39,6 +39,25 @@ func NewSensorTemperatureHumidity(macAddress string, temperature, humidity float
 	return sensor, nil
 }
 
+// NewSensorTemperatureHumidityAt creates a reading with an explicit measurement time,
+// used when readings are replayed or replicated from another instance
+func NewSensorTemperatureHumidityAt(macAddress string, temperature, humidity float64, timestamp time.Time) (*SensorTemperatureHumidity, error) {
+	sensor := &SensorTemperatureHumidity{
+		macAddress:  macAddress,
+		temperature: temperature,
+		humidity:    humidity,
+		timestamp:   timestamp.UTC(),
+	}
+
+	sensor.Normalize()
+
+	if err := sensor.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	return sensor, nil
+}
+
 // MacAddress returns the MAC address of the sensor device
 func (s *SensorTemperatureHumidity) MacAddress() string {
 	return s.macAddress
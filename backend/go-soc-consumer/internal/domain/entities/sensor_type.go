@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// sensorTypeNamePattern restricts sensor type names to identifiers usable as SQL view and column names
+var sensorTypeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// SensorType describes a kind of measurement devices report, such as pH or electrical conductivity
+type SensorType struct {
+	Name        string  // identifier used in messages and in the name of its typed view
+	Unit        string  // unit every value of the type is expressed in
+	MinValue    float64 // lowest plausible value, lower values are rejected
+	MaxValue    float64 // highest plausible value, higher values are rejected
+	Description string
+}
+
+// DefaultSensorTypes returns the sensor types known without configuration
+func DefaultSensorTypes() []*SensorType {
+	return []*SensorType{
+		{Name: "temperature", Unit: "celsius", MinValue: -40, MaxValue: 85, Description: "Air temperature"},
+		{Name: "humidity", Unit: "percent", MinValue: 0, MaxValue: 100, Description: "Relative air humidity"},
+		{Name: "soil_temperature", Unit: "celsius", MinValue: -30, MaxValue: 70, Description: "Soil temperature"},
+		{Name: "leaf_wetness", Unit: "percent", MinValue: 0, MaxValue: 100, Description: "Share of the leaf surface covered by water"},
+		{Name: "solar_radiation", Unit: "W/m2", MinValue: 0, MaxValue: 1500, Description: "Global solar radiation on a horizontal surface"},
+	}
+}
+
+// Normalize trims the fields and lowercases the name
+func (t *SensorType) Normalize() {
+	t.Name = strings.ToLower(strings.TrimSpace(t.Name))
+	t.Unit = strings.TrimSpace(t.Unit)
+	t.Description = strings.TrimSpace(t.Description)
+}
+
+// Validate checks the name, unit and value range
+func (t *SensorType) Validate() error {
+	if !sensorTypeNamePattern.MatchString(t.Name) {
+		return fmt.Errorf("sensor type name %q must start with a letter and contain only lowercase letters, digits and underscores (at most 32)", t.Name)
+	}
+	if t.Unit == "" {
+		return fmt.Errorf("sensor type %s: unit is required", t.Name)
+	}
+	if t.MinValue >= t.MaxValue {
+		return fmt.Errorf("sensor type %s: min value must be below max value", t.Name)
+	}
+	return nil
+}
+
+// Accepts reports whether the value is within the plausible range of the type
+func (t *SensorType) Accepts(value float64) bool {
+	return value >= t.MinValue && value <= t.MaxValue
+}
+
+// ViewName returns the name of the view listing the measurements of the type
+func (t *SensorType) ViewName() string {
+	return "measurements_" + t.Name
+}
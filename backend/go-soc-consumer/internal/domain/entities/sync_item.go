@@ -0,0 +1,121 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sync item kinds replicated from an edge instance to the cloud
+const (
+	SyncKindDevice                    = "device"
+	SyncKindSensorTemperatureHumidity = "sensor_temperature_humidity"
+	SyncKindDeviceDetectedEvent       = "device_detected_event"
+)
+
+// SyncItem is a change recorded on an edge instance waiting to be replicated to the cloud
+type SyncItem struct {
+	ID        string
+	Kind      string
+	Key       string          // natural key of the replicated entity, e.g. the device MAC address
+	Payload   json.RawMessage // JSON representation of the entity at change time
+	ChangedAt time.Time       // when the change happened on the edge, used for conflict resolution
+	Attempts  int
+	LastError string
+	SyncedAt  *time.Time
+}
+
+// NewSyncItem creates a pending sync item for the given entity snapshot
+func NewSyncItem(kind, key string, payload interface{}, changedAt time.Time) (*SyncItem, error) {
+	item := &SyncItem{
+		ID:        uuid.New().String(),
+		Kind:      kind,
+		Key:       key,
+		ChangedAt: changedAt.UTC(),
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sync payload: %w", err)
+	}
+	item.Payload = data
+
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Validate checks that the sync item can be replicated
+func (s *SyncItem) Validate() error {
+	switch s.Kind {
+	case SyncKindDevice, SyncKindSensorTemperatureHumidity, SyncKindDeviceDetectedEvent:
+	default:
+		return fmt.Errorf("unsupported sync item kind: %s", s.Kind)
+	}
+	if s.Key == "" {
+		return fmt.Errorf("sync item key is required")
+	}
+	if len(s.Payload) == 0 {
+		return fmt.Errorf("sync item payload is required")
+	}
+	if s.ChangedAt.IsZero() {
+		return fmt.Errorf("sync item change time is required")
+	}
+	return nil
+}
+
+// IsSynced returns true once the item was accepted by the cloud
+func (s *SyncItem) IsSynced() bool {
+	return s.SyncedAt != nil
+}
+
+// DeviceSyncPayload is the replicated representation of a device
+type DeviceSyncPayload struct {
+	MACAddress          string    `json:"mac_address"`
+	DeviceName          string    `json:"device_name"`
+	IPAddress           string    `json:"ip_address"`
+	LocationDescription string    `json:"location_description"`
+	RegisteredAt        time.Time `json:"registered_at"`
+	LastSeen            time.Time `json:"last_seen"`
+	Status              string    `json:"status"`
+}
+
+// SensorTemperatureHumiditySyncPayload is the replicated representation of a sensor reading
+type SensorTemperatureHumiditySyncPayload struct {
+	MACAddress         string    `json:"mac_address"`
+	TemperatureCelsius float64   `json:"temperature_celsius"`
+	HumidityPercent    float64   `json:"humidity_percent"`
+	Timestamp          time.Time `json:"timestamp"`
+}
+
+// DeviceDetectedEventSyncPayload is the replicated representation of a device detected event
+type DeviceDetectedEventSyncPayload struct {
+	EventID    string    `json:"event_id"`
+	MACAddress string    `json:"mac_address"`
+	IPAddress  string    `json:"ip_address"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// SyncStatus summarizes the replication state of an edge instance
+type SyncStatus struct {
+	Mode            string     `json:"mode"`
+	Online          bool       `json:"online"`
+	PendingItems    int64      `json:"pending_items"`
+	FailedAttempts  int64      `json:"failed_attempts"`
+	LastSyncAt      *time.Time `json:"last_sync_at,omitempty"`
+	LastSuccessAt   *time.Time `json:"last_success_at,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+	LastBatchSize   int        `json:"last_batch_size"`
+	TotalReplicated int64      `json:"total_replicated"`
+}
+
+// SyncApplyResult reports how the cloud resolved a replicated batch
+type SyncApplyResult struct {
+	Applied   []string `json:"applied"`   // item IDs written to the cloud store
+	Skipped   []string `json:"skipped"`   // item IDs that lost conflict resolution or were duplicates
+	Rejected  []string `json:"rejected"`  // item IDs that could not be applied
+	Conflicts int      `json:"conflicts"` // items resolved in favour of the newer cloud state
+}
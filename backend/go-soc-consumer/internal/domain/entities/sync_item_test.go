@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSyncItem(t *testing.T) {
+	changedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.FixedZone("COT", -5*3600))
+
+	tests := []struct {
+		name        string
+		kind        string
+		key         string
+		payload     interface{}
+		changedAt   time.Time
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:      "valid device item",
+			kind:      SyncKindDevice,
+			key:       "AA:BB:CC:DD:EE:FF",
+			payload:   DeviceSyncPayload{MACAddress: "AA:BB:CC:DD:EE:FF"},
+			changedAt: changedAt,
+		},
+		{
+			name:        "unsupported kind",
+			kind:        "irrigation_zone",
+			key:         "zone-1",
+			payload:     map[string]string{},
+			changedAt:   changedAt,
+			wantErr:     true,
+			errContains: "unsupported sync item kind",
+		},
+		{
+			name:        "missing key",
+			kind:        SyncKindDevice,
+			payload:     map[string]string{},
+			changedAt:   changedAt,
+			wantErr:     true,
+			errContains: "key is required",
+		},
+		{
+			name:        "missing change time",
+			kind:        SyncKindSensorTemperatureHumidity,
+			key:         "AA:BB:CC:DD:EE:FF",
+			payload:     map[string]string{},
+			wantErr:     true,
+			errContains: "change time is required",
+		},
+		{
+			name:        "unencodable payload",
+			kind:        SyncKindDevice,
+			key:         "AA:BB:CC:DD:EE:FF",
+			payload:     make(chan int),
+			changedAt:   changedAt,
+			wantErr:     true,
+			errContains: "failed to encode sync payload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewSyncItem(tt.kind, tt.key, tt.payload, tt.changedAt)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Nil(t, item)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, item.ID)
+			assert.Equal(t, time.UTC, item.ChangedAt.Location())
+			assert.True(t, item.ChangedAt.Equal(tt.changedAt))
+			assert.JSONEq(t, `{"mac_address":"AA:BB:CC:DD:EE:FF","device_name":"","ip_address":"","location_description":"","registered_at":"0001-01-01T00:00:00Z","last_seen":"0001-01-01T00:00:00Z","status":""}`, string(item.Payload))
+			assert.False(t, item.IsSynced())
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// TelemetryDay identifies the readings of one device on one UTC calendar day,
+// the unit in which old telemetry is compressed
+type TelemetryDay struct {
+	MACAddress string
+	Day        time.Time
+}
+
+// NewTelemetryDay creates a device-day truncated to the start of the UTC day
+func NewTelemetryDay(macAddress string, at time.Time) TelemetryDay {
+	return TelemetryDay{
+		MACAddress: macAddress,
+		Day:        StartOfDay(at),
+	}
+}
+
+// StartOfDay returns midnight UTC of the day containing t
+func StartOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the exclusive end of the day
+func (d TelemetryDay) End() time.Time {
+	return d.Day.AddDate(0, 0, 1)
+}
+
+// String returns the device-day as MAC@YYYY-MM-DD
+func (d TelemetryDay) String() string {
+	return fmt.Sprintf("%s@%s", d.MACAddress, d.Day.Format(time.DateOnly))
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// UsageRecord is what one farm used of the server in a calendar month, to allocate hosting costs
+// across farms. Devices outside farm namespaces are metered under an empty farm ID.
+type UsageRecord struct {
+	FarmID        string
+	Month         time.Time // first instant of the month in UTC
+	DeviceDays    int64     // sum over the days of the month of the devices registered under the farm
+	Messages      int64     // telemetry messages ingested
+	StorageBytes  int64     // estimated bytes of telemetry stored
+	Notifications int64     // notifications delivered by email or SMS
+}
+
+// UsageMonth returns the month the time falls in, as the first instant of the month in UTC
+func UsageMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Add adds the usage of another record of the same farm and month
+func (r *UsageRecord) Add(other *UsageRecord) {
+	r.DeviceDays += other.DeviceDays
+	r.Messages += other.Messages
+	r.StorageBytes += other.StorageBytes
+	r.Notifications += other.Notifications
+}
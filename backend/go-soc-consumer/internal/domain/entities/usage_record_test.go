@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageMonth(t *testing.T) {
+	bogota := time.FixedZone("COT", -5*3600)
+
+	assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), UsageMonth(time.Date(2025, 6, 30, 23, 59, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), UsageMonth(time.Date(2025, 6, 30, 20, 0, 0, 0, bogota)), "months are in UTC")
+}
+
+func TestUsageRecord_Add(t *testing.T) {
+	record := &UsageRecord{FarmID: "farm-a", DeviceDays: 30, Messages: 100, StorageBytes: 1000, Notifications: 2}
+
+	record.Add(&UsageRecord{FarmID: "farm-a", DeviceDays: 1, Messages: 5, StorageBytes: 50, Notifications: 1})
+
+	assert.Equal(t, &UsageRecord{FarmID: "farm-a", DeviceDays: 31, Messages: 105, StorageBytes: 1050, Notifications: 3}, record)
+}
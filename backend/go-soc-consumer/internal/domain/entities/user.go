@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Roles of the users of the HTTP API
+const (
+	UserRoleAdmin  = "admin"  // operators managing the installation
+	UserRoleViewer = "viewer" // read-only dashboards and integrations
+)
+
+// Password bounds; bcrypt ignores anything after 72 bytes
+const (
+	minPasswordLen = 8
+	maxPasswordLen = 72
+)
+
+// usernamePattern restricts usernames to lowercase letters, digits, dots, dashes and underscores
+var usernamePattern = regexp.MustCompile(`^[a-z0-9._-]{3,64}$`)
+
+// User is an account that logs in to the HTTP API. Only the bcrypt hash of its password is kept.
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash string
+	Role         string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// UserRequest holds the fields of a user set when it is created
+type UserRequest struct {
+	Username string
+	Password string
+	Role     string // defaults to viewer
+}
+
+// AuthClaims are the claims carried by an access token
+type AuthClaims struct {
+	Subject   string // user ID
+	Username  string
+	Role      string
+	Issuer    string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// AuthToken is an access token issued to a user on login
+type AuthToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// NewUser creates a user, hashing its password
+func NewUser(request UserRequest, now time.Time) (*User, error) {
+	username := strings.ToLower(strings.TrimSpace(request.Username))
+	if !usernamePattern.MatchString(username) {
+		return nil, fmt.Errorf("username must be 3 to 64 lowercase letters, digits, dots, dashes or underscores")
+	}
+
+	role := strings.ToLower(strings.TrimSpace(request.Role))
+	if role == "" {
+		role = UserRoleViewer
+	}
+	if role != UserRoleAdmin && role != UserRoleViewer {
+		return nil, fmt.Errorf("role must be %s or %s", UserRoleAdmin, UserRoleViewer)
+	}
+
+	user := &User{
+		ID:        uuid.New().String(),
+		Username:  username,
+		Role:      role,
+		CreatedAt: now.UTC(),
+	}
+	if err := user.SetPassword(request.Password, now); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SetPassword replaces the password of the user
+func (u *User) SetPassword(password string, at time.Time) error {
+	if len(password) < minPasswordLen || len(password) > maxPasswordLen {
+		return fmt.Errorf("password must be %d to %d bytes", minPasswordLen, maxPasswordLen)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	u.PasswordHash = string(hash)
+	u.UpdatedAt = at.UTC()
+	return nil
+}
+
+// CheckPassword reports whether the password matches the one of the user
+func (u *User) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// IsAdmin reports whether the claims belong to an administrator
+func (c *AuthClaims) IsAdmin() bool {
+	return c.Role == UserRoleAdmin
+}
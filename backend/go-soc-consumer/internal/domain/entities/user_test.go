@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUser(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should hash the password and default to viewer", func(t *testing.T) {
+		user, err := NewUser(UserRequest{Username: " Maria.Lopez ", Password: "regando-2025"}, now)
+		require.NoError(t, err)
+		assert.NotEmpty(t, user.ID)
+		assert.Equal(t, "maria.lopez", user.Username)
+		assert.Equal(t, UserRoleViewer, user.Role)
+		assert.NotContains(t, user.PasswordHash, "regando-2025")
+		assert.True(t, user.CheckPassword("regando-2025"))
+		assert.False(t, user.CheckPassword("regando-2024"))
+	})
+
+	t.Run("should reject invalid users", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			request UserRequest
+			wantErr string
+		}{
+			{name: "short username", request: UserRequest{Username: "ab", Password: "regando-2025"}, wantErr: "username must be"},
+			{name: "username with spaces", request: UserRequest{Username: "maria lopez", Password: "regando-2025"}, wantErr: "username must be"},
+			{name: "unknown role", request: UserRequest{Username: "maria", Password: "regando-2025", Role: "root"}, wantErr: "role must be"},
+			{name: "short password", request: UserRequest{Username: "maria", Password: "short"}, wantErr: "password must be"},
+		}
+		for _, tt := range tests {
+			_, err := NewUser(tt.request, now)
+			assert.ErrorContains(t, err, tt.wantErr, tt.name)
+		}
+	})
+}
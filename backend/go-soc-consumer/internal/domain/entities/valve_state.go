@@ -0,0 +1,47 @@
+package entities
+
+import "time"
+
+// Irrigation valve states
+const (
+	ValveStateOpen    = "open"
+	ValveStateClosed  = "closed"
+	ValveStateUnknown = "unknown" // no valve command was acknowledged by the device yet
+)
+
+// ValveTransition is a change of the irrigation valve of a device, recorded once the device
+// acknowledged the command that caused it
+type ValveTransition struct {
+	CommandID  string // the acknowledged command, a device acknowledges each command once
+	MACAddress string
+	State      string
+	ChangedAt  time.Time
+}
+
+// ValveStateForCommand returns the state a valve command moves the valve to, and false for the
+// commands that do not actuate the valve
+func ValveStateForCommand(command string) (string, bool) {
+	switch command {
+	case DeviceCommandValveOpen:
+		return ValveStateOpen, true
+	case DeviceCommandValveClose:
+		return ValveStateClosed, true
+	default:
+		return "", false
+	}
+}
+
+// ValveTransitionFor returns the transition recorded for an acknowledged valve command, and false
+// for commands that do not actuate the valve or were not acknowledged
+func ValveTransitionFor(command *DeviceCommand) (*ValveTransition, bool) {
+	state, ok := ValveStateForCommand(command.Command)
+	if !ok || command.Status != DeviceCommandStatusAcknowledged || command.AckedAt == nil {
+		return nil, false
+	}
+	return &ValveTransition{
+		CommandID:  command.ID,
+		MACAddress: command.MACAddress,
+		State:      state,
+		ChangedAt:  *command.AckedAt,
+	}, true
+}
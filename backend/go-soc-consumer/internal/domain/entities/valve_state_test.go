@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValveTransitionFor(t *testing.T) {
+	ackedAt := time.Date(2025, 6, 1, 6, 0, 5, 0, time.UTC)
+	command := func(name, status string) *DeviceCommand {
+		return &DeviceCommand{ID: "command-1", MACAddress: "AA:BB:CC:DD:EE:FF", Command: name, Status: status, AckedAt: &ackedAt}
+	}
+
+	transition, ok := ValveTransitionFor(command(DeviceCommandValveOpen, DeviceCommandStatusAcknowledged))
+	assert.True(t, ok)
+	assert.Equal(t, &ValveTransition{CommandID: "command-1", MACAddress: "AA:BB:CC:DD:EE:FF", State: ValveStateOpen, ChangedAt: ackedAt}, transition)
+
+	transition, ok = ValveTransitionFor(command(DeviceCommandValveClose, DeviceCommandStatusAcknowledged))
+	assert.True(t, ok)
+	assert.Equal(t, ValveStateClosed, transition.State)
+
+	_, ok = ValveTransitionFor(command(DeviceCommandValveOpen, DeviceCommandStatusFailed))
+	assert.False(t, ok, "a failed command leaves the valve as it was")
+	_, ok = ValveTransitionFor(command(DeviceCommandReboot, DeviceCommandStatusAcknowledged))
+	assert.False(t, ok, "other commands do not actuate the valve")
+}
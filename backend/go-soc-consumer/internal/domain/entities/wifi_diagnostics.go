@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// Bounds of Wi-Fi diagnostics
+const (
+	MaxSSIDLength  = 32
+	MinWifiRSSI    = -127
+	MaxWifiChannel = 196
+)
+
+// Kinds of Wi-Fi advisories
+const (
+	WifiAdvisoryWeakSignal         = "weak_signal"
+	WifiAdvisoryUnstableConnection = "unstable_connection"
+	WifiAdvisoryOverlappingChannel = "overlapping_channel"
+)
+
+// WifiDiagnostics is the state of the Wi-Fi link reported by a device
+type WifiDiagnostics struct {
+	MACAddress     string
+	SSID           string
+	RSSI           int // received signal strength in dBm
+	Channel        int // 0 when the device does not report it
+	ReconnectCount int // reconnections since the previous report
+	ReportedAt     time.Time
+}
+
+// NewWifiDiagnostics creates validated Wi-Fi diagnostics
+func NewWifiDiagnostics(macAddress, ssid string, rssi, channel, reconnectCount int, reportedAt time.Time) (*WifiDiagnostics, error) {
+	diagnostics := &WifiDiagnostics{
+		MACAddress:     strings.ToUpper(strings.TrimSpace(macAddress)),
+		SSID:           ssid,
+		RSSI:           rssi,
+		Channel:        channel,
+		ReconnectCount: reconnectCount,
+		ReportedAt:     reportedAt.UTC(),
+	}
+
+	if err := validation.ValidateMACAddress(diagnostics.MACAddress); err != nil {
+		return nil, fmt.Errorf("invalid mac address: %w", err)
+	}
+	if len(diagnostics.SSID) > MaxSSIDLength {
+		return nil, fmt.Errorf("ssid must be at most %d bytes", MaxSSIDLength)
+	}
+	if diagnostics.RSSI < MinWifiRSSI || diagnostics.RSSI >= 0 {
+		return nil, fmt.Errorf("rssi must be between %d and -1 dBm", MinWifiRSSI)
+	}
+	if diagnostics.Channel < 0 || diagnostics.Channel > MaxWifiChannel {
+		return nil, fmt.Errorf("channel must be between 0 and %d", MaxWifiChannel)
+	}
+	if diagnostics.ReconnectCount < 0 {
+		return nil, fmt.Errorf("reconnect count cannot be negative")
+	}
+	return diagnostics, nil
+}
+
+// OverlappingChannel reports whether the device uses a 2.4 GHz channel overlapping its neighbours;
+// only channels 1, 6 and 11 do not overlap each other
+func (d *WifiDiagnostics) OverlappingChannel() bool {
+	return d.Channel >= 1 && d.Channel <= 14 && d.Channel != 1 && d.Channel != 6 && d.Channel != 11
+}
+
+// WifiLocationStats aggregates the latest Wi-Fi diagnostics of the devices of a location
+type WifiLocationStats struct {
+	FarmID            string
+	Location          string // location description of the devices
+	Devices           int
+	AverageRSSI       float64
+	WeakestRSSI       int
+	WeakSignalDevices []string // MAC addresses of the devices below the RSSI threshold
+	Reconnects        int      // reconnections in the latest report of every device
+	SSIDs             []string
+	Channels          []int
+	LastReportAt      time.Time
+}
+
+// WifiAdvisory points at devices of a location whose Wi-Fi link suggests moving or adding an access point
+type WifiAdvisory struct {
+	Kind        string
+	FarmID      string
+	Location    string
+	Devices     []string // MAC addresses of the devices concerned
+	Threshold   int      // the RSSI or reconnect threshold the devices crossed, 0 for channel advisories
+	Condition   string   // the condition the devices meet, e.g. rssi < -80 dBm
+	Description string
+	// LocalizedDescriptions holds the description in every supported language, keyed by language code
+	LocalizedDescriptions map[string]string
+	Since                 time.Time
+}
+
+// Key identifies the advisory across evaluations
+func (a *WifiAdvisory) Key() string {
+	return a.Kind + "\n" + a.FarmID + "\n" + a.Location
+}
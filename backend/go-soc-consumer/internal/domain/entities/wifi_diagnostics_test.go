@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWifiDiagnostics(t *testing.T) {
+	reportedAt := time.Date(2025, 6, 1, 7, 0, 0, 0, time.FixedZone("COT", -5*3600))
+
+	t.Run("normalizes the fields", func(t *testing.T) {
+		diagnostics, err := NewWifiDiagnostics(" aa:bb:cc:dd:ee:ff ", "finca", -72, 6, 3, reportedAt)
+
+		require.NoError(t, err)
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", diagnostics.MACAddress)
+		assert.Equal(t, time.UTC, diagnostics.ReportedAt.Location())
+		assert.False(t, diagnostics.OverlappingChannel())
+	})
+
+	tests := []struct {
+		name       string
+		macAddress string
+		ssid       string
+		rssi       int
+		channel    int
+		reconnects int
+	}{
+		{name: "invalid mac address", macAddress: "not-a-mac", rssi: -60},
+		{name: "ssid too long", macAddress: "AA:BB:CC:DD:EE:FF", ssid: strings.Repeat("s", MaxSSIDLength+1), rssi: -60},
+		{name: "positive rssi", macAddress: "AA:BB:CC:DD:EE:FF", rssi: 10},
+		{name: "rssi too low", macAddress: "AA:BB:CC:DD:EE:FF", rssi: MinWifiRSSI - 1},
+		{name: "unknown channel", macAddress: "AA:BB:CC:DD:EE:FF", rssi: -60, channel: MaxWifiChannel + 1},
+		{name: "negative reconnects", macAddress: "AA:BB:CC:DD:EE:FF", rssi: -60, reconnects: -1},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			_, err := NewWifiDiagnostics(tt.macAddress, tt.ssid, tt.rssi, tt.channel, tt.reconnects, reportedAt)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWifiDiagnostics_OverlappingChannel(t *testing.T) {
+	for channel, overlapping := range map[int]bool{0: false, 1: false, 3: true, 6: false, 9: true, 11: false, 13: true, 36: false} {
+		diagnostics := &WifiDiagnostics{Channel: channel}
+		assert.Equal(t, overlapping, diagnostics.OverlappingChannel(), "channel %d", channel)
+	}
+}
@@ -0,0 +1,11 @@
+package errors
+
+// Authentication domain errors
+var (
+	ErrInvalidCredentials = NewDomainError("INVALID_CREDENTIALS", "Invalid username or password")
+	ErrInvalidToken       = NewDomainError("INVALID_TOKEN", "Invalid access token")
+	ErrTokenExpired       = NewDomainError("TOKEN_EXPIRED", "Access token expired")
+	ErrUserNotFound       = NewDomainError("USER_NOT_FOUND", "User not found")
+	ErrUserAlreadyExists  = NewDomainError("USER_ALREADY_EXISTS", "User already exists")
+	ErrInvalidUser        = NewDomainError("INVALID_USER", "Invalid user")
+)
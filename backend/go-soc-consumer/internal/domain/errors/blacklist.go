@@ -0,0 +1,8 @@
+package errors
+
+// Blacklist-specific domain errors
+var (
+	ErrBlacklistEntryNotFound = NewDomainError("BLACKLIST_ENTRY_NOT_FOUND", "Blacklist entry not found")
+	ErrBlacklistEntryExists   = NewDomainError("BLACKLIST_ENTRY_EXISTS", "Blacklist entry already exists")
+	ErrInvalidBlacklistEntry  = NewDomainError("INVALID_BLACKLIST_ENTRY", "Invalid blacklist entry")
+)
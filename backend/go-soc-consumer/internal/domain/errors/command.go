@@ -0,0 +1,15 @@
+package errors
+
+// Command-specific domain errors
+var (
+	ErrInvalidCommandSignature = NewDomainError("INVALID_COMMAND_SIGNATURE", "Command signature is invalid")
+	ErrCommandExpired          = NewDomainError("COMMAND_EXPIRED", "Command timestamp is outside the replay window")
+	ErrCommandReplayed         = NewDomainError("COMMAND_REPLAYED", "Command nonce was already used")
+	ErrUnknownSigningKey       = NewDomainError("UNKNOWN_SIGNING_KEY", "Command signing key is unknown")
+)
+
+// Device command domain errors
+var (
+	ErrInvalidDeviceCommand  = NewDomainError("INVALID_DEVICE_COMMAND", "Invalid device command")
+	ErrDeviceCommandNotFound = NewDomainError("DEVICE_COMMAND_NOT_FOUND", "Device command not found")
+)
@@ -0,0 +1,6 @@
+package errors
+
+// Declarative configuration domain errors
+var (
+	ErrInvalidDeclaration = NewDomainError("INVALID_DECLARATION", "Invalid configuration declaration")
+)
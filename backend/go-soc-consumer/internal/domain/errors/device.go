@@ -2,7 +2,17 @@ package errors
 
 // Device-specific domain errors
 var (
-	ErrDeviceNotFound      = NewDomainError("DEVICE_NOT_FOUND", "Device not found")
-	ErrDeviceAlreadyExists = NewDomainError("DEVICE_ALREADY_EXISTS", "Device already exists")
-	ErrInvalidDeviceStatus = NewDomainError("INVALID_DEVICE_STATUS", "Invalid device status")
+	ErrDeviceNotFound                = NewDomainError("DEVICE_NOT_FOUND", "Device not found")
+	ErrDeviceAlreadyExists           = NewDomainError("DEVICE_ALREADY_EXISTS", "Device already exists")
+	ErrInvalidDeviceStatus           = NewDomainError("INVALID_DEVICE_STATUS", "Invalid device status")
+	ErrInvalidDevice                 = NewDomainError("INVALID_DEVICE", "Invalid device")
+	ErrDeviceIdentityMismatch        = NewDomainError("DEVICE_IDENTITY_MISMATCH", "Message does not originate from the device's provisioned identity")
+	ErrInvalidCertificateFingerprint = NewDomainError("INVALID_CERTIFICATE_FINGERPRINT", "Invalid certificate fingerprint")
+	ErrDeviceFarmMismatch            = NewDomainError("DEVICE_FARM_MISMATCH", "Device belongs to another farm")
+	ErrInvalidCalibration            = NewDomainError("INVALID_CALIBRATION", "Invalid calibration")
+	ErrInvalidStatusQuery            = NewDomainError("INVALID_STATUS_QUERY", "Invalid device status query")
+	ErrChangeCursorExpired           = NewDomainError("CHANGE_CURSOR_EXPIRED", "Change cursor is no longer in the journal")
+	ErrInvalidDeviceBundle           = NewDomainError("INVALID_DEVICE_BUNDLE", "Invalid device bundle")
+	ErrInvalidTargetVersion          = NewDomainError("INVALID_TARGET_VERSION", "Invalid target version")
+	ErrDeviceConnectionNotFound      = NewDomainError("DEVICE_CONNECTION_NOT_FOUND", "Device connection not found")
 )
@@ -0,0 +1,9 @@
+package errors
+
+// Device archive domain errors
+var (
+	ErrDeviceArchiveNotFound = NewDomainError("DEVICE_ARCHIVE_NOT_FOUND", "Device archive not found")
+	ErrInvalidDeviceArchive  = NewDomainError("INVALID_DEVICE_ARCHIVE", "Invalid device archive")
+	ErrDeviceArchiveRestored = NewDomainError("DEVICE_ARCHIVE_RESTORED", "Device archive already restored")
+	ErrDeviceInService       = NewDomainError("DEVICE_IN_SERVICE", "Device is in service again")
+)
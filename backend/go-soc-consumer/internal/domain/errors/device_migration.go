@@ -0,0 +1,9 @@
+package errors
+
+// Device migration domain errors
+var (
+	ErrDeviceMigrationNotFound = NewDomainError("DEVICE_MIGRATION_NOT_FOUND", "Device migration not found")
+	ErrInvalidDeviceMigration  = NewDomainError("INVALID_DEVICE_MIGRATION", "Invalid device migration")
+	ErrDeviceMigrationRunning  = NewDomainError("DEVICE_MIGRATION_RUNNING", "Device migration already running")
+	ErrDeviceMigrationFinished = NewDomainError("DEVICE_MIGRATION_FINISHED", "Device migration already finished")
+)
@@ -0,0 +1,9 @@
+package errors
+
+// Device transfer domain errors
+var (
+	ErrDeviceTransferNotFound     = NewDomainError("DEVICE_TRANSFER_NOT_FOUND", "Device transfer not found")
+	ErrInvalidDeviceTransfer      = NewDomainError("INVALID_DEVICE_TRANSFER", "Invalid device transfer")
+	ErrDeviceTransferInProgress   = NewDomainError("DEVICE_TRANSFER_IN_PROGRESS", "Device has an open transfer")
+	ErrDeviceTransferStatusChange = NewDomainError("DEVICE_TRANSFER_STATUS_CHANGE", "Device transfer step not allowed")
+)
@@ -0,0 +1,7 @@
+package errors
+
+// Event publishing and handling domain errors
+var (
+	ErrInvalidEventPolicy     = NewDomainError("INVALID_EVENT_POLICY", "Invalid event policy")
+	ErrMessageHandlerPanicked = NewDomainError("MESSAGE_HANDLER_PANICKED", "The message handler panicked")
+)
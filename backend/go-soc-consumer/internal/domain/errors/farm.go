@@ -0,0 +1,19 @@
+package errors
+
+// Farm quota domain errors
+var (
+	ErrFarmQuotaExceeded = NewDomainError("FARM_QUOTA_EXCEEDED", "Farm quota exceeded")
+	ErrInvalidFarmQuota  = NewDomainError("INVALID_FARM_QUOTA", "Invalid farm quota")
+)
+
+// Farm hierarchy domain errors
+var (
+	ErrFarmNotFound      = NewDomainError("FARM_NOT_FOUND", "Farm not found")
+	ErrFarmAlreadyExists = NewDomainError("FARM_ALREADY_EXISTS", "Farm already exists")
+	ErrInvalidFarm       = NewDomainError("INVALID_FARM", "Invalid farm")
+	ErrFarmNotEmpty      = NewDomainError("FARM_NOT_EMPTY", "Farm has fields")
+	ErrZoneNotFound      = NewDomainError("ZONE_NOT_FOUND", "Zone not found")
+	ErrInvalidZone       = NewDomainError("INVALID_ZONE", "Invalid zone")
+	ErrZoneNotEmpty      = NewDomainError("ZONE_NOT_EMPTY", "Zone has zones or devices")
+	ErrDeviceNotInZone   = NewDomainError("DEVICE_NOT_IN_ZONE", "Device not in zone")
+)
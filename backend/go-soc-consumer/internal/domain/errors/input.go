@@ -0,0 +1,27 @@
+package errors
+
+import "fmt"
+
+// Use case input domain errors
+var (
+	ErrMissingInput = NewDomainError("MISSING_INPUT", "A required input is missing")
+)
+
+// RequireInput returns ErrMissingInput naming the input when it is nil, so use case entry points
+// reject it instead of panicking on the first dereference
+func RequireInput[T any](name string, input *T) error {
+	if input == nil {
+		return fmt.Errorf("%w: %s is required", ErrMissingInput, name)
+	}
+	return nil
+}
+
+// RequireInputs returns ErrMissingInput naming the first nil element of the inputs
+func RequireInputs[T any](name string, inputs []*T) error {
+	for i, input := range inputs {
+		if input == nil {
+			return fmt.Errorf("%w: %s[%d] is required", ErrMissingInput, name, i)
+		}
+	}
+	return nil
+}
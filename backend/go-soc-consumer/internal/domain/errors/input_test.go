@@ -0,0 +1,25 @@
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireInput(t *testing.T) {
+	var missing *DomainError
+
+	err := RequireInput("policy", missing)
+	assert.ErrorIs(t, err, ErrMissingInput)
+	assert.Contains(t, err.Error(), "policy is required")
+	assert.NoError(t, RequireInput("policy", ErrInvalidInput))
+}
+
+func TestRequireInputs(t *testing.T) {
+	assert.NoError(t, RequireInputs[DomainError]("items", nil), "empty inputs are left to the use case")
+	assert.NoError(t, RequireInputs("items", []*DomainError{ErrNotFound, ErrInvalidInput}))
+
+	err := RequireInputs("items", []*DomainError{ErrNotFound, nil})
+	assert.ErrorIs(t, err, ErrMissingInput)
+	assert.Contains(t, err.Error(), "items[1] is required")
+}
@@ -0,0 +1,20 @@
+package errors
+
+// Irrigation scheduling domain errors
+var (
+	ErrIrrigationScheduleNotFound = NewDomainError("IRRIGATION_SCHEDULE_NOT_FOUND", "Irrigation schedule not found")
+	ErrInvalidIrrigationSchedule  = NewDomainError("INVALID_IRRIGATION_SCHEDULE", "Invalid irrigation schedule")
+	ErrIrrigationScheduleOverlap  = NewDomainError("IRRIGATION_SCHEDULE_OVERLAP", "Irrigation schedules overlap")
+	ErrIrrigationOverrideNotFound = NewDomainError("IRRIGATION_OVERRIDE_NOT_FOUND", "Irrigation override not found")
+	ErrInvalidIrrigationOverride  = NewDomainError("INVALID_IRRIGATION_OVERRIDE", "Invalid irrigation override")
+	ErrIrrigationZoneNotFound     = NewDomainError("IRRIGATION_ZONE_NOT_FOUND", "Irrigation zone not found")
+	ErrInvalidAnalyticsQuery      = NewDomainError("INVALID_ANALYTICS_QUERY", "Invalid analytics query")
+)
+
+// Irrigation experiment domain errors
+var (
+	ErrIrrigationExperimentNotFound = NewDomainError("IRRIGATION_EXPERIMENT_NOT_FOUND", "Irrigation experiment not found")
+	ErrInvalidIrrigationExperiment  = NewDomainError("INVALID_IRRIGATION_EXPERIMENT", "Invalid irrigation experiment")
+	ErrIrrigationExperimentConflict = NewDomainError("IRRIGATION_EXPERIMENT_CONFLICT", "Zone already in an experiment")
+	ErrIrrigationExperimentEnded    = NewDomainError("IRRIGATION_EXPERIMENT_ENDED", "Irrigation experiment ended")
+)
@@ -0,0 +1,8 @@
+package errors
+
+// Job-specific domain errors
+var (
+	ErrJobNotFound        = NewDomainError("JOB_NOT_FOUND", "Job not found")
+	ErrJobAlreadyFinished = NewDomainError("JOB_ALREADY_FINISHED", "Job already finished")
+	ErrUnknownJobType     = NewDomainError("UNKNOWN_JOB_TYPE", "No handler is registered for the job type")
+)
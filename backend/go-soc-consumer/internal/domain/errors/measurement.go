@@ -0,0 +1,9 @@
+package errors
+
+// Measurement-specific domain errors
+var (
+	ErrUnknownSensorType    = NewDomainError("UNKNOWN_SENSOR_TYPE", "Unknown sensor type")
+	ErrInvalidMeasurement   = NewDomainError("INVALID_MEASUREMENT", "Invalid measurement")
+	ErrInvalidSensorChannel = NewDomainError("INVALID_SENSOR_CHANNEL", "Invalid sensor channel")
+	ErrInsufficientReadings = NewDomainError("INSUFFICIENT_READINGS", "Not enough readings to predict")
+)
@@ -0,0 +1,8 @@
+package errors
+
+// Message tracing domain errors
+var (
+	ErrTraceSessionNotFound     = NewDomainError("TRACE_SESSION_NOT_FOUND", "Trace session not found")
+	ErrInvalidTraceSession      = NewDomainError("INVALID_TRACE_SESSION", "Invalid trace session")
+	ErrTraceSessionLimitReached = NewDomainError("TRACE_SESSION_LIMIT_REACHED", "Too many trace sessions")
+)
@@ -0,0 +1,7 @@
+package errors
+
+// Notification-specific domain errors
+var (
+	ErrNotificationUserNotFound  = NewDomainError("NOTIFICATION_USER_NOT_FOUND", "Notification user not found")
+	ErrInboxNotificationNotFound = NewDomainError("INBOX_NOTIFICATION_NOT_FOUND", "Inbox notification not found")
+)
@@ -0,0 +1,9 @@
+package errors
+
+// Device onboarding domain errors
+var (
+	ErrOnboardingNotFound     = NewDomainError("ONBOARDING_NOT_FOUND", "Onboarding not found")
+	ErrInvalidOnboarding      = NewDomainError("INVALID_ONBOARDING", "Invalid onboarding")
+	ErrOnboardingExists       = NewDomainError("ONBOARDING_EXISTS", "Device already onboarded")
+	ErrOnboardingStepNotReady = NewDomainError("ONBOARDING_STEP_NOT_READY", "Onboarding step not ready")
+)
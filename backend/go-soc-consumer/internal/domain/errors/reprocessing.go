@@ -0,0 +1,6 @@
+package errors
+
+// Reprocessing-specific domain errors
+var (
+	ErrInvalidReprocessRange = NewDomainError("INVALID_REPROCESS_RANGE", "Invalid reprocessing range")
+)
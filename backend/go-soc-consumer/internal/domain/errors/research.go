@@ -0,0 +1,8 @@
+package errors
+
+// Research data sharing domain errors
+var (
+	ErrResearchTokenNotFound = NewDomainError("RESEARCH_TOKEN_NOT_FOUND", "Research token not found")
+	ErrInvalidResearchToken  = NewDomainError("INVALID_RESEARCH_TOKEN", "Invalid research token")
+	ErrResearchTokenRevoked  = NewDomainError("RESEARCH_TOKEN_REVOKED", "Research token already revoked")
+)
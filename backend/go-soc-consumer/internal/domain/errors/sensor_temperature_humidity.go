@@ -4,4 +4,6 @@ package errors
 var (
 	ErrSensorTemperatureHumidityNotFound   = NewDomainError("SENSOR_TEMPERATURE_HUMIDITY_NOT_FOUND", "Sensor temperature humidity not found")
 	ErrSensorTemperatureHumidityNotCreated = NewDomainError("SENSOR_TEMPERATURE_HUMIDITY_NOT_CREATED", "Sensor temperature humidity not created")
+	ErrSensorTemperatureHumidityCompacted  = NewDomainError("SENSOR_TEMPERATURE_HUMIDITY_COMPACTED", "Readings of a compacted day cannot be replaced")
+	ErrInvalidReadingsQuery                = NewDomainError("INVALID_READINGS_QUERY", "Invalid readings query")
 )
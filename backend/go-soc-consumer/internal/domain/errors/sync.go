@@ -0,0 +1,8 @@
+package errors
+
+// Sync-specific domain errors
+var (
+	ErrSyncDisabled       = NewDomainError("SYNC_DISABLED", "Edge-cloud synchronization is disabled")
+	ErrSyncRemoteRejected = NewDomainError("SYNC_REMOTE_REJECTED", "The cloud instance rejected the sync batch")
+	ErrInvalidSyncItem    = NewDomainError("INVALID_SYNC_ITEM", "Invalid sync item")
+)
@@ -4,10 +4,46 @@ package events
 const (
 	// DeviceDetectedEventType represents the type for device detected events
 	DeviceDetectedEventType = "device.detected"
+
+	// DeviceOfflineEventType represents the type for devices marked offline after going silent
+	DeviceOfflineEventType = "device.offline"
+
+	// DeviceOnlineEventType represents the type for devices found alive after not being online
+	DeviceOnlineEventType = "device.online"
+
+	// DeviceTransferEventType represents the type for device transfers between farms changing status
+	DeviceTransferEventType = "device.transfer"
+
+	// SecurityAlertEventType represents the type for security alert events
+	SecurityAlertEventType = "security.alert"
+
+	// AlertEventType represents the type for alerts raised by alert rules
+	AlertEventType = "alert"
+
+	// SystemHealthEventType represents the type for periodic health reports
+	SystemHealthEventType = "system.health"
 )
 
 // NATS subject constants following project naming conventions
 const (
 	// DeviceDetectedSubject is the NATS subject for device detected events
 	DeviceDetectedSubject = "liwaisi.iot.smart-irrigation.device.detected"
-)
\ No newline at end of file
+
+	// DeviceOfflineSubject is the NATS subject for devices marked offline by the offline sweeper
+	DeviceOfflineSubject = "liwaisi.iot.smart-irrigation.device.offline"
+
+	// DeviceOnlineSubject is the NATS subject for devices a health check found alive again
+	DeviceOnlineSubject = "liwaisi.iot.smart-irrigation.device.online"
+
+	// DeviceTransferSubject is the NATS subject device transfers are published on as they progress
+	DeviceTransferSubject = "liwaisi.iot.smart-irrigation.device.transfer"
+
+	// SecurityAlertSubject is the NATS subject for security alerts raised by anomaly detection
+	SecurityAlertSubject = "liwaisi.iot.smart-irrigation.security.alert"
+
+	// AlertSubject is the NATS subject alerts raised by alert rules are published on
+	AlertSubject = "liwaisi.iot.smart-irrigation.alert"
+
+	// SystemHealthSubject is the NATS subject health reports are published on for fleet monitoring
+	SystemHealthSubject = "liwaisi.iot.smart-irrigation.system.health"
+)
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// CommandPublisher defines the contract for delivering commands to devices
+type CommandPublisher interface {
+	// Publish sends the envelope to the device it targets, on the command topic of the farm of the
+	// device when it has one, and returns once the broker accepted it
+	Publish(ctx context.Context, device *entities.Device, envelope *entities.CommandEnvelope) error
+}
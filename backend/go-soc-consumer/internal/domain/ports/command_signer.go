@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// CommandSigner defines the contract for producing and checking signed command envelopes
+type CommandSigner interface {
+	// Sign wraps the command in an envelope signed with the active key
+	Sign(target, command string, payload interface{}) (*entities.CommandEnvelope, error)
+
+	// Verify checks the signature, the replay window and that the nonce was not used before
+	Verify(envelope *entities.CommandEnvelope) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// DeviceArchiveStore defines the contract for keeping the measurements of decommissioned devices
+// in object storage, one object per archive
+type DeviceArchiveStore interface {
+	// Store writes the measurements of the archive and returns the key of the object
+	Store(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement) (string, error)
+
+	// Load reads the measurements of the offloaded archive from its object, oldest first
+	Load(ctx context.Context, archive *entities.DeviceArchive) ([]*entities.Measurement, error)
+
+	// Delete removes the object of the offloaded archive
+	Delete(ctx context.Context, archive *entities.DeviceArchive) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+type clientIdentityKey struct{}
+
+// ContextWithClientIdentity attaches the identity of the publishing client to a message context
+func ContextWithClientIdentity(ctx context.Context, identity *entities.ClientIdentity) context.Context {
+	return context.WithValue(ctx, clientIdentityKey{}, identity)
+}
+
+// ClientIdentityFromContext returns the identity of the publishing client, or nil when the broker did not report it
+func ClientIdentityFromContext(ctx context.Context) *entities.ClientIdentity {
+	identity, _ := ctx.Value(clientIdentityKey{}).(*entities.ClientIdentity)
+	return identity
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+)
+
+// Delivery describes how the broker delivered a live message
+type Delivery struct {
+	QoS      byte
+	Retained bool
+}
+
+type deliveryKey struct{}
+
+// ContextWithDelivery records how the broker delivered a live message
+func ContextWithDelivery(ctx context.Context, delivery Delivery) context.Context {
+	return context.WithValue(ctx, deliveryKey{}, delivery)
+}
+
+// DeliveryFromContext returns how the broker delivered a live message; ok is false for messages
+// not delivered by a broker, such as replayed ones
+func DeliveryFromContext(ctx context.Context) (delivery Delivery, ok bool) {
+	delivery, ok = ctx.Value(deliveryKey{}).(Delivery)
+	return delivery, ok
+}
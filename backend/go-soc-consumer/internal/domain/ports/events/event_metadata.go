@@ -0,0 +1,22 @@
+package ports
+
+import "context"
+
+// EventMetadata describes a published event without its payload
+type EventMetadata struct {
+	EventType     string
+	SchemaVersion string
+}
+
+type eventMetadataKey struct{}
+
+// ContextWithEventMetadata attaches the metadata carried alongside a received event to a message context
+func ContextWithEventMetadata(ctx context.Context, metadata EventMetadata) context.Context {
+	return context.WithValue(ctx, eventMetadataKey{}, metadata)
+}
+
+// EventMetadataFromContext returns the metadata of the received event; ok is false when the publisher sent none
+func EventMetadataFromContext(ctx context.Context) (metadata EventMetadata, ok bool) {
+	metadata, ok = ctx.Value(eventMetadataKey{}).(EventMetadata)
+	return metadata, ok
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "context"
+
+type farmIDKey struct{}
+
+// ContextWithFarmID attaches the farm namespace a message was published in to a message context
+func ContextWithFarmID(ctx context.Context, farmID string) context.Context {
+	return context.WithValue(ctx, farmIDKey{}, farmID)
+}
+
+// FarmIDFromContext returns the farm namespace of the message, or an empty string for the shared topics
+func FarmIDFromContext(ctx context.Context) string {
+	farmID, _ := ctx.Value(farmIDKey{}).(string)
+	return farmID
+}
@@ -2,16 +2,75 @@ package ports
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
 )
 
 // MessageHandler defines a function type for handling received messages
 type MessageHandler func(ctx context.Context, topic string, payload []byte) error
 
+// Recovering wraps a message handler so a panic while handling a message is returned as an
+// ErrMessageHandlerPanicked error instead of taking down the consumer
+func Recovering(handler MessageHandler) MessageHandler {
+	return func(ctx context.Context, topic string, payload []byte) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = fmt.Errorf("%w: %v", domainerrors.ErrMessageHandlerPanicked, recovered)
+			}
+		}()
+		return handler(ctx, topic, payload)
+	}
+}
+
+// HandlerErrorPolicy decides what the handlers of a topic do when one of them fails
+type HandlerErrorPolicy int
+
+const (
+	// StopOnError skips the remaining handlers of a message once one fails and returns its error
+	StopOnError HandlerErrorPolicy = iota
+	// ContinueOnError hands the message to every handler and returns their errors joined
+	ContinueOnError
+)
+
+// FanOut returns a handler handing each message to the handlers in order, with the given error
+// policy. Each handler recovers from its own panics, so a panicking handler fails like any other.
+func FanOut(policy HandlerErrorPolicy, handlers ...MessageHandler) MessageHandler {
+	if len(handlers) == 1 {
+		return Recovering(handlers[0])
+	}
+
+	recovering := make([]MessageHandler, len(handlers))
+	for i, handler := range handlers {
+		recovering[i] = Recovering(handler)
+	}
+	return func(ctx context.Context, topic string, payload []byte) error {
+		var errs []error
+		for i, handler := range recovering {
+			err := handler(ctx, topic, payload)
+			if err == nil {
+				continue
+			}
+			err = fmt.Errorf("handler %d of %d: %w", i+1, len(recovering), err)
+			if policy == StopOnError {
+				return err
+			}
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	}
+}
+
 // MessageConsumer defines the contract for consuming messages from external systems
 type MessageConsumer interface {
 	// Subscribe starts consuming messages from the specified topic
 	Subscribe(ctx context.Context, topic string, handler MessageHandler) error
 
+	// SubscribeHandlers starts consuming messages from the specified topic, handing each message to
+	// the handlers in order, as FanOut does. Subscribing a topic again replaces its handlers.
+	SubscribeHandlers(ctx context.Context, topic string, policy HandlerErrorPolicy, handlers ...MessageHandler) error
+
 	// Unsubscribe stops consuming messages from the specified topic
 	Unsubscribe(topic string) error
 
@@ -23,4 +82,4 @@ type MessageConsumer interface {
 
 	// IsConnected returns the connection status
 	IsConnected() bool
-}
\ No newline at end of file
+}
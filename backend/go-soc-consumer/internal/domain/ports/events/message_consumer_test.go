@@ -0,0 +1,76 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+)
+
+func TestRecovering(t *testing.T) {
+	handlerErr := errors.New("invalid payload")
+	handler := Recovering(func(_ context.Context, topic string, _ []byte) error {
+		switch topic {
+		case "panics":
+			var message *struct{ MACAddress string }
+			_ = message.MACAddress
+		case "fails":
+			return handlerErr
+		}
+		return nil
+	})
+
+	assert.NoError(t, handler(context.Background(), "ok", nil))
+	assert.ErrorIs(t, handler(context.Background(), "fails", nil), handlerErr)
+
+	err := handler(context.Background(), "panics", nil)
+	assert.ErrorIs(t, err, domainerrors.ErrMessageHandlerPanicked)
+	assert.Contains(t, err.Error(), "nil pointer dereference")
+}
+
+func TestFanOut(t *testing.T) {
+	handlerErr := errors.New("audit log unavailable")
+	var calls []string
+	handler := func(name string, err error) MessageHandler {
+		return func(_ context.Context, _ string, _ []byte) error {
+			calls = append(calls, name)
+			return err
+		}
+	}
+	panicking := func(_ context.Context, _ string, _ []byte) error {
+		calls = append(calls, "tap")
+		panic("tap failed")
+	}
+
+	t.Run("should stop at the first failing handler", func(t *testing.T) {
+		calls = nil
+		err := FanOut(StopOnError, handler("audit", handlerErr), handler("store", nil))(context.Background(), "topic", nil)
+
+		assert.ErrorIs(t, err, handlerErr)
+		assert.EqualError(t, err, "handler 1 of 2: audit log unavailable")
+		assert.Equal(t, []string{"audit"}, calls)
+	})
+
+	t.Run("should run every handler and join their errors", func(t *testing.T) {
+		calls = nil
+		err := FanOut(ContinueOnError, handler("audit", handlerErr), panicking, handler("store", nil))(context.Background(), "topic", nil)
+
+		assert.ErrorIs(t, err, handlerErr)
+		assert.ErrorIs(t, err, domainerrors.ErrMessageHandlerPanicked)
+		assert.Equal(t, []string{"audit", "tap", "store"}, calls)
+	})
+
+	t.Run("should succeed when every handler does", func(t *testing.T) {
+		calls = nil
+		assert.NoError(t, FanOut(ContinueOnError, handler("audit", nil), handler("store", nil))(context.Background(), "topic", nil))
+		assert.Equal(t, []string{"audit", "store"}, calls)
+	})
+
+	t.Run("should leave the error of a single handler as it is", func(t *testing.T) {
+		err := FanOut(StopOnError, handler("store", handlerErr))(context.Background(), "topic", nil)
+		assert.Equal(t, handlerErr, err)
+	})
+}
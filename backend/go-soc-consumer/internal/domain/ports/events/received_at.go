@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type receivedAtKey struct{}
+
+// ContextWithReceivedAt records when a live message was received from the broker
+func ContextWithReceivedAt(ctx context.Context, receivedAt time.Time) context.Context {
+	return context.WithValue(ctx, receivedAtKey{}, receivedAt)
+}
+
+// ReceivedAtFromContext returns when a live message was received from the broker; ok is false
+// when the receipt time was not recorded
+func ReceivedAtFromContext(ctx context.Context) (receivedAt time.Time, ok bool) {
+	receivedAt, ok = ctx.Value(receivedAtKey{}).(time.Time)
+	return receivedAt, ok
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type replayKey struct{}
+
+// ContextWithReplay marks a message as replayed from the raw archive, originally received at receivedAt
+func ContextWithReplay(ctx context.Context, receivedAt time.Time) context.Context {
+	return context.WithValue(ctx, replayKey{}, receivedAt)
+}
+
+// ReplayFromContext returns when a replayed message was originally received; ok is false for live messages
+func ReplayFromContext(ctx context.Context) (receivedAt time.Time, ok bool) {
+	receivedAt, ok = ctx.Value(replayKey{}).(time.Time)
+	return receivedAt, ok
+}
+
+// IsReplay reports whether the message is replayed from the raw archive
+func IsReplay(ctx context.Context) bool {
+	_, ok := ReplayFromContext(ctx)
+	return ok
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// JobProgressFunc reports the progress of a running job as a percentage with an optional message
+type JobProgressFunc func(percent int, message string)
+
+// JobHandler executes a background job. The context is cancelled when cancellation is requested or the queue stops.
+// The returned result is stored as JSON on the job.
+type JobHandler func(ctx context.Context, job *entities.Job, progress JobProgressFunc) (interface{}, error)
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// MoisturePredictor defines the contract for the models predicting when the soil of a zone dries
+// to its irrigation threshold
+type MoisturePredictor interface {
+	// Name identifies the model in logs, metrics and predictions
+	Name() string
+
+	// Predict forecasts the moisture of a zone from its recent readings
+	Predict(ctx context.Context, request *entities.MoisturePredictionRequest) (*entities.MoisturePrediction, error)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// NotificationSender defines the contract for delivering notifications to users on one channel
+type NotificationSender interface {
+	// Channel is the notification channel the sender delivers on, such as email or sms
+	Channel() string
+
+	// Send delivers the notification to its address
+	Send(ctx context.Context, notification *entities.Notification) error
+}
+
+// AlertNotifier defines the contract for notifying users of raised alerts
+type AlertNotifier interface {
+	// Notify routes the alert to the users that want to hear about it; delivery happens asynchronously
+	Notify(ctx context.Context, alert *entities.Alert)
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+
+// OverrideSource reports the operator overrides of a component that are currently in effect
+type OverrideSource interface {
+	// ActiveOverrides returns the overrides in effect, none when the component runs normally
+	ActiveOverrides() []*entities.OperatorOverride
+}
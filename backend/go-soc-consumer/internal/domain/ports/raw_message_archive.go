@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// RawMessageArchive defines the contract for storing raw inbound messages in hourly bundles
+type RawMessageArchive interface {
+	// Store writes the messages received within the hour starting at hour as a new bundle.
+	// An hour may be stored in several bundles; none of them replaces another.
+	Store(ctx context.Context, hour time.Time, messages []*entities.RawMessage) error
+
+	// Read calls fn with every archived message received in [from, to), bundle by bundle in hour
+	// order. Bundles of the same hour are read in no particular order. Reading stops at the first error of fn.
+	Read(ctx context.Context, from, to time.Time, fn func(message *entities.RawMessage) error) error
+
+	// DeleteBefore removes the bundles of the hours that started before the cutoff and returns how many were removed
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int, error)
+}
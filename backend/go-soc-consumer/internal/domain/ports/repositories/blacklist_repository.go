@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// BlacklistRepository defines the contract for persisting blocked MAC addresses and IP ranges
+type BlacklistRepository interface {
+	// Create persists a new entry, failing with ErrBlacklistEntryExists when the kind and value are already blocked
+	Create(ctx context.Context, entry *entities.BlacklistEntry) error
+
+	// Delete removes an entry by its ID
+	Delete(ctx context.Context, id string) error
+
+	// List returns every entry, oldest first
+	List(ctx context.Context) ([]*entities.BlacklistEntry, error)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// CrashReportRepository defines the contract for persisting crash reports of devices, keeping the
+// stack information of each distinct crash once
+type CrashReportRepository interface {
+	// Record stores the report and its signature and reports whether the signature was seen for the first time
+	Record(ctx context.Context, report *entities.CrashReport) (bool, error)
+
+	// FirmwareStats returns the crashes reported since the given time by firmware version, most crashes first.
+	// TopSignatures is left empty.
+	FirmwareStats(ctx context.Context, since time.Time) ([]*entities.FirmwareCrashStats, error)
+
+	// SignatureStats returns every crash signature reported since the given time, most occurrences first
+	SignatureStats(ctx context.Context, since time.Time) ([]*entities.CrashSignature, error)
+}
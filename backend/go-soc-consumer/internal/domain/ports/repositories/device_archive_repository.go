@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// DeviceArchiveRepository defines the contract for archiving the data of decommissioned devices
+// apart from the active tables
+type DeviceArchiveRepository interface {
+	// Archive decommissions the device of the archive in one transaction: its measurements and its
+	// temperature and humidity readings, raw and compacted, move to the archive tables, the device
+	// is removed from the active devices and the archive is stored with the number of rows moved. It fails with ErrDeviceNotFound when the device is
+	// not registered.
+	Archive(ctx context.Context, archive *entities.DeviceArchive) error
+
+	// FindByID returns an archive, failing with ErrDeviceArchiveNotFound when it does not exist
+	FindByID(ctx context.Context, id string) (*entities.DeviceArchive, error)
+
+	// List returns the archives of the device, or of every device when empty, most recently
+	// archived first and up to limit
+	List(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceArchive, error)
+
+	// Measurements returns the measurements kept in the archive tables for an archive, oldest first
+	Measurements(ctx context.Context, archiveID string) ([]*entities.Measurement, error)
+
+	// Offload stores the object storage location of an archive and drops its measurements from the
+	// archive tables, once they were written to object storage. Its readings stay in the database.
+	Offload(ctx context.Context, archive *entities.DeviceArchive) error
+
+	// Restore puts the device of a restored archive back among the active devices in one
+	// transaction. Measurements kept in the database move back to the measurements table; those of
+	// an offloaded archive are given as measurements. Readings always move back from the database. It fails with ErrDeviceNotFound when the
+	// decommissioned device no longer exists.
+	Restore(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement) error
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// DeviceCommandRepository defines the contract for persisting commands sent to devices
+type DeviceCommandRepository interface {
+	// Create persists a new command
+	Create(ctx context.Context, command *entities.DeviceCommand) error
+
+	// FindByID returns a command, failing with ErrDeviceCommandNotFound when there is none
+	FindByID(ctx context.Context, id string) (*entities.DeviceCommand, error)
+
+	// ListByDevice returns up to limit commands of the device, newest first
+	ListByDevice(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceCommand, error)
+
+	// Complete records the outcome of a pending command and reports whether it was still pending,
+	// so an acknowledgement arriving after the command expired does not overwrite it
+	Complete(ctx context.Context, id, status, message string, ackedAt time.Time) (bool, error)
+
+	// RecordAttempt records that a pending command was published again, moving its expiry, and
+	// reports whether it was still pending
+	RecordAttempt(ctx context.Context, id string, attempts int, expiresAt time.Time) (bool, error)
+
+	// ExpirePending flags every pending command whose expiry is before the given time as expired and
+	// returns how many there were
+	ExpirePending(ctx context.Context, now time.Time) (int, error)
+}
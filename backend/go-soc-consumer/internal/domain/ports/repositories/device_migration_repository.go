@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// DeviceMigrationRepository defines the contract for persisting device migrations and the devices
+// taking part in them
+type DeviceMigrationRepository interface {
+	// Create stores a migration with its targets in one transaction
+	Create(ctx context.Context, migration *entities.DeviceMigration, targets []*entities.DeviceMigrationTarget) error
+
+	// FindByID returns a migration, failing with ErrDeviceMigrationNotFound when it does not exist
+	FindByID(ctx context.Context, id string) (*entities.DeviceMigration, error)
+
+	// FindRunning returns the running migration, failing with ErrDeviceMigrationNotFound when none runs
+	FindRunning(ctx context.Context) (*entities.DeviceMigration, error)
+
+	// List returns up to limit migrations, most recently started first
+	List(ctx context.Context, limit int) ([]*entities.DeviceMigration, error)
+
+	// Update stores the status, job and finish time of a migration
+	Update(ctx context.Context, migration *entities.DeviceMigration) error
+
+	// Targets returns the devices of a migration with the given status, or all of them when empty,
+	// ordered by MAC address
+	Targets(ctx context.Context, migrationID, status string) ([]*entities.DeviceMigrationTarget, error)
+
+	// UpdateTarget stores the status, command, error and send time of a target
+	UpdateTarget(ctx context.Context, target *entities.DeviceMigrationTarget) error
+
+	// MarkReconnected records a device that was sent the configuration as reconnected, reporting
+	// false when it was not waiting to reconnect
+	MarkReconnected(ctx context.Context, migrationID, macAddress string, at time.Time) (bool, error)
+}
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// DeviceOnboardingRepository defines the contract for persisting device onboardings
+type DeviceOnboardingRepository interface {
+	// Create persists a new onboarding
+	Create(ctx context.Context, onboarding *entities.DeviceOnboarding) error
+
+	// Update stores the token and the steps of an onboarding, failing with ErrOnboardingNotFound
+	// when it does not exist
+	Update(ctx context.Context, onboarding *entities.DeviceOnboarding) error
+
+	// Delete removes an onboarding, failing with ErrOnboardingNotFound when it does not exist
+	Delete(ctx context.Context, id string) error
+
+	// FindByID returns an onboarding, failing with ErrOnboardingNotFound when it does not exist
+	FindByID(ctx context.Context, id string) (*entities.DeviceOnboarding, error)
+
+	// ListPending returns the onboardings not assigned yet, oldest first
+	ListPending(ctx context.Context) ([]*entities.DeviceOnboarding, error)
+}
@@ -6,7 +6,11 @@ import (
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
 )
 
-// DeviceRepository defines the contract for device persistence operations
+// DeviceRepository defines the contract for device persistence operations.
+//
+// Devices passed to Create and Update are neither modified nor retained, and the devices
+// returned by the find and list methods are new instances owned by the caller. Callers that
+// share a returned device must update it through Device.WithChanges rather than in place.
 type DeviceRepository interface {
 	// Create persists a new device
 	Create(ctx context.Context, device *entities.Device) error
@@ -17,12 +21,26 @@ type DeviceRepository interface {
 	// FindByMACAddress retrieves a device by its MAC address
 	FindByMACAddress(ctx context.Context, macAddress string) (*entities.Device, error)
 
+	// FindByMACAddresses retrieves the devices with the given MAC addresses in a single query;
+	// addresses without a device are left out of the result
+	FindByMACAddresses(ctx context.Context, macAddresses []string) ([]*entities.Device, error)
+
 	// Exists checks if a device with the given MAC address exists
 	Exists(ctx context.Context, macAddress string) (bool, error)
 
-	// List retrieves all devices with optional pagination
+	// List retrieves a page of devices. A limit of 0 selects the default page size and
+	// pagination.Unlimited reads every device; limits above the maximum page size are rejected.
 	List(ctx context.Context, offset, limit int) ([]*entities.Device, error)
 
+	// ListByStatus retrieves a page of the devices with the given status, with the limits of List
+	ListByStatus(ctx context.Context, status string, offset, limit int) ([]*entities.Device, error)
+
+	// CountByVersion counts the devices by farm, firmware version and hardware version
+	CountByVersion(ctx context.Context) ([]*entities.FleetVersionCount, error)
+
+	// CountByFarm counts the devices registered under the farm
+	CountByFarm(ctx context.Context, farmID string) (int, error)
+
 	// Delete removes a device by MAC address
 	Delete(ctx context.Context, macAddress string) error
-}
\ No newline at end of file
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// DeviceShadowRepository defines the contract for persisting the configuration declared for devices
+type DeviceShadowRepository interface {
+	// Upsert stores the shadow, replacing the one declared before for the device
+	Upsert(ctx context.Context, shadow *entities.DeviceShadow) error
+
+	// Delete removes the shadow of the device, if any
+	Delete(ctx context.Context, macAddress string) error
+
+	// List returns every shadow, ordered by MAC address
+	List(ctx context.Context) ([]*entities.DeviceShadow, error)
+}
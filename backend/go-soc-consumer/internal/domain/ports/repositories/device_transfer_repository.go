@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// DeviceTransferRepository defines the contract for persisting device transfers with their audit trail
+type DeviceTransferRepository interface {
+	// Create persists a new transfer with the audit entry initiating it, failing with
+	// ErrDeviceTransferInProgress when the device already has an open transfer
+	Create(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error
+
+	// Update stores the status of a transfer with the audit entry changing it, failing with
+	// ErrDeviceTransferNotFound when it does not exist
+	Update(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error
+
+	// FindByID returns a transfer, failing with ErrDeviceTransferNotFound when it does not exist
+	FindByID(ctx context.Context, id string) (*entities.DeviceTransfer, error)
+
+	// List returns the transfers of the device, or of every device when empty, with the status, or
+	// any status when empty, most recently initiated first and up to limit
+	List(ctx context.Context, macAddress, status string, limit int) ([]*entities.DeviceTransfer, error)
+
+	// AuditEntries returns the audit trail of a transfer, oldest first
+	AuditEntries(ctx context.Context, transferID string) ([]entities.DeviceTransferAuditEntry, error)
+
+	// Complete archives the history of the device taken before the given time when the policy of the
+	// transfer asks for it, stores the farm of the device and the completed transfer with its audit
+	// entry in one transaction, and returns the entry. It fails with ErrDeviceNotFound,
+	// ErrDeviceTransferNotFound or ErrDeviceTransferStatusChange unless the transfer is accepted.
+	Complete(ctx context.Context, transfer *entities.DeviceTransfer, device *entities.Device, actor string, at time.Time) (entities.DeviceTransferAuditEntry, error)
+}
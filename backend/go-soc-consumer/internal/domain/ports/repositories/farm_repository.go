@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// FarmRepository defines the contract for persisting farms
+type FarmRepository interface {
+	// Create persists a new farm, failing with ErrFarmAlreadyExists when its ID is taken
+	Create(ctx context.Context, farm *entities.Farm) error
+
+	// Update stores the name of a farm, failing with ErrFarmNotFound when it does not exist
+	Update(ctx context.Context, farm *entities.Farm) error
+
+	// Delete removes a farm, failing with ErrFarmNotFound when it does not exist
+	Delete(ctx context.Context, id string) error
+
+	// FindByID returns a farm, failing with ErrFarmNotFound when it does not exist
+	FindByID(ctx context.Context, id string) (*entities.Farm, error)
+
+	// List returns every farm sorted by name
+	List(ctx context.Context) ([]*entities.Farm, error)
+}
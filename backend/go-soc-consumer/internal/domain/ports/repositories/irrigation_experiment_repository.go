@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// IrrigationExperimentRepository defines the contract for persisting irrigation experiments
+type IrrigationExperimentRepository interface {
+	// Create persists a new experiment
+	Create(ctx context.Context, experiment *entities.IrrigationExperiment) error
+
+	// Update stores the end of an experiment, failing with ErrIrrigationExperimentNotFound when it
+	// does not exist
+	Update(ctx context.Context, experiment *entities.IrrigationExperiment) error
+
+	// FindByID returns an experiment, failing with ErrIrrigationExperimentNotFound when it does not
+	// exist
+	FindByID(ctx context.Context, id string) (*entities.IrrigationExperiment, error)
+
+	// List returns every experiment, most recently started first
+	List(ctx context.Context) ([]*entities.IrrigationExperiment, error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// IrrigationOverrideRepository defines the contract for persisting the manual overrides of scheduled irrigation
+type IrrigationOverrideRepository interface {
+	// Upsert persists the override of a device, replacing the one it had
+	Upsert(ctx context.Context, override *entities.IrrigationOverride) error
+
+	// Delete removes the override of a device, failing with ErrIrrigationOverrideNotFound when it has none
+	Delete(ctx context.Context, macAddress string) error
+
+	// List returns every override, expired ones included, by MAC address
+	List(ctx context.Context) ([]*entities.IrrigationOverride, error)
+}
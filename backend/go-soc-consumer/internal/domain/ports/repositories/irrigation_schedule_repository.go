@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// IrrigationScheduleRepository defines the contract for persisting irrigation schedules
+type IrrigationScheduleRepository interface {
+	// Create persists a new schedule
+	Create(ctx context.Context, schedule *entities.IrrigationSchedule) error
+
+	// Update stores the fields of a schedule set by its request, leaving its run state alone.
+	// It fails with ErrIrrigationScheduleNotFound when the schedule does not exist.
+	Update(ctx context.Context, schedule *entities.IrrigationSchedule) error
+
+	// RecordRun stores the run state of a schedule: the start of its last run and the end of the
+	// running run, nil when it is not running
+	RecordRun(ctx context.Context, id string, lastRunAt, runEndsAt *time.Time) error
+
+	// Delete removes a schedule, failing with ErrIrrigationScheduleNotFound when it does not exist
+	Delete(ctx context.Context, id string) error
+
+	// FindByID returns a schedule, failing with ErrIrrigationScheduleNotFound when it does not exist
+	FindByID(ctx context.Context, id string) (*entities.IrrigationSchedule, error)
+
+	// List returns the schedules of a device, or of every device when macAddress is empty, oldest first
+	List(ctx context.Context, macAddress string) ([]*entities.IrrigationSchedule, error)
+}
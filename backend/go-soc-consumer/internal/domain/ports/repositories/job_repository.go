@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// JobRepository defines the contract for the persistent job queue
+type JobRepository interface {
+	// Create persists a new queued job
+	Create(ctx context.Context, job *entities.Job) error
+
+	// FindByID retrieves a job by its ID
+	FindByID(ctx context.Context, id string) (*entities.Job, error)
+
+	// ClaimNext atomically marks the next runnable job of the given types as running and leases it to the caller.
+	// Running jobs whose lease expired are claimable again. Returns nil when no job is runnable.
+	ClaimNext(ctx context.Context, types []string, leaseUntil time.Time) (*entities.Job, error)
+
+	// Heartbeat extends the lease of a running job and reports whether cancellation was requested
+	Heartbeat(ctx context.Context, id string, leaseUntil time.Time) (bool, error)
+
+	// UpdateProgress records the progress of a running job
+	UpdateProgress(ctx context.Context, id string, progress int, message string) error
+
+	// Finish stores the outcome of an attempt: status, result, error, attempts and next run time
+	Finish(ctx context.Context, job *entities.Job) error
+
+	// ListUnfinished returns up to limit queued and running jobs, earliest run time first
+	ListUnfinished(ctx context.Context, limit int) ([]*entities.Job, error)
+
+	// RequestCancel flags a job for cancellation; queued jobs are cancelled immediately
+	RequestCancel(ctx context.Context, id string) (*entities.Job, error)
+}
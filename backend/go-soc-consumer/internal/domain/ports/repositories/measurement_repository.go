@@ -0,0 +1,39 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// MeasurementRepository defines the contract for persisting measurements of every sensor type in one table
+type MeasurementRepository interface {
+	// Create stores the measurements in a single transaction
+	Create(ctx context.Context, measurements []*entities.Measurement) error
+
+	// Upsert stores each measurement in place of the device's measurements of the same type and channel stamped
+	// within window of its timestamp, so storing the same measurements again leaves a single row each
+	Upsert(ctx context.Context, measurements []*entities.Measurement, window time.Duration) error
+
+	// Latest returns the latest measurement of every device, channel and given sensor type stamped at
+	// or after since
+	Latest(ctx context.Context, sensorTypes []string, since time.Time) ([]*entities.Measurement, error)
+
+	// LatestByDevice returns the latest measurement of every sensor type and channel of a device
+	// stamped at or after since
+	LatestByDevice(ctx context.Context, macAddress string, since time.Time) ([]*entities.Measurement, error)
+
+	// History returns the measurements of the given sensor type of a device, of every channel, stamped
+	// in [from, to), oldest first
+	History(ctx context.Context, macAddress, sensorType string, from, to time.Time) ([]*entities.Measurement, error)
+
+	// Averages returns the hourly or daily averages of the given sensor type of a device, of every
+	// channel, for the hours or days starting in [from, to), oldest first. Empty hours and days are
+	// left out.
+	Averages(ctx context.Context, macAddress, sensorType, resolution string, from, to time.Time) ([]*entities.MeasurementAverage, error)
+
+	// EnsureTypeViews creates or replaces the view of every sensor type, exposing its measurements
+	// with the value in a column named after the type
+	EnsureTypeViews(ctx context.Context, sensorTypes []*entities.SensorType) error
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// NotificationInboxRepository defines the contract for persisting the in-app inbox of each user
+type NotificationInboxRepository interface {
+	// Create persists a new inbox notification
+	Create(ctx context.Context, notification *entities.InboxNotification) error
+
+	// List returns up to limit notifications of the user created before the given time, or any time
+	// when it is zero, newest first and only the unread ones when unreadOnly is set
+	List(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int) ([]*entities.InboxNotification, error)
+
+	// CountUnread returns the number of unread notifications of the user by severity
+	CountUnread(ctx context.Context, user string) (map[string]int, error)
+
+	// MarkRead flags a notification of the user as read, failing with ErrInboxNotificationNotFound
+	// when the user has no such notification. Marking a read notification again keeps its read time.
+	MarkRead(ctx context.Context, user, id string, readAt time.Time) error
+
+	// MarkAllRead flags every unread notification of the user as read and returns how many there were
+	MarkAllRead(ctx context.Context, user string, readAt time.Time) (int, error)
+
+	// DeleteCreatedBefore removes the notifications of every user created before the given time and
+	// returns how many were removed
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// ResearchTokenRepository defines the contract for persisting research tokens and their usage
+type ResearchTokenRepository interface {
+	// Create persists a new research token
+	Create(ctx context.Context, token *entities.ResearchToken) error
+
+	// Update stores the revocation of a token, failing with ErrResearchTokenNotFound when it does not exist
+	Update(ctx context.Context, token *entities.ResearchToken) error
+
+	// FindByID returns a token, failing with ErrResearchTokenNotFound when it does not exist
+	FindByID(ctx context.Context, id string) (*entities.ResearchToken, error)
+
+	// FindByHash returns the token with the given hash, failing with ErrResearchTokenNotFound
+	FindByHash(ctx context.Context, tokenHash string) (*entities.ResearchToken, error)
+
+	// List returns every token, most recently created first
+	List(ctx context.Context) ([]*entities.ResearchToken, error)
+
+	// RecordUsage adds a request serving the given readings to the usage of the token on the day
+	RecordUsage(ctx context.Context, tokenID string, day time.Time, readings int) error
+
+	// Usage returns the daily usage of a token on the days in [from, to), oldest first
+	Usage(ctx context.Context, tokenID string, from, to time.Time) ([]*entities.ResearchTokenUsage, error)
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// RetentionRepository defines the contract for removing data that outlived its retention
+type RetentionRepository interface {
+	// Prune removes up to limit rows of the dataset older than the given time and returns the number
+	// of rows removed. Datasets stored in TimescaleDB hypertables drop their chunks that ended before
+	// the given time instead, regardless of the limit.
+	Prune(ctx context.Context, dataset string, before time.Time, limit int) (int, error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// SensorChannelRepository defines the contract for persisting the probes of multi-sensor devices
+type SensorChannelRepository interface {
+	// FindByDevice returns the channels of a device ordered by sensor type and channel
+	FindByDevice(ctx context.Context, macAddress string) ([]*entities.SensorChannel, error)
+
+	// Save stores a channel, replacing the name and calibration of an existing channel
+	Save(ctx context.Context, channel *entities.SensorChannel) error
+
+	// CreateMissing stores the channels not stored yet, leaving existing ones untouched
+	CreateMissing(ctx context.Context, channels []*entities.SensorChannel) error
+}
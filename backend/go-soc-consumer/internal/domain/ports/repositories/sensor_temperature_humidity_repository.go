@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
 )
@@ -10,4 +11,21 @@ import (
 type SensorTemperatureHumidityRepository interface {
 	// Create creates a new sensor temperature humidity reading record
 	Create(ctx context.Context, sensorData *entities.SensorTemperatureHumidity) error
+
+	// Upsert stores the reading in place of the device's readings stamped within window of its
+	// timestamp, so storing the same reading again leaves a single row. Readings of a day that was
+	// already compacted are rejected with ErrSensorTemperatureHumidityCompacted.
+	Upsert(ctx context.Context, sensorData *entities.SensorTemperatureHumidity, window time.Duration) error
+
+	// FindByMACAddress retrieves the readings of a device in [from, to) ordered by time,
+	// including readings that were moved to compressed storage
+	FindByMACAddress(ctx context.Context, macAddress string, from, to time.Time) ([]*entities.SensorTemperatureHumidity, error)
+
+	// ListByMACAddress retrieves a page of the readings of a device in [from, to), newest first,
+	// with limit as resolved by the pagination policy. Compressed readings follow the raw ones.
+	ListByMACAddress(ctx context.Context, macAddress string, from, to time.Time, offset, limit int) ([]*entities.SensorTemperatureHumidity, error)
+
+	// FindLatest retrieves the latest raw reading of each of the given devices, or of every device
+	// when none are given; devices whose readings were all compressed are left out
+	FindLatest(ctx context.Context, macAddresses []string) ([]*entities.SensorTemperatureHumidity, error)
 }
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// SyncOutboxRepository defines the contract for the store-and-forward outbox of an edge instance
+type SyncOutboxRepository interface {
+	// Enqueue stores a change waiting to be replicated
+	Enqueue(ctx context.Context, item *entities.SyncItem) error
+
+	// ListPending retrieves the oldest unsynced items, up to limit as resolved by the pagination policy
+	ListPending(ctx context.Context, limit int) ([]*entities.SyncItem, error)
+
+	// MarkSynced flags the given items as replicated
+	MarkSynced(ctx context.Context, ids []string, syncedAt time.Time) error
+
+	// MarkFailed records a failed replication attempt for the given items
+	MarkFailed(ctx context.Context, ids []string, reason string) error
+
+	// CountPending returns the number of items waiting to be replicated
+	CountPending(ctx context.Context) (int64, error)
+}
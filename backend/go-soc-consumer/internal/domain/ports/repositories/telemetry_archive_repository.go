@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// TelemetryArchiveRepository defines the contract for moving old raw telemetry into compressed storage
+type TelemetryArchiveRepository interface {
+	// ListCompactableDays returns the oldest device-days with raw readings that ended before the given time,
+	// up to limit as resolved by the pagination policy
+	ListCompactableDays(ctx context.Context, before time.Time, limit int) ([]entities.TelemetryDay, error)
+
+	// CompactDay compresses the raw readings of a device-day and removes them, returning the number of rows compacted
+	CompactDay(ctx context.Context, day entities.TelemetryDay) (int, error)
+}
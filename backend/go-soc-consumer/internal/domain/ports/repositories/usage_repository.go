@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// UsageRepository defines the contract for persisting the monthly usage of the farms
+type UsageRepository interface {
+	// AddUsage adds the messages, storage and notifications of the records to the stored totals of
+	// their farm and month; their device-days are ignored
+	AddUsage(ctx context.Context, records []*entities.UsageRecord) error
+
+	// SaveDeviceCounts stores how many devices each farm has on the day, replacing the counts
+	// stored for the day before
+	SaveDeviceCounts(ctx context.Context, day time.Time, devices map[string]int) error
+
+	// ListByMonth returns the usage of every farm in the month sorted by farm ID, with the
+	// device-days summed over the days of the month
+	ListByMonth(ctx context.Context, month time.Time) ([]*entities.UsageRecord, error)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// UserRepository defines the contract for persisting the users of the HTTP API
+type UserRepository interface {
+	// Create persists a new user, failing with ErrUserAlreadyExists when its username is taken
+	Create(ctx context.Context, user *entities.User) error
+
+	// Delete removes a user, failing with ErrUserNotFound when it does not exist
+	Delete(ctx context.Context, username string) error
+
+	// FindByUsername returns a user, failing with ErrUserNotFound when it does not exist
+	FindByUsername(ctx context.Context, username string) (*entities.User, error)
+
+	// List returns every user sorted by username
+	List(ctx context.Context) ([]*entities.User, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// ValveStateRepository defines the contract for persisting the state transitions of irrigation valves
+type ValveStateRepository interface {
+	// Record persists a transition and reports whether it is new, so a transition already recorded
+	// for the same command is not stored twice
+	Record(ctx context.Context, transition *entities.ValveTransition) (bool, error)
+
+	// Latest returns the last transition of the valve of the device, or nil when there is none
+	Latest(ctx context.Context, macAddress string) (*entities.ValveTransition, error)
+
+	// ListByDevice returns up to limit transitions of the valve of the device, newest first
+	ListByDevice(ctx context.Context, macAddress string, limit int) ([]*entities.ValveTransition, error)
+
+	// ListBetween returns the transitions of the valve of the device changed in [from, to), oldest first
+	ListBetween(ctx context.Context, macAddress string, from, to time.Time) ([]*entities.ValveTransition, error)
+}
@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// ZoneRepository defines the contract for persisting the fields and zones of farms and the devices
+// assigned to zones
+type ZoneRepository interface {
+	// Create persists a new field or zone
+	Create(ctx context.Context, zone *entities.Zone) error
+
+	// Update stores the name of a field or zone, failing with ErrZoneNotFound when it does not exist
+	Update(ctx context.Context, zone *entities.Zone) error
+
+	// Delete removes a field or zone, failing with ErrZoneNotFound when it does not exist
+	Delete(ctx context.Context, id string) error
+
+	// FindByID returns a field or zone, failing with ErrZoneNotFound when it does not exist
+	FindByID(ctx context.Context, id string) (*entities.Zone, error)
+
+	// ListByFarm returns the fields and zones of a farm, oldest first
+	ListByFarm(ctx context.Context, farmID string) ([]*entities.Zone, error)
+
+	// AssignDevice assigns a device to a zone, moving it out of the zone it was assigned to
+	AssignDevice(ctx context.Context, zoneID, macAddress string) error
+
+	// UnassignDevice removes a device from a zone, failing with ErrDeviceNotInZone when it is not
+	// assigned to it
+	UnassignDevice(ctx context.Context, zoneID, macAddress string) error
+
+	// DeviceMACAddresses returns the MAC addresses of the devices assigned to a zone, or to the zones
+	// of a field, sorted
+	DeviceMACAddresses(ctx context.Context, zoneID string) ([]string, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// SyncRemote defines the contract for pushing replicated changes to the cloud instance
+type SyncRemote interface {
+	// Push sends a batch of changes and returns how the cloud resolved them
+	Push(ctx context.Context, items []*entities.SyncItem) (*entities.SyncApplyResult, error)
+}
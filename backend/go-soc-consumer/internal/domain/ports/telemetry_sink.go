@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// TelemetrySink defines the contract for mirroring accepted telemetry to an external system
+type TelemetrySink interface {
+	// Name identifies the sink in logs and metrics
+	Name() string
+
+	// Write sends a batch of readings; a failed batch is retried as a whole
+	Write(ctx context.Context, readings []*entities.SensorTemperatureHumidity) error
+}
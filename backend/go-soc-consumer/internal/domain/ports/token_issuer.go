@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// TokenIssuer defines the contract for issuing and checking the access tokens of API users
+type TokenIssuer interface {
+	// Issue creates an access token carrying the claims of the user
+	Issue(user *entities.User) (*entities.AuthToken, error)
+
+	// Verify checks the token and returns its claims, failing with ErrInvalidToken or ErrTokenExpired
+	Verify(token string) (*entities.AuthClaims, error)
+}
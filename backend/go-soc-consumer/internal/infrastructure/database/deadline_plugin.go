@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/deadline"
+)
+
+const deadlineSettingKey = "deadline:bound_context"
+
+// boundContext remembers the statement context replaced by the plugin
+type boundContext struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+// DeadlinePlugin bounds every statement with the database deadline of the policy, so repositories
+// get a deadline even when called with a background context.
+type DeadlinePlugin struct {
+	policy *deadline.Policy
+}
+
+// NewDeadlinePlugin creates the plugin; register it with (*gorm.DB).Use
+func NewDeadlinePlugin(policy *deadline.Policy) *DeadlinePlugin {
+	return &DeadlinePlugin{policy: policy}
+}
+
+// Name implements gorm.Plugin
+func (p *DeadlinePlugin) Name() string {
+	return "deadline"
+}
+
+// Initialize implements gorm.Plugin
+func (p *DeadlinePlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("*").Register("deadline:before_create", p.bound),
+		callbacks.Create().After("*").Register("deadline:after_create", p.release),
+		callbacks.Query().Before("*").Register("deadline:before_query", p.bound),
+		callbacks.Query().After("*").Register("deadline:after_query", p.release),
+		callbacks.Update().Before("*").Register("deadline:before_update", p.bound),
+		callbacks.Update().After("*").Register("deadline:after_update", p.release),
+		callbacks.Delete().Before("*").Register("deadline:before_delete", p.bound),
+		callbacks.Delete().After("*").Register("deadline:after_delete", p.release),
+		callbacks.Raw().Before("*").Register("deadline:before_raw", p.bound),
+		callbacks.Raw().After("*").Register("deadline:after_raw", p.release),
+		callbacks.Row().Before("*").Register("deadline:before_row", p.bound),
+		callbacks.Row().After("*").Register("deadline:after_row", p.releaseRow),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bound replaces the statement context with one bounded by the database deadline
+func (p *DeadlinePlugin) bound(db *gorm.DB) {
+	parent := db.Statement.Context
+	ctx, cancel := p.policy.WithDeadline(parent, deadline.ClassDatabase)
+	db.Statement.Context = ctx
+	db.InstanceSet(deadlineSettingKey, &boundContext{parent: parent, cancel: cancel})
+}
+
+// release records an expired deadline and restores the statement context, which chained
+// statements sharing the same *gorm.DB would otherwise inherit already cancelled
+func (p *DeadlinePlugin) release(db *gorm.DB) {
+	value, ok := db.InstanceGet(deadlineSettingKey)
+	if !ok {
+		return
+	}
+	bound := value.(*boundContext)
+	p.policy.Observe(db.Statement.Context, deadline.ClassDatabase, db.Error)
+	db.Statement.Context = bound.parent
+	bound.cancel()
+}
+
+// releaseRow restores the statement context of a row statement without cancelling the bounded one:
+// the caller reads the rows after the callbacks return, so it is released when the deadline expires
+func (p *DeadlinePlugin) releaseRow(db *gorm.DB) {
+	value, ok := db.InstanceGet(deadlineSettingKey)
+	if !ok {
+		return
+	}
+	p.policy.Observe(db.Statement.Context, deadline.ClassDatabase, db.Error)
+	db.Statement.Context = value.(*boundContext).parent
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/deadline"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func TestDeadlinePlugin(t *testing.T) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	t.Run("should cancel statements exceeding the database deadline", func(t *testing.T) {
+		db, mock := stubs.GetTestDB(t)
+		policy := deadline.NewPolicy(map[deadline.Class]time.Duration{deadline.ClassDatabase: 20 * time.Millisecond}, loggerFactory)
+		require.NoError(t, db.Use(NewDeadlinePlugin(policy)))
+		mock.ExpectExec("UPDATE devices").WillDelayFor(time.Second).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := db.WithContext(context.Background()).Exec("UPDATE devices SET status = ?", "offline").Error
+
+		assert.Error(t, err)
+		families := policy.Collect()
+		require.Len(t, families, 1)
+		require.Len(t, families[0].Samples, 1)
+		assert.Equal(t, map[string]string{"class": "database"}, families[0].Samples[0].Labels)
+		assert.Equal(t, 1.0, families[0].Samples[0].Value)
+	})
+
+	t.Run("should cancel row statements exceeding the database deadline", func(t *testing.T) {
+		db, mock := stubs.GetTestDB(t)
+		policy := deadline.NewPolicy(map[deadline.Class]time.Duration{deadline.ClassDatabase: 20 * time.Millisecond}, loggerFactory)
+		require.NoError(t, db.Use(NewDeadlinePlugin(policy)))
+		mock.ExpectQuery("SELECT 1").WillDelayFor(time.Second).WillReturnRows(sqlmock.NewRows([]string{"result"}).AddRow(1))
+
+		var result int
+		err := db.WithContext(context.Background()).Raw("SELECT 1").Scan(&result).Error
+
+		assert.Error(t, err)
+		assert.Equal(t, 1.0, policy.Collect()[0].Samples[0].Value)
+	})
+
+	t.Run("should restore the caller context after the statement", func(t *testing.T) {
+		db, mock := stubs.GetTestDB(t)
+		policy := deadline.NewPolicy(map[deadline.Class]time.Duration{deadline.ClassDatabase: time.Second}, loggerFactory)
+		require.NoError(t, db.Use(NewDeadlinePlugin(policy)))
+		mock.ExpectQuery("SELECT 1").WillReturnRows(sqlmock.NewRows([]string{"result"}).AddRow(1))
+
+		ctx := context.WithValue(context.Background(), struct{}{}, "caller")
+		var result int
+		tx := db.WithContext(ctx).Raw("SELECT 1").Scan(&result)
+
+		require.NoError(t, tx.Error)
+		assert.Equal(t, 1, result)
+		assert.Equal(t, ctx, tx.Statement.Context)
+		assert.Empty(t, policy.Collect()[0].Samples)
+	})
+}
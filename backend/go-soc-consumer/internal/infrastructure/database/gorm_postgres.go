@@ -6,6 +6,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -14,13 +16,15 @@ import (
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/config"
 	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
 )
 
 // GormPostgresDB wraps the GORM database connection and provides additional functionality
 type GormPostgresDB struct {
-	db     *gorm.DB
-	config *config.DatabaseConfig
-	logger pkglogger.InfrastructureLogger
+	db         *gorm.DB
+	config     *config.DatabaseConfig
+	logger     pkglogger.InfrastructureLogger
+	statements *StatementCacheTracer
 }
 
 var (
@@ -54,9 +58,16 @@ func initDatabase(cfg *config.DatabaseConfig, infraLogger pkglogger.Infrastructu
 		},
 	}
 
+	pgxConfig, err := pgx.ParseConfig(cfg.GetDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database connection string: %w", err)
+	}
+	statements := NewStatementCacheTracer(cfg.StatementCacheMode)
+	configureStatementCache(pgxConfig, cfg.StatementCacheMode, cfg.StatementCacheCapacity, statements)
+
 	// Open GORM connection
 	start := time.Now()
-	db, err := gorm.Open(postgres.Open(cfg.GetDSN()), gormConfig)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: stdlib.OpenDB(*pgxConfig)}), gormConfig)
 	connectionDuration := time.Since(start)
 
 	if err != nil {
@@ -79,9 +90,10 @@ func initDatabase(cfg *config.DatabaseConfig, infraLogger pkglogger.Infrastructu
 	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	gormDB := &GormPostgresDB{
-		db:     db,
-		config: cfg,
-		logger: infraLogger,
+		db:         db,
+		config:     cfg,
+		logger:     infraLogger,
+		statements: statements,
 	}
 
 	// Test the connection
@@ -183,6 +195,41 @@ func (g *GormPostgresDB) AutoMigrate() error {
 	err := g.db.AutoMigrate(
 		&models.DeviceModel{},
 		&models.SensorTemperatureHumidityModel{},
+		&models.SyncOutboxModel{},
+		&models.SensorTemperatureHumidityArchiveModel{},
+		&models.JobModel{},
+		&models.BlacklistEntryModel{},
+		&models.MeasurementModel{},
+		&models.SensorChannelModel{},
+		&models.InboxNotificationModel{},
+		&models.DeviceCommandModel{},
+		&models.CrashSignatureModel{},
+		&models.CrashReportModel{},
+		&models.UsageRecordModel{},
+		&models.UsageDeviceDayModel{},
+		&models.ValveStateModel{},
+		&models.DeviceShadowModel{},
+		&models.IrrigationScheduleModel{},
+		&models.IrrigationOverrideModel{},
+		&models.DeviceOnboardingModel{},
+		&models.FarmModel{},
+		&models.ZoneModel{},
+		&models.ZoneDeviceModel{},
+		&models.IrrigationExperimentModel{},
+		&models.UserModel{},
+		&models.ResearchTokenModel{},
+		&models.ResearchTokenUsageModel{},
+		&models.DeviceTransferModel{},
+		&models.DeviceTransferAuditModel{},
+		&models.TransferArchivedMeasurementModel{},
+		&models.TransferArchivedTemperatureHumidityModel{},
+		&models.TransferArchivedTelemetryDayModel{},
+		&models.DeviceArchiveModel{},
+		&models.ArchivedDeviceMeasurementModel{},
+		&models.ArchivedDeviceTemperatureHumidityModel{},
+		&models.ArchivedDeviceTelemetryDayModel{},
+		&models.DeviceMigrationModel{},
+		&models.DeviceMigrationTargetModel{},
 	)
 	duration := time.Since(start)
 
@@ -243,6 +290,14 @@ func (g *GormPostgresDB) Transaction(ctx context.Context, fn func(tx *gorm.DB) e
 	return g.db.WithContext(ctx).Transaction(fn)
 }
 
+// Collect implements metrics.Collector with the statement cache metrics
+func (g *GormPostgresDB) Collect() []metrics.Family {
+	if g.statements == nil {
+		return nil
+	}
+	return g.statements.Collect()
+}
+
 // GetConfig returns the database configuration
 func (g *GormPostgresDB) GetConfig() *config.DatabaseConfig {
 	return g.config
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// defaultStatementCacheCapacity is the number of statements cached per connection when none is configured
+const defaultStatementCacheCapacity = 512
+
+// configureStatementCache sets how the connections reuse query plans. With prepare, the first run
+// of a statement on a connection prepares it and later runs only bind and execute it, which saves
+// parsing and planning on high-frequency lookups and inserts. With describe only the parameter
+// and result types are cached, so statements still work when a pooler such as PgBouncer in
+// transaction mode moves them between server connections.
+func configureStatementCache(pgxConfig *pgx.ConnConfig, mode string, capacity int, tracer *StatementCacheTracer) {
+	if capacity <= 0 {
+		capacity = defaultStatementCacheCapacity
+	}
+	switch mode {
+	case "describe":
+		pgxConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheDescribe
+		pgxConfig.DescriptionCacheCapacity = capacity
+	case "off":
+		pgxConfig.DefaultQueryExecMode = pgx.QueryExecModeExec
+	default:
+		pgxConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+		pgxConfig.StatementCacheCapacity = capacity
+	}
+	pgxConfig.Tracer = tracer
+}
+
+// StatementCacheTracer counts statements and how many of them had to be prepared, which are the
+// statement cache misses. Statements run on new connections, including those replacing connections
+// closed for their age or idleness, are always misses.
+type StatementCacheTracer struct {
+	mode string
+
+	statements      atomic.Uint64
+	prepared        atomic.Uint64
+	prepareFailures atomic.Uint64
+}
+
+// NewStatementCacheTracer creates a tracer for connections using the statement cache mode
+func NewStatementCacheTracer(mode string) *StatementCacheTracer {
+	if mode == "" {
+		mode = "prepare"
+	}
+	return &StatementCacheTracer{mode: mode}
+}
+
+// TraceQueryStart implements pgx.QueryTracer
+func (t *StatementCacheTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	t.statements.Add(1)
+	return ctx
+}
+
+// TraceQueryEnd implements pgx.QueryTracer
+func (t *StatementCacheTracer) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+// TracePrepareStart implements pgx.PrepareTracer
+func (t *StatementCacheTracer) TracePrepareStart(ctx context.Context, _ *pgx.Conn, _ pgx.TracePrepareStartData) context.Context {
+	return ctx
+}
+
+// TracePrepareEnd implements pgx.PrepareTracer
+func (t *StatementCacheTracer) TracePrepareEnd(_ context.Context, _ *pgx.Conn, data pgx.TracePrepareEndData) {
+	switch {
+	case data.Err != nil:
+		t.prepareFailures.Add(1)
+	case !data.AlreadyPrepared:
+		t.prepared.Add(1)
+	}
+}
+
+// Collect implements metrics.Collector
+func (t *StatementCacheTracer) Collect() []metrics.Family {
+	labels := map[string]string{"mode": t.mode}
+	return []metrics.Family{
+		{Name: "db_statements_total", Help: "Database statements run", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Labels: labels, Value: float64(t.statements.Load())}}},
+		{Name: "db_statement_cache_misses_total", Help: "Database statements prepared because the connection had not cached them", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Labels: labels, Value: float64(t.prepared.Load())}}},
+		{Name: "db_statement_prepare_failures_total", Help: "Database statements that failed to prepare", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Labels: labels, Value: float64(t.prepareFailures.Load())}}},
+	}
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/config"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func TestConfigureStatementCache(t *testing.T) {
+	tests := []struct {
+		mode             string
+		capacity         int
+		wantMode         pgx.QueryExecMode
+		wantStatements   int
+		wantDescriptions int
+	}{
+		{mode: "", wantMode: pgx.QueryExecModeCacheStatement, wantStatements: defaultStatementCacheCapacity},
+		{mode: "prepare", capacity: 64, wantMode: pgx.QueryExecModeCacheStatement, wantStatements: 64},
+		{mode: "describe", capacity: 64, wantMode: pgx.QueryExecModeCacheDescribe, wantDescriptions: 64},
+		{mode: "off", wantMode: pgx.QueryExecModeExec},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			pgxConfig, err := pgx.ParseConfig("host=localhost statement_cache_capacity=0 description_cache_capacity=0")
+			require.NoError(t, err)
+			tracer := NewStatementCacheTracer(tt.mode)
+
+			configureStatementCache(pgxConfig, tt.mode, tt.capacity, tracer)
+
+			assert.Equal(t, tt.wantMode, pgxConfig.DefaultQueryExecMode)
+			assert.Equal(t, tt.wantStatements, pgxConfig.StatementCacheCapacity)
+			assert.Equal(t, tt.wantDescriptions, pgxConfig.DescriptionCacheCapacity)
+			assert.Same(t, tracer, pgxConfig.Tracer)
+		})
+	}
+}
+
+func TestStatementCacheTracer(t *testing.T) {
+	ctx := context.Background()
+	tracer := NewStatementCacheTracer("")
+
+	for range 3 {
+		tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	}
+	tracer.TracePrepareEnd(ctx, nil, pgx.TracePrepareEndData{})
+	tracer.TracePrepareEnd(ctx, nil, pgx.TracePrepareEndData{AlreadyPrepared: true})
+	tracer.TracePrepareEnd(ctx, nil, pgx.TracePrepareEndData{Err: errors.New("syntax error")})
+
+	families := tracer.Collect()
+	require.Len(t, families, 3)
+	assert.Equal(t, "db_statements_total", families[0].Name)
+	assert.Equal(t, float64(3), families[0].Samples[0].Value)
+	assert.Equal(t, map[string]string{"mode": "prepare"}, families[0].Samples[0].Labels)
+	assert.Equal(t, float64(1), families[1].Samples[0].Value, "statements already prepared are hits")
+	assert.Equal(t, float64(1), families[2].Samples[0].Value)
+}
+
+// BenchmarkStatementCache compares the statement cache modes on the device lookup and the reading
+// insert, the most frequent statements. It needs the integration test database.
+func BenchmarkStatementCache(b *testing.B) {
+	loggerFactory, err := logger.NewLoggerFactory(logger.LoggerConfig{Level: "error", Format: "json", Environment: "production"})
+	require.NoError(b, err)
+
+	for _, mode := range config.StatementCacheModes {
+		cfg := &config.DatabaseConfig{
+			Host:               getTestEnv("TEST_DB_HOST", "localhost"),
+			Port:               5432,
+			User:               getTestEnv("TEST_DB_USER", "postgres"),
+			Password:           getTestEnv("TEST_DB_PASSWORD", "password"),
+			Name:               getTestEnv("TEST_DB_NAME", "test_iot_smart_irrigation"),
+			SSLMode:            "disable",
+			MaxOpenConns:       1,
+			MaxIdleConns:       1,
+			ConnMaxLifetime:    time.Hour,
+			ConnMaxIdleTime:    time.Hour,
+			StatementCacheMode: mode,
+		}
+		gormDB, err := initDatabase(cfg, loggerFactory.Infrastructure())
+		if err != nil {
+			b.Skipf("Failed to connect to test database: %v", err)
+		}
+		db := gormDB.GetDB().Session(&gorm.Session{Logger: gormDB.GetDB().Logger.LogMode(gormlogger.Silent)})
+		require.NoError(b, gormDB.AutoMigrate())
+		device := &models.DeviceModel{MACAddress: "02:4C:54:BE:00:01", DeviceName: "bench", IPAddress: "10.0.0.1", Status: "registered", RegisteredAt: time.Now(), LastSeen: time.Now()}
+		require.NoError(b, db.Unscoped().Where("mac_address = ?", device.MACAddress).Delete(&models.DeviceModel{}).Error)
+		require.NoError(b, db.Create(device).Error)
+
+		b.Run(mode+"/find_by_mac", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var found models.DeviceModel
+				if err := db.Where("mac_address = ?", device.MACAddress).First(&found).Error; err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(mode+"/insert_reading", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				reading := &models.SensorTemperatureHumidityModel{MACAddress: device.MACAddress, TemperatureCelsius: 24.5, HumidityPercent: 61.25}
+				if err := db.Create(reading).Error; err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		db.Unscoped().Where("mac_address = ?", device.MACAddress).Delete(&models.SensorTemperatureHumidityModel{})
+		db.Unscoped().Where("mac_address = ?", device.MACAddress).Delete(&models.DeviceModel{})
+		gormDB.Close()
+	}
+}
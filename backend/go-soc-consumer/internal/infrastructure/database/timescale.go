@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Continuous aggregates of the measurements, one row per device, sensor type, channel and bucket with
+// the bucket, average, minimum, maximum and samples columns. Bad quality measurements are left out.
+const (
+	HourlyMeasurementsView = "measurements_hourly"
+	DailyMeasurementsView  = "measurements_daily"
+)
+
+// hypertable is a table of readings partitioned by its created_at column
+type hypertable struct {
+	table      string
+	primaryKey string // columns of the primary key widened with the time column, empty without a primary key
+	segmentBy  string // columns compressed chunks are segmented by, the series readings are queried by
+}
+
+var timescaleHypertables = []hypertable{
+	{table: "measurements", primaryKey: "id, created_at", segmentBy: "mac_address, sensor_type, channel"},
+	{table: "sensor_temperature_humidity", segmentBy: "mac_address"},
+}
+
+// continuousAggregate is a continuous aggregate of the measurements refreshed by a policy
+type continuousAggregate struct {
+	view        string
+	bucket      string
+	startOffset string // refreshed buckets reach this far back, catching late readings of buffering devices
+	endOffset   string // the newest buckets are left to real-time aggregation
+	schedule    string
+}
+
+var measurementAggregates = []continuousAggregate{
+	{view: HourlyMeasurementsView, bucket: "1 hour", startOffset: "2 days", endOffset: "1 hour", schedule: "30 minutes"},
+	{view: DailyMeasurementsView, bucket: "1 day", startOffset: "7 days", endOffset: "1 day", schedule: "1 hour"},
+}
+
+// Timescale reports whether the measurement tables are TimescaleDB hypertables
+func (g *GormPostgresDB) Timescale() bool {
+	return g.config != nil && g.config.Timescale
+}
+
+// EnableTimescale turns the measurement tables into hypertables, compresses their chunks past the
+// configured age and creates the continuous aggregates of the hourly and daily averages. Every step is
+// idempotent, so it runs after the migrations on each start; a changed compression age replaces the
+// compression policies.
+func (g *GormPostgresDB) EnableTimescale(ctx context.Context) error {
+	if !g.Timescale() {
+		return nil
+	}
+	start := time.Now()
+	if err := g.db.WithContext(ctx).Exec("CREATE EXTENSION IF NOT EXISTS timescaledb").Error; err != nil {
+		g.logger.LogDatabaseOperation("enable_timescale", "timescaledb", time.Since(start), 0, err)
+		return fmt.Errorf("failed to create the timescaledb extension: %w", err)
+	}
+
+	for _, h := range timescaleHypertables {
+		if err := g.createHypertable(ctx, h); err != nil {
+			g.logger.LogDatabaseOperation("enable_timescale", h.table, time.Since(start), 0, err)
+			return fmt.Errorf("failed to create hypertable %s: %w", h.table, err)
+		}
+	}
+	for _, aggregate := range measurementAggregates {
+		if err := g.createContinuousAggregate(ctx, aggregate); err != nil {
+			g.logger.LogDatabaseOperation("enable_timescale", aggregate.view, time.Since(start), 0, err)
+			return fmt.Errorf("failed to create continuous aggregate %s: %w", aggregate.view, err)
+		}
+	}
+
+	g.logger.LogDatabaseOperation("enable_timescale", "measurements", time.Since(start), int64(len(timescaleHypertables)), nil)
+	return nil
+}
+
+// createHypertable converts the table, moving its rows into chunks, and sets up its compression
+func (g *GormPostgresDB) createHypertable(ctx context.Context, h hypertable) error {
+	db := g.db.WithContext(ctx)
+
+	var compressed []bool
+	if err := db.Raw("SELECT compression_enabled FROM timescaledb_information.hypertables WHERE hypertable_name = ?", h.table).Scan(&compressed).Error; err != nil {
+		return err
+	}
+	if len(compressed) == 0 {
+		// Unique indexes of a hypertable must include the time column
+		if h.primaryKey != "" {
+			statement := fmt.Sprintf("ALTER TABLE %q DROP CONSTRAINT %q, ADD PRIMARY KEY (%s)", h.table, h.table+"_pkey", h.primaryKey)
+			if err := db.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		if err := db.Exec("SELECT create_hypertable(?::regclass, 'created_at', chunk_time_interval => ?::interval, migrate_data => true)", h.table, interval(g.config.TimescaleChunkInterval)).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(compressed) == 0 || !compressed[0] {
+		statement := fmt.Sprintf("ALTER TABLE %q SET (timescaledb.compress, timescaledb.compress_segmentby = '%s', timescaledb.compress_orderby = 'created_at DESC')", h.table, h.segmentBy)
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	if err := db.Exec("SELECT remove_compression_policy(?::regclass, if_exists => true)", h.table).Error; err != nil {
+		return err
+	}
+	return db.Exec("SELECT add_compression_policy(?::regclass, ?::interval)", h.table, interval(g.config.TimescaleCompressAfter)).Error
+}
+
+// createContinuousAggregate creates the view without data, leaving the backfill to its refresh policy.
+// Real-time aggregation covers the buckets not materialized yet.
+func (g *GormPostgresDB) createContinuousAggregate(ctx context.Context, aggregate continuousAggregate) error {
+	db := g.db.WithContext(ctx)
+	statement := fmt.Sprintf(`CREATE MATERIALIZED VIEW IF NOT EXISTS %q WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS `+
+		`SELECT time_bucket(INTERVAL '%s', created_at) AS bucket, mac_address, sensor_type, channel, `+
+		`avg(value) AS average, min(value) AS minimum, max(value) AS maximum, count(*) AS samples `+
+		`FROM measurements WHERE quality <> 'bad' GROUP BY bucket, mac_address, sensor_type, channel WITH NO DATA`,
+		aggregate.view, aggregate.bucket)
+	if err := db.Exec(statement).Error; err != nil {
+		return err
+	}
+	return db.Exec("SELECT add_continuous_aggregate_policy(?::regclass, start_offset => ?::interval, end_offset => ?::interval, schedule_interval => ?::interval, if_not_exists => true)",
+		aggregate.view, aggregate.startOffset, aggregate.endOffset, aggregate.schedule).Error
+}
+
+// interval formats the duration as a PostgreSQL interval
+func interval(d time.Duration) string {
+	return fmt.Sprintf("%d seconds", int64(d/time.Second))
+}
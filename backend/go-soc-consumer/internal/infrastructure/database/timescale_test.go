@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/config"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func newTimescaleTestDB(t *testing.T, enabled bool) (*GormPostgresDB, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	return &GormPostgresDB{
+		db: gormMockDB,
+		config: &config.DatabaseConfig{
+			Timescale:              enabled,
+			TimescaleChunkInterval: 7 * 24 * time.Hour,
+			TimescaleCompressAfter: 30 * 24 * time.Hour,
+		},
+		logger: loggerFactory.Infrastructure(),
+	}, sqlMock
+}
+
+func TestGormPostgresDB_EnableTimescale(t *testing.T) {
+	t.Run("should convert the tables and create the aggregates", func(t *testing.T) {
+		db, mock := newTimescaleTestDB(t, true)
+		mock.ExpectExec(`CREATE EXTENSION IF NOT EXISTS timescaledb`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// measurements is a plain table yet
+		mock.ExpectQuery(`SELECT compression_enabled FROM timescaledb_information.hypertables WHERE hypertable_name = \$1`).
+			WithArgs("measurements").WillReturnRows(sqlmock.NewRows([]string{"compression_enabled"}))
+		mock.ExpectExec(`ALTER TABLE "measurements" DROP CONSTRAINT "measurements_pkey", ADD PRIMARY KEY \(id, created_at\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`SELECT create_hypertable\(\$1::regclass, 'created_at', chunk_time_interval => \$2::interval, migrate_data => true\)`).
+			WithArgs("measurements", "604800 seconds").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`ALTER TABLE "measurements" SET \(timescaledb.compress, timescaledb.compress_segmentby = 'mac_address, sensor_type, channel'`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`SELECT remove_compression_policy\(\$1::regclass, if_exists => true\)`).WithArgs("measurements").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`SELECT add_compression_policy\(\$1::regclass, \$2::interval\)`).WithArgs("measurements", "2592000 seconds").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// sensor_temperature_humidity was converted and compressed on a previous start
+		mock.ExpectQuery(`SELECT compression_enabled FROM timescaledb_information.hypertables`).
+			WithArgs("sensor_temperature_humidity").WillReturnRows(sqlmock.NewRows([]string{"compression_enabled"}).AddRow(true))
+		mock.ExpectExec(`SELECT remove_compression_policy`).WithArgs("sensor_temperature_humidity").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`SELECT add_compression_policy`).WithArgs("sensor_temperature_humidity", "2592000 seconds").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		for _, aggregate := range []struct{ view, bucket, start, end, schedule string }{
+			{"measurements_hourly", "1 hour", "2 days", "1 hour", "30 minutes"},
+			{"measurements_daily", "1 day", "7 days", "1 day", "1 hour"},
+		} {
+			mock.ExpectExec(`CREATE MATERIALIZED VIEW IF NOT EXISTS "` + aggregate.view + `" WITH \(timescaledb.continuous, timescaledb.materialized_only = false\) AS SELECT time_bucket\(INTERVAL '` + aggregate.bucket + `', created_at\) AS bucket`).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`SELECT add_continuous_aggregate_policy`).
+				WithArgs(aggregate.view, aggregate.start, aggregate.end, aggregate.schedule).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+
+		require.NoError(t, db.EnableTimescale(context.Background()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should do nothing when disabled", func(t *testing.T) {
+		db, mock := newTimescaleTestDB(t, false)
+
+		require.NoError(t, db.EnableTimescale(context.Background()))
+		assert.False(t, db.Timescale())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
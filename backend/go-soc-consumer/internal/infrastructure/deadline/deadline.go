@@ -0,0 +1,75 @@
+package deadline
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// Class groups operations sharing a deadline
+type Class string
+
+// Operation classes
+const (
+	// ClassMessage bounds the handling of one MQTT or NATS message, including the calls it makes
+	ClassMessage Class = "message"
+	// ClassDatabase bounds one database statement
+	ClassDatabase Class = "database"
+	// ClassPublish bounds the publication of one event
+	ClassPublish Class = "publish"
+)
+
+// Policy derives bounded contexts for each operation class so a hung connection cannot pin
+// goroutines forever. A derived context never extends a deadline the caller already set.
+type Policy struct {
+	timeouts   map[Class]time.Duration
+	coreLogger logger.CoreLogger
+	exceeded   *metrics.Vec
+}
+
+// NewPolicy creates a deadline policy; classes without a positive timeout are left unbounded
+func NewPolicy(timeouts map[Class]time.Duration, loggerFactory logger.LoggerFactory) *Policy {
+	return &Policy{
+		timeouts:   timeouts,
+		coreLogger: loggerFactory.Core(),
+		exceeded:   metrics.NewCounterVec("operation_deadline_exceeded_total", "Operations that failed because their deadline expired", "class"),
+	}
+}
+
+// Timeout returns the timeout of the class
+func (p *Policy) Timeout(class Class) time.Duration {
+	return p.timeouts[class]
+}
+
+// WithDeadline returns a context bounded by the timeout of the class
+func (p *Policy) WithDeadline(ctx context.Context, class Class) (context.Context, context.CancelFunc) {
+	timeout := p.timeouts[class]
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Observe counts and logs an operation of the class that failed because its context deadline expired
+func (p *Policy) Observe(ctx context.Context, class Class, err error) {
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+	p.exceeded.Inc(string(class))
+	p.coreLogger.Warn("operation_deadline_exceeded",
+		zap.Error(err),
+		zap.String("class", string(class)),
+		zap.Duration("timeout", p.timeouts[class]),
+		zap.String("component", "deadline_policy"),
+	)
+}
+
+// Collect implements metrics.Collector
+func (p *Policy) Collect() []metrics.Family {
+	return p.exceeded.Collect()
+}
@@ -0,0 +1,93 @@
+package deadline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func newTestPolicy(t *testing.T, timeouts map[Class]time.Duration) *Policy {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return NewPolicy(timeouts, loggerFactory)
+}
+
+func exceeded(policy *Policy, class Class) float64 {
+	return policy.exceeded.Value(string(class))
+}
+
+func TestPolicy_WithDeadline(t *testing.T) {
+	policy := newTestPolicy(t, map[Class]time.Duration{ClassMessage: time.Minute})
+
+	t.Run("should bound the context with the class timeout", func(t *testing.T) {
+		ctx, cancel := policy.WithDeadline(context.Background(), ClassMessage)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+	})
+
+	t.Run("should keep an earlier caller deadline", func(t *testing.T) {
+		parent, parentCancel := context.WithTimeout(context.Background(), time.Second)
+		defer parentCancel()
+		ctx, cancel := policy.WithDeadline(parent, ClassMessage)
+		defer cancel()
+
+		parentDeadline, _ := parent.Deadline()
+		deadline, _ := ctx.Deadline()
+		assert.Equal(t, parentDeadline, deadline)
+	})
+
+	t.Run("should leave classes without timeout unbounded", func(t *testing.T) {
+		ctx, cancel := policy.WithDeadline(context.Background(), ClassPublish)
+		defer cancel()
+
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+	})
+}
+
+func TestPolicy_Handler(t *testing.T) {
+	t.Run("should count messages whose deadline expired", func(t *testing.T) {
+		policy := newTestPolicy(t, map[Class]time.Duration{ClassMessage: 10 * time.Millisecond})
+		handler := policy.Handler(func(ctx context.Context, topic string, payload []byte) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		err := handler(context.Background(), "topic", nil)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1.0, exceeded(policy, ClassMessage))
+	})
+
+	t.Run("should not count other failures", func(t *testing.T) {
+		policy := newTestPolicy(t, map[Class]time.Duration{ClassMessage: time.Minute})
+		handler := policy.Handler(func(ctx context.Context, topic string, payload []byte) error {
+			return errors.New("invalid payload")
+		})
+
+		assert.Error(t, handler(context.Background(), "topic", nil))
+		assert.Equal(t, 0.0, exceeded(policy, ClassMessage))
+	})
+}
+
+func TestBoundedPublisher_Publish(t *testing.T) {
+	policy := newTestPolicy(t, map[Class]time.Duration{ClassPublish: time.Minute})
+	inner := mocks.NewMockEventPublisher(t)
+	inner.EXPECT().Publish(mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	}), "subject", "event").Return(nil).Once()
+
+	assert.NoError(t, NewBoundedPublisher(inner, policy).Publish(context.Background(), "subject", "event"))
+}
@@ -0,0 +1,40 @@
+package deadline
+
+import (
+	"context"
+
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+)
+
+// Handler bounds each message handled by the handler with the message deadline
+func (p *Policy) Handler(handler eventports.MessageHandler) eventports.MessageHandler {
+	return func(ctx context.Context, topic string, payload []byte) error {
+		ctx, cancel := p.WithDeadline(ctx, ClassMessage)
+		defer cancel()
+
+		err := handler(ctx, topic, payload)
+		p.Observe(ctx, ClassMessage, err)
+		return err
+	}
+}
+
+// boundedPublisher bounds each publication with the publish deadline
+type boundedPublisher struct {
+	eventports.EventPublisher
+	policy *Policy
+}
+
+// NewBoundedPublisher wraps an event publisher so a stalled connection cannot block its callers
+func NewBoundedPublisher(inner eventports.EventPublisher, policy *Policy) eventports.EventPublisher {
+	return &boundedPublisher{EventPublisher: inner, policy: policy}
+}
+
+// Publish publishes the event within the publish deadline
+func (p *boundedPublisher) Publish(ctx context.Context, subject string, data interface{}) error {
+	ctx, cancel := p.policy.WithDeadline(ctx, ClassPublish)
+	defer cancel()
+
+	err := p.EventPublisher.Publish(ctx, subject, data)
+	p.policy.Observe(ctx, ClassPublish, err)
+	return err
+}
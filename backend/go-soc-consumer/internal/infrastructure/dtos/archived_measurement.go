@@ -0,0 +1,43 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// ArchivedMeasurement is one line of the object holding the measurements of a decommissioned device
+type ArchivedMeasurement struct {
+	SensorType string    `json:"sensor_type"`
+	Channel    int       `json:"channel,omitempty"`
+	Value      float64   `json:"value"`
+	Unit       string    `json:"unit"`
+	Quality    string    `json:"quality"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// NewArchivedMeasurement creates the object line of a measurement; the MAC address is kept once
+// for the whole object
+func NewArchivedMeasurement(measurement *entities.Measurement) ArchivedMeasurement {
+	return ArchivedMeasurement{
+		SensorType: measurement.SensorType,
+		Channel:    measurement.Channel,
+		Value:      measurement.Value,
+		Unit:       measurement.Unit,
+		Quality:    measurement.Quality,
+		Timestamp:  measurement.Timestamp,
+	}
+}
+
+// ToEntity converts the object line back to a measurement of the device
+func (m ArchivedMeasurement) ToEntity(macAddress string) *entities.Measurement {
+	return &entities.Measurement{
+		MACAddress: macAddress,
+		SensorType: m.SensorType,
+		Channel:    m.Channel,
+		Value:      m.Value,
+		Unit:       m.Unit,
+		Quality:    m.Quality,
+		Timestamp:  m.Timestamp,
+	}
+}
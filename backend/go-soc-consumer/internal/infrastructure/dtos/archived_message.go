@@ -0,0 +1,59 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// ArchivedMessage is one line of a raw message bundle. The payload is base64 encoded so it is
+// archived byte for byte, whether or not it is valid JSON.
+type ArchivedMessage struct {
+	Topic      string                  `json:"topic"`
+	ReceivedAt time.Time               `json:"received_at"`
+	Payload    []byte                  `json:"payload"`
+	Identity   *ArchivedClientIdentity `json:"client_identity,omitempty"`
+}
+
+// ArchivedClientIdentity is the publisher of an archived message, as reported by the broker
+type ArchivedClientIdentity struct {
+	ClientID               string `json:"client_id,omitempty"`
+	Username               string `json:"username,omitempty"`
+	CertificateFingerprint string `json:"certificate_fingerprint,omitempty"`
+	RemoteAddress          string `json:"remote_address,omitempty"`
+}
+
+// NewArchivedMessage creates the bundle line of a raw message
+func NewArchivedMessage(message *entities.RawMessage) ArchivedMessage {
+	archived := ArchivedMessage{
+		Topic:      message.Topic,
+		ReceivedAt: message.ReceivedAt,
+		Payload:    message.Payload,
+	}
+	if identity := message.Identity; identity != nil {
+		archived.Identity = &ArchivedClientIdentity{
+			ClientID:               identity.ClientID,
+			Username:               identity.Username,
+			CertificateFingerprint: identity.CertificateFingerprint,
+			RemoteAddress:          identity.RemoteAddress,
+		}
+	}
+	return archived
+}
+
+// ToEntity converts the bundle line back to a raw message
+func (m ArchivedMessage) ToEntity() (*entities.RawMessage, error) {
+	message, err := entities.NewRawMessage(m.Topic, m.Payload, m.ReceivedAt)
+	if err != nil {
+		return nil, err
+	}
+	if m.Identity != nil {
+		message.Identity = &entities.ClientIdentity{
+			ClientID:               m.Identity.ClientID,
+			Username:               m.Identity.Username,
+			CertificateFingerprint: m.Identity.CertificateFingerprint,
+			RemoteAddress:          m.Identity.RemoteAddress,
+		}
+	}
+	return message, nil
+}
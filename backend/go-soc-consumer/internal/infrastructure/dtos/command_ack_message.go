@@ -0,0 +1,16 @@
+package dtos
+
+// Outcomes reported in a CommandAckMessage
+const (
+	CommandAckStatusOK    = "ok"
+	CommandAckStatusError = "error"
+)
+
+// CommandAckMessage represents the JSON structure a device publishes once it carried out a command
+type CommandAckMessage struct {
+	EventType  string `json:"event_type"`
+	ID         string `json:"id"` // nonce of the command envelope
+	MacAddress string `json:"mac_address"`
+	Status     string `json:"status"`            // ok or error
+	Message    string `json:"message,omitempty"` // reason the command could not be carried out
+}
@@ -0,0 +1,11 @@
+package dtos
+
+// CrashReportMessage represents the JSON structure a device publishes after restarting from a crash
+type CrashReportMessage struct {
+	EventType       string   `json:"event_type"`
+	MacAddress      string   `json:"mac_address"`
+	FirmwareVersion string   `json:"firmware_version"`
+	Reason          string   `json:"reason"`              // reset reason or panic cause, e.g. LoadProhibited
+	Backtrace       []string `json:"backtrace,omitempty"` // program counters of the crashed task, innermost first
+	UptimeSeconds   int64    `json:"uptime_seconds"`      // uptime of the device when it crashed
+}
@@ -6,4 +6,7 @@ type DeviceRegistrationMessage struct {
 	DeviceName          string `json:"device_name"`
 	IPAddress           string `json:"ip_address"`
 	LocationDescription string `json:"location_description"`
+	FirmwareVersion     string `json:"firmware_version,omitempty"`
+	HardwareVersion     string `json:"hardware_version,omitempty"`
+	ProvisioningToken   string `json:"provisioning_token,omitempty"`
 }
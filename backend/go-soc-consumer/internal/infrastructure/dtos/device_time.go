@@ -0,0 +1,16 @@
+package dtos
+
+import (
+	"math"
+	"time"
+)
+
+// DeviceTime converts a device timestamp in unix seconds, possibly fractional, to a time; zero
+// when the device sent no timestamp
+func DeviceTime(unixSeconds float64) time.Time {
+	if unixSeconds <= 0 || math.IsInf(unixSeconds, 0) || math.IsNaN(unixSeconds) {
+		return time.Time{}
+	}
+	seconds, fraction := math.Modf(unixSeconds)
+	return time.Unix(int64(seconds), int64(fraction*float64(time.Second))).UTC()
+}
@@ -0,0 +1,25 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// ForwardedReading is the JSON payload of a reading re-published to an external MQTT broker
+type ForwardedReading struct {
+	MacAddress  string    `json:"mac_address"`
+	Temperature float64   `json:"temperature"`
+	Humidity    float64   `json:"humidity"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// NewForwardedReading creates the payload for an accepted reading
+func NewForwardedReading(reading *entities.SensorTemperatureHumidity) ForwardedReading {
+	return ForwardedReading{
+		MacAddress:  reading.MacAddress(),
+		Temperature: reading.Temperature(),
+		Humidity:    reading.Humidity(),
+		Timestamp:   reading.Timestamp().UTC(),
+	}
+}
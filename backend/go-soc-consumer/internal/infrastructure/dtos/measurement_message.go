@@ -0,0 +1,17 @@
+package dtos
+
+// MeasurementMessage represents the JSON structure for messages carrying values of registered sensor types
+type MeasurementMessage struct {
+	EventType    string             `json:"event_type"`
+	MacAddress   string             `json:"mac_address"`
+	Measurements []MeasurementValue `json:"measurements"`
+	Timestamp    float64            `json:"timestamp,omitempty"` // unix seconds on the device clock, omitted without a synchronized clock
+}
+
+// MeasurementValue is one value of a MeasurementMessage
+type MeasurementValue struct {
+	Type    string  `json:"type"`
+	Channel int     `json:"channel,omitempty"` // probe index on multi-sensor devices, 0 when omitted
+	Value   float64 `json:"value"`
+	Quality string  `json:"quality,omitempty"` // good, suspect or bad; empty means good
+}
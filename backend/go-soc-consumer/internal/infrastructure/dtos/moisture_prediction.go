@@ -0,0 +1,91 @@
+package dtos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// MoisturePredictionRequest represents the JSON body posted to an external moisture model
+type MoisturePredictionRequest struct {
+	Zone         string               `json:"zone"`
+	MACAddresses []string             `json:"mac_addresses"`
+	SensorType   string               `json:"sensor_type"`
+	Threshold    float64              `json:"threshold"`
+	HorizonHours float64              `json:"horizon_hours"`
+	At           time.Time            `json:"at"`
+	Readings     []MoistureReadingDTO `json:"readings"`
+}
+
+// MoistureReadingDTO represents one moisture reading given to the model
+type MoistureReadingDTO struct {
+	MACAddress string    `json:"mac_address"`
+	Channel    int       `json:"channel"`
+	Value      float64   `json:"value"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// MoisturePredictionResponse represents the prediction returned by an external moisture model
+type MoisturePredictionResponse struct {
+	Model               string                     `json:"model"`
+	MACAddress          string                     `json:"mac_address"`
+	Moisture            float64                    `json:"moisture"`
+	DryingRate          float64                    `json:"drying_rate"`
+	HoursUntilThreshold *float64                   `json:"hours_until_threshold"`
+	Forecast            []MoistureForecastPointDTO `json:"forecast"`
+}
+
+// MoistureForecastPointDTO represents one point of the forecast moisture
+type MoistureForecastPointDTO struct {
+	At       time.Time `json:"at"`
+	Moisture float64   `json:"moisture"`
+}
+
+// NewMoisturePredictionRequest builds the model request from a prediction request
+func NewMoisturePredictionRequest(request *entities.MoisturePredictionRequest) *MoisturePredictionRequest {
+	dto := &MoisturePredictionRequest{
+		Zone:         request.Zone,
+		MACAddresses: request.MACAddresses,
+		SensorType:   request.SensorType,
+		Threshold:    request.Threshold,
+		HorizonHours: request.Horizon.Hours(),
+		At:           request.At,
+		Readings:     make([]MoistureReadingDTO, 0, len(request.Readings)),
+	}
+	for _, reading := range request.Readings {
+		dto.Readings = append(dto.Readings, MoistureReadingDTO{
+			MACAddress: reading.MACAddress,
+			Channel:    reading.Channel,
+			Value:      reading.Value,
+			Timestamp:  reading.Timestamp,
+		})
+	}
+	return dto
+}
+
+// ToEntity converts the model response to the prediction of the requested zone, defaulting the
+// model name
+func (r *MoisturePredictionResponse) ToEntity(request *entities.MoisturePredictionRequest, model string) (*entities.MoisturePrediction, error) {
+	if r.HoursUntilThreshold != nil && *r.HoursUntilThreshold < 0 {
+		return nil, fmt.Errorf("hours until threshold cannot be negative, got %v", *r.HoursUntilThreshold)
+	}
+	if r.Model != "" {
+		model = r.Model
+	}
+
+	prediction := &entities.MoisturePrediction{
+		Zone:                request.Zone,
+		Model:               model,
+		MACAddress:          r.MACAddress,
+		PredictedAt:         request.At,
+		Moisture:            r.Moisture,
+		DryingRate:          r.DryingRate,
+		HoursUntilThreshold: r.HoursUntilThreshold,
+		Forecast:            make([]entities.MoistureForecastPoint, 0, len(r.Forecast)),
+	}
+	for _, point := range r.Forecast {
+		prediction.Forecast = append(prediction.Forecast, entities.MoistureForecastPoint{At: point.At, Moisture: point.Moisture})
+	}
+	return prediction, nil
+}
@@ -6,4 +6,5 @@ type SensorDataMessage struct {
 	MacAddress  string  `json:"mac_address"`
 	Temperature float64 `json:"temperature"`
 	Humidity    float64 `json:"humidity"`
-}
\ No newline at end of file
+	Timestamp   float64 `json:"timestamp,omitempty"` // unix seconds on the device clock, omitted without a synchronized clock
+}
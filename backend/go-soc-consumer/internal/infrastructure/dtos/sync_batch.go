@@ -0,0 +1,56 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// SyncBatchRequest represents the JSON body pushed by an edge instance to the cloud
+type SyncBatchRequest struct {
+	InstanceID string        `json:"instance_id"`
+	Items      []SyncItemDTO `json:"items"`
+}
+
+// SyncItemDTO represents a single replicated change
+type SyncItemDTO struct {
+	ID        string          `json:"id"`
+	Kind      string          `json:"kind"`
+	Key       string          `json:"key"`
+	Payload   json.RawMessage `json:"payload"`
+	ChangedAt time.Time       `json:"changed_at"`
+}
+
+// NewSyncBatchRequest builds a batch request from outbox items
+func NewSyncBatchRequest(instanceID string, items []*entities.SyncItem) *SyncBatchRequest {
+	request := &SyncBatchRequest{
+		InstanceID: instanceID,
+		Items:      make([]SyncItemDTO, 0, len(items)),
+	}
+	for _, item := range items {
+		request.Items = append(request.Items, SyncItemDTO{
+			ID:        item.ID,
+			Kind:      item.Kind,
+			Key:       item.Key,
+			Payload:   item.Payload,
+			ChangedAt: item.ChangedAt,
+		})
+	}
+	return request
+}
+
+// ToEntities converts the batch items to domain sync items
+func (r *SyncBatchRequest) ToEntities() []*entities.SyncItem {
+	items := make([]*entities.SyncItem, 0, len(r.Items))
+	for _, dto := range r.Items {
+		items = append(items, &entities.SyncItem{
+			ID:        dto.ID,
+			Kind:      dto.Kind,
+			Key:       dto.Key,
+			Payload:   dto.Payload,
+			ChangedAt: dto.ChangedAt,
+		})
+	}
+	return items
+}
@@ -0,0 +1,11 @@
+package dtos
+
+// WifiDiagnosticsMessage represents the JSON structure a device publishes to report its Wi-Fi link
+type WifiDiagnosticsMessage struct {
+	EventType      string `json:"event_type"`
+	MacAddress     string `json:"mac_address"`
+	SSID           string `json:"ssid"`
+	RSSI           int    `json:"rssi"`              // received signal strength in dBm
+	Channel        int    `json:"channel,omitempty"` // 0 when unknown
+	ReconnectCount int    `json:"reconnect_count"`   // reconnections since the previous report
+}
@@ -0,0 +1,109 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+)
+
+// SMTPSenderConfig holds configuration for sending email through an SMTP server
+type SMTPSenderConfig struct {
+	Host     string
+	Port     int
+	Username string // PLAIN authentication is used when set, which requires TLS
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// smtpSender implements the NotificationSender port for email
+type smtpSender struct {
+	config *SMTPSenderConfig
+}
+
+// NewSMTPSender creates a sender delivering email through an SMTP server, upgrading the connection
+// with STARTTLS when the server offers it
+func NewSMTPSender(config *SMTPSenderConfig) ports.NotificationSender {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &smtpSender{config: config}
+}
+
+// Channel returns the email channel
+func (s *smtpSender) Channel() string {
+	return entities.NotificationChannelEmail
+}
+
+// Send delivers the notification as a plain text email
+func (s *smtpSender) Send(ctx context.Context, notification *entities.Notification) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	address := net.JoinHostPort(s.config.Host, fmt.Sprint(s.config.Port))
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.config.Host}); err != nil {
+			return fmt.Errorf("smtp starttls failed: %w", err)
+		}
+	}
+	if s.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)); err != nil {
+			return fmt.Errorf("smtp authentication failed: %w", err)
+		}
+	}
+	if err := client.Mail(s.config.From); err != nil {
+		return fmt.Errorf("smtp sender rejected: %w", err)
+	}
+	if err := client.Rcpt(notification.Address); err != nil {
+		return fmt.Errorf("smtp recipient rejected: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data failed: %w", err)
+	}
+	if _, err := writer.Write(buildMessage(s.config.From, notification, time.Now())); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("email rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMessage formats a UTF-8 plain text email with CRLF line endings
+func buildMessage(from string, notification *entities.Notification, date time.Time) []byte {
+	var message strings.Builder
+	message.WriteString("From: " + from + "\r\n")
+	message.WriteString("To: " + notification.Address + "\r\n")
+	message.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", notification.Subject) + "\r\n")
+	message.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	message.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(strings.ReplaceAll(notification.Body, "\n", "\r\n"))
+	message.WriteString("\r\n")
+	return []byte(message.String())
+}
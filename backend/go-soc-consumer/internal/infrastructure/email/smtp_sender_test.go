@@ -0,0 +1,30 @@
+package email
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+func TestBuildMessage(t *testing.T) {
+	notification := &entities.Notification{
+		Address: "ana@example.com",
+		Subject: "[critical] la alerta dry_soil está activa",
+		Body:    "line one\nline two",
+	}
+
+	message := buildMessage("alerts@example.com", notification, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, "From: alerts@example.com\r\n"+
+		"To: ana@example.com\r\n"+
+		"Subject: =?utf-8?q?[critical]_la_alerta_dry=5Fsoil_est=C3=A1_activa?=\r\n"+
+		"Date: Sun, 01 Jun 2025 12:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n"+
+		"Content-Transfer-Encoding: 8bit\r\n"+
+		"\r\n"+
+		"line one\r\nline two\r\n", string(message))
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+)
+
+// deviceArchiveStore implements the DeviceArchiveStore port with one gzip compressed JSON lines
+// object per archive in S3, e.g. devices/AA-BB-CC-DD-EE-FF/<archive id>.jsonl.gz
+type deviceArchiveStore struct {
+	client *S3Client
+	prefix string
+}
+
+// NewDeviceArchiveStore creates a device archive store keeping objects under the key prefix
+func NewDeviceArchiveStore(client *S3Client, prefix string) ports.DeviceArchiveStore {
+	return &deviceArchiveStore{
+		client: client,
+		prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+// Store uploads the measurements of the archive as one object
+func (s *deviceArchiveStore) Store(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement) (string, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(writer)
+	for _, measurement := range measurements {
+		if err := encoder.Encode(dtos.NewArchivedMeasurement(measurement)); err != nil {
+			return "", fmt.Errorf("failed to encode archived measurement: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress device archive: %w", err)
+	}
+
+	key := s.prefix + "/" + strings.ReplaceAll(archive.MACAddress, ":", "-") + "/" + archive.ID + ".jsonl.gz"
+	if err := s.client.PutObject(ctx, key, buf.Bytes(), "application/gzip"); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// Load downloads the object of the archive and decodes it line by line
+func (s *deviceArchiveStore) Load(ctx context.Context, archive *entities.DeviceArchive) ([]*entities.Measurement, error) {
+	data, err := s.client.GetObject(ctx, archive.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress device archive %s: %w", archive.ObjectKey, err)
+	}
+	defer reader.Close()
+
+	measurements := make([]*entities.Measurement, 0, archive.Measurements)
+	decoder := json.NewDecoder(bufio.NewReader(reader))
+	for decoder.More() {
+		var line dtos.ArchivedMeasurement
+		if err := decoder.Decode(&line); err != nil {
+			return nil, fmt.Errorf("failed to decode device archive %s: %w", archive.ObjectKey, err)
+		}
+		measurements = append(measurements, line.ToEntity(archive.MACAddress))
+	}
+	return measurements, nil
+}
+
+// Delete removes the object of the archive
+func (s *deviceArchiveStore) Delete(ctx context.Context, archive *entities.DeviceArchive) error {
+	return s.client.DeleteObject(ctx, archive.ObjectKey)
+}
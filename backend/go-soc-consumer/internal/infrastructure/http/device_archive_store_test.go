@@ -0,0 +1,35 @@
+package http
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+func TestDeviceArchiveStore(t *testing.T) {
+	store, client := newFakeS3(t)
+	archiveStore := NewDeviceArchiveStore(client, "/devices/")
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	archive := &entities.DeviceArchive{ID: "archive-1", MACAddress: "AA:BB:CC:DD:EE:FF", Measurements: 2}
+	measurements := []*entities.Measurement{
+		{MACAddress: "AA:BB:CC:DD:EE:FF", SensorType: "soil_moisture", Value: 31.5, Unit: "%", Quality: "good", Timestamp: at},
+		{MACAddress: "AA:BB:CC:DD:EE:FF", SensorType: "soil_moisture", Channel: 1, Value: 28, Unit: "%", Quality: "suspect", Timestamp: at.Add(time.Minute)},
+	}
+
+	key, err := archiveStore.Store(context.Background(), archive, measurements)
+	require.NoError(t, err)
+	assert.Equal(t, "devices/AA-BB-CC-DD-EE-FF/archive-1.jsonl.gz", key)
+
+	archive.Offloaded(key)
+	loaded, err := archiveStore.Load(context.Background(), archive)
+	require.NoError(t, err)
+	assert.Equal(t, measurements, loaded)
+
+	require.NoError(t, archiveStore.Delete(context.Background(), archive))
+	assert.Empty(t, store.objects)
+}
@@ -0,0 +1,316 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/nats/dtos"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// Device status update types pushed to dashboard clients
+const (
+	DeviceStatusRegistered = "registered"
+	DeviceStatusOnline     = "online"
+	DeviceStatusOffline    = "offline"
+)
+
+// DeviceStatusHubConfig holds configuration for the WebSocket hub pushing device status updates
+type DeviceStatusHubConfig struct {
+	MaxClients     int           // connections accepted at once; further upgrades are refused
+	SendBuffer     int           // updates queued per client; clients falling further behind are disconnected
+	WriteTimeout   time.Duration // bound for writing one update or ping to a client
+	PingInterval   time.Duration // clients not answering a ping within twice this interval are disconnected
+	AllowedOrigins []string      // origins of cross-origin dashboards; same-origin clients are always accepted
+}
+
+// DefaultDeviceStatusHubConfig returns default configuration for the device status hub
+func DefaultDeviceStatusHubConfig() *DeviceStatusHubConfig {
+	return &DeviceStatusHubConfig{
+		MaxClients:   100,
+		SendBuffer:   32,
+		WriteTimeout: 10 * time.Second,
+		PingInterval: 30 * time.Second,
+	}
+}
+
+// DeviceStatusUpdate is the message pushed to dashboard clients for every device event
+type DeviceStatusUpdate struct {
+	Type       string     `json:"type"` // registered, online or offline
+	MACAddress string     `json:"mac_address"`
+	FarmID     string     `json:"farm_id,omitempty"`
+	IPAddress  string     `json:"ip_address,omitempty"`
+	LastSeen   *time.Time `json:"last_seen,omitempty"` // offline updates only
+	OccurredAt time.Time  `json:"occurred_at"`
+	EventID    string     `json:"event_id"`
+}
+
+// DeviceStatusHub pushes the device registration, online and offline events received from NATS to
+// the dashboard clients connected over WebSocket. Updates are only delivered while a client is
+// connected; clients reload the device list after connecting.
+type DeviceStatusHub struct {
+	config        *DeviceStatusHubConfig
+	upgrader      websocket.Upgrader
+	loggerFactory logger.LoggerFactory
+
+	mu      sync.Mutex
+	clients map[*hubClient]struct{}
+	closed  bool
+
+	connected *metrics.Vec
+	pushed    *metrics.Vec
+	dropped   *metrics.Vec
+}
+
+// hubClient is one connected dashboard with the updates waiting to be written to it
+type hubClient struct {
+	conn *websocket.Conn
+	send chan []byte
+}
+
+// NewDeviceStatusHub creates a device status hub; a nil config uses DefaultDeviceStatusHubConfig
+func NewDeviceStatusHub(config *DeviceStatusHubConfig, loggerFactory logger.LoggerFactory) *DeviceStatusHub {
+	if config == nil {
+		config = DefaultDeviceStatusHubConfig()
+	}
+
+	if loggerFactory == nil {
+		defaultLoggerFactory, err := logger.NewDefault()
+		if err != nil {
+			panic(fmt.Sprintf("failed to create default logger factory: %v", err))
+		}
+		loggerFactory = defaultLoggerFactory
+	}
+
+	hub := &DeviceStatusHub{
+		config:        config,
+		loggerFactory: loggerFactory,
+		clients:       make(map[*hubClient]struct{}),
+		connected:     metrics.NewGaugeVec("device_status_clients", "Dashboard clients connected to the device status WebSocket"),
+		pushed:        metrics.NewCounterVec("device_status_updates_total", "Device status updates pushed to dashboard clients, by update type", "type"),
+		dropped:       metrics.NewCounterVec("device_status_clients_dropped_total", "Dashboard clients disconnected for falling behind the device status updates"),
+	}
+	hub.upgrader = websocket.Upgrader{
+		HandshakeTimeout: config.WriteTimeout,
+		CheckOrigin:      hub.checkOrigin,
+		// Browsers offer their access token as the subprotocol after "bearer", and fail the
+		// handshake unless one of the offered subprotocols is selected
+		Subprotocols: []string{"bearer"},
+	}
+	return hub
+}
+
+// HandleMessage converts a device event received from NATS into a status update and pushes it to
+// the connected clients. It matches the eventports.MessageHandler signature.
+func (h *DeviceStatusHub) HandleMessage(ctx context.Context, subject string, payload []byte) error {
+	var update *DeviceStatusUpdate
+	switch subject {
+	case events.DeviceDetectedSubject:
+		var event dtos.DeviceDetectedEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("failed to parse device detected event: %w", err)
+		}
+		update = &DeviceStatusUpdate{Type: DeviceStatusRegistered, MACAddress: event.MACAddress, IPAddress: event.IPAddress, OccurredAt: event.DetectedAt, EventID: event.EventID}
+	case events.DeviceOnlineSubject:
+		var event dtos.DeviceOnlineEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("failed to parse device online event: %w", err)
+		}
+		update = &DeviceStatusUpdate{Type: DeviceStatusOnline, MACAddress: event.MACAddress, FarmID: event.FarmID, IPAddress: event.IPAddress, OccurredAt: event.DetectedAt, EventID: event.EventID}
+	case events.DeviceOfflineSubject:
+		var event dtos.DeviceOfflineEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("failed to parse device offline event: %w", err)
+		}
+		lastSeen := event.LastSeen
+		update = &DeviceStatusUpdate{Type: DeviceStatusOffline, MACAddress: event.MACAddress, FarmID: event.FarmID, LastSeen: &lastSeen, OccurredAt: event.DetectedAt, EventID: event.EventID}
+	default:
+		return fmt.Errorf("unknown NATS subject: %s", subject)
+	}
+
+	if update.MACAddress == "" {
+		return fmt.Errorf("%s event without a mac address", update.Type)
+	}
+	return h.Broadcast(update)
+}
+
+// Broadcast queues the update for every connected client. Clients whose queue is full are
+// disconnected rather than slowing down the others.
+func (h *DeviceStatusHub) Broadcast(update *DeviceStatusUpdate) error {
+	message, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal device status update: %w", err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for client := range h.clients {
+		select {
+		case client.send <- message:
+		default:
+			h.removeLocked(client)
+			h.dropped.Inc()
+			h.loggerFactory.Core().Warn("device_status_client_dropped",
+				zap.String("remote_address", client.conn.RemoteAddr().String()),
+				zap.String("component", "device_status_hub"),
+			)
+		}
+	}
+	h.pushed.Inc(update.Type)
+	return nil
+}
+
+// ServeHTTP upgrades the request to a WebSocket and streams device status updates until the client
+// disconnects or the hub is closed
+func (h *DeviceStatusHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	full := h.closed || len(h.clients) >= h.config.MaxClients
+	h.mu.Unlock()
+	if full {
+		http.Error(w, "too many device status clients", http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied with the error
+		return
+	}
+
+	client := &hubClient{conn: conn, send: make(chan []byte, h.config.SendBuffer)}
+	h.mu.Lock()
+	if h.closed || len(h.clients) >= h.config.MaxClients {
+		h.mu.Unlock()
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too many clients"), time.Now().Add(h.config.WriteTimeout))
+		_ = conn.Close()
+		return
+	}
+	h.clients[client] = struct{}{}
+	h.connected.Set(float64(len(h.clients)))
+	h.mu.Unlock()
+
+	h.loggerFactory.Core().Debug("device_status_client_connected",
+		zap.String("remote_address", conn.RemoteAddr().String()),
+		zap.String("component", "device_status_hub"),
+	)
+
+	go h.writeLoop(client)
+	h.readLoop(client)
+}
+
+// readLoop discards client messages, keeping the read deadline moving with the pongs, and
+// unregisters the client once the connection fails
+func (h *DeviceStatusHub) readLoop(client *hubClient) {
+	defer h.remove(client)
+
+	client.conn.SetReadLimit(512)
+	pongWait := 2 * h.config.PingInterval
+	_ = client.conn.SetReadDeadline(time.Now().Add(pongWait))
+	client.conn.SetPongHandler(func(string) error {
+		return client.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		if _, _, err := client.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// writeLoop writes the queued updates and the keepalive pings; it owns the connection's writes
+func (h *DeviceStatusHub) writeLoop(client *hubClient) {
+	ticker := time.NewTicker(h.config.PingInterval)
+	defer func() {
+		ticker.Stop()
+		_ = client.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-client.send:
+			_ = client.conn.SetWriteDeadline(time.Now().Add(h.config.WriteTimeout))
+			if !ok {
+				_ = client.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+				return
+			}
+			if err := client.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = client.conn.SetWriteDeadline(time.Now().Add(h.config.WriteTimeout))
+			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// remove unregisters the client, closing its queue so that its write loop closes the connection
+func (h *DeviceStatusHub) remove(client *hubClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(client)
+}
+
+func (h *DeviceStatusHub) removeLocked(client *hubClient) {
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	delete(h.clients, client)
+	close(client.send)
+	h.connected.Set(float64(len(h.clients)))
+}
+
+// Clients returns how many dashboard clients are connected
+func (h *DeviceStatusHub) Clients() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+// Close disconnects every client and refuses new ones. The HTTP server does not track upgraded
+// connections, so they are closed here when the server shuts down.
+func (h *DeviceStatusHub) Close(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for client := range h.clients {
+		h.removeLocked(client)
+	}
+	return nil
+}
+
+// checkOrigin accepts requests without an origin, same-origin requests and the allowed origins
+func (h *DeviceStatusHub) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	if err == nil && strings.EqualFold(parsed.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range h.config.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSpace(allowed), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Collect implements metrics.Collector
+func (h *DeviceStatusHub) Collect() []metrics.Family {
+	families := h.connected.Collect()
+	families = append(families, h.pushed.Collect()...)
+	return append(families, h.dropped.Collect()...)
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/middleware"
+)
+
+// dialHub connects a dashboard client to the hub served by the test server
+func dialHub(t *testing.T, server *httptest.Server, header http.Header) (*websocket.Conn, *http.Response, error) {
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, response, err := websocket.DefaultDialer.Dial(url, header)
+	if conn != nil {
+		t.Cleanup(func() { _ = conn.Close() })
+	}
+	return conn, response, err
+}
+
+// waitForClients waits until the hub registered the expected number of clients
+func waitForClients(t *testing.T, hub *DeviceStatusHub, expected int) {
+	require.Eventually(t, func() bool { return hub.Clients() == expected }, time.Second, 5*time.Millisecond)
+}
+
+func TestDeviceStatusHub_HandleMessage(t *testing.T) {
+	hub := NewDeviceStatusHub(nil, nil)
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	conn, _, err := dialHub(t, server, nil)
+	require.NoError(t, err)
+	waitForClients(t, hub, 1)
+
+	tests := []struct {
+		subject  string
+		payload  string
+		expected DeviceStatusUpdate
+	}{
+		{
+			subject:  events.DeviceDetectedSubject,
+			payload:  `{"mac_address":"AA:BB:CC:DD:EE:01","ip_address":"192.168.1.20","detected_at":"2025-06-01T12:00:00Z","event_id":"e1","event_type":"device.detected"}`,
+			expected: DeviceStatusUpdate{Type: DeviceStatusRegistered, MACAddress: "AA:BB:CC:DD:EE:01", IPAddress: "192.168.1.20", EventID: "e1"},
+		},
+		{
+			subject:  events.DeviceOnlineSubject,
+			payload:  `{"mac_address":"AA:BB:CC:DD:EE:01","farm_id":"farm-1","ip_address":"192.168.1.20","detected_at":"2025-06-01T12:00:00Z","event_id":"e2","event_type":"device.online"}`,
+			expected: DeviceStatusUpdate{Type: DeviceStatusOnline, MACAddress: "AA:BB:CC:DD:EE:01", FarmID: "farm-1", IPAddress: "192.168.1.20", EventID: "e2"},
+		},
+		{
+			subject:  events.DeviceOfflineSubject,
+			payload:  `{"mac_address":"AA:BB:CC:DD:EE:01","farm_id":"farm-1","last_seen":"2025-06-01T11:40:00Z","detected_at":"2025-06-01T12:00:00Z","event_id":"e3","event_type":"device.offline"}`,
+			expected: DeviceStatusUpdate{Type: DeviceStatusOffline, MACAddress: "AA:BB:CC:DD:EE:01", FarmID: "farm-1", EventID: "e3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected.Type, func(t *testing.T) {
+			require.NoError(t, hub.HandleMessage(context.Background(), tt.subject, []byte(tt.payload)))
+
+			var update DeviceStatusUpdate
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+			require.NoError(t, conn.ReadJSON(&update))
+			assert.Equal(t, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), update.OccurredAt)
+			if tt.expected.Type == DeviceStatusOffline {
+				require.NotNil(t, update.LastSeen)
+				assert.Equal(t, time.Date(2025, 6, 1, 11, 40, 0, 0, time.UTC), *update.LastSeen)
+			}
+			update.OccurredAt, update.LastSeen = time.Time{}, nil
+			assert.Equal(t, tt.expected, update)
+		})
+	}
+
+	t.Run("should reject other subjects and payloads without a device", func(t *testing.T) {
+		assert.Error(t, hub.HandleMessage(context.Background(), events.AlertSubject, []byte(`{}`)))
+		assert.Error(t, hub.HandleMessage(context.Background(), events.DeviceOfflineSubject, []byte(`{"event_id":"e4"}`)))
+		assert.Error(t, hub.HandleMessage(context.Background(), events.DeviceOnlineSubject, []byte(`not json`)))
+	})
+}
+
+func TestDeviceStatusHub_Clients(t *testing.T) {
+	t.Run("should refuse clients beyond the limit", func(t *testing.T) {
+		hub := NewDeviceStatusHub(&DeviceStatusHubConfig{MaxClients: 1, SendBuffer: 1, WriteTimeout: time.Second, PingInterval: time.Minute}, nil)
+		server := httptest.NewServer(hub)
+		defer server.Close()
+
+		_, _, err := dialHub(t, server, nil)
+		require.NoError(t, err)
+		waitForClients(t, hub, 1)
+
+		_, response, err := dialHub(t, server, nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	})
+
+	t.Run("should only accept allowed cross-origin dashboards", func(t *testing.T) {
+		hub := NewDeviceStatusHub(&DeviceStatusHubConfig{MaxClients: 2, SendBuffer: 1, WriteTimeout: time.Second, PingInterval: time.Minute, AllowedOrigins: []string{"https://dashboard.example.com"}}, nil)
+		server := httptest.NewServer(hub)
+		defer server.Close()
+
+		_, response, err := dialHub(t, server, http.Header{"Origin": {"https://evil.example.com"}})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+		_, _, err = dialHub(t, server, http.Header{"Origin": {"https://dashboard.example.com"}})
+		require.NoError(t, err)
+		waitForClients(t, hub, 1)
+	})
+
+	t.Run("should upgrade browsers presenting their access token when authentication is enabled", func(t *testing.T) {
+		hub := NewDeviceStatusHub(&DeviceStatusHubConfig{MaxClients: 3, SendBuffer: 1, WriteTimeout: time.Second, PingInterval: time.Minute}, nil)
+		authenticate := func(token string) (*entities.AuthClaims, error) {
+			if token != "valid" {
+				return nil, domainerrors.ErrInvalidToken
+			}
+			return &entities.AuthClaims{Subject: "user-1", Username: "maria", Role: entities.UserRoleViewer}, nil
+		}
+		mux := http.NewServeMux()
+		mux.Handle("GET /api/v1/devices/status/ws", hub)
+		server := httptest.NewServer(middleware.WebSocketToken("/api/v1/devices/status/ws")(middleware.Authentication(authenticate, "")(mux)))
+		defer server.Close()
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/devices/status/ws"
+
+		_, response, err := websocket.DefaultDialer.Dial(url, nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+		dialer := &websocket.Dialer{Subprotocols: []string{middleware.WebSocketTokenProtocol, "forged"}}
+		_, response, err = dialer.Dial(url, nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+		dialer = &websocket.Dialer{Subprotocols: []string{middleware.WebSocketTokenProtocol, "valid"}}
+		conn, response, err := dialer.Dial(url, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.Equal(t, middleware.WebSocketTokenProtocol, response.Header.Get("Sec-WebSocket-Protocol"))
+
+		conn, _, err = websocket.DefaultDialer.Dial(url+"?access_token=valid", nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		waitForClients(t, hub, 2)
+	})
+
+	t.Run("should disconnect clients falling behind and on close", func(t *testing.T) {
+		hub := NewDeviceStatusHub(&DeviceStatusHubConfig{MaxClients: 2, SendBuffer: 1, WriteTimeout: time.Second, PingInterval: time.Minute}, nil)
+		server := httptest.NewServer(hub)
+		defer server.Close()
+
+		_, _, err := dialHub(t, server, nil)
+		require.NoError(t, err)
+		waitForClients(t, hub, 1)
+
+		// The client never reads, so its queue fills once the socket buffers are full
+		update := &DeviceStatusUpdate{Type: DeviceStatusOnline, MACAddress: "AA:BB:CC:DD:EE:01", FarmID: strings.Repeat("x", 64*1024)}
+		require.Eventually(t, func() bool {
+			require.NoError(t, hub.Broadcast(update))
+			return hub.Clients() == 0
+		}, 5*time.Second, time.Millisecond)
+		assert.Equal(t, float64(1), hub.dropped.Value())
+
+		conn, _, err := dialHub(t, server, nil)
+		require.NoError(t, err)
+		waitForClients(t, hub, 1)
+		require.NoError(t, hub.Close(context.Background()))
+		assert.Zero(t, hub.Clients())
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "got %v", err)
+
+		_, response, err := dialHub(t, server, nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	})
+}
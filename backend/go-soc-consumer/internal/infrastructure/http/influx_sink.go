@@ -0,0 +1,98 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+)
+
+// influxMeasurement is the measurement readings are written to
+const influxMeasurement = "sensor_temperature_humidity"
+
+// InfluxSinkConfig holds configuration for the InfluxDB telemetry sink
+type InfluxSinkConfig struct {
+	WriteURL string // full write endpoint, e.g. http://influx:8086/api/v2/write?org=farm&bucket=telemetry&precision=ns
+	Token    string // sent as "Authorization: Token ..." when set
+	Timeout  time.Duration
+}
+
+// influxSink implements the TelemetrySink port with the InfluxDB line protocol
+type influxSink struct {
+	config *InfluxSinkConfig
+	client *http.Client
+}
+
+// NewInfluxSink creates a telemetry sink writing line protocol to an InfluxDB write endpoint
+func NewInfluxSink(config *InfluxSinkConfig) ports.TelemetrySink {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &influxSink{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name identifies the sink
+func (s *influxSink) Name() string {
+	return "influxdb"
+}
+
+// Write posts the readings as line protocol with nanosecond timestamps
+func (s *influxSink) Write(ctx context.Context, readings []*entities.SensorTemperatureHumidity) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.WriteURL, bytes.NewReader(encodeInfluxLines(readings)))
+	if err != nil {
+		return fmt.Errorf("failed to create influxdb request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if s.config.Token != "" {
+		req.Header.Set("Authorization", "Token "+s.config.Token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("influxdb write failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("influxdb write rejected with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// encodeInfluxLines encodes one line per reading, tagged with the device MAC address
+func encodeInfluxLines(readings []*entities.SensorTemperatureHumidity) []byte {
+	var buf bytes.Buffer
+	for _, reading := range readings {
+		buf.WriteString(influxMeasurement)
+		buf.WriteString(",mac_address=")
+		buf.WriteString(escapeInfluxTag(reading.MacAddress()))
+		buf.WriteString(" temperature=")
+		buf.WriteString(strconv.FormatFloat(reading.Temperature(), 'f', -1, 64))
+		buf.WriteString(",humidity=")
+		buf.WriteString(strconv.FormatFloat(reading.Humidity(), 'f', -1, 64))
+		buf.WriteByte(' ')
+		buf.WriteString(strconv.FormatInt(reading.Timestamp().UnixNano(), 10))
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// influxTagEscaper escapes the characters the line protocol reserves in tag values
+var influxTagEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+func escapeInfluxTag(value string) string {
+	return influxTagEscaper.Replace(value)
+}
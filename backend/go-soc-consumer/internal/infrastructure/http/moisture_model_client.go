@@ -0,0 +1,94 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+)
+
+// ExternalMoistureModel names the predictions of an external model that does not name itself
+const ExternalMoistureModel = "external"
+
+// MoistureModelClientConfig holds configuration for an external moisture model served over HTTP
+type MoistureModelClientConfig struct {
+	URL         string // endpoint receiving the prediction requests
+	BearerToken string // sent as "Authorization: Bearer ..." when set
+	Timeout     time.Duration
+}
+
+// moistureModelClient implements the MoisturePredictor port over HTTP
+type moistureModelClient struct {
+	config *MoistureModelClientConfig
+	client *http.Client
+}
+
+// NewMoistureModelClient creates a predictor posting the recent readings of a zone to an external
+// model, such as a regression trained on the farm's history, and reading back its prediction
+func NewMoistureModelClient(config *MoistureModelClientConfig) ports.MoisturePredictor {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &moistureModelClient{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the external model name
+func (c *moistureModelClient) Name() string {
+	return ExternalMoistureModel
+}
+
+// Predict posts the request to the model. A 422 reply means the model has too few readings to
+// predict the zone.
+func (c *moistureModelClient) Predict(ctx context.Context, request *entities.MoisturePredictionRequest) (*entities.MoisturePrediction, error) {
+	body, err := json.Marshal(dtos.NewMoisturePredictionRequest(request))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode moisture prediction request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create moisture prediction request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.BearerToken)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("moisture model request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if resp.StatusCode == http.StatusUnprocessableEntity {
+			return nil, fmt.Errorf("%w: %s", domainerrors.ErrInsufficientReadings, strings.TrimSpace(string(detail)))
+		}
+		return nil, fmt.Errorf("moisture model returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	var response dtos.MoisturePredictionResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode moisture prediction: %w", err)
+	}
+	prediction, err := response.ToEntity(request, ExternalMoistureModel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid moisture prediction: %w", err)
+	}
+	return prediction, nil
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+)
+
+func TestMoistureModelClient_Predict(t *testing.T) {
+	at := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	request := &entities.MoisturePredictionRequest{
+		Zone:         "north",
+		MACAddresses: []string{"AA:BB:CC:DD:EE:01"},
+		SensorType:   "soil_moisture",
+		Threshold:    25,
+		Horizon:      48 * time.Hour,
+		At:           at,
+		Readings:     []*entities.Measurement{{MACAddress: "AA:BB:CC:DD:EE:01", Value: 31, Timestamp: at}},
+	}
+
+	t.Run("should post the readings and read the prediction", func(t *testing.T) {
+		var body dtos.MoisturePredictionRequest
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			authorization = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"mac_address":"AA:BB:CC:DD:EE:01","moisture":31,"drying_rate":1.5,"hours_until_threshold":4,"forecast":[{"at":"2025-06-02T13:00:00Z","moisture":29.5}]}`))
+		}))
+		defer server.Close()
+
+		prediction, err := NewMoistureModelClient(&MoistureModelClientConfig{URL: server.URL, BearerToken: "secret"}).Predict(context.Background(), request)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer secret", authorization)
+		assert.Equal(t, "north", body.Zone)
+		assert.Equal(t, 48.0, body.HorizonHours)
+		require.Len(t, body.Readings, 1)
+		assert.Equal(t, ExternalMoistureModel, prediction.Model)
+		assert.Equal(t, at, prediction.PredictedAt)
+		require.NotNil(t, prediction.HoursUntilThreshold)
+		assert.Equal(t, 4.0, *prediction.HoursUntilThreshold)
+		require.Len(t, prediction.Forecast, 1)
+	})
+
+	t.Run("should report insufficient readings on 422", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "need more readings", http.StatusUnprocessableEntity)
+		}))
+		defer server.Close()
+
+		_, err := NewMoistureModelClient(&MoistureModelClientConfig{URL: server.URL}).Predict(context.Background(), request)
+		assert.ErrorIs(t, err, domainerrors.ErrInsufficientReadings)
+	})
+
+	t.Run("should reject negative hours until the threshold", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"model":"gbm","hours_until_threshold":-1}`))
+		}))
+		defer server.Close()
+
+		_, err := NewMoistureModelClient(&MoistureModelClientConfig{URL: server.URL}).Predict(context.Background(), request)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be negative")
+	})
+}
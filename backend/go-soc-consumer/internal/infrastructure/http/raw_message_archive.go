@@ -0,0 +1,143 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+)
+
+// rawBundleHourLayout is the part of a bundle key naming its hour, e.g. raw/2025/03/01/14/<id>.jsonl.gz
+const rawBundleHourLayout = "2006/01/02/15"
+
+// rawMessageArchive implements the RawMessageArchive port with gzip compressed JSON lines in S3
+type rawMessageArchive struct {
+	client *S3Client
+	prefix string
+}
+
+// NewRawMessageArchive creates a raw message archive storing bundles under the key prefix
+func NewRawMessageArchive(client *S3Client, prefix string) ports.RawMessageArchive {
+	return &rawMessageArchive{
+		client: client,
+		prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+// Store uploads the messages as one bundle with a unique key within the hour
+func (a *rawMessageArchive) Store(ctx context.Context, hour time.Time, messages []*entities.RawMessage) error {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(writer)
+	for _, message := range messages {
+		if err := encoder.Encode(dtos.NewArchivedMessage(message)); err != nil {
+			return fmt.Errorf("failed to encode raw message: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to compress raw message bundle: %w", err)
+	}
+
+	key := a.hourPrefix(hour) + uuid.New().String() + ".jsonl.gz"
+	return a.client.PutObject(ctx, key, buf.Bytes(), "application/gzip")
+}
+
+// Read downloads the bundles of every hour overlapping [from, to) and calls fn with the messages received in the range
+func (a *rawMessageArchive) Read(ctx context.Context, from, to time.Time, fn func(message *entities.RawMessage) error) error {
+	for hour := from.UTC().Truncate(time.Hour); hour.Before(to); hour = hour.Add(time.Hour) {
+		keys, err := a.client.ListObjects(ctx, a.hourPrefix(hour))
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := a.readBundle(ctx, key, from, to, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readBundle decodes one bundle line by line
+func (a *rawMessageArchive) readBundle(ctx context.Context, key string, from, to time.Time, fn func(message *entities.RawMessage) error) error {
+	data, err := a.client.GetObject(ctx, key)
+	if err != nil {
+		return err
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to decompress raw message bundle %s: %w", key, err)
+	}
+	defer reader.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(reader))
+	for decoder.More() {
+		var line dtos.ArchivedMessage
+		if err := decoder.Decode(&line); err != nil {
+			return fmt.Errorf("failed to decode raw message bundle %s: %w", key, err)
+		}
+		if line.ReceivedAt.Before(from) || !line.ReceivedAt.Before(to) {
+			continue
+		}
+
+		message, err := line.ToEntity()
+		if err != nil {
+			return fmt.Errorf("invalid message in raw message bundle %s: %w", key, err)
+		}
+		if err := fn(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteBefore removes the bundles whose hour in the key is before the cutoff. Keys that do
+// not follow the bundle layout are left alone.
+func (a *rawMessageArchive) DeleteBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	keys, err := a.client.ListObjects(ctx, a.prefix+"/")
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		hour, ok := a.bundleHour(key)
+		if !ok || !hour.Before(cutoff) {
+			continue
+		}
+		if err := a.client.DeleteObject(ctx, key); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// hourPrefix returns the key prefix of the bundles of an hour
+func (a *rawMessageArchive) hourPrefix(hour time.Time) string {
+	return a.prefix + "/" + hour.UTC().Format(rawBundleHourLayout) + "/"
+}
+
+// bundleHour parses the hour from a bundle key
+func (a *rawMessageArchive) bundleHour(key string) (time.Time, bool) {
+	rest, ok := strings.CutPrefix(key, a.prefix+"/")
+	if !ok || len(rest) <= len(rawBundleHourLayout) {
+		return time.Time{}, false
+	}
+
+	hour, err := time.Parse(rawBundleHourLayout, rest[:len(rawBundleHourLayout)])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return hour, true
+}
@@ -0,0 +1,166 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+)
+
+// fakeS3 keeps the objects of a single bucket in memory
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *S3Client) {
+	store := &fakeS3{objects: make(map[string][]byte)}
+	server := httptest.NewServer(store)
+	t.Cleanup(server.Close)
+
+	return store, NewS3Client(&S3ClientConfig{
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "archive",
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+	})
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/archive/")
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		s.objects[key] = body
+	case http.MethodDelete:
+		delete(s.objects, key)
+	case http.MethodGet:
+		if r.URL.Query().Get("list-type") != "2" {
+			body, ok := s.objects[key]
+			if !ok {
+				http.Error(w, "NoSuchKey", http.StatusNotFound)
+				return
+			}
+			w.Write(body)
+			return
+		}
+		keys := make([]string, 0, len(s.objects))
+		for key := range s.objects {
+			if strings.HasPrefix(key, r.URL.Query().Get("prefix")) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		fmt.Fprint(w, "<ListBucketResult>")
+		for _, key := range keys {
+			fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", key)
+		}
+		fmt.Fprint(w, "<IsTruncated>false</IsTruncated></ListBucketResult>")
+	}
+}
+
+func (s *fakeS3) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestRawMessageArchive_Store(t *testing.T) {
+	store, client := newFakeS3(t)
+	archive := NewRawMessageArchive(client, "/raw/")
+	hour := time.Date(2025, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	message, err := entities.NewRawMessage("/liwaisi/iot/smart-irrigation/sensors/temperature-humidity", []byte("not json"), hour.Add(5*time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, archive.Store(context.Background(), hour, []*entities.RawMessage{message}))
+	require.NoError(t, archive.Store(context.Background(), hour, []*entities.RawMessage{message}))
+
+	keys := store.keys()
+	require.Len(t, keys, 2, "bundles of the same hour must not replace each other")
+	assert.True(t, strings.HasPrefix(keys[0], "raw/2025/03/01/14/"))
+	assert.True(t, strings.HasSuffix(keys[0], ".jsonl.gz"))
+
+	reader, err := gzip.NewReader(bytes.NewReader(store.objects[keys[0]]))
+	require.NoError(t, err)
+	scanner := bufio.NewScanner(reader)
+	require.True(t, scanner.Scan())
+	var line dtos.ArchivedMessage
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+	assert.Equal(t, "not json", string(line.Payload))
+	assert.Equal(t, message.Topic, line.Topic)
+	assert.True(t, message.ReceivedAt.Equal(line.ReceivedAt))
+	assert.False(t, scanner.Scan())
+}
+
+func TestRawMessageArchive_DeleteBefore(t *testing.T) {
+	store, client := newFakeS3(t)
+	archive := NewRawMessageArchive(client, "raw")
+	ctx := context.Background()
+
+	for _, key := range []string{"raw/2025/03/01/13/a.jsonl.gz", "raw/2025/03/01/14/b.jsonl.gz", "raw/2025/03/01/15/c.jsonl.gz", "raw/README"} {
+		require.NoError(t, client.PutObject(ctx, key, []byte("x"), "text/plain"))
+	}
+
+	deleted, err := archive.DeleteBefore(ctx, time.Date(2025, 3, 1, 15, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, deleted)
+	assert.Equal(t, []string{"raw/2025/03/01/15/c.jsonl.gz", "raw/README"}, store.keys())
+}
+
+func TestRawMessageArchive_Read(t *testing.T) {
+	_, client := newFakeS3(t)
+	archive := NewRawMessageArchive(client, "raw")
+	ctx := context.Background()
+	hour := time.Date(2025, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	var messages []*entities.RawMessage
+	for i, offset := range []time.Duration{10 * time.Minute, 50 * time.Minute, 70 * time.Minute, 130 * time.Minute} {
+		message, err := entities.NewRawMessage(fmt.Sprintf("topic-%d", i), []byte("{}"), hour.Add(offset))
+		require.NoError(t, err)
+		messages = append(messages, message)
+	}
+	messages[1].Identity = &entities.ClientIdentity{ClientID: "esp32-1", CertificateFingerprint: "ab"}
+	require.NoError(t, archive.Store(ctx, hour, messages[:2]))
+	require.NoError(t, archive.Store(ctx, hour.Add(time.Hour), messages[2:3]))
+	require.NoError(t, archive.Store(ctx, hour.Add(2*time.Hour), messages[3:]))
+
+	var read []*entities.RawMessage
+	err := archive.Read(ctx, hour.Add(30*time.Minute), hour.Add(2*time.Hour), func(message *entities.RawMessage) error {
+		read = append(read, message)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, read, 2)
+	assert.Equal(t, "topic-1", read[0].Topic)
+	assert.Equal(t, &entities.ClientIdentity{ClientID: "esp32-1", CertificateFingerprint: "ab"}, read[0].Identity)
+	assert.Equal(t, "topic-2", read[1].Topic)
+	assert.Nil(t, read[1].Identity)
+}
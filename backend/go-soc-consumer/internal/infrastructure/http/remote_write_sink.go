@@ -0,0 +1,138 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+)
+
+// Series names readings are written under
+const (
+	remoteWriteTemperatureMetric = "irrigation_sensor_temperature_celsius"
+	remoteWriteHumidityMetric    = "irrigation_sensor_humidity_percent"
+)
+
+// RemoteWriteSinkConfig holds configuration for the Prometheus remote-write telemetry sink
+type RemoteWriteSinkConfig struct {
+	URL         string // remote-write endpoint, e.g. http://prometheus:9090/api/v1/write
+	BearerToken string // sent as "Authorization: Bearer ..." when set
+	Timeout     time.Duration
+}
+
+// remoteWriteSink implements the TelemetrySink port with the Prometheus remote-write protocol
+type remoteWriteSink struct {
+	config *RemoteWriteSinkConfig
+	client *http.Client
+}
+
+// NewRemoteWriteSink creates a telemetry sink pushing readings to a Prometheus remote-write endpoint
+func NewRemoteWriteSink(config *RemoteWriteSinkConfig) ports.TelemetrySink {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &remoteWriteSink{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name identifies the sink
+func (s *remoteWriteSink) Name() string {
+	return "prometheus_remote_write"
+}
+
+// Write pushes the readings as a snappy compressed WriteRequest
+func (s *remoteWriteSink) Write(ctx context.Context, readings []*entities.SensorTemperatureHumidity) error {
+	body := s2.EncodeSnappy(nil, encodeRemoteWriteRequest(readings))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create remote write request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if s.config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.config.BearerToken)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote write failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("remote write rejected with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// encodeRemoteWriteRequest encodes a prometheus.WriteRequest protobuf message with one temperature
+// and one humidity series per reading. Samples of a series must be in time order, which one sample
+// per series always is.
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries   { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label        { string name = 1; string value = 2; }
+//	message Sample       { double value = 1; int64 timestamp = 2; }
+func encodeRemoteWriteRequest(readings []*entities.SensorTemperatureHumidity) []byte {
+	var request []byte
+	for _, reading := range readings {
+		timestamp := reading.Timestamp().UnixMilli()
+		request = appendProtoBytes(request, 1, encodeTimeSeries(remoteWriteTemperatureMetric, reading.MacAddress(), reading.Temperature(), timestamp))
+		request = appendProtoBytes(request, 1, encodeTimeSeries(remoteWriteHumidityMetric, reading.MacAddress(), reading.Humidity(), timestamp))
+	}
+	return request
+}
+
+// encodeTimeSeries encodes a TimeSeries with its labels sorted by name, as remote-write requires
+func encodeTimeSeries(metric, macAddress string, value float64, timestamp int64) []byte {
+	var series []byte
+	series = appendProtoBytes(series, 1, encodeLabel("__name__", metric))
+	series = appendProtoBytes(series, 1, encodeLabel("mac_address", macAddress))
+
+	var sample []byte
+	sample = appendProtoFixed64(sample, 1, math.Float64bits(value))
+	sample = appendProtoVarint(sample, 2, uint64(timestamp))
+	return appendProtoBytes(series, 2, sample)
+}
+
+func encodeLabel(name, value string) []byte {
+	var label []byte
+	label = appendProtoBytes(label, 1, []byte(name))
+	label = appendProtoBytes(label, 2, []byte(value))
+	return label
+}
+
+// appendProtoBytes appends a length-delimited field
+func appendProtoBytes(buf []byte, field int, value []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3|2)
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+// appendProtoFixed64 appends a fixed 64-bit field
+func appendProtoFixed64(buf []byte, field int, value uint64) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3|1)
+	return binary.LittleEndian.AppendUint64(buf, value)
+}
+
+// appendProtoVarint appends a varint field
+func appendProtoVarint(buf []byte, field int, value uint64) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3)
+	return binary.AppendUvarint(buf, value)
+}
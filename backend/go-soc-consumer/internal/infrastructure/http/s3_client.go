@@ -0,0 +1,282 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3ClientConfig holds configuration for an S3 compatible object store such as AWS S3 or MinIO
+type S3ClientConfig struct {
+	Endpoint        string // e.g. https://s3.us-east-1.amazonaws.com or http://minio:9000
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// ServerSideEncryption is sent as x-amz-server-side-encryption on uploads: AES256, aws:kms or empty
+	ServerSideEncryption string
+	KMSKeyID             string // key used when ServerSideEncryption is aws:kms, empty for the bucket default
+	Timeout              time.Duration
+}
+
+// S3Client is a minimal S3 client signing path-style requests with AWS Signature Version 4
+type S3Client struct {
+	config *S3ClientConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Client creates a new S3 client
+func NewS3Client(config *S3ClientConfig) *S3Client {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+
+	return &S3Client{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+		now:    time.Now,
+	}
+}
+
+// PutObject uploads an object, applying the configured server-side encryption
+func (c *S3Client) PutObject(ctx context.Context, key string, body []byte, contentType string) error {
+	req, err := c.newRequest(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if c.config.ServerSideEncryption != "" {
+		req.Header.Set("X-Amz-Server-Side-Encryption", c.config.ServerSideEncryption)
+		if c.config.KMSKeyID != "" {
+			req.Header.Set("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id", c.config.KMSKeyID)
+		}
+	}
+
+	resp, err := c.do(req, body)
+	if err != nil {
+		return fmt.Errorf("failed to put object %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// GetObject downloads an object
+func (c *S3Client) GetObject(ctx context.Context, key string) ([]byte, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+	return body, nil
+}
+
+// ListObjects returns the keys of every object under the prefix, in lexicographic order
+func (c *S3Client) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	continuationToken := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		req, err := c.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.do(req, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects under %s: %w", prefix, err)
+		}
+
+		var result struct {
+			Contents []struct {
+				Key string `xml:"Key"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode object listing: %w", err)
+		}
+
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// DeleteObject removes an object; deleting a missing object succeeds
+func (c *S3Client) DeleteObject(ctx context.Context, key string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// newRequest creates a path-style request for an object of the bucket, or for the bucket itself when key is empty
+func (c *S3Client) newRequest(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Request, error) {
+	path := "/" + c.config.Bucket
+	if key != "" {
+		path += "/" + key
+	}
+
+	endpoint, err := url.Parse(strings.TrimRight(c.config.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+	endpoint.Path = path
+	endpoint.RawPath = s3URIEncode(path, false)
+	endpoint.RawQuery = s3CanonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 request: %w", err)
+	}
+	return req, nil
+}
+
+// do signs and sends the request, turning non 2xx responses into errors
+func (c *S3Client) do(req *http.Request, body []byte) (*http.Response, error) {
+	c.sign(req, body)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("S3 responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return resp, nil
+}
+
+// sign adds the AWS Signature Version 4 headers. Every header set on the request so far is signed.
+func (c *S3Client) sign(req *http.Request, body []byte) {
+	now := c.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3URIEncode(req.URL.Path, false),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.config.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+c.config.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, c.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// s3CanonicalQuery encodes the query sorted by name, as both the URL and the signature require
+func s3CanonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		for _, value := range query[name] {
+			pairs = append(pairs, s3URIEncode(name, true)+"="+s3URIEncode(value, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// s3URIEncode percent-encodes everything but the RFC 3986 unreserved characters, and the slash unless encodeSlash is set
+func s3URIEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case 'A' <= ch && ch <= 'Z', 'a' <= ch && ch <= 'z', '0' <= ch && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == '~':
+			encoded.WriteByte(ch)
+		case ch == '/' && !encodeSlash:
+			encoded.WriteByte(ch)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", ch)
+		}
+	}
+	return encoded.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+)
+
+// SMSGatewaySenderConfig holds configuration for sending SMS through an HTTP gateway
+type SMSGatewaySenderConfig struct {
+	URL         string // gateway endpoint receiving {"to": ..., "message": ...}
+	BearerToken string // sent as "Authorization: Bearer ..." when set
+	Timeout     time.Duration
+}
+
+// smsGatewayMessage is the request body posted to the gateway
+type smsGatewayMessage struct {
+	To      string `json:"to"`
+	Message string `json:"message"`
+}
+
+// smsGatewaySender implements the NotificationSender port for SMS over an HTTP gateway
+type smsGatewaySender struct {
+	config *SMSGatewaySenderConfig
+	client *http.Client
+}
+
+// NewSMSGatewaySender creates a sender posting SMS to a gateway that delivers them, such as a
+// webhook in front of the operator's SMS provider
+func NewSMSGatewaySender(config *SMSGatewaySenderConfig) ports.NotificationSender {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &smsGatewaySender{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Channel returns the sms channel
+func (s *smsGatewaySender) Channel() string {
+	return entities.NotificationChannelSMS
+}
+
+// Send posts the subject and body of the notification as one message
+func (s *smsGatewaySender) Send(ctx context.Context, notification *entities.Notification) error {
+	body, err := json.Marshal(smsGatewayMessage{
+		To:      notification.Address,
+		Message: notification.Subject + "\n" + notification.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode sms: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create sms request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.config.BearerToken)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms gateway request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("sms rejected with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
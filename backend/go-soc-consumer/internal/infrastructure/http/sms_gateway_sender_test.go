@@ -0,0 +1,53 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+func TestSMSGatewaySender_Send(t *testing.T) {
+	notification := &entities.Notification{
+		User:    "ana",
+		Channel: entities.NotificationChannelSMS,
+		Address: "+573001234567",
+		Subject: "[critical] alert dry_soil is firing",
+		Body:    "soil_moisture is 15",
+	}
+
+	t.Run("should post the message with the token", func(t *testing.T) {
+		var message smsGatewayMessage
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+			authorization = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		sender := NewSMSGatewaySender(&SMSGatewaySenderConfig{URL: server.URL, BearerToken: "secret"})
+		require.NoError(t, sender.Send(context.Background(), notification))
+
+		assert.Equal(t, entities.NotificationChannelSMS, sender.Channel())
+		assert.Equal(t, "Bearer secret", authorization)
+		assert.Equal(t, smsGatewayMessage{To: "+573001234567", Message: "[critical] alert dry_soil is firing\nsoil_moisture is 15"}, message)
+	})
+
+	t.Run("should return an error when the message is rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid number", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		err := NewSMSGatewaySender(&SMSGatewaySenderConfig{URL: server.URL}).Send(context.Background(), notification)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid number")
+	})
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// SyncClientConfig holds configuration for the cloud sync client
+type SyncClientConfig struct {
+	CloudURL   string
+	Token      string
+	Timeout    time.Duration
+	InstanceID string
+}
+
+// syncClient implements the SyncRemote port over HTTP
+type syncClient struct {
+	config        *SyncClientConfig
+	client        *http.Client
+	loggerFactory logger.LoggerFactory
+}
+
+// NewSyncClient creates a new HTTP client pushing sync batches to the cloud instance
+func NewSyncClient(config *SyncClientConfig, loggerFactory logger.LoggerFactory) ports.SyncRemote {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	return &syncClient{
+		config:        config,
+		client:        &http.Client{Timeout: timeout},
+		loggerFactory: loggerFactory,
+	}
+}
+
+// Push sends a batch of changes to POST {cloud}/api/v1/sync/batch
+func (c *syncClient) Push(ctx context.Context, items []*entities.SyncItem) (*entities.SyncApplyResult, error) {
+	body, err := json.Marshal(dtos.NewSyncBatchRequest(c.config.InstanceID, items))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sync batch: %w", err)
+	}
+
+	url := strings.TrimRight(c.config.CloudURL, "/") + "/api/v1/sync/batch"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sync request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		c.loggerFactory.Core().Warn("sync_batch_rejected",
+			zap.Int("status_code", resp.StatusCode),
+			zap.Int("batch_size", len(items)),
+			zap.String("component", "sync_client"),
+		)
+		return nil, fmt.Errorf("%w: HTTP status %d", domainerrors.ErrSyncRemoteRejected, resp.StatusCode)
+	}
+
+	var result entities.SyncApplyResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode sync response: %w", err)
+	}
+
+	c.loggerFactory.Core().Debug("sync_batch_pushed",
+		zap.Int("batch_size", len(items)),
+		zap.Int("applied", len(result.Applied)),
+		zap.Int("skipped", len(result.Skipped)),
+		zap.Int("rejected", len(result.Rejected)),
+		zap.Duration("duration", time.Since(start)),
+		zap.String("component", "sync_client"),
+	)
+	return &result, nil
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+func testReading(t *testing.T) *entities.SensorTemperatureHumidity {
+	reading, err := entities.NewSensorTemperatureHumidity("AA:BB:CC:DD:EE:FF", 21.5, 60)
+	require.NoError(t, err)
+	return reading
+}
+
+func TestInfluxSink_Write(t *testing.T) {
+	reading := testReading(t)
+
+	t.Run("should post line protocol with the token", func(t *testing.T) {
+		var body []byte
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ = io.ReadAll(r.Body)
+			authorization = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		sink := NewInfluxSink(&InfluxSinkConfig{WriteURL: server.URL, Token: "secret"})
+		require.NoError(t, sink.Write(context.Background(), []*entities.SensorTemperatureHumidity{reading}))
+
+		assert.Equal(t, "Token secret", authorization)
+		assert.Equal(t, "sensor_temperature_humidity,mac_address=AA:BB:CC:DD:EE:FF temperature=21.5,humidity=60 "+
+			strconv.FormatInt(reading.Timestamp().UnixNano(), 10)+"\n", string(body))
+	})
+
+	t.Run("should return an error when the write is rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bucket not found", http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		err := NewInfluxSink(&InfluxSinkConfig{WriteURL: server.URL}).Write(context.Background(), []*entities.SensorTemperatureHumidity{reading})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "bucket not found")
+	})
+}
+
+func TestEscapeInfluxTag(t *testing.T) {
+	assert.Equal(t, `a\,b\=c\ d`, escapeInfluxTag("a,b=c d"))
+}
+
+func TestRemoteWriteSink_Write(t *testing.T) {
+	reading := testReading(t)
+
+	var body []byte
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		headers = r.Header
+	}))
+	defer server.Close()
+
+	sink := NewRemoteWriteSink(&RemoteWriteSinkConfig{URL: server.URL, BearerToken: "secret"})
+	require.NoError(t, sink.Write(context.Background(), []*entities.SensorTemperatureHumidity{reading}))
+
+	assert.Equal(t, "Bearer secret", headers.Get("Authorization"))
+	assert.Equal(t, "snappy", headers.Get("Content-Encoding"))
+
+	decoded, err := s2.Decode(nil, body)
+	require.NoError(t, err)
+	assert.Equal(t, encodeRemoteWriteRequest([]*entities.SensorTemperatureHumidity{reading}), decoded)
+}
+
+func TestEncodeTimeSeries(t *testing.T) {
+	series := encodeTimeSeries("metric", "AA", 1.5, 1000)
+
+	expected := []byte{
+		0x0a, 0x12, 0x0a, 0x08, '_', '_', 'n', 'a', 'm', 'e', '_', '_', 0x12, 0x06, 'm', 'e', 't', 'r', 'i', 'c',
+		0x0a, 0x11, 0x0a, 0x0b, 'm', 'a', 'c', '_', 'a', 'd', 'd', 'r', 'e', 's', 's', 0x12, 0x02, 'A', 'A',
+		0x12, 0x0c, 0x09,
+	}
+	expected = binary.LittleEndian.AppendUint64(expected, math.Float64bits(1.5))
+	expected = append(expected, 0x10, 0xe8, 0x07)
+	assert.Equal(t, expected, series)
+}
@@ -0,0 +1,77 @@
+package inflight
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Tracker gives message handlers a consumer-scoped context and drains them when the consumer stops.
+// Handlers keep running while the consumer drains; only those still running when the stop deadline
+// expires see their context cancelled. A stopped tracker cannot be restarted.
+type Tracker struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	stopping bool
+	wg       sync.WaitGroup
+	count    atomic.Int64
+}
+
+// NewTracker creates a tracker for a consumer
+func NewTracker() *Tracker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Tracker{ctx: ctx, cancel: cancel}
+}
+
+// Begin registers an in-flight message and returns the context to handle it with.
+// It returns false once the consumer is stopping; the message must then be left unhandled.
+// Every successful Begin must be followed by Done.
+func (t *Tracker) Begin() (context.Context, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopping {
+		return nil, false
+	}
+	t.wg.Add(1)
+	t.count.Add(1)
+	return t.ctx, true
+}
+
+// Done marks a message registered with Begin as handled
+func (t *Tracker) Done() {
+	t.count.Add(-1)
+	t.wg.Done()
+}
+
+// InFlight returns the number of messages being handled
+func (t *Tracker) InFlight() int {
+	return int(t.count.Load())
+}
+
+// Drain refuses new messages and waits for the in-flight ones. When ctx expires first the
+// handlers still running are cancelled and an error is returned without waiting for them.
+func (t *Tracker) Drain(ctx context.Context) error {
+	t.mu.Lock()
+	t.stopping = true
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.cancel()
+		return nil
+	case <-ctx.Done():
+		inFlight := t.InFlight()
+		t.cancel()
+		return fmt.Errorf("cancelled %d in-flight messages: %w", inFlight, ctx.Err())
+	}
+}
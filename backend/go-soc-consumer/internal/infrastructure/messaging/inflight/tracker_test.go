@@ -0,0 +1,50 @@
+package inflight
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker_Drain(t *testing.T) {
+	t.Run("should wait for in-flight messages", func(t *testing.T) {
+		tracker := NewTracker()
+		msgCtx, ok := tracker.Begin()
+		require.True(t, ok)
+
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			tracker.Done()
+		}()
+
+		require.NoError(t, tracker.Drain(context.Background()))
+		assert.Equal(t, 0, tracker.InFlight())
+		assert.Error(t, msgCtx.Err(), "the consumer context ends with the consumer")
+	})
+
+	t.Run("should cancel in-flight messages when the drain times out", func(t *testing.T) {
+		tracker := NewTracker()
+		msgCtx, ok := tracker.Begin()
+		require.True(t, ok)
+		defer tracker.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := tracker.Drain(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "cancelled 1 in-flight messages")
+		assert.ErrorIs(t, msgCtx.Err(), context.Canceled)
+	})
+
+	t.Run("should refuse messages once stopping", func(t *testing.T) {
+		tracker := NewTracker()
+		require.NoError(t, tracker.Drain(context.Background()))
+
+		_, ok := tracker.Begin()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,137 @@
+package broker
+
+import (
+	"sync/atomic"
+	"time"
+
+	pahomqtt "github.com/eclipse/paho.mqtt.golang"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// bridgeSubscriptionID identifies the inline subscriptions owned by the bridge
+const bridgeSubscriptionID = 9000
+
+// BridgeConfig holds configuration for forwarding local messages to an upstream broker
+type BridgeConfig struct {
+	UpstreamURL    string
+	ClientID       string
+	Username       string
+	Password       string
+	Topics         []string // local topic filters forwarded upstream
+	ConnectTimeout time.Duration
+}
+
+// Bridge forwards messages published on the embedded broker to an upstream broker.
+// Messages published while the upstream is unreachable are dropped and counted.
+type Bridge struct {
+	config        BridgeConfig
+	server        *mochi.Server
+	client        pahomqtt.Client
+	loggerFactory logger.LoggerFactory
+
+	forwarded atomic.Int64
+	dropped   atomic.Int64
+}
+
+// NewBridge creates a bridge for the given embedded broker
+func NewBridge(config BridgeConfig, server *mochi.Server, loggerFactory logger.LoggerFactory) *Bridge {
+	if config.ConnectTimeout <= 0 {
+		config.ConnectTimeout = 10 * time.Second
+	}
+	return &Bridge{
+		config:        config,
+		server:        server,
+		loggerFactory: loggerFactory,
+	}
+}
+
+// Start connects to the upstream broker in the background and subscribes to the local topics.
+// The upstream connection is retried automatically so the bridge can start while offline.
+func (b *Bridge) Start() error {
+	opts := pahomqtt.NewClientOptions()
+	opts.AddBroker(b.config.UpstreamURL)
+	opts.SetClientID(b.config.ClientID)
+	opts.SetUsername(b.config.Username)
+	opts.SetPassword(b.config.Password)
+	opts.SetConnectTimeout(b.config.ConnectTimeout)
+	opts.SetAutoReconnect(true)
+	opts.SetConnectRetry(true)
+	opts.SetConnectionLostHandler(func(client pahomqtt.Client, err error) {
+		b.loggerFactory.Core().Warn("mqtt_bridge_connection_lost",
+			zap.Error(err),
+			zap.String("upstream_url", b.config.UpstreamURL),
+			zap.String("component", "mqtt_bridge"),
+		)
+	})
+	opts.SetOnConnectHandler(func(client pahomqtt.Client) {
+		b.loggerFactory.Application().LogApplicationEvent("mqtt_bridge_connected", "mqtt_bridge",
+			zap.String("upstream_url", b.config.UpstreamURL),
+		)
+	})
+
+	b.client = pahomqtt.NewClient(opts)
+	b.client.Connect()
+
+	for _, topic := range b.config.Topics {
+		if err := b.server.Subscribe(topic, bridgeSubscriptionID, b.forward); err != nil {
+			return err
+		}
+	}
+
+	b.loggerFactory.Application().LogApplicationEvent("mqtt_bridge_started", "mqtt_bridge",
+		zap.String("upstream_url", b.config.UpstreamURL),
+		zap.Strings("topics", b.config.Topics),
+	)
+	return nil
+}
+
+// forward publishes a locally received message to the upstream broker
+func (b *Bridge) forward(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+	if !b.client.IsConnected() {
+		b.dropped.Add(1)
+		return
+	}
+
+	b.client.Publish(pk.TopicName, pk.FixedHeader.Qos, pk.FixedHeader.Retain, pk.Payload)
+	b.forwarded.Add(1)
+}
+
+// Stop unsubscribes the local topics and disconnects from the upstream broker
+func (b *Bridge) Stop() {
+	for _, topic := range b.config.Topics {
+		_ = b.server.Unsubscribe(topic, bridgeSubscriptionID)
+	}
+	if b.client != nil {
+		b.client.Disconnect(250)
+	}
+	b.loggerFactory.Application().LogApplicationEvent("mqtt_bridge_stopped", "mqtt_bridge",
+		zap.Int64("forwarded_messages", b.forwarded.Load()),
+		zap.Int64("dropped_messages", b.dropped.Load()),
+	)
+}
+
+// IsConnected returns true when the upstream connection is established
+func (b *Bridge) IsConnected() bool {
+	return b.client != nil && b.client.IsConnected()
+}
+
+// Collect implements metrics.Collector
+func (b *Bridge) Collect() []metrics.Family {
+	connected := float64(0)
+	if b.IsConnected() {
+		connected = 1
+	}
+	return []metrics.Family{
+		{Name: "mqtt_bridge_connected", Help: "Whether the upstream MQTT bridge is connected", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: connected}}},
+		{Name: "mqtt_bridge_forwarded_total", Help: "Messages forwarded to the upstream MQTT broker", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(b.forwarded.Load())}}},
+		{Name: "mqtt_bridge_dropped_total", Help: "Messages dropped while the upstream MQTT broker was unreachable", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(b.dropped.Load())}}},
+	}
+}
@@ -0,0 +1,233 @@
+package broker
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// InlineClientID is the client ID of messages the server publishes through the embedded broker
+const InlineClientID = mochi.InlineClientId
+
+// EmbeddedBrokerConfig holds configuration for the in-process MQTT broker
+type EmbeddedBrokerConfig struct {
+	Address string             // TCP listen address for devices, e.g. :1883
+	Users   map[string]string  // username -> password; empty allows anonymous clients
+	Bridge  *BridgeConfig      // optional upstream bridge, nil disables bridging
+	TLS     *TLSListenerConfig // optional TLS listener, nil disables it
+}
+
+// TLSListenerConfig configures a TLS listener; with a client CA, devices must present a certificate signed by it
+type TLSListenerConfig struct {
+	Address      string
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+}
+
+// tlsConfig loads the server certificate and the optional client CA
+func (c TLSListenerConfig) tlsConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded broker certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read embedded broker client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("embedded broker client CA contains no certificates")
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// EmbeddedBroker runs an MQTT broker inside the server process for all-in-one edge deployments
+type EmbeddedBroker struct {
+	config        EmbeddedBrokerConfig
+	server        *mochi.Server
+	bridge        *Bridge
+	loggerFactory logger.LoggerFactory
+
+	mu      sync.Mutex
+	running atomic.Bool
+}
+
+// NewEmbeddedBroker creates an embedded broker with the inline client enabled
+func NewEmbeddedBroker(config EmbeddedBrokerConfig, loggerFactory logger.LoggerFactory) (*EmbeddedBroker, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("embedded broker address is required")
+	}
+
+	server := mochi.New(&mochi.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	if err := server.AddHook(new(auth.Hook), &auth.Options{Ledger: buildLedger(config.Users)}); err != nil {
+		return nil, fmt.Errorf("failed to add embedded broker auth hook: %w", err)
+	}
+
+	tcp := listeners.NewTCP(listeners.Config{ID: "tcp", Address: config.Address})
+	if err := server.AddListener(tcp); err != nil {
+		return nil, fmt.Errorf("failed to add embedded broker listener: %w", err)
+	}
+
+	if config.TLS != nil {
+		tlsConfig, err := config.TLS.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		secure := listeners.NewTCP(listeners.Config{ID: "tls", Address: config.TLS.Address, TLSConfig: tlsConfig})
+		if err := server.AddListener(secure); err != nil {
+			return nil, fmt.Errorf("failed to add embedded broker TLS listener: %w", err)
+		}
+	}
+
+	b := &EmbeddedBroker{
+		config:        config,
+		server:        server,
+		loggerFactory: loggerFactory,
+	}
+	if config.Bridge != nil {
+		b.bridge = NewBridge(*config.Bridge, server, loggerFactory)
+	}
+
+	return b, nil
+}
+
+// buildLedger creates the auth ledger; without users every client is allowed
+func buildLedger(users map[string]string) *auth.Ledger {
+	if len(users) == 0 {
+		return &auth.Ledger{
+			Auth: auth.AuthRules{{Allow: true}},
+		}
+	}
+
+	ledger := &auth.Ledger{Users: make(auth.Users, len(users))}
+	for username, password := range users {
+		ledger.Users[username] = auth.UserRule{
+			Username: auth.RString(username),
+			Password: auth.RString(password),
+		}
+	}
+	return ledger
+}
+
+// Start begins accepting device connections and starts the upstream bridge if configured
+func (b *EmbeddedBroker) Start() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.running.Load() {
+		return nil
+	}
+
+	start := time.Now()
+	if err := b.server.Serve(); err != nil {
+		b.loggerFactory.Core().Error("embedded_broker_start_failed",
+			zap.Error(err),
+			zap.String("address", b.config.Address),
+			zap.String("component", "embedded_broker"),
+		)
+		return fmt.Errorf("failed to start embedded broker: %w", err)
+	}
+	b.running.Store(true)
+
+	b.loggerFactory.Application().LogApplicationEvent("embedded_broker_started", "embedded_broker",
+		zap.String("address", b.config.Address),
+		zap.Bool("auth_enabled", len(b.config.Users) > 0),
+		zap.Bool("bridge_enabled", b.bridge != nil),
+		zap.Bool("tls_enabled", b.config.TLS != nil),
+		zap.Duration("startup_duration", time.Since(start)),
+	)
+
+	if b.bridge != nil {
+		if err := b.bridge.Start(); err != nil {
+			return fmt.Errorf("failed to start upstream bridge: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close stops the bridge and shuts the broker down
+func (b *EmbeddedBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.running.Load() {
+		return nil
+	}
+
+	if b.bridge != nil {
+		b.bridge.Stop()
+	}
+
+	start := time.Now()
+	if err := b.server.Close(); err != nil {
+		return fmt.Errorf("failed to close embedded broker: %w", err)
+	}
+	b.running.Store(false)
+
+	b.loggerFactory.Application().LogApplicationEvent("embedded_broker_stopped", "embedded_broker",
+		zap.Duration("shutdown_duration", time.Since(start)),
+	)
+	return nil
+}
+
+// IsRunning returns true while the broker is serving clients
+func (b *EmbeddedBroker) IsRunning() bool {
+	return b.running.Load()
+}
+
+// Server returns the underlying broker for in-process subscriptions
+func (b *EmbeddedBroker) Server() *mochi.Server {
+	return b.server
+}
+
+// BridgeConnected reports whether the upstream bridge is connected; false when bridging is disabled
+func (b *EmbeddedBroker) BridgeConnected() bool {
+	return b.bridge != nil && b.bridge.IsConnected()
+}
+
+// Collect implements metrics.Collector
+func (b *EmbeddedBroker) Collect() []metrics.Family {
+	info := b.server.Info.Clone()
+
+	families := []metrics.Family{
+		{Name: "mqtt_embedded_broker_clients_connected", Help: "Clients connected to the embedded MQTT broker", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(info.ClientsConnected)}}},
+		{Name: "mqtt_embedded_broker_messages_received_total", Help: "Messages received by the embedded MQTT broker", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(info.MessagesReceived)}}},
+		{Name: "mqtt_embedded_broker_messages_sent_total", Help: "Messages sent by the embedded MQTT broker", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(info.MessagesSent)}}},
+	}
+	if b.bridge != nil {
+		families = append(families, b.bridge.Collect()...)
+	}
+	return families
+}
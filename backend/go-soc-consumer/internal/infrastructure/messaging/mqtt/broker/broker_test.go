@@ -0,0 +1,133 @@
+package broker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func TestNewEmbeddedBroker(t *testing.T) {
+	t.Run("should require address", func(t *testing.T) {
+		broker, err := NewEmbeddedBroker(EmbeddedBrokerConfig{}, createTestLoggerFactory(t))
+		assert.Error(t, err)
+		assert.Nil(t, broker)
+	})
+
+	t.Run("should create broker with bridge", func(t *testing.T) {
+		broker, err := NewEmbeddedBroker(EmbeddedBrokerConfig{
+			Address: "127.0.0.1:0",
+			Bridge:  &BridgeConfig{UpstreamURL: "tcp://127.0.0.1:1", Topics: []string{"#"}},
+		}, createTestLoggerFactory(t))
+		require.NoError(t, err)
+		assert.NotNil(t, broker.bridge)
+		assert.False(t, broker.IsRunning())
+		assert.False(t, broker.BridgeConnected())
+	})
+}
+
+func TestBuildLedger(t *testing.T) {
+	t.Run("should allow anonymous clients without users", func(t *testing.T) {
+		ledger := buildLedger(nil)
+		require.Len(t, ledger.Auth, 1)
+		assert.True(t, ledger.Auth[0].Allow)
+		assert.Empty(t, ledger.Users)
+	})
+
+	t.Run("should register configured users", func(t *testing.T) {
+		ledger := buildLedger(map[string]string{"gateway": "secret"})
+		assert.Empty(t, ledger.Auth)
+		assert.Equal(t, auth.RString("secret"), ledger.Users["gateway"].Password)
+	})
+}
+
+func TestInProcessConsumer(t *testing.T) {
+	loggerFactory := createTestLoggerFactory(t)
+	broker, err := NewEmbeddedBroker(EmbeddedBrokerConfig{Address: "127.0.0.1:0"}, loggerFactory)
+	require.NoError(t, err)
+
+	consumer := NewInProcessConsumer(broker, loggerFactory)
+
+	err = consumer.Subscribe(context.Background(), "devices/+/data", nil)
+	assert.Error(t, err, "subscribe should fail before the broker is started")
+
+	require.NoError(t, consumer.Start(context.Background()))
+	defer consumer.Stop(context.Background())
+	assert.True(t, consumer.IsConnected())
+
+	received := make(chan string, 1)
+	err = consumer.Subscribe(context.Background(), "devices/+/data", func(ctx context.Context, topic string, payload []byte) error {
+		received <- topic + ":" + string(payload)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, broker.Server().Publish("devices/dev-1/data", []byte("42"), false, 0))
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, "devices/dev-1/data:42", msg)
+	case <-time.After(2 * time.Second):
+		t.Fatal("message was not delivered to the in-process consumer")
+	}
+
+	_, err = consumer.CheckReadiness(context.Background())
+	assert.NoError(t, err)
+
+	require.NoError(t, consumer.Unsubscribe("devices/+/data"))
+	require.NoError(t, consumer.Stop(context.Background()))
+	assert.False(t, consumer.IsConnected())
+
+	_, err = consumer.CheckReadiness(context.Background())
+	assert.Error(t, err)
+}
+
+func TestInProcessConsumer_StopCancelsInFlightHandlers(t *testing.T) {
+	loggerFactory := createTestLoggerFactory(t)
+	broker, err := NewEmbeddedBroker(EmbeddedBrokerConfig{Address: "127.0.0.1:0"}, loggerFactory)
+	require.NoError(t, err)
+
+	consumer := NewInProcessConsumer(broker, loggerFactory)
+	require.NoError(t, consumer.Start(context.Background()))
+
+	started := make(chan struct{})
+	cancelled := make(chan error, 1)
+	err = consumer.Subscribe(context.Background(), "devices/+/data", func(ctx context.Context, topic string, payload []byte) error {
+		close(started)
+		<-ctx.Done()
+		cancelled <- ctx.Err()
+		return ctx.Err()
+	})
+	require.NoError(t, err)
+
+	go func() {
+		_ = broker.Server().Publish("devices/dev-1/data", []byte("42"), false, 0)
+	}()
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("message was not delivered to the in-process consumer")
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.NoError(t, consumer.Stop(stopCtx))
+
+	select {
+	case err := <-cancelled:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("in-flight handler was not cancelled on stop")
+	}
+}
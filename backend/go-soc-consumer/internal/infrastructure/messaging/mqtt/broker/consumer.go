@@ -0,0 +1,186 @@
+package broker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/inflight"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// consumerSubscriptionID identifies the inline subscriptions owned by the in-process consumer
+const consumerSubscriptionID = 1
+
+// InProcessConsumer implements the MessageConsumer port on top of the embedded broker.
+// Messages are delivered directly from the broker without a network round trip.
+type InProcessConsumer struct {
+	broker        *EmbeddedBroker
+	loggerFactory logger.LoggerFactory
+	inFlight      *inflight.Tracker
+
+	mu       sync.RWMutex
+	handlers map[string]eventports.MessageHandler
+}
+
+// NewInProcessConsumer creates a consumer attached to the embedded broker
+func NewInProcessConsumer(broker *EmbeddedBroker, loggerFactory logger.LoggerFactory) *InProcessConsumer {
+	return &InProcessConsumer{
+		broker:        broker,
+		loggerFactory: loggerFactory,
+		handlers:      make(map[string]eventports.MessageHandler),
+		inFlight:      inflight.NewTracker(),
+	}
+}
+
+// Start starts the embedded broker
+func (c *InProcessConsumer) Start(ctx context.Context) error {
+	return c.broker.Start()
+}
+
+// Stop removes all inline subscriptions, waits for in-flight handlers and shuts the embedded broker down
+func (c *InProcessConsumer) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	for topic := range c.handlers {
+		_ = c.broker.Server().Unsubscribe(topic, consumerSubscriptionID)
+	}
+	c.handlers = make(map[string]eventports.MessageHandler)
+	c.mu.Unlock()
+
+	if err := c.inFlight.Drain(ctx); err != nil {
+		c.loggerFactory.Core().Warn("mqtt_consumer_drain_timeout",
+			zap.Error(err),
+			zap.String("component", "mqtt_inprocess_consumer"),
+		)
+	}
+
+	return c.broker.Close()
+}
+
+// Subscribe attaches a handler to a topic filter on the embedded broker
+func (c *InProcessConsumer) Subscribe(ctx context.Context, topic string, handler eventports.MessageHandler) error {
+	if !c.broker.IsRunning() {
+		return fmt.Errorf("embedded MQTT broker is not running")
+	}
+
+	c.mu.Lock()
+	c.handlers[topic] = handler
+	c.mu.Unlock()
+
+	messageHandler := func(cl *mochi.Client, sub packets.Subscription, pk packets.Packet) {
+		msgCtx, ok := c.inFlight.Begin()
+		if !ok {
+			return
+		}
+		defer c.inFlight.Done()
+
+		start := time.Now()
+		payloadSize := len(pk.Payload)
+
+		msgCtx = eventports.ContextWithDelivery(msgCtx, eventports.Delivery{QoS: pk.FixedHeader.Qos, Retained: pk.FixedHeader.Retain})
+		err := handler(eventports.ContextWithClientIdentity(msgCtx, clientIdentity(cl)), pk.TopicName, pk.Payload)
+		processingDuration := time.Since(start)
+
+		c.loggerFactory.Messaging().LogMQTTMessage(pk.TopicName, payloadSize, processingDuration, err == nil)
+
+		if err != nil {
+			c.loggerFactory.Core().Error("mqtt_message_processing_error",
+				zap.Error(err),
+				zap.String("topic", pk.TopicName),
+				zap.Int("payload_size_bytes", payloadSize),
+				zap.Duration("processing_duration", processingDuration),
+				zap.String("component", "mqtt_inprocess_consumer"),
+			)
+		}
+	}
+
+	if err := c.broker.Server().Subscribe(topic, consumerSubscriptionID, messageHandler); err != nil {
+		c.mu.Lock()
+		delete(c.handlers, topic)
+		c.mu.Unlock()
+		return fmt.Errorf("failed to subscribe to topic %s: %w", topic, err)
+	}
+
+	c.loggerFactory.Application().LogApplicationEvent("mqtt_topic_subscribed", "mqtt_inprocess_consumer",
+		zap.String("topic", topic),
+	)
+	return nil
+}
+
+// SubscribeHandlers attaches several ordered handlers to a topic filter on the embedded broker
+func (c *InProcessConsumer) SubscribeHandlers(ctx context.Context, topic string, policy eventports.HandlerErrorPolicy, handlers ...eventports.MessageHandler) error {
+	return c.Subscribe(ctx, topic, eventports.FanOut(policy, handlers...))
+}
+
+// Unsubscribe detaches the handler from a topic filter
+func (c *InProcessConsumer) Unsubscribe(topic string) error {
+	if err := c.broker.Server().Unsubscribe(topic, consumerSubscriptionID); err != nil {
+		return fmt.Errorf("failed to unsubscribe from topic %s: %w", topic, err)
+	}
+
+	c.mu.Lock()
+	delete(c.handlers, topic)
+	c.mu.Unlock()
+
+	c.loggerFactory.Application().LogApplicationEvent("mqtt_topic_unsubscribed", "mqtt_inprocess_consumer",
+		zap.String("topic", topic),
+	)
+	return nil
+}
+
+// Publish delivers a message with QoS 1 through the inline client of the embedded broker
+func (c *InProcessConsumer) Publish(ctx context.Context, topic string, payload []byte) error {
+	if !c.broker.IsRunning() {
+		return fmt.Errorf("embedded MQTT broker is not running")
+	}
+	if err := c.broker.Server().Publish(topic, payload, false, 1); err != nil {
+		return fmt.Errorf("failed to publish to topic %s: %w", topic, err)
+	}
+	return nil
+}
+
+// IsConnected returns true while the embedded broker is running
+func (c *InProcessConsumer) IsConnected() bool {
+	return c.broker.IsRunning()
+}
+
+// CheckReadiness reports an error unless the embedded broker is running
+func (c *InProcessConsumer) CheckReadiness(ctx context.Context) (interface{}, error) {
+	details := map[string]bool{
+		"bridge_connected": c.broker.BridgeConnected(),
+	}
+	if !c.broker.IsRunning() {
+		return details, fmt.Errorf("embedded MQTT broker is not running")
+	}
+	return details, nil
+}
+
+// clientIdentity describes the client that published a message, including its TLS certificate when present
+func clientIdentity(cl *mochi.Client) *entities.ClientIdentity {
+	if cl == nil {
+		return nil
+	}
+
+	identity := &entities.ClientIdentity{
+		ClientID: cl.ID,
+		Username: string(cl.Properties.Username),
+	}
+	if host, _, err := net.SplitHostPort(cl.Net.Remote); err == nil {
+		identity.RemoteAddress = host
+	}
+	if tlsConn, ok := cl.Net.Conn.(*tls.Conn); ok {
+		if peers := tlsConn.ConnectionState().PeerCertificates; len(peers) > 0 {
+			identity.CertificateFingerprint = entities.CertificateFingerprintFromDER(peers[0].Raw)
+		}
+	}
+	return identity
+}
@@ -0,0 +1,246 @@
+package mqtt
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// BrokerStatus describes the health of a single broker in the failover list
+type BrokerStatus struct {
+	URL           string    `json:"url"`
+	Priority      int       `json:"priority"`
+	Active        bool      `json:"active"`
+	Healthy       bool      `json:"healthy"`
+	LastError     string    `json:"last_error,omitempty"`
+	LastChangedAt time.Time `json:"last_changed_at"`
+}
+
+// brokerPool tracks the ordered broker list, which broker is active and per-broker health.
+// Index 0 is the primary broker.
+type brokerPool struct {
+	mu          sync.RWMutex
+	brokers     []BrokerStatus
+	active      int
+	lastAttempt int
+	failovers   int
+	failbacks   int
+}
+
+// newBrokerPool creates a pool for the given broker URLs in failover order
+func newBrokerPool(urls []string) *brokerPool {
+	pool := &brokerPool{
+		active:      -1,
+		lastAttempt: -1,
+	}
+	for i, u := range urls {
+		pool.brokers = append(pool.brokers, BrokerStatus{URL: u, Priority: i})
+	}
+	return pool
+}
+
+// URLs returns the broker URLs in failover order
+func (p *brokerPool) URLs() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	urls := make([]string, 0, len(p.brokers))
+	for _, broker := range p.brokers {
+		urls = append(urls, broker.URL)
+	}
+	return urls
+}
+
+// Primary returns the primary broker URL
+func (p *brokerPool) Primary() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.brokers) == 0 {
+		return ""
+	}
+	return p.brokers[0].URL
+}
+
+// Active returns the URL of the broker currently connected, or empty if disconnected
+func (p *brokerPool) Active() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.active < 0 {
+		return ""
+	}
+	return p.brokers[p.active].URL
+}
+
+// OnAttempt records a connection attempt. An attempt on a different broker while
+// not connected means the previously attempted broker failed.
+func (p *brokerPool) OnAttempt(broker *url.URL) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	idx := p.indexLocked(broker)
+	if p.active < 0 && p.lastAttempt >= 0 && p.lastAttempt != idx {
+		p.setHealthLocked(p.lastAttempt, false, "connection attempt failed")
+	}
+	p.lastAttempt = idx
+}
+
+// OnConnected marks the last attempted broker as active and healthy.
+// It returns the connected broker URL and whether it is a backup broker.
+func (p *brokerPool) OnConnected() (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	idx := p.lastAttempt
+	if idx < 0 {
+		idx = 0
+	}
+	p.active = idx
+	p.setHealthLocked(idx, true, "")
+
+	if idx > 0 {
+		p.failovers++
+		return p.brokers[idx].URL, true
+	}
+	return p.brokers[idx].URL, false
+}
+
+// OnConnectionLost marks the active broker as unhealthy
+func (p *brokerPool) OnConnectionLost(err error) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.active < 0 {
+		return ""
+	}
+	lost := p.active
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	p.setHealthLocked(lost, false, msg)
+	p.active = -1
+	p.lastAttempt = -1
+	return p.brokers[lost].URL
+}
+
+// OnPrimaryProbe records the result of a primary reachability probe while on a backup broker
+func (p *brokerPool) OnPrimaryProbe(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.brokers) == 0 {
+		return
+	}
+	if err != nil {
+		p.setHealthLocked(0, false, err.Error())
+		return
+	}
+	p.setHealthLocked(0, true, "")
+}
+
+// OnFailback records a migration back to the primary broker
+func (p *brokerPool) OnFailback() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failbacks++
+	p.active = -1
+	p.lastAttempt = -1
+}
+
+// Statuses returns a snapshot of all broker statuses in failover order
+func (p *brokerPool) Statuses() []BrokerStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	statuses := make([]BrokerStatus, len(p.brokers))
+	copy(statuses, p.brokers)
+	for i := range statuses {
+		statuses[i].Active = i == p.active
+	}
+	return statuses
+}
+
+// Collect implements metrics.Collector
+func (p *brokerPool) Collect() []metrics.Family {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	up := metrics.Family{Name: "mqtt_broker_up", Help: "Whether the MQTT broker is considered healthy", Type: metrics.TypeGauge}
+	active := metrics.Family{Name: "mqtt_broker_active", Help: "Whether the MQTT broker is the one currently connected", Type: metrics.TypeGauge}
+	for i, broker := range p.brokers {
+		labels := map[string]string{"broker": broker.URL, "priority": strconv.Itoa(broker.Priority)}
+		up.Samples = append(up.Samples, metrics.Sample{Labels: labels, Value: boolToFloat(broker.Healthy)})
+		active.Samples = append(active.Samples, metrics.Sample{Labels: labels, Value: boolToFloat(i == p.active)})
+	}
+
+	return []metrics.Family{
+		up,
+		active,
+		{Name: "mqtt_broker_failovers_total", Help: "Connections established to a non-primary MQTT broker", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(p.failovers)}}},
+		{Name: "mqtt_broker_failbacks_total", Help: "Migrations back to the primary MQTT broker", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(p.failbacks)}}},
+	}
+}
+
+func (p *brokerPool) indexLocked(broker *url.URL) int {
+	if broker == nil {
+		return -1
+	}
+	for i, b := range p.brokers {
+		if parsed, err := url.Parse(b.URL); err == nil && parsed.Host == broker.Host && parsed.Scheme == broker.Scheme {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *brokerPool) setHealthLocked(idx int, healthy bool, lastError string) {
+	if idx < 0 || idx >= len(p.brokers) {
+		return
+	}
+	if p.brokers[idx].Healthy != healthy || p.brokers[idx].LastChangedAt.IsZero() {
+		p.brokers[idx].LastChangedAt = time.Now()
+	}
+	p.brokers[idx].Healthy = healthy
+	p.brokers[idx].LastError = lastError
+}
+
+// probeBroker checks that the broker accepts TCP connections
+func probeBroker(brokerURL string, timeout time.Duration) error {
+	parsed, err := url.Parse(brokerURL)
+	if err != nil {
+		return err
+	}
+
+	host := parsed.Host
+	if parsed.Port() == "" {
+		port := "1883"
+		switch parsed.Scheme {
+		case "ssl", "tls", "mqtts", "tcps":
+			port = "8883"
+		case "ws":
+			port = "80"
+		case "wss":
+			port = "443"
+		}
+		host = net.JoinHostPort(parsed.Hostname(), port)
+	}
+
+	conn, err := net.DialTimeout("tcp", host, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+package mqtt
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	parsed, err := url.Parse(raw)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestBrokerPool(t *testing.T) {
+	primary := "tcp://primary:1883"
+	backup := "tcp://backup:1883"
+
+	t.Run("should mark primary active when first attempt succeeds", func(t *testing.T) {
+		pool := newBrokerPool([]string{primary, backup})
+
+		pool.OnAttempt(mustParseURL(t, primary))
+		connected, isBackup := pool.OnConnected()
+
+		assert.Equal(t, primary, connected)
+		assert.False(t, isBackup)
+		assert.Equal(t, primary, pool.Active())
+
+		statuses := pool.Statuses()
+		require.Len(t, statuses, 2)
+		assert.True(t, statuses[0].Active)
+		assert.True(t, statuses[0].Healthy)
+		assert.False(t, statuses[1].Active)
+	})
+
+	t.Run("should fail over to backup and record primary as unhealthy", func(t *testing.T) {
+		pool := newBrokerPool([]string{primary, backup})
+
+		pool.OnAttempt(mustParseURL(t, primary))
+		pool.OnAttempt(mustParseURL(t, backup))
+		connected, isBackup := pool.OnConnected()
+
+		assert.Equal(t, backup, connected)
+		assert.True(t, isBackup)
+
+		statuses := pool.Statuses()
+		assert.False(t, statuses[0].Healthy)
+		assert.Equal(t, "connection attempt failed", statuses[0].LastError)
+		assert.True(t, statuses[1].Active)
+		assert.True(t, statuses[1].Healthy)
+	})
+
+	t.Run("should clear active broker on connection loss", func(t *testing.T) {
+		pool := newBrokerPool([]string{primary, backup})
+		pool.OnAttempt(mustParseURL(t, primary))
+		pool.OnConnected()
+
+		lost := pool.OnConnectionLost(errors.New("EOF"))
+
+		assert.Equal(t, primary, lost)
+		assert.Empty(t, pool.Active())
+		assert.Equal(t, "EOF", pool.Statuses()[0].LastError)
+	})
+
+	t.Run("should expose failover and failback counters", func(t *testing.T) {
+		pool := newBrokerPool([]string{primary, backup})
+		pool.OnAttempt(mustParseURL(t, backup))
+		pool.OnConnected()
+		pool.OnFailback()
+
+		families := pool.Collect()
+		values := make(map[string]float64)
+		for _, family := range families {
+			if len(family.Samples) == 1 {
+				values[family.Name] = family.Samples[0].Value
+			}
+		}
+		assert.Equal(t, float64(1), values["mqtt_broker_failovers_total"])
+		assert.Equal(t, float64(1), values["mqtt_broker_failbacks_total"])
+	})
+}
+
+func TestProbeBroker(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+
+	assert.NoError(t, probeBroker("tcp://"+addr, time.Second))
+
+	require.NoError(t, listener.Close())
+	assert.Error(t, probeBroker("tcp://"+addr, time.Second))
+}
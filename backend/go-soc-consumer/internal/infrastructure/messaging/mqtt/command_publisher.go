@@ -0,0 +1,45 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/mqtt/handlers"
+)
+
+// MessagePublisher publishes raw messages on the broker the server is connected to
+type MessagePublisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
+
+// CommandPublisher implements the CommandPublisher port by publishing command envelopes as JSON
+// on the command topic of the device
+type CommandPublisher struct {
+	publisher    MessagePublisher
+	legacyTopics bool
+}
+
+// NewCommandPublisher creates a command publisher on top of the MQTT consumer connection. With legacy
+// topics, commands for farm devices are published on the shared command topic too, reaching devices
+// that have not moved to their farm namespace yet.
+func NewCommandPublisher(publisher MessagePublisher, legacyTopics bool) *CommandPublisher {
+	return &CommandPublisher{publisher: publisher, legacyTopics: legacyTopics}
+}
+
+// Publish sends the envelope on the command topic of the device
+func (p *CommandPublisher) Publish(ctx context.Context, device *entities.Device, envelope *entities.CommandEnvelope) error {
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to encode command envelope: %w", err)
+	}
+	topic := handlers.CommandTopic(device)
+	if err := p.publisher.Publish(ctx, topic, payload); err != nil {
+		return err
+	}
+	if legacyTopic := handlers.CommandTopicPrefix + device.GetID(); p.legacyTopics && legacyTopic != topic {
+		return p.publisher.Publish(ctx, legacyTopic, payload)
+	}
+	return nil
+}
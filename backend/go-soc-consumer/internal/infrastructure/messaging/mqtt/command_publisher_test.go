@@ -0,0 +1,53 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+type recordingPublisher struct {
+	topic   string
+	payload []byte
+	topics  []string
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	p.topic, p.payload = topic, payload
+	p.topics = append(p.topics, topic)
+	return nil
+}
+
+func TestCommandPublisher_Publish(t *testing.T) {
+	recorder := &recordingPublisher{}
+	publisher := NewCommandPublisher(recorder, false)
+	device := &entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "farm-7"}
+	envelope := &entities.CommandEnvelope{Version: entities.CommandEnvelopeVersion, Target: device.MACAddress, Command: entities.DeviceCommandReboot, IssuedAt: 1700000000, Nonce: "abc123", Payload: json.RawMessage(`{"delay_seconds":0}`)}
+
+	require.NoError(t, publisher.Publish(context.Background(), device, envelope))
+
+	assert.Equal(t, "farms/farm-7/devices/commands/AA:BB:CC:DD:EE:FF", recorder.topic)
+	var published entities.CommandEnvelope
+	require.NoError(t, json.Unmarshal(recorder.payload, &published))
+	assert.Equal(t, *envelope, published)
+}
+
+func TestCommandPublisher_PublishLegacyTopics(t *testing.T) {
+	recorder := &recordingPublisher{}
+	publisher := NewCommandPublisher(recorder, true)
+	envelope := &entities.CommandEnvelope{Version: entities.CommandEnvelopeVersion, Command: entities.DeviceCommandReboot}
+
+	require.NoError(t, publisher.Publish(context.Background(), &entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "farm-7"}, envelope))
+	require.NoError(t, publisher.Publish(context.Background(), &entities.Device{MACAddress: "AA:BB:CC:DD:EE:01"}, envelope))
+
+	assert.Equal(t, []string{
+		"farms/farm-7/devices/commands/AA:BB:CC:DD:EE:FF",
+		"/liwaisi/iot/smart-irrigation/commands/AA:BB:CC:DD:EE:FF",
+		"/liwaisi/iot/smart-irrigation/commands/AA:BB:CC:DD:EE:01",
+	}, recorder.topics)
+}
@@ -0,0 +1,84 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+)
+
+// ForwardedMessage is the envelope a broker rule (EMQX rule engine, HiveMQ extension) republishes
+// device messages in, carrying the identity the broker authenticated the publisher with
+type ForwardedMessage struct {
+	ClientID               string          `json:"client_id"`
+	Username               string          `json:"username"`
+	CertificateFingerprint string          `json:"cert_fingerprint"`
+	PeerHost               string          `json:"peerhost"`
+	Payload                json.RawMessage `json:"payload"`
+}
+
+// ForwardedIdentityConsumer consumes device topics republished by the broker under a prefix.
+// Devices must not be allowed to publish under the prefix, otherwise they could forge the identity.
+type ForwardedIdentityConsumer struct {
+	eventports.MessageConsumer
+	prefix string
+}
+
+// NewForwardedIdentityConsumer wraps the given consumer so that topic T is read from prefix+T
+func NewForwardedIdentityConsumer(consumer eventports.MessageConsumer, prefix string) *ForwardedIdentityConsumer {
+	return &ForwardedIdentityConsumer{
+		MessageConsumer: consumer,
+		prefix:          prefix,
+	}
+}
+
+// Subscribe subscribes to the forwarded topic and hands the unwrapped payload to the handler
+// together with the publisher identity
+func (f *ForwardedIdentityConsumer) Subscribe(ctx context.Context, topic string, handler eventports.MessageHandler) error {
+	return f.MessageConsumer.Subscribe(ctx, f.prefix+topic, func(ctx context.Context, forwardedTopic string, payload []byte) error {
+		identity, original, err := unwrapForwardedMessage(payload)
+		if err != nil {
+			return err
+		}
+		return handler(eventports.ContextWithClientIdentity(ctx, identity), strings.TrimPrefix(forwardedTopic, f.prefix), original)
+	})
+}
+
+// SubscribeHandlers subscribes to the forwarded topic with several ordered handlers, each given the
+// unwrapped payload
+func (f *ForwardedIdentityConsumer) SubscribeHandlers(ctx context.Context, topic string, policy eventports.HandlerErrorPolicy, handlers ...eventports.MessageHandler) error {
+	return f.Subscribe(ctx, topic, eventports.FanOut(policy, handlers...))
+}
+
+// Unsubscribe stops consuming the forwarded topic
+func (f *ForwardedIdentityConsumer) Unsubscribe(topic string) error {
+	return f.MessageConsumer.Unsubscribe(f.prefix + topic)
+}
+
+// unwrapForwardedMessage returns the publisher identity and the original payload
+func unwrapForwardedMessage(payload []byte) (*entities.ClientIdentity, []byte, error) {
+	var message ForwardedMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return nil, nil, fmt.Errorf("invalid forwarded message: %w", err)
+	}
+	if len(message.Payload) == 0 {
+		return nil, nil, fmt.Errorf("invalid forwarded message: payload is required")
+	}
+
+	identity := &entities.ClientIdentity{
+		ClientID:      message.ClientID,
+		Username:      message.Username,
+		RemoteAddress: message.PeerHost,
+	}
+	if message.CertificateFingerprint != "" {
+		fingerprint, err := entities.NormalizeCertificateFingerprint(message.CertificateFingerprint)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid forwarded message: %w", err)
+		}
+		identity.CertificateFingerprint = fingerprint
+	}
+	return identity, message.Payload, nil
+}
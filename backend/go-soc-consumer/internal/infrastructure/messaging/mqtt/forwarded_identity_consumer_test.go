@@ -0,0 +1,83 @@
+package mqtt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestForwardedIdentityConsumer(t *testing.T) {
+	ctx := context.Background()
+	inner := mocks.NewMockMessageConsumer(t)
+	consumer := NewForwardedIdentityConsumer(inner, "forwarded")
+
+	var forwardedHandler eventports.MessageHandler
+	inner.EXPECT().Subscribe(ctx, "forwarded/liwaisi/sensors", mock.Anything).
+		Run(func(ctx context.Context, topic string, handler eventports.MessageHandler) {
+			forwardedHandler = handler
+		}).Return(nil).Once()
+
+	var receivedTopic string
+	var receivedPayload []byte
+	var receivedIdentity *entities.ClientIdentity
+	err := consumer.Subscribe(ctx, "/liwaisi/sensors", func(ctx context.Context, topic string, payload []byte) error {
+		receivedTopic = topic
+		receivedPayload = payload
+		receivedIdentity = eventports.ClientIdentityFromContext(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+
+	t.Run("should unwrap payload and identity", func(t *testing.T) {
+		message := `{"client_id":"esp32-1","username":"device","peerhost":"192.168.1.20","cert_fingerprint":"` +
+			"5D41402ABC4B2A76B9719D911017C5925D41402ABC4B2A76B9719D911017C592" + `","payload":{"temperature":21.5}}`
+
+		require.NoError(t, forwardedHandler(ctx, "forwarded/liwaisi/sensors", []byte(message)))
+		assert.Equal(t, "/liwaisi/sensors", receivedTopic)
+		assert.JSONEq(t, `{"temperature":21.5}`, string(receivedPayload))
+		require.NotNil(t, receivedIdentity)
+		assert.Equal(t, "esp32-1", receivedIdentity.ClientID)
+		assert.Equal(t, "192.168.1.20", receivedIdentity.RemoteAddress)
+		assert.Equal(t, "5d41402abc4b2a76b9719d911017c5925d41402abc4b2a76b9719d911017c592", receivedIdentity.CertificateFingerprint)
+	})
+
+	t.Run("should reject messages that are not forwarded envelopes", func(t *testing.T) {
+		assert.Error(t, forwardedHandler(ctx, "forwarded/liwaisi/sensors", []byte(`{"temperature":21.5}`)))
+		assert.Error(t, forwardedHandler(ctx, "forwarded/liwaisi/sensors", []byte(`not json`)))
+		assert.Error(t, forwardedHandler(ctx, "forwarded/liwaisi/sensors", []byte(`{"cert_fingerprint":"abc","payload":{}}`)))
+	})
+
+	inner.EXPECT().Unsubscribe("forwarded/liwaisi/sensors").Return(nil).Once()
+	require.NoError(t, consumer.Unsubscribe("/liwaisi/sensors"))
+}
+
+func TestForwardedIdentityConsumer_SubscribeHandlers(t *testing.T) {
+	ctx := context.Background()
+	inner := mocks.NewMockMessageConsumer(t)
+	consumer := NewForwardedIdentityConsumer(inner, "forwarded")
+
+	var forwardedHandler eventports.MessageHandler
+	inner.EXPECT().Subscribe(ctx, "forwarded/liwaisi/sensors", mock.Anything).
+		Run(func(ctx context.Context, topic string, handler eventports.MessageHandler) {
+			forwardedHandler = handler
+		}).Return(nil).Once()
+
+	var payloads []string
+	record := func(ctx context.Context, topic string, payload []byte) error {
+		payloads = append(payloads, string(payload))
+		return nil
+	}
+	require.NoError(t, consumer.SubscribeHandlers(ctx, "/liwaisi/sensors", eventports.StopOnError, record, record))
+
+	message := `{"client_id":"esp32-1","cert_fingerprint":"` +
+		"5D41402ABC4B2A76B9719D911017C5925D41402ABC4B2A76B9719D911017C592" + `","payload":{"temperature":21.5}}`
+	require.NoError(t, forwardedHandler(ctx, "forwarded/liwaisi/sensors", []byte(message)))
+	assert.Equal(t, []string{`{"temperature":21.5}`, `{"temperature":21.5}`}, payloads, "every handler should get the unwrapped payload")
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	brokerstats "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/broker_stats"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// BrokerSysTopic is the filter of the statistics brokers publish about themselves
+const BrokerSysTopic = "$SYS/#"
+
+// sysPrefix starts every $SYS topic
+const sysPrefix = "$SYS/"
+
+// sysStats maps the $SYS topics of Mosquitto, and of every node of EMQX under brokers/{node}/, to statistics
+var sysStats = map[string]string{
+	"broker/clients/connected":        entities.BrokerStatConnectedClients,
+	"broker/publish/messages/dropped": entities.BrokerStatDroppedMessages,
+	"broker/store/messages/count":     entities.BrokerStatQueuedMessages,
+	"broker/messages/inflight":        entities.BrokerStatInflightMessages,
+	"broker/messages/received":        entities.BrokerStatMessagesReceived,
+	"broker/messages/sent":            entities.BrokerStatMessagesSent,
+	"broker/subscriptions/count":      entities.BrokerStatSubscriptions,
+	"stats/connections/count":         entities.BrokerStatConnectedClients,
+	"metrics/messages/dropped":        entities.BrokerStatDroppedMessages,
+	"metrics/messages/received":       entities.BrokerStatMessagesReceived,
+	"metrics/messages/sent":           entities.BrokerStatMessagesSent,
+	"stats/subscriptions/count":       entities.BrokerStatSubscriptions,
+}
+
+// BrokerStatsHandler handles the $SYS messages of the broker
+type BrokerStatsHandler struct {
+	coreLogger logger.CoreLogger
+	useCase    brokerstats.BrokerStatsUseCase
+}
+
+// NewBrokerStatsHandler creates a broker stats handler using LoggerFactory
+func NewBrokerStatsHandler(loggerFactory logger.LoggerFactory, useCase brokerstats.BrokerStatsUseCase) *BrokerStatsHandler {
+	return &BrokerStatsHandler{
+		coreLogger: loggerFactory.Core(),
+		useCase:    useCase,
+	}
+}
+
+// HandleMessage records the statistic of a $SYS topic; topics of other statistics are ignored
+func (h *BrokerStatsHandler) HandleMessage(ctx context.Context, topic string, payload []byte) error {
+	node, name, ok := ResolveSysTopic(topic)
+	if !ok {
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(payload)), 64)
+	if err != nil {
+		h.coreLogger.Warn("broker_stat_invalid",
+			zap.String("topic", topic),
+			zap.String("payload", string(payload)),
+			zap.String("component", "broker_stats_handler"),
+		)
+		return fmt.Errorf("invalid broker statistic on %s: %w", topic, err)
+	}
+
+	return h.useCase.Record(&entities.BrokerStat{Node: node, Name: name, Value: value, ReceivedAt: time.Now()})
+}
+
+// ResolveSysTopic returns the node and the statistic of a $SYS topic
+func ResolveSysTopic(topic string) (string, string, bool) {
+	path, found := strings.CutPrefix(topic, sysPrefix)
+	if !found {
+		return "", "", false
+	}
+
+	node := ""
+	if clustered, found := strings.CutPrefix(path, "brokers/"); found {
+		node, path, found = strings.Cut(clustered, "/")
+		if !found || node == "" {
+			return "", "", false
+		}
+	}
+
+	name, ok := sysStats[path]
+	return node, name, ok
+}
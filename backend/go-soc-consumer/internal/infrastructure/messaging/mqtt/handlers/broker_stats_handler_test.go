@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func TestResolveSysTopic(t *testing.T) {
+	tests := []struct {
+		topic string
+		node  string
+		name  string
+		ok    bool
+	}{
+		{"$SYS/broker/clients/connected", "", entities.BrokerStatConnectedClients, true},
+		{"$SYS/broker/publish/messages/dropped", "", entities.BrokerStatDroppedMessages, true},
+		{"$SYS/broker/store/messages/count", "", entities.BrokerStatQueuedMessages, true},
+		{"$SYS/brokers/emqx@10.0.0.1/stats/connections/count", "emqx@10.0.0.1", entities.BrokerStatConnectedClients, true},
+		{"$SYS/brokers/emqx@10.0.0.1/metrics/messages/dropped", "emqx@10.0.0.1", entities.BrokerStatDroppedMessages, true},
+		{"$SYS/broker/uptime", "", "", false},
+		{"$SYS/brokers//stats/connections/count", "", "", false},
+		{"liwaisi/iot/smart-irrigation/device/registration", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			node, name, ok := ResolveSysTopic(tt.topic)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.node, node)
+			assert.Equal(t, tt.name, name)
+		})
+	}
+}
+
+func TestBrokerStatsHandler_HandleMessage(t *testing.T) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("records the statistic", func(t *testing.T) {
+		useCase := mocks.NewMockBrokerStatsUseCase(t)
+		handler := NewBrokerStatsHandler(loggerFactory, useCase)
+		useCase.EXPECT().Record(mock.Anything).RunAndReturn(func(stat *entities.BrokerStat) error {
+			assert.Equal(t, "emqx@10.0.0.1", stat.Node)
+			assert.Equal(t, entities.BrokerStatDroppedMessages, stat.Name)
+			assert.Equal(t, float64(42), stat.Value)
+			assert.False(t, stat.ReceivedAt.IsZero())
+			return nil
+		}).Once()
+
+		assert.NoError(t, handler.HandleMessage(ctx, "$SYS/brokers/emqx@10.0.0.1/metrics/messages/dropped", []byte("42\n")))
+	})
+
+	t.Run("ignores other statistics", func(t *testing.T) {
+		handler := NewBrokerStatsHandler(loggerFactory, mocks.NewMockBrokerStatsUseCase(t))
+
+		assert.NoError(t, handler.HandleMessage(ctx, "$SYS/broker/version", []byte("mosquitto version 2.0.18")))
+	})
+
+	t.Run("rejects non-numeric values", func(t *testing.T) {
+		handler := NewBrokerStatsHandler(loggerFactory, mocks.NewMockBrokerStatsUseCase(t))
+
+		assert.Error(t, handler.HandleMessage(ctx, "$SYS/broker/clients/connected", []byte("many")))
+	})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+	devicecommands "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_commands"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// CommandAckHandler handles MQTT messages acknowledging commands sent to devices
+type CommandAckHandler struct {
+	coreLogger logger.CoreLogger
+	useCase    devicecommands.DeviceCommandsUseCase
+}
+
+// NewCommandAckHandler creates a command acknowledgement handler using LoggerFactory
+func NewCommandAckHandler(loggerFactory logger.LoggerFactory, useCase devicecommands.DeviceCommandsUseCase) *CommandAckHandler {
+	return &CommandAckHandler{
+		coreLogger: loggerFactory.Core(),
+		useCase:    useCase,
+	}
+}
+
+// HandleMessage processes acknowledgements on the shared or farm namespaced topic
+func (h *CommandAckHandler) HandleMessage(ctx context.Context, topic string, payload []byte) error {
+	if _, ok := ResolveFarmTopic(FarmCommandAckTopic, topic); topic != CommandAckTopic && !ok {
+		h.coreLogger.Warn("unknown_command_ack_topic",
+			zap.String("topic", topic),
+			zap.String("component", "command_ack_handler"),
+		)
+		return fmt.Errorf("unknown command acknowledgement topic: %s", topic)
+	}
+
+	var msgData dtos.CommandAckMessage
+	if err := json.Unmarshal(payload, &msgData); err != nil {
+		h.logProcessingError(topic, payload, err)
+		return fmt.Errorf("failed to unmarshal command acknowledgement: %w", err)
+	}
+	if msgData.EventType != "command_ack" {
+		err := fmt.Errorf("invalid event type for command acknowledgement: %s", msgData.EventType)
+		h.logProcessingError(topic, payload, err)
+		return err
+	}
+	if msgData.ID == "" || msgData.MacAddress == "" {
+		err := fmt.Errorf("command acknowledgement requires id and mac_address")
+		h.logProcessingError(topic, payload, err)
+		return err
+	}
+	if msgData.Status != dtos.CommandAckStatusOK && msgData.Status != dtos.CommandAckStatusError {
+		err := fmt.Errorf("invalid command acknowledgement status: %s", msgData.Status)
+		h.logProcessingError(topic, payload, err)
+		return err
+	}
+
+	ack := &entities.DeviceCommandAck{
+		CommandID:  msgData.ID,
+		MACAddress: msgData.MacAddress,
+		Success:    msgData.Status == dtos.CommandAckStatusOK,
+		Message:    msgData.Message,
+		ReceivedAt: time.Now(),
+	}
+	if err := h.useCase.Acknowledge(ctx, ack); err != nil {
+		return fmt.Errorf("failed to acknowledge command: %w", err)
+	}
+	return nil
+}
+
+// logProcessingError logs a message that could not be turned into an acknowledgement
+func (h *CommandAckHandler) logProcessingError(topic string, payload []byte, err error) {
+	h.coreLogger.Error("command_ack_processing_error",
+		zap.String("topic", topic),
+		zap.String("payload", string(payload)),
+		zap.Error(err),
+		zap.String("component", "command_ack_handler"),
+	)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func TestCommandAckHandler_HandleMessage(t *testing.T) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("acknowledges the command", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceCommandsUseCase(t)
+		handler := NewCommandAckHandler(loggerFactory, useCase)
+		useCase.EXPECT().Acknowledge(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, ack *entities.DeviceCommandAck) error {
+			assert.Equal(t, "abc123", ack.CommandID)
+			assert.Equal(t, "aa:bb:cc:dd:ee:ff", ack.MACAddress)
+			assert.False(t, ack.Success)
+			assert.Equal(t, "irrigation running", ack.Message)
+			assert.False(t, ack.ReceivedAt.IsZero())
+			return nil
+		}).Once()
+
+		payload := `{"event_type":"command_ack","id":"abc123","mac_address":"aa:bb:cc:dd:ee:ff","status":"error","message":"irrigation running"}`
+		assert.NoError(t, handler.HandleMessage(ctx, CommandAckTopic, []byte(payload)))
+	})
+
+	t.Run("accepts farm topics", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceCommandsUseCase(t)
+		handler := NewCommandAckHandler(loggerFactory, useCase)
+		useCase.EXPECT().Acknowledge(mock.Anything, mock.Anything).Return(domainerrors.ErrDeviceCommandNotFound).Once()
+
+		payload := `{"event_type":"command_ack","id":"abc123","mac_address":"AA:BB:CC:DD:EE:FF","status":"ok"}`
+		assert.ErrorIs(t, handler.HandleMessage(ctx, FarmTopic(FarmCommandAckTopic, "farm-7"), []byte(payload)), domainerrors.ErrDeviceCommandNotFound)
+	})
+
+	t.Run("rejects malformed acknowledgements", func(t *testing.T) {
+		handler := NewCommandAckHandler(loggerFactory, mocks.NewMockDeviceCommandsUseCase(t))
+
+		assert.Error(t, handler.HandleMessage(ctx, CommandAckTopic, []byte(`{"event_type":"command_ack","id":"abc123","mac_address":"AA:BB:CC:DD:EE:FF","status":"done"}`)))
+		assert.Error(t, handler.HandleMessage(ctx, CommandAckTopic, []byte(`{"event_type":"command_ack","mac_address":"AA:BB:CC:DD:EE:FF","status":"ok"}`)))
+		assert.Error(t, handler.HandleMessage(ctx, CommandAckTopic, []byte(`{"event_type":"measurement"}`)))
+		assert.Error(t, handler.HandleMessage(ctx, MeasurementTopic, []byte(`{}`)))
+	})
+}
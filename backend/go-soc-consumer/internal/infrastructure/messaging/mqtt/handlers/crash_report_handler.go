@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+	crashreports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/crash_reports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// CrashReportHandler handles MQTT messages reporting firmware crashes
+type CrashReportHandler struct {
+	coreLogger logger.CoreLogger
+	useCase    crashreports.CrashReportsUseCase
+}
+
+// NewCrashReportHandler creates a crash report handler using LoggerFactory
+func NewCrashReportHandler(loggerFactory logger.LoggerFactory, useCase crashreports.CrashReportsUseCase) *CrashReportHandler {
+	return &CrashReportHandler{
+		coreLogger: loggerFactory.Core(),
+		useCase:    useCase,
+	}
+}
+
+// HandleMessage processes crash reports on the shared or farm namespaced topic
+func (h *CrashReportHandler) HandleMessage(ctx context.Context, topic string, payload []byte) error {
+	if farmID, ok := ResolveFarmTopic(FarmCrashReportTopic, topic); ok {
+		ctx = eventports.ContextWithFarmID(ctx, farmID)
+	} else if topic != CrashReportTopic {
+		h.coreLogger.Warn("unknown_crash_report_topic",
+			zap.String("topic", topic),
+			zap.String("component", "crash_report_handler"),
+		)
+		return fmt.Errorf("unknown crash report topic: %s", topic)
+	}
+
+	var msgData dtos.CrashReportMessage
+	if err := json.Unmarshal(payload, &msgData); err != nil {
+		h.logProcessingError(topic, payload, err)
+		return fmt.Errorf("failed to unmarshal crash report: %w", err)
+	}
+	if msgData.EventType != "crash_report" {
+		err := fmt.Errorf("invalid event type for crash report: %s", msgData.EventType)
+		h.logProcessingError(topic, payload, err)
+		return err
+	}
+
+	report, err := entities.NewCrashReport(msgData.MacAddress, msgData.FirmwareVersion, msgData.Reason, msgData.Backtrace, msgData.UptimeSeconds, time.Now())
+	if err != nil {
+		h.logProcessingError(topic, payload, err)
+		return fmt.Errorf("invalid crash report: %w", err)
+	}
+	if err := h.useCase.Ingest(ctx, report); err != nil {
+		return fmt.Errorf("failed to ingest crash report: %w", err)
+	}
+	return nil
+}
+
+// logProcessingError logs a message that could not be turned into a crash report
+func (h *CrashReportHandler) logProcessingError(topic string, payload []byte, err error) {
+	h.coreLogger.Error("crash_report_processing_error",
+		zap.String("topic", topic),
+		zap.String("payload", string(payload)),
+		zap.Error(err),
+		zap.String("component", "crash_report_handler"),
+	)
+}
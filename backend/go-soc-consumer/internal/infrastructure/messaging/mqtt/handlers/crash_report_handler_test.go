@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func TestCrashReportHandler_HandleMessage(t *testing.T) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("ingests the crash report", func(t *testing.T) {
+		useCase := mocks.NewMockCrashReportsUseCase(t)
+		handler := NewCrashReportHandler(loggerFactory, useCase)
+		useCase.EXPECT().Ingest(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, report *entities.CrashReport) error {
+			assert.Equal(t, "AA:BB:CC:DD:EE:FF", report.MACAddress)
+			assert.Equal(t, "1.4.2", report.FirmwareVersion)
+			assert.Equal(t, "LoadProhibited", report.Reason)
+			assert.Equal(t, []string{"0x400d1234", "0x400d5678"}, report.Backtrace)
+			assert.Equal(t, int64(3600), report.UptimeSeconds)
+			assert.NotEmpty(t, report.Signature)
+			assert.Empty(t, eventports.FarmIDFromContext(ctx))
+			return nil
+		}).Once()
+
+		payload := `{"event_type":"crash_report","mac_address":"aa:bb:cc:dd:ee:ff","firmware_version":"1.4.2","reason":"LoadProhibited","backtrace":["0x400D1234","0x400d5678"],"uptime_seconds":3600}`
+		assert.NoError(t, handler.HandleMessage(ctx, CrashReportTopic, []byte(payload)))
+	})
+
+	t.Run("scopes farm topics to their farm", func(t *testing.T) {
+		useCase := mocks.NewMockCrashReportsUseCase(t)
+		handler := NewCrashReportHandler(loggerFactory, useCase)
+		useCase.EXPECT().Ingest(mock.MatchedBy(func(ctx context.Context) bool {
+			return eventports.FarmIDFromContext(ctx) == "farm-7"
+		}), mock.Anything).Return(domainerrors.ErrDeviceFarmMismatch).Once()
+
+		payload := `{"event_type":"crash_report","mac_address":"AA:BB:CC:DD:EE:FF","firmware_version":"1.4.2","reason":"TaskWDT"}`
+		assert.ErrorIs(t, handler.HandleMessage(ctx, FarmTopic(FarmCrashReportTopic, "farm-7"), []byte(payload)), domainerrors.ErrDeviceFarmMismatch)
+	})
+
+	t.Run("rejects malformed crash reports", func(t *testing.T) {
+		handler := NewCrashReportHandler(loggerFactory, mocks.NewMockCrashReportsUseCase(t))
+
+		assert.Error(t, handler.HandleMessage(ctx, CrashReportTopic, []byte(`{"event_type":"crash_report","mac_address":"AA:BB:CC:DD:EE:FF","reason":"TaskWDT"}`)))
+		assert.Error(t, handler.HandleMessage(ctx, CrashReportTopic, []byte(`{"event_type":"measurement"}`)))
+		assert.Error(t, handler.HandleMessage(ctx, CrashReportTopic, []byte(`not json`)))
+		assert.Error(t, handler.HandleMessage(ctx, MeasurementTopic, []byte(`{}`)))
+	})
+}
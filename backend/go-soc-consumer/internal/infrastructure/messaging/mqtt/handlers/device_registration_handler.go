@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
 	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
@@ -30,15 +32,18 @@ func NewDeviceRegistrationHandler(loggerFactory logger.LoggerFactory, useCase de
 func (h *DeviceRegistrationHandler) HandleMessage(ctx context.Context, topic string, payload []byte) error {
 	switch topic {
 	case "/liwaisi/iot/smart-irrigation/device/registration":
-		return h.processDeviceRegistration(ctx, payload)
+		return h.processDeviceRegistration(ctx, topic, payload)
 	default:
+		if farmID, ok := ResolveFarmTopic(FarmDeviceRegistrationTopic, topic); ok {
+			return h.processDeviceRegistration(eventports.ContextWithFarmID(ctx, farmID), topic, payload)
+		}
 		h.coreLogger.Error("unknown_topic", zap.String("topic", topic), zap.String("component", "device_registration_handler"))
 		return fmt.Errorf("unknown topic: %s", topic)
 	}
 }
 
 // processDeviceRegistration processes device registration messages
-func (h *DeviceRegistrationHandler) processDeviceRegistration(ctx context.Context, payload []byte) error {
+func (h *DeviceRegistrationHandler) processDeviceRegistration(ctx context.Context, topic string, payload []byte) error {
 	h.coreLogger.Info("device_registration_message_received", zap.String("topic", "/liwaisi/iot/smart-irrigation/device/registration"), zap.String("component", "device_registration_handler"))
 	// Parse JSON payload
 	var msgData dtos.DeviceRegistrationMessage
@@ -65,6 +70,28 @@ func (h *DeviceRegistrationHandler) processDeviceRegistration(ctx context.Contex
 		h.coreLogger.Error("failed_to_create_device_registration_message", zap.String("topic", "/liwaisi/iot/smart-irrigation/device/registration"), zap.String("component", "device_registration_handler"), zap.Error(err))
 		return fmt.Errorf("failed to create device registration message: %w", err)
 	}
+	deviceRegMsg.FarmID = eventports.FarmIDFromContext(ctx)
+	deviceRegMsg.FirmwareVersion = strings.TrimSpace(msgData.FirmwareVersion)
+	deviceRegMsg.HardwareVersion = strings.TrimSpace(msgData.HardwareVersion)
+	deviceRegMsg.ProvisioningToken = strings.TrimSpace(msgData.ProvisioningToken)
+	if receivedAt, ok := eventports.ReplayFromContext(ctx); ok {
+		deviceRegMsg.ReceivedAt = receivedAt
+	}
+	if delivery, ok := eventports.DeliveryFromContext(ctx); ok {
+		var clientID string
+		if identity := eventports.ClientIdentityFromContext(ctx); identity != nil {
+			clientID = identity.ClientID
+		}
+		deviceRegMsg.Broker = entities.NewBrokerMetadata(topic, delivery.QoS, delivery.Retained, clientID)
+		h.coreLogger.Debug("device_registration_delivery",
+			zap.String("mac_address", deviceRegMsg.MACAddress),
+			zap.String("topic", topic),
+			zap.Uint8("qos", delivery.QoS),
+			zap.Bool("retained", delivery.Retained),
+			zap.String("client_id", clientID),
+			zap.String("component", "device_registration_handler"),
+		)
+	}
 
 	// Process the message using the use case
 	if err := h.useCase.RegisterDevice(ctx, deviceRegMsg); err != nil {
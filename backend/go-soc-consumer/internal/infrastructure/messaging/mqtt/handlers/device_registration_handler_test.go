@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
@@ -161,13 +162,47 @@ func TestDeviceRegistrationHandler_processDeviceRegistration_ValidPayload(t *tes
 			require.NoError(t, err, "Failed to marshal test payload")
 
 			ctx := context.Background()
-			err = handler.processDeviceRegistration(ctx, payload)
+			err = handler.processDeviceRegistration(ctx, DeviceRegistrationTopic, payload)
 
 			assert.NoError(t, err, "processDeviceRegistration() unexpected error")
 		})
 	}
 }
 
+func TestDeviceRegistrationHandler_processDeviceRegistration_Versions(t *testing.T) {
+	mockUseCase := mocks.NewMockDeviceRegistrationUseCase(t)
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	handler := NewDeviceRegistrationHandler(loggerFactory, mockUseCase)
+
+	mockUseCase.EXPECT().RegisterDevice(mock.Anything, mock.MatchedBy(func(msg *entities.DeviceRegistrationMessage) bool {
+		return msg.FirmwareVersion == "1.5.0" && msg.HardwareVersion == "rev-b"
+	})).Return(nil).Once()
+
+	payload := `{"event_type":"register","mac_address":"AA:BB:CC:DD:EE:FF","device_name":"Probe","ip_address":"192.168.1.100","location_description":"Field","firmware_version":" 1.5.0","hardware_version":"rev-b"}`
+	assert.NoError(t, handler.processDeviceRegistration(context.Background(), DeviceRegistrationTopic, []byte(payload)))
+}
+
+func TestDeviceRegistrationHandler_processDeviceRegistration_BrokerMetadata(t *testing.T) {
+	mockUseCase := mocks.NewMockDeviceRegistrationUseCase(t)
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	handler := NewDeviceRegistrationHandler(loggerFactory, mockUseCase)
+	payload := []byte(`{"event_type":"register","mac_address":"AA:BB:CC:DD:EE:FF","device_name":"Probe","ip_address":"192.168.1.100","location_description":"Field"}`)
+
+	ctx := eventports.ContextWithDelivery(context.Background(), eventports.Delivery{QoS: 1, Retained: true})
+	ctx = eventports.ContextWithClientIdentity(ctx, &entities.ClientIdentity{ClientID: "probe-7"})
+	mockUseCase.EXPECT().RegisterDevice(mock.Anything, mock.MatchedBy(func(msg *entities.DeviceRegistrationMessage) bool {
+		return msg.Broker != nil && *msg.Broker == entities.BrokerMetadata{Topic: "farms/farm-7/devices/registration", QoS: 1, Retained: true, ClientID: "probe-7"}
+	})).Return(nil).Once()
+	require.NoError(t, handler.HandleMessage(ctx, "farms/farm-7/devices/registration", payload))
+
+	mockUseCase.EXPECT().RegisterDevice(mock.Anything, mock.MatchedBy(func(msg *entities.DeviceRegistrationMessage) bool {
+		return msg.Broker == nil
+	})).Return(nil).Once()
+	require.NoError(t, handler.HandleMessage(context.Background(), DeviceRegistrationTopic, payload), "messages without a delivery carry no broker metadata")
+}
+
 func TestDeviceRegistrationHandler_processDeviceRegistration_MalformedJSON(t *testing.T) {
 	// Create a mock use case for testing
 	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
@@ -201,7 +236,7 @@ func TestDeviceRegistrationHandler_processDeviceRegistration_MalformedJSON(t *te
 	for _, tt := range malformedPayloads {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
-			err := handler.processDeviceRegistration(ctx, tt.payload)
+			err := handler.processDeviceRegistration(ctx, DeviceRegistrationTopic, tt.payload)
 
 			assert.Error(t, err, "processDeviceRegistration() expected error for malformed JSON but got none")
 		})
@@ -242,7 +277,7 @@ func TestDeviceRegistrationHandler_processDeviceRegistration_InvalidEventType(t
 			require.NoError(t, err, "Failed to marshal test payload")
 
 			ctx := context.Background()
-			err = handler.processDeviceRegistration(ctx, payloadBytes)
+			err = handler.processDeviceRegistration(ctx, DeviceRegistrationTopic, payloadBytes)
 
 			require.Error(t, err, "processDeviceRegistration() expected error for invalid event type but got none")
 
@@ -358,7 +393,7 @@ func TestDeviceRegistrationHandler_processDeviceRegistration_InvalidDeviceData(t
 			require.NoError(t, err, "Failed to marshal test payload")
 
 			ctx := context.Background()
-			err = handler.processDeviceRegistration(ctx, payloadBytes)
+			err = handler.processDeviceRegistration(ctx, DeviceRegistrationTopic, payloadBytes)
 
 			assert.Error(t, err, "processDeviceRegistration() expected error for invalid device data but got none")
 		})
@@ -387,7 +422,7 @@ func TestDeviceRegistrationHandler_processDeviceRegistration_UseCaseError(t *tes
 	require.NoError(t, err, "Failed to marshal test payload")
 
 	ctx := context.Background()
-	err = handler.processDeviceRegistration(ctx, payloadBytes)
+	err = handler.processDeviceRegistration(ctx, DeviceRegistrationTopic, payloadBytes)
 
 	require.Error(t, err, "processDeviceRegistration() expected error from use case but got none")
 	assert.Equal(t, "failed to register device: use case processing failed", err.Error(), "processDeviceRegistration() error message mismatch")
@@ -461,3 +496,50 @@ func TestDeviceRegistrationHandler_RealUseCaseIntegration(t *testing.T) {
 	err = handler.HandleMessage(context.Background(), "/liwaisi/iot/smart-irrigation/device/registration", payloadBytes)
 	require.NoError(t, err, "HandleMessage() returned error")
 }
+
+func TestDeviceRegistrationHandler_HandleMessage_FarmTopic(t *testing.T) {
+	mockUseCase := mocks.NewMockDeviceRegistrationUseCase(t)
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	handler := NewDeviceRegistrationHandler(loggerFactory, mockUseCase)
+
+	payload := []byte(`{"event_type":"register","mac_address":"AA:BB:CC:DD:EE:FF","device_name":"Test Device","ip_address":"192.168.1.100","location_description":"Test Location"}`)
+
+	mockUseCase.EXPECT().RegisterDevice(mock.MatchedBy(func(ctx context.Context) bool {
+		return eventports.FarmIDFromContext(ctx) == "farm-7"
+	}), mock.MatchedBy(func(msg *entities.DeviceRegistrationMessage) bool {
+		return msg.FarmID == "farm-7"
+	})).Return(nil).Once()
+
+	require.NoError(t, handler.HandleMessage(context.Background(), "farms/farm-7/devices/registration", payload))
+	assert.Error(t, handler.HandleMessage(context.Background(), "farms/+/devices/registration", payload), "wildcard is not a farm")
+}
+
+// newBenchmarkLoggerFactory returns a logger factory that only logs errors, so benchmarks measure
+// the message path rather than the console
+func newBenchmarkLoggerFactory(b *testing.B) logger.LoggerFactory {
+	loggerFactory, err := logger.NewLoggerFactory(logger.LoggerConfig{Level: "error", Format: "json", Environment: "production"})
+	require.NoError(b, err)
+	return loggerFactory
+}
+
+// discardingDeviceRegistrationUseCase accepts registrations without storing them
+type discardingDeviceRegistrationUseCase struct{}
+
+func (discardingDeviceRegistrationUseCase) RegisterDevice(context.Context, *entities.DeviceRegistrationMessage) error {
+	return nil
+}
+
+func BenchmarkDeviceRegistrationHandler_HandleMessage(b *testing.B) {
+	handler := NewDeviceRegistrationHandler(newBenchmarkLoggerFactory(b), discardingDeviceRegistrationUseCase{})
+	payload := []byte(`{"event_type":"register","mac_address":"02:4C:54:00:00:01","device_name":"loadtest-000001","ip_address":"10.0.0.1","location_description":"Load test","firmware_version":"1.4.2"}`)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := handler.HandleMessage(ctx, DeviceRegistrationTopic, payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// Farm namespaced topic templates used on multi-tenant brokers
+const (
+	FarmIDPlaceholder           = "{farmID}"
+	FarmDeviceRegistrationTopic = "farms/{farmID}/devices/registration"
+	FarmSensorDataTopic         = "farms/{farmID}/devices/sensors/temperature-and-humidity"
+	FarmMeasurementTopic        = "farms/{farmID}/devices/sensors/measurements"
+	FarmCommandTopicPrefix      = "farms/{farmID}/devices/commands/"
+	FarmCommandAckTopic         = "farms/{farmID}/devices/command-ack"
+	FarmCrashReportTopic        = "farms/{farmID}/devices/crash-report"
+	FarmWifiDiagnosticsTopic    = "farms/{farmID}/devices/wifi-diagnostics"
+)
+
+// FarmTopic fills a farm topic template with the given farm
+func FarmTopic(template, farmID string) string {
+	return strings.Replace(template, FarmIDPlaceholder, farmID, 1)
+}
+
+// CommandTopic returns the topic a device listens on for commands, in its farm namespace when it has one
+func CommandTopic(device *entities.Device) string {
+	if device.FarmID != "" {
+		return FarmTopic(FarmCommandTopicPrefix, device.FarmID) + device.GetID()
+	}
+	return CommandTopicPrefix + device.GetID()
+}
+
+// FarmTopicFilter returns the subscription filter matching a farm topic template for every farm
+func FarmTopicFilter(template string) string {
+	return FarmTopic(template, "+")
+}
+
+// ResolveFarmTopic returns the farm of a topic published under a farm topic template
+func ResolveFarmTopic(template, topic string) (string, bool) {
+	prefix, suffix, found := strings.Cut(template, FarmIDPlaceholder)
+	if !found || !strings.HasPrefix(topic, prefix) || !strings.HasSuffix(topic, suffix) || len(topic) < len(prefix)+len(suffix) {
+		return "", false
+	}
+
+	farmID := topic[len(prefix) : len(topic)-len(suffix)]
+	if entities.ValidateFarmID(farmID) != nil {
+		return "", false
+	}
+	return farmID, true
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+func TestFarmTopics(t *testing.T) {
+	assert.Equal(t, "farms/farm-7/devices/registration", FarmTopic(FarmDeviceRegistrationTopic, "farm-7"))
+	assert.Equal(t, "farms/+/devices/sensors/temperature-and-humidity", FarmTopicFilter(FarmSensorDataTopic))
+
+	device := &entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF"}
+	assert.Equal(t, "/liwaisi/iot/smart-irrigation/commands/AA:BB:CC:DD:EE:FF", CommandTopic(device))
+	device.FarmID = "farm-7"
+	assert.Equal(t, "farms/farm-7/devices/commands/AA:BB:CC:DD:EE:FF", CommandTopic(device))
+
+	tests := []struct {
+		topic  string
+		farmID string
+		ok     bool
+	}{
+		{topic: "farms/farm-7/devices/registration", farmID: "farm-7", ok: true},
+		{topic: "farms/north_field/devices/registration", farmID: "north_field", ok: true},
+		{topic: "farms//devices/registration"},
+		{topic: "farms/a/b/devices/registration"},
+		{topic: "farms/farm-7/devices/sensors/temperature-and-humidity"},
+		{topic: "/liwaisi/iot/smart-irrigation/device/registration"},
+	}
+	for _, tt := range tests {
+		farmID, ok := ResolveFarmTopic(FarmDeviceRegistrationTopic, tt.topic)
+		assert.Equal(t, tt.ok, ok, tt.topic)
+		assert.Equal(t, tt.farmID, farmID, tt.topic)
+	}
+}
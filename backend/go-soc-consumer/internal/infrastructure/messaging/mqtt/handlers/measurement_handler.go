@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+	ingestionlatency "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/ingestion_latency"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// MeasurementHandler handles MQTT messages carrying values of registered sensor types
+type MeasurementHandler struct {
+	coreLogger logger.CoreLogger
+	useCase    measurements.MeasurementUseCase
+	latency    ingestionlatency.IngestionLatencyUseCase
+}
+
+// NewMeasurementHandler creates a measurement handler using LoggerFactory
+func NewMeasurementHandler(loggerFactory logger.LoggerFactory, useCase measurements.MeasurementUseCase, latency ingestionlatency.IngestionLatencyUseCase) *MeasurementHandler {
+	return &MeasurementHandler{
+		coreLogger: loggerFactory.Core(),
+		useCase:    useCase,
+		latency:    latency,
+	}
+}
+
+// HandleMessage processes measurement messages on the shared or farm namespaced topic
+func (h *MeasurementHandler) HandleMessage(ctx context.Context, topic string, payload []byte) error {
+	if topic == MeasurementTopic {
+		return h.processMeasurements(ctx, topic, MeasurementTopic, payload)
+	}
+	if farmID, ok := ResolveFarmTopic(FarmMeasurementTopic, topic); ok {
+		return h.processMeasurements(eventports.ContextWithFarmID(ctx, farmID), topic, FarmMeasurementTopic, payload)
+	}
+	h.coreLogger.Warn("unknown_measurement_topic",
+		zap.String("topic", topic),
+		zap.String("component", "measurement_handler"),
+	)
+	return fmt.Errorf("unknown measurement topic: %s", topic)
+}
+
+// processMeasurements converts a measurement message into entities and stores them; template is
+// the topic without its farm, under which the latency is recorded
+func (h *MeasurementHandler) processMeasurements(ctx context.Context, topic, template string, payload []byte) error {
+	msgData := acquireMeasurementMessage()
+	defer releaseMeasurementMessage(msgData)
+	if err := json.Unmarshal(payload, msgData); err != nil {
+		h.logProcessingError(topic, payload, err)
+		return fmt.Errorf("failed to unmarshal measurement message: %w", err)
+	}
+
+	if msgData.EventType != "measurement" {
+		err := fmt.Errorf("invalid event type for measurement: %s", msgData.EventType)
+		h.logProcessingError(topic, payload, err)
+		return err
+	}
+
+	// Replayed measurements keep the time they were received
+	receivedAt, replayed := eventports.ReplayFromContext(ctx)
+	if !replayed {
+		receivedAt = receiptTime(ctx)
+	}
+
+	values := make([]*entities.Measurement, 0, len(msgData.Measurements))
+	for _, value := range msgData.Measurements {
+		measurement, err := entities.NewMeasurement(msgData.MacAddress, value.Type, value.Channel, value.Value, value.Quality, receivedAt)
+		if err != nil {
+			h.logProcessingError(topic, payload, err)
+			return fmt.Errorf("failed to create measurement entity: %w", err)
+		}
+		values = append(values, measurement)
+	}
+
+	if err := h.useCase.StoreMeasurements(ctx, values); err != nil {
+		h.coreLogger.Error("failed_to_store_measurements",
+			zap.String("topic", topic),
+			zap.ByteString("payload", payload),
+			zap.Error(err),
+			zap.String("component", "measurement_handler"),
+		)
+		return fmt.Errorf("failed to store measurements: %w", err)
+	}
+
+	// Replayed messages were received long ago, so their latency is not recorded
+	if !replayed {
+		h.latency.Record(template, msgData.MacAddress, dtos.DeviceTime(msgData.Timestamp), receivedAt, time.Now())
+	}
+	return nil
+}
+
+// logProcessingError logs a message that could not be turned into measurements
+func (h *MeasurementHandler) logProcessingError(topic string, payload []byte, err error) {
+	h.coreLogger.Error("measurement_processing_error",
+		zap.String("topic", topic),
+		zap.ByteString("payload", payload),
+		zap.Error(err),
+		zap.String("component", "measurement_handler"),
+	)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	ingestionlatency "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/ingestion_latency"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func newTestLatency(loggerFactory logger.LoggerFactory) ingestionlatency.IngestionLatencyUseCase {
+	return ingestionlatency.NewIngestionLatencyUseCase(nil, loggerFactory)
+}
+
+func TestMeasurementHandler_HandleMessage(t *testing.T) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	ctx := context.Background()
+	const payload = `{"event_type":"measurement","mac_address":"a0:a3:b3:ab:2f:d8","measurements":[{"type":"ph","value":6.5},{"type":"ph","channel":1,"value":6.8},{"type":"ec","value":1.2,"quality":"suspect"}]}`
+
+	t.Run("stores the measurements of the message", func(t *testing.T) {
+		useCase := mocks.NewMockMeasurementUseCase(t)
+		handler := NewMeasurementHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
+		useCase.EXPECT().StoreMeasurements(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, values []*entities.Measurement) error {
+			require.Len(t, values, 3)
+			assert.Equal(t, "A0:A3:B3:AB:2F:D8", values[0].MACAddress)
+			assert.Equal(t, "ph", values[0].SensorType)
+			assert.Equal(t, 0, values[0].Channel)
+			assert.Equal(t, 1, values[1].Channel)
+			assert.Equal(t, entities.MeasurementQualityGood, values[0].Quality)
+			assert.Equal(t, entities.MeasurementQualitySuspect, values[2].Quality)
+			return nil
+		}).Once()
+
+		assert.NoError(t, handler.HandleMessage(ctx, MeasurementTopic, []byte(payload)))
+	})
+
+	t.Run("scopes farm topics to their farm", func(t *testing.T) {
+		useCase := mocks.NewMockMeasurementUseCase(t)
+		handler := NewMeasurementHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
+		useCase.EXPECT().StoreMeasurements(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, values []*entities.Measurement) error {
+			assert.Equal(t, "farm-7", eventports.FarmIDFromContext(ctx))
+			return nil
+		}).Once()
+
+		assert.NoError(t, handler.HandleMessage(ctx, FarmTopic(FarmMeasurementTopic, "farm-7"), []byte(payload)))
+	})
+
+	t.Run("records the latency under the topic template", func(t *testing.T) {
+		receivedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+		const timestamped = `{"event_type":"measurement","mac_address":"A0:A3:B3:AB:2F:D8","timestamp":1740823198.5,"measurements":[{"type":"ph","value":6.5}]}`
+		useCase := mocks.NewMockMeasurementUseCase(t)
+		latency := mocks.NewMockIngestionLatencyUseCase(t)
+		handler := NewMeasurementHandler(loggerFactory, useCase, latency)
+		useCase.EXPECT().StoreMeasurements(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, values []*entities.Measurement) error {
+			assert.Equal(t, receivedAt, values[0].Timestamp, "measurements are taken at their receipt")
+			return nil
+		}).Once()
+		latency.EXPECT().Record(FarmMeasurementTopic, "A0:A3:B3:AB:2F:D8", receivedAt.Add(-1500*time.Millisecond), receivedAt, mock.Anything).Once()
+
+		assert.NoError(t, handler.HandleMessage(eventports.ContextWithReceivedAt(ctx, receivedAt), FarmTopic(FarmMeasurementTopic, "farm-7"), []byte(timestamped)))
+	})
+
+	t.Run("replays keep the received time", func(t *testing.T) {
+		receivedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+		useCase := mocks.NewMockMeasurementUseCase(t)
+		handler := NewMeasurementHandler(loggerFactory, useCase, mocks.NewMockIngestionLatencyUseCase(t))
+		useCase.EXPECT().StoreMeasurements(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, values []*entities.Measurement) error {
+			assert.Equal(t, receivedAt, values[0].Timestamp)
+			return nil
+		}).Once()
+
+		assert.NoError(t, handler.HandleMessage(eventports.ContextWithReplay(ctx, receivedAt), MeasurementTopic, []byte(payload)))
+	})
+
+	tests := []struct {
+		name        string
+		topic       string
+		payload     string
+		errContains string
+	}{
+		{name: "unknown topic", topic: "/unknown/topic", payload: payload, errContains: "unknown measurement topic"},
+		{name: "invalid JSON", topic: MeasurementTopic, payload: `{invalid`, errContains: "failed to unmarshal"},
+		{name: "invalid event type", topic: MeasurementTopic, payload: `{"event_type":"sensor_data","mac_address":"A0:A3:B3:AB:2F:D8","measurements":[]}`, errContains: "invalid event type"},
+		{name: "invalid channel", topic: MeasurementTopic, payload: `{"event_type":"measurement","mac_address":"A0:A3:B3:AB:2F:D8","measurements":[{"type":"ph","channel":64,"value":6.5}]}`, errContains: "failed to create measurement entity"},
+		{name: "invalid quality", topic: MeasurementTopic, payload: `{"event_type":"measurement","mac_address":"A0:A3:B3:AB:2F:D8","measurements":[{"type":"ph","value":6.5,"quality":"great"}]}`, errContains: "failed to create measurement entity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewMeasurementHandler(loggerFactory, mocks.NewMockMeasurementUseCase(t), newTestLatency(loggerFactory))
+
+			err := handler.HandleMessage(ctx, tt.topic, []byte(tt.payload))
+
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+
+	t.Run("use case failures", func(t *testing.T) {
+		useCase := mocks.NewMockMeasurementUseCase(t)
+		handler := NewMeasurementHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
+		useCase.EXPECT().StoreMeasurements(mock.Anything, mock.Anything).Return(fmt.Errorf("db error")).Once()
+
+		err := handler.HandleMessage(ctx, MeasurementTopic, []byte(payload))
+
+		assert.ErrorContains(t, err, "failed to store measurements")
+	})
+}
+
+// discardingMeasurementUseCase accepts measurements without storing them
+type discardingMeasurementUseCase struct {
+	measurements.MeasurementUseCase
+}
+
+func (discardingMeasurementUseCase) StoreMeasurements(context.Context, []*entities.Measurement) error {
+	return nil
+}
+
+func BenchmarkMeasurementHandler_HandleMessage(b *testing.B) {
+	loggerFactory := newBenchmarkLoggerFactory(b)
+	handler := NewMeasurementHandler(loggerFactory, discardingMeasurementUseCase{}, newTestLatency(loggerFactory))
+	payload := []byte(`{"event_type":"measurement","mac_address":"02:4C:54:00:00:01","timestamp":1748779200.25,"measurements":[{"type":"temperature","value":24.5},{"type":"humidity","value":61.25}]}`)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := handler.HandleMessage(ctx, MeasurementTopic, payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
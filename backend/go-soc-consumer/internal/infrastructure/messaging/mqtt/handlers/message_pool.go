@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"sync"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+)
+
+// maxPooledMeasurements bounds the measurements slice kept by a pooled message, so one oversized
+// message does not pin its array for the life of the pool
+const maxPooledMeasurements = 64
+
+// Telemetry messages are decoded into pooled DTOs so the hot path does not allocate one per
+// message. A DTO goes back to the pool once the entities built from it are stored; entities
+// copy every field they keep, so nothing refers to the DTO afterwards.
+var (
+	sensorDataMessages  = sync.Pool{New: func() any { return new(dtos.SensorDataMessage) }}
+	measurementMessages = sync.Pool{New: func() any { return new(dtos.MeasurementMessage) }}
+)
+
+// acquireSensorDataMessage returns an empty sensor data message
+func acquireSensorDataMessage() *dtos.SensorDataMessage {
+	return sensorDataMessages.Get().(*dtos.SensorDataMessage)
+}
+
+// releaseSensorDataMessage clears the message and returns it to the pool
+func releaseSensorDataMessage(message *dtos.SensorDataMessage) {
+	*message = dtos.SensorDataMessage{}
+	sensorDataMessages.Put(message)
+}
+
+// acquireMeasurementMessage returns an empty measurement message whose measurements slice may
+// have spare capacity from an earlier message
+func acquireMeasurementMessage() *dtos.MeasurementMessage {
+	return measurementMessages.Get().(*dtos.MeasurementMessage)
+}
+
+// releaseMeasurementMessage clears the message, keeping a small measurements array for reuse, and
+// returns it to the pool. The whole array is cleared because the JSON decoder decodes into the
+// existing elements, which would otherwise keep fields omitted by the next message.
+func releaseMeasurementMessage(message *dtos.MeasurementMessage) {
+	measurements := message.Measurements[:0]
+	if cap(measurements) > maxPooledMeasurements {
+		measurements = nil
+	}
+	clear(measurements[:cap(measurements)])
+	*message = dtos.MeasurementMessage{Measurements: measurements}
+	measurementMessages.Put(message)
+}
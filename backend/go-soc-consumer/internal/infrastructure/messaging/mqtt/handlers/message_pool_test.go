@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+)
+
+func TestReleaseMeasurementMessage(t *testing.T) {
+	t.Run("should not leak omitted fields into the next message", func(t *testing.T) {
+		message := acquireMeasurementMessage()
+		require.NoError(t, json.Unmarshal([]byte(`{"event_type":"measurement","mac_address":"AA:BB:CC:DD:EE:FF","timestamp":1748779200,"measurements":[{"type":"soil_moisture","channel":2,"value":41,"quality":"suspect"}]}`), message))
+		releaseMeasurementMessage(message)
+
+		require.NoError(t, json.Unmarshal([]byte(`{"event_type":"measurement","measurements":[{"type":"soil_moisture","value":40}]}`), message))
+		assert.Empty(t, message.MacAddress)
+		assert.Zero(t, message.Timestamp)
+		require.Len(t, message.Measurements, 1)
+		assert.Zero(t, message.Measurements[0].Channel)
+		assert.Empty(t, message.Measurements[0].Quality)
+	})
+
+	t.Run("should not keep oversized measurement arrays", func(t *testing.T) {
+		message := acquireMeasurementMessage()
+		message.Measurements = make([]dtos.MeasurementValue, maxPooledMeasurements+1)
+		releaseMeasurementMessage(message)
+
+		assert.Nil(t, message.Measurements)
+	})
+}
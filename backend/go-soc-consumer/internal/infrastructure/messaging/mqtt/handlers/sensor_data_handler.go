@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+	ingestionlatency "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/ingestion_latency"
 	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
 )
@@ -17,13 +20,15 @@ import (
 type SensorDataHandler struct {
 	coreLogger logger.CoreLogger
 	useCase    sensordata.SensorDataUseCase
+	latency    ingestionlatency.IngestionLatencyUseCase
 }
 
 // NewSensorDataHandler creates a sensor data handler using LoggerFactory
-func NewSensorDataHandler(loggerFactory logger.LoggerFactory, useCase sensordata.SensorDataUseCase) *SensorDataHandler {
+func NewSensorDataHandler(loggerFactory logger.LoggerFactory, useCase sensordata.SensorDataUseCase, latency ingestionlatency.IngestionLatencyUseCase) *SensorDataHandler {
 	return &SensorDataHandler{
 		coreLogger: loggerFactory.Core(),
 		useCase:    useCase,
+		latency:    latency,
 	}
 }
 
@@ -31,8 +36,11 @@ func NewSensorDataHandler(loggerFactory logger.LoggerFactory, useCase sensordata
 func (h *SensorDataHandler) HandleMessage(ctx context.Context, topic string, payload []byte) error {
 	switch topic {
 	case "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity":
-		return h.processSensorData(ctx, payload)
+		return h.processSensorData(ctx, SensorDataTopic, payload)
 	default:
+		if farmID, ok := ResolveFarmTopic(FarmSensorDataTopic, topic); ok {
+			return h.processSensorData(eventports.ContextWithFarmID(ctx, farmID), FarmSensorDataTopic, payload)
+		}
 		h.coreLogger.Warn("unknown_sensor_topic",
 			zap.String("topic", topic),
 			zap.String("component", "sensor_data_handler"),
@@ -41,14 +49,16 @@ func (h *SensorDataHandler) HandleMessage(ctx context.Context, topic string, pay
 	}
 }
 
-// processSensorData processes temperature and humidity sensor messages
-func (h *SensorDataHandler) processSensorData(ctx context.Context, payload []byte) error {
+// processSensorData processes temperature and humidity sensor messages; template is the topic
+// without its farm, under which the latency is recorded
+func (h *SensorDataHandler) processSensorData(ctx context.Context, template string, payload []byte) error {
 	// Parse JSON payload
-	var msgData dtos.SensorDataMessage
-	if err := json.Unmarshal(payload, &msgData); err != nil {
+	msgData := acquireSensorDataMessage()
+	defer releaseSensorDataMessage(msgData)
+	if err := json.Unmarshal(payload, msgData); err != nil {
 		h.coreLogger.Error("sensor_data_processing_error",
 			zap.String("topic", "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity"),
-			zap.String("payload", string(payload)),
+			zap.ByteString("payload", payload),
 			zap.Error(err),
 			zap.String("component", "sensor_data_handler"),
 		)
@@ -60,23 +70,28 @@ func (h *SensorDataHandler) processSensorData(ctx context.Context, payload []byt
 		err := fmt.Errorf("invalid event type for sensor data: %s", msgData.EventType)
 		h.coreLogger.Error("sensor_data_processing_error",
 			zap.String("topic", "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity"),
-			zap.String("payload", string(payload)),
+			zap.ByteString("payload", payload),
 			zap.Error(err),
 			zap.String("component", "sensor_data_handler"),
 		)
 		return err
 	}
 
-	// Create domain entity with validation
-	sensorData, err := entities.NewSensorTemperatureHumidity(
+	// Create domain entity with validation; replayed readings keep the time they were received
+	receivedAt, replayed := eventports.ReplayFromContext(ctx)
+	if !replayed {
+		receivedAt = receiptTime(ctx)
+	}
+	sensorData, err := entities.NewSensorTemperatureHumidityAt(
 		msgData.MacAddress,
 		msgData.Temperature,
 		msgData.Humidity,
+		receivedAt,
 	)
 	if err != nil {
 		h.coreLogger.Error("sensor_data_processing_error",
 			zap.String("topic", "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity"),
-			zap.String("payload", string(payload)),
+			zap.ByteString("payload", payload),
 			zap.Error(err),
 			zap.String("component", "sensor_data_handler"),
 		)
@@ -87,11 +102,16 @@ func (h *SensorDataHandler) processSensorData(ctx context.Context, payload []byt
 	if err := h.useCase.StoreSensorData(ctx, sensorData); err != nil {
 		h.coreLogger.Error("failed_to_store_sensor_data",
 			zap.String("topic", "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity"),
-			zap.String("payload", string(payload)),
+			zap.ByteString("payload", payload),
 			zap.Error(err),
 			zap.String("component", "sensor_data_handler"),
 		)
 		return fmt.Errorf("failed to store sensor data: %w", err)
 	}
+
+	// Replayed messages were received long ago, so their latency is not recorded
+	if !replayed {
+		h.latency.Record(template, msgData.MacAddress, dtos.DeviceTime(msgData.Timestamp), receivedAt, time.Now())
+	}
 	return nil
 }
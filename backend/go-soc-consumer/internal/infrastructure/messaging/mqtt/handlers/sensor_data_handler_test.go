@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
@@ -119,7 +120,7 @@ func TestSensorDataHandler_HandleMessage(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// fresh mock per subtest
 			useCase := mocks.NewMockSensorDataUseCase(t)
-			handler := NewSensorDataHandler(loggerFactory, useCase)
+			handler := NewSensorDataHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
 
 			// Expect repository.Create only for valid messages on the known topic
 			if !tt.wantErr && tt.topic == "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity" {
@@ -145,7 +146,7 @@ func TestSensorDataHandler_processSensorData(t *testing.T) {
 
 	t.Run("valid processing", func(t *testing.T) {
 		useCase := mocks.NewMockSensorDataUseCase(t)
-		handler := NewSensorDataHandler(loggerFactory, useCase)
+		handler := NewSensorDataHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
 		payload := createValidSensorDataPayload(t, dtos.SensorDataMessage{
 			EventType:   "sensor_data",
 			MacAddress:  "A0:A3:B3:AB:2F:D8",
@@ -154,33 +155,33 @@ func TestSensorDataHandler_processSensorData(t *testing.T) {
 		})
 
 		useCase.EXPECT().StoreSensorData(mock.Anything, mock.Anything).Return(nil).Once()
-		err := handler.processSensorData(ctx, payload)
+		err := handler.processSensorData(ctx, SensorDataTopic, payload)
 		assert.NoError(t, err)
 	})
 
 	t.Run("malformed JSON", func(t *testing.T) {
 		useCase := mocks.NewMockSensorDataUseCase(t)
-		handler := NewSensorDataHandler(loggerFactory, useCase)
+		handler := NewSensorDataHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
 		payload := []byte(`{malformed`)
 
-		err := handler.processSensorData(ctx, payload)
+		err := handler.processSensorData(ctx, SensorDataTopic, payload)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to unmarshal")
 	})
 
 	t.Run("missing fields", func(t *testing.T) {
 		useCase := mocks.NewMockSensorDataUseCase(t)
-		handler := NewSensorDataHandler(loggerFactory, useCase)
+		handler := NewSensorDataHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
 		payload := []byte(`{"event_type":"sensor_data"}`)
 
-		err := handler.processSensorData(ctx, payload)
+		err := handler.processSensorData(ctx, SensorDataTopic, payload)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create sensor data entity")
 	})
 
 	t.Run("repo create fails", func(t *testing.T) {
 		useCase := mocks.NewMockSensorDataUseCase(t)
-		handler := NewSensorDataHandler(loggerFactory, useCase)
+		handler := NewSensorDataHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
 		payload := createValidSensorDataPayload(t, dtos.SensorDataMessage{
 			EventType:   "sensor_data",
 			MacAddress:  "A0:A3:B3:AB:2F:D8",
@@ -189,7 +190,7 @@ func TestSensorDataHandler_processSensorData(t *testing.T) {
 		})
 
 		useCase.EXPECT().StoreSensorData(mock.Anything, mock.Anything).Return(fmt.Errorf("db error")).Once()
-		err := handler.processSensorData(ctx, payload)
+		err := handler.processSensorData(ctx, SensorDataTopic, payload)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to store sensor data")
 	})
@@ -200,7 +201,7 @@ func TestNewSensorDataHandler(t *testing.T) {
 	require.NoError(t, err)
 	useCase := mocks.NewMockSensorDataUseCase(t)
 
-	handler := NewSensorDataHandler(loggerFactory, useCase)
+	handler := NewSensorDataHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
 	assert.NotNil(t, handler)
 	// Logger fields are private after refactoring - just test that handler was created
 }
@@ -218,7 +219,7 @@ func TestSensorDataHandler_HandleMessage_Integration(t *testing.T) {
 	require.NoError(t, err)
 
 	useCase := mocks.NewMockSensorDataUseCase(t)
-	handler := NewSensorDataHandler(loggerFactory, useCase)
+	handler := NewSensorDataHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
 	ctx := context.Background()
 
 	// Test with the exact JSON format specified in requirements
@@ -235,7 +236,7 @@ func TestSensorDataHandler_HandleMessage_RepositoryError(t *testing.T) {
 	require.NoError(t, err)
 
 	useCase := mocks.NewMockSensorDataUseCase(t)
-	handler := NewSensorDataHandler(loggerFactory, useCase)
+	handler := NewSensorDataHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
 	ctx := context.Background()
 
 	topic := "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity"
@@ -257,7 +258,7 @@ func TestSensorDataHandler_AbnormalReadingsLogging(t *testing.T) {
 	require.NoError(t, err)
 
 	useCase := mocks.NewMockSensorDataUseCase(t)
-	handler := NewSensorDataHandler(loggerFactory, useCase)
+	handler := NewSensorDataHandler(loggerFactory, useCase, newTestLatency(loggerFactory))
 	ctx := context.Background()
 	topic := "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity"
 
@@ -286,3 +287,25 @@ func TestSensorDataHandler_AbnormalReadingsLogging(t *testing.T) {
 	err = handler.HandleMessage(ctx, topic, abnormalPayload)
 	assert.NoError(t, err)
 }
+
+// discardingSensorDataUseCase accepts readings without storing them
+type discardingSensorDataUseCase struct{}
+
+func (discardingSensorDataUseCase) StoreSensorData(context.Context, *entities.SensorTemperatureHumidity) error {
+	return nil
+}
+
+func BenchmarkSensorDataHandler_HandleMessage(b *testing.B) {
+	loggerFactory := newBenchmarkLoggerFactory(b)
+	handler := NewSensorDataHandler(loggerFactory, discardingSensorDataUseCase{}, newTestLatency(loggerFactory))
+	payload := []byte(`{"event_type":"sensor_data","mac_address":"02:4C:54:00:00:01","temperature":24.5,"humidity":61.25,"timestamp":1748779200.25}`)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := handler.HandleMessage(ctx, SensorDataTopic, payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
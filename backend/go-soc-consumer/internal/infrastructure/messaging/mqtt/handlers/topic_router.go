@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+)
+
+// Shared device topics used when farm namespaces are disabled
+const (
+	DeviceRegistrationTopic = "/liwaisi/iot/smart-irrigation/device/registration"
+	SensorDataTopic         = "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity"
+	MeasurementTopic        = "/liwaisi/iot/smart-irrigation/sensors/measurements"
+	CommandTopicPrefix      = "/liwaisi/iot/smart-irrigation/commands/" // followed by the MAC address of the device
+	CommandAckTopic         = "/liwaisi/iot/smart-irrigation/device/command-ack"
+	CrashReportTopic        = "/liwaisi/iot/smart-irrigation/device/crash-report"
+	WifiDiagnosticsTopic    = "/liwaisi/iot/smart-irrigation/device/wifi-diagnostics"
+)
+
+// TopicRouter dispatches messages to the handler of their topic, for messages that do not arrive
+// through a per topic subscription such as those replayed from the raw archive
+type TopicRouter struct {
+	deviceRegistration eventports.MessageHandler
+	sensorData         eventports.MessageHandler
+	measurement        eventports.MessageHandler
+}
+
+// NewTopicRouter creates a router for the device registration, sensor data and measurement topics, shared or farm namespaced
+func NewTopicRouter(deviceRegistration, sensorData, measurement eventports.MessageHandler) *TopicRouter {
+	return &TopicRouter{
+		deviceRegistration: deviceRegistration,
+		sensorData:         sensorData,
+		measurement:        measurement,
+	}
+}
+
+// HandleMessage passes the message to the handler of its topic
+func (r *TopicRouter) HandleMessage(ctx context.Context, topic string, payload []byte) error {
+	if topic == DeviceRegistrationTopic {
+		return r.deviceRegistration(ctx, topic, payload)
+	}
+	if topic == SensorDataTopic {
+		return r.sensorData(ctx, topic, payload)
+	}
+	if topic == MeasurementTopic {
+		return r.measurement(ctx, topic, payload)
+	}
+	if _, ok := ResolveFarmTopic(FarmDeviceRegistrationTopic, topic); ok {
+		return r.deviceRegistration(ctx, topic, payload)
+	}
+	if _, ok := ResolveFarmTopic(FarmSensorDataTopic, topic); ok {
+		return r.sensorData(ctx, topic, payload)
+	}
+	if _, ok := ResolveFarmTopic(FarmMeasurementTopic, topic); ok {
+		return r.measurement(ctx, topic, payload)
+	}
+	return fmt.Errorf("no handler for topic %s", topic)
+}
+
+// receiptTime returns when a live message was received from the broker, now when the ingestion
+// pipeline did not record it
+func receiptTime(ctx context.Context) time.Time {
+	if receivedAt, ok := eventports.ReceivedAtFromContext(ctx); ok {
+		return receivedAt
+	}
+	return time.Now()
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicRouter_HandleMessage(t *testing.T) {
+	var routed []string
+	route := func(name string) func(ctx context.Context, topic string, payload []byte) error {
+		return func(ctx context.Context, topic string, payload []byte) error {
+			routed = append(routed, name+" "+topic)
+			return nil
+		}
+	}
+	router := NewTopicRouter(route("registration"), route("sensor"), route("measurement"))
+
+	assert.NoError(t, router.HandleMessage(context.Background(), DeviceRegistrationTopic, nil))
+	assert.NoError(t, router.HandleMessage(context.Background(), SensorDataTopic, nil))
+	assert.NoError(t, router.HandleMessage(context.Background(), "farms/farm-7/devices/registration", nil))
+	assert.NoError(t, router.HandleMessage(context.Background(), "farms/farm-7/devices/sensors/temperature-and-humidity", nil))
+	assert.NoError(t, router.HandleMessage(context.Background(), MeasurementTopic, nil))
+	assert.NoError(t, router.HandleMessage(context.Background(), "farms/farm-7/devices/sensors/measurements", nil))
+	assert.Error(t, router.HandleMessage(context.Background(), "/liwaisi/iot/smart-irrigation/commands/valve", nil))
+
+	assert.Equal(t, []string{
+		"registration " + DeviceRegistrationTopic,
+		"sensor " + SensorDataTopic,
+		"registration farms/farm-7/devices/registration",
+		"sensor farms/farm-7/devices/sensors/temperature-and-humidity",
+		"measurement " + MeasurementTopic,
+		"measurement farms/farm-7/devices/sensors/measurements",
+	}, routed)
+}
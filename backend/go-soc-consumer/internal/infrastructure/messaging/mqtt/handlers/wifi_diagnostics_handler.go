@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+	wifidiagnostics "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/wifi_diagnostics"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// WifiDiagnosticsHandler handles MQTT messages reporting the Wi-Fi link of devices
+type WifiDiagnosticsHandler struct {
+	coreLogger logger.CoreLogger
+	useCase    wifidiagnostics.WifiDiagnosticsUseCase
+}
+
+// NewWifiDiagnosticsHandler creates a Wi-Fi diagnostics handler using LoggerFactory
+func NewWifiDiagnosticsHandler(loggerFactory logger.LoggerFactory, useCase wifidiagnostics.WifiDiagnosticsUseCase) *WifiDiagnosticsHandler {
+	return &WifiDiagnosticsHandler{
+		coreLogger: loggerFactory.Core(),
+		useCase:    useCase,
+	}
+}
+
+// HandleMessage processes Wi-Fi diagnostics on the shared or farm namespaced topic
+func (h *WifiDiagnosticsHandler) HandleMessage(ctx context.Context, topic string, payload []byte) error {
+	if farmID, ok := ResolveFarmTopic(FarmWifiDiagnosticsTopic, topic); ok {
+		ctx = eventports.ContextWithFarmID(ctx, farmID)
+	} else if topic != WifiDiagnosticsTopic {
+		h.coreLogger.Warn("unknown_wifi_diagnostics_topic",
+			zap.String("topic", topic),
+			zap.String("component", "wifi_diagnostics_handler"),
+		)
+		return fmt.Errorf("unknown wifi diagnostics topic: %s", topic)
+	}
+
+	var msgData dtos.WifiDiagnosticsMessage
+	if err := json.Unmarshal(payload, &msgData); err != nil {
+		h.logProcessingError(topic, payload, err)
+		return fmt.Errorf("failed to unmarshal wifi diagnostics: %w", err)
+	}
+	if msgData.EventType != "wifi_diagnostics" {
+		err := fmt.Errorf("invalid event type for wifi diagnostics: %s", msgData.EventType)
+		h.logProcessingError(topic, payload, err)
+		return err
+	}
+
+	diagnostics, err := entities.NewWifiDiagnostics(msgData.MacAddress, msgData.SSID, msgData.RSSI, msgData.Channel, msgData.ReconnectCount, time.Now())
+	if err != nil {
+		h.logProcessingError(topic, payload, err)
+		return fmt.Errorf("invalid wifi diagnostics: %w", err)
+	}
+	if err := h.useCase.Ingest(ctx, diagnostics); err != nil {
+		return fmt.Errorf("failed to ingest wifi diagnostics: %w", err)
+	}
+	return nil
+}
+
+// logProcessingError logs a message that could not be turned into Wi-Fi diagnostics
+func (h *WifiDiagnosticsHandler) logProcessingError(topic string, payload []byte, err error) {
+	h.coreLogger.Error("wifi_diagnostics_processing_error",
+		zap.String("topic", topic),
+		zap.String("payload", string(payload)),
+		zap.Error(err),
+		zap.String("component", "wifi_diagnostics_handler"),
+	)
+}
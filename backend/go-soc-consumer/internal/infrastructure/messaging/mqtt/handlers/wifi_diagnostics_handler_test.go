@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func TestWifiDiagnosticsHandler_HandleMessage(t *testing.T) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("ingests the diagnostics", func(t *testing.T) {
+		useCase := mocks.NewMockWifiDiagnosticsUseCase(t)
+		handler := NewWifiDiagnosticsHandler(loggerFactory, useCase)
+		useCase.EXPECT().Ingest(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, diagnostics *entities.WifiDiagnostics) error {
+			assert.Equal(t, "AA:BB:CC:DD:EE:FF", diagnostics.MACAddress)
+			assert.Equal(t, "finca", diagnostics.SSID)
+			assert.Equal(t, -82, diagnostics.RSSI)
+			assert.Equal(t, 3, diagnostics.Channel)
+			assert.Equal(t, 2, diagnostics.ReconnectCount)
+			assert.Empty(t, eventports.FarmIDFromContext(ctx))
+			return nil
+		}).Once()
+
+		payload := `{"event_type":"wifi_diagnostics","mac_address":"aa:bb:cc:dd:ee:ff","ssid":"finca","rssi":-82,"channel":3,"reconnect_count":2}`
+		assert.NoError(t, handler.HandleMessage(ctx, WifiDiagnosticsTopic, []byte(payload)))
+	})
+
+	t.Run("scopes farm topics to their farm", func(t *testing.T) {
+		useCase := mocks.NewMockWifiDiagnosticsUseCase(t)
+		handler := NewWifiDiagnosticsHandler(loggerFactory, useCase)
+		useCase.EXPECT().Ingest(mock.MatchedBy(func(ctx context.Context) bool {
+			return eventports.FarmIDFromContext(ctx) == "farm-7"
+		}), mock.Anything).Return(domainerrors.ErrDeviceFarmMismatch).Once()
+
+		payload := `{"event_type":"wifi_diagnostics","mac_address":"AA:BB:CC:DD:EE:FF","ssid":"finca","rssi":-60}`
+		assert.ErrorIs(t, handler.HandleMessage(ctx, FarmTopic(FarmWifiDiagnosticsTopic, "farm-7"), []byte(payload)), domainerrors.ErrDeviceFarmMismatch)
+	})
+
+	t.Run("rejects malformed diagnostics", func(t *testing.T) {
+		handler := NewWifiDiagnosticsHandler(loggerFactory, mocks.NewMockWifiDiagnosticsUseCase(t))
+
+		assert.Error(t, handler.HandleMessage(ctx, WifiDiagnosticsTopic, []byte(`{"event_type":"wifi_diagnostics","mac_address":"AA:BB:CC:DD:EE:FF","rssi":12}`)))
+		assert.Error(t, handler.HandleMessage(ctx, WifiDiagnosticsTopic, []byte(`{"event_type":"crash_report"}`)))
+		assert.Error(t, handler.HandleMessage(ctx, WifiDiagnosticsTopic, []byte(`not json`)))
+		assert.Error(t, handler.HandleMessage(ctx, CrashReportTopic, []byte(`{}`)))
+	})
+}
@@ -2,19 +2,25 @@ package mqtt
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net/url"
+	"sync"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 	"go.uber.org/zap"
 
 	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/inflight"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
 )
 
 // MQTTConsumerConfig holds configuration for MQTT consumer
 type MQTTConsumerConfig struct {
 	BrokerURL            string
+	BrokerURLs           []string // failover order, takes precedence over BrokerURL; the first entry is the primary
 	ClientID             string
 	Username             string
 	Password             string
@@ -23,29 +29,57 @@ type MQTTConsumerConfig struct {
 	CleanSession         bool
 	AutoReconnect        bool
 	MaxReconnectInterval time.Duration
+	FailbackInterval     time.Duration    // how often the primary is probed while on a backup broker, 0 disables failback
+	TLS                  *TLSClientConfig // optional TLS settings for mqtts:// brokers, nil keeps the client defaults
 }
 
+// brokerURLs returns the configured brokers in failover order
+func (c MQTTConsumerConfig) brokerURLs() []string {
+	if len(c.BrokerURLs) > 0 {
+		return c.BrokerURLs
+	}
+	return []string{c.BrokerURL}
+}
+
+const (
+	defaultSubscribeTimeout = 10 * time.Second // used when no connect timeout is configured
+	maxResubscribeBackoff   = 30 * time.Second
+)
+
 // MQTTConsumerImpl implements the MessageConsumer port
 type MQTTConsumerImpl struct {
 	config        MQTTConsumerConfig
 	client        mqtt.Client
-	handlers      map[string]eventports.MessageHandler
 	loggerFactory logger.LoggerFactory
+
+	brokers       *brokerPool
+	mu            sync.RWMutex
+	subscriptions map[string]mqtt.MessageHandler
+	stopFailback  chan struct{}
+	inFlight      *inflight.Tracker
+
+	resubscribeBackoff time.Duration // first pause before retrying unacknowledged resubscriptions
 }
 
 // NewMQTTConsumer creates a new MQTT consumer
 func NewMQTTConsumer(config MQTTConsumerConfig, loggerFactory logger.LoggerFactory) *MQTTConsumerImpl {
 	return &MQTTConsumerImpl{
 		config:        config,
-		handlers:      make(map[string]eventports.MessageHandler),
 		loggerFactory: loggerFactory,
+		brokers:       newBrokerPool(config.brokerURLs()),
+		subscriptions: make(map[string]mqtt.MessageHandler),
+		inFlight:      inflight.NewTracker(),
+
+		resubscribeBackoff: time.Second,
 	}
 }
 
 // Start begins consuming messages from MQTT broker
 func (m *MQTTConsumerImpl) Start(ctx context.Context) error {
 	opts := mqtt.NewClientOptions()
-	opts.AddBroker(m.config.BrokerURL)
+	for _, brokerURL := range m.brokers.URLs() {
+		opts.AddBroker(brokerURL)
+	}
 	opts.SetClientID(m.config.ClientID)
 	opts.SetUsername(m.config.Username)
 	opts.SetPassword(m.config.Password)
@@ -54,12 +88,25 @@ func (m *MQTTConsumerImpl) Start(ctx context.Context) error {
 	opts.SetCleanSession(m.config.CleanSession)
 	opts.SetAutoReconnect(m.config.AutoReconnect)
 	opts.SetMaxReconnectInterval(m.config.MaxReconnectInterval)
+	if m.config.TLS != nil {
+		tlsConfig, err := m.config.TLS.tlsConfig()
+		if err != nil {
+			return err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	// Track which broker is being tried so the connected one can be identified
+	opts.SetConnectionAttemptHandler(func(broker *url.URL, tlsCfg *tls.Config) *tls.Config {
+		m.brokers.OnAttempt(broker)
+		return tlsCfg
+	})
 
 	// Set connection lost handler
 	opts.SetConnectionLostHandler(func(client mqtt.Client, err error) {
 		m.loggerFactory.Core().Error("mqtt_connection_lost",
 			zap.Error(err),
-			zap.String("broker_url", m.config.BrokerURL),
+			zap.String("broker_url", m.brokers.OnConnectionLost(err)),
 			zap.String("client_id", m.config.ClientID),
 			zap.String("component", "mqtt_consumer"),
 		)
@@ -67,10 +114,20 @@ func (m *MQTTConsumerImpl) Start(ctx context.Context) error {
 
 	// Set on connect handler
 	opts.SetOnConnectHandler(func(client mqtt.Client) {
+		brokerURL, backup := m.brokers.OnConnected()
 		m.loggerFactory.Application().LogApplicationEvent("mqtt_connected", "mqtt_consumer",
-			zap.String("broker_url", m.config.BrokerURL),
+			zap.String("broker_url", brokerURL),
 			zap.String("client_id", m.config.ClientID),
 		)
+		if backup {
+			m.loggerFactory.Core().Warn("mqtt_broker_failover",
+				zap.String("broker_url", brokerURL),
+				zap.String("primary_broker_url", m.brokers.Primary()),
+				zap.String("client_id", m.config.ClientID),
+				zap.String("component", "mqtt_consumer"),
+			)
+		}
+		m.resubscribe(client)
 	})
 
 	// Create MQTT client
@@ -81,7 +138,7 @@ func (m *MQTTConsumerImpl) Start(ctx context.Context) error {
 	if token := m.client.Connect(); token.Wait() && token.Error() != nil {
 		m.loggerFactory.Core().Error("mqtt_connection_failed",
 			zap.Error(token.Error()),
-			zap.String("broker_url", m.config.BrokerURL),
+			zap.Strings("broker_urls", m.brokers.URLs()),
 			zap.String("client_id", m.config.ClientID),
 			zap.Duration("connection_attempt_duration", time.Since(start)),
 			zap.String("component", "mqtt_consumer"),
@@ -90,15 +147,186 @@ func (m *MQTTConsumerImpl) Start(ctx context.Context) error {
 	}
 
 	m.loggerFactory.Application().LogApplicationEvent("mqtt_broker_connected", "mqtt_consumer",
-		zap.String("broker_url", m.config.BrokerURL),
+		zap.String("broker_url", m.brokers.Active()),
 		zap.String("client_id", m.config.ClientID),
 		zap.Duration("connection_duration", time.Since(start)),
 	)
+
+	// Migrate back to the primary broker once it becomes reachable again
+	if m.config.FailbackInterval > 0 && len(m.brokers.URLs()) > 1 {
+		m.stopFailback = make(chan struct{})
+		go m.runFailback(m.stopFailback)
+	}
 	return nil
 }
 
+// runFailback periodically probes the primary broker while connected to a backup
+// and reconnects to it once it accepts connections again
+func (m *MQTTConsumerImpl) runFailback(stop <-chan struct{}) {
+	ticker := time.NewTicker(m.config.FailbackInterval)
+	defer ticker.Stop()
+
+	// Set while a failed failback left the client disconnected. Paho does not auto-reconnect
+	// after an explicit Disconnect, so the connection is retried here until a broker accepts it.
+	reconnect := false
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		if reconnect {
+			reconnect = !m.reconnect()
+			continue
+		}
+
+		active := m.brokers.Active()
+		primary := m.brokers.Primary()
+		if active == "" || active == primary || !m.client.IsConnected() {
+			continue
+		}
+
+		err := probeBroker(primary, m.config.ConnectTimeout)
+		m.brokers.OnPrimaryProbe(err)
+		if err != nil {
+			m.loggerFactory.Core().Debug("mqtt_primary_broker_unreachable",
+				zap.Error(err),
+				zap.String("primary_broker_url", primary),
+				zap.String("component", "mqtt_consumer"),
+			)
+			continue
+		}
+
+		m.loggerFactory.Application().LogApplicationEvent("mqtt_broker_failback", "mqtt_consumer",
+			zap.String("from_broker_url", active),
+			zap.String("primary_broker_url", primary),
+			zap.String("client_id", m.config.ClientID),
+		)
+
+		// Brokers are tried in order on connect, so reconnecting lands on the primary
+		m.client.Disconnect(250)
+		m.brokers.OnFailback()
+		reconnect = !m.reconnect()
+	}
+}
+
+// reconnect connects to the first broker in failover order that accepts the connection,
+// falling back to the backups when the primary refuses it
+func (m *MQTTConsumerImpl) reconnect() bool {
+	if token := m.client.Connect(); token.Wait() && token.Error() != nil {
+		m.loggerFactory.Core().Error("mqtt_broker_failback_failed",
+			zap.Error(token.Error()),
+			zap.Strings("broker_urls", m.brokers.URLs()),
+			zap.String("client_id", m.config.ClientID),
+			zap.String("component", "mqtt_consumer"),
+		)
+		return false
+	}
+	return true
+}
+
+// resubscribe restores topic subscriptions after a reconnect or broker switch. A subscription the
+// broker does not acknowledge is retried with backoff until it is, or until the connection drops
+// and the next connect resubscribes anyway.
+func (m *MQTTConsumerImpl) resubscribe(client mqtt.Client) {
+	m.mu.RLock()
+	pending := make([]string, 0, len(m.subscriptions))
+	for topic := range m.subscriptions {
+		pending = append(pending, topic)
+	}
+	m.mu.RUnlock()
+
+	backoff := m.resubscribeBackoff
+	for {
+		failed := pending[:0]
+		for _, topic := range pending {
+			m.mu.RLock()
+			handler, subscribed := m.subscriptions[topic]
+			m.mu.RUnlock()
+			if !subscribed {
+				continue
+			}
+
+			if err := m.subscribeAndWait(client, topic, handler); err != nil {
+				m.loggerFactory.Core().Error("mqtt_topic_resubscribe_failed",
+					zap.Error(err),
+					zap.String("topic", topic),
+					zap.Duration("retry_in", backoff),
+					zap.String("component", "mqtt_consumer"),
+				)
+				failed = append(failed, topic)
+				continue
+			}
+			m.loggerFactory.Core().Debug("mqtt_topic_resubscribed",
+				zap.String("topic", topic),
+				zap.String("component", "mqtt_consumer"),
+			)
+		}
+
+		pending = failed
+		if len(pending) == 0 || !client.IsConnected() {
+			return
+		}
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxResubscribeBackoff)
+	}
+}
+
+// subscribeAndWait subscribes to a topic and waits for the broker to acknowledge it
+func (m *MQTTConsumerImpl) subscribeAndWait(client mqtt.Client, topic string, handler mqtt.MessageHandler) error {
+	timeout := m.config.ConnectTimeout
+	if timeout <= 0 {
+		timeout = defaultSubscribeTimeout
+	}
+
+	token := client.Subscribe(topic, 1, handler)
+	if !token.WaitTimeout(timeout) {
+		return fmt.Errorf("subscription not acknowledged within %s", timeout)
+	}
+	return token.Error()
+}
+
+// BrokerStatuses returns the health of every configured broker in failover order
+func (m *MQTTConsumerImpl) BrokerStatuses() []BrokerStatus {
+	return m.brokers.Statuses()
+}
+
+// ActiveBroker returns the URL of the broker currently connected, or empty if disconnected
+func (m *MQTTConsumerImpl) ActiveBroker() string {
+	return m.brokers.Active()
+}
+
+// CheckReadiness reports an error unless the consumer is connected to a broker
+func (m *MQTTConsumerImpl) CheckReadiness(ctx context.Context) (interface{}, error) {
+	statuses := m.BrokerStatuses()
+	if !m.IsConnected() {
+		return statuses, fmt.Errorf("MQTT client is not connected")
+	}
+	return statuses, nil
+}
+
+// MetricsCollector exposes per-broker health and failover counters
+func (m *MQTTConsumerImpl) MetricsCollector() metrics.Collector {
+	return m.brokers
+}
+
 // Stop gracefully stops the MQTT consumer
 func (m *MQTTConsumerImpl) Stop(ctx context.Context) error {
+	if m.stopFailback != nil {
+		close(m.stopFailback)
+		m.stopFailback = nil
+	}
+
+	// Drain before disconnecting so a slow handler cannot hold up the disconnect
+	if err := m.inFlight.Drain(ctx); err != nil {
+		m.loggerFactory.Core().Warn("mqtt_consumer_drain_timeout",
+			zap.Error(err),
+			zap.String("client_id", m.config.ClientID),
+			zap.String("component", "mqtt_consumer"),
+		)
+	}
+
 	if m.client != nil && m.client.IsConnected() {
 		start := time.Now()
 		m.client.Disconnect(250) // Wait 250ms for graceful disconnect
@@ -116,8 +344,8 @@ func (m *MQTTConsumerImpl) Subscribe(ctx context.Context, topic string, handler
 		return fmt.Errorf("MQTT client is not connected")
 	}
 
-	// Store the handler for this specific topic
-	m.handlers[topic] = handler
+	// A panicking handler fails only its message
+	handler = eventports.Recovering(handler)
 
 	// Create message handler function
 	messageHandler := func(client mqtt.Client, msg mqtt.Message) {
@@ -130,17 +358,22 @@ func (m *MQTTConsumerImpl) Subscribe(ctx context.Context, topic string, handler
 			zap.String("component", "mqtt_consumer"),
 		)
 
-		// Get the appropriate handler for this topic
-		topicHandler, exists := m.handlers[msg.Topic()]
-		if !exists {
-			m.loggerFactory.Core().Error("no_handler_for_topic",
+		// Handlers run on the consumer context rather than the subscribe context, so they are
+		// only cancelled when Stop gives up waiting for them
+		msgCtx, ok := m.inFlight.Begin()
+		if !ok {
+			m.loggerFactory.Core().Debug("mqtt_message_ignored_stopping",
 				zap.String("topic", msg.Topic()),
 				zap.String("component", "mqtt_consumer"),
 			)
 			return
 		}
+		defer m.inFlight.Done()
 
-		err := topicHandler(ctx, msg.Topic(), msg.Payload())
+		// The message topic only equals the subscribed topic for filters without wildcards,
+		// so dispatch to the handler of this subscription rather than looking it up by topic
+		msgCtx = eventports.ContextWithDelivery(msgCtx, eventports.Delivery{QoS: msg.Qos(), Retained: msg.Retained()})
+		err := handler(msgCtx, msg.Topic(), msg.Payload())
 		processingDuration := time.Since(start)
 
 		m.loggerFactory.Messaging().LogMQTTMessage(msg.Topic(), payloadSize, processingDuration, err == nil)
@@ -156,6 +389,11 @@ func (m *MQTTConsumerImpl) Subscribe(ctx context.Context, topic string, handler
 		}
 	}
 
+	// Remember the subscription so it can be restored after a broker switch
+	m.mu.Lock()
+	m.subscriptions[topic] = messageHandler
+	m.mu.Unlock()
+
 	// Subscribe to topic
 	start := time.Now()
 	if token := m.client.Subscribe(topic, 1, messageHandler); token.Wait() && token.Error() != nil {
@@ -178,6 +416,11 @@ func (m *MQTTConsumerImpl) Subscribe(ctx context.Context, topic string, handler
 	return nil
 }
 
+// SubscribeHandlers subscribes to a topic with several ordered handlers
+func (m *MQTTConsumerImpl) SubscribeHandlers(ctx context.Context, topic string, policy eventports.HandlerErrorPolicy, handlers ...eventports.MessageHandler) error {
+	return m.Subscribe(ctx, topic, eventports.FanOut(policy, handlers...))
+}
+
 // Unsubscribe stops consuming messages from the specified topic
 func (m *MQTTConsumerImpl) Unsubscribe(topic string) error {
 	if !m.client.IsConnected() {
@@ -196,8 +439,9 @@ func (m *MQTTConsumerImpl) Unsubscribe(topic string) error {
 		return fmt.Errorf("failed to unsubscribe from topic %s: %w", topic, token.Error())
 	}
 
-	// Remove the handler from the map
-	delete(m.handlers, topic)
+	m.mu.Lock()
+	delete(m.subscriptions, topic)
+	m.mu.Unlock()
 
 	m.loggerFactory.Application().LogApplicationEvent("mqtt_topic_unsubscribed", "mqtt_consumer",
 		zap.String("topic", topic),
@@ -207,6 +451,24 @@ func (m *MQTTConsumerImpl) Unsubscribe(topic string) error {
 	return nil
 }
 
+// Publish sends a message with QoS 1 and waits for the broker to acknowledge it
+func (m *MQTTConsumerImpl) Publish(ctx context.Context, topic string, payload []byte) error {
+	if !m.IsConnected() {
+		return fmt.Errorf("MQTT client is not connected")
+	}
+
+	token := m.client.Publish(topic, 1, false, payload)
+	select {
+	case <-token.Done():
+		if err := token.Error(); err != nil {
+			return fmt.Errorf("failed to publish to topic %s: %w", topic, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // IsConnected returns true if connected to MQTT broker
 func (m *MQTTConsumerImpl) IsConnected() bool {
 	return m.client != nil && m.client.IsConnected()
@@ -3,11 +3,14 @@ package mqtt
 import (
 	"context"
 	"errors"
+	"net"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 
@@ -141,8 +144,8 @@ func TestNewMQTTConsumer(t *testing.T) {
 	assert.NotNil(t, consumer)
 	assert.Equal(t, config, consumer.config)
 	assert.Nil(t, consumer.client)
-	assert.NotNil(t, consumer.handlers)
-	assert.Empty(t, consumer.handlers)
+	assert.NotNil(t, consumer.subscriptions)
+	assert.Empty(t, consumer.subscriptions)
 }
 
 // TestMQTTConsumer_Stop tests the Stop method
@@ -209,6 +212,109 @@ func TestMQTTConsumer_Stop(t *testing.T) {
 	}
 }
 
+// TestMQTTConsumer_Failback tests the migration back to the primary broker
+func TestMQTTConsumer_Failback(t *testing.T) {
+	t.Run("should keep reconnecting when the failback connect fails", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		primary := "tcp://" + listener.Addr().String()
+		backup := "tcp://127.0.0.1:1"
+		consumer := NewMQTTConsumer(MQTTConsumerConfig{
+			BrokerURLs:       []string{primary, backup},
+			ClientID:         "test-client",
+			ConnectTimeout:   time.Second,
+			FailbackInterval: 10 * time.Millisecond,
+		}, createTestLoggerFactory(t))
+
+		backupURL, err := url.Parse(backup)
+		require.NoError(t, err)
+		consumer.brokers.OnAttempt(backupURL)
+		consumer.brokers.OnConnected()
+
+		failed := NewMockMQTTToken(t)
+		failed.On("Wait").Return(true)
+		failed.On("Error").Return(errors.New("connection refused"))
+		succeeded := NewMockMQTTToken(t)
+		succeeded.On("Wait").Return(true)
+		succeeded.On("Error").Return(nil)
+
+		reconnected := make(chan struct{})
+		mockClient := NewMockMQTTClient(t)
+		mockClient.On("IsConnected").Return(true).Once()
+		mockClient.On("Disconnect", uint(250)).Return().Once()
+		mockClient.On("Connect").Return(failed).Once()
+		mockClient.On("Connect").Return(succeeded).Once().Run(func(mock.Arguments) { close(reconnected) })
+		consumer.client = mockClient
+
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			consumer.runFailback(stop)
+			close(done)
+		}()
+
+		select {
+		case <-reconnected:
+		case <-time.After(time.Second):
+			t.Fatal("consumer did not reconnect after the failed failback")
+		}
+		close(stop)
+		<-done
+	})
+}
+
+// TestMQTTConsumer_Resubscribe tests restoring subscriptions after a reconnect
+func TestMQTTConsumer_Resubscribe(t *testing.T) {
+	t.Run("should retry a resubscription the broker did not acknowledge", func(t *testing.T) {
+		consumer := NewMQTTConsumer(MQTTConsumerConfig{
+			BrokerURL:      "tcp://localhost:1883",
+			ClientID:       "test-client",
+			ConnectTimeout: time.Second,
+		}, createTestLoggerFactory(t))
+		consumer.resubscribeBackoff = time.Millisecond
+		consumer.subscriptions["test/topic"] = func(mqtt.Client, mqtt.Message) {}
+
+		failed := NewMockMQTTToken(t)
+		failed.On("WaitTimeout", time.Second).Return(true)
+		failed.On("Error").Return(errors.New("not authorized"))
+		timedOut := NewMockMQTTToken(t)
+		timedOut.On("WaitTimeout", time.Second).Return(false)
+		succeeded := NewMockMQTTToken(t)
+		succeeded.On("WaitTimeout", time.Second).Return(true)
+		succeeded.On("Error").Return(nil)
+
+		mockClient := NewMockMQTTClient(t)
+		mockClient.On("IsConnected").Return(true).Twice()
+		mockClient.On("Subscribe", "test/topic", byte(1), mock.AnythingOfType("mqtt.MessageHandler")).Return(failed).Once()
+		mockClient.On("Subscribe", "test/topic", byte(1), mock.AnythingOfType("mqtt.MessageHandler")).Return(timedOut).Once()
+		mockClient.On("Subscribe", "test/topic", byte(1), mock.AnythingOfType("mqtt.MessageHandler")).Return(succeeded).Once()
+
+		consumer.resubscribe(mockClient)
+	})
+
+	t.Run("should stop retrying once the connection is lost", func(t *testing.T) {
+		consumer := NewMQTTConsumer(MQTTConsumerConfig{
+			BrokerURL:      "tcp://localhost:1883",
+			ClientID:       "test-client",
+			ConnectTimeout: time.Second,
+		}, createTestLoggerFactory(t))
+		consumer.resubscribeBackoff = time.Millisecond
+		consumer.subscriptions["test/topic"] = func(mqtt.Client, mqtt.Message) {}
+
+		failed := NewMockMQTTToken(t)
+		failed.On("WaitTimeout", time.Second).Return(true)
+		failed.On("Error").Return(errors.New("not authorized"))
+
+		mockClient := NewMockMQTTClient(t)
+		mockClient.On("IsConnected").Return(false).Once()
+		mockClient.On("Subscribe", "test/topic", byte(1), mock.AnythingOfType("mqtt.MessageHandler")).Return(failed).Once()
+
+		consumer.resubscribe(mockClient)
+	})
+}
+
 // TestMQTTConsumer_Subscribe tests the Subscribe method
 func TestMQTTConsumer_Subscribe(t *testing.T) {
 	tests := []struct {
@@ -292,7 +398,7 @@ func TestMQTTConsumer_Subscribe(t *testing.T) {
 				assert.Contains(t, err.Error(), tt.errMsg)
 			} else {
 				assert.NoError(t, err)
-				assert.Contains(t, consumer.handlers, tt.topic)
+				assert.Contains(t, consumer.subscriptions, tt.topic)
 			}
 
 			// Mock expectations are automatically checked via cleanup functions
@@ -438,56 +544,76 @@ func TestMQTTConsumer_IsConnected(t *testing.T) {
 	}
 }
 
+// testMessage is a minimal mqtt.Message delivered to subscription callbacks
+type testMessage struct {
+	topic   string
+	payload []byte
+}
+
+func (m *testMessage) Duplicate() bool   { return false }
+func (m *testMessage) Qos() byte         { return 1 }
+func (m *testMessage) Retained() bool    { return false }
+func (m *testMessage) Topic() string     { return m.topic }
+func (m *testMessage) MessageID() uint16 { return 0 }
+func (m *testMessage) Payload() []byte   { return m.payload }
+func (m *testMessage) Ack()              {}
+
+// subscribeCallback subscribes the handler through a mock client and returns the callback the
+// client was given for the topic
+func subscribeCallback(t *testing.T, consumer *MQTTConsumerImpl, topic string, handler eventports.MessageHandler) mqtt.MessageHandler {
+	mockClient := NewMockMQTTClient(t)
+	mockToken := NewMockMQTTToken(t)
+	mockClient.On("IsConnected").Return(true)
+	mockToken.On("Wait").Return(true)
+	mockToken.On("Error").Return(nil)
+
+	var callback mqtt.MessageHandler
+	mockClient.On("Subscribe", topic, byte(1), mock.AnythingOfType("mqtt.MessageHandler")).
+		Run(func(args mock.Arguments) { callback = args.Get(2).(mqtt.MessageHandler) }).
+		Return(mockToken)
+	consumer.client = mockClient
+
+	assert.NoError(t, consumer.Subscribe(context.Background(), topic, handler))
+	return callback
+}
+
 // TestMQTTConsumer_MessageHandling tests message handling functionality
 func TestMQTTConsumer_MessageHandling(t *testing.T) {
-	t.Run("message handler processes messages correctly", func(t *testing.T) {
-		config := MQTTConsumerConfig{
-			BrokerURL: "tcp://localhost:1883",
-			ClientID:  "test-client",
-		}
+	config := MQTTConsumerConfig{
+		BrokerURL: "tcp://localhost:1883",
+		ClientID:  "test-client",
+	}
 
+	t.Run("message handler receives messages of a wildcard subscription", func(t *testing.T) {
 		consumer := NewMQTTConsumer(config, createTestLoggerFactory(t))
 
-		// Create a test handler
 		var receivedTopic string
 		var receivedPayload []byte
-		var handlerError error
-
-		testHandler := func(ctx context.Context, topic string, payload []byte) error {
+		callback := subscribeCallback(t, consumer, "farms/+/devices/+/temperature", func(ctx context.Context, topic string, payload []byte) error {
 			receivedTopic = topic
 			receivedPayload = payload
-			return handlerError
-		}
-
-		consumer.handlers["test/topic"] = testHandler
+			return nil
+		})
 
-		// Test that our handler works correctly
-		err := testHandler(context.Background(), "test/topic", []byte("test payload"))
+		callback(nil, &testMessage{topic: "farms/north/devices/AA:BB:CC:DD:EE:FF/temperature", payload: []byte("test payload")})
 
-		assert.NoError(t, err)
-		assert.Equal(t, "test/topic", receivedTopic)
+		assert.Equal(t, "farms/north/devices/AA:BB:CC:DD:EE:FF/temperature", receivedTopic)
 		assert.Equal(t, []byte("test payload"), receivedPayload)
 	})
 
 	t.Run("message handler handles errors", func(t *testing.T) {
-		config := MQTTConsumerConfig{
-			BrokerURL: "tcp://localhost:1883",
-			ClientID:  "test-client",
-		}
-
 		consumer := NewMQTTConsumer(config, createTestLoggerFactory(t))
 
-		// Create a handler that returns an error
-		testHandler := func(ctx context.Context, topic string, payload []byte) error {
+		calls := 0
+		callback := subscribeCallback(t, consumer, "test/topic", func(ctx context.Context, topic string, payload []byte) error {
+			calls++
 			return errors.New("handler error")
-		}
-
-		consumer.handlers["test/topic"] = testHandler
+		})
 
-		// Test that the handler returns the expected error
-		err := testHandler(context.Background(), "test/topic", []byte("test payload"))
-		assert.Error(t, err)
-		assert.Equal(t, "handler error", err.Error())
+		assert.NotPanics(t, func() {
+			callback(nil, &testMessage{topic: "test/topic", payload: []byte("test payload")})
+		})
+		assert.Equal(t, 1, calls)
 	})
 }
 
@@ -714,10 +840,23 @@ func BenchmarkMQTTConsumer_MessageHandling(b *testing.B) {
 		return nil
 	}
 
-	consumer.handlers["benchmark/topic"] = testHandler
+	mockClient := &MockMQTTClient{}
+	mockToken := &MockMQTTToken{}
+	mockClient.On("IsConnected").Return(true)
+	mockToken.On("Wait").Return(true)
+	mockToken.On("Error").Return(nil)
+	var callback mqtt.MessageHandler
+	mockClient.On("Subscribe", "benchmark/topic", byte(1), mock.AnythingOfType("mqtt.MessageHandler")).
+		Run(func(args mock.Arguments) { callback = args.Get(2).(mqtt.MessageHandler) }).
+		Return(mockToken)
+	consumer.client = mockClient
+	if err := consumer.Subscribe(context.Background(), "benchmark/topic", testHandler); err != nil {
+		b.Fatal(err)
+	}
 
+	msg := &testMessage{topic: "benchmark/topic", payload: []byte("test payload")}
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = testHandler(context.Background(), "test/topic", []byte("test payload")) // Ignore error in benchmark
+		callback(nil, msg)
 	}
 }
@@ -0,0 +1,209 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// PausableConsumer wraps a MessageConsumer so consumption can be paused and resumed at runtime.
+// Pausing unsubscribes every topic while keeping the connection open, resuming subscribes them again.
+type PausableConsumer struct {
+	eventports.MessageConsumer
+	loggerFactory logger.LoggerFactory
+
+	mu            sync.Mutex
+	subscriptions map[string]eventports.MessageHandler
+	subscribeCtx  context.Context
+	paused        bool
+	pausedAt      time.Time
+
+	pausedGauge *metrics.Vec
+	transitions *metrics.Vec
+}
+
+// ConsumptionStatus describes whether a consumer is currently receiving messages
+type ConsumptionStatus struct {
+	Paused   bool       `json:"paused"`
+	PausedAt *time.Time `json:"paused_at,omitempty"`
+	Topics   []string   `json:"topics"`
+}
+
+// NewPausableConsumer wraps the given consumer
+func NewPausableConsumer(consumer eventports.MessageConsumer, loggerFactory logger.LoggerFactory) *PausableConsumer {
+	pausedGauge := metrics.NewGaugeVec("mqtt_consumer_paused", "Whether MQTT consumption is paused (1) or active (0)")
+	pausedGauge.Set(0)
+
+	return &PausableConsumer{
+		MessageConsumer: consumer,
+		loggerFactory:   loggerFactory,
+		subscriptions:   make(map[string]eventports.MessageHandler),
+		subscribeCtx:    context.Background(),
+		pausedGauge:     pausedGauge,
+		transitions:     metrics.NewCounterVec("mqtt_consumer_pause_transitions_total", "MQTT consumption pause and resume operations", "action"),
+	}
+}
+
+// Subscribe remembers the subscription and subscribes unless consumption is paused
+func (p *PausableConsumer) Subscribe(ctx context.Context, topic string, handler eventports.MessageHandler) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.subscriptions[topic] = handler
+	p.subscribeCtx = ctx
+	if p.paused {
+		return nil
+	}
+	return p.MessageConsumer.Subscribe(ctx, topic, handler)
+}
+
+// SubscribeHandlers remembers the ordered handlers as one subscription, so they are paused and
+// resumed together
+func (p *PausableConsumer) SubscribeHandlers(ctx context.Context, topic string, policy eventports.HandlerErrorPolicy, handlers ...eventports.MessageHandler) error {
+	return p.Subscribe(ctx, topic, eventports.FanOut(policy, handlers...))
+}
+
+// Unsubscribe forgets the subscription so it is not restored on resume
+func (p *PausableConsumer) Unsubscribe(topic string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.subscriptions, topic)
+	if p.paused {
+		return nil
+	}
+	return p.MessageConsumer.Unsubscribe(topic)
+}
+
+// Pause unsubscribes every topic without disconnecting; pausing twice is a no-op
+func (p *PausableConsumer) Pause(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.paused {
+		return nil
+	}
+
+	var unsubscribed []string
+	for _, topic := range p.topicsLocked() {
+		if err := p.MessageConsumer.Unsubscribe(topic); err != nil {
+			// Restore the topics already paused so the consumer is not left half paused
+			p.resubscribeLocked(unsubscribed)
+			return fmt.Errorf("failed to pause topic %s: %w", topic, err)
+		}
+		unsubscribed = append(unsubscribed, topic)
+	}
+
+	p.paused = true
+	p.pausedAt = time.Now().UTC()
+	p.pausedGauge.Set(1)
+	p.transitions.Inc("pause")
+
+	p.loggerFactory.Application().LogApplicationEvent("mqtt_consumption_paused", "mqtt_consumer",
+		zap.Strings("topics", p.topicsLocked()),
+	)
+	return nil
+}
+
+// Resume subscribes every remembered topic again; resuming an active consumer is a no-op
+func (p *PausableConsumer) Resume(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.paused {
+		return nil
+	}
+
+	for _, topic := range p.topicsLocked() {
+		if err := p.MessageConsumer.Subscribe(p.subscribeCtx, topic, p.subscriptions[topic]); err != nil {
+			return fmt.Errorf("failed to resume topic %s: %w", topic, err)
+		}
+	}
+
+	pausedFor := time.Since(p.pausedAt)
+	p.paused = false
+	p.pausedAt = time.Time{}
+	p.pausedGauge.Set(0)
+	p.transitions.Inc("resume")
+
+	p.loggerFactory.Application().LogApplicationEvent("mqtt_consumption_resumed", "mqtt_consumer",
+		zap.Strings("topics", p.topicsLocked()),
+		zap.Duration("paused_for", pausedFor),
+	)
+	return nil
+}
+
+// Status returns the current consumption state
+func (p *PausableConsumer) Status() ConsumptionStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status := ConsumptionStatus{Paused: p.paused, Topics: p.topicsLocked()}
+	if p.paused {
+		pausedAt := p.pausedAt
+		status.PausedAt = &pausedAt
+	}
+	return status
+}
+
+// ActiveOverrides reports paused consumption as an operator override
+func (p *PausableConsumer) ActiveOverrides() []*entities.OperatorOverride {
+	status := p.Status()
+	if !status.Paused {
+		return nil
+	}
+	return []*entities.OperatorOverride{{
+		Kind:        entities.OverrideKindConsumptionPaused,
+		Description: fmt.Sprintf("MQTT consumption paused on %d topics", len(status.Topics)),
+		Since:       status.PausedAt,
+	}}
+}
+
+// WrapReadiness adds the consumption state to the details of a readiness check.
+// A paused consumer stays ready so the admin endpoints remain reachable to resume it.
+func (p *PausableConsumer) WrapReadiness(check func(ctx context.Context) (interface{}, error)) func(ctx context.Context) (interface{}, error) {
+	return func(ctx context.Context) (interface{}, error) {
+		details, err := check(ctx)
+		return map[string]interface{}{
+			"consumption": p.Status(),
+			"connection":  details,
+		}, err
+	}
+}
+
+// Collect implements metrics.Collector
+func (p *PausableConsumer) Collect() []metrics.Family {
+	return append(p.pausedGauge.Collect(), p.transitions.Collect()...)
+}
+
+// resubscribeLocked restores the given topics after a failed pause, logging failures
+func (p *PausableConsumer) resubscribeLocked(topics []string) {
+	for _, topic := range topics {
+		if err := p.MessageConsumer.Subscribe(p.subscribeCtx, topic, p.subscriptions[topic]); err != nil {
+			p.loggerFactory.Core().Error("mqtt_resubscribe_failed",
+				zap.Error(err),
+				zap.String("topic", topic),
+				zap.String("component", "mqtt_consumer"),
+			)
+		}
+	}
+}
+
+// topicsLocked returns the remembered topics in a stable order
+func (p *PausableConsumer) topicsLocked() []string {
+	topics := make([]string, 0, len(p.subscriptions))
+	for topic := range p.subscriptions {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
@@ -0,0 +1,141 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createPausableTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func noopHandler(ctx context.Context, topic string, payload []byte) error {
+	return nil
+}
+
+func TestPausableConsumer_PauseResume(t *testing.T) {
+	ctx := context.Background()
+	inner := mocks.NewMockMessageConsumer(t)
+	consumer := NewPausableConsumer(inner, createPausableTestLoggerFactory(t))
+
+	inner.EXPECT().Subscribe(ctx, "sensors", mock.Anything).Return(nil).Twice()
+	inner.EXPECT().Subscribe(ctx, "registration", mock.Anything).Return(nil).Twice()
+	require.NoError(t, consumer.Subscribe(ctx, "sensors", noopHandler))
+	require.NoError(t, consumer.Subscribe(ctx, "registration", noopHandler))
+
+	inner.EXPECT().Unsubscribe("registration").Return(nil).Once()
+	inner.EXPECT().Unsubscribe("sensors").Return(nil).Once()
+	require.NoError(t, consumer.Pause(ctx))
+	require.NoError(t, consumer.Pause(ctx), "pausing twice should be a no-op")
+
+	status := consumer.Status()
+	assert.True(t, status.Paused)
+	assert.NotNil(t, status.PausedAt)
+	assert.Equal(t, []string{"registration", "sensors"}, status.Topics)
+	assert.Equal(t, float64(1), consumer.pausedGauge.Value())
+	overrides := consumer.ActiveOverrides()
+	require.Len(t, overrides, 1)
+	assert.Equal(t, entities.OverrideKindConsumptionPaused, overrides[0].Kind)
+	assert.Equal(t, status.PausedAt, overrides[0].Since)
+
+	require.NoError(t, consumer.Resume(ctx))
+	require.NoError(t, consumer.Resume(ctx), "resuming an active consumer should be a no-op")
+
+	status = consumer.Status()
+	assert.False(t, status.Paused)
+	assert.Nil(t, status.PausedAt)
+	assert.Equal(t, float64(0), consumer.pausedGauge.Value())
+	assert.Empty(t, consumer.ActiveOverrides())
+	assert.Equal(t, float64(1), consumer.transitions.Value("pause"))
+	assert.Equal(t, float64(1), consumer.transitions.Value("resume"))
+}
+
+func TestPausableConsumer_SubscribeWhilePaused(t *testing.T) {
+	ctx := context.Background()
+	inner := mocks.NewMockMessageConsumer(t)
+	consumer := NewPausableConsumer(inner, createPausableTestLoggerFactory(t))
+	require.NoError(t, consumer.Pause(ctx))
+
+	require.NoError(t, consumer.Subscribe(ctx, "sensors", noopHandler))
+	require.NoError(t, consumer.Subscribe(ctx, "registration", noopHandler))
+	require.NoError(t, consumer.Unsubscribe("registration"))
+
+	inner.EXPECT().Subscribe(ctx, "sensors", mock.Anything).Return(nil).Once()
+	require.NoError(t, consumer.Resume(ctx))
+}
+
+func TestPausableConsumer_SubscribeHandlers(t *testing.T) {
+	ctx := context.Background()
+	inner := mocks.NewMockMessageConsumer(t)
+	consumer := NewPausableConsumer(inner, createPausableTestLoggerFactory(t))
+
+	var subscribed []eventports.MessageHandler
+	inner.EXPECT().Subscribe(ctx, "sensors", mock.Anything).
+		Run(func(ctx context.Context, topic string, handler eventports.MessageHandler) {
+			subscribed = append(subscribed, handler)
+		}).Return(nil).Twice()
+
+	var calls []string
+	record := func(name string) eventports.MessageHandler {
+		return func(ctx context.Context, topic string, payload []byte) error {
+			calls = append(calls, name)
+			return errors.New(name + " failed")
+		}
+	}
+	require.NoError(t, consumer.SubscribeHandlers(ctx, "sensors", eventports.ContinueOnError, record("store"), record("alert")))
+
+	inner.EXPECT().Unsubscribe("sensors").Return(nil).Once()
+	require.NoError(t, consumer.Pause(ctx))
+	require.NoError(t, consumer.Resume(ctx))
+
+	require.Len(t, subscribed, 2)
+	err := subscribed[1](ctx, "sensors", nil)
+	assert.ErrorContains(t, err, "store failed")
+	assert.ErrorContains(t, err, "alert failed")
+	assert.Equal(t, []string{"store", "alert"}, calls, "the handlers should survive a pause in order")
+}
+
+func TestPausableConsumer_PauseFailure(t *testing.T) {
+	ctx := context.Background()
+	inner := mocks.NewMockMessageConsumer(t)
+	consumer := NewPausableConsumer(inner, createPausableTestLoggerFactory(t))
+
+	inner.EXPECT().Subscribe(ctx, mock.Anything, mock.Anything).Return(nil).Times(2)
+	require.NoError(t, consumer.Subscribe(ctx, "a", noopHandler))
+	require.NoError(t, consumer.Subscribe(ctx, "b", noopHandler))
+
+	inner.EXPECT().Unsubscribe("a").Return(nil).Once()
+	inner.EXPECT().Unsubscribe("b").Return(errors.New("not connected")).Once()
+	inner.EXPECT().Subscribe(ctx, "a", mock.Anything).Return(nil).Once()
+
+	err := consumer.Pause(ctx)
+	assert.ErrorContains(t, err, "failed to pause topic b")
+	assert.False(t, consumer.Status().Paused)
+}
+
+func TestPausableConsumer_WrapReadiness(t *testing.T) {
+	consumer := NewPausableConsumer(mocks.NewMockMessageConsumer(t), createPausableTestLoggerFactory(t))
+	require.NoError(t, consumer.Pause(context.Background()))
+
+	check := consumer.WrapReadiness(func(ctx context.Context) (interface{}, error) {
+		return "connected", nil
+	})
+
+	details, err := check(context.Background())
+	require.NoError(t, err, "a paused consumer should stay ready")
+	detailsMap := details.(map[string]interface{})
+	assert.True(t, detailsMap["consumption"].(ConsumptionStatus).Paused)
+	assert.Equal(t, "connected", detailsMap["connection"])
+}
@@ -0,0 +1,106 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// TelemetrySinkConfig holds configuration for re-publishing telemetry to an external MQTT broker
+type TelemetrySinkConfig struct {
+	BrokerURL   string
+	ClientID    string
+	Username    string
+	Password    string
+	TopicPrefix string // readings are published on {prefix}/{mac_address}
+	QoS         byte
+}
+
+// TelemetrySink implements the TelemetrySink port by re-publishing readings as JSON on an external broker
+type TelemetrySink struct {
+	config        TelemetrySinkConfig
+	client        mqtt.Client
+	loggerFactory logger.LoggerFactory
+}
+
+// NewTelemetrySink creates the sink and starts connecting in the background, so an unreachable
+// broker does not delay startup; writes fail until the connection is up and are retried by the caller.
+func NewTelemetrySink(config TelemetrySinkConfig, loggerFactory logger.LoggerFactory) *TelemetrySink {
+	opts := mqtt.NewClientOptions()
+	opts.AddBroker(config.BrokerURL)
+	opts.SetClientID(config.ClientID)
+	opts.SetUsername(config.Username)
+	opts.SetPassword(config.Password)
+	opts.SetAutoReconnect(true)
+	opts.SetConnectRetry(true)
+	opts.SetConnectRetryInterval(5 * time.Second)
+	opts.SetOnConnectHandler(func(client mqtt.Client) {
+		loggerFactory.Application().LogApplicationEvent("mqtt_telemetry_sink_connected", "mqtt_telemetry_sink",
+			zap.String("broker_url", config.BrokerURL),
+		)
+	})
+	opts.SetConnectionLostHandler(func(client mqtt.Client, err error) {
+		loggerFactory.Core().Warn("mqtt_telemetry_sink_connection_lost",
+			zap.Error(err),
+			zap.String("broker_url", config.BrokerURL),
+			zap.String("component", "mqtt_telemetry_sink"),
+		)
+	})
+
+	sink := &TelemetrySink{
+		config:        config,
+		client:        mqtt.NewClient(opts),
+		loggerFactory: loggerFactory,
+	}
+	sink.client.Connect()
+	return sink
+}
+
+// Name identifies the sink
+func (s *TelemetrySink) Name() string {
+	return "mqtt"
+}
+
+// Write publishes every reading and waits for the broker to acknowledge them
+func (s *TelemetrySink) Write(ctx context.Context, readings []*entities.SensorTemperatureHumidity) error {
+	if !s.client.IsConnectionOpen() {
+		return fmt.Errorf("telemetry sink broker %s is not connected", s.config.BrokerURL)
+	}
+
+	prefix := strings.TrimRight(s.config.TopicPrefix, "/")
+	tokens := make([]mqtt.Token, 0, len(readings))
+	for _, reading := range readings {
+		payload, err := json.Marshal(dtos.NewForwardedReading(reading))
+		if err != nil {
+			return fmt.Errorf("failed to encode reading: %w", err)
+		}
+		tokens = append(tokens, s.client.Publish(prefix+"/"+reading.MacAddress(), s.config.QoS, false, payload))
+	}
+
+	for _, token := range tokens {
+		select {
+		case <-token.Done():
+			if err := token.Error(); err != nil {
+				return fmt.Errorf("failed to publish reading: %w", err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Close disconnects from the broker
+func (s *TelemetrySink) Close() error {
+	s.client.Disconnect(250)
+	return nil
+}
@@ -0,0 +1,44 @@
+package mqtt
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSClientConfig configures TLS towards the broker; with a certificate and key the consumer
+// authenticates with them (mutual TLS)
+type TLSClientConfig struct {
+	CAFile             string // CA the broker certificate must be signed by, the system roots when empty
+	CertFile           string
+	KeyFile            string
+	InsecureSkipVerify bool // accepts any broker certificate, only meant for testing
+}
+
+// tlsConfig loads the CA and the optional client certificate
+func (c TLSClientConfig) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CAFile != "" {
+		caPEM, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MQTT broker CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("MQTT broker CA contains no certificates")
+		}
+		config.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load MQTT client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,83 @@
+package mqtt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCertificate writes a self-signed certificate and its key, returning their paths
+func writeTestCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "iot-go-soc-consumer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestTLSClientConfig_TLSConfig(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+
+	t.Run("should trust the system roots without a CA", func(t *testing.T) {
+		config, err := TLSClientConfig{}.tlsConfig()
+		require.NoError(t, err)
+		assert.Nil(t, config.RootCAs)
+		assert.Empty(t, config.Certificates)
+		assert.False(t, config.InsecureSkipVerify)
+		assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	})
+
+	t.Run("should load the CA and the client certificate", func(t *testing.T) {
+		config, err := TLSClientConfig{CAFile: certFile, CertFile: certFile, KeyFile: keyFile}.tlsConfig()
+		require.NoError(t, err)
+		assert.NotNil(t, config.RootCAs)
+		require.Len(t, config.Certificates, 1)
+	})
+
+	t.Run("should skip verification when asked to", func(t *testing.T) {
+		config, err := TLSClientConfig{InsecureSkipVerify: true}.tlsConfig()
+		require.NoError(t, err)
+		assert.True(t, config.InsecureSkipVerify)
+	})
+
+	t.Run("should fail on unusable files", func(t *testing.T) {
+		_, err := TLSClientConfig{CAFile: filepath.Join(t.TempDir(), "missing.crt")}.tlsConfig()
+		assert.ErrorContains(t, err, "failed to read MQTT broker CA")
+
+		_, err = TLSClientConfig{CAFile: keyFile}.tlsConfig()
+		assert.ErrorContains(t, err, "contains no certificates")
+
+		_, err = TLSClientConfig{CertFile: certFile}.tlsConfig()
+		assert.ErrorContains(t, err, "failed to load MQTT client certificate")
+	})
+}
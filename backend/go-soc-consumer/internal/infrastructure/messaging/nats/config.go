@@ -8,14 +8,17 @@ import (
 
 // NATSConfig holds NATS connection configuration
 type NATSConfig struct {
-	URL                string
-	ClientID           string
-	SubjectPrefix      string
-	ConnectTimeout     time.Duration
-	ReconnectWait      time.Duration
+	URL                  string
+	ClientID             string
+	SubjectPrefix        string
+	ConnectTimeout       time.Duration
+	ReconnectWait        time.Duration
 	MaxReconnectAttempts int
-	PingInterval       time.Duration
-	MaxPingsOutstanding int
+	PingInterval         time.Duration
+	MaxPingsOutstanding  int
+	ReconnectBufSize     int         // bytes of publishes buffered while reconnecting; publishes fail once it is full
+	Publisher            Credentials // credentials of the publisher connection
+	Subscriber           Credentials // credentials of the subscriber connection
 }
 
 // DefaultNATSConfig returns default NATS configuration with environment variable overrides
@@ -29,6 +32,7 @@ func DefaultNATSConfig() *NATSConfig {
 		MaxReconnectAttempts: 60, // Will keep trying for ~2 minutes
 		PingInterval:         30 * time.Second,
 		MaxPingsOutstanding:  2,
+		ReconnectBufSize:     8 * 1024 * 1024,
 	}
 
 	// Override with environment variables if present
@@ -74,5 +78,13 @@ func (c *NATSConfig) Validate() error {
 		return fmt.Errorf("reconnect wait must be positive")
 	}
 
+	if err := c.Publisher.Validate(); err != nil {
+		return fmt.Errorf("publisher credentials: %w", err)
+	}
+
+	if err := c.Subscriber.Validate(); err != nil {
+		return fmt.Errorf("subscriber credentials: %w", err)
+	}
+
 	return nil
-}
\ No newline at end of file
+}
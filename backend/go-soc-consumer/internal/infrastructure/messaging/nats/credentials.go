@@ -0,0 +1,51 @@
+package nats
+
+import (
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Credentials authenticate a NATS connection. Set at most one of them; without any the connection
+// is anonymous. Giving the publisher and the subscriber their own users lets the server restrict
+// each to the subjects it needs.
+type Credentials struct {
+	CredsFile    string // JWT and NKey seed of a user of a decentralized auth account (.creds file)
+	NKeySeedFile string // NKey seed of a user listed in the server configuration
+}
+
+// Validate ensures at most one kind of credentials is set
+func (c Credentials) Validate() error {
+	if c.CredsFile != "" && c.NKeySeedFile != "" {
+		return fmt.Errorf("set either a credentials file or an NKey seed file, not both")
+	}
+	return nil
+}
+
+// Options returns the connection options authenticating with the credentials
+func (c Credentials) Options() ([]nats.Option, error) {
+	switch {
+	case c.CredsFile != "":
+		return []nats.Option{nats.UserCredentials(c.CredsFile)}, nil
+	case c.NKeySeedFile != "":
+		option, err := nats.NkeyOptionFromSeed(c.NKeySeedFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load NKey seed: %w", err)
+		}
+		return []nats.Option{option}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// AuthMethod names the kind of credentials for logging
+func (c Credentials) AuthMethod() string {
+	switch {
+	case c.CredsFile != "":
+		return "creds"
+	case c.NKeySeedFile != "":
+		return "nkey"
+	default:
+		return "none"
+	}
+}
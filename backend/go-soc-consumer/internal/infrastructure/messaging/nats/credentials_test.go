@@ -0,0 +1,43 @@
+package nats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentials_Options(t *testing.T) {
+	t.Run("anonymous without credentials", func(t *testing.T) {
+		options, err := Credentials{}.Options()
+		require.NoError(t, err)
+		assert.Empty(t, options)
+		assert.Equal(t, "none", Credentials{}.AuthMethod())
+	})
+
+	t.Run("loads an NKey seed", func(t *testing.T) {
+		user, err := nkeys.CreateUser()
+		require.NoError(t, err)
+		seed, err := user.Seed()
+		require.NoError(t, err)
+		seedFile := filepath.Join(t.TempDir(), "publisher.nk")
+		require.NoError(t, os.WriteFile(seedFile, seed, 0o600))
+
+		options, err := Credentials{NKeySeedFile: seedFile}.Options()
+		require.NoError(t, err)
+		assert.Len(t, options, 1)
+	})
+
+	t.Run("fails on a missing NKey seed", func(t *testing.T) {
+		_, err := Credentials{NKeySeedFile: filepath.Join(t.TempDir(), "missing.nk")}.Options()
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects both kinds of credentials", func(t *testing.T) {
+		assert.Error(t, Credentials{CredsFile: "user.creds", NKeySeedFile: "user.nk"}.Validate())
+		assert.NoError(t, Credentials{CredsFile: "user.creds"}.Validate())
+	})
+}
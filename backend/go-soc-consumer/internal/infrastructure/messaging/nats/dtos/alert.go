@@ -0,0 +1,25 @@
+package dtos
+
+import "time"
+
+// Alert is published when an alert rule starts firing and when it resolves
+//
+//eventgen:event type=alert version=1
+type Alert struct {
+	EventID     string  `json:"event_id"`
+	EventType   string  `json:"event_type"`
+	Rule        string  `json:"rule"`
+	Status      string  `json:"status"`
+	Severity    string  `json:"severity"`
+	MACAddress  string  `json:"mac_address,omitempty"`
+	SensorType  string  `json:"sensor_type,omitempty"`
+	Channel     int     `json:"channel"`
+	Value       float64 `json:"value"`
+	Threshold   float64 `json:"threshold"`
+	Condition   string  `json:"condition,omitempty"` // the condition of a composite rule
+	Description string  `json:"description"`
+
+	LocalizedDescriptions map[string]string `json:"localized_descriptions,omitempty"`
+	Since                 time.Time         `json:"since"`
+	RaisedAt              time.Time         `json:"raised_at"`
+}
@@ -2,6 +2,9 @@ package dtos
 
 import "time"
 
+// DeviceDetectedEvent is published when a device registers
+//
+//eventgen:event type=device.detected version=1
 type DeviceDetectedEvent struct {
 	MACAddress string    `json:"mac_address"`
 	IPAddress  string    `json:"ip_address"`
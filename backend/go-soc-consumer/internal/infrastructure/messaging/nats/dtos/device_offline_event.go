@@ -0,0 +1,15 @@
+package dtos
+
+import "time"
+
+// DeviceOfflineEvent is published when a device that stopped reporting is marked offline
+//
+//eventgen:event type=device.offline version=1
+type DeviceOfflineEvent struct {
+	MACAddress string    `json:"mac_address"`
+	FarmID     string    `json:"farm_id,omitempty"`
+	LastSeen   time.Time `json:"last_seen"`
+	DetectedAt time.Time `json:"detected_at"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import "time"
+
+// DeviceOnlineEvent is published when a health check finds a device alive that was not online
+//
+//eventgen:event type=device.online version=1
+type DeviceOnlineEvent struct {
+	MACAddress string    `json:"mac_address"`
+	FarmID     string    `json:"farm_id,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	DetectedAt time.Time `json:"detected_at"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+}
@@ -0,0 +1,20 @@
+package dtos
+
+import "time"
+
+// DeviceTransferEvent is published when a transfer of a device between farms is initiated,
+// accepted, completed or cancelled
+//
+//eventgen:event type=device.transfer version=1
+type DeviceTransferEvent struct {
+	TransferID    string    `json:"transfer_id"`
+	MACAddress    string    `json:"mac_address"`
+	FromFarmID    string    `json:"from_farm_id,omitempty"`
+	ToFarmID      string    `json:"to_farm_id"`
+	Status        string    `json:"status"`
+	HistoryPolicy string    `json:"history_policy"`
+	Actor         string    `json:"actor"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	EventID       string    `json:"event_id"`
+	EventType     string    `json:"event_type"`
+}
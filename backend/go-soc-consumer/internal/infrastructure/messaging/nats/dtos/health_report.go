@@ -0,0 +1,22 @@
+package dtos
+
+import "time"
+
+type DependencyHealth struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// HealthReport is published periodically for fleet monitoring
+//
+//eventgen:event type=system.health version=1 mapper=ToHealthReportDTO
+type HealthReport struct {
+	EventType     string                      `json:"event_type"`
+	Status        string                      `json:"status"`
+	Version       string                      `json:"version"`
+	StartedAt     time.Time                   `json:"started_at"`
+	UptimeSeconds float64                     `json:"uptime_seconds"`
+	CheckedAt     time.Time                   `json:"checked_at"`
+	Dependencies  map[string]DependencyHealth `json:"dependencies"`
+}
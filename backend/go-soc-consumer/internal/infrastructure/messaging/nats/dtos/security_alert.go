@@ -0,0 +1,19 @@
+package dtos
+
+import "time"
+
+// SecurityAlert is published when a suspicious pattern is detected in device traffic
+//
+//eventgen:event type=security.alert version=1
+type SecurityAlert struct {
+	EventID     string `json:"event_id"`
+	EventType   string `json:"event_type"`
+	Kind        string `json:"kind"`
+	Severity    string `json:"severity"`
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
+
+	LocalizedDescriptions map[string]string      `json:"localized_descriptions,omitempty"`
+	Context               map[string]interface{} `json:"context,omitempty"`
+	DetectedAt            time.Time              `json:"detected_at"`
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/nats/mappers"
 	devicehealth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_health"
 )
@@ -40,6 +41,11 @@ func (h *DeviceHealthHandler) HandleMessage(ctx context.Context, subject string,
 func (h *DeviceHealthHandler) processDeviceDetectedEvent(ctx context.Context, payload []byte) error {
 	log.Printf("Processing device detected event, payload size: %d bytes", len(payload))
 
+	// Reject other events from their headers before parsing the payload
+	if metadata, ok := eventports.EventMetadataFromContext(ctx); ok && metadata.EventType != "" && metadata.EventType != events.DeviceDetectedEventType {
+		return fmt.Errorf("invalid event type: %s", metadata.EventType)
+	}
+
 	// Parse JSON payload into domain event
 	event, err := h.mapper.ToDomainEventFromBytes(payload)
 	if err != nil {
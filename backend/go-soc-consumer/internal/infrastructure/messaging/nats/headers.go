@@ -0,0 +1,59 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/nats-io/nats.go"
+
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/nats/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/observability"
+)
+
+// Headers attached to every published event so consumers can route and trace it without
+// unmarshalling the payload
+const (
+	HeaderFarmID        = "Farm-Id"        // farm namespace the event belongs to, absent for shared topics
+	HeaderEventType     = "Event-Type"     // event_type of the payload, e.g. device.detected
+	HeaderSchemaVersion = "Schema-Version" // version of the payload schema
+	HeaderTraceParent   = "traceparent"    // W3C trace context of the publishing call
+)
+
+// traceParentPattern matches a version 00 W3C traceparent: version-trace id-parent id-flags
+var traceParentPattern = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+// eventHeaders builds the headers of an event from the schema of its payload and the publishing context
+func eventHeaders(ctx context.Context, schema mappers.EventSchema) nats.Header {
+	header := nats.Header{}
+	header.Set(HeaderEventType, schema.Type)
+	header.Set(HeaderSchemaVersion, schema.Version)
+	if farmID := eventports.FarmIDFromContext(ctx); farmID != "" {
+		header.Set(HeaderFarmID, farmID)
+	}
+	if span, ok := observability.SpanFromContext(ctx); ok {
+		header.Set(HeaderTraceParent, fmt.Sprintf("00-%s-%s-01", span.TraceID, span.SpanID))
+	}
+	return header
+}
+
+// contextFromHeaders attaches the farm, the event metadata and the trace context of a received
+// message to its context. Messages from publishers that send no headers keep the context unchanged.
+func contextFromHeaders(ctx context.Context, header nats.Header) context.Context {
+	if header == nil {
+		return ctx
+	}
+	if farmID := header.Get(HeaderFarmID); farmID != "" {
+		ctx = eventports.ContextWithFarmID(ctx, farmID)
+	}
+	eventType, schemaVersion := header.Get(HeaderEventType), header.Get(HeaderSchemaVersion)
+	if eventType != "" || schemaVersion != "" {
+		ctx = eventports.ContextWithEventMetadata(ctx, eventports.EventMetadata{EventType: eventType, SchemaVersion: schemaVersion})
+	}
+	// Port calls made while handling the message continue the trace of the publisher
+	if match := traceParentPattern.FindStringSubmatch(header.Get(HeaderTraceParent)); match != nil {
+		ctx = observability.ContextWithSpan(ctx, observability.Span{TraceID: match[1], SpanID: match[2]})
+	}
+	return ctx
+}
@@ -0,0 +1,65 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/nats/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/observability"
+)
+
+func TestEventHeaders(t *testing.T) {
+	span := observability.Span{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"}
+	ctx := observability.ContextWithSpan(eventports.ContextWithFarmID(context.Background(), "farm-1"), span)
+
+	header := eventHeaders(ctx, mappers.EventSchema{Type: events.DeviceDetectedEventType, Version: "1"})
+
+	assert.Equal(t, "farm-1", header.Get(HeaderFarmID))
+	assert.Equal(t, events.DeviceDetectedEventType, header.Get(HeaderEventType))
+	assert.Equal(t, "1", header.Get(HeaderSchemaVersion))
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", header.Get(HeaderTraceParent))
+
+	t.Run("should round trip into the message context", func(t *testing.T) {
+		received := contextFromHeaders(context.Background(), header)
+
+		assert.Equal(t, "farm-1", eventports.FarmIDFromContext(received))
+		metadata, ok := eventports.EventMetadataFromContext(received)
+		require.True(t, ok)
+		assert.Equal(t, eventports.EventMetadata{EventType: events.DeviceDetectedEventType, SchemaVersion: "1"}, metadata)
+		receivedSpan, ok := observability.SpanFromContext(received)
+		require.True(t, ok)
+		assert.Equal(t, span, receivedSpan)
+	})
+
+	t.Run("should omit the farm and trace when the context has none", func(t *testing.T) {
+		header := eventHeaders(context.Background(), mappers.EventSchema{Type: events.SystemHealthEventType, Version: "1"})
+
+		assert.Empty(t, header.Get(HeaderFarmID))
+		assert.Empty(t, header.Get(HeaderTraceParent))
+		assert.Equal(t, events.SystemHealthEventType, header.Get(HeaderEventType))
+	})
+}
+
+func TestContextFromHeaders(t *testing.T) {
+	t.Run("should keep the context of messages without headers", func(t *testing.T) {
+		ctx := contextFromHeaders(context.Background(), nil)
+
+		_, ok := eventports.EventMetadataFromContext(ctx)
+		assert.False(t, ok)
+		assert.Empty(t, eventports.FarmIDFromContext(ctx))
+	})
+
+	t.Run("should ignore a malformed traceparent", func(t *testing.T) {
+		header := nats.Header{}
+		header.Set(HeaderTraceParent, "01-not-a-trace-00")
+
+		_, ok := observability.SpanFromContext(contextFromHeaders(context.Background(), header))
+		assert.False(t, ok)
+	})
+}
@@ -34,16 +34,3 @@ func (m *DeviceDetectedEventMapper) ToDomainEventFromBytes(payload []byte) (*ent
 	}
 	return m.ToDomainEventFromDTO(&dto), nil
 }
-
-func (m *DeviceDetectedEventMapper) ToDTOFromDomainEvent(event *entities.DeviceDetectedEvent) *dtos.DeviceDetectedEvent {
-	if event == nil {
-		return nil
-	}
-	return &dtos.DeviceDetectedEvent{
-		MACAddress: event.MACAddress,
-		IPAddress:  event.IPAddress,
-		DetectedAt: event.DetectedAt,
-		EventID:    event.EventID,
-		EventType:  event.EventType,
-	}
-}
@@ -1,19 +1,11 @@
 package mappers
 
-import (
-	"fmt"
-	"reflect"
-
-	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
-)
-
-func (m *DeviceDetectedEventMapper) ToDTOFromInterface(data interface{}) (dto interface{}, err error) {
-	dataType := reflect.TypeOf(data)
-
-	switch dataType {
-	case reflect.TypeOf(&entities.DeviceDetectedEvent{}):
-		return m.ToDTOFromDomainEvent(data.(*entities.DeviceDetectedEvent)), nil
-	default:
-		return nil, fmt.Errorf("unsupported data type: %s", dataType)
-	}
+// The event mappers are regenerated from the annotated payloads, so a new event is published by
+// annotating its payload and running go generate
+//go:generate go run ../../../../../cmd/eventgen -dtos ../dtos -entities ../../../../domain/entities -out events_gen.go
+
+// EventSchema identifies the payload published for an event
+type EventSchema struct {
+	Type    string // event_type of the payload
+	Version string // version of the payload schema, bumped on breaking changes
 }
@@ -0,0 +1,350 @@
+// Code generated by eventgen from ../dtos. DO NOT EDIT.
+
+package mappers
+
+import (
+	"fmt"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/nats/dtos"
+)
+
+// ToEventDTO maps a domain event to the payload published for it and validates the payload
+func ToEventDTO(event interface{}) (interface{}, EventSchema, error) {
+	switch event := event.(type) {
+	case *entities.Alert:
+		dto := ToAlertDTO(event)
+		if err := ValidateAlert(dto); err != nil {
+			return nil, EventSchema{}, err
+		}
+		return dto, EventSchema{Type: "alert", Version: "1"}, nil
+	case *entities.DeviceDetectedEvent:
+		dto := ToDeviceDetectedEventDTO(event)
+		if err := ValidateDeviceDetectedEvent(dto); err != nil {
+			return nil, EventSchema{}, err
+		}
+		return dto, EventSchema{Type: "device.detected", Version: "1"}, nil
+	case *entities.DeviceOfflineEvent:
+		dto := ToDeviceOfflineEventDTO(event)
+		if err := ValidateDeviceOfflineEvent(dto); err != nil {
+			return nil, EventSchema{}, err
+		}
+		return dto, EventSchema{Type: "device.offline", Version: "1"}, nil
+	case *entities.DeviceOnlineEvent:
+		dto := ToDeviceOnlineEventDTO(event)
+		if err := ValidateDeviceOnlineEvent(dto); err != nil {
+			return nil, EventSchema{}, err
+		}
+		return dto, EventSchema{Type: "device.online", Version: "1"}, nil
+	case *entities.DeviceTransferEvent:
+		dto := ToDeviceTransferEventDTO(event)
+		if err := ValidateDeviceTransferEvent(dto); err != nil {
+			return nil, EventSchema{}, err
+		}
+		return dto, EventSchema{Type: "device.transfer", Version: "1"}, nil
+	case *entities.HealthReport:
+		dto := ToHealthReportDTO(event)
+		if err := ValidateHealthReport(dto); err != nil {
+			return nil, EventSchema{}, err
+		}
+		return dto, EventSchema{Type: "system.health", Version: "1"}, nil
+	case *entities.SecurityAlert:
+		dto := ToSecurityAlertDTO(event)
+		if err := ValidateSecurityAlert(dto); err != nil {
+			return nil, EventSchema{}, err
+		}
+		return dto, EventSchema{Type: "security.alert", Version: "1"}, nil
+	default:
+		return nil, EventSchema{}, fmt.Errorf("unsupported event type: %T", event)
+	}
+}
+
+// ToAlertDTO maps the entity to version 1 of the alert payload
+func ToAlertDTO(event *entities.Alert) *dtos.Alert {
+	if event == nil {
+		return nil
+	}
+	return &dtos.Alert{
+		EventID:               event.EventID,
+		EventType:             event.EventType,
+		Rule:                  event.Rule,
+		Status:                event.Status,
+		Severity:              event.Severity,
+		MACAddress:            event.MACAddress,
+		SensorType:            event.SensorType,
+		Channel:               event.Channel,
+		Value:                 event.Value,
+		Threshold:             event.Threshold,
+		Condition:             event.Condition,
+		Description:           event.Description,
+		LocalizedDescriptions: event.LocalizedDescriptions,
+		Since:                 event.Since,
+		RaisedAt:              event.RaisedAt,
+	}
+}
+
+// ValidateAlert checks the fields required by version 1 of the alert payload
+func ValidateAlert(dto *dtos.Alert) error {
+	if dto == nil {
+		return fmt.Errorf("alert: payload is missing")
+	}
+	if dto.EventType != "alert" {
+		return fmt.Errorf("alert: event_type must be %q, got %q", "alert", dto.EventType)
+	}
+	if dto.EventID == "" {
+		return fmt.Errorf("alert: event_id is required")
+	}
+	if dto.Rule == "" {
+		return fmt.Errorf("alert: rule is required")
+	}
+	if dto.Status == "" {
+		return fmt.Errorf("alert: status is required")
+	}
+	if dto.Severity == "" {
+		return fmt.Errorf("alert: severity is required")
+	}
+	if dto.Description == "" {
+		return fmt.Errorf("alert: description is required")
+	}
+	if dto.Since.IsZero() {
+		return fmt.Errorf("alert: since is required")
+	}
+	if dto.RaisedAt.IsZero() {
+		return fmt.Errorf("alert: raised_at is required")
+	}
+	return nil
+}
+
+// ToDeviceDetectedEventDTO maps the entity to version 1 of the device.detected payload
+func ToDeviceDetectedEventDTO(event *entities.DeviceDetectedEvent) *dtos.DeviceDetectedEvent {
+	if event == nil {
+		return nil
+	}
+	return &dtos.DeviceDetectedEvent{
+		MACAddress: event.MACAddress,
+		IPAddress:  event.IPAddress,
+		DetectedAt: event.DetectedAt,
+		EventID:    event.EventID,
+		EventType:  event.EventType,
+	}
+}
+
+// ValidateDeviceDetectedEvent checks the fields required by version 1 of the device.detected payload
+func ValidateDeviceDetectedEvent(dto *dtos.DeviceDetectedEvent) error {
+	if dto == nil {
+		return fmt.Errorf("device.detected: payload is missing")
+	}
+	if dto.EventType != "device.detected" {
+		return fmt.Errorf("device.detected: event_type must be %q, got %q", "device.detected", dto.EventType)
+	}
+	if dto.MACAddress == "" {
+		return fmt.Errorf("device.detected: mac_address is required")
+	}
+	if dto.IPAddress == "" {
+		return fmt.Errorf("device.detected: ip_address is required")
+	}
+	if dto.DetectedAt.IsZero() {
+		return fmt.Errorf("device.detected: detected_at is required")
+	}
+	if dto.EventID == "" {
+		return fmt.Errorf("device.detected: event_id is required")
+	}
+	return nil
+}
+
+// ToDeviceOfflineEventDTO maps the entity to version 1 of the device.offline payload
+func ToDeviceOfflineEventDTO(event *entities.DeviceOfflineEvent) *dtos.DeviceOfflineEvent {
+	if event == nil {
+		return nil
+	}
+	return &dtos.DeviceOfflineEvent{
+		MACAddress: event.MACAddress,
+		FarmID:     event.FarmID,
+		LastSeen:   event.LastSeen,
+		DetectedAt: event.DetectedAt,
+		EventID:    event.EventID,
+		EventType:  event.EventType,
+	}
+}
+
+// ValidateDeviceOfflineEvent checks the fields required by version 1 of the device.offline payload
+func ValidateDeviceOfflineEvent(dto *dtos.DeviceOfflineEvent) error {
+	if dto == nil {
+		return fmt.Errorf("device.offline: payload is missing")
+	}
+	if dto.EventType != "device.offline" {
+		return fmt.Errorf("device.offline: event_type must be %q, got %q", "device.offline", dto.EventType)
+	}
+	if dto.MACAddress == "" {
+		return fmt.Errorf("device.offline: mac_address is required")
+	}
+	if dto.LastSeen.IsZero() {
+		return fmt.Errorf("device.offline: last_seen is required")
+	}
+	if dto.DetectedAt.IsZero() {
+		return fmt.Errorf("device.offline: detected_at is required")
+	}
+	if dto.EventID == "" {
+		return fmt.Errorf("device.offline: event_id is required")
+	}
+	return nil
+}
+
+// ToDeviceOnlineEventDTO maps the entity to version 1 of the device.online payload
+func ToDeviceOnlineEventDTO(event *entities.DeviceOnlineEvent) *dtos.DeviceOnlineEvent {
+	if event == nil {
+		return nil
+	}
+	return &dtos.DeviceOnlineEvent{
+		MACAddress: event.MACAddress,
+		FarmID:     event.FarmID,
+		IPAddress:  event.IPAddress,
+		DetectedAt: event.DetectedAt,
+		EventID:    event.EventID,
+		EventType:  event.EventType,
+	}
+}
+
+// ValidateDeviceOnlineEvent checks the fields required by version 1 of the device.online payload
+func ValidateDeviceOnlineEvent(dto *dtos.DeviceOnlineEvent) error {
+	if dto == nil {
+		return fmt.Errorf("device.online: payload is missing")
+	}
+	if dto.EventType != "device.online" {
+		return fmt.Errorf("device.online: event_type must be %q, got %q", "device.online", dto.EventType)
+	}
+	if dto.MACAddress == "" {
+		return fmt.Errorf("device.online: mac_address is required")
+	}
+	if dto.DetectedAt.IsZero() {
+		return fmt.Errorf("device.online: detected_at is required")
+	}
+	if dto.EventID == "" {
+		return fmt.Errorf("device.online: event_id is required")
+	}
+	return nil
+}
+
+// ToDeviceTransferEventDTO maps the entity to version 1 of the device.transfer payload
+func ToDeviceTransferEventDTO(event *entities.DeviceTransferEvent) *dtos.DeviceTransferEvent {
+	if event == nil {
+		return nil
+	}
+	return &dtos.DeviceTransferEvent{
+		TransferID:    event.TransferID,
+		MACAddress:    event.MACAddress,
+		FromFarmID:    event.FromFarmID,
+		ToFarmID:      event.ToFarmID,
+		Status:        event.Status,
+		HistoryPolicy: event.HistoryPolicy,
+		Actor:         event.Actor,
+		OccurredAt:    event.OccurredAt,
+		EventID:       event.EventID,
+		EventType:     event.EventType,
+	}
+}
+
+// ValidateDeviceTransferEvent checks the fields required by version 1 of the device.transfer payload
+func ValidateDeviceTransferEvent(dto *dtos.DeviceTransferEvent) error {
+	if dto == nil {
+		return fmt.Errorf("device.transfer: payload is missing")
+	}
+	if dto.EventType != "device.transfer" {
+		return fmt.Errorf("device.transfer: event_type must be %q, got %q", "device.transfer", dto.EventType)
+	}
+	if dto.TransferID == "" {
+		return fmt.Errorf("device.transfer: transfer_id is required")
+	}
+	if dto.MACAddress == "" {
+		return fmt.Errorf("device.transfer: mac_address is required")
+	}
+	if dto.ToFarmID == "" {
+		return fmt.Errorf("device.transfer: to_farm_id is required")
+	}
+	if dto.Status == "" {
+		return fmt.Errorf("device.transfer: status is required")
+	}
+	if dto.HistoryPolicy == "" {
+		return fmt.Errorf("device.transfer: history_policy is required")
+	}
+	if dto.Actor == "" {
+		return fmt.Errorf("device.transfer: actor is required")
+	}
+	if dto.OccurredAt.IsZero() {
+		return fmt.Errorf("device.transfer: occurred_at is required")
+	}
+	if dto.EventID == "" {
+		return fmt.Errorf("device.transfer: event_id is required")
+	}
+	return nil
+}
+
+// ValidateHealthReport checks the fields required by version 1 of the system.health payload
+func ValidateHealthReport(dto *dtos.HealthReport) error {
+	if dto == nil {
+		return fmt.Errorf("system.health: payload is missing")
+	}
+	if dto.EventType != "system.health" {
+		return fmt.Errorf("system.health: event_type must be %q, got %q", "system.health", dto.EventType)
+	}
+	if dto.Status == "" {
+		return fmt.Errorf("system.health: status is required")
+	}
+	if dto.Version == "" {
+		return fmt.Errorf("system.health: version is required")
+	}
+	if dto.StartedAt.IsZero() {
+		return fmt.Errorf("system.health: started_at is required")
+	}
+	if dto.CheckedAt.IsZero() {
+		return fmt.Errorf("system.health: checked_at is required")
+	}
+	return nil
+}
+
+// ToSecurityAlertDTO maps the entity to version 1 of the security.alert payload
+func ToSecurityAlertDTO(event *entities.SecurityAlert) *dtos.SecurityAlert {
+	if event == nil {
+		return nil
+	}
+	return &dtos.SecurityAlert{
+		EventID:               event.EventID,
+		EventType:             event.EventType,
+		Kind:                  event.Kind,
+		Severity:              event.Severity,
+		Subject:               event.Subject,
+		Description:           event.Description,
+		LocalizedDescriptions: event.LocalizedDescriptions,
+		Context:               event.Context,
+		DetectedAt:            event.DetectedAt,
+	}
+}
+
+// ValidateSecurityAlert checks the fields required by version 1 of the security.alert payload
+func ValidateSecurityAlert(dto *dtos.SecurityAlert) error {
+	if dto == nil {
+		return fmt.Errorf("security.alert: payload is missing")
+	}
+	if dto.EventType != "security.alert" {
+		return fmt.Errorf("security.alert: event_type must be %q, got %q", "security.alert", dto.EventType)
+	}
+	if dto.EventID == "" {
+		return fmt.Errorf("security.alert: event_id is required")
+	}
+	if dto.Kind == "" {
+		return fmt.Errorf("security.alert: kind is required")
+	}
+	if dto.Severity == "" {
+		return fmt.Errorf("security.alert: severity is required")
+	}
+	if dto.Subject == "" {
+		return fmt.Errorf("security.alert: subject is required")
+	}
+	if dto.Description == "" {
+		return fmt.Errorf("security.alert: description is required")
+	}
+	if dto.DetectedAt.IsZero() {
+		return fmt.Errorf("security.alert: detected_at is required")
+	}
+	return nil
+}
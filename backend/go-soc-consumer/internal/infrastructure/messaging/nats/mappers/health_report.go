@@ -0,0 +1,32 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/nats/dtos"
+)
+
+// ToHealthReportDTO maps a health report to the payload published for fleet monitoring
+func ToHealthReportDTO(report *entities.HealthReport) *dtos.HealthReport {
+	if report == nil {
+		return nil
+	}
+
+	dependencies := make(map[string]dtos.DependencyHealth, len(report.Dependencies))
+	for name, dependency := range report.Dependencies {
+		dependencies[name] = dtos.DependencyHealth{
+			Status:    dependency.Status,
+			LatencyMS: float64(dependency.Latency.Microseconds()) / 1000,
+			Error:     dependency.Error,
+		}
+	}
+
+	return &dtos.HealthReport{
+		EventType:     report.EventType,
+		Status:        report.Status,
+		Version:       report.Version,
+		StartedAt:     report.StartedAt,
+		UptimeSeconds: report.Uptime.Seconds(),
+		CheckedAt:     report.CheckedAt,
+		Dependencies:  dependencies,
+	}
+}
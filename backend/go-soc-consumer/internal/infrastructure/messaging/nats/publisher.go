@@ -21,7 +21,6 @@ type publisher struct {
 	conn          *nats.Conn
 	loggerFactory logger.LoggerFactory
 	mu            sync.RWMutex
-	mapper        *mappers.DeviceDetectedEventMapper
 }
 
 // NewNATSPublisher creates a new NATS event publisher
@@ -45,7 +44,6 @@ func NewNATSPublisher(config *NATSConfig, loggerFactory logger.LoggerFactory) (p
 	p := &publisher{
 		config:        config,
 		loggerFactory: loggerFactory,
-		mapper:        mappers.NewDeviceDetectedEventMapper(),
 	}
 
 	// Establish connection
@@ -66,6 +64,7 @@ func (p *publisher) connect() error {
 		nats.Timeout(p.config.ConnectTimeout),
 		nats.ReconnectWait(p.config.ReconnectWait),
 		nats.MaxReconnects(p.config.MaxReconnectAttempts),
+		nats.ReconnectBufSize(p.config.ReconnectBufSize),
 		nats.PingInterval(p.config.PingInterval),
 		nats.MaxPingsOutstanding(p.config.MaxPingsOutstanding),
 		nats.DisconnectErrHandler(func(nc *nats.Conn, err error) {
@@ -106,6 +105,12 @@ func (p *publisher) connect() error {
 		}),
 	}
 
+	credentials, err := p.config.Publisher.Options()
+	if err != nil {
+		return fmt.Errorf("invalid publisher credentials: %w", err)
+	}
+	opts = append(opts, credentials...)
+
 	start := time.Now()
 	conn, err := nats.Connect(p.config.URL, opts...)
 	connectionDuration := time.Since(start)
@@ -124,6 +129,7 @@ func (p *publisher) connect() error {
 	p.conn = conn
 	p.loggerFactory.Application().LogApplicationEvent("nats_publisher_connected", "nats_publisher",
 		zap.String("server_url", conn.ConnectedUrl()),
+		zap.String("auth_method", p.config.Publisher.AuthMethod()),
 		zap.String("client_id", p.config.ClientID),
 		zap.Duration("connection_duration", connectionDuration),
 	)
@@ -150,9 +156,14 @@ func (p *publisher) Publish(ctx context.Context, subject string, data interface{
 		return fmt.Errorf("context cancelled before publish: %w", err)
 	}
 
-	dto, err := p.mapper.ToDTOFromInterface(data)
+	dto, schema, err := mappers.ToEventDTO(data)
 	if err != nil {
-		return err
+		p.loggerFactory.Core().Error("nats_event_mapping_failed",
+			zap.Error(err),
+			zap.String("subject", subject),
+			zap.String("component", "nats_publisher"),
+		)
+		return fmt.Errorf("invalid event for subject %s: %w", subject, err)
 	}
 
 	dataBytes, err := json.Marshal(dto)
@@ -175,7 +186,7 @@ func (p *publisher) Publish(ctx context.Context, subject string, data interface{
 	start := time.Now()
 	done := make(chan error, 1)
 	go func() {
-		done <- conn.Publish(subject, dataBytes)
+		done <- conn.PublishMsg(&nats.Msg{Subject: subject, Data: dataBytes, Header: eventHeaders(ctx, schema)})
 	}()
 
 	select {
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/inflight"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
 )
 
@@ -19,6 +20,7 @@ type subscriber struct {
 	conn          *nats.Conn
 	subscriptions map[string]*nats.Subscription
 	loggerFactory logger.LoggerFactory
+	inFlight      *inflight.Tracker
 	mu            sync.RWMutex
 	started       bool
 }
@@ -45,6 +47,7 @@ func NewNATSSubscriber(config *NATSConfig, loggerFactory logger.LoggerFactory) (
 		config:        config,
 		subscriptions: make(map[string]*nats.Subscription),
 		loggerFactory: loggerFactory,
+		inFlight:      inflight.NewTracker(),
 	}, nil
 }
 
@@ -76,6 +79,7 @@ func (s *subscriber) connect() error {
 		nats.Timeout(s.config.ConnectTimeout),
 		nats.ReconnectWait(s.config.ReconnectWait),
 		nats.MaxReconnects(s.config.MaxReconnectAttempts),
+		nats.ReconnectBufSize(s.config.ReconnectBufSize),
 		nats.PingInterval(s.config.PingInterval),
 		nats.MaxPingsOutstanding(s.config.MaxPingsOutstanding),
 		nats.DisconnectErrHandler(func(nc *nats.Conn, err error) {
@@ -116,6 +120,12 @@ func (s *subscriber) connect() error {
 		}),
 	}
 
+	credentials, err := s.config.Subscriber.Options()
+	if err != nil {
+		return fmt.Errorf("invalid subscriber credentials: %w", err)
+	}
+	opts = append(opts, credentials...)
+
 	conn, err := nats.Connect(s.config.URL, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to connect to NATS server at %s: %w", s.config.URL, err)
@@ -124,6 +134,7 @@ func (s *subscriber) connect() error {
 	s.conn = conn
 	s.loggerFactory.Application().LogApplicationEvent("nats_subscriber_connected", "nats_subscriber",
 		zap.String("server_url", conn.ConnectedUrl()),
+		zap.String("auth_method", s.config.Subscriber.AuthMethod()),
 		zap.String("client_id", s.config.ClientID),
 	)
 
@@ -152,22 +163,32 @@ func (s *subscriber) Subscribe(ctx context.Context, subject string, handler even
 		zap.String("client_id", s.config.ClientID),
 	)
 
-	// Create a wrapper handler that adapts NATS message to our MessageHandler interface
+	// Create a wrapper handler that adapts NATS message to our MessageHandler interface,
+	// a panicking handler fails only its message
+	handler = eventports.Recovering(handler)
 	natsHandler := func(msg *nats.Msg) {
 		start := time.Now()
 		payloadSize := len(msg.Data)
 
 		s.loggerFactory.Core().Debug("nats_message_received",
 			zap.String("subject", msg.Subject),
+			zap.String("event_type", msg.Header.Get(HeaderEventType)),
 			zap.Int("data_length_bytes", payloadSize),
 			zap.String("component", "nats_subscriber"),
 		)
 
-		// Create a background context for message processing
-		// Individual handlers should implement their own timeouts if needed
-		msgCtx := context.Background()
+		// Handlers run on the subscriber context so Stop can cancel those that outlive the drain
+		msgCtx, ok := s.inFlight.Begin()
+		if !ok {
+			s.loggerFactory.Core().Debug("nats_message_ignored_stopping",
+				zap.String("subject", msg.Subject),
+				zap.String("component", "nats_subscriber"),
+			)
+			return
+		}
+		defer s.inFlight.Done()
 
-		err := handler(msgCtx, msg.Subject, msg.Data)
+		err := handler(contextFromHeaders(msgCtx, msg.Header), msg.Subject, msg.Data)
 		processingDuration := time.Since(start)
 
 		if err != nil {
@@ -273,6 +294,14 @@ func (s *subscriber) Stop(ctx context.Context) error {
 	}
 	s.subscriptions = make(map[string]*nats.Subscription)
 
+	// Let in-flight handlers finish before closing the connection they may publish on
+	if err := s.inFlight.Drain(ctx); err != nil {
+		s.loggerFactory.Core().Warn("nats_subscriber_drain_timeout",
+			zap.Error(err),
+			zap.String("component", "nats_subscriber"),
+		)
+	}
+
 	// Close the connection
 	if s.conn != nil {
 		start := time.Now()
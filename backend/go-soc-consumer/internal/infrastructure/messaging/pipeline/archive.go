@@ -0,0 +1,34 @@
+package pipeline
+
+import (
+	"context"
+
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	rawarchive "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/raw_archive"
+)
+
+// StageArchive is the name of the raw archive stage
+const StageArchive = "archive"
+
+// ArchiveStage records every message in the raw archive before any other stage can drop it
+type ArchiveStage struct {
+	archive rawarchive.RawArchiveUseCase
+}
+
+// NewArchiveStage creates a raw archive stage
+func NewArchiveStage(archive rawarchive.RawArchiveUseCase) *ArchiveStage {
+	return &ArchiveStage{archive: archive}
+}
+
+// Name implements Stage
+func (s *ArchiveStage) Name() string {
+	return StageArchive
+}
+
+// Wrap records the message and always passes it on
+func (s *ArchiveStage) Wrap(next eventports.MessageHandler) eventports.MessageHandler {
+	return func(ctx context.Context, topic string, payload []byte) error {
+		s.archive.Record(ctx, topic, payload)
+		return next(ctx, topic, payload)
+	}
+}
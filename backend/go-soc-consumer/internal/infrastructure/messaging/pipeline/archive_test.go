@@ -0,0 +1,30 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestArchiveStage_Wrap(t *testing.T) {
+	const topic = "/liwaisi/iot/smart-irrigation/device/registration"
+	payload := []byte(`{"mac_address":"AA:BB:CC:DD:EE:FF"}`)
+
+	archive := mocks.NewMockRawArchiveUseCase(t)
+	archive.EXPECT().Record(mock.Anything, topic, payload).Once()
+	stage := NewArchiveStage(archive)
+
+	// the archive records messages that later stages drop
+	var calls []string
+	handler := NewPipeline(createTestLoggerFactory(t), stage, recordingStage{name: "rate_limit", calls: &calls, drop: true}).Handler(func(context.Context, string, []byte) error {
+		t.Fatal("dropped message reached the handler")
+		return nil
+	})
+	require.NoError(t, handler(context.Background(), topic, payload))
+	assert.Equal(t, StageArchive, stage.Name())
+}
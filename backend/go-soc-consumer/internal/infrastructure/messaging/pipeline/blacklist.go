@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/blacklist"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// StageBlacklist is the name of the blacklist stage
+const StageBlacklist = "blacklist"
+
+// BlacklistStage drops messages from blacklisted devices
+type BlacklistStage struct {
+	blacklist  blacklist.BlacklistUseCase
+	coreLogger logger.CoreLogger
+}
+
+// NewBlacklistStage creates a blacklist stage
+func NewBlacklistStage(loggerFactory logger.LoggerFactory, blacklistUseCase blacklist.BlacklistUseCase) *BlacklistStage {
+	return &BlacklistStage{
+		blacklist:  blacklistUseCase,
+		coreLogger: loggerFactory.Core(),
+	}
+}
+
+// Name implements Stage
+func (s *BlacklistStage) Name() string {
+	return StageBlacklist
+}
+
+// Wrap drops messages whose MAC address, reported IP address or broker reported remote address
+// is blacklisted. Payloads that cannot be parsed are passed on so later stages report them.
+func (s *BlacklistStage) Wrap(next eventports.MessageHandler) eventports.MessageHandler {
+	return func(ctx context.Context, topic string, payload []byte) error {
+		var fields deviceFields
+		if err := json.Unmarshal(payload, &fields); err != nil {
+			return next(ctx, topic, payload)
+		}
+
+		ipAddresses := []string{fields.IPAddress}
+		if identity := eventports.ClientIdentityFromContext(ctx); identity != nil {
+			ipAddresses = append(ipAddresses, identity.RemoteAddress)
+		}
+
+		entry := s.blacklist.Match(fields.MacAddress, ipAddresses)
+		if entry == nil {
+			return next(ctx, topic, payload)
+		}
+
+		s.coreLogger.Debug("mqtt_message_blacklisted",
+			zap.String("topic", topic),
+			zap.String("mac_address", fields.MacAddress),
+			zap.String("blacklist_entry_id", entry.ID),
+			zap.String("blacklist_value", entry.Value),
+			zap.String("component", "ingestion_pipeline"),
+		)
+		return Drop(StageBlacklist, "blacklisted_"+entry.Kind)
+	}
+}
@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestBlacklistStage_Wrap(t *testing.T) {
+	entry, err := entities.NewBlacklistEntry(entities.BlacklistKindMAC, "AA:BB:CC:DD:EE:FF", "stolen")
+	require.NoError(t, err)
+
+	const topic = "/liwaisi/iot/smart-irrigation/device/registration"
+	payload := []byte(`{"mac_address":"AA:BB:CC:DD:EE:FF","ip_address":"192.168.1.20"}`)
+
+	t.Run("should drop blacklisted messages", func(t *testing.T) {
+		blacklistUseCase := mocks.NewMockBlacklistUseCase(t)
+		blacklistUseCase.EXPECT().Match("AA:BB:CC:DD:EE:FF", []string{"192.168.1.20", "10.0.0.7"}).Return(entry).Once()
+		stage := NewBlacklistStage(createTestLoggerFactory(t), blacklistUseCase)
+
+		called := false
+		handler := stage.Wrap(func(context.Context, string, []byte) error {
+			called = true
+			return nil
+		})
+
+		ctx := eventports.ContextWithClientIdentity(context.Background(), &entities.ClientIdentity{ClientID: "esp32", RemoteAddress: "10.0.0.7"})
+		err := handler(ctx, topic, payload)
+		var dropped *DroppedError
+		require.True(t, errors.As(err, &dropped))
+		assert.Equal(t, "blacklisted_mac", dropped.Reason)
+		assert.False(t, called)
+	})
+
+	t.Run("should pass other messages on", func(t *testing.T) {
+		blacklistUseCase := mocks.NewMockBlacklistUseCase(t)
+		blacklistUseCase.EXPECT().Match("AA:BB:CC:DD:EE:FF", []string{"192.168.1.20"}).Return(nil).Once()
+		stage := NewBlacklistStage(createTestLoggerFactory(t), blacklistUseCase)
+
+		called := false
+		handler := stage.Wrap(func(context.Context, string, []byte) error {
+			called = true
+			return nil
+		})
+
+		require.NoError(t, handler(context.Background(), topic, payload))
+		assert.True(t, called)
+	})
+
+	t.Run("should leave invalid payloads to later stages", func(t *testing.T) {
+		stage := NewBlacklistStage(createTestLoggerFactory(t), mocks.NewMockBlacklistUseCase(t))
+
+		called := false
+		handler := stage.Wrap(func(context.Context, string, []byte) error {
+			called = true
+			return nil
+		})
+
+		require.NoError(t, handler(context.Background(), topic, []byte("not json")))
+		assert.True(t, called)
+	})
+}
@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/lru"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// StageDedup is the name of the deduplication stage
+const StageDedup = "dedup"
+
+// DedupStage drops a message identical to one received on the same topic within the window,
+// such as QoS 1 redeliveries and messages received through two brokers. It remembers at most
+// maxEntries messages; past that the oldest are forgotten early and may pass as new.
+type DedupStage struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	seen      *lru.Cache[[sha256.Size]byte, time.Time]
+	lastSweep time.Time
+}
+
+// NewDedupStage creates a deduplication stage remembering up to maxEntries messages
+func NewDedupStage(window time.Duration, maxEntries int) *DedupStage {
+	return &DedupStage{
+		window: window,
+		now:    time.Now,
+		seen:   lru.New[[sha256.Size]byte, time.Time](maxEntries),
+	}
+}
+
+// Name implements Stage
+func (s *DedupStage) Name() string {
+	return StageDedup
+}
+
+// Wrap implements Stage
+func (s *DedupStage) Wrap(next eventports.MessageHandler) eventports.MessageHandler {
+	return func(ctx context.Context, topic string, payload []byte) error {
+		if s.isDuplicate(topic, payload) {
+			return Drop(StageDedup, "duplicate")
+		}
+		return next(ctx, topic, payload)
+	}
+}
+
+// isDuplicate records the message and reports whether it was already seen within the window
+func (s *DedupStage) isDuplicate(topic string, payload []byte) bool {
+	h := sha256.New()
+	h.Write([]byte(topic))
+	h.Write([]byte{0})
+	h.Write(payload)
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= s.window {
+		s.seen.DeleteFunc(func(_ [sha256.Size]byte, seenAt time.Time) bool {
+			return now.Sub(seenAt) >= s.window
+		})
+		s.lastSweep = now
+	}
+
+	if seenAt, ok := s.seen.Get(key); ok && now.Sub(seenAt) < s.window {
+		return true
+	}
+	s.seen.Put(key, now)
+	return false
+}
+
+// Collect implements metrics.Collector
+func (s *DedupStage) Collect() []metrics.Family {
+	s.mu.Lock()
+	entries, evictions := s.seen.Len(), s.seen.Evictions()
+	s.mu.Unlock()
+
+	return []metrics.Family{
+		{Name: "mqtt_pipeline_dedup_entries", Help: "Messages remembered by the deduplication stage", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(entries)}}},
+		{Name: "mqtt_pipeline_dedup_evictions_total", Help: "Messages forgotten by the deduplication stage before their window ended to stay within its size limit", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(evictions)}}},
+	}
+}
@@ -0,0 +1,47 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupStage_Wrap(t *testing.T) {
+	stage := NewDedupStage(2*time.Second, 100)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	stage.now = func() time.Time { return now }
+
+	handled := 0
+	handler := stage.Wrap(func(context.Context, string, []byte) error {
+		handled++
+		return nil
+	})
+	ctx := context.Background()
+	payload := []byte(`{"mac_address":"AA:BB:CC:DD:EE:FF","temperature":21.5}`)
+
+	assert.NoError(t, handler(ctx, "sensors", payload))
+	assert.Error(t, handler(ctx, "sensors", payload), "identical message within the window")
+	assert.NoError(t, handler(ctx, "registration", payload), "same payload on another topic")
+
+	now = now.Add(2 * time.Second)
+	assert.NoError(t, handler(ctx, "sensors", payload), "identical message after the window")
+	assert.Equal(t, 3, handled)
+}
+
+func TestDedupStage_MaxEntries(t *testing.T) {
+	stage := NewDedupStage(time.Minute, 2)
+	handler := stage.Wrap(func(context.Context, string, []byte) error { return nil })
+	ctx := context.Background()
+
+	assert.NoError(t, handler(ctx, "sensors", []byte(`{"n":1}`)))
+	assert.NoError(t, handler(ctx, "sensors", []byte(`{"n":2}`)))
+	assert.NoError(t, handler(ctx, "sensors", []byte(`{"n":3}`)))
+	assert.NoError(t, handler(ctx, "sensors", []byte(`{"n":1}`)), "the oldest message was forgotten")
+	assert.Error(t, handler(ctx, "sensors", []byte(`{"n":3}`)))
+
+	families := stage.Collect()
+	assert.Equal(t, float64(2), families[0].Samples[0].Value)
+	assert.Equal(t, float64(2), families[1].Samples[0].Value)
+}
@@ -0,0 +1,119 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	messagetracing "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/message_tracing"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// Stage is one step of the ingestion pipeline. A stage wraps the next handler and either
+// passes the message on or drops it by returning an error created with Drop.
+type Stage interface {
+	// Name identifies the stage in configuration, logs and metrics
+	Name() string
+
+	// Wrap returns a handler running the stage before next
+	Wrap(next eventports.MessageHandler) eventports.MessageHandler
+}
+
+// DroppedError reports that a stage intentionally dropped a message
+type DroppedError struct {
+	Stage  string
+	Reason string
+}
+
+func (e *DroppedError) Error() string {
+	return fmt.Sprintf("message dropped by %s stage: %s", e.Stage, e.Reason)
+}
+
+// Drop returns the error a stage uses to drop a message
+func Drop(stage, reason string) error {
+	return &DroppedError{Stage: stage, Reason: reason}
+}
+
+// Pipeline runs MQTT messages through an ordered chain of stages before the topic handler,
+// which decodes the message and calls the use case
+type Pipeline struct {
+	stages     []Stage
+	coreLogger logger.CoreLogger
+	dropped    *metrics.Vec
+}
+
+// NewPipeline creates a pipeline running the stages in the given order
+func NewPipeline(loggerFactory logger.LoggerFactory, stages ...Stage) *Pipeline {
+	return &Pipeline{
+		stages:     stages,
+		coreLogger: loggerFactory.Core(),
+		dropped:    metrics.NewCounterVec("mqtt_pipeline_messages_dropped_total", "MQTT messages dropped by an ingestion pipeline stage", "topic", "stage", "reason"),
+	}
+}
+
+// Handler chains the stages in front of the topic handler. Messages are stamped with their
+// receipt time before the first stage. Dropped messages are counted and reported as handled so
+// they are not logged as processing errors. Traced messages record each stage they reach.
+func (p *Pipeline) Handler(handler eventports.MessageHandler) eventports.MessageHandler {
+	handler = traceStep(entities.TraceStageHandler, handler)
+	for i := len(p.stages) - 1; i >= 0; i-- {
+		handler = traceStep(p.stages[i].Name(), p.stages[i].Wrap(handler))
+	}
+
+	return func(ctx context.Context, topic string, payload []byte) error {
+		err := handler(eventports.ContextWithReceivedAt(ctx, time.Now()), topic, payload)
+
+		var dropped *DroppedError
+		if errors.As(err, &dropped) {
+			p.dropped.Inc(topic, dropped.Stage, dropped.Reason)
+			p.coreLogger.Debug("mqtt_message_dropped",
+				zap.String("topic", topic),
+				zap.String("stage", dropped.Stage),
+				zap.String("reason", dropped.Reason),
+				zap.String("component", "ingestion_pipeline"),
+			)
+			return nil
+		}
+		return err
+	}
+}
+
+// traceStep records that a traced message reached the named step before running the handler
+func traceStep(name string, handler eventports.MessageHandler) eventports.MessageHandler {
+	return func(ctx context.Context, topic string, payload []byte) error {
+		messagetracing.RecordStep(ctx, name)
+		return handler(ctx, topic, payload)
+	}
+}
+
+// StageNames returns the names of the stages in execution order
+func (p *Pipeline) StageNames() []string {
+	names := make([]string, 0, len(p.stages))
+	for _, stage := range p.stages {
+		names = append(names, stage.Name())
+	}
+	return names
+}
+
+// Collect implements metrics.Collector
+func (p *Pipeline) Collect() []metrics.Family {
+	families := p.dropped.Collect()
+	for _, stage := range p.stages {
+		if collector, ok := stage.(metrics.Collector); ok {
+			families = append(families, collector.Collect()...)
+		}
+	}
+	return families
+}
+
+// deviceFields holds the fields stages use to identify the publishing device
+type deviceFields struct {
+	MacAddress string `json:"mac_address"`
+	IPAddress  string `json:"ip_address"`
+}
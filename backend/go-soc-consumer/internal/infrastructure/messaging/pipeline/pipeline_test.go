@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+// recordingStage records its name when it runs and optionally drops the message
+type recordingStage struct {
+	name  string
+	calls *[]string
+	drop  bool
+}
+
+func (s recordingStage) Name() string { return s.name }
+
+func (s recordingStage) Wrap(next eventports.MessageHandler) eventports.MessageHandler {
+	return func(ctx context.Context, topic string, payload []byte) error {
+		*s.calls = append(*s.calls, s.name)
+		if s.drop {
+			return Drop(s.name, "test")
+		}
+		return next(ctx, topic, payload)
+	}
+}
+
+func TestPipeline_Handler(t *testing.T) {
+	t.Run("should run stages in order before the handler", func(t *testing.T) {
+		var calls []string
+		p := NewPipeline(createTestLoggerFactory(t), recordingStage{name: "first", calls: &calls}, recordingStage{name: "second", calls: &calls})
+
+		handler := p.Handler(func(context.Context, string, []byte) error {
+			calls = append(calls, "handler")
+			return nil
+		})
+
+		require.NoError(t, handler(context.Background(), "topic", nil))
+		assert.Equal(t, []string{"first", "second", "handler"}, calls)
+		assert.Equal(t, []string{"first", "second"}, p.StageNames())
+	})
+
+	t.Run("should stamp messages with their receipt time", func(t *testing.T) {
+		p := NewPipeline(createTestLoggerFactory(t))
+
+		handler := p.Handler(func(ctx context.Context, _ string, _ []byte) error {
+			_, ok := eventports.ReceivedAtFromContext(ctx)
+			assert.True(t, ok)
+			return nil
+		})
+
+		require.NoError(t, handler(context.Background(), "topic", nil))
+	})
+
+	t.Run("should count dropped messages and stop the chain", func(t *testing.T) {
+		var calls []string
+		p := NewPipeline(createTestLoggerFactory(t), recordingStage{name: "first", calls: &calls, drop: true}, recordingStage{name: "second", calls: &calls})
+
+		handler := p.Handler(func(context.Context, string, []byte) error {
+			calls = append(calls, "handler")
+			return nil
+		})
+
+		require.NoError(t, handler(context.Background(), "topic", nil))
+		assert.Equal(t, []string{"first"}, calls)
+		assert.Equal(t, 1.0, p.dropped.Value("topic", "first", "test"))
+	})
+
+	t.Run("should return handler errors", func(t *testing.T) {
+		p := NewPipeline(createTestLoggerFactory(t))
+		handler := p.Handler(func(context.Context, string, []byte) error {
+			return errors.New("store failed")
+		})
+
+		assert.EqualError(t, handler(context.Background(), "topic", nil), "store failed")
+	})
+}
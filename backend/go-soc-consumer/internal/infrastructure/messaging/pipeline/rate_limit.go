@@ -0,0 +1,120 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/lru"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// StageRateLimit is the name of the rate limiting stage
+const StageRateLimit = "rate_limit"
+
+// tokenBucket holds the remaining message allowance of a device
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// RateLimitStage limits how many messages each device may send, identified by MAC address or,
+// when the payload has none, by the broker reported client ID. Unidentified messages are not limited.
+// It tracks at most maxDevices devices; past that the least recently seen get a fresh allowance.
+type RateLimitStage struct {
+	perSecond float64
+	burst     float64
+	now       func() time.Time
+
+	mu        sync.Mutex
+	buckets   *lru.Cache[string, *tokenBucket]
+	lastSweep time.Time
+}
+
+// NewRateLimitStage creates a rate limiting stage allowing perMinute messages per device with the
+// given burst, tracking up to maxDevices devices
+func NewRateLimitStage(perMinute, burst, maxDevices int) *RateLimitStage {
+	return &RateLimitStage{
+		perSecond: float64(perMinute) / 60,
+		burst:     float64(burst),
+		now:       time.Now,
+		buckets:   lru.New[string, *tokenBucket](maxDevices),
+	}
+}
+
+// Name implements Stage
+func (s *RateLimitStage) Name() string {
+	return StageRateLimit
+}
+
+// Wrap implements Stage
+func (s *RateLimitStage) Wrap(next eventports.MessageHandler) eventports.MessageHandler {
+	return func(ctx context.Context, topic string, payload []byte) error {
+		key := ""
+		var fields deviceFields
+		if err := json.Unmarshal(payload, &fields); err == nil && fields.MacAddress != "" {
+			key = "mac:" + strings.ToUpper(strings.TrimSpace(fields.MacAddress))
+		} else if identity := eventports.ClientIdentityFromContext(ctx); identity != nil && identity.ClientID != "" {
+			key = "client:" + identity.ClientID
+		}
+
+		if key != "" && !s.allow(key) {
+			return Drop(StageRateLimit, "rate_limited")
+		}
+		return next(ctx, topic, payload)
+	}
+}
+
+// allow takes a token from the device's bucket
+func (s *RateLimitStage) allow(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweepLocked(now)
+
+	bucket, ok := s.buckets.Get(key)
+	if !ok {
+		bucket = &tokenBucket{tokens: s.burst, updated: now}
+		s.buckets.Put(key, bucket)
+	}
+	bucket.tokens = math.Min(s.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*s.perSecond)
+	bucket.updated = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// sweepLocked forgets buckets that have refilled completely, they behave like new ones
+func (s *RateLimitStage) sweepLocked(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	refill := time.Duration(s.burst / s.perSecond * float64(time.Second))
+	s.buckets.DeleteFunc(func(_ string, bucket *tokenBucket) bool {
+		return now.Sub(bucket.updated) >= refill
+	})
+}
+
+// Collect implements metrics.Collector
+func (s *RateLimitStage) Collect() []metrics.Family {
+	s.mu.Lock()
+	devices, evictions := s.buckets.Len(), s.buckets.Evictions()
+	s.mu.Unlock()
+
+	return []metrics.Family{
+		{Name: "mqtt_pipeline_rate_limit_devices", Help: "Devices tracked by the rate limiting stage", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(devices)}}},
+		{Name: "mqtt_pipeline_rate_limit_evictions_total", Help: "Devices forgotten by the rate limiting stage to stay within its size limit", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(evictions)}}},
+	}
+}
@@ -0,0 +1,59 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+)
+
+func TestRateLimitStage_Wrap(t *testing.T) {
+	stage := NewRateLimitStage(60, 2, 100)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	stage.now = func() time.Time { return now }
+	handler := stage.Wrap(func(context.Context, string, []byte) error { return nil })
+	ctx := context.Background()
+	device := []byte(`{"mac_address":"AA:BB:CC:DD:EE:FF"}`)
+
+	assert.NoError(t, handler(ctx, "sensors", device))
+	assert.NoError(t, handler(ctx, "sensors", device))
+	assert.Error(t, handler(ctx, "sensors", device), "burst exhausted")
+	assert.NoError(t, handler(ctx, "sensors", []byte(`{"mac_address":"11:22:33:44:55:66"}`)), "other devices have their own allowance")
+
+	now = now.Add(time.Second)
+	assert.NoError(t, handler(ctx, "sensors", device), "one token refilled")
+	assert.Error(t, handler(ctx, "sensors", device))
+
+	t.Run("should fall back to the client ID", func(t *testing.T) {
+		clientCtx := eventports.ContextWithClientIdentity(ctx, &entities.ClientIdentity{ClientID: "esp32"})
+		assert.NoError(t, handler(clientCtx, "status", []byte(`{}`)))
+		assert.NoError(t, handler(clientCtx, "status", []byte(`{}`)))
+		assert.Error(t, handler(clientCtx, "status", []byte(`{}`)))
+	})
+
+	t.Run("should not limit unidentified messages", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, handler(ctx, "status", []byte(`{}`)))
+		}
+	})
+}
+
+func TestRateLimitStage_MaxDevices(t *testing.T) {
+	stage := NewRateLimitStage(60, 1, 1)
+	handler := stage.Wrap(func(context.Context, string, []byte) error { return nil })
+	ctx := context.Background()
+	device := []byte(`{"mac_address":"AA:BB:CC:DD:EE:FF"}`)
+
+	assert.NoError(t, handler(ctx, "sensors", device))
+	assert.Error(t, handler(ctx, "sensors", device))
+	assert.NoError(t, handler(ctx, "sensors", []byte(`{"mac_address":"11:22:33:44:55:66"}`)))
+	assert.NoError(t, handler(ctx, "sensors", device), "the evicted device starts with a fresh allowance")
+
+	families := stage.Collect()
+	assert.Equal(t, float64(1), families[0].Samples[0].Value)
+	assert.Equal(t, float64(2), families[1].Samples[0].Value)
+}
@@ -0,0 +1,170 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// StageSchema is the name of the schema validation stage
+const StageSchema = "schema"
+
+// JSON field types checked by a MessageSchema
+const (
+	FieldString = "string"
+	FieldNumber = "number"
+	FieldBool   = "bool"
+	FieldArray  = "array"
+)
+
+// MessageSchema describes the JSON object expected on a topic. Fields not listed are allowed.
+type MessageSchema struct {
+	Required []string          // fields that must be present
+	Fields   map[string]string // field types, checked when the field is present
+}
+
+// DefaultSchemas returns the schemas of the device topics, keyed by topic or topic filter
+func DefaultSchemas() map[string]MessageSchema {
+	registration := MessageSchema{
+		Required: []string{"event_type", "mac_address", "device_name", "ip_address"},
+		Fields: map[string]string{
+			"event_type":           FieldString,
+			"mac_address":          FieldString,
+			"device_name":          FieldString,
+			"ip_address":           FieldString,
+			"location_description": FieldString,
+			"firmware_version":     FieldString,
+			"hardware_version":     FieldString,
+		},
+	}
+	sensorData := MessageSchema{
+		Required: []string{"event_type", "mac_address", "temperature", "humidity"},
+		Fields: map[string]string{
+			"event_type":  FieldString,
+			"mac_address": FieldString,
+			"temperature": FieldNumber,
+			"humidity":    FieldNumber,
+			"timestamp":   FieldNumber,
+		},
+	}
+	measurement := MessageSchema{
+		Required: []string{"event_type", "mac_address", "measurements"},
+		Fields: map[string]string{
+			"event_type":   FieldString,
+			"mac_address":  FieldString,
+			"measurements": FieldArray,
+			"timestamp":    FieldNumber,
+		},
+	}
+
+	return map[string]MessageSchema{
+		"/liwaisi/iot/smart-irrigation/device/registration":              registration,
+		"/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity": sensorData,
+		"farms/+/devices/registration":                                   registration,
+		"/liwaisi/iot/smart-irrigation/sensors/measurements":             measurement,
+		"farms/+/devices/sensors/temperature-and-humidity":               sensorData,
+		"farms/+/devices/sensors/measurements":                           measurement,
+	}
+}
+
+// Validate checks a payload against the schema
+func (s MessageSchema) Validate(payload []byte) error {
+	var object map[string]interface{}
+	if err := json.Unmarshal(payload, &object); err != nil {
+		return fmt.Errorf("payload is not a JSON object: %w", err)
+	}
+
+	for _, field := range s.Required {
+		if _, ok := object[field]; !ok {
+			return fmt.Errorf("missing required field %q", field)
+		}
+	}
+
+	fields := make([]string, 0, len(s.Fields))
+	for field := range s.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		value, ok := object[field]
+		if !ok {
+			continue
+		}
+		valid := false
+		switch s.Fields[field] {
+		case FieldString:
+			_, valid = value.(string)
+		case FieldNumber:
+			_, valid = value.(float64)
+		case FieldBool:
+			_, valid = value.(bool)
+		case FieldArray:
+			_, valid = value.([]interface{})
+		}
+		if !valid {
+			return fmt.Errorf("field %q must be a %s", field, s.Fields[field])
+		}
+	}
+	return nil
+}
+
+// SchemaStage drops messages that do not match the schema of their topic; topics without a schema pass
+type SchemaStage struct {
+	schemas    map[string]MessageSchema
+	coreLogger logger.CoreLogger
+}
+
+// NewSchemaStage creates a schema validation stage
+func NewSchemaStage(loggerFactory logger.LoggerFactory, schemas map[string]MessageSchema) *SchemaStage {
+	return &SchemaStage{
+		schemas:    schemas,
+		coreLogger: loggerFactory.Core(),
+	}
+}
+
+// Name implements Stage
+func (s *SchemaStage) Name() string {
+	return StageSchema
+}
+
+// schemaFor returns the schema of a topic, looking up exact topics before topic filters
+func (s *SchemaStage) schemaFor(topic string) (MessageSchema, bool) {
+	if schema, ok := s.schemas[topic]; ok {
+		return schema, true
+	}
+	for filter, schema := range s.schemas {
+		if strings.ContainsAny(filter, "+#") && entities.MQTTTopicMatches(filter, topic) {
+			return schema, true
+		}
+	}
+	return MessageSchema{}, false
+}
+
+// Wrap implements Stage
+func (s *SchemaStage) Wrap(next eventports.MessageHandler) eventports.MessageHandler {
+	return func(ctx context.Context, topic string, payload []byte) error {
+		schema, ok := s.schemaFor(topic)
+		if !ok {
+			return next(ctx, topic, payload)
+		}
+
+		if err := schema.Validate(payload); err != nil {
+			s.coreLogger.Warn("mqtt_message_schema_violation",
+				zap.String("topic", topic),
+				zap.Error(err),
+				zap.Int("payload_size_bytes", len(payload)),
+				zap.String("component", "ingestion_pipeline"),
+			)
+			return Drop(StageSchema, "schema_violation")
+		}
+		return next(ctx, topic, payload)
+	}
+}
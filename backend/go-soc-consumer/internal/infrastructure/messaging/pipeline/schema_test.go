@@ -0,0 +1,42 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaStage_Wrap(t *testing.T) {
+	const topic = "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity"
+	stage := NewSchemaStage(createTestLoggerFactory(t), DefaultSchemas())
+	handler := stage.Wrap(func(context.Context, string, []byte) error { return nil })
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		topic   string
+		payload string
+		valid   bool
+	}{
+		{name: "valid reading", topic: topic, payload: `{"event_type":"sensor_data","mac_address":"AA:BB:CC:DD:EE:FF","temperature":21.5,"humidity":60,"rssi":-70}`, valid: true},
+		{name: "missing field", topic: topic, payload: `{"event_type":"sensor_data","mac_address":"AA:BB:CC:DD:EE:FF","temperature":21.5}`},
+		{name: "wrong type", topic: topic, payload: `{"event_type":"sensor_data","mac_address":"AA:BB:CC:DD:EE:FF","temperature":"hot","humidity":60}`},
+		{name: "not an object", topic: topic, payload: `[1,2]`},
+		{name: "farm topic", topic: "farms/farm-a/devices/sensors/temperature-and-humidity", payload: `{"event_type":"sensor_data","mac_address":"AA:BB:CC:DD:EE:FF","temperature":21.5}`},
+		{name: "valid measurements", topic: "/liwaisi/iot/smart-irrigation/sensors/measurements", payload: `{"event_type":"measurement","mac_address":"AA:BB:CC:DD:EE:FF","measurements":[{"type":"ph","value":6.5}]}`, valid: true},
+		{name: "measurements not an array", topic: "/liwaisi/iot/smart-irrigation/sensors/measurements", payload: `{"event_type":"measurement","mac_address":"AA:BB:CC:DD:EE:FF","measurements":{"ph":6.5}}`},
+		{name: "topic without schema", topic: "/other", payload: `not json`, valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler(ctx, tt.topic, []byte(tt.payload))
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, "schema_violation")
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	messagetracing "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/message_tracing"
+)
+
+// StageTrace is the name of the message tracing stage
+const StageTrace = "trace"
+
+// TraceStage captures the messages matching the active trace sessions, with the stages they reached
+// and their outcome. Messages pass through untouched while no session is active.
+type TraceStage struct {
+	tracing messagetracing.MessageTracingUseCase
+}
+
+// NewTraceStage creates a message tracing stage
+func NewTraceStage(tracing messagetracing.MessageTracingUseCase) *TraceStage {
+	return &TraceStage{tracing: tracing}
+}
+
+// Name implements Stage
+func (s *TraceStage) Name() string {
+	return StageTrace
+}
+
+// Wrap traces the message when a session captures it, and always passes it on
+func (s *TraceStage) Wrap(next eventports.MessageHandler) eventports.MessageHandler {
+	return func(ctx context.Context, topic string, payload []byte) error {
+		if !s.tracing.Tracing() {
+			return next(ctx, topic, payload)
+		}
+
+		var fields deviceFields
+		_ = json.Unmarshal(payload, &fields)
+		ctx = s.tracing.Begin(ctx, topic, fields.MacAddress, payload)
+		err := next(ctx, topic, payload)
+
+		var dropped *DroppedError
+		switch {
+		case errors.As(err, &dropped):
+			s.tracing.Complete(ctx, entities.TraceOutcomeDropped, dropped.Stage, dropped.Reason)
+		case err != nil:
+			s.tracing.Complete(ctx, entities.TraceOutcomeFailed, "", err.Error())
+		default:
+			s.tracing.Complete(ctx, entities.TraceOutcomeHandled, "", "")
+		}
+		return err
+	}
+}
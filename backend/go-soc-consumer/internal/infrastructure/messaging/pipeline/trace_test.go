@@ -0,0 +1,72 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	messagetracing "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/message_tracing"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestTraceStage_Wrap(t *testing.T) {
+	const topic = "/liwaisi/iot/smart-irrigation/sensors/measurements"
+	payload := []byte(`{"mac_address":"AA:BB:CC:DD:EE:FF"}`)
+
+	t.Run("should pass messages untouched while no session is active", func(t *testing.T) {
+		tracing := mocks.NewMockMessageTracingUseCase(t)
+		tracing.EXPECT().Tracing().Return(false).Once()
+
+		called := false
+		handler := NewPipeline(createTestLoggerFactory(t), NewTraceStage(tracing)).Handler(func(context.Context, string, []byte) error {
+			called = true
+			return nil
+		})
+		require.NoError(t, handler(context.Background(), topic, payload))
+		assert.True(t, called)
+	})
+
+	t.Run("should record the stages reached and the outcome", func(t *testing.T) {
+		tracing := messagetracing.NewMessageTracingUseCase(nil, createTestLoggerFactory(t))
+		session, err := tracing.StartSession(context.Background(), entities.TraceSessionRequest{MACAddress: "AA:BB:CC:DD:EE:FF", Duration: time.Minute})
+		require.NoError(t, err)
+
+		var calls []string
+		handled := NewPipeline(createTestLoggerFactory(t), NewTraceStage(tracing), recordingStage{name: "schema", calls: &calls}).Handler(func(context.Context, string, []byte) error {
+			return errors.New("device not found")
+		})
+		dropped := NewPipeline(createTestLoggerFactory(t), NewTraceStage(tracing), recordingStage{name: "rate_limit", calls: &calls, drop: true}).Handler(func(context.Context, string, []byte) error {
+			return nil
+		})
+		require.Error(t, handled(context.Background(), topic, payload))
+		require.NoError(t, dropped(context.Background(), topic, payload))
+
+		_, traces, err := tracing.Traces(context.Background(), session.ID)
+		require.NoError(t, err)
+		require.Len(t, traces, 2)
+
+		assert.Equal(t, entities.TraceOutcomeFailed, traces[0].Outcome)
+		assert.Equal(t, "device not found", traces[0].Reason)
+		assert.Equal(t, []string{"schema", entities.TraceStageHandler}, stepNames(traces[0]))
+
+		assert.Equal(t, entities.TraceOutcomeDropped, traces[1].Outcome)
+		assert.Equal(t, "rate_limit", traces[1].Stage)
+		assert.Equal(t, "test", traces[1].Reason)
+		assert.Equal(t, []string{"rate_limit"}, stepNames(traces[1]))
+		assert.Equal(t, StageTrace, NewTraceStage(tracing).Name())
+	})
+}
+
+// stepNames returns the stages a traced message reached
+func stepNames(trace *entities.MessageTrace) []string {
+	names := make([]string, 0, len(trace.Steps))
+	for _, step := range trace.Steps {
+		names = append(names, step.Stage)
+	}
+	return names
+}
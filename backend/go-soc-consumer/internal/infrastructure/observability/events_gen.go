@@ -0,0 +1,38 @@
+// Code generated by portgen from ../../domain/ports/events. DO NOT EDIT.
+
+package observability
+
+import (
+	"context"
+
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+)
+
+// observedEventPublisher reports the calls made through the wrapped EventPublisher to a Recorder
+type observedEventPublisher struct {
+	inner    eventports.EventPublisher
+	recorder *Recorder
+}
+
+// NewObservedEventPublisher wraps the EventPublisher with call metrics, tracing and slow-call logging
+func NewObservedEventPublisher(inner eventports.EventPublisher, recorder *Recorder) eventports.EventPublisher {
+	return &observedEventPublisher{inner: inner, recorder: recorder}
+}
+
+func (o *observedEventPublisher) Publish(ctx context.Context, subject string, data interface{}) error {
+	ctx, call := o.recorder.Start(ctx, "EventPublisher", "Publish")
+	err := o.inner.Publish(ctx, subject, data)
+	call.End(err)
+	return err
+}
+
+func (o *observedEventPublisher) Close(ctx context.Context) error {
+	ctx, call := o.recorder.Start(ctx, "EventPublisher", "Close")
+	err := o.inner.Close(ctx)
+	call.End(err)
+	return err
+}
+
+func (o *observedEventPublisher) IsConnected() bool {
+	return o.inner.IsConnected()
+}
@@ -0,0 +1,5 @@
+package observability
+
+// The decorators are regenerated from the port interfaces, so a new port gets observed by running go generate
+//go:generate go run ../../../cmd/portgen -ports ../../domain/ports/repositories -alias repositoryports -out repositories_gen.go
+//go:generate go run ../../../cmd/portgen -ports ../../domain/ports/events -alias eventports -include EventPublisher -out events_gen.go
@@ -0,0 +1,96 @@
+package observability
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// Recorder observes the calls made through the generated port decorators. Each call is counted
+// and timed, runs under its own span and is logged as slow when it exceeds the threshold.
+type Recorder struct {
+	slowCallThreshold time.Duration
+	coreLogger        logger.CoreLogger
+	now               func() time.Time
+
+	calls    *metrics.Vec
+	duration *metrics.Vec
+	slow     *metrics.Vec
+}
+
+// Call is a port call in progress
+type Call struct {
+	recorder *Recorder
+	port     string
+	method   string
+	span     Span
+	start    time.Time
+}
+
+// NewRecorder creates a recorder; a zero threshold disables slow-call logging
+func NewRecorder(slowCallThreshold time.Duration, loggerFactory logger.LoggerFactory) *Recorder {
+	return &Recorder{
+		slowCallThreshold: slowCallThreshold,
+		coreLogger:        loggerFactory.Core(),
+		now:               time.Now,
+		calls:             metrics.NewCounterVec("port_calls_total", "Calls made through repository and publisher ports", "port", "method", "outcome"),
+		duration:          metrics.NewCounterVec("port_call_duration_seconds_total", "Time spent in repository and publisher port calls", "port", "method"),
+		slow:              metrics.NewCounterVec("port_slow_calls_total", "Port calls slower than the slow-call threshold", "port", "method"),
+	}
+}
+
+// Start begins a call and returns the context to make it with, which carries the call's span
+func (r *Recorder) Start(ctx context.Context, port, method string) (context.Context, *Call) {
+	call := &Call{
+		recorder: r,
+		port:     port,
+		method:   method,
+		span:     childSpan(ctx),
+		start:    r.now(),
+	}
+	return ContextWithSpan(ctx, call.span), call
+}
+
+// End records the outcome of the call
+func (c *Call) End(err error) {
+	r := c.recorder
+	duration := r.now().Sub(c.start)
+
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	r.calls.Inc(c.port, c.method, outcome)
+	r.duration.Add(duration.Seconds(), c.port, c.method)
+
+	fields := []zap.Field{
+		zap.String("port", c.port),
+		zap.String("method", c.method),
+		zap.Duration("duration", duration),
+		zap.String("trace_id", c.span.TraceID),
+		zap.String("span_id", c.span.SpanID),
+		zap.String("parent_span_id", c.span.ParentID),
+		zap.String("component", "port_observability"),
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	if r.slowCallThreshold > 0 && duration >= r.slowCallThreshold {
+		r.slow.Inc(c.port, c.method)
+		r.coreLogger.Warn("port_call_slow", append(fields, zap.Duration("threshold", r.slowCallThreshold))...)
+		return
+	}
+	r.coreLogger.Debug("port_call", fields...)
+}
+
+// Collect implements metrics.Collector
+func (r *Recorder) Collect() []metrics.Family {
+	families := r.calls.Collect()
+	families = append(families, r.duration.Collect()...)
+	return append(families, r.slow.Collect()...)
+}
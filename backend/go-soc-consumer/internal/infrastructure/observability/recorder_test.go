@@ -0,0 +1,84 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// newTestRecorder returns a recorder whose calls each take the given duration
+func newTestRecorder(t *testing.T, threshold, callDuration time.Duration) *Recorder {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	recorder := NewRecorder(threshold, loggerFactory)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time {
+		current := now
+		now = now.Add(callDuration)
+		return current
+	}
+	return recorder
+}
+
+func TestObservedDeviceRepository(t *testing.T) {
+	const mac = "AA:BB:CC:DD:EE:FF"
+
+	t.Run("should record calls and pass the span to the repository", func(t *testing.T) {
+		recorder := newTestRecorder(t, time.Second, 20*time.Millisecond)
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().FindByMACAddress(mock.Anything, mac).RunAndReturn(func(ctx context.Context, _ string) (*entities.Device, error) {
+			span, ok := SpanFromContext(ctx)
+			assert.True(t, ok)
+			assert.Len(t, span.TraceID, 32)
+			assert.Empty(t, span.ParentID)
+			return &entities.Device{MACAddress: mac}, nil
+		}).Once()
+		repo.EXPECT().Delete(mock.Anything, mac).Return(errors.New("connection reset")).Once()
+
+		observed := NewObservedDeviceRepository(repo, recorder)
+		device, err := observed.FindByMACAddress(context.Background(), mac)
+		require.NoError(t, err)
+		assert.Equal(t, mac, device.MACAddress)
+		assert.Error(t, observed.Delete(context.Background(), mac))
+
+		assert.Equal(t, 1.0, recorder.calls.Value("DeviceRepository", "FindByMACAddress", "ok"))
+		assert.Equal(t, 1.0, recorder.calls.Value("DeviceRepository", "Delete", "error"))
+		assert.InDelta(t, 0.02, recorder.duration.Value("DeviceRepository", "FindByMACAddress"), 1e-9)
+		assert.Zero(t, recorder.slow.Value("DeviceRepository", "FindByMACAddress"))
+	})
+
+	t.Run("should count slow calls", func(t *testing.T) {
+		recorder := newTestRecorder(t, 100*time.Millisecond, 200*time.Millisecond)
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().Exists(mock.Anything, mac).Return(true, nil).Once()
+
+		_, err := NewObservedDeviceRepository(repo, recorder).Exists(context.Background(), mac)
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, recorder.slow.Value("DeviceRepository", "Exists"))
+	})
+}
+
+func TestRecorder_Start(t *testing.T) {
+	recorder := newTestRecorder(t, 0, time.Millisecond)
+
+	ctx, parent := recorder.Start(context.Background(), "EventPublisher", "Publish")
+	_, child := recorder.Start(ctx, "DeviceRepository", "Update")
+
+	assert.Equal(t, parent.span.TraceID, child.span.TraceID)
+	assert.Equal(t, parent.span.SpanID, child.span.ParentID)
+	assert.NotEqual(t, parent.span.SpanID, child.span.SpanID)
+
+	child.End(nil)
+	parent.End(nil)
+	assert.Zero(t, recorder.slow.Value("EventPublisher", "Publish"), "a zero threshold disables slow-call logging")
+}
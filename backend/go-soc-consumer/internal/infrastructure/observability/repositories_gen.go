@@ -0,0 +1,1214 @@
+// Code generated by portgen from ../../domain/ports/repositories. DO NOT EDIT.
+
+package observability
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+)
+
+// observedBlacklistRepository reports the calls made through the wrapped BlacklistRepository to a Recorder
+type observedBlacklistRepository struct {
+	inner    repositoryports.BlacklistRepository
+	recorder *Recorder
+}
+
+// NewObservedBlacklistRepository wraps the BlacklistRepository with call metrics, tracing and slow-call logging
+func NewObservedBlacklistRepository(inner repositoryports.BlacklistRepository, recorder *Recorder) repositoryports.BlacklistRepository {
+	return &observedBlacklistRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedBlacklistRepository) Create(ctx context.Context, entry *entities.BlacklistEntry) error {
+	ctx, call := o.recorder.Start(ctx, "BlacklistRepository", "Create")
+	err := o.inner.Create(ctx, entry)
+	call.End(err)
+	return err
+}
+
+func (o *observedBlacklistRepository) Delete(ctx context.Context, id string) error {
+	ctx, call := o.recorder.Start(ctx, "BlacklistRepository", "Delete")
+	err := o.inner.Delete(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (o *observedBlacklistRepository) List(ctx context.Context) ([]*entities.BlacklistEntry, error) {
+	ctx, call := o.recorder.Start(ctx, "BlacklistRepository", "List")
+	r0, err := o.inner.List(ctx)
+	call.End(err)
+	return r0, err
+}
+
+// observedCrashReportRepository reports the calls made through the wrapped CrashReportRepository to a Recorder
+type observedCrashReportRepository struct {
+	inner    repositoryports.CrashReportRepository
+	recorder *Recorder
+}
+
+// NewObservedCrashReportRepository wraps the CrashReportRepository with call metrics, tracing and slow-call logging
+func NewObservedCrashReportRepository(inner repositoryports.CrashReportRepository, recorder *Recorder) repositoryports.CrashReportRepository {
+	return &observedCrashReportRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedCrashReportRepository) Record(ctx context.Context, report *entities.CrashReport) (bool, error) {
+	ctx, call := o.recorder.Start(ctx, "CrashReportRepository", "Record")
+	r0, err := o.inner.Record(ctx, report)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedCrashReportRepository) FirmwareStats(ctx context.Context, since time.Time) ([]*entities.FirmwareCrashStats, error) {
+	ctx, call := o.recorder.Start(ctx, "CrashReportRepository", "FirmwareStats")
+	r0, err := o.inner.FirmwareStats(ctx, since)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedCrashReportRepository) SignatureStats(ctx context.Context, since time.Time) ([]*entities.CrashSignature, error) {
+	ctx, call := o.recorder.Start(ctx, "CrashReportRepository", "SignatureStats")
+	r0, err := o.inner.SignatureStats(ctx, since)
+	call.End(err)
+	return r0, err
+}
+
+// observedDeviceArchiveRepository reports the calls made through the wrapped DeviceArchiveRepository to a Recorder
+type observedDeviceArchiveRepository struct {
+	inner    repositoryports.DeviceArchiveRepository
+	recorder *Recorder
+}
+
+// NewObservedDeviceArchiveRepository wraps the DeviceArchiveRepository with call metrics, tracing and slow-call logging
+func NewObservedDeviceArchiveRepository(inner repositoryports.DeviceArchiveRepository, recorder *Recorder) repositoryports.DeviceArchiveRepository {
+	return &observedDeviceArchiveRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedDeviceArchiveRepository) Archive(ctx context.Context, archive *entities.DeviceArchive) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceArchiveRepository", "Archive")
+	err := o.inner.Archive(ctx, archive)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceArchiveRepository) FindByID(ctx context.Context, id string) (*entities.DeviceArchive, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceArchiveRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceArchiveRepository) List(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceArchive, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceArchiveRepository", "List")
+	r0, err := o.inner.List(ctx, macAddress, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceArchiveRepository) Measurements(ctx context.Context, archiveID string) ([]*entities.Measurement, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceArchiveRepository", "Measurements")
+	r0, err := o.inner.Measurements(ctx, archiveID)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceArchiveRepository) Offload(ctx context.Context, archive *entities.DeviceArchive) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceArchiveRepository", "Offload")
+	err := o.inner.Offload(ctx, archive)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceArchiveRepository) Restore(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceArchiveRepository", "Restore")
+	err := o.inner.Restore(ctx, archive, measurements)
+	call.End(err)
+	return err
+}
+
+// observedDeviceCommandRepository reports the calls made through the wrapped DeviceCommandRepository to a Recorder
+type observedDeviceCommandRepository struct {
+	inner    repositoryports.DeviceCommandRepository
+	recorder *Recorder
+}
+
+// NewObservedDeviceCommandRepository wraps the DeviceCommandRepository with call metrics, tracing and slow-call logging
+func NewObservedDeviceCommandRepository(inner repositoryports.DeviceCommandRepository, recorder *Recorder) repositoryports.DeviceCommandRepository {
+	return &observedDeviceCommandRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedDeviceCommandRepository) Create(ctx context.Context, command *entities.DeviceCommand) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceCommandRepository", "Create")
+	err := o.inner.Create(ctx, command)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceCommandRepository) FindByID(ctx context.Context, id string) (*entities.DeviceCommand, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceCommandRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceCommandRepository) ListByDevice(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceCommand, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceCommandRepository", "ListByDevice")
+	r0, err := o.inner.ListByDevice(ctx, macAddress, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceCommandRepository) Complete(ctx context.Context, id string, status string, message string, ackedAt time.Time) (bool, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceCommandRepository", "Complete")
+	r0, err := o.inner.Complete(ctx, id, status, message, ackedAt)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceCommandRepository) RecordAttempt(ctx context.Context, id string, attempts int, expiresAt time.Time) (bool, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceCommandRepository", "RecordAttempt")
+	r0, err := o.inner.RecordAttempt(ctx, id, attempts, expiresAt)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceCommandRepository) ExpirePending(ctx context.Context, now time.Time) (int, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceCommandRepository", "ExpirePending")
+	r0, err := o.inner.ExpirePending(ctx, now)
+	call.End(err)
+	return r0, err
+}
+
+// observedDeviceMigrationRepository reports the calls made through the wrapped DeviceMigrationRepository to a Recorder
+type observedDeviceMigrationRepository struct {
+	inner    repositoryports.DeviceMigrationRepository
+	recorder *Recorder
+}
+
+// NewObservedDeviceMigrationRepository wraps the DeviceMigrationRepository with call metrics, tracing and slow-call logging
+func NewObservedDeviceMigrationRepository(inner repositoryports.DeviceMigrationRepository, recorder *Recorder) repositoryports.DeviceMigrationRepository {
+	return &observedDeviceMigrationRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedDeviceMigrationRepository) Create(ctx context.Context, migration *entities.DeviceMigration, targets []*entities.DeviceMigrationTarget) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceMigrationRepository", "Create")
+	err := o.inner.Create(ctx, migration, targets)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceMigrationRepository) FindByID(ctx context.Context, id string) (*entities.DeviceMigration, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceMigrationRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceMigrationRepository) FindRunning(ctx context.Context) (*entities.DeviceMigration, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceMigrationRepository", "FindRunning")
+	r0, err := o.inner.FindRunning(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceMigrationRepository) List(ctx context.Context, limit int) ([]*entities.DeviceMigration, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceMigrationRepository", "List")
+	r0, err := o.inner.List(ctx, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceMigrationRepository) Update(ctx context.Context, migration *entities.DeviceMigration) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceMigrationRepository", "Update")
+	err := o.inner.Update(ctx, migration)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceMigrationRepository) Targets(ctx context.Context, migrationID string, status string) ([]*entities.DeviceMigrationTarget, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceMigrationRepository", "Targets")
+	r0, err := o.inner.Targets(ctx, migrationID, status)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceMigrationRepository) UpdateTarget(ctx context.Context, target *entities.DeviceMigrationTarget) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceMigrationRepository", "UpdateTarget")
+	err := o.inner.UpdateTarget(ctx, target)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceMigrationRepository) MarkReconnected(ctx context.Context, migrationID string, macAddress string, at time.Time) (bool, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceMigrationRepository", "MarkReconnected")
+	r0, err := o.inner.MarkReconnected(ctx, migrationID, macAddress, at)
+	call.End(err)
+	return r0, err
+}
+
+// observedDeviceOnboardingRepository reports the calls made through the wrapped DeviceOnboardingRepository to a Recorder
+type observedDeviceOnboardingRepository struct {
+	inner    repositoryports.DeviceOnboardingRepository
+	recorder *Recorder
+}
+
+// NewObservedDeviceOnboardingRepository wraps the DeviceOnboardingRepository with call metrics, tracing and slow-call logging
+func NewObservedDeviceOnboardingRepository(inner repositoryports.DeviceOnboardingRepository, recorder *Recorder) repositoryports.DeviceOnboardingRepository {
+	return &observedDeviceOnboardingRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedDeviceOnboardingRepository) Create(ctx context.Context, onboarding *entities.DeviceOnboarding) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceOnboardingRepository", "Create")
+	err := o.inner.Create(ctx, onboarding)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceOnboardingRepository) Update(ctx context.Context, onboarding *entities.DeviceOnboarding) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceOnboardingRepository", "Update")
+	err := o.inner.Update(ctx, onboarding)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceOnboardingRepository) Delete(ctx context.Context, id string) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceOnboardingRepository", "Delete")
+	err := o.inner.Delete(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceOnboardingRepository) FindByID(ctx context.Context, id string) (*entities.DeviceOnboarding, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceOnboardingRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceOnboardingRepository) ListPending(ctx context.Context) ([]*entities.DeviceOnboarding, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceOnboardingRepository", "ListPending")
+	r0, err := o.inner.ListPending(ctx)
+	call.End(err)
+	return r0, err
+}
+
+// observedDeviceRepository reports the calls made through the wrapped DeviceRepository to a Recorder
+type observedDeviceRepository struct {
+	inner    repositoryports.DeviceRepository
+	recorder *Recorder
+}
+
+// NewObservedDeviceRepository wraps the DeviceRepository with call metrics, tracing and slow-call logging
+func NewObservedDeviceRepository(inner repositoryports.DeviceRepository, recorder *Recorder) repositoryports.DeviceRepository {
+	return &observedDeviceRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedDeviceRepository) Create(ctx context.Context, device *entities.Device) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceRepository", "Create")
+	err := o.inner.Create(ctx, device)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceRepository) Update(ctx context.Context, device *entities.Device) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceRepository", "Update")
+	err := o.inner.Update(ctx, device)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceRepository) FindByMACAddress(ctx context.Context, macAddress string) (*entities.Device, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceRepository", "FindByMACAddress")
+	r0, err := o.inner.FindByMACAddress(ctx, macAddress)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceRepository) FindByMACAddresses(ctx context.Context, macAddresses []string) ([]*entities.Device, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceRepository", "FindByMACAddresses")
+	r0, err := o.inner.FindByMACAddresses(ctx, macAddresses)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceRepository) Exists(ctx context.Context, macAddress string) (bool, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceRepository", "Exists")
+	r0, err := o.inner.Exists(ctx, macAddress)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceRepository) List(ctx context.Context, offset int, limit int) ([]*entities.Device, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceRepository", "List")
+	r0, err := o.inner.List(ctx, offset, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceRepository) ListByStatus(ctx context.Context, status string, offset int, limit int) ([]*entities.Device, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceRepository", "ListByStatus")
+	r0, err := o.inner.ListByStatus(ctx, status, offset, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceRepository) CountByVersion(ctx context.Context) ([]*entities.FleetVersionCount, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceRepository", "CountByVersion")
+	r0, err := o.inner.CountByVersion(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceRepository) CountByFarm(ctx context.Context, farmID string) (int, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceRepository", "CountByFarm")
+	r0, err := o.inner.CountByFarm(ctx, farmID)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceRepository) Delete(ctx context.Context, macAddress string) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceRepository", "Delete")
+	err := o.inner.Delete(ctx, macAddress)
+	call.End(err)
+	return err
+}
+
+// observedDeviceShadowRepository reports the calls made through the wrapped DeviceShadowRepository to a Recorder
+type observedDeviceShadowRepository struct {
+	inner    repositoryports.DeviceShadowRepository
+	recorder *Recorder
+}
+
+// NewObservedDeviceShadowRepository wraps the DeviceShadowRepository with call metrics, tracing and slow-call logging
+func NewObservedDeviceShadowRepository(inner repositoryports.DeviceShadowRepository, recorder *Recorder) repositoryports.DeviceShadowRepository {
+	return &observedDeviceShadowRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedDeviceShadowRepository) Upsert(ctx context.Context, shadow *entities.DeviceShadow) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceShadowRepository", "Upsert")
+	err := o.inner.Upsert(ctx, shadow)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceShadowRepository) Delete(ctx context.Context, macAddress string) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceShadowRepository", "Delete")
+	err := o.inner.Delete(ctx, macAddress)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceShadowRepository) List(ctx context.Context) ([]*entities.DeviceShadow, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceShadowRepository", "List")
+	r0, err := o.inner.List(ctx)
+	call.End(err)
+	return r0, err
+}
+
+// observedDeviceTransferRepository reports the calls made through the wrapped DeviceTransferRepository to a Recorder
+type observedDeviceTransferRepository struct {
+	inner    repositoryports.DeviceTransferRepository
+	recorder *Recorder
+}
+
+// NewObservedDeviceTransferRepository wraps the DeviceTransferRepository with call metrics, tracing and slow-call logging
+func NewObservedDeviceTransferRepository(inner repositoryports.DeviceTransferRepository, recorder *Recorder) repositoryports.DeviceTransferRepository {
+	return &observedDeviceTransferRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedDeviceTransferRepository) Create(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceTransferRepository", "Create")
+	err := o.inner.Create(ctx, transfer, entry)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceTransferRepository) Update(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error {
+	ctx, call := o.recorder.Start(ctx, "DeviceTransferRepository", "Update")
+	err := o.inner.Update(ctx, transfer, entry)
+	call.End(err)
+	return err
+}
+
+func (o *observedDeviceTransferRepository) FindByID(ctx context.Context, id string) (*entities.DeviceTransfer, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceTransferRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceTransferRepository) List(ctx context.Context, macAddress string, status string, limit int) ([]*entities.DeviceTransfer, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceTransferRepository", "List")
+	r0, err := o.inner.List(ctx, macAddress, status, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceTransferRepository) AuditEntries(ctx context.Context, transferID string) ([]entities.DeviceTransferAuditEntry, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceTransferRepository", "AuditEntries")
+	r0, err := o.inner.AuditEntries(ctx, transferID)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedDeviceTransferRepository) Complete(ctx context.Context, transfer *entities.DeviceTransfer, device *entities.Device, actor string, at time.Time) (entities.DeviceTransferAuditEntry, error) {
+	ctx, call := o.recorder.Start(ctx, "DeviceTransferRepository", "Complete")
+	r0, err := o.inner.Complete(ctx, transfer, device, actor, at)
+	call.End(err)
+	return r0, err
+}
+
+// observedFarmRepository reports the calls made through the wrapped FarmRepository to a Recorder
+type observedFarmRepository struct {
+	inner    repositoryports.FarmRepository
+	recorder *Recorder
+}
+
+// NewObservedFarmRepository wraps the FarmRepository with call metrics, tracing and slow-call logging
+func NewObservedFarmRepository(inner repositoryports.FarmRepository, recorder *Recorder) repositoryports.FarmRepository {
+	return &observedFarmRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedFarmRepository) Create(ctx context.Context, farm *entities.Farm) error {
+	ctx, call := o.recorder.Start(ctx, "FarmRepository", "Create")
+	err := o.inner.Create(ctx, farm)
+	call.End(err)
+	return err
+}
+
+func (o *observedFarmRepository) Update(ctx context.Context, farm *entities.Farm) error {
+	ctx, call := o.recorder.Start(ctx, "FarmRepository", "Update")
+	err := o.inner.Update(ctx, farm)
+	call.End(err)
+	return err
+}
+
+func (o *observedFarmRepository) Delete(ctx context.Context, id string) error {
+	ctx, call := o.recorder.Start(ctx, "FarmRepository", "Delete")
+	err := o.inner.Delete(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (o *observedFarmRepository) FindByID(ctx context.Context, id string) (*entities.Farm, error) {
+	ctx, call := o.recorder.Start(ctx, "FarmRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedFarmRepository) List(ctx context.Context) ([]*entities.Farm, error) {
+	ctx, call := o.recorder.Start(ctx, "FarmRepository", "List")
+	r0, err := o.inner.List(ctx)
+	call.End(err)
+	return r0, err
+}
+
+// observedIrrigationExperimentRepository reports the calls made through the wrapped IrrigationExperimentRepository to a Recorder
+type observedIrrigationExperimentRepository struct {
+	inner    repositoryports.IrrigationExperimentRepository
+	recorder *Recorder
+}
+
+// NewObservedIrrigationExperimentRepository wraps the IrrigationExperimentRepository with call metrics, tracing and slow-call logging
+func NewObservedIrrigationExperimentRepository(inner repositoryports.IrrigationExperimentRepository, recorder *Recorder) repositoryports.IrrigationExperimentRepository {
+	return &observedIrrigationExperimentRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedIrrigationExperimentRepository) Create(ctx context.Context, experiment *entities.IrrigationExperiment) error {
+	ctx, call := o.recorder.Start(ctx, "IrrigationExperimentRepository", "Create")
+	err := o.inner.Create(ctx, experiment)
+	call.End(err)
+	return err
+}
+
+func (o *observedIrrigationExperimentRepository) Update(ctx context.Context, experiment *entities.IrrigationExperiment) error {
+	ctx, call := o.recorder.Start(ctx, "IrrigationExperimentRepository", "Update")
+	err := o.inner.Update(ctx, experiment)
+	call.End(err)
+	return err
+}
+
+func (o *observedIrrigationExperimentRepository) FindByID(ctx context.Context, id string) (*entities.IrrigationExperiment, error) {
+	ctx, call := o.recorder.Start(ctx, "IrrigationExperimentRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedIrrigationExperimentRepository) List(ctx context.Context) ([]*entities.IrrigationExperiment, error) {
+	ctx, call := o.recorder.Start(ctx, "IrrigationExperimentRepository", "List")
+	r0, err := o.inner.List(ctx)
+	call.End(err)
+	return r0, err
+}
+
+// observedIrrigationOverrideRepository reports the calls made through the wrapped IrrigationOverrideRepository to a Recorder
+type observedIrrigationOverrideRepository struct {
+	inner    repositoryports.IrrigationOverrideRepository
+	recorder *Recorder
+}
+
+// NewObservedIrrigationOverrideRepository wraps the IrrigationOverrideRepository with call metrics, tracing and slow-call logging
+func NewObservedIrrigationOverrideRepository(inner repositoryports.IrrigationOverrideRepository, recorder *Recorder) repositoryports.IrrigationOverrideRepository {
+	return &observedIrrigationOverrideRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedIrrigationOverrideRepository) Upsert(ctx context.Context, override *entities.IrrigationOverride) error {
+	ctx, call := o.recorder.Start(ctx, "IrrigationOverrideRepository", "Upsert")
+	err := o.inner.Upsert(ctx, override)
+	call.End(err)
+	return err
+}
+
+func (o *observedIrrigationOverrideRepository) Delete(ctx context.Context, macAddress string) error {
+	ctx, call := o.recorder.Start(ctx, "IrrigationOverrideRepository", "Delete")
+	err := o.inner.Delete(ctx, macAddress)
+	call.End(err)
+	return err
+}
+
+func (o *observedIrrigationOverrideRepository) List(ctx context.Context) ([]*entities.IrrigationOverride, error) {
+	ctx, call := o.recorder.Start(ctx, "IrrigationOverrideRepository", "List")
+	r0, err := o.inner.List(ctx)
+	call.End(err)
+	return r0, err
+}
+
+// observedIrrigationScheduleRepository reports the calls made through the wrapped IrrigationScheduleRepository to a Recorder
+type observedIrrigationScheduleRepository struct {
+	inner    repositoryports.IrrigationScheduleRepository
+	recorder *Recorder
+}
+
+// NewObservedIrrigationScheduleRepository wraps the IrrigationScheduleRepository with call metrics, tracing and slow-call logging
+func NewObservedIrrigationScheduleRepository(inner repositoryports.IrrigationScheduleRepository, recorder *Recorder) repositoryports.IrrigationScheduleRepository {
+	return &observedIrrigationScheduleRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedIrrigationScheduleRepository) Create(ctx context.Context, schedule *entities.IrrigationSchedule) error {
+	ctx, call := o.recorder.Start(ctx, "IrrigationScheduleRepository", "Create")
+	err := o.inner.Create(ctx, schedule)
+	call.End(err)
+	return err
+}
+
+func (o *observedIrrigationScheduleRepository) Update(ctx context.Context, schedule *entities.IrrigationSchedule) error {
+	ctx, call := o.recorder.Start(ctx, "IrrigationScheduleRepository", "Update")
+	err := o.inner.Update(ctx, schedule)
+	call.End(err)
+	return err
+}
+
+func (o *observedIrrigationScheduleRepository) RecordRun(ctx context.Context, id string, lastRunAt *time.Time, runEndsAt *time.Time) error {
+	ctx, call := o.recorder.Start(ctx, "IrrigationScheduleRepository", "RecordRun")
+	err := o.inner.RecordRun(ctx, id, lastRunAt, runEndsAt)
+	call.End(err)
+	return err
+}
+
+func (o *observedIrrigationScheduleRepository) Delete(ctx context.Context, id string) error {
+	ctx, call := o.recorder.Start(ctx, "IrrigationScheduleRepository", "Delete")
+	err := o.inner.Delete(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (o *observedIrrigationScheduleRepository) FindByID(ctx context.Context, id string) (*entities.IrrigationSchedule, error) {
+	ctx, call := o.recorder.Start(ctx, "IrrigationScheduleRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedIrrigationScheduleRepository) List(ctx context.Context, macAddress string) ([]*entities.IrrigationSchedule, error) {
+	ctx, call := o.recorder.Start(ctx, "IrrigationScheduleRepository", "List")
+	r0, err := o.inner.List(ctx, macAddress)
+	call.End(err)
+	return r0, err
+}
+
+// observedJobRepository reports the calls made through the wrapped JobRepository to a Recorder
+type observedJobRepository struct {
+	inner    repositoryports.JobRepository
+	recorder *Recorder
+}
+
+// NewObservedJobRepository wraps the JobRepository with call metrics, tracing and slow-call logging
+func NewObservedJobRepository(inner repositoryports.JobRepository, recorder *Recorder) repositoryports.JobRepository {
+	return &observedJobRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedJobRepository) Create(ctx context.Context, job *entities.Job) error {
+	ctx, call := o.recorder.Start(ctx, "JobRepository", "Create")
+	err := o.inner.Create(ctx, job)
+	call.End(err)
+	return err
+}
+
+func (o *observedJobRepository) FindByID(ctx context.Context, id string) (*entities.Job, error) {
+	ctx, call := o.recorder.Start(ctx, "JobRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedJobRepository) ClaimNext(ctx context.Context, types []string, leaseUntil time.Time) (*entities.Job, error) {
+	ctx, call := o.recorder.Start(ctx, "JobRepository", "ClaimNext")
+	r0, err := o.inner.ClaimNext(ctx, types, leaseUntil)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedJobRepository) Heartbeat(ctx context.Context, id string, leaseUntil time.Time) (bool, error) {
+	ctx, call := o.recorder.Start(ctx, "JobRepository", "Heartbeat")
+	r0, err := o.inner.Heartbeat(ctx, id, leaseUntil)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedJobRepository) UpdateProgress(ctx context.Context, id string, progress int, message string) error {
+	ctx, call := o.recorder.Start(ctx, "JobRepository", "UpdateProgress")
+	err := o.inner.UpdateProgress(ctx, id, progress, message)
+	call.End(err)
+	return err
+}
+
+func (o *observedJobRepository) Finish(ctx context.Context, job *entities.Job) error {
+	ctx, call := o.recorder.Start(ctx, "JobRepository", "Finish")
+	err := o.inner.Finish(ctx, job)
+	call.End(err)
+	return err
+}
+
+func (o *observedJobRepository) ListUnfinished(ctx context.Context, limit int) ([]*entities.Job, error) {
+	ctx, call := o.recorder.Start(ctx, "JobRepository", "ListUnfinished")
+	r0, err := o.inner.ListUnfinished(ctx, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedJobRepository) RequestCancel(ctx context.Context, id string) (*entities.Job, error) {
+	ctx, call := o.recorder.Start(ctx, "JobRepository", "RequestCancel")
+	r0, err := o.inner.RequestCancel(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+// observedMeasurementRepository reports the calls made through the wrapped MeasurementRepository to a Recorder
+type observedMeasurementRepository struct {
+	inner    repositoryports.MeasurementRepository
+	recorder *Recorder
+}
+
+// NewObservedMeasurementRepository wraps the MeasurementRepository with call metrics, tracing and slow-call logging
+func NewObservedMeasurementRepository(inner repositoryports.MeasurementRepository, recorder *Recorder) repositoryports.MeasurementRepository {
+	return &observedMeasurementRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedMeasurementRepository) Create(ctx context.Context, measurements []*entities.Measurement) error {
+	ctx, call := o.recorder.Start(ctx, "MeasurementRepository", "Create")
+	err := o.inner.Create(ctx, measurements)
+	call.End(err)
+	return err
+}
+
+func (o *observedMeasurementRepository) Upsert(ctx context.Context, measurements []*entities.Measurement, window time.Duration) error {
+	ctx, call := o.recorder.Start(ctx, "MeasurementRepository", "Upsert")
+	err := o.inner.Upsert(ctx, measurements, window)
+	call.End(err)
+	return err
+}
+
+func (o *observedMeasurementRepository) Latest(ctx context.Context, sensorTypes []string, since time.Time) ([]*entities.Measurement, error) {
+	ctx, call := o.recorder.Start(ctx, "MeasurementRepository", "Latest")
+	r0, err := o.inner.Latest(ctx, sensorTypes, since)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedMeasurementRepository) LatestByDevice(ctx context.Context, macAddress string, since time.Time) ([]*entities.Measurement, error) {
+	ctx, call := o.recorder.Start(ctx, "MeasurementRepository", "LatestByDevice")
+	r0, err := o.inner.LatestByDevice(ctx, macAddress, since)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedMeasurementRepository) History(ctx context.Context, macAddress string, sensorType string, from time.Time, to time.Time) ([]*entities.Measurement, error) {
+	ctx, call := o.recorder.Start(ctx, "MeasurementRepository", "History")
+	r0, err := o.inner.History(ctx, macAddress, sensorType, from, to)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedMeasurementRepository) Averages(ctx context.Context, macAddress string, sensorType string, resolution string, from time.Time, to time.Time) ([]*entities.MeasurementAverage, error) {
+	ctx, call := o.recorder.Start(ctx, "MeasurementRepository", "Averages")
+	r0, err := o.inner.Averages(ctx, macAddress, sensorType, resolution, from, to)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedMeasurementRepository) EnsureTypeViews(ctx context.Context, sensorTypes []*entities.SensorType) error {
+	ctx, call := o.recorder.Start(ctx, "MeasurementRepository", "EnsureTypeViews")
+	err := o.inner.EnsureTypeViews(ctx, sensorTypes)
+	call.End(err)
+	return err
+}
+
+// observedNotificationInboxRepository reports the calls made through the wrapped NotificationInboxRepository to a Recorder
+type observedNotificationInboxRepository struct {
+	inner    repositoryports.NotificationInboxRepository
+	recorder *Recorder
+}
+
+// NewObservedNotificationInboxRepository wraps the NotificationInboxRepository with call metrics, tracing and slow-call logging
+func NewObservedNotificationInboxRepository(inner repositoryports.NotificationInboxRepository, recorder *Recorder) repositoryports.NotificationInboxRepository {
+	return &observedNotificationInboxRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedNotificationInboxRepository) Create(ctx context.Context, notification *entities.InboxNotification) error {
+	ctx, call := o.recorder.Start(ctx, "NotificationInboxRepository", "Create")
+	err := o.inner.Create(ctx, notification)
+	call.End(err)
+	return err
+}
+
+func (o *observedNotificationInboxRepository) List(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int) ([]*entities.InboxNotification, error) {
+	ctx, call := o.recorder.Start(ctx, "NotificationInboxRepository", "List")
+	r0, err := o.inner.List(ctx, user, unreadOnly, before, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedNotificationInboxRepository) CountUnread(ctx context.Context, user string) (map[string]int, error) {
+	ctx, call := o.recorder.Start(ctx, "NotificationInboxRepository", "CountUnread")
+	r0, err := o.inner.CountUnread(ctx, user)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedNotificationInboxRepository) MarkRead(ctx context.Context, user string, id string, readAt time.Time) error {
+	ctx, call := o.recorder.Start(ctx, "NotificationInboxRepository", "MarkRead")
+	err := o.inner.MarkRead(ctx, user, id, readAt)
+	call.End(err)
+	return err
+}
+
+func (o *observedNotificationInboxRepository) MarkAllRead(ctx context.Context, user string, readAt time.Time) (int, error) {
+	ctx, call := o.recorder.Start(ctx, "NotificationInboxRepository", "MarkAllRead")
+	r0, err := o.inner.MarkAllRead(ctx, user, readAt)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedNotificationInboxRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error) {
+	ctx, call := o.recorder.Start(ctx, "NotificationInboxRepository", "DeleteCreatedBefore")
+	r0, err := o.inner.DeleteCreatedBefore(ctx, before)
+	call.End(err)
+	return r0, err
+}
+
+// observedResearchTokenRepository reports the calls made through the wrapped ResearchTokenRepository to a Recorder
+type observedResearchTokenRepository struct {
+	inner    repositoryports.ResearchTokenRepository
+	recorder *Recorder
+}
+
+// NewObservedResearchTokenRepository wraps the ResearchTokenRepository with call metrics, tracing and slow-call logging
+func NewObservedResearchTokenRepository(inner repositoryports.ResearchTokenRepository, recorder *Recorder) repositoryports.ResearchTokenRepository {
+	return &observedResearchTokenRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedResearchTokenRepository) Create(ctx context.Context, token *entities.ResearchToken) error {
+	ctx, call := o.recorder.Start(ctx, "ResearchTokenRepository", "Create")
+	err := o.inner.Create(ctx, token)
+	call.End(err)
+	return err
+}
+
+func (o *observedResearchTokenRepository) Update(ctx context.Context, token *entities.ResearchToken) error {
+	ctx, call := o.recorder.Start(ctx, "ResearchTokenRepository", "Update")
+	err := o.inner.Update(ctx, token)
+	call.End(err)
+	return err
+}
+
+func (o *observedResearchTokenRepository) FindByID(ctx context.Context, id string) (*entities.ResearchToken, error) {
+	ctx, call := o.recorder.Start(ctx, "ResearchTokenRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedResearchTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entities.ResearchToken, error) {
+	ctx, call := o.recorder.Start(ctx, "ResearchTokenRepository", "FindByHash")
+	r0, err := o.inner.FindByHash(ctx, tokenHash)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedResearchTokenRepository) List(ctx context.Context) ([]*entities.ResearchToken, error) {
+	ctx, call := o.recorder.Start(ctx, "ResearchTokenRepository", "List")
+	r0, err := o.inner.List(ctx)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedResearchTokenRepository) RecordUsage(ctx context.Context, tokenID string, day time.Time, readings int) error {
+	ctx, call := o.recorder.Start(ctx, "ResearchTokenRepository", "RecordUsage")
+	err := o.inner.RecordUsage(ctx, tokenID, day, readings)
+	call.End(err)
+	return err
+}
+
+func (o *observedResearchTokenRepository) Usage(ctx context.Context, tokenID string, from time.Time, to time.Time) ([]*entities.ResearchTokenUsage, error) {
+	ctx, call := o.recorder.Start(ctx, "ResearchTokenRepository", "Usage")
+	r0, err := o.inner.Usage(ctx, tokenID, from, to)
+	call.End(err)
+	return r0, err
+}
+
+// observedRetentionRepository reports the calls made through the wrapped RetentionRepository to a Recorder
+type observedRetentionRepository struct {
+	inner    repositoryports.RetentionRepository
+	recorder *Recorder
+}
+
+// NewObservedRetentionRepository wraps the RetentionRepository with call metrics, tracing and slow-call logging
+func NewObservedRetentionRepository(inner repositoryports.RetentionRepository, recorder *Recorder) repositoryports.RetentionRepository {
+	return &observedRetentionRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedRetentionRepository) Prune(ctx context.Context, dataset string, before time.Time, limit int) (int, error) {
+	ctx, call := o.recorder.Start(ctx, "RetentionRepository", "Prune")
+	r0, err := o.inner.Prune(ctx, dataset, before, limit)
+	call.End(err)
+	return r0, err
+}
+
+// observedSensorChannelRepository reports the calls made through the wrapped SensorChannelRepository to a Recorder
+type observedSensorChannelRepository struct {
+	inner    repositoryports.SensorChannelRepository
+	recorder *Recorder
+}
+
+// NewObservedSensorChannelRepository wraps the SensorChannelRepository with call metrics, tracing and slow-call logging
+func NewObservedSensorChannelRepository(inner repositoryports.SensorChannelRepository, recorder *Recorder) repositoryports.SensorChannelRepository {
+	return &observedSensorChannelRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedSensorChannelRepository) FindByDevice(ctx context.Context, macAddress string) ([]*entities.SensorChannel, error) {
+	ctx, call := o.recorder.Start(ctx, "SensorChannelRepository", "FindByDevice")
+	r0, err := o.inner.FindByDevice(ctx, macAddress)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedSensorChannelRepository) Save(ctx context.Context, channel *entities.SensorChannel) error {
+	ctx, call := o.recorder.Start(ctx, "SensorChannelRepository", "Save")
+	err := o.inner.Save(ctx, channel)
+	call.End(err)
+	return err
+}
+
+func (o *observedSensorChannelRepository) CreateMissing(ctx context.Context, channels []*entities.SensorChannel) error {
+	ctx, call := o.recorder.Start(ctx, "SensorChannelRepository", "CreateMissing")
+	err := o.inner.CreateMissing(ctx, channels)
+	call.End(err)
+	return err
+}
+
+// observedSensorTemperatureHumidityRepository reports the calls made through the wrapped SensorTemperatureHumidityRepository to a Recorder
+type observedSensorTemperatureHumidityRepository struct {
+	inner    repositoryports.SensorTemperatureHumidityRepository
+	recorder *Recorder
+}
+
+// NewObservedSensorTemperatureHumidityRepository wraps the SensorTemperatureHumidityRepository with call metrics, tracing and slow-call logging
+func NewObservedSensorTemperatureHumidityRepository(inner repositoryports.SensorTemperatureHumidityRepository, recorder *Recorder) repositoryports.SensorTemperatureHumidityRepository {
+	return &observedSensorTemperatureHumidityRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedSensorTemperatureHumidityRepository) Create(ctx context.Context, sensorData *entities.SensorTemperatureHumidity) error {
+	ctx, call := o.recorder.Start(ctx, "SensorTemperatureHumidityRepository", "Create")
+	err := o.inner.Create(ctx, sensorData)
+	call.End(err)
+	return err
+}
+
+func (o *observedSensorTemperatureHumidityRepository) Upsert(ctx context.Context, sensorData *entities.SensorTemperatureHumidity, window time.Duration) error {
+	ctx, call := o.recorder.Start(ctx, "SensorTemperatureHumidityRepository", "Upsert")
+	err := o.inner.Upsert(ctx, sensorData, window)
+	call.End(err)
+	return err
+}
+
+func (o *observedSensorTemperatureHumidityRepository) FindByMACAddress(ctx context.Context, macAddress string, from time.Time, to time.Time) ([]*entities.SensorTemperatureHumidity, error) {
+	ctx, call := o.recorder.Start(ctx, "SensorTemperatureHumidityRepository", "FindByMACAddress")
+	r0, err := o.inner.FindByMACAddress(ctx, macAddress, from, to)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedSensorTemperatureHumidityRepository) ListByMACAddress(ctx context.Context, macAddress string, from time.Time, to time.Time, offset int, limit int) ([]*entities.SensorTemperatureHumidity, error) {
+	ctx, call := o.recorder.Start(ctx, "SensorTemperatureHumidityRepository", "ListByMACAddress")
+	r0, err := o.inner.ListByMACAddress(ctx, macAddress, from, to, offset, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedSensorTemperatureHumidityRepository) FindLatest(ctx context.Context, macAddresses []string) ([]*entities.SensorTemperatureHumidity, error) {
+	ctx, call := o.recorder.Start(ctx, "SensorTemperatureHumidityRepository", "FindLatest")
+	r0, err := o.inner.FindLatest(ctx, macAddresses)
+	call.End(err)
+	return r0, err
+}
+
+// observedSyncOutboxRepository reports the calls made through the wrapped SyncOutboxRepository to a Recorder
+type observedSyncOutboxRepository struct {
+	inner    repositoryports.SyncOutboxRepository
+	recorder *Recorder
+}
+
+// NewObservedSyncOutboxRepository wraps the SyncOutboxRepository with call metrics, tracing and slow-call logging
+func NewObservedSyncOutboxRepository(inner repositoryports.SyncOutboxRepository, recorder *Recorder) repositoryports.SyncOutboxRepository {
+	return &observedSyncOutboxRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedSyncOutboxRepository) Enqueue(ctx context.Context, item *entities.SyncItem) error {
+	ctx, call := o.recorder.Start(ctx, "SyncOutboxRepository", "Enqueue")
+	err := o.inner.Enqueue(ctx, item)
+	call.End(err)
+	return err
+}
+
+func (o *observedSyncOutboxRepository) ListPending(ctx context.Context, limit int) ([]*entities.SyncItem, error) {
+	ctx, call := o.recorder.Start(ctx, "SyncOutboxRepository", "ListPending")
+	r0, err := o.inner.ListPending(ctx, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedSyncOutboxRepository) MarkSynced(ctx context.Context, ids []string, syncedAt time.Time) error {
+	ctx, call := o.recorder.Start(ctx, "SyncOutboxRepository", "MarkSynced")
+	err := o.inner.MarkSynced(ctx, ids, syncedAt)
+	call.End(err)
+	return err
+}
+
+func (o *observedSyncOutboxRepository) MarkFailed(ctx context.Context, ids []string, reason string) error {
+	ctx, call := o.recorder.Start(ctx, "SyncOutboxRepository", "MarkFailed")
+	err := o.inner.MarkFailed(ctx, ids, reason)
+	call.End(err)
+	return err
+}
+
+func (o *observedSyncOutboxRepository) CountPending(ctx context.Context) (int64, error) {
+	ctx, call := o.recorder.Start(ctx, "SyncOutboxRepository", "CountPending")
+	r0, err := o.inner.CountPending(ctx)
+	call.End(err)
+	return r0, err
+}
+
+// observedTelemetryArchiveRepository reports the calls made through the wrapped TelemetryArchiveRepository to a Recorder
+type observedTelemetryArchiveRepository struct {
+	inner    repositoryports.TelemetryArchiveRepository
+	recorder *Recorder
+}
+
+// NewObservedTelemetryArchiveRepository wraps the TelemetryArchiveRepository with call metrics, tracing and slow-call logging
+func NewObservedTelemetryArchiveRepository(inner repositoryports.TelemetryArchiveRepository, recorder *Recorder) repositoryports.TelemetryArchiveRepository {
+	return &observedTelemetryArchiveRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedTelemetryArchiveRepository) ListCompactableDays(ctx context.Context, before time.Time, limit int) ([]entities.TelemetryDay, error) {
+	ctx, call := o.recorder.Start(ctx, "TelemetryArchiveRepository", "ListCompactableDays")
+	r0, err := o.inner.ListCompactableDays(ctx, before, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedTelemetryArchiveRepository) CompactDay(ctx context.Context, day entities.TelemetryDay) (int, error) {
+	ctx, call := o.recorder.Start(ctx, "TelemetryArchiveRepository", "CompactDay")
+	r0, err := o.inner.CompactDay(ctx, day)
+	call.End(err)
+	return r0, err
+}
+
+// observedUsageRepository reports the calls made through the wrapped UsageRepository to a Recorder
+type observedUsageRepository struct {
+	inner    repositoryports.UsageRepository
+	recorder *Recorder
+}
+
+// NewObservedUsageRepository wraps the UsageRepository with call metrics, tracing and slow-call logging
+func NewObservedUsageRepository(inner repositoryports.UsageRepository, recorder *Recorder) repositoryports.UsageRepository {
+	return &observedUsageRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedUsageRepository) AddUsage(ctx context.Context, records []*entities.UsageRecord) error {
+	ctx, call := o.recorder.Start(ctx, "UsageRepository", "AddUsage")
+	err := o.inner.AddUsage(ctx, records)
+	call.End(err)
+	return err
+}
+
+func (o *observedUsageRepository) SaveDeviceCounts(ctx context.Context, day time.Time, devices map[string]int) error {
+	ctx, call := o.recorder.Start(ctx, "UsageRepository", "SaveDeviceCounts")
+	err := o.inner.SaveDeviceCounts(ctx, day, devices)
+	call.End(err)
+	return err
+}
+
+func (o *observedUsageRepository) ListByMonth(ctx context.Context, month time.Time) ([]*entities.UsageRecord, error) {
+	ctx, call := o.recorder.Start(ctx, "UsageRepository", "ListByMonth")
+	r0, err := o.inner.ListByMonth(ctx, month)
+	call.End(err)
+	return r0, err
+}
+
+// observedUserRepository reports the calls made through the wrapped UserRepository to a Recorder
+type observedUserRepository struct {
+	inner    repositoryports.UserRepository
+	recorder *Recorder
+}
+
+// NewObservedUserRepository wraps the UserRepository with call metrics, tracing and slow-call logging
+func NewObservedUserRepository(inner repositoryports.UserRepository, recorder *Recorder) repositoryports.UserRepository {
+	return &observedUserRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedUserRepository) Create(ctx context.Context, user *entities.User) error {
+	ctx, call := o.recorder.Start(ctx, "UserRepository", "Create")
+	err := o.inner.Create(ctx, user)
+	call.End(err)
+	return err
+}
+
+func (o *observedUserRepository) Delete(ctx context.Context, username string) error {
+	ctx, call := o.recorder.Start(ctx, "UserRepository", "Delete")
+	err := o.inner.Delete(ctx, username)
+	call.End(err)
+	return err
+}
+
+func (o *observedUserRepository) FindByUsername(ctx context.Context, username string) (*entities.User, error) {
+	ctx, call := o.recorder.Start(ctx, "UserRepository", "FindByUsername")
+	r0, err := o.inner.FindByUsername(ctx, username)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedUserRepository) List(ctx context.Context) ([]*entities.User, error) {
+	ctx, call := o.recorder.Start(ctx, "UserRepository", "List")
+	r0, err := o.inner.List(ctx)
+	call.End(err)
+	return r0, err
+}
+
+// observedValveStateRepository reports the calls made through the wrapped ValveStateRepository to a Recorder
+type observedValveStateRepository struct {
+	inner    repositoryports.ValveStateRepository
+	recorder *Recorder
+}
+
+// NewObservedValveStateRepository wraps the ValveStateRepository with call metrics, tracing and slow-call logging
+func NewObservedValveStateRepository(inner repositoryports.ValveStateRepository, recorder *Recorder) repositoryports.ValveStateRepository {
+	return &observedValveStateRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedValveStateRepository) Record(ctx context.Context, transition *entities.ValveTransition) (bool, error) {
+	ctx, call := o.recorder.Start(ctx, "ValveStateRepository", "Record")
+	r0, err := o.inner.Record(ctx, transition)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedValveStateRepository) Latest(ctx context.Context, macAddress string) (*entities.ValveTransition, error) {
+	ctx, call := o.recorder.Start(ctx, "ValveStateRepository", "Latest")
+	r0, err := o.inner.Latest(ctx, macAddress)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedValveStateRepository) ListByDevice(ctx context.Context, macAddress string, limit int) ([]*entities.ValveTransition, error) {
+	ctx, call := o.recorder.Start(ctx, "ValveStateRepository", "ListByDevice")
+	r0, err := o.inner.ListByDevice(ctx, macAddress, limit)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedValveStateRepository) ListBetween(ctx context.Context, macAddress string, from time.Time, to time.Time) ([]*entities.ValveTransition, error) {
+	ctx, call := o.recorder.Start(ctx, "ValveStateRepository", "ListBetween")
+	r0, err := o.inner.ListBetween(ctx, macAddress, from, to)
+	call.End(err)
+	return r0, err
+}
+
+// observedZoneRepository reports the calls made through the wrapped ZoneRepository to a Recorder
+type observedZoneRepository struct {
+	inner    repositoryports.ZoneRepository
+	recorder *Recorder
+}
+
+// NewObservedZoneRepository wraps the ZoneRepository with call metrics, tracing and slow-call logging
+func NewObservedZoneRepository(inner repositoryports.ZoneRepository, recorder *Recorder) repositoryports.ZoneRepository {
+	return &observedZoneRepository{inner: inner, recorder: recorder}
+}
+
+func (o *observedZoneRepository) Create(ctx context.Context, zone *entities.Zone) error {
+	ctx, call := o.recorder.Start(ctx, "ZoneRepository", "Create")
+	err := o.inner.Create(ctx, zone)
+	call.End(err)
+	return err
+}
+
+func (o *observedZoneRepository) Update(ctx context.Context, zone *entities.Zone) error {
+	ctx, call := o.recorder.Start(ctx, "ZoneRepository", "Update")
+	err := o.inner.Update(ctx, zone)
+	call.End(err)
+	return err
+}
+
+func (o *observedZoneRepository) Delete(ctx context.Context, id string) error {
+	ctx, call := o.recorder.Start(ctx, "ZoneRepository", "Delete")
+	err := o.inner.Delete(ctx, id)
+	call.End(err)
+	return err
+}
+
+func (o *observedZoneRepository) FindByID(ctx context.Context, id string) (*entities.Zone, error) {
+	ctx, call := o.recorder.Start(ctx, "ZoneRepository", "FindByID")
+	r0, err := o.inner.FindByID(ctx, id)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedZoneRepository) ListByFarm(ctx context.Context, farmID string) ([]*entities.Zone, error) {
+	ctx, call := o.recorder.Start(ctx, "ZoneRepository", "ListByFarm")
+	r0, err := o.inner.ListByFarm(ctx, farmID)
+	call.End(err)
+	return r0, err
+}
+
+func (o *observedZoneRepository) AssignDevice(ctx context.Context, zoneID string, macAddress string) error {
+	ctx, call := o.recorder.Start(ctx, "ZoneRepository", "AssignDevice")
+	err := o.inner.AssignDevice(ctx, zoneID, macAddress)
+	call.End(err)
+	return err
+}
+
+func (o *observedZoneRepository) UnassignDevice(ctx context.Context, zoneID string, macAddress string) error {
+	ctx, call := o.recorder.Start(ctx, "ZoneRepository", "UnassignDevice")
+	err := o.inner.UnassignDevice(ctx, zoneID, macAddress)
+	call.End(err)
+	return err
+}
+
+func (o *observedZoneRepository) DeviceMACAddresses(ctx context.Context, zoneID string) ([]string, error) {
+	ctx, call := o.recorder.Start(ctx, "ZoneRepository", "DeviceMACAddresses")
+	r0, err := o.inner.DeviceMACAddresses(ctx, zoneID)
+	call.End(err)
+	return r0, err
+}
@@ -0,0 +1,44 @@
+package observability
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Span identifies one port call within a trace. Calls made with the context of a call
+// become its children and share its trace ID.
+type Span struct {
+	TraceID  string
+	SpanID   string
+	ParentID string // empty for the first call of a trace
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a context carrying the span
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span of the port call the context belongs to
+func SpanFromContext(ctx context.Context) (Span, bool) {
+	span, ok := ctx.Value(spanKey{}).(Span)
+	return span, ok
+}
+
+// childSpan starts a span under the one carried by ctx, or a new trace when there is none
+func childSpan(ctx context.Context) Span {
+	parent, ok := SpanFromContext(ctx)
+	if !ok {
+		return Span{TraceID: newID(16), SpanID: newID(8)}
+	}
+	return Span{TraceID: parent.TraceID, SpanID: newID(8), ParentID: parent.SpanID}
+}
+
+// newID returns a random hex identifier of n bytes
+func newID(n int) string {
+	id := make([]byte, n)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
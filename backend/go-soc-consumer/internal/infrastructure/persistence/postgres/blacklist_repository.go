@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// blacklistRepository implements the BlacklistRepository interface using GORM PostgreSQL
+type blacklistRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.BlacklistEntryMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewBlacklistRepository creates a new GORM-based PostgreSQL blacklist repository
+func NewBlacklistRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.BlacklistRepository {
+	return &blacklistRepository{
+		db:     db,
+		mapper: mappers.NewBlacklistEntryMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new blacklist entry
+func (r *blacklistRepository) Create(ctx context.Context, entry *entities.BlacklistEntry) error {
+	if entry == nil {
+		return fmt.Errorf("blacklist entry cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Create(r.mapper.ToModel(entry))
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return domainerrors.ErrBlacklistEntryExists
+		}
+		r.logger.Error("blacklist_entry_create_failed", zap.String("operation", "create"), zap.String("table", "blacklist_entries"), zap.String("kind", entry.Kind), zap.Error(result.Error))
+		return fmt.Errorf("failed to create blacklist entry: %w", result.Error)
+	}
+	return nil
+}
+
+// Delete removes a blacklist entry by its ID
+func (r *blacklistRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).Delete(&models.BlacklistEntryModel{})
+	if result.Error != nil {
+		r.logger.Error("blacklist_entry_delete_failed", zap.String("operation", "delete"), zap.String("table", "blacklist_entries"), zap.String("id", id), zap.Error(result.Error))
+		return fmt.Errorf("failed to delete blacklist entry: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrBlacklistEntryNotFound
+	}
+	return nil
+}
+
+// List returns every blacklist entry, oldest first
+func (r *blacklistRepository) List(ctx context.Context) ([]*entities.BlacklistEntry, error) {
+	var records []models.BlacklistEntryModel
+	result := r.db.GetDB().WithContext(ctx).Order("created_at ASC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list blacklist entries: %w", result.Error)
+	}
+
+	entries := make([]*entities.BlacklistEntry, 0, len(records))
+	for i := range records {
+		entries = append(entries, r.mapper.FromModel(&records[i]))
+	}
+	return entries, nil
+}
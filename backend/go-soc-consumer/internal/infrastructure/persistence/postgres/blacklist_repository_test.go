@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupBlacklistTestRepository initializes a test repository with a mock database
+func setupBlacklistTestRepository(t *testing.T) (*blacklistRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewBlacklistRepository(postgresDB, loggerFactory).(*blacklistRepository), sqlMock
+}
+
+func TestBlacklistRepository_Create(t *testing.T) {
+	entry, err := entities.NewBlacklistEntry(entities.BlacklistKindMAC, "AA:BB:CC:DD:EE:FF", "stolen")
+	require.NoError(t, err)
+
+	t.Run("should insert the entry", func(t *testing.T) {
+		repo, mock := setupBlacklistTestRepository(t)
+		mock.ExpectQuery(`INSERT INTO "blacklist_entries" .* RETURNING`).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+
+		require.NoError(t, repo.Create(context.Background(), entry))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should map duplicates to already exists", func(t *testing.T) {
+		repo, mock := setupBlacklistTestRepository(t)
+		mock.ExpectQuery(`INSERT INTO "blacklist_entries"`).WillReturnError(gorm.ErrDuplicatedKey)
+
+		assert.ErrorIs(t, repo.Create(context.Background(), entry), domainerrors.ErrBlacklistEntryExists)
+	})
+}
+
+func TestBlacklistRepository_Delete(t *testing.T) {
+	t.Run("should delete the entry", func(t *testing.T) {
+		repo, mock := setupBlacklistTestRepository(t)
+		mock.ExpectExec(`DELETE FROM "blacklist_entries" WHERE id = \$1`).WithArgs("entry-1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.Delete(context.Background(), "entry-1"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		repo, mock := setupBlacklistTestRepository(t)
+		mock.ExpectExec(`DELETE FROM "blacklist_entries"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, repo.Delete(context.Background(), "missing"), domainerrors.ErrBlacklistEntryNotFound)
+	})
+}
+
+func TestBlacklistRepository_List(t *testing.T) {
+	repo, mock := setupBlacklistTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "blacklist_entries" ORDER BY created_at ASC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "value", "reason", "created_at"}).
+			AddRow("entry-1", entities.BlacklistKindIPRange, "10.0.0.0/8", "lab network", time.Now()))
+
+	entries, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "10.0.0.0/8", entries[0].Value)
+	assert.Equal(t, entities.BlacklistKindIPRange, entries[0].Kind)
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// crashReportRepository implements the CrashReportRepository interface using GORM PostgreSQL
+type crashReportRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.CrashReportMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewCrashReportRepository creates a new GORM-based PostgreSQL crash report repository
+func NewCrashReportRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.CrashReportRepository {
+	return &crashReportRepository{
+		db:     db,
+		mapper: mappers.NewCrashReportMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Record stores the signature unless already known, moves its last seen time forward and stores the report
+func (r *crashReportRepository) Record(ctx context.Context, report *entities.CrashReport) (bool, error) {
+	if report == nil {
+		return false, fmt.Errorf("crash report cannot be nil")
+	}
+
+	signature, record := r.mapper.ToModels(report)
+	isNew := false
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(signature)
+		if result.Error != nil {
+			return fmt.Errorf("failed to store crash signature: %w", result.Error)
+		}
+		isNew = result.RowsAffected > 0
+		if !isNew {
+			if err := tx.Model(&models.CrashSignatureModel{}).
+				Where("signature = ?", signature.Signature).
+				Update("last_seen", gorm.Expr("GREATEST(last_seen, ?)", signature.LastSeen)).Error; err != nil {
+				return fmt.Errorf("failed to update crash signature: %w", err)
+			}
+		}
+		if err := tx.Create(record).Error; err != nil {
+			return fmt.Errorf("failed to store crash report: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("crash_report_record_failed", zap.String("operation", "create"), zap.String("table", "crash_reports"), zap.String("mac_address", report.MACAddress), zap.Error(err))
+		return false, err
+	}
+	return isNew, nil
+}
+
+// FirmwareStats aggregates the reports since the given time by firmware version
+func (r *crashReportRepository) FirmwareStats(ctx context.Context, since time.Time) ([]*entities.FirmwareCrashStats, error) {
+	var rows []struct {
+		FirmwareVersion string
+		Crashes         int
+		Devices         int
+		Signatures      int
+		LastCrashAt     time.Time
+	}
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.CrashReportModel{}).
+		Select("firmware_version, COUNT(*) AS crashes, COUNT(DISTINCT mac_address) AS devices, COUNT(DISTINCT signature) AS signatures, MAX(reported_at) AS last_crash_at").
+		Where("reported_at >= ?", since).
+		Group("firmware_version").
+		Order("crashes DESC, firmware_version").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to aggregate crash reports: %w", result.Error)
+	}
+
+	stats := make([]*entities.FirmwareCrashStats, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, &entities.FirmwareCrashStats{
+			FirmwareVersion: row.FirmwareVersion,
+			Crashes:         row.Crashes,
+			Devices:         row.Devices,
+			Signatures:      row.Signatures,
+			LastCrashAt:     row.LastCrashAt,
+		})
+	}
+	return stats, nil
+}
+
+// SignatureStats aggregates the reports since the given time by signature, along with its stack information
+func (r *crashReportRepository) SignatureStats(ctx context.Context, since time.Time) ([]*entities.CrashSignature, error) {
+	var rows []struct {
+		Signature       string
+		FirmwareVersion string
+		Reason          string
+		Backtrace       string
+		FirstSeen       time.Time
+		LastSeen        time.Time
+		Occurrences     int
+		Devices         int
+	}
+	result := r.db.GetDB().WithContext(ctx).
+		Table("crash_reports AS r").
+		Select("s.signature, s.firmware_version, s.reason, s.backtrace, s.first_seen, MAX(r.reported_at) AS last_seen, COUNT(*) AS occurrences, COUNT(DISTINCT r.mac_address) AS devices").
+		Joins("JOIN crash_signatures AS s ON s.signature = r.signature").
+		Where("r.reported_at >= ?", since).
+		Group("s.signature, s.firmware_version, s.reason, s.backtrace, s.first_seen").
+		Order("occurrences DESC, s.signature").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to aggregate crash signatures: %w", result.Error)
+	}
+
+	signatures := make([]*entities.CrashSignature, 0, len(rows))
+	for _, row := range rows {
+		signatures = append(signatures, &entities.CrashSignature{
+			Signature:       row.Signature,
+			FirmwareVersion: row.FirmwareVersion,
+			Reason:          row.Reason,
+			Backtrace:       r.mapper.SplitBacktrace(row.Backtrace),
+			FirstSeen:       row.FirstSeen,
+			LastSeen:        row.LastSeen,
+			Occurrences:     row.Occurrences,
+			Devices:         row.Devices,
+		})
+	}
+	return signatures, nil
+}
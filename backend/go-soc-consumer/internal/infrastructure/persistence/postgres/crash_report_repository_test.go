@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupCrashReportTestRepository initializes a test repository with a mock database
+func setupCrashReportTestRepository(t *testing.T) (*crashReportRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewCrashReportRepository(postgresDB, loggerFactory).(*crashReportRepository), sqlMock
+}
+
+func TestCrashReportRepository_Record(t *testing.T) {
+	reportedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	report, err := entities.NewCrashReport("AA:BB:CC:DD:EE:FF", "1.4.2", "LoadProhibited", []string{"0x400d1234", "0x400d5678"}, 3600, reportedAt)
+	require.NoError(t, err)
+
+	t.Run("should store a new signature with the report", func(t *testing.T) {
+		repo, mock := setupCrashReportTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "crash_signatures" .* ON CONFLICT DO NOTHING`).
+			WithArgs(report.Signature, "1.4.2", "LoadProhibited", "0x400d1234 0x400d5678", reportedAt, reportedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "crash_reports"`).
+			WithArgs(report.ID, report.Signature, "AA:BB:CC:DD:EE:FF", "1.4.2", int64(3600), reportedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		isNew, err := repo.Record(context.Background(), report)
+		require.NoError(t, err)
+		assert.True(t, isNew)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should move the last seen time of a known signature", func(t *testing.T) {
+		repo, mock := setupCrashReportTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "crash_signatures"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`UPDATE "crash_signatures" SET "last_seen"=GREATEST\(last_seen, \$1\) WHERE signature = \$2`).
+			WithArgs(reportedAt, report.Signature).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "crash_reports"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		isNew, err := repo.Record(context.Background(), report)
+		require.NoError(t, err)
+		assert.False(t, isNew)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCrashReportRepository_FirmwareStats(t *testing.T) {
+	since := time.Date(2025, 5, 25, 0, 0, 0, 0, time.UTC)
+	repo, mock := setupCrashReportTestRepository(t)
+	mock.ExpectQuery(`SELECT firmware_version, COUNT\(\*\) AS crashes, COUNT\(DISTINCT mac_address\) AS devices, COUNT\(DISTINCT signature\) AS signatures, MAX\(reported_at\) AS last_crash_at FROM "crash_reports" WHERE reported_at >= \$1 GROUP BY "firmware_version" ORDER BY crashes DESC, firmware_version`).
+		WithArgs(since).
+		WillReturnRows(sqlmock.NewRows([]string{"firmware_version", "crashes", "devices", "signatures", "last_crash_at"}).
+			AddRow("1.4.2", 12, 5, 2, since.Add(48*time.Hour)).
+			AddRow("1.4.1", 1, 1, 1, since.Add(time.Hour)))
+
+	stats, err := repo.FirmwareStats(context.Background(), since)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, "1.4.2", stats[0].FirmwareVersion)
+	assert.Equal(t, 12, stats[0].Crashes)
+	assert.Equal(t, 5, stats[0].Devices)
+	assert.Equal(t, 2, stats[0].Signatures)
+}
+
+func TestCrashReportRepository_SignatureStats(t *testing.T) {
+	since := time.Date(2025, 5, 25, 0, 0, 0, 0, time.UTC)
+	repo, mock := setupCrashReportTestRepository(t)
+	mock.ExpectQuery(`SELECT s.signature, .* FROM crash_reports AS r JOIN crash_signatures AS s ON s.signature = r.signature WHERE r.reported_at >= \$1 GROUP BY .* ORDER BY occurrences DESC, s.signature`).
+		WithArgs(since).
+		WillReturnRows(sqlmock.NewRows([]string{"signature", "firmware_version", "reason", "backtrace", "first_seen", "last_seen", "occurrences", "devices"}).
+			AddRow("abc", "1.4.2", "LoadProhibited", "0x400d1234 0x400d5678", since.Add(-time.Hour), since.Add(time.Hour), 9, 4))
+
+	signatures, err := repo.SignatureStats(context.Background(), since)
+	require.NoError(t, err)
+	require.Len(t, signatures, 1)
+	assert.Equal(t, []string{"0x400d1234", "0x400d5678"}, signatures[0].Backtrace)
+	assert.Equal(t, 9, signatures[0].Occurrences)
+	assert.Equal(t, 4, signatures[0].Devices)
+}
@@ -0,0 +1,295 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// archiveDeviceMeasurementsSQL moves every measurement of a device out of the measurements table
+// into the archive tables in a single statement, so no reading is lost or kept twice
+const archiveDeviceMeasurementsSQL = `WITH moved AS (
+	DELETE FROM measurements WHERE mac_address = ?
+	RETURNING mac_address, sensor_type, channel, value, unit, quality, created_at
+)
+INSERT INTO device_archive_measurements (archive_id, mac_address, sensor_type, channel, value, unit, quality, created_at)
+SELECT ?, mac_address, sensor_type, channel, value, unit, quality, created_at FROM moved`
+
+// restoreDeviceMeasurementsSQL moves the measurements of an archive back into the measurements table
+const restoreDeviceMeasurementsSQL = `WITH moved AS (
+	DELETE FROM device_archive_measurements WHERE archive_id = ?
+	RETURNING mac_address, sensor_type, channel, value, unit, quality, created_at
+)
+INSERT INTO measurements (mac_address, sensor_type, channel, value, unit, quality, created_at)
+SELECT mac_address, sensor_type, channel, value, unit, quality, created_at FROM moved`
+
+// archiveDeviceReadingsSQL moves the raw temperature and humidity readings of a device into the
+// archive tables. Soft deleted readings are left to compaction, which already holds them.
+const archiveDeviceReadingsSQL = `WITH moved AS (
+	DELETE FROM sensor_temperature_humidity WHERE mac_address = ? AND deleted_at IS NULL
+	RETURNING mac_address, temperature_celsius, humidity_percent, created_at
+)
+INSERT INTO device_archive_temperature_humidity (archive_id, mac_address, temperature_celsius, humidity_percent, created_at)
+SELECT ?, mac_address, temperature_celsius, humidity_percent, created_at FROM moved`
+
+// archiveDeviceTelemetryDaysSQL moves the compacted device-days of a device into the archive tables
+// and returns the number of readings they hold
+const archiveDeviceTelemetryDaysSQL = `WITH moved AS (
+	DELETE FROM sensor_temperature_humidity_archive WHERE mac_address = ?
+	RETURNING mac_address, day, encoding, data, sample_count, first_reading_at, last_reading_at
+), archived AS (
+	INSERT INTO device_archive_telemetry_days (archive_id, mac_address, day, encoding, data, sample_count, first_reading_at, last_reading_at)
+	SELECT ?, mac_address, day, encoding, data, sample_count, first_reading_at, last_reading_at FROM moved
+)
+SELECT COALESCE(SUM(sample_count), 0) FROM moved`
+
+// restoreDeviceReadingsSQL moves the raw readings of an archive back into the readings table
+const restoreDeviceReadingsSQL = `WITH moved AS (
+	DELETE FROM device_archive_temperature_humidity WHERE archive_id = ?
+	RETURNING mac_address, temperature_celsius, humidity_percent, created_at
+)
+INSERT INTO sensor_temperature_humidity (mac_address, temperature_celsius, humidity_percent, created_at)
+SELECT mac_address, temperature_celsius, humidity_percent, created_at FROM moved`
+
+// restoreDeviceTelemetryDaysSQL moves the compacted days of an archive back among the compacted days
+const restoreDeviceTelemetryDaysSQL = `WITH moved AS (
+	DELETE FROM device_archive_telemetry_days WHERE archive_id = ?
+	RETURNING mac_address, day, encoding, data, sample_count, first_reading_at, last_reading_at
+)
+INSERT INTO sensor_temperature_humidity_archive (mac_address, day, encoding, data, sample_count, first_reading_at, last_reading_at)
+SELECT mac_address, day, encoding, data, sample_count, first_reading_at, last_reading_at FROM moved`
+
+// deviceArchiveRepository implements the DeviceArchiveRepository interface using GORM PostgreSQL
+type deviceArchiveRepository struct {
+	db                *database.GormPostgresDB
+	mapper            *mappers.DeviceArchiveMapper
+	measurementMapper *mappers.MeasurementMapper
+	logger            pkglogger.CoreLogger
+}
+
+// NewDeviceArchiveRepository creates a new GORM-based PostgreSQL device archive repository
+func NewDeviceArchiveRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.DeviceArchiveRepository {
+	return &deviceArchiveRepository{
+		db:                db,
+		mapper:            mappers.NewDeviceArchiveMapper(),
+		measurementMapper: mappers.NewMeasurementMapper(),
+		logger:            loggerFactory.Core(),
+	}
+}
+
+// Archive soft deletes the device, moves its measurements and readings and stores the archive in one
+// transaction
+func (r *deviceArchiveRepository) Archive(ctx context.Context, archive *entities.DeviceArchive) error {
+	if archive == nil {
+		return fmt.Errorf("device archive cannot be nil")
+	}
+
+	start := time.Now()
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("mac_address = ?", archive.MACAddress).Delete(&models.DeviceModel{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to decommission device: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domainerrors.ErrDeviceNotFound
+		}
+
+		result = tx.Exec(archiveDeviceMeasurementsSQL, archive.MACAddress, archive.ID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to archive device measurements: %w", result.Error)
+		}
+		archive.Measurements = int(result.RowsAffected)
+
+		result = tx.Exec(archiveDeviceReadingsSQL, archive.MACAddress, archive.ID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to archive device readings: %w", result.Error)
+		}
+		var compacted int
+		if err := tx.Raw(archiveDeviceTelemetryDaysSQL, archive.MACAddress, archive.ID).Scan(&compacted).Error; err != nil {
+			return fmt.Errorf("failed to archive device telemetry days: %w", err)
+		}
+		archive.Readings = int(result.RowsAffected) + compacted
+
+		if err := tx.Create(r.mapper.ToModel(archive)).Error; err != nil {
+			return fmt.Errorf("failed to create device archive: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, domainerrors.ErrDeviceNotFound) {
+			r.logger.Error("device_archive_failed", zap.String("operation", "archive"), zap.String("table", "device_archives"), zap.String("mac_address", archive.MACAddress), zap.Error(err))
+		}
+		return err
+	}
+
+	r.logger.Info("device_archived",
+		zap.String("archive_id", archive.ID),
+		zap.String("mac_address", archive.MACAddress),
+		zap.Int("measurements", archive.Measurements),
+		zap.Int("readings", archive.Readings),
+		zap.Duration("duration", time.Since(start)),
+	)
+	return nil
+}
+
+// FindByID retrieves an archive by its ID
+func (r *deviceArchiveRepository) FindByID(ctx context.Context, id string) (*entities.DeviceArchive, error) {
+	var model models.DeviceArchiveModel
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrDeviceArchiveNotFound
+		}
+		return nil, fmt.Errorf("failed to find device archive: %w", result.Error)
+	}
+	return r.mapper.FromModel(&model), nil
+}
+
+// List returns the matching archives, most recently archived first
+func (r *deviceArchiveRepository) List(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceArchive, error) {
+	query := r.db.GetDB().WithContext(ctx).Model(&models.DeviceArchiveModel{})
+	if macAddress != "" {
+		query = query.Where("mac_address = ?", macAddress)
+	}
+
+	var records []models.DeviceArchiveModel
+	result := query.Order("archived_at DESC").Order("id ASC").Limit(limit).Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list device archives: %w", result.Error)
+	}
+
+	archives := make([]*entities.DeviceArchive, 0, len(records))
+	for i := range records {
+		archives = append(archives, r.mapper.FromModel(&records[i]))
+	}
+	return archives, nil
+}
+
+// Measurements returns the archived measurements of an archive, oldest first
+func (r *deviceArchiveRepository) Measurements(ctx context.Context, archiveID string) ([]*entities.Measurement, error) {
+	var records []models.ArchivedDeviceMeasurementModel
+	result := r.db.GetDB().WithContext(ctx).Where("archive_id = ?", archiveID).Order("created_at ASC").Order("id ASC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to load archived measurements: %w", result.Error)
+	}
+
+	measurements := make([]*entities.Measurement, 0, len(records))
+	for i := range records {
+		measurements = append(measurements, r.mapper.MeasurementFromModel(&records[i]))
+	}
+	return measurements, nil
+}
+
+// Offload records the object of the archive and drops its archived measurements in one transaction
+func (r *deviceArchiveRepository) Offload(ctx context.Context, archive *entities.DeviceArchive) error {
+	if archive == nil {
+		return fmt.Errorf("device archive cannot be nil")
+	}
+
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.DeviceArchiveModel{}).
+			Where("id = ?", archive.ID).
+			Updates(map[string]interface{}{
+				"storage":    archive.Storage,
+				"object_key": archive.ObjectKey,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update device archive: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domainerrors.ErrDeviceArchiveNotFound
+		}
+		if err := tx.Where("archive_id = ?", archive.ID).Delete(&models.ArchivedDeviceMeasurementModel{}).Error; err != nil {
+			return fmt.Errorf("failed to drop archived measurements: %w", err)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, domainerrors.ErrDeviceArchiveNotFound) {
+		r.logger.Error("device_archive_offload_failed", zap.String("operation", "offload"), zap.String("table", "device_archives"), zap.String("id", archive.ID), zap.Error(err))
+	}
+	return err
+}
+
+// Restore undeletes the device, moves its measurements and readings back and stores the archive
+// status in one transaction. Readings are never offloaded, so they always move back from the archive
+// tables.
+func (r *deviceArchiveRepository) Restore(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement) error {
+	if archive == nil {
+		return fmt.Errorf("device archive cannot be nil")
+	}
+
+	start := time.Now()
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&models.DeviceModel{}).
+			Where("mac_address = ? AND deleted_at IS NOT NULL", archive.MACAddress).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore device: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domainerrors.ErrDeviceNotFound
+		}
+
+		if archive.Storage == entities.ArchiveStorageDatabase {
+			if err := tx.Exec(restoreDeviceMeasurementsSQL, archive.ID).Error; err != nil {
+				return fmt.Errorf("failed to restore device measurements: %w", err)
+			}
+		} else if len(measurements) > 0 {
+			records := make([]*models.MeasurementModel, 0, len(measurements))
+			for _, measurement := range measurements {
+				records = append(records, r.measurementMapper.ToModel(measurement))
+			}
+			if err := tx.CreateInBatches(&records, 1000).Error; err != nil {
+				return fmt.Errorf("failed to restore device measurements: %w", err)
+			}
+		}
+
+		if err := tx.Exec(restoreDeviceReadingsSQL, archive.ID).Error; err != nil {
+			return fmt.Errorf("failed to restore device readings: %w", err)
+		}
+		if err := tx.Exec(restoreDeviceTelemetryDaysSQL, archive.ID).Error; err != nil {
+			return fmt.Errorf("failed to restore device telemetry days: %w", err)
+		}
+
+		result = tx.Model(&models.DeviceArchiveModel{}).
+			Where("id = ?", archive.ID).
+			Updates(map[string]interface{}{
+				"status":      archive.Status,
+				"restored_at": archive.RestoredAt,
+				"restored_by": archive.RestoredBy,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update device archive: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domainerrors.ErrDeviceArchiveNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, domainerrors.ErrDeviceNotFound) && !errors.Is(err, domainerrors.ErrDeviceArchiveNotFound) {
+			r.logger.Error("device_archive_restore_failed", zap.String("operation", "restore"), zap.String("table", "device_archives"), zap.String("id", archive.ID), zap.Error(err))
+		}
+		return err
+	}
+
+	r.logger.Info("device_archive_restored",
+		zap.String("archive_id", archive.ID),
+		zap.String("mac_address", archive.MACAddress),
+		zap.String("storage", archive.Storage),
+		zap.Duration("duration", time.Since(start)),
+	)
+	return nil
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupDeviceArchiveTestRepository initializes a test repository with a mock database
+func setupDeviceArchiveTestRepository(t *testing.T) (*deviceArchiveRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewDeviceArchiveRepository(postgresDB, loggerFactory).(*deviceArchiveRepository), sqlMock
+}
+
+func TestDeviceArchiveRepository_Archive(t *testing.T) {
+	archivedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	newArchive := func() *entities.DeviceArchive {
+		return &entities.DeviceArchive{
+			ID: "archive-1", MACAddress: "AA:BB:CC:DD:EE:FF", Actor: "maria",
+			Status: entities.DeviceArchiveArchived, Storage: entities.ArchiveStorageDatabase, ArchivedAt: archivedAt,
+		}
+	}
+
+	t.Run("should decommission the device and move its measurements and readings", func(t *testing.T) {
+		repo, mock := setupDeviceArchiveTestRepository(t)
+		archive := newArchive()
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices" SET "deleted_at"=\$1 WHERE mac_address = \$2 AND "devices"."deleted_at" IS NULL`).
+			WithArgs(sqlmock.AnyArg(), "AA:BB:CC:DD:EE:FF").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`WITH moved AS \( DELETE FROM measurements WHERE mac_address = \$1 RETURNING .* \) INSERT INTO device_archive_measurements .* SELECT \$2, .* FROM moved`).
+			WithArgs("AA:BB:CC:DD:EE:FF", "archive-1").
+			WillReturnResult(sqlmock.NewResult(0, 2880))
+		mock.ExpectExec(`WITH moved AS \( DELETE FROM sensor_temperature_humidity WHERE mac_address = \$1 AND deleted_at IS NULL RETURNING .* \) INSERT INTO device_archive_temperature_humidity .* SELECT \$2, .* FROM moved`).
+			WithArgs("AA:BB:CC:DD:EE:FF", "archive-1").
+			WillReturnResult(sqlmock.NewResult(0, 96))
+		mock.ExpectQuery(`WITH moved AS \( DELETE FROM sensor_temperature_humidity_archive WHERE mac_address = \$1 RETURNING .* \), archived AS \( INSERT INTO device_archive_telemetry_days .* SELECT \$2, .* FROM moved \) SELECT COALESCE\(SUM\(sample_count\), 0\) FROM moved`).
+			WithArgs("AA:BB:CC:DD:EE:FF", "archive-1").
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(1440))
+		mock.ExpectExec(`INSERT INTO "device_archives"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Archive(context.Background(), archive))
+		assert.Equal(t, 2880, archive.Measurements)
+		assert.Equal(t, 1536, archive.Readings)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report unregistered devices without moving anything", func(t *testing.T) {
+		repo, mock := setupDeviceArchiveTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices" SET "deleted_at"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.Archive(context.Background(), newArchive())
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeviceArchiveRepository_Restore(t *testing.T) {
+	restoredAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	newArchive := func(storage string) *entities.DeviceArchive {
+		return &entities.DeviceArchive{
+			ID: "archive-1", MACAddress: "AA:BB:CC:DD:EE:FF", Status: entities.DeviceArchiveRestored,
+			Storage: storage, RestoredAt: &restoredAt, RestoredBy: "pedro",
+		}
+	}
+
+	t.Run("should move the archived measurements and readings back", func(t *testing.T) {
+		repo, mock := setupDeviceArchiveTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE mac_address = \$3 AND deleted_at IS NOT NULL`).
+			WithArgs(nil, sqlmock.AnyArg(), "AA:BB:CC:DD:EE:FF").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`WITH moved AS \( DELETE FROM device_archive_measurements WHERE archive_id = \$1 RETURNING .* \) INSERT INTO measurements`).
+			WithArgs("archive-1").
+			WillReturnResult(sqlmock.NewResult(0, 2880))
+		mock.ExpectExec(`WITH moved AS \( DELETE FROM device_archive_temperature_humidity WHERE archive_id = \$1 RETURNING .* \) INSERT INTO sensor_temperature_humidity`).
+			WithArgs("archive-1").
+			WillReturnResult(sqlmock.NewResult(0, 96))
+		mock.ExpectExec(`WITH moved AS \( DELETE FROM device_archive_telemetry_days WHERE archive_id = \$1 RETURNING .* \) INSERT INTO sensor_temperature_humidity_archive`).
+			WithArgs("archive-1").
+			WillReturnResult(sqlmock.NewResult(0, 30))
+		mock.ExpectExec(`UPDATE "device_archives" SET "restored_at"=\$1,"restored_by"=\$2,"status"=\$3 WHERE id = \$4`).
+			WithArgs(&restoredAt, "pedro", "restored", "archive-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Restore(context.Background(), newArchive(entities.ArchiveStorageDatabase), nil))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should insert the measurements loaded from object storage and move the readings back", func(t *testing.T) {
+		repo, mock := setupDeviceArchiveTestRepository(t)
+		at := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices" SET "deleted_at"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "measurements" \("mac_address","sensor_type","channel","value","unit","quality","created_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7\) RETURNING "created_at","id"`).
+			WithArgs("AA:BB:CC:DD:EE:FF", "soil_moisture", 0, 31.5, "%", "good", at).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "id"}).AddRow(at, 1))
+		mock.ExpectExec(`WITH moved AS \( DELETE FROM device_archive_temperature_humidity WHERE archive_id = \$1 RETURNING .* \) INSERT INTO sensor_temperature_humidity`).
+			WithArgs("archive-1").
+			WillReturnResult(sqlmock.NewResult(0, 96))
+		mock.ExpectExec(`WITH moved AS \( DELETE FROM device_archive_telemetry_days WHERE archive_id = \$1 RETURNING .* \) INSERT INTO sensor_temperature_humidity_archive`).
+			WithArgs("archive-1").
+			WillReturnResult(sqlmock.NewResult(0, 30))
+		mock.ExpectExec(`UPDATE "device_archives"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Restore(context.Background(), newArchive(entities.ArchiveStorageObject), []*entities.Measurement{
+			{MACAddress: "AA:BB:CC:DD:EE:FF", SensorType: "soil_moisture", Value: 31.5, Unit: "%", Quality: "good", Timestamp: at},
+		})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report devices that are no longer decommissioned", func(t *testing.T) {
+		repo, mock := setupDeviceArchiveTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices" SET "deleted_at"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.Restore(context.Background(), newArchive(entities.ArchiveStorageDatabase), nil)
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
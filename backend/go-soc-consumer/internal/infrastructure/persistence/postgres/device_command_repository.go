@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// deviceCommandRepository implements the DeviceCommandRepository interface using GORM PostgreSQL
+type deviceCommandRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.DeviceCommandMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewDeviceCommandRepository creates a new GORM-based PostgreSQL device command repository
+func NewDeviceCommandRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.DeviceCommandRepository {
+	return &deviceCommandRepository{
+		db:     db,
+		mapper: mappers.NewDeviceCommandMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new device command
+func (r *deviceCommandRepository) Create(ctx context.Context, command *entities.DeviceCommand) error {
+	if command == nil {
+		return fmt.Errorf("device command cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Create(r.mapper.ToModel(command))
+	if result.Error != nil {
+		r.logger.Error("device_command_create_failed", zap.String("operation", "create"), zap.String("table", "device_commands"), zap.String("mac_address", command.MACAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to create device command: %w", result.Error)
+	}
+	return nil
+}
+
+// FindByID returns a device command by its ID
+func (r *deviceCommandRepository) FindByID(ctx context.Context, id string) (*entities.DeviceCommand, error) {
+	var record models.DeviceCommandModel
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).First(&record)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrDeviceCommandNotFound
+		}
+		return nil, fmt.Errorf("failed to find device command: %w", result.Error)
+	}
+	return r.mapper.FromModel(&record), nil
+}
+
+// ListByDevice returns the latest commands of the device, newest first
+func (r *deviceCommandRepository) ListByDevice(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceCommand, error) {
+	var records []models.DeviceCommandModel
+	result := r.db.GetDB().WithContext(ctx).Where("mac_address = ?", macAddress).Order("issued_at DESC").Limit(limit).Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list device commands: %w", result.Error)
+	}
+
+	commands := make([]*entities.DeviceCommand, 0, len(records))
+	for i := range records {
+		commands = append(commands, r.mapper.FromModel(&records[i]))
+	}
+	return commands, nil
+}
+
+// Complete records the outcome of a command that is still pending
+func (r *deviceCommandRepository) Complete(ctx context.Context, id, status, message string, ackedAt time.Time) (bool, error) {
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.DeviceCommandModel{}).
+		Where("id = ? AND status = ?", id, entities.DeviceCommandStatusSent).
+		Updates(map[string]interface{}{"status": status, "message": message, "acked_at": ackedAt})
+	if result.Error != nil {
+		r.logger.Error("device_command_complete_failed", zap.String("table", "device_commands"), zap.String("id", id), zap.Error(result.Error))
+		return false, fmt.Errorf("failed to complete device command: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RecordAttempt records another publication of a command that is still pending
+func (r *deviceCommandRepository) RecordAttempt(ctx context.Context, id string, attempts int, expiresAt time.Time) (bool, error) {
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.DeviceCommandModel{}).
+		Where("id = ? AND status = ?", id, entities.DeviceCommandStatusSent).
+		Updates(map[string]interface{}{"attempts": attempts, "expires_at": expiresAt})
+	if result.Error != nil {
+		r.logger.Error("device_command_record_attempt_failed", zap.String("table", "device_commands"), zap.String("id", id), zap.Error(result.Error))
+		return false, fmt.Errorf("failed to record device command attempt: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ExpirePending flags the pending commands past their expiry as expired
+func (r *deviceCommandRepository) ExpirePending(ctx context.Context, now time.Time) (int, error) {
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.DeviceCommandModel{}).
+		Where("status = ? AND expires_at < ?", entities.DeviceCommandStatusSent, now).
+		Update("status", entities.DeviceCommandStatusExpired)
+	if result.Error != nil {
+		r.logger.Error("device_commands_expire_failed", zap.String("table", "device_commands"), zap.Error(result.Error))
+		return 0, fmt.Errorf("failed to expire device commands: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
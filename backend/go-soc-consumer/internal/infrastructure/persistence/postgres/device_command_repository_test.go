@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+var deviceCommandColumns = []string{"id", "mac_address", "command", "payload", "status", "message", "issued_at", "expires_at", "acked_at", "attempts"}
+
+// setupDeviceCommandTestRepository initializes a test repository with a mock database
+func setupDeviceCommandTestRepository(t *testing.T) (*deviceCommandRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewDeviceCommandRepository(postgresDB, loggerFactory).(*deviceCommandRepository), sqlMock
+}
+
+func TestDeviceCommandRepository_Create(t *testing.T) {
+	issuedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo, mock := setupDeviceCommandTestRepository(t)
+	mock.ExpectExec(`INSERT INTO "device_commands"`).
+		WithArgs("command-1", "AA:BB:CC:DD:EE:FF", entities.DeviceCommandReboot, `{"delay_seconds":5}`, entities.DeviceCommandStatusSent, "", issuedAt, issuedAt.Add(2*time.Minute), nil, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Create(context.Background(), &entities.DeviceCommand{
+		ID:         "command-1",
+		MACAddress: "AA:BB:CC:DD:EE:FF",
+		Command:    entities.DeviceCommandReboot,
+		Payload:    json.RawMessage(`{"delay_seconds":5}`),
+		Status:     entities.DeviceCommandStatusSent,
+		IssuedAt:   issuedAt,
+		ExpiresAt:  issuedAt.Add(2 * time.Minute),
+		Attempts:   1,
+	}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceCommandRepository_FindByID(t *testing.T) {
+	issuedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should return the command", func(t *testing.T) {
+		repo, mock := setupDeviceCommandTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "device_commands" WHERE id = \$1`).
+			WithArgs("command-1", 1).
+			WillReturnRows(sqlmock.NewRows(deviceCommandColumns).
+				AddRow("command-1", "AA:BB:CC:DD:EE:FF", entities.DeviceCommandIdentify, `{"duration_seconds":30}`, entities.DeviceCommandStatusAcknowledged, "", issuedAt, issuedAt.Add(2*time.Minute), issuedAt.Add(time.Second), 2))
+
+		command, err := repo.FindByID(context.Background(), "command-1")
+		require.NoError(t, err)
+		assert.Equal(t, entities.DeviceCommandIdentify, command.Command)
+		assert.JSONEq(t, `{"duration_seconds":30}`, string(command.Payload))
+		require.NotNil(t, command.AckedAt)
+		assert.False(t, command.Pending())
+		assert.Equal(t, 2, command.Attempts)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		repo, mock := setupDeviceCommandTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "device_commands"`).WillReturnRows(sqlmock.NewRows(deviceCommandColumns))
+
+		_, err := repo.FindByID(context.Background(), "command-1")
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceCommandNotFound)
+	})
+}
+
+func TestDeviceCommandRepository_ListByDevice(t *testing.T) {
+	issuedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo, mock := setupDeviceCommandTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "device_commands" WHERE mac_address = \$1 ORDER BY issued_at DESC LIMIT \$2`).
+		WithArgs("AA:BB:CC:DD:EE:FF", 10).
+		WillReturnRows(sqlmock.NewRows(deviceCommandColumns).
+			AddRow("command-2", "AA:BB:CC:DD:EE:FF", entities.DeviceCommandReboot, `{"delay_seconds":0}`, entities.DeviceCommandStatusSent, "", issuedAt, issuedAt.Add(2*time.Minute), nil, 1).
+			AddRow("command-1", "AA:BB:CC:DD:EE:FF", entities.DeviceCommandIdentify, `{"duration_seconds":30}`, entities.DeviceCommandStatusExpired, "", issuedAt.Add(-time.Hour), issuedAt.Add(-58*time.Minute), nil, 3))
+
+	commands, err := repo.ListByDevice(context.Background(), "AA:BB:CC:DD:EE:FF", 10)
+	require.NoError(t, err)
+	require.Len(t, commands, 2)
+	assert.True(t, commands[0].Pending())
+	assert.Equal(t, entities.DeviceCommandStatusExpired, commands[1].Status)
+}
+
+func TestDeviceCommandRepository_Complete(t *testing.T) {
+	ackedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should complete a pending command", func(t *testing.T) {
+		repo, mock := setupDeviceCommandTestRepository(t)
+		mock.ExpectExec(`UPDATE "device_commands" SET "acked_at"=\$1,"message"=\$2,"status"=\$3 WHERE id = \$4 AND status = \$5`).
+			WithArgs(ackedAt, "", entities.DeviceCommandStatusAcknowledged, "command-1", entities.DeviceCommandStatusSent).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		completed, err := repo.Complete(context.Background(), "command-1", entities.DeviceCommandStatusAcknowledged, "", ackedAt)
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should leave a command that is no longer pending", func(t *testing.T) {
+		repo, mock := setupDeviceCommandTestRepository(t)
+		mock.ExpectExec(`UPDATE "device_commands"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		completed, err := repo.Complete(context.Background(), "command-1", entities.DeviceCommandStatusFailed, "busy", ackedAt)
+		require.NoError(t, err)
+		assert.False(t, completed)
+	})
+}
+
+func TestDeviceCommandRepository_RecordAttempt(t *testing.T) {
+	expiresAt := time.Date(2025, 6, 1, 12, 14, 0, 0, time.UTC)
+	repo, mock := setupDeviceCommandTestRepository(t)
+	mock.ExpectExec(`UPDATE "device_commands" SET "attempts"=\$1,"expires_at"=\$2 WHERE id = \$3 AND status = \$4`).
+		WithArgs(2, expiresAt, "command-1", entities.DeviceCommandStatusSent).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	pending, err := repo.RecordAttempt(context.Background(), "command-1", 2, expiresAt)
+	require.NoError(t, err)
+	assert.True(t, pending)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceCommandRepository_ExpirePending(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo, mock := setupDeviceCommandTestRepository(t)
+	mock.ExpectExec(`UPDATE "device_commands" SET "status"=\$1 WHERE status = \$2 AND expires_at < \$3`).
+		WithArgs(entities.DeviceCommandStatusExpired, entities.DeviceCommandStatusSent, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	expired, err := repo.ExpirePending(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, expired)
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// deviceMigrationRepository implements the DeviceMigrationRepository interface using GORM PostgreSQL
+type deviceMigrationRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.DeviceMigrationMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewDeviceMigrationRepository creates a new GORM-based PostgreSQL device migration repository
+func NewDeviceMigrationRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.DeviceMigrationRepository {
+	return &deviceMigrationRepository{
+		db:     db,
+		mapper: mappers.NewDeviceMigrationMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create inserts the migration and its targets in one transaction
+func (r *deviceMigrationRepository) Create(ctx context.Context, migration *entities.DeviceMigration, targets []*entities.DeviceMigrationTarget) error {
+	if migration == nil {
+		return fmt.Errorf("device migration cannot be nil")
+	}
+
+	records := make([]*models.DeviceMigrationTargetModel, 0, len(targets))
+	for _, target := range targets {
+		records = append(records, r.mapper.TargetToModel(target))
+	}
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(r.mapper.ToModel(migration)).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(records, 1000).Error
+	})
+	if err != nil {
+		r.logger.Error("device_migration_create_failed", zap.String("operation", "create"), zap.String("table", "device_migrations"), zap.String("id", migration.ID), zap.Error(err))
+		return fmt.Errorf("failed to create device migration: %w", err)
+	}
+	return nil
+}
+
+// FindByID loads a migration
+func (r *deviceMigrationRepository) FindByID(ctx context.Context, id string) (*entities.DeviceMigration, error) {
+	return r.find(ctx, "id = ?", id)
+}
+
+// FindRunning loads the running migration
+func (r *deviceMigrationRepository) FindRunning(ctx context.Context) (*entities.DeviceMigration, error) {
+	return r.find(ctx, "status = ?", entities.DeviceMigrationRunning)
+}
+
+func (r *deviceMigrationRepository) find(ctx context.Context, query string, arg interface{}) (*entities.DeviceMigration, error) {
+	var model models.DeviceMigrationModel
+	result := r.db.GetDB().WithContext(ctx).Where(query, arg).Order("started_at DESC").First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrDeviceMigrationNotFound
+		}
+		return nil, fmt.Errorf("failed to find device migration: %w", result.Error)
+	}
+	return r.mapper.FromModel(&model), nil
+}
+
+// List loads the latest migrations
+func (r *deviceMigrationRepository) List(ctx context.Context, limit int) ([]*entities.DeviceMigration, error) {
+	var records []models.DeviceMigrationModel
+	result := r.db.GetDB().WithContext(ctx).Order("started_at DESC").Order("id ASC").Limit(limit).Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list device migrations: %w", result.Error)
+	}
+
+	migrations := make([]*entities.DeviceMigration, 0, len(records))
+	for i := range records {
+		migrations = append(migrations, r.mapper.FromModel(&records[i]))
+	}
+	return migrations, nil
+}
+
+// Update stores the mutable fields of a migration
+func (r *deviceMigrationRepository) Update(ctx context.Context, migration *entities.DeviceMigration) error {
+	if migration == nil {
+		return fmt.Errorf("device migration cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Model(&models.DeviceMigrationModel{}).
+		Where("id = ?", migration.ID).
+		Updates(map[string]interface{}{
+			"status":      migration.Status,
+			"job_id":      migration.JobID,
+			"finished_at": migration.FinishedAt,
+		})
+	if result.Error != nil {
+		r.logger.Error("device_migration_update_failed", zap.String("operation", "update"), zap.String("table", "device_migrations"), zap.String("id", migration.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to update device migration: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrDeviceMigrationNotFound
+	}
+	return nil
+}
+
+// Targets loads the devices of a migration
+func (r *deviceMigrationRepository) Targets(ctx context.Context, migrationID, status string) ([]*entities.DeviceMigrationTarget, error) {
+	query := r.db.GetDB().WithContext(ctx).Where("migration_id = ?", migrationID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var records []models.DeviceMigrationTargetModel
+	if err := query.Order("mac_address").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to list device migration targets: %w", err)
+	}
+
+	targets := make([]*entities.DeviceMigrationTarget, 0, len(records))
+	for i := range records {
+		targets = append(targets, r.mapper.TargetFromModel(&records[i]))
+	}
+	return targets, nil
+}
+
+// UpdateTarget stores the outcome of publishing the configuration to a device
+func (r *deviceMigrationRepository) UpdateTarget(ctx context.Context, target *entities.DeviceMigrationTarget) error {
+	result := r.db.GetDB().WithContext(ctx).Model(&models.DeviceMigrationTargetModel{}).
+		Where("migration_id = ? AND mac_address = ?", target.MigrationID, target.MACAddress).
+		Updates(map[string]interface{}{
+			"status":     target.Status,
+			"command_id": target.CommandID,
+			"error":      target.Error,
+			"sent_at":    target.SentAt,
+		})
+	if result.Error != nil {
+		r.logger.Error("device_migration_target_update_failed", zap.String("operation", "update_target"), zap.String("table", "device_migration_targets"), zap.String("mac_address", target.MACAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to update device migration target: %w", result.Error)
+	}
+	return nil
+}
+
+// MarkReconnected moves a sent target to reconnected, leaving targets in any other status alone
+func (r *deviceMigrationRepository) MarkReconnected(ctx context.Context, migrationID, macAddress string, at time.Time) (bool, error) {
+	result := r.db.GetDB().WithContext(ctx).Model(&models.DeviceMigrationTargetModel{}).
+		Where("migration_id = ? AND mac_address = ? AND status = ?", migrationID, macAddress, entities.MigrationTargetSent).
+		Updates(map[string]interface{}{
+			"status":         entities.MigrationTargetReconnected,
+			"reconnected_at": at.UTC(),
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to mark device reconnected: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
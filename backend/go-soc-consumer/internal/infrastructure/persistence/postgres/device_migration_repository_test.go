@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupDeviceMigrationTestRepository initializes a test repository with a mock database
+func setupDeviceMigrationTestRepository(t *testing.T) (*deviceMigrationRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewDeviceMigrationRepository(postgresDB, loggerFactory).(*deviceMigrationRepository), sqlMock
+}
+
+func TestDeviceMigrationRepository_Create(t *testing.T) {
+	repo, mock := setupDeviceMigrationTestRepository(t)
+	migration := &entities.DeviceMigration{
+		ID: "migration-1", BrokerURL: "tcp://broker:1883", Layout: entities.TopicLayoutFarm, Actor: "maria",
+		Status: entities.DeviceMigrationRunning, Devices: 2, StartedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	targets := []*entities.DeviceMigrationTarget{
+		{MigrationID: "migration-1", MACAddress: "AA:BB:CC:DD:EE:01", FarmID: "farm-a", Status: entities.MigrationTargetPending},
+		{MigrationID: "migration-1", MACAddress: "AA:BB:CC:DD:EE:02", Status: entities.MigrationTargetSkipped},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "device_migrations"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO "device_migration_targets"`).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Create(context.Background(), migration, targets))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceMigrationRepository_FindRunning(t *testing.T) {
+	t.Run("should load the running migration", func(t *testing.T) {
+		repo, mock := setupDeviceMigrationTestRepository(t)
+		rows := sqlmock.NewRows([]string{"id", "broker_url", "layout", "farm_id", "actor", "status", "job_id", "devices", "started_at", "finished_at"}).
+			AddRow("migration-1", "tcp://broker:1883", "farm", "", "maria", "running", "job-1", 2, time.Now(), nil)
+		mock.ExpectQuery(`SELECT \* FROM "device_migrations" WHERE status = \$1 ORDER BY started_at DESC,"device_migrations"."id" LIMIT \$2`).
+			WithArgs(entities.DeviceMigrationRunning, 1).
+			WillReturnRows(rows)
+
+		migration, err := repo.FindRunning(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "migration-1", migration.ID)
+		assert.Equal(t, "job-1", migration.JobID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report no running migration", func(t *testing.T) {
+		repo, mock := setupDeviceMigrationTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "device_migrations"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.FindRunning(context.Background())
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceMigrationNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeviceMigrationRepository_Targets(t *testing.T) {
+	repo, mock := setupDeviceMigrationTestRepository(t)
+	rows := sqlmock.NewRows([]string{"migration_id", "mac_address", "farm_id", "status", "command_id", "error", "sent_at", "reconnected_at"}).
+		AddRow("migration-1", "AA:BB:CC:DD:EE:01", "farm-a", "sent", "cmd-1", "", time.Now(), nil)
+	mock.ExpectQuery(`SELECT \* FROM "device_migration_targets" WHERE migration_id = \$1 AND status = \$2 ORDER BY mac_address`).
+		WithArgs("migration-1", entities.MigrationTargetSent).
+		WillReturnRows(rows)
+
+	targets, err := repo.Targets(context.Background(), "migration-1", entities.MigrationTargetSent)
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, "cmd-1", targets[0].CommandID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceMigrationRepository_MarkReconnected(t *testing.T) {
+	at := time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC)
+
+	t.Run("should mark a sent target reconnected", func(t *testing.T) {
+		repo, mock := setupDeviceMigrationTestRepository(t)
+		mock.ExpectExec(`UPDATE "device_migration_targets" SET "reconnected_at"=\$1,"status"=\$2 WHERE migration_id = \$3 AND mac_address = \$4 AND status = \$5`).
+			WithArgs(at, entities.MigrationTargetReconnected, "migration-1", "AA:BB:CC:DD:EE:01", entities.MigrationTargetSent).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		marked, err := repo.MarkReconnected(context.Background(), "migration-1", "AA:BB:CC:DD:EE:01", at)
+		require.NoError(t, err)
+		assert.True(t, marked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should leave targets that are not waiting alone", func(t *testing.T) {
+		repo, mock := setupDeviceMigrationTestRepository(t)
+		mock.ExpectExec(`UPDATE "device_migration_targets"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		marked, err := repo.MarkReconnected(context.Background(), "migration-1", "AA:BB:CC:DD:EE:01", at)
+		require.NoError(t, err)
+		assert.False(t, marked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
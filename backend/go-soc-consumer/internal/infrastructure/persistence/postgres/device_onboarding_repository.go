@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// deviceOnboardingRepository implements the DeviceOnboardingRepository interface using GORM PostgreSQL
+type deviceOnboardingRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.DeviceOnboardingMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewDeviceOnboardingRepository creates a new GORM-based PostgreSQL device onboarding repository
+func NewDeviceOnboardingRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.DeviceOnboardingRepository {
+	return &deviceOnboardingRepository{
+		db:     db,
+		mapper: mappers.NewDeviceOnboardingMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new device onboarding
+func (r *deviceOnboardingRepository) Create(ctx context.Context, onboarding *entities.DeviceOnboarding) error {
+	if onboarding == nil {
+		return fmt.Errorf("device onboarding cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Create(r.mapper.ToModel(onboarding))
+	if result.Error != nil {
+		r.logger.Error("device_onboarding_create_failed", zap.String("operation", "create"), zap.String("table", "device_onboardings"), zap.String("mac_address", onboarding.MACAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to create device onboarding: %w", result.Error)
+	}
+	return nil
+}
+
+// Update stores the token and the steps of a device onboarding
+func (r *deviceOnboardingRepository) Update(ctx context.Context, onboarding *entities.DeviceOnboarding) error {
+	if onboarding == nil {
+		return fmt.Errorf("device onboarding cannot be nil")
+	}
+
+	model := r.mapper.ToModel(onboarding)
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.DeviceOnboardingModel{}).
+		Where("id = ?", onboarding.ID).
+		Updates(map[string]interface{}{
+			"location_description":     model.LocationDescription,
+			"token_hash":               model.TokenHash,
+			"token_expires_at":         model.TokenExpiresAt,
+			"credentials_delivered_at": model.CredentialsDeliveredAt,
+			"first_connected_at":       model.FirstConnectedAt,
+			"first_telemetry_at":       model.FirstTelemetryAt,
+			"assigned_at":              model.AssignedAt,
+			"updated_at":               model.UpdatedAt,
+		})
+	if result.Error != nil {
+		r.logger.Error("device_onboarding_update_failed", zap.String("operation", "update"), zap.String("table", "device_onboardings"), zap.String("id", onboarding.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to update device onboarding: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrOnboardingNotFound
+	}
+	return nil
+}
+
+// Delete removes a device onboarding by its ID
+func (r *deviceOnboardingRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).Delete(&models.DeviceOnboardingModel{})
+	if result.Error != nil {
+		r.logger.Error("device_onboarding_delete_failed", zap.String("operation", "delete"), zap.String("table", "device_onboardings"), zap.String("id", id), zap.Error(result.Error))
+		return fmt.Errorf("failed to delete device onboarding: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrOnboardingNotFound
+	}
+	return nil
+}
+
+// FindByID retrieves a device onboarding by its ID
+func (r *deviceOnboardingRepository) FindByID(ctx context.Context, id string) (*entities.DeviceOnboarding, error) {
+	var model models.DeviceOnboardingModel
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrOnboardingNotFound
+		}
+		return nil, fmt.Errorf("failed to find device onboarding: %w", result.Error)
+	}
+	return r.mapper.FromModel(&model), nil
+}
+
+// ListPending returns the onboardings not assigned yet, oldest first
+func (r *deviceOnboardingRepository) ListPending(ctx context.Context) ([]*entities.DeviceOnboarding, error) {
+	var records []models.DeviceOnboardingModel
+	result := r.db.GetDB().WithContext(ctx).Where("assigned_at IS NULL").Order("created_at ASC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list device onboardings: %w", result.Error)
+	}
+
+	onboardings := make([]*entities.DeviceOnboarding, 0, len(records))
+	for i := range records {
+		onboardings = append(onboardings, r.mapper.FromModel(&records[i]))
+	}
+	return onboardings, nil
+}
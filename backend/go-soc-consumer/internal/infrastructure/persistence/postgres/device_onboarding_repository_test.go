@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupDeviceOnboardingTestRepository initializes a test repository with a mock database
+func setupDeviceOnboardingTestRepository(t *testing.T) (*deviceOnboardingRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewDeviceOnboardingRepository(postgresDB, loggerFactory).(*deviceOnboardingRepository), sqlMock
+}
+
+func TestDeviceOnboardingRepository_Create(t *testing.T) {
+	repo, mock := setupDeviceOnboardingTestRepository(t)
+	onboarding, _, err := entities.NewDeviceOnboarding(entities.OnboardingRequest{MACAddress: "AA:BB:CC:DD:EE:FF", DeviceName: "Greenhouse 1"}, time.Hour, time.Now())
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`INSERT INTO "device_onboardings" .* RETURNING`).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(onboarding.CreatedAt, onboarding.UpdatedAt))
+
+	require.NoError(t, repo.Create(context.Background(), onboarding))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceOnboardingRepository_Update(t *testing.T) {
+	connectedAt := time.Date(2025, 6, 1, 12, 10, 0, 0, time.UTC)
+	onboarding := &entities.DeviceOnboarding{ID: "onboarding-1", TokenHash: "hash", FirstConnectedAt: &connectedAt, UpdatedAt: connectedAt}
+
+	t.Run("should store the token and the steps", func(t *testing.T) {
+		repo, mock := setupDeviceOnboardingTestRepository(t)
+		mock.ExpectExec(`UPDATE "device_onboardings" SET "assigned_at"=\$1,"credentials_delivered_at"=\$2,"first_connected_at"=\$3,"first_telemetry_at"=\$4,"location_description"=\$5,"token_expires_at"=\$6,"token_hash"=\$7,"updated_at"=\$8 WHERE id = \$9`).
+			WithArgs(nil, nil, &connectedAt, nil, "", time.Time{}, "hash", connectedAt, "onboarding-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.Update(context.Background(), onboarding))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report unknown onboardings", func(t *testing.T) {
+		repo, mock := setupDeviceOnboardingTestRepository(t)
+		mock.ExpectExec(`UPDATE "device_onboardings"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, repo.Update(context.Background(), onboarding), domainerrors.ErrOnboardingNotFound)
+	})
+}
+
+func TestDeviceOnboardingRepository_FindAndList(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "mac_address", "device_name", "location_description", "farm_id", "token_hash", "token_expires_at", "first_connected_at", "created_at", "updated_at"}).
+			AddRow("onboarding-1", "AA:BB:CC:DD:EE:FF", "Greenhouse 1", "North bed", "farm-1", "hash", createdAt.Add(24*time.Hour), createdAt.Add(time.Hour), createdAt, createdAt)
+	}
+
+	repo, mock := setupDeviceOnboardingTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "device_onboardings" WHERE id = \$1`).WithArgs("onboarding-1", 1).WillReturnRows(rows())
+	mock.ExpectQuery(`SELECT \* FROM "device_onboardings" WHERE id = \$1`).WithArgs("missing", 1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT \* FROM "device_onboardings" WHERE assigned_at IS NULL ORDER BY created_at ASC`).WillReturnRows(rows())
+
+	onboarding, err := repo.FindByID(context.Background(), "onboarding-1")
+	require.NoError(t, err)
+	assert.Equal(t, "farm-1", onboarding.FarmID)
+	assert.Equal(t, entities.OnboardingStepCredentialsDelivered, onboarding.CurrentStep())
+	require.NotNil(t, onboarding.FirstConnectedAt)
+
+	_, err = repo.FindByID(context.Background(), "missing")
+	assert.ErrorIs(t, err, domainerrors.ErrOnboardingNotFound)
+
+	pending, err := repo.ListPending(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceOnboardingRepository_Delete(t *testing.T) {
+	repo, mock := setupDeviceOnboardingTestRepository(t)
+	mock.ExpectExec(`DELETE FROM "device_onboardings" WHERE id = \$1`).WithArgs("onboarding-1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.Delete(context.Background(), "onboarding-1"), domainerrors.ErrOnboardingNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
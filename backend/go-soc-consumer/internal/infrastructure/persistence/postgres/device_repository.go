@@ -16,21 +16,24 @@ import (
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
 	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
 )
 
 // DeviceRepository implements the DeviceRepository interface using GORM PostgreSQL
 type deviceRepository struct {
-	db     *database.GormPostgresDB
-	mapper *mappers.DeviceMapper
-	logger pkglogger.CoreLogger
+	db         *database.GormPostgresDB
+	mapper     *mappers.DeviceMapper
+	pagination pagination.Policy
+	logger     pkglogger.CoreLogger
 }
 
 // NewDeviceRepository creates a new GORM-based PostgreSQL device repository
-func NewDeviceRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.DeviceRepository {
+func NewDeviceRepository(db *database.GormPostgresDB, policy pagination.Policy, loggerFactory pkglogger.LoggerFactory) ports.DeviceRepository {
 	return &deviceRepository{
-		db:     db,
-		mapper: mappers.NewDeviceMapper(),
-		logger: loggerFactory.Core(),
+		db:         db,
+		mapper:     mappers.NewDeviceMapper(),
+		pagination: policy,
+		logger:     loggerFactory.Core(),
 	}
 }
 
@@ -40,7 +43,8 @@ func (r *deviceRepository) Create(ctx context.Context, device *entities.Device)
 		return fmt.Errorf("device cannot be nil")
 	}
 
-	// Validate and normalize the domain entity before mapping
+	// Validate and normalize a copy of the domain entity before mapping, the caller's device is left untouched
+	device = device.Clone()
 	device.Normalize()
 	if err := device.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -74,7 +78,8 @@ func (r *deviceRepository) Update(ctx context.Context, device *entities.Device)
 		return fmt.Errorf("device cannot be nil")
 	}
 
-	// Validate and normalize the domain entity before mapping
+	// Validate and normalize a copy of the domain entity before mapping, the caller's device is left untouched
+	device = device.Clone()
 	device.Normalize()
 	if err := device.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -130,6 +135,26 @@ func (r *deviceRepository) FindByMACAddress(ctx context.Context, macAddress stri
 	return device, nil
 }
 
+// FindByMACAddresses retrieves the devices with the given MAC addresses using GORM
+func (r *deviceRepository) FindByMACAddresses(ctx context.Context, macAddresses []string) ([]*entities.Device, error) {
+	if len(macAddresses) == 0 {
+		return []*entities.Device{}, nil
+	}
+
+	start := time.Now()
+	var models []*models.DeviceModel
+	result := r.db.GetDB().WithContext(ctx).Where("mac_address IN ?", macAddresses).Find(&models)
+	duration := time.Since(start)
+
+	if result.Error != nil {
+		r.logger.Info("device_not_found", zap.String("operation", "find_by_macs"), zap.String("table", "devices"), zap.Duration("duration", duration), zap.Int64("records_affected", 0), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to find devices by MAC addresses: %w", result.Error)
+	}
+
+	r.logger.Info("devices_found_successfully", zap.Int("requested", len(macAddresses)), zap.Int("count", len(models)), zap.String("component", "device_repository"))
+	return r.mapper.FromModelSlice(models), nil
+}
+
 // Exists checks if a device with the given MAC address exists using GORM
 func (r *deviceRepository) Exists(ctx context.Context, macAddress string) (bool, error) {
 	if macAddress == "" {
@@ -151,20 +176,36 @@ func (r *deviceRepository) Exists(ctx context.Context, macAddress string) (bool,
 	return count > 0, nil
 }
 
-// List retrieves all devices with optional pagination using GORM
+// List retrieves a page of devices using GORM, resolving the limit with the pagination policy
 func (r *deviceRepository) List(ctx context.Context, offset, limit int) ([]*entities.Device, error) {
+	return r.list(ctx, "", offset, limit)
+}
+
+// ListByStatus retrieves a page of the devices with the given status, most recently registered first
+func (r *deviceRepository) ListByStatus(ctx context.Context, status string, offset, limit int) ([]*entities.Device, error) {
+	if status == "" {
+		return nil, fmt.Errorf("status cannot be empty")
+	}
+	return r.list(ctx, status, offset, limit)
+}
+
+// list retrieves a page of devices, only those with the status unless it is empty
+func (r *deviceRepository) list(ctx context.Context, status string, offset, limit int) ([]*entities.Device, error) {
 	if offset < 0 {
 		return nil, fmt.Errorf("offset cannot be negative")
 	}
-	if limit < 0 {
-		return nil, fmt.Errorf("limit cannot be negative")
+	limit, err := r.pagination.Limit(limit)
+	if err != nil {
+		return nil, err
 	}
 
 	var models []*models.DeviceModel
 	query := r.db.GetDB().WithContext(ctx).Order("registered_at DESC")
 
-	// Apply pagination if specified
-	if limit > 0 {
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if limit != pagination.Unlimited {
 		query = query.Limit(limit)
 	}
 	if offset > 0 {
@@ -181,6 +222,7 @@ func (r *deviceRepository) List(ctx context.Context, offset, limit int) ([]*enti
 	}
 
 	r.logger.Info("devices_listed_successfully", zap.Int("count", len(models)),
+		zap.String("status", status),
 		zap.Int("limit", limit),
 		zap.Int("offset", offset),
 		zap.String("component", "device_repository"),
@@ -191,6 +233,49 @@ func (r *deviceRepository) List(ctx context.Context, offset, limit int) ([]*enti
 	return devices, nil
 }
 
+// CountByVersion counts the devices by farm, firmware version and hardware version in a single query
+func (r *deviceRepository) CountByVersion(ctx context.Context) ([]*entities.FleetVersionCount, error) {
+	var rows []struct {
+		FarmID          string
+		FirmwareVersion string
+		HardwareVersion string
+		Devices         int
+		Online          int
+	}
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.DeviceModel{}).
+		Select("farm_id, firmware_version, hardware_version, COUNT(*) AS devices, COUNT(*) FILTER (WHERE status = 'online') AS online").
+		Group("farm_id, firmware_version, hardware_version").
+		Scan(&rows)
+	if result.Error != nil {
+		r.logger.Error("device_version_count_failed", zap.String("table", "devices"), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to count devices by version: %w", result.Error)
+	}
+
+	counts := make([]*entities.FleetVersionCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, &entities.FleetVersionCount{
+			FarmID:          row.FarmID,
+			FirmwareVersion: row.FirmwareVersion,
+			HardwareVersion: row.HardwareVersion,
+			Devices:         row.Devices,
+			Online:          row.Online,
+		})
+	}
+	return counts, nil
+}
+
+// CountByFarm counts the devices registered under the farm
+func (r *deviceRepository) CountByFarm(ctx context.Context, farmID string) (int, error) {
+	var count int64
+	result := r.db.GetDB().WithContext(ctx).Model(&models.DeviceModel{}).Where("farm_id = ?", farmID).Count(&count)
+	if result.Error != nil {
+		r.logger.Error("device_farm_count_failed", zap.String("farm_id", farmID), zap.String("table", "devices"), zap.Error(result.Error))
+		return 0, fmt.Errorf("failed to count farm devices: %w", result.Error)
+	}
+	return int(count), nil
+}
+
 // Delete removes a device by MAC address using GORM soft delete
 func (r *deviceRepository) Delete(ctx context.Context, macAddress string) error {
 	if macAddress == "" {
@@ -12,6 +12,7 @@ import (
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
@@ -28,7 +29,7 @@ func setupTestRepository(t *testing.T) (*deviceRepository, sqlmock.Sqlmock) {
 	assert.NoError(t, err)
 	assert.NotNil(t, postgresDB)
 
-	deviceRepository := NewDeviceRepository(postgresDB, testLoggerFactory).(*deviceRepository)
+	deviceRepository := NewDeviceRepository(postgresDB, pagination.DefaultPolicy(), testLoggerFactory).(*deviceRepository)
 	assert.NotNil(t, deviceRepository)
 
 	return deviceRepository, sqkmockDB
@@ -51,7 +52,7 @@ func TestNewDeviceRepository(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, postgresDB)
 
-	deviceRepository := NewDeviceRepository(postgresDB, testLoggerFactory)
+	deviceRepository := NewDeviceRepository(postgresDB, pagination.DefaultPolicy(), testLoggerFactory)
 	assert.NotNil(t, deviceRepository)
 }
 
@@ -64,7 +65,7 @@ func TestSave(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, postgresDB)
 
-	deviceRepository := NewDeviceRepository(postgresDB, testLoggerFactory)
+	deviceRepository := NewDeviceRepository(postgresDB, pagination.DefaultPolicy(), testLoggerFactory)
 	assert.NotNil(t, deviceRepository)
 
 	deviceEntity, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "test_device", "127.0.0.1", "In the very test code")
@@ -106,7 +107,7 @@ func TestSave(t *testing.T) {
 
 	t.Run("should success due to the device is saved successfully", func(t *testing.T) {
 		sqkmockDB.ExpectQuery(
-			`INSERT INTO "devices" \("mac_address","device_name","ip_address","location_description","status","deleted_at","registered_at","last_seen","created_at","updated_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10\) RETURNING "registered_at","last_seen","created_at","updated_at"`).
+			`INSERT INTO "devices" \("mac_address","device_name","ip_address","location_description","status","certificate_fingerprint","credential_hash","farm_id","calibrated_at","firmware_version","hardware_version","registration_topic","registration_qos","registration_retained","registration_client_id","deleted_at","registered_at","last_seen","created_at","updated_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10,\$11,\$12,\$13,\$14,\$15,\$16,\$17,\$18,\$19,\$20\) RETURNING "registered_at","last_seen","created_at","updated_at"`).
 			WillReturnRows(sqlmock.NewRows([]string{"registered_at", "last_seen", "created_at", "updated_at"}).
 				AddRow(time.Now(), time.Now(), time.Now(), time.Now()))
 
@@ -125,7 +126,7 @@ func TestUpdate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, postgresDB)
 
-	deviceRepository := NewDeviceRepository(postgresDB, testLoggerFactory)
+	deviceRepository := NewDeviceRepository(postgresDB, pagination.DefaultPolicy(), testLoggerFactory)
 	assert.NotNil(t, deviceRepository)
 
 	deviceEntity, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "updated_device", "127.0.0.2", "Updated location")
@@ -180,7 +181,7 @@ func TestFindByMACAddress(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, postgresDB)
 
-	deviceRepository := NewDeviceRepository(postgresDB, testLoggerFactory)
+	deviceRepository := NewDeviceRepository(postgresDB, pagination.DefaultPolicy(), testLoggerFactory)
 	assert.NotNil(t, deviceRepository)
 
 	macAddress := "AA:BB:CC:DD:EE:FF"
@@ -234,6 +235,75 @@ func TestFindByMACAddress(t *testing.T) {
 	})
 }
 
+func TestFindByMACAddresses(t *testing.T) {
+	gormMockDB, sqkmockDB := stubs.GetTestDB(t)
+	testLoggerFactory := createTestLoggerFactory(t)
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, testLoggerFactory.Infrastructure())
+	assert.NoError(t, err)
+
+	deviceRepository := NewDeviceRepository(postgresDB, pagination.DefaultPolicy(), testLoggerFactory)
+	macAddresses := []string{"AA:BB:CC:DD:EE:FF", "11:22:33:44:55:66"}
+
+	t.Run("should not query when no MAC address is given", func(t *testing.T) {
+		devices, err := deviceRepository.FindByMACAddresses(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Empty(t, devices)
+	})
+
+	t.Run("should return error when database query fails", func(t *testing.T) {
+		sqkmockDB.ExpectQuery(`SELECT .* FROM "devices" WHERE mac_address IN \(\$1,\$2\) AND "devices"\."deleted_at" IS NULL`).
+			WithArgs(macAddresses[0], macAddresses[1]).
+			WillReturnError(errors.New("query failed"))
+
+		devices, err := deviceRepository.FindByMACAddresses(context.Background(), macAddresses)
+		assert.Nil(t, devices)
+		assert.Contains(t, err.Error(), "failed to find devices by MAC addresses: query failed")
+	})
+
+	t.Run("should find the existing devices in one query", func(t *testing.T) {
+		sqkmockDB.ExpectQuery(`SELECT .* FROM "devices" WHERE mac_address IN \(\$1,\$2\) AND "devices"\."deleted_at" IS NULL`).
+			WithArgs(macAddresses[0], macAddresses[1]).
+			WillReturnRows(sqlmock.NewRows([]string{"mac_address", "device_name", "ip_address", "location_description", "status", "registered_at", "last_seen"}).
+				AddRow(macAddresses[0], "test_device", "127.0.0.1", "Test location", "online", time.Now(), time.Now()))
+
+		devices, err := deviceRepository.FindByMACAddresses(context.Background(), macAddresses)
+		assert.NoError(t, err)
+		assert.Len(t, devices, 1)
+		assert.Equal(t, "online", devices[0].Status)
+		assert.NoError(t, sqkmockDB.ExpectationsWereMet())
+	})
+}
+
+func TestCountByVersion(t *testing.T) {
+	deviceRepository, sqkmockDB := setupTestRepository(t)
+
+	sqkmockDB.ExpectQuery(`SELECT farm_id, firmware_version, hardware_version, COUNT\(\*\) AS devices, COUNT\(\*\) FILTER \(WHERE status = 'online'\) AS online FROM "devices" WHERE "devices"\."deleted_at" IS NULL GROUP BY farm_id, firmware_version, hardware_version`).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_id", "firmware_version", "hardware_version", "devices", "online"}).
+			AddRow("farm-1", "1.5.0", "rev-b", 12, 10).
+			AddRow("", "", "", 3, 1))
+
+	counts, err := deviceRepository.CountByVersion(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.FleetVersionCount{
+		{FarmID: "farm-1", FirmwareVersion: "1.5.0", HardwareVersion: "rev-b", Devices: 12, Online: 10},
+		{Devices: 3, Online: 1},
+	}, counts)
+	assert.NoError(t, sqkmockDB.ExpectationsWereMet())
+}
+
+func TestCountByFarm(t *testing.T) {
+	deviceRepository, sqkmockDB := setupTestRepository(t)
+
+	sqkmockDB.ExpectQuery(`SELECT count\(\*\) FROM "devices" WHERE farm_id = \$1 AND "devices"\."deleted_at" IS NULL`).
+		WithArgs("farm-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := deviceRepository.CountByFarm(context.Background(), "farm-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, count)
+	assert.NoError(t, sqkmockDB.ExpectationsWereMet())
+}
+
 func TestExists(t *testing.T) {
 	gormMockDB, sqkmockDB := stubs.GetTestDB(t)
 	assert.NotNil(t, gormMockDB)
@@ -243,7 +313,7 @@ func TestExists(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, postgresDB)
 
-	deviceRepository := NewDeviceRepository(postgresDB, testLoggerFactory)
+	deviceRepository := NewDeviceRepository(postgresDB, pagination.DefaultPolicy(), testLoggerFactory)
 	assert.NotNil(t, deviceRepository)
 
 	macAddress := "AA:BB:CC:DD:EE:FF"
@@ -297,7 +367,7 @@ func TestList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, postgresDB)
 
-	deviceRepository := NewDeviceRepository(postgresDB, testLoggerFactory)
+	deviceRepository := NewDeviceRepository(postgresDB, pagination.DefaultPolicy(), testLoggerFactory)
 	assert.NotNil(t, deviceRepository)
 
 	t.Run("should return error when offset is negative", func(t *testing.T) {
@@ -309,11 +379,17 @@ func TestList(t *testing.T) {
 	})
 
 	t.Run("should return error when limit is negative", func(t *testing.T) {
-		devices, err := deviceRepository.List(context.Background(), 0, -1)
+		devices, err := deviceRepository.List(context.Background(), 0, -5)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, pagination.ErrInvalidLimit)
+		assert.Nil(t, devices)
+	})
+
+	t.Run("should return error when limit exceeds the maximum", func(t *testing.T) {
+		devices, err := deviceRepository.List(context.Background(), 0, pagination.DefaultPolicy().MaxLimit+1)
+
+		assert.ErrorIs(t, err, pagination.ErrLimitTooLarge)
 		assert.Nil(t, devices)
-		assert.Equal(t, "limit cannot be negative", err.Error())
 	})
 
 	t.Run("should return error when database query fails", func(t *testing.T) {
@@ -326,6 +402,18 @@ func TestList(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to list devices: query failed")
 	})
 
+	t.Run("should apply the default limit when none is given", func(t *testing.T) {
+		sqkmockDB.ExpectQuery(`SELECT .* FROM "devices" WHERE "devices"\."deleted_at" IS NULL ORDER BY registered_at DESC LIMIT \$1`).
+			WithArgs(pagination.DefaultPolicy().DefaultLimit).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"mac_address", "device_name", "ip_address", "location_description",
+				"status", "registered_at", "last_seen"}))
+
+		devices, err := deviceRepository.List(context.Background(), 0, 0)
+		assert.NoError(t, err)
+		assert.Len(t, devices, 0)
+	})
+
 	t.Run("should successfully list all devices without pagination", func(t *testing.T) {
 		registeredAt := time.Now()
 		lastSeen := time.Now()
@@ -339,7 +427,7 @@ func TestList(t *testing.T) {
 				AddRow("AA:BB:CC:DD:EE:02", "device2", "127.0.0.2", "Location 2",
 					"offline", registeredAt, lastSeen))
 
-		devices, err := deviceRepository.List(context.Background(), 0, 0)
+		devices, err := deviceRepository.List(context.Background(), 0, pagination.Unlimited)
 		assert.NoError(t, err)
 		assert.NotNil(t, devices)
 		assert.Len(t, devices, 2)
@@ -378,6 +466,35 @@ func TestList(t *testing.T) {
 	})
 }
 
+func TestListByStatus(t *testing.T) {
+	deviceRepository, sqkmockDB := setupTestRepository(t)
+
+	t.Run("should require a status", func(t *testing.T) {
+		devices, err := deviceRepository.ListByStatus(context.Background(), "", 0, 0)
+
+		assert.EqualError(t, err, "status cannot be empty")
+		assert.Nil(t, devices)
+	})
+
+	t.Run("should list a page of the devices with the status", func(t *testing.T) {
+		registeredAt := time.Now()
+
+		sqkmockDB.ExpectQuery(`SELECT .* FROM "devices" WHERE status = \$1 AND "devices"\."deleted_at" IS NULL ORDER BY registered_at DESC LIMIT \$2 OFFSET \$3`).
+			WithArgs("online", 5, 10).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"mac_address", "device_name", "ip_address", "location_description",
+				"status", "registered_at", "last_seen"}).
+				AddRow("AA:BB:CC:DD:EE:01", "device1", "127.0.0.1", "Location 1",
+					"online", registeredAt, registeredAt))
+
+		devices, err := deviceRepository.ListByStatus(context.Background(), "online", 10, 5)
+		assert.NoError(t, err)
+		assert.Len(t, devices, 1)
+		assert.Equal(t, "online", devices[0].Status)
+		assert.NoError(t, sqkmockDB.ExpectationsWereMet())
+	})
+}
+
 func TestDelete(t *testing.T) {
 	gormMockDB, sqkmockDB := stubs.GetTestDB(t)
 	assert.NotNil(t, gormMockDB)
@@ -387,7 +504,7 @@ func TestDelete(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, postgresDB)
 
-	deviceRepository := NewDeviceRepository(postgresDB, testLoggerFactory)
+	deviceRepository := NewDeviceRepository(postgresDB, pagination.DefaultPolicy(), testLoggerFactory)
 	assert.NotNil(t, deviceRepository)
 
 	macAddress := "AA:BB:CC:DD:EE:FF"
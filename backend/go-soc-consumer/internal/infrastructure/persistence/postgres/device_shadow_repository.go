@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// deviceShadowRepository implements the DeviceShadowRepository interface using GORM PostgreSQL
+type deviceShadowRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.DeviceShadowMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewDeviceShadowRepository creates a new GORM-based PostgreSQL device shadow repository
+func NewDeviceShadowRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.DeviceShadowRepository {
+	return &deviceShadowRepository{
+		db:     db,
+		mapper: mappers.NewDeviceShadowMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Upsert inserts the shadow or replaces the stored one
+func (r *deviceShadowRepository) Upsert(ctx context.Context, shadow *entities.DeviceShadow) error {
+	if shadow == nil {
+		return fmt.Errorf("device shadow cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "mac_address"}},
+			DoUpdates: clause.AssignmentColumns([]string{"device_name", "location_description", "declared_at"}),
+		}).
+		Create(r.mapper.ToModel(shadow))
+	if result.Error != nil {
+		r.logger.Error("device_shadow_upsert_failed", zap.String("operation", "upsert"), zap.String("table", "device_shadows"), zap.String("mac_address", shadow.MACAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to store device shadow: %w", result.Error)
+	}
+	return nil
+}
+
+// Delete removes the shadow of the device
+func (r *deviceShadowRepository) Delete(ctx context.Context, macAddress string) error {
+	result := r.db.GetDB().WithContext(ctx).Where("mac_address = ?", macAddress).Delete(&models.DeviceShadowModel{})
+	if result.Error != nil {
+		r.logger.Error("device_shadow_delete_failed", zap.String("operation", "delete"), zap.String("table", "device_shadows"), zap.String("mac_address", macAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to delete device shadow: %w", result.Error)
+	}
+	return nil
+}
+
+// List returns every shadow by MAC address
+func (r *deviceShadowRepository) List(ctx context.Context) ([]*entities.DeviceShadow, error) {
+	var records []models.DeviceShadowModel
+	result := r.db.GetDB().WithContext(ctx).Order("mac_address").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list device shadows: %w", result.Error)
+	}
+
+	shadows := make([]*entities.DeviceShadow, 0, len(records))
+	for i := range records {
+		shadows = append(shadows, r.mapper.FromModel(&records[i]))
+	}
+	return shadows, nil
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupDeviceShadowTestRepository initializes a test repository with a mock database
+func setupDeviceShadowTestRepository(t *testing.T) (*deviceShadowRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewDeviceShadowRepository(postgresDB, loggerFactory).(*deviceShadowRepository), sqlMock
+}
+
+func TestDeviceShadowRepository_Upsert(t *testing.T) {
+	declaredAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo, mock := setupDeviceShadowTestRepository(t)
+	mock.ExpectExec(`INSERT INTO "device_shadows" .* ON CONFLICT \("mac_address"\) DO UPDATE SET "device_name"="excluded"."device_name","location_description"="excluded"."location_description","declared_at"="excluded"."declared_at"`).
+		WithArgs("AA:BB:CC:DD:EE:FF", "North valve", "Greenhouse 2", declaredAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Upsert(context.Background(), &entities.DeviceShadow{
+		MACAddress: "AA:BB:CC:DD:EE:FF", DeviceName: "North valve", LocationDescription: "Greenhouse 2", DeclaredAt: declaredAt,
+	}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceShadowRepository_Delete(t *testing.T) {
+	repo, mock := setupDeviceShadowTestRepository(t)
+	mock.ExpectExec(`DELETE FROM "device_shadows" WHERE mac_address = \$1`).
+		WithArgs("AA:BB:CC:DD:EE:FF").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Delete(context.Background(), "AA:BB:CC:DD:EE:FF"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeviceShadowRepository_List(t *testing.T) {
+	declaredAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo, mock := setupDeviceShadowTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "device_shadows" ORDER BY mac_address`).
+		WillReturnRows(sqlmock.NewRows([]string{"mac_address", "device_name", "location_description", "declared_at"}).
+			AddRow("AA:BB:CC:DD:EE:01", "North valve", "Greenhouse 2", declaredAt).
+			AddRow("AA:BB:CC:DD:EE:02", "South valve", "Greenhouse 3", declaredAt))
+
+	shadows, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, shadows, 2)
+	assert.Equal(t, &entities.DeviceShadow{MACAddress: "AA:BB:CC:DD:EE:01", DeviceName: "North valve", LocationDescription: "Greenhouse 2", DeclaredAt: declaredAt}, shadows[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// archiveTransferMeasurementsSQL moves the measurements of a device out of the measurements table
+// into the archive of a transfer in a single statement, so no reading is lost or kept twice
+const archiveTransferMeasurementsSQL = `WITH moved AS (
+	DELETE FROM measurements WHERE mac_address = ? AND created_at < ?
+	RETURNING mac_address, sensor_type, channel, value, unit, quality, created_at
+)
+INSERT INTO transfer_archived_measurements (transfer_id, farm_id, mac_address, sensor_type, channel, value, unit, quality, created_at)
+SELECT ?, ?, mac_address, sensor_type, channel, value, unit, quality, created_at FROM moved`
+
+// archiveTransferReadingsSQL moves the raw temperature and humidity readings of a device into the
+// archive of a transfer. Soft deleted readings are left to compaction, which already holds them.
+const archiveTransferReadingsSQL = `WITH moved AS (
+	DELETE FROM sensor_temperature_humidity WHERE mac_address = ? AND created_at < ? AND deleted_at IS NULL
+	RETURNING mac_address, temperature_celsius, humidity_percent, created_at
+)
+INSERT INTO transfer_archived_temperature_humidity (transfer_id, farm_id, mac_address, temperature_celsius, humidity_percent, created_at)
+SELECT ?, ?, mac_address, temperature_celsius, humidity_percent, created_at FROM moved`
+
+// archiveTransferTelemetryDaysSQL moves the compacted device-days of a device into the archive of a
+// transfer and returns the number of readings they hold
+const archiveTransferTelemetryDaysSQL = `WITH moved AS (
+	DELETE FROM sensor_temperature_humidity_archive WHERE mac_address = ? AND last_reading_at < ?
+	RETURNING mac_address, day, encoding, data, sample_count, first_reading_at, last_reading_at
+), archived AS (
+	INSERT INTO transfer_archived_telemetry_days (transfer_id, farm_id, mac_address, day, encoding, data, sample_count, first_reading_at, last_reading_at)
+	SELECT ?, ?, mac_address, day, encoding, data, sample_count, first_reading_at, last_reading_at FROM moved
+)
+SELECT COALESCE(SUM(sample_count), 0) FROM moved`
+
+// deviceTransferRepository implements the DeviceTransferRepository interface using GORM PostgreSQL
+type deviceTransferRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.DeviceTransferMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewDeviceTransferRepository creates a new GORM-based PostgreSQL device transfer repository
+func NewDeviceTransferRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.DeviceTransferRepository {
+	return &deviceTransferRepository{
+		db:     db,
+		mapper: mappers.NewDeviceTransferMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new transfer with its first audit entry in one transaction
+func (r *deviceTransferRepository) Create(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error {
+	if transfer == nil {
+		return fmt.Errorf("device transfer cannot be nil")
+	}
+
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(r.mapper.ToModel(transfer)).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return domainerrors.ErrDeviceTransferInProgress
+			}
+			return fmt.Errorf("failed to create device transfer: %w", err)
+		}
+		if err := tx.Create(r.mapper.AuditToModel(entry)).Error; err != nil {
+			return fmt.Errorf("failed to record device transfer audit entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, domainerrors.ErrDeviceTransferInProgress) {
+		r.logger.Error("device_transfer_create_failed", zap.String("operation", "create"), zap.String("table", "device_transfers"), zap.String("mac_address", transfer.MACAddress), zap.Error(err))
+	}
+	return err
+}
+
+// Update stores the status of a transfer with its audit entry in one transaction
+func (r *deviceTransferRepository) Update(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error {
+	if transfer == nil {
+		return fmt.Errorf("device transfer cannot be nil")
+	}
+
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.store(tx, transfer, entry)
+	})
+	if err != nil && !errors.Is(err, domainerrors.ErrDeviceTransferNotFound) {
+		r.logger.Error("device_transfer_update_failed", zap.String("operation", "update"), zap.String("table", "device_transfers"), zap.String("id", transfer.ID), zap.Error(err))
+	}
+	return err
+}
+
+// store updates the status of a transfer and records its audit entry within a transaction
+func (r *deviceTransferRepository) store(tx *gorm.DB, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error {
+	result := tx.Model(&models.DeviceTransferModel{}).
+		Where("id = ?", transfer.ID).
+		Updates(map[string]interface{}{
+			"status":            transfer.Status,
+			"archived_readings": transfer.ArchivedReadings,
+			"accepted_at":       transfer.AcceptedAt,
+			"completed_at":      transfer.CompletedAt,
+			"cancelled_at":      transfer.CancelledAt,
+			"updated_at":        transfer.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update device transfer: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrDeviceTransferNotFound
+	}
+	if err := tx.Create(r.mapper.AuditToModel(entry)).Error; err != nil {
+		return fmt.Errorf("failed to record device transfer audit entry: %w", err)
+	}
+	return nil
+}
+
+// FindByID retrieves a transfer by its ID
+func (r *deviceTransferRepository) FindByID(ctx context.Context, id string) (*entities.DeviceTransfer, error) {
+	var model models.DeviceTransferModel
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrDeviceTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to find device transfer: %w", result.Error)
+	}
+	return r.mapper.FromModel(&model), nil
+}
+
+// List returns the matching transfers, most recently initiated first
+func (r *deviceTransferRepository) List(ctx context.Context, macAddress, status string, limit int) ([]*entities.DeviceTransfer, error) {
+	query := r.db.GetDB().WithContext(ctx).Model(&models.DeviceTransferModel{})
+	if macAddress != "" {
+		query = query.Where("mac_address = ?", macAddress)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var records []models.DeviceTransferModel
+	result := query.Order("created_at DESC").Order("id ASC").Limit(limit).Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list device transfers: %w", result.Error)
+	}
+
+	transfers := make([]*entities.DeviceTransfer, 0, len(records))
+	for i := range records {
+		transfers = append(transfers, r.mapper.FromModel(&records[i]))
+	}
+	return transfers, nil
+}
+
+// AuditEntries returns the audit trail of a transfer, oldest first
+func (r *deviceTransferRepository) AuditEntries(ctx context.Context, transferID string) ([]entities.DeviceTransferAuditEntry, error) {
+	var records []models.DeviceTransferAuditModel
+	result := r.db.GetDB().WithContext(ctx).Where("transfer_id = ?", transferID).Order("at ASC").Order("id ASC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to load device transfer audit entries: %w", result.Error)
+	}
+
+	entries := make([]entities.DeviceTransferAuditEntry, 0, len(records))
+	for i := range records {
+		entries = append(entries, r.mapper.AuditFromModel(&records[i]))
+	}
+	return entries, nil
+}
+
+// Complete archives the history of the device per policy, binds it to the target farm and stores
+// the completed transfer with its audit entry in one transaction
+func (r *deviceTransferRepository) Complete(ctx context.Context, transfer *entities.DeviceTransfer, device *entities.Device, actor string, at time.Time) (entities.DeviceTransferAuditEntry, error) {
+	if transfer == nil || device == nil {
+		return entities.DeviceTransferAuditEntry{}, fmt.Errorf("device transfer and device cannot be nil")
+	}
+
+	start := time.Now()
+	var entry entities.DeviceTransferAuditEntry
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		archived := 0
+		if transfer.HistoryPolicy == entities.TransferHistoryArchive {
+			if archived, err = r.archiveHistory(tx, transfer, at); err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(&models.DeviceModel{}).Where("mac_address = ?", device.MACAddress).Update("farm_id", device.FarmID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update device farm: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domainerrors.ErrDeviceNotFound
+		}
+
+		if entry, err = transfer.Complete(actor, archived, at); err != nil {
+			return fmt.Errorf("%w: %v", domainerrors.ErrDeviceTransferStatusChange, err)
+		}
+		return r.store(tx, transfer, entry)
+	})
+	if err != nil {
+		if !errors.Is(err, domainerrors.ErrDeviceNotFound) && !errors.Is(err, domainerrors.ErrDeviceTransferNotFound) && !errors.Is(err, domainerrors.ErrDeviceTransferStatusChange) {
+			r.logger.Error("device_transfer_complete_failed", zap.String("operation", "complete"), zap.String("table", "device_transfers"), zap.String("id", transfer.ID), zap.Error(err))
+		}
+		return entities.DeviceTransferAuditEntry{}, err
+	}
+
+	r.logger.Info("device_transfer_completed",
+		zap.String("transfer_id", transfer.ID),
+		zap.String("mac_address", transfer.MACAddress),
+		zap.Int("archived_readings", transfer.ArchivedReadings),
+		zap.Duration("duration", time.Since(start)),
+	)
+	return entry, nil
+}
+
+// archiveHistory moves the measurements, raw readings and compacted days of the device taken before
+// the given time into the archive of the transfer, returning the number of readings moved
+func (r *deviceTransferRepository) archiveHistory(tx *gorm.DB, transfer *entities.DeviceTransfer, before time.Time) (int, error) {
+	result := tx.Exec(archiveTransferMeasurementsSQL, transfer.MACAddress, before, transfer.ID, transfer.FromFarmID)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to archive device measurements: %w", result.Error)
+	}
+	archived := int(result.RowsAffected)
+
+	result = tx.Exec(archiveTransferReadingsSQL, transfer.MACAddress, before, transfer.ID, transfer.FromFarmID)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to archive device readings: %w", result.Error)
+	}
+	archived += int(result.RowsAffected)
+
+	var compacted int
+	if err := tx.Raw(archiveTransferTelemetryDaysSQL, transfer.MACAddress, before, transfer.ID, transfer.FromFarmID).Scan(&compacted).Error; err != nil {
+		return 0, fmt.Errorf("failed to archive device telemetry days: %w", err)
+	}
+	return archived + compacted, nil
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupDeviceTransferTestRepository initializes a test repository with a mock database
+func setupDeviceTransferTestRepository(t *testing.T) (*deviceTransferRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewDeviceTransferRepository(postgresDB, loggerFactory).(*deviceTransferRepository), sqlMock
+}
+
+func TestDeviceTransferRepository_Update(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	transfer := &entities.DeviceTransfer{ID: "transfer-1", Status: entities.DeviceTransferAccepted, AcceptedAt: &at, UpdatedAt: at}
+	entry := entities.DeviceTransferAuditEntry{TransferID: "transfer-1", Action: entities.DeviceTransferAccepted, Actor: "maria", At: at}
+
+	t.Run("should store the status with its audit entry", func(t *testing.T) {
+		repo, mock := setupDeviceTransferTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "device_transfers" SET "accepted_at"=\$1,"archived_readings"=\$2,"cancelled_at"=\$3,"completed_at"=\$4,"status"=\$5,"updated_at"=\$6 WHERE id = \$7`).
+			WithArgs(&at, 0, nil, nil, "accepted", at, "transfer-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "device_transfer_audit" \("transfer_id","action","actor","note","at"\) VALUES \(\$1,\$2,\$3,\$4,\$5\) RETURNING "id"`).
+			WithArgs("transfer-1", "accepted", "maria", "", at).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Update(context.Background(), transfer, entry))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report unknown transfers without an audit entry", func(t *testing.T) {
+		repo, mock := setupDeviceTransferTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "device_transfers"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.Update(context.Background(), transfer, entry)
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceTransferNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeviceTransferRepository_FindByID(t *testing.T) {
+	repo, mock := setupDeviceTransferTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "device_transfers" WHERE id = \$1`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.FindByID(context.Background(), "transfer-1")
+	assert.ErrorIs(t, err, domainerrors.ErrDeviceTransferNotFound)
+}
+
+func TestDeviceTransferRepository_Complete(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	newTransfer := func(policy string) *entities.DeviceTransfer {
+		return &entities.DeviceTransfer{ID: "transfer-1", MACAddress: "AA:BB:CC:DD:EE:FF", FromFarmID: "finca-norte", ToFarmID: "finca-sur", HistoryPolicy: policy, Status: entities.DeviceTransferAccepted}
+	}
+	device := &entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "finca-sur"}
+
+	t.Run("should archive every reading table with the device and the transfer", func(t *testing.T) {
+		repo, mock := setupDeviceTransferTestRepository(t)
+		transfer := newTransfer(entities.TransferHistoryArchive)
+		mock.ExpectBegin()
+		mock.ExpectExec(`WITH moved AS \( DELETE FROM measurements WHERE mac_address = \$1 AND created_at < \$2 RETURNING .* \) INSERT INTO transfer_archived_measurements .* SELECT \$3, \$4, .* FROM moved`).
+			WithArgs("AA:BB:CC:DD:EE:FF", at, "transfer-1", "finca-norte").
+			WillReturnResult(sqlmock.NewResult(0, 1440))
+		mock.ExpectExec(`WITH moved AS \( DELETE FROM sensor_temperature_humidity WHERE mac_address = \$1 AND created_at < \$2 AND deleted_at IS NULL RETURNING .* \) INSERT INTO transfer_archived_temperature_humidity .* SELECT \$3, \$4, .* FROM moved`).
+			WithArgs("AA:BB:CC:DD:EE:FF", at, "transfer-1", "finca-norte").
+			WillReturnResult(sqlmock.NewResult(0, 300))
+		mock.ExpectQuery(`WITH moved AS \( DELETE FROM sensor_temperature_humidity_archive WHERE mac_address = \$1 AND last_reading_at < \$2 RETURNING .* \), archived AS \( INSERT INTO transfer_archived_telemetry_days .* SELECT \$3, \$4, .* FROM moved \) SELECT COALESCE\(SUM\(sample_count\), 0\) FROM moved`).
+			WithArgs("AA:BB:CC:DD:EE:FF", at, "transfer-1", "finca-norte").
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(2880))
+		mock.ExpectExec(`UPDATE "devices" SET "farm_id"=\$1.* WHERE mac_address = \$\d`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "device_transfers" SET .*"archived_readings"=\$2.*"status"=\$5`).
+			WithArgs(nil, 4620, nil, &at, "completed", at, "transfer-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "device_transfer_audit"`).
+			WithArgs("transfer-1", "completed", "pedro", "4620 readings archived", at).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		entry, err := repo.Complete(context.Background(), transfer, device, "pedro", at)
+		require.NoError(t, err)
+		assert.Equal(t, 4620, transfer.ArchivedReadings)
+		assert.Equal(t, entities.DeviceTransferCompleted, entry.Action)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should roll the archive back when the device is gone", func(t *testing.T) {
+		repo, mock := setupDeviceTransferTestRepository(t)
+		transfer := newTransfer(entities.TransferHistoryArchive)
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM measurements`).WillReturnResult(sqlmock.NewResult(0, 1440))
+		mock.ExpectExec(`DELETE FROM sensor_temperature_humidity`).WillReturnResult(sqlmock.NewResult(0, 300))
+		mock.ExpectQuery(`DELETE FROM sensor_temperature_humidity_archive`).WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(0))
+		mock.ExpectExec(`UPDATE "devices"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := repo.Complete(context.Background(), transfer, device, "pedro", at)
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+		assert.Equal(t, entities.DeviceTransferAccepted, transfer.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should keep the history with the device", func(t *testing.T) {
+		repo, mock := setupDeviceTransferTestRepository(t)
+		transfer := newTransfer(entities.TransferHistoryKeep)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "devices"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "device_transfers"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "device_transfer_audit"`).
+			WithArgs("transfer-1", "completed", "pedro", "", at).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		_, err := repo.Complete(context.Background(), transfer, device, "pedro", at)
+		require.NoError(t, err)
+		assert.Zero(t, transfer.ArchivedReadings)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// farmRepository implements the FarmRepository interface using GORM PostgreSQL
+type farmRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.FarmMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewFarmRepository creates a new GORM-based PostgreSQL farm repository
+func NewFarmRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.FarmRepository {
+	return &farmRepository{
+		db:     db,
+		mapper: mappers.NewFarmMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new farm
+func (r *farmRepository) Create(ctx context.Context, farm *entities.Farm) error {
+	if farm == nil {
+		return fmt.Errorf("farm cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Create(r.mapper.ToModel(farm))
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return domainerrors.ErrFarmAlreadyExists
+		}
+		r.logger.Error("farm_create_failed", zap.String("operation", "create"), zap.String("table", "farms"), zap.String("id", farm.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to create farm: %w", result.Error)
+	}
+	return nil
+}
+
+// Update stores the name of a farm
+func (r *farmRepository) Update(ctx context.Context, farm *entities.Farm) error {
+	if farm == nil {
+		return fmt.Errorf("farm cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.FarmModel{}).
+		Where("id = ?", farm.ID).
+		Updates(map[string]interface{}{"name": farm.Name, "updated_at": farm.UpdatedAt})
+	if result.Error != nil {
+		r.logger.Error("farm_update_failed", zap.String("operation", "update"), zap.String("table", "farms"), zap.String("id", farm.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to update farm: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrFarmNotFound
+	}
+	return nil
+}
+
+// Delete removes a farm by its ID
+func (r *farmRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).Delete(&models.FarmModel{})
+	if result.Error != nil {
+		r.logger.Error("farm_delete_failed", zap.String("operation", "delete"), zap.String("table", "farms"), zap.String("id", id), zap.Error(result.Error))
+		return fmt.Errorf("failed to delete farm: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrFarmNotFound
+	}
+	return nil
+}
+
+// FindByID retrieves a farm by its ID
+func (r *farmRepository) FindByID(ctx context.Context, id string) (*entities.Farm, error) {
+	var model models.FarmModel
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrFarmNotFound
+		}
+		return nil, fmt.Errorf("failed to find farm: %w", result.Error)
+	}
+	return r.mapper.FromModel(&model), nil
+}
+
+// List returns every farm sorted by name
+func (r *farmRepository) List(ctx context.Context) ([]*entities.Farm, error) {
+	var records []models.FarmModel
+	result := r.db.GetDB().WithContext(ctx).Order("name ASC").Order("id ASC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list farms: %w", result.Error)
+	}
+
+	farms := make([]*entities.Farm, 0, len(records))
+	for i := range records {
+		farms = append(farms, r.mapper.FromModel(&records[i]))
+	}
+	return farms, nil
+}
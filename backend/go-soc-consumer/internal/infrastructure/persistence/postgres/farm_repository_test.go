@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupFarmTestRepository initializes a test repository with a mock database
+func setupFarmTestRepository(t *testing.T) (*farmRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewFarmRepository(postgresDB, loggerFactory).(*farmRepository), sqlMock
+}
+
+func TestFarmRepository_Create(t *testing.T) {
+	repo, mock := setupFarmTestRepository(t)
+	farm, err := entities.NewFarm("la-esperanza", "La Esperanza", time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`INSERT INTO "farms" \("id","name","created_at","updated_at"\) .* RETURNING`).
+		WithArgs("la-esperanza", "La Esperanza", farm.CreatedAt, farm.UpdatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(farm.CreatedAt, farm.UpdatedAt))
+
+	require.NoError(t, repo.Create(context.Background(), farm))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFarmRepository_Update(t *testing.T) {
+	repo, mock := setupFarmTestRepository(t)
+	farm := &entities.Farm{ID: "la-esperanza", Name: "Finca La Esperanza", UpdatedAt: time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)}
+	mock.ExpectExec(`UPDATE "farms" SET "name"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+		WithArgs("Finca La Esperanza", farm.UpdatedAt, "la-esperanza").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.Update(context.Background(), farm), domainerrors.ErrFarmNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFarmRepository_FindByID(t *testing.T) {
+	t.Run("should map the farm", func(t *testing.T) {
+		repo, mock := setupFarmTestRepository(t)
+		createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT \* FROM "farms" WHERE id = \$1`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).AddRow("la-esperanza", "La Esperanza", createdAt, createdAt))
+
+		farm, err := repo.FindByID(context.Background(), "la-esperanza")
+		require.NoError(t, err)
+		assert.Equal(t, &entities.Farm{ID: "la-esperanza", Name: "La Esperanza", CreatedAt: createdAt, UpdatedAt: createdAt}, farm)
+	})
+
+	t.Run("should report unknown farms", func(t *testing.T) {
+		repo, mock := setupFarmTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "farms"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.FindByID(context.Background(), "la-esperanza")
+		assert.ErrorIs(t, err, domainerrors.ErrFarmNotFound)
+	})
+}
+
+func TestFarmRepository_List(t *testing.T) {
+	repo, mock := setupFarmTestRepository(t)
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT \* FROM "farms" ORDER BY name ASC,id ASC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+			AddRow("el-paraiso", "El Paraíso", createdAt, createdAt).
+			AddRow("la-esperanza", "La Esperanza", createdAt, createdAt))
+
+	farms, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, farms, 2)
+	assert.Equal(t, "el-paraiso", farms[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
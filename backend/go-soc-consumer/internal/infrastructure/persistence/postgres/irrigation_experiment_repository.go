@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// irrigationExperimentRepository implements the IrrigationExperimentRepository interface using GORM PostgreSQL
+type irrigationExperimentRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.IrrigationExperimentMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewIrrigationExperimentRepository creates a new GORM-based PostgreSQL irrigation experiment repository
+func NewIrrigationExperimentRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.IrrigationExperimentRepository {
+	return &irrigationExperimentRepository{
+		db:     db,
+		mapper: mappers.NewIrrigationExperimentMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new irrigation experiment
+func (r *irrigationExperimentRepository) Create(ctx context.Context, experiment *entities.IrrigationExperiment) error {
+	if experiment == nil {
+		return fmt.Errorf("irrigation experiment cannot be nil")
+	}
+
+	model, err := r.mapper.ToModel(experiment)
+	if err != nil {
+		return err
+	}
+	result := r.db.GetDB().WithContext(ctx).Create(model)
+	if result.Error != nil {
+		r.logger.Error("irrigation_experiment_create_failed", zap.String("operation", "create"), zap.String("table", "irrigation_experiments"), zap.String("id", experiment.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to create irrigation experiment: %w", result.Error)
+	}
+	return nil
+}
+
+// Update stores the end of an irrigation experiment
+func (r *irrigationExperimentRepository) Update(ctx context.Context, experiment *entities.IrrigationExperiment) error {
+	if experiment == nil {
+		return fmt.Errorf("irrigation experiment cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.IrrigationExperimentModel{}).
+		Where("id = ?", experiment.ID).
+		Updates(map[string]interface{}{"ended_at": experiment.EndedAt, "updated_at": experiment.UpdatedAt})
+	if result.Error != nil {
+		r.logger.Error("irrigation_experiment_update_failed", zap.String("operation", "update"), zap.String("table", "irrigation_experiments"), zap.String("id", experiment.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to update irrigation experiment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrIrrigationExperimentNotFound
+	}
+	return nil
+}
+
+// FindByID retrieves an irrigation experiment by its ID
+func (r *irrigationExperimentRepository) FindByID(ctx context.Context, id string) (*entities.IrrigationExperiment, error) {
+	var model models.IrrigationExperimentModel
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrIrrigationExperimentNotFound
+		}
+		return nil, fmt.Errorf("failed to find irrigation experiment: %w", result.Error)
+	}
+	return r.mapper.FromModel(&model)
+}
+
+// List returns every irrigation experiment, most recently started first
+func (r *irrigationExperimentRepository) List(ctx context.Context) ([]*entities.IrrigationExperiment, error) {
+	var records []models.IrrigationExperimentModel
+	result := r.db.GetDB().WithContext(ctx).Order("started_at DESC").Order("id ASC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list irrigation experiments: %w", result.Error)
+	}
+
+	experiments := make([]*entities.IrrigationExperiment, 0, len(records))
+	for i := range records {
+		experiment, err := r.mapper.FromModel(&records[i])
+		if err != nil {
+			return nil, err
+		}
+		experiments = append(experiments, experiment)
+	}
+	return experiments, nil
+}
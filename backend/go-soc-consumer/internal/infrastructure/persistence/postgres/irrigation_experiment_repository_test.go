@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupIrrigationExperimentTestRepository initializes a test repository with a mock database
+func setupIrrigationExperimentTestRepository(t *testing.T) (*irrigationExperimentRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewIrrigationExperimentRepository(postgresDB, loggerFactory).(*irrigationExperimentRepository), sqlMock
+}
+
+func TestIrrigationExperimentRepository_Create(t *testing.T) {
+	repo, mock := setupIrrigationExperimentTestRepository(t)
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	experiment := &entities.IrrigationExperiment{
+		ID:        "experiment-1",
+		Name:      "Tomato season",
+		Metadata:  map[string]string{"crop": "tomato"},
+		Variants:  []entities.ExperimentVariant{{Name: "Control", Strategy: entities.IrrigationStrategyRuleBased, Zones: []string{"north"}}},
+		StartedAt: at,
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+
+	mock.ExpectQuery(`INSERT INTO "irrigation_experiments" .* RETURNING`).
+		WithArgs("experiment-1", "Tomato season", "", `{"crop":"tomato"}`, `[{"name":"Control","strategy":"rule_based","zones":["north"]}]`, at, nil, at, at).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(at, at))
+
+	require.NoError(t, repo.Create(context.Background(), experiment))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIrrigationExperimentRepository_Update(t *testing.T) {
+	repo, mock := setupIrrigationExperimentTestRepository(t)
+	endedAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	experiment := &entities.IrrigationExperiment{ID: "experiment-1", EndedAt: &endedAt, UpdatedAt: endedAt}
+	mock.ExpectExec(`UPDATE "irrigation_experiments" SET "ended_at"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+		WithArgs(&endedAt, endedAt, "experiment-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.Update(context.Background(), experiment), domainerrors.ErrIrrigationExperimentNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIrrigationExperimentRepository_FindByID(t *testing.T) {
+	columns := []string{"id", "name", "hypothesis", "metadata", "variants", "started_at", "ended_at", "created_at", "updated_at"}
+
+	t.Run("should decode the variants", func(t *testing.T) {
+		repo, mock := setupIrrigationExperimentTestRepository(t)
+		at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT \* FROM "irrigation_experiments" WHERE id = \$1`).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("experiment-1", "Tomato season", "", `{"crop":"tomato"}`,
+				`[{"name":"Control","strategy":"rule_based","zones":["north"]},{"name":"ML","strategy":"ml","zones":["south"]}]`, at, nil, at, at))
+
+		experiment, err := repo.FindByID(context.Background(), "experiment-1")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"crop": "tomato"}, experiment.Metadata)
+		assert.Equal(t, []entities.ExperimentVariant{
+			{Name: "Control", Strategy: entities.IrrigationStrategyRuleBased, Zones: []string{"north"}},
+			{Name: "ML", Strategy: entities.IrrigationStrategyML, Zones: []string{"south"}},
+		}, experiment.Variants)
+		assert.Nil(t, experiment.EndedAt)
+	})
+
+	t.Run("should report unknown experiments", func(t *testing.T) {
+		repo, mock := setupIrrigationExperimentTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "irrigation_experiments"`).WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := repo.FindByID(context.Background(), "experiment-1")
+		assert.ErrorIs(t, err, domainerrors.ErrIrrigationExperimentNotFound)
+	})
+}
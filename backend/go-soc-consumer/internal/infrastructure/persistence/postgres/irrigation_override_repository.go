@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// irrigationOverrideRepository implements the IrrigationOverrideRepository interface using GORM PostgreSQL
+type irrigationOverrideRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.IrrigationOverrideMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewIrrigationOverrideRepository creates a new GORM-based PostgreSQL irrigation override repository
+func NewIrrigationOverrideRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.IrrigationOverrideRepository {
+	return &irrigationOverrideRepository{
+		db:     db,
+		mapper: mappers.NewIrrigationOverrideMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Upsert inserts the override or replaces the one of the device
+func (r *irrigationOverrideRepository) Upsert(ctx context.Context, override *entities.IrrigationOverride) error {
+	if override == nil {
+		return fmt.Errorf("irrigation override cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "mac_address"}},
+			DoUpdates: clause.AssignmentColumns([]string{"reason", "until", "created_at"}),
+		}).
+		Create(r.mapper.ToModel(override))
+	if result.Error != nil {
+		r.logger.Error("irrigation_override_upsert_failed", zap.String("operation", "upsert"), zap.String("table", "irrigation_overrides"), zap.String("mac_address", override.MACAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to store irrigation override: %w", result.Error)
+	}
+	return nil
+}
+
+// Delete removes the override of the device
+func (r *irrigationOverrideRepository) Delete(ctx context.Context, macAddress string) error {
+	result := r.db.GetDB().WithContext(ctx).Where("mac_address = ?", macAddress).Delete(&models.IrrigationOverrideModel{})
+	if result.Error != nil {
+		r.logger.Error("irrigation_override_delete_failed", zap.String("operation", "delete"), zap.String("table", "irrigation_overrides"), zap.String("mac_address", macAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to delete irrigation override: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrIrrigationOverrideNotFound
+	}
+	return nil
+}
+
+// List returns every override by MAC address
+func (r *irrigationOverrideRepository) List(ctx context.Context) ([]*entities.IrrigationOverride, error) {
+	var records []models.IrrigationOverrideModel
+	result := r.db.GetDB().WithContext(ctx).Order("mac_address").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list irrigation overrides: %w", result.Error)
+	}
+
+	overrides := make([]*entities.IrrigationOverride, 0, len(records))
+	for i := range records {
+		overrides = append(overrides, r.mapper.FromModel(&records[i]))
+	}
+	return overrides, nil
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupIrrigationOverrideTestRepository initializes a test repository with a mock database
+func setupIrrigationOverrideTestRepository(t *testing.T) (*irrigationOverrideRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewIrrigationOverrideRepository(postgresDB, loggerFactory).(*irrigationOverrideRepository), sqlMock
+}
+
+func TestIrrigationOverrideRepository_Upsert(t *testing.T) {
+	createdAt := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+	until := createdAt.Add(2 * time.Hour)
+	repo, mock := setupIrrigationOverrideTestRepository(t)
+	mock.ExpectExec(`INSERT INTO "irrigation_overrides" .* ON CONFLICT \("mac_address"\) DO UPDATE SET "reason"="excluded"."reason","until"="excluded"."until","created_at"="excluded"."created_at"`).
+		WithArgs("AA:BB:CC:DD:EE:FF", "Rain", until, createdAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Upsert(context.Background(), &entities.IrrigationOverride{MACAddress: "AA:BB:CC:DD:EE:FF", Reason: "Rain", Until: until, CreatedAt: createdAt}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIrrigationOverrideRepository_Delete(t *testing.T) {
+	repo, mock := setupIrrigationOverrideTestRepository(t)
+	mock.ExpectExec(`DELETE FROM "irrigation_overrides" WHERE mac_address = \$1`).
+		WithArgs("AA:BB:CC:DD:EE:FF").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "irrigation_overrides"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, repo.Delete(context.Background(), "AA:BB:CC:DD:EE:FF"))
+	assert.ErrorIs(t, repo.Delete(context.Background(), "AA:BB:CC:DD:EE:FF"), domainerrors.ErrIrrigationOverrideNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIrrigationOverrideRepository_List(t *testing.T) {
+	createdAt := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+	repo, mock := setupIrrigationOverrideTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "irrigation_overrides" ORDER BY mac_address`).
+		WillReturnRows(sqlmock.NewRows([]string{"mac_address", "reason", "until", "created_at"}).
+			AddRow("AA:BB:CC:DD:EE:FF", "Rain", createdAt.Add(time.Hour), createdAt))
+
+	overrides, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	assert.Equal(t, &entities.IrrigationOverride{MACAddress: "AA:BB:CC:DD:EE:FF", Reason: "Rain", Until: createdAt.Add(time.Hour), CreatedAt: createdAt}, overrides[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
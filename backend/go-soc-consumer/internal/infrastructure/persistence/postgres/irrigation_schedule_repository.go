@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// irrigationScheduleRepository implements the IrrigationScheduleRepository interface using GORM PostgreSQL
+type irrigationScheduleRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.IrrigationScheduleMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewIrrigationScheduleRepository creates a new GORM-based PostgreSQL irrigation schedule repository
+func NewIrrigationScheduleRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.IrrigationScheduleRepository {
+	return &irrigationScheduleRepository{
+		db:     db,
+		mapper: mappers.NewIrrigationScheduleMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new irrigation schedule
+func (r *irrigationScheduleRepository) Create(ctx context.Context, schedule *entities.IrrigationSchedule) error {
+	if schedule == nil {
+		return fmt.Errorf("irrigation schedule cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Create(r.mapper.ToModel(schedule))
+	if result.Error != nil {
+		r.logger.Error("irrigation_schedule_create_failed", zap.String("operation", "create"), zap.String("table", "irrigation_schedules"), zap.String("mac_address", schedule.MACAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to create irrigation schedule: %w", result.Error)
+	}
+	return nil
+}
+
+// Update stores the requested fields of a schedule without touching its run state
+func (r *irrigationScheduleRepository) Update(ctx context.Context, schedule *entities.IrrigationSchedule) error {
+	if schedule == nil {
+		return fmt.Errorf("irrigation schedule cannot be nil")
+	}
+
+	model := r.mapper.ToModel(schedule)
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.IrrigationScheduleModel{}).
+		Where("id = ?", schedule.ID).
+		Updates(map[string]interface{}{
+			"name":             model.Name,
+			"cron":             model.Cron,
+			"duration_seconds": model.DurationSeconds,
+			"enabled":          model.Enabled,
+			"updated_at":       model.UpdatedAt,
+		})
+	if result.Error != nil {
+		r.logger.Error("irrigation_schedule_update_failed", zap.String("operation", "update"), zap.String("table", "irrigation_schedules"), zap.String("id", schedule.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to update irrigation schedule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrIrrigationScheduleNotFound
+	}
+	return nil
+}
+
+// RecordRun stores the run state of a schedule
+func (r *irrigationScheduleRepository) RecordRun(ctx context.Context, id string, lastRunAt, runEndsAt *time.Time) error {
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.IrrigationScheduleModel{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"last_run_at": lastRunAt, "run_ends_at": runEndsAt})
+	if result.Error != nil {
+		r.logger.Error("irrigation_schedule_run_record_failed", zap.String("operation", "update"), zap.String("table", "irrigation_schedules"), zap.String("id", id), zap.Error(result.Error))
+		return fmt.Errorf("failed to record irrigation run: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrIrrigationScheduleNotFound
+	}
+	return nil
+}
+
+// Delete removes an irrigation schedule by its ID
+func (r *irrigationScheduleRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).Delete(&models.IrrigationScheduleModel{})
+	if result.Error != nil {
+		r.logger.Error("irrigation_schedule_delete_failed", zap.String("operation", "delete"), zap.String("table", "irrigation_schedules"), zap.String("id", id), zap.Error(result.Error))
+		return fmt.Errorf("failed to delete irrigation schedule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrIrrigationScheduleNotFound
+	}
+	return nil
+}
+
+// FindByID retrieves an irrigation schedule by its ID
+func (r *irrigationScheduleRepository) FindByID(ctx context.Context, id string) (*entities.IrrigationSchedule, error) {
+	var model models.IrrigationScheduleModel
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrIrrigationScheduleNotFound
+		}
+		return nil, fmt.Errorf("failed to find irrigation schedule: %w", result.Error)
+	}
+	return r.mapper.FromModel(&model), nil
+}
+
+// List returns the schedules of a device, or of every device, oldest first
+func (r *irrigationScheduleRepository) List(ctx context.Context, macAddress string) ([]*entities.IrrigationSchedule, error) {
+	query := r.db.GetDB().WithContext(ctx)
+	if macAddress != "" {
+		query = query.Where("mac_address = ?", macAddress)
+	}
+
+	var records []models.IrrigationScheduleModel
+	result := query.Order("created_at ASC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list irrigation schedules: %w", result.Error)
+	}
+
+	schedules := make([]*entities.IrrigationSchedule, 0, len(records))
+	for i := range records {
+		schedules = append(schedules, r.mapper.FromModel(&records[i]))
+	}
+	return schedules, nil
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupIrrigationScheduleTestRepository initializes a test repository with a mock database
+func setupIrrigationScheduleTestRepository(t *testing.T) (*irrigationScheduleRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewIrrigationScheduleRepository(postgresDB, loggerFactory).(*irrigationScheduleRepository), sqlMock
+}
+
+func irrigationScheduleRows(lastRunAt, runEndsAt *time.Time) *sqlmock.Rows {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	return sqlmock.NewRows([]string{"id", "mac_address", "name", "cron", "duration_seconds", "enabled", "last_run_at", "run_ends_at", "created_at", "updated_at"}).
+		AddRow("schedule-1", "AA:BB:CC:DD:EE:FF", "Morning", "0 6 * * *", 1800, true, lastRunAt, runEndsAt, createdAt, createdAt)
+}
+
+func TestIrrigationScheduleRepository_Create(t *testing.T) {
+	repo, mock := setupIrrigationScheduleTestRepository(t)
+	schedule, err := entities.NewIrrigationSchedule("AA:BB:CC:DD:EE:FF", entities.IrrigationScheduleRequest{Name: "Morning", Cron: "0 6 * * *", Duration: 30 * time.Minute, Enabled: true})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`INSERT INTO "irrigation_schedules" .* RETURNING`).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(schedule.CreatedAt, schedule.UpdatedAt))
+
+	require.NoError(t, repo.Create(context.Background(), schedule))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIrrigationScheduleRepository_Update(t *testing.T) {
+	schedule := &entities.IrrigationSchedule{ID: "schedule-1", Name: "Evening", Cron: "0 18 * * *", Duration: time.Hour, UpdatedAt: time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)}
+
+	t.Run("should only update the requested fields", func(t *testing.T) {
+		repo, mock := setupIrrigationScheduleTestRepository(t)
+		mock.ExpectExec(`UPDATE "irrigation_schedules" SET "cron"=\$1,"duration_seconds"=\$2,"enabled"=\$3,"name"=\$4,"updated_at"=\$5 WHERE id = \$6`).
+			WithArgs("0 18 * * *", int64(3600), false, "Evening", schedule.UpdatedAt, "schedule-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.Update(context.Background(), schedule))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should report unknown schedules", func(t *testing.T) {
+		repo, mock := setupIrrigationScheduleTestRepository(t)
+		mock.ExpectExec(`UPDATE "irrigation_schedules"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, repo.Update(context.Background(), schedule), domainerrors.ErrIrrigationScheduleNotFound)
+	})
+}
+
+func TestIrrigationScheduleRepository_RecordRun(t *testing.T) {
+	repo, mock := setupIrrigationScheduleTestRepository(t)
+	lastRunAt := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`UPDATE "irrigation_schedules" SET "last_run_at"=\$1,"run_ends_at"=\$2 WHERE id = \$3`).
+		WithArgs(&lastRunAt, nil, "schedule-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.RecordRun(context.Background(), "schedule-1", &lastRunAt, nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIrrigationScheduleRepository_Delete(t *testing.T) {
+	repo, mock := setupIrrigationScheduleTestRepository(t)
+	mock.ExpectExec(`DELETE FROM "irrigation_schedules" WHERE id = \$1`).WithArgs("schedule-1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.Delete(context.Background(), "schedule-1"), domainerrors.ErrIrrigationScheduleNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIrrigationScheduleRepository_FindByID(t *testing.T) {
+	t.Run("should map the schedule", func(t *testing.T) {
+		repo, mock := setupIrrigationScheduleTestRepository(t)
+		lastRunAt := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+		runEndsAt := lastRunAt.Add(30 * time.Minute)
+		mock.ExpectQuery(`SELECT \* FROM "irrigation_schedules" WHERE id = \$1`).WillReturnRows(irrigationScheduleRows(&lastRunAt, &runEndsAt))
+
+		schedule, err := repo.FindByID(context.Background(), "schedule-1")
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Minute, schedule.Duration)
+		assert.Equal(t, &runEndsAt, schedule.RunEndsAt)
+		assert.True(t, schedule.Running())
+	})
+
+	t.Run("should report unknown schedules", func(t *testing.T) {
+		repo, mock := setupIrrigationScheduleTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "irrigation_schedules"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.FindByID(context.Background(), "schedule-1")
+		assert.ErrorIs(t, err, domainerrors.ErrIrrigationScheduleNotFound)
+	})
+}
+
+func TestIrrigationScheduleRepository_List(t *testing.T) {
+	t.Run("should list the schedules of a device", func(t *testing.T) {
+		repo, mock := setupIrrigationScheduleTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "irrigation_schedules" WHERE mac_address = \$1 ORDER BY created_at ASC`).
+			WithArgs("AA:BB:CC:DD:EE:FF").
+			WillReturnRows(irrigationScheduleRows(nil, nil))
+
+		schedules, err := repo.List(context.Background(), "AA:BB:CC:DD:EE:FF")
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		assert.Equal(t, "Morning", schedules[0].Name)
+		assert.False(t, schedules[0].Running())
+	})
+
+	t.Run("should list every schedule", func(t *testing.T) {
+		repo, mock := setupIrrigationScheduleTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "irrigation_schedules" ORDER BY created_at ASC`).WillReturnRows(irrigationScheduleRows(nil, nil))
+
+		schedules, err := repo.List(context.Background(), "")
+		require.NoError(t, err)
+		assert.Len(t, schedules, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// jobRepository implements the JobRepository interface using GORM PostgreSQL
+type jobRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.JobMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewJobRepository creates a new GORM-based PostgreSQL job repository
+func NewJobRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.JobRepository {
+	return &jobRepository{
+		db:     db,
+		mapper: mappers.NewJobMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new queued job
+func (r *jobRepository) Create(ctx context.Context, job *entities.Job) error {
+	if job == nil {
+		return fmt.Errorf("job cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Create(r.mapper.ToModel(job))
+	if result.Error != nil {
+		r.logger.Error("job_create_failed", zap.String("operation", "create"), zap.String("table", "jobs"), zap.String("job_type", job.Type), zap.Error(result.Error))
+		return fmt.Errorf("failed to create job: %w", result.Error)
+	}
+	return nil
+}
+
+// FindByID retrieves a job by its ID
+func (r *jobRepository) FindByID(ctx context.Context, id string) (*entities.Job, error) {
+	var model models.JobModel
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to find job: %w", result.Error)
+	}
+	return r.mapper.FromModel(&model), nil
+}
+
+// ListUnfinished returns the queued and running jobs, earliest run time first
+func (r *jobRepository) ListUnfinished(ctx context.Context, limit int) ([]*entities.Job, error) {
+	var records []models.JobModel
+	result := r.db.GetDB().WithContext(ctx).
+		Where("status IN ?", []string{entities.JobStatusQueued, entities.JobStatusRunning}).
+		Order("run_at ASC").
+		Limit(limit).
+		Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list unfinished jobs: %w", result.Error)
+	}
+
+	jobs := make([]*entities.Job, 0, len(records))
+	for i := range records {
+		jobs = append(jobs, r.mapper.FromModel(&records[i]))
+	}
+	return jobs, nil
+}
+
+// ClaimNext atomically leases the next runnable job; concurrent workers skip rows locked by each other
+func (r *jobRepository) ClaimNext(ctx context.Context, types []string, leaseUntil time.Time) (*entities.Job, error) {
+	if len(types) == 0 {
+		return nil, nil
+	}
+
+	var claimed *entities.Job
+	err := r.db.Transaction(ctx, func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+
+		var model models.JobModel
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("type IN ?", types).
+			Where("(status = ? AND run_at <= ?) OR (status = ? AND lease_until < ?)", entities.JobStatusQueued, now, entities.JobStatusRunning, now).
+			Order("run_at ASC").
+			Limit(1).
+			Find(&model)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		model.Status = entities.JobStatusRunning
+		model.Attempts++
+		model.LeaseUntil = &leaseUntil
+		model.StartedAt = &now
+		model.UpdatedAt = now
+		if err := tx.Model(&models.JobModel{}).Where("id = ?", model.ID).Updates(map[string]interface{}{
+			"status":      model.Status,
+			"attempts":    model.Attempts,
+			"lease_until": leaseUntil,
+			"started_at":  now,
+			"updated_at":  now,
+		}).Error; err != nil {
+			return err
+		}
+
+		claimed = r.mapper.FromModel(&model)
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("job_claim_failed", zap.String("table", "jobs"), zap.Strings("job_types", types), zap.Error(err))
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return claimed, nil
+}
+
+// Heartbeat extends the lease of a running job and reports whether cancellation was requested
+func (r *jobRepository) Heartbeat(ctx context.Context, id string, leaseUntil time.Time) (bool, error) {
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.JobModel{}).
+		Where("id = ? AND status = ?", id, entities.JobStatusRunning).
+		Updates(map[string]interface{}{"lease_until": leaseUntil, "updated_at": time.Now().UTC()})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to extend job lease: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, domainerrors.ErrJobNotFound
+	}
+
+	var model models.JobModel
+	if err := r.db.GetDB().WithContext(ctx).Select("cancel_requested").Where("id = ?", id).First(&model).Error; err != nil {
+		return false, fmt.Errorf("failed to read job cancellation: %w", err)
+	}
+	return model.CancelRequested, nil
+}
+
+// UpdateProgress records the progress of a running job
+func (r *jobRepository) UpdateProgress(ctx context.Context, id string, progress int, message string) error {
+	if len(message) > 255 {
+		message = message[:255]
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.JobModel{}).
+		Where("id = ? AND status = ?", id, entities.JobStatusRunning).
+		Updates(map[string]interface{}{"progress": progress, "progress_message": message, "updated_at": time.Now().UTC()})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job progress: %w", result.Error)
+	}
+	return nil
+}
+
+// Finish stores the outcome of an attempt
+func (r *jobRepository) Finish(ctx context.Context, job *entities.Job) error {
+	model := r.mapper.ToModel(job)
+	if len(model.LastError) > 1000 {
+		model.LastError = model.LastError[:1000]
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.JobModel{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]interface{}{
+			"status":           model.Status,
+			"attempts":         model.Attempts,
+			"progress":         model.Progress,
+			"progress_message": model.ProgressMessage,
+			"result":           model.Result,
+			"last_error":       model.LastError,
+			"run_at":           model.RunAt,
+			"lease_until":      nil,
+			"finished_at":      model.FinishedAt,
+			"updated_at":       time.Now().UTC(),
+		})
+	if result.Error != nil {
+		r.logger.Error("job_finish_failed", zap.String("table", "jobs"), zap.String("job_id", job.ID), zap.String("status", job.Status), zap.Error(result.Error))
+		return fmt.Errorf("failed to finish job: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrJobNotFound
+	}
+	return nil
+}
+
+// RequestCancel flags a job for cancellation; queued jobs are cancelled immediately
+func (r *jobRepository) RequestCancel(ctx context.Context, id string) (*entities.Job, error) {
+	var job *entities.Job
+	err := r.db.Transaction(ctx, func(tx *gorm.DB) error {
+		var model models.JobModel
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&model)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return domainerrors.ErrJobNotFound
+			}
+			return result.Error
+		}
+
+		job = r.mapper.FromModel(&model)
+		if job.IsFinished() {
+			return domainerrors.ErrJobAlreadyFinished
+		}
+
+		now := time.Now().UTC()
+		updates := map[string]interface{}{"cancel_requested": true, "updated_at": now}
+		job.CancelRequested = true
+		if job.Status == entities.JobStatusQueued {
+			updates["status"] = entities.JobStatusCancelled
+			updates["finished_at"] = now
+			job.Status = entities.JobStatusCancelled
+			job.FinishedAt = &now
+		}
+		return tx.Model(&models.JobModel{}).Where("id = ?", id).Updates(updates).Error
+	})
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrJobNotFound) || errors.Is(err, domainerrors.ErrJobAlreadyFinished) {
+			return job, err
+		}
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	return job, nil
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupJobTestRepository initializes a test repository with a mock database
+func setupJobTestRepository(t *testing.T) (*jobRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewJobRepository(postgresDB, loggerFactory).(*jobRepository), sqlMock
+}
+
+func jobRows(id, status string) *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows([]string{"id", "type", "payload", "status", "attempts", "max_attempts", "run_at", "created_at", "updated_at"}).
+		AddRow(id, "export", "{}", status, 0, 3, now, now, now)
+}
+
+func TestJobRepository_Create(t *testing.T) {
+	repo, mock := setupJobTestRepository(t)
+	job, err := entities.NewJob("export", map[string]string{"format": "csv"}, 3)
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`INSERT INTO "jobs" .* RETURNING`).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+
+	require.NoError(t, repo.Create(context.Background(), job))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestJobRepository_FindByID(t *testing.T) {
+	t.Run("should map stored job", func(t *testing.T) {
+		repo, mock := setupJobTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "jobs" WHERE id = \$1`).WillReturnRows(jobRows("job-1", entities.JobStatusRunning))
+
+		job, err := repo.FindByID(context.Background(), "job-1")
+		require.NoError(t, err)
+		assert.Equal(t, "job-1", job.ID)
+		assert.Equal(t, entities.JobStatusRunning, job.Status)
+	})
+
+	t.Run("should return not found", func(t *testing.T) {
+		repo, mock := setupJobTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "jobs"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.FindByID(context.Background(), "missing")
+		assert.ErrorIs(t, err, domainerrors.ErrJobNotFound)
+	})
+}
+
+func TestJobRepository_ListUnfinished(t *testing.T) {
+	repo, mock := setupJobTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "jobs" WHERE status IN \(\$1,\$2\) ORDER BY run_at ASC LIMIT \$3`).
+		WithArgs(entities.JobStatusQueued, entities.JobStatusRunning, 20).
+		WillReturnRows(jobRows("job-1", entities.JobStatusQueued))
+
+	jobs, err := repo.ListUnfinished(context.Background(), 20)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, entities.JobStatusQueued, jobs[0].Status)
+}
+
+func TestJobRepository_RequestCancel(t *testing.T) {
+	t.Run("should cancel queued job immediately", func(t *testing.T) {
+		repo, mock := setupJobTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "jobs" WHERE id = \$1 .* FOR UPDATE`).WillReturnRows(jobRows("job-1", entities.JobStatusQueued))
+		mock.ExpectExec(`UPDATE "jobs" SET`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		job, err := repo.RequestCancel(context.Background(), "job-1")
+		require.NoError(t, err)
+		assert.Equal(t, entities.JobStatusCancelled, job.Status)
+		assert.True(t, job.CancelRequested)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should reject finished job", func(t *testing.T) {
+		repo, mock := setupJobTestRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "jobs"`).WillReturnRows(jobRows("job-1", entities.JobStatusSucceeded))
+		mock.ExpectRollback()
+
+		_, err := repo.RequestCancel(context.Background(), "job-1")
+		assert.ErrorIs(t, err, domainerrors.ErrJobAlreadyFinished)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,44 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// BlacklistEntryMapper provides mapping functions between blacklist entries and the GORM model
+type BlacklistEntryMapper struct{}
+
+// NewBlacklistEntryMapper creates a new blacklist entry mapper
+func NewBlacklistEntryMapper() *BlacklistEntryMapper {
+	return &BlacklistEntryMapper{}
+}
+
+// ToModel converts a blacklist entry to a GORM model
+func (m *BlacklistEntryMapper) ToModel(entry *entities.BlacklistEntry) *models.BlacklistEntryModel {
+	if entry == nil {
+		return nil
+	}
+
+	return &models.BlacklistEntryModel{
+		ID:        entry.ID,
+		Kind:      entry.Kind,
+		Value:     entry.Value,
+		Reason:    entry.Reason,
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+// FromModel converts a GORM model to a blacklist entry
+func (m *BlacklistEntryMapper) FromModel(model *models.BlacklistEntryModel) *entities.BlacklistEntry {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.BlacklistEntry{
+		ID:        model.ID,
+		Kind:      model.Kind,
+		Value:     model.Value,
+		Reason:    model.Reason,
+		CreatedAt: model.CreatedAt,
+	}
+}
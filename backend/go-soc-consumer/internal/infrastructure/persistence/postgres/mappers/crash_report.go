@@ -0,0 +1,46 @@
+package mappers
+
+import (
+	"strings"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// CrashReportMapper provides mapping functions between crash reports and the GORM models
+type CrashReportMapper struct{}
+
+// NewCrashReportMapper creates a new crash report mapper
+func NewCrashReportMapper() *CrashReportMapper {
+	return &CrashReportMapper{}
+}
+
+// ToModels converts a crash report to the GORM models of its signature and of the report itself
+func (m *CrashReportMapper) ToModels(report *entities.CrashReport) (*models.CrashSignatureModel, *models.CrashReportModel) {
+	if report == nil {
+		return nil, nil
+	}
+
+	signature := &models.CrashSignatureModel{
+		Signature:       report.Signature,
+		FirmwareVersion: report.FirmwareVersion,
+		Reason:          report.Reason,
+		Backtrace:       strings.Join(report.Backtrace, " "),
+		FirstSeen:       report.ReportedAt,
+		LastSeen:        report.ReportedAt,
+	}
+	record := &models.CrashReportModel{
+		ID:              report.ID,
+		Signature:       report.Signature,
+		MACAddress:      report.MACAddress,
+		FirmwareVersion: report.FirmwareVersion,
+		UptimeSeconds:   report.UptimeSeconds,
+		ReportedAt:      report.ReportedAt,
+	}
+	return signature, record
+}
+
+// SplitBacktrace converts a stored backtrace back to its frames
+func (m *CrashReportMapper) SplitBacktrace(backtrace string) []string {
+	return strings.Fields(backtrace)
+}
@@ -22,17 +22,31 @@ func (m *DeviceMapper) ToModel(device *entities.Device) *models.DeviceModel {
 	}
 
 	now := time.Now()
-	return &models.DeviceModel{
-		MACAddress:          device.MACAddress,
-		DeviceName:          device.DeviceName,
-		IPAddress:           device.IPAddress,
-		LocationDescription: device.LocationDescription,
-		RegisteredAt:        device.RegisteredAt,
-		LastSeen:            device.LastSeen,
-		Status:              device.Status,
-		CreatedAt:           now, // Will be overridden by GORM if already set
-		UpdatedAt:           now, // Will be overridden by GORM if already set
+	model := &models.DeviceModel{
+		MACAddress:             device.MACAddress,
+		DeviceName:             device.DeviceName,
+		IPAddress:              device.IPAddress,
+		LocationDescription:    device.LocationDescription,
+		RegisteredAt:           device.RegisteredAt,
+		LastSeen:               device.LastSeen,
+		Status:                 device.Status,
+		CertificateFingerprint: device.CertificateFingerprint,
+		CredentialHash:         device.CredentialHash,
+		FarmID:                 device.FarmID,
+		CalibratedAt:           device.CalibratedAt,
+		FirmwareVersion:        device.FirmwareVersion,
+		HardwareVersion:        device.HardwareVersion,
+		CreatedAt:              now, // Will be overridden by GORM if already set
+		UpdatedAt:              now, // Will be overridden by GORM if already set
 	}
+	if registration := device.LastRegistration; registration != nil {
+		topic := registration.Topic
+		model.RegistrationTopic = &topic
+		model.RegistrationQoS = int16(registration.QoS)
+		model.RegistrationRetained = registration.Retained
+		model.RegistrationClientID = registration.ClientID
+	}
+	return model
 }
 
 // FromModel converts a GORM model to a domain entity
@@ -50,6 +64,20 @@ func (m *DeviceMapper) FromModel(model *models.DeviceModel) *entities.Device {
 	device.RegisteredAt = model.RegisteredAt
 	device.LastSeen = model.LastSeen
 	device.Status = model.Status
+	device.CertificateFingerprint = model.CertificateFingerprint
+	device.CredentialHash = model.CredentialHash
+	device.FarmID = model.FarmID
+	device.CalibratedAt = model.CalibratedAt
+	device.FirmwareVersion = model.FirmwareVersion
+	device.HardwareVersion = model.HardwareVersion
+	if model.RegistrationTopic != nil {
+		device.LastRegistration = &entities.BrokerMetadata{
+			Topic:    *model.RegistrationTopic,
+			QoS:      byte(model.RegistrationQoS),
+			Retained: model.RegistrationRetained,
+			ClientID: model.RegistrationClientID,
+		}
+	}
 
 	return device
 }
@@ -0,0 +1,75 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// DeviceArchiveMapper provides mapping functions between device archives and the GORM models
+type DeviceArchiveMapper struct{}
+
+// NewDeviceArchiveMapper creates a new device archive mapper
+func NewDeviceArchiveMapper() *DeviceArchiveMapper {
+	return &DeviceArchiveMapper{}
+}
+
+// ToModel converts a device archive to a GORM model
+func (m *DeviceArchiveMapper) ToModel(archive *entities.DeviceArchive) *models.DeviceArchiveModel {
+	if archive == nil {
+		return nil
+	}
+
+	return &models.DeviceArchiveModel{
+		ID:           archive.ID,
+		MACAddress:   archive.MACAddress,
+		DeviceName:   archive.DeviceName,
+		FarmID:       archive.FarmID,
+		Reason:       archive.Reason,
+		Actor:        archive.Actor,
+		Status:       archive.Status,
+		Storage:      archive.Storage,
+		ObjectKey:    archive.ObjectKey,
+		Measurements: archive.Measurements,
+		Readings:     archive.Readings,
+		ArchivedAt:   archive.ArchivedAt,
+		RestoredAt:   archive.RestoredAt,
+		RestoredBy:   archive.RestoredBy,
+	}
+}
+
+// FromModel converts a GORM model to a device archive
+func (m *DeviceArchiveMapper) FromModel(model *models.DeviceArchiveModel) *entities.DeviceArchive {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.DeviceArchive{
+		ID:           model.ID,
+		MACAddress:   model.MACAddress,
+		DeviceName:   model.DeviceName,
+		FarmID:       model.FarmID,
+		Reason:       model.Reason,
+		Actor:        model.Actor,
+		Status:       model.Status,
+		Storage:      model.Storage,
+		ObjectKey:    model.ObjectKey,
+		Measurements: model.Measurements,
+		Readings:     model.Readings,
+		ArchivedAt:   model.ArchivedAt,
+		RestoredAt:   model.RestoredAt,
+		RestoredBy:   model.RestoredBy,
+	}
+}
+
+// MeasurementFromModel converts an archived measurement to a measurement
+func (m *DeviceArchiveMapper) MeasurementFromModel(model *models.ArchivedDeviceMeasurementModel) *entities.Measurement {
+	return &entities.Measurement{
+		MACAddress: model.MACAddress,
+		SensorType: model.SensorType,
+		Channel:    model.Channel,
+		Value:      model.Value,
+		Unit:       model.Unit,
+		Quality:    model.Quality,
+		Timestamp:  model.CreatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package mappers
+
+import (
+	"encoding/json"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// DeviceCommandMapper provides mapping functions between device commands and the GORM model
+type DeviceCommandMapper struct{}
+
+// NewDeviceCommandMapper creates a new device command mapper
+func NewDeviceCommandMapper() *DeviceCommandMapper {
+	return &DeviceCommandMapper{}
+}
+
+// ToModel converts a device command to a GORM model
+func (m *DeviceCommandMapper) ToModel(command *entities.DeviceCommand) *models.DeviceCommandModel {
+	if command == nil {
+		return nil
+	}
+
+	payload := string(command.Payload)
+	if payload == "" {
+		payload = "{}"
+	}
+	return &models.DeviceCommandModel{
+		ID:         command.ID,
+		MACAddress: command.MACAddress,
+		Command:    command.Command,
+		Payload:    payload,
+		Status:     command.Status,
+		Message:    command.Message,
+		IssuedAt:   command.IssuedAt,
+		ExpiresAt:  command.ExpiresAt,
+		AckedAt:    command.AckedAt,
+		Attempts:   command.Attempts,
+	}
+}
+
+// FromModel converts a GORM model to a device command
+func (m *DeviceCommandMapper) FromModel(model *models.DeviceCommandModel) *entities.DeviceCommand {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.DeviceCommand{
+		ID:         model.ID,
+		MACAddress: model.MACAddress,
+		Command:    model.Command,
+		Payload:    json.RawMessage(model.Payload),
+		Status:     model.Status,
+		Message:    model.Message,
+		IssuedAt:   model.IssuedAt,
+		ExpiresAt:  model.ExpiresAt,
+		AckedAt:    model.AckedAt,
+		Attempts:   model.Attempts,
+	}
+}
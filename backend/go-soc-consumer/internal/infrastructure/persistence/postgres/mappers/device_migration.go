@@ -0,0 +1,82 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// DeviceMigrationMapper provides mapping functions between device migrations and the GORM models
+type DeviceMigrationMapper struct{}
+
+// NewDeviceMigrationMapper creates a new device migration mapper
+func NewDeviceMigrationMapper() *DeviceMigrationMapper {
+	return &DeviceMigrationMapper{}
+}
+
+// ToModel converts a device migration to a GORM model
+func (m *DeviceMigrationMapper) ToModel(migration *entities.DeviceMigration) *models.DeviceMigrationModel {
+	if migration == nil {
+		return nil
+	}
+
+	return &models.DeviceMigrationModel{
+		ID:         migration.ID,
+		BrokerURL:  migration.BrokerURL,
+		Layout:     migration.Layout,
+		FarmID:     migration.FarmID,
+		Actor:      migration.Actor,
+		Status:     migration.Status,
+		JobID:      migration.JobID,
+		Devices:    migration.Devices,
+		StartedAt:  migration.StartedAt,
+		FinishedAt: migration.FinishedAt,
+	}
+}
+
+// FromModel converts a GORM model to a device migration
+func (m *DeviceMigrationMapper) FromModel(model *models.DeviceMigrationModel) *entities.DeviceMigration {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.DeviceMigration{
+		ID:         model.ID,
+		BrokerURL:  model.BrokerURL,
+		Layout:     model.Layout,
+		FarmID:     model.FarmID,
+		Actor:      model.Actor,
+		Status:     model.Status,
+		JobID:      model.JobID,
+		Devices:    model.Devices,
+		StartedAt:  model.StartedAt,
+		FinishedAt: model.FinishedAt,
+	}
+}
+
+// TargetToModel converts a migration target to a GORM model
+func (m *DeviceMigrationMapper) TargetToModel(target *entities.DeviceMigrationTarget) *models.DeviceMigrationTargetModel {
+	return &models.DeviceMigrationTargetModel{
+		MigrationID:   target.MigrationID,
+		MACAddress:    target.MACAddress,
+		FarmID:        target.FarmID,
+		Status:        target.Status,
+		CommandID:     target.CommandID,
+		Error:         target.Error,
+		SentAt:        target.SentAt,
+		ReconnectedAt: target.ReconnectedAt,
+	}
+}
+
+// TargetFromModel converts a GORM model to a migration target
+func (m *DeviceMigrationMapper) TargetFromModel(model *models.DeviceMigrationTargetModel) *entities.DeviceMigrationTarget {
+	return &entities.DeviceMigrationTarget{
+		MigrationID:   model.MigrationID,
+		MACAddress:    model.MACAddress,
+		FarmID:        model.FarmID,
+		Status:        model.Status,
+		CommandID:     model.CommandID,
+		Error:         model.Error,
+		SentAt:        model.SentAt,
+		ReconnectedAt: model.ReconnectedAt,
+	}
+}
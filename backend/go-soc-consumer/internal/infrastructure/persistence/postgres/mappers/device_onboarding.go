@@ -0,0 +1,60 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// DeviceOnboardingMapper provides mapping functions between device onboardings and the GORM model
+type DeviceOnboardingMapper struct{}
+
+// NewDeviceOnboardingMapper creates a new device onboarding mapper
+func NewDeviceOnboardingMapper() *DeviceOnboardingMapper {
+	return &DeviceOnboardingMapper{}
+}
+
+// ToModel converts a device onboarding to a GORM model
+func (m *DeviceOnboardingMapper) ToModel(onboarding *entities.DeviceOnboarding) *models.DeviceOnboardingModel {
+	if onboarding == nil {
+		return nil
+	}
+
+	return &models.DeviceOnboardingModel{
+		ID:                     onboarding.ID,
+		MACAddress:             onboarding.MACAddress,
+		DeviceName:             onboarding.DeviceName,
+		LocationDescription:    onboarding.LocationDescription,
+		FarmID:                 onboarding.FarmID,
+		TokenHash:              onboarding.TokenHash,
+		TokenExpiresAt:         onboarding.TokenExpiresAt,
+		CredentialsDeliveredAt: onboarding.CredentialsDeliveredAt,
+		FirstConnectedAt:       onboarding.FirstConnectedAt,
+		FirstTelemetryAt:       onboarding.FirstTelemetryAt,
+		AssignedAt:             onboarding.AssignedAt,
+		CreatedAt:              onboarding.CreatedAt,
+		UpdatedAt:              onboarding.UpdatedAt,
+	}
+}
+
+// FromModel converts a GORM model to a device onboarding
+func (m *DeviceOnboardingMapper) FromModel(model *models.DeviceOnboardingModel) *entities.DeviceOnboarding {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.DeviceOnboarding{
+		ID:                     model.ID,
+		MACAddress:             model.MACAddress,
+		DeviceName:             model.DeviceName,
+		LocationDescription:    model.LocationDescription,
+		FarmID:                 model.FarmID,
+		TokenHash:              model.TokenHash,
+		TokenExpiresAt:         model.TokenExpiresAt,
+		CredentialsDeliveredAt: model.CredentialsDeliveredAt,
+		FirstConnectedAt:       model.FirstConnectedAt,
+		FirstTelemetryAt:       model.FirstTelemetryAt,
+		AssignedAt:             model.AssignedAt,
+		CreatedAt:              model.CreatedAt,
+		UpdatedAt:              model.UpdatedAt,
+	}
+}
@@ -0,0 +1,42 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// DeviceShadowMapper provides mapping functions between device shadows and the GORM model
+type DeviceShadowMapper struct{}
+
+// NewDeviceShadowMapper creates a new device shadow mapper
+func NewDeviceShadowMapper() *DeviceShadowMapper {
+	return &DeviceShadowMapper{}
+}
+
+// ToModel converts a device shadow to a GORM model
+func (m *DeviceShadowMapper) ToModel(shadow *entities.DeviceShadow) *models.DeviceShadowModel {
+	if shadow == nil {
+		return nil
+	}
+
+	return &models.DeviceShadowModel{
+		MACAddress:          shadow.MACAddress,
+		DeviceName:          shadow.DeviceName,
+		LocationDescription: shadow.LocationDescription,
+		DeclaredAt:          shadow.DeclaredAt,
+	}
+}
+
+// FromModel converts a GORM model to a device shadow
+func (m *DeviceShadowMapper) FromModel(model *models.DeviceShadowModel) *entities.DeviceShadow {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.DeviceShadow{
+		MACAddress:          model.MACAddress,
+		DeviceName:          model.DeviceName,
+		LocationDescription: model.LocationDescription,
+		DeclaredAt:          model.DeclaredAt,
+	}
+}
@@ -123,3 +123,17 @@ func TestDeviceMapper_FromModel(t *testing.T) {
 		})
 	}
 }
+
+func TestDeviceMapper_LastRegistration(t *testing.T) {
+	mapper := NewDeviceMapper()
+	device := &entities.Device{MACAddress: "00:11:22:33:44:55", Status: "online"}
+
+	model := mapper.ToModel(device)
+	assert.Nil(t, model.RegistrationTopic, "no registration recorded")
+	assert.Nil(t, mapper.FromModel(model).LastRegistration)
+
+	device.LastRegistration = &entities.BrokerMetadata{Topic: "farms/farm-7/devices/registration", QoS: 1, Retained: true, ClientID: "probe-7"}
+	model = mapper.ToModel(device)
+	assert.Equal(t, int16(1), model.RegistrationQoS)
+	assert.Equal(t, device.LastRegistration, mapper.FromModel(model).LastRegistration)
+}
@@ -0,0 +1,84 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// DeviceTransferMapper provides mapping functions between device transfers and the GORM models
+type DeviceTransferMapper struct{}
+
+// NewDeviceTransferMapper creates a new device transfer mapper
+func NewDeviceTransferMapper() *DeviceTransferMapper {
+	return &DeviceTransferMapper{}
+}
+
+// ToModel converts a device transfer to a GORM model
+func (m *DeviceTransferMapper) ToModel(transfer *entities.DeviceTransfer) *models.DeviceTransferModel {
+	if transfer == nil {
+		return nil
+	}
+
+	return &models.DeviceTransferModel{
+		ID:               transfer.ID,
+		MACAddress:       transfer.MACAddress,
+		FromFarmID:       transfer.FromFarmID,
+		ToFarmID:         transfer.ToFarmID,
+		ToZoneID:         transfer.ToZoneID,
+		HistoryPolicy:    transfer.HistoryPolicy,
+		Status:           transfer.Status,
+		Note:             transfer.Note,
+		ArchivedReadings: transfer.ArchivedReadings,
+		AcceptedAt:       transfer.AcceptedAt,
+		CompletedAt:      transfer.CompletedAt,
+		CancelledAt:      transfer.CancelledAt,
+		CreatedAt:        transfer.CreatedAt,
+		UpdatedAt:        transfer.UpdatedAt,
+	}
+}
+
+// FromModel converts a GORM model to a device transfer
+func (m *DeviceTransferMapper) FromModel(model *models.DeviceTransferModel) *entities.DeviceTransfer {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.DeviceTransfer{
+		ID:               model.ID,
+		MACAddress:       model.MACAddress,
+		FromFarmID:       model.FromFarmID,
+		ToFarmID:         model.ToFarmID,
+		ToZoneID:         model.ToZoneID,
+		HistoryPolicy:    model.HistoryPolicy,
+		Status:           model.Status,
+		Note:             model.Note,
+		ArchivedReadings: model.ArchivedReadings,
+		AcceptedAt:       model.AcceptedAt,
+		CompletedAt:      model.CompletedAt,
+		CancelledAt:      model.CancelledAt,
+		CreatedAt:        model.CreatedAt,
+		UpdatedAt:        model.UpdatedAt,
+	}
+}
+
+// AuditToModel converts an audit entry to a GORM model
+func (m *DeviceTransferMapper) AuditToModel(entry entities.DeviceTransferAuditEntry) *models.DeviceTransferAuditModel {
+	return &models.DeviceTransferAuditModel{
+		TransferID: entry.TransferID,
+		Action:     entry.Action,
+		Actor:      entry.Actor,
+		Note:       entry.Note,
+		At:         entry.At,
+	}
+}
+
+// AuditFromModel converts a GORM model to an audit entry
+func (m *DeviceTransferMapper) AuditFromModel(model *models.DeviceTransferAuditModel) entities.DeviceTransferAuditEntry {
+	return entities.DeviceTransferAuditEntry{
+		TransferID: model.TransferID,
+		Action:     model.Action,
+		Actor:      model.Actor,
+		Note:       model.Note,
+		At:         model.At,
+	}
+}
@@ -0,0 +1,76 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// FarmMapper provides mapping functions between farms, their fields and zones and the GORM models
+type FarmMapper struct{}
+
+// NewFarmMapper creates a new farm mapper
+func NewFarmMapper() *FarmMapper {
+	return &FarmMapper{}
+}
+
+// ToModel converts a farm to a GORM model
+func (m *FarmMapper) ToModel(farm *entities.Farm) *models.FarmModel {
+	if farm == nil {
+		return nil
+	}
+
+	return &models.FarmModel{
+		ID:        farm.ID,
+		Name:      farm.Name,
+		CreatedAt: farm.CreatedAt,
+		UpdatedAt: farm.UpdatedAt,
+	}
+}
+
+// FromModel converts a GORM model to a farm
+func (m *FarmMapper) FromModel(model *models.FarmModel) *entities.Farm {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.Farm{
+		ID:        model.ID,
+		Name:      model.Name,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+// ZoneToModel converts a field or zone to a GORM model
+func (m *FarmMapper) ZoneToModel(zone *entities.Zone) *models.ZoneModel {
+	if zone == nil {
+		return nil
+	}
+
+	return &models.ZoneModel{
+		ID:        zone.ID,
+		FarmID:    zone.FarmID,
+		ParentID:  zone.ParentID,
+		Kind:      zone.Kind,
+		Name:      zone.Name,
+		CreatedAt: zone.CreatedAt,
+		UpdatedAt: zone.UpdatedAt,
+	}
+}
+
+// ZoneFromModel converts a GORM model to a field or zone
+func (m *FarmMapper) ZoneFromModel(model *models.ZoneModel) *entities.Zone {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.Zone{
+		ID:        model.ID,
+		FarmID:    model.FarmID,
+		ParentID:  model.ParentID,
+		Kind:      model.Kind,
+		Name:      model.Name,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// InboxNotificationMapper provides mapping functions between inbox notifications and the GORM model
+type InboxNotificationMapper struct{}
+
+// NewInboxNotificationMapper creates a new inbox notification mapper
+func NewInboxNotificationMapper() *InboxNotificationMapper {
+	return &InboxNotificationMapper{}
+}
+
+// ToModel converts an inbox notification to a GORM model
+func (m *InboxNotificationMapper) ToModel(notification *entities.InboxNotification) *models.InboxNotificationModel {
+	if notification == nil {
+		return nil
+	}
+
+	return &models.InboxNotificationModel{
+		ID:        notification.ID,
+		User:      notification.User,
+		AlertID:   notification.AlertID,
+		Rule:      notification.Rule,
+		Severity:  notification.Severity,
+		Status:    notification.Status,
+		Subject:   notification.Subject,
+		Body:      notification.Body,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+// FromModel converts a GORM model to an inbox notification
+func (m *InboxNotificationMapper) FromModel(model *models.InboxNotificationModel) *entities.InboxNotification {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.InboxNotification{
+		ID:        model.ID,
+		User:      model.User,
+		AlertID:   model.AlertID,
+		Rule:      model.Rule,
+		Severity:  model.Severity,
+		Status:    model.Status,
+		Subject:   model.Subject,
+		Body:      model.Body,
+		ReadAt:    model.ReadAt,
+		CreatedAt: model.CreatedAt,
+	}
+}
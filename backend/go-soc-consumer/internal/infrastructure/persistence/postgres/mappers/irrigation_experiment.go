@@ -0,0 +1,92 @@
+package mappers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// experimentVariantColumn is the JSON encoding of a variant in the variants column
+type experimentVariantColumn struct {
+	Name     string   `json:"name"`
+	Strategy string   `json:"strategy"`
+	Zones    []string `json:"zones"`
+}
+
+// IrrigationExperimentMapper provides mapping functions between irrigation experiments and the GORM model
+type IrrigationExperimentMapper struct{}
+
+// NewIrrigationExperimentMapper creates a new irrigation experiment mapper
+func NewIrrigationExperimentMapper() *IrrigationExperimentMapper {
+	return &IrrigationExperimentMapper{}
+}
+
+// ToModel converts an irrigation experiment to a GORM model
+func (m *IrrigationExperimentMapper) ToModel(experiment *entities.IrrigationExperiment) (*models.IrrigationExperimentModel, error) {
+	if experiment == nil {
+		return nil, nil
+	}
+
+	metadata := experiment.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	encodedMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode experiment metadata: %w", err)
+	}
+	variants := make([]experimentVariantColumn, 0, len(experiment.Variants))
+	for _, variant := range experiment.Variants {
+		variants = append(variants, experimentVariantColumn(variant))
+	}
+	encodedVariants, err := json.Marshal(variants)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode experiment variants: %w", err)
+	}
+
+	return &models.IrrigationExperimentModel{
+		ID:         experiment.ID,
+		Name:       experiment.Name,
+		Hypothesis: experiment.Hypothesis,
+		Metadata:   string(encodedMetadata),
+		Variants:   string(encodedVariants),
+		StartedAt:  experiment.StartedAt,
+		EndedAt:    experiment.EndedAt,
+		CreatedAt:  experiment.CreatedAt,
+		UpdatedAt:  experiment.UpdatedAt,
+	}, nil
+}
+
+// FromModel converts a GORM model to an irrigation experiment
+func (m *IrrigationExperimentMapper) FromModel(model *models.IrrigationExperimentModel) (*entities.IrrigationExperiment, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	metadata := map[string]string{}
+	if err := json.Unmarshal([]byte(model.Metadata), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata of experiment %s: %w", model.ID, err)
+	}
+	var variants []experimentVariantColumn
+	if err := json.Unmarshal([]byte(model.Variants), &variants); err != nil {
+		return nil, fmt.Errorf("failed to decode variants of experiment %s: %w", model.ID, err)
+	}
+
+	experiment := &entities.IrrigationExperiment{
+		ID:         model.ID,
+		Name:       model.Name,
+		Hypothesis: model.Hypothesis,
+		Metadata:   metadata,
+		Variants:   make([]entities.ExperimentVariant, 0, len(variants)),
+		StartedAt:  model.StartedAt,
+		EndedAt:    model.EndedAt,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+	for _, variant := range variants {
+		experiment.Variants = append(experiment.Variants, entities.ExperimentVariant(variant))
+	}
+	return experiment, nil
+}
@@ -0,0 +1,42 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// IrrigationOverrideMapper provides mapping functions between irrigation overrides and the GORM model
+type IrrigationOverrideMapper struct{}
+
+// NewIrrigationOverrideMapper creates a new irrigation override mapper
+func NewIrrigationOverrideMapper() *IrrigationOverrideMapper {
+	return &IrrigationOverrideMapper{}
+}
+
+// ToModel converts an irrigation override to a GORM model
+func (m *IrrigationOverrideMapper) ToModel(override *entities.IrrigationOverride) *models.IrrigationOverrideModel {
+	if override == nil {
+		return nil
+	}
+
+	return &models.IrrigationOverrideModel{
+		MACAddress: override.MACAddress,
+		Reason:     override.Reason,
+		Until:      override.Until,
+		CreatedAt:  override.CreatedAt,
+	}
+}
+
+// FromModel converts a GORM model to an irrigation override
+func (m *IrrigationOverrideMapper) FromModel(model *models.IrrigationOverrideModel) *entities.IrrigationOverride {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.IrrigationOverride{
+		MACAddress: model.MACAddress,
+		Reason:     model.Reason,
+		Until:      model.Until,
+		CreatedAt:  model.CreatedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// IrrigationScheduleMapper provides mapping functions between irrigation schedules and the GORM model
+type IrrigationScheduleMapper struct{}
+
+// NewIrrigationScheduleMapper creates a new irrigation schedule mapper
+func NewIrrigationScheduleMapper() *IrrigationScheduleMapper {
+	return &IrrigationScheduleMapper{}
+}
+
+// ToModel converts an irrigation schedule to a GORM model
+func (m *IrrigationScheduleMapper) ToModel(schedule *entities.IrrigationSchedule) *models.IrrigationScheduleModel {
+	if schedule == nil {
+		return nil
+	}
+
+	return &models.IrrigationScheduleModel{
+		ID:              schedule.ID,
+		MACAddress:      schedule.MACAddress,
+		Name:            schedule.Name,
+		Cron:            schedule.Cron,
+		DurationSeconds: int64(schedule.Duration / time.Second),
+		Enabled:         schedule.Enabled,
+		LastRunAt:       schedule.LastRunAt,
+		RunEndsAt:       schedule.RunEndsAt,
+		CreatedAt:       schedule.CreatedAt,
+		UpdatedAt:       schedule.UpdatedAt,
+	}
+}
+
+// FromModel converts a GORM model to an irrigation schedule
+func (m *IrrigationScheduleMapper) FromModel(model *models.IrrigationScheduleModel) *entities.IrrigationSchedule {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.IrrigationSchedule{
+		ID:         model.ID,
+		MACAddress: model.MACAddress,
+		Name:       model.Name,
+		Cron:       model.Cron,
+		Duration:   time.Duration(model.DurationSeconds) * time.Second,
+		Enabled:    model.Enabled,
+		LastRunAt:  model.LastRunAt,
+		RunEndsAt:  model.RunEndsAt,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
@@ -0,0 +1,77 @@
+package mappers
+
+import (
+	"encoding/json"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// JobMapper provides mapping functions between jobs and the GORM model
+type JobMapper struct{}
+
+// NewJobMapper creates a new job mapper
+func NewJobMapper() *JobMapper {
+	return &JobMapper{}
+}
+
+// ToModel converts a job to a GORM model
+func (m *JobMapper) ToModel(job *entities.Job) *models.JobModel {
+	if job == nil {
+		return nil
+	}
+
+	model := &models.JobModel{
+		ID:              job.ID,
+		Type:            job.Type,
+		Payload:         string(job.Payload),
+		Status:          job.Status,
+		Attempts:        job.Attempts,
+		MaxAttempts:     job.MaxAttempts,
+		Progress:        job.Progress,
+		ProgressMessage: job.ProgressMessage,
+		LastError:       job.LastError,
+		CancelRequested: job.CancelRequested,
+		RunAt:           job.RunAt,
+		LeaseUntil:      job.LeaseUntil,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+		CreatedAt:       job.CreatedAt,
+		UpdatedAt:       job.UpdatedAt,
+	}
+	if len(job.Result) > 0 {
+		result := string(job.Result)
+		model.Result = &result
+	}
+	return model
+}
+
+// FromModel converts a GORM model to a job
+func (m *JobMapper) FromModel(model *models.JobModel) *entities.Job {
+	if model == nil {
+		return nil
+	}
+
+	job := &entities.Job{
+		ID:              model.ID,
+		Type:            model.Type,
+		Payload:         json.RawMessage(model.Payload),
+		Status:          model.Status,
+		Attempts:        model.Attempts,
+		MaxAttempts:     model.MaxAttempts,
+		Progress:        model.Progress,
+		ProgressMessage: model.ProgressMessage,
+		LastError:       model.LastError,
+		CancelRequested: model.CancelRequested,
+		RunAt:           model.RunAt,
+		LeaseUntil:      model.LeaseUntil,
+		StartedAt:       model.StartedAt,
+		FinishedAt:      model.FinishedAt,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
+	}
+	if model.Result != nil {
+		job.Result = json.RawMessage(*model.Result)
+	}
+	return job
+}
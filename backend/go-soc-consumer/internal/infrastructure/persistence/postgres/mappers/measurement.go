@@ -0,0 +1,48 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// MeasurementMapper provides mapping functions between measurements and the GORM model
+type MeasurementMapper struct{}
+
+// NewMeasurementMapper creates a new measurement mapper
+func NewMeasurementMapper() *MeasurementMapper {
+	return &MeasurementMapper{}
+}
+
+// ToModel converts a measurement to a GORM model
+func (m *MeasurementMapper) ToModel(measurement *entities.Measurement) *models.MeasurementModel {
+	if measurement == nil {
+		return nil
+	}
+
+	return &models.MeasurementModel{
+		MACAddress: measurement.MACAddress,
+		SensorType: measurement.SensorType,
+		Channel:    measurement.Channel,
+		Value:      measurement.Value,
+		Unit:       measurement.Unit,
+		Quality:    measurement.Quality,
+		CreatedAt:  measurement.Timestamp,
+	}
+}
+
+// FromModel converts a GORM model to a measurement
+func (m *MeasurementMapper) FromModel(model *models.MeasurementModel) *entities.Measurement {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.Measurement{
+		MACAddress: model.MACAddress,
+		SensorType: model.SensorType,
+		Channel:    model.Channel,
+		Value:      model.Value,
+		Unit:       model.Unit,
+		Quality:    model.Quality,
+		Timestamp:  model.CreatedAt,
+	}
+}
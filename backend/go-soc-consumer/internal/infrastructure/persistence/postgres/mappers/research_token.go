@@ -0,0 +1,83 @@
+package mappers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// ResearchTokenMapper provides mapping functions between research tokens, their usage and the GORM models
+type ResearchTokenMapper struct{}
+
+// NewResearchTokenMapper creates a new research token mapper
+func NewResearchTokenMapper() *ResearchTokenMapper {
+	return &ResearchTokenMapper{}
+}
+
+// ToModel converts a research token to a GORM model
+func (m *ResearchTokenMapper) ToModel(token *entities.ResearchToken) (*models.ResearchTokenModel, error) {
+	if token == nil {
+		return nil, nil
+	}
+
+	zones := token.Zones
+	if zones == nil {
+		zones = []string{}
+	}
+	encodedZones, err := json.Marshal(zones)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode research token zones: %w", err)
+	}
+
+	return &models.ResearchTokenModel{
+		ID:           token.ID,
+		Name:         token.Name,
+		TokenHash:    token.TokenHash,
+		PseudonymKey: token.PseudonymKey,
+		Zones:        string(encodedZones),
+		ExpiresAt:    token.ExpiresAt,
+		RevokedAt:    token.RevokedAt,
+		CreatedAt:    token.CreatedAt,
+		UpdatedAt:    token.UpdatedAt,
+	}, nil
+}
+
+// FromModel converts a GORM model to a research token
+func (m *ResearchTokenMapper) FromModel(model *models.ResearchTokenModel) (*entities.ResearchToken, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	var zones []string
+	if err := json.Unmarshal([]byte(model.Zones), &zones); err != nil {
+		return nil, fmt.Errorf("failed to decode zones of research token %s: %w", model.ID, err)
+	}
+
+	return &entities.ResearchToken{
+		ID:           model.ID,
+		Name:         model.Name,
+		TokenHash:    model.TokenHash,
+		PseudonymKey: model.PseudonymKey,
+		Zones:        zones,
+		ExpiresAt:    model.ExpiresAt,
+		RevokedAt:    model.RevokedAt,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+	}, nil
+}
+
+// UsageFromModel converts a GORM model to the usage of a research token on a day
+func (m *ResearchTokenMapper) UsageFromModel(model *models.ResearchTokenUsageModel) *entities.ResearchTokenUsage {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.ResearchTokenUsage{
+		TokenID:  model.TokenID,
+		Day:      model.Day.UTC(),
+		Requests: model.Requests,
+		Readings: model.Readings,
+	}
+}
@@ -0,0 +1,52 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// SensorChannelMapper provides mapping functions between sensor channels and the GORM model
+type SensorChannelMapper struct{}
+
+// NewSensorChannelMapper creates a new sensor channel mapper
+func NewSensorChannelMapper() *SensorChannelMapper {
+	return &SensorChannelMapper{}
+}
+
+// ToModel converts a sensor channel to a GORM model
+func (m *SensorChannelMapper) ToModel(channel *entities.SensorChannel) *models.SensorChannelModel {
+	if channel == nil {
+		return nil
+	}
+
+	return &models.SensorChannelModel{
+		MACAddress:   channel.MACAddress,
+		SensorType:   channel.SensorType,
+		Channel:      channel.Channel,
+		Name:         channel.Name,
+		Scale:        channel.Scale,
+		Offset:       channel.Offset,
+		CalibratedAt: channel.CalibratedAt,
+		CreatedAt:    channel.CreatedAt,
+		UpdatedAt:    channel.UpdatedAt,
+	}
+}
+
+// FromModel converts a GORM model to a sensor channel
+func (m *SensorChannelMapper) FromModel(model *models.SensorChannelModel) *entities.SensorChannel {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.SensorChannel{
+		MACAddress:   model.MACAddress,
+		SensorType:   model.SensorType,
+		Channel:      model.Channel,
+		Name:         model.Name,
+		Scale:        model.Scale,
+		Offset:       model.Offset,
+		CalibratedAt: model.CalibratedAt,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+	}
+}
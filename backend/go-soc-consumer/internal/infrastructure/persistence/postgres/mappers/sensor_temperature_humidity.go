@@ -28,7 +28,7 @@ func (m *SensorTemperatureHumidityMapper) FromModel(model *models.SensorTemperat
 	if model == nil {
 		return nil, nil
 	}
-	return entities.NewSensorTemperatureHumidity(model.MACAddress, model.TemperatureCelsius, model.HumidityPercent)
+	return entities.NewSensorTemperatureHumidityAt(model.MACAddress, model.TemperatureCelsius, model.HumidityPercent, model.CreatedAt)
 }
 
 func (m *SensorTemperatureHumidityMapper) FromModelSlice(models []*models.SensorTemperatureHumidityModel) ([]*entities.SensorTemperatureHumidity, error) {
@@ -0,0 +1,112 @@
+package mappers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// ArchiveEncodingColumnarJSONGzip stores readings as gzip-compressed column arrays
+const ArchiveEncodingColumnarJSONGzip = "json-columnar+gzip"
+
+// columnarReadings is the archived layout: one array per column compresses far better than row objects
+type columnarReadings struct {
+	TimestampsMs []int64   `json:"t"`
+	Temperatures []float64 `json:"temp"`
+	Humidities   []float64 `json:"hum"`
+}
+
+type SensorTemperatureHumidityArchiveMapper struct{}
+
+func NewSensorTemperatureHumidityArchiveMapper() *SensorTemperatureHumidityArchiveMapper {
+	return &SensorTemperatureHumidityArchiveMapper{}
+}
+
+// ToModel compresses the readings of a device-day into an archive model
+func (m *SensorTemperatureHumidityArchiveMapper) ToModel(day entities.TelemetryDay, readings []*entities.SensorTemperatureHumidity) (*models.SensorTemperatureHumidityArchiveModel, error) {
+	if len(readings) == 0 {
+		return nil, fmt.Errorf("no readings to archive for %s", day)
+	}
+
+	sorted := make([]*entities.SensorTemperatureHumidity, len(readings))
+	copy(sorted, readings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp().Before(sorted[j].Timestamp())
+	})
+
+	columns := columnarReadings{
+		TimestampsMs: make([]int64, len(sorted)),
+		Temperatures: make([]float64, len(sorted)),
+		Humidities:   make([]float64, len(sorted)),
+	}
+	for i, reading := range sorted {
+		columns.TimestampsMs[i] = reading.Timestamp().UnixMilli()
+		columns.Temperatures[i] = reading.Temperature()
+		columns.Humidities[i] = reading.Humidity()
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(columns); err != nil {
+		return nil, fmt.Errorf("failed to encode archived readings: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress archived readings: %w", err)
+	}
+
+	return &models.SensorTemperatureHumidityArchiveModel{
+		MACAddress:     day.MACAddress,
+		Day:            day.Day,
+		Encoding:       ArchiveEncodingColumnarJSONGzip,
+		Data:           buf.Bytes(),
+		SampleCount:    len(sorted),
+		FirstReadingAt: sorted[0].Timestamp(),
+		LastReadingAt:  sorted[len(sorted)-1].Timestamp(),
+	}, nil
+}
+
+// FromModel decompresses the readings stored in an archive model
+func (m *SensorTemperatureHumidityArchiveMapper) FromModel(model *models.SensorTemperatureHumidityArchiveModel) ([]*entities.SensorTemperatureHumidity, error) {
+	if model == nil {
+		return nil, nil
+	}
+	if model.Encoding != ArchiveEncodingColumnarJSONGzip {
+		return nil, fmt.Errorf("unsupported archive encoding: %s", model.Encoding)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(model.Data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress archived readings: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress archived readings: %w", err)
+	}
+
+	var columns columnarReadings
+	if err := json.Unmarshal(data, &columns); err != nil {
+		return nil, fmt.Errorf("failed to decode archived readings: %w", err)
+	}
+	if len(columns.Temperatures) != len(columns.TimestampsMs) || len(columns.Humidities) != len(columns.TimestampsMs) {
+		return nil, fmt.Errorf("corrupted archive for %s: column lengths differ", model.MACAddress)
+	}
+
+	readings := make([]*entities.SensorTemperatureHumidity, 0, len(columns.TimestampsMs))
+	for i, ms := range columns.TimestampsMs {
+		reading, err := entities.NewSensorTemperatureHumidityAt(model.MACAddress, columns.Temperatures[i], columns.Humidities[i], time.UnixMilli(ms))
+		if err != nil {
+			return nil, fmt.Errorf("invalid archived reading: %w", err)
+		}
+		readings = append(readings, reading)
+	}
+	return readings, nil
+}
@@ -0,0 +1,59 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+func TestSensorTemperatureHumidityArchiveMapper_RoundTrip(t *testing.T) {
+	mapper := NewSensorTemperatureHumidityArchiveMapper()
+	mac := "00:11:22:33:44:55"
+	day := entities.NewTelemetryDay(mac, time.Date(2025, 6, 1, 18, 30, 0, 0, time.UTC))
+
+	// A reading per minute for a whole day, supplied out of order
+	readings := make([]*entities.SensorTemperatureHumidity, 0, 1440)
+	for i := 1439; i >= 0; i-- {
+		reading, err := entities.NewSensorTemperatureHumidityAt(mac, 20+float64(i%10)/10, 50+float64(i%20)/10, day.Day.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, err)
+		readings = append(readings, reading)
+	}
+
+	model, err := mapper.ToModel(day, readings)
+	require.NoError(t, err)
+	assert.Equal(t, mac, model.MACAddress)
+	assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), model.Day)
+	assert.Equal(t, 1440, model.SampleCount)
+	assert.Equal(t, day.Day, model.FirstReadingAt)
+	assert.Equal(t, day.Day.Add(1439*time.Minute), model.LastReadingAt)
+	assert.Less(t, len(model.Data), 1440*10, "archive should be much smaller than the raw rows")
+
+	decoded, err := mapper.FromModel(model)
+	require.NoError(t, err)
+	require.Len(t, decoded, 1440)
+	assert.Equal(t, day.Day, decoded[0].Timestamp())
+	assert.Equal(t, readings[len(readings)-1].Temperature(), decoded[0].Temperature())
+	assert.Equal(t, readings[len(readings)-1].Humidity(), decoded[0].Humidity())
+}
+
+func TestSensorTemperatureHumidityArchiveMapper_Errors(t *testing.T) {
+	mapper := NewSensorTemperatureHumidityArchiveMapper()
+
+	_, err := mapper.ToModel(entities.NewTelemetryDay("00:11:22:33:44:55", time.Now()), nil)
+	assert.Error(t, err)
+
+	_, err = mapper.FromModel(&models.SensorTemperatureHumidityArchiveModel{Encoding: "parquet"})
+	assert.ErrorContains(t, err, "unsupported archive encoding")
+
+	_, err = mapper.FromModel(&models.SensorTemperatureHumidityArchiveModel{Encoding: ArchiveEncodingColumnarJSONGzip, Data: []byte("not gzip")})
+	assert.ErrorContains(t, err, "failed to decompress")
+
+	decoded, err := mapper.FromModel(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+}
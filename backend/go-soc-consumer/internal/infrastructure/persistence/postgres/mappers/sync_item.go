@@ -0,0 +1,65 @@
+package mappers
+
+import (
+	"encoding/json"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// SyncItemMapper provides mapping functions between sync items and the outbox model
+type SyncItemMapper struct{}
+
+// NewSyncItemMapper creates a new sync item mapper
+func NewSyncItemMapper() *SyncItemMapper {
+	return &SyncItemMapper{}
+}
+
+// ToModel converts a sync item to a GORM model
+func (m *SyncItemMapper) ToModel(item *entities.SyncItem) *models.SyncOutboxModel {
+	if item == nil {
+		return nil
+	}
+
+	return &models.SyncOutboxModel{
+		ID:        item.ID,
+		Kind:      item.Kind,
+		EntityKey: item.Key,
+		Payload:   string(item.Payload),
+		ChangedAt: item.ChangedAt,
+		Attempts:  item.Attempts,
+		LastError: item.LastError,
+		SyncedAt:  item.SyncedAt,
+	}
+}
+
+// FromModel converts a GORM model to a sync item
+func (m *SyncItemMapper) FromModel(model *models.SyncOutboxModel) *entities.SyncItem {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.SyncItem{
+		ID:        model.ID,
+		Kind:      model.Kind,
+		Key:       model.EntityKey,
+		Payload:   json.RawMessage(model.Payload),
+		ChangedAt: model.ChangedAt,
+		Attempts:  model.Attempts,
+		LastError: model.LastError,
+		SyncedAt:  model.SyncedAt,
+	}
+}
+
+// FromModelSlice converts a slice of GORM models to sync items
+func (m *SyncItemMapper) FromModelSlice(models []*models.SyncOutboxModel) []*entities.SyncItem {
+	if models == nil {
+		return nil
+	}
+
+	items := make([]*entities.SyncItem, len(models))
+	for i, model := range models {
+		items[i] = m.FromModel(model)
+	}
+	return items
+}
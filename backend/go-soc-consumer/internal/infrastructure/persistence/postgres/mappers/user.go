@@ -0,0 +1,46 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// UserMapper provides mapping functions between users and the GORM model
+type UserMapper struct{}
+
+// NewUserMapper creates a new user mapper
+func NewUserMapper() *UserMapper {
+	return &UserMapper{}
+}
+
+// ToModel converts a user to a GORM model
+func (m *UserMapper) ToModel(user *entities.User) *models.UserModel {
+	if user == nil {
+		return nil
+	}
+
+	return &models.UserModel{
+		ID:           user.ID,
+		Username:     user.Username,
+		PasswordHash: user.PasswordHash,
+		Role:         user.Role,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+	}
+}
+
+// FromModel converts a GORM model to a user
+func (m *UserMapper) FromModel(model *models.UserModel) *entities.User {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.User{
+		ID:           model.ID,
+		Username:     model.Username,
+		PasswordHash: model.PasswordHash,
+		Role:         model.Role,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+	}
+}
@@ -0,0 +1,42 @@
+package mappers
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+)
+
+// ValveStateMapper provides mapping functions between valve transitions and the GORM model
+type ValveStateMapper struct{}
+
+// NewValveStateMapper creates a new valve state mapper
+func NewValveStateMapper() *ValveStateMapper {
+	return &ValveStateMapper{}
+}
+
+// ToModel converts a valve transition to a GORM model
+func (m *ValveStateMapper) ToModel(transition *entities.ValveTransition) *models.ValveStateModel {
+	if transition == nil {
+		return nil
+	}
+
+	return &models.ValveStateModel{
+		CommandID:  transition.CommandID,
+		MACAddress: transition.MACAddress,
+		State:      transition.State,
+		ChangedAt:  transition.ChangedAt,
+	}
+}
+
+// FromModel converts a GORM model to a valve transition
+func (m *ValveStateMapper) FromModel(model *models.ValveStateModel) *entities.ValveTransition {
+	if model == nil {
+		return nil
+	}
+
+	return &entities.ValveTransition{
+		CommandID:  model.CommandID,
+		MACAddress: model.MACAddress,
+		State:      model.State,
+		ChangedAt:  model.ChangedAt,
+	}
+}
@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// measurementRepository implements the MeasurementRepository interface using GORM PostgreSQL
+type measurementRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.MeasurementMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewMeasurementRepository creates a new GORM-based PostgreSQL measurement repository
+func NewMeasurementRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.MeasurementRepository {
+	return &measurementRepository{
+		db:     db,
+		mapper: mappers.NewMeasurementMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create inserts the measurements in one statement
+func (r *measurementRepository) Create(ctx context.Context, measurements []*entities.Measurement) error {
+	if len(measurements) == 0 {
+		return nil
+	}
+
+	records := make([]*models.MeasurementModel, 0, len(measurements))
+	for _, measurement := range measurements {
+		records = append(records, r.mapper.ToModel(measurement))
+	}
+
+	if err := r.db.GetDB().WithContext(ctx).Create(&records).Error; err != nil {
+		r.logger.Error("measurements_create_failed", zap.String("operation", "create"), zap.String("table", "measurements"), zap.Int("count", len(records)), zap.Error(err))
+		return fmt.Errorf("failed to create measurements: %w", err)
+	}
+	return nil
+}
+
+// Upsert replaces, per measurement, the device's measurements of the same type and channel stamped within window
+// of its timestamp, inserting it when there are none
+func (r *measurementRepository) Upsert(ctx context.Context, measurements []*entities.Measurement, window time.Duration) error {
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, measurement := range measurements {
+			record := r.mapper.ToModel(measurement)
+			result := tx.Model(&models.MeasurementModel{}).
+				Where("mac_address = ? AND sensor_type = ? AND channel = ? AND created_at BETWEEN ? AND ?", record.MACAddress, record.SensorType, record.Channel, record.CreatedAt.Add(-window), record.CreatedAt.Add(window)).
+				Updates(map[string]interface{}{
+					"value":      record.Value,
+					"unit":       record.Unit,
+					"quality":    record.Quality,
+					"created_at": record.CreatedAt,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				continue
+			}
+			if err := tx.Create(record).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("measurements_upsert_failed", zap.String("operation", "upsert"), zap.String("table", "measurements"), zap.Int("count", len(measurements)), zap.Error(err))
+		return fmt.Errorf("failed to upsert measurements: %w", err)
+	}
+	return nil
+}
+
+// Latest returns the latest measurement per device, sensor type and channel among the given types
+func (r *measurementRepository) Latest(ctx context.Context, sensorTypes []string, since time.Time) ([]*entities.Measurement, error) {
+	if len(sensorTypes) == 0 {
+		return nil, nil
+	}
+
+	var records []models.MeasurementModel
+	result := r.db.GetDB().WithContext(ctx).
+		Select("DISTINCT ON (mac_address, sensor_type, channel) *").
+		Where("sensor_type IN ? AND created_at >= ?", sensorTypes, since).
+		Order("mac_address, sensor_type, channel, created_at DESC").
+		Find(&records)
+	if result.Error != nil {
+		r.logger.Error("measurements_latest_query_failed", zap.String("operation", "latest"), zap.String("table", "measurements"), zap.Strings("sensor_types", sensorTypes), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to query latest measurements: %w", result.Error)
+	}
+
+	measurements := make([]*entities.Measurement, 0, len(records))
+	for i := range records {
+		measurements = append(measurements, r.mapper.FromModel(&records[i]))
+	}
+	return measurements, nil
+}
+
+// LatestByDevice returns the latest measurement per sensor type and channel of one device
+func (r *measurementRepository) LatestByDevice(ctx context.Context, macAddress string, since time.Time) ([]*entities.Measurement, error) {
+	var records []models.MeasurementModel
+	result := r.db.GetDB().WithContext(ctx).
+		Select("DISTINCT ON (sensor_type, channel) *").
+		Where("mac_address = ? AND created_at >= ?", macAddress, since).
+		Order("sensor_type, channel, created_at DESC").
+		Find(&records)
+	if result.Error != nil {
+		r.logger.Error("measurements_latest_query_failed", zap.String("operation", "latest_by_device"), zap.String("table", "measurements"), zap.String("mac_address", macAddress), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to query latest measurements: %w", result.Error)
+	}
+
+	measurements := make([]*entities.Measurement, 0, len(records))
+	for i := range records {
+		measurements = append(measurements, r.mapper.FromModel(&records[i]))
+	}
+	return measurements, nil
+}
+
+// History returns the measurements of one sensor type of a device in [from, to), oldest first
+func (r *measurementRepository) History(ctx context.Context, macAddress, sensorType string, from, to time.Time) ([]*entities.Measurement, error) {
+	var records []models.MeasurementModel
+	result := r.db.GetDB().WithContext(ctx).
+		Where("mac_address = ? AND sensor_type = ? AND created_at >= ? AND created_at < ?", macAddress, sensorType, from, to).
+		Order("created_at").
+		Find(&records)
+	if result.Error != nil {
+		r.logger.Error("measurements_history_query_failed", zap.String("operation", "history"), zap.String("table", "measurements"), zap.String("mac_address", macAddress), zap.String("sensor_type", sensorType), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to query measurement history: %w", result.Error)
+	}
+
+	measurements := make([]*entities.Measurement, 0, len(records))
+	for i := range records {
+		measurements = append(measurements, r.mapper.FromModel(&records[i]))
+	}
+	return measurements, nil
+}
+
+// Averages reads the continuous aggregate of the resolution on TimescaleDB and groups the measurements
+// by hour or UTC day otherwise
+func (r *measurementRepository) Averages(ctx context.Context, macAddress, sensorType, resolution string, from, to time.Time) ([]*entities.MeasurementAverage, error) {
+	step, err := entities.MeasurementResolutionStep(resolution)
+	if err != nil {
+		return nil, err
+	}
+	// Only whole hours and days are averaged, so both bounds move up to the start of one
+	start, end := bucketStart(from, step), bucketStart(to, step)
+
+	var rows []struct {
+		Bucket     time.Time
+		MACAddress string
+		SensorType string
+		Channel    int
+		Average    float64
+		Minimum    float64
+		Maximum    float64
+		Samples    int
+	}
+	query := r.db.GetDB().WithContext(ctx)
+	if r.db.Timescale() {
+		view := database.HourlyMeasurementsView
+		if resolution == entities.MeasurementResolutionDay {
+			view = database.DailyMeasurementsView
+		}
+		query = query.Table(view).
+			Select("bucket, mac_address, sensor_type, channel, average, minimum, maximum, samples").
+			Where("mac_address = ? AND sensor_type = ? AND bucket >= ? AND bucket < ?", macAddress, sensorType, start, end)
+	} else {
+		query = query.Model(&models.MeasurementModel{}).
+			Select("date_trunc(?, created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket, mac_address, sensor_type, channel, "+
+				"avg(value) AS average, min(value) AS minimum, max(value) AS maximum, count(*) AS samples", resolution).
+			Where("mac_address = ? AND sensor_type = ? AND quality <> ? AND created_at >= ? AND created_at < ?", macAddress, sensorType, entities.MeasurementQualityBad, start, end).
+			Group("bucket, mac_address, sensor_type, channel")
+	}
+	if err := query.Order("bucket, channel").Scan(&rows).Error; err != nil {
+		r.logger.Error("measurements_averages_query_failed", zap.String("operation", "averages"), zap.String("table", "measurements"), zap.String("mac_address", macAddress), zap.String("sensor_type", sensorType), zap.String("resolution", resolution), zap.Error(err))
+		return nil, fmt.Errorf("failed to query measurement averages: %w", err)
+	}
+
+	averages := make([]*entities.MeasurementAverage, 0, len(rows))
+	for _, row := range rows {
+		averages = append(averages, &entities.MeasurementAverage{
+			MACAddress: row.MACAddress,
+			SensorType: row.SensorType,
+			Channel:    row.Channel,
+			Start:      row.Bucket.UTC(),
+			Average:    row.Average,
+			Minimum:    row.Minimum,
+			Maximum:    row.Maximum,
+			Samples:    row.Samples,
+		})
+	}
+	return averages, nil
+}
+
+// EnsureTypeViews creates or replaces one view per sensor type. Type names are validated identifiers,
+// so they are safe to use in the view and column names.
+func (r *measurementRepository) EnsureTypeViews(ctx context.Context, sensorTypes []*entities.SensorType) error {
+	for _, sensorType := range sensorTypes {
+		if err := sensorType.Validate(); err != nil {
+			return fmt.Errorf("invalid sensor type: %w", err)
+		}
+
+		statement := fmt.Sprintf(
+			`CREATE OR REPLACE VIEW %q AS SELECT mac_address, channel, value AS %q, unit, quality, created_at FROM measurements WHERE sensor_type = '%s'`,
+			sensorType.ViewName(), sensorType.Name, sensorType.Name,
+		)
+		if err := r.db.GetDB().WithContext(ctx).Exec(statement).Error; err != nil {
+			r.logger.Error("measurement_view_create_failed", zap.String("operation", "ensure_type_views"), zap.String("view", sensorType.ViewName()), zap.Error(err))
+			return fmt.Errorf("failed to create view %s: %w", sensorType.ViewName(), err)
+		}
+	}
+	return nil
+}
+
+// bucketStart rounds the time up to the start of a bucket of the given length
+func bucketStart(t time.Time, step time.Duration) time.Time {
+	start := t.UTC().Truncate(step)
+	if start.Before(t) {
+		start = start.Add(step)
+	}
+	return start
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupMeasurementTestRepository initializes a test repository with a mock database
+func setupMeasurementTestRepository(t *testing.T) (*measurementRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewMeasurementRepository(postgresDB, loggerFactory).(*measurementRepository), sqlMock
+}
+
+func TestMeasurementRepository_Latest(t *testing.T) {
+	since := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should return the latest measurement of each stream", func(t *testing.T) {
+		repo, mock := setupMeasurementTestRepository(t)
+		mock.ExpectQuery(`SELECT DISTINCT ON \(mac_address, sensor_type, channel\) \* FROM "measurements" WHERE sensor_type IN \(\$1,\$2\) AND created_at >= \$3 ORDER BY mac_address, sensor_type, channel, created_at DESC`).
+			WithArgs("humidity", "temperature", since).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "mac_address", "sensor_type", "channel", "value", "unit", "quality", "created_at"}).
+				AddRow(1, "AA:BB:CC:DD:EE:FF", "humidity", 1, 55.5, "percent", "good", since.Add(time.Minute)))
+
+		measurements, err := repo.Latest(context.Background(), []string{"humidity", "temperature"}, since)
+
+		require.NoError(t, err)
+		require.Len(t, measurements, 1)
+		assert.Equal(t, "humidity", measurements[0].SensorType)
+		assert.Equal(t, 1, measurements[0].Channel)
+		assert.Equal(t, 55.5, measurements[0].Value)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should not query without sensor types", func(t *testing.T) {
+		repo, mock := setupMeasurementTestRepository(t)
+
+		measurements, err := repo.Latest(context.Background(), nil, since)
+
+		require.NoError(t, err)
+		assert.Empty(t, measurements)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMeasurementRepository_LatestByDevice(t *testing.T) {
+	since := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	repo, mock := setupMeasurementTestRepository(t)
+	mock.ExpectQuery(`SELECT DISTINCT ON \(sensor_type, channel\) \* FROM "measurements" WHERE mac_address = \$1 AND created_at >= \$2 ORDER BY sensor_type, channel, created_at DESC`).
+		WithArgs("AA:BB:CC:DD:EE:FF", since).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "mac_address", "sensor_type", "channel", "value", "unit", "quality", "created_at"}).
+			AddRow(1, "AA:BB:CC:DD:EE:FF", "soil_moisture", 0, 31.5, "percent", "good", since.Add(time.Minute)))
+
+	measurements, err := repo.LatestByDevice(context.Background(), "AA:BB:CC:DD:EE:FF", since)
+
+	require.NoError(t, err)
+	require.Len(t, measurements, 1)
+	assert.Equal(t, "soil_moisture", measurements[0].SensorType)
+	assert.Equal(t, 31.5, measurements[0].Value)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMeasurementRepository_History(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	repo, mock := setupMeasurementTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "measurements" WHERE mac_address = \$1 AND sensor_type = \$2 AND created_at >= \$3 AND created_at < \$4 ORDER BY created_at`).
+		WithArgs("AA:BB:CC:DD:EE:FF", "soil_moisture", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "mac_address", "sensor_type", "channel", "value", "unit", "quality", "created_at"}).
+			AddRow(1, "AA:BB:CC:DD:EE:FF", "soil_moisture", 0, 24.0, "percent", "good", from.Add(time.Hour)).
+			AddRow(2, "AA:BB:CC:DD:EE:FF", "soil_moisture", 0, 31.5, "percent", "good", from.Add(2*time.Hour)))
+
+	measurements, err := repo.History(context.Background(), "AA:BB:CC:DD:EE:FF", "soil_moisture", from, to)
+
+	require.NoError(t, err)
+	require.Len(t, measurements, 2)
+	assert.Equal(t, 24.0, measurements[0].Value)
+	assert.Equal(t, from.Add(2*time.Hour), measurements[1].Timestamp)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMeasurementRepository_Averages(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should group whole hours of the measurements table", func(t *testing.T) {
+		repo, mock := setupMeasurementTestRepository(t)
+		mock.ExpectQuery(`SELECT date_trunc\(\$1, created_at AT TIME ZONE 'UTC'\) AT TIME ZONE 'UTC' AS bucket, mac_address, sensor_type, channel, avg\(value\) AS average, min\(value\) AS minimum, max\(value\) AS maximum, count\(\*\) AS samples FROM "measurements" WHERE mac_address = \$2 AND sensor_type = \$3 AND quality <> \$4 AND created_at >= \$5 AND created_at < \$6 GROUP BY bucket, mac_address, sensor_type, channel ORDER BY bucket, channel`).
+			WithArgs("hour", "AA:BB:CC:DD:EE:FF", "soil_moisture", "bad", from.Add(time.Hour), from.Add(3*time.Hour)).
+			WillReturnRows(sqlmock.NewRows([]string{"bucket", "mac_address", "sensor_type", "channel", "average", "minimum", "maximum", "samples"}).
+				AddRow(from.Add(time.Hour), "AA:BB:CC:DD:EE:FF", "soil_moisture", 0, 31.5, 30.0, 33.0, 12).
+				AddRow(from.Add(2*time.Hour), "AA:BB:CC:DD:EE:FF", "soil_moisture", 0, 29.25, 28.5, 30.0, 12))
+
+		averages, err := repo.Averages(context.Background(), "AA:BB:CC:DD:EE:FF", "soil_moisture", "hour", from.Add(30*time.Minute), from.Add(3*time.Hour))
+
+		require.NoError(t, err)
+		require.Len(t, averages, 2)
+		assert.Equal(t, from.Add(time.Hour), averages[0].Start)
+		assert.Equal(t, 31.5, averages[0].Average)
+		assert.Equal(t, 12, averages[1].Samples)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should reject unknown resolutions", func(t *testing.T) {
+		repo, mock := setupMeasurementTestRepository(t)
+
+		_, err := repo.Averages(context.Background(), "AA:BB:CC:DD:EE:FF", "soil_moisture", "week", from, from.AddDate(0, 1, 0))
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// BlacklistEntryModel represents the GORM model for blocked MAC addresses and IP ranges
+// This model contains only data persistence concerns and GORM-specific annotations
+type BlacklistEntryModel struct {
+	ID     string `gorm:"primaryKey;size:36;not null" json:"id"`
+	Kind   string `gorm:"size:20;not null;uniqueIndex:idx_blacklist_kind_value,priority:1" json:"kind"`
+	Value  string `gorm:"size:64;not null;uniqueIndex:idx_blacklist_kind_value,priority:2" json:"value"`
+	Reason string `gorm:"size:255" json:"reason"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (BlacklistEntryModel) TableName() string {
+	return "blacklist_entries"
+}
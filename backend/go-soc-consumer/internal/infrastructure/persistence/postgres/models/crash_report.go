@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// CrashSignatureModel represents the GORM model for distinct crashes, holding their stack information once
+// This model contains only data persistence concerns and GORM-specific annotations
+type CrashSignatureModel struct {
+	Signature       string    `gorm:"primaryKey;size:32;not null" json:"signature"`
+	FirmwareVersion string    `gorm:"size:32;not null" json:"firmware_version"`
+	Reason          string    `gorm:"size:128" json:"reason"`
+	Backtrace       string    `gorm:"type:text" json:"backtrace"` // frames separated by spaces
+	FirstSeen       time.Time `gorm:"not null" json:"first_seen"`
+	LastSeen        time.Time `gorm:"not null" json:"last_seen"`
+}
+
+// TableName specifies the table name for GORM
+func (CrashSignatureModel) TableName() string {
+	return "crash_signatures"
+}
+
+// CrashReportModel represents the GORM model for each crash reported by a device
+// This model contains only data persistence concerns and GORM-specific annotations
+type CrashReportModel struct {
+	ID              string    `gorm:"primaryKey;size:36;not null" json:"id"`
+	Signature       string    `gorm:"size:32;not null;index" json:"signature"`
+	MACAddress      string    `gorm:"size:17;not null;index" json:"mac_address"`
+	FirmwareVersion string    `gorm:"size:32;not null" json:"firmware_version"`
+	UptimeSeconds   int64     `gorm:"not null" json:"uptime_seconds"`
+	ReportedAt      time.Time `gorm:"not null;index" json:"reported_at"`
+}
+
+// TableName specifies the table name for GORM
+func (CrashReportModel) TableName() string {
+	return "crash_reports"
+}
@@ -17,6 +17,22 @@ type DeviceModel struct {
 	RegisteredAt        time.Time `gorm:"not null;default:now();index" json:"registered_at"`
 	LastSeen            time.Time `gorm:"not null;default:now();index" json:"last_seen"`
 	Status              string    `gorm:"size:20;not null;default:'registered';check:status IN ('registered', 'online', 'offline');index" json:"status"`
+	// SHA-256 fingerprint of the provisioned client certificate, empty when not provisioned
+	CertificateFingerprint string `gorm:"size:64" json:"certificate_fingerprint"`
+	// SHA-256 of the broker password issued at onboarding, empty for devices not onboarded
+	CredentialHash string `gorm:"size:64" json:"-"`
+	// Farm namespace the device registered under, empty for devices on the shared topics
+	FarmID string `gorm:"size:64;index" json:"farm_id"`
+	// When the device's sensors were last calibrated, NULL when unknown
+	CalibratedAt *time.Time `json:"calibrated_at"`
+	// Versions reported by the device when it registers, empty when not reported
+	FirmwareVersion string `gorm:"size:32;index" json:"firmware_version"`
+	HardwareVersion string `gorm:"size:32;index" json:"hardware_version"`
+	// Broker metadata of the latest live registration, the topic is NULL until one is recorded
+	RegistrationTopic    *string `gorm:"size:255" json:"registration_topic"`
+	RegistrationQoS      int16   `gorm:"column:registration_qos;not null;default:0" json:"registration_qos"`
+	RegistrationRetained bool    `gorm:"not null;default:false" json:"registration_retained"`
+	RegistrationClientID string  `gorm:"size:255" json:"registration_client_id"`
 
 	// Associations
 	SensorTemperatureHumidity []SensorTemperatureHumidityModel `gorm:"foreignKey:MACAddress;references:MACAddress;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
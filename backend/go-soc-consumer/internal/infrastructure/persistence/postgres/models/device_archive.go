@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+)
+
+// DeviceArchiveModel represents the GORM model for the archives of decommissioned devices
+// This model contains only data persistence concerns and GORM-specific annotations
+type DeviceArchiveModel struct {
+	ID           string     `gorm:"primaryKey;size:36;not null" json:"id"`
+	MACAddress   string     `gorm:"size:17;not null;index" json:"mac_address"`
+	DeviceName   string     `gorm:"size:150;not null;default:''" json:"device_name"`
+	FarmID       string     `gorm:"size:64;not null;default:''" json:"farm_id"`
+	Reason       string     `gorm:"size:500;not null;default:''" json:"reason"`
+	Actor        string     `gorm:"size:64;not null" json:"actor"`
+	Status       string     `gorm:"size:16;not null;index" json:"status"`
+	Storage      string     `gorm:"size:16;not null" json:"storage"`
+	ObjectKey    string     `gorm:"size:255;not null;default:''" json:"object_key"`
+	Measurements int        `gorm:"not null;default:0" json:"measurements"`
+	Readings     int        `gorm:"not null;default:0" json:"readings"`
+	ArchivedAt   time.Time  `gorm:"not null;index" json:"archived_at"`
+	RestoredAt   *time.Time `json:"restored_at"`
+	RestoredBy   string     `gorm:"size:64;not null;default:''" json:"restored_by"`
+}
+
+// TableName specifies the table name for GORM
+func (DeviceArchiveModel) TableName() string {
+	return "device_archives"
+}
+
+// ArchivedDeviceMeasurementModel represents the GORM model of a measurement of a decommissioned
+// device, kept apart from the measurements table until the device is restored
+type ArchivedDeviceMeasurementModel struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ArchiveID  string    `gorm:"size:36;not null;index" json:"archive_id"`
+	MACAddress string    `gorm:"size:17;not null" json:"mac_address"`
+	SensorType string    `gorm:"size:32;not null" json:"sensor_type"`
+	Channel    int       `gorm:"not null;default:0" json:"channel"`
+	Value      float64   `gorm:"type:double precision;not null" json:"value"`
+	Unit       string    `gorm:"size:32;not null" json:"unit"`
+	Quality    string    `gorm:"size:16;not null;default:good" json:"quality"`
+	CreatedAt  time.Time `gorm:"not null" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ArchivedDeviceMeasurementModel) TableName() string {
+	return "device_archive_measurements"
+}
+
+// ArchivedDeviceTemperatureHumidityModel represents the GORM model of a temperature and humidity
+// reading of a decommissioned device, kept apart from the readings table until it is restored
+type ArchivedDeviceTemperatureHumidityModel struct {
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	ArchiveID          string    `gorm:"size:36;not null;index" json:"archive_id"`
+	MACAddress         string    `gorm:"size:17;not null" json:"mac_address"`
+	TemperatureCelsius float64   `gorm:"type:decimal(5,2);not null" json:"temperature_celsius"`
+	HumidityPercent    float64   `gorm:"type:decimal(5,2);not null" json:"humidity_percent"`
+	CreatedAt          time.Time `gorm:"not null" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ArchivedDeviceTemperatureHumidityModel) TableName() string {
+	return "device_archive_temperature_humidity"
+}
+
+// ArchivedDeviceTelemetryDayModel represents the GORM model of a compacted device-day of a
+// decommissioned device, kept apart from the compacted days until the device is restored
+type ArchivedDeviceTelemetryDayModel struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	ArchiveID      string    `gorm:"size:36;not null;index" json:"archive_id"`
+	MACAddress     string    `gorm:"size:17;not null" json:"mac_address"`
+	Day            time.Time `gorm:"type:date;not null" json:"day"`
+	Encoding       string    `gorm:"size:20;not null" json:"encoding"`
+	Data           []byte    `gorm:"type:bytea;not null" json:"-"`
+	SampleCount    int       `gorm:"not null" json:"sample_count"`
+	FirstReadingAt time.Time `gorm:"not null" json:"first_reading_at"`
+	LastReadingAt  time.Time `gorm:"not null" json:"last_reading_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ArchivedDeviceTelemetryDayModel) TableName() string {
+	return "device_archive_telemetry_days"
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// DeviceCommandModel represents the GORM model for commands sent to devices
+// This model contains only data persistence concerns and GORM-specific annotations
+type DeviceCommandModel struct {
+	ID         string     `gorm:"primaryKey;size:64;not null" json:"id"`
+	MACAddress string     `gorm:"size:17;not null;index:idx_device_commands_mac_issued,priority:1" json:"mac_address"`
+	Command    string     `gorm:"size:32;not null" json:"command"`
+	Payload    string     `gorm:"type:jsonb;not null" json:"payload"`
+	Status     string     `gorm:"size:20;not null;index:idx_device_commands_status_expires,priority:1" json:"status"`
+	Message    string     `gorm:"size:255" json:"message"`
+	IssuedAt   time.Time  `gorm:"not null;index:idx_device_commands_mac_issued,priority:2" json:"issued_at"`
+	ExpiresAt  time.Time  `gorm:"not null;index:idx_device_commands_status_expires,priority:2" json:"expires_at"`
+	AckedAt    *time.Time `json:"acked_at"`
+	Attempts   int        `gorm:"not null;default:1" json:"attempts"`
+}
+
+// TableName specifies the table name for GORM
+func (DeviceCommandModel) TableName() string {
+	return "device_commands"
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// DeviceMigrationModel represents the GORM model for migrations of devices to another broker or topic layout
+// This model contains only data persistence concerns and GORM-specific annotations
+type DeviceMigrationModel struct {
+	ID         string     `gorm:"primaryKey;size:36;not null" json:"id"`
+	BrokerURL  string     `gorm:"size:255;not null" json:"broker_url"`
+	Layout     string     `gorm:"size:16;not null" json:"layout"`
+	FarmID     string     `gorm:"size:64;not null;default:''" json:"farm_id"`
+	Actor      string     `gorm:"size:64;not null" json:"actor"`
+	Status     string     `gorm:"size:16;not null;index" json:"status"`
+	JobID      string     `gorm:"size:36;not null;default:''" json:"job_id"`
+	Devices    int        `gorm:"not null;default:0" json:"devices"`
+	StartedAt  time.Time  `gorm:"not null;index" json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// TableName specifies the table name for GORM
+func (DeviceMigrationModel) TableName() string {
+	return "device_migrations"
+}
+
+// DeviceMigrationTargetModel represents the GORM model of a device taking part in a migration
+type DeviceMigrationTargetModel struct {
+	MigrationID   string     `gorm:"primaryKey;size:36;not null" json:"migration_id"`
+	MACAddress    string     `gorm:"primaryKey;size:17;not null" json:"mac_address"`
+	FarmID        string     `gorm:"size:64;not null;default:''" json:"farm_id"`
+	Status        string     `gorm:"size:16;not null" json:"status"`
+	CommandID     string     `gorm:"size:64;not null;default:''" json:"command_id"`
+	Error         string     `gorm:"size:500;not null;default:''" json:"error"`
+	SentAt        *time.Time `json:"sent_at"`
+	ReconnectedAt *time.Time `json:"reconnected_at"`
+}
+
+// TableName specifies the table name for GORM
+func (DeviceMigrationTargetModel) TableName() string {
+	return "device_migration_targets"
+}
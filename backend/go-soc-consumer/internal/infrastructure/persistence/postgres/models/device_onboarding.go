@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// DeviceOnboardingModel represents the GORM model for the onboardings of devices
+// This model contains only data persistence concerns and GORM-specific annotations
+type DeviceOnboardingModel struct {
+	ID                     string     `gorm:"primaryKey;size:36;not null" json:"id"`
+	MACAddress             string     `gorm:"size:17;not null;index" json:"mac_address"`
+	DeviceName             string     `gorm:"size:100;not null" json:"device_name"`
+	LocationDescription    string     `gorm:"size:255" json:"location_description"`
+	FarmID                 string     `gorm:"size:64" json:"farm_id"`
+	TokenHash              string     `gorm:"size:64;not null" json:"token_hash"`
+	TokenExpiresAt         time.Time  `gorm:"not null" json:"token_expires_at"`
+	CredentialsDeliveredAt *time.Time `json:"credentials_delivered_at"`
+	FirstConnectedAt       *time.Time `json:"first_connected_at"`
+	FirstTelemetryAt       *time.Time `json:"first_telemetry_at"`
+	AssignedAt             *time.Time `gorm:"index" json:"assigned_at"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (DeviceOnboardingModel) TableName() string {
+	return "device_onboardings"
+}
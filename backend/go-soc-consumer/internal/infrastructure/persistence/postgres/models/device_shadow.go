@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// DeviceShadowModel represents the GORM model for the configuration declared for devices
+// This model contains only data persistence concerns and GORM-specific annotations
+type DeviceShadowModel struct {
+	MACAddress          string    `gorm:"primaryKey;size:17;not null" json:"mac_address"`
+	DeviceName          string    `gorm:"size:100;not null" json:"device_name"`
+	LocationDescription string    `gorm:"size:255;not null" json:"location_description"`
+	DeclaredAt          time.Time `gorm:"not null" json:"declared_at"`
+}
+
+// TableName specifies the table name for GORM
+func (DeviceShadowModel) TableName() string {
+	return "device_shadows"
+}
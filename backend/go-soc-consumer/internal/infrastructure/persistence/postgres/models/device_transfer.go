@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+)
+
+// DeviceTransferModel represents the GORM model for the transfers of devices between farms
+// This model contains only data persistence concerns and GORM-specific annotations
+type DeviceTransferModel struct {
+	ID               string     `gorm:"primaryKey;size:36;not null" json:"id"`
+	MACAddress       string     `gorm:"size:17;not null;index;uniqueIndex:idx_device_transfers_open,where:status = 'pending' OR status = 'accepted'" json:"mac_address"` // one open transfer per device
+	FromFarmID       string     `gorm:"size:64;not null;default:''" json:"from_farm_id"`
+	ToFarmID         string     `gorm:"size:64;not null" json:"to_farm_id"`
+	ToZoneID         string     `gorm:"size:36;not null;default:''" json:"to_zone_id"`
+	HistoryPolicy    string     `gorm:"size:16;not null" json:"history_policy"`
+	Status           string     `gorm:"size:16;not null;index" json:"status"`
+	Note             string     `gorm:"size:500;not null;default:''" json:"note"`
+	ArchivedReadings int        `gorm:"not null;default:0" json:"archived_readings"`
+	AcceptedAt       *time.Time `json:"accepted_at"`
+	CompletedAt      *time.Time `json:"completed_at"`
+	CancelledAt      *time.Time `json:"cancelled_at"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (DeviceTransferModel) TableName() string {
+	return "device_transfers"
+}
+
+// DeviceTransferAuditModel represents the GORM model for the audit trail of the device transfers
+// This model contains only data persistence concerns and GORM-specific annotations
+type DeviceTransferAuditModel struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	TransferID string    `gorm:"size:36;not null;index" json:"transfer_id"`
+	Action     string    `gorm:"size:16;not null" json:"action"`
+	Actor      string    `gorm:"size:64;not null" json:"actor"`
+	Note       string    `gorm:"size:500;not null;default:''" json:"note"`
+	At         time.Time `gorm:"not null" json:"at"`
+}
+
+// TableName specifies the table name for GORM
+func (DeviceTransferAuditModel) TableName() string {
+	return "device_transfer_audit"
+}
+
+// TransferArchivedMeasurementModel represents the GORM model of a measurement archived with the
+// source farm of a device transfer
+type TransferArchivedMeasurementModel struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	TransferID string    `gorm:"size:36;not null;index" json:"transfer_id"`
+	FarmID     string    `gorm:"size:64;not null;default:''" json:"farm_id"` // source farm of the transfer
+	MACAddress string    `gorm:"size:17;not null;index" json:"mac_address"`
+	SensorType string    `gorm:"size:32;not null" json:"sensor_type"`
+	Channel    int       `gorm:"not null;default:0" json:"channel"`
+	Value      float64   `gorm:"type:double precision;not null" json:"value"`
+	Unit       string    `gorm:"size:32;not null" json:"unit"`
+	Quality    string    `gorm:"size:16;not null;default:good" json:"quality"`
+	CreatedAt  time.Time `gorm:"not null" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (TransferArchivedMeasurementModel) TableName() string {
+	return "transfer_archived_measurements"
+}
+
+// TransferArchivedTemperatureHumidityModel represents the GORM model of a temperature and humidity
+// reading archived with the source farm of a device transfer
+type TransferArchivedTemperatureHumidityModel struct {
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	TransferID         string    `gorm:"size:36;not null;index" json:"transfer_id"`
+	FarmID             string    `gorm:"size:64;not null;default:''" json:"farm_id"` // source farm of the transfer
+	MACAddress         string    `gorm:"size:17;not null;index" json:"mac_address"`
+	TemperatureCelsius float64   `gorm:"type:decimal(5,2);not null" json:"temperature_celsius"`
+	HumidityPercent    float64   `gorm:"type:decimal(5,2);not null" json:"humidity_percent"`
+	CreatedAt          time.Time `gorm:"not null" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (TransferArchivedTemperatureHumidityModel) TableName() string {
+	return "transfer_archived_temperature_humidity"
+}
+
+// TransferArchivedTelemetryDayModel represents the GORM model of a compacted device-day archived
+// with the source farm of a device transfer
+type TransferArchivedTelemetryDayModel struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	TransferID     string    `gorm:"size:36;not null;index" json:"transfer_id"`
+	FarmID         string    `gorm:"size:64;not null;default:''" json:"farm_id"` // source farm of the transfer
+	MACAddress     string    `gorm:"size:17;not null;index" json:"mac_address"`
+	Day            time.Time `gorm:"type:date;not null" json:"day"`
+	Encoding       string    `gorm:"size:20;not null" json:"encoding"`
+	Data           []byte    `gorm:"type:bytea;not null" json:"-"`
+	SampleCount    int       `gorm:"not null" json:"sample_count"`
+	FirstReadingAt time.Time `gorm:"not null" json:"first_reading_at"`
+	LastReadingAt  time.Time `gorm:"not null" json:"last_reading_at"`
+}
+
+// TableName specifies the table name for GORM
+func (TransferArchivedTelemetryDayModel) TableName() string {
+	return "transfer_archived_telemetry_days"
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// FarmModel represents the GORM model for the farms devices are grouped in
+// This model contains only data persistence concerns and GORM-specific annotations
+type FarmModel struct {
+	ID   string `gorm:"primaryKey;size:64;not null" json:"id"`
+	Name string `gorm:"size:100;not null" json:"name"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (FarmModel) TableName() string {
+	return "farms"
+}
+
+// ZoneModel represents the GORM model for the fields of farms and the zones of fields
+// This model contains only data persistence concerns and GORM-specific annotations
+type ZoneModel struct {
+	ID       string `gorm:"primaryKey;size:36;not null" json:"id"`
+	FarmID   string `gorm:"size:64;not null;index" json:"farm_id"`
+	ParentID string `gorm:"size:36;index" json:"parent_id"` // empty for fields
+	Kind     string `gorm:"size:16;not null" json:"kind"`
+	Name     string `gorm:"size:100;not null" json:"name"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ZoneModel) TableName() string {
+	return "zones"
+}
+
+// ZoneDeviceModel represents the GORM model for the zone each device is assigned to
+// This model contains only data persistence concerns and GORM-specific annotations
+type ZoneDeviceModel struct {
+	MACAddress string    `gorm:"primaryKey;size:17;not null" json:"mac_address"`
+	ZoneID     string    `gorm:"size:36;not null;index" json:"zone_id"`
+	AssignedAt time.Time `gorm:"not null" json:"assigned_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ZoneDeviceModel) TableName() string {
+	return "zone_devices"
+}
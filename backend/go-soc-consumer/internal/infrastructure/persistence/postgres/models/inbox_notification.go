@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// InboxNotificationModel represents the GORM model for the in-app notification inbox of each user
+// This model contains only data persistence concerns and GORM-specific annotations
+type InboxNotificationModel struct {
+	ID       string     `gorm:"primaryKey;size:36;not null" json:"id"`
+	User     string     `gorm:"column:user_name;size:64;not null;index:idx_inbox_user_created,priority:1" json:"user"`
+	AlertID  string     `gorm:"size:36;not null" json:"alert_id"`
+	Rule     string     `gorm:"size:64;not null" json:"rule"`
+	Severity string     `gorm:"size:20;not null" json:"severity"`
+	Status   string     `gorm:"size:20;not null" json:"status"`
+	Subject  string     `gorm:"size:255;not null" json:"subject"`
+	Body     string     `gorm:"type:text" json:"body"`
+	ReadAt   *time.Time `json:"read_at"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now();index:idx_inbox_user_created,priority:2" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (InboxNotificationModel) TableName() string {
+	return "inbox_notifications"
+}
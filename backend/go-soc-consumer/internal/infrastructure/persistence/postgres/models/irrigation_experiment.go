@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// IrrigationExperimentModel represents the GORM model for the experiments comparing irrigation strategies
+// This model contains only data persistence concerns and GORM-specific annotations
+type IrrigationExperimentModel struct {
+	ID         string     `gorm:"primaryKey;size:36;not null" json:"id"`
+	Name       string     `gorm:"size:100;not null" json:"name"`
+	Hypothesis string     `gorm:"type:text" json:"hypothesis"`
+	Metadata   string     `gorm:"type:jsonb;not null" json:"metadata"`
+	Variants   string     `gorm:"type:jsonb;not null" json:"variants"` // variants with their strategy and zones, baseline first
+	StartedAt  time.Time  `gorm:"not null;index" json:"started_at"`
+	EndedAt    *time.Time `json:"ended_at"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (IrrigationExperimentModel) TableName() string {
+	return "irrigation_experiments"
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// IrrigationOverrideModel represents the GORM model for the manual overrides of scheduled irrigation
+// This model contains only data persistence concerns and GORM-specific annotations
+type IrrigationOverrideModel struct {
+	MACAddress string    `gorm:"primaryKey;size:17;not null" json:"mac_address"`
+	Reason     string    `gorm:"size:255" json:"reason"`
+	Until      time.Time `gorm:"not null" json:"until"`
+	CreatedAt  time.Time `gorm:"not null" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (IrrigationOverrideModel) TableName() string {
+	return "irrigation_overrides"
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// IrrigationScheduleModel represents the GORM model for the irrigation schedules of devices
+// This model contains only data persistence concerns and GORM-specific annotations
+type IrrigationScheduleModel struct {
+	ID              string     `gorm:"primaryKey;size:36;not null" json:"id"`
+	MACAddress      string     `gorm:"size:17;not null;index" json:"mac_address"`
+	Name            string     `gorm:"size:100;not null" json:"name"`
+	Cron            string     `gorm:"size:100;not null" json:"cron"`
+	DurationSeconds int64      `gorm:"not null" json:"duration_seconds"`
+	Enabled         bool       `gorm:"not null" json:"enabled"`
+	LastRunAt       *time.Time `json:"last_run_at"`
+	RunEndsAt       *time.Time `json:"run_ends_at"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (IrrigationScheduleModel) TableName() string {
+	return "irrigation_schedules"
+}
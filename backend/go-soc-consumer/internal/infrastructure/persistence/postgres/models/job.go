@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// JobModel represents the GORM model for the persistent job queue
+// This model contains only data persistence concerns and GORM-specific annotations
+type JobModel struct {
+	ID              string     `gorm:"primaryKey;size:36;not null" json:"id"`
+	Type            string     `gorm:"size:100;not null;index:idx_jobs_claim,priority:2" json:"type"`
+	Payload         string     `gorm:"type:jsonb;not null" json:"payload"`
+	Status          string     `gorm:"size:20;not null;index:idx_jobs_claim,priority:1" json:"status"`
+	Attempts        int        `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts     int        `gorm:"not null;default:1" json:"max_attempts"`
+	Progress        int        `gorm:"not null;default:0" json:"progress"`
+	ProgressMessage string     `gorm:"size:255" json:"progress_message"`
+	Result          *string    `gorm:"type:jsonb" json:"result"`
+	LastError       string     `gorm:"size:1000" json:"last_error"`
+	CancelRequested bool       `gorm:"not null;default:false" json:"cancel_requested"`
+	RunAt           time.Time  `gorm:"not null;index:idx_jobs_claim,priority:3" json:"run_at"`
+	LeaseUntil      *time.Time `json:"lease_until"`
+	StartedAt       *time.Time `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now();index" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (JobModel) TableName() string {
+	return "jobs"
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// MeasurementModel represents the GORM model of a measurement of any registered sensor type
+type MeasurementModel struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+
+	// Device, sensor type and channel, indexed together with the time for per-probe range queries
+	MACAddress string `gorm:"size:17;not null;index:idx_measurements_device_type_time,priority:1" json:"mac_address"`
+	SensorType string `gorm:"size:32;not null;index:idx_measurements_device_type_time,priority:2;index" json:"sensor_type"`
+	Channel    int    `gorm:"not null;default:0;index:idx_measurements_device_type_time,priority:3" json:"channel"`
+
+	Value   float64 `gorm:"type:double precision;not null" json:"value"`
+	Unit    string  `gorm:"size:32;not null" json:"unit"`
+	Quality string  `gorm:"size:16;not null;default:good" json:"quality"`
+
+	CreatedAt time.Time `gorm:"not null;default:now();index:idx_measurements_device_type_time,priority:4" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (MeasurementModel) TableName() string {
+	return "measurements"
+}
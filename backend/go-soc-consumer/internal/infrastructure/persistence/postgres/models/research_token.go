@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// ResearchTokenModel represents the GORM model for the read-only tokens of research partners
+// This model contains only data persistence concerns and GORM-specific annotations
+type ResearchTokenModel struct {
+	ID        string     `gorm:"primaryKey;size:36;not null" json:"id"`
+	Name      string     `gorm:"size:100;not null" json:"name"`
+	TokenHash string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	Zones     string     `gorm:"type:jsonb;not null" json:"zones"` // IDs of the readable fields and zones
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+
+	// Key of the device pseudonyms, never returned; tokens created before it existed get a random one
+	PseudonymKey string `gorm:"size:64;not null;default:gen_random_uuid()::text" json:"-"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ResearchTokenModel) TableName() string {
+	return "research_tokens"
+}
+
+// ResearchTokenUsageModel represents the GORM model for the daily usage of a research token
+// This model contains only data persistence concerns and GORM-specific annotations
+type ResearchTokenUsageModel struct {
+	TokenID  string    `gorm:"primaryKey;size:36" json:"token_id"`
+	Day      time.Time `gorm:"primaryKey" json:"day"`
+	Requests int64     `gorm:"not null;default:0" json:"requests"`
+	Readings int64     `gorm:"not null;default:0" json:"readings"`
+}
+
+// TableName specifies the table name for GORM
+func (ResearchTokenUsageModel) TableName() string {
+	return "research_token_usage"
+}
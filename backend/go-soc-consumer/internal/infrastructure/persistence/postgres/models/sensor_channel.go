@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// SensorChannelModel represents the GORM model of a probe of a multi-sensor device
+type SensorChannelModel struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+
+	// A device has at most one channel per sensor type and probe index
+	MACAddress string `gorm:"size:17;not null;uniqueIndex:idx_sensor_channels_device_type_channel,priority:1" json:"mac_address"`
+	SensorType string `gorm:"size:32;not null;uniqueIndex:idx_sensor_channels_device_type_channel,priority:2" json:"sensor_type"`
+	Channel    int    `gorm:"not null;uniqueIndex:idx_sensor_channels_device_type_channel,priority:3" json:"channel"`
+
+	Name         string     `gorm:"size:64" json:"name"`
+	Scale        float64    `gorm:"type:double precision;not null;default:1" json:"scale"`
+	Offset       float64    `gorm:"type:double precision;not null;default:0" json:"offset"`
+	CalibratedAt *time.Time `json:"calibrated_at"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (SensorChannelModel) TableName() string {
+	return "sensor_channels"
+}
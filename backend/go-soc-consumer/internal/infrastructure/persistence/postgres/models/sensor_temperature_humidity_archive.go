@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// SensorTemperatureHumidityArchiveModel stores the compressed readings of one device on one UTC day
+// This model contains only data persistence concerns and GORM-specific annotations
+type SensorTemperatureHumidityArchiveModel struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+
+	// Device-day covered by the archive
+	MACAddress string    `gorm:"size:17;not null;uniqueIndex:idx_sensor_th_archive_device_day" json:"mac_address"`
+	Day        time.Time `gorm:"type:date;not null;uniqueIndex:idx_sensor_th_archive_device_day" json:"day"`
+
+	// Compressed readings
+	Encoding       string    `gorm:"size:20;not null" json:"encoding"`
+	Data           []byte    `gorm:"type:bytea;not null" json:"-"`
+	SampleCount    int       `gorm:"not null" json:"sample_count"`
+	FirstReadingAt time.Time `gorm:"not null" json:"first_reading_at"`
+	LastReadingAt  time.Time `gorm:"not null" json:"last_reading_at"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (SensorTemperatureHumidityArchiveModel) TableName() string {
+	return "sensor_temperature_humidity_archive"
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// SyncOutboxModel represents the GORM model for the edge store-and-forward outbox
+// This model contains only data persistence concerns and GORM-specific annotations
+type SyncOutboxModel struct {
+	ID        string     `gorm:"primaryKey;size:36;not null" json:"id"`
+	Kind      string     `gorm:"size:50;not null;index" json:"kind"`
+	EntityKey string     `gorm:"size:100;not null;index" json:"entity_key"`
+	Payload   string     `gorm:"type:jsonb;not null" json:"payload"`
+	ChangedAt time.Time  `gorm:"not null;index" json:"changed_at"`
+	Attempts  int        `gorm:"not null;default:0" json:"attempts"`
+	LastError string     `gorm:"size:500" json:"last_error"`
+	SyncedAt  *time.Time `gorm:"index" json:"synced_at"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now();index" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (SyncOutboxModel) TableName() string {
+	return "sync_outbox"
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// UsageRecordModel represents the GORM model for the metered usage of a farm in a month
+// This model contains only data persistence concerns and GORM-specific annotations
+type UsageRecordModel struct {
+	FarmID        string    `gorm:"primaryKey;size:64" json:"farm_id"` // empty for devices outside farm namespaces
+	Month         time.Time `gorm:"primaryKey" json:"month"`
+	Messages      int64     `gorm:"not null;default:0" json:"messages"`
+	StorageBytes  int64     `gorm:"not null;default:0" json:"storage_bytes"`
+	Notifications int64     `gorm:"not null;default:0" json:"notifications"`
+	UpdatedAt     time.Time `gorm:"not null" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UsageRecordModel) TableName() string {
+	return "usage_records"
+}
+
+// UsageDeviceDayModel represents the GORM model for the devices a farm had on a day
+// This model contains only data persistence concerns and GORM-specific annotations
+type UsageDeviceDayModel struct {
+	FarmID  string    `gorm:"primaryKey;size:64" json:"farm_id"`
+	Day     time.Time `gorm:"primaryKey" json:"day"`
+	Devices int       `gorm:"not null" json:"devices"`
+}
+
+// TableName specifies the table name for GORM
+func (UsageDeviceDayModel) TableName() string {
+	return "usage_device_days"
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// UserModel represents the GORM model for the users of the HTTP API
+// This model contains only data persistence concerns and GORM-specific annotations
+type UserModel struct {
+	ID           string `gorm:"primaryKey;size:36;not null" json:"id"`
+	Username     string `gorm:"size:64;not null;uniqueIndex" json:"username"`
+	PasswordHash string `gorm:"size:60;not null" json:"-"`
+	Role         string `gorm:"size:16;not null" json:"role"`
+
+	// Audit fields (GORM will handle these automatically)
+	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UserModel) TableName() string {
+	return "users"
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// ValveStateModel represents the GORM model for the state transitions of irrigation valves
+// This model contains only data persistence concerns and GORM-specific annotations
+type ValveStateModel struct {
+	CommandID  string    `gorm:"primaryKey;size:64;not null" json:"command_id"`
+	MACAddress string    `gorm:"size:17;not null;index:idx_valve_states_mac_changed,priority:1" json:"mac_address"`
+	State      string    `gorm:"size:16;not null" json:"state"`
+	ChangedAt  time.Time `gorm:"not null;index:idx_valve_states_mac_changed,priority:2" json:"changed_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ValveStateModel) TableName() string {
+	return "valve_states"
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// notificationInboxRepository implements the NotificationInboxRepository interface using GORM PostgreSQL
+type notificationInboxRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.InboxNotificationMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewNotificationInboxRepository creates a new GORM-based PostgreSQL notification inbox repository
+func NewNotificationInboxRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.NotificationInboxRepository {
+	return &notificationInboxRepository{
+		db:     db,
+		mapper: mappers.NewInboxNotificationMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new inbox notification
+func (r *notificationInboxRepository) Create(ctx context.Context, notification *entities.InboxNotification) error {
+	if notification == nil {
+		return fmt.Errorf("inbox notification cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Create(r.mapper.ToModel(notification))
+	if result.Error != nil {
+		r.logger.Error("inbox_notification_create_failed", zap.String("operation", "create"), zap.String("table", "inbox_notifications"), zap.String("user", notification.User), zap.Error(result.Error))
+		return fmt.Errorf("failed to create inbox notification: %w", result.Error)
+	}
+	return nil
+}
+
+// List returns the latest notifications of the user, newest first
+func (r *notificationInboxRepository) List(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int) ([]*entities.InboxNotification, error) {
+	query := r.db.GetDB().WithContext(ctx).Where("user_name = ?", user)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if !before.IsZero() {
+		query = query.Where("created_at < ?", before)
+	}
+
+	var records []models.InboxNotificationModel
+	result := query.Order("created_at DESC").Limit(limit).Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list inbox notifications: %w", result.Error)
+	}
+
+	notifications := make([]*entities.InboxNotification, 0, len(records))
+	for i := range records {
+		notifications = append(notifications, r.mapper.FromModel(&records[i]))
+	}
+	return notifications, nil
+}
+
+// CountUnread returns the number of unread notifications of the user by severity
+func (r *notificationInboxRepository) CountUnread(ctx context.Context, user string) (map[string]int, error) {
+	var rows []struct {
+		Severity string
+		Count    int
+	}
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.InboxNotificationModel{}).
+		Select("severity, COUNT(*) AS count").
+		Where("user_name = ? AND read_at IS NULL", user).
+		Group("severity").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to count unread inbox notifications: %w", result.Error)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Severity] = row.Count
+	}
+	return counts, nil
+}
+
+// MarkRead flags a notification of the user as read
+func (r *notificationInboxRepository) MarkRead(ctx context.Context, user, id string, readAt time.Time) error {
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.InboxNotificationModel{}).
+		Where("id = ? AND user_name = ?", id, user).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", readAt))
+	if result.Error != nil {
+		r.logger.Error("inbox_notification_mark_read_failed", zap.String("table", "inbox_notifications"), zap.String("user", user), zap.String("id", id), zap.Error(result.Error))
+		return fmt.Errorf("failed to mark inbox notification as read: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrInboxNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead flags every unread notification of the user as read
+func (r *notificationInboxRepository) MarkAllRead(ctx context.Context, user string, readAt time.Time) (int, error) {
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.InboxNotificationModel{}).
+		Where("user_name = ? AND read_at IS NULL", user).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		r.logger.Error("inbox_notifications_mark_all_read_failed", zap.String("table", "inbox_notifications"), zap.String("user", user), zap.Error(result.Error))
+		return 0, fmt.Errorf("failed to mark inbox notifications as read: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+// DeleteCreatedBefore removes the notifications created before the given time
+func (r *notificationInboxRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error) {
+	result := r.db.GetDB().WithContext(ctx).Where("created_at < ?", before).Delete(&models.InboxNotificationModel{})
+	if result.Error != nil {
+		r.logger.Error("inbox_notifications_prune_failed", zap.String("operation", "delete"), zap.String("table", "inbox_notifications"), zap.Error(result.Error))
+		return 0, fmt.Errorf("failed to prune inbox notifications: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupNotificationInboxTestRepository initializes a test repository with a mock database
+func setupNotificationInboxTestRepository(t *testing.T) (*notificationInboxRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewNotificationInboxRepository(postgresDB, loggerFactory).(*notificationInboxRepository), sqlMock
+}
+
+func TestNotificationInboxRepository_Create(t *testing.T) {
+	repo, mock := setupNotificationInboxTestRepository(t)
+	mock.ExpectQuery(`INSERT INTO "inbox_notifications" .* RETURNING`).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+
+	require.NoError(t, repo.Create(context.Background(), &entities.InboxNotification{
+		ID:        "notification-1",
+		User:      "ana",
+		AlertID:   "alert-1",
+		Rule:      "dry_soil",
+		Severity:  entities.AlertSeverityWarning,
+		Status:    entities.AlertStatusFiring,
+		Subject:   "[warning] alert dry_soil is firing",
+		CreatedAt: time.Now(),
+	}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationInboxRepository_List(t *testing.T) {
+	before := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo, mock := setupNotificationInboxTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "inbox_notifications" WHERE user_name = \$1 AND read_at IS NULL AND created_at < \$2 ORDER BY created_at DESC LIMIT \$3`).
+		WithArgs("ana", before, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_name", "alert_id", "rule", "severity", "status", "subject", "body", "read_at", "created_at"}).
+			AddRow("notification-1", "ana", "alert-1", "dry_soil", entities.AlertSeverityWarning, entities.AlertStatusFiring, "subject", "body", nil, before.Add(-time.Minute)))
+
+	notifications, err := repo.List(context.Background(), "ana", true, before, 10)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "alert-1", notifications[0].AlertID)
+	assert.False(t, notifications[0].Read())
+}
+
+func TestNotificationInboxRepository_CountUnread(t *testing.T) {
+	repo, mock := setupNotificationInboxTestRepository(t)
+	mock.ExpectQuery(`SELECT severity, COUNT\(\*\) AS count FROM "inbox_notifications" WHERE user_name = \$1 AND read_at IS NULL GROUP BY "severity"`).
+		WithArgs("ana").
+		WillReturnRows(sqlmock.NewRows([]string{"severity", "count"}).AddRow(entities.AlertSeverityCritical, 2).AddRow(entities.AlertSeverityInfo, 5))
+
+	counts, err := repo.CountUnread(context.Background(), "ana")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{entities.AlertSeverityCritical: 2, entities.AlertSeverityInfo: 5}, counts)
+}
+
+func TestNotificationInboxRepository_MarkRead(t *testing.T) {
+	readAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should keep the first read time", func(t *testing.T) {
+		repo, mock := setupNotificationInboxTestRepository(t)
+		mock.ExpectExec(`UPDATE "inbox_notifications" SET "read_at"=COALESCE\(read_at, \$1\) WHERE id = \$2 AND user_name = \$3`).
+			WithArgs(readAt, "notification-1", "ana").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.MarkRead(context.Background(), "ana", "notification-1", readAt))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return not found for notifications of other users", func(t *testing.T) {
+		repo, mock := setupNotificationInboxTestRepository(t)
+		mock.ExpectExec(`UPDATE "inbox_notifications"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, repo.MarkRead(context.Background(), "bob", "notification-1", readAt), domainerrors.ErrInboxNotificationNotFound)
+	})
+
+	t.Run("should mark every unread notification", func(t *testing.T) {
+		repo, mock := setupNotificationInboxTestRepository(t)
+		mock.ExpectExec(`UPDATE "inbox_notifications" SET "read_at"=\$1 WHERE user_name = \$2 AND read_at IS NULL`).
+			WithArgs(readAt, "ana").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		marked, err := repo.MarkAllRead(context.Background(), "ana", readAt)
+		require.NoError(t, err)
+		assert.Equal(t, 3, marked)
+	})
+}
+
+func TestNotificationInboxRepository_DeleteCreatedBefore(t *testing.T) {
+	before := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	repo, mock := setupNotificationInboxTestRepository(t)
+	mock.ExpectExec(`DELETE FROM "inbox_notifications" WHERE created_at < \$1`).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 7))
+
+	removed, err := repo.DeleteCreatedBefore(context.Background(), before)
+	require.NoError(t, err)
+	assert.Equal(t, 7, removed)
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// researchTokenUsageConflict is the key of the daily usage of a research token
+var researchTokenUsageConflict = []clause.Column{{Name: "token_id"}, {Name: "day"}}
+
+// researchTokenRepository implements the ResearchTokenRepository interface using GORM PostgreSQL
+type researchTokenRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.ResearchTokenMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewResearchTokenRepository creates a new GORM-based PostgreSQL research token repository
+func NewResearchTokenRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.ResearchTokenRepository {
+	return &researchTokenRepository{
+		db:     db,
+		mapper: mappers.NewResearchTokenMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new research token
+func (r *researchTokenRepository) Create(ctx context.Context, token *entities.ResearchToken) error {
+	if token == nil {
+		return fmt.Errorf("research token cannot be nil")
+	}
+
+	model, err := r.mapper.ToModel(token)
+	if err != nil {
+		return err
+	}
+	result := r.db.GetDB().WithContext(ctx).Create(model)
+	if result.Error != nil {
+		r.logger.Error("research_token_create_failed", zap.String("operation", "create"), zap.String("table", "research_tokens"), zap.String("id", token.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to create research token: %w", result.Error)
+	}
+	return nil
+}
+
+// Update stores the revocation of a research token
+func (r *researchTokenRepository) Update(ctx context.Context, token *entities.ResearchToken) error {
+	if token == nil {
+		return fmt.Errorf("research token cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.ResearchTokenModel{}).
+		Where("id = ?", token.ID).
+		Updates(map[string]interface{}{"revoked_at": token.RevokedAt, "updated_at": token.UpdatedAt})
+	if result.Error != nil {
+		r.logger.Error("research_token_update_failed", zap.String("operation", "update"), zap.String("table", "research_tokens"), zap.String("id", token.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to update research token: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrResearchTokenNotFound
+	}
+	return nil
+}
+
+// FindByID retrieves a research token by its ID
+func (r *researchTokenRepository) FindByID(ctx context.Context, id string) (*entities.ResearchToken, error) {
+	return r.find(ctx, "id = ?", id)
+}
+
+// FindByHash retrieves a research token by the hash of its secret
+func (r *researchTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entities.ResearchToken, error) {
+	return r.find(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *researchTokenRepository) find(ctx context.Context, query string, value string) (*entities.ResearchToken, error) {
+	var model models.ResearchTokenModel
+	result := r.db.GetDB().WithContext(ctx).Where(query, value).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrResearchTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to find research token: %w", result.Error)
+	}
+	return r.mapper.FromModel(&model)
+}
+
+// List returns every research token, most recently created first
+func (r *researchTokenRepository) List(ctx context.Context) ([]*entities.ResearchToken, error) {
+	var records []models.ResearchTokenModel
+	result := r.db.GetDB().WithContext(ctx).Order("created_at DESC").Order("id ASC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list research tokens: %w", result.Error)
+	}
+
+	tokens := make([]*entities.ResearchToken, 0, len(records))
+	for i := range records {
+		token, err := r.mapper.FromModel(&records[i])
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// RecordUsage adds a request to the daily usage of the token in a single statement
+func (r *researchTokenRepository) RecordUsage(ctx context.Context, tokenID string, day time.Time, readings int) error {
+	row := &models.ResearchTokenUsageModel{
+		TokenID:  tokenID,
+		Day:      entities.ResearchUsageDay(day),
+		Requests: 1,
+		Readings: int64(readings),
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: researchTokenUsageConflict,
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"requests": gorm.Expr("research_token_usage.requests + excluded.requests"),
+				"readings": gorm.Expr("research_token_usage.readings + excluded.readings"),
+			}),
+		}).
+		Create(row)
+	if result.Error != nil {
+		r.logger.Error("research_token_usage_failed", zap.String("operation", "upsert"), zap.String("table", "research_token_usage"), zap.String("token_id", tokenID), zap.Error(result.Error))
+		return fmt.Errorf("failed to record research token usage: %w", result.Error)
+	}
+	return nil
+}
+
+// Usage returns the daily usage of a token, oldest first
+func (r *researchTokenRepository) Usage(ctx context.Context, tokenID string, from, to time.Time) ([]*entities.ResearchTokenUsage, error) {
+	var records []models.ResearchTokenUsageModel
+	result := r.db.GetDB().WithContext(ctx).
+		Where("token_id = ? AND day >= ? AND day < ?", tokenID, from, to).
+		Order("day ASC").
+		Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to load research token usage: %w", result.Error)
+	}
+
+	usage := make([]*entities.ResearchTokenUsage, 0, len(records))
+	for i := range records {
+		usage = append(usage, r.mapper.UsageFromModel(&records[i]))
+	}
+	return usage, nil
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupResearchTokenTestRepository initializes a test repository with a mock database
+func setupResearchTokenTestRepository(t *testing.T) (*researchTokenRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewResearchTokenRepository(postgresDB, loggerFactory).(*researchTokenRepository), sqlMock
+}
+
+func TestResearchTokenRepository_Create(t *testing.T) {
+	repo, mock := setupResearchTokenTestRepository(t)
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := &entities.ResearchToken{ID: "token-1", Name: "Universidad Nacional", TokenHash: "hash", PseudonymKey: "key", Zones: []string{"zone-a"}, ExpiresAt: at.Add(time.Hour), CreatedAt: at, UpdatedAt: at}
+
+	mock.ExpectQuery(`INSERT INTO "research_tokens" \("id","name","token_hash","zones","expires_at","revoked_at","pseudonym_key","created_at","updated_at"\) .* RETURNING`).
+		WithArgs("token-1", "Universidad Nacional", "hash", `["zone-a"]`, token.ExpiresAt, nil, "key", at, at).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(at, at))
+
+	require.NoError(t, repo.Create(context.Background(), token))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResearchTokenRepository_FindByHash(t *testing.T) {
+	t.Run("should map the token", func(t *testing.T) {
+		repo, mock := setupResearchTokenTestRepository(t)
+		at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT \* FROM "research_tokens" WHERE token_hash = \$1`).
+			WithArgs("hash", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "token_hash", "zones", "expires_at", "revoked_at", "created_at", "updated_at"}).
+				AddRow("token-1", "Universidad Nacional", "hash", `["zone-a","zone-b"]`, at.Add(time.Hour), nil, at, at))
+
+		token, err := repo.FindByHash(context.Background(), "hash")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"zone-a", "zone-b"}, token.Zones)
+		assert.Nil(t, token.RevokedAt)
+	})
+
+	t.Run("should report unknown tokens", func(t *testing.T) {
+		repo, mock := setupResearchTokenTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "research_tokens"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.FindByHash(context.Background(), "hash")
+		assert.ErrorIs(t, err, domainerrors.ErrResearchTokenNotFound)
+	})
+}
+
+func TestResearchTokenRepository_RecordUsage(t *testing.T) {
+	repo, mock := setupResearchTokenTestRepository(t)
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`INSERT INTO "research_token_usage" \("token_id","day","requests","readings"\) VALUES \(\$1,\$2,\$3,\$4\) ON CONFLICT \("token_id","day"\) DO UPDATE SET "readings"=research_token_usage.readings \+ excluded.readings,"requests"=research_token_usage.requests \+ excluded.requests`).
+		WithArgs("token-1", day, 1, 120).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.RecordUsage(context.Background(), "token-1", day.Add(15*time.Hour), 120))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResearchTokenRepository_Usage(t *testing.T) {
+	repo, mock := setupResearchTokenTestRepository(t)
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT \* FROM "research_token_usage" WHERE token_id = \$1 AND day >= \$2 AND day < \$3 ORDER BY day ASC`).
+		WithArgs("token-1", day, day.Add(48*time.Hour)).
+		WillReturnRows(sqlmock.NewRows([]string{"token_id", "day", "requests", "readings"}).AddRow("token-1", day, 3, 360))
+
+	usage, err := repo.Usage(context.Background(), "token-1", day, day.Add(48*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []*entities.ResearchTokenUsage{{TokenID: "token-1", Day: day, Requests: 3, Readings: 360}}, usage)
+}
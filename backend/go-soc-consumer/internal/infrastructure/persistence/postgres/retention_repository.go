@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// retentionTable is the table of a dataset and the column its rows age by
+type retentionTable struct {
+	name       string
+	timeColumn string
+	daily      bool // rows cover a whole day, kept until the day ended before the cutoff
+	hypertable bool // a hypertable in TimescaleDB mode
+}
+
+var retentionTables = map[string]retentionTable{
+	entities.RetentionTemperatureHumidity: {name: "sensor_temperature_humidity", timeColumn: "created_at", hypertable: true},
+	entities.RetentionMeasurements:        {name: "measurements", timeColumn: "created_at", hypertable: true},
+	entities.RetentionTelemetryArchive:    {name: "sensor_temperature_humidity_archive", timeColumn: "day", daily: true},
+}
+
+// retentionRepository implements the RetentionRepository interface using GORM PostgreSQL
+type retentionRepository struct {
+	db     *database.GormPostgresDB
+	logger pkglogger.CoreLogger
+}
+
+// NewRetentionRepository creates a new GORM-based PostgreSQL retention repository
+func NewRetentionRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.RetentionRepository {
+	return &retentionRepository{
+		db:     db,
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Prune deletes a batch of the oldest rows by their physical location, since the raw readings have no
+// primary key. Readings are removed for good, bypassing soft deletes.
+func (r *retentionRepository) Prune(ctx context.Context, dataset string, before time.Time, limit int) (int, error) {
+	table, ok := retentionTables[dataset]
+	if !ok {
+		return 0, fmt.Errorf("unknown retention dataset: %s", dataset)
+	}
+	before = before.UTC()
+	if table.daily {
+		before = before.Truncate(24 * time.Hour)
+	}
+	if table.hypertable && r.db.Timescale() {
+		return r.dropChunks(ctx, table, before)
+	}
+
+	statement := fmt.Sprintf("DELETE FROM %q WHERE ctid = ANY(ARRAY(SELECT ctid FROM %q WHERE %s < ? LIMIT ?))", table.name, table.name, table.timeColumn)
+	result := r.db.GetDB().WithContext(ctx).Exec(statement, before, limit)
+	if result.Error != nil {
+		r.logger.Error("retention_prune_failed", zap.String("operation", "prune"), zap.String("table", table.name), zap.Error(result.Error))
+		return 0, fmt.Errorf("failed to prune %s: %w", dataset, result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+// dropChunks drops the chunks of the hypertable that ended before the cutoff, counting their rows
+// first. Chunks do not overlap, so the rows before the end of the newest dropped chunk are exactly
+// the rows dropped.
+func (r *retentionRepository) dropChunks(ctx context.Context, table retentionTable, before time.Time) (int, error) {
+	db := r.db.GetDB().WithContext(ctx)
+
+	var end sql.NullTime
+	if err := db.Raw("SELECT max(range_end) FROM timescaledb_information.chunks WHERE hypertable_name = ? AND range_end <= ?", table.name, before).Scan(&end).Error; err != nil {
+		return 0, fmt.Errorf("failed to list the chunks of %s: %w", table.name, err)
+	}
+	if !end.Valid {
+		return 0, nil
+	}
+
+	var rows int64
+	if err := db.Raw(fmt.Sprintf("SELECT count(*) FROM %q WHERE %s < ?", table.name, table.timeColumn), end.Time).Scan(&rows).Error; err != nil {
+		return 0, fmt.Errorf("failed to count the rows of %s: %w", table.name, err)
+	}
+	if err := db.Exec("SELECT drop_chunks(?::regclass, older_than => ?::timestamptz)", table.name, before).Error; err != nil {
+		r.logger.Error("retention_prune_failed", zap.String("operation", "drop_chunks"), zap.String("table", table.name), zap.Error(err))
+		return 0, fmt.Errorf("failed to drop the chunks of %s: %w", table.name, err)
+	}
+	return int(rows), nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupRetentionTestRepository initializes a test repository with a mock database
+func setupRetentionTestRepository(t *testing.T) (*retentionRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewRetentionRepository(postgresDB, loggerFactory).(*retentionRepository), sqlMock
+}
+
+func TestRetentionRepository_Prune(t *testing.T) {
+	before := time.Date(2025, 6, 10, 15, 30, 0, 0, time.UTC)
+
+	t.Run("should delete a batch of old readings", func(t *testing.T) {
+		repo, mock := setupRetentionTestRepository(t)
+		mock.ExpectExec(`DELETE FROM "measurements" WHERE ctid = ANY\(ARRAY\(SELECT ctid FROM "measurements" WHERE created_at < \$1 LIMIT \$2\)\)`).
+			WithArgs(before, 10000).
+			WillReturnResult(sqlmock.NewResult(0, 10000))
+
+		rows, err := repo.Prune(context.Background(), entities.RetentionMeasurements, before, 10000)
+		require.NoError(t, err)
+		assert.Equal(t, 10000, rows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should keep the archived day the cutoff falls in", func(t *testing.T) {
+		repo, mock := setupRetentionTestRepository(t)
+		mock.ExpectExec(`DELETE FROM "sensor_temperature_humidity_archive" WHERE ctid = ANY\(ARRAY\(SELECT ctid FROM "sensor_temperature_humidity_archive" WHERE day < \$1 LIMIT \$2\)\)`).
+			WithArgs(time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC), 500).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		rows, err := repo.Prune(context.Background(), entities.RetentionTelemetryArchive, before, 500)
+		require.NoError(t, err)
+		assert.Equal(t, 3, rows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should reject unknown datasets", func(t *testing.T) {
+		repo, _ := setupRetentionTestRepository(t)
+
+		_, err := repo.Prune(context.Background(), "dead_letters", before, 500)
+		assert.ErrorContains(t, err, "unknown retention dataset")
+	})
+}
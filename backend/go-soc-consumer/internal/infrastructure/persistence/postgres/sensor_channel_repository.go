@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// sensorChannelConflict identifies a channel by its device, sensor type and probe index
+var sensorChannelConflict = []clause.Column{{Name: "mac_address"}, {Name: "sensor_type"}, {Name: "channel"}}
+
+// sensorChannelRepository implements the SensorChannelRepository interface using GORM PostgreSQL
+type sensorChannelRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.SensorChannelMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewSensorChannelRepository creates a new GORM-based PostgreSQL sensor channel repository
+func NewSensorChannelRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.SensorChannelRepository {
+	return &sensorChannelRepository{
+		db:     db,
+		mapper: mappers.NewSensorChannelMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// FindByDevice returns the channels of a device ordered by sensor type and channel
+func (r *sensorChannelRepository) FindByDevice(ctx context.Context, macAddress string) ([]*entities.SensorChannel, error) {
+	var records []models.SensorChannelModel
+	result := r.db.GetDB().WithContext(ctx).
+		Where("mac_address = ?", macAddress).
+		Order("sensor_type ASC, channel ASC").
+		Find(&records)
+	if result.Error != nil {
+		r.logger.Error("sensor_channels_query_failed", zap.String("operation", "find_by_device"), zap.String("table", "sensor_channels"), zap.String("mac_address", macAddress), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to find sensor channels: %w", result.Error)
+	}
+
+	channels := make([]*entities.SensorChannel, 0, len(records))
+	for i := range records {
+		channels = append(channels, r.mapper.FromModel(&records[i]))
+	}
+	return channels, nil
+}
+
+// Save inserts the channel or updates the name and calibration of the stored one
+func (r *sensorChannelRepository) Save(ctx context.Context, channel *entities.SensorChannel) error {
+	if channel == nil {
+		return fmt.Errorf("sensor channel cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   sensorChannelConflict,
+			DoUpdates: clause.AssignmentColumns([]string{"name", "scale", "offset", "calibrated_at", "updated_at"}),
+		}).
+		Create(r.mapper.ToModel(channel))
+	if result.Error != nil {
+		r.logger.Error("sensor_channel_save_failed", zap.String("operation", "save"), zap.String("table", "sensor_channels"), zap.String("mac_address", channel.MACAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to save sensor channel: %w", result.Error)
+	}
+	return nil
+}
+
+// CreateMissing inserts the channels in one statement, skipping those already stored
+func (r *sensorChannelRepository) CreateMissing(ctx context.Context, channels []*entities.SensorChannel) error {
+	if len(channels) == 0 {
+		return nil
+	}
+
+	records := make([]*models.SensorChannelModel, 0, len(channels))
+	for _, channel := range channels {
+		records = append(records, r.mapper.ToModel(channel))
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: sensorChannelConflict, DoNothing: true}).
+		Create(&records)
+	if result.Error != nil {
+		r.logger.Error("sensor_channels_create_failed", zap.String("operation", "create_missing"), zap.String("table", "sensor_channels"), zap.Int("count", len(records)), zap.Error(result.Error))
+		return fmt.Errorf("failed to create sensor channels: %w", result.Error)
+	}
+	return nil
+}
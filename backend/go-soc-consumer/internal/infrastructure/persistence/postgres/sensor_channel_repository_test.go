@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupSensorChannelTestRepository initializes a test repository with a mock database
+func setupSensorChannelTestRepository(t *testing.T) (*sensorChannelRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewSensorChannelRepository(postgresDB, loggerFactory).(*sensorChannelRepository), sqlMock
+}
+
+func TestSensorChannelRepository_FindByDevice(t *testing.T) {
+	repo, mock := setupSensorChannelTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "sensor_channels" WHERE mac_address = \$1 ORDER BY sensor_type ASC, channel ASC`).
+		WithArgs("AA:BB:CC:DD:EE:FF").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "mac_address", "sensor_type", "channel", "name", "scale", "offset", "calibrated_at", "created_at", "updated_at"}).
+			AddRow(1, "AA:BB:CC:DD:EE:FF", "ph", 2, "bed 3", 1.1, -0.2, time.Now(), time.Now(), time.Now()))
+
+	channels, err := repo.FindByDevice(context.Background(), "AA:BB:CC:DD:EE:FF")
+
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	assert.Equal(t, 2, channels[0].Channel)
+	assert.Equal(t, "bed 3", channels[0].Name)
+	assert.Equal(t, 1.1, channels[0].Scale)
+	assert.NotNil(t, channels[0].CalibratedAt)
+}
+
+func TestSensorChannelRepository_Save(t *testing.T) {
+	channel, err := entities.NewSensorChannel("AA:BB:CC:DD:EE:FF", "ph", 2)
+	require.NoError(t, err)
+
+	repo, mock := setupSensorChannelTestRepository(t)
+	mock.ExpectQuery(`INSERT INTO "sensor_channels" .* ON CONFLICT \("mac_address","sensor_type","channel"\) DO UPDATE SET "name"="excluded"."name"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	require.NoError(t, repo.Save(context.Background(), channel))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSensorChannelRepository_CreateMissing(t *testing.T) {
+	t.Run("should skip stored channels", func(t *testing.T) {
+		channel, err := entities.NewSensorChannel("AA:BB:CC:DD:EE:FF", "ph", 2)
+		require.NoError(t, err)
+
+		repo, mock := setupSensorChannelTestRepository(t)
+		mock.ExpectQuery(`INSERT INTO "sensor_channels" .* ON CONFLICT \("mac_address","sensor_type","channel"\) DO NOTHING`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		require.NoError(t, repo.CreateMissing(context.Background(), []*entities.SensorChannel{channel}))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should do nothing without channels", func(t *testing.T) {
+		repo, mock := setupSensorChannelTestRepository(t)
+
+		require.NoError(t, repo.CreateMissing(context.Background(), nil))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
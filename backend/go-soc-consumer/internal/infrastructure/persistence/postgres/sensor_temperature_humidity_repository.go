@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
@@ -10,22 +12,26 @@ import (
 	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
 	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type sensorTemperatureHumidityRepository struct {
-	db      *database.GormPostgresDB
-	mapper  *mappers.SensorTemperatureHumidityMapper
-	coreLog pkglogger.CoreLogger
+	db            *database.GormPostgresDB
+	mapper        *mappers.SensorTemperatureHumidityMapper
+	archiveMapper *mappers.SensorTemperatureHumidityArchiveMapper
+	coreLog       pkglogger.CoreLogger
 }
 
 // NewSensorTemperatureHumidityRepository creates a new GORM-based PostgreSQL sensor temperature humidity repository
 func NewSensorTemperatureHumidityRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.SensorTemperatureHumidityRepository {
 	return &sensorTemperatureHumidityRepository{
-		db:      db,
-		mapper:  mappers.NewSensorTemperatureHumidityMapper(),
-		coreLog: loggerFactory.Core(),
+		db:            db,
+		mapper:        mappers.NewSensorTemperatureHumidityMapper(),
+		archiveMapper: mappers.NewSensorTemperatureHumidityArchiveMapper(),
+		coreLog:       loggerFactory.Core(),
 	}
 }
 
@@ -62,3 +68,223 @@ func (r *sensorTemperatureHumidityRepository) Create(ctx context.Context, sensor
 	r.coreLog.Info("sensor_temperature_humidity_created_successfully", zap.String("mac_address", sensorData.MacAddress()), zap.String("component", "sensor_temperature_humidity_repository"))
 	return nil
 }
+
+// Upsert replaces the readings of the device stamped within window of the reading's timestamp,
+// inserting it when there are none
+func (r *sensorTemperatureHumidityRepository) Upsert(ctx context.Context, sensorData *entities.SensorTemperatureHumidity, window time.Duration) error {
+	if sensorData == nil {
+		return fmt.Errorf("sensor data cannot be nil")
+	}
+
+	sensorData.Normalize()
+	if err := sensorData.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	model := r.mapper.ToModel(sensorData)
+	start := time.Now()
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var compacted int64
+		if err := tx.Model(&models.SensorTemperatureHumidityArchiveModel{}).
+			Where("mac_address = ? AND day = ?", model.MACAddress, model.CreatedAt.UTC().Truncate(24*time.Hour)).
+			Count(&compacted).Error; err != nil {
+			return err
+		}
+		if compacted > 0 {
+			return domainerrors.ErrSensorTemperatureHumidityCompacted
+		}
+
+		result := tx.Model(&models.SensorTemperatureHumidityModel{}).
+			Where("mac_address = ? AND created_at BETWEEN ? AND ?", model.MACAddress, model.CreatedAt.Add(-window), model.CreatedAt.Add(window)).
+			Updates(map[string]interface{}{
+				"temperature_celsius": model.TemperatureCelsius,
+				"humidity_percent":    model.HumidityPercent,
+				"created_at":          model.CreatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+		return tx.Create(model).Error
+	})
+	duration := time.Since(start)
+
+	if errors.Is(err, domainerrors.ErrSensorTemperatureHumidityCompacted) {
+		return err
+	}
+	if err != nil {
+		r.coreLog.Error("sensor_temperature_humidity_not_upserted", zap.String("operation", "upsert"), zap.String("table", "sensor_temperature_humidities"), zap.Duration("duration", duration), zap.Error(err))
+		return fmt.Errorf("failed to upsert sensor temperature humidity: %w", err)
+	}
+
+	r.coreLog.Debug("sensor_temperature_humidity_upserted", zap.String("mac_address", sensorData.MacAddress()), zap.Duration("duration", duration), zap.String("component", "sensor_temperature_humidity_repository"))
+	return nil
+}
+
+// FindByMACAddress retrieves the readings of a device in [from, to), merging raw rows with
+// compressed archives which are decompressed on demand
+func (r *sensorTemperatureHumidityRepository) FindByMACAddress(ctx context.Context, macAddress string, from, to time.Time) ([]*entities.SensorTemperatureHumidity, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("invalid time range: from must be before to")
+	}
+
+	start := time.Now()
+	var rawModels []*models.SensorTemperatureHumidityModel
+	result := r.db.GetDB().WithContext(ctx).
+		Where("mac_address = ? AND created_at >= ? AND created_at < ?", macAddress, from, to).
+		Order("created_at ASC").
+		Find(&rawModels)
+	if result.Error != nil {
+		r.coreLog.Error("sensor_temperature_humidity_query_failed", zap.String("operation", "find_by_mac"), zap.String("table", "sensor_temperature_humidity"), zap.Duration("duration", time.Since(start)), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to query sensor temperature humidity: %w", result.Error)
+	}
+
+	readings, err := r.mapper.FromModelSlice(rawModels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map sensor temperature humidity: %w", err)
+	}
+
+	var archives []*models.SensorTemperatureHumidityArchiveModel
+	result = r.db.GetDB().WithContext(ctx).
+		Where("mac_address = ? AND day >= ? AND day < ?", macAddress, entities.StartOfDay(from), to).
+		Order("day ASC").
+		Find(&archives)
+	if result.Error != nil {
+		r.coreLog.Error("sensor_temperature_humidity_query_failed", zap.String("operation", "find_by_mac"), zap.String("table", "sensor_temperature_humidity_archive"), zap.Duration("duration", time.Since(start)), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to query sensor temperature humidity archive: %w", result.Error)
+	}
+
+	for _, archive := range archives {
+		archived, err := r.archiveMapper.FromModel(archive)
+		if err != nil {
+			return nil, err
+		}
+		for _, reading := range archived {
+			if !reading.Timestamp().Before(from) && reading.Timestamp().Before(to) {
+				readings = append(readings, reading)
+			}
+		}
+	}
+
+	if len(archives) > 0 {
+		sort.SliceStable(readings, func(i, j int) bool {
+			return readings[i].Timestamp().Before(readings[j].Timestamp())
+		})
+	}
+
+	r.coreLog.Debug("sensor_temperature_humidity_found", zap.String("mac_address", macAddress), zap.Int("readings", len(readings)), zap.Int("archives", len(archives)), zap.Duration("duration", time.Since(start)), zap.String("component", "sensor_temperature_humidity_repository"))
+	return readings, nil
+}
+
+// ListByMACAddress retrieves a page of the readings of a device in [from, to), newest first. Raw
+// readings come first; only days older than the raw readings are compressed, so the archives are
+// read only once the page reaches past the raw readings.
+func (r *sensorTemperatureHumidityRepository) ListByMACAddress(ctx context.Context, macAddress string, from, to time.Time, offset, limit int) ([]*entities.SensorTemperatureHumidity, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("invalid time range: from must be before to")
+	}
+	if offset < 0 || limit < 1 {
+		return nil, fmt.Errorf("invalid page: offset must not be negative and limit must be positive")
+	}
+
+	start := time.Now()
+	var rawModels []*models.SensorTemperatureHumidityModel
+	result := r.db.GetDB().WithContext(ctx).
+		Where("mac_address = ? AND created_at >= ? AND created_at < ?", macAddress, from, to).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&rawModels)
+	if result.Error != nil {
+		r.coreLog.Error("sensor_temperature_humidity_query_failed", zap.String("operation", "list_by_mac"), zap.String("table", "sensor_temperature_humidity"), zap.Duration("duration", time.Since(start)), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to query sensor temperature humidity: %w", result.Error)
+	}
+
+	readings, err := r.mapper.FromModelSlice(rawModels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map sensor temperature humidity: %w", err)
+	}
+	if len(readings) == limit {
+		return readings, nil
+	}
+
+	// The page starts past the raw readings when it holds none of them
+	skip := 0
+	if len(readings) == 0 && offset > 0 {
+		var rawCount int64
+		result = r.db.GetDB().WithContext(ctx).
+			Model(&models.SensorTemperatureHumidityModel{}).
+			Where("mac_address = ? AND created_at >= ? AND created_at < ?", macAddress, from, to).
+			Count(&rawCount)
+		if result.Error != nil {
+			r.coreLog.Error("sensor_temperature_humidity_query_failed", zap.String("operation", "list_by_mac"), zap.String("table", "sensor_temperature_humidity"), zap.Duration("duration", time.Since(start)), zap.Error(result.Error))
+			return nil, fmt.Errorf("failed to count sensor temperature humidity: %w", result.Error)
+		}
+		skip = offset - int(rawCount)
+	}
+
+	var archives []*models.SensorTemperatureHumidityArchiveModel
+	result = r.db.GetDB().WithContext(ctx).
+		Where("mac_address = ? AND day >= ? AND day < ?", macAddress, entities.StartOfDay(from), to).
+		Order("day DESC").
+		Find(&archives)
+	if result.Error != nil {
+		r.coreLog.Error("sensor_temperature_humidity_query_failed", zap.String("operation", "list_by_mac"), zap.String("table", "sensor_temperature_humidity_archive"), zap.Duration("duration", time.Since(start)), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to query sensor temperature humidity archive: %w", result.Error)
+	}
+
+	for _, archive := range archives {
+		// Whole days outside the page are skipped without decompressing them
+		if archive.SampleCount <= skip && !archive.FirstReadingAt.Before(from) && archive.LastReadingAt.Before(to) {
+			skip -= archive.SampleCount
+			continue
+		}
+
+		archived, err := r.archiveMapper.FromModel(archive)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(archived) - 1; i >= 0 && len(readings) < limit; i-- {
+			reading := archived[i]
+			if reading.Timestamp().Before(from) || !reading.Timestamp().Before(to) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			readings = append(readings, reading)
+		}
+		if len(readings) == limit {
+			break
+		}
+	}
+
+	r.coreLog.Debug("sensor_temperature_humidity_listed", zap.String("mac_address", macAddress), zap.Int("readings", len(readings)), zap.Int("archives", len(archives)), zap.Duration("duration", time.Since(start)), zap.String("component", "sensor_temperature_humidity_repository"))
+	return readings, nil
+}
+
+// FindLatest retrieves the latest raw reading of each device with a single DISTINCT ON query
+func (r *sensorTemperatureHumidityRepository) FindLatest(ctx context.Context, macAddresses []string) ([]*entities.SensorTemperatureHumidity, error) {
+	start := time.Now()
+	query := r.db.GetDB().WithContext(ctx).
+		Select("DISTINCT ON (mac_address) *").
+		Order("mac_address, created_at DESC")
+	if len(macAddresses) > 0 {
+		query = query.Where("mac_address IN ?", macAddresses)
+	}
+
+	var rawModels []*models.SensorTemperatureHumidityModel
+	if result := query.Find(&rawModels); result.Error != nil {
+		r.coreLog.Error("sensor_temperature_humidity_query_failed", zap.String("operation", "find_latest"), zap.String("table", "sensor_temperature_humidity"), zap.Duration("duration", time.Since(start)), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to query latest sensor temperature humidity: %w", result.Error)
+	}
+
+	readings, err := r.mapper.FromModelSlice(rawModels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map sensor temperature humidity: %w", err)
+	}
+	return readings, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
 	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
 	"github.com/stretchr/testify/assert"
@@ -145,3 +146,164 @@ func TestSensorTemperatureHumidityRepository_Create_ZeroRowsAffected(t *testing.
 	err = mock.ExpectationsWereMet()
 	assert.NoError(t, err)
 }
+
+func TestSensorTemperatureHumidityRepository_FindByMACAddress(t *testing.T) {
+	repo, mock := setupSensorTestRepository(t)
+	mac := "00:11:22:33:44:55"
+	from := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	// Raw readings of the most recent day
+	mock.ExpectQuery(`SELECT \* FROM "sensor_temperature_humidity" WHERE \(mac_address = \$1 AND created_at >= \$2 AND created_at < \$3\) AND "sensor_temperature_humidity"."deleted_at" IS NULL ORDER BY created_at ASC`).
+		WithArgs(mac, from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"mac_address", "temperature_celsius", "humidity_percent", "created_at", "deleted_at"}).
+			AddRow(mac, 24.0, 60.0, time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC), nil))
+
+	// Archived readings of the first day, one of them before the requested range
+	archiveDay := entities.NewTelemetryDay(mac, from)
+	early, _ := entities.NewSensorTemperatureHumidityAt(mac, 20.0, 55.0, archiveDay.Day.Add(6*time.Hour))
+	inRange, _ := entities.NewSensorTemperatureHumidityAt(mac, 22.0, 58.0, archiveDay.Day.Add(18*time.Hour))
+	archive, err := mappers.NewSensorTemperatureHumidityArchiveMapper().ToModel(archiveDay, []*entities.SensorTemperatureHumidity{early, inRange})
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT \* FROM "sensor_temperature_humidity_archive" WHERE mac_address = \$1 AND day >= \$2 AND day < \$3 ORDER BY day ASC`).
+		WithArgs(mac, archiveDay.Day, to).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "mac_address", "day", "encoding", "data", "sample_count", "first_reading_at", "last_reading_at"}).
+			AddRow(1, mac, archive.Day, archive.Encoding, archive.Data, archive.SampleCount, archive.FirstReadingAt, archive.LastReadingAt))
+
+	readings, err := repo.FindByMACAddress(context.Background(), mac, from, to)
+
+	assert.NoError(t, err)
+	if assert.Len(t, readings, 2) {
+		assert.Equal(t, 22.0, readings[0].Temperature())
+		assert.Equal(t, 24.0, readings[1].Temperature())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = repo.FindByMACAddress(context.Background(), mac, to, from)
+	assert.Error(t, err)
+}
+
+func TestSensorTemperatureHumidityRepository_Upsert_ReplacesMatchingReading(t *testing.T) {
+	repo, mock := setupSensorTestRepository(t)
+	sensor := createTestSensorData()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "sensor_temperature_humidity_archive"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(`UPDATE "sensor_temperature_humidity" SET .* WHERE \(mac_address = \$\d+ AND created_at BETWEEN \$\d+ AND \$\d+\)`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Upsert(context.Background(), sensor, 2*time.Second)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSensorTemperatureHumidityRepository_Upsert_InsertsWhenNoneMatches(t *testing.T) {
+	repo, mock := setupSensorTestRepository(t)
+	sensor := createTestSensorData()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "sensor_temperature_humidity_archive"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(`UPDATE "sensor_temperature_humidity"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`INSERT INTO "sensor_temperature_humidity"`).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(sensor.Timestamp()))
+	mock.ExpectCommit()
+
+	err := repo.Upsert(context.Background(), sensor, 2*time.Second)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSensorTemperatureHumidityRepository_Upsert_CompactedDay(t *testing.T) {
+	repo, mock := setupSensorTestRepository(t)
+	sensor := createTestSensorData()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "sensor_temperature_humidity_archive"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	err := repo.Upsert(context.Background(), sensor, 2*time.Second)
+
+	assert.ErrorIs(t, err, domainerrors.ErrSensorTemperatureHumidityCompacted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSensorTemperatureHumidityRepository_ListByMACAddress(t *testing.T) {
+	mac := "00:11:22:33:44:55"
+	from := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)
+	rawColumns := []string{"mac_address", "temperature_celsius", "humidity_percent", "created_at", "deleted_at"}
+
+	t.Run("reads a page of raw readings without the archives", func(t *testing.T) {
+		repo, mock := setupSensorTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "sensor_temperature_humidity" WHERE \(mac_address = \$1 AND created_at >= \$2 AND created_at < \$3\) AND "sensor_temperature_humidity"."deleted_at" IS NULL ORDER BY created_at DESC LIMIT \$4 OFFSET \$5`).
+			WithArgs(mac, from, to, 1, 1).
+			WillReturnRows(sqlmock.NewRows(rawColumns).AddRow(mac, 24.0, 60.0, time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC), nil))
+
+		readings, err := repo.ListByMACAddress(context.Background(), mac, from, to, 1, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, readings, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("continues with the archived readings, newest first", func(t *testing.T) {
+		repo, mock := setupSensorTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "sensor_temperature_humidity" .* ORDER BY created_at DESC LIMIT \$4 OFFSET \$5`).
+			WithArgs(mac, from, to, 2, 1).
+			WillReturnRows(sqlmock.NewRows(rawColumns))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "sensor_temperature_humidity"`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		archiveDay := entities.NewTelemetryDay(mac, from)
+		early, _ := entities.NewSensorTemperatureHumidityAt(mac, 20.0, 55.0, archiveDay.Day.Add(6*time.Hour))
+		first, _ := entities.NewSensorTemperatureHumidityAt(mac, 21.0, 57.0, archiveDay.Day.Add(14*time.Hour))
+		second, _ := entities.NewSensorTemperatureHumidityAt(mac, 22.0, 58.0, archiveDay.Day.Add(18*time.Hour))
+		archive, err := mappers.NewSensorTemperatureHumidityArchiveMapper().ToModel(archiveDay, []*entities.SensorTemperatureHumidity{early, first, second})
+		assert.NoError(t, err)
+		mock.ExpectQuery(`SELECT \* FROM "sensor_temperature_humidity_archive" WHERE mac_address = \$1 AND day >= \$2 AND day < \$3 ORDER BY day DESC`).
+			WithArgs(mac, archiveDay.Day, to).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "mac_address", "day", "encoding", "data", "sample_count", "first_reading_at", "last_reading_at"}).
+				AddRow(1, mac, archive.Day, archive.Encoding, archive.Data, archive.SampleCount, archive.FirstReadingAt, archive.LastReadingAt))
+
+		readings, err := repo.ListByMACAddress(context.Background(), mac, from, to, 1, 2)
+
+		assert.NoError(t, err)
+		if assert.Len(t, readings, 1, "the reading before the range is left out") {
+			assert.Equal(t, 21.0, readings[0].Temperature())
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects invalid pages", func(t *testing.T) {
+		repo, _ := setupSensorTestRepository(t)
+
+		_, err := repo.ListByMACAddress(context.Background(), mac, to, from, 0, 10)
+		assert.Error(t, err)
+		_, err = repo.ListByMACAddress(context.Background(), mac, from, to, 0, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestSensorTemperatureHumidityRepository_FindLatest(t *testing.T) {
+	repo, mock := setupSensorTestRepository(t)
+	macs := []string{"00:11:22:33:44:55", "00:11:22:33:44:66"}
+
+	mock.ExpectQuery(`SELECT DISTINCT ON \(mac_address\) \* FROM "sensor_temperature_humidity" WHERE mac_address IN \(\$1,\$2\) AND "sensor_temperature_humidity"."deleted_at" IS NULL ORDER BY mac_address, created_at DESC`).
+		WithArgs(macs[0], macs[1]).
+		WillReturnRows(sqlmock.NewRows([]string{"mac_address", "temperature_celsius", "humidity_percent", "created_at", "deleted_at"}).
+			AddRow(macs[0], 24.0, 60.0, time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC), nil))
+
+	readings, err := repo.FindLatest(context.Background(), macs)
+
+	assert.NoError(t, err)
+	assert.Len(t, readings, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// syncOutboxRepository implements the SyncOutboxRepository interface using GORM PostgreSQL
+type syncOutboxRepository struct {
+	db         *database.GormPostgresDB
+	mapper     *mappers.SyncItemMapper
+	pagination pagination.Policy
+	logger     pkglogger.CoreLogger
+}
+
+// NewSyncOutboxRepository creates a new GORM-based PostgreSQL sync outbox repository
+func NewSyncOutboxRepository(db *database.GormPostgresDB, policy pagination.Policy, loggerFactory pkglogger.LoggerFactory) ports.SyncOutboxRepository {
+	return &syncOutboxRepository{
+		db:         db,
+		mapper:     mappers.NewSyncItemMapper(),
+		pagination: policy,
+		logger:     loggerFactory.Core(),
+	}
+}
+
+// Enqueue stores a change waiting to be replicated
+func (r *syncOutboxRepository) Enqueue(ctx context.Context, item *entities.SyncItem) error {
+	if item == nil {
+		return fmt.Errorf("sync item cannot be nil")
+	}
+	if err := item.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", domainerrors.ErrInvalidSyncItem, err)
+	}
+
+	start := time.Now()
+	result := r.db.GetDB().WithContext(ctx).Create(r.mapper.ToModel(item))
+	duration := time.Since(start)
+
+	if result.Error != nil {
+		r.logger.Error("sync_item_enqueue_failed", zap.String("operation", "create"), zap.String("table", "sync_outbox"), zap.Duration("duration", duration), zap.Error(result.Error))
+		return fmt.Errorf("failed to enqueue sync item: %w", result.Error)
+	}
+
+	r.logger.Debug("sync_item_enqueued", zap.String("kind", item.Kind), zap.String("key", item.Key), zap.String("component", "sync_outbox_repository"))
+	return nil
+}
+
+// ListPending retrieves the oldest unsynced items
+func (r *syncOutboxRepository) ListPending(ctx context.Context, limit int) ([]*entities.SyncItem, error) {
+	limit, err := r.pagination.Limit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*models.SyncOutboxModel
+	query := r.db.GetDB().WithContext(ctx).
+		Where("synced_at IS NULL").
+		Order("changed_at ASC")
+	if limit != pagination.Unlimited {
+		query = query.Limit(limit)
+	}
+
+	start := time.Now()
+	result := query.Find(&pending)
+	duration := time.Since(start)
+
+	if result.Error != nil {
+		r.logger.Error("sync_items_list_failed", zap.String("operation", "list_pending"), zap.String("table", "sync_outbox"), zap.Duration("duration", duration), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to list pending sync items: %w", result.Error)
+	}
+
+	return r.mapper.FromModelSlice(pending), nil
+}
+
+// MarkSynced flags the given items as replicated
+func (r *syncOutboxRepository) MarkSynced(ctx context.Context, ids []string, syncedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.SyncOutboxModel{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"synced_at": syncedAt, "last_error": ""})
+	if result.Error != nil {
+		r.logger.Error("sync_items_mark_synced_failed", zap.String("table", "sync_outbox"), zap.Int("count", len(ids)), zap.Error(result.Error))
+		return fmt.Errorf("failed to mark sync items as synced: %w", result.Error)
+	}
+	return nil
+}
+
+// MarkFailed records a failed replication attempt for the given items
+func (r *syncOutboxRepository) MarkFailed(ctx context.Context, ids []string, reason string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if len(reason) > 500 {
+		reason = reason[:500]
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.SyncOutboxModel{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"attempts": gorm.Expr("attempts + 1"), "last_error": reason})
+	if result.Error != nil {
+		r.logger.Error("sync_items_mark_failed_failed", zap.String("table", "sync_outbox"), zap.Int("count", len(ids)), zap.Error(result.Error))
+		return fmt.Errorf("failed to mark sync items as failed: %w", result.Error)
+	}
+	return nil
+}
+
+// CountPending returns the number of items waiting to be replicated
+func (r *syncOutboxRepository) CountPending(ctx context.Context) (int64, error) {
+	var count int64
+	result := r.db.GetDB().WithContext(ctx).Model(&models.SyncOutboxModel{}).Where("synced_at IS NULL").Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count pending sync items: %w", result.Error)
+	}
+	return count, nil
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// setupSyncOutboxTestRepository initializes a test repository with a mock database
+func setupSyncOutboxTestRepository(t *testing.T) (*syncOutboxRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewSyncOutboxRepository(postgresDB, pagination.DefaultPolicy(), loggerFactory).(*syncOutboxRepository), sqlMock
+}
+
+func TestSyncOutboxRepository_Enqueue(t *testing.T) {
+	t.Run("should reject invalid items", func(t *testing.T) {
+		repo, _ := setupSyncOutboxTestRepository(t)
+
+		err := repo.Enqueue(context.Background(), &entities.SyncItem{Kind: entities.SyncKindDevice})
+
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidSyncItem)
+	})
+
+	t.Run("should insert pending item", func(t *testing.T) {
+		repo, mock := setupSyncOutboxTestRepository(t)
+		item, err := entities.NewSyncItem(entities.SyncKindDevice, "AA:BB:CC:DD:EE:FF", map[string]string{}, time.Now())
+		require.NoError(t, err)
+
+		mock.ExpectQuery(`INSERT INTO "sync_outbox" .* RETURNING "created_at"`).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+
+		require.NoError(t, repo.Enqueue(context.Background(), item))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should wrap database errors", func(t *testing.T) {
+		repo, mock := setupSyncOutboxTestRepository(t)
+		item, err := entities.NewSyncItem(entities.SyncKindDevice, "AA:BB:CC:DD:EE:FF", map[string]string{}, time.Now())
+		require.NoError(t, err)
+
+		mock.ExpectQuery(`INSERT INTO "sync_outbox"`).WillReturnError(errors.New("disk full"))
+
+		err = repo.Enqueue(context.Background(), item)
+		assert.ErrorContains(t, err, "failed to enqueue sync item: disk full")
+	})
+}
+
+func TestSyncOutboxRepository_ListPending(t *testing.T) {
+	repo, mock := setupSyncOutboxTestRepository(t)
+	changedAt := time.Now().UTC()
+
+	mock.ExpectQuery(`SELECT \* FROM "sync_outbox" WHERE synced_at IS NULL ORDER BY changed_at ASC LIMIT \$1`).
+		WithArgs(50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "entity_key", "payload", "changed_at", "attempts", "last_error", "synced_at", "created_at"}).
+			AddRow("item-1", entities.SyncKindDevice, "AA:BB:CC:DD:EE:FF", `{"mac_address":"AA:BB:CC:DD:EE:FF"}`, changedAt, 2, "timeout", nil, changedAt))
+
+	items, err := repo.ListPending(context.Background(), 50)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "item-1", items[0].ID)
+	assert.Equal(t, "AA:BB:CC:DD:EE:FF", items[0].Key)
+	assert.Equal(t, 2, items[0].Attempts)
+	assert.JSONEq(t, `{"mac_address":"AA:BB:CC:DD:EE:FF"}`, string(items[0].Payload))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = repo.ListPending(context.Background(), pagination.DefaultPolicy().MaxLimit+1)
+	assert.ErrorIs(t, err, pagination.ErrLimitTooLarge)
+}
+
+func TestSyncOutboxRepository_MarkSynced(t *testing.T) {
+	repo, mock := setupSyncOutboxTestRepository(t)
+
+	require.NoError(t, repo.MarkSynced(context.Background(), nil, time.Now()), "empty id list is a no-op")
+
+	mock.ExpectExec(`UPDATE "sync_outbox" SET .* WHERE id IN \(\$\d,\$\d\)`).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	require.NoError(t, repo.MarkSynced(context.Background(), []string{"item-1", "item-2"}, time.Now()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// telemetryArchiveRepository implements the TelemetryArchiveRepository interface using GORM PostgreSQL
+type telemetryArchiveRepository struct {
+	db            *database.GormPostgresDB
+	mapper        *mappers.SensorTemperatureHumidityMapper
+	archiveMapper *mappers.SensorTemperatureHumidityArchiveMapper
+	pagination    pagination.Policy
+	logger        pkglogger.CoreLogger
+}
+
+// NewTelemetryArchiveRepository creates a new GORM-based PostgreSQL telemetry archive repository
+func NewTelemetryArchiveRepository(db *database.GormPostgresDB, policy pagination.Policy, loggerFactory pkglogger.LoggerFactory) ports.TelemetryArchiveRepository {
+	return &telemetryArchiveRepository{
+		db:            db,
+		mapper:        mappers.NewSensorTemperatureHumidityMapper(),
+		archiveMapper: mappers.NewSensorTemperatureHumidityArchiveMapper(),
+		pagination:    policy,
+		logger:        loggerFactory.Core(),
+	}
+}
+
+// compactableDayRow is the result row of the compactable days query
+type compactableDayRow struct {
+	MACAddress string
+	Day        time.Time
+}
+
+// rawReadingRow is a raw reading with its physical location, the table (a chunk in TimescaleDB mode)
+// and tuple it is stored in. The raw readings have no primary key, so the location is what
+// identifies the rows that were archived.
+type rawReadingRow struct {
+	Location string
+	models.SensorTemperatureHumidityModel
+}
+
+// rawReadingLocation is the SQL expression of a raw reading's location
+const rawReadingLocation = "tableoid::text || ctid::text"
+
+// ListCompactableDays returns the oldest device-days with raw readings that ended before the given time
+func (r *telemetryArchiveRepository) ListCompactableDays(ctx context.Context, before time.Time, limit int) ([]entities.TelemetryDay, error) {
+	limit, err := r.pagination.Limit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []compactableDayRow
+	query := r.db.GetDB().WithContext(ctx).
+		Model(&models.SensorTemperatureHumidityModel{}).
+		Select("mac_address, date_trunc('day', created_at AT TIME ZONE 'UTC') AS day").
+		Where("created_at < ?", entities.StartOfDay(before)).
+		Group("mac_address, day").
+		Order("day ASC, mac_address ASC")
+	if limit != pagination.Unlimited {
+		query = query.Limit(limit)
+	}
+	result := query.Scan(&rows)
+	if result.Error != nil {
+		r.logger.Error("telemetry_compactable_days_query_failed", zap.String("table", "sensor_temperature_humidity"), zap.Error(result.Error))
+		return nil, fmt.Errorf("failed to list compactable telemetry days: %w", result.Error)
+	}
+
+	days := make([]entities.TelemetryDay, 0, len(rows))
+	for _, row := range rows {
+		days = append(days, entities.NewTelemetryDay(row.MACAddress, row.Day))
+	}
+	return days, nil
+}
+
+// CompactDay compresses the raw readings of a device-day into its archive and deletes them.
+// Readings arriving late for an already archived day are merged into the existing archive.
+func (r *telemetryArchiveRepository) CompactDay(ctx context.Context, day entities.TelemetryDay) (int, error) {
+	start := time.Now()
+	compacted := 0
+
+	err := r.db.Transaction(ctx, func(tx *gorm.DB) error {
+		// Lock the loaded readings so their locations stay valid until they are deleted
+		var rows []rawReadingRow
+		if err := tx.Model(&models.SensorTemperatureHumidityModel{}).
+			Select(rawReadingLocation+" AS location, *").
+			Where("mac_address = ? AND created_at >= ? AND created_at < ?", day.MACAddress, day.Day, day.End()).
+			Order("created_at ASC").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Find(&rows).Error; err != nil {
+			return fmt.Errorf("failed to load raw readings: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		rawModels := make([]*models.SensorTemperatureHumidityModel, 0, len(rows))
+		locations := make([]string, 0, len(rows))
+		for i := range rows {
+			rawModels = append(rawModels, &rows[i].SensorTemperatureHumidityModel)
+			locations = append(locations, rows[i].Location)
+		}
+
+		readings, err := r.mapper.FromModelSlice(rawModels)
+		if err != nil {
+			return fmt.Errorf("failed to map raw readings: %w", err)
+		}
+
+		var existing models.SensorTemperatureHumidityArchiveModel
+		result := tx.Where("mac_address = ? AND day = ?", day.MACAddress, day.Day).Limit(1).Find(&existing)
+		if result.Error != nil {
+			return fmt.Errorf("failed to load existing archive: %w", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			archived, err := r.archiveMapper.FromModel(&existing)
+			if err != nil {
+				return err
+			}
+			readings = append(archived, readings...)
+		}
+
+		archive, err := r.archiveMapper.ToModel(day, readings)
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "mac_address"}, {Name: "day"}},
+			DoUpdates: clause.AssignmentColumns([]string{"encoding", "data", "sample_count", "first_reading_at", "last_reading_at", "updated_at"}),
+		}).Create(archive).Error; err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+
+		// Hard delete so the space is actually reclaimed. Only the archived readings go, so one
+		// inserted for the day after they were loaded stays for the next compaction.
+		if err := tx.Unscoped().
+			Where("mac_address = ? AND created_at >= ? AND created_at < ?", day.MACAddress, day.Day, day.End()).
+			Where(rawReadingLocation+" = ANY(?::text[]) OR deleted_at IS NOT NULL", textArray(locations)).
+			Delete(&models.SensorTemperatureHumidityModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete raw readings: %w", err)
+		}
+
+		compacted = len(rawModels)
+		return nil
+	})
+	duration := time.Since(start)
+
+	if err != nil {
+		r.logger.Error("telemetry_day_compaction_failed", zap.String("device_day", day.String()), zap.Duration("duration", duration), zap.Error(err))
+		return 0, fmt.Errorf("failed to compact %s: %w", day, err)
+	}
+
+	r.logger.Debug("telemetry_day_compacted", zap.String("device_day", day.String()), zap.Int("rows", compacted), zap.Duration("duration", duration), zap.String("component", "telemetry_archive_repository"))
+	return compacted, nil
+}
+
+// textArray formats values as a PostgreSQL text[] literal, sent as a single parameter however many
+// values there are
+func textArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = `"` + value + `"`
+	}
+	return "{" + strings.Join(quoted, ",") + "}"
+}
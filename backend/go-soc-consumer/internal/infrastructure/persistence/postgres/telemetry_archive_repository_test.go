@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// setupTelemetryArchiveTestRepository initializes a test repository with a mock database
+func setupTelemetryArchiveTestRepository(t *testing.T) (*telemetryArchiveRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewTelemetryArchiveRepository(postgresDB, pagination.DefaultPolicy(), loggerFactory).(*telemetryArchiveRepository), sqlMock
+}
+
+func TestTelemetryArchiveRepository_CompactDay(t *testing.T) {
+	day := entities.NewTelemetryDay("AA:BB:CC:DD:EE:FF", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	t.Run("should delete only the archived readings so one inserted after the load survives", func(t *testing.T) {
+		repo, mock := setupTelemetryArchiveTestRepository(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT tableoid::text \|\| ctid::text AS location, \* FROM "sensor_temperature_humidity" WHERE .* FOR UPDATE`).
+			WithArgs(day.MACAddress, day.Day, day.End()).
+			WillReturnRows(sqlmock.NewRows([]string{"location", "mac_address", "temperature_celsius", "humidity_percent", "created_at"}).
+				AddRow("16384(0,1)", day.MACAddress, 21.5, 60.0, day.Day.Add(time.Hour)).
+				AddRow("16384(0,2)", day.MACAddress, 22.0, 58.5, day.Day.Add(2*time.Hour)))
+		mock.ExpectQuery(`SELECT \* FROM "sensor_temperature_humidity_archive"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(`INSERT INTO "sensor_temperature_humidity_archive" .* ON CONFLICT`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		// A reading stored for the day after the load is at another location, so it is left alone
+		mock.ExpectExec(`DELETE FROM "sensor_temperature_humidity" WHERE \(mac_address = \$1 AND created_at >= \$2 AND created_at < \$3\) AND \(tableoid::text \|\| ctid::text = ANY\(\$4::text\[\]\) OR deleted_at IS NOT NULL\)`).
+			WithArgs(day.MACAddress, day.Day, day.End(), `{"16384(0,1)","16384(0,2)"}`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		rows, err := repo.CompactDay(context.Background(), day)
+		require.NoError(t, err)
+		assert.Equal(t, 2, rows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should skip days without raw readings", func(t *testing.T) {
+		repo, mock := setupTelemetryArchiveTestRepository(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM "sensor_temperature_humidity" WHERE`).
+			WillReturnRows(sqlmock.NewRows([]string{"location"}))
+		mock.ExpectCommit()
+
+		rows, err := repo.CompactDay(context.Background(), day)
+		require.NoError(t, err)
+		assert.Zero(t, rows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// usageConflict is the key of a monthly usage record
+var usageConflict = []clause.Column{{Name: "farm_id"}, {Name: "month"}}
+
+// usageRepository implements the UsageRepository interface using GORM PostgreSQL
+type usageRepository struct {
+	db     *database.GormPostgresDB
+	logger pkglogger.CoreLogger
+}
+
+// NewUsageRepository creates a new GORM-based PostgreSQL usage repository
+func NewUsageRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.UsageRepository {
+	return &usageRepository{
+		db:     db,
+		logger: loggerFactory.Core(),
+	}
+}
+
+// AddUsage adds the records to the stored monthly totals in a single statement
+func (r *usageRepository) AddUsage(ctx context.Context, records []*entities.UsageRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	rows := make([]*models.UsageRecordModel, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, &models.UsageRecordModel{
+			FarmID:        record.FarmID,
+			Month:         entities.UsageMonth(record.Month),
+			Messages:      record.Messages,
+			StorageBytes:  record.StorageBytes,
+			Notifications: record.Notifications,
+			UpdatedAt:     now,
+		})
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: usageConflict,
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"messages":      gorm.Expr("usage_records.messages + excluded.messages"),
+				"storage_bytes": gorm.Expr("usage_records.storage_bytes + excluded.storage_bytes"),
+				"notifications": gorm.Expr("usage_records.notifications + excluded.notifications"),
+				"updated_at":    gorm.Expr("excluded.updated_at"),
+			}),
+		}).
+		Create(&rows)
+	if result.Error != nil {
+		r.logger.Error("usage_add_failed", zap.String("operation", "upsert"), zap.String("table", "usage_records"), zap.Int("records", len(rows)), zap.Error(result.Error))
+		return fmt.Errorf("failed to add usage: %w", result.Error)
+	}
+	return nil
+}
+
+// SaveDeviceCounts replaces the device counts of the day
+func (r *usageRepository) SaveDeviceCounts(ctx context.Context, day time.Time, devices map[string]int) error {
+	day = day.UTC().Truncate(24 * time.Hour)
+	rows := make([]*models.UsageDeviceDayModel, 0, len(devices))
+	for farmID, count := range devices {
+		rows = append(rows, &models.UsageDeviceDayModel{FarmID: farmID, Day: day, Devices: count})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].FarmID < rows[j].FarmID })
+
+	err := r.db.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("day = ?", day).Delete(&models.UsageDeviceDayModel{}).Error; err != nil {
+			return fmt.Errorf("failed to clear device counts: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return fmt.Errorf("failed to store device counts: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("usage_device_counts_failed", zap.String("table", "usage_device_days"), zap.Time("day", day), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListByMonth merges the monthly totals with the device-days of the month
+func (r *usageRepository) ListByMonth(ctx context.Context, month time.Time) ([]*entities.UsageRecord, error) {
+	month = entities.UsageMonth(month)
+
+	var totals []models.UsageRecordModel
+	if err := r.db.GetDB().WithContext(ctx).Where("month = ?", month).Find(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to list usage: %w", err)
+	}
+
+	var deviceDays []struct {
+		FarmID     string
+		DeviceDays int64
+	}
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.UsageDeviceDayModel{}).
+		Select("farm_id, SUM(devices) AS device_days").
+		Where("day >= ? AND day < ?", month, month.AddDate(0, 1, 0)).
+		Group("farm_id").
+		Scan(&deviceDays)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to sum device-days: %w", result.Error)
+	}
+
+	byFarm := make(map[string]*entities.UsageRecord, len(totals)+len(deviceDays))
+	record := func(farmID string) *entities.UsageRecord {
+		if existing, ok := byFarm[farmID]; ok {
+			return existing
+		}
+		created := &entities.UsageRecord{FarmID: farmID, Month: month}
+		byFarm[farmID] = created
+		return created
+	}
+	for _, total := range totals {
+		record(total.FarmID).Add(&entities.UsageRecord{
+			Messages:      total.Messages,
+			StorageBytes:  total.StorageBytes,
+			Notifications: total.Notifications,
+		})
+	}
+	for _, row := range deviceDays {
+		record(row.FarmID).DeviceDays = row.DeviceDays
+	}
+
+	records := make([]*entities.UsageRecord, 0, len(byFarm))
+	for _, usage := range byFarm {
+		records = append(records, usage)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].FarmID < records[j].FarmID })
+	return records, nil
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupUsageTestRepository initializes a test repository with a mock database
+func setupUsageTestRepository(t *testing.T) (*usageRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewUsageRepository(postgresDB, loggerFactory).(*usageRepository), sqlMock
+}
+
+func TestUsageRepository_AddUsage(t *testing.T) {
+	june := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo, mock := setupUsageTestRepository(t)
+	mock.ExpectExec(`INSERT INTO "usage_records" \("farm_id","month","messages","storage_bytes","notifications","updated_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6\),\(\$7,\$8,\$9,\$10,\$11,\$12\) ON CONFLICT \("farm_id","month"\) DO UPDATE SET "messages"=usage_records.messages \+ excluded.messages,"notifications"=usage_records.notifications \+ excluded.notifications,"storage_bytes"=usage_records.storage_bytes \+ excluded.storage_bytes,"updated_at"=excluded.updated_at`).
+		WithArgs("", june, int64(3), int64(300), int64(0), sqlmock.AnyArg(), "farm-a", june, int64(10), int64(1000), int64(2), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := repo.AddUsage(context.Background(), []*entities.UsageRecord{
+		{Month: june.Add(48 * time.Hour), Messages: 3, StorageBytes: 300},
+		{FarmID: "farm-a", Month: june, Messages: 10, StorageBytes: 1000, Notifications: 2},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, repo.AddUsage(context.Background(), nil), "nothing to add")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUsageRepository_SaveDeviceCounts(t *testing.T) {
+	day := time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC)
+	repo, mock := setupUsageTestRepository(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "usage_device_days" WHERE day = \$1`).
+		WithArgs(day).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO "usage_device_days" \("farm_id","day","devices"\) VALUES \(\$1,\$2,\$3\),\(\$4,\$5,\$6\)`).
+		WithArgs("", day, 3, "farm-a", day, 12).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.SaveDeviceCounts(context.Background(), day.Add(15*time.Hour), map[string]int{"farm-a": 12, "": 3}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUsageRepository_ListByMonth(t *testing.T) {
+	june := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	repo, mock := setupUsageTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "usage_records" WHERE month = \$1`).
+		WithArgs(june).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_id", "month", "messages", "storage_bytes", "notifications", "updated_at"}).
+			AddRow("farm-b", june, 40, 4000, 1, june).
+			AddRow("farm-a", june, 10, 1000, 0, june))
+	mock.ExpectQuery(`SELECT farm_id, SUM\(devices\) AS device_days FROM "usage_device_days" WHERE day >= \$1 AND day < \$2 GROUP BY "farm_id"`).
+		WithArgs(june, june.AddDate(0, 1, 0)).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_id", "device_days"}).
+			AddRow("farm-a", 60).
+			AddRow("farm-c", 5))
+
+	records, err := repo.ListByMonth(context.Background(), june.Add(10*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []*entities.UsageRecord{
+		{FarmID: "farm-a", Month: june, DeviceDays: 60, Messages: 10, StorageBytes: 1000},
+		{FarmID: "farm-b", Month: june, Messages: 40, StorageBytes: 4000, Notifications: 1},
+		{FarmID: "farm-c", Month: june, DeviceDays: 5},
+	}, records)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// userRepository implements the UserRepository interface using GORM PostgreSQL
+type userRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.UserMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewUserRepository creates a new GORM-based PostgreSQL user repository
+func NewUserRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.UserRepository {
+	return &userRepository{
+		db:     db,
+		mapper: mappers.NewUserMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new user
+func (r *userRepository) Create(ctx context.Context, user *entities.User) error {
+	if user == nil {
+		return fmt.Errorf("user cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Create(r.mapper.ToModel(user))
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return domainerrors.ErrUserAlreadyExists
+		}
+		r.logger.Error("user_create_failed", zap.String("operation", "create"), zap.String("table", "users"), zap.String("username", user.Username), zap.Error(result.Error))
+		return fmt.Errorf("failed to create user: %w", result.Error)
+	}
+	return nil
+}
+
+// Delete removes a user by its username
+func (r *userRepository) Delete(ctx context.Context, username string) error {
+	result := r.db.GetDB().WithContext(ctx).Where("username = ?", username).Delete(&models.UserModel{})
+	if result.Error != nil {
+		r.logger.Error("user_delete_failed", zap.String("operation", "delete"), zap.String("table", "users"), zap.String("username", username), zap.Error(result.Error))
+		return fmt.Errorf("failed to delete user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// FindByUsername retrieves a user by its username
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*entities.User, error) {
+	var model models.UserModel
+	result := r.db.GetDB().WithContext(ctx).Where("username = ?", username).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", result.Error)
+	}
+	return r.mapper.FromModel(&model), nil
+}
+
+// List returns every user sorted by username
+func (r *userRepository) List(ctx context.Context) ([]*entities.User, error) {
+	var records []models.UserModel
+	result := r.db.GetDB().WithContext(ctx).Order("username ASC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list users: %w", result.Error)
+	}
+
+	users := make([]*entities.User, 0, len(records))
+	for i := range records {
+		users = append(users, r.mapper.FromModel(&records[i]))
+	}
+	return users, nil
+}
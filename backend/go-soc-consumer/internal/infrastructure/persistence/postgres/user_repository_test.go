@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupUserTestRepository initializes a test repository with a mock database
+func setupUserTestRepository(t *testing.T) (*userRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewUserRepository(postgresDB, loggerFactory).(*userRepository), sqlMock
+}
+
+func TestUserRepository_Create(t *testing.T) {
+	repo, mock := setupUserTestRepository(t)
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	user := &entities.User{ID: "user-1", Username: "maria", PasswordHash: "$2a$10$hash", Role: entities.UserRoleAdmin, CreatedAt: at, UpdatedAt: at}
+
+	mock.ExpectQuery(`INSERT INTO "users" \("id","username","password_hash","role","created_at","updated_at"\) .* RETURNING`).
+		WithArgs("user-1", "maria", "$2a$10$hash", "admin", at, at).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(at, at))
+
+	require.NoError(t, repo.Create(context.Background(), user))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_FindByUsername(t *testing.T) {
+	t.Run("should map the user", func(t *testing.T) {
+		repo, mock := setupUserTestRepository(t)
+		at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE username = \$1`).
+			WithArgs("maria", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password_hash", "role", "created_at", "updated_at"}).
+				AddRow("user-1", "maria", "$2a$10$hash", "viewer", at, at))
+
+		user, err := repo.FindByUsername(context.Background(), "maria")
+		require.NoError(t, err)
+		assert.Equal(t, &entities.User{ID: "user-1", Username: "maria", PasswordHash: "$2a$10$hash", Role: "viewer", CreatedAt: at, UpdatedAt: at}, user)
+	})
+
+	t.Run("should report unknown users", func(t *testing.T) {
+		repo, mock := setupUserTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "users"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repo.FindByUsername(context.Background(), "maria")
+		assert.ErrorIs(t, err, domainerrors.ErrUserNotFound)
+	})
+}
+
+func TestUserRepository_Delete(t *testing.T) {
+	repo, mock := setupUserTestRepository(t)
+	mock.ExpectExec(`DELETE FROM "users" WHERE username = \$1`).
+		WithArgs("maria").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.Delete(context.Background(), "maria"), domainerrors.ErrUserNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
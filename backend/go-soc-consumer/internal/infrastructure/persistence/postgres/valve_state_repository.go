@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// valveStateRepository implements the ValveStateRepository interface using GORM PostgreSQL
+type valveStateRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.ValveStateMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewValveStateRepository creates a new GORM-based PostgreSQL valve state repository
+func NewValveStateRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.ValveStateRepository {
+	return &valveStateRepository{
+		db:     db,
+		mapper: mappers.NewValveStateMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Record persists a transition unless one was already recorded for its command
+func (r *valveStateRepository) Record(ctx context.Context, transition *entities.ValveTransition) (bool, error) {
+	if transition == nil {
+		return false, fmt.Errorf("valve transition cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(r.mapper.ToModel(transition))
+	if result.Error != nil {
+		r.logger.Error("valve_state_record_failed", zap.String("operation", "create"), zap.String("table", "valve_states"), zap.String("mac_address", transition.MACAddress), zap.Error(result.Error))
+		return false, fmt.Errorf("failed to record valve state: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Latest returns the last transition of the valve of the device
+func (r *valveStateRepository) Latest(ctx context.Context, macAddress string) (*entities.ValveTransition, error) {
+	var record models.ValveStateModel
+	result := r.db.GetDB().WithContext(ctx).Where("mac_address = ?", macAddress).Order("changed_at DESC").First(&record)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find valve state: %w", result.Error)
+	}
+	return r.mapper.FromModel(&record), nil
+}
+
+// ListByDevice returns the latest transitions of the valve of the device, newest first
+func (r *valveStateRepository) ListByDevice(ctx context.Context, macAddress string, limit int) ([]*entities.ValveTransition, error) {
+	var records []models.ValveStateModel
+	result := r.db.GetDB().WithContext(ctx).Where("mac_address = ?", macAddress).Order("changed_at DESC").Limit(limit).Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list valve states: %w", result.Error)
+	}
+
+	transitions := make([]*entities.ValveTransition, 0, len(records))
+	for i := range records {
+		transitions = append(transitions, r.mapper.FromModel(&records[i]))
+	}
+	return transitions, nil
+}
+
+// ListBetween returns the transitions of the valve of the device changed in [from, to), oldest first
+func (r *valveStateRepository) ListBetween(ctx context.Context, macAddress string, from, to time.Time) ([]*entities.ValveTransition, error) {
+	var records []models.ValveStateModel
+	result := r.db.GetDB().WithContext(ctx).Where("mac_address = ? AND changed_at >= ? AND changed_at < ?", macAddress, from, to).Order("changed_at").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list valve states: %w", result.Error)
+	}
+
+	transitions := make([]*entities.ValveTransition, 0, len(records))
+	for i := range records {
+		transitions = append(transitions, r.mapper.FromModel(&records[i]))
+	}
+	return transitions, nil
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+var valveStateColumns = []string{"command_id", "mac_address", "state", "changed_at"}
+
+// setupValveStateTestRepository initializes a test repository with a mock database
+func setupValveStateTestRepository(t *testing.T) (*valveStateRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewValveStateRepository(postgresDB, loggerFactory).(*valveStateRepository), sqlMock
+}
+
+func TestValveStateRepository_Record(t *testing.T) {
+	changedAt := time.Date(2025, 6, 1, 6, 0, 5, 0, time.UTC)
+	transition := &entities.ValveTransition{CommandID: "command-1", MACAddress: "AA:BB:CC:DD:EE:FF", State: entities.ValveStateOpen, ChangedAt: changedAt}
+
+	t.Run("should record a new transition", func(t *testing.T) {
+		repo, mock := setupValveStateTestRepository(t)
+		mock.ExpectExec(`INSERT INTO "valve_states" .* ON CONFLICT DO NOTHING`).
+			WithArgs("command-1", "AA:BB:CC:DD:EE:FF", entities.ValveStateOpen, changedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		recorded, err := repo.Record(context.Background(), transition)
+		require.NoError(t, err)
+		assert.True(t, recorded)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should skip a transition already recorded for the command", func(t *testing.T) {
+		repo, mock := setupValveStateTestRepository(t)
+		mock.ExpectExec(`INSERT INTO "valve_states"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		recorded, err := repo.Record(context.Background(), transition)
+		require.NoError(t, err)
+		assert.False(t, recorded)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestValveStateRepository_Latest(t *testing.T) {
+	changedAt := time.Date(2025, 6, 1, 6, 0, 5, 0, time.UTC)
+
+	t.Run("should return the last transition", func(t *testing.T) {
+		repo, mock := setupValveStateTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "valve_states" WHERE mac_address = \$1 ORDER BY changed_at DESC`).
+			WithArgs("AA:BB:CC:DD:EE:FF", 1).
+			WillReturnRows(sqlmock.NewRows(valveStateColumns).AddRow("command-2", "AA:BB:CC:DD:EE:FF", entities.ValveStateClosed, changedAt))
+
+		transition, err := repo.Latest(context.Background(), "AA:BB:CC:DD:EE:FF")
+		require.NoError(t, err)
+		assert.Equal(t, &entities.ValveTransition{CommandID: "command-2", MACAddress: "AA:BB:CC:DD:EE:FF", State: entities.ValveStateClosed, ChangedAt: changedAt}, transition)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return nil for a valve never actuated", func(t *testing.T) {
+		repo, mock := setupValveStateTestRepository(t)
+		mock.ExpectQuery(`SELECT \* FROM "valve_states"`).WillReturnRows(sqlmock.NewRows(valveStateColumns))
+
+		transition, err := repo.Latest(context.Background(), "AA:BB:CC:DD:EE:FF")
+		require.NoError(t, err)
+		assert.Nil(t, transition)
+	})
+}
+
+func TestValveStateRepository_ListByDevice(t *testing.T) {
+	changedAt := time.Date(2025, 6, 1, 6, 0, 5, 0, time.UTC)
+	repo, mock := setupValveStateTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "valve_states" WHERE mac_address = \$1 ORDER BY changed_at DESC LIMIT \$2`).
+		WithArgs("AA:BB:CC:DD:EE:FF", 2).
+		WillReturnRows(sqlmock.NewRows(valveStateColumns).
+			AddRow("command-2", "AA:BB:CC:DD:EE:FF", entities.ValveStateClosed, changedAt.Add(time.Hour)).
+			AddRow("command-1", "AA:BB:CC:DD:EE:FF", entities.ValveStateOpen, changedAt))
+
+	transitions, err := repo.ListByDevice(context.Background(), "AA:BB:CC:DD:EE:FF", 2)
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	assert.Equal(t, entities.ValveStateClosed, transitions[0].State)
+	assert.Equal(t, "command-1", transitions[1].CommandID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestValveStateRepository_ListBetween(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	repo, mock := setupValveStateTestRepository(t)
+	mock.ExpectQuery(`SELECT \* FROM "valve_states" WHERE mac_address = \$1 AND changed_at >= \$2 AND changed_at < \$3 ORDER BY changed_at`).
+		WithArgs("AA:BB:CC:DD:EE:FF", from, to).
+		WillReturnRows(sqlmock.NewRows(valveStateColumns).
+			AddRow("command-1", "AA:BB:CC:DD:EE:FF", entities.ValveStateOpen, from.Add(6*time.Hour)).
+			AddRow("command-2", "AA:BB:CC:DD:EE:FF", entities.ValveStateClosed, from.Add(7*time.Hour)))
+
+	transitions, err := repo.ListBetween(context.Background(), "AA:BB:CC:DD:EE:FF", from, to)
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	assert.Equal(t, entities.ValveStateOpen, transitions[0].State)
+	assert.Equal(t, "command-2", transitions[1].CommandID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/mappers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/persistence/postgres/models"
+	pkglogger "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// zoneRepository implements the ZoneRepository interface using GORM PostgreSQL
+type zoneRepository struct {
+	db     *database.GormPostgresDB
+	mapper *mappers.FarmMapper
+	logger pkglogger.CoreLogger
+}
+
+// NewZoneRepository creates a new GORM-based PostgreSQL zone repository
+func NewZoneRepository(db *database.GormPostgresDB, loggerFactory pkglogger.LoggerFactory) ports.ZoneRepository {
+	return &zoneRepository{
+		db:     db,
+		mapper: mappers.NewFarmMapper(),
+		logger: loggerFactory.Core(),
+	}
+}
+
+// Create persists a new field or zone
+func (r *zoneRepository) Create(ctx context.Context, zone *entities.Zone) error {
+	if zone == nil {
+		return fmt.Errorf("zone cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).Create(r.mapper.ZoneToModel(zone))
+	if result.Error != nil {
+		r.logger.Error("zone_create_failed", zap.String("operation", "create"), zap.String("table", "zones"), zap.String("farm_id", zone.FarmID), zap.Error(result.Error))
+		return fmt.Errorf("failed to create zone: %w", result.Error)
+	}
+	return nil
+}
+
+// Update stores the name of a field or zone
+func (r *zoneRepository) Update(ctx context.Context, zone *entities.Zone) error {
+	if zone == nil {
+		return fmt.Errorf("zone cannot be nil")
+	}
+
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.ZoneModel{}).
+		Where("id = ?", zone.ID).
+		Updates(map[string]interface{}{"name": zone.Name, "updated_at": zone.UpdatedAt})
+	if result.Error != nil {
+		r.logger.Error("zone_update_failed", zap.String("operation", "update"), zap.String("table", "zones"), zap.String("id", zone.ID), zap.Error(result.Error))
+		return fmt.Errorf("failed to update zone: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrZoneNotFound
+	}
+	return nil
+}
+
+// Delete removes a field or zone by its ID
+func (r *zoneRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).Delete(&models.ZoneModel{})
+	if result.Error != nil {
+		r.logger.Error("zone_delete_failed", zap.String("operation", "delete"), zap.String("table", "zones"), zap.String("id", id), zap.Error(result.Error))
+		return fmt.Errorf("failed to delete zone: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrZoneNotFound
+	}
+	return nil
+}
+
+// FindByID retrieves a field or zone by its ID
+func (r *zoneRepository) FindByID(ctx context.Context, id string) (*entities.Zone, error) {
+	var model models.ZoneModel
+	result := r.db.GetDB().WithContext(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrZoneNotFound
+		}
+		return nil, fmt.Errorf("failed to find zone: %w", result.Error)
+	}
+	return r.mapper.ZoneFromModel(&model), nil
+}
+
+// ListByFarm returns the fields and zones of a farm, oldest first
+func (r *zoneRepository) ListByFarm(ctx context.Context, farmID string) ([]*entities.Zone, error) {
+	var records []models.ZoneModel
+	result := r.db.GetDB().WithContext(ctx).Where("farm_id = ?", farmID).Order("created_at ASC").Find(&records)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", result.Error)
+	}
+
+	zones := make([]*entities.Zone, 0, len(records))
+	for i := range records {
+		zones = append(zones, r.mapper.ZoneFromModel(&records[i]))
+	}
+	return zones, nil
+}
+
+// AssignDevice inserts the assignment of the device or moves it to the zone
+func (r *zoneRepository) AssignDevice(ctx context.Context, zoneID, macAddress string) error {
+	result := r.db.GetDB().WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "mac_address"}},
+			DoUpdates: clause.AssignmentColumns([]string{"zone_id", "assigned_at"}),
+		}).
+		Create(&models.ZoneDeviceModel{MACAddress: macAddress, ZoneID: zoneID, AssignedAt: time.Now().UTC()})
+	if result.Error != nil {
+		r.logger.Error("zone_device_assign_failed", zap.String("operation", "upsert"), zap.String("table", "zone_devices"), zap.String("zone_id", zoneID), zap.String("mac_address", macAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to assign device to zone: %w", result.Error)
+	}
+	return nil
+}
+
+// UnassignDevice removes the assignment of the device to the zone
+func (r *zoneRepository) UnassignDevice(ctx context.Context, zoneID, macAddress string) error {
+	result := r.db.GetDB().WithContext(ctx).Where("zone_id = ? AND mac_address = ?", zoneID, macAddress).Delete(&models.ZoneDeviceModel{})
+	if result.Error != nil {
+		r.logger.Error("zone_device_unassign_failed", zap.String("operation", "delete"), zap.String("table", "zone_devices"), zap.String("zone_id", zoneID), zap.String("mac_address", macAddress), zap.Error(result.Error))
+		return fmt.Errorf("failed to remove device from zone: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrDeviceNotInZone
+	}
+	return nil
+}
+
+// DeviceMACAddresses returns the devices of the zone, or of the zones of the field
+func (r *zoneRepository) DeviceMACAddresses(ctx context.Context, zoneID string) ([]string, error) {
+	var macAddresses []string
+	result := r.db.GetDB().WithContext(ctx).
+		Model(&models.ZoneDeviceModel{}).
+		Where("zone_id = ? OR zone_id IN (?)", zoneID, r.db.GetDB().Model(&models.ZoneModel{}).Select("id").Where("parent_id = ?", zoneID)).
+		Order("mac_address ASC").
+		Pluck("mac_address", &macAddresses)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list zone devices: %w", result.Error)
+	}
+	return macAddresses, nil
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/database"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks/stubs"
+)
+
+// setupZoneTestRepository initializes a test repository with a mock database
+func setupZoneTestRepository(t *testing.T) (*zoneRepository, sqlmock.Sqlmock) {
+	gormMockDB, sqlMock := stubs.GetTestDB(t)
+	loggerFactory := createSensorTestLoggerFactory(t)
+
+	postgresDB, err := database.NewGormPostgresDBWithoutConfig(gormMockDB, loggerFactory.Infrastructure())
+	require.NoError(t, err)
+
+	return NewZoneRepository(postgresDB, loggerFactory).(*zoneRepository), sqlMock
+}
+
+func TestZoneRepository_ListByFarm(t *testing.T) {
+	repo, mock := setupZoneTestRepository(t)
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT \* FROM "zones" WHERE farm_id = \$1 ORDER BY created_at ASC`).
+		WithArgs("la-esperanza").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "farm_id", "parent_id", "kind", "name", "created_at", "updated_at"}).
+			AddRow("field-1", "la-esperanza", "", "field", "North field", createdAt, createdAt).
+			AddRow("zone-1", "la-esperanza", "field-1", "zone", "Tomatoes", createdAt, createdAt))
+
+	zones, err := repo.ListByFarm(context.Background(), "la-esperanza")
+	require.NoError(t, err)
+	require.Len(t, zones, 2)
+	assert.Equal(t, "field-1", zones[1].ParentID)
+	assert.True(t, zones[1].HoldsDevices())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestZoneRepository_AssignDevice(t *testing.T) {
+	repo, mock := setupZoneTestRepository(t)
+	mock.ExpectExec(`INSERT INTO "zone_devices" .* ON CONFLICT \("mac_address"\) DO UPDATE SET "zone_id"="excluded"."zone_id","assigned_at"="excluded"."assigned_at"`).
+		WithArgs("AA:BB:CC:DD:EE:FF", "zone-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.AssignDevice(context.Background(), "zone-1", "AA:BB:CC:DD:EE:FF"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestZoneRepository_UnassignDevice(t *testing.T) {
+	repo, mock := setupZoneTestRepository(t)
+	mock.ExpectExec(`DELETE FROM "zone_devices" WHERE zone_id = \$1 AND mac_address = \$2`).
+		WithArgs("zone-1", "AA:BB:CC:DD:EE:FF").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.UnassignDevice(context.Background(), "zone-1", "AA:BB:CC:DD:EE:FF"), domainerrors.ErrDeviceNotInZone)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestZoneRepository_DeviceMACAddresses(t *testing.T) {
+	repo, mock := setupZoneTestRepository(t)
+	mock.ExpectQuery(`SELECT "mac_address" FROM "zone_devices" WHERE zone_id = \$1 OR zone_id IN \(SELECT "id" FROM "zones" WHERE parent_id = \$2\) ORDER BY mac_address ASC`).
+		WithArgs("field-1", "field-1").
+		WillReturnRows(sqlmock.NewRows([]string{"mac_address"}).AddRow("AA:BB:CC:DD:EE:01").AddRow("AA:BB:CC:DD:EE:02"))
+
+	macAddresses, err := repo.DeviceMACAddresses(context.Background(), "field-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"}, macAddresses)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
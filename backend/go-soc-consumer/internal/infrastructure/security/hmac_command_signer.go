@@ -0,0 +1,130 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+)
+
+// minSigningKeyBytes is the shortest HMAC key accepted
+const minSigningKeyBytes = 16
+
+// HMACCommandSignerConfig holds the signing keys and replay window
+type HMACCommandSignerConfig struct {
+	Keys         map[string][]byte // key ID to secret; retired keys stay here until every device has the new one
+	ActiveKeyID  string            // key used to sign new commands
+	ReplayWindow time.Duration     // maximum clock difference accepted when verifying
+}
+
+// hmacCommandSigner implements the CommandSigner port with HMAC-SHA256
+type hmacCommandSigner struct {
+	config HMACCommandSignerConfig
+	now    func() time.Time
+
+	mu         sync.Mutex
+	seenNonces map[string]time.Time
+}
+
+// NewHMACCommandSigner creates a command signer using the given keys
+func NewHMACCommandSigner(config HMACCommandSignerConfig) (ports.CommandSigner, error) {
+	if _, ok := config.Keys[config.ActiveKeyID]; !ok {
+		return nil, fmt.Errorf("%w: active key %q is not configured", domainerrors.ErrUnknownSigningKey, config.ActiveKeyID)
+	}
+	for keyID, key := range config.Keys {
+		if len(key) < minSigningKeyBytes {
+			return nil, fmt.Errorf("signing key %q must be at least %d bytes", keyID, minSigningKeyBytes)
+		}
+	}
+	if config.ReplayWindow <= 0 {
+		return nil, fmt.Errorf("replay window must be greater than 0")
+	}
+
+	return &hmacCommandSigner{
+		config:     config,
+		now:        time.Now,
+		seenNonces: make(map[string]time.Time),
+	}, nil
+}
+
+// Sign wraps the command in an envelope signed with the active key
+func (s *hmacCommandSigner) Sign(target, command string, payload interface{}) (*entities.CommandEnvelope, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate command nonce: %w", err)
+	}
+
+	envelope := &entities.CommandEnvelope{
+		Version:  entities.CommandEnvelopeVersion,
+		KeyID:    s.config.ActiveKeyID,
+		Target:   target,
+		Command:  command,
+		IssuedAt: s.now().Unix(),
+		Nonce:    hex.EncodeToString(nonce),
+	}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode command payload: %w", err)
+		}
+		envelope.Payload = data
+	}
+
+	if err := envelope.Validate(); err != nil {
+		return nil, err
+	}
+	envelope.Signature = hex.EncodeToString(s.mac(s.config.Keys[envelope.KeyID], envelope))
+	return envelope, nil
+}
+
+// Verify checks the signature, the replay window and that the nonce was not used before
+func (s *hmacCommandSigner) Verify(envelope *entities.CommandEnvelope) error {
+	if err := envelope.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", domainerrors.ErrInvalidCommandSignature, err)
+	}
+
+	key, ok := s.config.Keys[envelope.KeyID]
+	if !ok {
+		return fmt.Errorf("%w: %s", domainerrors.ErrUnknownSigningKey, envelope.KeyID)
+	}
+
+	signature, err := hex.DecodeString(envelope.Signature)
+	if err != nil || !hmac.Equal(signature, s.mac(key, envelope)) {
+		return domainerrors.ErrInvalidCommandSignature
+	}
+
+	now := s.now()
+	issuedAt := time.Unix(envelope.IssuedAt, 0)
+	if issuedAt.Before(now.Add(-s.config.ReplayWindow)) || issuedAt.After(now.Add(s.config.ReplayWindow)) {
+		return domainerrors.ErrCommandExpired
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Nonces only need to be remembered while their timestamp is inside the window
+	for nonce, expiry := range s.seenNonces {
+		if now.After(expiry) {
+			delete(s.seenNonces, nonce)
+		}
+	}
+	if _, seen := s.seenNonces[envelope.Nonce]; seen {
+		return domainerrors.ErrCommandReplayed
+	}
+	s.seenNonces[envelope.Nonce] = issuedAt.Add(s.config.ReplayWindow)
+	return nil
+}
+
+func (s *hmacCommandSigner) mac(key []byte, envelope *entities.CommandEnvelope) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(envelope.SigningInput())
+	return h.Sum(nil)
+}
@@ -0,0 +1,111 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+)
+
+var (
+	currentKey  = []byte("0123456789abcdef0123456789abcdef")
+	previousKey = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func newTestSigner(t *testing.T, activeKeyID string, now time.Time) *hmacCommandSigner {
+	signer, err := NewHMACCommandSigner(HMACCommandSignerConfig{
+		Keys:         map[string][]byte{"current": currentKey, "previous": previousKey},
+		ActiveKeyID:  activeKeyID,
+		ReplayWindow: 30 * time.Second,
+	})
+	require.NoError(t, err)
+
+	impl := signer.(*hmacCommandSigner)
+	impl.now = func() time.Time { return now }
+	return impl
+}
+
+func TestNewHMACCommandSigner(t *testing.T) {
+	tests := []struct {
+		name   string
+		config HMACCommandSignerConfig
+	}{
+		{name: "unknown active key", config: HMACCommandSignerConfig{Keys: map[string][]byte{"a": currentKey}, ActiveKeyID: "b", ReplayWindow: time.Second}},
+		{name: "short key", config: HMACCommandSignerConfig{Keys: map[string][]byte{"a": []byte("short")}, ActiveKeyID: "a", ReplayWindow: time.Second}},
+		{name: "missing replay window", config: HMACCommandSignerConfig{Keys: map[string][]byte{"a": currentKey}, ActiveKeyID: "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewHMACCommandSigner(tt.config)
+			assert.Error(t, err)
+			assert.Nil(t, signer)
+		})
+	}
+}
+
+func TestHMACCommandSigner_SignAndVerify(t *testing.T) {
+	now := time.Unix(1718000000, 0)
+
+	t.Run("should produce a signature firmware can recompute", func(t *testing.T) {
+		signer := newTestSigner(t, "current", now)
+
+		envelope, err := signer.Sign("AA:BB:CC:DD:EE:FF", "reboot", map[string]int{"delay_seconds": 5})
+		require.NoError(t, err)
+		assert.Equal(t, "current", envelope.KeyID)
+		assert.Equal(t, now.Unix(), envelope.IssuedAt)
+		assert.Len(t, envelope.Nonce, 32)
+
+		expectedInput := "v1\ncurrent\nAA:BB:CC:DD:EE:FF\nreboot\n1718000000\n" + envelope.Nonce + "\n{\"delay_seconds\":5}"
+		mac := hmac.New(sha256.New, currentKey)
+		mac.Write([]byte(expectedInput))
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), envelope.Signature)
+
+		require.NoError(t, signer.Verify(envelope))
+	})
+
+	t.Run("should reject tampered commands", func(t *testing.T) {
+		signer := newTestSigner(t, "current", now)
+		envelope, err := signer.Sign("AA:BB:CC:DD:EE:FF", "reboot", nil)
+		require.NoError(t, err)
+
+		envelope.Target = "11:22:33:44:55:66"
+		assert.ErrorIs(t, signer.Verify(envelope), domainerrors.ErrInvalidCommandSignature)
+	})
+
+	t.Run("should reject replayed nonces", func(t *testing.T) {
+		signer := newTestSigner(t, "current", now)
+		envelope, err := signer.Sign("AA:BB:CC:DD:EE:FF", "reboot", nil)
+		require.NoError(t, err)
+
+		require.NoError(t, signer.Verify(envelope))
+		assert.ErrorIs(t, signer.Verify(envelope), domainerrors.ErrCommandReplayed)
+	})
+
+	t.Run("should reject commands outside the replay window", func(t *testing.T) {
+		signer := newTestSigner(t, "current", now)
+		envelope, err := signer.Sign("AA:BB:CC:DD:EE:FF", "reboot", nil)
+		require.NoError(t, err)
+
+		signer.now = func() time.Time { return now.Add(time.Minute) }
+		assert.ErrorIs(t, signer.Verify(envelope), domainerrors.ErrCommandExpired)
+	})
+
+	t.Run("should accept commands signed with a retired key during rotation", func(t *testing.T) {
+		oldSigner := newTestSigner(t, "previous", now)
+		envelope, err := oldSigner.Sign("AA:BB:CC:DD:EE:FF", "identify", nil)
+		require.NoError(t, err)
+
+		rotated := newTestSigner(t, "current", now)
+		assert.NoError(t, rotated.Verify(envelope))
+
+		envelope.KeyID = "unknown"
+		assert.ErrorIs(t, rotated.Verify(envelope), domainerrors.ErrUnknownSigningKey)
+	})
+}
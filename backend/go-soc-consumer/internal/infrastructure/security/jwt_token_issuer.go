@@ -0,0 +1,152 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+)
+
+// minJWTSecretBytes is the shortest HS256 key accepted, the size of the SHA-256 output
+const minJWTSecretBytes = 32
+
+// jwtClockSkew is the clock difference tolerated between the instances issuing and checking tokens
+const jwtClockSkew = 30 * time.Second
+
+// jwtHeader is the only header issued and accepted; the algorithm is never taken from the token
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// JWTTokenIssuerConfig holds the signing key, issuer and lifetime of the access tokens
+type JWTTokenIssuerConfig struct {
+	Secret []byte
+	Issuer string        // iss claim issued and required
+	TTL    time.Duration // lifetime of the issued tokens
+}
+
+// jwtClaims is the JSON payload of an access token
+type jwtClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	Username  string `json:"preferred_username"`
+	Role      string `json:"role"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// jwtTokenIssuer implements the TokenIssuer port with HS256 signed JSON Web Tokens
+type jwtTokenIssuer struct {
+	config JWTTokenIssuerConfig
+	now    func() time.Time
+}
+
+// NewJWTTokenIssuer creates a token issuer signing with the given key
+func NewJWTTokenIssuer(config JWTTokenIssuerConfig) (ports.TokenIssuer, error) {
+	if len(config.Secret) < minJWTSecretBytes {
+		return nil, fmt.Errorf("jwt signing key must be at least %d bytes", minJWTSecretBytes)
+	}
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("jwt issuer is required")
+	}
+	if config.TTL <= 0 {
+		return nil, fmt.Errorf("jwt token TTL must be greater than 0")
+	}
+
+	return &jwtTokenIssuer{
+		config: config,
+		now:    time.Now,
+	}, nil
+}
+
+// Issue signs a token with the claims of the user, valid for the configured TTL
+func (i *jwtTokenIssuer) Issue(user *entities.User) (*entities.AuthToken, error) {
+	now := i.now()
+	expiresAt := now.Add(i.config.TTL)
+
+	payload, err := json.Marshal(jwtClaims{
+		Issuer:    i.config.Issuer,
+		Subject:   user.ID,
+		Username:  user.Username,
+		Role:      user.Role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	signingInput := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return &entities.AuthToken{
+		Token:     signingInput + "." + base64.RawURLEncoding.EncodeToString(i.mac(signingInput)),
+		ExpiresAt: time.Unix(expiresAt.Unix(), 0).UTC(),
+	}, nil
+}
+
+// Verify checks the header, signature, issuer and lifetime of the token
+func (i *jwtTokenIssuer) Verify(token string) (*entities.AuthClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", domainerrors.ErrInvalidToken)
+	}
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed header", domainerrors.ErrInvalidToken)
+	}
+	var decodedHeader struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := json.Unmarshal(header, &decodedHeader); err != nil || decodedHeader.Algorithm != "HS256" {
+		return nil, fmt.Errorf("%w: unsupported algorithm", domainerrors.ErrInvalidToken)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, i.mac(parts[0]+"."+parts[1])) {
+		return nil, fmt.Errorf("%w: bad signature", domainerrors.ErrInvalidToken)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", domainerrors.ErrInvalidToken)
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", domainerrors.ErrInvalidToken)
+	}
+	if claims.Issuer != i.config.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", domainerrors.ErrInvalidToken, claims.Issuer)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", domainerrors.ErrInvalidToken)
+	}
+
+	now := i.now()
+	issuedAt, expiresAt := time.Unix(claims.IssuedAt, 0).UTC(), time.Unix(claims.ExpiresAt, 0).UTC()
+	if issuedAt.After(now.Add(jwtClockSkew)) {
+		return nil, fmt.Errorf("%w: issued in the future", domainerrors.ErrInvalidToken)
+	}
+	if !now.Before(expiresAt.Add(jwtClockSkew)) {
+		return nil, domainerrors.ErrTokenExpired
+	}
+
+	return &entities.AuthClaims{
+		Subject:   claims.Subject,
+		Username:  claims.Username,
+		Role:      claims.Role,
+		Issuer:    claims.Issuer,
+		IssuedAt:  issuedAt,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (i *jwtTokenIssuer) mac(signingInput string) []byte {
+	h := hmac.New(sha256.New, i.config.Secret)
+	h.Write([]byte(signingInput))
+	return h.Sum(nil)
+}
@@ -0,0 +1,100 @@
+package security
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+)
+
+var jwtSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestIssuer(t *testing.T, issuer string, now time.Time) *jwtTokenIssuer {
+	tokenIssuer, err := NewJWTTokenIssuer(JWTTokenIssuerConfig{Secret: jwtSecret, Issuer: issuer, TTL: time.Hour})
+	require.NoError(t, err)
+
+	impl := tokenIssuer.(*jwtTokenIssuer)
+	impl.now = func() time.Time { return now }
+	return impl
+}
+
+func TestNewJWTTokenIssuer(t *testing.T) {
+	tests := []struct {
+		name   string
+		config JWTTokenIssuerConfig
+	}{
+		{name: "short key", config: JWTTokenIssuerConfig{Secret: []byte("short"), Issuer: "soc", TTL: time.Hour}},
+		{name: "missing issuer", config: JWTTokenIssuerConfig{Secret: jwtSecret, TTL: time.Hour}},
+		{name: "missing TTL", config: JWTTokenIssuerConfig{Secret: jwtSecret, Issuer: "soc"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenIssuer, err := NewJWTTokenIssuer(tt.config)
+			assert.Error(t, err)
+			assert.Nil(t, tokenIssuer)
+		})
+	}
+}
+
+func TestJWTTokenIssuer_IssueAndVerify(t *testing.T) {
+	now := time.Unix(1718000000, 0).UTC()
+	user := &entities.User{ID: "user-1", Username: "maria", Role: entities.UserRoleAdmin}
+
+	t.Run("should round trip the user claims", func(t *testing.T) {
+		issuer := newTestIssuer(t, "soc", now)
+		token, err := issuer.Issue(user)
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(time.Hour), token.ExpiresAt)
+
+		claims, err := issuer.Verify(token.Token)
+		require.NoError(t, err)
+		assert.Equal(t, &entities.AuthClaims{
+			Subject:   "user-1",
+			Username:  "maria",
+			Role:      entities.UserRoleAdmin,
+			Issuer:    "soc",
+			IssuedAt:  now,
+			ExpiresAt: now.Add(time.Hour),
+		}, claims)
+	})
+
+	t.Run("should reject expired tokens", func(t *testing.T) {
+		issuer := newTestIssuer(t, "soc", now)
+		token, err := issuer.Issue(user)
+		require.NoError(t, err)
+
+		issuer.now = func() time.Time { return now.Add(2 * time.Hour) }
+		_, err = issuer.Verify(token.Token)
+		assert.ErrorIs(t, err, domainerrors.ErrTokenExpired)
+	})
+
+	t.Run("should reject tampered and foreign tokens", func(t *testing.T) {
+		issuer := newTestIssuer(t, "soc", now)
+		token, err := issuer.Issue(user)
+		require.NoError(t, err)
+		parts := strings.Split(token.Token, ".")
+
+		other, err := newTestIssuer(t, "other", now).Issue(user)
+		require.NoError(t, err)
+
+		unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."
+		forged := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"soc","sub":"user-1","role":"admin","iat":1718000000,"exp":1918000000}`)) + "." + parts[2]
+
+		for name, candidate := range map[string]string{
+			"malformed":      "not-a-token",
+			"alg none":       unsigned,
+			"forged claims":  forged,
+			"foreign issuer": other.Token,
+		} {
+			_, err := issuer.Verify(candidate)
+			assert.ErrorIs(t, err, domainerrors.ErrInvalidToken, name)
+		}
+	})
+}
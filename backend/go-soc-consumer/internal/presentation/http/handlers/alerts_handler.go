@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/alerting"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// AlertResponse is the JSON representation of an alert raised by an alert rule
+type AlertResponse struct {
+	ID          string    `json:"id"`
+	Rule        string    `json:"rule"`
+	Status      string    `json:"status"`
+	Severity    string    `json:"severity"`
+	MACAddress  string    `json:"mac_address,omitempty"`
+	SensorType  string    `json:"sensor_type,omitempty"`
+	Condition   string    `json:"condition,omitempty"` // the condition of a composite rule
+	Channel     int       `json:"channel"`
+	Value       float64   `json:"value"`
+	Threshold   float64   `json:"threshold"`
+	Description string    `json:"description"`
+	Since       time.Time `json:"since"`
+	RaisedAt    time.Time `json:"raised_at"`
+}
+
+// AlertsResponse lists alerts
+type AlertsResponse struct {
+	Alerts []AlertResponse `json:"alerts"`
+}
+
+// AlertsHandler serves the active and recent alerts of the alert rules
+type AlertsHandler struct {
+	alertingUseCase alerting.AlertingUseCase
+	pagination      pagination.Policy
+}
+
+func NewAlertsHandler(alertingUseCase alerting.AlertingUseCase, policy pagination.Policy) *AlertsHandler {
+	return &AlertsHandler{
+		alertingUseCase: alertingUseCase,
+		pagination:      policy,
+	}
+}
+
+// ListActive handles GET /api/v1/alerts/active
+func (h *AlertsHandler) ListActive(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, newAlertsResponse(h.alertingUseCase.ActiveAlerts(), i18n.LanguageFromContext(r.Context())))
+}
+
+// ListRecent handles GET /api/v1/alerts/recent?limit=N, newest first
+func (h *AlertsHandler) ListRecent(w http.ResponseWriter, r *http.Request) {
+	limit, err := h.pagination.ParseRequestLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		writeError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAlertsResponse(h.alertingUseCase.RecentAlerts(limit), i18n.LanguageFromContext(r.Context())))
+}
+
+func newAlertsResponse(alerts []*entities.Alert, language i18n.Language) AlertsResponse {
+	response := AlertsResponse{Alerts: make([]AlertResponse, 0, len(alerts))}
+	for _, alert := range alerts {
+		description := alert.Description
+		if localized, ok := alert.LocalizedDescriptions[string(language)]; ok {
+			description = localized
+		}
+		response.Alerts = append(response.Alerts, AlertResponse{
+			ID:          alert.EventID,
+			Rule:        alert.Rule,
+			Status:      alert.Status,
+			Severity:    alert.Severity,
+			MACAddress:  alert.MACAddress,
+			SensorType:  alert.SensorType,
+			Condition:   alert.Condition,
+			Channel:     alert.Channel,
+			Value:       alert.Value,
+			Threshold:   alert.Threshold,
+			Description: description,
+			Since:       alert.Since,
+			RaisedAt:    alert.RaisedAt,
+		})
+	}
+	return response
+}
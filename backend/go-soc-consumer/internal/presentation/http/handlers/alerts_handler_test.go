@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func TestAlertsHandler(t *testing.T) {
+	rule, err := entities.NewAlertRule("dry_soil", "soil_moisture", entities.AlertOperatorBelow, 20, 5, 15*time.Minute, time.Hour, entities.AlertSeverityCritical)
+	require.NoError(t, err)
+	since := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	alert, err := entities.NewAlert(rule, entities.AlertStatusFiring, "AA:BB:CC:DD:EE:FF", 2, 17.5, since, since.Add(15*time.Minute), "dry")
+	require.NoError(t, err)
+	alert.LocalizedDescriptions = map[string]string{"en": "dry", "es": "seco"}
+
+	t.Run("should list active alerts", func(t *testing.T) {
+		useCase := mocks.NewMockAlertingUseCase(t)
+		useCase.EXPECT().ActiveAlerts().Return([]*entities.Alert{alert}).Once()
+		handler := NewAlertsHandler(useCase, pagination.DefaultPolicy())
+
+		rec := httptest.NewRecorder()
+		handler.ListActive(rec, httptest.NewRequest(http.MethodGet, "/api/v1/alerts/active", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response AlertsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Alerts, 1)
+		assert.Equal(t, alert.EventID, response.Alerts[0].ID)
+		assert.Equal(t, "dry_soil", response.Alerts[0].Rule)
+		assert.Equal(t, entities.AlertSeverityCritical, response.Alerts[0].Severity)
+		assert.Equal(t, 2, response.Alerts[0].Channel)
+		assert.Equal(t, 17.5, response.Alerts[0].Value)
+		assert.Equal(t, since, response.Alerts[0].Since)
+		assert.Equal(t, "dry", response.Alerts[0].Description)
+	})
+
+	t.Run("should list recent alerts in the request language", func(t *testing.T) {
+		useCase := mocks.NewMockAlertingUseCase(t)
+		useCase.EXPECT().RecentAlerts(5).Return([]*entities.Alert{alert}).Once()
+		handler := NewAlertsHandler(useCase, pagination.DefaultPolicy())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/alerts/recent?limit=5", nil)
+		req = req.WithContext(i18n.ContextWithLanguage(req.Context(), i18n.Spanish))
+		rec := httptest.NewRecorder()
+		handler.ListRecent(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response AlertsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Alerts, 1)
+		assert.Equal(t, "seco", response.Alerts[0].Description)
+	})
+
+	t.Run("should list composite alerts with their condition", func(t *testing.T) {
+		composite, err := entities.NewCompositeAlertRule("dry_zone_a", "all(soil_moisture@zone_a < 20)", map[string][]string{"zone_a": {"AA:BB:CC:DD:EE:FF"}}, 0, 0, "")
+		require.NoError(t, err)
+		compositeAlert, err := entities.NewCompositeAlert(composite, entities.AlertStatusFiring, since, since, "dry zone")
+		require.NoError(t, err)
+		useCase := mocks.NewMockAlertingUseCase(t)
+		useCase.EXPECT().ActiveAlerts().Return([]*entities.Alert{compositeAlert}).Once()
+		handler := NewAlertsHandler(useCase, pagination.DefaultPolicy())
+
+		rec := httptest.NewRecorder()
+		handler.ListActive(rec, httptest.NewRequest(http.MethodGet, "/api/v1/alerts/active", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"condition":"all(soil_moisture@zone_a \u003c 20)"`)
+		assert.NotContains(t, rec.Body.String(), "mac_address")
+	})
+
+	t.Run("should reject an invalid limit", func(t *testing.T) {
+		handler := NewAlertsHandler(mocks.NewMockAlertingUseCase(t), pagination.DefaultPolicy())
+
+		rec := httptest.NewRecorder()
+		handler.ListRecent(rec, httptest.NewRequest(http.MethodGet, "/api/v1/alerts/recent?limit=abc", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/middleware"
+	userauth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/user_auth"
+)
+
+// LoginRequest is the body of the login endpoint
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginResponse carries the access token issued on login
+type LoginResponse struct {
+	AccessToken string       `json:"access_token"`
+	TokenType   string       `json:"token_type"` // always Bearer
+	ExpiresAt   time.Time    `json:"expires_at"`
+	ExpiresIn   int64        `json:"expires_in"` // seconds
+	User        UserResponse `json:"user"`
+}
+
+// UserRequest is the body of the user creation endpoint
+type UserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     string `json:"role,omitempty"` // admin or viewer, defaults to viewer
+}
+
+// UserResponse is the JSON representation of a user, without its password
+type UserResponse struct {
+	Username  string     `json:"username"`
+	Role      string     `json:"role"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// UsersResponse lists the users sorted by username
+type UsersResponse struct {
+	Users []UserResponse `json:"users"`
+}
+
+// ClaimsResponse is the JSON representation of the claims of the caller's access token
+type ClaimsResponse struct {
+	Subject   string     `json:"subject"`
+	Username  string     `json:"username"`
+	Role      string     `json:"role"`
+	Issuer    string     `json:"issuer,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// AuthHandler serves the login of the API users and their administration
+type AuthHandler struct {
+	authUseCase userauth.UserAuthUseCase
+	token       string
+	now         func() time.Time
+}
+
+func NewAuthHandler(authUseCase userauth.UserAuthUseCase, token string) *AuthHandler {
+	return &AuthHandler{
+		authUseCase: authUseCase,
+		token:       token,
+		now:         time.Now,
+	}
+}
+
+// Login handles POST /auth/login
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var request LoginRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, token, err := h.authUseCase.Login(r.Context(), request.Username, request.Password)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidCredentials) {
+			writeDomainError(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		writeError(w, r, "failed to log in", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, LoginResponse{
+		AccessToken: token.Token,
+		TokenType:   "Bearer",
+		ExpiresAt:   token.ExpiresAt,
+		ExpiresIn:   int64(token.ExpiresAt.Sub(h.now()).Seconds()),
+		User:        UserResponse{Username: user.Username, Role: user.Role},
+	})
+}
+
+// Me handles GET /api/v1/auth/me, returning the claims the authentication middleware stored
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	claims := middleware.ClaimsFromContext(r.Context())
+	if claims == nil {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	response := ClaimsResponse{
+		Subject:  claims.Subject,
+		Username: claims.Username,
+		Role:     claims.Role,
+		Issuer:   claims.Issuer,
+	}
+	if !claims.ExpiresAt.IsZero() {
+		response.ExpiresAt = &claims.ExpiresAt
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// ListUsers handles GET /admin/users
+func (h *AuthHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	users, err := h.authUseCase.ListUsers(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to list users", http.StatusInternalServerError)
+		return
+	}
+
+	response := UsersResponse{Users: make([]UserResponse, 0, len(users))}
+	for _, user := range users {
+		response.Users = append(response.Users, newUserResponse(user))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// CreateUser handles POST /admin/users
+func (h *AuthHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request UserRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.authUseCase.CreateUser(r.Context(), entities.UserRequest(request))
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrInvalidUser):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		case errors.Is(err, domainerrors.ErrUserAlreadyExists):
+			writeDomainError(w, r, err, http.StatusConflict)
+		default:
+			writeError(w, r, "failed to create user", http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, newUserResponse(user))
+}
+
+// DeleteUser handles DELETE /admin/users/{user}
+func (h *AuthHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.authUseCase.DeleteUser(r.Context(), r.PathValue("user")); err != nil {
+		if errors.Is(err, domainerrors.ErrUserNotFound) {
+			writeDomainError(w, r, err, http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to delete user", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newUserResponse(user *entities.User) UserResponse {
+	return UserResponse{
+		Username:  user.Username,
+		Role:      user.Role,
+		CreatedAt: &user.CreatedAt,
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/middleware"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestAuthHandler_Login(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	body := `{"username":"maria","password":"regando-2025"}`
+
+	t.Run("should return the access token", func(t *testing.T) {
+		useCase := mocks.NewMockUserAuthUseCase(t)
+		useCase.EXPECT().Login(mock.Anything, "maria", "regando-2025").Return(
+			&entities.User{Username: "maria", Role: entities.UserRoleAdmin},
+			&entities.AuthToken{Token: "signed", ExpiresAt: now.Add(12 * time.Hour)},
+			nil,
+		).Once()
+
+		handler := NewAuthHandler(useCase, "secret")
+		handler.now = func() time.Time { return now }
+		rec := httptest.NewRecorder()
+		handler.Login(rec, httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body)))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"access_token":"signed","token_type":"Bearer","expires_at":"2025-06-02T00:00:00Z","expires_in":43200,
+			"user":{"username":"maria","role":"admin"}}`, rec.Body.String())
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: domainerrors.ErrInvalidCredentials, expected: http.StatusUnauthorized},
+			{err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockUserAuthUseCase(t)
+			useCase.EXPECT().Login(mock.Anything, "maria", "regando-2025").Return(nil, nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			NewAuthHandler(useCase, "secret").Login(rec, httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body)))
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+}
+
+func TestAuthHandler_Me(t *testing.T) {
+	expiresAt := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/me", nil)
+	req = req.WithContext(middleware.ContextWithClaims(req.Context(), &entities.AuthClaims{
+		Subject: "user-1", Username: "maria", Role: entities.UserRoleViewer, Issuer: "go-soc-consumer", ExpiresAt: expiresAt,
+	}))
+
+	rec := httptest.NewRecorder()
+	NewAuthHandler(mocks.NewMockUserAuthUseCase(t), "secret").Me(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"subject":"user-1","username":"maria","role":"viewer","issuer":"go-soc-consumer","expires_at":"2025-06-02T00:00:00Z"}`, rec.Body.String())
+}
+
+func TestAuthHandler_CreateUser(t *testing.T) {
+	body := `{"username":"maria","password":"regando-2025","role":"admin"}`
+	request := entities.UserRequest{Username: "maria", Password: "regando-2025", Role: "admin"}
+
+	t.Run("should create the user without returning its password", func(t *testing.T) {
+		createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		useCase := mocks.NewMockUserAuthUseCase(t)
+		useCase.EXPECT().CreateUser(mock.Anything, request).Return(&entities.User{Username: "maria", PasswordHash: "$2a$10$hash", Role: "admin", CreatedAt: createdAt}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/users", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		NewAuthHandler(useCase, "secret").CreateUser(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"username":"maria","role":"admin","created_at":"2025-06-01T12:00:00Z"}`, rec.Body.String())
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: fmt.Errorf("%w: role must be admin or viewer", domainerrors.ErrInvalidUser), expected: http.StatusBadRequest},
+			{err: domainerrors.ErrUserAlreadyExists, expected: http.StatusConflict},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockUserAuthUseCase(t)
+			useCase.EXPECT().CreateUser(mock.Anything, request).Return(nil, tt.err).Once()
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/users", strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer secret")
+			rec := httptest.NewRecorder()
+			NewAuthHandler(useCase, "secret").CreateUser(rec, req)
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+
+	t.Run("should reject requests without the admin token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewAuthHandler(mocks.NewMockUserAuthUseCase(t), "secret").CreateUser(rec, httptest.NewRequest(http.MethodPost, "/admin/users", strings.NewReader(body)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
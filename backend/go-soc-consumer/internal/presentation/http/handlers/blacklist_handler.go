@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/blacklist"
+)
+
+// BlacklistEntryRequest is the body of the blacklist creation endpoint
+type BlacklistEntryRequest struct {
+	Kind   string `json:"kind"`  // "mac" or "ip_range"
+	Value  string `json:"value"` // MAC address, IP address or CIDR range
+	Reason string `json:"reason"`
+}
+
+// BlacklistEntryResponse is the JSON representation of a blacklist entry
+type BlacklistEntryResponse struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Value     string    `json:"value"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BlacklistResponse lists every blacklist entry
+type BlacklistResponse struct {
+	Entries []BlacklistEntryResponse `json:"entries"`
+}
+
+// BlacklistHandler serves the blacklist management endpoints
+type BlacklistHandler struct {
+	blacklistUseCase blacklist.BlacklistUseCase
+	token            string
+}
+
+func NewBlacklistHandler(blacklistUseCase blacklist.BlacklistUseCase, token string) *BlacklistHandler {
+	return &BlacklistHandler{
+		blacklistUseCase: blacklistUseCase,
+		token:            token,
+	}
+}
+
+// List handles GET /admin/blacklist
+func (h *BlacklistHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	entries, err := h.blacklistUseCase.List(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to list blacklist", http.StatusInternalServerError)
+		return
+	}
+
+	response := BlacklistResponse{Entries: make([]BlacklistEntryResponse, 0, len(entries))}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, newBlacklistEntryResponse(entry))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Add handles POST /admin/blacklist
+func (h *BlacklistHandler) Add(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request BlacklistEntryRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.blacklistUseCase.Add(r.Context(), request.Kind, request.Value, request.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrInvalidBlacklistEntry):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		case errors.Is(err, domainerrors.ErrBlacklistEntryExists):
+			writeError(w, r, "entry already blacklisted", http.StatusConflict)
+		default:
+			writeError(w, r, "failed to add blacklist entry", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newBlacklistEntryResponse(entry))
+}
+
+// Remove handles DELETE /admin/blacklist/{id}
+func (h *BlacklistHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.blacklistUseCase.Remove(r.Context(), r.PathValue("id")); err != nil {
+		if errors.Is(err, domainerrors.ErrBlacklistEntryNotFound) {
+			writeError(w, r, "blacklist entry not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to remove blacklist entry", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newBlacklistEntryResponse(entry *entities.BlacklistEntry) BlacklistEntryResponse {
+	return BlacklistEntryResponse{
+		ID:        entry.ID,
+		Kind:      entry.Kind,
+		Value:     entry.Value,
+		Reason:    entry.Reason,
+		CreatedAt: entry.CreatedAt,
+	}
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestBlacklistHandler_Add(t *testing.T) {
+	entry, err := entities.NewBlacklistEntry(entities.BlacklistKindIPRange, "10.0.0.0/24", "lab")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		token          string
+		body           string
+		entry          *entities.BlacklistEntry
+		err            error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "added", token: "secret", body: `{"kind":"ip_range","value":"10.0.0.0/24","reason":"lab"}`, entry: entry, expectCall: true, expectedStatus: http.StatusCreated},
+		{name: "invalid entry", token: "secret", body: `{"kind":"mac","value":"x"}`, err: domainerrors.ErrInvalidBlacklistEntry, expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "duplicate", token: "secret", body: `{"kind":"ip_range","value":"10.0.0.0/24"}`, err: domainerrors.ErrBlacklistEntryExists, expectCall: true, expectedStatus: http.StatusConflict},
+		{name: "invalid body", token: "secret", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "unauthorized", token: "guess", body: `{}`, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockBlacklistUseCase(t)
+			if tt.expectCall {
+				useCase.EXPECT().Add(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.entry, tt.err).Once()
+			}
+			handler := NewBlacklistHandler(useCase, "secret")
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/blacklist", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			handler.Add(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var response BlacklistEntryResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, entry.ID, response.ID)
+				assert.Equal(t, "10.0.0.0/24", response.Value)
+			}
+		})
+	}
+}
+
+func TestBlacklistHandler_List(t *testing.T) {
+	entry, err := entities.NewBlacklistEntry(entities.BlacklistKindMAC, "AA:BB:CC:DD:EE:FF", "")
+	require.NoError(t, err)
+	useCase := mocks.NewMockBlacklistUseCase(t)
+	useCase.EXPECT().List(mock.Anything).Return([]*entities.BlacklistEntry{entry}, nil).Once()
+	handler := NewBlacklistHandler(useCase, "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/blacklist", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler.List(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response BlacklistResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Entries, 1)
+	assert.Equal(t, "AA:BB:CC:DD:EE:FF", response.Entries[0].Value)
+}
+
+func TestBlacklistHandler_Remove(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "removed", expectedStatus: http.StatusNoContent},
+		{name: "unknown entry", err: domainerrors.ErrBlacklistEntryNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockBlacklistUseCase(t)
+			useCase.EXPECT().Remove(mock.Anything, "entry-1").Return(tt.err).Once()
+			handler := NewBlacklistHandler(useCase, "secret")
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/blacklist/entry-1", nil)
+			req.SetPathValue("id", "entry-1")
+			req.Header.Set("Authorization", "Bearer secret")
+			rec := httptest.NewRecorder()
+			handler.Remove(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	brokerauth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/broker_auth"
+)
+
+// Access values of Mosquitto auth plugins, sent instead of an action
+const (
+	mosquittoAccessRead      = 1
+	mosquittoAccessWrite     = 2
+	mosquittoAccessSubscribe = 4
+)
+
+// BrokerAuthRequest is the body of the broker authentication hook, with the field names of EMQX
+// and Mosquitto HTTP auth backends
+type BrokerAuthRequest struct {
+	ClientID        string `json:"clientid"`
+	Username        string `json:"username"`
+	Password        string `json:"password"`
+	PeerHost        string `json:"peerhost"`
+	CertFingerprint string `json:"cert_fingerprint"` // SHA-256 of the client certificate, when the broker forwards it
+}
+
+// BrokerACLRequest is the body of the broker authorization hook; EMQX sends the action, Mosquitto
+// auth plugins the access (1 read, 2 write, 4 subscribe)
+type BrokerACLRequest struct {
+	ClientID string `json:"clientid"`
+	Username string `json:"username"`
+	Topic    string `json:"topic"`
+	Action   string `json:"action"`
+	Access   int    `json:"acc"`
+}
+
+// BrokerAuthResponse is the answer to a broker hook, sent with 200 when allowed and 403 when denied
+type BrokerAuthResponse struct {
+	Result      string `json:"result"` // allow or deny
+	IsSuperuser bool   `json:"is_superuser"`
+}
+
+// BrokerAuthHandler serves the authentication and authorization hooks of external MQTT brokers
+type BrokerAuthHandler struct {
+	brokerAuthUseCase brokerauth.BrokerAuthUseCase
+	token             string
+}
+
+func NewBrokerAuthHandler(brokerAuthUseCase brokerauth.BrokerAuthUseCase, token string) *BrokerAuthHandler {
+	return &BrokerAuthHandler{
+		brokerAuthUseCase: brokerAuthUseCase,
+		token:             token,
+	}
+}
+
+// Authenticate handles POST /api/v1/broker/auth
+func (h *BrokerAuthHandler) Authenticate(w http.ResponseWriter, r *http.Request) {
+	if !bearerAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request BrokerAuthRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	decision, err := h.brokerAuthUseCase.Authenticate(r.Context(), &entities.BrokerAuthRequest{
+		Identity: entities.ClientIdentity{
+			ClientID:               request.ClientID,
+			Username:               request.Username,
+			CertificateFingerprint: request.CertFingerprint,
+			RemoteAddress:          request.PeerHost,
+		},
+		Password: request.Password,
+	})
+	if err != nil {
+		writeError(w, r, "failed to authenticate client", http.StatusInternalServerError)
+		return
+	}
+	writeBrokerDecision(w, decision)
+}
+
+// Authorize handles POST /api/v1/broker/acl
+func (h *BrokerAuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	if !bearerAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request BrokerACLRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	action := request.Action
+	if action == "" {
+		switch request.Access {
+		case mosquittoAccessRead, mosquittoAccessSubscribe:
+			action = entities.BrokerActionSubscribe
+		case mosquittoAccessWrite:
+			action = entities.BrokerActionPublish
+		}
+	}
+	if action != entities.BrokerActionPublish && action != entities.BrokerActionSubscribe {
+		writeError(w, r, "invalid broker action", http.StatusBadRequest)
+		return
+	}
+
+	decision, err := h.brokerAuthUseCase.Authorize(r.Context(), &entities.BrokerACLRequest{
+		ClientID: request.ClientID,
+		Username: request.Username,
+		Action:   action,
+		Topic:    request.Topic,
+	})
+	if err != nil {
+		writeError(w, r, "failed to authorize client", http.StatusInternalServerError)
+		return
+	}
+	writeBrokerDecision(w, decision)
+}
+
+// writeBrokerDecision replies with 200 when the decision allows and 403 when it denies, which both
+// EMQX and Mosquitto auth plugins read as the outcome
+func writeBrokerDecision(w http.ResponseWriter, decision *entities.BrokerAuthDecision) {
+	if !decision.Allow {
+		writeJSON(w, http.StatusForbidden, BrokerAuthResponse{Result: "deny"})
+		return
+	}
+	writeJSON(w, http.StatusOK, BrokerAuthResponse{Result: "allow", IsSuperuser: decision.Superuser})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestBrokerAuthHandler(t *testing.T) {
+	newRequest := func(path, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer hook-token")
+		return req
+	}
+
+	t.Run("should reject calls without the hook token", func(t *testing.T) {
+		useCase := mocks.NewMockBrokerAuthUseCase(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/broker/auth", strings.NewReader(`{}`))
+
+		rec := httptest.NewRecorder()
+		NewBrokerAuthHandler(useCase, "hook-token").Authenticate(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("should answer an allowed authentication with 200", func(t *testing.T) {
+		useCase := mocks.NewMockBrokerAuthUseCase(t)
+		useCase.EXPECT().Authenticate(
+			// the hook fields reach the use case as the client identity
+			mock.Anything, mock.MatchedBy(func(request *entities.BrokerAuthRequest) bool {
+				return request.Identity.Username == "AA:BB:CC:DD:EE:01" && request.Identity.RemoteAddress == "192.168.1.20" &&
+					request.Identity.CertificateFingerprint == "5d41" && request.Password == "token"
+			}),
+		).Return(&entities.BrokerAuthDecision{Allow: true}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewBrokerAuthHandler(useCase, "hook-token").Authenticate(rec, newRequest("/api/v1/broker/auth",
+			`{"clientid":"esp32-1","username":"AA:BB:CC:DD:EE:01","password":"token","peerhost":"192.168.1.20","cert_fingerprint":"5d41"}`))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response BrokerAuthResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "allow", response.Result)
+		assert.False(t, response.IsSuperuser)
+	})
+
+	t.Run("should answer a denied authorization with 403", func(t *testing.T) {
+		useCase := mocks.NewMockBrokerAuthUseCase(t)
+		useCase.EXPECT().Authorize(
+			mock.Anything, mock.MatchedBy(func(request *entities.BrokerACLRequest) bool {
+				return request.Action == entities.BrokerActionPublish && request.Topic == "/commands/AA:BB:CC:DD:EE:02"
+			}),
+		).Return(&entities.BrokerAuthDecision{}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewBrokerAuthHandler(useCase, "hook-token").Authorize(rec, newRequest("/api/v1/broker/acl",
+			`{"clientid":"esp32-1","username":"AA:BB:CC:DD:EE:01","topic":"/commands/AA:BB:CC:DD:EE:02","acc":2}`))
+
+		require.Equal(t, http.StatusForbidden, rec.Code)
+		var response BrokerAuthResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "deny", response.Result)
+	})
+
+	t.Run("should reject authorizations without a known action", func(t *testing.T) {
+		useCase := mocks.NewMockBrokerAuthUseCase(t)
+
+		rec := httptest.NewRecorder()
+		NewBrokerAuthHandler(useCase, "hook-token").Authorize(rec, newRequest("/api/v1/broker/acl",
+			`{"username":"AA:BB:CC:DD:EE:01","topic":"/commands/AA:BB:CC:DD:EE:01","acc":8}`))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	messagingmqtt "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/mqtt"
+)
+
+// ConsumerController pauses and resumes message consumption without restarting the consumer
+type ConsumerController interface {
+	Pause(ctx context.Context) error
+	Resume(ctx context.Context) error
+	Status() messagingmqtt.ConsumptionStatus
+}
+
+// ConsumerAdminHandler serves the operational endpoints used during maintenance windows
+type ConsumerAdminHandler struct {
+	consumer ConsumerController
+	token    string
+}
+
+func NewConsumerAdminHandler(consumer ConsumerController, token string) *ConsumerAdminHandler {
+	return &ConsumerAdminHandler{
+		consumer: consumer,
+		token:    token,
+	}
+}
+
+// Status handles GET /admin/consumers/mqtt
+func (h *ConsumerAdminHandler) Status(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.consumer.Status())
+}
+
+// Pause handles POST /admin/consumers/mqtt/pause
+func (h *ConsumerAdminHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.consumer.Pause(r.Context()); err != nil {
+		http.Error(w, "failed to pause consumption: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.consumer.Status())
+}
+
+// Resume handles POST /admin/consumers/mqtt/resume
+func (h *ConsumerAdminHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.consumer.Resume(r.Context()); err != nil {
+		http.Error(w, "failed to resume consumption: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h.consumer.Status())
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	messagingmqtt "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/mqtt"
+)
+
+type fakeConsumerController struct {
+	paused   bool
+	pauseErr error
+}
+
+func (f *fakeConsumerController) Pause(ctx context.Context) error {
+	if f.pauseErr != nil {
+		return f.pauseErr
+	}
+	f.paused = true
+	return nil
+}
+
+func (f *fakeConsumerController) Resume(ctx context.Context) error {
+	f.paused = false
+	return nil
+}
+
+func (f *fakeConsumerController) Status() messagingmqtt.ConsumptionStatus {
+	return messagingmqtt.ConsumptionStatus{Paused: f.paused}
+}
+
+func TestConsumerAdminHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		action         string
+		token          string
+		pauseErr       error
+		expectedStatus int
+		expectedPaused bool
+	}{
+		{name: "pause", action: "pause", token: "secret", expectedStatus: http.StatusOK, expectedPaused: true},
+		{name: "resume", action: "resume", token: "secret", expectedStatus: http.StatusOK},
+		{name: "missing token", action: "pause", expectedStatus: http.StatusUnauthorized},
+		{name: "wrong token", action: "pause", token: "guess", expectedStatus: http.StatusUnauthorized},
+		{name: "pause failure", action: "pause", token: "secret", pauseErr: errors.New("not connected"), expectedStatus: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &fakeConsumerController{pauseErr: tt.pauseErr}
+			handler := NewConsumerAdminHandler(controller, "secret")
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/consumers/mqtt/"+tt.action, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+
+			if tt.action == "pause" {
+				handler.Pause(w, req)
+			} else {
+				handler.Resume(w, req)
+			}
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedPaused, controller.paused)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	crashreports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/crash_reports"
+)
+
+// CrashSignatureResponse is the JSON representation of a distinct crash
+type CrashSignatureResponse struct {
+	Signature   string    `json:"signature"`
+	Reason      string    `json:"reason"`
+	Backtrace   []string  `json:"backtrace"`
+	Occurrences int       `json:"occurrences"`
+	Devices     int       `json:"devices"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+// FirmwareCrashStatsResponse is the JSON representation of the crashes of a firmware version
+type FirmwareCrashStatsResponse struct {
+	FirmwareVersion string                   `json:"firmware_version"`
+	Crashes         int                      `json:"crashes"`
+	Devices         int                      `json:"devices"`
+	Signatures      int                      `json:"signatures"`
+	LastCrashAt     time.Time                `json:"last_crash_at"`
+	TopSignatures   []CrashSignatureResponse `json:"top_signatures"`
+}
+
+// CrashReportResponse lists the crashes of each firmware version, most crashes first
+type CrashReportResponse struct {
+	Firmware []FirmwareCrashStatsResponse `json:"firmware"`
+}
+
+// CrashReportHandler serves the crash frequency report of the firmware versions
+type CrashReportHandler struct {
+	crashReportsUseCase crashreports.CrashReportsUseCase
+}
+
+func NewCrashReportHandler(crashReportsUseCase crashreports.CrashReportsUseCase) *CrashReportHandler {
+	return &CrashReportHandler{crashReportsUseCase: crashReportsUseCase}
+}
+
+// Report handles GET /api/v1/firmware/crash-report?since=RFC3339, defaulting to the configured window
+func (h *CrashReportHandler) Report(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			writeError(w, r, "invalid since time", http.StatusBadRequest)
+			return
+		}
+	}
+
+	stats, err := h.crashReportsUseCase.FrequencyReport(r.Context(), since)
+	if err != nil {
+		writeError(w, r, "failed to load crash report", http.StatusInternalServerError)
+		return
+	}
+
+	response := CrashReportResponse{Firmware: make([]FirmwareCrashStatsResponse, 0, len(stats))}
+	for _, version := range stats {
+		response.Firmware = append(response.Firmware, newFirmwareCrashStatsResponse(version))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newFirmwareCrashStatsResponse(stats *entities.FirmwareCrashStats) FirmwareCrashStatsResponse {
+	response := FirmwareCrashStatsResponse{
+		FirmwareVersion: stats.FirmwareVersion,
+		Crashes:         stats.Crashes,
+		Devices:         stats.Devices,
+		Signatures:      stats.Signatures,
+		LastCrashAt:     stats.LastCrashAt,
+		TopSignatures:   make([]CrashSignatureResponse, 0, len(stats.TopSignatures)),
+	}
+	for _, signature := range stats.TopSignatures {
+		response.TopSignatures = append(response.TopSignatures, CrashSignatureResponse{
+			Signature:   signature.Signature,
+			Reason:      signature.Reason,
+			Backtrace:   signature.Backtrace,
+			Occurrences: signature.Occurrences,
+			Devices:     signature.Devices,
+			FirstSeen:   signature.FirstSeen,
+			LastSeen:    signature.LastSeen,
+		})
+	}
+	return response
+}
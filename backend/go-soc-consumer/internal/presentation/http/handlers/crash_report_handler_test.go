@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestCrashReportHandler(t *testing.T) {
+	t.Run("should render the crashes of each firmware version", func(t *testing.T) {
+		since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		useCase := mocks.NewMockCrashReportsUseCase(t)
+		useCase.EXPECT().FrequencyReport(mock.Anything, since).Return([]*entities.FirmwareCrashStats{
+			{FirmwareVersion: "1.4.2", Crashes: 14, Devices: 6, Signatures: 1, TopSignatures: []*entities.CrashSignature{
+				{Signature: "abc", Reason: "LoadProhibited", Backtrace: []string{"0x400d1234"}, Occurrences: 14, Devices: 6},
+			}},
+			{FirmwareVersion: "1.4.1", Crashes: 1, Devices: 1},
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewCrashReportHandler(useCase).Report(rec, httptest.NewRequest(http.MethodGet, "/api/v1/firmware/crash-report?since=2025-06-01T00:00:00Z", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response CrashReportResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Firmware, 2)
+		assert.Equal(t, 14, response.Firmware[0].Crashes)
+		assert.Equal(t, "LoadProhibited", response.Firmware[0].TopSignatures[0].Reason)
+		assert.NotNil(t, response.Firmware[1].TopSignatures, "versions without signatures render an empty list")
+	})
+
+	t.Run("should default to the configured window", func(t *testing.T) {
+		useCase := mocks.NewMockCrashReportsUseCase(t)
+		useCase.EXPECT().FrequencyReport(mock.Anything, time.Time{}).Return(nil, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewCrashReportHandler(useCase).Report(rec, httptest.NewRequest(http.MethodGet, "/api/v1/firmware/crash-report", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"firmware":[]}`, rec.Body.String())
+	})
+
+	t.Run("should reject an invalid since time", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewCrashReportHandler(mocks.NewMockCrashReportsUseCase(t)).Report(rec, httptest.NewRequest(http.MethodGet, "/api/v1/firmware/crash-report?since=yesterday", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should report failures", func(t *testing.T) {
+		useCase := mocks.NewMockCrashReportsUseCase(t)
+		useCase.EXPECT().FrequencyReport(mock.Anything, time.Time{}).Return(nil, errors.New("connection refused")).Once()
+
+		rec := httptest.NewRecorder()
+		NewCrashReportHandler(useCase).Report(rec, httptest.NewRequest(http.MethodGet, "/api/v1/firmware/crash-report", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
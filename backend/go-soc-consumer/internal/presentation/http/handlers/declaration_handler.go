@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	declarativeconfig "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/declarative_config"
+)
+
+// DeclarationDocument is the declarative configuration reconciled by PUT /api/v1/config/declare.
+// Zones, schedules and rules are recognized so declarations holding them are rejected instead of
+// silently ignored: this server has no zones or schedules and its alert rules are configured at startup.
+type DeclarationDocument struct {
+	FarmID    string                    `json:"farm_id,omitempty"`
+	Devices   []DeviceBundleDeviceEntry `json:"devices"`
+	Zones     json.RawMessage           `json:"zones,omitempty"`
+	Schedules json.RawMessage           `json:"schedules,omitempty"`
+	Rules     json.RawMessage           `json:"rules,omitempty"`
+}
+
+// DeclarationChangeResponse is one change that reconciles a declaration
+type DeclarationChangeResponse struct {
+	Action     string   `json:"action"` // create, update or delete
+	MACAddress string   `json:"mac_address"`
+	Fields     []string `json:"fields,omitempty"`
+}
+
+// DeclarationPlanResponse lists the changes planned on a dry run, or applied otherwise
+type DeclarationPlanResponse struct {
+	DryRun    bool                        `json:"dry_run"`
+	Changes   []DeclarationChangeResponse `json:"changes"`
+	Unchanged int                         `json:"unchanged"`
+}
+
+// DeclarationHandler reconciles the device configuration with declarations from infrastructure-as-code tools
+type DeclarationHandler struct {
+	declarativeConfigUseCase declarativeconfig.DeclarativeConfigUseCase
+	token                    string
+}
+
+func NewDeclarationHandler(declarativeConfigUseCase declarativeconfig.DeclarativeConfigUseCase, token string) *DeclarationHandler {
+	return &DeclarationHandler{
+		declarativeConfigUseCase: declarativeConfigUseCase,
+		token:                    token,
+	}
+}
+
+// Declare handles PUT /api/v1/config/declare?dry_run=true
+func (h *DeclarationHandler) Declare(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			writeError(w, r, "invalid dry_run", http.StatusBadRequest)
+			return
+		}
+		dryRun = parsed
+	}
+
+	var document DeclarationDocument
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxDeviceBundleBytes)).Decode(&document); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	for section, value := range map[string]json.RawMessage{"zones": document.Zones, "schedules": document.Schedules, "rules": document.Rules} {
+		if len(value) > 0 && string(value) != "null" && string(value) != "[]" {
+			writeDomainError(w, r, fmt.Errorf("%w: %s cannot be declared on this server", domainerrors.ErrInvalidDeclaration, section), http.StatusBadRequest)
+			return
+		}
+	}
+
+	declaration := &entities.Declaration{FarmID: document.FarmID, Devices: make([]entities.DeviceConfiguration, 0, len(document.Devices))}
+	for _, device := range document.Devices {
+		declaration.Devices = append(declaration.Devices, entities.DeviceConfiguration(device))
+	}
+
+	plan, err := h.declarativeConfigUseCase.Reconcile(r.Context(), declaration, dryRun)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidDeclaration) {
+			writeDomainError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		writeError(w, r, "failed to apply declaration", http.StatusInternalServerError)
+		return
+	}
+
+	response := DeclarationPlanResponse{DryRun: plan.DryRun, Changes: make([]DeclarationChangeResponse, 0, len(plan.Changes)), Unchanged: plan.Unchanged}
+	for _, change := range plan.Changes {
+		response.Changes = append(response.Changes, DeclarationChangeResponse(change))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newDeclareRequest(query, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/config/declare"+query, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func TestDeclarationHandler_Declare(t *testing.T) {
+	body := `{"farm_id":"farm-a","devices":[{"mac_address":"AA:BB:CC:DD:EE:01","device_name":"Sensor 1","ip_address":"10.0.0.1","location_description":"Greenhouse"}],"rules":[]}`
+	declaration := &entities.Declaration{FarmID: "farm-a", Devices: []entities.DeviceConfiguration{
+		{MACAddress: "AA:BB:CC:DD:EE:01", DeviceName: "Sensor 1", IPAddress: "10.0.0.1", LocationDescription: "Greenhouse"},
+	}}
+
+	t.Run("should return the planned changes of a dry run", func(t *testing.T) {
+		useCase := mocks.NewMockDeclarativeConfigUseCase(t)
+		useCase.EXPECT().Reconcile(mock.Anything, declaration, true).Return(&entities.DeclarationPlan{
+			DryRun: true,
+			Changes: []entities.DeclarationChange{
+				{Action: entities.DeclarationUpdate, MACAddress: "AA:BB:CC:DD:EE:01", Fields: []string{"device_name"}},
+				{Action: entities.DeclarationDelete, MACAddress: "AA:BB:CC:DD:EE:02"},
+			},
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewDeclarationHandler(useCase, "secret").Declare(rec, newDeclareRequest("?dry_run=true", body))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"dry_run":true,"unchanged":0,"changes":[
+			{"action":"update","mac_address":"AA:BB:CC:DD:EE:01","fields":["device_name"]},
+			{"action":"delete","mac_address":"AA:BB:CC:DD:EE:02"}]}`, rec.Body.String())
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			err      error
+			expected int
+		}{
+			{name: "invalid", err: fmt.Errorf("%w: device AA:BB:CC:DD:EE:01 belongs to farm farm-b", domainerrors.ErrInvalidDeclaration), expected: http.StatusBadRequest},
+			{name: "write failure", err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockDeclarativeConfigUseCase(t)
+			useCase.EXPECT().Reconcile(mock.Anything, declaration, false).Return(nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			NewDeclarationHandler(useCase, "secret").Declare(rec, newDeclareRequest("", body))
+			assert.Equal(t, tt.expected, rec.Code, tt.name)
+		}
+	})
+
+	t.Run("should reject sections this server cannot declare", func(t *testing.T) {
+		handler := NewDeclarationHandler(mocks.NewMockDeclarativeConfigUseCase(t), "secret")
+
+		for _, section := range []string{`"zones":[{"name":"north"}]`, `"schedules":[{}]`, `"rules":[{"name":"dry soil"}]`} {
+			rec := httptest.NewRecorder()
+			handler.Declare(rec, newDeclareRequest("", `{"devices":[],`+section+`}`))
+			assert.Equal(t, http.StatusBadRequest, rec.Code, section)
+			assert.Contains(t, rec.Body.String(), "cannot be declared on this server")
+		}
+	})
+
+	t.Run("should reject invalid requests", func(t *testing.T) {
+		handler := NewDeclarationHandler(mocks.NewMockDeclarativeConfigUseCase(t), "secret")
+
+		rec := httptest.NewRecorder()
+		handler.Declare(rec, newDeclareRequest("?dry_run=maybe", body))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		handler.Declare(rec, newDeclareRequest("", `{"devices":`))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		req := newDeclareRequest("", body)
+		req.Header.Del("Authorization")
+		rec = httptest.NewRecorder()
+		handler.Declare(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
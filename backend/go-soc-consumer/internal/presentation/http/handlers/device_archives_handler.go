@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicearchival "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_archival"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// DecommissionDeviceRequest is the optional body of the decommission endpoint
+type DecommissionDeviceRequest struct {
+	Reason string `json:"reason,omitempty"`
+	Actor  string `json:"actor,omitempty"`
+}
+
+// RestoreDeviceArchiveRequest is the optional body of the restore endpoint
+type RestoreDeviceArchiveRequest struct {
+	Actor string `json:"actor,omitempty"`
+}
+
+// DeviceArchiveResponse is the JSON representation of the archive of a decommissioned device
+type DeviceArchiveResponse struct {
+	ID           string     `json:"id"`
+	MACAddress   string     `json:"mac_address"`
+	DeviceName   string     `json:"device_name,omitempty"`
+	Farm         string     `json:"farm,omitempty"`
+	Reason       string     `json:"reason,omitempty"`
+	Actor        string     `json:"actor"`
+	Status       string     `json:"status"`
+	Storage      string     `json:"storage"`
+	ObjectKey    string     `json:"object_key,omitempty"`
+	Measurements int        `json:"measurements"`
+	Readings     int        `json:"readings"`
+	ArchivedAt   time.Time  `json:"archived_at"`
+	RestoredAt   *time.Time `json:"restored_at,omitempty"`
+	RestoredBy   string     `json:"restored_by,omitempty"`
+}
+
+// DeviceArchivesResponse lists device archives, most recently archived first
+type DeviceArchivesResponse struct {
+	Archives []DeviceArchiveResponse `json:"archives"`
+}
+
+// DeviceArchivesHandler decommissions devices into archives and restores them
+type DeviceArchivesHandler struct {
+	archivalUseCase devicearchival.DeviceArchivalUseCase
+	pagination      pagination.Policy
+	token           string
+}
+
+func NewDeviceArchivesHandler(archivalUseCase devicearchival.DeviceArchivalUseCase, policy pagination.Policy, token string) *DeviceArchivesHandler {
+	return &DeviceArchivesHandler{
+		archivalUseCase: archivalUseCase,
+		pagination:      policy,
+		token:           token,
+	}
+}
+
+// Decommission handles POST /admin/devices/{mac}/decommission
+func (h *DeviceArchivesHandler) Decommission(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request DecommissionDeviceRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	archive, err := h.archivalUseCase.Decommission(r.Context(), r.PathValue("mac"), request.Reason, request.Actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrDeviceNotFound):
+			writeError(w, r, "device not found", http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrInvalidDeviceArchive):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		default:
+			writeError(w, r, "failed to decommission device", http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, newDeviceArchiveResponse(archive))
+}
+
+// List handles GET /admin/archives?mac=&limit=N, most recently archived first
+func (h *DeviceArchivesHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	limit, err := h.pagination.ParseRequestLimit(query.Get("limit"))
+	if err != nil {
+		writeError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	archives, err := h.archivalUseCase.List(r.Context(), query.Get("mac"), limit)
+	if err != nil {
+		writeError(w, r, "failed to list device archives", http.StatusInternalServerError)
+		return
+	}
+
+	response := DeviceArchivesResponse{Archives: make([]DeviceArchiveResponse, 0, len(archives))}
+	for _, archive := range archives {
+		response.Archives = append(response.Archives, newDeviceArchiveResponse(archive))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Get handles GET /admin/archives/{id}
+func (h *DeviceArchivesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	archive, err := h.archivalUseCase.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceArchiveNotFound) {
+			writeDomainError(w, r, err, http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to read device archive", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, newDeviceArchiveResponse(archive))
+}
+
+// Restore handles POST /admin/archives/{id}/restore, putting the device back into service
+func (h *DeviceArchivesHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request RestoreDeviceArchiveRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	archive, err := h.archivalUseCase.Restore(r.Context(), r.PathValue("id"), request.Actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrDeviceArchiveNotFound):
+			writeDomainError(w, r, err, http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrDeviceNotFound):
+			writeError(w, r, "device not found", http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrDeviceArchiveRestored), errors.Is(err, domainerrors.ErrDeviceInService):
+			writeDomainError(w, r, err, http.StatusConflict)
+		default:
+			writeError(w, r, "failed to restore device archive", http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, newDeviceArchiveResponse(archive))
+}
+
+func newDeviceArchiveResponse(archive *entities.DeviceArchive) DeviceArchiveResponse {
+	return DeviceArchiveResponse{
+		ID:           archive.ID,
+		MACAddress:   archive.MACAddress,
+		DeviceName:   archive.DeviceName,
+		Farm:         archive.FarmID,
+		Reason:       archive.Reason,
+		Actor:        archive.Actor,
+		Status:       archive.Status,
+		Storage:      archive.Storage,
+		ObjectKey:    archive.ObjectKey,
+		Measurements: archive.Measurements,
+		Readings:     archive.Readings,
+		ArchivedAt:   archive.ArchivedAt,
+		RestoredAt:   archive.RestoredAt,
+		RestoredBy:   archive.RestoredBy,
+	}
+}
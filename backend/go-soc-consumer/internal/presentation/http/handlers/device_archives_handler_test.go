@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func TestDeviceArchivesHandler_Decommission(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/admin/devices/AA:BB:CC:DD:EE:FF/decommission", strings.NewReader(body))
+		req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+		req.Header.Set("Authorization", "Bearer secret")
+		return req
+	}
+
+	t.Run("should archive the device", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceArchivalUseCase(t)
+		useCase.EXPECT().Decommission(mock.Anything, "AA:BB:CC:DD:EE:FF", "water damage", "maria").Return(&entities.DeviceArchive{
+			ID: "archive-1", MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "finca-norte", Reason: "water damage", Actor: "maria",
+			Status: "archived", Storage: "database", Measurements: 2880, Readings: 1536, ArchivedAt: now,
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewDeviceArchivesHandler(useCase, pagination.DefaultPolicy(), "secret").Decommission(rec, newRequest(`{"reason":"water damage","actor":"maria"}`))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"id":"archive-1","mac_address":"AA:BB:CC:DD:EE:FF","farm":"finca-norte","reason":"water damage","actor":"maria",
+			"status":"archived","storage":"database","measurements":2880,"readings":1536,"archived_at":"2025-06-01T12:00:00Z"}`, rec.Body.String())
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: domainerrors.ErrDeviceNotFound, expected: http.StatusNotFound},
+			{err: domainerrors.ErrInvalidDeviceArchive, expected: http.StatusBadRequest},
+			{err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockDeviceArchivalUseCase(t)
+			useCase.EXPECT().Decommission(mock.Anything, "AA:BB:CC:DD:EE:FF", "", "").Return(nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			NewDeviceArchivesHandler(useCase, pagination.DefaultPolicy(), "secret").Decommission(rec, newRequest(""))
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		req := newRequest("")
+		req.Header.Del("Authorization")
+
+		rec := httptest.NewRecorder()
+		NewDeviceArchivesHandler(mocks.NewMockDeviceArchivalUseCase(t), pagination.DefaultPolicy(), "secret").Decommission(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestDeviceArchivesHandler_Restore(t *testing.T) {
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/admin/archives/archive-1/restore", strings.NewReader(`{"actor":"pedro"}`))
+		req.SetPathValue("id", "archive-1")
+		req.Header.Set("Authorization", "Bearer secret")
+		return req
+	}
+
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: domainerrors.ErrDeviceArchiveNotFound, expected: http.StatusNotFound},
+		{err: domainerrors.ErrDeviceArchiveRestored, expected: http.StatusConflict},
+		{err: domainerrors.ErrDeviceInService, expected: http.StatusConflict},
+		{err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		useCase := mocks.NewMockDeviceArchivalUseCase(t)
+		useCase.EXPECT().Restore(mock.Anything, "archive-1", "pedro").Return(nil, tt.err).Once()
+
+		rec := httptest.NewRecorder()
+		NewDeviceArchivesHandler(useCase, pagination.DefaultPolicy(), "secret").Restore(rec, newRequest())
+		assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicebundle "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_bundle"
+)
+
+// maxDeviceBundleBytes bounds the size of an imported bundle
+const maxDeviceBundleBytes = 8 << 20
+
+// DeviceBundleDocument is the JSON and YAML representation of a device bundle
+type DeviceBundleDocument struct {
+	Version    int                       `json:"version" yaml:"version"`
+	ExportedAt time.Time                 `json:"exported_at" yaml:"exported_at"`
+	Devices    []DeviceBundleDeviceEntry `json:"devices" yaml:"devices"`
+}
+
+// DeviceBundleDeviceEntry is the configuration of one device in a bundle document
+type DeviceBundleDeviceEntry struct {
+	MACAddress             string     `json:"mac_address" yaml:"mac_address"`
+	DeviceName             string     `json:"device_name,omitempty" yaml:"device_name,omitempty"`
+	IPAddress              string     `json:"ip_address,omitempty" yaml:"ip_address,omitempty"`
+	LocationDescription    string     `json:"location_description,omitempty" yaml:"location_description,omitempty"`
+	FarmID                 string     `json:"farm_id,omitempty" yaml:"farm_id,omitempty"`
+	CertificateFingerprint string     `json:"certificate_fingerprint,omitempty" yaml:"certificate_fingerprint,omitempty"`
+	CalibratedAt           *time.Time `json:"calibrated_at,omitempty" yaml:"calibrated_at,omitempty"`
+}
+
+// DeviceBundleImportResponse lists the MAC addresses an import created, updated and skipped
+type DeviceBundleImportResponse struct {
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Skipped []string `json:"skipped"`
+}
+
+// DeviceBundleHandler serves the device configuration export and import endpoints
+type DeviceBundleHandler struct {
+	deviceBundleUseCase devicebundle.DeviceBundleUseCase
+	token               string
+}
+
+func NewDeviceBundleHandler(deviceBundleUseCase devicebundle.DeviceBundleUseCase, token string) *DeviceBundleHandler {
+	return &DeviceBundleHandler{
+		deviceBundleUseCase: deviceBundleUseCase,
+		token:               token,
+	}
+}
+
+// Export handles GET /admin/devices/bundle?format=json|yaml
+func (h *DeviceBundleHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "yaml" {
+		writeError(w, r, "format must be json or yaml", http.StatusBadRequest)
+		return
+	}
+
+	bundle, err := h.deviceBundleUseCase.Export(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to export devices", http.StatusInternalServerError)
+		return
+	}
+
+	document := newDeviceBundleDocument(bundle)
+	w.Header().Set("Content-Disposition", `attachment; filename="devices-bundle.`+format+`"`)
+	if format == "json" {
+		writeJSON(w, http.StatusOK, document)
+		return
+	}
+
+	body, err := yaml.Marshal(document)
+	if err != nil {
+		writeError(w, r, "failed to export devices", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// Import handles POST /admin/devices/bundle?strategy=skip|overwrite|merge.
+// YAML bodies are recognized by their content type, anything else is read as JSON.
+func (h *DeviceBundleHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDeviceBundleBytes))
+	if err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var document DeviceBundleDocument
+	if strings.Contains(r.Header.Get("Content-Type"), "yaml") {
+		err = yaml.Unmarshal(body, &document)
+	} else {
+		err = json.Unmarshal(body, &document)
+	}
+	if err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	strategy := r.URL.Query().Get("strategy")
+	if strategy == "" {
+		strategy = entities.BundleConflictSkip
+	}
+
+	result, err := h.deviceBundleUseCase.Import(r.Context(), document.toEntity(), strategy)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidDeviceBundle) {
+			writeDomainError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		writeError(w, r, "failed to import devices", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, DeviceBundleImportResponse{
+		Created: result.Created,
+		Updated: result.Updated,
+		Skipped: result.Skipped,
+	})
+}
+
+func newDeviceBundleDocument(bundle *entities.DeviceBundle) DeviceBundleDocument {
+	document := DeviceBundleDocument{
+		Version:    bundle.Version,
+		ExportedAt: bundle.ExportedAt,
+		Devices:    make([]DeviceBundleDeviceEntry, 0, len(bundle.Devices)),
+	}
+	for _, device := range bundle.Devices {
+		document.Devices = append(document.Devices, DeviceBundleDeviceEntry(device))
+	}
+	return document
+}
+
+func (d DeviceBundleDocument) toEntity() *entities.DeviceBundle {
+	bundle := &entities.DeviceBundle{
+		Version:    d.Version,
+		ExportedAt: d.ExportedAt,
+		Devices:    make([]entities.DeviceConfiguration, 0, len(d.Devices)),
+	}
+	for _, device := range d.Devices {
+		bundle.Devices = append(bundle.Devices, entities.DeviceConfiguration(device))
+	}
+	return bundle
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func testDeviceBundle() *entities.DeviceBundle {
+	return &entities.DeviceBundle{
+		Version:    entities.DeviceBundleVersion,
+		ExportedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Devices: []entities.DeviceConfiguration{
+			{MACAddress: "AA:BB:CC:DD:EE:01", DeviceName: "Greenhouse sensor", IPAddress: "192.168.1.10", LocationDescription: "Greenhouse", FarmID: "farm-1"},
+		},
+	}
+}
+
+func TestDeviceBundleHandler_Export(t *testing.T) {
+	t.Run("should export JSON by default", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceBundleUseCase(t)
+		useCase.EXPECT().Export(mock.Anything).Return(testDeviceBundle(), nil).Once()
+		handler := NewDeviceBundleHandler(useCase, "secret")
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/devices/bundle", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.Export(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var document DeviceBundleDocument
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &document))
+		assert.Equal(t, entities.DeviceBundleVersion, document.Version)
+		require.Len(t, document.Devices, 1)
+		assert.Equal(t, "farm-1", document.Devices[0].FarmID)
+	})
+
+	t.Run("should export YAML on request", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceBundleUseCase(t)
+		useCase.EXPECT().Export(mock.Anything).Return(testDeviceBundle(), nil).Once()
+		handler := NewDeviceBundleHandler(useCase, "secret")
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/devices/bundle?format=yaml", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.Export(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+		var document DeviceBundleDocument
+		require.NoError(t, yaml.Unmarshal(rec.Body.Bytes(), &document))
+		require.Len(t, document.Devices, 1)
+		assert.Equal(t, "AA:BB:CC:DD:EE:01", document.Devices[0].MACAddress)
+	})
+
+	t.Run("should reject unknown formats", func(t *testing.T) {
+		handler := NewDeviceBundleHandler(mocks.NewMockDeviceBundleUseCase(t), "secret")
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/devices/bundle?format=xml", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.Export(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		handler := NewDeviceBundleHandler(mocks.NewMockDeviceBundleUseCase(t), "secret")
+
+		rec := httptest.NewRecorder()
+		handler.Export(rec, httptest.NewRequest(http.MethodGet, "/admin/devices/bundle", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestDeviceBundleHandler_Import(t *testing.T) {
+	jsonBody := `{"version":1,"devices":[{"mac_address":"AA:BB:CC:DD:EE:01","device_name":"Greenhouse sensor"}]}`
+	yamlBody := "version: 1\ndevices:\n  - mac_address: AA:BB:CC:DD:EE:01\n    device_name: Greenhouse sensor\n"
+	result := &entities.DeviceBundleImportResult{Created: []string{}, Updated: []string{"AA:BB:CC:DD:EE:01"}, Skipped: []string{}}
+
+	tests := []struct {
+		name             string
+		token            string
+		query            string
+		contentType      string
+		body             string
+		err              error
+		expectCall       bool
+		expectedStrategy string
+		expectedStatus   int
+	}{
+		{name: "json with the default strategy", token: "secret", contentType: "application/json", body: jsonBody, expectCall: true, expectedStrategy: entities.BundleConflictSkip, expectedStatus: http.StatusOK},
+		{name: "yaml merge", token: "secret", query: "?strategy=merge", contentType: "application/yaml", body: yamlBody, expectCall: true, expectedStrategy: entities.BundleConflictMerge, expectedStatus: http.StatusOK},
+		{name: "invalid bundle", token: "secret", query: "?strategy=overwrite", body: jsonBody, err: domainerrors.ErrInvalidDeviceBundle, expectCall: true, expectedStrategy: entities.BundleConflictOverwrite, expectedStatus: http.StatusBadRequest},
+		{name: "repository failure", token: "secret", body: jsonBody, err: errors.New("db down"), expectCall: true, expectedStrategy: entities.BundleConflictSkip, expectedStatus: http.StatusInternalServerError},
+		{name: "invalid body", token: "secret", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "unauthorized", token: "guess", body: jsonBody, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockDeviceBundleUseCase(t)
+			if tt.expectCall {
+				useCase.EXPECT().Import(mock.Anything, mock.MatchedBy(func(bundle *entities.DeviceBundle) bool {
+					return bundle.Version == 1 && len(bundle.Devices) == 1 && bundle.Devices[0].DeviceName == "Greenhouse sensor"
+				}), tt.expectedStrategy).Return(result, tt.err).Once()
+			}
+			handler := NewDeviceBundleHandler(useCase, "secret")
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/devices/bundle"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler.Import(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response DeviceBundleImportResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, []string{"AA:BB:CC:DD:EE:01"}, response.Updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicechanges "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_changes"
+)
+
+// DeviceChangeResponse is the JSON representation of a device change
+type DeviceChangeResponse struct {
+	Cursor     uint64    `json:"cursor"`
+	MACAddress string    `json:"mac_address"`
+	Status     string    `json:"status"`
+	LastSeen   time.Time `json:"last_seen"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// DeviceChangesResponse lists device changes and the cursor to pass as since on the next request
+type DeviceChangesResponse struct {
+	Changes []DeviceChangeResponse `json:"changes"`
+	Cursor  uint64                 `json:"cursor"`
+}
+
+// DeviceChangesHandler serves the long-poll fallback for clients that cannot use streaming updates
+type DeviceChangesHandler struct {
+	deviceChangesUseCase devicechanges.DeviceChangesUseCase
+	defaultWait          time.Duration
+	maxWait              time.Duration
+}
+
+func NewDeviceChangesHandler(deviceChangesUseCase devicechanges.DeviceChangesUseCase, defaultWait, maxWait time.Duration) *DeviceChangesHandler {
+	return &DeviceChangesHandler{
+		deviceChangesUseCase: deviceChangesUseCase,
+		defaultWait:          defaultWait,
+		maxWait:              maxWait,
+	}
+}
+
+// ListChanges handles GET /api/v1/devices/changes?since=cursor&timeout=30s.
+// Without since it returns the current cursor right away. A 410 response carries the current
+// cursor: the client must reload the devices it tracks and resume from there.
+func (h *DeviceChangesHandler) ListChanges(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("since") == "" {
+		writeJSON(w, http.StatusOK, DeviceChangesResponse{Changes: []DeviceChangeResponse{}, Cursor: h.deviceChangesUseCase.Cursor()})
+		return
+	}
+	since, err := strconv.ParseUint(query.Get("since"), 10, 64)
+	if err != nil {
+		writeError(w, r, "invalid since cursor", http.StatusBadRequest)
+		return
+	}
+
+	wait := h.defaultWait
+	if query.Get("timeout") != "" {
+		wait, err = time.ParseDuration(query.Get("timeout"))
+		if err != nil || wait < 0 {
+			writeError(w, r, "invalid timeout", http.StatusBadRequest)
+			return
+		}
+	}
+	wait = min(wait, h.maxWait)
+
+	// The server write timeout is shorter than a long poll
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(wait + 10*time.Second))
+
+	changes, cursor, err := h.deviceChangesUseCase.Changes(r.Context(), since, wait)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrChangeCursorExpired):
+			writeJSON(w, http.StatusGone, DeviceChangesResponse{Changes: []DeviceChangeResponse{}, Cursor: cursor})
+		case errors.Is(err, context.Canceled):
+			// The client went away
+		default:
+			writeError(w, r, "failed to load device changes", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := DeviceChangesResponse{Changes: make([]DeviceChangeResponse, 0, len(changes)), Cursor: cursor}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, DeviceChangeResponse{
+			Cursor:     change.Cursor,
+			MACAddress: change.MACAddress,
+			Status:     change.Status,
+			LastSeen:   change.LastSeen,
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestDeviceChangesHandler_ListChanges(t *testing.T) {
+	t.Run("should return the current cursor without since", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceChangesUseCase(t)
+		useCase.EXPECT().Cursor().Return(uint64(7)).Once()
+
+		w := httptest.NewRecorder()
+		NewDeviceChangesHandler(useCase, 30*time.Second, time.Minute).ListChanges(w, httptest.NewRequest(http.MethodGet, "/api/v1/devices/changes", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response DeviceChangesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, uint64(7), response.Cursor)
+		assert.Empty(t, response.Changes)
+	})
+
+	t.Run("should wait for changes with the capped timeout", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceChangesUseCase(t)
+		useCase.EXPECT().Changes(mock.Anything, uint64(3), time.Minute).Return([]entities.DeviceChange{{
+			Cursor:     4,
+			MACAddress: "AA:BB:CC:DD:EE:FF",
+			Status:     "online",
+		}}, uint64(4), nil).Once()
+
+		w := httptest.NewRecorder()
+		NewDeviceChangesHandler(useCase, 30*time.Second, time.Minute).ListChanges(w, httptest.NewRequest(http.MethodGet, "/api/v1/devices/changes?since=3&timeout=5m", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response DeviceChangesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, uint64(4), response.Cursor)
+		require.Len(t, response.Changes, 1)
+		assert.Equal(t, "online", response.Changes[0].Status)
+	})
+
+	tests := []struct {
+		name         string
+		url          string
+		useCaseError error
+		expectedCode int
+	}{
+		{name: "invalid since", url: "/api/v1/devices/changes?since=abc", expectedCode: http.StatusBadRequest},
+		{name: "invalid timeout", url: "/api/v1/devices/changes?since=1&timeout=soon", expectedCode: http.StatusBadRequest},
+		{name: "expired cursor", url: "/api/v1/devices/changes?since=1", useCaseError: fmt.Errorf("%w: old", domainerrors.ErrChangeCursorExpired), expectedCode: http.StatusGone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockDeviceChangesUseCase(t)
+			if tt.useCaseError != nil {
+				useCase.EXPECT().Changes(mock.Anything, uint64(1), 30*time.Second).Return(nil, uint64(9), tt.useCaseError).Once()
+			}
+
+			w := httptest.NewRecorder()
+			NewDeviceChangesHandler(useCase, 30*time.Second, time.Minute).ListChanges(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
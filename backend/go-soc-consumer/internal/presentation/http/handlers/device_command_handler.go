@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicecommands "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_commands"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// SendDeviceCommandRequest is the body of a command sent to a device; only the parameters of the
+// named command are used
+type SendDeviceCommandRequest struct {
+	Command         string `json:"command"`                    // reboot, identify, factory_reset, valve_open, valve_close, configure or migrate
+	DelaySeconds    int    `json:"delay_seconds,omitempty"`    // reboot
+	DurationSeconds int    `json:"duration_seconds,omitempty"` // identify
+	Confirm         string `json:"confirm,omitempty"`          // factory_reset, the MAC address of the device
+
+	DeviceName          string `json:"device_name,omitempty"`          // configure
+	LocationDescription string `json:"location_description,omitempty"` // configure
+
+	BrokerURL   string `json:"broker_url,omitempty"`   // migrate
+	TopicPrefix string `json:"topic_prefix,omitempty"` // migrate
+}
+
+// DeviceCommandResponse is the JSON representation of a command sent to a device
+type DeviceCommandResponse struct {
+	ID         string          `json:"id"`
+	MACAddress string          `json:"mac_address"`
+	Command    string          `json:"command"`
+	Payload    json.RawMessage `json:"payload"`
+	Status     string          `json:"status"`
+	Message    string          `json:"message,omitempty"`
+	IssuedAt   time.Time       `json:"issued_at"`
+	ExpiresAt  time.Time       `json:"expires_at"`
+	AckedAt    *time.Time      `json:"acked_at,omitempty"`
+	Attempts   int             `json:"attempts"`
+}
+
+// DeviceCommandsResponse lists the commands sent to a device, newest first
+type DeviceCommandsResponse struct {
+	Commands []DeviceCommandResponse `json:"commands"`
+}
+
+// DeviceCommandHandler sends remote troubleshooting commands to devices
+type DeviceCommandHandler struct {
+	commandsUseCase devicecommands.DeviceCommandsUseCase
+	pagination      pagination.Policy
+	token           string
+}
+
+func NewDeviceCommandHandler(commandsUseCase devicecommands.DeviceCommandsUseCase, policy pagination.Policy, token string) *DeviceCommandHandler {
+	return &DeviceCommandHandler{
+		commandsUseCase: commandsUseCase,
+		pagination:      policy,
+		token:           token,
+	}
+}
+
+// Send handles POST /admin/devices/{mac}/commands. The command is accepted once published; poll it
+// to learn whether the device acknowledged it.
+func (h *DeviceCommandHandler) Send(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request SendDeviceCommandRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	command, err := h.commandsUseCase.Send(r.Context(), r.PathValue("mac"), entities.DeviceCommandRequest{
+		Command:         request.Command,
+		DelaySeconds:    request.DelaySeconds,
+		DurationSeconds: request.DurationSeconds,
+		Confirm:         request.Confirm,
+
+		DeviceName:          request.DeviceName,
+		LocationDescription: request.LocationDescription,
+
+		BrokerURL:   request.BrokerURL,
+		TopicPrefix: request.TopicPrefix,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrDeviceNotFound):
+			writeError(w, r, "device not found", http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrInvalidDeviceCommand):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		default:
+			writeError(w, r, "failed to send device command", http.StatusServiceUnavailable)
+		}
+		return
+	}
+	writeJSON(w, http.StatusAccepted, newDeviceCommandResponse(command))
+}
+
+// Get handles GET /admin/devices/{mac}/commands/{id}
+func (h *DeviceCommandHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	command, err := h.commandsUseCase.Get(r.Context(), r.PathValue("mac"), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceCommandNotFound) {
+			writeError(w, r, "device command not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to read device command", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, newDeviceCommandResponse(command))
+}
+
+// List handles GET /admin/devices/{mac}/commands?limit=N, newest first
+func (h *DeviceCommandHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit, err := h.pagination.ParseRequestLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		writeError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	commands, err := h.commandsUseCase.List(r.Context(), r.PathValue("mac"), limit)
+	if err != nil {
+		writeError(w, r, "failed to list device commands", http.StatusInternalServerError)
+		return
+	}
+
+	response := DeviceCommandsResponse{Commands: make([]DeviceCommandResponse, 0, len(commands))}
+	for _, command := range commands {
+		response.Commands = append(response.Commands, newDeviceCommandResponse(command))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newDeviceCommandResponse(command *entities.DeviceCommand) DeviceCommandResponse {
+	return DeviceCommandResponse{
+		ID:         command.ID,
+		MACAddress: command.MACAddress,
+		Command:    command.Command,
+		Payload:    command.Payload,
+		Status:     command.Status,
+		Message:    command.Message,
+		IssuedAt:   command.IssuedAt,
+		ExpiresAt:  command.ExpiresAt,
+		AckedAt:    command.AckedAt,
+		Attempts:   command.Attempts,
+	}
+}
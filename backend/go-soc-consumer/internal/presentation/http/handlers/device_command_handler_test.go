@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func newDeviceCommandMux(useCase *mocks.MockDeviceCommandsUseCase) *http.ServeMux {
+	handler := NewDeviceCommandHandler(useCase, pagination.DefaultPolicy(), "secret")
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/devices/{mac}/commands", handler.Send)
+	mux.HandleFunc("GET /admin/devices/{mac}/commands", handler.List)
+	mux.HandleFunc("GET /admin/devices/{mac}/commands/{id}", handler.Get)
+	return mux
+}
+
+func TestDeviceCommandHandler_Send(t *testing.T) {
+	issuedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		token          string
+		body           string
+		command        *entities.DeviceCommand
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "accepted",
+			token:          "secret",
+			body:           `{"command":"reboot","delay_seconds":5}`,
+			command:        &entities.DeviceCommand{ID: "abc123", MACAddress: "AA:BB:CC:DD:EE:FF", Command: entities.DeviceCommandReboot, Payload: json.RawMessage(`{"delay_seconds":5}`), Status: entities.DeviceCommandStatusSent, IssuedAt: issuedAt, ExpiresAt: issuedAt.Add(2 * time.Minute)},
+			expectedStatus: http.StatusAccepted,
+		},
+		{name: "unauthorized", token: "wrong", body: `{"command":"reboot"}`, expectedStatus: http.StatusUnauthorized},
+		{name: "invalid body", token: "secret", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "unconfirmed factory reset", token: "secret", body: `{"command":"factory_reset"}`, err: fmt.Errorf("%w: not confirmed", domainerrors.ErrInvalidDeviceCommand), expectedStatus: http.StatusBadRequest},
+		{name: "unknown device", token: "secret", body: `{"command":"identify"}`, err: domainerrors.ErrDeviceNotFound, expectedStatus: http.StatusNotFound},
+		{name: "broker unavailable", token: "secret", body: `{"command":"identify"}`, err: errors.New("MQTT client is not connected"), expectedStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockDeviceCommandsUseCase(t)
+			if tt.command != nil || tt.err != nil {
+				useCase.EXPECT().Send(mock.Anything, "aa:bb:cc:dd:ee:ff", mock.Anything).Return(tt.command, tt.err).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/devices/aa:bb:cc:dd:ee:ff/commands", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			newDeviceCommandMux(useCase).ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.command == nil {
+				return
+			}
+			var response DeviceCommandResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "abc123", response.ID)
+			assert.Equal(t, entities.DeviceCommandStatusSent, response.Status)
+			assert.JSONEq(t, `{"delay_seconds":5}`, string(response.Payload))
+			assert.Nil(t, response.AckedAt)
+		})
+	}
+}
+
+func TestDeviceCommandHandler_GetAndList(t *testing.T) {
+	ackedAt := time.Date(2025, 6, 1, 12, 0, 3, 0, time.UTC)
+	command := &entities.DeviceCommand{ID: "abc123", MACAddress: "AA:BB:CC:DD:EE:FF", Command: entities.DeviceCommandIdentify, Payload: json.RawMessage(`{}`), Status: entities.DeviceCommandStatusAcknowledged, AckedAt: &ackedAt}
+
+	useCase := mocks.NewMockDeviceCommandsUseCase(t)
+	useCase.EXPECT().Get(mock.Anything, "AA:BB:CC:DD:EE:FF", "abc123").Return(command, nil).Once()
+	useCase.EXPECT().Get(mock.Anything, "AA:BB:CC:DD:EE:FF", "missing").Return(nil, domainerrors.ErrDeviceCommandNotFound).Once()
+	useCase.EXPECT().List(mock.Anything, "AA:BB:CC:DD:EE:FF", 5).Return([]*entities.DeviceCommand{command}, nil).Once()
+	mux := newDeviceCommandMux(useCase)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("/admin/devices/AA:BB:CC:DD:EE:FF/commands/abc123")
+	require.Equal(t, http.StatusOK, w.Code)
+	var response DeviceCommandResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, entities.DeviceCommandStatusAcknowledged, response.Status)
+	require.NotNil(t, response.AckedAt)
+	assert.Equal(t, ackedAt, *response.AckedAt)
+
+	assert.Equal(t, http.StatusNotFound, serve("/admin/devices/AA:BB:CC:DD:EE:FF/commands/missing").Code)
+
+	w = serve("/admin/devices/AA:BB:CC:DD:EE:FF/commands?limit=5")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list DeviceCommandsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Commands, 1)
+	assert.Equal(t, "abc123", list.Commands[0].ID)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	deviceconnections "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_connections"
+)
+
+// DeviceClientResponse is the JSON representation of a client that published messages of a device
+type DeviceClientResponse struct {
+	ClientID      string    `json:"client_id"`
+	Username      string    `json:"username,omitempty"`
+	RemoteAddress string    `json:"remote_address,omitempty"`
+	FirstSeenAt   time.Time `json:"first_seen_at"`
+	LastSeenAt    time.Time `json:"last_seen_at"`
+}
+
+// DeviceConnectionResponse is the JSON representation of the clients of a device, the one that
+// published last first
+type DeviceConnectionResponse struct {
+	MACAddress string                 `json:"mac_address"`
+	Mismatch   bool                   `json:"mismatch"`
+	Clients    []DeviceClientResponse `json:"clients"`
+}
+
+// DeviceConnectionMismatchesResponse lists the devices several clients published for
+type DeviceConnectionMismatchesResponse struct {
+	Devices []DeviceConnectionResponse `json:"devices"`
+}
+
+// DeviceConnectionHandler serves the MQTT clients publishing the messages of devices
+type DeviceConnectionHandler struct {
+	connectionsUseCase deviceconnections.DeviceConnectionsUseCase
+	token              string
+}
+
+func NewDeviceConnectionHandler(connectionsUseCase deviceconnections.DeviceConnectionsUseCase, token string) *DeviceConnectionHandler {
+	return &DeviceConnectionHandler{
+		connectionsUseCase: connectionsUseCase,
+		token:              token,
+	}
+}
+
+// Get handles GET /admin/devices/{mac}/connection
+func (h *DeviceConnectionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	connection, err := h.connectionsUseCase.Get(r.Context(), r.PathValue("mac"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceConnectionNotFound) {
+			writeDomainError(w, r, err, http.StatusNotFound)
+			return
+		}
+		writeDomainError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusOK, newDeviceConnectionResponse(connection))
+}
+
+// ListMismatches handles GET /admin/devices/connections/mismatches
+func (h *DeviceConnectionHandler) ListMismatches(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	mismatches := h.connectionsUseCase.Mismatches()
+	response := DeviceConnectionMismatchesResponse{Devices: make([]DeviceConnectionResponse, 0, len(mismatches))}
+	for _, connection := range mismatches {
+		response.Devices = append(response.Devices, newDeviceConnectionResponse(connection))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newDeviceConnectionResponse(connection *entities.DeviceConnection) DeviceConnectionResponse {
+	response := DeviceConnectionResponse{
+		MACAddress: connection.MACAddress,
+		Mismatch:   connection.Mismatch(),
+		Clients:    make([]DeviceClientResponse, 0, len(connection.Clients)),
+	}
+	for _, client := range connection.Clients {
+		response.Clients = append(response.Clients, DeviceClientResponse{
+			ClientID:      client.ClientID,
+			Username:      client.Username,
+			RemoteAddress: client.RemoteAddress,
+			FirstSeenAt:   client.FirstSeenAt,
+			LastSeenAt:    client.LastSeenAt,
+		})
+	}
+	return response
+}
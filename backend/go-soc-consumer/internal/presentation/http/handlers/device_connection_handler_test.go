@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestDeviceConnectionHandler_Get(t *testing.T) {
+	seenAt := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+	connection := &entities.DeviceConnection{
+		MACAddress: "AA:BB:CC:DD:EE:FF",
+		Clients: []entities.DeviceClient{
+			{ClientID: "clone", RemoteAddress: "10.0.0.9", FirstSeenAt: seenAt, LastSeenAt: seenAt},
+			{ClientID: "sensor-01", Username: "sensor", RemoteAddress: "192.168.1.20", FirstSeenAt: seenAt.Add(-time.Hour), LastSeenAt: seenAt.Add(-time.Minute)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		err            error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "found", token: "secret", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "never seen", token: "secret", err: domainerrors.ErrDeviceConnectionNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "invalid mac", token: "secret", err: domainerrors.ErrInvalidDevice, expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "unauthorized", token: "guess", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockDeviceConnectionsUseCase(t)
+			if tt.expectCall {
+				result := connection
+				if tt.err != nil {
+					result = nil
+				}
+				useCase.EXPECT().Get(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(result, tt.err).Once()
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /admin/devices/{mac}/connection", NewDeviceConnectionHandler(useCase, "secret").Get)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/devices/AA:BB:CC:DD:EE:FF/connection", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var response DeviceConnectionResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "AA:BB:CC:DD:EE:FF", response.MACAddress)
+			assert.True(t, response.Mismatch)
+			require.Len(t, response.Clients, 2)
+			assert.Equal(t, "clone", response.Clients[0].ClientID)
+			assert.Equal(t, "192.168.1.20", response.Clients[1].RemoteAddress)
+		})
+	}
+}
+
+func TestDeviceConnectionHandler_ListMismatches(t *testing.T) {
+	useCase := mocks.NewMockDeviceConnectionsUseCase(t)
+	useCase.EXPECT().Mismatches().Return([]*entities.DeviceConnection{{
+		MACAddress: "AA:BB:CC:DD:EE:FF",
+		Clients:    []entities.DeviceClient{{ClientID: "clone"}, {ClientID: "sensor-01"}},
+	}}).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/devices/connections/mismatches", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	NewDeviceConnectionHandler(useCase, "secret").ListMismatches(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response DeviceConnectionMismatchesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Devices, 1)
+	assert.True(t, response.Devices[0].Mismatch)
+}
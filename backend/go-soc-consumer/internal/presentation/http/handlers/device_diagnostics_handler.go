@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicediagnostics "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_diagnostics"
+)
+
+// DeviceRecordResponse is the JSON representation of a stored device
+type DeviceRecordResponse struct {
+	MACAddress          string     `json:"mac_address"`
+	DeviceName          string     `json:"device_name"`
+	IPAddress           string     `json:"ip_address"`
+	LocationDescription string     `json:"location_description"`
+	Status              string     `json:"status"`
+	RegisteredAt        time.Time  `json:"registered_at"`
+	LastSeen            time.Time  `json:"last_seen"`
+	FarmID              string     `json:"farm_id,omitempty"`
+	HasCertificate      bool       `json:"has_certificate"`
+	CalibratedAt        *time.Time `json:"calibrated_at,omitempty"`
+
+	LastRegistration *BrokerMetadataResponse `json:"last_registration,omitempty"`
+}
+
+// BrokerMetadataResponse is the JSON representation of how the broker delivered a registration
+type BrokerMetadataResponse struct {
+	Topic    string `json:"topic"`
+	QoS      byte   `json:"qos"`
+	Retained bool   `json:"retained"`
+	ClientID string `json:"client_id,omitempty"`
+}
+
+// TelemetryReadingResponse is the JSON representation of the latest reading of a stream
+type TelemetryReadingResponse struct {
+	Stream    string    `json:"stream"`
+	Channel   int       `json:"channel"`
+	Value     float64   `json:"value"`
+	Unit      string    `json:"unit"`
+	Quality   string    `json:"quality"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DeviceDiagnosticsResponse is the JSON representation of the diagnostics of a device
+type DeviceDiagnosticsResponse struct {
+	GeneratedAt time.Time                  `json:"generated_at"`
+	Device      DeviceRecordResponse       `json:"device"`
+	Telemetry   []TelemetryReadingResponse `json:"telemetry"`
+	Transitions []DeviceChangeResponse     `json:"transitions"`
+	Probe       DeviceProbeResponse        `json:"probe"`
+}
+
+// DeviceDiagnosticsHandler serves the diagnostics of a device
+type DeviceDiagnosticsHandler struct {
+	diagnosticsUseCase devicediagnostics.DeviceDiagnosticsUseCase
+	token              string
+}
+
+func NewDeviceDiagnosticsHandler(diagnosticsUseCase devicediagnostics.DeviceDiagnosticsUseCase, token string) *DeviceDiagnosticsHandler {
+	return &DeviceDiagnosticsHandler{
+		diagnosticsUseCase: diagnosticsUseCase,
+		token:              token,
+	}
+}
+
+// Diagnose handles GET /admin/devices/{mac}/diagnostics
+func (h *DeviceDiagnosticsHandler) Diagnose(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	diagnostics, err := h.diagnosticsUseCase.Diagnose(r.Context(), r.PathValue("mac"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceNotFound) {
+			writeError(w, r, "device not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to diagnose device", http.StatusInternalServerError)
+		return
+	}
+
+	response := DeviceDiagnosticsResponse{
+		GeneratedAt: diagnostics.GeneratedAt,
+		Device:      newDeviceRecordResponse(diagnostics.Device),
+		Telemetry:   make([]TelemetryReadingResponse, 0, len(diagnostics.Telemetry)),
+		Transitions: make([]DeviceChangeResponse, 0, len(diagnostics.Transitions)),
+		Probe:       newDeviceProbeResponse(diagnostics.Probe),
+	}
+	for _, reading := range diagnostics.Telemetry {
+		response.Telemetry = append(response.Telemetry, TelemetryReadingResponse{
+			Stream:    reading.Stream,
+			Channel:   reading.Channel,
+			Value:     reading.Value,
+			Unit:      reading.Unit,
+			Quality:   reading.Quality,
+			Timestamp: reading.Timestamp,
+		})
+	}
+	for _, change := range diagnostics.Transitions {
+		response.Transitions = append(response.Transitions, DeviceChangeResponse{
+			Cursor:     change.Cursor,
+			MACAddress: change.MACAddress,
+			Status:     change.Status,
+			LastSeen:   change.LastSeen,
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// newBrokerMetadataResponse converts the broker metadata of a registration, nil when not recorded
+func newDeviceRecordResponse(device *entities.Device) DeviceRecordResponse {
+	return DeviceRecordResponse{
+		MACAddress:          device.GetID(),
+		DeviceName:          device.GetDeviceName(),
+		IPAddress:           device.GetIPAddress(),
+		LocationDescription: device.LocationDescription,
+		Status:              device.GetStatus(),
+		RegisteredAt:        device.RegisteredAt,
+		LastSeen:            device.GetLastSeen(),
+		FarmID:              device.FarmID,
+		HasCertificate:      device.CertificateFingerprint != "",
+		CalibratedAt:        device.CalibratedAt,
+		LastRegistration:    newBrokerMetadataResponse(device.LastRegistration),
+	}
+}
+
+func newBrokerMetadataResponse(metadata *entities.BrokerMetadata) *BrokerMetadataResponse {
+	if metadata == nil {
+		return nil
+	}
+	return &BrokerMetadataResponse{
+		Topic:    metadata.Topic,
+		QoS:      metadata.QoS,
+		Retained: metadata.Retained,
+		ClientID: metadata.ClientID,
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestDeviceDiagnosticsHandler_Diagnose(t *testing.T) {
+	generatedAt := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	device.LastRegistration = entities.NewBrokerMetadata("farms/farm-7/devices/registration", 1, false, "probe-7")
+	diagnostics := &entities.DeviceDiagnostics{
+		GeneratedAt: generatedAt,
+		Device:      device,
+		Telemetry: []*entities.TelemetryReading{
+			{Stream: "soil_moisture", Channel: 1, Value: 31, Unit: "percent", Quality: entities.MeasurementQualityGood, Timestamp: generatedAt.Add(-time.Minute)},
+		},
+		Transitions: []entities.DeviceChange{{Cursor: 7, MACAddress: "AA:BB:CC:DD:EE:FF", Status: "offline", ChangedAt: generatedAt.Add(-time.Hour)}},
+		Probe:       &entities.DeviceProbe{IPAddress: "192.168.1.10", Latency: 120 * time.Millisecond, Error: "connection refused", CheckedAt: generatedAt},
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		err            error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "diagnosed", token: "secret", expectCall: true, expectedStatus: http.StatusOK},
+		{name: "unknown device", token: "secret", err: domainerrors.ErrDeviceNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "failure", token: "secret", err: errors.New("database down"), expectCall: true, expectedStatus: http.StatusInternalServerError},
+		{name: "unauthorized", token: "guess", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockDeviceDiagnosticsUseCase(t)
+			if tt.expectCall {
+				result := diagnostics
+				if tt.err != nil {
+					result = nil
+				}
+				useCase.EXPECT().Diagnose(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(result, tt.err).Once()
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /admin/devices/{mac}/diagnostics", NewDeviceDiagnosticsHandler(useCase, "secret").Diagnose)
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/devices/AA:BB:CC:DD:EE:FF/diagnostics", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var response DeviceDiagnosticsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "AA:BB:CC:DD:EE:FF", response.Device.MACAddress)
+			assert.Equal(t, "192.168.1.10", response.Device.IPAddress)
+			assert.Equal(t, &BrokerMetadataResponse{Topic: "farms/farm-7/devices/registration", QoS: 1, ClientID: "probe-7"}, response.Device.LastRegistration)
+			require.Len(t, response.Telemetry, 1)
+			assert.Equal(t, "soil_moisture", response.Telemetry[0].Stream)
+			require.Len(t, response.Transitions, 1)
+			assert.Equal(t, "offline", response.Transitions[0].Status)
+			assert.Equal(t, int64(120), response.Probe.LatencyMS)
+			assert.Equal(t, "connection refused", response.Probe.Error)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicehealth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_health"
+)
+
+// DeviceProbeResponse is the JSON representation of a device health check
+type DeviceProbeResponse struct {
+	IPAddress string    `json:"ip_address"`
+	Alive     bool      `json:"alive"`
+	LatencyMS int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// DeviceHealthCheckResponse is the result of an on-demand health check of a device
+type DeviceHealthCheckResponse struct {
+	MACAddress string `json:"mac_address"`
+	DeviceProbeResponse
+}
+
+// DeviceHealthHandler runs on-demand device health checks
+type DeviceHealthHandler struct {
+	deviceHealthUseCase devicehealth.DeviceHealthUseCase
+}
+
+func NewDeviceHealthHandler(deviceHealthUseCase devicehealth.DeviceHealthUseCase) *DeviceHealthHandler {
+	return &DeviceHealthHandler{deviceHealthUseCase: deviceHealthUseCase}
+}
+
+// CheckHealth handles POST /api/v1/devices/{mac}/health-check
+func (h *DeviceHealthHandler) CheckHealth(w http.ResponseWriter, r *http.Request) {
+	probe, err := h.deviceHealthUseCase.CheckDevice(r.Context(), r.PathValue("mac"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceNotFound) {
+			writeError(w, r, "device not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to check device health", http.StatusServiceUnavailable)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, DeviceHealthCheckResponse{
+		MACAddress:          strings.ToUpper(strings.TrimSpace(r.PathValue("mac"))),
+		DeviceProbeResponse: newDeviceProbeResponse(probe),
+	})
+}
+
+func newDeviceProbeResponse(probe *entities.DeviceProbe) DeviceProbeResponse {
+	return DeviceProbeResponse{
+		IPAddress: probe.IPAddress,
+		Alive:     probe.Alive,
+		LatencyMS: probe.Latency.Milliseconds(),
+		Error:     probe.Error,
+		CheckedAt: probe.CheckedAt,
+	}
+}
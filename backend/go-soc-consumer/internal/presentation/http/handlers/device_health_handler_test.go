@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestDeviceHealthHandler_CheckHealth(t *testing.T) {
+	checkedAt := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		probe          *entities.DeviceProbe
+		err            error
+		expectedStatus int
+	}{
+		{name: "alive", probe: &entities.DeviceProbe{IPAddress: "192.168.1.10", Alive: true, Latency: 85 * time.Millisecond, CheckedAt: checkedAt}, expectedStatus: http.StatusOK},
+		{name: "unreachable", probe: &entities.DeviceProbe{IPAddress: "192.168.1.10", Latency: 10 * time.Second, Error: "context deadline exceeded", CheckedAt: checkedAt}, expectedStatus: http.StatusOK},
+		{name: "unknown device", err: domainerrors.ErrDeviceNotFound, expectedStatus: http.StatusNotFound},
+		{name: "no free slot", err: context.DeadlineExceeded, expectedStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockDeviceHealthUseCase(t)
+			useCase.EXPECT().CheckDevice(mock.Anything, "aa:bb:cc:dd:ee:ff").Return(tt.probe, tt.err).Once()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /api/v1/devices/{mac}/health-check", NewDeviceHealthHandler(useCase).CheckHealth)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/devices/aa:bb:cc:dd:ee:ff/health-check", nil))
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.probe == nil {
+				return
+			}
+			var response DeviceHealthCheckResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "AA:BB:CC:DD:EE:FF", response.MACAddress)
+			assert.Equal(t, tt.probe.Alive, response.Alive)
+			assert.Equal(t, tt.probe.Latency.Milliseconds(), response.LatencyMS)
+			assert.Equal(t, tt.probe.Error, response.Error)
+			assert.Equal(t, checkedAt, response.CheckedAt)
+		})
+	}
+}
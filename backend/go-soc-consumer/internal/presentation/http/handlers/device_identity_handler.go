@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	deviceidentity "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_identity"
+)
+
+// ProvisionCertificateRequest is the body of the certificate provisioning endpoint
+type ProvisionCertificateRequest struct {
+	Fingerprint string `json:"fingerprint"` // SHA-256 of the device certificate, empty removes the binding
+}
+
+// ProvisionCertificateResponse is returned after a certificate is provisioned
+type ProvisionCertificateResponse struct {
+	MACAddress  string `json:"mac_address"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// DeviceIdentityHandler serves the device certificate provisioning endpoint
+type DeviceIdentityHandler struct {
+	deviceIdentityUseCase deviceidentity.DeviceIdentityUseCase
+	token                 string
+}
+
+func NewDeviceIdentityHandler(deviceIdentityUseCase deviceidentity.DeviceIdentityUseCase, token string) *DeviceIdentityHandler {
+	return &DeviceIdentityHandler{
+		deviceIdentityUseCase: deviceIdentityUseCase,
+		token:                 token,
+	}
+}
+
+// ProvisionCertificate handles PUT /admin/devices/{mac}/certificate
+func (h *DeviceIdentityHandler) ProvisionCertificate(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request ProvisionCertificateRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	device, err := h.deviceIdentityUseCase.ProvisionCertificate(r.Context(), r.PathValue("mac"), request.Fingerprint)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrDeviceNotFound):
+			writeError(w, r, "device not found", http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrInvalidCertificateFingerprint):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		default:
+			writeError(w, r, "failed to provision certificate", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ProvisionCertificateResponse{
+		MACAddress:  device.GetID(),
+		Fingerprint: device.CertificateFingerprint,
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestDeviceIdentityHandler_ProvisionCertificate(t *testing.T) {
+	fingerprint := strings.Repeat("ab", 32)
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	require.NoError(t, device.SetCertificateFingerprint(fingerprint))
+
+	tests := []struct {
+		name           string
+		token          string
+		body           string
+		device         *entities.Device
+		err            error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "provisioned", token: "secret", body: `{"fingerprint":"` + fingerprint + `"}`, device: device, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "unknown device", token: "secret", body: `{"fingerprint":"` + fingerprint + `"}`, err: domainerrors.ErrDeviceNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "invalid fingerprint", token: "secret", body: `{"fingerprint":"abc"}`, err: domainerrors.ErrInvalidCertificateFingerprint, expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "invalid body", token: "secret", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "unauthorized", token: "guess", body: `{}`, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockDeviceIdentityUseCase(t)
+			if tt.expectCall {
+				useCase.EXPECT().ProvisionCertificate(mock.Anything, "AA:BB:CC:DD:EE:FF", mock.Anything).Return(tt.device, tt.err).Once()
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /admin/devices/{mac}/certificate", NewDeviceIdentityHandler(useCase, "secret").ProvisionCertificate)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/devices/AA:BB:CC:DD:EE:FF/certificate", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Contains(t, w.Body.String(), fingerprint)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicemigration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_migration"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// StartDeviceMigrationRequest is the body of the endpoint starting a device migration
+type StartDeviceMigrationRequest struct {
+	BrokerURL string `json:"broker_url"`
+	Layout    string `json:"layout"`         // shared or farm
+	Farm      string `json:"farm,omitempty"` // migrates the devices of this farm only
+	Actor     string `json:"actor,omitempty"`
+}
+
+// DeviceMigrationResponse is the JSON representation of a device migration
+type DeviceMigrationResponse struct {
+	ID         string     `json:"id"`
+	BrokerURL  string     `json:"broker_url"`
+	Layout     string     `json:"layout"`
+	Farm       string     `json:"farm,omitempty"`
+	Actor      string     `json:"actor"`
+	Status     string     `json:"status"`
+	JobID      string     `json:"job_id,omitempty"`
+	Devices    int        `json:"devices"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// DeviceMigrationsResponse lists device migrations, most recently started first
+type DeviceMigrationsResponse struct {
+	Migrations []DeviceMigrationResponse `json:"migrations"`
+}
+
+// DeviceMigrationTargetResponse is the JSON representation of a device taking part in a migration
+type DeviceMigrationTargetResponse struct {
+	MACAddress    string     `json:"mac_address"`
+	Farm          string     `json:"farm,omitempty"`
+	Status        string     `json:"status"`
+	CommandID     string     `json:"command_id,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	ReconnectedAt *time.Time `json:"reconnected_at,omitempty"`
+}
+
+// DeviceMigrationReportResponse is a device migration with its progress
+type DeviceMigrationReportResponse struct {
+	DeviceMigrationResponse
+	Counts     map[string]int                  `json:"counts"` // devices by status
+	Stragglers []DeviceMigrationTargetResponse `json:"stragglers"`
+	Failed     []DeviceMigrationTargetResponse `json:"failed"`
+}
+
+// DeviceMigrationsHandler moves devices to another broker or topic layout and reports on their progress
+type DeviceMigrationsHandler struct {
+	migrationUseCase devicemigration.DeviceMigrationUseCase
+	pagination       pagination.Policy
+	token            string
+}
+
+func NewDeviceMigrationsHandler(migrationUseCase devicemigration.DeviceMigrationUseCase, policy pagination.Policy, token string) *DeviceMigrationsHandler {
+	return &DeviceMigrationsHandler{
+		migrationUseCase: migrationUseCase,
+		pagination:       policy,
+		token:            token,
+	}
+}
+
+// Start handles POST /admin/migrations, queuing the job publishing the new configuration to the devices
+func (h *DeviceMigrationsHandler) Start(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request StartDeviceMigrationRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	migration, err := h.migrationUseCase.Start(r.Context(), request.BrokerURL, request.Layout, request.Farm, request.Actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrInvalidDeviceMigration):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		case errors.Is(err, domainerrors.ErrDeviceMigrationRunning):
+			writeDomainError(w, r, err, http.StatusConflict)
+		default:
+			writeError(w, r, "failed to start device migration", http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(w, http.StatusAccepted, newDeviceMigrationResponse(migration))
+}
+
+// List handles GET /admin/migrations?limit=N, most recently started first
+func (h *DeviceMigrationsHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit, err := h.pagination.ParseRequestLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		writeError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	migrations, err := h.migrationUseCase.List(r.Context(), limit)
+	if err != nil {
+		writeError(w, r, "failed to list device migrations", http.StatusInternalServerError)
+		return
+	}
+
+	response := DeviceMigrationsResponse{Migrations: make([]DeviceMigrationResponse, 0, len(migrations))}
+	for _, migration := range migrations {
+		response.Migrations = append(response.Migrations, newDeviceMigrationResponse(migration))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Get handles GET /admin/migrations/{id}, reporting the devices by status with the stragglers
+func (h *DeviceMigrationsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	report, err := h.migrationUseCase.Report(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceMigrationNotFound) {
+			writeDomainError(w, r, err, http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to read device migration", http.StatusInternalServerError)
+		return
+	}
+
+	response := DeviceMigrationReportResponse{
+		DeviceMigrationResponse: newDeviceMigrationResponse(report.Migration),
+		Counts:                  report.Counts,
+		Stragglers:              newDeviceMigrationTargetResponses(report.Stragglers),
+		Failed:                  newDeviceMigrationTargetResponses(report.Failed),
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Cancel handles POST /admin/migrations/{id}/cancel
+func (h *DeviceMigrationsHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	migration, err := h.migrationUseCase.Cancel(r.Context(), r.PathValue("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrDeviceMigrationNotFound):
+			writeDomainError(w, r, err, http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrDeviceMigrationFinished):
+			writeDomainError(w, r, err, http.StatusConflict)
+		default:
+			writeError(w, r, "failed to cancel device migration", http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, newDeviceMigrationResponse(migration))
+}
+
+func newDeviceMigrationResponse(migration *entities.DeviceMigration) DeviceMigrationResponse {
+	return DeviceMigrationResponse{
+		ID:         migration.ID,
+		BrokerURL:  migration.BrokerURL,
+		Layout:     migration.Layout,
+		Farm:       migration.FarmID,
+		Actor:      migration.Actor,
+		Status:     migration.Status,
+		JobID:      migration.JobID,
+		Devices:    migration.Devices,
+		StartedAt:  migration.StartedAt,
+		FinishedAt: migration.FinishedAt,
+	}
+}
+
+func newDeviceMigrationTargetResponses(targets []*entities.DeviceMigrationTarget) []DeviceMigrationTargetResponse {
+	responses := make([]DeviceMigrationTargetResponse, 0, len(targets))
+	for _, target := range targets {
+		responses = append(responses, DeviceMigrationTargetResponse{
+			MACAddress:    target.MACAddress,
+			Farm:          target.FarmID,
+			Status:        target.Status,
+			CommandID:     target.CommandID,
+			Error:         target.Error,
+			SentAt:        target.SentAt,
+			ReconnectedAt: target.ReconnectedAt,
+		})
+	}
+	return responses
+}
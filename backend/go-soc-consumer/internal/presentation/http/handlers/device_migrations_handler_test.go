@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func TestDeviceMigrationsHandler_Start(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/admin/migrations", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		return req
+	}
+
+	t.Run("should queue the migration", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceMigrationUseCase(t)
+		useCase.EXPECT().Start(mock.Anything, "tcp://broker.example.com:1883", "farm", "", "maria").Return(&entities.DeviceMigration{
+			ID: "migration-1", BrokerURL: "tcp://broker.example.com:1883", Layout: "farm", Actor: "maria",
+			Status: "running", JobID: "job-1", Devices: 42, StartedAt: now,
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewDeviceMigrationsHandler(useCase, pagination.DefaultPolicy(), "secret").Start(rec, newRequest(`{"broker_url":"tcp://broker.example.com:1883","layout":"farm","actor":"maria"}`))
+
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		assert.JSONEq(t, `{"id":"migration-1","broker_url":"tcp://broker.example.com:1883","layout":"farm","actor":"maria",
+			"status":"running","job_id":"job-1","devices":42,"started_at":"2025-06-01T12:00:00Z"}`, rec.Body.String())
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: domainerrors.ErrInvalidDeviceMigration, expected: http.StatusBadRequest},
+			{err: domainerrors.ErrDeviceMigrationRunning, expected: http.StatusConflict},
+			{err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockDeviceMigrationUseCase(t)
+			useCase.EXPECT().Start(mock.Anything, "", "", "", "").Return(nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			NewDeviceMigrationsHandler(useCase, pagination.DefaultPolicy(), "secret").Start(rec, newRequest(`{}`))
+			assert.Equal(t, tt.expected, rec.Code)
+		}
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		req := newRequest(`{}`)
+		req.Header.Del("Authorization")
+
+		rec := httptest.NewRecorder()
+		NewDeviceMigrationsHandler(mocks.NewMockDeviceMigrationUseCase(t), pagination.DefaultPolicy(), "secret").Start(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestDeviceMigrationsHandler_Get(t *testing.T) {
+	startedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	sentAt := startedAt.Add(time.Minute)
+
+	t.Run("should report the stragglers", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceMigrationUseCase(t)
+		useCase.EXPECT().Report(mock.Anything, "migration-1").Return(&entities.DeviceMigrationReport{
+			Migration: &entities.DeviceMigration{ID: "migration-1", BrokerURL: "tcp://broker.example.com:1883", Layout: "shared", Actor: "maria", Status: "running", Devices: 2, StartedAt: startedAt},
+			Counts:    map[string]int{"sent": 1, "reconnected": 1},
+			Stragglers: []*entities.DeviceMigrationTarget{
+				{MACAddress: "AA:BB:CC:DD:EE:01", FarmID: "finca-norte", Status: "sent", CommandID: "cmd-1", SentAt: &sentAt},
+			},
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/migrations/migration-1", nil)
+		req.SetPathValue("id", "migration-1")
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		NewDeviceMigrationsHandler(useCase, pagination.DefaultPolicy(), "secret").Get(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"id":"migration-1","broker_url":"tcp://broker.example.com:1883","layout":"shared","actor":"maria",
+			"status":"running","devices":2,"started_at":"2025-06-01T12:00:00Z","counts":{"sent":1,"reconnected":1},
+			"stragglers":[{"mac_address":"AA:BB:CC:DD:EE:01","farm":"finca-norte","status":"sent","command_id":"cmd-1","sent_at":"2025-06-01T12:01:00Z"}],
+			"failed":[]}`, rec.Body.String())
+	})
+
+	t.Run("should report unknown migrations", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceMigrationUseCase(t)
+		useCase.EXPECT().Report(mock.Anything, "missing").Return(nil, domainerrors.ErrDeviceMigrationNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/migrations/missing", nil)
+		req.SetPathValue("id", "missing")
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		NewDeviceMigrationsHandler(useCase, pagination.DefaultPolicy(), "secret").Get(rec, req)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestDeviceMigrationsHandler_Cancel(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: nil, expected: http.StatusOK},
+		{err: domainerrors.ErrDeviceMigrationNotFound, expected: http.StatusNotFound},
+		{err: domainerrors.ErrDeviceMigrationFinished, expected: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		useCase := mocks.NewMockDeviceMigrationUseCase(t)
+		var migration *entities.DeviceMigration
+		if tt.err == nil {
+			migration = &entities.DeviceMigration{ID: "migration-1", Status: entities.DeviceMigrationCancelled}
+		}
+		useCase.EXPECT().Cancel(mock.Anything, "migration-1").Return(migration, tt.err).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/migrations/migration-1/cancel", nil)
+		req.SetPathValue("id", "migration-1")
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		NewDeviceMigrationsHandler(useCase, pagination.DefaultPolicy(), "secret").Cancel(rec, req)
+		assert.Equal(t, tt.expected, rec.Code)
+	}
+}
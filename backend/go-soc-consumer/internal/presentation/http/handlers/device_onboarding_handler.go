@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	deviceonboarding "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_onboarding"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/qrcode"
+)
+
+// QR code PNG scales accepted, in pixels per module
+const (
+	defaultQRCodeScale = 8
+	maxQRCodeScale     = 32
+)
+
+// OnboardingRequest is the body of the onboarding creation endpoint
+type OnboardingRequest struct {
+	MACAddress          string `json:"mac_address"`
+	DeviceName          string `json:"device_name"`
+	LocationDescription string `json:"location_description,omitempty"`
+	FarmID              string `json:"farm_id,omitempty"`
+}
+
+// OnboardingAssignRequest is the body of the onboarding assignment endpoint
+type OnboardingAssignRequest struct {
+	LocationDescription string `json:"location_description,omitempty"` // defaults to the one given at creation
+}
+
+// OnboardingStepResponse is a step of an onboarding
+type OnboardingStepResponse struct {
+	Name        string     `json:"name"`
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// OnboardingResponse is the JSON representation of the progress of an onboarding
+type OnboardingResponse struct {
+	ID                  string                   `json:"id"`
+	MACAddress          string                   `json:"mac_address"`
+	DeviceName          string                   `json:"device_name"`
+	LocationDescription string                   `json:"location_description,omitempty"`
+	FarmID              string                   `json:"farm_id,omitempty"`
+	Steps               []OnboardingStepResponse `json:"steps"`
+	CurrentStep         string                   `json:"current_step,omitempty"` // empty once completed
+	Completed           bool                     `json:"completed"`
+	TokenExpiresAt      time.Time                `json:"token_expires_at"`
+	CreatedAt           time.Time                `json:"created_at"`
+}
+
+// ProvisioningResponse is an onboarding with the provisioning payload to hand to the device. The
+// token is only returned here; the server keeps its hash.
+type ProvisioningResponse struct {
+	Onboarding        OnboardingResponse            `json:"onboarding"`
+	ProvisioningToken string                        `json:"provisioning_token"`
+	Provisioning      *entities.ProvisioningPayload `json:"provisioning"`
+	QRPayload         string                        `json:"qr_payload"` // the provisioning payload as compact JSON
+}
+
+// OnboardingsResponse lists the pending onboardings, oldest first
+type OnboardingsResponse struct {
+	Onboardings []OnboardingResponse `json:"onboardings"`
+}
+
+// DeviceOnboardingHandler serves the onboarding of new devices by installers
+type DeviceOnboardingHandler struct {
+	onboardingUseCase deviceonboarding.DeviceOnboardingUseCase
+	token             string
+}
+
+func NewDeviceOnboardingHandler(onboardingUseCase deviceonboarding.DeviceOnboardingUseCase, token string) *DeviceOnboardingHandler {
+	return &DeviceOnboardingHandler{
+		onboardingUseCase: onboardingUseCase,
+		token:             token,
+	}
+}
+
+// Create handles POST /admin/onboarding
+func (h *DeviceOnboardingHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request OnboardingRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	onboarding, payload, err := h.onboardingUseCase.Create(r.Context(), entities.OnboardingRequest{
+		MACAddress:          request.MACAddress,
+		DeviceName:          request.DeviceName,
+		LocationDescription: request.LocationDescription,
+		FarmID:              request.FarmID,
+	})
+	if err != nil {
+		h.writeOnboardingError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newProvisioningResponse(onboarding, payload))
+}
+
+// List handles GET /admin/onboarding
+func (h *DeviceOnboardingHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	onboardings, err := h.onboardingUseCase.List(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to list onboardings", http.StatusInternalServerError)
+		return
+	}
+	response := OnboardingsResponse{Onboardings: make([]OnboardingResponse, 0, len(onboardings))}
+	for _, onboarding := range onboardings {
+		response.Onboardings = append(response.Onboardings, newOnboardingResponse(onboarding))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Progress handles GET /api/v1/onboarding/{id}, polled by the installer's app. The unguessable
+// onboarding id is enough to read the progress, which holds no credentials.
+func (h *DeviceOnboardingHandler) Progress(w http.ResponseWriter, r *http.Request) {
+	onboarding, err := h.onboardingUseCase.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeOnboardingError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newOnboardingResponse(onboarding))
+}
+
+// MarkCredentialsDelivered handles POST /admin/onboarding/{id}/credentials-delivered
+func (h *DeviceOnboardingHandler) MarkCredentialsDelivered(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	onboarding, err := h.onboardingUseCase.MarkCredentialsDelivered(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeOnboardingError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newOnboardingResponse(onboarding))
+}
+
+// RenewToken handles POST /admin/onboarding/{id}/token
+func (h *DeviceOnboardingHandler) RenewToken(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	onboarding, payload, err := h.onboardingUseCase.RenewToken(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeOnboardingError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newProvisioningResponse(onboarding, payload))
+}
+
+// QRCode handles POST /admin/onboarding/{id}/qr?format=png|svg&scale=8, rendering the provisioning
+// payload as a QR code for the device's configuration portal. The server only keeps the hash of the
+// token, so every render issues a new token, invalidating the previous one.
+func (h *DeviceOnboardingHandler) QRCode(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "png"
+	}
+	if format != "png" && format != "svg" {
+		writeError(w, r, "format must be png or svg", http.StatusBadRequest)
+		return
+	}
+	scale := defaultQRCodeScale
+	if value := r.URL.Query().Get("scale"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxQRCodeScale {
+			writeError(w, r, "invalid QR code scale", http.StatusBadRequest)
+			return
+		}
+		scale = parsed
+	}
+
+	_, payload, err := h.onboardingUseCase.RenewToken(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeOnboardingError(w, r, err)
+		return
+	}
+	code, err := qrcode.Encode([]byte(payload.Encode()))
+	if err != nil {
+		writeError(w, r, "failed to render QR code", http.StatusInternalServerError)
+		return
+	}
+
+	var body []byte
+	if format == "svg" {
+		body = []byte(code.SVG())
+		w.Header().Set("Content-Type", "image/svg+xml")
+	} else {
+		body, err = code.PNG(scale)
+		if err != nil {
+			writeError(w, r, "failed to render QR code", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+	}
+	// The code holds a credential
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// Assign handles POST /admin/onboarding/{id}/assign
+func (h *DeviceOnboardingHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request OnboardingAssignRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	onboarding, err := h.onboardingUseCase.Assign(r.Context(), r.PathValue("id"), request.LocationDescription)
+	if err != nil {
+		h.writeOnboardingError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newOnboardingResponse(onboarding))
+}
+
+// Delete handles DELETE /admin/onboarding/{id}
+func (h *DeviceOnboardingHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.onboardingUseCase.Delete(r.Context(), r.PathValue("id")); err != nil {
+		h.writeOnboardingError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeOnboardingError maps the failures of the onboarding endpoints to their status
+func (h *DeviceOnboardingHandler) writeOnboardingError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domainerrors.ErrOnboardingNotFound):
+		writeError(w, r, "onboarding not found", http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrInvalidOnboarding):
+		writeDomainError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, domainerrors.ErrOnboardingExists), errors.Is(err, domainerrors.ErrOnboardingStepNotReady):
+		writeDomainError(w, r, err, http.StatusConflict)
+	case errors.Is(err, domainerrors.ErrDeviceNotFound):
+		writeError(w, r, "device not found", http.StatusNotFound)
+	default:
+		writeError(w, r, "failed to process onboarding", http.StatusInternalServerError)
+	}
+}
+
+func newOnboardingResponse(onboarding *entities.DeviceOnboarding) OnboardingResponse {
+	steps := onboarding.Steps()
+	response := OnboardingResponse{
+		ID:                  onboarding.ID,
+		MACAddress:          onboarding.MACAddress,
+		DeviceName:          onboarding.DeviceName,
+		LocationDescription: onboarding.LocationDescription,
+		FarmID:              onboarding.FarmID,
+		Steps:               make([]OnboardingStepResponse, 0, len(steps)),
+		CurrentStep:         onboarding.CurrentStep(),
+		Completed:           onboarding.Completed(),
+		TokenExpiresAt:      onboarding.TokenExpiresAt,
+		CreatedAt:           onboarding.CreatedAt,
+	}
+	for _, step := range steps {
+		response.Steps = append(response.Steps, OnboardingStepResponse{Name: step.Name, Completed: step.CompletedAt != nil, CompletedAt: step.CompletedAt})
+	}
+	return response
+}
+
+func newProvisioningResponse(onboarding *entities.DeviceOnboarding, payload *entities.ProvisioningPayload) ProvisioningResponse {
+	return ProvisioningResponse{
+		Onboarding:        newOnboardingResponse(onboarding),
+		ProvisioningToken: payload.Token,
+		Provisioning:      payload,
+		QRPayload:         payload.Encode(),
+	}
+}
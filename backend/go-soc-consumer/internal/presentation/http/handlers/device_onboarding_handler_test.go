@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newOnboardingRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.SetPathValue("id", "onboarding-1")
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func testOnboarding() *entities.DeviceOnboarding {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	return &entities.DeviceOnboarding{
+		ID: "onboarding-1", MACAddress: "AA:BB:CC:DD:EE:FF", DeviceName: "Sensor", FarmID: "farm-1",
+		TokenExpiresAt: createdAt.Add(24 * time.Hour), CreatedAt: createdAt, CredentialsDeliveredAt: &createdAt,
+	}
+}
+
+func TestDeviceOnboardingHandler_Create(t *testing.T) {
+	request := entities.OnboardingRequest{MACAddress: "AA:BB:CC:DD:EE:FF", DeviceName: "Sensor", FarmID: "farm-1"}
+	body := `{"mac_address":"AA:BB:CC:DD:EE:FF","device_name":"Sensor","farm_id":"farm-1"}`
+
+	t.Run("should return the provisioning payload", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceOnboardingUseCase(t)
+		handler := NewDeviceOnboardingHandler(useCase, "secret")
+		onboarding := testOnboarding()
+		payload := onboarding.ProvisioningPayload("tcp://broker:1883", "0123abcd")
+		useCase.EXPECT().Create(mock.Anything, request).Return(onboarding, payload, nil).Once()
+
+		rec := httptest.NewRecorder()
+		handler.Create(rec, newOnboardingRequest(http.MethodPost, "/admin/onboarding", body))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"provisioning_token":"0123abcd"`)
+		assert.Contains(t, rec.Body.String(), `"current_step":"first_connection"`)
+		assert.Contains(t, rec.Body.String(), `"prefix":"farms/farm-1/devices"`)
+		assert.Contains(t, rec.Body.String(), `"qr_payload":"{\"v\":1,\"id\":\"onboarding-1\"`)
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: fmt.Errorf("%w: device name is required", domainerrors.ErrInvalidOnboarding), expected: http.StatusBadRequest},
+			{err: fmt.Errorf("%w: AA:BB:CC:DD:EE:FF is registered", domainerrors.ErrOnboardingExists), expected: http.StatusConflict},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockDeviceOnboardingUseCase(t)
+			handler := NewDeviceOnboardingHandler(useCase, "secret")
+			useCase.EXPECT().Create(mock.Anything, request).Return(nil, nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			handler.Create(rec, newOnboardingRequest(http.MethodPost, "/admin/onboarding", body))
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+
+	t.Run("should reject unauthorized requests", func(t *testing.T) {
+		handler := NewDeviceOnboardingHandler(mocks.NewMockDeviceOnboardingUseCase(t), "secret")
+		req := newOnboardingRequest(http.MethodPost, "/admin/onboarding", body)
+		req.Header.Del("Authorization")
+
+		rec := httptest.NewRecorder()
+		handler.Create(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestDeviceOnboardingHandler_Progress(t *testing.T) {
+	t.Run("should return the steps without credentials", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceOnboardingUseCase(t)
+		handler := NewDeviceOnboardingHandler(useCase, "secret")
+		useCase.EXPECT().Get(mock.Anything, "onboarding-1").Return(testOnboarding(), nil).Once()
+
+		req := newOnboardingRequest(http.MethodGet, "/api/v1/onboarding/onboarding-1", "")
+		req.Header.Del("Authorization")
+		rec := httptest.NewRecorder()
+		handler.Progress(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `{"name":"credentials_delivered","completed":true,"completed_at":"2025-06-01T12:00:00Z"}`)
+		assert.Contains(t, rec.Body.String(), `{"name":"assigned","completed":false}`)
+		assert.Contains(t, rec.Body.String(), `"completed":false,"token_expires_at"`)
+		assert.NotContains(t, rec.Body.String(), "token\":")
+	})
+
+	t.Run("should return 404 for an unknown onboarding", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceOnboardingUseCase(t)
+		handler := NewDeviceOnboardingHandler(useCase, "secret")
+		useCase.EXPECT().Get(mock.Anything, "onboarding-1").Return(nil, domainerrors.ErrOnboardingNotFound).Once()
+
+		rec := httptest.NewRecorder()
+		handler.Progress(rec, newOnboardingRequest(http.MethodGet, "/api/v1/onboarding/onboarding-1", ""))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestDeviceOnboardingHandler_Assign(t *testing.T) {
+	useCase := mocks.NewMockDeviceOnboardingUseCase(t)
+	handler := NewDeviceOnboardingHandler(useCase, "secret")
+	useCase.EXPECT().Assign(mock.Anything, "onboarding-1", "Row 4").
+		Return(nil, fmt.Errorf("%w: the device has not sent readings yet", domainerrors.ErrOnboardingStepNotReady)).Once()
+	useCase.EXPECT().Delete(mock.Anything, "onboarding-1").Return(nil).Once()
+
+	rec := httptest.NewRecorder()
+	handler.Assign(rec, newOnboardingRequest(http.MethodPost, "/admin/onboarding/onboarding-1/assign", `{"location_description":"Row 4"}`))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.Delete(rec, newOnboardingRequest(http.MethodDelete, "/admin/onboarding/onboarding-1", ""))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestDeviceOnboardingHandler_QRCode(t *testing.T) {
+	onboarding := testOnboarding()
+	payload := onboarding.ProvisioningPayload("tcp://broker:1883", "0123abcd")
+
+	t.Run("should render the payload with a new token", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceOnboardingUseCase(t)
+		handler := NewDeviceOnboardingHandler(useCase, "secret")
+		useCase.EXPECT().RenewToken(mock.Anything, "onboarding-1").Return(onboarding, payload, nil).Twice()
+
+		rec := httptest.NewRecorder()
+		handler.QRCode(rec, newOnboardingRequest(http.MethodPost, "/admin/onboarding/onboarding-1/qr?scale=2", ""))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		img, err := png.Decode(bytes.NewReader(rec.Body.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, 0, img.Bounds().Dx()%2)
+
+		rec = httptest.NewRecorder()
+		handler.QRCode(rec, newOnboardingRequest(http.MethodPost, "/admin/onboarding/onboarding-1/qr?format=svg", ""))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "image/svg+xml", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "<svg")
+	})
+
+	t.Run("should reject invalid options before issuing a token", func(t *testing.T) {
+		handler := NewDeviceOnboardingHandler(mocks.NewMockDeviceOnboardingUseCase(t), "secret")
+		for _, query := range []string{"format=gif", "scale=0", "scale=64"} {
+			rec := httptest.NewRecorder()
+			handler.QRCode(rec, newOnboardingRequest(http.MethodPost, "/admin/onboarding/onboarding-1/qr?"+query, ""))
+			assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		}
+	})
+
+	t.Run("should refuse once the device connected", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceOnboardingUseCase(t)
+		handler := NewDeviceOnboardingHandler(useCase, "secret")
+		useCase.EXPECT().RenewToken(mock.Anything, "onboarding-1").
+			Return(nil, nil, fmt.Errorf("%w: the device already connected", domainerrors.ErrOnboardingStepNotReady)).Once()
+
+		rec := httptest.NewRecorder()
+		handler.QRCode(rec, newOnboardingRequest(http.MethodPost, "/admin/onboarding/onboarding-1/qr", ""))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicestatus "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_status"
+)
+
+// DeviceStatusQueryRequest is the body of the bulk device status endpoint
+type DeviceStatusQueryRequest struct {
+	MACAddresses []string `json:"mac_addresses"`
+}
+
+// DeviceStatusResponse is the current status of one queried device
+type DeviceStatusResponse struct {
+	MACAddress string     `json:"mac_address"`
+	Found      bool       `json:"found"`
+	Status     string     `json:"status,omitempty"`
+	LastSeen   *time.Time `json:"last_seen,omitempty"`
+}
+
+// DeviceStatusQueryResponse lists the queried devices in request order
+type DeviceStatusQueryResponse struct {
+	Devices []DeviceStatusResponse `json:"devices"`
+}
+
+// DeviceStatusHandler serves bulk device status queries
+type DeviceStatusHandler struct {
+	deviceStatusUseCase devicestatus.DeviceStatusUseCase
+}
+
+func NewDeviceStatusHandler(deviceStatusUseCase devicestatus.DeviceStatusUseCase) *DeviceStatusHandler {
+	return &DeviceStatusHandler{deviceStatusUseCase: deviceStatusUseCase}
+}
+
+// QueryStatus handles POST /api/v1/devices/status-query
+func (h *DeviceStatusHandler) QueryStatus(w http.ResponseWriter, r *http.Request) {
+	var request DeviceStatusQueryRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	devices, err := h.deviceStatusUseCase.QueryStatus(r.Context(), request.MACAddresses)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidStatusQuery) {
+			writeDomainError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		writeError(w, r, "failed to query device status", http.StatusInternalServerError)
+		return
+	}
+
+	response := DeviceStatusQueryResponse{Devices: make([]DeviceStatusResponse, 0, len(request.MACAddresses))}
+	for _, macAddress := range request.MACAddresses {
+		macAddress = strings.ToUpper(strings.TrimSpace(macAddress))
+		status := DeviceStatusResponse{MACAddress: macAddress}
+		if device, ok := devices[macAddress]; ok {
+			lastSeen := device.LastSeen
+			status.Found = true
+			status.Status = device.Status
+			status.LastSeen = &lastSeen
+		}
+		response.Devices = append(response.Devices, status)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
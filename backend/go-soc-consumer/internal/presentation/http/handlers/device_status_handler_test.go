@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestDeviceStatusHandler_QueryStatus(t *testing.T) {
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+
+	t.Run("should report every requested device in order", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceStatusUseCase(t)
+		useCase.EXPECT().QueryStatus(mock.Anything, []string{"11:22:33:44:55:66", "aa:bb:cc:dd:ee:ff"}).
+			Return(map[string]*entities.Device{"AA:BB:CC:DD:EE:FF": device}, nil).Once()
+
+		body := `{"mac_addresses":["11:22:33:44:55:66","aa:bb:cc:dd:ee:ff"]}`
+		w := httptest.NewRecorder()
+		NewDeviceStatusHandler(useCase).QueryStatus(w, httptest.NewRequest(http.MethodPost, "/api/v1/devices/status-query", strings.NewReader(body)))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response DeviceStatusQueryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Devices, 2)
+		assert.Equal(t, DeviceStatusResponse{MACAddress: "11:22:33:44:55:66"}, response.Devices[0])
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", response.Devices[1].MACAddress)
+		assert.True(t, response.Devices[1].Found)
+		assert.Equal(t, "registered", response.Devices[1].Status)
+		require.NotNil(t, response.Devices[1].LastSeen)
+		assert.True(t, device.LastSeen.Equal(*response.Devices[1].LastSeen))
+	})
+
+	tests := []struct {
+		name         string
+		body         string
+		useCaseError error
+		expectedCode int
+	}{
+		{name: "invalid body", body: `{`, expectedCode: http.StatusBadRequest},
+		{name: "invalid query", body: `{"mac_addresses":[]}`, useCaseError: fmt.Errorf("%w: empty", domainerrors.ErrInvalidStatusQuery), expectedCode: http.StatusBadRequest},
+		{name: "repository failure", body: `{"mac_addresses":["AA:BB:CC:DD:EE:FF"]}`, useCaseError: errors.New("database down"), expectedCode: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockDeviceStatusUseCase(t)
+			if tt.useCaseError != nil {
+				useCase.EXPECT().QueryStatus(mock.Anything, mock.Anything).Return(nil, tt.useCaseError).Once()
+			}
+
+			w := httptest.NewRecorder()
+			NewDeviceStatusHandler(useCase).QueryStatus(w, httptest.NewRequest(http.MethodPost, "/api/v1/devices/status-query", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicetransfers "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_transfers"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// InitiateDeviceTransferRequest is the body of the transfer initiation endpoint
+type InitiateDeviceTransferRequest struct {
+	ToFarm        string `json:"to_farm"`
+	ToZone        string `json:"to_zone,omitempty"`
+	HistoryPolicy string `json:"history_policy,omitempty"` // keep or archive, defaults to keep
+	Note          string `json:"note,omitempty"`
+	Actor         string `json:"actor,omitempty"`
+}
+
+// DeviceTransferActionRequest is the optional body of the accept, complete and cancel endpoints
+type DeviceTransferActionRequest struct {
+	Actor  string `json:"actor,omitempty"`
+	Reason string `json:"reason,omitempty"` // cancel
+}
+
+// DeviceTransferResponse is the JSON representation of a device transfer
+type DeviceTransferResponse struct {
+	ID               string     `json:"id"`
+	MACAddress       string     `json:"mac_address"`
+	FromFarm         string     `json:"from_farm,omitempty"`
+	ToFarm           string     `json:"to_farm"`
+	ToZone           string     `json:"to_zone,omitempty"`
+	HistoryPolicy    string     `json:"history_policy"`
+	Status           string     `json:"status"`
+	Note             string     `json:"note,omitempty"`
+	ArchivedReadings int        `json:"archived_readings"`
+	CreatedAt        time.Time  `json:"created_at"`
+	AcceptedAt       *time.Time `json:"accepted_at,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	CancelledAt      *time.Time `json:"cancelled_at,omitempty"`
+}
+
+// DeviceTransferDetailResponse is a device transfer with its audit trail, oldest entry first
+type DeviceTransferDetailResponse struct {
+	DeviceTransferResponse
+	Audit []DeviceTransferAuditResponse `json:"audit"`
+}
+
+// DeviceTransferAuditResponse is an entry of the audit trail of a device transfer
+type DeviceTransferAuditResponse struct {
+	Action string    `json:"action"`
+	Actor  string    `json:"actor"`
+	Note   string    `json:"note,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// DeviceTransfersResponse lists device transfers, most recently initiated first
+type DeviceTransfersResponse struct {
+	Transfers []DeviceTransferResponse `json:"transfers"`
+}
+
+// DeviceTransfersHandler moves devices between farms through the transfer workflow
+type DeviceTransfersHandler struct {
+	transfersUseCase devicetransfers.DeviceTransfersUseCase
+	pagination       pagination.Policy
+	token            string
+}
+
+func NewDeviceTransfersHandler(transfersUseCase devicetransfers.DeviceTransfersUseCase, policy pagination.Policy, token string) *DeviceTransfersHandler {
+	return &DeviceTransfersHandler{
+		transfersUseCase: transfersUseCase,
+		pagination:       policy,
+		token:            token,
+	}
+}
+
+// Initiate handles POST /admin/devices/{mac}/transfers
+func (h *DeviceTransfersHandler) Initiate(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request InitiateDeviceTransferRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := h.transfersUseCase.Initiate(r.Context(), r.PathValue("mac"), entities.DeviceTransferRequest{
+		ToFarmID:      request.ToFarm,
+		ToZoneID:      request.ToZone,
+		HistoryPolicy: request.HistoryPolicy,
+		Note:          request.Note,
+	}, request.Actor)
+	if err != nil {
+		writeDeviceTransferError(w, r, err, "failed to initiate device transfer")
+		return
+	}
+	writeJSON(w, http.StatusCreated, newDeviceTransferResponse(transfer))
+}
+
+// List handles GET /admin/transfers?mac=&status=&limit=N, most recently initiated first
+func (h *DeviceTransfersHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	limit, err := h.pagination.ParseRequestLimit(query.Get("limit"))
+	if err != nil {
+		writeError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	transfers, err := h.transfersUseCase.List(r.Context(), query.Get("mac"), query.Get("status"), limit)
+	if err != nil {
+		writeError(w, r, "failed to list device transfers", http.StatusInternalServerError)
+		return
+	}
+
+	response := DeviceTransfersResponse{Transfers: make([]DeviceTransferResponse, 0, len(transfers))}
+	for _, transfer := range transfers {
+		response.Transfers = append(response.Transfers, newDeviceTransferResponse(transfer))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Get handles GET /admin/transfers/{id}, returning the transfer with its audit trail
+func (h *DeviceTransfersHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	transfer, entries, err := h.transfersUseCase.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeDeviceTransferError(w, r, err, "failed to read device transfer")
+		return
+	}
+
+	response := DeviceTransferDetailResponse{
+		DeviceTransferResponse: newDeviceTransferResponse(transfer),
+		Audit:                  make([]DeviceTransferAuditResponse, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		response.Audit = append(response.Audit, DeviceTransferAuditResponse{Action: entry.Action, Actor: entry.Actor, Note: entry.Note, At: entry.At})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Accept handles POST /admin/transfers/{id}/accept
+func (h *DeviceTransfersHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	request, ok := h.actionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	transfer, err := h.transfersUseCase.Accept(r.Context(), r.PathValue("id"), request.Actor)
+	if err != nil {
+		writeDeviceTransferError(w, r, err, "failed to accept device transfer")
+		return
+	}
+	writeJSON(w, http.StatusOK, newDeviceTransferResponse(transfer))
+}
+
+// Complete handles POST /admin/transfers/{id}/complete, once the device is installed at the target farm
+func (h *DeviceTransfersHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	request, ok := h.actionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	transfer, err := h.transfersUseCase.Complete(r.Context(), r.PathValue("id"), request.Actor)
+	if err != nil {
+		writeDeviceTransferError(w, r, err, "failed to complete device transfer")
+		return
+	}
+	writeJSON(w, http.StatusOK, newDeviceTransferResponse(transfer))
+}
+
+// Cancel handles POST /admin/transfers/{id}/cancel
+func (h *DeviceTransfersHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	request, ok := h.actionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	transfer, err := h.transfersUseCase.Cancel(r.Context(), r.PathValue("id"), request.Actor, request.Reason)
+	if err != nil {
+		writeDeviceTransferError(w, r, err, "failed to cancel device transfer")
+		return
+	}
+	writeJSON(w, http.StatusOK, newDeviceTransferResponse(transfer))
+}
+
+// actionRequest authorizes the request and decodes its optional body
+func (h *DeviceTransfersHandler) actionRequest(w http.ResponseWriter, r *http.Request) (DeviceTransferActionRequest, bool) {
+	var request DeviceTransferActionRequest
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return request, false
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return request, false
+	}
+	return request, true
+}
+
+// writeDeviceTransferError maps the failures of the transfer workflow to their status
+func writeDeviceTransferError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrDeviceNotFound):
+		writeError(w, r, "device not found", http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrFarmNotFound), errors.Is(err, domainerrors.ErrDeviceTransferNotFound):
+		writeDomainError(w, r, err, http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrInvalidDeviceTransfer):
+		writeDomainError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, domainerrors.ErrDeviceTransferInProgress), errors.Is(err, domainerrors.ErrDeviceTransferStatusChange):
+		writeDomainError(w, r, err, http.StatusConflict)
+	default:
+		writeError(w, r, message, http.StatusInternalServerError)
+	}
+}
+
+func newDeviceTransferResponse(transfer *entities.DeviceTransfer) DeviceTransferResponse {
+	return DeviceTransferResponse{
+		ID:               transfer.ID,
+		MACAddress:       transfer.MACAddress,
+		FromFarm:         transfer.FromFarmID,
+		ToFarm:           transfer.ToFarmID,
+		ToZone:           transfer.ToZoneID,
+		HistoryPolicy:    transfer.HistoryPolicy,
+		Status:           transfer.Status,
+		Note:             transfer.Note,
+		ArchivedReadings: transfer.ArchivedReadings,
+		CreatedAt:        transfer.CreatedAt,
+		AcceptedAt:       transfer.AcceptedAt,
+		CompletedAt:      transfer.CompletedAt,
+		CancelledAt:      transfer.CancelledAt,
+	}
+}
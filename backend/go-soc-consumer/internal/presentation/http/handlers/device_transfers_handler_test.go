@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func TestDeviceTransfersHandler_Initiate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	body := `{"to_farm":"finca-sur","to_zone":"zone-1","history_policy":"archive","actor":"maria"}`
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/admin/devices/AA:BB:CC:DD:EE:FF/transfers", strings.NewReader(body))
+		req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+		req.Header.Set("Authorization", "Bearer secret")
+		return req
+	}
+
+	t.Run("should create the pending transfer", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceTransfersUseCase(t)
+		useCase.EXPECT().Initiate(mock.Anything, "AA:BB:CC:DD:EE:FF", entities.DeviceTransferRequest{ToFarmID: "finca-sur", ToZoneID: "zone-1", HistoryPolicy: "archive"}, "maria").
+			Return(&entities.DeviceTransfer{
+				ID: "transfer-1", MACAddress: "AA:BB:CC:DD:EE:FF", FromFarmID: "finca-norte", ToFarmID: "finca-sur", ToZoneID: "zone-1",
+				HistoryPolicy: "archive", Status: "pending", CreatedAt: now,
+			}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewDeviceTransfersHandler(useCase, pagination.DefaultPolicy(), "secret").Initiate(rec, newRequest(body))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"id":"transfer-1","mac_address":"AA:BB:CC:DD:EE:FF","from_farm":"finca-norte","to_farm":"finca-sur","to_zone":"zone-1",
+			"history_policy":"archive","status":"pending","archived_readings":0,"created_at":"2025-06-01T12:00:00Z"}`, rec.Body.String())
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: domainerrors.ErrDeviceNotFound, expected: http.StatusNotFound},
+			{err: domainerrors.ErrFarmNotFound, expected: http.StatusNotFound},
+			{err: domainerrors.ErrInvalidDeviceTransfer, expected: http.StatusBadRequest},
+			{err: domainerrors.ErrDeviceTransferInProgress, expected: http.StatusConflict},
+			{err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockDeviceTransfersUseCase(t)
+			useCase.EXPECT().Initiate(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			NewDeviceTransfersHandler(useCase, pagination.DefaultPolicy(), "secret").Initiate(rec, newRequest(body))
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		req := newRequest(body)
+		req.Header.Set("Authorization", "Bearer wrong")
+
+		rec := httptest.NewRecorder()
+		NewDeviceTransfersHandler(mocks.NewMockDeviceTransfersUseCase(t), pagination.DefaultPolicy(), "secret").Initiate(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestDeviceTransfersHandler_Complete(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/admin/transfers/transfer-1/complete", strings.NewReader(body))
+		req.SetPathValue("id", "transfer-1")
+		req.Header.Set("Authorization", "Bearer secret")
+		return req
+	}
+
+	t.Run("should complete the transfer without a body", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceTransfersUseCase(t)
+		useCase.EXPECT().Complete(mock.Anything, "transfer-1", "").Return(&entities.DeviceTransfer{
+			ID: "transfer-1", MACAddress: "AA:BB:CC:DD:EE:FF", ToFarmID: "finca-sur", HistoryPolicy: "archive",
+			Status: "completed", ArchivedReadings: 1440, CreatedAt: now, CompletedAt: &now,
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewDeviceTransfersHandler(useCase, pagination.DefaultPolicy(), "secret").Complete(rec, newRequest(""))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"archived_readings":1440`)
+		assert.Contains(t, rec.Body.String(), `"completed_at":"2025-06-01T12:00:00Z"`)
+	})
+
+	t.Run("should reject transfers that are not accepted", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceTransfersUseCase(t)
+		useCase.EXPECT().Complete(mock.Anything, "transfer-1", "pedro").Return(nil, domainerrors.ErrDeviceTransferStatusChange).Once()
+
+		rec := httptest.NewRecorder()
+		NewDeviceTransfersHandler(useCase, pagination.DefaultPolicy(), "secret").Complete(rec, newRequest(`{"actor":"pedro"}`))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestDeviceTransfersHandler_Get(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase := mocks.NewMockDeviceTransfersUseCase(t)
+	useCase.EXPECT().Get(mock.Anything, "transfer-1").Return(
+		&entities.DeviceTransfer{ID: "transfer-1", MACAddress: "AA:BB:CC:DD:EE:FF", ToFarmID: "finca-sur", HistoryPolicy: "keep", Status: "cancelled", CreatedAt: now, CancelledAt: &now},
+		[]entities.DeviceTransferAuditEntry{
+			{TransferID: "transfer-1", Action: "initiated", Actor: "maria", At: now},
+			{TransferID: "transfer-1", Action: "cancelled", Actor: "pedro", Note: "wrong device", At: now},
+		}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/transfers/transfer-1", nil)
+	req.SetPathValue("id", "transfer-1")
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	NewDeviceTransfersHandler(useCase, pagination.DefaultPolicy(), "secret").Get(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"audit":[{"action":"initiated","actor":"maria","at":"2025-06-01T12:00:00Z"},{"action":"cancelled","actor":"pedro","note":"wrong device","at":"2025-06-01T12:00:00Z"}]`)
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicemanagement "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_management"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// DeviceRequest is the body of a device creation or update; the MAC address is only read on
+// creation and omitted fields of an update keep their current value
+type DeviceRequest struct {
+	MACAddress          string `json:"mac_address,omitempty"`
+	DeviceName          string `json:"device_name,omitempty"`
+	IPAddress           string `json:"ip_address,omitempty"`
+	LocationDescription string `json:"location_description,omitempty"`
+}
+
+// DevicesResponse is a page of devices, most recently registered first
+type DevicesResponse struct {
+	Devices []DeviceRecordResponse `json:"devices"`
+	Offset  int                    `json:"offset"`
+	Limit   int                    `json:"limit"`
+}
+
+// DevicesHandler serves the registered devices. Reads are public like the other device endpoints;
+// writes require the admin token.
+type DevicesHandler struct {
+	devicesUseCase devicemanagement.DeviceManagementUseCase
+	pagination     pagination.Policy
+	token          string
+}
+
+func NewDevicesHandler(devicesUseCase devicemanagement.DeviceManagementUseCase, policy pagination.Policy, token string) *DevicesHandler {
+	return &DevicesHandler{
+		devicesUseCase: devicesUseCase,
+		pagination:     policy,
+		token:          token,
+	}
+}
+
+// List handles GET /api/v1/devices?status=online&zone=ID&offset=N&limit=N
+func (h *DevicesHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, err := h.pagination.ParseRequestLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		writeError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	offset := 0
+	if value := r.URL.Query().Get("offset"); value != "" {
+		offset, err = strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			writeError(w, r, "invalid offset", http.StatusBadRequest)
+			return
+		}
+	}
+
+	devices, err := h.devicesUseCase.List(r.Context(), r.URL.Query().Get("status"), r.URL.Query().Get("zone"), offset, limit)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidDeviceStatus) {
+			writeDomainError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, domainerrors.ErrZoneNotFound) {
+			writeDomainError(w, r, err, http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to list devices", http.StatusInternalServerError)
+		return
+	}
+
+	response := DevicesResponse{Devices: make([]DeviceRecordResponse, 0, len(devices)), Offset: offset, Limit: limit}
+	for _, device := range devices {
+		response.Devices = append(response.Devices, newDeviceRecordResponse(device))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Get handles GET /api/v1/devices/{mac}
+func (h *DevicesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	device, err := h.devicesUseCase.Get(r.Context(), r.PathValue("mac"))
+	if err != nil {
+		h.writeDeviceError(w, r, err, "failed to load device")
+		return
+	}
+	writeJSON(w, http.StatusOK, newDeviceRecordResponse(device))
+}
+
+// Create handles POST /api/v1/devices
+func (h *DevicesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	request, ok := decodeDeviceRequest(w, r)
+	if !ok {
+		return
+	}
+	device, err := h.devicesUseCase.Create(r.Context(), request.configuration())
+	if err != nil {
+		h.writeDeviceError(w, r, err, "failed to create device")
+		return
+	}
+	w.Header().Set("Location", "/api/v1/devices/"+device.GetID())
+	writeJSON(w, http.StatusCreated, newDeviceRecordResponse(device))
+}
+
+// Update handles PUT /api/v1/devices/{mac}
+func (h *DevicesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	request, ok := decodeDeviceRequest(w, r)
+	if !ok {
+		return
+	}
+	device, err := h.devicesUseCase.Update(r.Context(), r.PathValue("mac"), request.configuration())
+	if err != nil {
+		h.writeDeviceError(w, r, err, "failed to update device")
+		return
+	}
+	writeJSON(w, http.StatusOK, newDeviceRecordResponse(device))
+}
+
+// Delete handles DELETE /api/v1/devices/{mac}
+func (h *DevicesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.devicesUseCase.Delete(r.Context(), r.PathValue("mac")); err != nil {
+		h.writeDeviceError(w, r, err, "failed to delete device")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeDeviceError maps the domain errors of the device use case to their status codes
+func (h *DevicesHandler) writeDeviceError(w http.ResponseWriter, r *http.Request, err error, failure string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrDeviceNotFound):
+		writeError(w, r, "device not found", http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrDeviceAlreadyExists):
+		writeError(w, r, "device already exists", http.StatusConflict)
+	case errors.Is(err, domainerrors.ErrInvalidDevice):
+		writeDomainError(w, r, err, http.StatusBadRequest)
+	default:
+		writeError(w, r, failure, http.StatusInternalServerError)
+	}
+}
+
+func decodeDeviceRequest(w http.ResponseWriter, r *http.Request) (*DeviceRequest, bool) {
+	var request DeviceRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return nil, false
+	}
+	return &request, true
+}
+
+func (request *DeviceRequest) configuration() entities.DeviceConfiguration {
+	return entities.DeviceConfiguration{
+		MACAddress:          request.MACAddress,
+		DeviceName:          request.DeviceName,
+		IPAddress:           request.IPAddress,
+		LocationDescription: request.LocationDescription,
+	}
+}
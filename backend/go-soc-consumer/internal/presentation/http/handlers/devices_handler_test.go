@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/middleware"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func newDevicesRequest(method, target, mac, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if mac != "" {
+		req.SetPathValue("mac", mac)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func newTestDevice(t *testing.T) *entities.Device {
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	return device
+}
+
+func TestDevicesHandler_List(t *testing.T) {
+	t.Run("should return a page of devices with the status", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceManagementUseCase(t)
+		useCase.EXPECT().List(mock.Anything, "online", "", 20, 10).Return([]*entities.Device{newTestDevice(t)}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewDevicesHandler(useCase, pagination.DefaultPolicy(), "secret").List(rec, newDevicesRequest(http.MethodGet, "/api/v1/devices?status=online&offset=20&limit=10", "", ""))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"mac_address":"AA:BB:CC:DD:EE:FF"`)
+		assert.Contains(t, rec.Body.String(), `"offset":20,"limit":10`)
+	})
+
+	t.Run("should reject invalid pages and statuses", func(t *testing.T) {
+		useCase := mocks.NewMockDeviceManagementUseCase(t)
+		useCase.EXPECT().List(mock.Anything, "sleeping", "", 0, 50).Return(nil, fmt.Errorf("%w: invalid status: sleeping", domainerrors.ErrInvalidDeviceStatus)).Once()
+		handler := NewDevicesHandler(useCase, pagination.DefaultPolicy(), "secret")
+
+		for _, target := range []string{"/api/v1/devices?offset=-1", "/api/v1/devices?limit=0", "/api/v1/devices?limit=1000", "/api/v1/devices?status=sleeping"} {
+			rec := httptest.NewRecorder()
+			handler.List(rec, newDevicesRequest(http.MethodGet, target, "", ""))
+			assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+		}
+	})
+}
+
+func TestDevicesHandler_Get(t *testing.T) {
+	useCase := mocks.NewMockDeviceManagementUseCase(t)
+	handler := NewDevicesHandler(useCase, pagination.DefaultPolicy(), "")
+
+	useCase.EXPECT().Get(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(newTestDevice(t), nil).Once()
+	rec := httptest.NewRecorder()
+	handler.Get(rec, newDevicesRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF", "AA:BB:CC:DD:EE:FF", ""))
+	assert.Equal(t, http.StatusOK, rec.Code, "reads need no token")
+
+	useCase.EXPECT().Get(mock.Anything, "11:22:33:44:55:66").Return(nil, domainerrors.ErrDeviceNotFound).Once()
+	rec = httptest.NewRecorder()
+	handler.Get(rec, newDevicesRequest(http.MethodGet, "/api/v1/devices/11:22:33:44:55:66", "11:22:33:44:55:66", ""))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestDevicesHandler_Create(t *testing.T) {
+	body := `{"mac_address":"aa:bb:cc:dd:ee:ff","device_name":"Sensor","ip_address":"192.168.1.10","location_description":"Greenhouse"}`
+	configuration := entities.DeviceConfiguration{MACAddress: "aa:bb:cc:dd:ee:ff", DeviceName: "Sensor", IPAddress: "192.168.1.10", LocationDescription: "Greenhouse"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "created", expected: http.StatusCreated},
+		{name: "already exists", err: domainerrors.ErrDeviceAlreadyExists, expected: http.StatusConflict},
+		{name: "invalid", err: fmt.Errorf("%w: ip address is required", domainerrors.ErrInvalidDevice), expected: http.StatusBadRequest},
+		{name: "storage failure", err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockDeviceManagementUseCase(t)
+			var device *entities.Device
+			if tt.err == nil {
+				device = newTestDevice(t)
+			}
+			useCase.EXPECT().Create(mock.Anything, configuration).Return(device, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			NewDevicesHandler(useCase, pagination.DefaultPolicy(), "secret").Create(rec, newDevicesRequest(http.MethodPost, "/api/v1/devices", "", body))
+
+			assert.Equal(t, tt.expected, rec.Code)
+			if tt.err == nil {
+				assert.Equal(t, "/api/v1/devices/AA:BB:CC:DD:EE:FF", rec.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestDevicesHandler_UpdateAndDelete(t *testing.T) {
+	useCase := mocks.NewMockDeviceManagementUseCase(t)
+	handler := NewDevicesHandler(useCase, pagination.DefaultPolicy(), "secret")
+	mac := "AA:BB:CC:DD:EE:FF"
+
+	useCase.EXPECT().Update(mock.Anything, mac, entities.DeviceConfiguration{DeviceName: "North valve"}).Return(newTestDevice(t), nil).Once()
+	rec := httptest.NewRecorder()
+	handler.Update(rec, newDevicesRequest(http.MethodPut, "/api/v1/devices/"+mac, mac, `{"device_name":"North valve"}`))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.Update(rec, newDevicesRequest(http.MethodPut, "/api/v1/devices/"+mac, mac, `{`))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	useCase.EXPECT().Delete(mock.Anything, mac).Return(nil).Once()
+	rec = httptest.NewRecorder()
+	handler.Delete(rec, newDevicesRequest(http.MethodDelete, "/api/v1/devices/"+mac, mac, ""))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	useCase.EXPECT().Delete(mock.Anything, mac).Return(domainerrors.ErrDeviceNotFound).Once()
+	rec = httptest.NewRecorder()
+	handler.Delete(rec, newDevicesRequest(http.MethodDelete, "/api/v1/devices/"+mac, mac, ""))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	req := newDevicesRequest(http.MethodDelete, "/api/v1/devices/"+mac, mac, "")
+	req.Header.Set("Authorization", "Bearer wrong")
+	rec = httptest.NewRecorder()
+	handler.Delete(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// Admin users authenticated with their access token are accepted, viewers are not
+	useCase.EXPECT().Delete(mock.Anything, mac).Return(nil).Once()
+	for role, expected := range map[string]int{entities.UserRoleAdmin: http.StatusNoContent, entities.UserRoleViewer: http.StatusUnauthorized} {
+		req := newDevicesRequest(http.MethodDelete, "/api/v1/devices/"+mac, mac, "")
+		req.Header.Set("Authorization", "Bearer access-token")
+		req = req.WithContext(middleware.ContextWithClaims(req.Context(), &entities.AuthClaims{Subject: "user-1", Username: "ana", Role: role}))
+		rec = httptest.NewRecorder()
+		handler.Delete(rec, req)
+		assert.Equal(t, expected, rec.Code, role)
+	}
+}
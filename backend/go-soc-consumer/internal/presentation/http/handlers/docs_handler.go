@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// DocsHandler serves the OpenAPI document of the public API, for clients to be generated from
+type DocsHandler struct {
+	document []byte
+}
+
+// NewDocsHandler generates the OpenAPI document once; it only depends on the handler types
+func NewDocsHandler() (*DocsHandler, error) {
+	document, err := json.MarshalIndent(NewOpenAPIDocument(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &DocsHandler{document: document}, nil
+}
+
+// OpenAPI handles GET /api/v1/docs
+func (h *DocsHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(h.document); err != nil {
+		http.Error(w, "failed to write response", http.StatusInternalServerError)
+	}
+}
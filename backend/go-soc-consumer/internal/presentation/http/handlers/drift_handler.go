@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	driftreconciliation "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/drift_reconciliation"
+)
+
+// DeviceDriftResponse is a device reporting a configuration other than the declared one
+type DeviceDriftResponse struct {
+	MACAddress string     `json:"mac_address"`
+	Fields     []string   `json:"fields"`
+	Since      time.Time  `json:"since"`
+	Pushes     int        `json:"pushes"`
+	LastPushAt *time.Time `json:"last_push_at,omitempty"`
+	Persistent bool       `json:"persistent"`
+}
+
+// DeviceDriftsResponse lists the drifting devices by MAC address
+type DeviceDriftsResponse struct {
+	Devices []DeviceDriftResponse `json:"devices"`
+}
+
+// DriftHandler reports the devices drifting from their declared configuration
+type DriftHandler struct {
+	driftUseCase driftreconciliation.DriftReconciliationUseCase
+	token        string
+}
+
+func NewDriftHandler(driftUseCase driftreconciliation.DriftReconciliationUseCase, token string) *DriftHandler {
+	return &DriftHandler{
+		driftUseCase: driftUseCase,
+		token:        token,
+	}
+}
+
+// List handles GET /admin/devices/drift
+func (h *DriftHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	drifts := h.driftUseCase.Drifts()
+	response := DeviceDriftsResponse{Devices: make([]DeviceDriftResponse, 0, len(drifts))}
+	for _, drift := range drifts {
+		response.Devices = append(response.Devices, DeviceDriftResponse{
+			MACAddress: drift.MACAddress,
+			Fields:     drift.Fields,
+			Since:      drift.Since,
+			Pushes:     drift.Pushes,
+			LastPushAt: drift.LastPushAt,
+			Persistent: drift.Persistent,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestDriftHandler_List(t *testing.T) {
+	since := time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC)
+	lastPush := since.Add(2 * time.Minute)
+	useCase := mocks.NewMockDriftReconciliationUseCase(t)
+	useCase.EXPECT().Drifts().Return([]*entities.DeviceDrift{
+		{MACAddress: "AA:BB:CC:DD:EE:FF", Fields: []string{"device_name"}, Since: since, Pushes: 2, LastPushAt: &lastPush, Persistent: true},
+	}).Once()
+	handler := NewDriftHandler(useCase, "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/devices/drift", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler.List(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"devices":[{"mac_address":"AA:BB:CC:DD:EE:FF","fields":["device_name"],"since":"2025-06-01T06:00:00Z","pushes":2,"last_push_at":"2025-06-01T06:02:00Z","persistent":true}]}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.List(rec, httptest.NewRequest(http.MethodGet, "/admin/devices/drift", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventpolicies "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/event_policies"
+)
+
+// SetEventPolicyRequest is the body of a publishing policy; omitted settings publish every event
+type SetEventPolicyRequest struct {
+	Enabled            *bool    `json:"enabled,omitempty"`     // true when omitted
+	SampleRate         *float64 `json:"sample_rate,omitempty"` // 1 when omitted
+	MinIntervalSeconds float64  `json:"min_interval_seconds,omitempty"`
+}
+
+// EventPolicyResponse is the JSON representation of the publishing policy of an event type
+type EventPolicyResponse struct {
+	EventType          string  `json:"event_type"`
+	Enabled            bool    `json:"enabled"`
+	SampleRate         float64 `json:"sample_rate"`
+	MinIntervalSeconds float64 `json:"min_interval_seconds"`
+}
+
+// EventPoliciesResponse lists the publishing policies, sorted by event type
+type EventPoliciesResponse struct {
+	Policies []EventPolicyResponse `json:"policies"`
+}
+
+// EventPoliciesHandler changes the publishing policies of outbound events at runtime
+type EventPoliciesHandler struct {
+	policiesUseCase eventpolicies.EventPoliciesUseCase
+	token           string
+}
+
+func NewEventPoliciesHandler(policiesUseCase eventpolicies.EventPoliciesUseCase, token string) *EventPoliciesHandler {
+	return &EventPoliciesHandler{
+		policiesUseCase: policiesUseCase,
+		token:           token,
+	}
+}
+
+// List handles GET /admin/events/policies
+func (h *EventPoliciesHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	policies := h.policiesUseCase.List()
+	response := EventPoliciesResponse{Policies: make([]EventPolicyResponse, 0, len(policies))}
+	for _, policy := range policies {
+		response.Policies = append(response.Policies, newEventPolicyResponse(policy))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Set handles PUT /admin/events/policies/{type}
+func (h *EventPoliciesHandler) Set(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request SetEventPolicyRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	policy := entities.DefaultEventPolicy(r.PathValue("type"))
+	if request.Enabled != nil {
+		policy.Enabled = *request.Enabled
+	}
+	if request.SampleRate != nil {
+		policy.SampleRate = *request.SampleRate
+	}
+	policy.MinInterval = time.Duration(request.MinIntervalSeconds * float64(time.Second))
+
+	// Set only fails on invalid policies
+	if err := h.policiesUseCase.Set(policy); err != nil {
+		writeDomainError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusOK, newEventPolicyResponse(policy))
+}
+
+// Reset handles DELETE /admin/events/policies/{type}, restoring the configured policy
+func (h *EventPoliciesHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newEventPolicyResponse(h.policiesUseCase.Reset(r.PathValue("type"))))
+}
+
+func newEventPolicyResponse(policy *entities.EventPolicy) EventPolicyResponse {
+	return EventPolicyResponse{
+		EventType:          policy.EventType,
+		Enabled:            policy.Enabled,
+		SampleRate:         policy.SampleRate,
+		MinIntervalSeconds: policy.MinInterval.Seconds(),
+	}
+}
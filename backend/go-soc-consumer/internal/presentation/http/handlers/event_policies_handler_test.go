@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newEventPolicyRequest(method, eventType, body string) *http.Request {
+	req := httptest.NewRequest(method, "/admin/events/policies/"+eventType, strings.NewReader(body))
+	req.SetPathValue("type", eventType)
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func TestEventPoliciesHandler(t *testing.T) {
+	t.Run("should list the policies", func(t *testing.T) {
+		useCase := mocks.NewMockEventPoliciesUseCase(t)
+		useCase.EXPECT().List().Return([]*entities.EventPolicy{
+			{EventType: "alert", Enabled: true, SampleRate: 1},
+			{EventType: "device.detected", Enabled: true, SampleRate: 0.5, MinInterval: time.Minute},
+		}).Once()
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/admin/events/policies", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		NewEventPoliciesHandler(useCase, "secret").List(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"policies":[
+			{"event_type":"alert","enabled":true,"sample_rate":1,"min_interval_seconds":0},
+			{"event_type":"device.detected","enabled":true,"sample_rate":0.5,"min_interval_seconds":60}
+		]}`, rec.Body.String())
+	})
+
+	t.Run("should set a policy defaulting omitted settings", func(t *testing.T) {
+		useCase := mocks.NewMockEventPoliciesUseCase(t)
+		useCase.EXPECT().Set(&entities.EventPolicy{EventType: "device.detected", Enabled: true, SampleRate: 1, MinInterval: 30 * time.Second}).Return(nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewEventPoliciesHandler(useCase, "secret").Set(rec, newEventPolicyRequest(http.MethodPut, "device.detected", `{"min_interval_seconds":30}`))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response EventPolicyResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, 30.0, response.MinIntervalSeconds)
+	})
+
+	t.Run("should reject an invalid policy", func(t *testing.T) {
+		useCase := mocks.NewMockEventPoliciesUseCase(t)
+		useCase.EXPECT().Set(mock.Anything).Return(fmt.Errorf("%w: sample rate must be between 0 and 1", domainerrors.ErrInvalidEventPolicy)).Once()
+
+		rec := httptest.NewRecorder()
+		NewEventPoliciesHandler(useCase, "secret").Set(rec, newEventPolicyRequest(http.MethodPut, "alert", `{"sample_rate":2}`))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should reset a policy", func(t *testing.T) {
+		useCase := mocks.NewMockEventPoliciesUseCase(t)
+		useCase.EXPECT().Reset("alert").Return(entities.DefaultEventPolicy("alert")).Once()
+
+		rec := httptest.NewRecorder()
+		NewEventPoliciesHandler(useCase, "secret").Reset(rec, newEventPolicyRequest(http.MethodDelete, "alert", ""))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"enabled":true`)
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewEventPoliciesHandler(mocks.NewMockEventPoliciesUseCase(t), "secret").List(rec, httptest.NewRequest(http.MethodGet, "/admin/events/policies", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	farmquotas "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/farm_quotas"
+)
+
+// SetFarmQuotaRequest is the body of a farm quota override; omitted limits keep the quota currently
+// applied and 0 is unlimited
+type SetFarmQuotaRequest struct {
+	MaxDevices           *int `json:"max_devices,omitempty"`
+	MaxMessagesPerMinute *int `json:"max_messages_per_minute,omitempty"`
+}
+
+// FarmQuotaResponse is the JSON representation of the quota of a farm
+type FarmQuotaResponse struct {
+	FarmID               string `json:"farm_id"`
+	MaxDevices           int    `json:"max_devices"`
+	MaxMessagesPerMinute int    `json:"max_messages_per_minute"`
+}
+
+// FarmQuotaUsageResponse is the quota of a farm with its current usage
+type FarmQuotaUsageResponse struct {
+	FarmQuotaResponse
+	Source             string `json:"source"` // default, configured or override
+	Devices            int    `json:"devices"`
+	MessagesThisMinute int    `json:"messages_this_minute"`
+}
+
+// FarmQuotasHandler inspects and overrides the quotas of the farms at runtime
+type FarmQuotasHandler struct {
+	quotasUseCase farmquotas.FarmQuotasUseCase
+	token         string
+}
+
+func NewFarmQuotasHandler(quotasUseCase farmquotas.FarmQuotasUseCase, token string) *FarmQuotasHandler {
+	return &FarmQuotasHandler{
+		quotasUseCase: quotasUseCase,
+		token:         token,
+	}
+}
+
+// Get handles GET /admin/farms/{farm}/quota
+func (h *FarmQuotasHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	usage, ok := h.usage(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, newFarmQuotaUsageResponse(usage))
+}
+
+// Set handles PUT /admin/farms/{farm}/quota
+func (h *FarmQuotasHandler) Set(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request SetFarmQuotaRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	usage, ok := h.usage(w, r)
+	if !ok {
+		return
+	}
+	quota := usage.Quota
+	if request.MaxDevices != nil {
+		quota.MaxDevices = *request.MaxDevices
+	}
+	if request.MaxMessagesPerMinute != nil {
+		quota.MaxMessagesPerMinute = *request.MaxMessagesPerMinute
+	}
+
+	// Set only fails on invalid quotas
+	if err := h.quotasUseCase.Set(quota); err != nil {
+		writeDomainError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	usage.Quota, usage.Source = quota, entities.FarmQuotaSourceOverride
+	writeJSON(w, http.StatusOK, newFarmQuotaUsageResponse(usage))
+}
+
+// Reset handles DELETE /admin/farms/{farm}/quota, restoring the configured or default quota
+func (h *FarmQuotasHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newFarmQuotaResponse(h.quotasUseCase.Reset(r.PathValue("farm"))))
+}
+
+// usage loads the quota and usage of the farm in the path, writing the error response on failure
+func (h *FarmQuotasHandler) usage(w http.ResponseWriter, r *http.Request) (*entities.FarmQuotaUsage, bool) {
+	usage, err := h.quotasUseCase.Get(r.Context(), r.PathValue("farm"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidFarmQuota) {
+			writeDomainError(w, r, err, http.StatusBadRequest)
+			return nil, false
+		}
+		writeError(w, r, "failed to load farm quota", http.StatusInternalServerError)
+		return nil, false
+	}
+	return usage, true
+}
+
+func newFarmQuotaResponse(quota *entities.FarmQuota) FarmQuotaResponse {
+	return FarmQuotaResponse{
+		FarmID:               quota.FarmID,
+		MaxDevices:           quota.MaxDevices,
+		MaxMessagesPerMinute: quota.MaxMessagesPerMinute,
+	}
+}
+
+func newFarmQuotaUsageResponse(usage *entities.FarmQuotaUsage) FarmQuotaUsageResponse {
+	return FarmQuotaUsageResponse{
+		FarmQuotaResponse:  newFarmQuotaResponse(usage.Quota),
+		Source:             usage.Source,
+		Devices:            usage.Devices,
+		MessagesThisMinute: usage.MessagesThisMinute,
+	}
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newFarmQuotaRequest(method, farmID, body string) *http.Request {
+	req := httptest.NewRequest(method, "/admin/farms/"+farmID+"/quota", strings.NewReader(body))
+	req.SetPathValue("farm", farmID)
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func TestFarmQuotasHandler(t *testing.T) {
+	usage := func() *entities.FarmQuotaUsage {
+		return &entities.FarmQuotaUsage{
+			Quota:              &entities.FarmQuota{FarmID: "farm-a", MaxDevices: 10, MaxMessagesPerMinute: 600},
+			Source:             entities.FarmQuotaSourceDefault,
+			Devices:            4,
+			MessagesThisMinute: 120,
+		}
+	}
+
+	t.Run("should return the quota and usage of a farm", func(t *testing.T) {
+		useCase := mocks.NewMockFarmQuotasUseCase(t)
+		useCase.EXPECT().Get(mock.Anything, "farm-a").Return(usage(), nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewFarmQuotasHandler(useCase, "secret").Get(rec, newFarmQuotaRequest(http.MethodGet, "farm-a", ""))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"farm_id":"farm-a","max_devices":10,"max_messages_per_minute":600,"source":"default","devices":4,"messages_this_minute":120}`, rec.Body.String())
+	})
+
+	t.Run("should override only the given limits", func(t *testing.T) {
+		useCase := mocks.NewMockFarmQuotasUseCase(t)
+		useCase.EXPECT().Get(mock.Anything, "farm-a").Return(usage(), nil).Once()
+		useCase.EXPECT().Set(&entities.FarmQuota{FarmID: "farm-a", MaxDevices: 25, MaxMessagesPerMinute: 600}).Return(nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewFarmQuotasHandler(useCase, "secret").Set(rec, newFarmQuotaRequest(http.MethodPut, "farm-a", `{"max_devices":25}`))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"max_devices":25`)
+		assert.Contains(t, rec.Body.String(), `"source":"override"`)
+	})
+
+	t.Run("should reject an invalid quota", func(t *testing.T) {
+		useCase := mocks.NewMockFarmQuotasUseCase(t)
+		useCase.EXPECT().Get(mock.Anything, "farm-a").Return(usage(), nil).Once()
+		useCase.EXPECT().Set(mock.Anything).Return(fmt.Errorf("%w: maximum devices cannot be negative", domainerrors.ErrInvalidFarmQuota)).Once()
+
+		rec := httptest.NewRecorder()
+		NewFarmQuotasHandler(useCase, "secret").Set(rec, newFarmQuotaRequest(http.MethodPut, "farm-a", `{"max_devices":-1}`))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should reject an invalid farm", func(t *testing.T) {
+		useCase := mocks.NewMockFarmQuotasUseCase(t)
+		useCase.EXPECT().Get(mock.Anything, "farm.a").Return(nil, fmt.Errorf("%w: invalid farm id", domainerrors.ErrInvalidFarmQuota)).Once()
+
+		rec := httptest.NewRecorder()
+		NewFarmQuotasHandler(useCase, "secret").Get(rec, newFarmQuotaRequest(http.MethodGet, "farm.a", ""))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should fail when the usage cannot be loaded", func(t *testing.T) {
+		useCase := mocks.NewMockFarmQuotasUseCase(t)
+		useCase.EXPECT().Get(mock.Anything, "farm-a").Return(nil, errors.New("connection refused")).Once()
+
+		rec := httptest.NewRecorder()
+		NewFarmQuotasHandler(useCase, "secret").Get(rec, newFarmQuotaRequest(http.MethodGet, "farm-a", ""))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "failed to load farm quota")
+	})
+
+	t.Run("should reset a quota", func(t *testing.T) {
+		useCase := mocks.NewMockFarmQuotasUseCase(t)
+		useCase.EXPECT().Reset("farm-a").Return(&entities.FarmQuota{FarmID: "farm-a", MaxDevices: 10}).Once()
+
+		rec := httptest.NewRecorder()
+		NewFarmQuotasHandler(useCase, "secret").Reset(rec, newFarmQuotaRequest(http.MethodDelete, "farm-a", ""))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"farm_id":"farm-a","max_devices":10,"max_messages_per_minute":0}`, rec.Body.String())
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := newFarmQuotaRequest(http.MethodGet, "farm-a", "")
+		req.Header.Del("Authorization")
+		NewFarmQuotasHandler(mocks.NewMockFarmQuotasUseCase(t), "secret").Get(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	farmzones "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/farm_zones"
+)
+
+// FarmRequest is the body of the farm creation and rename endpoints; the ID is only read on creation
+type FarmRequest struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// FarmZoneRequest is the body of the field and zone creation and rename endpoints; the kind and
+// parent are only read on creation
+type FarmZoneRequest struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind,omitempty"`      // field or zone
+	ParentID string `json:"parent_id,omitempty"` // field of a zone
+}
+
+// FarmResponse is the JSON representation of a farm
+type FarmResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FarmsResponse lists the farms, sorted by name
+type FarmsResponse struct {
+	Farms []FarmResponse `json:"farms"`
+}
+
+// ZoneResponse is the JSON representation of a field or zone
+type ZoneResponse struct {
+	ID        string    `json:"id"`
+	FarmID    string    `json:"farm_id"`
+	ParentID  string    `json:"parent_id,omitempty"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FarmFieldResponse is a field of a farm with its zones
+type FarmFieldResponse struct {
+	ZoneResponse
+	Zones []ZoneResponse `json:"zones"`
+}
+
+// FarmDetailResponse is a farm with its fields and their zones, oldest first
+type FarmDetailResponse struct {
+	FarmResponse
+	Fields []FarmFieldResponse `json:"fields"`
+}
+
+// ZoneDetailResponse is a field or zone with its devices; those of a field are the devices of its zones
+type ZoneDetailResponse struct {
+	ZoneResponse
+	Devices []DeviceRecordResponse `json:"devices"`
+}
+
+// ZoneSensorSummaryResponse aggregates the latest measurements of a sensor type in a zone
+type ZoneSensorSummaryResponse struct {
+	SensorType string    `json:"sensor_type"`
+	Unit       string    `json:"unit"`
+	Devices    int       `json:"devices"`
+	Readings   int       `json:"readings"`
+	Min        float64   `json:"min"`
+	Max        float64   `json:"max"`
+	Average    float64   `json:"average"`
+	LatestAt   time.Time `json:"latest_at"`
+}
+
+// ZoneMeasurementsResponse aggregates by sensor type the latest measurements of a field or zone
+type ZoneMeasurementsResponse struct {
+	ZoneID  string                      `json:"zone_id"`
+	Since   time.Time                   `json:"since"`
+	Sensors []ZoneSensorSummaryResponse `json:"sensors"`
+}
+
+// FarmsHandler serves the farm → field → zone hierarchy and the devices assigned to its zones
+type FarmsHandler struct {
+	zonesUseCase farmzones.FarmZonesUseCase
+	token        string
+}
+
+func NewFarmsHandler(zonesUseCase farmzones.FarmZonesUseCase, token string) *FarmsHandler {
+	return &FarmsHandler{
+		zonesUseCase: zonesUseCase,
+		token:        token,
+	}
+}
+
+// ListFarms handles GET /api/v1/farms
+func (h *FarmsHandler) ListFarms(w http.ResponseWriter, r *http.Request) {
+	farms, err := h.zonesUseCase.ListFarms(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to list farms", http.StatusInternalServerError)
+		return
+	}
+
+	response := FarmsResponse{Farms: make([]FarmResponse, 0, len(farms))}
+	for _, farm := range farms {
+		response.Farms = append(response.Farms, FarmResponse(*farm))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetFarm handles GET /api/v1/farms/{farm}
+func (h *FarmsHandler) GetFarm(w http.ResponseWriter, r *http.Request) {
+	farm, zones, err := h.zonesUseCase.GetFarm(r.Context(), r.PathValue("farm"))
+	if err != nil {
+		h.writeFarmError(w, r, err, "failed to load farm")
+		return
+	}
+	writeJSON(w, http.StatusOK, newFarmDetailResponse(farm, zones))
+}
+
+// CreateFarm handles POST /admin/farms
+func (h *FarmsHandler) CreateFarm(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request FarmRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	farm, err := h.zonesUseCase.CreateFarm(r.Context(), request.ID, request.Name)
+	if err != nil {
+		h.writeFarmError(w, r, err, "failed to save farm")
+		return
+	}
+	writeJSON(w, http.StatusCreated, FarmResponse(*farm))
+}
+
+// RenameFarm handles PUT /admin/farms/{farm}
+func (h *FarmsHandler) RenameFarm(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request FarmRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	farm, err := h.zonesUseCase.RenameFarm(r.Context(), r.PathValue("farm"), request.Name)
+	if err != nil {
+		h.writeFarmError(w, r, err, "failed to save farm")
+		return
+	}
+	writeJSON(w, http.StatusOK, FarmResponse(*farm))
+}
+
+// DeleteFarm handles DELETE /admin/farms/{farm}
+func (h *FarmsHandler) DeleteFarm(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.zonesUseCase.DeleteFarm(r.Context(), r.PathValue("farm")); err != nil {
+		h.writeFarmError(w, r, err, "failed to delete farm")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateZone handles POST /admin/farms/{farm}/zones
+func (h *FarmsHandler) CreateZone(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request FarmZoneRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	zone, err := h.zonesUseCase.CreateZone(r.Context(), r.PathValue("farm"), entities.ZoneRequest(request))
+	if err != nil {
+		h.writeFarmError(w, r, err, "failed to save zone")
+		return
+	}
+	writeJSON(w, http.StatusCreated, ZoneResponse(*zone))
+}
+
+// GetZone handles GET /api/v1/zones/{id}
+func (h *FarmsHandler) GetZone(w http.ResponseWriter, r *http.Request) {
+	zone, devices, err := h.zonesUseCase.GetZone(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeFarmError(w, r, err, "failed to load zone")
+		return
+	}
+
+	response := ZoneDetailResponse{ZoneResponse: ZoneResponse(*zone), Devices: make([]DeviceRecordResponse, 0, len(devices))}
+	for _, device := range devices {
+		response.Devices = append(response.Devices, newDeviceRecordResponse(device))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// RenameZone handles PUT /admin/zones/{id}
+func (h *FarmsHandler) RenameZone(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request FarmZoneRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	zone, err := h.zonesUseCase.RenameZone(r.Context(), r.PathValue("id"), request.Name)
+	if err != nil {
+		h.writeFarmError(w, r, err, "failed to save zone")
+		return
+	}
+	writeJSON(w, http.StatusOK, ZoneResponse(*zone))
+}
+
+// DeleteZone handles DELETE /admin/zones/{id}
+func (h *FarmsHandler) DeleteZone(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.zonesUseCase.DeleteZone(r.Context(), r.PathValue("id")); err != nil {
+		h.writeFarmError(w, r, err, "failed to delete zone")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AssignDevice handles PUT /admin/zones/{id}/devices/{mac}
+func (h *FarmsHandler) AssignDevice(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.zonesUseCase.AssignDevice(r.Context(), r.PathValue("id"), r.PathValue("mac")); err != nil {
+		h.writeFarmError(w, r, err, "failed to assign device")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnassignDevice handles DELETE /admin/zones/{id}/devices/{mac}
+func (h *FarmsHandler) UnassignDevice(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.zonesUseCase.UnassignDevice(r.Context(), r.PathValue("id"), r.PathValue("mac")); err != nil {
+		h.writeFarmError(w, r, err, "failed to unassign device")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Measurements handles GET /api/v1/zones/{id}/measurements?since=RFC3339, defaulting to the last hour
+func (h *FarmsHandler) Measurements(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			writeError(w, r, "invalid since time", http.StatusBadRequest)
+			return
+		}
+	}
+
+	summaries, since, err := h.zonesUseCase.Measurements(r.Context(), r.PathValue("id"), since)
+	if err != nil {
+		h.writeFarmError(w, r, err, "failed to load zone measurements")
+		return
+	}
+
+	response := ZoneMeasurementsResponse{ZoneID: r.PathValue("id"), Since: since, Sensors: make([]ZoneSensorSummaryResponse, 0, len(summaries))}
+	for _, summary := range summaries {
+		response.Sensors = append(response.Sensors, ZoneSensorSummaryResponse(*summary))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// writeFarmError maps the failures of the farm and zone endpoints to their status
+func (h *FarmsHandler) writeFarmError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrInvalidFarm),
+		errors.Is(err, domainerrors.ErrInvalidZone),
+		errors.Is(err, domainerrors.ErrInvalidDevice),
+		errors.Is(err, domainerrors.ErrInvalidReadingsQuery):
+		writeDomainError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, domainerrors.ErrFarmAlreadyExists),
+		errors.Is(err, domainerrors.ErrFarmNotEmpty),
+		errors.Is(err, domainerrors.ErrZoneNotEmpty):
+		writeDomainError(w, r, err, http.StatusConflict)
+	case errors.Is(err, domainerrors.ErrFarmNotFound),
+		errors.Is(err, domainerrors.ErrZoneNotFound),
+		errors.Is(err, domainerrors.ErrDeviceNotFound),
+		errors.Is(err, domainerrors.ErrDeviceNotInZone):
+		writeDomainError(w, r, err, http.StatusNotFound)
+	default:
+		writeError(w, r, message, http.StatusInternalServerError)
+	}
+}
+
+// newFarmDetailResponse nests the zones of the farm under their fields
+func newFarmDetailResponse(farm *entities.Farm, zones []*entities.Zone) FarmDetailResponse {
+	response := FarmDetailResponse{FarmResponse: FarmResponse(*farm), Fields: []FarmFieldResponse{}}
+	fields := make(map[string]int)
+	for _, zone := range zones {
+		if zone.Kind == entities.ZoneKindField {
+			fields[zone.ID] = len(response.Fields)
+			response.Fields = append(response.Fields, FarmFieldResponse{ZoneResponse: ZoneResponse(*zone), Zones: []ZoneResponse{}})
+		}
+	}
+	for _, zone := range zones {
+		if index, ok := fields[zone.ParentID]; ok && zone.Kind == entities.ZoneKindZone {
+			response.Fields[index].Zones = append(response.Fields[index].Zones, ZoneResponse(*zone))
+		}
+	}
+	return response
+}
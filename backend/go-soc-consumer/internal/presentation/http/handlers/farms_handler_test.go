@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newFarmsRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.SetPathValue("farm", "la-esperanza")
+	req.SetPathValue("id", "zone-1")
+	req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func TestFarmsHandler_GetFarm(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should nest the zones under their fields", func(t *testing.T) {
+		useCase := mocks.NewMockFarmZonesUseCase(t)
+		useCase.EXPECT().GetFarm(mock.Anything, "la-esperanza").Return(
+			&entities.Farm{ID: "la-esperanza", Name: "La Esperanza", CreatedAt: at, UpdatedAt: at},
+			[]*entities.Zone{
+				{ID: "field-1", FarmID: "la-esperanza", Kind: entities.ZoneKindField, Name: "North", CreatedAt: at, UpdatedAt: at},
+				{ID: "zone-1", FarmID: "la-esperanza", ParentID: "field-1", Kind: entities.ZoneKindZone, Name: "Tomatoes", CreatedAt: at, UpdatedAt: at},
+			}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewFarmsHandler(useCase, "secret").GetFarm(rec, newFarmsRequest(http.MethodGet, "/api/v1/farms/la-esperanza", ""))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"id":"la-esperanza","name":"La Esperanza","created_at":"2025-06-01T12:00:00Z","updated_at":"2025-06-01T12:00:00Z",
+			"fields":[{"id":"field-1","farm_id":"la-esperanza","kind":"field","name":"North","created_at":"2025-06-01T12:00:00Z","updated_at":"2025-06-01T12:00:00Z",
+				"zones":[{"id":"zone-1","farm_id":"la-esperanza","parent_id":"field-1","kind":"zone","name":"Tomatoes","created_at":"2025-06-01T12:00:00Z","updated_at":"2025-06-01T12:00:00Z"}]}]}`, rec.Body.String())
+	})
+
+	t.Run("should return 404 for unknown farms", func(t *testing.T) {
+		useCase := mocks.NewMockFarmZonesUseCase(t)
+		useCase.EXPECT().GetFarm(mock.Anything, "la-esperanza").Return(nil, nil, domainerrors.ErrFarmNotFound).Once()
+
+		rec := httptest.NewRecorder()
+		NewFarmsHandler(useCase, "secret").GetFarm(rec, newFarmsRequest(http.MethodGet, "/api/v1/farms/la-esperanza", ""))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestFarmsHandler_CreateZone(t *testing.T) {
+	request := entities.ZoneRequest{Name: "Tomatoes", Kind: "zone", ParentID: "field-1"}
+	body := `{"name":"Tomatoes","kind":"zone","parent_id":"field-1"}`
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: fmt.Errorf("%w: a zone needs a parent field", domainerrors.ErrInvalidZone), expected: http.StatusBadRequest},
+			{err: domainerrors.ErrFarmNotFound, expected: http.StatusNotFound},
+			{err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockFarmZonesUseCase(t)
+			useCase.EXPECT().CreateZone(mock.Anything, "la-esperanza", request).Return(nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			NewFarmsHandler(useCase, "secret").CreateZone(rec, newFarmsRequest(http.MethodPost, "/admin/farms/la-esperanza/zones", body))
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+
+	t.Run("should reject requests without the admin token", func(t *testing.T) {
+		req := newFarmsRequest(http.MethodPost, "/admin/farms/la-esperanza/zones", body)
+		req.Header.Set("Authorization", "Bearer wrong")
+
+		rec := httptest.NewRecorder()
+		NewFarmsHandler(mocks.NewMockFarmZonesUseCase(t), "secret").CreateZone(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestFarmsHandler_DeleteZone(t *testing.T) {
+	useCase := mocks.NewMockFarmZonesUseCase(t)
+	useCase.EXPECT().DeleteZone(mock.Anything, "zone-1").Return(fmt.Errorf("%w: zone Tomatoes has 2 devices", domainerrors.ErrZoneNotEmpty)).Once()
+
+	rec := httptest.NewRecorder()
+	NewFarmsHandler(useCase, "secret").DeleteZone(rec, newFarmsRequest(http.MethodDelete, "/admin/zones/zone-1", ""))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestFarmsHandler_AssignDevice(t *testing.T) {
+	useCase := mocks.NewMockFarmZonesUseCase(t)
+	useCase.EXPECT().AssignDevice(mock.Anything, "zone-1", "AA:BB:CC:DD:EE:FF").Return(nil).Once()
+
+	rec := httptest.NewRecorder()
+	NewFarmsHandler(useCase, "secret").AssignDevice(rec, newFarmsRequest(http.MethodPut, "/admin/zones/zone-1/devices/AA:BB:CC:DD:EE:FF", ""))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestFarmsHandler_Measurements(t *testing.T) {
+	since := time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC)
+
+	t.Run("should aggregate the zone measurements", func(t *testing.T) {
+		useCase := mocks.NewMockFarmZonesUseCase(t)
+		useCase.EXPECT().Measurements(mock.Anything, "zone-1", since).Return([]*entities.ZoneSensorSummary{
+			{SensorType: "soil_moisture", Unit: "%", Devices: 2, Readings: 3, Min: 20, Max: 40, Average: 30, LatestAt: since.Add(time.Hour)},
+		}, since, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewFarmsHandler(useCase, "secret").Measurements(rec, newFarmsRequest(http.MethodGet, "/api/v1/zones/zone-1/measurements?since=2025-06-01T11:00:00Z", ""))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"zone_id":"zone-1","since":"2025-06-01T11:00:00Z","sensors":[{"sensor_type":"soil_moisture","unit":"%","devices":2,"readings":3,
+			"min":20,"max":40,"average":30,"latest_at":"2025-06-01T12:00:00Z"}]}`, rec.Body.String())
+	})
+
+	t.Run("should reject an invalid since time", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewFarmsHandler(mocks.NewMockFarmZonesUseCase(t), "secret").Measurements(rec, newFarmsRequest(http.MethodGet, "/api/v1/zones/zone-1/measurements?since=yesterday", ""))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	fleetversions "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/fleet_versions"
+)
+
+// VersionCountResponse is the JSON representation of the devices running a version; an empty
+// version counts the devices that never reported one
+type VersionCountResponse struct {
+	Version string `json:"version"`
+	Devices int    `json:"devices"`
+	Online  int    `json:"online"`
+}
+
+// FarmVersionsResponse is the JSON representation of the firmware versions of a farm
+type FarmVersionsResponse struct {
+	FarmID   string                 `json:"farm_id"`
+	Devices  int                    `json:"devices"`
+	Upgraded *int                   `json:"upgraded,omitempty"`
+	Firmware []VersionCountResponse `json:"firmware"`
+}
+
+// UpgradeProgressResponse is the JSON representation of the progress towards a target version
+type UpgradeProgressResponse struct {
+	TargetVersion string  `json:"target_version"`
+	Upgraded      int     `json:"upgraded"`
+	Pending       int     `json:"pending"`
+	Unknown       int     `json:"unknown"`
+	Percent       float64 `json:"percent"`
+}
+
+// FleetVersionsResponse is the JSON representation of the versions the fleet runs
+type FleetVersionsResponse struct {
+	Devices  int                      `json:"devices"`
+	Online   int                      `json:"online"`
+	Firmware []VersionCountResponse   `json:"firmware"`
+	Hardware []VersionCountResponse   `json:"hardware"`
+	Farms    []FarmVersionsResponse   `json:"farms"`
+	Progress *UpgradeProgressResponse `json:"progress,omitempty"`
+}
+
+// FleetVersionsHandler serves the firmware and hardware versions of the fleet
+type FleetVersionsHandler struct {
+	fleetVersionsUseCase fleetversions.FleetVersionsUseCase
+}
+
+func NewFleetVersionsHandler(fleetVersionsUseCase fleetversions.FleetVersionsUseCase) *FleetVersionsHandler {
+	return &FleetVersionsHandler{fleetVersionsUseCase: fleetVersionsUseCase}
+}
+
+// Report handles GET /api/v1/devices/versions?target=VERSION
+func (h *FleetVersionsHandler) Report(w http.ResponseWriter, r *http.Request) {
+	report, err := h.fleetVersionsUseCase.Report(r.Context(), r.URL.Query().Get("target"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidTargetVersion) {
+			writeDomainError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		writeError(w, r, "failed to load firmware versions", http.StatusInternalServerError)
+		return
+	}
+
+	response := FleetVersionsResponse{
+		Devices:  report.Devices,
+		Online:   report.Online,
+		Firmware: newVersionCountsResponse(report.Firmware),
+		Hardware: newVersionCountsResponse(report.Hardware),
+		Farms:    make([]FarmVersionsResponse, 0, len(report.Farms)),
+	}
+	for _, farm := range report.Farms {
+		farmResponse := FarmVersionsResponse{
+			FarmID:   farm.FarmID,
+			Devices:  farm.Devices,
+			Firmware: newVersionCountsResponse(farm.Firmware),
+		}
+		if report.Progress != nil {
+			upgraded := farm.Upgraded
+			farmResponse.Upgraded = &upgraded
+		}
+		response.Farms = append(response.Farms, farmResponse)
+	}
+	if report.Progress != nil {
+		response.Progress = &UpgradeProgressResponse{
+			TargetVersion: report.Progress.TargetVersion,
+			Upgraded:      report.Progress.Upgraded,
+			Pending:       report.Progress.Pending,
+			Unknown:       report.Progress.Unknown,
+			Percent:       report.Progress.Percent(),
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newVersionCountsResponse(versions []entities.VersionCount) []VersionCountResponse {
+	response := make([]VersionCountResponse, 0, len(versions))
+	for _, version := range versions {
+		response = append(response, VersionCountResponse{
+			Version: version.Version,
+			Devices: version.Devices,
+			Online:  version.Online,
+		})
+	}
+	return response
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestFleetVersionsHandler(t *testing.T) {
+	t.Run("should render the versions and the upgrade progress", func(t *testing.T) {
+		useCase := mocks.NewMockFleetVersionsUseCase(t)
+		useCase.EXPECT().Report(mock.Anything, "1.5.0").Return(&entities.FleetVersionReport{
+			Devices:  10,
+			Online:   9,
+			Firmware: []entities.VersionCount{{Version: "1.5.0", Devices: 6, Online: 6}, {Version: "1.4.2", Devices: 4, Online: 3}},
+			Hardware: []entities.VersionCount{{Version: "rev-b", Devices: 10, Online: 9}},
+			Farms:    []entities.FarmVersions{{FarmID: "farm-1", Devices: 10, Upgraded: 6, Firmware: []entities.VersionCount{{Version: "1.5.0", Devices: 6, Online: 6}}}},
+			Progress: &entities.UpgradeProgress{TargetVersion: "1.5.0", Upgraded: 6, Pending: 4},
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewFleetVersionsHandler(useCase).Report(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devices/versions?target=1.5.0", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response FleetVersionsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Len(t, response.Firmware, 2)
+		require.NotNil(t, response.Progress)
+		assert.Equal(t, 60.0, response.Progress.Percent)
+		require.NotNil(t, response.Farms[0].Upgraded)
+		assert.Equal(t, 6, *response.Farms[0].Upgraded)
+	})
+
+	t.Run("should leave out the progress without a target", func(t *testing.T) {
+		useCase := mocks.NewMockFleetVersionsUseCase(t)
+		useCase.EXPECT().Report(mock.Anything, "").Return(&entities.FleetVersionReport{}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewFleetVersionsHandler(useCase).Report(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devices/versions", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"devices":0,"online":0,"firmware":[],"hardware":[],"farms":[]}`, rec.Body.String())
+	})
+
+	t.Run("should reject an invalid target version", func(t *testing.T) {
+		useCase := mocks.NewMockFleetVersionsUseCase(t)
+		useCase.EXPECT().Report(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: too long", domainerrors.ErrInvalidTargetVersion)).Once()
+
+		rec := httptest.NewRecorder()
+		NewFleetVersionsHandler(useCase).Report(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devices/versions?target=x", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should report failures", func(t *testing.T) {
+		useCase := mocks.NewMockFleetVersionsUseCase(t)
+		useCase.EXPECT().Report(mock.Anything, "").Return(nil, errors.New("connection refused")).Once()
+
+		rec := httptest.NewRecorder()
+		NewFleetVersionsHandler(useCase).Report(rec, httptest.NewRequest(http.MethodGet, "/api/v1/devices/versions", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/handover"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+)
+
+// HandoverSummaryResponse counts the entries of each section of a handover report
+type HandoverSummaryResponse struct {
+	OpenAlerts      int `json:"open_alerts"`
+	OfflineDevices  int `json:"offline_devices"`
+	DegradedSensors int `json:"degraded_sensors"`
+	Overrides       int `json:"overrides"`
+	ScheduledJobs   int `json:"scheduled_jobs"`
+}
+
+// OfflineDeviceResponse is the JSON representation of an offline device
+type OfflineDeviceResponse struct {
+	MACAddress          string    `json:"mac_address"`
+	DeviceName          string    `json:"device_name"`
+	LocationDescription string    `json:"location_description"`
+	LastSeen            time.Time `json:"last_seen"`
+}
+
+// OperatorOverrideResponse is the JSON representation of an operator override in effect
+type OperatorOverrideResponse struct {
+	Kind        string     `json:"kind"`
+	Description string     `json:"description"`
+	Since       *time.Time `json:"since,omitempty"`
+}
+
+// HandoverResponse is the JSON representation of a shift handover report
+type HandoverResponse struct {
+	GeneratedAt     time.Time                    `json:"generated_at"`
+	Summary         HandoverSummaryResponse      `json:"summary"`
+	OpenAlerts      []AlertResponse              `json:"open_alerts"`
+	OfflineDevices  []OfflineDeviceResponse      `json:"offline_devices"`
+	DegradedSensors []SensorStreamHealthResponse `json:"degraded_sensors"`
+	Overrides       []OperatorOverrideResponse   `json:"overrides"`
+	ScheduledJobs   []JobResponse                `json:"scheduled_jobs"`
+}
+
+// HandoverHandler serves the shift handover report
+type HandoverHandler struct {
+	handoverUseCase handover.HandoverUseCase
+}
+
+func NewHandoverHandler(handoverUseCase handover.HandoverUseCase) *HandoverHandler {
+	return &HandoverHandler{handoverUseCase: handoverUseCase}
+}
+
+// Report handles GET /api/v1/handover
+func (h *HandoverHandler) Report(w http.ResponseWriter, r *http.Request) {
+	report, err := h.handoverUseCase.Report(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to generate handover report", http.StatusInternalServerError)
+		return
+	}
+
+	response := HandoverResponse{
+		GeneratedAt: report.GeneratedAt,
+		Summary: HandoverSummaryResponse{
+			OpenAlerts:      len(report.OpenAlerts),
+			OfflineDevices:  len(report.OfflineDevices),
+			DegradedSensors: len(report.DegradedSensors),
+			Overrides:       len(report.Overrides),
+			ScheduledJobs:   len(report.ScheduledJobs),
+		},
+		OpenAlerts:      newAlertsResponse(report.OpenAlerts, i18n.LanguageFromContext(r.Context())).Alerts,
+		OfflineDevices:  make([]OfflineDeviceResponse, 0, len(report.OfflineDevices)),
+		DegradedSensors: newSensorStreamsResponse(report.DegradedSensors).Streams,
+		Overrides:       make([]OperatorOverrideResponse, 0, len(report.Overrides)),
+		ScheduledJobs:   make([]JobResponse, 0, len(report.ScheduledJobs)),
+	}
+	for _, device := range report.OfflineDevices {
+		response.OfflineDevices = append(response.OfflineDevices, OfflineDeviceResponse{
+			MACAddress:          device.MACAddress,
+			DeviceName:          device.DeviceName,
+			LocationDescription: device.LocationDescription,
+			LastSeen:            device.LastSeen,
+		})
+	}
+	for _, override := range report.Overrides {
+		response.Overrides = append(response.Overrides, OperatorOverrideResponse{
+			Kind:        override.Kind,
+			Description: override.Description,
+			Since:       override.Since,
+		})
+	}
+	for _, job := range report.ScheduledJobs {
+		response.ScheduledJobs = append(response.ScheduledJobs, NewJobResponse(job))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
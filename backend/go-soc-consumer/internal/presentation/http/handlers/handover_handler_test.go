@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestHandoverHandler(t *testing.T) {
+	t.Run("should render every section with its count", func(t *testing.T) {
+		generatedAt := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+		device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "probe", "192.168.1.10", "greenhouse")
+		require.NoError(t, err)
+		useCase := mocks.NewMockHandoverUseCase(t)
+		useCase.EXPECT().Report(mock.Anything).Return(&entities.HandoverReport{
+			GeneratedAt:    generatedAt,
+			OfflineDevices: []*entities.Device{device},
+			Overrides:      []*entities.OperatorOverride{{Kind: entities.OverrideKindConsumptionPaused, Description: "MQTT consumption paused on 3 topics", Since: &generatedAt}},
+			ScheduledJobs:  []*entities.Job{{ID: "job-1", Type: "reprocess", Status: entities.JobStatusQueued}},
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewHandoverHandler(useCase).Report(rec, httptest.NewRequest(http.MethodGet, "/api/v1/handover", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response HandoverResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, HandoverSummaryResponse{OfflineDevices: 1, Overrides: 1, ScheduledJobs: 1}, response.Summary)
+		assert.NotNil(t, response.OpenAlerts, "empty sections are rendered as empty lists")
+		assert.Equal(t, "greenhouse", response.OfflineDevices[0].LocationDescription)
+		assert.Equal(t, entities.OverrideKindConsumptionPaused, response.Overrides[0].Kind)
+		assert.Equal(t, "job-1", response.ScheduledJobs[0].ID)
+	})
+
+	t.Run("should report failures", func(t *testing.T) {
+		useCase := mocks.NewMockHandoverUseCase(t)
+		useCase.EXPECT().Report(mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		rec := httptest.NewRecorder()
+		NewHandoverHandler(useCase).Report(rec, httptest.NewRequest(http.MethodGet, "/api/v1/handover", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	irrigationefficiency "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/irrigation_efficiency"
+)
+
+// IrrigationEventResponse is the JSON representation of one irrigation of a zone
+type IrrigationEventResponse struct {
+	MACAddress          string    `json:"mac_address"`
+	StartedAt           time.Time `json:"started_at"`
+	EndedAt             time.Time `json:"ended_at"`
+	DurationSeconds     float64   `json:"duration_seconds"`
+	Liters              float64   `json:"liters"`
+	MoistureBefore      *float64  `json:"moisture_before,omitempty"`
+	MoistureAfter       *float64  `json:"moisture_after,omitempty"`
+	MoistureGain        *float64  `json:"moisture_gain,omitempty"`
+	GainPerLiter        *float64  `json:"gain_per_liter,omitempty"`
+	TimeToTargetSeconds *float64  `json:"time_to_target_seconds,omitempty"`
+}
+
+// IrrigationZoneEfficiencyResponse is the JSON representation of the efficiency of a zone; events
+// are only listed for a single zone
+type IrrigationZoneEfficiencyResponse struct {
+	Zone                       string                    `json:"zone"`
+	From                       time.Time                 `json:"from"`
+	To                         time.Time                 `json:"to"`
+	Irrigations                int                       `json:"irrigations"`
+	TotalLiters                float64                   `json:"total_liters"`
+	AverageMoistureGain        *float64                  `json:"average_moisture_gain,omitempty"`
+	AverageGainPerLiter        *float64                  `json:"average_gain_per_liter,omitempty"`
+	AverageTimeToTargetSeconds *float64                  `json:"average_time_to_target_seconds,omitempty"`
+	TargetReached              int                       `json:"target_reached"`
+	GainPerLiterChange         *float64                  `json:"gain_per_liter_change,omitempty"`
+	Declining                  bool                      `json:"declining"`
+	Events                     []IrrigationEventResponse `json:"events,omitempty"`
+}
+
+// IrrigationZonesEfficiencyResponse lists the efficiency of every zone
+type IrrigationZonesEfficiencyResponse struct {
+	Zones []IrrigationZoneEfficiencyResponse `json:"zones"`
+}
+
+// IrrigationAnalyticsHandler serves the irrigation efficiency of the zones
+type IrrigationAnalyticsHandler struct {
+	efficiencyUseCase irrigationefficiency.IrrigationEfficiencyUseCase
+}
+
+func NewIrrigationAnalyticsHandler(efficiencyUseCase irrigationefficiency.IrrigationEfficiencyUseCase) *IrrigationAnalyticsHandler {
+	return &IrrigationAnalyticsHandler{efficiencyUseCase: efficiencyUseCase}
+}
+
+// ListZones handles GET /api/v1/analytics/irrigation/zones?from=RFC3339&to=RFC3339
+func (h *IrrigationAnalyticsHandler) ListZones(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := parseAnalyticsRange(w, r)
+	if !ok {
+		return
+	}
+
+	efficiencies, err := h.efficiencyUseCase.Zones(r.Context(), from, to)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	response := IrrigationZonesEfficiencyResponse{Zones: make([]IrrigationZoneEfficiencyResponse, 0, len(efficiencies))}
+	for _, efficiency := range efficiencies {
+		response.Zones = append(response.Zones, newIrrigationZoneEfficiencyResponse(efficiency, false))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetZone handles GET /api/v1/analytics/irrigation/zones/{zone}?from=RFC3339&to=RFC3339, listing
+// the irrigations of the zone
+func (h *IrrigationAnalyticsHandler) GetZone(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := parseAnalyticsRange(w, r)
+	if !ok {
+		return
+	}
+
+	efficiency, err := h.efficiencyUseCase.Zone(r.Context(), r.PathValue("zone"), from, to)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newIrrigationZoneEfficiencyResponse(efficiency, true))
+}
+
+func (h *IrrigationAnalyticsHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domainerrors.ErrIrrigationZoneNotFound):
+		writeDomainError(w, r, err, http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrInvalidAnalyticsQuery):
+		writeDomainError(w, r, err, http.StatusBadRequest)
+	default:
+		writeError(w, r, "failed to compute irrigation efficiency", http.StatusInternalServerError)
+	}
+}
+
+// parseAnalyticsRange reads the optional from and to query parameters, replying on invalid ones
+func parseAnalyticsRange(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, bool) {
+	var from, to time.Time
+	var err error
+	query := r.URL.Query()
+	if value := query.Get("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			writeError(w, r, "invalid from time", http.StatusBadRequest)
+			return from, to, false
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			writeError(w, r, "invalid to time", http.StatusBadRequest)
+			return from, to, false
+		}
+	}
+	return from, to, true
+}
+
+func newIrrigationZoneEfficiencyResponse(efficiency *entities.IrrigationZoneEfficiency, withEvents bool) IrrigationZoneEfficiencyResponse {
+	response := IrrigationZoneEfficiencyResponse{
+		Zone:                       efficiency.Zone,
+		From:                       efficiency.From,
+		To:                         efficiency.To,
+		Irrigations:                len(efficiency.Events),
+		TotalLiters:                efficiency.TotalLiters,
+		AverageMoistureGain:        efficiency.AverageGain,
+		AverageGainPerLiter:        efficiency.AverageGainPerLiter,
+		AverageTimeToTargetSeconds: durationSeconds(efficiency.AverageTimeToTarget),
+		TargetReached:              efficiency.TargetReached,
+		GainPerLiterChange:         efficiency.GainPerLiterChange,
+		Declining:                  efficiency.Declining,
+	}
+	if !withEvents {
+		return response
+	}
+
+	response.Events = make([]IrrigationEventResponse, 0, len(efficiency.Events))
+	for _, event := range efficiency.Events {
+		eventResponse := IrrigationEventResponse{
+			MACAddress:          event.MACAddress,
+			StartedAt:           event.StartedAt,
+			EndedAt:             event.EndedAt,
+			DurationSeconds:     event.Duration().Seconds(),
+			Liters:              event.Liters,
+			MoistureBefore:      event.MoistureBefore,
+			MoistureAfter:       event.MoistureAfter,
+			TimeToTargetSeconds: durationSeconds(event.TimeToTarget),
+		}
+		if gain, ok := event.MoistureGain(); ok {
+			eventResponse.MoistureGain = &gain
+		}
+		if gainPerLiter, ok := event.GainPerLiter(); ok {
+			eventResponse.GainPerLiter = &gainPerLiter
+		}
+		response.Events = append(response.Events, eventResponse)
+	}
+	return response
+}
+
+// durationSeconds converts an optional duration to seconds
+func durationSeconds(duration *time.Duration) *float64 {
+	if duration == nil {
+		return nil
+	}
+	seconds := duration.Seconds()
+	return &seconds
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestIrrigationAnalyticsHandler_GetZone(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	before, after := 24.0, 36.0
+	reached := 45 * time.Minute
+	event := &entities.IrrigationEvent{
+		MACAddress: "AA:BB:CC:DD:EE:01", StartedAt: from.Add(6 * time.Hour), EndedAt: from.Add(6*time.Hour + 30*time.Minute),
+		Liters: 300, MoistureBefore: &before, MoistureAfter: &after, TimeToTarget: &reached,
+	}
+
+	t.Run("should return the efficiency and irrigations of the zone", func(t *testing.T) {
+		useCase := mocks.NewMockIrrigationEfficiencyUseCase(t)
+		useCase.EXPECT().Zone(mock.Anything, "zone_a", from, to).
+			Return(entities.NewIrrigationZoneEfficiency("zone_a", from, to, []*entities.IrrigationEvent{event}, 0.3), nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/irrigation/zones/zone_a?from=2025-06-01T00:00:00Z&to=2025-06-08T00:00:00Z", nil)
+		req.SetPathValue("zone", "zone_a")
+		rec := httptest.NewRecorder()
+		NewIrrigationAnalyticsHandler(useCase).GetZone(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"total_liters":300`)
+		assert.Contains(t, rec.Body.String(), `"average_gain_per_liter":0.04`)
+		assert.Contains(t, rec.Body.String(), `"average_time_to_target_seconds":2700`)
+		assert.Contains(t, rec.Body.String(), `"moisture_gain":12`)
+		assert.Contains(t, rec.Body.String(), `"duration_seconds":1800`)
+	})
+
+	t.Run("should map errors to status codes", func(t *testing.T) {
+		tests := []struct {
+			err    error
+			status int
+		}{
+			{err: domainerrors.ErrIrrigationZoneNotFound, status: http.StatusNotFound},
+			{err: domainerrors.ErrInvalidAnalyticsQuery, status: http.StatusBadRequest},
+			{err: fmt.Errorf("connection refused"), status: http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockIrrigationEfficiencyUseCase(t)
+			useCase.EXPECT().Zone(mock.Anything, "zone_a", time.Time{}, time.Time{}).Return(nil, tt.err).Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/irrigation/zones/zone_a", nil)
+			req.SetPathValue("zone", "zone_a")
+			rec := httptest.NewRecorder()
+			NewIrrigationAnalyticsHandler(useCase).GetZone(rec, req)
+			assert.Equal(t, tt.status, rec.Code, tt.err.Error())
+		}
+	})
+}
+
+func TestIrrigationAnalyticsHandler_ListZones(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	t.Run("should list the zones without their irrigations", func(t *testing.T) {
+		useCase := mocks.NewMockIrrigationEfficiencyUseCase(t)
+		event := &entities.IrrigationEvent{MACAddress: "AA:BB:CC:DD:EE:01", StartedAt: from, EndedAt: from.Add(time.Minute)}
+		useCase.EXPECT().Zones(mock.Anything, from, time.Time{}).
+			Return([]*entities.IrrigationZoneEfficiency{entities.NewIrrigationZoneEfficiency("zone_a", from, to, []*entities.IrrigationEvent{event}, 0.3)}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/irrigation/zones?from=2025-06-01T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+		NewIrrigationAnalyticsHandler(useCase).ListZones(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"zone":"zone_a"`)
+		assert.Contains(t, rec.Body.String(), `"irrigations":1`)
+		assert.NotContains(t, rec.Body.String(), `"events"`)
+	})
+
+	t.Run("should reject invalid times", func(t *testing.T) {
+		for _, rawQuery := range []string{"from=yesterday", "to=2025-06-01"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/irrigation/zones?"+rawQuery, nil)
+			rec := httptest.NewRecorder()
+			NewIrrigationAnalyticsHandler(mocks.NewMockIrrigationEfficiencyUseCase(t)).ListZones(rec, req)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rawQuery)
+		}
+	})
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	irrigationexperiments "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/irrigation_experiments"
+)
+
+// ExperimentVariantRequest is a variant of the experiment creation endpoint
+type ExperimentVariantRequest struct {
+	Name     string   `json:"name"`
+	Strategy string   `json:"strategy"` // rule_based, et_based or ml
+	Zones    []string `json:"zones"`    // irrigation zone names
+}
+
+// IrrigationExperimentRequest is the body of the experiment creation endpoint; the first variant is
+// the baseline
+type IrrigationExperimentRequest struct {
+	Name       string                     `json:"name"`
+	Hypothesis string                     `json:"hypothesis,omitempty"`
+	Metadata   map[string]string          `json:"metadata,omitempty"`
+	Variants   []ExperimentVariantRequest `json:"variants"`
+	StartAt    *time.Time                 `json:"start_at,omitempty"` // defaults to now
+}
+
+// ExperimentVariantResponse is the JSON representation of a variant of an experiment
+type ExperimentVariantResponse struct {
+	Name     string   `json:"name"`
+	Strategy string   `json:"strategy"`
+	Zones    []string `json:"zones"`
+}
+
+// IrrigationExperimentResponse is the JSON representation of an irrigation experiment
+type IrrigationExperimentResponse struct {
+	ID         string                      `json:"id"`
+	Name       string                      `json:"name"`
+	Hypothesis string                      `json:"hypothesis,omitempty"`
+	Metadata   map[string]string           `json:"metadata"`
+	Variants   []ExperimentVariantResponse `json:"variants"`
+	StartedAt  time.Time                   `json:"started_at"`
+	EndedAt    *time.Time                  `json:"ended_at,omitempty"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	UpdatedAt  time.Time                   `json:"updated_at"`
+}
+
+// IrrigationExperimentsResponse lists the experiments, most recently started first
+type IrrigationExperimentsResponse struct {
+	Experiments []IrrigationExperimentResponse `json:"experiments"`
+}
+
+// ExperimentVariantOutcomeResponse is the JSON representation of the outcome of a variant
+type ExperimentVariantOutcomeResponse struct {
+	Variant                    string   `json:"variant"`
+	Strategy                   string   `json:"strategy"`
+	Zones                      []string `json:"zones"`
+	Irrigations                int      `json:"irrigations"`
+	TotalLiters                float64  `json:"total_liters"`
+	LitersPerZone              float64  `json:"liters_per_zone"`
+	AverageMoistureGain        *float64 `json:"average_moisture_gain,omitempty"`
+	AverageGainPerLiter        *float64 `json:"average_gain_per_liter,omitempty"`
+	AverageTimeToTargetSeconds *float64 `json:"average_time_to_target_seconds,omitempty"`
+	TargetReachedRate          *float64 `json:"target_reached_rate,omitempty"`
+	GainPerLiterVsBaseline     *float64 `json:"gain_per_liter_vs_baseline,omitempty"`
+	LitersPerZoneVsBaseline    *float64 `json:"liters_per_zone_vs_baseline,omitempty"`
+}
+
+// IrrigationExperimentReportResponse compares the outcomes of the variants of an experiment
+type IrrigationExperimentReportResponse struct {
+	Experiment IrrigationExperimentResponse       `json:"experiment"`
+	From       time.Time                          `json:"from"`
+	To         time.Time                          `json:"to"`
+	Outcomes   []ExperimentVariantOutcomeResponse `json:"outcomes"`
+	Leader     string                             `json:"leader,omitempty"`
+}
+
+// IrrigationExperimentsHandler serves the A/B experiments comparing irrigation strategies
+type IrrigationExperimentsHandler struct {
+	experimentsUseCase irrigationexperiments.IrrigationExperimentsUseCase
+	token              string
+}
+
+func NewIrrigationExperimentsHandler(experimentsUseCase irrigationexperiments.IrrigationExperimentsUseCase, token string) *IrrigationExperimentsHandler {
+	return &IrrigationExperimentsHandler{
+		experimentsUseCase: experimentsUseCase,
+		token:              token,
+	}
+}
+
+// List handles GET /api/v1/analytics/irrigation/experiments
+func (h *IrrigationExperimentsHandler) List(w http.ResponseWriter, r *http.Request) {
+	experiments, err := h.experimentsUseCase.List(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to list experiments", http.StatusInternalServerError)
+		return
+	}
+
+	response := IrrigationExperimentsResponse{Experiments: make([]IrrigationExperimentResponse, 0, len(experiments))}
+	for _, experiment := range experiments {
+		response.Experiments = append(response.Experiments, newIrrigationExperimentResponse(experiment))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Get handles GET /api/v1/analytics/irrigation/experiments/{id}
+func (h *IrrigationExperimentsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	experiment, err := h.experimentsUseCase.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeExperimentError(w, r, err, "failed to load experiment")
+		return
+	}
+	writeJSON(w, http.StatusOK, newIrrigationExperimentResponse(experiment))
+}
+
+// Report handles GET /api/v1/analytics/irrigation/experiments/{id}/report?from=RFC3339&to=RFC3339
+func (h *IrrigationExperimentsHandler) Report(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := parseAnalyticsRange(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.experimentsUseCase.Report(r.Context(), r.PathValue("id"), from, to)
+	if err != nil {
+		h.writeExperimentError(w, r, err, "failed to load experiment report")
+		return
+	}
+
+	response := IrrigationExperimentReportResponse{
+		Experiment: newIrrigationExperimentResponse(report.Experiment),
+		From:       report.From,
+		To:         report.To,
+		Outcomes:   make([]ExperimentVariantOutcomeResponse, 0, len(report.Outcomes)),
+		Leader:     report.Leader,
+	}
+	for _, outcome := range report.Outcomes {
+		response.Outcomes = append(response.Outcomes, ExperimentVariantOutcomeResponse{
+			Variant:                    outcome.Variant,
+			Strategy:                   outcome.Strategy,
+			Zones:                      outcome.Zones,
+			Irrigations:                outcome.Irrigations,
+			TotalLiters:                outcome.TotalLiters,
+			LitersPerZone:              outcome.LitersPerZone,
+			AverageMoistureGain:        outcome.AverageGain,
+			AverageGainPerLiter:        outcome.AverageGainPerLiter,
+			AverageTimeToTargetSeconds: durationSeconds(outcome.AverageTimeToTarget),
+			TargetReachedRate:          outcome.TargetReachedRate,
+			GainPerLiterVsBaseline:     outcome.GainPerLiterVsBaseline,
+			LitersPerZoneVsBaseline:    outcome.LitersPerZoneVsBaseline,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Create handles POST /admin/irrigation/experiments
+func (h *IrrigationExperimentsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request IrrigationExperimentRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 65536)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	experimentRequest := entities.IrrigationExperimentRequest{
+		Name:       request.Name,
+		Hypothesis: request.Hypothesis,
+		Metadata:   request.Metadata,
+		Variants:   make([]entities.ExperimentVariant, 0, len(request.Variants)),
+	}
+	if request.StartAt != nil {
+		experimentRequest.StartAt = *request.StartAt
+	}
+	for _, variant := range request.Variants {
+		experimentRequest.Variants = append(experimentRequest.Variants, entities.ExperimentVariant(variant))
+	}
+
+	experiment, err := h.experimentsUseCase.Create(r.Context(), experimentRequest)
+	if err != nil {
+		h.writeExperimentError(w, r, err, "failed to save experiment")
+		return
+	}
+	writeJSON(w, http.StatusCreated, newIrrigationExperimentResponse(experiment))
+}
+
+// End handles POST /admin/irrigation/experiments/{id}/end
+func (h *IrrigationExperimentsHandler) End(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	experiment, err := h.experimentsUseCase.End(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeExperimentError(w, r, err, "failed to save experiment")
+		return
+	}
+	writeJSON(w, http.StatusOK, newIrrigationExperimentResponse(experiment))
+}
+
+// writeExperimentError maps the failures of the experiment endpoints to their status
+func (h *IrrigationExperimentsHandler) writeExperimentError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrInvalidIrrigationExperiment),
+		errors.Is(err, domainerrors.ErrInvalidAnalyticsQuery):
+		writeDomainError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, domainerrors.ErrIrrigationExperimentNotFound):
+		writeDomainError(w, r, err, http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrIrrigationExperimentConflict),
+		errors.Is(err, domainerrors.ErrIrrigationExperimentEnded):
+		writeDomainError(w, r, err, http.StatusConflict)
+	default:
+		writeError(w, r, message, http.StatusInternalServerError)
+	}
+}
+
+func newIrrigationExperimentResponse(experiment *entities.IrrigationExperiment) IrrigationExperimentResponse {
+	response := IrrigationExperimentResponse{
+		ID:         experiment.ID,
+		Name:       experiment.Name,
+		Hypothesis: experiment.Hypothesis,
+		Metadata:   experiment.Metadata,
+		Variants:   make([]ExperimentVariantResponse, 0, len(experiment.Variants)),
+		StartedAt:  experiment.StartedAt,
+		EndedAt:    experiment.EndedAt,
+		CreatedAt:  experiment.CreatedAt,
+		UpdatedAt:  experiment.UpdatedAt,
+	}
+	if response.Metadata == nil {
+		response.Metadata = map[string]string{}
+	}
+	for _, variant := range experiment.Variants {
+		response.Variants = append(response.Variants, ExperimentVariantResponse(variant))
+	}
+	return response
+}
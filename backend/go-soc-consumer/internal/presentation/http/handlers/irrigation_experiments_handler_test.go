@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newExperimentRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.SetPathValue("id", "experiment-1")
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func testExperiment() *entities.IrrigationExperiment {
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	return &entities.IrrigationExperiment{
+		ID:   "experiment-1",
+		Name: "Tomato season",
+		Variants: []entities.ExperimentVariant{
+			{Name: "Control", Strategy: entities.IrrigationStrategyRuleBased, Zones: []string{"north"}},
+			{Name: "ET", Strategy: entities.IrrigationStrategyETBased, Zones: []string{"south"}},
+		},
+		StartedAt: at,
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+}
+
+func TestIrrigationExperimentsHandler_Create(t *testing.T) {
+	body := `{"name":"Tomato season","variants":[{"name":"Control","strategy":"rule_based","zones":["north"]},{"name":"ET","strategy":"et_based","zones":["south"]}]}`
+	request := entities.IrrigationExperimentRequest{
+		Name:     "Tomato season",
+		Variants: testExperiment().Variants,
+	}
+
+	t.Run("should create the experiment", func(t *testing.T) {
+		useCase := mocks.NewMockIrrigationExperimentsUseCase(t)
+		useCase.EXPECT().Create(mock.Anything, request).Return(testExperiment(), nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewIrrigationExperimentsHandler(useCase, "secret").Create(rec, newExperimentRequest(http.MethodPost, "/admin/irrigation/experiments", body))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"id":"experiment-1","name":"Tomato season","metadata":{},
+			"variants":[{"name":"Control","strategy":"rule_based","zones":["north"]},{"name":"ET","strategy":"et_based","zones":["south"]}],
+			"started_at":"2025-06-01T12:00:00Z","created_at":"2025-06-01T12:00:00Z","updated_at":"2025-06-01T12:00:00Z"}`, rec.Body.String())
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: fmt.Errorf("%w: unknown irrigation zone west", domainerrors.ErrInvalidIrrigationExperiment), expected: http.StatusBadRequest},
+			{err: fmt.Errorf("%w: zone north is in experiment Spring", domainerrors.ErrIrrigationExperimentConflict), expected: http.StatusConflict},
+			{err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockIrrigationExperimentsUseCase(t)
+			useCase.EXPECT().Create(mock.Anything, request).Return(nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			NewIrrigationExperimentsHandler(useCase, "secret").Create(rec, newExperimentRequest(http.MethodPost, "/admin/irrigation/experiments", body))
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+
+	t.Run("should reject requests without the admin token", func(t *testing.T) {
+		req := newExperimentRequest(http.MethodPost, "/admin/irrigation/experiments", body)
+		req.Header.Del("Authorization")
+
+		rec := httptest.NewRecorder()
+		NewIrrigationExperimentsHandler(mocks.NewMockIrrigationExperimentsUseCase(t), "secret").Create(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestIrrigationExperimentsHandler_Report(t *testing.T) {
+	from := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	t.Run("should compare the variants", func(t *testing.T) {
+		change := -0.2
+		useCase := mocks.NewMockIrrigationExperimentsUseCase(t)
+		useCase.EXPECT().Report(mock.Anything, "experiment-1", time.Time{}, to).Return(&entities.IrrigationExperimentReport{
+			Experiment: testExperiment(),
+			From:       from,
+			To:         to,
+			Outcomes: []*entities.ExperimentVariantOutcome{
+				{Variant: "Control", Strategy: entities.IrrigationStrategyRuleBased, Zones: []string{"north"}, Irrigations: 2, TotalLiters: 200, LitersPerZone: 200},
+				{Variant: "ET", Strategy: entities.IrrigationStrategyETBased, Zones: []string{"south"}, Irrigations: 2, TotalLiters: 160, LitersPerZone: 160, LitersPerZoneVsBaseline: &change},
+			},
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewIrrigationExperimentsHandler(useCase, "secret").Report(rec, newExperimentRequest(http.MethodGet, "/api/v1/analytics/irrigation/experiments/experiment-1/report?to=2025-06-02T12:00:00Z", ""))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"liters_per_zone_vs_baseline":-0.2`)
+		assert.Contains(t, rec.Body.String(), `"from":"2025-06-01T12:00:00Z"`)
+	})
+
+	t.Run("should return 404 for unknown experiments", func(t *testing.T) {
+		useCase := mocks.NewMockIrrigationExperimentsUseCase(t)
+		useCase.EXPECT().Report(mock.Anything, "experiment-1", time.Time{}, time.Time{}).Return(nil, domainerrors.ErrIrrigationExperimentNotFound).Once()
+
+		rec := httptest.NewRecorder()
+		NewIrrigationExperimentsHandler(useCase, "secret").Report(rec, newExperimentRequest(http.MethodGet, "/api/v1/analytics/irrigation/experiments/experiment-1/report", ""))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestIrrigationExperimentsHandler_End(t *testing.T) {
+	useCase := mocks.NewMockIrrigationExperimentsUseCase(t)
+	useCase.EXPECT().End(mock.Anything, "experiment-1").Return(nil, fmt.Errorf("%w: ended at 2025-06-02T12:00:00Z", domainerrors.ErrIrrigationExperimentEnded)).Once()
+
+	rec := httptest.NewRecorder()
+	NewIrrigationExperimentsHandler(useCase, "secret").End(rec, newExperimentRequest(http.MethodPost, "/admin/irrigation/experiments/experiment-1/end", ""))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
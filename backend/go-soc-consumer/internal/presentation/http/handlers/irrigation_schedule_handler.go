@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	irrigationscheduling "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/irrigation_scheduling"
+)
+
+// IrrigationScheduleRequest is the body of the schedule creation and update endpoints
+type IrrigationScheduleRequest struct {
+	Name            string `json:"name"`
+	Cron            string `json:"cron"` // five-field cron expression, in the scheduler time zone
+	DurationMinutes int    `json:"duration_minutes"`
+	Enabled         *bool  `json:"enabled,omitempty"` // defaults to true
+}
+
+// IrrigationScheduleResponse is the JSON representation of an irrigation schedule
+type IrrigationScheduleResponse struct {
+	ID              string     `json:"id"`
+	MACAddress      string     `json:"mac_address"`
+	Name            string     `json:"name"`
+	Cron            string     `json:"cron"`
+	DurationMinutes int        `json:"duration_minutes"`
+	Enabled         bool       `json:"enabled"`
+	Running         bool       `json:"running"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	RunEndsAt       *time.Time `json:"run_ends_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// IrrigationSchedulesResponse lists irrigation schedules, oldest first
+type IrrigationSchedulesResponse struct {
+	Schedules []IrrigationScheduleResponse `json:"schedules"`
+}
+
+// IrrigationOverrideRequest is the body of the override endpoint
+type IrrigationOverrideRequest struct {
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// IrrigationOverrideResponse is the JSON representation of an irrigation override
+type IrrigationOverrideResponse struct {
+	MACAddress string    `json:"mac_address"`
+	Reason     string    `json:"reason,omitempty"`
+	Until      time.Time `json:"until"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// IrrigationOverridesResponse lists the overrides in effect
+type IrrigationOverridesResponse struct {
+	Overrides []IrrigationOverrideResponse `json:"overrides"`
+}
+
+// IrrigationScheduleHandler serves the irrigation schedules of devices and their manual overrides
+type IrrigationScheduleHandler struct {
+	schedulingUseCase irrigationscheduling.IrrigationSchedulingUseCase
+	token             string
+}
+
+func NewIrrigationScheduleHandler(schedulingUseCase irrigationscheduling.IrrigationSchedulingUseCase, token string) *IrrigationScheduleHandler {
+	return &IrrigationScheduleHandler{
+		schedulingUseCase: schedulingUseCase,
+		token:             token,
+	}
+}
+
+// List handles GET /api/v1/devices/{mac}/schedules
+func (h *IrrigationScheduleHandler) List(w http.ResponseWriter, r *http.Request) {
+	schedules, err := h.schedulingUseCase.List(r.Context(), r.PathValue("mac"))
+	if err != nil {
+		writeError(w, r, "failed to list irrigation schedules", http.StatusInternalServerError)
+		return
+	}
+
+	response := IrrigationSchedulesResponse{Schedules: make([]IrrigationScheduleResponse, 0, len(schedules))}
+	for _, schedule := range schedules {
+		response.Schedules = append(response.Schedules, newIrrigationScheduleResponse(schedule))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Create handles POST /admin/devices/{mac}/schedules
+func (h *IrrigationScheduleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	request, ok := decodeIrrigationScheduleRequest(w, r)
+	if !ok {
+		return
+	}
+	schedule, err := h.schedulingUseCase.Create(r.Context(), r.PathValue("mac"), request)
+	if err != nil {
+		h.writeScheduleError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newIrrigationScheduleResponse(schedule))
+}
+
+// Update handles PUT /admin/schedules/{id}
+func (h *IrrigationScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	request, ok := decodeIrrigationScheduleRequest(w, r)
+	if !ok {
+		return
+	}
+	schedule, err := h.schedulingUseCase.Update(r.Context(), r.PathValue("id"), request)
+	if err != nil {
+		h.writeScheduleError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newIrrigationScheduleResponse(schedule))
+}
+
+// Delete handles DELETE /admin/schedules/{id}
+func (h *IrrigationScheduleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.schedulingUseCase.Delete(r.Context(), r.PathValue("id")); err != nil {
+		h.writeScheduleError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeIrrigationScheduleRequest reads the schedule fields of the request body
+func decodeIrrigationScheduleRequest(w http.ResponseWriter, r *http.Request) (entities.IrrigationScheduleRequest, bool) {
+	var request IrrigationScheduleRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return entities.IrrigationScheduleRequest{}, false
+	}
+
+	enabled := true
+	if request.Enabled != nil {
+		enabled = *request.Enabled
+	}
+	return entities.IrrigationScheduleRequest{
+		Name:     request.Name,
+		Cron:     request.Cron,
+		Duration: time.Duration(request.DurationMinutes) * time.Minute,
+		Enabled:  enabled,
+	}, true
+}
+
+// writeScheduleError maps the failures of the schedule endpoints to their status
+func (h *IrrigationScheduleHandler) writeScheduleError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domainerrors.ErrInvalidIrrigationSchedule):
+		writeDomainError(w, r, err, http.StatusBadRequest)
+	case errors.Is(err, domainerrors.ErrIrrigationScheduleOverlap):
+		writeDomainError(w, r, err, http.StatusConflict)
+	case errors.Is(err, domainerrors.ErrDeviceNotFound):
+		writeError(w, r, "device not found", http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrIrrigationScheduleNotFound):
+		writeError(w, r, "irrigation schedule not found", http.StatusNotFound)
+	default:
+		writeError(w, r, "failed to save irrigation schedule", http.StatusInternalServerError)
+	}
+}
+
+// Overrides handles GET /admin/irrigation/overrides
+func (h *IrrigationScheduleHandler) Overrides(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	overrides, err := h.schedulingUseCase.Overrides(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to list irrigation overrides", http.StatusInternalServerError)
+		return
+	}
+
+	response := IrrigationOverridesResponse{Overrides: make([]IrrigationOverrideResponse, 0, len(overrides))}
+	for _, override := range overrides {
+		response.Overrides = append(response.Overrides, IrrigationOverrideResponse(*override))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// SetOverride handles PUT /admin/devices/{mac}/irrigation/override
+func (h *IrrigationScheduleHandler) SetOverride(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request IrrigationOverrideRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	override, err := h.schedulingUseCase.SetOverride(r.Context(), r.PathValue("mac"), request.Reason, request.Until)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrInvalidIrrigationOverride):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		case errors.Is(err, domainerrors.ErrDeviceNotFound):
+			writeError(w, r, "device not found", http.StatusNotFound)
+		default:
+			writeError(w, r, "failed to set irrigation override", http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, IrrigationOverrideResponse(*override))
+}
+
+// ClearOverride handles DELETE /admin/devices/{mac}/irrigation/override
+func (h *IrrigationScheduleHandler) ClearOverride(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.schedulingUseCase.ClearOverride(r.Context(), r.PathValue("mac")); err != nil {
+		if errors.Is(err, domainerrors.ErrIrrigationOverrideNotFound) {
+			writeError(w, r, "irrigation override not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to clear irrigation override", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newIrrigationScheduleResponse(schedule *entities.IrrigationSchedule) IrrigationScheduleResponse {
+	return IrrigationScheduleResponse{
+		ID:              schedule.ID,
+		MACAddress:      schedule.MACAddress,
+		Name:            schedule.Name,
+		Cron:            schedule.Cron,
+		DurationMinutes: int(schedule.Duration / time.Minute),
+		Enabled:         schedule.Enabled,
+		Running:         schedule.Running(),
+		LastRunAt:       schedule.LastRunAt,
+		RunEndsAt:       schedule.RunEndsAt,
+		CreatedAt:       schedule.CreatedAt,
+		UpdatedAt:       schedule.UpdatedAt,
+	}
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newScheduleRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+	req.SetPathValue("id", "schedule-1")
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func TestIrrigationScheduleHandler_Create(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	request := entities.IrrigationScheduleRequest{Name: "Morning", Cron: "0 6 * * *", Duration: 30 * time.Minute, Enabled: true}
+	body := `{"name":"Morning","cron":"0 6 * * *","duration_minutes":30}`
+
+	t.Run("should create an enabled schedule by default", func(t *testing.T) {
+		useCase := mocks.NewMockIrrigationSchedulingUseCase(t)
+		useCase.EXPECT().Create(mock.Anything, "AA:BB:CC:DD:EE:FF", request).Return(&entities.IrrigationSchedule{
+			ID: "schedule-1", MACAddress: "AA:BB:CC:DD:EE:FF", Name: "Morning", Cron: "0 6 * * *", Duration: 30 * time.Minute,
+			Enabled: true, CreatedAt: createdAt, UpdatedAt: createdAt,
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewIrrigationScheduleHandler(useCase, "secret").Create(rec, newScheduleRequest(http.MethodPost, "/admin/devices/AA:BB:CC:DD:EE:FF/schedules", body))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"id":"schedule-1","mac_address":"AA:BB:CC:DD:EE:FF","name":"Morning","cron":"0 6 * * *","duration_minutes":30,
+			"enabled":true,"running":false,"created_at":"2025-06-01T12:00:00Z","updated_at":"2025-06-01T12:00:00Z"}`, rec.Body.String())
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: fmt.Errorf("%w: duration must be between 1m0s and 12h0m0s", domainerrors.ErrInvalidIrrigationSchedule), expected: http.StatusBadRequest},
+			{err: fmt.Errorf("%w: a run of \"weekend\" starts while \"Morning\" is running", domainerrors.ErrIrrigationScheduleOverlap), expected: http.StatusConflict},
+			{err: domainerrors.ErrDeviceNotFound, expected: http.StatusNotFound},
+			{err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockIrrigationSchedulingUseCase(t)
+			useCase.EXPECT().Create(mock.Anything, "AA:BB:CC:DD:EE:FF", request).Return(nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			NewIrrigationScheduleHandler(useCase, "secret").Create(rec, newScheduleRequest(http.MethodPost, "/admin/devices/AA:BB:CC:DD:EE:FF/schedules", body))
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+
+	t.Run("should reject invalid requests", func(t *testing.T) {
+		handler := NewIrrigationScheduleHandler(mocks.NewMockIrrigationSchedulingUseCase(t), "secret")
+
+		rec := httptest.NewRecorder()
+		handler.Create(rec, newScheduleRequest(http.MethodPost, "/admin/devices/AA:BB:CC:DD:EE:FF/schedules", `{"name":`))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		req := newScheduleRequest(http.MethodPost, "/admin/devices/AA:BB:CC:DD:EE:FF/schedules", body)
+		req.Header.Del("Authorization")
+		rec = httptest.NewRecorder()
+		handler.Create(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestIrrigationScheduleHandler_UpdateAndDelete(t *testing.T) {
+	useCase := mocks.NewMockIrrigationSchedulingUseCase(t)
+	handler := NewIrrigationScheduleHandler(useCase, "secret")
+
+	useCase.EXPECT().Update(mock.Anything, "schedule-1", entities.IrrigationScheduleRequest{Name: "Morning", Cron: "0 7 * * *", Duration: time.Hour}).
+		Return(&entities.IrrigationSchedule{ID: "schedule-1", Name: "Morning", Cron: "0 7 * * *", Duration: time.Hour}, nil).Once()
+	rec := httptest.NewRecorder()
+	handler.Update(rec, newScheduleRequest(http.MethodPut, "/admin/schedules/schedule-1", `{"name":"Morning","cron":"0 7 * * *","duration_minutes":60,"enabled":false}`))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"enabled":false`)
+
+	useCase.EXPECT().Delete(mock.Anything, "schedule-1").Return(domainerrors.ErrIrrigationScheduleNotFound).Once()
+	rec = httptest.NewRecorder()
+	handler.Delete(rec, newScheduleRequest(http.MethodDelete, "/admin/schedules/schedule-1", ""))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestIrrigationScheduleHandler_List(t *testing.T) {
+	runEndsAt := time.Date(2025, 6, 2, 6, 30, 0, 0, time.UTC)
+	useCase := mocks.NewMockIrrigationSchedulingUseCase(t)
+	useCase.EXPECT().List(mock.Anything, "AA:BB:CC:DD:EE:FF").Return([]*entities.IrrigationSchedule{
+		{ID: "schedule-1", Name: "Morning", Cron: "0 6 * * *", Duration: 30 * time.Minute, Enabled: true, RunEndsAt: &runEndsAt},
+	}, nil).Once()
+
+	rec := httptest.NewRecorder()
+	NewIrrigationScheduleHandler(useCase, "secret").List(rec, newScheduleRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF/schedules", ""))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"running":true`)
+	assert.Contains(t, rec.Body.String(), `"run_ends_at":"2025-06-02T06:30:00Z"`)
+}
+
+func TestIrrigationScheduleHandler_Overrides(t *testing.T) {
+	until := time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+	override := &entities.IrrigationOverride{MACAddress: "AA:BB:CC:DD:EE:FF", Reason: "Rain", Until: until, CreatedAt: createdAt}
+
+	t.Run("should set an override", func(t *testing.T) {
+		useCase := mocks.NewMockIrrigationSchedulingUseCase(t)
+		useCase.EXPECT().SetOverride(mock.Anything, "AA:BB:CC:DD:EE:FF", "Rain", until).Return(override, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewIrrigationScheduleHandler(useCase, "secret").SetOverride(rec, newScheduleRequest(http.MethodPut, "/admin/devices/AA:BB:CC:DD:EE:FF/irrigation/override", `{"until":"2025-06-02T18:00:00Z","reason":"Rain"}`))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"mac_address":"AA:BB:CC:DD:EE:FF","reason":"Rain","until":"2025-06-02T18:00:00Z","created_at":"2025-06-02T06:00:00Z"}`, rec.Body.String())
+	})
+
+	t.Run("should reject invalid overrides", func(t *testing.T) {
+		useCase := mocks.NewMockIrrigationSchedulingUseCase(t)
+		useCase.EXPECT().SetOverride(mock.Anything, "AA:BB:CC:DD:EE:FF", "", mock.Anything).
+			Return(nil, fmt.Errorf("%w: override must last until a future time", domainerrors.ErrInvalidIrrigationOverride)).Once()
+
+		rec := httptest.NewRecorder()
+		NewIrrigationScheduleHandler(useCase, "secret").SetOverride(rec, newScheduleRequest(http.MethodPut, "/admin/devices/AA:BB:CC:DD:EE:FF/irrigation/override", `{}`))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should list and clear overrides", func(t *testing.T) {
+		useCase := mocks.NewMockIrrigationSchedulingUseCase(t)
+		handler := NewIrrigationScheduleHandler(useCase, "secret")
+		useCase.EXPECT().Overrides(mock.Anything).Return([]*entities.IrrigationOverride{override}, nil).Once()
+		useCase.EXPECT().ClearOverride(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(nil).Once()
+		useCase.EXPECT().ClearOverride(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(domainerrors.ErrIrrigationOverrideNotFound).Once()
+
+		rec := httptest.NewRecorder()
+		handler.Overrides(rec, newScheduleRequest(http.MethodGet, "/admin/irrigation/overrides", ""))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"reason":"Rain"`)
+
+		rec = httptest.NewRecorder()
+		handler.ClearOverride(rec, newScheduleRequest(http.MethodDelete, "/admin/devices/AA:BB:CC:DD:EE:FF/irrigation/override", ""))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+
+		rec = httptest.NewRecorder()
+		handler.ClearOverride(rec, newScheduleRequest(http.MethodDelete, "/admin/devices/AA:BB:CC:DD:EE:FF/irrigation/override", ""))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
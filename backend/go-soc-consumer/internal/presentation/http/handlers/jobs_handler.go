@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/jobs"
+)
+
+// JobResponse is the JSON representation of a background job
+type JobResponse struct {
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	Progress        int             `json:"progress"`
+	ProgressMessage string          `json:"progress_message,omitempty"`
+	Attempts        int             `json:"attempts"`
+	MaxAttempts     int             `json:"max_attempts"`
+	Result          json.RawMessage `json:"result,omitempty"`
+	Error           string          `json:"error,omitempty"`
+	CancelRequested bool            `json:"cancel_requested"`
+	CreatedAt       time.Time       `json:"created_at"`
+	StartedAt       *time.Time      `json:"started_at,omitempty"`
+	FinishedAt      *time.Time      `json:"finished_at,omitempty"`
+}
+
+// NewJobResponse converts a job to its JSON representation
+func NewJobResponse(job *entities.Job) JobResponse {
+	return JobResponse{
+		ID:              job.ID,
+		Type:            job.Type,
+		Status:          job.Status,
+		Progress:        job.Progress,
+		ProgressMessage: job.ProgressMessage,
+		Attempts:        job.Attempts,
+		MaxAttempts:     job.MaxAttempts,
+		Result:          job.Result,
+		Error:           job.LastError,
+		CancelRequested: job.CancelRequested,
+		CreatedAt:       job.CreatedAt,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+	}
+}
+
+type JobsHandler struct {
+	jobQueueUseCase jobs.JobQueueUseCase
+}
+
+func NewJobsHandler(jobQueueUseCase jobs.JobQueueUseCase) *JobsHandler {
+	return &JobsHandler{
+		jobQueueUseCase: jobQueueUseCase,
+	}
+}
+
+// GetJob handles GET /api/v1/jobs/{id}
+func (h *JobsHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobQueueUseCase.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeJobError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NewJobResponse(job))
+}
+
+// CancelJob handles POST /api/v1/jobs/{id}/cancel
+func (h *JobsHandler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobQueueUseCase.Cancel(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeJobError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, NewJobResponse(job))
+}
+
+// writeJobError maps job errors to HTTP status codes
+func writeJobError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domainerrors.ErrJobNotFound):
+		writeError(w, r, "job not found", http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrJobAlreadyFinished):
+		writeError(w, r, "job already finished", http.StatusConflict)
+	default:
+		writeError(w, r, "failed to read job", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newJobsMux(handler *JobsHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/jobs/{id}", handler.GetJob)
+	mux.HandleFunc("POST /api/v1/jobs/{id}/cancel", handler.CancelJob)
+	return mux
+}
+
+func TestJobsHandler_GetJob(t *testing.T) {
+	job, err := entities.NewJob("export", nil, 3)
+	require.NoError(t, err)
+	job.Progress = 40
+
+	tests := []struct {
+		name           string
+		job            *entities.Job
+		err            error
+		expectedStatus int
+	}{
+		{name: "existing job", job: job, expectedStatus: http.StatusOK},
+		{name: "unknown job", err: domainerrors.ErrJobNotFound, expectedStatus: http.StatusNotFound},
+		{name: "repository failure", err: errors.New("connection refused"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockJobQueueUseCase(t)
+			useCase.EXPECT().Get(mock.Anything, job.ID).Return(tt.job, tt.err).Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/"+job.ID, nil)
+			w := httptest.NewRecorder()
+			newJobsMux(NewJobsHandler(useCase)).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.job != nil {
+				var response JobResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, job.ID, response.ID)
+				assert.Equal(t, entities.JobStatusQueued, response.Status)
+				assert.Equal(t, 40, response.Progress)
+			}
+		})
+	}
+}
+
+func TestJobsHandler_CancelJob(t *testing.T) {
+	t.Run("should accept cancellation", func(t *testing.T) {
+		job, err := entities.NewJob("export", nil, 3)
+		require.NoError(t, err)
+		job.Status = entities.JobStatusRunning
+		job.CancelRequested = true
+
+		useCase := mocks.NewMockJobQueueUseCase(t)
+		useCase.EXPECT().Cancel(mock.Anything, job.ID).Return(job, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs/"+job.ID+"/cancel", nil)
+		w := httptest.NewRecorder()
+		newJobsMux(NewJobsHandler(useCase)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), `"cancel_requested":true`)
+	})
+
+	t.Run("should reject finished jobs", func(t *testing.T) {
+		useCase := mocks.NewMockJobQueueUseCase(t)
+		useCase.EXPECT().Cancel(mock.Anything, "job-1").Return(nil, domainerrors.ErrJobAlreadyFinished).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs/job-1/cancel", nil)
+		w := httptest.NewRecorder()
+		newJobsMux(NewJobsHandler(useCase)).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	messagetracing "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/message_tracing"
+)
+
+// TraceSessionRequest is the body of the trace session creation endpoint
+type TraceSessionRequest struct {
+	Topic           string  `json:"topic,omitempty"`       // MQTT topic filter
+	MACAddress      string  `json:"mac_address,omitempty"` // device to trace
+	DurationSeconds int     `json:"duration_seconds"`
+	SampleRate      float64 `json:"sample_rate,omitempty"`  // defaults to 1, every matching message
+	MaxMessages     int     `json:"max_messages,omitempty"` // defaults to 100
+}
+
+// TraceSessionResponse is the JSON representation of a trace session
+type TraceSessionResponse struct {
+	ID          string     `json:"id"`
+	Topic       string     `json:"topic,omitempty"`
+	MACAddress  string     `json:"mac_address,omitempty"`
+	SampleRate  float64    `json:"sample_rate"`
+	MaxMessages int        `json:"max_messages"`
+	Matched     int        `json:"matched"`
+	Captured    int        `json:"captured"`
+	Active      bool       `json:"active"`
+	StartedAt   time.Time  `json:"started_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	StoppedAt   *time.Time `json:"stopped_at,omitempty"`
+}
+
+// TraceSessionsResponse lists the trace sessions kept, oldest first
+type TraceSessionsResponse struct {
+	Sessions []TraceSessionResponse `json:"sessions"`
+}
+
+// TraceStepResponse is a pipeline stage reached by a traced message
+type TraceStepResponse struct {
+	Stage     string  `json:"stage"`
+	ElapsedMS float64 `json:"elapsed_ms"`
+}
+
+// MessageTraceResponse is the JSON representation of a traced message
+type MessageTraceResponse struct {
+	ID               string              `json:"id"`
+	Topic            string              `json:"topic"`
+	MACAddress       string              `json:"mac_address,omitempty"`
+	Payload          string              `json:"payload"`
+	PayloadTruncated bool                `json:"payload_truncated,omitempty"`
+	ReceivedAt       time.Time           `json:"received_at"`
+	Steps            []TraceStepResponse `json:"steps"`
+	Outcome          string              `json:"outcome"`
+	Stage            string              `json:"stage,omitempty"`
+	Reason           string              `json:"reason,omitempty"`
+	DurationMS       float64             `json:"duration_ms"`
+}
+
+// MessageTracesResponse is a trace session with the messages it captured, oldest first
+type MessageTracesResponse struct {
+	Session  TraceSessionResponse   `json:"session"`
+	Messages []MessageTraceResponse `json:"messages"`
+}
+
+// MessageTraceHandler serves the trace sessions capturing inbound messages for debugging
+type MessageTraceHandler struct {
+	tracingUseCase messagetracing.MessageTracingUseCase
+	token          string
+	now            func() time.Time
+}
+
+func NewMessageTraceHandler(tracingUseCase messagetracing.MessageTracingUseCase, token string) *MessageTraceHandler {
+	return &MessageTraceHandler{
+		tracingUseCase: tracingUseCase,
+		token:          token,
+		now:            time.Now,
+	}
+}
+
+// Start handles POST /admin/traces
+func (h *MessageTraceHandler) Start(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request TraceSessionRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	session, err := h.tracingUseCase.StartSession(r.Context(), entities.TraceSessionRequest{
+		Topic:       request.Topic,
+		MACAddress:  request.MACAddress,
+		Duration:    time.Duration(request.DurationSeconds) * time.Second,
+		SampleRate:  request.SampleRate,
+		MaxMessages: request.MaxMessages,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrInvalidTraceSession):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		case errors.Is(err, domainerrors.ErrTraceSessionLimitReached):
+			writeDomainError(w, r, err, http.StatusConflict)
+		default:
+			writeError(w, r, "failed to start trace session", http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, h.newTraceSessionResponse(session))
+}
+
+// List handles GET /admin/traces
+func (h *MessageTraceHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sessions := h.tracingUseCase.Sessions(r.Context())
+	response := TraceSessionsResponse{Sessions: make([]TraceSessionResponse, 0, len(sessions))}
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, h.newTraceSessionResponse(session))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Get handles GET /admin/traces/{id}
+func (h *MessageTraceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	session, traces, err := h.tracingUseCase.Traces(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeSessionError(w, r, err)
+		return
+	}
+
+	response := MessageTracesResponse{
+		Session:  h.newTraceSessionResponse(session),
+		Messages: make([]MessageTraceResponse, 0, len(traces)),
+	}
+	for _, trace := range traces {
+		response.Messages = append(response.Messages, newMessageTraceResponse(trace))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Stop handles POST /admin/traces/{id}/stop
+func (h *MessageTraceHandler) Stop(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	session, err := h.tracingUseCase.StopSession(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeSessionError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.newTraceSessionResponse(session))
+}
+
+// Delete handles DELETE /admin/traces/{id}
+func (h *MessageTraceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.tracingUseCase.DeleteSession(r.Context(), r.PathValue("id")); err != nil {
+		h.writeSessionError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeSessionError maps the failures of the endpoints of an existing session to their status
+func (h *MessageTraceHandler) writeSessionError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, domainerrors.ErrTraceSessionNotFound) {
+		writeError(w, r, "trace session not found", http.StatusNotFound)
+		return
+	}
+	writeError(w, r, "failed to load trace session", http.StatusInternalServerError)
+}
+
+func (h *MessageTraceHandler) newTraceSessionResponse(session *entities.TraceSession) TraceSessionResponse {
+	return TraceSessionResponse{
+		ID:          session.ID,
+		Topic:       session.Topic,
+		MACAddress:  session.MACAddress,
+		SampleRate:  session.SampleRate,
+		MaxMessages: session.MaxMessages,
+		Matched:     session.Matched,
+		Captured:    session.Captured,
+		Active:      session.Active(h.now()),
+		StartedAt:   session.StartedAt,
+		ExpiresAt:   session.ExpiresAt,
+		StoppedAt:   session.StoppedAt,
+	}
+}
+
+func newMessageTraceResponse(trace *entities.MessageTrace) MessageTraceResponse {
+	steps := make([]TraceStepResponse, 0, len(trace.Steps))
+	for _, step := range trace.Steps {
+		steps = append(steps, TraceStepResponse{Stage: step.Stage, ElapsedMS: float64(step.Elapsed) / float64(time.Millisecond)})
+	}
+	return MessageTraceResponse{
+		ID:               trace.ID,
+		Topic:            trace.Topic,
+		MACAddress:       trace.MACAddress,
+		Payload:          string(trace.Payload),
+		PayloadTruncated: trace.PayloadTruncated,
+		ReceivedAt:       trace.ReceivedAt,
+		Steps:            steps,
+		Outcome:          trace.Outcome,
+		Stage:            trace.Stage,
+		Reason:           trace.Reason,
+		DurationMS:       float64(trace.Duration) / float64(time.Millisecond),
+	}
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newTraceRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.SetPathValue("id", "session-1")
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func newTestMessageTraceHandler(t *testing.T) (*MessageTraceHandler, *mocks.MockMessageTracingUseCase) {
+	useCase := mocks.NewMockMessageTracingUseCase(t)
+	handler := NewMessageTraceHandler(useCase, "secret")
+	handler.now = func() time.Time { return time.Date(2025, 6, 1, 12, 5, 0, 0, time.UTC) }
+	return handler, useCase
+}
+
+func TestMessageTraceHandler_Start(t *testing.T) {
+	startedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	request := entities.TraceSessionRequest{MACAddress: "AA:BB:CC:DD:EE:FF", Duration: 10 * time.Minute, SampleRate: 0.5}
+	body := `{"mac_address":"AA:BB:CC:DD:EE:FF","duration_seconds":600,"sample_rate":0.5}`
+
+	t.Run("should start a session", func(t *testing.T) {
+		handler, useCase := newTestMessageTraceHandler(t)
+		useCase.EXPECT().StartSession(mock.Anything, request).Return(&entities.TraceSession{
+			ID: "session-1", MACAddress: "AA:BB:CC:DD:EE:FF", SampleRate: 0.5, MaxMessages: 100,
+			StartedAt: startedAt, ExpiresAt: startedAt.Add(10 * time.Minute),
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		handler.Start(rec, newTraceRequest(http.MethodPost, "/admin/traces", body))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"id":"session-1","mac_address":"AA:BB:CC:DD:EE:FF","sample_rate":0.5,"max_messages":100,"matched":0,"captured":0,
+			"active":true,"started_at":"2025-06-01T12:00:00Z","expires_at":"2025-06-01T12:10:00Z"}`, rec.Body.String())
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: fmt.Errorf("%w: duration must be positive and at most 1h0m0s", domainerrors.ErrInvalidTraceSession), expected: http.StatusBadRequest},
+			{err: fmt.Errorf("%w: 5 sessions are active", domainerrors.ErrTraceSessionLimitReached), expected: http.StatusConflict},
+		}
+		for _, tt := range tests {
+			handler, useCase := newTestMessageTraceHandler(t)
+			useCase.EXPECT().StartSession(mock.Anything, request).Return(nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			handler.Start(rec, newTraceRequest(http.MethodPost, "/admin/traces", body))
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+
+	t.Run("should reject unauthorized requests", func(t *testing.T) {
+		handler, _ := newTestMessageTraceHandler(t)
+		req := newTraceRequest(http.MethodPost, "/admin/traces", body)
+		req.Header.Del("Authorization")
+
+		rec := httptest.NewRecorder()
+		handler.Start(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestMessageTraceHandler_Get(t *testing.T) {
+	receivedAt := time.Date(2025, 6, 1, 12, 1, 0, 0, time.UTC)
+
+	t.Run("should return the captured messages", func(t *testing.T) {
+		handler, useCase := newTestMessageTraceHandler(t)
+		useCase.EXPECT().Traces(mock.Anything, "session-1").Return(
+			&entities.TraceSession{ID: "session-1", Topic: "farms/+/devices/#", ExpiresAt: receivedAt, Captured: 1, Matched: 1},
+			[]*entities.MessageTrace{{
+				ID: "trace-1", Topic: "farms/farm-1/devices/sensors/measurements", MACAddress: "AA:BB:CC:DD:EE:FF",
+				Payload: []byte(`{"mac_address":"AA:BB:CC:DD:EE:FF"}`), ReceivedAt: receivedAt,
+				Steps:   []entities.TraceStep{{Stage: "rate_limit", Elapsed: 1500 * time.Microsecond}},
+				Outcome: entities.TraceOutcomeDropped, Stage: "rate_limit", Reason: "rate limited", Duration: 2 * time.Millisecond,
+			}}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		handler.Get(rec, newTraceRequest(http.MethodGet, "/admin/traces/session-1", ""))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"active":false`)
+		assert.Contains(t, rec.Body.String(), `"payload":"{\"mac_address\":\"AA:BB:CC:DD:EE:FF\"}"`)
+		assert.Contains(t, rec.Body.String(), `"steps":[{"stage":"rate_limit","elapsed_ms":1.5}]`)
+		assert.Contains(t, rec.Body.String(), `"outcome":"dropped","stage":"rate_limit","reason":"rate limited","duration_ms":2`)
+	})
+
+	t.Run("should return 404 for an unknown session", func(t *testing.T) {
+		handler, useCase := newTestMessageTraceHandler(t)
+		useCase.EXPECT().Traces(mock.Anything, "session-1").Return(nil, nil, domainerrors.ErrTraceSessionNotFound).Once()
+
+		rec := httptest.NewRecorder()
+		handler.Get(rec, newTraceRequest(http.MethodGet, "/admin/traces/session-1", ""))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestMessageTraceHandler_StopAndDelete(t *testing.T) {
+	handler, useCase := newTestMessageTraceHandler(t)
+	stoppedAt := time.Date(2025, 6, 1, 12, 4, 0, 0, time.UTC)
+	useCase.EXPECT().Sessions(mock.Anything).Return([]*entities.TraceSession{{ID: "session-1", Topic: "#"}}).Once()
+	useCase.EXPECT().StopSession(mock.Anything, "session-1").Return(&entities.TraceSession{ID: "session-1", Topic: "#", StoppedAt: &stoppedAt}, nil).Once()
+	useCase.EXPECT().DeleteSession(mock.Anything, "session-1").Return(nil).Once()
+
+	rec := httptest.NewRecorder()
+	handler.List(rec, newTraceRequest(http.MethodGet, "/admin/traces", ""))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"id":"session-1"`)
+
+	rec = httptest.NewRecorder()
+	handler.Stop(rec, newTraceRequest(http.MethodPost, "/admin/traces/session-1/stop", ""))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"stopped_at":"2025-06-01T12:04:00Z"`)
+
+	rec = httptest.NewRecorder()
+	handler.Delete(rec, newTraceRequest(http.MethodDelete, "/admin/traces/session-1", ""))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	moistureprediction "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/moisture_prediction"
+)
+
+// MoistureForecastPointResponse is the JSON representation of one forecast moisture
+type MoistureForecastPointResponse struct {
+	At       time.Time `json:"at"`
+	Moisture float64   `json:"moisture"`
+}
+
+// MoisturePredictionResponse is the JSON representation of the latest prediction of a zone
+type MoisturePredictionResponse struct {
+	Zone                string                          `json:"zone"`
+	Model               string                          `json:"model"`
+	MACAddress          string                          `json:"mac_address,omitempty"`
+	PredictedAt         time.Time                       `json:"predicted_at"`
+	Moisture            float64                         `json:"moisture"`
+	DryingRate          float64                         `json:"drying_rate"`
+	HoursUntilThreshold *float64                        `json:"hours_until_threshold,omitempty"`
+	ThresholdAt         *time.Time                      `json:"threshold_at,omitempty"`
+	Forecast            []MoistureForecastPointResponse `json:"forecast"`
+}
+
+// MoisturePredictionsResponse lists the latest prediction of every predicted zone
+type MoisturePredictionsResponse struct {
+	Predictions []MoisturePredictionResponse `json:"predictions"`
+}
+
+// MoisturePredictionHandler serves the moisture predictions of the zones
+type MoisturePredictionHandler struct {
+	predictionUseCase moistureprediction.MoisturePredictionUseCase
+}
+
+func NewMoisturePredictionHandler(predictionUseCase moistureprediction.MoisturePredictionUseCase) *MoisturePredictionHandler {
+	return &MoisturePredictionHandler{predictionUseCase: predictionUseCase}
+}
+
+// ListPredictions handles GET /api/v1/analytics/irrigation/predictions
+func (h *MoisturePredictionHandler) ListPredictions(w http.ResponseWriter, r *http.Request) {
+	predictions := h.predictionUseCase.Predictions()
+	response := MoisturePredictionsResponse{Predictions: make([]MoisturePredictionResponse, 0, len(predictions))}
+	for _, prediction := range predictions {
+		response.Predictions = append(response.Predictions, newMoisturePredictionResponse(prediction))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newMoisturePredictionResponse(prediction *entities.MoisturePrediction) MoisturePredictionResponse {
+	response := MoisturePredictionResponse{
+		Zone:                prediction.Zone,
+		Model:               prediction.Model,
+		MACAddress:          prediction.MACAddress,
+		PredictedAt:         prediction.PredictedAt,
+		Moisture:            prediction.Moisture,
+		DryingRate:          prediction.DryingRate,
+		HoursUntilThreshold: prediction.HoursUntilThreshold,
+		Forecast:            make([]MoistureForecastPointResponse, 0, len(prediction.Forecast)),
+	}
+	if thresholdAt, ok := prediction.ThresholdAt(); ok {
+		response.ThresholdAt = &thresholdAt
+	}
+	for _, point := range prediction.Forecast {
+		response.Forecast = append(response.Forecast, MoistureForecastPointResponse{At: point.At, Moisture: point.Moisture})
+	}
+	return response
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestMoisturePredictionHandler_ListPredictions(t *testing.T) {
+	predictedAt := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	hours := 3.0
+	useCase := mocks.NewMockMoisturePredictionUseCase(t)
+	useCase.EXPECT().Predictions().Return([]*entities.MoisturePrediction{{
+		Zone: "north", Model: "baseline", MACAddress: "AA:BB:CC:DD:EE:01", PredictedAt: predictedAt,
+		Moisture: 31, DryingRate: 2, HoursUntilThreshold: &hours,
+		Forecast: []entities.MoistureForecastPoint{{At: predictedAt, Moisture: 31}},
+	}}).Once()
+
+	rec := httptest.NewRecorder()
+	NewMoisturePredictionHandler(useCase).ListPredictions(rec, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/irrigation/predictions", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"zone":"north"`)
+	assert.Contains(t, rec.Body.String(), `"hours_until_threshold":3`)
+	assert.Contains(t, rec.Body.String(), `"threshold_at":"2025-06-02T15:00:00Z"`)
+	assert.Contains(t, rec.Body.String(), `"forecast":[{"at":"2025-06-02T12:00:00Z","moisture":31}]`)
+}
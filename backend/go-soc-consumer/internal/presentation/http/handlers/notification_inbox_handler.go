@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/middleware"
+	notificationinbox "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/notification_inbox"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// InboxNotificationResponse is the JSON representation of a notification in the inbox of a user
+type InboxNotificationResponse struct {
+	ID        string     `json:"id"`
+	AlertID   string     `json:"alert_id"`
+	Rule      string     `json:"rule"`
+	Severity  string     `json:"severity"`
+	Status    string     `json:"status"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	Read      bool       `json:"read"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+}
+
+// InboxNotificationsResponse lists notifications of the inbox of a user, newest first
+type InboxNotificationsResponse struct {
+	Notifications []InboxNotificationResponse `json:"notifications"`
+}
+
+// UnreadCountResponse is the number of unread notifications of a user, in total and by severity
+type UnreadCountResponse struct {
+	Total      int            `json:"total"`
+	BySeverity map[string]int `json:"by_severity"`
+}
+
+// MarkAllReadResponse is the number of notifications marked as read
+type MarkAllReadResponse struct {
+	Marked int `json:"marked"`
+}
+
+// NotificationInboxHandler serves the in-app notification inbox of each user
+type NotificationInboxHandler struct {
+	inboxUseCase notificationinbox.NotificationInboxUseCase
+	pagination   pagination.Policy
+}
+
+func NewNotificationInboxHandler(inboxUseCase notificationinbox.NotificationInboxUseCase, policy pagination.Policy) *NotificationInboxHandler {
+	return &NotificationInboxHandler{
+		inboxUseCase: inboxUseCase,
+		pagination:   policy,
+	}
+}
+
+// List handles GET /api/v1/users/{user}/notifications?unread=true&before=RFC3339&limit=N, newest
+// first. Pass the created_at of the last notification as before to get the next page.
+func (h *NotificationInboxHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInboxUser(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	limit, err := h.pagination.ParseRequestLimit(query.Get("limit"))
+	if err != nil {
+		writeError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var before time.Time
+	if value := query.Get("before"); value != "" {
+		if before, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			writeError(w, r, "invalid before cursor", http.StatusBadRequest)
+			return
+		}
+	}
+
+	notifications, err := h.inboxUseCase.List(r.Context(), r.PathValue("user"), query.Get("unread") == "true", before, limit)
+	if err != nil {
+		h.writeInboxError(w, r, err, "failed to list notifications")
+		return
+	}
+
+	response := InboxNotificationsResponse{Notifications: make([]InboxNotificationResponse, 0, len(notifications))}
+	for _, notification := range notifications {
+		response.Notifications = append(response.Notifications, newInboxNotificationResponse(notification))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// UnreadCount handles GET /api/v1/users/{user}/notifications/unread-count
+func (h *NotificationInboxHandler) UnreadCount(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInboxUser(w, r) {
+		return
+	}
+	counts, err := h.inboxUseCase.UnreadCounts(r.Context(), r.PathValue("user"))
+	if err != nil {
+		h.writeInboxError(w, r, err, "failed to count notifications")
+		return
+	}
+
+	response := UnreadCountResponse{BySeverity: counts}
+	for _, count := range counts {
+		response.Total += count
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// MarkRead handles POST /api/v1/users/{user}/notifications/{id}/read
+func (h *NotificationInboxHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInboxUser(w, r) {
+		return
+	}
+	if err := h.inboxUseCase.MarkRead(r.Context(), r.PathValue("user"), r.PathValue("id")); err != nil {
+		h.writeInboxError(w, r, err, "failed to mark notifications read")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkAllRead handles POST /api/v1/users/{user}/notifications/read-all
+func (h *NotificationInboxHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	if !authorizeInboxUser(w, r) {
+		return
+	}
+	marked, err := h.inboxUseCase.MarkAllRead(r.Context(), r.PathValue("user"))
+	if err != nil {
+		h.writeInboxError(w, r, err, "failed to mark notifications read")
+		return
+	}
+	writeJSON(w, http.StatusOK, MarkAllReadResponse{Marked: marked})
+}
+
+// authorizeInboxUser restricts an inbox to its own user and to administrators. Without user
+// authentication no claims are stored and every inbox stays reachable.
+func authorizeInboxUser(w http.ResponseWriter, r *http.Request) bool {
+	claims := middleware.ClaimsFromContext(r.Context())
+	if claims == nil || claims.IsAdmin() || claims.Username == r.PathValue("user") {
+		return true
+	}
+	writeError(w, r, "forbidden", http.StatusForbidden)
+	return false
+}
+
+// writeInboxError maps unknown users and notifications to 404 and anything else to the failure message
+func (h *NotificationInboxHandler) writeInboxError(w http.ResponseWriter, r *http.Request, err error, failure string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrNotificationUserNotFound):
+		writeError(w, r, "notification user not found", http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrInboxNotificationNotFound):
+		writeError(w, r, "notification not found", http.StatusNotFound)
+	default:
+		writeError(w, r, failure, http.StatusInternalServerError)
+	}
+}
+
+func newInboxNotificationResponse(notification *entities.InboxNotification) InboxNotificationResponse {
+	return InboxNotificationResponse{
+		ID:        notification.ID,
+		AlertID:   notification.AlertID,
+		Rule:      notification.Rule,
+		Severity:  notification.Severity,
+		Status:    notification.Status,
+		Subject:   notification.Subject,
+		Body:      notification.Body,
+		Read:      notification.Read(),
+		CreatedAt: notification.CreatedAt,
+		ReadAt:    notification.ReadAt,
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/middleware"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func TestNotificationInboxHandler(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	notification := &entities.InboxNotification{
+		ID:        "notification-1",
+		User:      "ana",
+		AlertID:   "alert-1",
+		Rule:      "dry_soil",
+		Severity:  entities.AlertSeverityWarning,
+		Status:    entities.AlertStatusFiring,
+		Subject:   "[warning] alert dry_soil is firing",
+		Body:      "dry",
+		CreatedAt: createdAt,
+	}
+	newRequest := func(method, target string) *http.Request {
+		req := httptest.NewRequest(method, target, nil)
+		req.SetPathValue("user", "ana")
+		return req
+	}
+
+	t.Run("should list unread notifications before the cursor", func(t *testing.T) {
+		useCase := mocks.NewMockNotificationInboxUseCase(t)
+		useCase.EXPECT().List(mock.Anything, "ana", true, createdAt, 5).Return([]*entities.InboxNotification{notification}, nil).Once()
+		handler := NewNotificationInboxHandler(useCase, pagination.DefaultPolicy())
+
+		rec := httptest.NewRecorder()
+		handler.List(rec, newRequest(http.MethodGet, "/api/v1/users/ana/notifications?unread=true&limit=5&before=2025-06-01T12:00:00Z"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response InboxNotificationsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Notifications, 1)
+		assert.Equal(t, "alert-1", response.Notifications[0].AlertID)
+		assert.False(t, response.Notifications[0].Read)
+	})
+
+	t.Run("should reject an invalid cursor", func(t *testing.T) {
+		handler := NewNotificationInboxHandler(mocks.NewMockNotificationInboxUseCase(t), pagination.DefaultPolicy())
+
+		rec := httptest.NewRecorder()
+		handler.List(rec, newRequest(http.MethodGet, "/api/v1/users/ana/notifications?before=yesterday"))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should return not found for unknown users", func(t *testing.T) {
+		useCase := mocks.NewMockNotificationInboxUseCase(t)
+		useCase.EXPECT().UnreadCounts(mock.Anything, "ana").Return(nil, domainerrors.ErrNotificationUserNotFound).Once()
+		handler := NewNotificationInboxHandler(useCase, pagination.DefaultPolicy())
+
+		rec := httptest.NewRecorder()
+		handler.UnreadCount(rec, newRequest(http.MethodGet, "/api/v1/users/ana/notifications/unread-count"))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("should total the unread counts", func(t *testing.T) {
+		useCase := mocks.NewMockNotificationInboxUseCase(t)
+		useCase.EXPECT().UnreadCounts(mock.Anything, "ana").Return(map[string]int{entities.AlertSeverityCritical: 1, entities.AlertSeverityInfo: 3}, nil).Once()
+		handler := NewNotificationInboxHandler(useCase, pagination.DefaultPolicy())
+
+		rec := httptest.NewRecorder()
+		handler.UnreadCount(rec, newRequest(http.MethodGet, "/api/v1/users/ana/notifications/unread-count"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response UnreadCountResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, 4, response.Total)
+		assert.Equal(t, 1, response.BySeverity[entities.AlertSeverityCritical])
+	})
+
+	t.Run("should mark a notification read", func(t *testing.T) {
+		useCase := mocks.NewMockNotificationInboxUseCase(t)
+		useCase.EXPECT().MarkRead(mock.Anything, "ana", "notification-1").Return(nil).Once()
+		useCase.EXPECT().MarkRead(mock.Anything, "ana", "missing").Return(domainerrors.ErrInboxNotificationNotFound).Once()
+		handler := NewNotificationInboxHandler(useCase, pagination.DefaultPolicy())
+
+		req := newRequest(http.MethodPost, "/api/v1/users/ana/notifications/notification-1/read")
+		req.SetPathValue("id", "notification-1")
+		rec := httptest.NewRecorder()
+		handler.MarkRead(rec, req)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+
+		req = newRequest(http.MethodPost, "/api/v1/users/ana/notifications/missing/read")
+		req.SetPathValue("id", "missing")
+		rec = httptest.NewRecorder()
+		handler.MarkRead(rec, req)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("should mark every notification read", func(t *testing.T) {
+		useCase := mocks.NewMockNotificationInboxUseCase(t)
+		useCase.EXPECT().MarkAllRead(mock.Anything, "ana").Return(2, nil).Once()
+		handler := NewNotificationInboxHandler(useCase, pagination.DefaultPolicy())
+
+		rec := httptest.NewRecorder()
+		handler.MarkAllRead(rec, newRequest(http.MethodPost, "/api/v1/users/ana/notifications/read-all"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"marked":2}`, rec.Body.String())
+	})
+
+	t.Run("should keep other users out of an inbox", func(t *testing.T) {
+		useCase := mocks.NewMockNotificationInboxUseCase(t)
+		useCase.EXPECT().UnreadCounts(mock.Anything, "ana").Return(map[string]int{}, nil).Twice()
+		handler := NewNotificationInboxHandler(useCase, pagination.DefaultPolicy())
+		withClaims := func(req *http.Request, username, role string) *http.Request {
+			return req.WithContext(middleware.ContextWithClaims(req.Context(), &entities.AuthClaims{Subject: username, Username: username, Role: role}))
+		}
+
+		rec := httptest.NewRecorder()
+		handler.List(rec, withClaims(newRequest(http.MethodGet, "/api/v1/users/ana/notifications"), "luis", entities.UserRoleViewer))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+
+		rec = httptest.NewRecorder()
+		handler.MarkAllRead(rec, withClaims(newRequest(http.MethodPost, "/api/v1/users/ana/notifications/read-all"), "luis", entities.UserRoleViewer))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+
+		rec = httptest.NewRecorder()
+		handler.UnreadCount(rec, withClaims(newRequest(http.MethodGet, "/api/v1/users/ana/notifications/unread-count"), "ana", entities.UserRoleViewer))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		rec = httptest.NewRecorder()
+		handler.UnreadCount(rec, withClaims(newRequest(http.MethodGet, "/api/v1/users/ana/notifications/unread-count"), "root", entities.UserRoleAdmin))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,676 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+)
+
+// apiParameter is a query parameter of an operation; path parameters are taken from the path
+type apiParameter struct {
+	name        string
+	description string
+	schema      map[string]any
+	repeated    bool
+}
+
+// apiResponse is a response of an operation. Without a body, a success has no content and an
+// error carries a plain text message.
+type apiResponse struct {
+	status      int
+	description string
+	body        any
+}
+
+// apiOperation documents an operation of the public API
+type apiOperation struct {
+	method       string
+	path         string
+	tag          string
+	summary      string
+	availability string // when the operation is only served with some configuration
+	security     string // bearer token scheme, empty for public operations
+	parameters   []apiParameter
+	request      any
+	responses    []apiResponse
+}
+
+// Bearer token schemes of the API
+const (
+	accessTokenScheme   = "accessToken"
+	adminTokenScheme    = "adminToken"
+	brokerTokenScheme   = "brokerToken"
+	researchTokenScheme = "researchToken"
+	syncTokenScheme     = "syncToken"
+)
+
+var pathParameterPattern = regexp.MustCompile(`\{([a-z]+)\}`)
+
+var pathParameterDescriptions = map[string]string{
+	"mac":  "MAC address of the device",
+	"id":   "Identifier of the resource",
+	"user": "Name of the notification user",
+	"zone": "Name of the irrigation zone",
+	"farm": "Identifier of the farm",
+}
+
+var (
+	limitParameter  = apiParameter{name: "limit", description: "Maximum number of items, bounded by the server pagination policy", schema: map[string]any{"type": "integer", "minimum": 1}}
+	offsetParameter = apiParameter{name: "offset", description: "Number of items to skip", schema: map[string]any{"type": "integer", "minimum": 0}}
+
+	analyticsRangeParameters = []apiParameter{
+		{name: "from", description: "Start of the range, defaulting to 30 days before to", schema: map[string]any{"type": "string", "format": "date-time"}},
+		{name: "to", description: "End of the range, excluded, defaulting to now", schema: map[string]any{"type": "string", "format": "date-time"}},
+	}
+)
+
+// apiOperations lists the operations under /api/v1; admin endpoints are documented in the README
+var apiOperations = []apiOperation{
+	{
+		method: http.MethodGet, path: "/api/v1/jobs/{id}", tag: "jobs",
+		summary:   "Get a background job",
+		responses: []apiResponse{{http.StatusOK, "The job", JobResponse{}}, {http.StatusNotFound, "Job not found", nil}},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/jobs/{id}/cancel", tag: "jobs",
+		summary: "Request the cancellation of a background job",
+		responses: []apiResponse{
+			{http.StatusAccepted, "Cancellation requested", JobResponse{}},
+			{http.StatusNotFound, "Job not found", nil},
+			{http.StatusConflict, "Job already finished", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices", tag: "devices",
+		summary: "List devices",
+		parameters: []apiParameter{
+			{name: "status", description: "Only devices with this status: registered, online or offline", schema: map[string]any{"type": "string"}},
+			{name: "zone", description: "Only devices of this field or zone", schema: map[string]any{"type": "string"}},
+			offsetParameter, limitParameter,
+		},
+		responses: []apiResponse{
+			{http.StatusOK, "A page of devices", DevicesResponse{}},
+			{http.StatusBadRequest, "Invalid query", nil},
+			{http.StatusNotFound, "Zone not found", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices/{mac}", tag: "devices",
+		summary:   "Get a device",
+		responses: []apiResponse{{http.StatusOK, "The device", DeviceRecordResponse{}}, {http.StatusNotFound, "Device not found", nil}},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/devices", tag: "devices",
+		summary: "Create a device", availability: "Served when ADMIN_TOKEN is set", security: adminTokenScheme,
+		request: DeviceRequest{},
+		responses: []apiResponse{
+			{http.StatusCreated, "The created device", DeviceRecordResponse{}},
+			{http.StatusBadRequest, "Invalid device", nil},
+			{http.StatusUnauthorized, "Missing or wrong token", nil},
+			{http.StatusConflict, "Device already exists", nil},
+		},
+	},
+	{
+		method: http.MethodPut, path: "/api/v1/devices/{mac}", tag: "devices",
+		summary: "Update a device", availability: "Served when ADMIN_TOKEN is set", security: adminTokenScheme,
+		request: DeviceRequest{},
+		responses: []apiResponse{
+			{http.StatusOK, "The updated device", DeviceRecordResponse{}},
+			{http.StatusBadRequest, "Invalid device", nil},
+			{http.StatusUnauthorized, "Missing or wrong token", nil},
+			{http.StatusNotFound, "Device not found", nil},
+		},
+	},
+	{
+		method: http.MethodDelete, path: "/api/v1/devices/{mac}", tag: "devices",
+		summary: "Delete a device", availability: "Served when ADMIN_TOKEN is set", security: adminTokenScheme,
+		responses: []apiResponse{
+			{http.StatusNoContent, "Device deleted", nil},
+			{http.StatusUnauthorized, "Missing or wrong token", nil},
+			{http.StatusNotFound, "Device not found", nil},
+		},
+	},
+	{
+		method: http.MethodPut, path: "/api/v1/config/declare", tag: "devices",
+		summary: "Converge the devices to a declared configuration", availability: "Served when ADMIN_TOKEN is set", security: adminTokenScheme,
+		parameters: []apiParameter{{name: "dry_run", description: "Only plan the changes", schema: map[string]any{"type": "boolean"}}},
+		request:    DeclarationDocument{},
+		responses: []apiResponse{
+			{http.StatusOK, "The planned or applied changes", DeclarationPlanResponse{}},
+			{http.StatusBadRequest, "Invalid declaration", nil},
+			{http.StatusUnauthorized, "Missing or wrong token", nil},
+		},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/devices/status-query", tag: "devices",
+		summary: "Get the status of several devices",
+		request: DeviceStatusQueryRequest{},
+		responses: []apiResponse{
+			{http.StatusOK, "The status of every requested device", DeviceStatusQueryResponse{}},
+			{http.StatusBadRequest, "Invalid query", nil},
+		},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/devices/{mac}/health-check", tag: "devices",
+		summary: "Probe a device on the network",
+		responses: []apiResponse{
+			{http.StatusOK, "The probe result", DeviceHealthCheckResponse{}},
+			{http.StatusNotFound, "Device not found", nil},
+			{http.StatusServiceUnavailable, "Probe failed", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices/changes", tag: "devices",
+		summary: "Long poll the device status changes after a cursor",
+		parameters: []apiParameter{
+			{name: "since", description: "Cursor of the last change seen; without it the current cursor is returned right away", schema: map[string]any{"type": "integer", "minimum": 0}},
+			{name: "timeout", description: "How long to wait for a change, as a Go duration such as 30s", schema: map[string]any{"type": "string"}},
+		},
+		responses: []apiResponse{
+			{http.StatusOK, "The changes after the cursor and the cursor to resume from", DeviceChangesResponse{}},
+			{http.StatusBadRequest, "Invalid cursor or timeout", nil},
+			{http.StatusGone, "The cursor is too old; reload the devices and resume from the returned cursor", DeviceChangesResponse{}},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices/versions", tag: "devices",
+		summary:    "Report the firmware and hardware versions of the fleet",
+		parameters: []apiParameter{{name: "target", description: "Firmware version to report the upgrade progress to", schema: map[string]any{"type": "string"}}},
+		responses:  []apiResponse{{http.StatusOK, "The fleet versions", FleetVersionsResponse{}}, {http.StatusBadRequest, "Invalid target version", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices/status/ws", tag: "devices",
+		summary:      "Stream the device registration, online and offline events over WebSocket",
+		availability: "Served when the NATS subscriber is available",
+		responses: []apiResponse{
+			{http.StatusSwitchingProtocols, "Upgraded; every event is pushed as a JSON text message with type (registered, online or offline), mac_address, farm_id, ip_address, last_seen, occurred_at and event_id", nil},
+			{http.StatusUnauthorized, "Missing or invalid access token, which may be passed as the subprotocol after bearer or as access_token", nil},
+			{http.StatusForbidden, "The origin is not allowed", nil},
+			{http.StatusServiceUnavailable, "Too many clients are connected", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices/{mac}/channels", tag: "sensors",
+		summary:   "List the sensor channels of a device",
+		responses: []apiResponse{{http.StatusOK, "The channels", SensorChannelsResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices/{mac}/readings", tag: "sensors",
+		summary: "List the temperature and humidity readings of a device, newest first",
+		parameters: []apiParameter{
+			{name: "from", description: "Start of the range, defaulting to a day before to", schema: map[string]any{"type": "string", "format": "date-time"}},
+			{name: "to", description: "End of the range, excluded, defaulting to now", schema: map[string]any{"type": "string", "format": "date-time"}},
+			offsetParameter, limitParameter,
+		},
+		responses: []apiResponse{{http.StatusOK, "A page of readings", SensorReadingsResponse{}}, {http.StatusBadRequest, "Invalid query", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices/readings/latest", tag: "sensors",
+		summary:    "Get the latest temperature and humidity reading of devices",
+		parameters: []apiParameter{{name: "mac", description: "MAC addresses of the devices, every device when omitted", schema: map[string]any{"type": "string"}, repeated: true}},
+		responses:  []apiResponse{{http.StatusOK, "The latest reading of each device", LatestSensorReadingsResponse{}}, {http.StatusBadRequest, "Invalid MAC address", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices/{mac}/valve", tag: "irrigation",
+		summary:   "Get the valve state of a device",
+		responses: []apiResponse{{http.StatusOK, "The valve state", ValveStateResponse{}}, {http.StatusNotFound, "Device not found", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices/{mac}/valve/history", tag: "irrigation",
+		summary:    "List the valve transitions of a device, newest first",
+		parameters: []apiParameter{limitParameter},
+		responses:  []apiResponse{{http.StatusOK, "The transitions", ValveHistoryResponse{}}, {http.StatusBadRequest, "Invalid limit", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/devices/{mac}/schedules", tag: "irrigation",
+		summary: "List the irrigation schedules of a device", availability: "Served when the irrigation scheduler is enabled",
+		responses: []apiResponse{
+			{http.StatusOK, "The schedules", IrrigationSchedulesResponse{}},
+			{http.StatusBadRequest, "Invalid MAC address", nil},
+			{http.StatusNotFound, "Device not found", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/analytics/irrigation/zones", tag: "irrigation",
+		summary:    "Summarize the irrigation efficiency of every zone",
+		parameters: analyticsRangeParameters,
+		responses:  []apiResponse{{http.StatusOK, "The efficiency of each zone", IrrigationZonesEfficiencyResponse{}}, {http.StatusBadRequest, "Invalid range", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/analytics/irrigation/zones/{zone}", tag: "irrigation",
+		summary:    "Get the irrigation efficiency of a zone with its irrigations",
+		parameters: analyticsRangeParameters,
+		responses: []apiResponse{
+			{http.StatusOK, "The efficiency and irrigations of the zone", IrrigationZoneEfficiencyResponse{}},
+			{http.StatusBadRequest, "Invalid range", nil},
+			{http.StatusNotFound, "Zone not found", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/analytics/irrigation/predictions", tag: "irrigation",
+		summary: "List the soil moisture predicted for every zone", availability: "Served when moisture prediction is enabled",
+		responses: []apiResponse{{http.StatusOK, "The latest prediction of each predicted zone", MoisturePredictionsResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/analytics/irrigation/experiments", tag: "irrigation",
+		summary:   "List the irrigation strategy experiments",
+		responses: []apiResponse{{http.StatusOK, "The experiments, most recently started first", IrrigationExperimentsResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/analytics/irrigation/experiments/{id}", tag: "irrigation",
+		summary:   "Get an irrigation strategy experiment",
+		responses: []apiResponse{{http.StatusOK, "The experiment", IrrigationExperimentResponse{}}, {http.StatusNotFound, "Experiment not found", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/analytics/irrigation/experiments/{id}/report", tag: "irrigation",
+		summary:    "Compare the outcomes of the variants of an experiment",
+		parameters: analyticsRangeParameters,
+		responses: []apiResponse{
+			{http.StatusOK, "The outcome of each variant, baseline first", IrrigationExperimentReportResponse{}},
+			{http.StatusBadRequest, "Invalid range", nil},
+			{http.StatusNotFound, "Experiment not found", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/farms", tag: "farms",
+		summary:   "List the farms",
+		responses: []apiResponse{{http.StatusOK, "The farms, sorted by name", FarmsResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/farms/{farm}", tag: "farms",
+		summary:   "Get a farm with its fields and zones",
+		responses: []apiResponse{{http.StatusOK, "The farm", FarmDetailResponse{}}, {http.StatusNotFound, "Farm not found", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/zones/{id}", tag: "farms",
+		summary:   "Get a field or zone with its devices",
+		responses: []apiResponse{{http.StatusOK, "The field or zone", ZoneDetailResponse{}}, {http.StatusNotFound, "Zone not found", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/zones/{id}/measurements", tag: "farms",
+		summary: "Aggregate the latest measurements of a field or zone by sensor type",
+		parameters: []apiParameter{
+			{name: "since", description: "Oldest measurement taken, RFC 3339; defaults to one hour ago", schema: map[string]any{"type": "string", "format": "date-time"}},
+		},
+		responses: []apiResponse{
+			{http.StatusOK, "The measurements by sensor type", ZoneMeasurementsResponse{}},
+			{http.StatusBadRequest, "Invalid since time", nil},
+			{http.StatusNotFound, "Zone not found", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/alerts/active", tag: "alerts",
+		summary: "List the active alerts", availability: "Served when alerting is enabled",
+		responses: []apiResponse{{http.StatusOK, "The active alerts", AlertsResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/alerts/recent", tag: "alerts",
+		summary: "List the recent alerts, newest first", availability: "Served when alerting is enabled",
+		parameters: []apiParameter{limitParameter},
+		responses:  []apiResponse{{http.StatusOK, "The recent alerts", AlertsResponse{}}, {http.StatusBadRequest, "Invalid limit", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/handover", tag: "operations",
+		summary:   "Report what the next operator shift needs to know",
+		responses: []apiResponse{{http.StatusOK, "The handover report", HandoverResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/firmware/crash-report", tag: "operations",
+		summary:    "Report the firmware crashes by version",
+		parameters: []apiParameter{{name: "since", description: "Start of the report, defaulting to the configured window", schema: map[string]any{"type": "string", "format": "date-time"}}},
+		responses:  []apiResponse{{http.StatusOK, "The crash report", CrashReportResponse{}}, {http.StatusBadRequest, "Invalid since time", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/wifi/locations", tag: "operations",
+		summary:   "Report the Wi-Fi link of the devices by location",
+		responses: []apiResponse{{http.StatusOK, "The locations", WifiLocationsResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/wifi/advisories", tag: "operations",
+		summary:   "List the Wi-Fi advisories, most devices first",
+		responses: []apiResponse{{http.StatusOK, "The advisories", WifiAdvisoriesResponse{}}},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/broker/auth", tag: "broker",
+		summary: "Authenticate an MQTT client for an external broker", availability: "Served when BROKER_AUTH_TOKEN is set", security: brokerTokenScheme,
+		request: BrokerAuthRequest{},
+		responses: []apiResponse{
+			{http.StatusOK, "Client allowed", BrokerAuthResponse{}},
+			{http.StatusBadRequest, "Invalid request body", nil},
+			{http.StatusUnauthorized, "Missing or wrong token", nil},
+			{http.StatusForbidden, "Client denied", BrokerAuthResponse{}},
+		},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/broker/acl", tag: "broker",
+		summary: "Authorize a publish or subscribe of an MQTT client for an external broker", availability: "Served when BROKER_AUTH_TOKEN is set", security: brokerTokenScheme,
+		request: BrokerACLRequest{},
+		responses: []apiResponse{
+			{http.StatusOK, "Access allowed", BrokerAuthResponse{}},
+			{http.StatusBadRequest, "Invalid request body or action", nil},
+			{http.StatusUnauthorized, "Missing or wrong token", nil},
+			{http.StatusForbidden, "Access denied", BrokerAuthResponse{}},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/users/{user}/notifications", tag: "notifications",
+		summary: "List the notifications of a user, newest first", availability: "Served when notifications are configured",
+		parameters: []apiParameter{
+			{name: "unread", description: "Only unread notifications", schema: map[string]any{"type": "boolean"}},
+			{name: "before", description: "Only notifications created before this time, to read the next page", schema: map[string]any{"type": "string", "format": "date-time"}},
+			limitParameter,
+		},
+		responses: []apiResponse{
+			{http.StatusOK, "The notifications", InboxNotificationsResponse{}},
+			{http.StatusBadRequest, "Invalid query", nil},
+			{http.StatusForbidden, "Inbox of another user", nil},
+			{http.StatusNotFound, "Notification user not found", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/users/{user}/notifications/unread-count", tag: "notifications",
+		summary: "Count the unread notifications of a user", availability: "Served when notifications are configured",
+		responses: []apiResponse{{http.StatusOK, "The unread counts", UnreadCountResponse{}}, {http.StatusForbidden, "Inbox of another user", nil}, {http.StatusNotFound, "Notification user not found", nil}},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/users/{user}/notifications/read-all", tag: "notifications",
+		summary: "Mark every notification of a user as read", availability: "Served when notifications are configured",
+		responses: []apiResponse{{http.StatusOK, "The number of notifications marked", MarkAllReadResponse{}}, {http.StatusForbidden, "Inbox of another user", nil}, {http.StatusNotFound, "Notification user not found", nil}},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/users/{user}/notifications/{id}/read", tag: "notifications",
+		summary: "Mark a notification as read", availability: "Served when notifications are configured",
+		responses: []apiResponse{{http.StatusNoContent, "Notification marked as read", nil}, {http.StatusForbidden, "Inbox of another user", nil}, {http.StatusNotFound, "Notification user or notification not found", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/sensors/health", tag: "sensors",
+		summary: "Score the health of every sensor stream", availability: "Served when sensor health scoring is enabled",
+		responses: []apiResponse{{http.StatusOK, "The sensor streams", SensorStreamsResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/sensors/degraded", tag: "sensors",
+		summary: "List the degraded sensor streams", availability: "Served when sensor health scoring is enabled",
+		responses: []apiResponse{{http.StatusOK, "The degraded sensor streams", SensorStreamsResponse{}}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/onboarding/{id}", tag: "devices",
+		summary:   "Get the progress of a device onboarding, polled by the installer app",
+		responses: []apiResponse{{http.StatusOK, "The onboarding", OnboardingResponse{}}, {http.StatusNotFound, "Onboarding not found", nil}},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/auth/me", tag: "auth",
+		summary: "Get the claims of the caller's access token", availability: "Served when AUTH_JWT_SECRET is set", security: accessTokenScheme,
+		responses: []apiResponse{
+			{http.StatusOK, "The claims of the access token", ClaimsResponse{}},
+			{http.StatusUnauthorized, "Missing, invalid or expired access token", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/research/zones", tag: "research",
+		summary: "List the fields and zones a research token may read", security: researchTokenScheme,
+		responses: []apiResponse{
+			{http.StatusOK, "The fields and zones of the token", ResearchZonesResponse{}},
+			{http.StatusUnauthorized, "Missing, revoked or expired research token", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/research/zones/{id}/telemetry", tag: "research",
+		summary: "Get the anonymized readings of a field or zone", security: researchTokenScheme,
+		parameters: []apiParameter{
+			{name: "type", description: "Sensor type of the readings, required", schema: map[string]any{"type": "string"}},
+			{name: "from", description: "Start of the range, defaulting to one day before to", schema: map[string]any{"type": "string", "format": "date-time"}},
+			{name: "to", description: "End of the range, excluded, defaulting to now", schema: map[string]any{"type": "string", "format": "date-time"}},
+		},
+		responses: []apiResponse{
+			{http.StatusOK, "The readings, oldest first, with their devices replaced by pseudonyms", ResearchTelemetryResponse{}},
+			{http.StatusBadRequest, "Missing type or invalid range", nil},
+			{http.StatusUnauthorized, "Missing, revoked or expired research token", nil},
+			{http.StatusNotFound, "Zone not found or not readable by the token", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/research/usage", tag: "research",
+		summary: "Get the daily usage of the caller's research token", security: researchTokenScheme,
+		parameters: analyticsRangeParameters,
+		responses: []apiResponse{
+			{http.StatusOK, "The requests and readings served per day", ResearchUsageResponse{}},
+			{http.StatusBadRequest, "Invalid range", nil},
+			{http.StatusUnauthorized, "Missing, revoked or expired research token", nil},
+		},
+	},
+	{
+		method: http.MethodGet, path: "/api/v1/sync/status", tag: "sync",
+		summary: "Get the replication state of an edge instance", availability: "Served by edge instances",
+		responses: []apiResponse{{http.StatusOK, "The replication state", entities.SyncStatus{}}},
+	},
+	{
+		method: http.MethodPost, path: "/api/v1/sync/batch", tag: "sync",
+		summary: "Apply a batch replicated by an edge instance", availability: "Served by cloud instances", security: syncTokenScheme,
+		request: dtos.SyncBatchRequest{},
+		responses: []apiResponse{
+			{http.StatusOK, "How the batch was applied", entities.SyncApplyResult{}},
+			{http.StatusBadRequest, "Invalid sync batch", nil},
+			{http.StatusUnauthorized, "Missing or wrong token", nil},
+		},
+	},
+}
+
+// NewOpenAPIDocument generates the OpenAPI 3.0 document of the public API, with the schemas of
+// the request and response types
+func NewOpenAPIDocument() map[string]any {
+	schemas := &schemaRegistry{schemas: map[string]any{}}
+	paths := map[string]any{}
+	for _, operation := range apiOperations {
+		item, ok := paths[operation.path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[operation.path] = item
+		}
+		item[strings.ToLower(operation.method)] = schemas.operation(operation)
+	}
+
+	bearer := func(description string) map[string]any {
+		return map[string]any{"type": "http", "scheme": "bearer", "description": description}
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Liwaisi IoT smart irrigation server",
+			"version": "1.0.0",
+			"description": "Devices, sensor readings and irrigation of the smart irrigation server. " +
+				"Errors are plain text messages in the language negotiated from the Accept-Language header.",
+		},
+		"servers": []any{map[string]any{"url": "/"}},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": schemas.schemas,
+			"securitySchemes": map[string]any{
+				accessTokenScheme:   bearer("Access token issued by POST /auth/login, required by every /api/v1 operation when AUTH_JWT_SECRET is set"),
+				adminTokenScheme:    bearer("ADMIN_TOKEN"),
+				brokerTokenScheme:   bearer("BROKER_AUTH_TOKEN"),
+				researchTokenScheme: bearer("Research token created under /admin/research/tokens, read-only and limited to its zones"),
+				syncTokenScheme:     bearer("SYNC_TOKEN"),
+			},
+		},
+	}
+}
+
+// schemaRegistry collects the schemas of the named types used by the operations
+type schemaRegistry struct {
+	schemas map[string]any
+}
+
+func (s *schemaRegistry) operation(operation apiOperation) map[string]any {
+	description := operation.summary + "."
+	if operation.availability != "" {
+		description += " " + operation.availability + "."
+	}
+	document := map[string]any{
+		"operationId": operationID(operation),
+		"summary":     operation.summary,
+		"description": description,
+		"tags":        []string{operation.tag},
+	}
+
+	var parameters []any
+	for _, match := range pathParameterPattern.FindAllStringSubmatch(operation.path, -1) {
+		parameters = append(parameters, map[string]any{
+			"name":        match[1],
+			"in":          "path",
+			"required":    true,
+			"description": pathParameterDescriptions[match[1]],
+			"schema":      map[string]any{"type": "string"},
+		})
+	}
+	for _, parameter := range operation.parameters {
+		schema := parameter.schema
+		if parameter.repeated {
+			schema = map[string]any{"type": "array", "items": schema}
+		}
+		parameters = append(parameters, map[string]any{
+			"name":        parameter.name,
+			"in":          "query",
+			"description": parameter.description,
+			"schema":      schema,
+		})
+	}
+	if len(parameters) > 0 {
+		document["parameters"] = parameters
+	}
+
+	if operation.request != nil {
+		document["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{"application/json": map[string]any{"schema": s.schema(reflect.TypeOf(operation.request))}},
+		}
+	}
+	if operation.security != "" {
+		document["security"] = []any{map[string]any{operation.security: []string{}}}
+	}
+
+	responses := map[string]any{}
+	for _, response := range operation.responses {
+		content := map[string]any{"description": response.description}
+		switch {
+		case response.body != nil:
+			content["content"] = map[string]any{"application/json": map[string]any{"schema": s.schema(reflect.TypeOf(response.body))}}
+		case response.status >= http.StatusBadRequest:
+			content["content"] = map[string]any{"text/plain": map[string]any{"schema": map[string]any{"type": "string"}}}
+		}
+		responses[strconv.Itoa(response.status)] = content
+	}
+	document["responses"] = responses
+	return document
+}
+
+// schema returns the schema of a type, registering named structs as components
+func (s *schemaRegistry) schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return map[string]any{"type": "string", "format": "date-time"}
+	case reflect.TypeOf(json.RawMessage{}):
+		return map[string]any{"description": "Any JSON value"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		if _, ok := s.schemas[t.Name()]; !ok {
+			s.schemas[t.Name()] = map[string]any{} // breaks recursion until the object is built
+			s.schemas[t.Name()] = s.object(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		return map[string]any{}
+	}
+}
+
+// object returns the schema of a struct from its JSON encoding; fields that are always encoded
+// are required
+func (s *schemaRegistry) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := s.object(field.Type)
+			for property, schema := range embedded["properties"].(map[string]any) {
+				properties[property] = schema
+			}
+			if names, ok := embedded["required"].([]string); ok {
+				required = append(required, names...)
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := s.schema(field.Type)
+		omitted := strings.Contains(options, "omitempty")
+		if field.Type.Kind() == reflect.Pointer && !omitted {
+			if _, ref := schema["$ref"]; !ref {
+				schema["nullable"] = true
+			}
+		}
+		properties[name] = schema
+		if !omitted {
+			required = append(required, name)
+		}
+	}
+
+	object := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		object["required"] = required
+	}
+	return object
+}
+
+// operationID names an operation after its method and path, such as getDevicesMacReadings
+func operationID(operation apiOperation) string {
+	var id strings.Builder
+	id.WriteString(strings.ToLower(operation.method))
+	for _, segment := range strings.Split(strings.TrimPrefix(operation.path, "/api/v1/"), "/") {
+		segment = strings.Trim(segment, "{}")
+		for _, word := range strings.Split(segment, "-") {
+			if word != "" {
+				id.WriteString(strings.ToUpper(word[:1]) + word[1:])
+			}
+		}
+	}
+	return id.String()
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOpenAPIDocument(t *testing.T) {
+	encoded, err := json.Marshal(NewOpenAPIDocument())
+	require.NoError(t, err)
+	var document struct {
+		OpenAPI    string                               `json:"openapi"`
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+				Required   []string                  `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &document))
+	assert.Equal(t, "3.0.3", document.OpenAPI)
+
+	t.Run("should give every operation a unique ID and responses", func(t *testing.T) {
+		ids := map[string]bool{}
+		for path, item := range document.Paths {
+			for method, operation := range item {
+				id, _ := operation["operationId"].(string)
+				assert.False(t, ids[id], "%s %s reuses operation ID %q", method, path, id)
+				ids[id] = true
+				assert.NotEmpty(t, operation["responses"], "%s %s", method, path)
+			}
+		}
+		assert.True(t, ids["getDevicesMacReadings"])
+	})
+
+	t.Run("should define every referenced schema", func(t *testing.T) {
+		for _, ref := range strings.Split(string(encoded), `"$ref":"#/components/schemas/`)[1:] {
+			name, _, _ := strings.Cut(ref, `"`)
+			assert.Contains(t, document.Components.Schemas, name)
+		}
+	})
+
+	t.Run("should derive the schemas from the JSON encoding", func(t *testing.T) {
+		reading := document.Components.Schemas["SensorReadingResponse"]
+		assert.Equal(t, "number", reading.Properties["temperature_celsius"]["type"])
+		assert.Equal(t, "date-time", reading.Properties["timestamp"]["format"])
+		assert.ElementsMatch(t, []string{"mac_address", "temperature_celsius", "humidity_percent", "timestamp"}, reading.Required)
+
+		health := document.Components.Schemas["DeviceHealthCheckResponse"]
+		assert.Contains(t, health.Properties, "mac_address")
+		assert.Contains(t, health.Properties, "latency_ms", "embedded fields are flattened")
+
+		device := document.Components.Schemas["DeviceRecordResponse"]
+		assert.NotContains(t, device.Required, "farm_id", "omitted when empty")
+		assert.Equal(t, true, document.Components.Schemas["SensorChannelResponse"].Properties["calibrated_at"]["nullable"])
+	})
+}
+
+func TestDocsHandler_OpenAPI(t *testing.T) {
+	handler, err := NewDocsHandler()
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	handler.OpenAPI(w, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &document))
+	assert.Equal(t, "3.0.3", document["openapi"])
+}
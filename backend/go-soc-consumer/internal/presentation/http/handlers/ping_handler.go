@@ -2,10 +2,28 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/ping"
 )
 
+// DependencyHealthResponse is the outcome of checking one dependency
+type DependencyHealthResponse struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// HealthResponse is the body returned by the health endpoint
+type HealthResponse struct {
+	Status        string                              `json:"status"`
+	Version       string                              `json:"version"`
+	StartedAt     time.Time                           `json:"started_at"`
+	UptimeSeconds float64                             `json:"uptime_seconds"`
+	CheckedAt     time.Time                           `json:"checked_at"`
+	Dependencies  map[string]DependencyHealthResponse `json:"dependencies"`
+}
+
 type PingHandler struct {
 	pingUseCase ping.PingUseCase
 }
@@ -30,3 +48,27 @@ func (h *PingHandler) Ping(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// Health reports the version, uptime and the status and latency of every dependency.
+// It answers 200 even when degraded; readiness decides whether traffic is routed here.
+func (h *PingHandler) Health(w http.ResponseWriter, r *http.Request) {
+	report := h.pingUseCase.Report(r.Context())
+
+	response := HealthResponse{
+		Status:        report.Status,
+		Version:       report.Version,
+		StartedAt:     report.StartedAt,
+		UptimeSeconds: report.Uptime.Seconds(),
+		CheckedAt:     report.CheckedAt,
+		Dependencies:  make(map[string]DependencyHealthResponse, len(report.Dependencies)),
+	}
+	for name, dependency := range report.Dependencies {
+		response.Dependencies[name] = DependencyHealthResponse{
+			Status:    dependency.Status,
+			LatencyMS: float64(dependency.Latency.Microseconds()) / 1000,
+			Error:     dependency.Error,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
@@ -2,13 +2,17 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
 )
 
@@ -269,6 +273,56 @@ func TestPingHandler_Ping_EdgeCases(t *testing.T) {
 	})
 }
 
+func TestPingHandler_Health(t *testing.T) {
+	startedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		dependencies map[string]entities.DependencyHealth
+		expected     map[string]DependencyHealthResponse
+	}{
+		{
+			name: "healthy dependencies",
+			dependencies: map[string]entities.DependencyHealth{
+				"database": {Status: entities.DependencyStatusOK, Latency: 1500 * time.Microsecond},
+			},
+			expected: map[string]DependencyHealthResponse{
+				"database": {Status: "ok", LatencyMS: 1.5},
+			},
+		},
+		{
+			name: "degraded dependency",
+			dependencies: map[string]entities.DependencyHealth{
+				"mqtt": {Status: entities.DependencyStatusFailing, Latency: 2 * time.Millisecond, Error: "MQTT client is not connected"},
+			},
+			expected: map[string]DependencyHealthResponse{
+				"mqtt": {Status: "failing", LatencyMS: 2, Error: "MQTT client is not connected"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := entities.NewHealthReport("1.4.0", startedAt, startedAt.Add(time.Minute), tt.dependencies)
+			mockUseCase := mocks.NewMockPingUseCase(t)
+			mockUseCase.EXPECT().Report(mock.Anything).Return(report).Once()
+
+			w := httptest.NewRecorder()
+			NewPingHandler(mockUseCase).Health(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var response HealthResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+			assert.Equal(t, report.Status, response.Status)
+			assert.Equal(t, "1.4.0", response.Version)
+			assert.Equal(t, 60.0, response.UptimeSeconds)
+			assert.Equal(t, tt.expected, response.Dependencies)
+		})
+	}
+}
+
 // Benchmark tests
 func BenchmarkPingHandler_Ping(b *testing.B) {
 	mockUseCase := mocks.NewMockPingUseCase(&testing.T{})
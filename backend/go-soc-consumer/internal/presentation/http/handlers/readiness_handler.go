@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// ReadinessCheck is a named dependency check used by the readiness endpoint.
+// Check may return details that are included in the response regardless of the outcome.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) (interface{}, error)
+}
+
+// ReadinessCheckResult is the outcome of a single readiness check
+type ReadinessCheckResult struct {
+	Status  string      `json:"status"`
+	Error   string      `json:"error,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// ReadinessResponse is the body returned by the readiness endpoint
+type ReadinessResponse struct {
+	Status string                          `json:"status"`
+	Checks map[string]ReadinessCheckResult `json:"checks"`
+}
+
+type ReadinessHandler struct {
+	checks  []ReadinessCheck
+	timeout time.Duration
+}
+
+func NewReadinessHandler(checks ...ReadinessCheck) *ReadinessHandler {
+	return &ReadinessHandler{
+		checks:  checks,
+		timeout: 5 * time.Second,
+	}
+}
+
+// Ready reports 200 when every check passes and 503 otherwise
+func (h *ReadinessHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	response := ReadinessResponse{
+		Status: "ready",
+		Checks: make(map[string]ReadinessCheckResult, len(h.checks)),
+	}
+
+	for _, check := range h.checks {
+		details, err := check.Check(ctx)
+		result := ReadinessCheckResult{Status: "ok", Details: details}
+		if err != nil {
+			result.Status = "failing"
+			result.Error = err.Error()
+			response.Status = "not_ready"
+		}
+		response.Checks[check.Name] = result
+	}
+
+	statusCode := http.StatusOK
+	if response.Status != "ready" {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "failed to write response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadinessHandler_Ready(t *testing.T) {
+	passing := ReadinessCheck{
+		Name: "database",
+		Check: func(ctx context.Context) (interface{}, error) {
+			return nil, nil
+		},
+	}
+	failing := ReadinessCheck{
+		Name: "mqtt",
+		Check: func(ctx context.Context) (interface{}, error) {
+			return []string{"tcp://primary:1883"}, errors.New("MQTT client is not connected")
+		},
+	}
+
+	tests := []struct {
+		name           string
+		checks         []ReadinessCheck
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "all checks passing",
+			checks:         []ReadinessCheck{passing},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ready",
+		},
+		{
+			name:           "one check failing",
+			checks:         []ReadinessCheck{passing, failing},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "not_ready",
+		},
+		{
+			name:           "no checks registered",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewReadinessHandler(tt.checks...)
+
+			req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+			rec := httptest.NewRecorder()
+			handler.Ready(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			var response ReadinessResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedBody, response.Status)
+			assert.Len(t, response.Checks, len(tt.checks))
+		})
+	}
+
+	t.Run("failing check includes error and details", func(t *testing.T) {
+		handler := NewReadinessHandler(failing)
+
+		rec := httptest.NewRecorder()
+		handler.Ready(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+		var response ReadinessResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		result := response.Checks["mqtt"]
+		assert.Equal(t, "failing", result.Status)
+		assert.Equal(t, "MQTT client is not connected", result.Error)
+		assert.NotNil(t, result.Details)
+	})
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/reprocessing"
+)
+
+// ReprocessRequest is the time range to replay from the raw archive, in RFC 3339
+type ReprocessRequest struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// ReprocessingHandler serves the endpoint starting a replay of the raw archive
+type ReprocessingHandler struct {
+	reprocessingUseCase reprocessing.ReprocessingUseCase
+	token               string
+}
+
+func NewReprocessingHandler(reprocessingUseCase reprocessing.ReprocessingUseCase, token string) *ReprocessingHandler {
+	return &ReprocessingHandler{
+		reprocessingUseCase: reprocessingUseCase,
+		token:               token,
+	}
+}
+
+// Reprocess handles POST /admin/reprocess, replying with the queued job to follow on /api/v1/jobs/{id}
+func (h *ReprocessingHandler) Reprocess(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request ReprocessRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.reprocessingUseCase.Start(r.Context(), request.From, request.To)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidReprocessRange) {
+			writeDomainError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		writeError(w, r, "failed to start reprocessing", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, NewJobResponse(job))
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/reprocessing"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestReprocessingHandler_Reprocess(t *testing.T) {
+	from := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	body := `{"from":"2025-03-01T10:00:00Z","to":"2025-03-01T12:00:00Z"}`
+
+	t.Run("should queue a reprocessing job", func(t *testing.T) {
+		useCase := mocks.NewMockReprocessingUseCase(t)
+		useCase.EXPECT().Start(mock.Anything, from, to).
+			Return(&entities.Job{ID: "job-1", Type: reprocessing.JobTypeReprocess, Status: entities.JobStatusQueued}, nil).
+			Once()
+		handler := NewReprocessingHandler(useCase, "secret")
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/reprocess", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.Reprocess(rec, req)
+
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		var response JobResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "job-1", response.ID)
+		assert.Equal(t, reprocessing.JobTypeReprocess, response.Type)
+	})
+
+	t.Run("should reject invalid ranges", func(t *testing.T) {
+		useCase := mocks.NewMockReprocessingUseCase(t)
+		useCase.EXPECT().Start(mock.Anything, from, to).
+			Return(nil, fmt.Errorf("%w: range exceeds 744h0m0s", domainerrors.ErrInvalidReprocessRange)).
+			Once()
+		handler := NewReprocessingHandler(useCase, "secret")
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/reprocess", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.Reprocess(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Invalid reprocessing range")
+	})
+
+	t.Run("should reject malformed bodies", func(t *testing.T) {
+		handler := NewReprocessingHandler(mocks.NewMockReprocessingUseCase(t), "secret")
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/reprocess", strings.NewReader(`{"from":"yesterday"}`))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.Reprocess(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should report queue failures", func(t *testing.T) {
+		useCase := mocks.NewMockReprocessingUseCase(t)
+		useCase.EXPECT().Start(mock.Anything, from, to).Return(nil, errors.New("database down")).Once()
+		handler := NewReprocessingHandler(useCase, "secret")
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/reprocess", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.Reprocess(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		handler := NewReprocessingHandler(mocks.NewMockReprocessingUseCase(t), "secret")
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/reprocess", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.Reprocess(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	researchsharing "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/research_sharing"
+)
+
+// ResearchTokenRequest is the body of the research token creation endpoint
+type ResearchTokenRequest struct {
+	Name      string    `json:"name"`
+	Zones     []string  `json:"zones"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ResearchTokenResponse is the JSON representation of a research token, without its secret
+type ResearchTokenResponse struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Zones     []string   `json:"zones"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// CreatedResearchTokenResponse carries the secret of a new research token, which is only shown once
+type CreatedResearchTokenResponse struct {
+	ResearchTokenResponse
+	Token string `json:"token"`
+}
+
+// ResearchTokensResponse lists the research tokens, most recently created first
+type ResearchTokensResponse struct {
+	Tokens []ResearchTokenResponse `json:"tokens"`
+}
+
+// ResearchUsageResponse is the daily usage of a research token
+type ResearchUsageResponse struct {
+	TokenID  string             `json:"token_id"`
+	Requests int64              `json:"requests"`
+	Readings int64              `json:"readings"`
+	Days     []ResearchUsageDay `json:"days"`
+}
+
+// ResearchUsageDay is the usage of a research token on a UTC day
+type ResearchUsageDay struct {
+	Day      string `json:"day"` // YYYY-MM-DD
+	Requests int64  `json:"requests"`
+	Readings int64  `json:"readings"`
+}
+
+// ResearchZonesResponse lists the fields and zones a research token may read
+type ResearchZonesResponse struct {
+	Zones []ResearchZoneResponse `json:"zones"`
+}
+
+// ResearchZoneResponse is a field or zone as shown to research partners
+type ResearchZoneResponse struct {
+	ID       string `json:"id"`
+	ParentID string `json:"parent_id,omitempty"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+}
+
+// ResearchTelemetryResponse holds the anonymized readings of a field or zone
+type ResearchTelemetryResponse struct {
+	Zone     string                    `json:"zone"`
+	Type     string                    `json:"type"`
+	Count    int                       `json:"count"`
+	Readings []AnonymizedReadingResult `json:"readings"`
+}
+
+// AnonymizedReadingResult is a reading with its device replaced by a pseudonym
+type AnonymizedReadingResult struct {
+	Device    string    `json:"device"`
+	Channel   int       `json:"channel"`
+	Value     float64   `json:"value"`
+	Unit      string    `json:"unit,omitempty"`
+	Quality   string    `json:"quality,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ResearchHandler serves the research data sharing API to partners holding a research token, and
+// the administration of those tokens
+type ResearchHandler struct {
+	researchUseCase researchsharing.ResearchSharingUseCase
+	token           string
+}
+
+func NewResearchHandler(researchUseCase researchsharing.ResearchSharingUseCase, token string) *ResearchHandler {
+	return &ResearchHandler{
+		researchUseCase: researchUseCase,
+		token:           token,
+	}
+}
+
+// Zones handles GET /api/v1/research/zones
+func (h *ResearchHandler) Zones(w http.ResponseWriter, r *http.Request) {
+	token, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	zones, err := h.researchUseCase.Zones(r.Context(), token)
+	if err != nil {
+		writeError(w, r, "failed to list research zones", http.StatusInternalServerError)
+		return
+	}
+
+	response := ResearchZonesResponse{Zones: make([]ResearchZoneResponse, 0, len(zones))}
+	for _, zone := range zones {
+		response.Zones = append(response.Zones, ResearchZoneResponse{ID: zone.ID, ParentID: zone.ParentID, Kind: zone.Kind, Name: zone.Name})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Telemetry handles GET /api/v1/research/zones/{id}/telemetry?type=&from=&to=
+func (h *ResearchHandler) Telemetry(w http.ResponseWriter, r *http.Request) {
+	token, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+	from, to, ok := parseAnalyticsRange(w, r)
+	if !ok {
+		return
+	}
+
+	zoneID := r.PathValue("id")
+	sensorType := r.URL.Query().Get("type")
+	readings, err := h.researchUseCase.Telemetry(r.Context(), token, zoneID, sensorType, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrZoneNotFound):
+			writeDomainError(w, r, err, http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrInvalidReadingsQuery):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		default:
+			writeError(w, r, "failed to load research telemetry", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := ResearchTelemetryResponse{
+		Zone:     zoneID,
+		Type:     sensorType,
+		Count:    len(readings),
+		Readings: make([]AnonymizedReadingResult, 0, len(readings)),
+	}
+	for _, reading := range readings {
+		response.Readings = append(response.Readings, AnonymizedReadingResult{
+			Device:    reading.Device,
+			Channel:   reading.Channel,
+			Value:     reading.Value,
+			Unit:      reading.Unit,
+			Quality:   reading.Quality,
+			Timestamp: reading.Timestamp,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// OwnUsage handles GET /api/v1/research/usage, returning the usage of the caller's token
+func (h *ResearchHandler) OwnUsage(w http.ResponseWriter, r *http.Request) {
+	token, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+	h.writeUsage(w, r, token.ID)
+}
+
+// ListTokens handles GET /admin/research/tokens
+func (h *ResearchHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	tokens, err := h.researchUseCase.ListTokens(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to list research tokens", http.StatusInternalServerError)
+		return
+	}
+
+	response := ResearchTokensResponse{Tokens: make([]ResearchTokenResponse, 0, len(tokens))}
+	for _, token := range tokens {
+		response.Tokens = append(response.Tokens, newResearchTokenResponse(token))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// CreateToken handles POST /admin/research/tokens
+func (h *ResearchHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request ResearchTokenRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16384)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	token, secret, err := h.researchUseCase.CreateToken(r.Context(), entities.ResearchTokenRequest(request))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidResearchToken) {
+			writeDomainError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		writeError(w, r, "failed to create research token", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusCreated, CreatedResearchTokenResponse{ResearchTokenResponse: newResearchTokenResponse(token), Token: secret})
+}
+
+// RevokeToken handles DELETE /admin/research/tokens/{id}
+func (h *ResearchHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	token, err := h.researchUseCase.RevokeToken(r.Context(), r.PathValue("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrResearchTokenNotFound):
+			writeDomainError(w, r, err, http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrResearchTokenRevoked):
+			writeDomainError(w, r, err, http.StatusConflict)
+		default:
+			writeError(w, r, "failed to revoke research token", http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, newResearchTokenResponse(token))
+}
+
+// TokenUsage handles GET /admin/research/tokens/{id}/usage?from=&to=
+func (h *ResearchHandler) TokenUsage(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	h.writeUsage(w, r, r.PathValue("id"))
+}
+
+// authenticate resolves the research token of the request, replying when it is missing or rejected
+func (h *ResearchHandler) authenticate(w http.ResponseWriter, r *http.Request) (*entities.ResearchToken, bool) {
+	secret, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || strings.TrimSpace(secret) == "" {
+		writeResearchUnauthorized(w, r, domainerrors.ErrInvalidResearchToken)
+		return nil, false
+	}
+
+	token, err := h.researchUseCase.Authenticate(r.Context(), strings.TrimSpace(secret))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidToken) || errors.Is(err, domainerrors.ErrTokenExpired) {
+			writeResearchUnauthorized(w, r, err)
+			return nil, false
+		}
+		writeError(w, r, "failed to check research token", http.StatusInternalServerError)
+		return nil, false
+	}
+	return token, true
+}
+
+// writeUsage replies with the usage of the token over the optional from and to query parameters
+func (h *ResearchHandler) writeUsage(w http.ResponseWriter, r *http.Request, tokenID string) {
+	from, to, ok := parseAnalyticsRange(w, r)
+	if !ok {
+		return
+	}
+
+	usage, err := h.researchUseCase.Usage(r.Context(), tokenID, from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrResearchTokenNotFound):
+			writeDomainError(w, r, err, http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrInvalidReadingsQuery):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		default:
+			writeError(w, r, "failed to load research token usage", http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, newResearchUsageResponse(tokenID, usage))
+}
+
+func writeResearchUnauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="research"`)
+	writeDomainError(w, r, err, http.StatusUnauthorized)
+}
+
+func newResearchTokenResponse(token *entities.ResearchToken) ResearchTokenResponse {
+	return ResearchTokenResponse{
+		ID:        token.ID,
+		Name:      token.Name,
+		Zones:     token.Zones,
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: token.RevokedAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+func newResearchUsageResponse(tokenID string, usage []*entities.ResearchTokenUsage) ResearchUsageResponse {
+	response := ResearchUsageResponse{TokenID: tokenID, Days: make([]ResearchUsageDay, 0, len(usage))}
+	for _, day := range usage {
+		response.Requests += day.Requests
+		response.Readings += day.Readings
+		response.Days = append(response.Days, ResearchUsageDay{Day: day.Day.Format(time.DateOnly), Requests: day.Requests, Readings: day.Readings})
+	}
+	return response
+}
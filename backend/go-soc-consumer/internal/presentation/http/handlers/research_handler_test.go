@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestResearchHandler_Telemetry(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	token := &entities.ResearchToken{ID: "token-1", Zones: []string{"zone-1"}}
+	path := "/api/v1/research/zones/zone-1/telemetry?type=soil_moisture&from=2025-06-01T10:00:00Z"
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.SetPathValue("id", "zone-1")
+		req.Header.Set("Authorization", "Bearer rt_secret")
+		return req
+	}
+
+	t.Run("should return the anonymized readings", func(t *testing.T) {
+		useCase := mocks.NewMockResearchSharingUseCase(t)
+		useCase.EXPECT().Authenticate(mock.Anything, "rt_secret").Return(token, nil).Once()
+		useCase.EXPECT().Telemetry(mock.Anything, token, "zone-1", "soil_moisture", now.Add(-2*time.Hour), time.Time{}).Return([]*entities.AnonymizedReading{
+			{Device: "device-0a1b2c3d4e5f", SensorType: "soil_moisture", Value: 31.5, Unit: "%", Timestamp: now},
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewResearchHandler(useCase, "secret").Telemetry(rec, newRequest())
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"zone":"zone-1","type":"soil_moisture","count":1,"readings":[
+			{"device":"device-0a1b2c3d4e5f","channel":0,"value":31.5,"unit":"%","timestamp":"2025-06-01T12:00:00Z"}]}`, rec.Body.String())
+	})
+
+	t.Run("should reject missing and rejected tokens", func(t *testing.T) {
+		tests := []struct {
+			header string
+			err    error
+		}{
+			{header: ""},
+			{header: "Bearer rt_secret", err: domainerrors.ErrInvalidToken},
+			{header: "Bearer rt_secret", err: domainerrors.ErrTokenExpired},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockResearchSharingUseCase(t)
+			if tt.err != nil {
+				useCase.EXPECT().Authenticate(mock.Anything, "rt_secret").Return(nil, tt.err).Once()
+			}
+			req := newRequest()
+			req.Header.Set("Authorization", tt.header)
+
+			rec := httptest.NewRecorder()
+			NewResearchHandler(useCase, "secret").Telemetry(rec, req)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, `Bearer realm="research"`, rec.Header().Get("WWW-Authenticate"))
+		}
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: domainerrors.ErrZoneNotFound, expected: http.StatusNotFound},
+			{err: domainerrors.ErrInvalidReadingsQuery, expected: http.StatusBadRequest},
+			{err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockResearchSharingUseCase(t)
+			useCase.EXPECT().Authenticate(mock.Anything, "rt_secret").Return(token, nil).Once()
+			useCase.EXPECT().Telemetry(mock.Anything, token, "zone-1", "soil_moisture", mock.Anything, mock.Anything).Return(nil, tt.err).Once()
+
+			rec := httptest.NewRecorder()
+			NewResearchHandler(useCase, "secret").Telemetry(rec, newRequest())
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+}
+
+func TestResearchHandler_CreateToken(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	body := `{"name":"Universidad Nacional","zones":["zone-1"],"expires_at":"2025-09-01T00:00:00Z"}`
+
+	newRequest := func(token string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/admin/research/tokens", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req
+	}
+
+	t.Run("should return the secret once", func(t *testing.T) {
+		useCase := mocks.NewMockResearchSharingUseCase(t)
+		request := entities.ResearchTokenRequest{Name: "Universidad Nacional", Zones: []string{"zone-1"}, ExpiresAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)}
+		useCase.EXPECT().CreateToken(mock.Anything, request).Return(&entities.ResearchToken{
+			ID: "token-1", Name: request.Name, Zones: request.Zones, ExpiresAt: request.ExpiresAt, CreatedAt: now,
+		}, "rt_secret", nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewResearchHandler(useCase, "secret").CreateToken(rec, newRequest("secret"))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"id":"token-1","name":"Universidad Nacional","zones":["zone-1"],"expires_at":"2025-09-01T00:00:00Z",
+			"created_at":"2025-06-01T12:00:00Z","token":"rt_secret"}`, rec.Body.String())
+	})
+
+	t.Run("should reject invalid tokens", func(t *testing.T) {
+		useCase := mocks.NewMockResearchSharingUseCase(t)
+		useCase.EXPECT().CreateToken(mock.Anything, mock.Anything).Return(nil, "", domainerrors.ErrInvalidResearchToken).Once()
+
+		rec := httptest.NewRecorder()
+		NewResearchHandler(useCase, "secret").CreateToken(rec, newRequest("secret"))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewResearchHandler(mocks.NewMockResearchSharingUseCase(t), "secret").CreateToken(rec, newRequest("wrong"))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestResearchHandler_TokenUsage(t *testing.T) {
+	useCase := mocks.NewMockResearchSharingUseCase(t)
+	useCase.EXPECT().Usage(mock.Anything, "token-1", time.Time{}, time.Time{}).Return([]*entities.ResearchTokenUsage{
+		{TokenID: "token-1", Day: time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC), Requests: 3, Readings: 120},
+		{TokenID: "token-1", Day: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Requests: 1, Readings: 40},
+	}, nil).Once()
+	useCase.EXPECT().RevokeToken(mock.Anything, "token-2").Return(nil, domainerrors.ErrResearchTokenRevoked).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/research/tokens/token-1/usage", nil)
+	req.SetPathValue("id", "token-1")
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	NewResearchHandler(useCase, "secret").TokenUsage(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"token_id":"token-1","requests":4,"readings":160,"days":[
+		{"day":"2025-05-31","requests":3,"readings":120},{"day":"2025-06-01","requests":1,"readings":40}]}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodDelete, "/admin/research/tokens/token-2", nil)
+	req.SetPathValue("id", "token-2")
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	NewResearchHandler(useCase, "secret").RevokeToken(rec, req)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	securitymonitoring "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/security_monitoring"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// SecurityAlertResponse is the JSON representation of a security alert
+type SecurityAlertResponse struct {
+	ID          string                 `json:"id"`
+	Kind        string                 `json:"kind"`
+	Severity    string                 `json:"severity"`
+	Subject     string                 `json:"subject"`
+	Description string                 `json:"description"`
+	Context     map[string]interface{} `json:"context"`
+	DetectedAt  time.Time              `json:"detected_at"`
+}
+
+// SecurityAlertsResponse lists recent security alerts, newest first
+type SecurityAlertsResponse struct {
+	Alerts []SecurityAlertResponse `json:"alerts"`
+}
+
+// SecurityAlertsHandler serves the recent security alerts
+type SecurityAlertsHandler struct {
+	securityMonitorUseCase securitymonitoring.SecurityMonitorUseCase
+	pagination             pagination.Policy
+	token                  string
+}
+
+func NewSecurityAlertsHandler(securityMonitorUseCase securitymonitoring.SecurityMonitorUseCase, policy pagination.Policy, token string) *SecurityAlertsHandler {
+	return &SecurityAlertsHandler{
+		securityMonitorUseCase: securityMonitorUseCase,
+		pagination:             policy,
+		token:                  token,
+	}
+}
+
+// ListAlerts handles GET /admin/security/alerts?limit=N
+func (h *SecurityAlertsHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit, err := h.pagination.ParseRequestLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		writeError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	alerts := h.securityMonitorUseCase.RecentAlerts(limit)
+	response := SecurityAlertsResponse{Alerts: make([]SecurityAlertResponse, 0, len(alerts))}
+	for _, alert := range alerts {
+		response.Alerts = append(response.Alerts, newSecurityAlertResponse(alert, i18n.LanguageFromContext(r.Context())))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newSecurityAlertResponse(alert *entities.SecurityAlert, language i18n.Language) SecurityAlertResponse {
+	description := alert.Description
+	if localized, ok := alert.LocalizedDescriptions[string(language)]; ok {
+		description = localized
+	}
+	return SecurityAlertResponse{
+		ID:          alert.EventID,
+		Kind:        alert.Kind,
+		Severity:    alert.Severity,
+		Subject:     alert.Subject,
+		Description: description,
+		Context:     alert.Context,
+		DetectedAt:  alert.DetectedAt,
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func TestSecurityAlertsHandler_ListAlerts(t *testing.T) {
+	alert, err := entities.NewSecurityAlert(entities.SecurityAlertTelemetryBurst, entities.SecuritySeverityWarning, "AA:BB:CC:DD:EE:FF", "burst", map[string]interface{}{"readings_in_window": 120})
+	require.NoError(t, err)
+
+	t.Run("should list recent alerts", func(t *testing.T) {
+		useCase := mocks.NewMockSecurityMonitorUseCase(t)
+		useCase.EXPECT().RecentAlerts(10).Return([]*entities.SecurityAlert{alert}).Once()
+		handler := NewSecurityAlertsHandler(useCase, pagination.DefaultPolicy(), "secret")
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/security/alerts?limit=10", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.ListAlerts(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response SecurityAlertsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Alerts, 1)
+		assert.Equal(t, alert.EventID, response.Alerts[0].ID)
+		assert.Equal(t, entities.SecurityAlertTelemetryBurst, response.Alerts[0].Kind)
+		assert.EqualValues(t, 120, response.Alerts[0].Context["readings_in_window"])
+	})
+
+	t.Run("should describe alerts in the request language", func(t *testing.T) {
+		localized := *alert
+		localized.LocalizedDescriptions = map[string]string{"en": "burst", "es": "ráfaga"}
+		useCase := mocks.NewMockSecurityMonitorUseCase(t)
+		useCase.EXPECT().RecentAlerts(pagination.DefaultPolicy().DefaultLimit).Return([]*entities.SecurityAlert{&localized}).Once()
+		handler := NewSecurityAlertsHandler(useCase, pagination.DefaultPolicy(), "secret")
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/security/alerts", nil)
+		req = req.WithContext(i18n.ContextWithLanguage(req.Context(), i18n.Spanish))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.ListAlerts(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response SecurityAlertsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Alerts, 1)
+		assert.Equal(t, "ráfaga", response.Alerts[0].Description)
+	})
+
+	t.Run("should reject an invalid limit", func(t *testing.T) {
+		handler := NewSecurityAlertsHandler(mocks.NewMockSecurityMonitorUseCase(t), pagination.DefaultPolicy(), "secret")
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/security/alerts?limit=-1", nil)
+		req = req.WithContext(i18n.ContextWithLanguage(req.Context(), i18n.Spanish))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.ListAlerts(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "el límite debe ser un entero positivo\n", rec.Body.String())
+	})
+
+	t.Run("should reject a limit above the maximum page size", func(t *testing.T) {
+		handler := NewSecurityAlertsHandler(mocks.NewMockSecurityMonitorUseCase(t), pagination.Policy{DefaultLimit: 10, MaxLimit: 20}, "secret")
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/security/alerts?limit=21", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		handler.ListAlerts(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "limit exceeds the maximum page size\n", rec.Body.String())
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		handler := NewSecurityAlertsHandler(mocks.NewMockSecurityMonitorUseCase(t), pagination.DefaultPolicy(), "secret")
+
+		rec := httptest.NewRecorder()
+		handler.ListAlerts(rec, httptest.NewRequest(http.MethodGet, "/admin/security/alerts", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+)
+
+// SensorChannelResponse is the JSON representation of a probe of a device
+type SensorChannelResponse struct {
+	SensorType   string     `json:"sensor_type"`
+	Channel      int        `json:"channel"`
+	Name         string     `json:"name"`
+	Scale        float64    `json:"scale"`
+	Offset       float64    `json:"offset"`
+	CalibratedAt *time.Time `json:"calibrated_at"`
+}
+
+// SensorChannelsResponse lists the channels of a device
+type SensorChannelsResponse struct {
+	MACAddress string                  `json:"mac_address"`
+	Channels   []SensorChannelResponse `json:"channels"`
+}
+
+// ConfigureSensorChannelRequest is the body of the channel configuration endpoint
+type ConfigureSensorChannelRequest struct {
+	Name   string   `json:"name"`
+	Scale  *float64 `json:"scale"` // defaults to 1
+	Offset float64  `json:"offset"`
+}
+
+// SensorChannelsHandler lists and configures the channels of multi-sensor devices
+type SensorChannelsHandler struct {
+	measurementUseCase measurements.MeasurementUseCase
+	token              string
+}
+
+func NewSensorChannelsHandler(measurementUseCase measurements.MeasurementUseCase, token string) *SensorChannelsHandler {
+	return &SensorChannelsHandler{
+		measurementUseCase: measurementUseCase,
+		token:              token,
+	}
+}
+
+// ListChannels handles GET /api/v1/devices/{mac}/channels
+func (h *SensorChannelsHandler) ListChannels(w http.ResponseWriter, r *http.Request) {
+	channels, err := h.measurementUseCase.ListChannels(r.Context(), r.PathValue("mac"))
+	if err != nil {
+		writeError(w, r, "failed to load sensor channels", http.StatusInternalServerError)
+		return
+	}
+
+	response := SensorChannelsResponse{MACAddress: r.PathValue("mac"), Channels: make([]SensorChannelResponse, 0, len(channels))}
+	for _, channel := range channels {
+		response.MACAddress = channel.MACAddress
+		response.Channels = append(response.Channels, newSensorChannelResponse(channel))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// ConfigureChannel handles PUT /admin/devices/{mac}/channels/{type}/{channel}
+func (h *SensorChannelsHandler) ConfigureChannel(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	channel, err := strconv.Atoi(r.PathValue("channel"))
+	if err != nil {
+		writeError(w, r, "invalid channel", http.StatusBadRequest)
+		return
+	}
+
+	var request ConfigureSensorChannelRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	scale := 1.0
+	if request.Scale != nil {
+		scale = *request.Scale
+	}
+
+	configured, err := h.measurementUseCase.ConfigureChannel(r.Context(), &entities.SensorChannel{
+		MACAddress: r.PathValue("mac"),
+		SensorType: r.PathValue("type"),
+		Channel:    channel,
+		Name:       request.Name,
+		Scale:      scale,
+		Offset:     request.Offset,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrUnknownSensorType), errors.Is(err, domainerrors.ErrInvalidSensorChannel):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		default:
+			writeError(w, r, "failed to configure sensor channel", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newSensorChannelResponse(configured))
+}
+
+func newSensorChannelResponse(channel *entities.SensorChannel) SensorChannelResponse {
+	return SensorChannelResponse{
+		SensorType:   channel.SensorType,
+		Channel:      channel.Channel,
+		Name:         channel.Name,
+		Scale:        channel.Scale,
+		Offset:       channel.Offset,
+		CalibratedAt: channel.CalibratedAt,
+	}
+}
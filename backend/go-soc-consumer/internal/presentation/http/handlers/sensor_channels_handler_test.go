@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestSensorChannelsHandler_ListChannels(t *testing.T) {
+	t.Run("should list the channels of the device", func(t *testing.T) {
+		channel, err := entities.NewSensorChannel("AA:BB:CC:DD:EE:FF", "ph", 2)
+		require.NoError(t, err)
+		useCase := mocks.NewMockMeasurementUseCase(t)
+		useCase.EXPECT().ListChannels(mock.Anything, "aa:bb:cc:dd:ee:ff").Return([]*entities.SensorChannel{channel}, nil).Once()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/v1/devices/{mac}/channels", NewSensorChannelsHandler(useCase, "").ListChannels)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/devices/aa:bb:cc:dd:ee:ff/channels", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response SensorChannelsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", response.MACAddress)
+		require.Len(t, response.Channels, 1)
+		assert.Equal(t, 2, response.Channels[0].Channel)
+		assert.Equal(t, 1.0, response.Channels[0].Scale)
+	})
+
+	t.Run("should fail when channels cannot be loaded", func(t *testing.T) {
+		useCase := mocks.NewMockMeasurementUseCase(t)
+		useCase.EXPECT().ListChannels(mock.Anything, mock.Anything).Return(nil, errors.New("database down")).Once()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/v1/devices/{mac}/channels", NewSensorChannelsHandler(useCase, "").ListChannels)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF/channels", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestSensorChannelsHandler_ConfigureChannel(t *testing.T) {
+	configured, err := entities.NewSensorChannel("AA:BB:CC:DD:EE:FF", "ph", 2)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		token          string
+		channel        string
+		body           string
+		err            error
+		expectCall     bool
+		expectedScale  float64
+		expectedStatus int
+	}{
+		{name: "configured", token: "secret", channel: "2", body: `{"name":"bed 3","scale":1.02,"offset":-0.1}`, expectCall: true, expectedScale: 1.02, expectedStatus: http.StatusOK},
+		{name: "scale defaults to one", token: "secret", channel: "2", body: `{"name":"bed 3"}`, expectCall: true, expectedScale: 1, expectedStatus: http.StatusOK},
+		{name: "unknown sensor type", token: "secret", channel: "2", body: `{}`, err: domainerrors.ErrUnknownSensorType, expectCall: true, expectedScale: 1, expectedStatus: http.StatusBadRequest},
+		{name: "invalid calibration", token: "secret", channel: "2", body: `{"scale":0}`, err: domainerrors.ErrInvalidSensorChannel, expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "repository failure", token: "secret", channel: "2", body: `{}`, err: errors.New("database down"), expectCall: true, expectedScale: 1, expectedStatus: http.StatusInternalServerError},
+		{name: "invalid channel", token: "secret", channel: "first", body: `{}`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid body", token: "secret", channel: "2", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "unauthorized", token: "guess", channel: "2", body: `{}`, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockMeasurementUseCase(t)
+			if tt.expectCall {
+				useCase.EXPECT().ConfigureChannel(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, settings *entities.SensorChannel) (*entities.SensorChannel, error) {
+					assert.Equal(t, "AA:BB:CC:DD:EE:FF", settings.MACAddress)
+					assert.Equal(t, "ph", settings.SensorType)
+					assert.Equal(t, 2, settings.Channel)
+					assert.Equal(t, tt.expectedScale, settings.Scale)
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return configured, nil
+				}).Once()
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /admin/devices/{mac}/channels/{type}/{channel}", NewSensorChannelsHandler(useCase, "secret").ConfigureChannel)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/devices/AA:BB:CC:DD:EE:FF/channels/ph/"+tt.channel, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	sensorhealth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_health"
+)
+
+// SensorStreamHealthResponse is the JSON representation of the health of a sensor stream
+type SensorStreamHealthResponse struct {
+	MACAddress              string     `json:"mac_address"`
+	Measurement             string     `json:"measurement"`
+	Score                   int        `json:"score"`
+	Stale                   bool       `json:"stale"`
+	Degraded                bool       `json:"degraded"`
+	Issues                  []string   `json:"issues"`
+	Readings                int        `json:"readings"`
+	LastReadingAt           time.Time  `json:"last_reading_at"`
+	ExpectedIntervalSeconds float64    `json:"expected_interval_seconds"`
+	CalibratedAt            *time.Time `json:"calibrated_at"`
+}
+
+// SensorStreamsResponse lists sensor stream health
+type SensorStreamsResponse struct {
+	Streams []SensorStreamHealthResponse `json:"streams"`
+}
+
+// RecordCalibrationRequest is the body of the calibration endpoint
+type RecordCalibrationRequest struct {
+	CalibratedAt *time.Time `json:"calibrated_at"` // defaults to now
+}
+
+// RecordCalibrationResponse is returned after a calibration is recorded
+type RecordCalibrationResponse struct {
+	MACAddress   string    `json:"mac_address"`
+	CalibratedAt time.Time `json:"calibrated_at"`
+}
+
+// SensorHealthHandler serves sensor stream health and records device calibrations
+type SensorHealthHandler struct {
+	sensorHealthUseCase sensorhealth.SensorHealthUseCase
+	token               string
+}
+
+func NewSensorHealthHandler(sensorHealthUseCase sensorhealth.SensorHealthUseCase, token string) *SensorHealthHandler {
+	return &SensorHealthHandler{
+		sensorHealthUseCase: sensorHealthUseCase,
+		token:               token,
+	}
+}
+
+// ListStreams handles GET /api/v1/sensors/health
+func (h *SensorHealthHandler) ListStreams(w http.ResponseWriter, r *http.Request) {
+	streams, err := h.sensorHealthUseCase.Streams(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to load sensor health", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSensorStreamsResponse(streams))
+}
+
+// ListDegraded handles GET /api/v1/sensors/degraded
+func (h *SensorHealthHandler) ListDegraded(w http.ResponseWriter, r *http.Request) {
+	streams, err := h.sensorHealthUseCase.Degraded(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to load sensor health", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSensorStreamsResponse(streams))
+}
+
+// RecordCalibration handles PUT /admin/devices/{mac}/calibration
+func (h *SensorHealthHandler) RecordCalibration(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request RecordCalibrationRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, r, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	calibratedAt := time.Now()
+	if request.CalibratedAt != nil {
+		calibratedAt = *request.CalibratedAt
+	}
+
+	device, err := h.sensorHealthUseCase.RecordCalibration(r.Context(), r.PathValue("mac"), calibratedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrDeviceNotFound):
+			writeError(w, r, "device not found", http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrInvalidCalibration):
+			writeDomainError(w, r, err, http.StatusBadRequest)
+		default:
+			writeError(w, r, "failed to record calibration", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RecordCalibrationResponse{
+		MACAddress:   device.GetID(),
+		CalibratedAt: *device.CalibratedAt,
+	})
+}
+
+func newSensorStreamsResponse(streams []*entities.SensorStreamHealth) SensorStreamsResponse {
+	response := SensorStreamsResponse{Streams: make([]SensorStreamHealthResponse, 0, len(streams))}
+	for _, stream := range streams {
+		response.Streams = append(response.Streams, SensorStreamHealthResponse{
+			MACAddress:              stream.MACAddress,
+			Measurement:             stream.Measurement,
+			Score:                   stream.Score,
+			Stale:                   stream.Stale,
+			Degraded:                stream.Degraded,
+			Issues:                  stream.Issues,
+			Readings:                stream.Readings,
+			LastReadingAt:           stream.LastReadingAt,
+			ExpectedIntervalSeconds: stream.ExpectedInterval.Seconds(),
+			CalibratedAt:            stream.CalibratedAt,
+		})
+	}
+	return response
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestSensorHealthHandler_ListDegraded(t *testing.T) {
+	t.Run("should list degraded streams", func(t *testing.T) {
+		useCase := mocks.NewMockSensorHealthUseCase(t)
+		useCase.EXPECT().Degraded(mock.Anything).Return([]*entities.SensorStreamHealth{{
+			MACAddress:       "AA:BB:CC:DD:EE:FF",
+			Measurement:      entities.MeasurementHumidity,
+			Score:            40,
+			Stale:            true,
+			Degraded:         true,
+			Issues:           []string{entities.SensorIssueStale},
+			ExpectedInterval: time.Minute,
+		}}, nil).Once()
+
+		w := httptest.NewRecorder()
+		NewSensorHealthHandler(useCase, "").ListDegraded(w, httptest.NewRequest(http.MethodGet, "/api/v1/sensors/degraded", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response SensorStreamsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Streams, 1)
+		assert.Equal(t, "humidity", response.Streams[0].Measurement)
+		assert.Equal(t, 60.0, response.Streams[0].ExpectedIntervalSeconds)
+		assert.Equal(t, []string{"stale"}, response.Streams[0].Issues)
+	})
+
+	t.Run("should fail when health cannot be loaded", func(t *testing.T) {
+		useCase := mocks.NewMockSensorHealthUseCase(t)
+		useCase.EXPECT().Streams(mock.Anything).Return(nil, errors.New("database down")).Once()
+
+		w := httptest.NewRecorder()
+		NewSensorHealthHandler(useCase, "").ListStreams(w, httptest.NewRequest(http.MethodGet, "/api/v1/sensors/health", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestSensorHealthHandler_RecordCalibration(t *testing.T) {
+	calibratedAt := time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	require.NoError(t, device.RecordCalibration(calibratedAt))
+
+	tests := []struct {
+		name           string
+		token          string
+		body           string
+		device         *entities.Device
+		err            error
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "recorded", token: "secret", body: `{"calibrated_at":"2025-05-01T08:00:00Z"}`, device: device, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "defaults to now", token: "secret", device: device, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "unknown device", token: "secret", body: `{}`, err: domainerrors.ErrDeviceNotFound, expectCall: true, expectedStatus: http.StatusNotFound},
+		{name: "future calibration", token: "secret", body: `{}`, err: domainerrors.ErrInvalidCalibration, expectCall: true, expectedStatus: http.StatusBadRequest},
+		{name: "invalid body", token: "secret", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "unauthorized", token: "guess", body: `{}`, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := mocks.NewMockSensorHealthUseCase(t)
+			if tt.expectCall {
+				useCase.EXPECT().RecordCalibration(mock.Anything, "AA:BB:CC:DD:EE:FF", mock.Anything).Return(tt.device, tt.err).Once()
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /admin/devices/{mac}/calibration", NewSensorHealthHandler(useCase, "secret").RecordCalibration)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/devices/AA:BB:CC:DD:EE:FF/calibration", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Contains(t, w.Body.String(), "2025-05-01T08:00:00Z")
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	sensorreadings "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_readings"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// SensorReadingResponse is the JSON representation of a temperature and humidity reading
+type SensorReadingResponse struct {
+	MACAddress         string    `json:"mac_address"`
+	TemperatureCelsius float64   `json:"temperature_celsius"`
+	HumidityPercent    float64   `json:"humidity_percent"`
+	Timestamp          time.Time `json:"timestamp"`
+}
+
+// SensorReadingsResponse is a page of the readings of a device in [from, to), newest first
+type SensorReadingsResponse struct {
+	Readings []SensorReadingResponse `json:"readings"`
+	From     time.Time               `json:"from"`
+	To       time.Time               `json:"to"`
+	Offset   int                     `json:"offset"`
+	Limit    int                     `json:"limit"`
+}
+
+// LatestSensorReadingsResponse lists the latest reading of each device
+type LatestSensorReadingsResponse struct {
+	Readings []SensorReadingResponse `json:"readings"`
+}
+
+// SensorReadingsHandler serves the stored temperature and humidity readings
+type SensorReadingsHandler struct {
+	readingsUseCase sensorreadings.SensorReadingsUseCase
+	pagination      pagination.Policy
+}
+
+func NewSensorReadingsHandler(readingsUseCase sensorreadings.SensorReadingsUseCase, policy pagination.Policy) *SensorReadingsHandler {
+	return &SensorReadingsHandler{
+		readingsUseCase: readingsUseCase,
+		pagination:      policy,
+	}
+}
+
+// List handles GET /api/v1/devices/{mac}/readings?from=RFC3339&to=RFC3339&offset=N&limit=N,
+// defaulting to the last day
+func (h *SensorReadingsHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, err := h.pagination.ParseRequestLimit(query.Get("limit"))
+	if err != nil {
+		writeError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	readingsQuery := entities.SensorReadingsQuery{Limit: limit}
+	if value := query.Get("offset"); value != "" {
+		readingsQuery.Offset, err = strconv.Atoi(value)
+		if err != nil || readingsQuery.Offset < 0 {
+			writeError(w, r, "invalid offset", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("from"); value != "" {
+		if readingsQuery.From, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			writeError(w, r, "invalid from time", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		if readingsQuery.To, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			writeError(w, r, "invalid to time", http.StatusBadRequest)
+			return
+		}
+	}
+
+	readings, resolved, err := h.readingsUseCase.List(r.Context(), r.PathValue("mac"), readingsQuery)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidReadingsQuery) {
+			writeDomainError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		writeError(w, r, "failed to load readings", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, SensorReadingsResponse{
+		Readings: newSensorReadingResponses(readings),
+		From:     resolved.From,
+		To:       resolved.To,
+		Offset:   resolved.Offset,
+		Limit:    resolved.Limit,
+	})
+}
+
+// Latest handles GET /api/v1/devices/readings/latest?mac=A&mac=B, for every device without mac
+func (h *SensorReadingsHandler) Latest(w http.ResponseWriter, r *http.Request) {
+	readings, err := h.readingsUseCase.Latest(r.Context(), r.URL.Query()["mac"])
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidReadingsQuery) {
+			writeDomainError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		writeError(w, r, "failed to load readings", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, LatestSensorReadingsResponse{Readings: newSensorReadingResponses(readings)})
+}
+
+func newSensorReadingResponses(readings []*entities.SensorTemperatureHumidity) []SensorReadingResponse {
+	responses := make([]SensorReadingResponse, 0, len(readings))
+	for _, reading := range readings {
+		responses = append(responses, SensorReadingResponse{
+			MACAddress:         reading.MacAddress(),
+			TemperatureCelsius: reading.Temperature(),
+			HumidityPercent:    reading.Humidity(),
+			Timestamp:          reading.Timestamp(),
+		})
+	}
+	return responses
+}
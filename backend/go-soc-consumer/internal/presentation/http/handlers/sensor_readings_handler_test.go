@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func TestSensorReadingsHandler_List(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(6 * time.Hour)
+	reading, err := entities.NewSensorTemperatureHumidityAt("AA:BB:CC:DD:EE:FF", 24.5, 61, from.Add(time.Hour))
+	require.NoError(t, err)
+
+	t.Run("should return the page of readings", func(t *testing.T) {
+		useCase := mocks.NewMockSensorReadingsUseCase(t)
+		query := entities.SensorReadingsQuery{From: from, To: to, Offset: 20, Limit: 10}
+		useCase.EXPECT().List(mock.Anything, "AA:BB:CC:DD:EE:FF", query).
+			Return([]*entities.SensorTemperatureHumidity{reading}, query, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF/readings?from=2025-06-01T00:00:00Z&to=2025-06-01T06:00:00Z&offset=20&limit=10", nil)
+		req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+		rec := httptest.NewRecorder()
+		NewSensorReadingsHandler(useCase, pagination.DefaultPolicy()).List(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"temperature_celsius":24.5`)
+		assert.Contains(t, rec.Body.String(), `"timestamp":"2025-06-01T01:00:00Z"`)
+		assert.Contains(t, rec.Body.String(), `"offset":20`)
+	})
+
+	t.Run("should reject invalid parameters", func(t *testing.T) {
+		for _, rawQuery := range []string{"limit=0", "offset=-1", "from=yesterday", "to=2025-06-01"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF/readings?"+rawQuery, nil)
+			req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+			rec := httptest.NewRecorder()
+			NewSensorReadingsHandler(mocks.NewMockSensorReadingsUseCase(t), pagination.DefaultPolicy()).List(rec, req)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rawQuery)
+		}
+	})
+
+	t.Run("should map an invalid range to bad request", func(t *testing.T) {
+		useCase := mocks.NewMockSensorReadingsUseCase(t)
+		useCase.EXPECT().List(mock.Anything, "AA:BB:CC:DD:EE:FF", mock.Anything).
+			Return(nil, entities.SensorReadingsQuery{}, fmt.Errorf("%w: from must be before to", domainerrors.ErrInvalidReadingsQuery)).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF/readings?from=2025-06-02T00:00:00Z&to=2025-06-01T00:00:00Z", nil)
+		req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+		rec := httptest.NewRecorder()
+		NewSensorReadingsHandler(useCase, pagination.DefaultPolicy()).List(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestSensorReadingsHandler_Latest(t *testing.T) {
+	reading, err := entities.NewSensorTemperatureHumidityAt("AA:BB:CC:DD:EE:01", 22, 55, time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	useCase := mocks.NewMockSensorReadingsUseCase(t)
+	useCase.EXPECT().Latest(mock.Anything, []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"}).
+		Return([]*entities.SensorTemperatureHumidity{reading}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/devices/readings/latest?mac=AA:BB:CC:DD:EE:01&mac=AA:BB:CC:DD:EE:02", nil)
+	rec := httptest.NewRecorder()
+	NewSensorReadingsHandler(useCase, pagination.DefaultPolicy()).Latest(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"mac_address":"AA:BB:CC:DD:EE:01"`)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/presentation/http/middleware"
+	edgesync "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/edge_sync"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+)
+
+// maxSyncBatchBytes bounds the size of a replicated batch accepted by the cloud instance
+const maxSyncBatchBytes = 8 << 20
+
+// SyncHandler serves the edge/cloud replication endpoints.
+// On an edge instance only the status endpoint is wired, on a cloud instance only the batch endpoint.
+type SyncHandler struct {
+	edgeSyncUseCase  edgesync.EdgeSyncUseCase
+	syncApplyUseCase edgesync.SyncApplyUseCase
+	token            string
+}
+
+func NewSyncHandler(edgeSyncUseCase edgesync.EdgeSyncUseCase, syncApplyUseCase edgesync.SyncApplyUseCase, token string) *SyncHandler {
+	return &SyncHandler{
+		edgeSyncUseCase:  edgeSyncUseCase,
+		syncApplyUseCase: syncApplyUseCase,
+		token:            token,
+	}
+}
+
+// Status returns the replication state of an edge instance
+func (h *SyncHandler) Status(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status, err := h.edgeSyncUseCase.Status(r.Context())
+	if err != nil {
+		writeError(w, r, "failed to read sync status", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
+
+// ApplyBatch applies a batch pushed by an edge instance
+func (h *SyncHandler) ApplyBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, r, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !bearerAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request dtos.SyncBatchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSyncBatchBytes)).Decode(&request); err != nil {
+		writeError(w, r, "invalid sync batch", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.syncApplyUseCase.ApplyBatch(r.Context(), request.ToEntities())
+	if err != nil {
+		writeError(w, r, "failed to apply sync batch", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// bearerAuthorized reports whether the request carries the expected bearer token; an empty token rejects every request
+func bearerAuthorized(r *http.Request, token string) bool {
+	provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+// adminAuthorized reports whether the request carries the admin token or was authenticated as an
+// administrator, such as an admin user holding an access token
+func adminAuthorized(r *http.Request, token string) bool {
+	if claims := middleware.ClaimsFromContext(r.Context()); claims != nil && claims.IsAdmin() {
+		return true
+	}
+	return bearerAuthorized(r, token)
+}
+
+// localize translates the message to the language negotiated for the request
+func localize(r *http.Request, message string) string {
+	return i18n.Translate(i18n.LanguageFromContext(r.Context()), message)
+}
+
+// writeError replies with the message translated to the language negotiated for the request
+func writeError(w http.ResponseWriter, r *http.Request, message string, statusCode int) {
+	http.Error(w, localize(r, message), statusCode)
+}
+
+// writeDomainError replies with the error, translating the message of the domain error it wraps
+func writeDomainError(w http.ResponseWriter, r *http.Request, err error, statusCode int) {
+	message := err.Error()
+	var domainErr *domainerrors.DomainError
+	if errors.As(err, &domainErr) {
+		message = strings.Replace(message, domainErr.Message, localize(r, domainErr.Message), 1)
+	}
+	http.Error(w, message, statusCode)
+}
+
+// writeJSON writes the value as a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		http.Error(w, "failed to write response", http.StatusInternalServerError)
+		return
+	}
+}
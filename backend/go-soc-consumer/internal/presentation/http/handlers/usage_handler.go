@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	usagemetering "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/usage_metering"
+)
+
+// usageMonthLayout is the format of the month query parameter and of the exported months
+const usageMonthLayout = "2006-01"
+
+// UsageRecordResponse is the JSON representation of the usage of a farm in a month
+type UsageRecordResponse struct {
+	FarmID        string `json:"farm_id"`
+	Month         string `json:"month"`
+	DeviceDays    int64  `json:"device_days"`
+	Messages      int64  `json:"messages"`
+	StorageBytes  int64  `json:"storage_bytes"`
+	Notifications int64  `json:"notifications"`
+}
+
+// UsageResponse is the usage of every farm in a month
+type UsageResponse struct {
+	Month string                `json:"month"`
+	Farms []UsageRecordResponse `json:"farms"`
+}
+
+// UsageHandler exports the metered usage of the farms so hosting costs can be allocated
+type UsageHandler struct {
+	usageUseCase usagemetering.UsageMeteringUseCase
+	token        string
+	now          func() time.Time
+}
+
+func NewUsageHandler(usageUseCase usagemetering.UsageMeteringUseCase, token string) *UsageHandler {
+	return &UsageHandler{
+		usageUseCase: usageUseCase,
+		token:        token,
+		now:          time.Now,
+	}
+}
+
+// Export handles GET /admin/usage?month=2006-01&format=json|csv, the month defaulting to the current one
+func (h *UsageHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		writeError(w, r, "format must be json or csv", http.StatusBadRequest)
+		return
+	}
+
+	month := entities.UsageMonth(h.now())
+	if value := r.URL.Query().Get("month"); value != "" {
+		parsed, err := time.Parse(usageMonthLayout, value)
+		if err != nil {
+			writeError(w, r, "invalid month", http.StatusBadRequest)
+			return
+		}
+		month = parsed
+	}
+
+	records, err := h.usageUseCase.Export(r.Context(), month)
+	if err != nil {
+		writeError(w, r, "failed to export usage", http.StatusInternalServerError)
+		return
+	}
+
+	response := UsageResponse{Month: month.Format(usageMonthLayout), Farms: make([]UsageRecordResponse, 0, len(records))}
+	for _, record := range records {
+		response.Farms = append(response.Farms, UsageRecordResponse{
+			FarmID:        record.FarmID,
+			Month:         record.Month.Format(usageMonthLayout),
+			DeviceDays:    record.DeviceDays,
+			Messages:      record.Messages,
+			StorageBytes:  record.StorageBytes,
+			Notifications: record.Notifications,
+		})
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="usage-`+response.Month+`.`+format+`"`)
+	if format == "json" {
+		writeJSON(w, http.StatusOK, response)
+		return
+	}
+
+	var body bytes.Buffer
+	writer := csv.NewWriter(&body)
+	_ = writer.Write([]string{"farm_id", "month", "device_days", "messages", "storage_bytes", "notifications"})
+	for _, farm := range response.Farms {
+		_ = writer.Write([]string{
+			farm.FarmID,
+			farm.Month,
+			strconv.FormatInt(farm.DeviceDays, 10),
+			strconv.FormatInt(farm.Messages, 10),
+			strconv.FormatInt(farm.StorageBytes, 10),
+			strconv.FormatInt(farm.Notifications, 10),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		writeError(w, r, "failed to export usage", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newUsageRequest(query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/admin/usage"+query, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func TestUsageHandler_Export(t *testing.T) {
+	may := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	records := []*entities.UsageRecord{
+		{Month: may, Messages: 12, StorageBytes: 1200},
+		{FarmID: "farm-a", Month: may, DeviceDays: 310, Messages: 44640, StorageBytes: 8928000, Notifications: 3},
+	}
+
+	t.Run("should export the usage of the month as JSON", func(t *testing.T) {
+		useCase := mocks.NewMockUsageMeteringUseCase(t)
+		useCase.EXPECT().Export(mock.Anything, may).Return(records[1:], nil).Once()
+
+		rec := httptest.NewRecorder()
+		NewUsageHandler(useCase, "secret").Export(rec, newUsageRequest("?month=2025-05"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"month":"2025-05","farms":[{"farm_id":"farm-a","month":"2025-05","device_days":310,"messages":44640,"storage_bytes":8928000,"notifications":3}]}`, rec.Body.String())
+	})
+
+	t.Run("should download the usage of the current month as CSV", func(t *testing.T) {
+		useCase := mocks.NewMockUsageMeteringUseCase(t)
+		useCase.EXPECT().Export(mock.Anything, may).Return(records, nil).Once()
+		handler := NewUsageHandler(useCase, "secret")
+		handler.now = func() time.Time { return may.Add(20 * 24 * time.Hour) }
+
+		rec := httptest.NewRecorder()
+		handler.Export(rec, newUsageRequest("?format=csv"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="usage-2025-05.csv"`, rec.Header().Get("Content-Disposition"))
+		assert.Equal(t, "farm_id,month,device_days,messages,storage_bytes,notifications\n"+
+			",2025-05,0,12,1200,0\n"+
+			"farm-a,2025-05,310,44640,8928000,3\n", rec.Body.String())
+	})
+
+	t.Run("should reject invalid months and formats", func(t *testing.T) {
+		handler := NewUsageHandler(mocks.NewMockUsageMeteringUseCase(t), "secret")
+
+		rec := httptest.NewRecorder()
+		handler.Export(rec, newUsageRequest("?month=2025-13"))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		handler.Export(rec, newUsageRequest("?format=xlsx"))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should fail when the usage cannot be exported", func(t *testing.T) {
+		useCase := mocks.NewMockUsageMeteringUseCase(t)
+		useCase.EXPECT().Export(mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		rec := httptest.NewRecorder()
+		NewUsageHandler(useCase, "secret").Export(rec, newUsageRequest(""))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		req := newUsageRequest("")
+		req.Header.Del("Authorization")
+
+		rec := httptest.NewRecorder()
+		NewUsageHandler(mocks.NewMockUsageMeteringUseCase(t), "secret").Export(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	valvecontrol "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/valve_control"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// ValveStateResponse is the JSON representation of the state of a valve and the command that set it
+type ValveStateResponse struct {
+	MACAddress string     `json:"mac_address"`
+	State      string     `json:"state"` // open, closed or unknown
+	CommandID  string     `json:"command_id,omitempty"`
+	ChangedAt  *time.Time `json:"changed_at,omitempty"`
+}
+
+// ValveHistoryResponse lists the transitions of a valve, newest first
+type ValveHistoryResponse struct {
+	Transitions []ValveStateResponse `json:"transitions"`
+}
+
+// ValveHandler opens and closes the irrigation valves of devices
+type ValveHandler struct {
+	valveUseCase valvecontrol.ValveControlUseCase
+	pagination   pagination.Policy
+	token        string
+}
+
+func NewValveHandler(valveUseCase valvecontrol.ValveControlUseCase, policy pagination.Policy, token string) *ValveHandler {
+	return &ValveHandler{
+		valveUseCase: valveUseCase,
+		pagination:   policy,
+		token:        token,
+	}
+}
+
+// Open handles POST /admin/devices/{mac}/valve/open
+func (h *ValveHandler) Open(w http.ResponseWriter, r *http.Request) {
+	h.actuate(w, r, h.valveUseCase.Open)
+}
+
+// Close handles POST /admin/devices/{mac}/valve/close
+func (h *ValveHandler) Close(w http.ResponseWriter, r *http.Request) {
+	h.actuate(w, r, h.valveUseCase.Close)
+}
+
+// actuate sends a valve command; like other device commands it is accepted once published
+func (h *ValveHandler) actuate(w http.ResponseWriter, r *http.Request, send func(ctx context.Context, macAddress string) (*entities.DeviceCommand, error)) {
+	if !adminAuthorized(r, h.token) {
+		writeError(w, r, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	command, err := send(r.Context(), r.PathValue("mac"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceNotFound) {
+			writeError(w, r, "device not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to send device command", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, newDeviceCommandResponse(command))
+}
+
+// State handles GET /api/v1/devices/{mac}/valve
+func (h *ValveHandler) State(w http.ResponseWriter, r *http.Request) {
+	transition, err := h.valveUseCase.State(r.Context(), r.PathValue("mac"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceNotFound) {
+			writeError(w, r, "device not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, r, "failed to load valve state", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, newValveStateResponse(transition))
+}
+
+// History handles GET /api/v1/devices/{mac}/valve/history?limit=N, newest first
+func (h *ValveHandler) History(w http.ResponseWriter, r *http.Request) {
+	limit, err := h.pagination.ParseRequestLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		writeError(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	transitions, err := h.valveUseCase.History(r.Context(), r.PathValue("mac"), limit)
+	if err != nil {
+		writeError(w, r, "failed to load valve state", http.StatusInternalServerError)
+		return
+	}
+
+	response := ValveHistoryResponse{Transitions: make([]ValveStateResponse, 0, len(transitions))}
+	for _, transition := range transitions {
+		response.Transitions = append(response.Transitions, newValveStateResponse(transition))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newValveStateResponse(transition *entities.ValveTransition) ValveStateResponse {
+	response := ValveStateResponse{
+		MACAddress: transition.MACAddress,
+		State:      transition.State,
+		CommandID:  transition.CommandID,
+	}
+	if !transition.ChangedAt.IsZero() {
+		changedAt := transition.ChangedAt
+		response.ChangedAt = &changedAt
+	}
+	return response
+}
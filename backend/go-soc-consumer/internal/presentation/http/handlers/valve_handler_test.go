@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func TestValveHandler_Open(t *testing.T) {
+	issuedAt := time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC)
+
+	t.Run("should accept the published command", func(t *testing.T) {
+		useCase := mocks.NewMockValveControlUseCase(t)
+		useCase.EXPECT().Open(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(&entities.DeviceCommand{
+			ID: "command-1", MACAddress: "AA:BB:CC:DD:EE:FF", Command: entities.DeviceCommandValveOpen, Status: entities.DeviceCommandStatusSent,
+			IssuedAt: issuedAt, ExpiresAt: issuedAt.Add(2 * time.Minute),
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/devices/AA:BB:CC:DD:EE:FF/valve/open", nil)
+		req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		NewValveHandler(useCase, pagination.DefaultPolicy(), "secret").Open(rec, req)
+
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"command":"valve_open"`)
+		assert.Contains(t, rec.Body.String(), `"status":"sent"`)
+	})
+
+	t.Run("should map the failures to their status", func(t *testing.T) {
+		tests := []struct {
+			err      error
+			expected int
+		}{
+			{err: domainerrors.ErrDeviceNotFound, expected: http.StatusNotFound},
+			{err: errors.New("broker unavailable"), expected: http.StatusServiceUnavailable},
+		}
+		for _, tt := range tests {
+			useCase := mocks.NewMockValveControlUseCase(t)
+			useCase.EXPECT().Close(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(nil, tt.err).Once()
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/devices/AA:BB:CC:DD:EE:FF/valve/close", nil)
+			req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+			req.Header.Set("Authorization", "Bearer secret")
+			rec := httptest.NewRecorder()
+			NewValveHandler(useCase, pagination.DefaultPolicy(), "secret").Close(rec, req)
+			assert.Equal(t, tt.expected, rec.Code, tt.err.Error())
+		}
+	})
+
+	t.Run("should require the admin token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewValveHandler(mocks.NewMockValveControlUseCase(t), pagination.DefaultPolicy(), "secret").Open(rec, httptest.NewRequest(http.MethodPost, "/admin/devices/AA:BB:CC:DD:EE:FF/valve/open", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestValveHandler_State(t *testing.T) {
+	changedAt := time.Date(2025, 6, 1, 6, 0, 5, 0, time.UTC)
+
+	t.Run("should return the valve state", func(t *testing.T) {
+		useCase := mocks.NewMockValveControlUseCase(t)
+		useCase.EXPECT().State(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(&entities.ValveTransition{
+			CommandID: "command-1", MACAddress: "AA:BB:CC:DD:EE:FF", State: entities.ValveStateOpen, ChangedAt: changedAt,
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF/valve", nil)
+		req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+		rec := httptest.NewRecorder()
+		NewValveHandler(useCase, pagination.DefaultPolicy(), "secret").State(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"mac_address":"AA:BB:CC:DD:EE:FF","state":"open","command_id":"command-1","changed_at":"2025-06-01T06:00:05Z"}`, rec.Body.String())
+	})
+
+	t.Run("should omit the change of a valve never actuated", func(t *testing.T) {
+		useCase := mocks.NewMockValveControlUseCase(t)
+		useCase.EXPECT().State(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(&entities.ValveTransition{MACAddress: "AA:BB:CC:DD:EE:FF", State: entities.ValveStateUnknown}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF/valve", nil)
+		req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+		rec := httptest.NewRecorder()
+		NewValveHandler(useCase, pagination.DefaultPolicy(), "secret").State(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"mac_address":"AA:BB:CC:DD:EE:FF","state":"unknown"}`, rec.Body.String())
+	})
+
+	t.Run("should return 404 for an unknown device", func(t *testing.T) {
+		useCase := mocks.NewMockValveControlUseCase(t)
+		useCase.EXPECT().State(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF/valve", nil)
+		req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+		rec := httptest.NewRecorder()
+		NewValveHandler(useCase, pagination.DefaultPolicy(), "secret").State(rec, req)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestValveHandler_History(t *testing.T) {
+	changedAt := time.Date(2025, 6, 1, 6, 0, 5, 0, time.UTC)
+	useCase := mocks.NewMockValveControlUseCase(t)
+	useCase.EXPECT().History(mock.Anything, "AA:BB:CC:DD:EE:FF", 2).Return([]*entities.ValveTransition{
+		{CommandID: "command-2", MACAddress: "AA:BB:CC:DD:EE:FF", State: entities.ValveStateClosed, ChangedAt: changedAt.Add(time.Hour)},
+		{CommandID: "command-1", MACAddress: "AA:BB:CC:DD:EE:FF", State: entities.ValveStateOpen, ChangedAt: changedAt},
+	}, nil).Once()
+	handler := NewValveHandler(useCase, pagination.DefaultPolicy(), "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF/valve/history?limit=2", nil)
+	req.SetPathValue("mac", "AA:BB:CC:DD:EE:FF")
+	rec := httptest.NewRecorder()
+	handler.History(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"transitions":[
+		{"mac_address":"AA:BB:CC:DD:EE:FF","state":"closed","command_id":"command-2","changed_at":"2025-06-01T07:00:05Z"},
+		{"mac_address":"AA:BB:CC:DD:EE:FF","state":"open","command_id":"command-1","changed_at":"2025-06-01T06:00:05Z"}]}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/devices/AA:BB:CC:DD:EE:FF/valve/history?limit=nope", nil)
+	rec = httptest.NewRecorder()
+	handler.History(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
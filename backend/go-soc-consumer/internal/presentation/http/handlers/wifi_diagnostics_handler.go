@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	wifidiagnostics "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/wifi_diagnostics"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+)
+
+// WifiLocationResponse is the JSON representation of the Wi-Fi link of the devices of a location
+type WifiLocationResponse struct {
+	FarmID            string    `json:"farm_id,omitempty"`
+	Location          string    `json:"location"`
+	Devices           int       `json:"devices"`
+	AverageRSSI       float64   `json:"average_rssi"`
+	WeakestRSSI       int       `json:"weakest_rssi"`
+	WeakSignalDevices []string  `json:"weak_signal_devices"`
+	Reconnects        int       `json:"reconnects"`
+	SSIDs             []string  `json:"ssids"`
+	Channels          []int     `json:"channels"`
+	LastReportAt      time.Time `json:"last_report_at"`
+}
+
+// WifiLocationsResponse lists the locations, ordered by farm and location
+type WifiLocationsResponse struct {
+	Locations []WifiLocationResponse `json:"locations"`
+}
+
+// WifiAdvisoryResponse is the JSON representation of a Wi-Fi advisory
+type WifiAdvisoryResponse struct {
+	Kind        string    `json:"kind"`
+	FarmID      string    `json:"farm_id,omitempty"`
+	Location    string    `json:"location"`
+	Devices     []string  `json:"devices"`
+	Threshold   int       `json:"threshold,omitempty"`
+	Description string    `json:"description"`
+	Since       time.Time `json:"since"`
+}
+
+// WifiAdvisoriesResponse lists the advisories, most devices first
+type WifiAdvisoriesResponse struct {
+	Advisories []WifiAdvisoryResponse `json:"advisories"`
+}
+
+// WifiDiagnosticsHandler serves the Wi-Fi link of devices by location and the advisories it raises
+type WifiDiagnosticsHandler struct {
+	wifiUseCase wifidiagnostics.WifiDiagnosticsUseCase
+}
+
+func NewWifiDiagnosticsHandler(wifiUseCase wifidiagnostics.WifiDiagnosticsUseCase) *WifiDiagnosticsHandler {
+	return &WifiDiagnosticsHandler{wifiUseCase: wifiUseCase}
+}
+
+// Locations handles GET /api/v1/wifi/locations
+func (h *WifiDiagnosticsHandler) Locations(w http.ResponseWriter, r *http.Request) {
+	locations := h.wifiUseCase.Locations()
+	response := WifiLocationsResponse{Locations: make([]WifiLocationResponse, 0, len(locations))}
+	for _, location := range locations {
+		response.Locations = append(response.Locations, WifiLocationResponse{
+			FarmID:            location.FarmID,
+			Location:          location.Location,
+			Devices:           location.Devices,
+			AverageRSSI:       location.AverageRSSI,
+			WeakestRSSI:       location.WeakestRSSI,
+			WeakSignalDevices: location.WeakSignalDevices,
+			Reconnects:        location.Reconnects,
+			SSIDs:             location.SSIDs,
+			Channels:          location.Channels,
+			LastReportAt:      location.LastReportAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Advisories handles GET /api/v1/wifi/advisories
+func (h *WifiDiagnosticsHandler) Advisories(w http.ResponseWriter, r *http.Request) {
+	language := i18n.LanguageFromContext(r.Context())
+	advisories := h.wifiUseCase.Advisories()
+	response := WifiAdvisoriesResponse{Advisories: make([]WifiAdvisoryResponse, 0, len(advisories))}
+	for _, advisory := range advisories {
+		response.Advisories = append(response.Advisories, newWifiAdvisoryResponse(advisory, language))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func newWifiAdvisoryResponse(advisory *entities.WifiAdvisory, language i18n.Language) WifiAdvisoryResponse {
+	description := advisory.Description
+	if localized, ok := advisory.LocalizedDescriptions[string(language)]; ok {
+		description = localized
+	}
+	return WifiAdvisoryResponse{
+		Kind:        advisory.Kind,
+		FarmID:      advisory.FarmID,
+		Location:    advisory.Location,
+		Devices:     advisory.Devices,
+		Threshold:   advisory.Threshold,
+		Description: description,
+		Since:       advisory.Since,
+	}
+}
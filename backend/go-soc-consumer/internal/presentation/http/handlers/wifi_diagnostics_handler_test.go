@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+)
+
+func TestWifiDiagnosticsHandler(t *testing.T) {
+	reportedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should list the locations", func(t *testing.T) {
+		useCase := mocks.NewMockWifiDiagnosticsUseCase(t)
+		useCase.EXPECT().Locations().Return([]*entities.WifiLocationStats{{
+			Location: "Zone B", Devices: 2, AverageRSSI: -78.5, WeakestRSSI: -85,
+			WeakSignalDevices: []string{"AA:BB:CC:DD:EE:01"}, Reconnects: 3,
+			SSIDs: []string{"finca"}, Channels: []int{6}, LastReportAt: reportedAt,
+		}}).Once()
+
+		rec := httptest.NewRecorder()
+		NewWifiDiagnosticsHandler(useCase).Locations(rec, httptest.NewRequest(http.MethodGet, "/api/v1/wifi/locations", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response WifiLocationsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Locations, 1)
+		assert.Equal(t, "Zone B", response.Locations[0].Location)
+		assert.Equal(t, -85, response.Locations[0].WeakestRSSI)
+		assert.Equal(t, []string{"AA:BB:CC:DD:EE:01"}, response.Locations[0].WeakSignalDevices)
+		assert.Equal(t, reportedAt, response.Locations[0].LastReportAt)
+	})
+
+	t.Run("should list the advisories in the request language", func(t *testing.T) {
+		useCase := mocks.NewMockWifiDiagnosticsUseCase(t)
+		useCase.EXPECT().Advisories().Return([]*entities.WifiAdvisory{{
+			Kind: entities.WifiAdvisoryWeakSignal, Location: "Zone B", Devices: []string{"AA:BB:CC:DD:EE:01"},
+			Threshold: -80, Description: "weak", LocalizedDescriptions: map[string]string{"en": "weak", "es": "débil"}, Since: reportedAt,
+		}}).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/wifi/advisories", nil)
+		req = req.WithContext(i18n.ContextWithLanguage(req.Context(), i18n.Spanish))
+		rec := httptest.NewRecorder()
+		NewWifiDiagnosticsHandler(useCase).Advisories(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var response WifiAdvisoriesResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Advisories, 1)
+		assert.Equal(t, entities.WifiAdvisoryWeakSignal, response.Advisories[0].Kind)
+		assert.Equal(t, -80, response.Advisories[0].Threshold)
+		assert.Equal(t, "débil", response.Advisories[0].Description)
+	})
+}
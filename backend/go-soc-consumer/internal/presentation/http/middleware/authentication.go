@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+)
+
+// protectedPrefix is the path prefix of the routes requiring an access token
+const protectedPrefix = "/api/"
+
+// AdminTokenSubject is the subject of the claims of requests made with the admin token
+const AdminTokenSubject = "admin-token"
+
+// How browsers, which cannot set headers on a WebSocket handshake, present their access token: as
+// the subprotocol following "bearer" in Sec-WebSocket-Protocol, or in the access_token parameter
+const (
+	WebSocketTokenProtocol  = "bearer"
+	webSocketTokenParameter = "access_token"
+)
+
+// AuthenticateFunc returns the claims of a valid access token
+type AuthenticateFunc func(token string) (*entities.AuthClaims, error)
+
+type claimsKey struct{}
+
+// ContextWithClaims returns a context carrying the claims of the authenticated user
+func ContextWithClaims(ctx context.Context, claims *entities.AuthClaims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims of the authenticated user, nil when the request was not authenticated
+func ClaimsFromContext(ctx context.Context) *entities.AuthClaims {
+	claims, _ := ctx.Value(claimsKey{}).(*entities.AuthClaims)
+	return claims
+}
+
+// Authentication requires a bearer access token on the routes under /api/, except those under the
+// exempt path prefixes, and stores its claims in the request context. The admin token is accepted
+// as an administrator so the admin clients keep working.
+func Authentication(authenticate AuthenticateFunc, adminToken string, exempt ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, protectedPrefix) || isExempt(r.URL.Path, exempt) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, message := authenticateRequest(r, authenticate, adminToken)
+			if claims == nil {
+				writeUnauthorized(w, r, message)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
+		})
+	}
+}
+
+// RequireAdmin only lets administrators through: callers presenting the admin token or the access
+// token of an admin user. Viewers get a 403. Claims already stored by Authentication are reused,
+// and authenticate may be nil when there are no users, leaving the admin token as the only way in.
+func RequireAdmin(authenticate AuthenticateFunc, adminToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := ClaimsFromContext(r.Context())
+			if claims == nil {
+				var message string
+				if claims, message = authenticateRequest(r, authenticate, adminToken); claims == nil {
+					writeUnauthorized(w, r, message)
+					return
+				}
+			}
+			if !claims.IsAdmin() {
+				http.Error(w, i18n.Translate(i18n.LanguageFromContext(r.Context()), "forbidden"), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
+		})
+	}
+}
+
+// authenticateRequest returns the claims of the bearer token of the request, or nil with the
+// message explaining the rejection
+func authenticateRequest(r *http.Request, authenticate AuthenticateFunc, adminToken string) (*entities.AuthClaims, string) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return nil, "unauthorized"
+	}
+
+	if adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+		return &entities.AuthClaims{Subject: AdminTokenSubject, Username: "admin", Role: entities.UserRoleAdmin}, ""
+	}
+	if authenticate == nil {
+		return nil, "unauthorized"
+	}
+
+	claims, err := authenticate(token)
+	if err != nil {
+		// The reason a token was rejected is not disclosed beyond the domain error
+		var domainErr *domainerrors.DomainError
+		if errors.As(err, &domainErr) {
+			return nil, domainErr.Message
+		}
+		return nil, "unauthorized"
+	}
+	return claims, ""
+}
+
+// WebSocketToken moves the access token a browser presents on the WebSocket routes at paths to the
+// Authorization header, so Authentication, which must come after it, checks it like any other.
+// Requests already carrying an Authorization header and the other routes are left untouched.
+func WebSocketToken(paths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "" || !slices.Contains(paths, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if token := webSocketToken(r); token != "" {
+				r = r.Clone(r.Context())
+				r.Header.Set("Authorization", "Bearer "+token)
+				// Keep the token out of the URLs logged further down
+				query := r.URL.Query()
+				query.Del(webSocketTokenParameter)
+				r.URL.RawQuery = query.Encode()
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// webSocketToken returns the access token of the subprotocols of a WebSocket handshake, or of its query
+func webSocketToken(r *http.Request) string {
+	var protocols []string
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			protocols = append(protocols, strings.TrimSpace(protocol))
+		}
+	}
+	for i, protocol := range protocols {
+		if protocol == WebSocketTokenProtocol && i+1 < len(protocols) {
+			return protocols[i+1]
+		}
+	}
+	return r.URL.Query().Get(webSocketTokenParameter)
+}
+
+func isExempt(path string, exempt []string) bool {
+	for _, prefix := range exempt {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeUnauthorized(w http.ResponseWriter, r *http.Request, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	http.Error(w, i18n.Translate(i18n.LanguageFromContext(r.Context()), message), http.StatusUnauthorized)
+}
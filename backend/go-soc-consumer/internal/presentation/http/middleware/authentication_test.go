@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+)
+
+func TestAuthentication(t *testing.T) {
+	authenticate := func(token string) (*entities.AuthClaims, error) {
+		switch token {
+		case "valid":
+			return &entities.AuthClaims{Subject: "user-1", Username: "maria", Role: entities.UserRoleViewer}, nil
+		case "expired":
+			return nil, domainerrors.ErrTokenExpired
+		default:
+			return nil, fmt.Errorf("%w: bad signature", domainerrors.ErrInvalidToken)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		expected      int
+		subject       string
+		body          string
+	}{
+		{name: "valid token", path: "/api/v1/devices", authorization: "Bearer valid", expected: http.StatusOK, subject: "user-1"},
+		{name: "admin token", path: "/api/v1/devices", authorization: "Bearer secret", expected: http.StatusOK, subject: AdminTokenSubject},
+		{name: "missing token", path: "/api/v1/devices", expected: http.StatusUnauthorized, body: "no autorizado\n"},
+		{name: "expired token", path: "/api/v1/devices", authorization: "Bearer expired", expected: http.StatusUnauthorized, body: "Token de acceso expirado\n"},
+		{name: "forged token", path: "/api/v1/devices", authorization: "Bearer forged", expected: http.StatusUnauthorized, body: "Token de acceso inválido\n"},
+		{name: "exempt route", path: "/api/v1/docs", expected: http.StatusOK},
+		{name: "route outside the API", path: "/auth/login", expected: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var claims *entities.AuthClaims
+			handler := Authentication(authenticate, "secret", "/api/v1/docs")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				claims = ClaimsFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			r = r.WithContext(i18n.ContextWithLanguage(r.Context(), i18n.Spanish))
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.subject != "" {
+				assert.Equal(t, tt.subject, claims.Subject)
+			}
+			if tt.body != "" {
+				assert.Equal(t, tt.body, w.Body.String())
+				assert.Equal(t, `Bearer realm="api"`, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	authenticate := func(token string) (*entities.AuthClaims, error) {
+		switch token {
+		case "admin-user":
+			return &entities.AuthClaims{Subject: "user-1", Username: "ana", Role: entities.UserRoleAdmin}, nil
+		case "viewer":
+			return &entities.AuthClaims{Subject: "user-2", Username: "maria", Role: entities.UserRoleViewer}, nil
+		default:
+			return nil, domainerrors.ErrInvalidToken
+		}
+	}
+
+	tests := []struct {
+		name          string
+		authenticate  AuthenticateFunc
+		authorization string
+		expected      int
+		subject       string
+	}{
+		{name: "admin token", authenticate: authenticate, authorization: "Bearer secret", expected: http.StatusOK, subject: AdminTokenSubject},
+		{name: "admin user", authenticate: authenticate, authorization: "Bearer admin-user", expected: http.StatusOK, subject: "user-1"},
+		{name: "viewer", authenticate: authenticate, authorization: "Bearer viewer", expected: http.StatusForbidden},
+		{name: "forged token", authenticate: authenticate, authorization: "Bearer forged", expected: http.StatusUnauthorized},
+		{name: "missing token", authenticate: authenticate, expected: http.StatusUnauthorized},
+		{name: "access token without users", authorization: "Bearer admin-user", expected: http.StatusUnauthorized},
+		{name: "admin token without users", authorization: "Bearer secret", expected: http.StatusOK, subject: AdminTokenSubject},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var claims *entities.AuthClaims
+			handler := RequireAdmin(tt.authenticate, "secret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				claims = ClaimsFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodPost, "/admin/farms", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.subject != "" {
+				assert.Equal(t, tt.subject, claims.Subject)
+			}
+		})
+	}
+
+	t.Run("should reuse the claims stored by the authentication", func(t *testing.T) {
+		handler := Authentication(authenticate, "secret")(RequireAdmin(authenticate, "secret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+		r := httptest.NewRequest(http.MethodDelete, "/api/v1/devices/AA:BB:CC:DD:EE:FF", nil)
+		r.Header.Set("Authorization", "Bearer viewer")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestWebSocketToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		protocol      string
+		authorization string
+		expected      string
+		query         string
+	}{
+		{name: "subprotocol", target: "/ws", protocol: "bearer, token-1", expected: "Bearer token-1"},
+		{name: "query parameter", target: "/ws?access_token=token-1&since=1", expected: "Bearer token-1", query: "since=1"},
+		{name: "authorization header first", target: "/ws?access_token=token-1", authorization: "Bearer header", expected: "Bearer header", query: "access_token=token-1"},
+		{name: "other route", target: "/api/v1/devices?access_token=token-1", query: "access_token=token-1"},
+		{name: "no token", target: "/ws", protocol: "chat"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var forwarded *http.Request
+			handler := WebSocketToken("/ws")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				forwarded = r
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.protocol != "" {
+				r.Header.Set("Sec-WebSocket-Protocol", tt.protocol)
+			}
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.Equal(t, tt.expected, forwarded.Header.Get("Authorization"))
+			assert.Equal(t, tt.query, forwarded.URL.RawQuery)
+		})
+	}
+}
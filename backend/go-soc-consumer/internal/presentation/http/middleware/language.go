@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+)
+
+// Language negotiates the response language from the Accept-Language header and stores it
+// in the request context, using fallback for clients that do not ask for a supported language
+func Language(fallback i18n.Language) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			language := i18n.Negotiate(r.Header.Get("Accept-Language"), fallback)
+			w.Header().Set("Content-Language", string(language))
+			w.Header().Add("Vary", "Accept-Language")
+			next.ServeHTTP(w, r.WithContext(i18n.ContextWithLanguage(r.Context(), language)))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+)
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       i18n.Language
+	}{
+		{name: "requested language", acceptLanguage: "en-US,en;q=0.9", expected: i18n.English},
+		{name: "fallback language", acceptLanguage: "", expected: i18n.Spanish},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var language i18n.Language
+			handler := Language(i18n.Spanish)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				language = i18n.LanguageFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			r.Header.Set("Accept-Language", tt.acceptLanguage)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expected, language)
+			assert.Equal(t, string(tt.expected), w.Header().Get("Content-Language"))
+		})
+	}
+}
@@ -0,0 +1,459 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// streamIdleTTL is how long the rule state of a silent stream is kept
+const streamIdleTTL = 24 * time.Hour
+
+// AlertingConfig holds the alert history and composite rule evaluation settings
+type AlertingConfig struct {
+	AlertHistory int           // number of recent alerts kept in memory
+	Interval     time.Duration // how often composite rules are evaluated
+	MaxAge       time.Duration // values older than this are ignored by composite rules
+}
+
+// DefaultAlertingConfig returns default configuration
+func DefaultAlertingConfig() *AlertingConfig {
+	return &AlertingConfig{
+		AlertHistory: 100,
+		Interval:     time.Minute,
+		MaxAge:       15 * time.Minute,
+	}
+}
+
+// AlertingUseCase evaluates alert rules against stored values and raises alerts when they fire and resolve
+type AlertingUseCase interface {
+	// ObserveMeasurements evaluates the rules of the sensor types of the measurements on their streams
+	ObserveMeasurements(ctx context.Context, values []*entities.Measurement)
+
+	// EvaluateComposites evaluates the composite rules over the latest value of the streams they read
+	EvaluateComposites(ctx context.Context) error
+
+	// Run evaluates the composite rules periodically until the context is cancelled
+	Run(ctx context.Context)
+
+	// ActiveAlerts returns the alerts currently firing, oldest first
+	ActiveAlerts() []*entities.Alert
+
+	// RecentAlerts returns up to limit of the most recent alerts, newest first
+	RecentAlerts(limit int) []*entities.Alert
+}
+
+// streamState is the state of a rule on one channel of a device, or of a composite rule
+type streamState struct {
+	state    entities.AlertRuleState
+	active   *entities.Alert // the firing alert, nil when the rule is not firing
+	lastSeen time.Time
+}
+
+// useCaseImpl implements the AlertingUseCase interface
+type useCaseImpl struct {
+	rules          map[string][]*entities.AlertRule // sensor type to its rules
+	composites     []*entities.CompositeAlertRule
+	repo           repositoryports.MeasurementRepository
+	devices        repositoryports.DeviceRepository
+	config         *AlertingConfig
+	eventPublisher eventports.EventPublisher
+	notifier       ports.AlertNotifier
+	loggerFactory  logger.LoggerFactory
+	now            func() time.Time
+
+	mu        sync.Mutex
+	streams   map[string]*streamState
+	lastSweep time.Time
+	alerts    []*entities.Alert
+
+	alertsTotal  *metrics.Vec
+	alertsActive *metrics.Vec
+}
+
+// NewAlertingUseCase creates a new alerting use case. Composite rules read the latest measurements
+// from repo and the connection state of devices from devices; eventPublisher and notifier are optional.
+func NewAlertingUseCase(
+	rules []*entities.AlertRule,
+	composites []*entities.CompositeAlertRule,
+	repo repositoryports.MeasurementRepository,
+	devices repositoryports.DeviceRepository,
+	config *AlertingConfig,
+	eventPublisher eventports.EventPublisher,
+	notifier ports.AlertNotifier,
+	loggerFactory logger.LoggerFactory,
+) AlertingUseCase {
+	if config == nil {
+		config = DefaultAlertingConfig()
+	}
+
+	bySensorType := make(map[string][]*entities.AlertRule)
+	for _, rule := range rules {
+		bySensorType[rule.SensorType] = append(bySensorType[rule.SensorType], rule)
+	}
+
+	return &useCaseImpl{
+		rules:          bySensorType,
+		composites:     composites,
+		repo:           repo,
+		devices:        devices,
+		config:         config,
+		eventPublisher: eventPublisher,
+		notifier:       notifier,
+		loggerFactory:  loggerFactory,
+		now:            time.Now,
+		streams:        make(map[string]*streamState),
+		alertsTotal:    metrics.NewCounterVec("alerts_total", "Alerts raised by alert rules", "rule", "status"),
+		alertsActive:   metrics.NewGaugeVec("alerts_active", "Streams on which an alert rule is firing", "rule"),
+	}
+}
+
+// ObserveMeasurements evaluates every rule of the sensor type of each measurement, at the time the
+// measurement was taken. Values older than the last one evaluated on the stream are ignored.
+func (uc *useCaseImpl) ObserveMeasurements(ctx context.Context, values []*entities.Measurement) {
+	var raised []*entities.Alert
+
+	uc.mu.Lock()
+	for _, measurement := range values {
+		for _, rule := range uc.rules[measurement.SensorType] {
+			if alert := uc.evaluateLocked(rule, measurement); alert != nil {
+				raised = append(raised, alert)
+			}
+		}
+		uc.sweepLocked(measurement.Timestamp)
+	}
+	uc.mu.Unlock()
+
+	for _, alert := range raised {
+		uc.publish(ctx, alert)
+	}
+}
+
+// EvaluateComposites evaluates every composite rule over one snapshot of the latest values of the
+// streams they read and of the connection state of their devices
+func (uc *useCaseImpl) EvaluateComposites(ctx context.Context) error {
+	if len(uc.composites) == 0 {
+		return nil
+	}
+
+	seenTypes, seenDevices := make(map[string]bool), make(map[string]bool)
+	var sensorTypes, onlineDevices []string
+	for _, rule := range uc.composites {
+		for _, sensorType := range rule.SensorTypes() {
+			if !seenTypes[sensorType] {
+				seenTypes[sensorType] = true
+				sensorTypes = append(sensorTypes, sensorType)
+			}
+		}
+		if !rule.ReadsOnline() {
+			continue
+		}
+		for _, macAddress := range rule.MACAddresses() {
+			if !seenDevices[macAddress] {
+				seenDevices[macAddress] = true
+				onlineDevices = append(onlineDevices, macAddress)
+			}
+		}
+	}
+
+	now := uc.now()
+	var latest []*entities.Measurement
+	if len(sensorTypes) > 0 {
+		var err error
+		if latest, err = uc.repo.Latest(ctx, sensorTypes, now.Add(-uc.config.MaxAge)); err != nil {
+			return fmt.Errorf("failed to load latest measurements: %w", err)
+		}
+	}
+	var devices []*entities.Device
+	if len(onlineDevices) > 0 {
+		var err error
+		if devices, err = uc.devices.FindByMACAddresses(ctx, onlineDevices); err != nil {
+			return fmt.Errorf("failed to load devices: %w", err)
+		}
+	}
+	snapshot := entities.NewStreamSnapshot(latest, devices)
+
+	var raised []*entities.Alert
+	uc.mu.Lock()
+	for _, rule := range uc.composites {
+		if alert := uc.evaluateCompositeLocked(rule, snapshot, now); alert != nil {
+			raised = append(raised, alert)
+		}
+	}
+	uc.mu.Unlock()
+
+	for _, alert := range raised {
+		uc.publish(ctx, alert)
+	}
+	return nil
+}
+
+// Run evaluates the composite rules periodically until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	if len(uc.composites) == 0 {
+		return
+	}
+
+	uc.loggerFactory.Application().LogApplicationEvent("composite_alert_rules_started", "alerting_usecase",
+		zap.Int("rules", len(uc.composites)),
+		zap.Duration("interval", uc.config.Interval),
+		zap.Duration("max_age", uc.config.MaxAge),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("composite_alert_rules_stopped", "alerting_usecase")
+			return
+		case <-ticker.C:
+		}
+
+		if err := uc.EvaluateComposites(ctx); err != nil && ctx.Err() == nil {
+			uc.loggerFactory.Core().Error("composite_alert_rules_evaluation_failed",
+				zap.Error(err),
+				zap.String("component", "alerting_usecase"),
+			)
+		}
+	}
+}
+
+// ActiveAlerts returns the alerts currently firing, oldest first
+func (uc *useCaseImpl) ActiveAlerts() []*entities.Alert {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	alerts := make([]*entities.Alert, 0)
+	for _, stream := range uc.streams {
+		if stream.active != nil {
+			alerts = append(alerts, stream.active)
+		}
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].RaisedAt.Before(alerts[j].RaisedAt)
+	})
+	return alerts
+}
+
+// RecentAlerts returns up to limit of the most recent alerts, newest first
+func (uc *useCaseImpl) RecentAlerts(limit int) []*entities.Alert {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if limit <= 0 || limit > len(uc.alerts) {
+		limit = len(uc.alerts)
+	}
+	alerts := make([]*entities.Alert, 0, limit)
+	for i := len(uc.alerts) - 1; i >= 0 && len(alerts) < limit; i-- {
+		alerts = append(alerts, uc.alerts[i])
+	}
+	return alerts
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return append(uc.alertsTotal.Collect(), uc.alertsActive.Collect()...)
+}
+
+// MetricsCollector returns the alerting metrics collector
+func MetricsCollector(useCase AlertingUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
+
+// evaluateLocked evaluates a rule on the stream of a measurement and returns the alert it raised, if any
+func (uc *useCaseImpl) evaluateLocked(rule *entities.AlertRule, measurement *entities.Measurement) *entities.Alert {
+	key := rule.Name + "|" + measurement.MACAddress + "|" + strconv.Itoa(measurement.Channel)
+	stream, ok := uc.streams[key]
+	if !ok {
+		stream = &streamState{}
+		uc.streams[key] = stream
+	}
+	if measurement.Timestamp.Before(stream.lastSeen) {
+		return nil
+	}
+	stream.lastSeen = measurement.Timestamp
+
+	since := stream.state.BreachedSince
+	switch rule.Evaluate(&stream.state, measurement.Value, measurement.Timestamp) {
+	case entities.AlertTransitionFired:
+		since = stream.state.BreachedSince
+		format := "%s of %s channel %d is %v, above %v for %s"
+		if rule.Operator == entities.AlertOperatorBelow {
+			format = "%s of %s channel %d is %v, below %v for %s"
+		}
+		alert := uc.newAlert(rule, entities.AlertStatusFiring, measurement, since, format,
+			rule.SensorType, measurement.MACAddress, measurement.Channel, measurement.Value, rule.Threshold, measurement.Timestamp.Sub(since).Round(time.Second),
+		)
+		if alert == nil {
+			return nil
+		}
+		stream.active = alert
+		uc.alertsActive.Add(1, rule.Name)
+		uc.recordLocked(alert)
+		return alert
+	case entities.AlertTransitionResolved:
+		alert := uc.newAlert(rule, entities.AlertStatusResolved, measurement, since, "%s of %s channel %d recovered to %v",
+			rule.SensorType, measurement.MACAddress, measurement.Channel, measurement.Value,
+		)
+		stream.active = nil
+		uc.alertsActive.Add(-1, rule.Name)
+		if alert == nil {
+			return nil
+		}
+		uc.recordLocked(alert)
+		return alert
+	}
+	return nil
+}
+
+// evaluateCompositeLocked evaluates a composite rule and returns the alert it raised, if any
+func (uc *useCaseImpl) evaluateCompositeLocked(rule *entities.CompositeAlertRule, snapshot *entities.StreamSnapshot, now time.Time) *entities.Alert {
+	key := "composite|" + rule.Name
+	stream, ok := uc.streams[key]
+	if !ok {
+		stream = &streamState{}
+		uc.streams[key] = stream
+	}
+	stream.lastSeen = now
+
+	since := stream.state.BreachedSince
+	transition, err := rule.Evaluate(&stream.state, snapshot, now)
+	if err != nil {
+		uc.loggerFactory.Core().Warn("composite_alert_rule_evaluation_failed",
+			zap.Error(err),
+			zap.String("rule", rule.Name),
+			zap.String("component", "alerting_usecase"),
+		)
+		return nil
+	}
+
+	var alert *entities.Alert
+	switch transition {
+	case entities.AlertTransitionFired:
+		since = stream.state.BreachedSince
+		alert = uc.newCompositeAlert(rule, entities.AlertStatusFiring, since, now, "condition %s of rule %s held for %s",
+			rule.Condition, rule.Name, now.Sub(since).Round(time.Second),
+		)
+		if alert == nil {
+			return nil
+		}
+		stream.active = alert
+		uc.alertsActive.Add(1, rule.Name)
+	case entities.AlertTransitionResolved:
+		alert = uc.newCompositeAlert(rule, entities.AlertStatusResolved, since, now, "condition %s of rule %s cleared",
+			rule.Condition, rule.Name,
+		)
+		stream.active = nil
+		uc.alertsActive.Add(-1, rule.Name)
+		if alert == nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+	uc.recordLocked(alert)
+	return alert
+}
+
+// newCompositeAlert creates an alert of a composite rule described in every language
+func (uc *useCaseImpl) newCompositeAlert(rule *entities.CompositeAlertRule, status string, since, at time.Time, format string, args ...interface{}) *entities.Alert {
+	alert, err := entities.NewCompositeAlert(rule, status, since, at, fmt.Sprintf(format, args...))
+	if err != nil {
+		uc.loggerFactory.Core().Error("failed_to_create_alert",
+			zap.Error(err),
+			zap.String("rule", rule.Name),
+			zap.String("component", "alerting_usecase"),
+		)
+		return nil
+	}
+	alert.LocalizedDescriptions = i18n.SprintfAll(format, args...)
+	return alert
+}
+
+// newAlert creates an alert described in every language; the description format is one of the i18n catalog messages
+func (uc *useCaseImpl) newAlert(rule *entities.AlertRule, status string, measurement *entities.Measurement, since time.Time, format string, args ...interface{}) *entities.Alert {
+	alert, err := entities.NewAlert(rule, status, measurement.MACAddress, measurement.Channel, measurement.Value, since, measurement.Timestamp, fmt.Sprintf(format, args...))
+	if err != nil {
+		uc.loggerFactory.Core().Error("failed_to_create_alert",
+			zap.Error(err),
+			zap.String("rule", rule.Name),
+			zap.String("component", "alerting_usecase"),
+		)
+		return nil
+	}
+	alert.LocalizedDescriptions = i18n.SprintfAll(format, args...)
+	return alert
+}
+
+// recordLocked adds an alert to the bounded history
+func (uc *useCaseImpl) recordLocked(alert *entities.Alert) {
+	uc.alerts = append(uc.alerts, alert)
+	if len(uc.alerts) > uc.config.AlertHistory {
+		uc.alerts = uc.alerts[len(uc.alerts)-uc.config.AlertHistory:]
+	}
+	uc.alertsTotal.Inc(alert.Rule, alert.Status)
+}
+
+// publish logs an alert, notifies users of it and publishes it
+func (uc *useCaseImpl) publish(ctx context.Context, alert *entities.Alert) {
+	uc.loggerFactory.Core().Warn("alert",
+		zap.String("event_id", alert.EventID),
+		zap.String("rule", alert.Rule),
+		zap.String("status", alert.Status),
+		zap.String("severity", alert.Severity),
+		zap.String("mac_address", alert.MACAddress),
+		zap.Int("channel", alert.Channel),
+		zap.String("condition", alert.Condition),
+		zap.String("description", alert.Description),
+		zap.String("component", "alerting_usecase"),
+	)
+
+	if uc.notifier != nil {
+		uc.notifier.Notify(ctx, alert)
+	}
+	if uc.eventPublisher == nil || !uc.eventPublisher.IsConnected() {
+		return
+	}
+	if err := uc.eventPublisher.Publish(ctx, alert.GetSubject(), alert); err != nil {
+		uc.loggerFactory.Messaging().LogEventPublishing("alert", alert.GetSubject(), alert.EventID, false, err)
+		return
+	}
+	uc.loggerFactory.Messaging().LogEventPublishing("alert", alert.GetSubject(), alert.EventID, true, nil)
+}
+
+// sweepLocked forgets streams that went silent so memory stays bounded; their firing alerts are
+// dropped from the active alerts but stay in the history
+func (uc *useCaseImpl) sweepLocked(now time.Time) {
+	if now.Sub(uc.lastSweep) < time.Hour {
+		return
+	}
+	uc.lastSweep = now
+
+	for key, stream := range uc.streams {
+		if now.Sub(stream.lastSeen) <= streamIdleTTL {
+			continue
+		}
+		if stream.active != nil {
+			uc.alertsActive.Add(-1, stream.active.Rule)
+		}
+		delete(uc.streams, key)
+	}
+}
@@ -0,0 +1,246 @@
+package alerting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+var testStart = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestRule(t *testing.T) *entities.AlertRule {
+	rule, err := entities.NewAlertRule("dry_soil", "soil_moisture", entities.AlertOperatorBelow, 20, 5, 10*time.Minute, time.Hour, entities.AlertSeverityCritical)
+	require.NoError(t, err)
+	return rule
+}
+
+func moisture(t *testing.T, channel int, value float64, minutes int) *entities.Measurement {
+	measurement, err := entities.NewMeasurement(testMAC, "soil_moisture", channel, value, entities.MeasurementQualityGood, testStart.Add(time.Duration(minutes)*time.Minute))
+	require.NoError(t, err)
+	return measurement
+}
+
+func TestAlertingUseCase_ObserveMeasurements(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should raise an alert once the breach lasted the minimum duration and resolve it past the band", func(t *testing.T) {
+		useCase := NewAlertingUseCase([]*entities.AlertRule{newTestRule(t)}, nil, nil, nil, nil, nil, nil, createTestLoggerFactory(t))
+
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 18, 0)})
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 22, 5)})
+		assert.Empty(t, useCase.ActiveAlerts())
+
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 17.5, 10)})
+		active := useCase.ActiveAlerts()
+		require.Len(t, active, 1)
+		assert.Equal(t, "dry_soil", active[0].Rule)
+		assert.Equal(t, entities.AlertStatusFiring, active[0].Status)
+		assert.Equal(t, entities.AlertSeverityCritical, active[0].Severity)
+		assert.Equal(t, testStart, active[0].Since)
+		assert.Equal(t, "soil_moisture of AA:BB:CC:DD:EE:FF channel 0 is 17.5, below 20 for 10m0s", active[0].Description)
+		assert.Equal(t, "soil_moisture de AA:BB:CC:DD:EE:FF canal 0 está en 17.5, por debajo de 20 durante 10m0s", active[0].LocalizedDescriptions["es"])
+
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 24, 15)})
+		assert.Len(t, useCase.ActiveAlerts(), 1)
+
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 26, 20)})
+		assert.Empty(t, useCase.ActiveAlerts())
+
+		recent := useCase.RecentAlerts(0)
+		require.Len(t, recent, 2)
+		assert.Equal(t, entities.AlertStatusResolved, recent[0].Status)
+		assert.Equal(t, testStart, recent[0].Since)
+		assert.Equal(t, "soil_moisture of AA:BB:CC:DD:EE:FF channel 0 recovered to 26", recent[0].Description)
+		assert.Equal(t, entities.AlertStatusFiring, recent[1].Status)
+	})
+
+	t.Run("should evaluate every channel separately", func(t *testing.T) {
+		rule, err := entities.NewAlertRule("dry_soil", "soil_moisture", entities.AlertOperatorBelow, 20, 0, 0, 0, "")
+		require.NoError(t, err)
+		useCase := NewAlertingUseCase([]*entities.AlertRule{rule}, nil, nil, nil, nil, nil, nil, createTestLoggerFactory(t))
+
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 25, 0), moisture(t, 1, 15, 0), moisture(t, 2, 10, 0)})
+
+		active := useCase.ActiveAlerts()
+		require.Len(t, active, 2)
+		assert.ElementsMatch(t, []int{1, 2}, []int{active[0].Channel, active[1].Channel})
+	})
+
+	t.Run("should hold back a new alert during the cooldown", func(t *testing.T) {
+		rule, err := entities.NewAlertRule("dry_soil", "soil_moisture", entities.AlertOperatorBelow, 20, 0, 0, time.Hour, "")
+		require.NoError(t, err)
+		useCase := NewAlertingUseCase([]*entities.AlertRule{rule}, nil, nil, nil, nil, nil, nil, createTestLoggerFactory(t))
+
+		for minutes, value := range []float64{15, 25, 15, 25, 15} {
+			useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, value, minutes)})
+		}
+
+		assert.Len(t, useCase.RecentAlerts(0), 2)
+		assert.Empty(t, useCase.ActiveAlerts())
+
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 15, 60)})
+		assert.Len(t, useCase.ActiveAlerts(), 1)
+	})
+
+	t.Run("should ignore values older than the last one evaluated", func(t *testing.T) {
+		rule, err := entities.NewAlertRule("dry_soil", "soil_moisture", entities.AlertOperatorBelow, 20, 0, 0, 0, "")
+		require.NoError(t, err)
+		useCase := NewAlertingUseCase([]*entities.AlertRule{rule}, nil, nil, nil, nil, nil, nil, createTestLoggerFactory(t))
+
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 25, 10)})
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 15, 5)})
+
+		assert.Empty(t, useCase.RecentAlerts(0))
+	})
+
+	t.Run("should publish alerts", func(t *testing.T) {
+		rule, err := entities.NewAlertRule("dry_soil", "soil_moisture", entities.AlertOperatorBelow, 20, 0, 0, 0, "")
+		require.NoError(t, err)
+		publisher := mocks.NewMockEventPublisher(t)
+		publisher.EXPECT().IsConnected().Return(true).Once()
+		publisher.EXPECT().Publish(mock.Anything, events.AlertSubject, mock.AnythingOfType("*entities.Alert")).Return(nil).Once()
+		useCase := NewAlertingUseCase([]*entities.AlertRule{rule}, nil, nil, nil, nil, publisher, nil, createTestLoggerFactory(t))
+
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 15, 0)})
+	})
+
+	t.Run("should notify users of fired and resolved alerts", func(t *testing.T) {
+		rule, err := entities.NewAlertRule("dry_soil", "soil_moisture", entities.AlertOperatorBelow, 20, 0, 0, 0, "")
+		require.NoError(t, err)
+		notifier := mocks.NewMockAlertNotifier(t)
+		notifier.EXPECT().Notify(mock.Anything, mock.MatchedBy(func(alert *entities.Alert) bool {
+			return alert.Status == entities.AlertStatusFiring
+		})).Once()
+		notifier.EXPECT().Notify(mock.Anything, mock.MatchedBy(func(alert *entities.Alert) bool {
+			return alert.Status == entities.AlertStatusResolved
+		})).Once()
+		useCase := NewAlertingUseCase([]*entities.AlertRule{rule}, nil, nil, nil, nil, nil, notifier, createTestLoggerFactory(t))
+
+		useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, 15, 0), moisture(t, 0, 25, 1)})
+	})
+
+	t.Run("should bound the alert history", func(t *testing.T) {
+		rule, err := entities.NewAlertRule("dry_soil", "soil_moisture", entities.AlertOperatorBelow, 20, 0, 0, 0, "")
+		require.NoError(t, err)
+		useCase := NewAlertingUseCase([]*entities.AlertRule{rule}, nil, nil, nil, &AlertingConfig{AlertHistory: 3}, nil, nil, createTestLoggerFactory(t))
+
+		for minutes, value := range []float64{15, 25, 15, 25, 15} {
+			useCase.ObserveMeasurements(ctx, []*entities.Measurement{moisture(t, 0, value, minutes)})
+		}
+
+		assert.Len(t, useCase.RecentAlerts(0), 3)
+		assert.Len(t, useCase.RecentAlerts(2), 2)
+	})
+}
+
+func TestObservedMeasurementUseCase_StoreMeasurements(t *testing.T) {
+	values := []*entities.Measurement{moisture(t, 0, 15, 0)}
+
+	t.Run("should evaluate stored measurements", func(t *testing.T) {
+		inner := mocks.NewMockMeasurementUseCase(t)
+		alerting := mocks.NewMockAlertingUseCase(t)
+		inner.EXPECT().StoreMeasurements(mock.Anything, values).Return(nil).Once()
+		alerting.EXPECT().ObserveMeasurements(mock.Anything, values).Once()
+
+		assert.NoError(t, NewObservedMeasurementUseCase(inner, alerting).StoreMeasurements(context.Background(), values))
+	})
+
+	t.Run("should not evaluate replayed measurements", func(t *testing.T) {
+		inner := mocks.NewMockMeasurementUseCase(t)
+		inner.EXPECT().StoreMeasurements(mock.Anything, values).Return(nil).Once()
+		ctx := eventports.ContextWithReplay(context.Background(), testStart)
+
+		assert.NoError(t, NewObservedMeasurementUseCase(inner, mocks.NewMockAlertingUseCase(t)).StoreMeasurements(ctx, values))
+	})
+}
+
+func TestAlertingUseCase_EvaluateComposites(t *testing.T) {
+	ctx := context.Background()
+	groups := map[string][]string{
+		"zone_a":  {"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"},
+		"gateway": {"AA:BB:CC:DD:EE:10"},
+	}
+	zoneMoisture := func(t *testing.T, values ...float64) []*entities.Measurement {
+		var measurements []*entities.Measurement
+		for i, value := range values {
+			measurement, err := entities.NewMeasurement(groups["zone_a"][i], "soil_moisture", 0, value, entities.MeasurementQualityGood, testStart)
+			require.NoError(t, err)
+			measurements = append(measurements, measurement)
+		}
+		return measurements
+	}
+
+	t.Run("should fire once the condition held for the minimum duration and resolve when it clears", func(t *testing.T) {
+		rule, err := entities.NewCompositeAlertRule("dry_zone_a", "all(soil_moisture@zone_a < 20)", groups, 2*time.Minute, 0, entities.AlertSeverityCritical)
+		require.NoError(t, err)
+		repo := mocks.NewMockMeasurementRepository(t)
+		useCase := NewAlertingUseCase(nil, []*entities.CompositeAlertRule{rule}, repo, nil, nil, nil, nil, createTestLoggerFactory(t)).(*useCaseImpl)
+		now := testStart
+		useCase.now = func() time.Time { return now }
+
+		repo.EXPECT().Latest(mock.Anything, []string{"soil_moisture"}, testStart.Add(-15*time.Minute)).Return(zoneMoisture(t, 15, 18), nil).Once()
+		require.NoError(t, useCase.EvaluateComposites(ctx))
+		assert.Empty(t, useCase.ActiveAlerts())
+
+		now = now.Add(2 * time.Minute)
+		repo.EXPECT().Latest(mock.Anything, []string{"soil_moisture"}, mock.Anything).Return(zoneMoisture(t, 16, 19), nil).Once()
+		require.NoError(t, useCase.EvaluateComposites(ctx))
+		active := useCase.ActiveAlerts()
+		require.Len(t, active, 1)
+		assert.Equal(t, "dry_zone_a", active[0].Rule)
+		assert.Equal(t, "all(soil_moisture@zone_a < 20)", active[0].Condition)
+		assert.Empty(t, active[0].MACAddress)
+		assert.Equal(t, "condition all(soil_moisture@zone_a < 20) of rule dry_zone_a held for 2m0s", active[0].Description)
+
+		now = now.Add(time.Minute)
+		repo.EXPECT().Latest(mock.Anything, []string{"soil_moisture"}, mock.Anything).Return(zoneMoisture(t, 16, 25), nil).Once()
+		require.NoError(t, useCase.EvaluateComposites(ctx))
+		assert.Empty(t, useCase.ActiveAlerts())
+		assert.Equal(t, entities.AlertStatusResolved, useCase.RecentAlerts(1)[0].Status)
+	})
+
+	t.Run("should read the connection state of devices", func(t *testing.T) {
+		rule, err := entities.NewCompositeAlertRule("gateway_down_and_dry", "all(online@gateway == 0) and any(soil_moisture@zone_a < 20)", groups, 0, 0, "")
+		require.NoError(t, err)
+		repo := mocks.NewMockMeasurementRepository(t)
+		devices := mocks.NewMockDeviceRepository(t)
+		gateway, err := entities.NewDevice("AA:BB:CC:DD:EE:10", "Gateway", "192.168.1.10", "Shed")
+		require.NoError(t, err)
+		gateway.MarkOffline()
+		repo.EXPECT().Latest(mock.Anything, []string{"soil_moisture"}, mock.Anything).Return(zoneMoisture(t, 15, 30), nil).Once()
+		devices.EXPECT().FindByMACAddresses(mock.Anything, []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02", "AA:BB:CC:DD:EE:10"}).Return([]*entities.Device{gateway}, nil).Once()
+		useCase := NewAlertingUseCase(nil, []*entities.CompositeAlertRule{rule}, repo, devices, nil, nil, nil, createTestLoggerFactory(t))
+
+		require.NoError(t, useCase.EvaluateComposites(ctx))
+
+		assert.Len(t, useCase.ActiveAlerts(), 1)
+	})
+
+	t.Run("should report load failures", func(t *testing.T) {
+		rule, err := entities.NewCompositeAlertRule("dry_zone_a", "all(soil_moisture@zone_a < 20)", groups, 0, 0, "")
+		require.NoError(t, err)
+		repo := mocks.NewMockMeasurementRepository(t)
+		repo.EXPECT().Latest(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+		useCase := NewAlertingUseCase(nil, []*entities.CompositeAlertRule{rule}, repo, nil, nil, nil, nil, createTestLoggerFactory(t))
+
+		assert.ErrorContains(t, useCase.EvaluateComposites(ctx), "failed to load latest measurements")
+	})
+}
@@ -0,0 +1,60 @@
+package alerting
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// observedMeasurementUseCase evaluates the alert rules on measurements once they are stored
+type observedMeasurementUseCase struct {
+	measurements.MeasurementUseCase
+	alerting AlertingUseCase
+}
+
+// NewObservedMeasurementUseCase wraps a measurement use case so stored measurements are evaluated by the alert rules
+func NewObservedMeasurementUseCase(inner measurements.MeasurementUseCase, alerting AlertingUseCase) measurements.MeasurementUseCase {
+	return &observedMeasurementUseCase{MeasurementUseCase: inner, alerting: alerting}
+}
+
+// StoreMeasurements stores the measurements and evaluates them once stored.
+// Replayed measurements were evaluated when first received.
+func (uc *observedMeasurementUseCase) StoreMeasurements(ctx context.Context, values []*entities.Measurement) error {
+	if err := uc.MeasurementUseCase.StoreMeasurements(ctx, values); err != nil {
+		return err
+	}
+	if !eventports.IsReplay(ctx) {
+		uc.alerting.ObserveMeasurements(ctx, values)
+	}
+	return nil
+}
+
+// observedSensorDataUseCase evaluates the alert rules on temperature and humidity readings once they are stored
+type observedSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	alerting AlertingUseCase
+}
+
+// NewObservedSensorDataUseCase wraps a sensor data use case so stored readings are evaluated by the
+// temperature and humidity alert rules on channel 0
+func NewObservedSensorDataUseCase(inner sensordata.SensorDataUseCase, alerting AlertingUseCase) sensordata.SensorDataUseCase {
+	return &observedSensorDataUseCase{SensorDataUseCase: inner, alerting: alerting}
+}
+
+// StoreSensorData stores the reading and evaluates it once stored.
+// Replayed readings were evaluated when first received.
+func (uc *observedSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if err := uc.SensorDataUseCase.StoreSensorData(ctx, data); err != nil {
+		return err
+	}
+	if !eventports.IsReplay(ctx) {
+		uc.alerting.ObserveMeasurements(ctx, []*entities.Measurement{
+			{MACAddress: data.MacAddress(), SensorType: "temperature", Value: data.Temperature(), Unit: "celsius", Quality: entities.MeasurementQualityGood, Timestamp: data.Timestamp()},
+			{MACAddress: data.MacAddress(), SensorType: "humidity", Value: data.Humidity(), Unit: "percent", Quality: entities.MeasurementQualityGood, Timestamp: data.Timestamp()},
+		})
+	}
+	return nil
+}
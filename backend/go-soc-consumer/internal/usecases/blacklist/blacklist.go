@@ -0,0 +1,188 @@
+package blacklist
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// BlacklistUseCase manages blocked MAC addresses and IP ranges and matches messages against them
+type BlacklistUseCase interface {
+	// Add blocks a MAC address or IP range
+	Add(ctx context.Context, kind, value, reason string) (*entities.BlacklistEntry, error)
+
+	// Remove unblocks an entry by its ID
+	Remove(ctx context.Context, id string) error
+
+	// List returns every entry, oldest first
+	List(ctx context.Context) ([]*entities.BlacklistEntry, error)
+
+	// Refresh reloads the in-memory cache from the repository
+	Refresh(ctx context.Context) error
+
+	// Match returns the entry blocking the MAC address or any of the IP addresses, or nil.
+	// It only reads the in-memory cache so it is cheap enough to call for every message.
+	Match(macAddress string, ipAddresses []string) *entities.BlacklistEntry
+
+	// Run refreshes the cache periodically until the context is cancelled, picking up changes made by other instances
+	Run(ctx context.Context)
+}
+
+// blockedNetwork is a parsed IP range entry
+type blockedNetwork struct {
+	network *net.IPNet
+	entry   *entities.BlacklistEntry
+}
+
+// useCaseImpl implements the BlacklistUseCase interface
+type useCaseImpl struct {
+	repo            repositoryports.BlacklistRepository
+	refreshInterval time.Duration
+	loggerFactory   logger.LoggerFactory
+
+	mu       sync.RWMutex
+	macs     map[string]*entities.BlacklistEntry
+	networks []blockedNetwork
+}
+
+// NewBlacklistUseCase creates a new blacklist use case; call Refresh to load the cache before matching
+func NewBlacklistUseCase(repo repositoryports.BlacklistRepository, refreshInterval time.Duration, loggerFactory logger.LoggerFactory) BlacklistUseCase {
+	if refreshInterval <= 0 {
+		refreshInterval = time.Minute
+	}
+
+	return &useCaseImpl{
+		repo:            repo,
+		refreshInterval: refreshInterval,
+		loggerFactory:   loggerFactory,
+		macs:            make(map[string]*entities.BlacklistEntry),
+	}
+}
+
+// Add blocks a MAC address or IP range and updates the cache
+func (uc *useCaseImpl) Add(ctx context.Context, kind, value, reason string) (*entities.BlacklistEntry, error) {
+	entry, err := entities.NewBlacklistEntry(kind, value, reason)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidBlacklistEntry, err)
+	}
+	if err := uc.repo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("blacklist_entry_added",
+		zap.String("id", entry.ID),
+		zap.String("kind", entry.Kind),
+		zap.String("value", entry.Value),
+		zap.String("reason", entry.Reason),
+		zap.String("component", "blacklist_usecase"),
+	)
+	return entry, uc.Refresh(ctx)
+}
+
+// Remove unblocks an entry and updates the cache
+func (uc *useCaseImpl) Remove(ctx context.Context, id string) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.loggerFactory.Core().Info("blacklist_entry_removed",
+		zap.String("id", id),
+		zap.String("component", "blacklist_usecase"),
+	)
+	return uc.Refresh(ctx)
+}
+
+// List returns every entry from the repository
+func (uc *useCaseImpl) List(ctx context.Context) ([]*entities.BlacklistEntry, error) {
+	return uc.repo.List(ctx)
+}
+
+// Refresh replaces the cache with the entries in the repository
+func (uc *useCaseImpl) Refresh(ctx context.Context) error {
+	entries, err := uc.repo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load blacklist: %w", err)
+	}
+
+	macs := make(map[string]*entities.BlacklistEntry)
+	var networks []blockedNetwork
+	for _, entry := range entries {
+		switch entry.Kind {
+		case entities.BlacklistKindMAC:
+			macs[entry.Value] = entry
+		case entities.BlacklistKindIPRange:
+			_, network, err := net.ParseCIDR(entry.Value)
+			if err != nil {
+				uc.loggerFactory.Core().Warn("blacklist_entry_invalid",
+					zap.String("id", entry.ID),
+					zap.String("value", entry.Value),
+					zap.String("component", "blacklist_usecase"),
+				)
+				continue
+			}
+			networks = append(networks, blockedNetwork{network: network, entry: entry})
+		}
+	}
+
+	uc.mu.Lock()
+	uc.macs = macs
+	uc.networks = networks
+	uc.mu.Unlock()
+	return nil
+}
+
+// Match returns the entry blocking the MAC address or any of the IP addresses
+func (uc *useCaseImpl) Match(macAddress string, ipAddresses []string) *entities.BlacklistEntry {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	if macAddress != "" {
+		normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(macAddress), "-", ":"))
+		if entry, ok := uc.macs[normalized]; ok {
+			return entry
+		}
+	}
+
+	for _, ipAddress := range ipAddresses {
+		ip := net.ParseIP(strings.TrimSpace(ipAddress))
+		if ip == nil {
+			continue
+		}
+		for _, blocked := range uc.networks {
+			if blocked.network.Contains(ip) {
+				return blocked.entry
+			}
+		}
+	}
+	return nil
+}
+
+// Run refreshes the cache every refresh interval until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	ticker := time.NewTicker(uc.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := uc.Refresh(ctx); err != nil {
+				uc.loggerFactory.Core().Error("blacklist_refresh_failed",
+					zap.Error(err),
+					zap.String("component", "blacklist_usecase"),
+				)
+			}
+		}
+	}
+}
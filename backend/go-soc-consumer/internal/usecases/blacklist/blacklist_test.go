@@ -0,0 +1,107 @@
+package blacklist
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func blacklistEntry(t *testing.T, kind, value string) *entities.BlacklistEntry {
+	entry, err := entities.NewBlacklistEntry(kind, value, "compromised")
+	require.NoError(t, err)
+	return entry
+}
+
+func TestBlacklistUseCase_Match(t *testing.T) {
+	repo := mocks.NewMockBlacklistRepository(t)
+	macEntry := blacklistEntry(t, entities.BlacklistKindMAC, "AA:BB:CC:DD:EE:FF")
+	rangeEntry := blacklistEntry(t, entities.BlacklistKindIPRange, "10.0.5.0/24")
+	repo.EXPECT().List(mock.Anything).Return([]*entities.BlacklistEntry{macEntry, rangeEntry}, nil).Once()
+
+	useCase := NewBlacklistUseCase(repo, 0, createTestLoggerFactory(t))
+	require.NoError(t, useCase.Refresh(context.Background()))
+
+	assert.Equal(t, macEntry, useCase.Match("aa-bb-cc-dd-ee-ff", nil))
+	assert.Equal(t, rangeEntry, useCase.Match("11:22:33:44:55:66", []string{"", "10.0.5.77"}))
+	assert.Nil(t, useCase.Match("11:22:33:44:55:66", []string{"10.0.6.1", "not-an-ip"}))
+}
+
+func TestBlacklistUseCase_Add(t *testing.T) {
+	t.Run("should persist the entry and refresh the cache", func(t *testing.T) {
+		repo := mocks.NewMockBlacklistRepository(t)
+		var created *entities.BlacklistEntry
+		repo.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, entry *entities.BlacklistEntry) error {
+			created = entry
+			return nil
+		}).Once()
+		repo.EXPECT().List(mock.Anything).RunAndReturn(func(context.Context) ([]*entities.BlacklistEntry, error) {
+			return []*entities.BlacklistEntry{created}, nil
+		}).Once()
+
+		useCase := NewBlacklistUseCase(repo, 0, createTestLoggerFactory(t))
+		entry, err := useCase.Add(context.Background(), entities.BlacklistKindIPRange, "192.168.1.20", "stolen")
+		require.NoError(t, err)
+		assert.Equal(t, "192.168.1.20/32", entry.Value)
+		assert.Equal(t, entry, useCase.Match("", []string{"192.168.1.20"}))
+	})
+
+	t.Run("should reject invalid entries", func(t *testing.T) {
+		useCase := NewBlacklistUseCase(mocks.NewMockBlacklistRepository(t), 0, createTestLoggerFactory(t))
+
+		_, err := useCase.Add(context.Background(), entities.BlacklistKindMAC, "not-a-mac", "")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidBlacklistEntry)
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		repo := mocks.NewMockBlacklistRepository(t)
+		repo.EXPECT().Create(mock.Anything, mock.Anything).Return(domainerrors.ErrBlacklistEntryExists).Once()
+		useCase := NewBlacklistUseCase(repo, 0, createTestLoggerFactory(t))
+
+		_, err := useCase.Add(context.Background(), entities.BlacklistKindMAC, "AA:BB:CC:DD:EE:FF", "")
+		assert.ErrorIs(t, err, domainerrors.ErrBlacklistEntryExists)
+	})
+}
+
+func TestBlacklistUseCase_Remove(t *testing.T) {
+	repo := mocks.NewMockBlacklistRepository(t)
+	entry := blacklistEntry(t, entities.BlacklistKindMAC, "AA:BB:CC:DD:EE:FF")
+	repo.EXPECT().List(mock.Anything).Return([]*entities.BlacklistEntry{entry}, nil).Once()
+	repo.EXPECT().Delete(mock.Anything, entry.ID).Return(nil).Once()
+	repo.EXPECT().List(mock.Anything).Return(nil, nil).Once()
+
+	useCase := NewBlacklistUseCase(repo, 0, createTestLoggerFactory(t))
+	require.NoError(t, useCase.Refresh(context.Background()))
+	require.NotNil(t, useCase.Match(entry.Value, nil))
+
+	require.NoError(t, useCase.Remove(context.Background(), entry.ID))
+	assert.Nil(t, useCase.Match(entry.Value, nil))
+}
+
+func TestBlacklistUseCase_Refresh(t *testing.T) {
+	t.Run("should keep the previous cache when loading fails", func(t *testing.T) {
+		repo := mocks.NewMockBlacklistRepository(t)
+		entry := blacklistEntry(t, entities.BlacklistKindMAC, "AA:BB:CC:DD:EE:FF")
+		repo.EXPECT().List(mock.Anything).Return([]*entities.BlacklistEntry{entry}, nil).Once()
+		repo.EXPECT().List(mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		useCase := NewBlacklistUseCase(repo, 0, createTestLoggerFactory(t))
+		require.NoError(t, useCase.Refresh(context.Background()))
+		assert.Error(t, useCase.Refresh(context.Background()))
+		assert.NotNil(t, useCase.Match(entry.Value, nil))
+	})
+}
@@ -0,0 +1,340 @@
+package brokerauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// Placeholders of the topic ACL templates
+const (
+	FarmIDPlaceholder     = "{farmID}"
+	MACAddressPlaceholder = "{mac}"
+)
+
+// TopicACL lists the topics devices may use as templates, filled with the farm and the MAC address
+// of the device. Templates naming a farm only apply to devices that have one.
+type TopicACL struct {
+	Publish   []string
+	Subscribe []string
+}
+
+// allows reports whether a device of the farm may perform the action on the topic
+func (a TopicACL) allows(action, topic, farmID, macAddress string) bool {
+	templates := a.Publish
+	if action == entities.BrokerActionSubscribe {
+		templates = a.Subscribe
+	}
+
+	for _, template := range templates {
+		if strings.Contains(template, FarmIDPlaceholder) {
+			if farmID == "" {
+				continue
+			}
+			template = strings.ReplaceAll(template, FarmIDPlaceholder, farmID)
+		}
+		if strings.ReplaceAll(template, MACAddressPlaceholder, macAddress) == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// BrokerAuthConfig holds the credentials and topic rules broker security is driven by
+type BrokerAuthConfig struct {
+	ServiceUsers map[string]string // username -> password of the accounts allowed every topic, such as the server's own
+	ACL          TopicACL
+	// RequireCertificate rejects registered devices without a provisioned certificate fingerprint
+	RequireCertificate bool
+	// AllowUnknownDevices accepts devices neither registered nor being onboarded, so they can register
+	AllowUnknownDevices bool
+}
+
+// DefaultBrokerAuthConfig returns default configuration
+func DefaultBrokerAuthConfig() *BrokerAuthConfig {
+	return &BrokerAuthConfig{
+		ServiceUsers: map[string]string{},
+	}
+}
+
+// BrokerAuthUseCase answers the authentication and authorization hooks of external brokers (EMQX,
+// Mosquitto), so broker security follows the device registry and the pending onboardings
+type BrokerAuthUseCase interface {
+	// Authenticate decides whether a client may connect. Service users authenticate with their password.
+	// Devices connect with their MAC address as username: registered devices with a provisioned
+	// certificate must present it, the others must use the provisioning token they were onboarded
+	// with as password, as must devices being onboarded.
+	Authenticate(ctx context.Context, request *entities.BrokerAuthRequest) (*entities.BrokerAuthDecision, error)
+
+	// Authorize decides whether a connected client may publish on or subscribe to a topic. Service
+	// users may use every topic and devices the topics of the ACL filled with their farm and MAC address.
+	Authorize(ctx context.Context, request *entities.BrokerACLRequest) (*entities.BrokerAuthDecision, error)
+}
+
+// useCaseImpl implements the BrokerAuthUseCase interface
+type useCaseImpl struct {
+	deviceRepo     repositoryports.DeviceRepository
+	onboardingRepo repositoryports.DeviceOnboardingRepository
+	config         *BrokerAuthConfig
+	loggerFactory  logger.LoggerFactory
+	now            func() time.Time
+
+	decisions *metrics.Vec
+}
+
+// NewBrokerAuthUseCase creates a broker auth use case
+func NewBrokerAuthUseCase(
+	deviceRepo repositoryports.DeviceRepository,
+	onboardingRepo repositoryports.DeviceOnboardingRepository,
+	config *BrokerAuthConfig,
+	loggerFactory logger.LoggerFactory,
+) BrokerAuthUseCase {
+	if config == nil {
+		config = DefaultBrokerAuthConfig()
+	}
+
+	return &useCaseImpl{
+		deviceRepo:     deviceRepo,
+		onboardingRepo: onboardingRepo,
+		config:         config,
+		loggerFactory:  loggerFactory,
+		now:            time.Now,
+		decisions:      metrics.NewCounterVec("broker_auth_decisions_total", "Broker authentication and authorization decisions", "check", "result"),
+	}
+}
+
+// Authenticate checks the credentials of a client against the service users and the device credentials
+func (uc *useCaseImpl) Authenticate(ctx context.Context, request *entities.BrokerAuthRequest) (*entities.BrokerAuthDecision, error) {
+	if err := domainerrors.RequireInput("broker auth request", request); err != nil {
+		return nil, err
+	}
+
+	decision, err := uc.authenticate(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	uc.record("authenticate", decision,
+		zap.String("client_id", request.Identity.ClientID),
+		zap.String("username", request.Identity.Username),
+		zap.String("remote_address", request.Identity.RemoteAddress),
+	)
+	return decision, nil
+}
+
+// authenticate decides on the credentials of a client
+func (uc *useCaseImpl) authenticate(ctx context.Context, request *entities.BrokerAuthRequest) (*entities.BrokerAuthDecision, error) {
+	identity := request.Identity
+	if password, ok := uc.config.ServiceUsers[identity.Username]; ok {
+		if subtle.ConstantTimeCompare([]byte(request.Password), []byte(password)) != 1 {
+			return deny("invalid service user password"), nil
+		}
+		return &entities.BrokerAuthDecision{Allow: true, Superuser: true, Reason: "service user"}, nil
+	}
+
+	macAddress, ok := deviceUsername(identity.Username)
+	if !ok {
+		return deny("username is not a device MAC address"), nil
+	}
+	if identity.CertificateFingerprint != "" {
+		fingerprint, err := entities.NormalizeCertificateFingerprint(identity.CertificateFingerprint)
+		if err != nil {
+			return deny("invalid certificate fingerprint"), nil
+		}
+		identity.CertificateFingerprint = fingerprint
+	}
+
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, macAddress)
+	if err == nil {
+		return uc.authenticateDevice(ctx, device, &identity, request.Password)
+	}
+	if !errors.Is(err, domainerrors.ErrDeviceNotFound) {
+		return nil, fmt.Errorf("failed to load device credentials: %w", err)
+	}
+
+	onboarding, err := uc.findPendingOnboarding(ctx, macAddress)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding != nil {
+		if !onboarding.VerifyToken(request.Password, uc.now()) {
+			return deny("invalid or expired provisioning token"), nil
+		}
+		return allow("device being onboarded"), nil
+	}
+
+	if !uc.config.AllowUnknownDevices {
+		return deny("unknown device"), nil
+	}
+	return allow("unknown device"), nil
+}
+
+// authenticateDevice decides on a registered device. A device with a provisioned certificate must
+// present it; otherwise its password must be the broker credential issued at onboarding, or its
+// provisioning token while the onboarding is pending. Devices with neither are rejected.
+func (uc *useCaseImpl) authenticateDevice(ctx context.Context, device *entities.Device, identity *entities.ClientIdentity, password string) (*entities.BrokerAuthDecision, error) {
+	if err := device.VerifyIdentity(identity); err != nil {
+		return deny(err.Error()), nil
+	}
+	if device.CertificateFingerprint != "" {
+		return allow("registered device"), nil
+	}
+	if uc.config.RequireCertificate {
+		return deny("device has no provisioned certificate"), nil
+	}
+	if device.CredentialHash != "" {
+		if !device.VerifyCredential(password) {
+			return deny("invalid device password"), nil
+		}
+		return allow("registered device"), nil
+	}
+
+	onboarding, err := uc.findPendingOnboarding(ctx, device.MACAddress)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding == nil {
+		return deny("device has neither a certificate nor a broker password"), nil
+	}
+	if !onboarding.VerifyToken(password, uc.now()) {
+		return deny("invalid or expired provisioning token"), nil
+	}
+	return allow("registered device being onboarded"), nil
+}
+
+// Authorize checks a publish or subscribe against the topic ACL of the client
+func (uc *useCaseImpl) Authorize(ctx context.Context, request *entities.BrokerACLRequest) (*entities.BrokerAuthDecision, error) {
+	if err := domainerrors.RequireInput("broker ACL request", request); err != nil {
+		return nil, err
+	}
+	if request.Action != entities.BrokerActionPublish && request.Action != entities.BrokerActionSubscribe {
+		return nil, fmt.Errorf("%w: action must be publish or subscribe", domainerrors.ErrInvalidInput)
+	}
+
+	decision, err := uc.authorize(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	uc.record("authorize", decision,
+		zap.String("client_id", request.ClientID),
+		zap.String("username", request.Username),
+		zap.String("action", request.Action),
+		zap.String("topic", request.Topic),
+	)
+	return decision, nil
+}
+
+// authorize decides on the topic a client uses
+func (uc *useCaseImpl) authorize(ctx context.Context, request *entities.BrokerACLRequest) (*entities.BrokerAuthDecision, error) {
+	if _, ok := uc.config.ServiceUsers[request.Username]; ok {
+		return &entities.BrokerAuthDecision{Allow: true, Superuser: true, Reason: "service user"}, nil
+	}
+
+	macAddress, ok := deviceUsername(request.Username)
+	if !ok {
+		return deny("username is not a device MAC address"), nil
+	}
+
+	farmID := ""
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, macAddress)
+	switch {
+	case err == nil:
+		farmID = device.FarmID
+	case errors.Is(err, domainerrors.ErrDeviceNotFound):
+		onboarding, err := uc.findPendingOnboarding(ctx, macAddress)
+		if err != nil {
+			return nil, err
+		}
+		if onboarding != nil {
+			farmID = onboarding.FarmID
+		} else if !uc.config.AllowUnknownDevices {
+			return deny("unknown device"), nil
+		}
+	default:
+		return nil, fmt.Errorf("failed to load device: %w", err)
+	}
+
+	if !uc.config.ACL.allows(request.Action, request.Topic, farmID, macAddress) {
+		return deny("topic not in the device ACL"), nil
+	}
+	return allow("topic in the device ACL"), nil
+}
+
+// findPendingOnboarding returns the pending onboarding of the device, nil when there is none
+func (uc *useCaseImpl) findPendingOnboarding(ctx context.Context, macAddress string) (*entities.DeviceOnboarding, error) {
+	if uc.onboardingRepo == nil {
+		return nil, nil
+	}
+
+	onboardings, err := uc.onboardingRepo.ListPending(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pending onboardings: %w", err)
+	}
+	for _, onboarding := range onboardings {
+		if onboarding.MACAddress == macAddress {
+			return onboarding, nil
+		}
+	}
+	return nil, nil
+}
+
+// record counts the decision and logs denials
+func (uc *useCaseImpl) record(check string, decision *entities.BrokerAuthDecision, fields ...zap.Field) {
+	result := "allow"
+	if !decision.Allow {
+		result = "deny"
+	}
+	uc.decisions.Inc(check, result)
+
+	fields = append(fields,
+		zap.String("check", check),
+		zap.String("reason", decision.Reason),
+		zap.String("component", "broker_auth_usecase"),
+	)
+	if decision.Allow {
+		uc.loggerFactory.Core().Debug("broker_auth_allowed", fields...)
+		return
+	}
+	uc.loggerFactory.Core().Warn("broker_auth_denied", fields...)
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return uc.decisions.Collect()
+}
+
+// MetricsCollector returns the broker auth metrics collector
+func MetricsCollector(useCase BrokerAuthUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
+
+// deviceUsername returns the MAC address a device connects with as username
+func deviceUsername(username string) (string, bool) {
+	macAddress := strings.ToUpper(strings.TrimSpace(username))
+	if validation.ValidateMACAddress(macAddress) != nil {
+		return "", false
+	}
+	return macAddress, true
+}
+
+func allow(reason string) *entities.BrokerAuthDecision {
+	return &entities.BrokerAuthDecision{Allow: true, Reason: reason}
+}
+
+func deny(reason string) *entities.BrokerAuthDecision {
+	return &entities.BrokerAuthDecision{Reason: reason}
+}
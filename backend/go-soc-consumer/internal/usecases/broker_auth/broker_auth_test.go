@@ -0,0 +1,248 @@
+package brokerauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const fingerprint = "5d41402abc4b2a76b9719d911017c5925d41402abc4b2a76b9719d911017c592"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestUseCase(t *testing.T, config *BrokerAuthConfig) (*useCaseImpl, *mocks.MockDeviceRepository, *mocks.MockDeviceOnboardingRepository) {
+	deviceRepo := mocks.NewMockDeviceRepository(t)
+	onboardingRepo := mocks.NewMockDeviceOnboardingRepository(t)
+	if config.ServiceUsers == nil {
+		config.ServiceUsers = map[string]string{"soc-consumer": "secret"}
+	}
+	config.ACL = TopicACL{
+		Publish:   []string{"/devices/registration", "farms/{farmID}/devices/registration"},
+		Subscribe: []string{"/commands/{mac}", "farms/{farmID}/commands/{mac}"},
+	}
+	useCase := NewBrokerAuthUseCase(deviceRepo, onboardingRepo, config, createTestLoggerFactory(t)).(*useCaseImpl)
+	return useCase, deviceRepo, onboardingRepo
+}
+
+func newTestDevice(t *testing.T, farmID, fingerprint string) *entities.Device {
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:01", "Sensor 1", "192.168.1.10", "Zone A")
+	require.NoError(t, err)
+	device.FarmID = farmID
+	device.CertificateFingerprint = fingerprint
+	return device
+}
+
+func authRequest(username, password, fingerprint string) *entities.BrokerAuthRequest {
+	return &entities.BrokerAuthRequest{
+		Identity: entities.ClientIdentity{ClientID: "esp32-1", Username: username, CertificateFingerprint: fingerprint},
+		Password: password,
+	}
+}
+
+func TestBrokerAuthUseCase_Authenticate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should authenticate service users by password", func(t *testing.T) {
+		useCase, _, _ := newTestUseCase(t, &BrokerAuthConfig{})
+
+		decision, err := useCase.Authenticate(context.Background(), authRequest("soc-consumer", "secret", ""))
+		require.NoError(t, err)
+		assert.True(t, decision.Allow)
+		assert.True(t, decision.Superuser)
+
+		decision, err = useCase.Authenticate(context.Background(), authRequest("soc-consumer", "guess", ""))
+		require.NoError(t, err)
+		assert.False(t, decision.Allow)
+		assert.Equal(t, float64(1), useCase.decisions.Value("authenticate", "deny"))
+	})
+
+	t.Run("should reject usernames that are not MAC addresses", func(t *testing.T) {
+		useCase, _, _ := newTestUseCase(t, &BrokerAuthConfig{})
+
+		decision, err := useCase.Authenticate(context.Background(), authRequest("admin", "", ""))
+		require.NoError(t, err)
+		assert.False(t, decision.Allow)
+	})
+
+	t.Run("should require the provisioned certificate of registered devices", func(t *testing.T) {
+		useCase, deviceRepo, _ := newTestUseCase(t, &BrokerAuthConfig{})
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(newTestDevice(t, "", fingerprint), nil).Times(3)
+
+		decision, err := useCase.Authenticate(context.Background(), authRequest("aa:bb:cc:dd:ee:01", "", ""))
+		require.NoError(t, err)
+		assert.False(t, decision.Allow)
+
+		decision, err = useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", "", "00"+fingerprint[2:]))
+		require.NoError(t, err)
+		assert.False(t, decision.Allow)
+
+		decision, err = useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", "", fingerprint))
+		require.NoError(t, err)
+		assert.True(t, decision.Allow)
+		assert.False(t, decision.Superuser)
+	})
+
+	t.Run("should reject registered devices without certificate when one is required", func(t *testing.T) {
+		useCase, deviceRepo, _ := newTestUseCase(t, &BrokerAuthConfig{RequireCertificate: true})
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(newTestDevice(t, "", ""), nil).Once()
+
+		decision, err := useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", "", ""))
+		require.NoError(t, err)
+		assert.False(t, decision.Allow)
+	})
+
+	t.Run("should authenticate onboarded devices with their broker password", func(t *testing.T) {
+		useCase, deviceRepo, _ := newTestUseCase(t, &BrokerAuthConfig{})
+		onboarding, token, err := entities.NewDeviceOnboarding(entities.OnboardingRequest{
+			MACAddress: "AA:BB:CC:DD:EE:01", DeviceName: "Sensor 1", LocationDescription: "Zone A",
+		}, time.Hour, now)
+		require.NoError(t, err)
+		device := newTestDevice(t, "", "")
+		device.CredentialHash = onboarding.TokenHash
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(device, nil).Times(3)
+
+		decision, err := useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", token, ""))
+		require.NoError(t, err)
+		assert.True(t, decision.Allow)
+
+		decision, err = useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", "wrong", ""))
+		require.NoError(t, err)
+		assert.False(t, decision.Allow)
+
+		decision, err = useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", "", ""))
+		require.NoError(t, err)
+		assert.False(t, decision.Allow)
+	})
+
+	t.Run("should reject registered devices with neither certificate nor password", func(t *testing.T) {
+		useCase, deviceRepo, onboardingRepo := newTestUseCase(t, &BrokerAuthConfig{})
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(newTestDevice(t, "", ""), nil).Once()
+		onboardingRepo.EXPECT().ListPending(mock.Anything).Return(nil, nil).Once()
+
+		decision, err := useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", "anything", ""))
+		require.NoError(t, err)
+		assert.False(t, decision.Allow)
+	})
+
+	t.Run("should accept the provisioning token of registered devices still being onboarded", func(t *testing.T) {
+		useCase, deviceRepo, onboardingRepo := newTestUseCase(t, &BrokerAuthConfig{})
+		useCase.now = func() time.Time { return now }
+		onboarding, token, err := entities.NewDeviceOnboarding(entities.OnboardingRequest{
+			MACAddress: "AA:BB:CC:DD:EE:01", DeviceName: "Sensor 1", LocationDescription: "Zone A",
+		}, time.Hour, now)
+		require.NoError(t, err)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(newTestDevice(t, "", ""), nil).Times(2)
+		onboardingRepo.EXPECT().ListPending(mock.Anything).Return([]*entities.DeviceOnboarding{onboarding}, nil).Times(2)
+
+		decision, err := useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", token, ""))
+		require.NoError(t, err)
+		assert.True(t, decision.Allow)
+
+		decision, err = useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", "wrong", ""))
+		require.NoError(t, err)
+		assert.False(t, decision.Allow)
+	})
+
+	t.Run("should authenticate devices being onboarded with their provisioning token", func(t *testing.T) {
+		useCase, deviceRepo, onboardingRepo := newTestUseCase(t, &BrokerAuthConfig{})
+		useCase.now = func() time.Time { return now }
+		onboarding, token, err := entities.NewDeviceOnboarding(entities.OnboardingRequest{
+			MACAddress: "AA:BB:CC:DD:EE:01", DeviceName: "Sensor 1", LocationDescription: "Zone A",
+		}, time.Hour, now)
+		require.NoError(t, err)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(nil, domainerrors.ErrDeviceNotFound).Times(2)
+		onboardingRepo.EXPECT().ListPending(mock.Anything).Return([]*entities.DeviceOnboarding{onboarding}, nil).Times(2)
+
+		decision, err := useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", token, ""))
+		require.NoError(t, err)
+		assert.True(t, decision.Allow)
+
+		decision, err = useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", "wrong", ""))
+		require.NoError(t, err)
+		assert.False(t, decision.Allow)
+	})
+
+	t.Run("should reject unknown devices unless allowed", func(t *testing.T) {
+		for _, allowUnknown := range []bool{false, true} {
+			useCase, deviceRepo, onboardingRepo := newTestUseCase(t, &BrokerAuthConfig{AllowUnknownDevices: allowUnknown})
+			deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(nil, domainerrors.ErrDeviceNotFound).Once()
+			onboardingRepo.EXPECT().ListPending(mock.Anything).Return(nil, nil).Once()
+
+			decision, err := useCase.Authenticate(context.Background(), authRequest("AA:BB:CC:DD:EE:01", "", ""))
+			require.NoError(t, err)
+			assert.Equal(t, allowUnknown, decision.Allow)
+		}
+	})
+}
+
+func TestBrokerAuthUseCase_Authorize(t *testing.T) {
+	aclRequest := func(action, topic string) *entities.BrokerACLRequest {
+		return &entities.BrokerACLRequest{ClientID: "esp32-1", Username: "AA:BB:CC:DD:EE:01", Action: action, Topic: topic}
+	}
+
+	t.Run("should allow service users every topic", func(t *testing.T) {
+		useCase, _, _ := newTestUseCase(t, &BrokerAuthConfig{})
+
+		decision, err := useCase.Authorize(context.Background(), &entities.BrokerACLRequest{Username: "soc-consumer", Action: entities.BrokerActionSubscribe, Topic: "#"})
+		require.NoError(t, err)
+		assert.True(t, decision.Allow)
+		assert.True(t, decision.Superuser)
+	})
+
+	t.Run("should limit devices to the topics of their farm and MAC address", func(t *testing.T) {
+		useCase, deviceRepo, _ := newTestUseCase(t, &BrokerAuthConfig{})
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(newTestDevice(t, "farm-1", ""), nil)
+
+		for _, tc := range []struct {
+			action string
+			topic  string
+			allow  bool
+		}{
+			{entities.BrokerActionPublish, "/devices/registration", true},
+			{entities.BrokerActionPublish, "farms/farm-1/devices/registration", true},
+			{entities.BrokerActionPublish, "farms/farm-2/devices/registration", false},
+			{entities.BrokerActionPublish, "/commands/AA:BB:CC:DD:EE:01", false},
+			{entities.BrokerActionSubscribe, "farms/farm-1/commands/AA:BB:CC:DD:EE:01", true},
+			{entities.BrokerActionSubscribe, "/commands/AA:BB:CC:DD:EE:02", false},
+			{entities.BrokerActionSubscribe, "/commands/#", false},
+		} {
+			decision, err := useCase.Authorize(context.Background(), aclRequest(tc.action, tc.topic))
+			require.NoError(t, err)
+			assert.Equal(t, tc.allow, decision.Allow, "%s %s", tc.action, tc.topic)
+		}
+	})
+
+	t.Run("should use the farm of the onboarding for devices not registered yet", func(t *testing.T) {
+		useCase, deviceRepo, onboardingRepo := newTestUseCase(t, &BrokerAuthConfig{})
+		onboarding, _, err := entities.NewDeviceOnboarding(entities.OnboardingRequest{
+			MACAddress: "AA:BB:CC:DD:EE:01", DeviceName: "Sensor 1", LocationDescription: "Zone A", FarmID: "farm-2",
+		}, time.Hour, time.Now())
+		require.NoError(t, err)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(nil, domainerrors.ErrDeviceNotFound).Once()
+		onboardingRepo.EXPECT().ListPending(mock.Anything).Return([]*entities.DeviceOnboarding{onboarding}, nil).Once()
+
+		decision, err := useCase.Authorize(context.Background(), aclRequest(entities.BrokerActionPublish, "farms/farm-2/devices/registration"))
+		require.NoError(t, err)
+		assert.True(t, decision.Allow)
+	})
+
+	t.Run("should reject unknown actions", func(t *testing.T) {
+		useCase, _, _ := newTestUseCase(t, &BrokerAuthConfig{})
+
+		_, err := useCase.Authorize(context.Background(), aclRequest("retain", "/devices/registration"))
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidInput)
+	})
+}
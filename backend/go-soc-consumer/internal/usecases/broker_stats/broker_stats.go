@@ -0,0 +1,153 @@
+package brokerstats
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// statFamily is the metric a broker statistic is exposed as
+type statFamily struct {
+	stat string
+	name string
+	help string
+	kind string
+}
+
+// statFamilies lists the exposed statistics; the broker counts since it started, so its counters
+// reset when it restarts
+var statFamilies = []statFamily{
+	{entities.BrokerStatConnectedClients, "mqtt_broker_connected_clients", "Clients connected to the MQTT broker", metrics.TypeGauge},
+	{entities.BrokerStatDroppedMessages, "mqtt_broker_dropped_messages_total", "Messages dropped by the MQTT broker", metrics.TypeCounter},
+	{entities.BrokerStatQueuedMessages, "mqtt_broker_queued_messages", "Messages stored by the MQTT broker for delivery", metrics.TypeGauge},
+	{entities.BrokerStatInflightMessages, "mqtt_broker_inflight_messages", "Messages in flight in the MQTT broker", metrics.TypeGauge},
+	{entities.BrokerStatMessagesReceived, "mqtt_broker_messages_received_total", "Messages received by the MQTT broker", metrics.TypeCounter},
+	{entities.BrokerStatMessagesSent, "mqtt_broker_messages_sent_total", "Messages sent by the MQTT broker", metrics.TypeCounter},
+	{entities.BrokerStatSubscriptions, "mqtt_broker_subscriptions", "Subscriptions held by the MQTT broker", metrics.TypeGauge},
+}
+
+// BrokerStatsUseCase keeps the statistics the broker publishes on its $SYS topics and exposes them
+// as metrics, next to the ingestion latency, so a growing consumer lag can be matched with the
+// health of the broker
+type BrokerStatsUseCase interface {
+	// Record keeps the statistic as the latest of its node, warning when the broker dropped messages
+	// since the previous value
+	Record(stat *entities.BrokerStat) error
+}
+
+// statKey identifies a statistic of a node
+type statKey struct {
+	node string
+	name string
+}
+
+// useCaseImpl implements the BrokerStatsUseCase interface
+type useCaseImpl struct {
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+
+	mu          sync.Mutex
+	stats       map[statKey]*entities.BrokerStat
+	lastUpdates map[string]time.Time // node to when it last published a statistic
+}
+
+// NewBrokerStatsUseCase creates a broker stats use case
+func NewBrokerStatsUseCase(loggerFactory logger.LoggerFactory) BrokerStatsUseCase {
+	return &useCaseImpl{
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		stats:         make(map[statKey]*entities.BrokerStat),
+		lastUpdates:   make(map[string]time.Time),
+	}
+}
+
+// Record keeps the latest value of a statistic
+func (uc *useCaseImpl) Record(stat *entities.BrokerStat) error {
+	if err := domainerrors.RequireInput("broker stat", stat); err != nil {
+		return err
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	key := statKey{node: stat.Node, name: stat.Name}
+	previous := uc.stats[key]
+	stored := *stat
+	uc.stats[key] = &stored
+	uc.lastUpdates[stat.Node] = stat.ReceivedAt
+
+	if stat.Name == entities.BrokerStatDroppedMessages && previous != nil && stat.Value > previous.Value {
+		fields := []zap.Field{
+			zap.String("node", stat.Node),
+			zap.Float64("dropped", stat.Value-previous.Value),
+			zap.Duration("interval", stat.ReceivedAt.Sub(previous.ReceivedAt)),
+			zap.String("component", "broker_stats_usecase"),
+		}
+		for _, name := range []string{entities.BrokerStatQueuedMessages, entities.BrokerStatConnectedClients} {
+			if related, ok := uc.stats[statKey{node: stat.Node, name: name}]; ok {
+				fields = append(fields, zap.Float64(name, related.Value))
+			}
+		}
+		uc.loggerFactory.Core().Warn("broker_dropped_messages", fields...)
+	}
+	return nil
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	families := make([]metrics.Family, 0, len(statFamilies)+1)
+	for _, family := range statFamilies {
+		var samples []metrics.Sample
+		for key, stat := range uc.stats {
+			if key.name == family.stat {
+				samples = append(samples, metrics.Sample{Labels: map[string]string{"node": key.node}, Value: stat.Value})
+			}
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		sortSamples(samples)
+		families = append(families, metrics.Family{Name: family.name, Help: family.help, Type: family.kind, Samples: samples})
+	}
+
+	if len(uc.lastUpdates) > 0 {
+		now := uc.now()
+		samples := make([]metrics.Sample, 0, len(uc.lastUpdates))
+		for node, updatedAt := range uc.lastUpdates {
+			samples = append(samples, metrics.Sample{Labels: map[string]string{"node": node}, Value: now.Sub(updatedAt).Seconds()})
+		}
+		sortSamples(samples)
+		families = append(families, metrics.Family{
+			Name:    "mqtt_broker_stats_age_seconds",
+			Help:    "Time since the MQTT broker last published its statistics",
+			Type:    metrics.TypeGauge,
+			Samples: samples,
+		})
+	}
+	return families
+}
+
+// MetricsCollector returns the broker stats metrics collector
+func MetricsCollector(useCase BrokerStatsUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
+
+// sortSamples orders samples by node so scrapes are stable
+func sortSamples(samples []metrics.Sample) {
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Labels["node"] < samples[j].Labels["node"]
+	})
+}
@@ -0,0 +1,78 @@
+package brokerstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+func newTestUseCase(t *testing.T, now *time.Time) *useCaseImpl {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	useCase := NewBrokerStatsUseCase(loggerFactory).(*useCaseImpl)
+	useCase.now = func() time.Time { return *now }
+	return useCase
+}
+
+// family returns the collected family with the given name
+func family(families []metrics.Family, name string) *metrics.Family {
+	for i := range families {
+		if families[i].Name == name {
+			return &families[i]
+		}
+	}
+	return nil
+}
+
+func TestBrokerStatsUseCase_Record(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase := newTestUseCase(t, &now)
+
+	assert.ErrorIs(t, useCase.Record(nil), domainerrors.ErrMissingInput)
+
+	require.NoError(t, useCase.Record(&entities.BrokerStat{Name: entities.BrokerStatConnectedClients, Value: 12, ReceivedAt: now.Add(-time.Minute)}))
+	require.NoError(t, useCase.Record(&entities.BrokerStat{Name: entities.BrokerStatDroppedMessages, Value: 3, ReceivedAt: now.Add(-time.Minute)}))
+	require.NoError(t, useCase.Record(&entities.BrokerStat{Name: entities.BrokerStatDroppedMessages, Value: 8, ReceivedAt: now.Add(-30 * time.Second)}))
+	require.NoError(t, useCase.Record(&entities.BrokerStat{Node: "emqx@node2", Name: entities.BrokerStatConnectedClients, Value: 4, ReceivedAt: now.Add(-10 * time.Second)}))
+
+	assert.Equal(t, float64(8), useCase.stats[statKey{name: entities.BrokerStatDroppedMessages}].Value, "the latest value is kept")
+}
+
+func TestBrokerStatsUseCase_Collect(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase := newTestUseCase(t, &now)
+	assert.Empty(t, useCase.Collect(), "nothing is exposed before the broker publishes")
+
+	require.NoError(t, useCase.Record(&entities.BrokerStat{Node: "emqx@node2", Name: entities.BrokerStatConnectedClients, Value: 4, ReceivedAt: now.Add(-10 * time.Second)}))
+	require.NoError(t, useCase.Record(&entities.BrokerStat{Node: "emqx@node1", Name: entities.BrokerStatConnectedClients, Value: 12, ReceivedAt: now.Add(-time.Minute)}))
+	require.NoError(t, useCase.Record(&entities.BrokerStat{Node: "emqx@node1", Name: entities.BrokerStatDroppedMessages, Value: 3, ReceivedAt: now.Add(-time.Minute)}))
+
+	families := useCase.Collect()
+	require.Len(t, families, 3)
+
+	clients := family(families, "mqtt_broker_connected_clients")
+	require.NotNil(t, clients)
+	assert.Equal(t, metrics.TypeGauge, clients.Type)
+	assert.Equal(t, []metrics.Sample{
+		{Labels: map[string]string{"node": "emqx@node1"}, Value: 12},
+		{Labels: map[string]string{"node": "emqx@node2"}, Value: 4},
+	}, clients.Samples)
+
+	dropped := family(families, "mqtt_broker_dropped_messages_total")
+	require.NotNil(t, dropped)
+	assert.Equal(t, metrics.TypeCounter, dropped.Type)
+
+	age := family(families, "mqtt_broker_stats_age_seconds")
+	require.NotNil(t, age)
+	assert.Equal(t, []metrics.Sample{
+		{Labels: map[string]string{"node": "emqx@node1"}, Value: 60},
+		{Labels: map[string]string{"node": "emqx@node2"}, Value: 10},
+	}, age.Samples)
+}
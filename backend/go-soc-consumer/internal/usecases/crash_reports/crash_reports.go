@@ -0,0 +1,153 @@
+package crashreports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// CrashReportsConfig holds configuration for firmware crash reports
+type CrashReportsConfig struct {
+	Window        time.Duration // default window of the crash frequency report
+	TopSignatures int           // most frequent signatures listed for each firmware version
+}
+
+// DefaultCrashReportsConfig returns default configuration
+func DefaultCrashReportsConfig() *CrashReportsConfig {
+	return &CrashReportsConfig{
+		Window:        7 * 24 * time.Hour,
+		TopSignatures: 5,
+	}
+}
+
+// CrashReportsUseCase stores the crash reports of devices and reports how often each firmware
+// version crashes, to guide OTA rollbacks
+type CrashReportsUseCase interface {
+	// Ingest stores a crash report of a registered device. An unknown device fails with
+	// ErrDeviceNotFound and a device reporting from the namespace of another farm with ErrDeviceFarmMismatch.
+	Ingest(ctx context.Context, report *entities.CrashReport) error
+
+	// FrequencyReport returns the crashes reported since the given time, or within the configured window
+	// when it is zero, by firmware version with the most crashes first
+	FrequencyReport(ctx context.Context, since time.Time) ([]*entities.FirmwareCrashStats, error)
+}
+
+// useCaseImpl implements the CrashReportsUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	crashRepo     repositoryports.CrashReportRepository
+	config        *CrashReportsConfig
+	loggerFactory logger.LoggerFactory
+	crashes       *metrics.Vec
+	now           func() time.Time
+}
+
+// NewCrashReportsUseCase creates a crash reports use case
+func NewCrashReportsUseCase(
+	deviceRepo repositoryports.DeviceRepository,
+	crashRepo repositoryports.CrashReportRepository,
+	config *CrashReportsConfig,
+	loggerFactory logger.LoggerFactory,
+) CrashReportsUseCase {
+	if config == nil {
+		config = DefaultCrashReportsConfig()
+	}
+
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		crashRepo:     crashRepo,
+		config:        config,
+		loggerFactory: loggerFactory,
+		crashes:       metrics.NewCounterVec("device_crash_reports_total", "Crash reports received from devices by firmware version", "firmware_version"),
+		now:           time.Now,
+	}
+}
+
+// Ingest stores the crash report of a known device
+func (uc *useCaseImpl) Ingest(ctx context.Context, report *entities.CrashReport) error {
+	if err := domainerrors.RequireInput("crash report", report); err != nil {
+		return err
+	}
+
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, report.MACAddress)
+	if err != nil {
+		return err
+	}
+	if err := device.VerifyFarm(eventports.FarmIDFromContext(ctx)); err != nil {
+		return fmt.Errorf("%w: %v", domainerrors.ErrDeviceFarmMismatch, err)
+	}
+
+	isNew, err := uc.crashRepo.Record(ctx, report)
+	if err != nil {
+		return fmt.Errorf("failed to store crash report: %w", err)
+	}
+	uc.crashes.Inc(report.FirmwareVersion)
+
+	fields := []zap.Field{
+		zap.String("mac_address", report.MACAddress),
+		zap.String("firmware_version", report.FirmwareVersion),
+		zap.String("reason", report.Reason),
+		zap.String("signature", report.Signature),
+		zap.Int64("uptime_seconds", report.UptimeSeconds),
+		zap.String("component", "crash_reports_usecase"),
+	}
+	if isNew {
+		uc.loggerFactory.Core().Warn("crash_signature_new", append(fields, zap.Strings("backtrace", report.Backtrace))...)
+		return nil
+	}
+	uc.loggerFactory.Core().Info("crash_report_recorded", fields...)
+	return nil
+}
+
+// FrequencyReport aggregates the crashes of the window by firmware version and attaches the most
+// frequent signatures of each
+func (uc *useCaseImpl) FrequencyReport(ctx context.Context, since time.Time) ([]*entities.FirmwareCrashStats, error) {
+	if since.IsZero() {
+		since = uc.now().Add(-uc.config.Window)
+	}
+
+	stats, err := uc.crashRepo.FirmwareStats(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	signatures, err := uc.crashRepo.SignatureStats(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[string]*entities.FirmwareCrashStats, len(stats))
+	for _, version := range stats {
+		version.TopSignatures = make([]*entities.CrashSignature, 0, uc.config.TopSignatures)
+		byVersion[version.FirmwareVersion] = version
+	}
+	// Signatures come most frequent first
+	for _, signature := range signatures {
+		version, ok := byVersion[signature.FirmwareVersion]
+		if ok && len(version.TopSignatures) < uc.config.TopSignatures {
+			version.TopSignatures = append(version.TopSignatures, signature)
+		}
+	}
+	return stats, nil
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return uc.crashes.Collect()
+}
+
+// MetricsCollector returns the crash reports metrics collector
+func MetricsCollector(useCase CrashReportsUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
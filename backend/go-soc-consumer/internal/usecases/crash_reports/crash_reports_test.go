@@ -0,0 +1,95 @@
+package crashreports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestUseCase(t *testing.T, now time.Time) (*useCaseImpl, *mocks.MockDeviceRepository, *mocks.MockCrashReportRepository) {
+	deviceRepo := mocks.NewMockDeviceRepository(t)
+	crashRepo := mocks.NewMockCrashReportRepository(t)
+	useCase := NewCrashReportsUseCase(deviceRepo, crashRepo, nil, createTestLoggerFactory(t)).(*useCaseImpl)
+	useCase.now = func() time.Time { return now }
+	return useCase, deviceRepo, crashRepo
+}
+
+func TestCrashReportsUseCase_Ingest(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	report, err := entities.NewCrashReport(testMAC, "1.4.2", "LoadProhibited", []string{"0x400d1234"}, 3600, now)
+	require.NoError(t, err)
+
+	t.Run("should store the report of a known device", func(t *testing.T) {
+		useCase, deviceRepo, crashRepo := newTestUseCase(t, now)
+		device, err := entities.NewDevice(testMAC, "probe", "192.168.1.10", "greenhouse")
+		require.NoError(t, err)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+		crashRepo.EXPECT().Record(mock.Anything, report).Return(true, nil).Once()
+
+		require.NoError(t, useCase.Ingest(context.Background(), report))
+		assert.Equal(t, float64(1), useCase.crashes.Value("1.4.2"))
+	})
+
+	t.Run("should reject reports from the namespace of another farm", func(t *testing.T) {
+		useCase, deviceRepo, _ := newTestUseCase(t, now)
+		device, err := entities.NewDevice(testMAC, "probe", "192.168.1.10", "greenhouse")
+		require.NoError(t, err)
+		device.FarmID = "farm-1"
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+
+		err = useCase.Ingest(eventports.ContextWithFarmID(context.Background(), "farm-2"), report)
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceFarmMismatch)
+	})
+
+	t.Run("should reject reports of unknown devices", func(t *testing.T) {
+		useCase, deviceRepo, _ := newTestUseCase(t, now)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+		assert.ErrorIs(t, useCase.Ingest(context.Background(), report), domainerrors.ErrDeviceNotFound)
+	})
+}
+
+func TestCrashReportsUseCase_FrequencyReport(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-7 * 24 * time.Hour)
+	useCase, _, crashRepo := newTestUseCase(t, now)
+	useCase.config.TopSignatures = 2
+	crashRepo.EXPECT().FirmwareStats(mock.Anything, since).Return([]*entities.FirmwareCrashStats{
+		{FirmwareVersion: "1.4.2", Crashes: 14, Devices: 6, Signatures: 3},
+		{FirmwareVersion: "1.4.1", Crashes: 1, Devices: 1, Signatures: 1},
+	}, nil).Once()
+	crashRepo.EXPECT().SignatureStats(mock.Anything, since).Return([]*entities.CrashSignature{
+		{Signature: "a", FirmwareVersion: "1.4.2", Occurrences: 10},
+		{Signature: "b", FirmwareVersion: "1.4.2", Occurrences: 3},
+		{Signature: "c", FirmwareVersion: "1.4.1", Occurrences: 1},
+		{Signature: "d", FirmwareVersion: "1.4.2", Occurrences: 1},
+	}, nil).Once()
+
+	stats, err := useCase.FrequencyReport(context.Background(), time.Time{})
+
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	require.Len(t, stats[0].TopSignatures, 2)
+	assert.Equal(t, "a", stats[0].TopSignatures[0].Signature)
+	assert.Equal(t, "b", stats[0].TopSignatures[1].Signature)
+	require.Len(t, stats[1].TopSignatures, 1)
+	assert.Equal(t, "c", stats[1].TopSignatures[0].Signature)
+}
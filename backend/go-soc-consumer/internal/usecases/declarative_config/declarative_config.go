@@ -0,0 +1,201 @@
+package declarativeconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// DeclarativeConfigUseCase reconciles the stored device configuration with a declaration, so
+// infrastructure-as-code tools can manage it. Reconciling the same declaration twice changes
+// nothing the second time. Applied declarations also store the shadow of every declared device,
+// which the drift reconciler keeps the devices in line with.
+type DeclarativeConfigUseCase interface {
+	// Reconcile plans the creates, updates and deletes that make the devices match the declaration
+	// and applies them unless dryRun is set. Invalid declarations fail with ErrInvalidDeclaration
+	// before anything is written. When a write fails, the returned plan holds the changes applied
+	// before it.
+	Reconcile(ctx context.Context, declaration *entities.Declaration, dryRun bool) (*entities.DeclarationPlan, error)
+}
+
+// useCaseImpl implements the DeclarativeConfigUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	shadowRepo    repositoryports.DeviceShadowRepository
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+}
+
+// NewDeclarativeConfigUseCase creates a new declarative configuration use case
+func NewDeclarativeConfigUseCase(deviceRepo repositoryports.DeviceRepository, shadowRepo repositoryports.DeviceShadowRepository, loggerFactory logger.LoggerFactory) DeclarativeConfigUseCase {
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		shadowRepo:    shadowRepo,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+	}
+}
+
+// plannedChange is a change of the plan with the device it writes, nil for deletes
+type plannedChange struct {
+	change entities.DeclarationChange
+	device *entities.Device
+}
+
+// actionOrder applies creates first and deletes last
+var actionOrder = map[string]int{
+	entities.DeclarationCreate: 0,
+	entities.DeclarationUpdate: 1,
+	entities.DeclarationDelete: 2,
+}
+
+// Reconcile plans the declaration and applies the plan
+func (uc *useCaseImpl) Reconcile(ctx context.Context, declaration *entities.Declaration, dryRun bool) (*entities.DeclarationPlan, error) {
+	if err := domainerrors.RequireInput("declaration", declaration); err != nil {
+		return nil, err
+	}
+	if err := declaration.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDeclaration, err)
+	}
+
+	changes, shadows, unchanged, err := uc.plan(ctx, declaration)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &entities.DeclarationPlan{DryRun: dryRun, Changes: []entities.DeclarationChange{}, Unchanged: unchanged}
+	if dryRun {
+		for _, planned := range changes {
+			plan.Changes = append(plan.Changes, planned.change)
+		}
+		return plan, nil
+	}
+
+	for _, planned := range changes {
+		if err := ctx.Err(); err != nil {
+			return plan, err
+		}
+		if err := uc.apply(ctx, planned); err != nil {
+			return plan, fmt.Errorf("failed to %s device %s: %w", planned.change.Action, planned.change.MACAddress, err)
+		}
+		plan.Changes = append(plan.Changes, planned.change)
+	}
+	for _, shadow := range shadows {
+		if err := uc.shadowRepo.Upsert(ctx, shadow); err != nil {
+			return plan, fmt.Errorf("failed to store shadow of device %s: %w", shadow.MACAddress, err)
+		}
+	}
+
+	uc.loggerFactory.Core().Info("declaration_reconciled",
+		zap.String("farm_id", declaration.FarmID),
+		zap.Int("changes", len(plan.Changes)),
+		zap.Int("unchanged", plan.Unchanged),
+		zap.String("component", "declarative_config_usecase"),
+	)
+	return plan, nil
+}
+
+// plan resolves every change before anything is written, so an invalid device does not leave a
+// partial reconciliation behind. It also returns the shadows of the declared devices.
+func (uc *useCaseImpl) plan(ctx context.Context, declaration *entities.Declaration) ([]plannedChange, []*entities.DeviceShadow, int, error) {
+	devices, err := uc.deviceRepo.List(ctx, 0, pagination.Unlimited)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to load devices: %w", err)
+	}
+	existing := make(map[string]*entities.Device, len(devices))
+	for _, device := range devices {
+		existing[device.GetID()] = device
+	}
+
+	var changes []plannedChange
+	var shadows []*entities.DeviceShadow
+	declaredAt := uc.now()
+	unchanged := 0
+	declared := make(map[string]bool, len(declaration.Devices))
+	for _, configuration := range declaration.Devices {
+		configuration.MACAddress = strings.ToUpper(strings.TrimSpace(configuration.MACAddress))
+		if declaration.FarmID != "" {
+			configuration.FarmID = declaration.FarmID
+		}
+		macAddress := configuration.MACAddress
+		declared[macAddress] = true
+
+		device, found := existing[macAddress]
+		if !found {
+			created, err := configuration.NewDevice()
+			if err != nil {
+				return nil, nil, 0, fmt.Errorf("%w: device %s: %v", domainerrors.ErrInvalidDeclaration, macAddress, err)
+			}
+			changes = append(changes, plannedChange{
+				change: entities.DeclarationChange{Action: entities.DeclarationCreate, MACAddress: macAddress},
+				device: created,
+			})
+			shadows = append(shadows, entities.NewDeviceShadow(created, declaredAt))
+			continue
+		}
+		// A farm-scoped declaration cannot take over the devices of another farm
+		if declaration.FarmID != "" && device.FarmID != "" && device.FarmID != declaration.FarmID {
+			return nil, nil, 0, fmt.Errorf("%w: device %s belongs to farm %s", domainerrors.ErrInvalidDeclaration, macAddress, device.FarmID)
+		}
+
+		updated, err := device.WithChanges(func(device *entities.Device) error {
+			return device.ApplyConfiguration(configuration, false)
+		})
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("%w: device %s: %v", domainerrors.ErrInvalidDeclaration, macAddress, err)
+		}
+		shadows = append(shadows, entities.NewDeviceShadow(updated, declaredAt))
+		fields := entities.ChangedConfigurationFields(device.Configuration(), updated.Configuration())
+		if len(fields) == 0 {
+			unchanged++
+			continue
+		}
+		changes = append(changes, plannedChange{
+			change: entities.DeclarationChange{Action: entities.DeclarationUpdate, MACAddress: macAddress, Fields: fields},
+			device: updated,
+		})
+	}
+
+	for macAddress, device := range existing {
+		if declared[macAddress] || (declaration.FarmID != "" && device.FarmID != declaration.FarmID) {
+			continue
+		}
+		changes = append(changes, plannedChange{
+			change: entities.DeclarationChange{Action: entities.DeclarationDelete, MACAddress: macAddress},
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i].change, changes[j].change
+		if a.Action != b.Action {
+			return actionOrder[a.Action] < actionOrder[b.Action]
+		}
+		return a.MACAddress < b.MACAddress
+	})
+	return changes, shadows, unchanged, nil
+}
+
+// apply writes one planned change
+func (uc *useCaseImpl) apply(ctx context.Context, planned plannedChange) error {
+	switch planned.change.Action {
+	case entities.DeclarationCreate:
+		return uc.deviceRepo.Create(ctx, planned.device)
+	case entities.DeclarationUpdate:
+		return uc.deviceRepo.Update(ctx, planned.device)
+	default:
+		if err := uc.deviceRepo.Delete(ctx, planned.change.MACAddress); err != nil {
+			return err
+		}
+		return uc.shadowRepo.Delete(ctx, planned.change.MACAddress)
+	}
+}
@@ -0,0 +1,144 @@
+package declarativeconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func newTestUseCase(t *testing.T, repo *mocks.MockDeviceRepository, shadowRepo *mocks.MockDeviceShadowRepository) DeclarativeConfigUseCase {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return NewDeclarativeConfigUseCase(repo, shadowRepo, loggerFactory)
+}
+
+func storedDevice(t *testing.T, macAddress, name, farmID string) *entities.Device {
+	device, err := entities.NewDevice(macAddress, name, "10.0.0.1", "Greenhouse")
+	require.NoError(t, err)
+	if farmID != "" {
+		require.NoError(t, device.AssignFarm(farmID))
+	}
+	return device
+}
+
+func declared(macAddress, name string) entities.DeviceConfiguration {
+	return entities.DeviceConfiguration{MACAddress: macAddress, DeviceName: name, IPAddress: "10.0.0.1", LocationDescription: "Greenhouse"}
+}
+
+func TestDeclarativeConfigUseCase_Reconcile(t *testing.T) {
+	stored := func(t *testing.T) []*entities.Device {
+		return []*entities.Device{
+			storedDevice(t, "AA:BB:CC:DD:EE:01", "Sensor 1", "farm-a"),
+			storedDevice(t, "AA:BB:CC:DD:EE:02", "Sensor 2", "farm-a"),
+			storedDevice(t, "AA:BB:CC:DD:EE:03", "Sensor 3", "farm-a"),
+			storedDevice(t, "AA:BB:CC:DD:EE:09", "Other farm", "farm-b"),
+		}
+	}
+	declaration := &entities.Declaration{FarmID: "farm-a", Devices: []entities.DeviceConfiguration{
+		declared("aa:bb:cc:dd:ee:01", "Sensor 1"),
+		declared("AA:BB:CC:DD:EE:02", "North valve"),
+		declared("AA:BB:CC:DD:EE:04", "Sensor 4"),
+	}}
+	expected := []entities.DeclarationChange{
+		{Action: entities.DeclarationCreate, MACAddress: "AA:BB:CC:DD:EE:04"},
+		{Action: entities.DeclarationUpdate, MACAddress: "AA:BB:CC:DD:EE:02", Fields: []string{"device_name"}},
+		{Action: entities.DeclarationDelete, MACAddress: "AA:BB:CC:DD:EE:03"},
+	}
+
+	t.Run("should only plan the changes on a dry run", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return(stored(t), nil).Once()
+
+		plan, err := newTestUseCase(t, repo, mocks.NewMockDeviceShadowRepository(t)).Reconcile(context.Background(), declaration, true)
+		require.NoError(t, err)
+		assert.Equal(t, &entities.DeclarationPlan{DryRun: true, Changes: expected, Unchanged: 1}, plan)
+	})
+
+	t.Run("should apply the changes of the farm", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return(stored(t), nil).Once()
+		repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.MACAddress == "AA:BB:CC:DD:EE:04" && device.FarmID == "farm-a"
+		})).Return(nil).Once()
+		repo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.MACAddress == "AA:BB:CC:DD:EE:02" && device.DeviceName == "North valve" && device.FarmID == "farm-a"
+		})).Return(nil).Once()
+		repo.EXPECT().Delete(mock.Anything, "AA:BB:CC:DD:EE:03").Return(nil).Once()
+		shadowRepo := mocks.NewMockDeviceShadowRepository(t)
+		shadowRepo.EXPECT().Delete(mock.Anything, "AA:BB:CC:DD:EE:03").Return(nil).Once()
+		var shadows []string
+		shadowRepo.EXPECT().Upsert(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, shadow *entities.DeviceShadow) error {
+			shadows = append(shadows, shadow.MACAddress+" "+shadow.DeviceName)
+			return nil
+		}).Times(3)
+
+		plan, err := newTestUseCase(t, repo, shadowRepo).Reconcile(context.Background(), declaration, false)
+		require.NoError(t, err)
+		assert.Equal(t, expected, plan.Changes)
+		assert.Equal(t, []string{"AA:BB:CC:DD:EE:01 Sensor 1", "AA:BB:CC:DD:EE:02 North valve", "AA:BB:CC:DD:EE:04 Sensor 4"}, shadows, "unchanged devices are declared too")
+	})
+
+	t.Run("should report the changes applied before a failure", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return(stored(t), nil).Once()
+		repo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+		repo.EXPECT().Update(mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+
+		plan, err := newTestUseCase(t, repo, mocks.NewMockDeviceShadowRepository(t)).Reconcile(context.Background(), declaration, false)
+		assert.ErrorContains(t, err, "failed to update device AA:BB:CC:DD:EE:02")
+		assert.Equal(t, expected[:1], plan.Changes)
+	})
+
+	t.Run("should change nothing when the devices match", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return([]*entities.Device{storedDevice(t, "AA:BB:CC:DD:EE:01", "Sensor 1", "")}, nil).Once()
+		shadowRepo := mocks.NewMockDeviceShadowRepository(t)
+		shadowRepo.EXPECT().Upsert(mock.Anything, mock.Anything).Return(nil).Once()
+
+		plan, err := newTestUseCase(t, repo, shadowRepo).Reconcile(context.Background(), &entities.Declaration{Devices: []entities.DeviceConfiguration{declared("AA:BB:CC:DD:EE:01", "Sensor 1")}}, false)
+		require.NoError(t, err)
+		assert.Empty(t, plan.Changes)
+		assert.Equal(t, 1, plan.Unchanged)
+	})
+}
+
+func TestDeclarativeConfigUseCase_InvalidDeclarations(t *testing.T) {
+	t.Run("should reject devices of another farm", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return([]*entities.Device{storedDevice(t, "AA:BB:CC:DD:EE:09", "Other farm", "farm-b")}, nil).Once()
+
+		_, err := newTestUseCase(t, repo, mocks.NewMockDeviceShadowRepository(t)).Reconcile(context.Background(), &entities.Declaration{FarmID: "farm-a", Devices: []entities.DeviceConfiguration{declared("AA:BB:CC:DD:EE:09", "Mine")}}, true)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeclaration)
+		assert.ErrorContains(t, err, "belongs to farm farm-b")
+	})
+
+	t.Run("should reject incomplete new devices before writing", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return(nil, nil).Once()
+
+		_, err := newTestUseCase(t, repo, mocks.NewMockDeviceShadowRepository(t)).Reconcile(context.Background(), &entities.Declaration{Devices: []entities.DeviceConfiguration{
+			declared("AA:BB:CC:DD:EE:01", "Sensor 1"),
+			{MACAddress: "AA:BB:CC:DD:EE:02"},
+		}}, false)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeclaration)
+	})
+
+	t.Run("should reject invalid and missing declarations", func(t *testing.T) {
+		useCase := newTestUseCase(t, mocks.NewMockDeviceRepository(t), mocks.NewMockDeviceShadowRepository(t))
+
+		_, err := useCase.Reconcile(context.Background(), &entities.Declaration{FarmID: "farm a"}, true)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeclaration)
+		_, err = useCase.Reconcile(context.Background(), nil, true)
+		assert.ErrorIs(t, err, domainerrors.ErrMissingInput)
+	})
+}
@@ -0,0 +1,212 @@
+package derivedsensors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// DerivedConfig holds configuration for evaluating scheduled derived sensors
+type DerivedConfig struct {
+	Interval time.Duration // how often scheduled sensors are evaluated
+	MaxAge   time.Duration // inputs older than this are ignored by scheduled sensors
+}
+
+// DefaultDerivedConfig returns default configuration
+func DefaultDerivedConfig() *DerivedConfig {
+	return &DerivedConfig{
+		Interval: 5 * time.Minute,
+		MaxAge:   15 * time.Minute,
+	}
+}
+
+// DerivedSensorUseCase computes and stores the values of derived sensors
+type DerivedSensorUseCase interface {
+	// DeriveFrom stores the values of the ingestion sensors computed from just stored measurements.
+	// Failures are logged, the stored measurements are not affected.
+	DeriveFrom(ctx context.Context, stored []*entities.Measurement)
+
+	// EvaluateOnce stores the values of the scheduled sensors computed from the latest value of every
+	// input and returns how many values were stored
+	EvaluateOnce(ctx context.Context) (int, error)
+
+	// Run evaluates the scheduled sensors periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// useCaseImpl implements the DerivedSensorUseCase interface
+type useCaseImpl struct {
+	ingestion     []*entities.DerivedSensor
+	scheduled     []*entities.DerivedSensor
+	measurements  measurements.MeasurementUseCase
+	repo          repositoryports.MeasurementRepository
+	config        *DerivedConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+}
+
+// NewDerivedSensorUseCase creates a derived sensor use case. Derived values are stored through the
+// measurement use case, whose registry must include the derived sensor types.
+func NewDerivedSensorUseCase(
+	sensors []*entities.DerivedSensor,
+	measurementUseCase measurements.MeasurementUseCase,
+	repo repositoryports.MeasurementRepository,
+	config *DerivedConfig,
+	loggerFactory logger.LoggerFactory,
+) DerivedSensorUseCase {
+	if config == nil {
+		config = DefaultDerivedConfig()
+	}
+
+	uc := &useCaseImpl{
+		measurements:  measurementUseCase,
+		repo:          repo,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+	}
+	for _, sensor := range sensors {
+		if sensor.Mode == entities.DerivedSensorModeSchedule {
+			uc.scheduled = append(uc.scheduled, sensor)
+		} else {
+			uc.ingestion = append(uc.ingestion, sensor)
+		}
+	}
+	return uc
+}
+
+// DeriveFrom evaluates the ingestion sensors over the stored measurements, stamping the derived
+// values with the latest timestamp among them
+func (uc *useCaseImpl) DeriveFrom(ctx context.Context, stored []*entities.Measurement) {
+	if len(uc.ingestion) == 0 || len(stored) == 0 {
+		return
+	}
+
+	at := stored[0].Timestamp
+	for _, measurement := range stored[1:] {
+		if measurement.Timestamp.After(at) {
+			at = measurement.Timestamp
+		}
+	}
+
+	derived := uc.derive(uc.ingestion, stored, at)
+	if len(derived) == 0 {
+		return
+	}
+	if err := uc.measurements.StoreMeasurements(ctx, derived); err != nil {
+		uc.loggerFactory.Core().Warn("derived_measurements_store_failed",
+			zap.Error(err),
+			zap.String("mac_address", derived[0].MACAddress),
+			zap.Int("count", len(derived)),
+			zap.String("component", "derived_sensors_usecase"),
+		)
+	}
+}
+
+// EvaluateOnce evaluates the scheduled sensors over the latest value of their inputs, storing the
+// derived values of each device together
+func (uc *useCaseImpl) EvaluateOnce(ctx context.Context) (int, error) {
+	if len(uc.scheduled) == 0 {
+		return 0, nil
+	}
+
+	seen := make(map[string]bool)
+	var inputs []string
+	for _, sensor := range uc.scheduled {
+		for _, input := range sensor.Inputs() {
+			if !seen[input] {
+				seen[input] = true
+				inputs = append(inputs, input)
+			}
+		}
+	}
+
+	now := uc.now()
+	latest, err := uc.repo.Latest(ctx, inputs, now.Add(-uc.config.MaxAge))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load latest measurements: %w", err)
+	}
+
+	byDevice := make(map[string][]*entities.Measurement)
+	var devices []string
+	for _, measurement := range uc.derive(uc.scheduled, latest, now) {
+		if _, ok := byDevice[measurement.MACAddress]; !ok {
+			devices = append(devices, measurement.MACAddress)
+		}
+		byDevice[measurement.MACAddress] = append(byDevice[measurement.MACAddress], measurement)
+	}
+
+	stored := 0
+	for _, macAddress := range devices {
+		if err := ctx.Err(); err != nil {
+			return stored, err
+		}
+		if err := uc.measurements.StoreMeasurements(ctx, byDevice[macAddress]); err != nil {
+			uc.loggerFactory.Core().Warn("derived_measurements_store_failed",
+				zap.Error(err),
+				zap.String("mac_address", macAddress),
+				zap.String("component", "derived_sensors_usecase"),
+			)
+			continue
+		}
+		stored += len(byDevice[macAddress])
+	}
+	return stored, nil
+}
+
+// derive evaluates the sensors, logging the values that could not be derived
+func (uc *useCaseImpl) derive(sensors []*entities.DerivedSensor, inputs []*entities.Measurement, at time.Time) []*entities.Measurement {
+	var derived []*entities.Measurement
+	for _, sensor := range sensors {
+		values, err := sensor.Derive(inputs, at)
+		if err != nil {
+			uc.loggerFactory.Core().Warn("derived_sensor_evaluation_failed",
+				zap.Error(err),
+				zap.String("sensor_type", sensor.SensorType.Name),
+				zap.String("expression", sensor.Expression.String()),
+				zap.String("component", "derived_sensors_usecase"),
+			)
+		}
+		derived = append(derived, values...)
+	}
+	return derived
+}
+
+// Run evaluates the scheduled sensors periodically until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	if len(uc.scheduled) == 0 {
+		return
+	}
+
+	uc.loggerFactory.Application().LogApplicationEvent("derived_sensors_started", "derived_sensors_usecase",
+		zap.Int("scheduled_sensors", len(uc.scheduled)),
+		zap.Duration("interval", uc.config.Interval),
+		zap.Duration("max_age", uc.config.MaxAge),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("derived_sensors_stopped", "derived_sensors_usecase")
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := uc.EvaluateOnce(ctx); err != nil && ctx.Err() == nil {
+			uc.loggerFactory.Core().Error("derived_sensors_evaluation_failed",
+				zap.Error(err),
+				zap.String("component", "derived_sensors_usecase"),
+			)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package derivedsensors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+func newTestDerivedSensor(t *testing.T, name, source, mode string) *entities.DerivedSensor {
+	sensor, err := entities.NewDerivedSensor(&entities.SensorType{Name: name, Unit: "celsius", MinValue: -60, MaxValue: 60}, source, mode)
+	require.NoError(t, err)
+	return sensor
+}
+
+func newTestMeasurement(t *testing.T, sensorType string, value float64, timestamp time.Time) *entities.Measurement {
+	measurement, err := entities.NewMeasurement(testMAC, sensorType, 0, value, "", timestamp)
+	require.NoError(t, err)
+	return measurement
+}
+
+func newTestUseCase(t *testing.T, measurementUseCase *mocks.MockMeasurementUseCase, repo *mocks.MockMeasurementRepository, sensors ...*entities.DerivedSensor) *useCaseImpl {
+	loggerFactory, err := logger.NewDevelopment()
+	require.NoError(t, err)
+	return NewDerivedSensorUseCase(sensors, measurementUseCase, repo, nil, loggerFactory).(*useCaseImpl)
+}
+
+func TestDerivedSensorUseCase_DeriveFrom(t *testing.T) {
+	ctx := context.Background()
+	takenAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("stores the ingestion sensors whose inputs were stored", func(t *testing.T) {
+		measurementUseCase := mocks.NewMockMeasurementUseCase(t)
+		useCase := newTestUseCase(t, measurementUseCase, mocks.NewMockMeasurementRepository(t),
+			newTestDerivedSensor(t, "dew_point", "dew_point(temperature, humidity)", entities.DerivedSensorModeIngestion),
+			newTestDerivedSensor(t, "soil_dew_point", "dew_point(soil_temperature, humidity)", entities.DerivedSensorModeIngestion),
+			newTestDerivedSensor(t, "hourly_dew_point", "dew_point(temperature, humidity)", entities.DerivedSensorModeSchedule),
+		)
+		measurementUseCase.EXPECT().StoreMeasurements(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, values []*entities.Measurement) error {
+			require.Len(t, values, 1)
+			assert.Equal(t, "dew_point", values[0].SensorType)
+			assert.InDelta(t, 9.26, values[0].Value, 0.01)
+			assert.Equal(t, takenAt, values[0].Timestamp)
+			return nil
+		}).Once()
+
+		useCase.DeriveFrom(ctx, []*entities.Measurement{newTestMeasurement(t, "temperature", 20, takenAt), newTestMeasurement(t, "humidity", 50, takenAt)})
+	})
+
+	t.Run("stores nothing without complete inputs", func(t *testing.T) {
+		useCase := newTestUseCase(t, mocks.NewMockMeasurementUseCase(t), mocks.NewMockMeasurementRepository(t),
+			newTestDerivedSensor(t, "dew_point", "dew_point(temperature, humidity)", entities.DerivedSensorModeIngestion),
+		)
+
+		useCase.DeriveFrom(ctx, []*entities.Measurement{newTestMeasurement(t, "temperature", 20, takenAt)})
+	})
+
+	t.Run("absorbs store failures", func(t *testing.T) {
+		measurementUseCase := mocks.NewMockMeasurementUseCase(t)
+		useCase := newTestUseCase(t, measurementUseCase, mocks.NewMockMeasurementRepository(t),
+			newTestDerivedSensor(t, "dew_point", "dew_point(temperature, humidity)", entities.DerivedSensorModeIngestion),
+		)
+		measurementUseCase.EXPECT().StoreMeasurements(ctx, mock.Anything).Return(errors.New("database down")).Once()
+
+		useCase.DeriveFrom(ctx, []*entities.Measurement{newTestMeasurement(t, "temperature", 20, takenAt), newTestMeasurement(t, "humidity", 50, takenAt)})
+	})
+}
+
+func TestDerivedSensorUseCase_EvaluateOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("derives from the latest inputs", func(t *testing.T) {
+		measurementUseCase := mocks.NewMockMeasurementUseCase(t)
+		repo := mocks.NewMockMeasurementRepository(t)
+		useCase := newTestUseCase(t, measurementUseCase, repo,
+			newTestDerivedSensor(t, "dew_point", "dew_point(temperature, humidity)", entities.DerivedSensorModeSchedule),
+			newTestDerivedSensor(t, "warming", "temperature - 10", entities.DerivedSensorModeSchedule),
+		)
+		useCase.now = func() time.Time { return now }
+		repo.EXPECT().Latest(ctx, []string{"humidity", "temperature"}, now.Add(-15*time.Minute)).Return([]*entities.Measurement{
+			newTestMeasurement(t, "temperature", 20, now.Add(-time.Minute)),
+			newTestMeasurement(t, "humidity", 50, now.Add(-2*time.Minute)),
+		}, nil).Once()
+		measurementUseCase.EXPECT().StoreMeasurements(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, values []*entities.Measurement) error {
+			require.Len(t, values, 2)
+			assert.Equal(t, now, values[0].Timestamp)
+			assert.Equal(t, 10.0, values[1].Value)
+			return nil
+		}).Once()
+
+		stored, err := useCase.EvaluateOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, stored)
+	})
+
+	t.Run("does nothing without scheduled sensors", func(t *testing.T) {
+		useCase := newTestUseCase(t, mocks.NewMockMeasurementUseCase(t), mocks.NewMockMeasurementRepository(t),
+			newTestDerivedSensor(t, "dew_point", "dew_point(temperature, humidity)", entities.DerivedSensorModeIngestion),
+		)
+
+		stored, err := useCase.EvaluateOnce(ctx)
+
+		require.NoError(t, err)
+		assert.Zero(t, stored)
+	})
+
+	t.Run("wraps repository failures", func(t *testing.T) {
+		repo := mocks.NewMockMeasurementRepository(t)
+		useCase := newTestUseCase(t, mocks.NewMockMeasurementUseCase(t), repo,
+			newTestDerivedSensor(t, "warming", "temperature - 10", entities.DerivedSensorModeSchedule),
+		)
+		repo.EXPECT().Latest(ctx, mock.Anything, mock.Anything).Return(nil, errors.New("database down")).Once()
+
+		_, err := useCase.EvaluateOnce(ctx)
+
+		assert.ErrorContains(t, err, "failed to load latest measurements")
+	})
+}
+
+func TestNewDerivingSensorDataUseCase(t *testing.T) {
+	ctx := context.Background()
+	reading, err := entities.NewSensorTemperatureHumidity(testMAC, 20, 50)
+	require.NoError(t, err)
+
+	t.Run("derives from stored readings", func(t *testing.T) {
+		inner := mocks.NewMockSensorDataUseCase(t)
+		derived := mocks.NewMockDerivedSensorUseCase(t)
+		inner.EXPECT().StoreSensorData(ctx, reading).Return(nil).Once()
+		derived.EXPECT().DeriveFrom(ctx, mock.Anything).Run(func(ctx context.Context, stored []*entities.Measurement) {
+			require.Len(t, stored, 2)
+			assert.Equal(t, "temperature", stored[0].SensorType)
+			assert.Equal(t, 50.0, stored[1].Value)
+		}).Once()
+
+		assert.NoError(t, NewDerivingSensorDataUseCase(inner, derived).StoreSensorData(ctx, reading))
+	})
+
+	t.Run("does not derive from rejected readings", func(t *testing.T) {
+		inner := mocks.NewMockSensorDataUseCase(t)
+		inner.EXPECT().StoreSensorData(ctx, reading).Return(errors.New("database down")).Once()
+
+		assert.Error(t, NewDerivingSensorDataUseCase(inner, mocks.NewMockDerivedSensorUseCase(t)).StoreSensorData(ctx, reading))
+	})
+}
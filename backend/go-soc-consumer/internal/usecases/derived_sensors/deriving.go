@@ -0,0 +1,53 @@
+package derivedsensors
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// derivingMeasurementUseCase derives the ingestion sensors from measurements once they are stored
+type derivingMeasurementUseCase struct {
+	measurements.MeasurementUseCase
+	derived DerivedSensorUseCase
+}
+
+// NewDerivingMeasurementUseCase wraps a measurement use case so ingestion sensors are derived from stored measurements
+func NewDerivingMeasurementUseCase(inner measurements.MeasurementUseCase, derived DerivedSensorUseCase) measurements.MeasurementUseCase {
+	return &derivingMeasurementUseCase{MeasurementUseCase: inner, derived: derived}
+}
+
+// StoreMeasurements stores the measurements and derives from them once stored
+func (uc *derivingMeasurementUseCase) StoreMeasurements(ctx context.Context, values []*entities.Measurement) error {
+	if err := uc.MeasurementUseCase.StoreMeasurements(ctx, values); err != nil {
+		return err
+	}
+	uc.derived.DeriveFrom(ctx, values)
+	return nil
+}
+
+// derivingSensorDataUseCase derives the ingestion sensors from temperature and humidity readings once they are stored
+type derivingSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	derived DerivedSensorUseCase
+}
+
+// NewDerivingSensorDataUseCase wraps a sensor data use case so ingestion sensors are derived from stored readings,
+// which provide the temperature and humidity inputs on channel 0
+func NewDerivingSensorDataUseCase(inner sensordata.SensorDataUseCase, derived DerivedSensorUseCase) sensordata.SensorDataUseCase {
+	return &derivingSensorDataUseCase{SensorDataUseCase: inner, derived: derived}
+}
+
+// StoreSensorData stores the reading and derives from it once stored
+func (uc *derivingSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if err := uc.SensorDataUseCase.StoreSensorData(ctx, data); err != nil {
+		return err
+	}
+	uc.derived.DeriveFrom(ctx, []*entities.Measurement{
+		{MACAddress: data.MacAddress(), SensorType: "temperature", Value: data.Temperature(), Unit: "celsius", Quality: entities.MeasurementQualityGood, Timestamp: data.Timestamp()},
+		{MACAddress: data.MacAddress(), SensorType: "humidity", Value: data.Humidity(), Unit: "percent", Quality: entities.MeasurementQualityGood, Timestamp: data.Timestamp()},
+	})
+	return nil
+}
@@ -0,0 +1,183 @@
+package devicearchival
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// DeviceArchivalUseCase decommissions devices without losing their data. The measurements of a
+// decommissioned device move out of the active tables into archive tables, and on to object
+// storage when a store is configured, until the device is restored.
+type DeviceArchivalUseCase interface {
+	// Decommission archives the measurements of the device and removes it from the active devices.
+	// It fails with ErrDeviceNotFound or ErrInvalidDeviceArchive. An archive that cannot be written
+	// to object storage is kept in the database.
+	Decommission(ctx context.Context, macAddress, reason, actor string) (*entities.DeviceArchive, error)
+
+	// Restore puts the device of the archive back into service with its measurements. It fails
+	// with ErrDeviceArchiveNotFound, ErrDeviceArchiveRestored, or ErrDeviceInService when a device
+	// with the MAC address registered since.
+	Restore(ctx context.Context, id, actor string) (*entities.DeviceArchive, error)
+
+	// Get returns an archive, failing with ErrDeviceArchiveNotFound
+	Get(ctx context.Context, id string) (*entities.DeviceArchive, error)
+
+	// List returns the archives of the device, or of every device when empty, most recently
+	// archived first
+	List(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceArchive, error)
+}
+
+// useCaseImpl implements the DeviceArchivalUseCase interface
+type useCaseImpl struct {
+	archiveRepo   repositoryports.DeviceArchiveRepository
+	deviceRepo    repositoryports.DeviceRepository
+	store         ports.DeviceArchiveStore
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+}
+
+// NewDeviceArchivalUseCase creates a device archival use case; archives stay in the database
+// without a store
+func NewDeviceArchivalUseCase(
+	archiveRepo repositoryports.DeviceArchiveRepository,
+	deviceRepo repositoryports.DeviceRepository,
+	store ports.DeviceArchiveStore,
+	loggerFactory logger.LoggerFactory,
+) DeviceArchivalUseCase {
+	return &useCaseImpl{
+		archiveRepo:   archiveRepo,
+		deviceRepo:    deviceRepo,
+		store:         store,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+	}
+}
+
+// Decommission archives the device in the database, then offloads the archive when a store is configured
+func (uc *useCaseImpl) Decommission(ctx context.Context, macAddress, reason, actor string) (*entities.DeviceArchive, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := entities.NewDeviceArchive(device, reason, actor, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDeviceArchive, err)
+	}
+	if err := uc.archiveRepo.Archive(ctx, archive); err != nil {
+		return nil, err
+	}
+	if uc.store != nil {
+		uc.offload(ctx, archive)
+	}
+
+	uc.loggerFactory.Core().Info("device_decommissioned",
+		zap.String("archive_id", archive.ID),
+		zap.String("mac_address", archive.MACAddress),
+		zap.Int("measurements", archive.Measurements),
+		zap.String("storage", archive.Storage),
+		zap.String("actor", archive.Actor),
+		zap.String("component", "device_archival_usecase"),
+	)
+	return archive, nil
+}
+
+// Restore loads the measurements of an offloaded archive and restores the device with them
+func (uc *useCaseImpl) Restore(ctx context.Context, id, actor string) (*entities.DeviceArchive, error) {
+	archive, err := uc.archiveRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if archive.Status != entities.DeviceArchiveArchived {
+		return nil, domainerrors.ErrDeviceArchiveRestored
+	}
+	inService, err := uc.deviceRepo.Exists(ctx, archive.MACAddress)
+	if err != nil {
+		return nil, err
+	}
+	if inService {
+		return nil, domainerrors.ErrDeviceInService
+	}
+
+	var measurements []*entities.Measurement
+	if archive.Storage == entities.ArchiveStorageObject {
+		if uc.store == nil {
+			return nil, fmt.Errorf("archive %s is in object storage, which is not configured", archive.ID)
+		}
+		if measurements, err = uc.store.Load(ctx, archive); err != nil {
+			return nil, fmt.Errorf("failed to load device archive: %w", err)
+		}
+	}
+
+	if err := archive.Restore(actor, uc.now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrDeviceArchiveRestored, err)
+	}
+	if err := uc.archiveRepo.Restore(ctx, archive, measurements); err != nil {
+		return nil, err
+	}
+	if archive.Storage == entities.ArchiveStorageObject {
+		if err := uc.store.Delete(ctx, archive); err != nil {
+			uc.loggerFactory.Core().Warn("device_archive_object_not_deleted",
+				zap.Error(err),
+				zap.String("archive_id", archive.ID),
+				zap.String("object_key", archive.ObjectKey),
+				zap.String("component", "device_archival_usecase"),
+			)
+		}
+	}
+
+	uc.loggerFactory.Core().Info("device_restored",
+		zap.String("archive_id", archive.ID),
+		zap.String("mac_address", archive.MACAddress),
+		zap.Int("measurements", archive.Measurements),
+		zap.String("actor", archive.RestoredBy),
+		zap.String("component", "device_archival_usecase"),
+	)
+	return archive, nil
+}
+
+// Get loads an archive
+func (uc *useCaseImpl) Get(ctx context.Context, id string) (*entities.DeviceArchive, error) {
+	return uc.archiveRepo.FindByID(ctx, id)
+}
+
+// List loads the matching archives
+func (uc *useCaseImpl) List(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceArchive, error) {
+	return uc.archiveRepo.List(ctx, strings.ToUpper(strings.TrimSpace(macAddress)), limit)
+}
+
+// offload writes the archived measurements to object storage and drops them from the archive
+// tables, logging failures and keeping the archive in the database
+func (uc *useCaseImpl) offload(ctx context.Context, archive *entities.DeviceArchive) {
+	if err := uc.moveToStore(ctx, archive); err != nil {
+		archive.Storage, archive.ObjectKey = entities.ArchiveStorageDatabase, ""
+		uc.loggerFactory.Core().Warn("device_archive_offload_failed",
+			zap.Error(err),
+			zap.String("archive_id", archive.ID),
+			zap.String("component", "device_archival_usecase"),
+		)
+	}
+}
+
+func (uc *useCaseImpl) moveToStore(ctx context.Context, archive *entities.DeviceArchive) error {
+	measurements, err := uc.archiveRepo.Measurements(ctx, archive.ID)
+	if err != nil {
+		return err
+	}
+	key, err := uc.store.Store(ctx, archive, measurements)
+	if err != nil {
+		return err
+	}
+	archive.Offloaded(key)
+	return uc.archiveRepo.Offload(ctx, archive)
+}
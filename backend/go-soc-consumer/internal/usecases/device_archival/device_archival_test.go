@@ -0,0 +1,146 @@
+package devicearchival
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+type testDeps struct {
+	archives *mocks.MockDeviceArchiveRepository
+	devices  *mocks.MockDeviceRepository
+	store    *mocks.MockDeviceArchiveStore
+}
+
+func newTestUseCase(t *testing.T, withStore bool) (*useCaseImpl, testDeps) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	deps := testDeps{
+		archives: mocks.NewMockDeviceArchiveRepository(t),
+		devices:  mocks.NewMockDeviceRepository(t),
+	}
+	var useCase DeviceArchivalUseCase
+	if withStore {
+		deps.store = mocks.NewMockDeviceArchiveStore(t)
+		useCase = NewDeviceArchivalUseCase(deps.archives, deps.devices, deps.store, loggerFactory)
+	} else {
+		useCase = NewDeviceArchivalUseCase(deps.archives, deps.devices, nil, loggerFactory)
+	}
+	impl := useCase.(*useCaseImpl)
+	impl.now = func() time.Time { return testNow }
+	return impl, deps
+}
+
+func TestDeviceArchivalUseCase_Decommission(t *testing.T) {
+	device := &entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "finca-norte"}
+	readings := []*entities.Measurement{{MACAddress: "AA:BB:CC:DD:EE:FF", SensorType: "soil_moisture", Value: 31.5, Timestamp: testNow}}
+
+	t.Run("should keep the archive in the database without a store", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t, false)
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil).Once()
+		deps.archives.EXPECT().Archive(mock.Anything, mock.AnythingOfType("*entities.DeviceArchive")).
+			Run(func(_ context.Context, archive *entities.DeviceArchive) { archive.Measurements = 1 }).Return(nil).Once()
+
+		archive, err := useCase.Decommission(context.Background(), "aa:bb:cc:dd:ee:ff", "water damage", "maria")
+		require.NoError(t, err)
+		assert.Equal(t, entities.ArchiveStorageDatabase, archive.Storage)
+		assert.Equal(t, "finca-norte", archive.FarmID)
+		assert.Equal(t, testNow, archive.ArchivedAt)
+	})
+
+	t.Run("should offload the archive to object storage", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t, true)
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil).Once()
+		deps.archives.EXPECT().Archive(mock.Anything, mock.Anything).Return(nil).Once()
+		deps.archives.EXPECT().Measurements(mock.Anything, mock.Anything).Return(readings, nil).Once()
+		deps.store.EXPECT().Store(mock.Anything, mock.Anything, readings).Return("devices/AA-BB-CC-DD-EE-FF/archive-1.jsonl.gz", nil).Once()
+		deps.archives.EXPECT().Offload(mock.Anything, mock.MatchedBy(func(archive *entities.DeviceArchive) bool {
+			return archive.Storage == entities.ArchiveStorageObject
+		})).Return(nil).Once()
+
+		archive, err := useCase.Decommission(context.Background(), "AA:BB:CC:DD:EE:FF", "", "maria")
+		require.NoError(t, err)
+		assert.Equal(t, "devices/AA-BB-CC-DD-EE-FF/archive-1.jsonl.gz", archive.ObjectKey)
+	})
+
+	t.Run("should keep the archive in the database when the upload fails", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t, true)
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil).Once()
+		deps.archives.EXPECT().Archive(mock.Anything, mock.Anything).Return(nil).Once()
+		deps.archives.EXPECT().Measurements(mock.Anything, mock.Anything).Return(readings, nil).Once()
+		deps.store.EXPECT().Store(mock.Anything, mock.Anything, readings).Return("", errors.New("connection refused")).Once()
+
+		archive, err := useCase.Decommission(context.Background(), "AA:BB:CC:DD:EE:FF", "", "maria")
+		require.NoError(t, err)
+		assert.Equal(t, entities.ArchiveStorageDatabase, archive.Storage)
+		assert.Empty(t, archive.ObjectKey)
+	})
+
+	t.Run("should report unregistered devices", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t, false)
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+		_, err := useCase.Decommission(context.Background(), "AA:BB:CC:DD:EE:FF", "", "maria")
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+	})
+}
+
+func TestDeviceArchivalUseCase_Restore(t *testing.T) {
+	newArchive := func(storage string) *entities.DeviceArchive {
+		return &entities.DeviceArchive{ID: "archive-1", MACAddress: "AA:BB:CC:DD:EE:FF", Status: entities.DeviceArchiveArchived, Storage: storage, ObjectKey: "devices/AA-BB-CC-DD-EE-FF/archive-1.jsonl.gz"}
+	}
+
+	t.Run("should restore an offloaded archive and delete its object", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t, true)
+		archive := newArchive(entities.ArchiveStorageObject)
+		readings := []*entities.Measurement{{MACAddress: "AA:BB:CC:DD:EE:FF", SensorType: "soil_moisture", Value: 31.5, Timestamp: testNow}}
+		deps.archives.EXPECT().FindByID(mock.Anything, "archive-1").Return(archive, nil).Once()
+		deps.devices.EXPECT().Exists(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(false, nil).Once()
+		deps.store.EXPECT().Load(mock.Anything, archive).Return(readings, nil).Once()
+		deps.archives.EXPECT().Restore(mock.Anything, archive, readings).Return(nil).Once()
+		deps.store.EXPECT().Delete(mock.Anything, archive).Return(nil).Once()
+
+		restored, err := useCase.Restore(context.Background(), "archive-1", "pedro")
+		require.NoError(t, err)
+		assert.Equal(t, entities.DeviceArchiveRestored, restored.Status)
+		assert.Equal(t, "pedro", restored.RestoredBy)
+	})
+
+	t.Run("should restore an archive kept in the database", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t, false)
+		archive := newArchive(entities.ArchiveStorageDatabase)
+		deps.archives.EXPECT().FindByID(mock.Anything, "archive-1").Return(archive, nil).Once()
+		deps.devices.EXPECT().Exists(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(false, nil).Once()
+		deps.archives.EXPECT().Restore(mock.Anything, archive, []*entities.Measurement(nil)).Return(nil).Once()
+
+		_, err := useCase.Restore(context.Background(), "archive-1", "pedro")
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject restored archives and devices in service", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t, false)
+		restored := newArchive(entities.ArchiveStorageDatabase)
+		restored.Status = entities.DeviceArchiveRestored
+		deps.archives.EXPECT().FindByID(mock.Anything, "archive-1").Return(restored, nil).Once()
+		_, err := useCase.Restore(context.Background(), "archive-1", "pedro")
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceArchiveRestored)
+
+		deps.archives.EXPECT().FindByID(mock.Anything, "archive-2").Return(newArchive(entities.ArchiveStorageDatabase), nil).Once()
+		deps.devices.EXPECT().Exists(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(true, nil).Once()
+		_, err = useCase.Restore(context.Background(), "archive-2", "pedro")
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceInService)
+	})
+}
@@ -0,0 +1,145 @@
+package devicebundle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// DeviceBundleUseCase exports the device configuration as a portable bundle and imports it back
+type DeviceBundleUseCase interface {
+	// Export returns the configuration of every device
+	Export(ctx context.Context) (*entities.DeviceBundle, error)
+
+	// Import creates the devices of the bundle and resolves devices that already exist with the
+	// conflict strategy. Every device is validated before anything is written; a repository failure
+	// stops the import and the result lists what was written until then.
+	Import(ctx context.Context, bundle *entities.DeviceBundle, strategy string) (*entities.DeviceBundleImportResult, error)
+}
+
+// useCaseImpl implements the DeviceBundleUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+}
+
+// NewDeviceBundleUseCase creates a new device bundle use case
+func NewDeviceBundleUseCase(deviceRepo repositoryports.DeviceRepository, loggerFactory logger.LoggerFactory) DeviceBundleUseCase {
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+	}
+}
+
+// Export returns the configuration of every device
+func (uc *useCaseImpl) Export(ctx context.Context) (*entities.DeviceBundle, error) {
+	devices, err := uc.deviceRepo.List(ctx, 0, pagination.Unlimited)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	bundle := entities.NewDeviceBundle(devices, uc.now())
+	uc.loggerFactory.Core().Info("device_bundle_exported",
+		zap.Int("devices", len(bundle.Devices)),
+		zap.String("component", "device_bundle_usecase"),
+	)
+	return bundle, nil
+}
+
+// Import creates or updates the devices of the bundle
+func (uc *useCaseImpl) Import(ctx context.Context, bundle *entities.DeviceBundle, strategy string) (*entities.DeviceBundleImportResult, error) {
+	if bundle == nil {
+		return nil, fmt.Errorf("%w: bundle is required", domainerrors.ErrInvalidDeviceBundle)
+	}
+	if err := entities.ValidateBundleConflictStrategy(strategy); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDeviceBundle, err)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDeviceBundle, err)
+	}
+
+	macAddresses := make([]string, 0, len(bundle.Devices))
+	for _, configuration := range bundle.Devices {
+		macAddresses = append(macAddresses, strings.ToUpper(strings.TrimSpace(configuration.MACAddress)))
+	}
+	existing, err := uc.deviceRepo.FindByMACAddresses(ctx, macAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load existing devices: %w", err)
+	}
+	existingByMAC := make(map[string]*entities.Device, len(existing))
+	for _, device := range existing {
+		existingByMAC[device.GetID()] = device
+	}
+
+	// Resolve every device before writing so an invalid entry does not leave a partial import behind
+	type plannedWrite struct {
+		device *entities.Device
+		create bool
+	}
+	writes := make([]plannedWrite, 0, len(bundle.Devices))
+	result := &entities.DeviceBundleImportResult{
+		Created: []string{},
+		Updated: []string{},
+		Skipped: []string{},
+	}
+	for i, configuration := range bundle.Devices {
+		device, found := existingByMAC[macAddresses[i]]
+		switch {
+		case !found:
+			created, err := configuration.NewDevice()
+			if err != nil {
+				return nil, fmt.Errorf("%w: device %s: %v", domainerrors.ErrInvalidDeviceBundle, macAddresses[i], err)
+			}
+			writes = append(writes, plannedWrite{device: created, create: true})
+		case strategy == entities.BundleConflictSkip:
+			result.Skipped = append(result.Skipped, macAddresses[i])
+		default:
+			updated, err := device.WithChanges(func(device *entities.Device) error {
+				return device.ApplyConfiguration(configuration, strategy == entities.BundleConflictMerge)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%w: device %s: %v", domainerrors.ErrInvalidDeviceBundle, macAddresses[i], err)
+			}
+			writes = append(writes, plannedWrite{device: updated})
+		}
+	}
+
+	for _, write := range writes {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		macAddress := write.device.GetID()
+		if write.create {
+			if err := uc.deviceRepo.Create(ctx, write.device); err != nil {
+				return result, fmt.Errorf("failed to create device %s: %w", macAddress, err)
+			}
+			result.Created = append(result.Created, macAddress)
+			continue
+		}
+		if err := uc.deviceRepo.Update(ctx, write.device); err != nil {
+			return result, fmt.Errorf("failed to update device %s: %w", macAddress, err)
+		}
+		result.Updated = append(result.Updated, macAddress)
+	}
+
+	uc.loggerFactory.Core().Info("device_bundle_imported",
+		zap.String("strategy", strategy),
+		zap.Int("created", len(result.Created)),
+		zap.Int("updated", len(result.Updated)),
+		zap.Int("skipped", len(result.Skipped)),
+		zap.String("component", "device_bundle_usecase"),
+	)
+	return result, nil
+}
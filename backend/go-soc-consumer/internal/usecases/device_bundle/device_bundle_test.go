@@ -0,0 +1,154 @@
+package devicebundle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+const (
+	existingMAC = "AA:BB:CC:DD:EE:01"
+	newMAC      = "AA:BB:CC:DD:EE:02"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func existingDevice(t *testing.T) *entities.Device {
+	device, err := entities.NewDevice(existingMAC, "Greenhouse sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	require.NoError(t, device.AssignFarm("farm-1"))
+	return device
+}
+
+func testBundle() *entities.DeviceBundle {
+	return &entities.DeviceBundle{
+		Version: entities.DeviceBundleVersion,
+		Devices: []entities.DeviceConfiguration{
+			{MACAddress: existingMAC, DeviceName: "Renamed sensor", IPAddress: "192.168.1.20"},
+			{MACAddress: newMAC, DeviceName: "Orchard sensor", IPAddress: "192.168.1.30", LocationDescription: "Orchard"},
+		},
+	}
+}
+
+func TestDeviceBundleUseCase_Export(t *testing.T) {
+	repo := mocks.NewMockDeviceRepository(t)
+	useCase := NewDeviceBundleUseCase(repo, createTestLoggerFactory(t))
+	repo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return([]*entities.Device{existingDevice(t)}, nil).Once()
+
+	bundle, err := useCase.Export(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, entities.DeviceBundleVersion, bundle.Version)
+	require.Len(t, bundle.Devices, 1)
+	assert.Equal(t, existingMAC, bundle.Devices[0].MACAddress)
+	assert.Equal(t, "farm-1", bundle.Devices[0].FarmID)
+}
+
+func TestDeviceBundleUseCase_Import(t *testing.T) {
+	ctx := context.Background()
+
+	expectNewDevice := func(repo *mocks.MockDeviceRepository) {
+		repo.EXPECT().Create(ctx, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.MACAddress == newMAC && device.LocationDescription == "Orchard"
+		})).Return(nil).Once()
+	}
+
+	t.Run("should skip existing devices", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceBundleUseCase(repo, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddresses(ctx, []string{existingMAC, newMAC}).Return([]*entities.Device{existingDevice(t)}, nil).Once()
+		expectNewDevice(repo)
+
+		result, err := useCase.Import(ctx, testBundle(), entities.BundleConflictSkip)
+		require.NoError(t, err)
+		assert.Equal(t, []string{newMAC}, result.Created)
+		assert.Equal(t, []string{existingMAC}, result.Skipped)
+		assert.Empty(t, result.Updated)
+	})
+
+	t.Run("should merge the non-empty fields into existing devices", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceBundleUseCase(repo, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddresses(ctx, []string{existingMAC, newMAC}).Return([]*entities.Device{existingDevice(t)}, nil).Once()
+		repo.EXPECT().Update(ctx, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.DeviceName == "Renamed sensor" && device.LocationDescription == "Greenhouse" && device.FarmID == "farm-1"
+		})).Return(nil).Once()
+		expectNewDevice(repo)
+
+		result, err := useCase.Import(ctx, testBundle(), entities.BundleConflictMerge)
+		require.NoError(t, err)
+		assert.Equal(t, []string{existingMAC}, result.Updated)
+		assert.Equal(t, []string{newMAC}, result.Created)
+	})
+
+	t.Run("should reject an overwrite that leaves a device invalid", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceBundleUseCase(repo, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddresses(ctx, []string{existingMAC, newMAC}).Return([]*entities.Device{existingDevice(t)}, nil).Once()
+
+		_, err := useCase.Import(ctx, testBundle(), entities.BundleConflictOverwrite)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeviceBundle)
+	})
+
+	t.Run("should overwrite the configuration of existing devices", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceBundleUseCase(repo, createTestLoggerFactory(t))
+		bundle := testBundle()
+		bundle.Devices[0].LocationDescription = "Nursery"
+		repo.EXPECT().FindByMACAddresses(ctx, []string{existingMAC, newMAC}).Return([]*entities.Device{existingDevice(t)}, nil).Once()
+		repo.EXPECT().Update(ctx, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.LocationDescription == "Nursery" && device.FarmID == "" && device.Status == "registered"
+		})).Return(nil).Once()
+		expectNewDevice(repo)
+
+		result, err := useCase.Import(ctx, bundle, entities.BundleConflictOverwrite)
+		require.NoError(t, err)
+		assert.Equal(t, []string{existingMAC}, result.Updated)
+	})
+
+	t.Run("should validate the bundle before writing", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceBundleUseCase(repo, createTestLoggerFactory(t))
+
+		unsupported := testBundle()
+		unsupported.Version = 2
+		_, err := useCase.Import(ctx, unsupported, entities.BundleConflictSkip)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeviceBundle)
+
+		_, err = useCase.Import(ctx, testBundle(), "replace")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeviceBundle)
+	})
+
+	t.Run("should reject new devices with an incomplete configuration", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceBundleUseCase(repo, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddresses(ctx, []string{existingMAC, newMAC}).Return(nil, nil).Once()
+
+		_, err := useCase.Import(ctx, testBundle(), entities.BundleConflictMerge)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeviceBundle)
+	})
+
+	t.Run("should stop at the first repository failure", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceBundleUseCase(repo, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddresses(ctx, []string{existingMAC, newMAC}).Return([]*entities.Device{existingDevice(t)}, nil).Once()
+		repo.EXPECT().Create(ctx, mock.Anything).Return(errors.New("db down")).Once()
+
+		result, err := useCase.Import(ctx, testBundle(), entities.BundleConflictSkip)
+		require.Error(t, err)
+		assert.Empty(t, result.Created)
+	})
+}
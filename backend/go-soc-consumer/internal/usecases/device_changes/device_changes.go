@@ -0,0 +1,161 @@
+package devicechanges
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+)
+
+// DeviceChangesUseCase keeps a bounded journal of device changes and lets clients wait for new entries
+type DeviceChangesUseCase interface {
+	// Record appends the current state of the device to the journal and wakes up waiting clients
+	Record(device *entities.Device)
+
+	// Cursor returns the cursor of the latest journal entry
+	Cursor() uint64
+
+	// Changes returns the entries recorded after the since cursor, together with the cursor to resume from.
+	// When there are none it waits up to timeout for new entries. A cursor that fell out of the journal,
+	// or one handed out before a restart, fails with ErrChangeCursorExpired.
+	Changes(ctx context.Context, since uint64, timeout time.Duration) ([]entities.DeviceChange, uint64, error)
+
+	// Transitions returns up to limit journal entries of a device where its status changed, newest first.
+	// The first entry of the device still in the journal counts as a transition.
+	Transitions(macAddress string, limit int) []entities.DeviceChange
+}
+
+// JournalConfig holds the configuration of the change journal
+type JournalConfig struct {
+	Size int // entries kept; clients further behind must reload the devices
+}
+
+// DefaultJournalConfig returns the default change journal configuration
+func DefaultJournalConfig() *JournalConfig {
+	return &JournalConfig{Size: 1000}
+}
+
+// useCaseImpl implements the DeviceChangesUseCase interface
+type useCaseImpl struct {
+	config *JournalConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries []entities.DeviceChange // ring buffer ordered by cursor
+	start   int                     // index of the oldest entry
+	latest  uint64
+	notify  chan struct{} // closed and replaced on every record
+}
+
+// NewDeviceChangesUseCase creates a device changes use case
+func NewDeviceChangesUseCase(config *JournalConfig) DeviceChangesUseCase {
+	if config == nil {
+		config = DefaultJournalConfig()
+	}
+	return &useCaseImpl{
+		config:  config,
+		now:     time.Now,
+		entries: make([]entities.DeviceChange, 0, config.Size),
+		notify:  make(chan struct{}),
+	}
+}
+
+// Record appends the device state to the journal
+func (uc *useCaseImpl) Record(device *entities.Device) {
+	if device == nil {
+		return
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.latest++
+	change := entities.NewDeviceChange(device, uc.now())
+	change.Cursor = uc.latest
+	if len(uc.entries) < uc.config.Size {
+		uc.entries = append(uc.entries, change)
+	} else {
+		uc.entries[uc.start] = change
+		uc.start = (uc.start + 1) % len(uc.entries)
+	}
+
+	close(uc.notify)
+	uc.notify = make(chan struct{})
+}
+
+// Cursor returns the latest cursor
+func (uc *useCaseImpl) Cursor() uint64 {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	return uc.latest
+}
+
+// Changes returns the journal entries after since, waiting for new ones when needed
+func (uc *useCaseImpl) Changes(ctx context.Context, since uint64, timeout time.Duration) ([]entities.DeviceChange, uint64, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		changes, cursor, notify, err := uc.changesAfter(since)
+		if err != nil || len(changes) > 0 {
+			return changes, cursor, err
+		}
+
+		select {
+		case <-notify:
+		case <-timer.C:
+			return changes, cursor, nil
+		case <-ctx.Done():
+			return nil, since, ctx.Err()
+		}
+	}
+}
+
+// Transitions returns the latest status changes of the device still in the journal
+func (uc *useCaseImpl) Transitions(macAddress string, limit int) []entities.DeviceChange {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	var transitions []entities.DeviceChange
+	status := ""
+	for i := range uc.entries {
+		change := uc.entries[(uc.start+i)%len(uc.entries)]
+		if change.MACAddress != macAddress || change.Status == status {
+			continue
+		}
+		status = change.Status
+		transitions = append(transitions, change)
+	}
+
+	if len(transitions) > limit {
+		transitions = transitions[len(transitions)-limit:]
+	}
+	for i, j := 0, len(transitions)-1; i < j; i, j = i+1, j-1 {
+		transitions[i], transitions[j] = transitions[j], transitions[i]
+	}
+	return transitions
+}
+
+// changesAfter copies the entries after since, along with the channel closed by the next record
+func (uc *useCaseImpl) changesAfter(since uint64) ([]entities.DeviceChange, uint64, <-chan struct{}, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if since > uc.latest {
+		return nil, uc.latest, nil, fmt.Errorf("%w: cursor %d is ahead of the journal", domainerrors.ErrChangeCursorExpired, since)
+	}
+	oldest := uc.latest - uint64(len(uc.entries))
+	if since < oldest {
+		return nil, uc.latest, nil, fmt.Errorf("%w: cursor %d is older than the journal", domainerrors.ErrChangeCursorExpired, since)
+	}
+
+	pending := int(uc.latest - since)
+	changes := make([]entities.DeviceChange, 0, pending)
+	for i := len(uc.entries) - pending; i < len(uc.entries); i++ {
+		changes = append(changes, uc.entries[(uc.start+i)%len(uc.entries)])
+	}
+	return changes, uc.latest, uc.notify, nil
+}
@@ -0,0 +1,137 @@
+package devicechanges
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newTestDevice(t *testing.T, macAddress string) *entities.Device {
+	device, err := entities.NewDevice(macAddress, "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	return device
+}
+
+func TestDeviceChangesUseCase_Changes(t *testing.T) {
+	t.Run("should return pending changes without waiting", func(t *testing.T) {
+		useCase := NewDeviceChangesUseCase(DefaultJournalConfig())
+		useCase.Record(newTestDevice(t, "AA:BB:CC:DD:EE:01"))
+		useCase.Record(newTestDevice(t, "AA:BB:CC:DD:EE:02"))
+
+		changes, cursor, err := useCase.Changes(context.Background(), 1, time.Hour)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, "AA:BB:CC:DD:EE:02", changes[0].MACAddress)
+		assert.Equal(t, uint64(2), changes[0].Cursor)
+		assert.Equal(t, uint64(2), cursor)
+	})
+
+	t.Run("should keep only the newest entries", func(t *testing.T) {
+		useCase := NewDeviceChangesUseCase(&JournalConfig{Size: 2})
+		for _, macAddress := range []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02", "AA:BB:CC:DD:EE:03"} {
+			useCase.Record(newTestDevice(t, macAddress))
+		}
+
+		changes, cursor, err := useCase.Changes(context.Background(), 1, time.Hour)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		assert.Equal(t, "AA:BB:CC:DD:EE:02", changes[0].MACAddress)
+		assert.Equal(t, "AA:BB:CC:DD:EE:03", changes[1].MACAddress)
+		assert.Equal(t, uint64(3), cursor)
+
+		_, _, err = useCase.Changes(context.Background(), 0, time.Hour)
+		assert.ErrorIs(t, err, domainerrors.ErrChangeCursorExpired)
+	})
+
+	t.Run("should reject cursors ahead of the journal", func(t *testing.T) {
+		useCase := NewDeviceChangesUseCase(DefaultJournalConfig())
+
+		_, cursor, err := useCase.Changes(context.Background(), 5, time.Hour)
+		assert.ErrorIs(t, err, domainerrors.ErrChangeCursorExpired)
+		assert.Equal(t, uint64(0), cursor)
+	})
+
+	t.Run("should wake up when a change is recorded", func(t *testing.T) {
+		useCase := NewDeviceChangesUseCase(DefaultJournalConfig())
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			useCase.Record(newTestDevice(t, "AA:BB:CC:DD:EE:01"))
+		}()
+
+		changes, cursor, err := useCase.Changes(context.Background(), useCase.Cursor(), 5*time.Second)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, uint64(1), cursor)
+	})
+
+	t.Run("should return no changes after the timeout", func(t *testing.T) {
+		useCase := NewDeviceChangesUseCase(DefaultJournalConfig())
+
+		changes, cursor, err := useCase.Changes(context.Background(), 0, 10*time.Millisecond)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Equal(t, uint64(0), cursor)
+	})
+
+	t.Run("should stop waiting when the context is cancelled", func(t *testing.T) {
+		useCase := NewDeviceChangesUseCase(DefaultJournalConfig())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, err := useCase.Changes(ctx, 0, time.Hour)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestDeviceChangesUseCase_Transitions(t *testing.T) {
+	useCase := NewDeviceChangesUseCase(DefaultJournalConfig())
+	device := newTestDevice(t, "AA:BB:CC:DD:EE:01")
+	for _, status := range []string{"online", "online", "offline", "online", "offline"} {
+		require.NoError(t, device.UpdateStatus(status))
+		useCase.Record(device)
+		useCase.Record(newTestDevice(t, "AA:BB:CC:DD:EE:02"))
+	}
+
+	transitions := useCase.Transitions("AA:BB:CC:DD:EE:01", 3)
+	require.Len(t, transitions, 3)
+	assert.Equal(t, "offline", transitions[0].Status)
+	assert.Equal(t, uint64(9), transitions[0].Cursor)
+	assert.Equal(t, "online", transitions[1].Status)
+	assert.Equal(t, "offline", transitions[2].Status)
+	assert.Len(t, useCase.Transitions("AA:BB:CC:DD:EE:02", 10), 1)
+	assert.Empty(t, useCase.Transitions("AA:BB:CC:DD:EE:03", 10))
+}
+
+func TestJournalingDeviceRepository(t *testing.T) {
+	device := newTestDevice(t, "AA:BB:CC:DD:EE:FF")
+
+	t.Run("should record successful writes", func(t *testing.T) {
+		inner := mocks.NewMockDeviceRepository(t)
+		inner.EXPECT().Create(mock.Anything, device).Return(nil).Once()
+		inner.EXPECT().Update(mock.Anything, device).Return(nil).Once()
+		useCase := NewDeviceChangesUseCase(DefaultJournalConfig())
+		repo := NewJournalingDeviceRepository(inner, useCase)
+
+		require.NoError(t, repo.Create(context.Background(), device))
+		require.NoError(t, repo.Update(context.Background(), device))
+		assert.Equal(t, uint64(2), useCase.Cursor())
+	})
+
+	t.Run("should not record failed writes", func(t *testing.T) {
+		inner := mocks.NewMockDeviceRepository(t)
+		inner.EXPECT().Update(mock.Anything, device).Return(errors.New("database down")).Once()
+		useCase := NewDeviceChangesUseCase(DefaultJournalConfig())
+
+		assert.Error(t, NewJournalingDeviceRepository(inner, useCase).Update(context.Background(), device))
+		assert.Equal(t, uint64(0), useCase.Cursor())
+	})
+}
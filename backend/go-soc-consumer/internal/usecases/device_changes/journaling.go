@@ -0,0 +1,37 @@
+package devicechanges
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+)
+
+// journalingDeviceRepository records successful device writes in the change journal
+type journalingDeviceRepository struct {
+	repositoryports.DeviceRepository
+	changes DeviceChangesUseCase
+}
+
+// NewJournalingDeviceRepository wraps a device repository so creates and updates reach the change journal
+func NewJournalingDeviceRepository(inner repositoryports.DeviceRepository, changes DeviceChangesUseCase) repositoryports.DeviceRepository {
+	return &journalingDeviceRepository{DeviceRepository: inner, changes: changes}
+}
+
+// Create persists the device and records the change
+func (r *journalingDeviceRepository) Create(ctx context.Context, device *entities.Device) error {
+	if err := r.DeviceRepository.Create(ctx, device); err != nil {
+		return err
+	}
+	r.changes.Record(device)
+	return nil
+}
+
+// Update updates the device and records the change
+func (r *journalingDeviceRepository) Update(ctx context.Context, device *entities.Device) error {
+	if err := r.DeviceRepository.Update(ctx, device); err != nil {
+		return err
+	}
+	r.changes.Record(device)
+	return nil
+}
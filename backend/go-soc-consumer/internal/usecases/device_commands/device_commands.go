@@ -0,0 +1,368 @@
+package devicecommands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// CommandsConfig holds configuration for remote device commands
+type CommandsConfig struct {
+	AckTimeout  time.Duration // wait for the acknowledgement of the first delivery, doubled for every retry
+	MaxAttempts int           // deliveries of a command before it expires, the first one included
+	MaxTracked  int           // deliveries kept for correlating acknowledgements; evicted ones are not retried
+	Interval    time.Duration // how often unacknowledged commands are retried or expired
+}
+
+// DefaultCommandsConfig returns default configuration
+func DefaultCommandsConfig() *CommandsConfig {
+	return &CommandsConfig{
+		AckTimeout:  2 * time.Minute,
+		MaxAttempts: 3,
+		MaxTracked:  10000,
+		Interval:    15 * time.Second,
+	}
+}
+
+// DeviceCommandsUseCase sends remote troubleshooting commands to devices and tracks their acknowledgements
+type DeviceCommandsUseCase interface {
+	// Send validates the command, publishes it to the device and records it as waiting for an
+	// acknowledgement. An unknown device fails with ErrDeviceNotFound and an invalid or unconfirmed
+	// command with ErrInvalidDeviceCommand.
+	Send(ctx context.Context, macAddress string, request entities.DeviceCommandRequest) (*entities.DeviceCommand, error)
+
+	// Get returns a command sent to the device, failing with ErrDeviceCommandNotFound when there is none
+	Get(ctx context.Context, macAddress, id string) (*entities.DeviceCommand, error)
+
+	// List returns up to limit commands sent to the device, newest first
+	List(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceCommand, error)
+
+	// Acknowledge records the outcome reported by a device for a command it was sent. Acknowledgements
+	// of commands that already expired or were acknowledged before are ignored. An acknowledgement of a
+	// retry names the nonce of that delivery; ack.CommandID is set to the ID of the command retried.
+	Acknowledge(ctx context.Context, ack *entities.DeviceCommandAck) error
+
+	// Run retries and expires unacknowledged commands periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// useCaseImpl implements the DeviceCommandsUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	commandRepo   repositoryports.DeviceCommandRepository
+	publisher     ports.CommandPublisher
+	signer        ports.CommandSigner
+	config        *CommandsConfig
+	loggerFactory logger.LoggerFactory
+	tracker       *CommandTracker
+	commands      *metrics.Vec
+	retries       *metrics.Vec
+	tracked       *metrics.Vec
+	now           func() time.Time
+}
+
+// NewDeviceCommandsUseCase creates a device commands use case. Commands are signed when a signer is
+// given and published as unsigned envelopes otherwise.
+func NewDeviceCommandsUseCase(
+	deviceRepo repositoryports.DeviceRepository,
+	commandRepo repositoryports.DeviceCommandRepository,
+	publisher ports.CommandPublisher,
+	signer ports.CommandSigner,
+	config *CommandsConfig,
+	loggerFactory logger.LoggerFactory,
+) DeviceCommandsUseCase {
+	if config == nil {
+		config = DefaultCommandsConfig()
+	}
+
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		commandRepo:   commandRepo,
+		publisher:     publisher,
+		signer:        signer,
+		config:        config,
+		loggerFactory: loggerFactory,
+		tracker:       NewCommandTracker(config.AckTimeout, config.MaxAttempts, config.MaxTracked),
+		commands:      metrics.NewCounterVec("device_commands_total", "Commands sent to devices by delivery status", "status"),
+		retries:       metrics.NewCounterVec("device_command_retries_total", "Unacknowledged commands published again, by command", "command"),
+		tracked:       metrics.NewGaugeVec("device_command_deliveries_tracked", "Command deliveries tracked for correlating acknowledgements"),
+		now:           time.Now,
+	}
+}
+
+// Send publishes a command to a known device
+func (uc *useCaseImpl) Send(ctx context.Context, macAddress string, request entities.DeviceCommandRequest) (*entities.DeviceCommand, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, err
+	}
+	payload, err := request.Payload(device.GetID())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDeviceCommand, err)
+	}
+
+	now := uc.now().UTC()
+	envelope, err := uc.envelope(device.GetID(), request.Command, payload, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.publisher.Publish(ctx, device, envelope); err != nil {
+		uc.loggerFactory.Core().Error("device_command_publish_failed",
+			zap.Error(err),
+			zap.String("mac_address", device.GetID()),
+			zap.String("command", request.Command),
+			zap.String("component", "device_commands_usecase"),
+		)
+		return nil, fmt.Errorf("failed to publish command: %w", err)
+	}
+
+	command := &entities.DeviceCommand{
+		ID:         envelope.Nonce,
+		MACAddress: device.GetID(),
+		Command:    request.Command,
+		Payload:    payload,
+		Status:     entities.DeviceCommandStatusSent,
+		IssuedAt:   now,
+		ExpiresAt:  uc.tracker.ExpiresAt(now, 1),
+		Attempts:   1,
+	}
+	if err := uc.commandRepo.Create(ctx, command); err != nil {
+		return nil, fmt.Errorf("failed to store command: %w", err)
+	}
+	uc.tracker.Track(command, envelope.Nonce, now)
+	uc.commands.Inc(command.Status)
+
+	uc.loggerFactory.Core().Info("device_command_sent",
+		zap.String("mac_address", command.MACAddress),
+		zap.String("command", command.Command),
+		zap.String("command_id", command.ID),
+		zap.Bool("signed", envelope.Signature != ""),
+		zap.String("component", "device_commands_usecase"),
+	)
+	return command, nil
+}
+
+// envelope wraps the command for publishing, signed when a signer is configured
+func (uc *useCaseImpl) envelope(macAddress, command string, payload json.RawMessage, now time.Time) (*entities.CommandEnvelope, error) {
+	if uc.signer != nil {
+		envelope, err := uc.signer.Sign(macAddress, command, payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign command: %w", err)
+		}
+		return envelope, nil
+	}
+
+	return &entities.CommandEnvelope{
+		Version:  entities.CommandEnvelopeVersion,
+		Target:   macAddress,
+		Command:  command,
+		IssuedAt: now.Unix(),
+		Nonce:    strings.ReplaceAll(uuid.New().String(), "-", ""),
+		Payload:  payload,
+	}, nil
+}
+
+// Get returns a command of the device
+func (uc *useCaseImpl) Get(ctx context.Context, macAddress, id string) (*entities.DeviceCommand, error) {
+	command, err := uc.commandRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if command.MACAddress != strings.ToUpper(strings.TrimSpace(macAddress)) {
+		return nil, domainerrors.ErrDeviceCommandNotFound
+	}
+	return command, nil
+}
+
+// List returns the latest commands of the device
+func (uc *useCaseImpl) List(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceCommand, error) {
+	return uc.commandRepo.ListByDevice(ctx, strings.ToUpper(strings.TrimSpace(macAddress)), limit)
+}
+
+// Acknowledge completes a pending command addressed to the acknowledging device
+func (uc *useCaseImpl) Acknowledge(ctx context.Context, ack *entities.DeviceCommandAck) error {
+	if err := domainerrors.RequireInput("command acknowledgement", ack); err != nil {
+		return err
+	}
+	ack.CommandID = uc.tracker.Resolve(ack.CommandID)
+
+	command, err := uc.commandRepo.FindByID(ctx, ack.CommandID)
+	if err != nil && !errors.Is(err, domainerrors.ErrDeviceCommandNotFound) {
+		return err
+	}
+	// A device only acknowledges the commands it was sent
+	if err != nil || command.MACAddress != strings.ToUpper(strings.TrimSpace(ack.MACAddress)) {
+		uc.loggerFactory.Core().Warn("device_command_ack_unknown",
+			zap.String("mac_address", ack.MACAddress),
+			zap.String("command_id", ack.CommandID),
+			zap.String("component", "device_commands_usecase"),
+		)
+		return domainerrors.ErrDeviceCommandNotFound
+	}
+
+	status := entities.DeviceCommandStatusAcknowledged
+	if !ack.Success {
+		status = entities.DeviceCommandStatusFailed
+	}
+	completed, err := uc.commandRepo.Complete(ctx, command.ID, status, ack.Message, ack.ReceivedAt.UTC())
+	if err != nil {
+		return err
+	}
+	uc.tracker.Forget(command.ID)
+	if !completed {
+		uc.loggerFactory.Core().Info("device_command_ack_ignored",
+			zap.String("mac_address", command.MACAddress),
+			zap.String("command_id", command.ID),
+			zap.String("status", command.Status),
+			zap.String("component", "device_commands_usecase"),
+		)
+		return nil
+	}
+	uc.commands.Inc(status)
+
+	uc.loggerFactory.Core().Info("device_command_acknowledged",
+		zap.String("mac_address", command.MACAddress),
+		zap.String("command", command.Command),
+		zap.String("command_id", command.ID),
+		zap.String("status", status),
+		zap.String("message", ack.Message),
+		zap.Int("attempts", command.Attempts),
+		zap.Duration("round_trip", ack.ReceivedAt.Sub(command.IssuedAt)),
+		zap.String("component", "device_commands_usecase"),
+	)
+	return nil
+}
+
+// Run retries and expires unacknowledged commands periodically until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("device_commands_started", "device_commands_usecase",
+		zap.Duration("ack_timeout", uc.config.AckTimeout),
+		zap.Int("max_attempts", uc.config.MaxAttempts),
+		zap.Duration("interval", uc.config.Interval),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		uc.retry(ctx)
+		uc.expire(ctx)
+
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("device_commands_stopped", "device_commands_usecase")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// retry publishes again the tracked commands whose latest delivery went unacknowledged, each with
+// a fresh nonce
+func (uc *useCaseImpl) retry(ctx context.Context) {
+	for _, command := range uc.tracker.Due(uc.now().UTC()) {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := uc.resend(ctx, command); err != nil {
+			uc.loggerFactory.Core().Error("device_command_retry_failed",
+				zap.Error(err),
+				zap.String("mac_address", command.MACAddress),
+				zap.String("command_id", command.ID),
+				zap.Int("attempt", command.Attempts+1),
+				zap.String("component", "device_commands_usecase"),
+			)
+		}
+	}
+}
+
+// resend records and publishes the next delivery of a command. A delivery that could not be
+// published still counts, so a device that cannot be reached does not hold the retries back.
+func (uc *useCaseImpl) resend(ctx context.Context, command *entities.DeviceCommand) error {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, command.MACAddress)
+	if err != nil {
+		return err
+	}
+
+	now := uc.now().UTC()
+	envelope, err := uc.envelope(command.MACAddress, command.Command, command.Payload, now)
+	if err != nil {
+		return err
+	}
+	command.Attempts++
+	command.ExpiresAt = uc.tracker.ExpiresAt(now, command.Attempts)
+	pending, err := uc.commandRepo.RecordAttempt(ctx, command.ID, command.Attempts, command.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	if !pending {
+		// Acknowledged or expired since it was found due
+		uc.tracker.Forget(command.ID)
+		return nil
+	}
+	uc.tracker.Track(command, envelope.Nonce, now)
+	uc.retries.Inc(command.Command)
+
+	if err := uc.publisher.Publish(ctx, device, envelope); err != nil {
+		return fmt.Errorf("failed to publish command: %w", err)
+	}
+	uc.loggerFactory.Core().Info("device_command_retried",
+		zap.String("mac_address", command.MACAddress),
+		zap.String("command", command.Command),
+		zap.String("command_id", command.ID),
+		zap.Int("attempt", command.Attempts),
+		zap.Time("expires_at", command.ExpiresAt),
+		zap.String("component", "device_commands_usecase"),
+	)
+	return nil
+}
+
+// expire flags the commands past their acknowledgement timeout as expired
+func (uc *useCaseImpl) expire(ctx context.Context) {
+	expired, err := uc.commandRepo.ExpirePending(ctx, uc.now().UTC())
+	if err != nil {
+		if ctx.Err() == nil {
+			uc.loggerFactory.Core().Error("device_commands_expire_failed",
+				zap.Error(err),
+				zap.String("component", "device_commands_usecase"),
+			)
+		}
+		return
+	}
+	if expired > 0 {
+		uc.commands.Add(float64(expired), entities.DeviceCommandStatusExpired)
+		uc.loggerFactory.Core().Warn("device_commands_expired",
+			zap.Int("commands", expired),
+			zap.String("component", "device_commands_usecase"),
+		)
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	uc.tracked.Set(float64(uc.tracker.Len()))
+	families := uc.commands.Collect()
+	families = append(families, uc.retries.Collect()...)
+	return append(families, uc.tracked.Collect()...)
+}
+
+// MetricsCollector returns the device commands metrics collector
+func MetricsCollector(useCase DeviceCommandsUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package devicecommands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestUseCase(t *testing.T, signer ports.CommandSigner, now time.Time) (*useCaseImpl, *mocks.MockDeviceRepository, *mocks.MockDeviceCommandRepository, *mocks.MockCommandPublisher) {
+	deviceRepo := mocks.NewMockDeviceRepository(t)
+	commandRepo := mocks.NewMockDeviceCommandRepository(t)
+	publisher := mocks.NewMockCommandPublisher(t)
+	useCase := NewDeviceCommandsUseCase(deviceRepo, commandRepo, publisher, signer, nil, createTestLoggerFactory(t)).(*useCaseImpl)
+	useCase.now = func() time.Time { return now }
+	return useCase, deviceRepo, commandRepo, publisher
+}
+
+func TestDeviceCommandsUseCase_Send(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	device, err := entities.NewDevice(testMAC, "probe", "192.168.1.10", "greenhouse")
+	require.NoError(t, err)
+
+	t.Run("should publish an unsigned envelope and record the command", func(t *testing.T) {
+		useCase, deviceRepo, commandRepo, publisher := newTestUseCase(t, nil, now)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+		var published *entities.CommandEnvelope
+		publisher.EXPECT().Publish(mock.Anything, device, mock.Anything).Run(func(_ context.Context, _ *entities.Device, envelope *entities.CommandEnvelope) {
+			published = envelope
+		}).Return(nil).Once()
+		commandRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+
+		command, err := useCase.Send(ctx, "aa:bb:cc:dd:ee:ff", entities.DeviceCommandRequest{Command: entities.DeviceCommandIdentify})
+
+		require.NoError(t, err)
+		require.NotNil(t, published)
+		assert.Equal(t, testMAC, published.Target)
+		assert.Equal(t, entities.DeviceCommandIdentify, published.Command)
+		assert.Equal(t, now.Unix(), published.IssuedAt)
+		assert.Empty(t, published.Signature)
+		assert.JSONEq(t, `{"duration_seconds":30}`, string(published.Payload))
+		assert.Equal(t, published.Nonce, command.ID)
+		assert.Equal(t, entities.DeviceCommandStatusSent, command.Status)
+		assert.Equal(t, now.Add(14*time.Minute), command.ExpiresAt, "2m, 4m and 8m for the three deliveries")
+		assert.Equal(t, 1, command.Attempts)
+		assert.Equal(t, float64(1), useCase.commands.Value(entities.DeviceCommandStatusSent))
+	})
+
+	t.Run("should sign the envelope when a signer is configured", func(t *testing.T) {
+		signer := mocks.NewMockCommandSigner(t)
+		useCase, deviceRepo, commandRepo, publisher := newTestUseCase(t, signer, now)
+		envelope := &entities.CommandEnvelope{Version: entities.CommandEnvelopeVersion, KeyID: "k1", Target: testMAC, Command: entities.DeviceCommandReboot, IssuedAt: now.Unix(), Nonce: "abc123", Signature: "sig"}
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+		signer.EXPECT().Sign(testMAC, entities.DeviceCommandReboot, json.RawMessage(`{"delay_seconds":5}`)).Return(envelope, nil).Once()
+		publisher.EXPECT().Publish(mock.Anything, device, envelope).Return(nil).Once()
+		commandRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(command *entities.DeviceCommand) bool {
+			return command.ID == "abc123" && command.Command == entities.DeviceCommandReboot
+		})).Return(nil).Once()
+
+		command, err := useCase.Send(ctx, testMAC, entities.DeviceCommandRequest{Command: entities.DeviceCommandReboot, DelaySeconds: 5})
+
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", command.ID)
+	})
+
+	t.Run("should reject an unconfirmed factory reset without publishing it", func(t *testing.T) {
+		useCase, deviceRepo, _, _ := newTestUseCase(t, nil, now)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+
+		_, err := useCase.Send(ctx, testMAC, entities.DeviceCommandRequest{Command: entities.DeviceCommandFactoryReset, Confirm: "11:22:33:44:55:66"})
+
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeviceCommand)
+	})
+
+	t.Run("should not record a command that could not be published", func(t *testing.T) {
+		useCase, deviceRepo, _, publisher := newTestUseCase(t, nil, now)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+		publisher.EXPECT().Publish(mock.Anything, device, mock.Anything).Return(errors.New("not connected")).Once()
+
+		_, err := useCase.Send(ctx, testMAC, entities.DeviceCommandRequest{Command: entities.DeviceCommandFactoryReset, Confirm: "aa:bb:cc:dd:ee:ff"})
+
+		assert.ErrorContains(t, err, "not connected")
+	})
+
+	t.Run("should fail for an unknown device", func(t *testing.T) {
+		useCase, deviceRepo, _, _ := newTestUseCase(t, nil, now)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+		_, err := useCase.Send(ctx, testMAC, entities.DeviceCommandRequest{Command: entities.DeviceCommandReboot})
+
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+	})
+}
+
+func TestDeviceCommandsUseCase_Get(t *testing.T) {
+	useCase, _, commandRepo, _ := newTestUseCase(t, nil, time.Now())
+	commandRepo.EXPECT().FindByID(mock.Anything, "command-1").Return(&entities.DeviceCommand{ID: "command-1", MACAddress: testMAC}, nil).Twice()
+
+	command, err := useCase.Get(context.Background(), "aa:bb:cc:dd:ee:ff", "command-1")
+	require.NoError(t, err)
+	assert.Equal(t, "command-1", command.ID)
+
+	_, err = useCase.Get(context.Background(), "11:22:33:44:55:66", "command-1")
+	assert.ErrorIs(t, err, domainerrors.ErrDeviceCommandNotFound, "commands of other devices are not found")
+}
+
+func TestDeviceCommandsUseCase_Acknowledge(t *testing.T) {
+	ctx := context.Background()
+	issuedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	receivedAt := issuedAt.Add(3 * time.Second)
+	pending := &entities.DeviceCommand{ID: "command-1", MACAddress: testMAC, Command: entities.DeviceCommandReboot, Status: entities.DeviceCommandStatusSent, IssuedAt: issuedAt}
+
+	t.Run("should record a successful acknowledgement", func(t *testing.T) {
+		useCase, _, commandRepo, _ := newTestUseCase(t, nil, receivedAt)
+		commandRepo.EXPECT().FindByID(mock.Anything, "command-1").Return(pending, nil).Once()
+		commandRepo.EXPECT().Complete(mock.Anything, "command-1", entities.DeviceCommandStatusAcknowledged, "", receivedAt).Return(true, nil).Once()
+
+		require.NoError(t, useCase.Acknowledge(ctx, &entities.DeviceCommandAck{CommandID: "command-1", MACAddress: testMAC, Success: true, ReceivedAt: receivedAt}))
+		assert.Equal(t, float64(1), useCase.commands.Value(entities.DeviceCommandStatusAcknowledged))
+	})
+
+	t.Run("should record a failure reported by the device", func(t *testing.T) {
+		useCase, _, commandRepo, _ := newTestUseCase(t, nil, receivedAt)
+		commandRepo.EXPECT().FindByID(mock.Anything, "command-1").Return(pending, nil).Once()
+		commandRepo.EXPECT().Complete(mock.Anything, "command-1", entities.DeviceCommandStatusFailed, "irrigation running", receivedAt).Return(true, nil).Once()
+
+		require.NoError(t, useCase.Acknowledge(ctx, &entities.DeviceCommandAck{CommandID: "command-1", MACAddress: testMAC, Message: "irrigation running", ReceivedAt: receivedAt}))
+	})
+
+	t.Run("should ignore a late acknowledgement", func(t *testing.T) {
+		useCase, _, commandRepo, _ := newTestUseCase(t, nil, receivedAt)
+		commandRepo.EXPECT().FindByID(mock.Anything, "command-1").Return(pending, nil).Once()
+		commandRepo.EXPECT().Complete(mock.Anything, "command-1", entities.DeviceCommandStatusAcknowledged, "", receivedAt).Return(false, nil).Once()
+
+		require.NoError(t, useCase.Acknowledge(ctx, &entities.DeviceCommandAck{CommandID: "command-1", MACAddress: testMAC, Success: true, ReceivedAt: receivedAt}))
+		assert.Zero(t, useCase.commands.Value(entities.DeviceCommandStatusAcknowledged))
+	})
+
+	t.Run("should reject acknowledgements from other devices", func(t *testing.T) {
+		useCase, _, commandRepo, _ := newTestUseCase(t, nil, receivedAt)
+		commandRepo.EXPECT().FindByID(mock.Anything, "command-1").Return(pending, nil).Once()
+
+		err := useCase.Acknowledge(ctx, &entities.DeviceCommandAck{CommandID: "command-1", MACAddress: "11:22:33:44:55:66", Success: true, ReceivedAt: receivedAt})
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceCommandNotFound)
+	})
+}
+
+func TestDeviceCommandsUseCase_Run(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase, _, commandRepo, _ := newTestUseCase(t, nil, now)
+	ctx, cancel := context.WithCancel(context.Background())
+	commandRepo.EXPECT().ExpirePending(mock.Anything, now).Run(func(context.Context, time.Time) { cancel() }).Return(2, nil).Once()
+
+	useCase.Run(ctx)
+
+	assert.Equal(t, float64(2), useCase.commands.Value(entities.DeviceCommandStatusExpired))
+}
+
+func TestDeviceCommandsUseCase_Retry(t *testing.T) {
+	ctx := context.Background()
+	issuedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	device, err := entities.NewDevice(testMAC, "probe", "192.168.1.10", "greenhouse")
+	require.NoError(t, err)
+
+	// sendCommand sends a reboot and returns the nonces of its deliveries so far
+	sendCommand := func(t *testing.T) (*useCaseImpl, *mocks.MockDeviceRepository, *mocks.MockDeviceCommandRepository, *mocks.MockCommandPublisher, *[]string) {
+		useCase, deviceRepo, commandRepo, publisher := newTestUseCase(t, nil, issuedAt)
+		nonces := &[]string{}
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil)
+		publisher.EXPECT().Publish(mock.Anything, device, mock.Anything).Run(func(_ context.Context, _ *entities.Device, envelope *entities.CommandEnvelope) {
+			*nonces = append(*nonces, envelope.Nonce)
+		}).Return(nil)
+		commandRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := useCase.Send(ctx, testMAC, entities.DeviceCommandRequest{Command: entities.DeviceCommandReboot})
+		require.NoError(t, err)
+		return useCase, deviceRepo, commandRepo, publisher, nonces
+	}
+
+	t.Run("should publish again with backoff until the deliveries run out", func(t *testing.T) {
+		useCase, _, commandRepo, _, nonces := sendCommand(t)
+		commandID := (*nonces)[0]
+		commandRepo.EXPECT().RecordAttempt(mock.Anything, commandID, 2, issuedAt.Add(2*time.Minute).Add(12*time.Minute)).Return(true, nil).Once()
+		commandRepo.EXPECT().RecordAttempt(mock.Anything, commandID, 3, issuedAt.Add(6*time.Minute).Add(8*time.Minute)).Return(true, nil).Once()
+
+		for _, at := range []time.Duration{time.Minute, 2 * time.Minute, 5 * time.Minute, 6 * time.Minute, 13 * time.Minute} {
+			useCase.now = func() time.Time { return issuedAt.Add(at) }
+			useCase.retry(ctx)
+		}
+
+		require.Len(t, *nonces, 3)
+		assert.NotEqual(t, (*nonces)[1], (*nonces)[2], "every delivery carries a fresh nonce")
+		assert.Equal(t, float64(2), useCase.retries.Value(entities.DeviceCommandReboot))
+	})
+
+	t.Run("should correlate the acknowledgement of a retry with its command", func(t *testing.T) {
+		useCase, _, commandRepo, _, nonces := sendCommand(t)
+		commandID := (*nonces)[0]
+		commandRepo.EXPECT().RecordAttempt(mock.Anything, commandID, 2, mock.Anything).Return(true, nil).Once()
+		useCase.now = func() time.Time { return issuedAt.Add(2 * time.Minute) }
+		useCase.retry(ctx)
+		require.Len(t, *nonces, 2)
+
+		receivedAt := issuedAt.Add(3 * time.Minute)
+		commandRepo.EXPECT().FindByID(mock.Anything, commandID).Return(&entities.DeviceCommand{ID: commandID, MACAddress: testMAC, Status: entities.DeviceCommandStatusSent, Attempts: 2}, nil).Once()
+		commandRepo.EXPECT().Complete(mock.Anything, commandID, entities.DeviceCommandStatusAcknowledged, "", receivedAt).Return(true, nil).Once()
+		ack := &entities.DeviceCommandAck{CommandID: (*nonces)[1], MACAddress: testMAC, Success: true, ReceivedAt: receivedAt}
+		require.NoError(t, useCase.Acknowledge(ctx, ack))
+		assert.Equal(t, commandID, ack.CommandID)
+
+		useCase.now = func() time.Time { return issuedAt.Add(10 * time.Minute) }
+		useCase.retry(ctx)
+		assert.Len(t, *nonces, 2, "acknowledged commands are not retried")
+		assert.Zero(t, useCase.tracker.Len())
+	})
+
+	t.Run("should stop retrying a command no longer pending", func(t *testing.T) {
+		useCase, _, commandRepo, _, nonces := sendCommand(t)
+		commandRepo.EXPECT().RecordAttempt(mock.Anything, (*nonces)[0], 2, mock.Anything).Return(false, nil).Once()
+
+		useCase.now = func() time.Time { return issuedAt.Add(2 * time.Minute) }
+		useCase.retry(ctx)
+
+		assert.Len(t, *nonces, 1)
+		assert.Zero(t, useCase.tracker.Len())
+	})
+}
@@ -0,0 +1,136 @@
+package devicecommands
+
+import (
+	"sync"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/lru"
+)
+
+// CommandTracker correlates the deliveries of pending commands with the acknowledgements sent back
+// for them and schedules the retries of the commands left unacknowledged. Every delivery carries a
+// fresh nonce, since devices reject replayed nonces, so the acknowledgement of a retry names the
+// nonce of that delivery rather than the command ID.
+//
+// The tracker lives in memory. After a restart, or once a delivery is evicted, the command is no
+// longer retried and expires at its recorded expiry.
+type CommandTracker struct {
+	ackTimeout  time.Duration
+	maxAttempts int
+
+	mu         sync.Mutex
+	deliveries *lru.Cache[string, *trackedCommand] // by delivery nonce
+}
+
+// trackedCommand is a pending command and the schedule of its next retry
+type trackedCommand struct {
+	command   entities.DeviceCommand
+	nonces    []string // nonces of the deliveries, oldest first
+	retryAt   time.Time
+	expiresAt time.Time
+}
+
+// NewCommandTracker creates a tracker retrying commands up to maxAttempts deliveries. Delivery n
+// waits ackTimeout·2^(n-1) for its acknowledgement before the next one.
+func NewCommandTracker(ackTimeout time.Duration, maxAttempts, maxTracked int) *CommandTracker {
+	return &CommandTracker{
+		ackTimeout:  ackTimeout,
+		maxAttempts: max(maxAttempts, 1),
+		deliveries:  lru.New[string, *trackedCommand](maxTracked),
+	}
+}
+
+// ExpiresAt returns when a command delivered for the given attempt at publishedAt expires if
+// neither this delivery nor its retries are acknowledged
+func (t *CommandTracker) ExpiresAt(publishedAt time.Time, attempt int) time.Time {
+	// Delivery n waits ackTimeout·2^(n-1); the waits of the deliveries n to maxAttempts add up to
+	// ackTimeout·(2^maxAttempts - 2^(n-1))
+	remaining := (1 << t.maxAttempts) - (1 << (attempt - 1))
+	return publishedAt.Add(time.Duration(remaining) * t.ackTimeout)
+}
+
+// Track records a delivery of a pending command, the first one or a retry, whose acknowledgement
+// names the given nonce. command.Attempts counts this delivery.
+func (t *CommandTracker) Track(command *entities.DeviceCommand, nonce string, publishedAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked := &trackedCommand{command: *command, expiresAt: command.ExpiresAt}
+	if previous, ok := t.find(command.ID); ok {
+		tracked.nonces = previous.nonces
+	}
+	tracked.nonces = append(tracked.nonces, nonce)
+	tracked.retryAt = publishedAt.Add(time.Duration(1<<(command.Attempts-1)) * t.ackTimeout)
+	for _, delivery := range tracked.nonces {
+		t.deliveries.Put(delivery, tracked)
+	}
+}
+
+// Resolve returns the ID of the command a delivery belongs to. IDs of untracked deliveries, such
+// as first deliveries sent before a restart, are returned unchanged.
+func (t *CommandTracker) Resolve(nonce string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if tracked, ok := t.deliveries.Get(nonce); ok {
+		return tracked.command.ID
+	}
+	return nonce
+}
+
+// Forget stops tracking a command, once it was acknowledged
+func (t *CommandTracker) Forget(commandID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if tracked, ok := t.find(commandID); ok {
+		for _, nonce := range tracked.nonces {
+			t.deliveries.Delete(nonce)
+		}
+	}
+}
+
+// Due returns the tracked commands whose latest delivery went unacknowledged for its whole wait and
+// that have deliveries left, and stops tracking the commands past their expiry
+func (t *CommandTracker) Due(now time.Time) []*entities.DeviceCommand {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var due []*entities.DeviceCommand
+	t.deliveries.DeleteFunc(func(nonce string, tracked *trackedCommand) bool {
+		if now.After(tracked.expiresAt) {
+			return true
+		}
+		// Each command is visited once, through its latest delivery
+		if nonce == tracked.nonces[len(tracked.nonces)-1] && tracked.command.Attempts < t.maxAttempts && !now.Before(tracked.retryAt) {
+			command := tracked.command
+			due = append(due, &command)
+		}
+		return false
+	})
+	return due
+}
+
+// Len returns the number of deliveries tracked
+func (t *CommandTracker) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.deliveries.Len()
+}
+
+// find returns the tracked command with the given ID; the caller holds the lock
+func (t *CommandTracker) find(commandID string) (*trackedCommand, bool) {
+	if tracked, ok := t.deliveries.Get(commandID); ok {
+		return tracked, true
+	}
+	var found *trackedCommand
+	t.deliveries.Range(func(_ string, tracked *trackedCommand) bool {
+		if tracked.command.ID == commandID {
+			found = tracked
+			return false
+		}
+		return true
+	})
+	return found, found != nil
+}
@@ -0,0 +1,70 @@
+package devicecommands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+func TestCommandTracker(t *testing.T) {
+	publishedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should double the wait of every delivery", func(t *testing.T) {
+		tracker := NewCommandTracker(time.Minute, 3, 10)
+
+		assert.Equal(t, publishedAt.Add(7*time.Minute), tracker.ExpiresAt(publishedAt, 1))
+		assert.Equal(t, publishedAt.Add(6*time.Minute), tracker.ExpiresAt(publishedAt, 2))
+		assert.Equal(t, publishedAt.Add(4*time.Minute), tracker.ExpiresAt(publishedAt, 3))
+		assert.Equal(t, publishedAt.Add(time.Minute), NewCommandTracker(time.Minute, 1, 10).ExpiresAt(publishedAt, 1), "a single delivery waits the ack timeout")
+	})
+
+	t.Run("should resolve every delivery of a command until it is forgotten", func(t *testing.T) {
+		tracker := NewCommandTracker(time.Minute, 3, 10)
+		command := &entities.DeviceCommand{ID: "first", Attempts: 1, ExpiresAt: tracker.ExpiresAt(publishedAt, 1)}
+		tracker.Track(command, "first", publishedAt)
+		command.Attempts = 2
+		tracker.Track(command, "second", publishedAt.Add(time.Minute))
+
+		assert.Equal(t, "first", tracker.Resolve("second"))
+		assert.Equal(t, "first", tracker.Resolve("first"))
+		assert.Equal(t, "unknown", tracker.Resolve("unknown"))
+
+		tracker.Forget("first")
+		assert.Zero(t, tracker.Len())
+		assert.Equal(t, "second", tracker.Resolve("second"))
+	})
+
+	t.Run("should report the commands due for a retry once", func(t *testing.T) {
+		tracker := NewCommandTracker(time.Minute, 2, 10)
+		command := &entities.DeviceCommand{ID: "first", Attempts: 1, ExpiresAt: tracker.ExpiresAt(publishedAt, 1)}
+		tracker.Track(command, "first", publishedAt)
+
+		assert.Empty(t, tracker.Due(publishedAt.Add(30*time.Second)))
+		due := tracker.Due(publishedAt.Add(time.Minute))
+		require.Len(t, due, 1)
+		assert.Equal(t, "first", due[0].ID)
+
+		due[0].Attempts = 2
+		tracker.Track(due[0], "second", publishedAt.Add(time.Minute))
+		assert.Empty(t, tracker.Due(publishedAt.Add(2*time.Minute)), "no deliveries left")
+		assert.Equal(t, 2, tracker.Len())
+
+		assert.Empty(t, tracker.Due(publishedAt.Add(4*time.Minute)))
+		assert.Zero(t, tracker.Len(), "expired commands are dropped")
+	})
+
+	t.Run("should bound the deliveries tracked", func(t *testing.T) {
+		tracker := NewCommandTracker(time.Minute, 3, 2)
+		for _, id := range []string{"a", "b", "c"} {
+			tracker.Track(&entities.DeviceCommand{ID: id, Attempts: 1, ExpiresAt: publishedAt.Add(time.Hour)}, id, publishedAt)
+		}
+
+		assert.Equal(t, 2, tracker.Len())
+		due := tracker.Due(publishedAt.Add(time.Minute))
+		assert.Len(t, due, 2, "the oldest command is no longer retried")
+	})
+}
@@ -0,0 +1,225 @@
+package deviceconnections
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/lru"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// maxClientsPerDevice bounds the clients remembered for one device; the least recently seen are
+// forgotten first, which never hides a mismatch since it only takes two
+const maxClientsPerDevice = 16
+
+// ConnectionsConfig holds configuration for tracking the clients of devices
+type ConnectionsConfig struct {
+	MismatchWindow time.Duration // how long a client is remembered after its last message
+	MaxTracked     int           // devices remembered; the least recently seen are forgotten
+}
+
+// DefaultConnectionsConfig returns default configuration
+func DefaultConnectionsConfig() *ConnectionsConfig {
+	return &ConnectionsConfig{
+		MismatchWindow: time.Hour,
+		MaxTracked:     50000,
+	}
+}
+
+// MismatchObserver is told when another client starts publishing messages of a device, such as
+// the security monitor which raises the clone alert
+type MismatchObserver interface {
+	ObserveConnectionMismatch(ctx context.Context, connection *entities.DeviceConnection)
+}
+
+// DeviceConnectionsUseCase maps devices to the MQTT clients publishing their messages, using the
+// publisher identity reported by the broker
+type DeviceConnectionsUseCase interface {
+	// Observe records the client that published a message of the device. Messages without a
+	// publisher identity cannot be attributed and are ignored.
+	Observe(ctx context.Context, macAddress string)
+
+	// Get returns the clients that recently published messages of the device, failing with
+	// ErrDeviceConnectionNotFound when none did since the server started
+	Get(ctx context.Context, macAddress string) (*entities.DeviceConnection, error)
+
+	// Mismatches returns the devices that several client IDs published for, by MAC address
+	Mismatches() []*entities.DeviceConnection
+}
+
+// useCaseImpl implements the DeviceConnectionsUseCase interface
+type useCaseImpl struct {
+	config        *ConnectionsConfig
+	observer      MismatchObserver
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+
+	mu          sync.Mutex
+	connections *lru.Cache[string, *entities.DeviceConnection]
+
+	mismatches *metrics.Vec
+}
+
+// NewDeviceConnectionsUseCase creates a device connections use case; observer is optional
+func NewDeviceConnectionsUseCase(config *ConnectionsConfig, observer MismatchObserver, loggerFactory logger.LoggerFactory) DeviceConnectionsUseCase {
+	if config == nil {
+		config = DefaultConnectionsConfig()
+	}
+
+	return &useCaseImpl{
+		config:        config,
+		observer:      observer,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		connections:   lru.New[string, *entities.DeviceConnection](config.MaxTracked),
+		mismatches:    metrics.NewCounterVec("device_connection_mismatches_total", "Clients that started publishing messages of a device another client published for"),
+	}
+}
+
+// Observe moves the publishing client to the front of the device's clients and reports a client
+// joining others that published within the mismatch window
+func (uc *useCaseImpl) Observe(ctx context.Context, macAddress string) {
+	identity := eventports.ClientIdentityFromContext(ctx)
+	if identity == nil || identity.ClientID == "" || macAddress == "" {
+		return
+	}
+	macAddress = strings.ToUpper(strings.TrimSpace(macAddress))
+
+	uc.mu.Lock()
+	now := uc.now()
+	connection, ok := uc.connections.Get(macAddress)
+	if !ok {
+		connection = &entities.DeviceConnection{MACAddress: macAddress}
+		uc.connections.Put(macAddress, connection)
+	}
+
+	client := entities.DeviceClient{ClientID: identity.ClientID, FirstSeenAt: now}
+	clients := make([]entities.DeviceClient, 0, len(connection.Clients)+1)
+	for _, known := range connection.Clients {
+		if known.ClientID == identity.ClientID {
+			client.FirstSeenAt = known.FirstSeenAt
+			continue
+		}
+		if now.Sub(known.LastSeenAt) <= uc.config.MismatchWindow {
+			clients = append(clients, known)
+		}
+	}
+	joined := client.FirstSeenAt.Equal(now) && len(clients) > 0
+	client.Username = identity.Username
+	client.RemoteAddress = identity.RemoteAddress
+	client.LastSeenAt = now
+	connection.Clients = append([]entities.DeviceClient{client}, clients...)
+	if len(connection.Clients) > maxClientsPerDevice {
+		connection.Clients = connection.Clients[:maxClientsPerDevice]
+	}
+
+	var mismatch *entities.DeviceConnection
+	if joined {
+		mismatch = connection.Clone()
+	}
+	uc.mu.Unlock()
+
+	if mismatch == nil {
+		return
+	}
+	uc.mismatches.Inc()
+	uc.loggerFactory.Core().Warn("device_connection_mismatch",
+		zap.String("mac_address", macAddress),
+		zap.String("client_id", client.ClientID),
+		zap.String("remote_address", client.RemoteAddress),
+		zap.Int("clients", len(mismatch.Clients)),
+		zap.String("component", "device_connections_usecase"),
+	)
+	if uc.observer != nil {
+		uc.observer.ObserveConnectionMismatch(ctx, mismatch)
+	}
+}
+
+// Get returns a copy of the device's connection without the clients that left the window
+func (uc *useCaseImpl) Get(ctx context.Context, macAddress string) (*entities.DeviceConnection, error) {
+	if err := validation.ValidateMACAddress(macAddress); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDevice, err)
+	}
+	macAddress = strings.ToUpper(strings.TrimSpace(macAddress))
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	connection, ok := uc.connections.Get(macAddress)
+	if !ok {
+		return nil, domainerrors.ErrDeviceConnectionNotFound
+	}
+	return uc.recentLocked(connection, uc.now()), nil
+}
+
+// Mismatches returns the devices with several clients in the window
+func (uc *useCaseImpl) Mismatches() []*entities.DeviceConnection {
+	uc.mu.Lock()
+	now := uc.now()
+	var mismatches []*entities.DeviceConnection
+	uc.connections.Range(func(_ string, connection *entities.DeviceConnection) bool {
+		if recent := uc.recentLocked(connection, now); recent.Mismatch() {
+			mismatches = append(mismatches, recent)
+		}
+		return true
+	})
+	uc.mu.Unlock()
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].MACAddress < mismatches[j].MACAddress
+	})
+	return mismatches
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	uc.mu.Lock()
+	tracked, evictions := uc.connections.Len(), uc.connections.Evictions()
+	uc.mu.Unlock()
+
+	return append(uc.mismatches.Collect(),
+		metrics.Family{
+			Name:    "device_connections_tracked",
+			Help:    "Devices whose publishing clients are remembered",
+			Type:    metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(tracked)}},
+		},
+		metrics.Family{
+			Name:    "device_connections_evictions_total",
+			Help:    "Devices forgotten to stay within the size limit",
+			Type:    metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(evictions)}},
+		},
+	)
+}
+
+// MetricsCollector returns the device connections metrics collector
+func MetricsCollector(useCase DeviceConnectionsUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
+
+// recentLocked copies the connection keeping the client that published last and the others
+// seen within the mismatch window
+func (uc *useCaseImpl) recentLocked(connection *entities.DeviceConnection, now time.Time) *entities.DeviceConnection {
+	recent := &entities.DeviceConnection{MACAddress: connection.MACAddress}
+	for i, client := range connection.Clients {
+		if i == 0 || now.Sub(client.LastSeenAt) <= uc.config.MismatchWindow {
+			recent.Clients = append(recent.Clients, client)
+		}
+	}
+	return recent
+}
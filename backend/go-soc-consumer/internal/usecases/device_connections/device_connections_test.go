@@ -0,0 +1,116 @@
+package deviceconnections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:01"
+
+// newTestConnections returns a use case whose clock is advanced by the returned function
+func newTestConnections(t *testing.T, observer MismatchObserver) (*useCaseImpl, func(time.Duration)) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	useCase := NewDeviceConnectionsUseCase(nil, observer, loggerFactory).(*useCaseImpl)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase.now = func() time.Time { return now }
+	return useCase, func(d time.Duration) { now = now.Add(d) }
+}
+
+func publishedBy(clientID, remoteAddress string) context.Context {
+	return eventports.ContextWithClientIdentity(context.Background(), &entities.ClientIdentity{ClientID: clientID, RemoteAddress: remoteAddress})
+}
+
+func TestDeviceConnections_Observe(t *testing.T) {
+	t.Run("should remember the client publishing for a device", func(t *testing.T) {
+		useCase, advance := newTestConnections(t, nil)
+
+		useCase.Observe(publishedBy("sensor-01", "192.168.1.20"), "aa:bb:cc:dd:ee:01")
+		advance(time.Minute)
+		useCase.Observe(publishedBy("sensor-01", "192.168.1.21"), testMAC)
+
+		connection, err := useCase.Get(context.Background(), testMAC)
+		require.NoError(t, err)
+		require.Len(t, connection.Clients, 1)
+		assert.False(t, connection.Mismatch())
+		assert.Equal(t, "192.168.1.21", connection.Current().RemoteAddress)
+		assert.Equal(t, time.Minute, connection.Current().LastSeenAt.Sub(connection.Current().FirstSeenAt))
+		assert.Empty(t, useCase.Mismatches())
+	})
+
+	t.Run("should report a second client publishing for a device", func(t *testing.T) {
+		observer := mocks.NewMockMismatchObserver(t)
+		observer.EXPECT().ObserveConnectionMismatch(mock.Anything, mock.MatchedBy(func(connection *entities.DeviceConnection) bool {
+			return connection.MACAddress == testMAC && len(connection.Clients) == 2 && connection.Current().ClientID == "clone"
+		})).Once()
+		useCase, advance := newTestConnections(t, observer)
+
+		useCase.Observe(publishedBy("sensor-01", "192.168.1.20"), testMAC)
+		advance(time.Minute)
+		useCase.Observe(publishedBy("clone", "10.0.0.9"), testMAC)
+		advance(time.Minute)
+		useCase.Observe(publishedBy("sensor-01", "192.168.1.20"), testMAC)
+
+		mismatches := useCase.Mismatches()
+		require.Len(t, mismatches, 1)
+		assert.Equal(t, "sensor-01", mismatches[0].Current().ClientID)
+	})
+
+	t.Run("should forget clients outside the mismatch window", func(t *testing.T) {
+		useCase, advance := newTestConnections(t, nil)
+
+		useCase.Observe(publishedBy("sensor-01", "192.168.1.20"), testMAC)
+		advance(2 * time.Hour)
+		useCase.Observe(publishedBy("replacement", "192.168.1.30"), testMAC)
+
+		connection, err := useCase.Get(context.Background(), testMAC)
+		require.NoError(t, err)
+		require.Len(t, connection.Clients, 1)
+		assert.Equal(t, "replacement", connection.Current().ClientID)
+	})
+
+	t.Run("should ignore messages without a publisher identity", func(t *testing.T) {
+		useCase, _ := newTestConnections(t, nil)
+
+		useCase.Observe(context.Background(), testMAC)
+
+		_, err := useCase.Get(context.Background(), testMAC)
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceConnectionNotFound)
+	})
+}
+
+func TestDeviceConnections_Get(t *testing.T) {
+	useCase, _ := newTestConnections(t, nil)
+
+	_, err := useCase.Get(context.Background(), "not-a-mac")
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidDevice)
+}
+
+func TestDeviceConnections_Trackers(t *testing.T) {
+	useCase, _ := newTestConnections(t, nil)
+	inner := mocks.NewMockSensorDataUseCase(t)
+	inner.EXPECT().StoreSensorData(mock.Anything, mock.Anything).Return(nil).Twice()
+	tracked := NewTrackedSensorDataUseCase(inner, useCase)
+
+	data, err := entities.NewSensorTemperatureHumidity(testMAC, 22.5, 60)
+	require.NoError(t, err)
+	require.NoError(t, tracked.StoreSensorData(eventports.ContextWithReplay(publishedBy("sensor-01", ""), time.Now()), data))
+	_, err = useCase.Get(context.Background(), testMAC)
+	assert.ErrorIs(t, err, domainerrors.ErrDeviceConnectionNotFound, "replays were observed when first received")
+
+	require.NoError(t, tracked.StoreSensorData(publishedBy("sensor-01", ""), data))
+	connection, err := useCase.Get(context.Background(), testMAC)
+	require.NoError(t, err)
+	assert.Equal(t, "sensor-01", connection.Current().ClientID)
+}
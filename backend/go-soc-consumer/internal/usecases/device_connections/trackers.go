@@ -0,0 +1,50 @@
+package deviceconnections
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// trackedDeviceRegistrationUseCase records the client publishing every registration
+type trackedDeviceRegistrationUseCase struct {
+	deviceregistration.DeviceRegistrationUseCase
+	connections DeviceConnectionsUseCase
+}
+
+// NewTrackedDeviceRegistrationUseCase wraps a registration use case with connection tracking
+func NewTrackedDeviceRegistrationUseCase(inner deviceregistration.DeviceRegistrationUseCase, connections DeviceConnectionsUseCase) deviceregistration.DeviceRegistrationUseCase {
+	return &trackedDeviceRegistrationUseCase{DeviceRegistrationUseCase: inner, connections: connections}
+}
+
+// RegisterDevice observes the publishing client before registering. Replayed registrations were
+// observed when first received.
+func (uc *trackedDeviceRegistrationUseCase) RegisterDevice(ctx context.Context, message *entities.DeviceRegistrationMessage) error {
+	if message != nil && !eventports.IsReplay(ctx) {
+		uc.connections.Observe(ctx, message.MACAddress)
+	}
+	return uc.DeviceRegistrationUseCase.RegisterDevice(ctx, message)
+}
+
+// trackedSensorDataUseCase records the client publishing every reading
+type trackedSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	connections DeviceConnectionsUseCase
+}
+
+// NewTrackedSensorDataUseCase wraps a sensor data use case with connection tracking
+func NewTrackedSensorDataUseCase(inner sensordata.SensorDataUseCase, connections DeviceConnectionsUseCase) sensordata.SensorDataUseCase {
+	return &trackedSensorDataUseCase{SensorDataUseCase: inner, connections: connections}
+}
+
+// StoreSensorData observes the publishing client before storing the reading. Replayed readings
+// were observed when first received.
+func (uc *trackedSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if data != nil && !eventports.IsReplay(ctx) {
+		uc.connections.Observe(ctx, data.MacAddress())
+	}
+	return uc.SensorDataUseCase.StoreSensorData(ctx, data)
+}
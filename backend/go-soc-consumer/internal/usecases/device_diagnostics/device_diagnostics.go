@@ -0,0 +1,163 @@
+package devicediagnostics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	devicechanges "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_changes"
+	devicehealth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_health"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// DiagnosticsConfig holds configuration for device diagnostics
+type DiagnosticsConfig struct {
+	TelemetryWindow time.Duration // how far back the latest reading of each stream is looked for
+	Transitions     int           // status transitions listed
+}
+
+// DefaultDiagnosticsConfig returns default configuration
+func DefaultDiagnosticsConfig() *DiagnosticsConfig {
+	return &DiagnosticsConfig{
+		TelemetryWindow: 24 * time.Hour,
+		Transitions:     10,
+	}
+}
+
+// DeviceDiagnosticsUseCase gathers what the server knows about a device for field debugging
+type DeviceDiagnosticsUseCase interface {
+	// Diagnose returns the stored device, the latest reading of each of its streams, its latest status
+	// transitions and the outcome of a health check run now, which also updates its status. An unknown
+	// device fails with ErrDeviceNotFound.
+	Diagnose(ctx context.Context, macAddress string) (*entities.DeviceDiagnostics, error)
+}
+
+// useCaseImpl implements the DeviceDiagnosticsUseCase interface
+type useCaseImpl struct {
+	deviceRepo      repositoryports.DeviceRepository
+	sensorDataRepo  repositoryports.SensorTemperatureHumidityRepository
+	measurementRepo repositoryports.MeasurementRepository
+	changes         devicechanges.DeviceChangesUseCase
+	deviceHealth    devicehealth.DeviceHealthUseCase
+	config          *DiagnosticsConfig
+	loggerFactory   logger.LoggerFactory
+	now             func() time.Time
+}
+
+// NewDeviceDiagnosticsUseCase creates a device diagnostics use case
+func NewDeviceDiagnosticsUseCase(
+	deviceRepo repositoryports.DeviceRepository,
+	sensorDataRepo repositoryports.SensorTemperatureHumidityRepository,
+	measurementRepo repositoryports.MeasurementRepository,
+	changes devicechanges.DeviceChangesUseCase,
+	deviceHealth devicehealth.DeviceHealthUseCase,
+	config *DiagnosticsConfig,
+	loggerFactory logger.LoggerFactory,
+) DeviceDiagnosticsUseCase {
+	if config == nil {
+		config = DefaultDiagnosticsConfig()
+	}
+
+	return &useCaseImpl{
+		deviceRepo:      deviceRepo,
+		sensorDataRepo:  sensorDataRepo,
+		measurementRepo: measurementRepo,
+		changes:         changes,
+		deviceHealth:    deviceHealth,
+		config:          config,
+		loggerFactory:   loggerFactory,
+		now:             time.Now,
+	}
+}
+
+// Diagnose gathers every section of the device diagnostics
+func (uc *useCaseImpl) Diagnose(ctx context.Context, macAddress string) (*entities.DeviceDiagnostics, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	diagnostics := &entities.DeviceDiagnostics{GeneratedAt: now.UTC(), Device: device}
+	if diagnostics.Telemetry, err = uc.telemetry(ctx, device.GetID(), now); err != nil {
+		return nil, err
+	}
+	diagnostics.Transitions = uc.changes.Transitions(device.GetID(), uc.config.Transitions)
+	diagnostics.Probe = uc.probe(ctx, device)
+
+	uc.loggerFactory.Core().Info("device_diagnosed",
+		zap.String("mac_address", device.GetID()),
+		zap.Int("streams", len(diagnostics.Telemetry)),
+		zap.Int("transitions", len(diagnostics.Transitions)),
+		zap.Bool("alive", diagnostics.Probe.Alive),
+		zap.Duration("latency", diagnostics.Probe.Latency),
+		zap.String("component", "device_diagnostics_usecase"),
+	)
+	return diagnostics, nil
+}
+
+// telemetry merges the latest temperature and humidity reading with the latest measurement of every
+// registered sensor type, keeping the newest reading of each stream and channel
+func (uc *useCaseImpl) telemetry(ctx context.Context, macAddress string, now time.Time) ([]*entities.TelemetryReading, error) {
+	since := now.Add(-uc.config.TelemetryWindow)
+	latest := make(map[string]*entities.TelemetryReading)
+	keep := func(reading *entities.TelemetryReading) {
+		key := fmt.Sprintf("%s|%d", reading.Stream, reading.Channel)
+		if current, ok := latest[key]; !ok || reading.Timestamp.After(current.Timestamp) {
+			latest[key] = reading
+		}
+	}
+
+	readings, err := uc.sensorDataRepo.FindByMACAddress(ctx, macAddress, since, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sensor readings: %w", err)
+	}
+	if len(readings) > 0 {
+		reading := readings[len(readings)-1]
+		keep(&entities.TelemetryReading{Stream: entities.MeasurementTemperature, Value: reading.Temperature(), Unit: "celsius", Quality: entities.MeasurementQualityGood, Timestamp: reading.Timestamp()})
+		keep(&entities.TelemetryReading{Stream: entities.MeasurementHumidity, Value: reading.Humidity(), Unit: "percent", Quality: entities.MeasurementQualityGood, Timestamp: reading.Timestamp()})
+	}
+
+	measurements, err := uc.measurementRepo.LatestByDevice(ctx, macAddress, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load measurements: %w", err)
+	}
+	for _, measurement := range measurements {
+		keep(&entities.TelemetryReading{
+			Stream:    measurement.SensorType,
+			Channel:   measurement.Channel,
+			Value:     measurement.Value,
+			Unit:      measurement.Unit,
+			Quality:   measurement.Quality,
+			Timestamp: measurement.Timestamp,
+		})
+	}
+
+	telemetry := make([]*entities.TelemetryReading, 0, len(latest))
+	for _, reading := range latest {
+		telemetry = append(telemetry, reading)
+	}
+	sort.Slice(telemetry, func(i, j int) bool {
+		if telemetry[i].Stream != telemetry[j].Stream {
+			return telemetry[i].Stream < telemetry[j].Stream
+		}
+		return telemetry[i].Channel < telemetry[j].Channel
+	})
+	return telemetry, nil
+}
+
+// probe runs an on-demand health check of the device; a check that could not be run is reported
+// as a failed probe rather than failing the diagnostics
+func (uc *useCaseImpl) probe(ctx context.Context, device *entities.Device) *entities.DeviceProbe {
+	probe, err := uc.deviceHealth.CheckDevice(ctx, device.GetID())
+	if err != nil {
+		return &entities.DeviceProbe{IPAddress: device.GetIPAddress(), Error: err.Error(), CheckedAt: uc.now().UTC()}
+	}
+	return probe
+}
@@ -0,0 +1,109 @@
+package devicediagnostics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	devicechanges "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_changes"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func TestDeviceDiagnosticsUseCase_Diagnose(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+
+	newTestUseCase := func(t *testing.T) (*useCaseImpl, *mocks.MockDeviceRepository, *mocks.MockSensorTemperatureHumidityRepository, *mocks.MockMeasurementRepository, *mocks.MockDeviceHealthUseCase, devicechanges.DeviceChangesUseCase) {
+		deviceRepo := mocks.NewMockDeviceRepository(t)
+		sensorDataRepo := mocks.NewMockSensorTemperatureHumidityRepository(t)
+		measurementRepo := mocks.NewMockMeasurementRepository(t)
+		deviceHealth := mocks.NewMockDeviceHealthUseCase(t)
+		changes := devicechanges.NewDeviceChangesUseCase(nil)
+		useCase := NewDeviceDiagnosticsUseCase(deviceRepo, sensorDataRepo, measurementRepo, changes, deviceHealth, nil, createTestLoggerFactory(t)).(*useCaseImpl)
+		useCase.now = func() time.Time { return now }
+		return useCase, deviceRepo, sensorDataRepo, measurementRepo, deviceHealth, changes
+	}
+
+	t.Run("should gather every section", func(t *testing.T) {
+		useCase, deviceRepo, sensorDataRepo, measurementRepo, deviceHealth, changes := newTestUseCase(t)
+		device, err := entities.NewDevice(testMAC, "probe", "192.168.1.10", "greenhouse")
+		require.NoError(t, err)
+		for _, status := range []string{"online", "offline", "online"} {
+			require.NoError(t, device.UpdateStatus(status))
+			changes.Record(device)
+		}
+
+		older, err := entities.NewSensorTemperatureHumidityAt(testMAC, 20, 60, now.Add(-2*time.Hour))
+		require.NoError(t, err)
+		newer, err := entities.NewSensorTemperatureHumidityAt(testMAC, 22.5, 55, now.Add(-time.Hour))
+		require.NoError(t, err)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+		sensorDataRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC, since, now).Return([]*entities.SensorTemperatureHumidity{older, newer}, nil).Once()
+		measurementRepo.EXPECT().LatestByDevice(mock.Anything, testMAC, since).Return([]*entities.Measurement{
+			{MACAddress: testMAC, SensorType: "soil_moisture", Channel: 1, Value: 31, Unit: "percent", Quality: entities.MeasurementQualityGood, Timestamp: now.Add(-time.Minute)},
+			{MACAddress: testMAC, SensorType: "temperature", Value: 23, Unit: "celsius", Quality: entities.MeasurementQualitySuspect, Timestamp: now.Add(-3 * time.Hour)},
+		}, nil).Once()
+		deviceHealth.EXPECT().CheckDevice(mock.Anything, testMAC).Return(&entities.DeviceProbe{IPAddress: "192.168.1.10", Alive: true, Latency: 80 * time.Millisecond}, nil).Once()
+
+		diagnostics, err := useCase.Diagnose(ctx, "aa:bb:cc:dd:ee:ff")
+
+		require.NoError(t, err)
+		assert.Equal(t, now, diagnostics.GeneratedAt)
+		assert.Same(t, device, diagnostics.Device)
+		require.Len(t, diagnostics.Telemetry, 3)
+		assert.Equal(t, "humidity", diagnostics.Telemetry[0].Stream)
+		assert.Equal(t, "soil_moisture", diagnostics.Telemetry[1].Stream)
+		assert.Equal(t, 1, diagnostics.Telemetry[1].Channel)
+		assert.Equal(t, "temperature", diagnostics.Telemetry[2].Stream)
+		assert.Equal(t, 22.5, diagnostics.Telemetry[2].Value, "the newer stored reading wins")
+		require.Len(t, diagnostics.Transitions, 3)
+		assert.Equal(t, uint64(3), diagnostics.Transitions[0].Cursor)
+		assert.True(t, diagnostics.Probe.Alive)
+		assert.Equal(t, 80*time.Millisecond, diagnostics.Probe.Latency)
+	})
+
+	t.Run("should report a health check that could not run", func(t *testing.T) {
+		useCase, deviceRepo, sensorDataRepo, measurementRepo, deviceHealth, _ := newTestUseCase(t)
+		device, err := entities.NewDevice(testMAC, "probe", "192.168.1.10", "greenhouse")
+		require.NoError(t, err)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+		sensorDataRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC, since, now).Return(nil, nil).Once()
+		measurementRepo.EXPECT().LatestByDevice(mock.Anything, testMAC, since).Return(nil, nil).Once()
+		deviceHealth.EXPECT().CheckDevice(mock.Anything, testMAC).Return(nil, errors.New("no health check slot became free")).Once()
+
+		diagnostics, err := useCase.Diagnose(ctx, testMAC)
+
+		require.NoError(t, err)
+		assert.Empty(t, diagnostics.Telemetry)
+		assert.Empty(t, diagnostics.Transitions)
+		assert.False(t, diagnostics.Probe.Alive)
+		assert.Equal(t, "192.168.1.10", diagnostics.Probe.IPAddress)
+		assert.Equal(t, "no health check slot became free", diagnostics.Probe.Error)
+	})
+
+	t.Run("should fail for an unknown device", func(t *testing.T) {
+		useCase, deviceRepo, _, _, _, _ := newTestUseCase(t)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+		_, err := useCase.Diagnose(ctx, testMAC)
+
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+	})
+}
@@ -3,47 +3,78 @@ package devicehealth
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
 )
 
 // HealthCheckConfig holds configuration for the health check use case
 type HealthCheckConfig struct {
 	MaxConcurrent int
+	CheckTimeout  time.Duration // bound of an on-demand check, waiting for a free slot included
+
+	// OfflineThreshold is how long an online device may stay silent before the sweeper marks it offline
+	OfflineThreshold time.Duration
+	SweepInterval    time.Duration
 }
 
 // DefaultHealthCheckConfig returns default configuration
 func DefaultHealthCheckConfig() *HealthCheckConfig {
 	return &HealthCheckConfig{
 		MaxConcurrent: 10,
+		CheckTimeout:  10 * time.Second,
+
+		OfflineThreshold: 15 * time.Minute,
+		SweepInterval:    time.Minute,
 	}
 }
 
 // DeviceHealthUseCase defines the interface for device health checking operations
 type DeviceHealthUseCase interface {
-	// ProcessDeviceDetectedEvent processes a device detected event and performs health check
+	// ProcessDeviceDetectedEvent processes a device detected event and performs health check. Health
+	// checks finding alive a device that was not online publish a device.online event.
 	ProcessDeviceDetectedEvent(ctx context.Context, event *entities.DeviceDetectedEvent) error
+
+	// CheckDevice checks the health of a registered device right away, updates its status and returns
+	// the probe result. An unknown device fails with ErrDeviceNotFound.
+	CheckDevice(ctx context.Context, macAddress string) (*entities.DeviceProbe, error)
+
+	// SweepOffline marks offline the online devices not seen within the offline threshold, publishing
+	// a device.offline event for each, and returns how many were marked
+	SweepOffline(ctx context.Context) (int, error)
+
+	// Run sweeps offline devices every sweep interval until the context is cancelled
+	Run(ctx context.Context)
 }
 
 // useCaseImpl implements the DeviceHealthUseCase interface
 type useCaseImpl struct {
-	deviceRepo    repositoryports.DeviceRepository
-	healthChecker ports.DeviceHealthChecker
-	config        *HealthCheckConfig
-	loggerFactory logger.LoggerFactory
-	semaphore     chan struct{} // For limiting concurrent health checks
+	deviceRepo     repositoryports.DeviceRepository
+	healthChecker  ports.DeviceHealthChecker
+	eventPublisher eventports.EventPublisher
+	config         *HealthCheckConfig
+	loggerFactory  logger.LoggerFactory
+	semaphore      chan struct{} // For limiting concurrent health checks
+	now            func() time.Time
+
+	markedOffline *metrics.Vec
 }
 
 // NewDeviceHealthUseCase creates a new device health use case
 func NewDeviceHealthUseCase(
 	deviceRepo repositoryports.DeviceRepository,
 	healthChecker ports.DeviceHealthChecker,
+	eventPublisher eventports.EventPublisher,
 	config *HealthCheckConfig,
 	loggerFactory logger.LoggerFactory,
 ) DeviceHealthUseCase {
@@ -61,18 +92,21 @@ func NewDeviceHealthUseCase(
 	}
 
 	return &useCaseImpl{
-		deviceRepo:    deviceRepo,
-		healthChecker: healthChecker,
-		config:        config,
-		loggerFactory: loggerFactory,
-		semaphore:     make(chan struct{}, config.MaxConcurrent),
+		deviceRepo:     deviceRepo,
+		healthChecker:  healthChecker,
+		eventPublisher: eventPublisher,
+		config:         config,
+		loggerFactory:  loggerFactory,
+		semaphore:      make(chan struct{}, config.MaxConcurrent),
+		now:            time.Now,
+		markedOffline:  metrics.NewCounterVec("devices_marked_offline_total", "Devices marked offline after staying silent beyond the offline threshold"),
 	}
 }
 
 // ProcessDeviceDetectedEvent processes a device detected event
 func (uc *useCaseImpl) ProcessDeviceDetectedEvent(ctx context.Context, event *entities.DeviceDetectedEvent) error {
-	if event == nil {
-		return fmt.Errorf("event cannot be nil")
+	if err := domainerrors.RequireInput("device detected event", event); err != nil {
+		return err
 	}
 
 	if err := event.Validate(); err != nil {
@@ -142,6 +176,39 @@ func (uc *useCaseImpl) performHealthCheck(ctx context.Context, event *entities.D
 	}
 }
 
+// CheckDevice performs a health check bounded by the check timeout and records its outcome
+func (uc *useCaseImpl) CheckDevice(ctx context.Context, macAddress string) (*entities.DeviceProbe, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, err
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, uc.config.CheckTimeout)
+	defer cancel()
+
+	select {
+	case uc.semaphore <- struct{}{}:
+		defer func() { <-uc.semaphore }()
+	case <-checkCtx.Done():
+		return nil, fmt.Errorf("no health check slot became free: %w", checkCtx.Err())
+	}
+
+	probe := &entities.DeviceProbe{IPAddress: device.GetIPAddress(), CheckedAt: time.Now().UTC()}
+	start := time.Now()
+	isAlive, err := uc.healthChecker.CheckHealth(checkCtx, probe.IPAddress)
+	probe.Latency = time.Since(start)
+	probe.Alive = isAlive && err == nil
+	if err != nil {
+		probe.Error = err.Error()
+	}
+	uc.loggerFactory.Device().LogDeviceHealthCheck(device.GetID(), probe.IPAddress, probe.Alive, probe.Latency, err)
+
+	if err := uc.updateDeviceStatus(ctx, device.GetID(), probe.Alive); err != nil {
+		return nil, err
+	}
+	return probe, nil
+}
+
 // updateDeviceStatus updates the device status based on health check results
 func (uc *useCaseImpl) updateDeviceStatus(ctx context.Context, macAddress string, isAlive bool) error {
 	// Retrieve the device from repository
@@ -175,13 +242,16 @@ func (uc *useCaseImpl) updateDeviceStatus(ctx context.Context, macAddress string
 		)
 	}
 
-	// Update device status
-	if err := device.UpdateStatus(newStatus); err != nil {
+	// Update the status on a copy of the device
+	updatedDevice, err := device.WithChanges(func(device *entities.Device) error {
+		return device.UpdateStatus(newStatus)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update device status: %w", err)
 	}
 
 	// Save updated device to repository
-	if err := uc.deviceRepo.Update(ctx, device); err != nil {
+	if err := uc.deviceRepo.Update(ctx, updatedDevice); err != nil {
 		return fmt.Errorf("failed to update device status: %w", err)
 	}
 
@@ -191,5 +261,131 @@ func (uc *useCaseImpl) updateDeviceStatus(ctx context.Context, macAddress string
 		zap.String("component", "device_health_usecase"),
 	)
 
+	if isAlive && device.GetStatus() != "online" {
+		uc.publishDeviceOnlineEvent(ctx, updatedDevice)
+	}
+
+	return nil
+}
+
+// SweepOffline marks offline the online devices whose last contact is older than the offline threshold
+func (uc *useCaseImpl) SweepOffline(ctx context.Context) (int, error) {
+	devices, err := uc.deviceRepo.ListByStatus(ctx, "online", 0, pagination.Unlimited)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list online devices: %w", err)
+	}
+
+	now := uc.now()
+	cutoff := now.Add(-uc.config.OfflineThreshold)
+	marked := 0
+	for _, device := range devices {
+		lastSeen := device.GetLastSeen()
+		if !lastSeen.Before(cutoff) {
+			continue
+		}
+
+		// The status changes without touching LastSeen, which keeps the time of the last contact
+		updatedDevice, err := device.WithChanges(func(device *entities.Device) error {
+			device.Status = "offline"
+			return nil
+		})
+		if err != nil {
+			return marked, fmt.Errorf("failed to mark device %s offline: %w", device.GetID(), err)
+		}
+		if err := uc.deviceRepo.Update(ctx, updatedDevice); err != nil {
+			return marked, fmt.Errorf("failed to mark device %s offline: %w", device.GetID(), err)
+		}
+		marked++
+		uc.markedOffline.Inc()
+
+		uc.loggerFactory.Core().Warn("device_marked_offline",
+			zap.String("mac_address", updatedDevice.GetID()),
+			zap.Time("last_seen", lastSeen),
+			zap.Duration("silent_for", now.Sub(lastSeen)),
+			zap.String("component", "device_health_usecase"),
+		)
+		uc.publishDeviceOfflineEvent(ctx, updatedDevice, lastSeen, now)
+	}
+	return marked, nil
+}
+
+// publishDeviceOfflineEvent publishes a device offline event, logging failures without returning them
+func (uc *useCaseImpl) publishDeviceOfflineEvent(ctx context.Context, device *entities.Device, lastSeen, detectedAt time.Time) {
+	if uc.eventPublisher == nil || !uc.eventPublisher.IsConnected() {
+		return
+	}
+
+	event, err := entities.NewDeviceOfflineEvent(device.GetID(), device.FarmID, lastSeen, detectedAt)
+	if err != nil {
+		uc.loggerFactory.Core().Error("failed_to_create_device_offline_event",
+			zap.Error(err),
+			zap.String("mac_address", device.GetID()),
+			zap.String("component", "device_health_usecase"),
+		)
+		return
+	}
+
+	subject := event.GetSubject()
+	err = uc.eventPublisher.Publish(ctx, subject, event)
+	uc.loggerFactory.Messaging().LogEventPublishing("device_offline", subject, event.EventID, err == nil, err)
+}
+
+// publishDeviceOnlineEvent publishes a device online event, logging failures without returning them
+func (uc *useCaseImpl) publishDeviceOnlineEvent(ctx context.Context, device *entities.Device) {
+	if uc.eventPublisher == nil || !uc.eventPublisher.IsConnected() {
+		return
+	}
+
+	event, err := entities.NewDeviceOnlineEvent(device.GetID(), device.FarmID, device.GetIPAddress(), uc.now())
+	if err != nil {
+		uc.loggerFactory.Core().Error("failed_to_create_device_online_event",
+			zap.Error(err),
+			zap.String("mac_address", device.GetID()),
+			zap.String("component", "device_health_usecase"),
+		)
+		return
+	}
+
+	subject := event.GetSubject()
+	err = uc.eventPublisher.Publish(ctx, subject, event)
+	uc.loggerFactory.Messaging().LogEventPublishing("device_online", subject, event.EventID, err == nil, err)
+}
+
+// Run sweeps offline devices on every tick until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("offline_sweeper_started", "device_health_usecase",
+		zap.Duration("interval", uc.config.SweepInterval),
+		zap.Duration("offline_threshold", uc.config.OfflineThreshold),
+	)
+
+	ticker := time.NewTicker(uc.config.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("offline_sweeper_stopped", "device_health_usecase")
+			return
+		case <-ticker.C:
+			if _, err := uc.SweepOffline(ctx); err != nil && ctx.Err() == nil {
+				uc.loggerFactory.Core().Error("offline_sweep_failed",
+					zap.Error(err),
+					zap.String("component", "device_health_usecase"),
+				)
+			}
+		}
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return uc.markedOffline.Collect()
+}
+
+// MetricsCollector returns the device health metrics collector
+func MetricsCollector(useCase DeviceHealthUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
 	return nil
 }
@@ -2,6 +2,7 @@ package devicehealth
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -10,8 +11,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
 )
 
 func TestDefaultHealthCheckConfig(t *testing.T) {
@@ -19,6 +22,9 @@ func TestDefaultHealthCheckConfig(t *testing.T) {
 
 	require.NotNil(t, config)
 	assert.Equal(t, 10, config.MaxConcurrent)
+	assert.Equal(t, 10*time.Second, config.CheckTimeout)
+	assert.Equal(t, 15*time.Minute, config.OfflineThreshold)
+	assert.Equal(t, time.Minute, config.SweepInterval)
 }
 
 func TestNewDeviceHealthUseCase(t *testing.T) {
@@ -31,7 +37,7 @@ func TestNewDeviceHealthUseCase(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, loggerFactory)
 
-	uc := NewDeviceHealthUseCase(repo, checker, config, loggerFactory)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, config, loggerFactory)
 
 	require.NotNil(t, uc)
 	impl := uc.(*useCaseImpl)
@@ -46,7 +52,7 @@ func TestNewDeviceHealthUseCase_NilConfig(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
 
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 
 	require.NotNil(t, uc)
 	impl := uc.(*useCaseImpl)
@@ -64,7 +70,7 @@ func TestNewDeviceHealthUseCase_NilLogger(t *testing.T) {
 	checker := &mocks.MockDeviceHealthChecker{}
 	config := DefaultHealthCheckConfig()
 
-	uc := NewDeviceHealthUseCase(repo, checker, config, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, config, nil)
 
 	require.NotNil(t, uc)
 	impl := uc.(*useCaseImpl)
@@ -74,7 +80,7 @@ func TestNewDeviceHealthUseCase_NilLogger(t *testing.T) {
 func TestProcessDeviceDetectedEvent_ValidEvent(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 
 	// Add mock expectations for the goroutine that will be launched
 	device, _ := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Test Device", "192.168.1.100", "Test Location")
@@ -96,18 +102,17 @@ func TestProcessDeviceDetectedEvent_ValidEvent(t *testing.T) {
 func TestProcessDeviceDetectedEvent_NilEvent(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 
 	err := uc.ProcessDeviceDetectedEvent(context.Background(), nil)
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "event cannot be nil")
+	assert.ErrorIs(t, err, domainerrors.ErrMissingInput)
 }
 
 func TestProcessDeviceDetectedEvent_InvalidEvent(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 
 	// Create invalid event with empty MAC address
 	event := &entities.DeviceDetectedEvent{
@@ -123,10 +128,15 @@ func TestProcessDeviceDetectedEvent_InvalidEvent(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid event")
 }
 
+// deviceWithStatus matches the updated device persisted with the status
+func deviceWithStatus(status string) interface{} {
+	return mock.MatchedBy(func(device *entities.Device) bool { return device.GetStatus() == status })
+}
+
 func TestUpdateDeviceStatus_OnlineTransition(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 	impl := uc.(*useCaseImpl)
 
 	// Create a test device
@@ -135,20 +145,66 @@ func TestUpdateDeviceStatus_OnlineTransition(t *testing.T) {
 
 	// Set up repository mocks
 	repo.On("FindByMACAddress", mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil)
-	repo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Device")).Return(nil)
+	repo.On("Update", mock.Anything, deviceWithStatus("online")).Return(nil)
 
 	err = impl.updateDeviceStatus(context.Background(), "AA:BB:CC:DD:EE:FF", true)
 
 	assert.NoError(t, err)
-	assert.Equal(t, "online", device.GetStatus())
+	assert.Equal(t, "registered", device.GetStatus(), "the device read from the repository is not modified")
 
 	repo.AssertExpectations(t)
 }
 
+func TestUpdateDeviceStatus_PublishesOnlineTransition(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Test Device", "192.168.1.100", "Test Location")
+	require.NoError(t, err)
+	device.FarmID = "farm-1"
+
+	t.Run("should publish when a device comes online", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		publisher := mocks.NewMockEventPublisher(t)
+		uc := NewDeviceHealthUseCase(repo, nil, publisher, nil, nil).(*useCaseImpl)
+		uc.now = func() time.Time { return now }
+
+		repo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil).Once()
+		repo.EXPECT().Update(mock.Anything, deviceWithStatus("online")).Return(nil).Once()
+		publisher.EXPECT().IsConnected().Return(true).Once()
+		var published *entities.DeviceOnlineEvent
+		publisher.EXPECT().Publish(mock.Anything, "liwaisi.iot.smart-irrigation.device.online", mock.Anything).Run(func(_ context.Context, _ string, data interface{}) {
+			published = data.(*entities.DeviceOnlineEvent)
+		}).Return(nil).Once()
+
+		require.NoError(t, uc.updateDeviceStatus(context.Background(), "AA:BB:CC:DD:EE:FF", true))
+
+		require.NotNil(t, published)
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", published.MACAddress)
+		assert.Equal(t, "farm-1", published.FarmID)
+		assert.Equal(t, "192.168.1.100", published.IPAddress)
+		assert.Equal(t, now, published.DetectedAt)
+		assert.Equal(t, "device.online", published.EventType)
+	})
+
+	t.Run("should not publish for a device already online", func(t *testing.T) {
+		online, err := device.WithChanges(func(device *entities.Device) error {
+			return device.UpdateStatus("online")
+		})
+		require.NoError(t, err)
+		repo := mocks.NewMockDeviceRepository(t)
+		publisher := mocks.NewMockEventPublisher(t)
+		uc := NewDeviceHealthUseCase(repo, nil, publisher, nil, nil).(*useCaseImpl)
+
+		repo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(online, nil).Once()
+		repo.EXPECT().Update(mock.Anything, deviceWithStatus("online")).Return(nil).Once()
+
+		require.NoError(t, uc.updateDeviceStatus(context.Background(), "AA:BB:CC:DD:EE:FF", true))
+	})
+}
+
 func TestUpdateDeviceStatus_OfflineTransition(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 	impl := uc.(*useCaseImpl)
 
 	// Create a test device
@@ -157,12 +213,12 @@ func TestUpdateDeviceStatus_OfflineTransition(t *testing.T) {
 
 	// Set up repository mocks
 	repo.On("FindByMACAddress", mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil)
-	repo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Device")).Return(nil)
+	repo.On("Update", mock.Anything, deviceWithStatus("offline")).Return(nil)
 
 	err = impl.updateDeviceStatus(context.Background(), "AA:BB:CC:DD:EE:FF", false)
 
 	assert.NoError(t, err)
-	assert.Equal(t, "offline", device.GetStatus())
+	assert.Equal(t, "registered", device.GetStatus(), "the device read from the repository is not modified")
 
 	repo.AssertExpectations(t)
 }
@@ -170,7 +226,7 @@ func TestUpdateDeviceStatus_OfflineTransition(t *testing.T) {
 func TestUpdateDeviceStatus_NilResult(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 	impl := uc.(*useCaseImpl)
 
 	// Create a test device
@@ -179,12 +235,12 @@ func TestUpdateDeviceStatus_NilResult(t *testing.T) {
 
 	// Set up repository mocks
 	repo.On("FindByMACAddress", mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil)
-	repo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Device")).Return(nil)
+	repo.On("Update", mock.Anything, deviceWithStatus("offline")).Return(nil)
 
 	err = impl.updateDeviceStatus(context.Background(), "AA:BB:CC:DD:EE:FF", false)
 
 	assert.NoError(t, err)
-	assert.Equal(t, "offline", device.GetStatus()) // Should default to offline
+	assert.Equal(t, "registered", device.GetStatus(), "the device read from the repository is not modified")
 
 	repo.AssertExpectations(t)
 }
@@ -192,7 +248,7 @@ func TestUpdateDeviceStatus_NilResult(t *testing.T) {
 func TestUpdateDeviceStatus_DeviceNotFound(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 	impl := uc.(*useCaseImpl)
 	// Mock repository returning nil device
 	repo.On("FindByMACAddress", mock.Anything, "AA:BB:CC:DD:EE:FF").Return(nil, nil)
@@ -208,7 +264,7 @@ func TestUpdateDeviceStatus_DeviceNotFound(t *testing.T) {
 func TestUpdateDeviceStatus_RepositoryFindError(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 	impl := uc.(*useCaseImpl)
 
 	// Mock repository returning error
@@ -225,7 +281,7 @@ func TestUpdateDeviceStatus_RepositoryFindError(t *testing.T) {
 func TestUpdateDeviceStatus_RepositoryUpdateError(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 	impl := uc.(*useCaseImpl)
 
 	// Create a test device
@@ -255,7 +311,7 @@ func TestUpdateDeviceStatus_DeviceUpdateStatusError(t *testing.T) {
 func TestPerformHealthCheck_Success(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 	impl := uc.(*useCaseImpl)
 
 	// Create test event
@@ -268,20 +324,20 @@ func TestPerformHealthCheck_Success(t *testing.T) {
 
 	checker.On("CheckHealth", mock.Anything, "192.168.1.100").Return(true, nil)
 	repo.On("FindByMACAddress", mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil)
-	repo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Device")).Return(nil)
+	repo.On("Update", mock.Anything, deviceWithStatus("online")).Return(nil)
 
 	// Test performHealthCheck directly (not through goroutine)
 	impl.performHealthCheck(context.Background(), event)
 
 	checker.AssertExpectations(t)
 	repo.AssertExpectations(t)
-	assert.Equal(t, "online", device.GetStatus())
+	assert.Equal(t, "registered", device.GetStatus(), "the device read from the repository is not modified")
 }
 
 func TestPerformHealthCheck_Failure(t *testing.T) {
 	repo := &mocks.MockDeviceRepository{}
 	checker := &mocks.MockDeviceHealthChecker{}
-	uc := NewDeviceHealthUseCase(repo, checker, nil, nil)
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
 	impl := uc.(*useCaseImpl)
 
 	// Create test event
@@ -295,14 +351,92 @@ func TestPerformHealthCheck_Failure(t *testing.T) {
 	// Mock failed health check
 	checker.On("CheckHealth", mock.Anything, "192.168.1.100").Return(false, nil)
 	repo.On("FindByMACAddress", mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil)
-	repo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Device")).Return(nil)
+	repo.On("Update", mock.Anything, deviceWithStatus("offline")).Return(nil)
 
 	// Test performHealthCheck directly (not through goroutine)
 	impl.performHealthCheck(context.Background(), event)
 
 	checker.AssertExpectations(t)
 	repo.AssertExpectations(t)
-	assert.Equal(t, "offline", device.GetStatus())
+	assert.Equal(t, "registered", device.GetStatus(), "the device read from the repository is not modified")
+}
+
+func TestCheckDevice_Alive(t *testing.T) {
+	repo := &mocks.MockDeviceRepository{}
+	checker := &mocks.MockDeviceHealthChecker{}
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
+
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Test Device", "192.168.1.100", "Test Location")
+	require.NoError(t, err)
+
+	checker.On("CheckHealth", mock.Anything, "192.168.1.100").Return(func(ctx context.Context, _ string) (bool, error) {
+		_, bounded := ctx.Deadline()
+		assert.True(t, bounded, "on-demand checks are bounded by the check timeout")
+		return true, nil
+	})
+	repo.On("FindByMACAddress", mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil)
+	repo.On("Update", mock.Anything, deviceWithStatus("online")).Return(nil)
+
+	probe, err := uc.CheckDevice(context.Background(), "aa:bb:cc:dd:ee:ff")
+
+	require.NoError(t, err)
+	assert.True(t, probe.Alive)
+	assert.Equal(t, "192.168.1.100", probe.IPAddress)
+	assert.Empty(t, probe.Error)
+	assert.False(t, probe.CheckedAt.IsZero())
+	assert.Equal(t, "registered", device.GetStatus(), "the device read from the repository is not modified")
+	checker.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestCheckDevice_CheckError(t *testing.T) {
+	repo := &mocks.MockDeviceRepository{}
+	checker := &mocks.MockDeviceHealthChecker{}
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
+
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Test Device", "192.168.1.100", "Test Location")
+	require.NoError(t, err)
+
+	checker.On("CheckHealth", mock.Anything, "192.168.1.100").Return(false, errors.New("connection refused"))
+	repo.On("FindByMACAddress", mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil)
+	repo.On("Update", mock.Anything, deviceWithStatus("offline")).Return(nil)
+
+	probe, err := uc.CheckDevice(context.Background(), "AA:BB:CC:DD:EE:FF")
+
+	require.NoError(t, err)
+	assert.False(t, probe.Alive)
+	assert.Equal(t, "connection refused", probe.Error)
+	assert.Equal(t, "registered", device.GetStatus(), "the device read from the repository is not modified")
+}
+
+func TestCheckDevice_DeviceNotFound(t *testing.T) {
+	repo := &mocks.MockDeviceRepository{}
+	checker := &mocks.MockDeviceHealthChecker{}
+	uc := NewDeviceHealthUseCase(repo, checker, nil, nil, nil)
+
+	repo.On("FindByMACAddress", mock.Anything, "AA:BB:CC:DD:EE:FF").Return(nil, domainerrors.ErrDeviceNotFound)
+
+	_, err := uc.CheckDevice(context.Background(), "AA:BB:CC:DD:EE:FF")
+
+	assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+	checker.AssertNotCalled(t, "CheckHealth", mock.Anything, mock.Anything)
+}
+
+func TestCheckDevice_NoFreeSlot(t *testing.T) {
+	repo := &mocks.MockDeviceRepository{}
+	checker := &mocks.MockDeviceHealthChecker{}
+	uc := NewDeviceHealthUseCase(repo, checker, nil, &HealthCheckConfig{MaxConcurrent: 1, CheckTimeout: 10 * time.Millisecond}, nil)
+	impl := uc.(*useCaseImpl)
+	impl.semaphore <- struct{}{}
+
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Test Device", "192.168.1.100", "Test Location")
+	require.NoError(t, err)
+	repo.On("FindByMACAddress", mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil)
+
+	_, err = uc.CheckDevice(context.Background(), "AA:BB:CC:DD:EE:FF")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	checker.AssertNotCalled(t, "CheckHealth", mock.Anything, mock.Anything)
 }
 
 func TestSemaphore_ConcurrencyLimiting(t *testing.T) {
@@ -316,3 +450,64 @@ func TestSemaphore_ContextCancellation(t *testing.T) {
 	// This test would need more complex setup to actually test the cancellation behavior effectively.
 	t.Skip("Context cancellation test requires complex setup to block semaphore acquisition")
 }
+
+func TestSweepOffline(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	onlineDevice := func(t *testing.T, macAddress string, lastSeen time.Time) *entities.Device {
+		device, err := entities.NewDevice(macAddress, "Test Device", "192.168.1.100", "Test Location")
+		require.NoError(t, err)
+		device.FarmID = "farm-1"
+		device.Status = "online"
+		device.LastSeen = lastSeen
+		return device
+	}
+
+	t.Run("should mark offline the devices silent beyond the threshold", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		publisher := mocks.NewMockEventPublisher(t)
+		uc := NewDeviceHealthUseCase(repo, nil, publisher, nil, nil).(*useCaseImpl)
+		uc.now = func() time.Time { return now }
+
+		repo.EXPECT().ListByStatus(mock.Anything, "online", 0, pagination.Unlimited).Return([]*entities.Device{
+			onlineDevice(t, "AA:BB:CC:DD:EE:01", now.Add(-20*time.Minute)),
+			onlineDevice(t, "AA:BB:CC:DD:EE:02", now.Add(-5*time.Minute)),
+		}, nil).Once()
+		repo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.GetID() == "AA:BB:CC:DD:EE:01" && device.Status == "offline" && device.LastSeen.Equal(now.Add(-20*time.Minute))
+		})).Return(nil).Once()
+		publisher.EXPECT().IsConnected().Return(true).Once()
+		var published *entities.DeviceOfflineEvent
+		publisher.EXPECT().Publish(mock.Anything, "liwaisi.iot.smart-irrigation.device.offline", mock.Anything).Run(func(_ context.Context, _ string, data interface{}) {
+			published = data.(*entities.DeviceOfflineEvent)
+		}).Return(nil).Once()
+
+		marked, err := uc.SweepOffline(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, marked)
+		require.NotNil(t, published)
+		assert.Equal(t, "AA:BB:CC:DD:EE:01", published.MACAddress)
+		assert.Equal(t, "farm-1", published.FarmID)
+		assert.Equal(t, now.Add(-20*time.Minute), published.LastSeen)
+		assert.Equal(t, now, published.DetectedAt)
+		assert.Equal(t, "device.offline", published.EventType)
+		assert.Equal(t, float64(1), uc.markedOffline.Value())
+	})
+
+	t.Run("should stop at the first device that cannot be updated", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		uc := NewDeviceHealthUseCase(repo, nil, nil, nil, nil).(*useCaseImpl)
+		uc.now = func() time.Time { return now }
+
+		repo.EXPECT().ListByStatus(mock.Anything, "online", 0, pagination.Unlimited).Return([]*entities.Device{
+			onlineDevice(t, "AA:BB:CC:DD:EE:01", now.Add(-time.Hour)),
+			onlineDevice(t, "AA:BB:CC:DD:EE:02", now.Add(-time.Hour)),
+		}, nil).Once()
+		repo.EXPECT().Update(mock.Anything, mock.Anything).Return(errors.New("database down")).Once()
+
+		marked, err := uc.SweepOffline(context.Background())
+
+		assert.ErrorContains(t, err, "failed to mark device AA:BB:CC:DD:EE:01 offline")
+		assert.Zero(t, marked)
+	})
+}
@@ -0,0 +1,117 @@
+package deviceidentity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// DeviceIdentityUseCase binds devices to client certificates and rejects messages from other identities
+type DeviceIdentityUseCase interface {
+	// Verify checks the publisher identity carried by the context against the device's provisioned certificate.
+	// Unknown devices and devices without a provisioned certificate are accepted.
+	Verify(ctx context.Context, macAddress string) error
+
+	// ProvisionCertificate sets the certificate fingerprint of a device; an empty fingerprint removes it
+	ProvisionCertificate(ctx context.Context, macAddress, fingerprint string) (*entities.Device, error)
+}
+
+// useCaseImpl implements the DeviceIdentityUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	loggerFactory logger.LoggerFactory
+	rejections    *metrics.Vec
+}
+
+// NewDeviceIdentityUseCase creates a new device identity use case
+func NewDeviceIdentityUseCase(deviceRepo repositoryports.DeviceRepository, loggerFactory logger.LoggerFactory) DeviceIdentityUseCase {
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		loggerFactory: loggerFactory,
+		rejections:    metrics.NewCounterVec("device_identity_rejections_total", "Messages rejected because the publisher identity did not match the device", "reason"),
+	}
+}
+
+// Verify checks the publisher identity against the device's provisioned certificate
+func (uc *useCaseImpl) Verify(ctx context.Context, macAddress string) error {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to load device identity: %w", err)
+	}
+
+	identity := eventports.ClientIdentityFromContext(ctx)
+	if err := device.VerifyIdentity(identity); err != nil {
+		reason := "unexpected_certificate"
+		if !identity.HasCertificate() {
+			reason = "missing_certificate"
+		}
+		uc.rejections.Inc(reason)
+
+		fields := []zap.Field{
+			zap.String("mac_address", device.GetID()),
+			zap.String("reason", reason),
+			zap.String("component", "device_identity_usecase"),
+		}
+		if identity != nil {
+			fields = append(fields,
+				zap.String("client_id", identity.ClientID),
+				zap.String("username", identity.Username),
+				zap.String("certificate_fingerprint", identity.CertificateFingerprint),
+			)
+		}
+		uc.loggerFactory.Core().Warn("device_identity_rejected", fields...)
+		return fmt.Errorf("%w: %v", domainerrors.ErrDeviceIdentityMismatch, err)
+	}
+	return nil
+}
+
+// ProvisionCertificate sets the certificate fingerprint of a device
+func (uc *useCaseImpl) ProvisionCertificate(ctx context.Context, macAddress, fingerprint string) (*entities.Device, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, err
+	}
+
+	device, err = device.WithChanges(func(device *entities.Device) error {
+		return device.SetCertificateFingerprint(fingerprint)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidCertificateFingerprint, err)
+	}
+	if err := uc.deviceRepo.Update(ctx, device); err != nil {
+		return nil, fmt.Errorf("failed to provision device certificate: %w", err)
+	}
+
+	uc.loggerFactory.Core().Info("device_certificate_provisioned",
+		zap.String("mac_address", device.GetID()),
+		zap.String("certificate_fingerprint", device.CertificateFingerprint),
+		zap.String("component", "device_identity_usecase"),
+	)
+	return device, nil
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return uc.rejections.Collect()
+}
+
+// MetricsCollector returns the device identity metrics collector
+func MetricsCollector(useCase DeviceIdentityUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
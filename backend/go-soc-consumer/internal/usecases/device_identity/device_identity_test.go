@@ -0,0 +1,125 @@
+package deviceidentity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const (
+	testMAC         = "AA:BB:CC:DD:EE:FF"
+	testFingerprint = "5d41402abc4b2a76b9719d911017c5925d41402abc4b2a76b9719d911017c592"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func provisionedDevice(t *testing.T) *entities.Device {
+	device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	require.NoError(t, device.SetCertificateFingerprint(testFingerprint))
+	return device
+}
+
+func TestDeviceIdentityUseCase_Verify(t *testing.T) {
+	t.Run("should accept unknown devices", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceIdentityUseCase(repo, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+		assert.NoError(t, useCase.Verify(context.Background(), "aa:bb:cc:dd:ee:ff"))
+	})
+
+	t.Run("should accept the provisioned certificate", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceIdentityUseCase(repo, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(provisionedDevice(t), nil).Once()
+
+		ctx := eventports.ContextWithClientIdentity(context.Background(), &entities.ClientIdentity{CertificateFingerprint: testFingerprint})
+		assert.NoError(t, useCase.Verify(ctx, testMAC))
+	})
+
+	t.Run("should reject spoofed MACs and count the rejection", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceIdentityUseCase(repo, createTestLoggerFactory(t)).(*useCaseImpl)
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(provisionedDevice(t), nil).Twice()
+
+		err := useCase.Verify(context.Background(), testMAC)
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceIdentityMismatch)
+
+		ctx := eventports.ContextWithClientIdentity(context.Background(), &entities.ClientIdentity{ClientID: "intruder", CertificateFingerprint: "ab" + testFingerprint[2:]})
+		assert.ErrorIs(t, useCase.Verify(ctx, testMAC), domainerrors.ErrDeviceIdentityMismatch)
+
+		assert.Equal(t, float64(1), useCase.rejections.Value("missing_certificate"))
+		assert.Equal(t, float64(1), useCase.rejections.Value("unexpected_certificate"))
+	})
+}
+
+func TestDeviceIdentityUseCase_ProvisionCertificate(t *testing.T) {
+	t.Run("should store normalized fingerprint", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceIdentityUseCase(repo, createTestLoggerFactory(t))
+		device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+		require.NoError(t, err)
+
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+		repo.EXPECT().Update(mock.Anything, mock.AnythingOfType("*entities.Device")).Return(nil).Once()
+
+		updated, err := useCase.ProvisionCertificate(context.Background(), testMAC, "5D:41:40:2A:BC:4B:2A:76:B9:71:9D:91:10:17:C5:92:5D:41:40:2A:BC:4B:2A:76:B9:71:9D:91:10:17:C5:92")
+		require.NoError(t, err)
+		assert.Equal(t, testFingerprint, updated.CertificateFingerprint)
+		assert.Empty(t, device.CertificateFingerprint, "the device read from the repository is not modified")
+	})
+
+	t.Run("should reject invalid fingerprints", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceIdentityUseCase(repo, createTestLoggerFactory(t))
+		device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+		require.NoError(t, err)
+
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+
+		_, err = useCase.ProvisionCertificate(context.Background(), testMAC, "md5:abc")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidCertificateFingerprint)
+	})
+}
+
+func TestVerifyingUseCases(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should not register devices failing verification", func(t *testing.T) {
+		identity := mocks.NewMockDeviceIdentityUseCase(t)
+		inner := mocks.NewMockDeviceRegistrationUseCase(t)
+		useCase := NewVerifyingDeviceRegistrationUseCase(inner, identity)
+		message := &entities.DeviceRegistrationMessage{MACAddress: testMAC}
+
+		identity.EXPECT().Verify(ctx, testMAC).Return(domainerrors.ErrDeviceIdentityMismatch).Once()
+
+		assert.ErrorIs(t, useCase.RegisterDevice(ctx, message), domainerrors.ErrDeviceIdentityMismatch)
+	})
+
+	t.Run("should store readings passing verification", func(t *testing.T) {
+		identity := mocks.NewMockDeviceIdentityUseCase(t)
+		inner := mocks.NewMockSensorDataUseCase(t)
+		useCase := NewVerifyingSensorDataUseCase(inner, identity)
+		reading, err := entities.NewSensorTemperatureHumidity(testMAC, 21.5, 55)
+		require.NoError(t, err)
+
+		identity.EXPECT().Verify(ctx, testMAC).Return(nil).Once()
+		inner.EXPECT().StoreSensorData(ctx, reading).Return(nil).Once()
+
+		assert.NoError(t, useCase.StoreSensorData(ctx, reading))
+	})
+}
@@ -0,0 +1,78 @@
+package deviceidentity
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// verifyingDeviceRegistrationUseCase rejects registrations whose publisher is not the device's provisioned identity
+type verifyingDeviceRegistrationUseCase struct {
+	deviceregistration.DeviceRegistrationUseCase
+	identity DeviceIdentityUseCase
+}
+
+// NewVerifyingDeviceRegistrationUseCase wraps a registration use case with identity verification
+func NewVerifyingDeviceRegistrationUseCase(inner deviceregistration.DeviceRegistrationUseCase, identity DeviceIdentityUseCase) deviceregistration.DeviceRegistrationUseCase {
+	return &verifyingDeviceRegistrationUseCase{DeviceRegistrationUseCase: inner, identity: identity}
+}
+
+// RegisterDevice verifies the publisher before registering
+func (uc *verifyingDeviceRegistrationUseCase) RegisterDevice(ctx context.Context, message *entities.DeviceRegistrationMessage) error {
+	if err := domainerrors.RequireInput("registration message", message); err != nil {
+		return err
+	}
+
+	if err := uc.identity.Verify(ctx, message.MACAddress); err != nil {
+		return err
+	}
+	return uc.DeviceRegistrationUseCase.RegisterDevice(ctx, message)
+}
+
+// verifyingSensorDataUseCase rejects readings whose publisher is not the device's provisioned identity
+type verifyingSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	identity DeviceIdentityUseCase
+}
+
+// NewVerifyingSensorDataUseCase wraps a sensor data use case with identity verification
+func NewVerifyingSensorDataUseCase(inner sensordata.SensorDataUseCase, identity DeviceIdentityUseCase) sensordata.SensorDataUseCase {
+	return &verifyingSensorDataUseCase{SensorDataUseCase: inner, identity: identity}
+}
+
+// StoreSensorData verifies the publisher before storing the reading
+func (uc *verifyingSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if err := domainerrors.RequireInput("sensor data", data); err != nil {
+		return err
+	}
+
+	if err := uc.identity.Verify(ctx, data.MacAddress()); err != nil {
+		return err
+	}
+	return uc.SensorDataUseCase.StoreSensorData(ctx, data)
+}
+
+// verifyingMeasurementUseCase rejects measurements whose publisher is not the device's provisioned identity
+type verifyingMeasurementUseCase struct {
+	measurements.MeasurementUseCase
+	identity DeviceIdentityUseCase
+}
+
+// NewVerifyingMeasurementUseCase wraps a measurement use case with identity verification
+func NewVerifyingMeasurementUseCase(inner measurements.MeasurementUseCase, identity DeviceIdentityUseCase) measurements.MeasurementUseCase {
+	return &verifyingMeasurementUseCase{MeasurementUseCase: inner, identity: identity}
+}
+
+// StoreMeasurements verifies the publisher before storing the measurements, which all come from one device
+func (uc *verifyingMeasurementUseCase) StoreMeasurements(ctx context.Context, values []*entities.Measurement) error {
+	if len(values) > 0 {
+		if err := uc.identity.Verify(ctx, values[0].MACAddress); err != nil {
+			return err
+		}
+	}
+	return uc.MeasurementUseCase.StoreMeasurements(ctx, values)
+}
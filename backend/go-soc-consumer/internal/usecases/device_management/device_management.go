@@ -0,0 +1,212 @@
+package devicemanagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// DeviceManagementUseCase manages the registered devices on behalf of API clients. Devices are
+// otherwise created by their MQTT registrations; those created here are registered the same way.
+type DeviceManagementUseCase interface {
+	// List returns a page of devices, most recently registered first, only those with the status
+	// unless it is empty and only those of the field or zone unless zoneID is empty. An unknown
+	// status fails with ErrInvalidDeviceStatus, an unknown zone with ErrZoneNotFound.
+	List(ctx context.Context, status, zoneID string, offset, limit int) ([]*entities.Device, error)
+
+	// Get returns the device, failing with ErrDeviceNotFound when it is not registered
+	Get(ctx context.Context, macAddress string) (*entities.Device, error)
+
+	// Create registers a device with the MAC address, name, IP address and location of the
+	// configuration, failing with ErrInvalidDevice or ErrDeviceAlreadyExists
+	Create(ctx context.Context, configuration entities.DeviceConfiguration) (*entities.Device, error)
+
+	// Update changes the name, IP address and location of the device; empty fields keep their
+	// current value. It fails with ErrDeviceNotFound or ErrInvalidDevice.
+	Update(ctx context.Context, macAddress string, configuration entities.DeviceConfiguration) (*entities.Device, error)
+
+	// Delete removes the device, failing with ErrDeviceNotFound when it is not registered
+	Delete(ctx context.Context, macAddress string) error
+}
+
+// useCaseImpl implements the DeviceManagementUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	zoneRepo      repositoryports.ZoneRepository
+	loggerFactory logger.LoggerFactory
+}
+
+// NewDeviceManagementUseCase creates a new device management use case
+func NewDeviceManagementUseCase(deviceRepo repositoryports.DeviceRepository, zoneRepo repositoryports.ZoneRepository, loggerFactory logger.LoggerFactory) DeviceManagementUseCase {
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		zoneRepo:      zoneRepo,
+		loggerFactory: loggerFactory,
+	}
+}
+
+// List loads a page of devices, filtered by status and zone when they are given
+func (uc *useCaseImpl) List(ctx context.Context, status, zoneID string, offset, limit int) ([]*entities.Device, error) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status != "" {
+		if err := entities.ValidateDeviceStatus(status); err != nil {
+			return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDeviceStatus, err)
+		}
+	}
+	if zoneID = strings.TrimSpace(zoneID); zoneID != "" {
+		return uc.listByZone(ctx, status, zoneID, offset, limit)
+	}
+	if status == "" {
+		return uc.deviceRepo.List(ctx, offset, limit)
+	}
+	return uc.deviceRepo.ListByStatus(ctx, status, offset, limit)
+}
+
+// listByZone loads the devices of a field or zone and pages through them in memory, as a zone
+// holds few devices
+func (uc *useCaseImpl) listByZone(ctx context.Context, status, zoneID string, offset, limit int) ([]*entities.Device, error) {
+	zone, err := uc.zoneRepo.FindByID(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	macAddresses, err := uc.zoneRepo.DeviceMACAddresses(ctx, zone.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(macAddresses) == 0 {
+		return []*entities.Device{}, nil
+	}
+	devices, err := uc.deviceRepo.FindByMACAddresses(ctx, macAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entities.Device, 0, len(devices))
+	for _, device := range devices {
+		if status == "" || device.Status == status {
+			filtered = append(filtered, device)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		if !filtered[i].RegisteredAt.Equal(filtered[j].RegisteredAt) {
+			return filtered[i].RegisteredAt.After(filtered[j].RegisteredAt)
+		}
+		return filtered[i].MACAddress < filtered[j].MACAddress
+	})
+
+	if offset >= len(filtered) {
+		return []*entities.Device{}, nil
+	}
+	filtered = filtered[offset:]
+	if limit != pagination.Unlimited && limit > 0 && limit < len(filtered) {
+		filtered = filtered[:limit]
+	}
+	return filtered, nil
+}
+
+// Get loads a device by MAC address
+func (uc *useCaseImpl) Get(ctx context.Context, macAddress string) (*entities.Device, error) {
+	macAddress, err := normalizeMACAddress(macAddress)
+	if err != nil {
+		return nil, err
+	}
+	return uc.deviceRepo.FindByMACAddress(ctx, macAddress)
+}
+
+// Create registers a new device
+func (uc *useCaseImpl) Create(ctx context.Context, configuration entities.DeviceConfiguration) (*entities.Device, error) {
+	device, err := entities.NewDevice(configuration.MACAddress, configuration.DeviceName, configuration.IPAddress, configuration.LocationDescription)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDevice, err)
+	}
+
+	exists, err := uc.deviceRepo.Exists(ctx, device.GetID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check device existence: %w", err)
+	}
+	if exists {
+		return nil, domainerrors.ErrDeviceAlreadyExists
+	}
+	if err := uc.deviceRepo.Create(ctx, device); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("device_created_via_api",
+		zap.String("mac_address", device.GetID()),
+		zap.String("component", "device_management_usecase"),
+	)
+	return device, nil
+}
+
+// Update changes the editable fields of a device
+func (uc *useCaseImpl) Update(ctx context.Context, macAddress string, configuration entities.DeviceConfiguration) (*entities.Device, error) {
+	macAddress, err := normalizeMACAddress(macAddress)
+	if err != nil {
+		return nil, err
+	}
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, macAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the fields clients manage are taken; farm, certificate and calibration have their own endpoints
+	changes := entities.DeviceConfiguration{
+		DeviceName:          configuration.DeviceName,
+		IPAddress:           configuration.IPAddress,
+		LocationDescription: configuration.LocationDescription,
+	}
+	updated, err := device.WithChanges(func(device *entities.Device) error {
+		return device.ApplyConfiguration(changes, true)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDevice, err)
+	}
+	if err := uc.deviceRepo.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("device_updated_via_api",
+		zap.String("mac_address", macAddress),
+		zap.String("component", "device_management_usecase"),
+	)
+	return updated, nil
+}
+
+// Delete removes a device
+func (uc *useCaseImpl) Delete(ctx context.Context, macAddress string) error {
+	macAddress, err := normalizeMACAddress(macAddress)
+	if err != nil {
+		return err
+	}
+	if err := uc.deviceRepo.Delete(ctx, macAddress); err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete device: %w", err)
+	}
+
+	uc.loggerFactory.Core().Info("device_deleted_via_api",
+		zap.String("mac_address", macAddress),
+		zap.String("component", "device_management_usecase"),
+	)
+	return nil
+}
+
+// normalizeMACAddress validates a MAC address taken from a request and uppercases it
+func normalizeMACAddress(macAddress string) (string, error) {
+	if err := validation.ValidateMACAddress(macAddress); err != nil {
+		return "", fmt.Errorf("%w: %v", domainerrors.ErrInvalidDevice, err)
+	}
+	return strings.ToUpper(strings.TrimSpace(macAddress)), nil
+}
@@ -0,0 +1,185 @@
+package devicemanagement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+func newTestUseCase(t *testing.T, repo *mocks.MockDeviceRepository) DeviceManagementUseCase {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return NewDeviceManagementUseCase(repo, mocks.NewMockZoneRepository(t), loggerFactory)
+}
+
+func testDevice(t *testing.T) *entities.Device {
+	device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	require.NoError(t, device.AssignFarm("farm-a"))
+	return device
+}
+
+func TestDeviceManagementUseCase_List(t *testing.T) {
+	t.Run("should list every device without a status", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().List(mock.Anything, 20, 10).Return([]*entities.Device{testDevice(t)}, nil).Once()
+
+		devices, err := newTestUseCase(t, repo).List(context.Background(), "", "", 20, 10)
+		require.NoError(t, err)
+		assert.Len(t, devices, 1)
+	})
+
+	t.Run("should filter by status", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().ListByStatus(mock.Anything, "online", 0, 10).Return([]*entities.Device{}, nil).Once()
+
+		_, err := newTestUseCase(t, repo).List(context.Background(), " Online ", "", 0, 10)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject unknown statuses", func(t *testing.T) {
+		_, err := newTestUseCase(t, mocks.NewMockDeviceRepository(t)).List(context.Background(), "sleeping", "", 0, 10)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeviceStatus)
+	})
+
+	t.Run("should page through the devices of a zone", func(t *testing.T) {
+		loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+		require.NoError(t, err)
+		repo := mocks.NewMockDeviceRepository(t)
+		zoneRepo := mocks.NewMockZoneRepository(t)
+		useCase := NewDeviceManagementUseCase(repo, zoneRepo, loggerFactory)
+
+		at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		devices := []*entities.Device{
+			{MACAddress: "AA:BB:CC:DD:EE:01", Status: "online", RegisteredAt: at},
+			{MACAddress: "AA:BB:CC:DD:EE:02", Status: "offline", RegisteredAt: at.Add(time.Hour)},
+			{MACAddress: "AA:BB:CC:DD:EE:03", Status: "online", RegisteredAt: at.Add(2 * time.Hour)},
+		}
+		macAddresses := []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02", "AA:BB:CC:DD:EE:03"}
+		zone := &entities.Zone{ID: "zone-1", FarmID: "farm-a", Kind: entities.ZoneKindZone}
+		zoneRepo.EXPECT().FindByID(mock.Anything, "zone-1").Return(zone, nil).Twice()
+		zoneRepo.EXPECT().DeviceMACAddresses(mock.Anything, "zone-1").Return(macAddresses, nil).Twice()
+		repo.EXPECT().FindByMACAddresses(mock.Anything, macAddresses).Return(devices, nil).Twice()
+
+		page, err := useCase.List(context.Background(), "online", "zone-1", 0, 10)
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, "AA:BB:CC:DD:EE:03", page[0].MACAddress)
+		assert.Equal(t, "AA:BB:CC:DD:EE:01", page[1].MACAddress)
+
+		page, err = useCase.List(context.Background(), "", "zone-1", 1, 1)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, "AA:BB:CC:DD:EE:02", page[0].MACAddress)
+	})
+
+	t.Run("should reject unknown zones", func(t *testing.T) {
+		loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+		require.NoError(t, err)
+		zoneRepo := mocks.NewMockZoneRepository(t)
+		zoneRepo.EXPECT().FindByID(mock.Anything, "missing").Return(nil, domainerrors.ErrZoneNotFound).Once()
+
+		_, err = NewDeviceManagementUseCase(mocks.NewMockDeviceRepository(t), zoneRepo, loggerFactory).List(context.Background(), "", "missing", 0, 10)
+		assert.ErrorIs(t, err, domainerrors.ErrZoneNotFound)
+	})
+}
+
+func TestDeviceManagementUseCase_Create(t *testing.T) {
+	configuration := entities.DeviceConfiguration{
+		MACAddress:          "aa:bb:cc:dd:ee:ff",
+		DeviceName:          "Sensor",
+		IPAddress:           "192.168.1.10",
+		LocationDescription: "Greenhouse",
+	}
+
+	t.Run("should register the device", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().Exists(mock.Anything, testMAC).Return(false, nil).Once()
+		repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.MACAddress == testMAC && device.Status == "registered"
+		})).Return(nil).Once()
+
+		device, err := newTestUseCase(t, repo).Create(context.Background(), configuration)
+		require.NoError(t, err)
+		assert.Equal(t, testMAC, device.MACAddress)
+	})
+
+	t.Run("should reject existing devices", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().Exists(mock.Anything, testMAC).Return(true, nil).Once()
+
+		_, err := newTestUseCase(t, repo).Create(context.Background(), configuration)
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceAlreadyExists)
+	})
+
+	t.Run("should reject invalid devices", func(t *testing.T) {
+		invalid := configuration
+		invalid.IPAddress = "not-an-ip"
+
+		_, err := newTestUseCase(t, mocks.NewMockDeviceRepository(t)).Create(context.Background(), invalid)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDevice)
+	})
+}
+
+func TestDeviceManagementUseCase_Update(t *testing.T) {
+	t.Run("should change only the given fields", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		current := testDevice(t)
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(current, nil).Once()
+		repo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		updated, err := newTestUseCase(t, repo).Update(context.Background(), "aa:bb:cc:dd:ee:ff", entities.DeviceConfiguration{
+			DeviceName: "North valve",
+			FarmID:     "farm-b",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "North valve", updated.DeviceName)
+		assert.Equal(t, "192.168.1.10", updated.IPAddress)
+		assert.Equal(t, "farm-a", updated.FarmID, "the farm is not managed through this use case")
+		assert.Equal(t, "Sensor", current.DeviceName, "the loaded device is left untouched")
+	})
+
+	t.Run("should fail for unknown devices", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+		_, err := newTestUseCase(t, repo).Update(context.Background(), testMAC, entities.DeviceConfiguration{DeviceName: "Valve"})
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+	})
+
+	t.Run("should reject invalid changes", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(testDevice(t), nil).Once()
+
+		_, err := newTestUseCase(t, repo).Update(context.Background(), testMAC, entities.DeviceConfiguration{IPAddress: "999.1.1.1"})
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDevice)
+	})
+}
+
+func TestDeviceManagementUseCase_Delete(t *testing.T) {
+	repo := mocks.NewMockDeviceRepository(t)
+	useCase := newTestUseCase(t, repo)
+
+	repo.EXPECT().Delete(mock.Anything, testMAC).Return(nil).Once()
+	assert.NoError(t, useCase.Delete(context.Background(), "aa:bb:cc:dd:ee:ff"))
+
+	repo.EXPECT().Delete(mock.Anything, testMAC).Return(domainerrors.ErrDeviceNotFound).Once()
+	assert.ErrorIs(t, useCase.Delete(context.Background(), testMAC), domainerrors.ErrDeviceNotFound)
+
+	repo.EXPECT().Delete(mock.Anything, testMAC).Return(errors.New("connection refused")).Once()
+	assert.ErrorContains(t, useCase.Delete(context.Background(), testMAC), "failed to delete device")
+
+	assert.ErrorIs(t, useCase.Delete(context.Background(), "not-a-mac"), domainerrors.ErrInvalidDevice)
+}
@@ -0,0 +1,470 @@
+package devicemigration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	devicecommands "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_commands"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/jobs"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// JobTypeDeviceMigration is the job type publishing the configuration of a migration to its devices
+const JobTypeDeviceMigration = "publish_device_migration"
+
+// waitingRefreshInterval is how often the devices waited on are reloaded, picking up a migration
+// started before a restart or by another instance
+const waitingRefreshInterval = time.Minute
+
+// MigrationConfig holds configuration for moving devices to another broker or topic layout
+type MigrationConfig struct {
+	StragglerAfter  time.Duration // devices not reconnected this long after being sent the configuration are stragglers
+	PublishInterval time.Duration // pause between devices, sparing the broker a reconnect storm
+}
+
+// DefaultMigrationConfig returns default configuration
+func DefaultMigrationConfig() *MigrationConfig {
+	return &MigrationConfig{
+		StragglerAfter:  time.Hour,
+		PublishInterval: 50 * time.Millisecond,
+	}
+}
+
+// DeviceMigrationUseCase moves the fleet to another broker or topic layout. A migration publishes the
+// new broker URL and topic prefix to every device in a background job, then waits for each device to
+// publish under the new layout; devices that take too long are reported as stragglers.
+type DeviceMigrationUseCase interface {
+	// Start creates a migration of the devices of the farm, or of every device when empty, and queues
+	// the job publishing its configuration. It fails with ErrDeviceMigrationRunning while another
+	// migration runs and with ErrInvalidDeviceMigration for an invalid broker URL or layout, or
+	// without devices to migrate.
+	Start(ctx context.Context, brokerURL, layout, farmID, actor string) (*entities.DeviceMigration, error)
+
+	// Get returns a migration, failing with ErrDeviceMigrationNotFound
+	Get(ctx context.Context, id string) (*entities.DeviceMigration, error)
+
+	// List returns up to limit migrations, most recently started first
+	List(ctx context.Context, limit int) ([]*entities.DeviceMigration, error)
+
+	// Report returns the progress of a migration with its stragglers and the devices that could not
+	// be sent the configuration
+	Report(ctx context.Context, id string) (*entities.DeviceMigrationReport, error)
+
+	// Cancel stops a running migration and its job, failing with ErrDeviceMigrationFinished.
+	// Devices already sent the configuration are not moved back.
+	Cancel(ctx context.Context, id string) (*entities.DeviceMigration, error)
+
+	// Observe records a message of the device, marking it reconnected when it was published under
+	// the layout of the running migration
+	Observe(ctx context.Context, macAddress string)
+}
+
+// migrationPayload is the payload of a device migration job
+type migrationPayload struct {
+	MigrationID string `json:"migration_id"`
+}
+
+// migrationResult is the result of a device migration job
+type migrationResult struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
+
+// useCaseImpl implements the DeviceMigrationUseCase interface
+type useCaseImpl struct {
+	migrationRepo repositoryports.DeviceMigrationRepository
+	deviceRepo    repositoryports.DeviceRepository
+	commands      devicecommands.DeviceCommandsUseCase
+	jobQueue      jobs.JobQueueUseCase
+	config        *MigrationConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+
+	startMu sync.Mutex // serializes starts, keeping a single migration running
+
+	mu        sync.Mutex
+	running   *entities.DeviceMigration                  // the running migration, nil when none
+	waiting   map[string]*entities.DeviceMigrationTarget // devices sent the configuration by MAC address
+	refreshAt time.Time                                  // when the running migration is reloaded
+}
+
+// NewDeviceMigrationUseCase creates a new device migration use case and registers its job type with the queue
+func NewDeviceMigrationUseCase(
+	migrationRepo repositoryports.DeviceMigrationRepository,
+	deviceRepo repositoryports.DeviceRepository,
+	commands devicecommands.DeviceCommandsUseCase,
+	jobQueue jobs.JobQueueUseCase,
+	config *MigrationConfig,
+	loggerFactory logger.LoggerFactory,
+) DeviceMigrationUseCase {
+	if config == nil {
+		config = DefaultMigrationConfig()
+	}
+
+	uc := &useCaseImpl{
+		migrationRepo: migrationRepo,
+		deviceRepo:    deviceRepo,
+		commands:      commands,
+		jobQueue:      jobQueue,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		waiting:       make(map[string]*entities.DeviceMigrationTarget),
+	}
+	jobQueue.Register(JobTypeDeviceMigration, uc.runJob)
+	return uc
+}
+
+// Start creates the migration with a target per device and queues its job
+func (uc *useCaseImpl) Start(ctx context.Context, brokerURL, layout, farmID, actor string) (*entities.DeviceMigration, error) {
+	uc.startMu.Lock()
+	defer uc.startMu.Unlock()
+
+	if _, err := uc.migrationRepo.FindRunning(ctx); err == nil {
+		return nil, domainerrors.ErrDeviceMigrationRunning
+	} else if !errors.Is(err, domainerrors.ErrDeviceMigrationNotFound) {
+		return nil, err
+	}
+
+	migration, err := entities.NewDeviceMigration(brokerURL, layout, farmID, actor, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDeviceMigration, err)
+	}
+
+	devices, err := uc.deviceRepo.List(ctx, 0, pagination.Unlimited)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+	targets := make([]*entities.DeviceMigrationTarget, 0, len(devices))
+	for _, device := range devices {
+		target := migration.Target(device)
+		if migration.FarmID != "" && target.FarmID != migration.FarmID {
+			continue
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%w: no devices to migrate", domainerrors.ErrInvalidDeviceMigration)
+	}
+	migration.Devices = len(targets)
+
+	if err := uc.migrationRepo.Create(ctx, migration, targets); err != nil {
+		return nil, err
+	}
+	job, err := uc.jobQueue.Enqueue(ctx, JobTypeDeviceMigration, migrationPayload{MigrationID: migration.ID})
+	if err != nil {
+		// A migration nothing publishes for would block every later one
+		_ = migration.Finish(entities.DeviceMigrationCancelled, uc.now())
+		if updateErr := uc.migrationRepo.Update(ctx, migration); updateErr != nil {
+			uc.loggerFactory.Core().Warn("device_migration_not_cancelled",
+				zap.Error(updateErr),
+				zap.String("migration_id", migration.ID),
+				zap.String("component", "device_migration_usecase"),
+			)
+		}
+		return nil, fmt.Errorf("failed to queue device migration job: %w", err)
+	}
+	migration.JobID = job.ID
+	if err := uc.migrationRepo.Update(ctx, migration); err != nil {
+		return nil, err
+	}
+	uc.watch(migration, nil)
+
+	uc.loggerFactory.Core().Info("device_migration_started",
+		zap.String("migration_id", migration.ID),
+		zap.String("broker_url", migration.BrokerURL),
+		zap.String("layout", migration.Layout),
+		zap.String("farm_id", migration.FarmID),
+		zap.Int("devices", migration.Devices),
+		zap.String("job_id", job.ID),
+		zap.String("actor", migration.Actor),
+		zap.String("component", "device_migration_usecase"),
+	)
+	return migration, nil
+}
+
+// Get returns a migration
+func (uc *useCaseImpl) Get(ctx context.Context, id string) (*entities.DeviceMigration, error) {
+	return uc.migrationRepo.FindByID(ctx, id)
+}
+
+// List returns the latest migrations
+func (uc *useCaseImpl) List(ctx context.Context, limit int) ([]*entities.DeviceMigration, error) {
+	return uc.migrationRepo.List(ctx, limit)
+}
+
+// Report counts the targets of the migration by status and picks out stragglers and failures
+func (uc *useCaseImpl) Report(ctx context.Context, id string) (*entities.DeviceMigrationReport, error) {
+	migration, err := uc.migrationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := uc.migrationRepo.Targets(ctx, id, "")
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	report := &entities.DeviceMigrationReport{
+		Migration:  migration,
+		Counts:     make(map[string]int),
+		Stragglers: []*entities.DeviceMigrationTarget{},
+		Failed:     []*entities.DeviceMigrationTarget{},
+	}
+	for _, target := range targets {
+		report.Counts[target.Status]++
+		switch {
+		case target.Straggling(uc.config.StragglerAfter, now):
+			report.Stragglers = append(report.Stragglers, target)
+		case target.Status == entities.MigrationTargetFailed:
+			report.Failed = append(report.Failed, target)
+		}
+	}
+	return report, nil
+}
+
+// Cancel finishes the migration as cancelled and cancels its job
+func (uc *useCaseImpl) Cancel(ctx context.Context, id string) (*entities.DeviceMigration, error) {
+	migration, err := uc.migrationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := migration.Finish(entities.DeviceMigrationCancelled, uc.now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrDeviceMigrationFinished, err)
+	}
+	if err := uc.migrationRepo.Update(ctx, migration); err != nil {
+		return nil, err
+	}
+	uc.unwatch(migration.ID)
+
+	if migration.JobID != "" {
+		if _, err := uc.jobQueue.Cancel(ctx, migration.JobID); err != nil && !errors.Is(err, domainerrors.ErrJobAlreadyFinished) {
+			uc.loggerFactory.Core().Warn("device_migration_job_not_cancelled",
+				zap.Error(err),
+				zap.String("migration_id", migration.ID),
+				zap.String("job_id", migration.JobID),
+				zap.String("component", "device_migration_usecase"),
+			)
+		}
+	}
+
+	uc.loggerFactory.Core().Info("device_migration_cancelled",
+		zap.String("migration_id", migration.ID),
+		zap.String("component", "device_migration_usecase"),
+	)
+	return migration, nil
+}
+
+// runJob is the job handler of JobTypeDeviceMigration. It publishes the configuration to the targets
+// still pending, so a retried job carries on where the previous attempt stopped.
+func (uc *useCaseImpl) runJob(ctx context.Context, job *entities.Job, progress ports.JobProgressFunc) (interface{}, error) {
+	var payload migrationPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("invalid device migration job payload: %w", err)
+	}
+	migration, err := uc.migrationRepo.FindByID(ctx, payload.MigrationID)
+	if err != nil {
+		return nil, err
+	}
+	result := &migrationResult{}
+	if migration.Status != entities.DeviceMigrationRunning {
+		return result, nil
+	}
+
+	targets, err := uc.migrationRepo.Targets(ctx, migration.ID, entities.MigrationTargetPending)
+	if err != nil {
+		return nil, err
+	}
+	for i, target := range targets {
+		if i > 0 && uc.config.PublishInterval > 0 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(uc.config.PublishInterval):
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		command, err := uc.commands.Send(ctx, target.MACAddress, entities.DeviceCommandRequest{
+			Command:     entities.DeviceCommandMigrate,
+			BrokerURL:   migration.BrokerURL,
+			TopicPrefix: migration.TopicPrefix(target.FarmID),
+		})
+		if err != nil {
+			target.Failed(err)
+			result.Failed++
+			uc.loggerFactory.Core().Warn("device_migration_send_failed",
+				zap.Error(err),
+				zap.String("migration_id", migration.ID),
+				zap.String("mac_address", target.MACAddress),
+				zap.String("component", "device_migration_usecase"),
+			)
+		} else {
+			target.Sent(command.ID, uc.now())
+			result.Sent++
+		}
+		if err := uc.migrationRepo.UpdateTarget(ctx, target); err != nil {
+			return result, err
+		}
+		if target.Status == entities.MigrationTargetSent {
+			uc.watch(migration, target)
+		}
+
+		if progress != nil {
+			progress((i+1)*100/len(targets), fmt.Sprintf("published the configuration to %d of %d devices", i+1, len(targets)))
+		}
+	}
+
+	uc.completeIfDone(ctx, migration.ID)
+	return result, nil
+}
+
+// Observe marks the device reconnected when it waits on the running migration and the message came
+// in under the new layout
+func (uc *useCaseImpl) Observe(ctx context.Context, macAddress string) {
+	macAddress = strings.ToUpper(strings.TrimSpace(macAddress))
+
+	uc.mu.Lock()
+	if !uc.now().Before(uc.refreshAt) {
+		uc.refresh(ctx)
+	}
+	target, ok := uc.waiting[macAddress]
+	if !ok || !target.ReconnectedUnder(uc.running.Layout, eventports.FarmIDFromContext(ctx)) {
+		uc.mu.Unlock()
+		return
+	}
+	delete(uc.waiting, macAddress)
+	remaining := len(uc.waiting)
+	uc.mu.Unlock()
+
+	marked, err := uc.migrationRepo.MarkReconnected(ctx, target.MigrationID, macAddress, uc.now())
+	if err != nil {
+		uc.loggerFactory.Core().Warn("device_migration_reconnect_not_recorded",
+			zap.Error(err),
+			zap.String("migration_id", target.MigrationID),
+			zap.String("mac_address", macAddress),
+			zap.String("component", "device_migration_usecase"),
+		)
+		return
+	}
+	if !marked {
+		return
+	}
+
+	uc.loggerFactory.Core().Info("device_migration_device_reconnected",
+		zap.String("migration_id", target.MigrationID),
+		zap.String("mac_address", macAddress),
+		zap.Int("waiting", remaining),
+		zap.String("component", "device_migration_usecase"),
+	)
+	if remaining == 0 {
+		uc.completeIfDone(ctx, target.MigrationID)
+	}
+}
+
+// refresh reloads the running migration and the devices it waits on; it must be called with mu held
+func (uc *useCaseImpl) refresh(ctx context.Context) {
+	uc.refreshAt = uc.now().Add(waitingRefreshInterval)
+
+	migration, err := uc.migrationRepo.FindRunning(ctx)
+	if errors.Is(err, domainerrors.ErrDeviceMigrationNotFound) {
+		uc.running, uc.waiting = nil, make(map[string]*entities.DeviceMigrationTarget)
+		return
+	}
+	var targets []*entities.DeviceMigrationTarget
+	if err == nil {
+		targets, err = uc.migrationRepo.Targets(ctx, migration.ID, entities.MigrationTargetSent)
+	}
+	if err != nil {
+		uc.loggerFactory.Core().Warn("device_migration_refresh_failed",
+			zap.Error(err),
+			zap.String("component", "device_migration_usecase"),
+		)
+		return
+	}
+
+	uc.running, uc.waiting = migration, make(map[string]*entities.DeviceMigrationTarget, len(targets))
+	for _, target := range targets {
+		uc.waiting[target.MACAddress] = target
+	}
+}
+
+// watch makes the migration the running one and waits on the target, when set, to reconnect
+func (uc *useCaseImpl) watch(migration *entities.DeviceMigration, target *entities.DeviceMigrationTarget) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.running == nil || uc.running.ID != migration.ID {
+		uc.running, uc.waiting = migration, make(map[string]*entities.DeviceMigrationTarget)
+	}
+	if target != nil {
+		uc.waiting[target.MACAddress] = target
+	}
+}
+
+// unwatch stops waiting on the devices of the migration
+func (uc *useCaseImpl) unwatch(id string) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.running != nil && uc.running.ID == id {
+		uc.running, uc.waiting = nil, make(map[string]*entities.DeviceMigrationTarget)
+	}
+}
+
+// completeIfDone completes the migration once no device is left to publish to or wait on
+func (uc *useCaseImpl) completeIfDone(ctx context.Context, id string) {
+	targets, err := uc.migrationRepo.Targets(ctx, id, "")
+	if err != nil {
+		uc.loggerFactory.Core().Warn("device_migration_completion_check_failed",
+			zap.Error(err),
+			zap.String("migration_id", id),
+			zap.String("component", "device_migration_usecase"),
+		)
+		return
+	}
+	counts := make(map[string]int)
+	for _, target := range targets {
+		counts[target.Status]++
+	}
+	if counts[entities.MigrationTargetPending] > 0 || counts[entities.MigrationTargetSent] > 0 {
+		return
+	}
+
+	migration, err := uc.migrationRepo.FindByID(ctx, id)
+	if err != nil || migration.Finish(entities.DeviceMigrationCompleted, uc.now()) != nil {
+		return
+	}
+	if err := uc.migrationRepo.Update(ctx, migration); err != nil {
+		uc.loggerFactory.Core().Warn("device_migration_not_completed",
+			zap.Error(err),
+			zap.String("migration_id", id),
+			zap.String("component", "device_migration_usecase"),
+		)
+		return
+	}
+	uc.unwatch(id)
+
+	uc.loggerFactory.Core().Info("device_migration_completed",
+		zap.String("migration_id", id),
+		zap.Int("reconnected", counts[entities.MigrationTargetReconnected]),
+		zap.Int("failed", counts[entities.MigrationTargetFailed]),
+		zap.Int("skipped", counts[entities.MigrationTargetSkipped]),
+		zap.String("component", "device_migration_usecase"),
+	)
+}
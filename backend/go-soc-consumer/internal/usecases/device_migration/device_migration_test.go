@@ -0,0 +1,246 @@
+package devicemigration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+type testDeps struct {
+	migrationRepo *mocks.MockDeviceMigrationRepository
+	deviceRepo    *mocks.MockDeviceRepository
+	commands      *mocks.MockDeviceCommandsUseCase
+	jobQueue      *mocks.MockJobQueueUseCase
+}
+
+// newTestUseCase creates the use case with a job queue expecting the job type registration
+func newTestUseCase(t *testing.T) (*useCaseImpl, *testDeps) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	deps := &testDeps{
+		migrationRepo: mocks.NewMockDeviceMigrationRepository(t),
+		deviceRepo:    mocks.NewMockDeviceRepository(t),
+		commands:      mocks.NewMockDeviceCommandsUseCase(t),
+		jobQueue:      mocks.NewMockJobQueueUseCase(t),
+	}
+	deps.jobQueue.EXPECT().Register(JobTypeDeviceMigration, mock.Anything).Once()
+	useCase := NewDeviceMigrationUseCase(deps.migrationRepo, deps.deviceRepo, deps.commands, deps.jobQueue,
+		&MigrationConfig{StragglerAfter: time.Hour}, loggerFactory).(*useCaseImpl)
+	useCase.now = func() time.Time { return testNow }
+	return useCase, deps
+}
+
+func TestDeviceMigrationUseCase_Start(t *testing.T) {
+	devices := []*entities.Device{
+		{MACAddress: "AA:BB:CC:DD:EE:01", FarmID: "finca-norte"},
+		{MACAddress: "AA:BB:CC:DD:EE:02", FarmID: "finca-sur"},
+		{MACAddress: "AA:BB:CC:DD:EE:03"},
+	}
+
+	t.Run("should create the migration and queue its job", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.migrationRepo.EXPECT().FindRunning(mock.Anything).Return(nil, domainerrors.ErrDeviceMigrationNotFound).Once()
+		deps.deviceRepo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return(devices, nil).Once()
+		deps.migrationRepo.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, migration *entities.DeviceMigration, targets []*entities.DeviceMigrationTarget) error {
+				require.Len(t, targets, 3)
+				assert.Equal(t, entities.MigrationTargetPending, targets[0].Status)
+				assert.Equal(t, entities.MigrationTargetSkipped, targets[2].Status)
+				return nil
+			}).Once()
+		deps.jobQueue.EXPECT().Enqueue(mock.Anything, JobTypeDeviceMigration, mock.Anything).Return(&entities.Job{ID: "job-1"}, nil).Once()
+		deps.migrationRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(m *entities.DeviceMigration) bool {
+			return m.JobID == "job-1" && m.Status == entities.DeviceMigrationRunning
+		})).Return(nil).Once()
+
+		migration, err := useCase.Start(context.Background(), "tcp://broker.example.com:1883", "farm", "", "maria")
+		require.NoError(t, err)
+		assert.Equal(t, 3, migration.Devices)
+		assert.Equal(t, "job-1", migration.JobID)
+	})
+
+	t.Run("should migrate the devices of the farm only", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.migrationRepo.EXPECT().FindRunning(mock.Anything).Return(nil, domainerrors.ErrDeviceMigrationNotFound).Once()
+		deps.deviceRepo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return(devices, nil).Once()
+		deps.migrationRepo.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, migration *entities.DeviceMigration, targets []*entities.DeviceMigrationTarget) error {
+				require.Len(t, targets, 1)
+				assert.Equal(t, "AA:BB:CC:DD:EE:02", targets[0].MACAddress)
+				return nil
+			}).Once()
+		deps.jobQueue.EXPECT().Enqueue(mock.Anything, JobTypeDeviceMigration, mock.Anything).Return(&entities.Job{ID: "job-1"}, nil).Once()
+		deps.migrationRepo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := useCase.Start(context.Background(), "tcp://broker.example.com:1883", "farm", "finca-sur", "maria")
+		require.NoError(t, err)
+	})
+
+	t.Run("should refuse while another migration runs", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.migrationRepo.EXPECT().FindRunning(mock.Anything).Return(&entities.DeviceMigration{ID: "migration-0"}, nil).Once()
+
+		_, err := useCase.Start(context.Background(), "tcp://broker.example.com:1883", "farm", "", "maria")
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceMigrationRunning)
+	})
+
+	t.Run("should reject an invalid broker url", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.migrationRepo.EXPECT().FindRunning(mock.Anything).Return(nil, domainerrors.ErrDeviceMigrationNotFound).Once()
+
+		_, err := useCase.Start(context.Background(), "https://broker.example.com", "farm", "", "maria")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeviceMigration)
+	})
+
+	t.Run("should cancel the migration when its job cannot be queued", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.migrationRepo.EXPECT().FindRunning(mock.Anything).Return(nil, domainerrors.ErrDeviceMigrationNotFound).Once()
+		deps.deviceRepo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return(devices, nil).Once()
+		deps.migrationRepo.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		deps.jobQueue.EXPECT().Enqueue(mock.Anything, JobTypeDeviceMigration, mock.Anything).Return(nil, errors.New("queue full")).Once()
+		deps.migrationRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(m *entities.DeviceMigration) bool {
+			return m.Status == entities.DeviceMigrationCancelled
+		})).Return(nil).Once()
+
+		_, err := useCase.Start(context.Background(), "tcp://broker.example.com:1883", "shared", "", "maria")
+		assert.ErrorContains(t, err, "queue full")
+	})
+}
+
+func TestDeviceMigrationUseCase_RunJob(t *testing.T) {
+	useCase, deps := newTestUseCase(t)
+	migration := &entities.DeviceMigration{ID: "migration-1", BrokerURL: "tcp://broker.example.com:1883", Layout: entities.TopicLayoutFarm, Status: entities.DeviceMigrationRunning}
+	payload, err := json.Marshal(migrationPayload{MigrationID: "migration-1"})
+	require.NoError(t, err)
+
+	deps.migrationRepo.EXPECT().FindByID(mock.Anything, "migration-1").Return(migration, nil).Once()
+	deps.migrationRepo.EXPECT().Targets(mock.Anything, "migration-1", entities.MigrationTargetPending).Return([]*entities.DeviceMigrationTarget{
+		{MigrationID: "migration-1", MACAddress: "AA:BB:CC:DD:EE:01", FarmID: "finca-norte", Status: entities.MigrationTargetPending},
+		{MigrationID: "migration-1", MACAddress: "AA:BB:CC:DD:EE:02", FarmID: "finca-sur", Status: entities.MigrationTargetPending},
+	}, nil).Once()
+	deps.commands.EXPECT().Send(mock.Anything, "AA:BB:CC:DD:EE:01", entities.DeviceCommandRequest{
+		Command: entities.DeviceCommandMigrate, BrokerURL: "tcp://broker.example.com:1883", TopicPrefix: "farms/finca-norte/devices",
+	}).Return(&entities.DeviceCommand{ID: "cmd-1"}, nil).Once()
+	deps.commands.EXPECT().Send(mock.Anything, "AA:BB:CC:DD:EE:02", mock.Anything).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+	deps.migrationRepo.EXPECT().UpdateTarget(mock.Anything, mock.MatchedBy(func(target *entities.DeviceMigrationTarget) bool {
+		return target.MACAddress == "AA:BB:CC:DD:EE:01" && target.Status == entities.MigrationTargetSent && target.CommandID == "cmd-1"
+	})).Return(nil).Once()
+	deps.migrationRepo.EXPECT().UpdateTarget(mock.Anything, mock.MatchedBy(func(target *entities.DeviceMigrationTarget) bool {
+		return target.MACAddress == "AA:BB:CC:DD:EE:02" && target.Status == entities.MigrationTargetFailed
+	})).Return(nil).Once()
+	// The device sent the configuration has not reconnected yet
+	deps.migrationRepo.EXPECT().Targets(mock.Anything, "migration-1", "").Return([]*entities.DeviceMigrationTarget{
+		{MACAddress: "AA:BB:CC:DD:EE:01", Status: entities.MigrationTargetSent},
+		{MACAddress: "AA:BB:CC:DD:EE:02", Status: entities.MigrationTargetFailed},
+	}, nil).Once()
+
+	var percents []int
+	result, err := useCase.runJob(context.Background(), &entities.Job{Payload: payload}, func(percent int, message string) {
+		percents = append(percents, percent)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &migrationResult{Sent: 1, Failed: 1}, result)
+	assert.Equal(t, []int{50, 100}, percents)
+	assert.Contains(t, useCase.waiting, "AA:BB:CC:DD:EE:01")
+}
+
+func TestDeviceMigrationUseCase_Observe(t *testing.T) {
+	migration := &entities.DeviceMigration{ID: "migration-1", Layout: entities.TopicLayoutFarm, Status: entities.DeviceMigrationRunning}
+	sentAt := testNow.Add(-time.Minute)
+	waiting := func() []*entities.DeviceMigrationTarget {
+		return []*entities.DeviceMigrationTarget{
+			{MigrationID: "migration-1", MACAddress: "AA:BB:CC:DD:EE:01", FarmID: "finca-norte", Status: entities.MigrationTargetSent, SentAt: &sentAt},
+		}
+	}
+
+	t.Run("should wait on devices still publishing on the shared topics", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.migrationRepo.EXPECT().FindRunning(mock.Anything).Return(migration, nil).Once()
+		deps.migrationRepo.EXPECT().Targets(mock.Anything, "migration-1", entities.MigrationTargetSent).Return(waiting(), nil).Once()
+
+		useCase.Observe(context.Background(), "aa:bb:cc:dd:ee:01")
+		assert.Contains(t, useCase.waiting, "AA:BB:CC:DD:EE:01")
+	})
+
+	t.Run("should complete the migration once the last device reconnects", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.migrationRepo.EXPECT().FindRunning(mock.Anything).Return(migration, nil).Once()
+		deps.migrationRepo.EXPECT().Targets(mock.Anything, "migration-1", entities.MigrationTargetSent).Return(waiting(), nil).Once()
+		deps.migrationRepo.EXPECT().MarkReconnected(mock.Anything, "migration-1", "AA:BB:CC:DD:EE:01", testNow).Return(true, nil).Once()
+		deps.migrationRepo.EXPECT().Targets(mock.Anything, "migration-1", "").Return([]*entities.DeviceMigrationTarget{
+			{MACAddress: "AA:BB:CC:DD:EE:01", Status: entities.MigrationTargetReconnected},
+		}, nil).Once()
+		deps.migrationRepo.EXPECT().FindByID(mock.Anything, "migration-1").Return(&entities.DeviceMigration{ID: "migration-1", Status: entities.DeviceMigrationRunning}, nil).Once()
+		deps.migrationRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(m *entities.DeviceMigration) bool {
+			return m.Status == entities.DeviceMigrationCompleted
+		})).Return(nil).Once()
+
+		useCase.Observe(eventports.ContextWithFarmID(context.Background(), "finca-norte"), "AA:BB:CC:DD:EE:01")
+		assert.Nil(t, useCase.running)
+	})
+
+	t.Run("should not reload the running migration before the refresh interval", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.migrationRepo.EXPECT().FindRunning(mock.Anything).Return(nil, domainerrors.ErrDeviceMigrationNotFound).Once()
+
+		useCase.Observe(context.Background(), "AA:BB:CC:DD:EE:01")
+		useCase.Observe(context.Background(), "AA:BB:CC:DD:EE:01")
+	})
+}
+
+func TestDeviceMigrationUseCase_Report(t *testing.T) {
+	useCase, deps := newTestUseCase(t)
+	longAgo, recently := testNow.Add(-2*time.Hour), testNow.Add(-time.Minute)
+	deps.migrationRepo.EXPECT().FindByID(mock.Anything, "migration-1").Return(&entities.DeviceMigration{ID: "migration-1"}, nil).Once()
+	deps.migrationRepo.EXPECT().Targets(mock.Anything, "migration-1", "").Return([]*entities.DeviceMigrationTarget{
+		{MACAddress: "AA:BB:CC:DD:EE:01", Status: entities.MigrationTargetSent, SentAt: &longAgo},
+		{MACAddress: "AA:BB:CC:DD:EE:02", Status: entities.MigrationTargetSent, SentAt: &recently},
+		{MACAddress: "AA:BB:CC:DD:EE:03", Status: entities.MigrationTargetReconnected, SentAt: &longAgo},
+		{MACAddress: "AA:BB:CC:DD:EE:04", Status: entities.MigrationTargetFailed, Error: "device not found"},
+	}, nil).Once()
+
+	report, err := useCase.Report(context.Background(), "migration-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"sent": 2, "reconnected": 1, "failed": 1}, report.Counts)
+	require.Len(t, report.Stragglers, 1)
+	assert.Equal(t, "AA:BB:CC:DD:EE:01", report.Stragglers[0].MACAddress)
+	require.Len(t, report.Failed, 1)
+	assert.Equal(t, "AA:BB:CC:DD:EE:04", report.Failed[0].MACAddress)
+}
+
+func TestDeviceMigrationUseCase_Cancel(t *testing.T) {
+	t.Run("should cancel the migration and its job", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.migrationRepo.EXPECT().FindByID(mock.Anything, "migration-1").Return(&entities.DeviceMigration{ID: "migration-1", Status: entities.DeviceMigrationRunning, JobID: "job-1"}, nil).Once()
+		deps.migrationRepo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+		deps.jobQueue.EXPECT().Cancel(mock.Anything, "job-1").Return(nil, domainerrors.ErrJobAlreadyFinished).Once()
+
+		migration, err := useCase.Cancel(context.Background(), "migration-1")
+		require.NoError(t, err)
+		assert.Equal(t, entities.DeviceMigrationCancelled, migration.Status)
+	})
+
+	t.Run("should refuse finished migrations", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.migrationRepo.EXPECT().FindByID(mock.Anything, "migration-1").Return(&entities.DeviceMigration{ID: "migration-1", Status: entities.DeviceMigrationCompleted}, nil).Once()
+
+		_, err := useCase.Cancel(context.Background(), "migration-1")
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceMigrationFinished)
+	})
+}
@@ -0,0 +1,69 @@
+package devicemigration
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// trackedDeviceRegistrationUseCase reports every registration to the migration
+type trackedDeviceRegistrationUseCase struct {
+	deviceregistration.DeviceRegistrationUseCase
+	migrations DeviceMigrationUseCase
+}
+
+// NewTrackedDeviceRegistrationUseCase wraps a registration use case with migration tracking
+func NewTrackedDeviceRegistrationUseCase(inner deviceregistration.DeviceRegistrationUseCase, migrations DeviceMigrationUseCase) deviceregistration.DeviceRegistrationUseCase {
+	return &trackedDeviceRegistrationUseCase{DeviceRegistrationUseCase: inner, migrations: migrations}
+}
+
+// RegisterDevice observes the device before registering it. Replayed registrations tell nothing
+// about the topics the device uses now.
+func (uc *trackedDeviceRegistrationUseCase) RegisterDevice(ctx context.Context, message *entities.DeviceRegistrationMessage) error {
+	if message != nil && !eventports.IsReplay(ctx) {
+		uc.migrations.Observe(ctx, message.MACAddress)
+	}
+	return uc.DeviceRegistrationUseCase.RegisterDevice(ctx, message)
+}
+
+// trackedSensorDataUseCase reports every reading to the migration
+type trackedSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	migrations DeviceMigrationUseCase
+}
+
+// NewTrackedSensorDataUseCase wraps a sensor data use case with migration tracking
+func NewTrackedSensorDataUseCase(inner sensordata.SensorDataUseCase, migrations DeviceMigrationUseCase) sensordata.SensorDataUseCase {
+	return &trackedSensorDataUseCase{SensorDataUseCase: inner, migrations: migrations}
+}
+
+// StoreSensorData observes the device before storing the reading, skipping replays
+func (uc *trackedSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if data != nil && !eventports.IsReplay(ctx) {
+		uc.migrations.Observe(ctx, data.MacAddress())
+	}
+	return uc.SensorDataUseCase.StoreSensorData(ctx, data)
+}
+
+// trackedMeasurementUseCase reports every batch of measurements to the migration
+type trackedMeasurementUseCase struct {
+	measurements.MeasurementUseCase
+	migrations DeviceMigrationUseCase
+}
+
+// NewTrackedMeasurementUseCase wraps a measurement use case with migration tracking
+func NewTrackedMeasurementUseCase(inner measurements.MeasurementUseCase, migrations DeviceMigrationUseCase) measurements.MeasurementUseCase {
+	return &trackedMeasurementUseCase{MeasurementUseCase: inner, migrations: migrations}
+}
+
+// StoreMeasurements observes the device of the batch before storing it, skipping replays
+func (uc *trackedMeasurementUseCase) StoreMeasurements(ctx context.Context, batch []*entities.Measurement) error {
+	if len(batch) > 0 && !eventports.IsReplay(ctx) {
+		uc.migrations.Observe(ctx, batch[0].MACAddress)
+	}
+	return uc.MeasurementUseCase.StoreMeasurements(ctx, batch)
+}
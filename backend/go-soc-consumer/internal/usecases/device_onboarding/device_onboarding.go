@@ -0,0 +1,440 @@
+package deviceonboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// OnboardingConfig holds configuration for device onboarding
+type OnboardingConfig struct {
+	BrokerURL       string        // broker URL handed to devices in the provisioning payload
+	TokenTTL        time.Duration // how long a provisioning token is accepted
+	RefreshInterval time.Duration // how often the pending onboardings are reloaded from the database
+}
+
+// DefaultOnboardingConfig returns default configuration
+func DefaultOnboardingConfig() *OnboardingConfig {
+	return &OnboardingConfig{
+		TokenTTL:        24 * time.Hour,
+		RefreshInterval: 30 * time.Second,
+	}
+}
+
+// DeviceOnboardingUseCase guides an installer through bringing a new device online: the
+// onboarding is created with a provisioning payload for the device, and its steps complete as the
+// device registers with its token, sends its first readings and is placed by the installer.
+// Registrations and readings are matched against the pending onboardings kept in memory, so
+// devices already onboarded cost no database access.
+type DeviceOnboardingUseCase interface {
+	// Create starts the onboarding of a device that is not registered yet and returns it with the
+	// provisioning payload, which holds the only copy of the token
+	Create(ctx context.Context, request entities.OnboardingRequest) (*entities.DeviceOnboarding, *entities.ProvisioningPayload, error)
+
+	// Get returns an onboarding
+	Get(ctx context.Context, id string) (*entities.DeviceOnboarding, error)
+
+	// List returns the onboardings not assigned yet, oldest first
+	List(ctx context.Context) ([]*entities.DeviceOnboarding, error)
+
+	// RenewToken replaces the provisioning token of an onboarding and returns the new payload
+	RenewToken(ctx context.Context, id string) (*entities.DeviceOnboarding, *entities.ProvisioningPayload, error)
+
+	// MarkCredentialsDelivered records that the installer handed the payload to the device
+	MarkCredentialsDelivered(ctx context.Context, id string) (*entities.DeviceOnboarding, error)
+
+	// Assign records where the installer placed the device, completing the onboarding. The device
+	// must have sent its first readings; its provisioning token becomes its broker password.
+	Assign(ctx context.Context, id, locationDescription string) (*entities.DeviceOnboarding, error)
+
+	// Delete cancels an onboarding
+	Delete(ctx context.Context, id string) error
+
+	// RecordConnection completes the first connection step of the pending onboarding of the device
+	// when the token is its provisioning token
+	RecordConnection(ctx context.Context, macAddress, token string) error
+
+	// RecordTelemetry completes the first telemetry step of the pending onboarding of the device,
+	// once it is connected
+	RecordTelemetry(ctx context.Context, macAddress string) error
+
+	// Run reloads the pending onboardings periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// pendingOnboarding is what the hot path needs to know about a pending onboarding
+type pendingOnboarding struct {
+	id        string
+	connected bool
+	reporting bool
+}
+
+// useCaseImpl implements the DeviceOnboardingUseCase interface
+type useCaseImpl struct {
+	onboardingRepo repositoryports.DeviceOnboardingRepository
+	deviceRepo     repositoryports.DeviceRepository
+	config         *OnboardingConfig
+	loggerFactory  logger.LoggerFactory
+	now            func() time.Time
+
+	mu      sync.RWMutex
+	pending map[string]pendingOnboarding // by MAC address
+
+	steps           *metrics.Vec
+	tokenRejections *metrics.Vec
+}
+
+// NewDeviceOnboardingUseCase creates a new device onboarding use case
+func NewDeviceOnboardingUseCase(
+	onboardingRepo repositoryports.DeviceOnboardingRepository,
+	deviceRepo repositoryports.DeviceRepository,
+	config *OnboardingConfig,
+	loggerFactory logger.LoggerFactory,
+) DeviceOnboardingUseCase {
+	if config == nil {
+		config = DefaultOnboardingConfig()
+	}
+
+	return &useCaseImpl{
+		onboardingRepo:  onboardingRepo,
+		deviceRepo:      deviceRepo,
+		config:          config,
+		loggerFactory:   loggerFactory,
+		now:             time.Now,
+		pending:         make(map[string]pendingOnboarding),
+		steps:           metrics.NewCounterVec("device_onboarding_steps_total", "Onboarding steps completed", "step"),
+		tokenRejections: metrics.NewCounterVec("device_onboarding_token_rejections_total", "Registrations of devices being onboarded presenting a wrong or expired token", "reason"),
+	}
+}
+
+// Create rejects devices already registered or being onboarded
+func (uc *useCaseImpl) Create(ctx context.Context, request entities.OnboardingRequest) (*entities.DeviceOnboarding, *entities.ProvisioningPayload, error) {
+	onboarding, token, err := entities.NewDeviceOnboarding(request, uc.config.TokenTTL, uc.now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidOnboarding, err)
+	}
+
+	exists, err := uc.deviceRepo.Exists(ctx, onboarding.MACAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exists {
+		return nil, nil, fmt.Errorf("%w: %s is registered", domainerrors.ErrOnboardingExists, onboarding.MACAddress)
+	}
+	pending, err := uc.onboardingRepo.ListPending(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, candidate := range pending {
+		if candidate.MACAddress == onboarding.MACAddress {
+			return nil, nil, fmt.Errorf("%w: %s is being onboarded", domainerrors.ErrOnboardingExists, onboarding.MACAddress)
+		}
+	}
+
+	if err := uc.onboardingRepo.Create(ctx, onboarding); err != nil {
+		return nil, nil, err
+	}
+	uc.track(onboarding)
+
+	uc.loggerFactory.Core().Info("device_onboarding_created",
+		zap.String("onboarding_id", onboarding.ID),
+		zap.String("mac_address", onboarding.MACAddress),
+		zap.String("farm_id", onboarding.FarmID),
+		zap.String("component", "device_onboarding_usecase"),
+	)
+	return onboarding, onboarding.ProvisioningPayload(uc.config.BrokerURL, token), nil
+}
+
+// Get returns an onboarding
+func (uc *useCaseImpl) Get(ctx context.Context, id string) (*entities.DeviceOnboarding, error) {
+	return uc.onboardingRepo.FindByID(ctx, id)
+}
+
+// List returns the pending onboardings
+func (uc *useCaseImpl) List(ctx context.Context) ([]*entities.DeviceOnboarding, error) {
+	return uc.onboardingRepo.ListPending(ctx)
+}
+
+// RenewToken replaces the token of a device that has not connected yet
+func (uc *useCaseImpl) RenewToken(ctx context.Context, id string) (*entities.DeviceOnboarding, *entities.ProvisioningPayload, error) {
+	onboarding, err := uc.onboardingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if onboarding.FirstConnectedAt != nil {
+		return nil, nil, fmt.Errorf("%w: the device already connected", domainerrors.ErrOnboardingStepNotReady)
+	}
+
+	token, err := onboarding.RenewToken(uc.config.TokenTTL, uc.now())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.onboardingRepo.Update(ctx, onboarding); err != nil {
+		return nil, nil, err
+	}
+	return onboarding, onboarding.ProvisioningPayload(uc.config.BrokerURL, token), nil
+}
+
+// MarkCredentialsDelivered completes the first step; marking it again changes nothing
+func (uc *useCaseImpl) MarkCredentialsDelivered(ctx context.Context, id string) (*entities.DeviceOnboarding, error) {
+	onboarding, err := uc.onboardingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding.CredentialsDeliveredAt != nil {
+		return onboarding, nil
+	}
+
+	now := uc.now().UTC()
+	onboarding.CredentialsDeliveredAt = &now
+	onboarding.UpdatedAt = now
+	if err := uc.onboardingRepo.Update(ctx, onboarding); err != nil {
+		return nil, err
+	}
+	uc.completed(onboarding, entities.OnboardingStepCredentialsDelivered)
+	return onboarding, nil
+}
+
+// Assign applies the location to the device and completes the onboarding
+func (uc *useCaseImpl) Assign(ctx context.Context, id, locationDescription string) (*entities.DeviceOnboarding, error) {
+	onboarding, err := uc.onboardingRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding.AssignedAt != nil {
+		return onboarding, nil
+	}
+	if onboarding.FirstTelemetryAt == nil {
+		return nil, fmt.Errorf("%w: the device has not sent readings yet", domainerrors.ErrOnboardingStepNotReady)
+	}
+
+	locationDescription = strings.TrimSpace(locationDescription)
+	if locationDescription == "" {
+		locationDescription = onboarding.LocationDescription
+	}
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, onboarding.MACAddress)
+	if err != nil {
+		return nil, err
+	}
+	device, err = device.WithChanges(func(device *entities.Device) error {
+		device.LocationDescription = locationDescription
+		device.CredentialHash = onboarding.TokenHash
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidOnboarding, err)
+	}
+	if err := uc.deviceRepo.Update(ctx, device); err != nil {
+		return nil, err
+	}
+
+	now := uc.now().UTC()
+	onboarding.LocationDescription = locationDescription
+	onboarding.AssignedAt = &now
+	onboarding.UpdatedAt = now
+	if err := uc.onboardingRepo.Update(ctx, onboarding); err != nil {
+		return nil, err
+	}
+	uc.untrack(onboarding.MACAddress)
+	uc.completed(onboarding, entities.OnboardingStepAssigned)
+	return onboarding, nil
+}
+
+// Delete cancels an onboarding
+func (uc *useCaseImpl) Delete(ctx context.Context, id string) error {
+	onboarding, err := uc.onboardingRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.onboardingRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.untrack(onboarding.MACAddress)
+	return nil
+}
+
+// RecordConnection verifies the token of a device being onboarded. A wrong or expired token is
+// counted and logged; the registration itself is not refused, as the device is not known yet.
+func (uc *useCaseImpl) RecordConnection(ctx context.Context, macAddress, token string) error {
+	pending, ok := uc.lookup(macAddress)
+	if !ok || pending.connected {
+		return nil
+	}
+
+	onboarding, err := uc.onboardingRepo.FindByID(ctx, pending.id)
+	if err != nil {
+		return err
+	}
+	now := uc.now()
+	if !onboarding.VerifyToken(token, now) {
+		reason := "mismatch"
+		switch {
+		case token == "":
+			reason = "missing"
+		case !now.Before(onboarding.TokenExpiresAt):
+			reason = "expired"
+		}
+		uc.tokenRejections.Inc(reason)
+		uc.loggerFactory.Core().Warn("device_onboarding_token_rejected",
+			zap.String("onboarding_id", onboarding.ID),
+			zap.String("mac_address", onboarding.MACAddress),
+			zap.String("reason", reason),
+			zap.String("component", "device_onboarding_usecase"),
+		)
+		return nil
+	}
+
+	connectedAt := now.UTC()
+	if onboarding.CredentialsDeliveredAt == nil {
+		onboarding.CredentialsDeliveredAt = &connectedAt
+	}
+	onboarding.FirstConnectedAt = &connectedAt
+	onboarding.UpdatedAt = connectedAt
+	if err := uc.onboardingRepo.Update(ctx, onboarding); err != nil {
+		return err
+	}
+	uc.track(onboarding)
+	uc.completed(onboarding, entities.OnboardingStepFirstConnection)
+	return nil
+}
+
+// RecordTelemetry completes the first telemetry step of a connected device
+func (uc *useCaseImpl) RecordTelemetry(ctx context.Context, macAddress string) error {
+	pending, ok := uc.lookup(macAddress)
+	if !ok || !pending.connected || pending.reporting {
+		return nil
+	}
+
+	onboarding, err := uc.onboardingRepo.FindByID(ctx, pending.id)
+	if err != nil {
+		return err
+	}
+	if onboarding.FirstTelemetryAt == nil {
+		reportedAt := uc.now().UTC()
+		onboarding.FirstTelemetryAt = &reportedAt
+		onboarding.UpdatedAt = reportedAt
+		if err := uc.onboardingRepo.Update(ctx, onboarding); err != nil {
+			return err
+		}
+		uc.completed(onboarding, entities.OnboardingStepFirstTelemetry)
+	}
+	uc.track(onboarding)
+	return nil
+}
+
+// Run reloads the pending onboardings, picking up the changes made by other instances
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("device_onboarding_started", "device_onboarding_usecase",
+		zap.Duration("refresh_interval", uc.config.RefreshInterval),
+	)
+
+	ticker := time.NewTicker(uc.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := uc.refresh(ctx); err != nil && ctx.Err() == nil {
+			uc.loggerFactory.Core().Error("device_onboarding_refresh_failed",
+				zap.Error(err),
+				zap.String("component", "device_onboarding_usecase"),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("device_onboarding_stopped", "device_onboarding_usecase")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh replaces the pending onboardings kept in memory
+func (uc *useCaseImpl) refresh(ctx context.Context) error {
+	onboardings, err := uc.onboardingRepo.ListPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := make(map[string]pendingOnboarding, len(onboardings))
+	for _, onboarding := range onboardings {
+		pending[onboarding.MACAddress] = newPendingOnboarding(onboarding)
+	}
+	uc.mu.Lock()
+	uc.pending = pending
+	uc.mu.Unlock()
+	return nil
+}
+
+func newPendingOnboarding(onboarding *entities.DeviceOnboarding) pendingOnboarding {
+	return pendingOnboarding{
+		id:        onboarding.ID,
+		connected: onboarding.FirstConnectedAt != nil,
+		reporting: onboarding.FirstTelemetryAt != nil,
+	}
+}
+
+func (uc *useCaseImpl) lookup(macAddress string) (pendingOnboarding, bool) {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	pending, ok := uc.pending[strings.ToUpper(macAddress)]
+	return pending, ok
+}
+
+func (uc *useCaseImpl) track(onboarding *entities.DeviceOnboarding) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.pending[onboarding.MACAddress] = newPendingOnboarding(onboarding)
+}
+
+func (uc *useCaseImpl) untrack(macAddress string) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	delete(uc.pending, macAddress)
+}
+
+// completed counts and logs a completed step
+func (uc *useCaseImpl) completed(onboarding *entities.DeviceOnboarding, step string) {
+	uc.steps.Inc(step)
+	uc.loggerFactory.Core().Info("device_onboarding_step_completed",
+		zap.String("onboarding_id", onboarding.ID),
+		zap.String("mac_address", onboarding.MACAddress),
+		zap.String("step", step),
+		zap.String("component", "device_onboarding_usecase"),
+	)
+}
+
+// logFailure logs a failure to record a step; the message that triggered it is handled anyway
+func logFailure(loggerFactory logger.LoggerFactory, macAddress string, err error) {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	loggerFactory.Core().Error("device_onboarding_step_failed",
+		zap.String("mac_address", macAddress),
+		zap.Error(err),
+		zap.String("component", "device_onboarding_usecase"),
+	)
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return append(uc.steps.Collect(), uc.tokenRejections.Collect()...)
+}
+
+// MetricsCollector returns the device onboarding metrics collector
+func MetricsCollector(useCase DeviceOnboardingUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
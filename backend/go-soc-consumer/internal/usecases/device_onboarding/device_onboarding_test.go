@@ -0,0 +1,182 @@
+package deviceonboarding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestUseCase(t *testing.T) (*useCaseImpl, *mocks.MockDeviceOnboardingRepository, *mocks.MockDeviceRepository, *time.Time) {
+	onboardingRepo := mocks.NewMockDeviceOnboardingRepository(t)
+	deviceRepo := mocks.NewMockDeviceRepository(t)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase := NewDeviceOnboardingUseCase(onboardingRepo, deviceRepo, &OnboardingConfig{
+		BrokerURL: "tcp://broker:1883", TokenTTL: time.Hour, RefreshInterval: time.Minute,
+	}, createTestLoggerFactory(t)).(*useCaseImpl)
+	useCase.now = func() time.Time { return now }
+	return useCase, onboardingRepo, deviceRepo, &now
+}
+
+// createOnboarding creates an onboarding tracked by the use case and returns it with its token
+func createOnboarding(t *testing.T, useCase *useCaseImpl, onboardingRepo *mocks.MockDeviceOnboardingRepository, deviceRepo *mocks.MockDeviceRepository) (*entities.DeviceOnboarding, string) {
+	deviceRepo.EXPECT().Exists(mock.Anything, testMAC).Return(false, nil).Once()
+	onboardingRepo.EXPECT().ListPending(mock.Anything).Return(nil, nil).Once()
+	onboardingRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+
+	onboarding, payload, err := useCase.Create(context.Background(), entities.OnboardingRequest{
+		MACAddress: "aa:bb:cc:dd:ee:ff", DeviceName: "Sensor", LocationDescription: "Greenhouse", FarmID: "farm-1",
+	})
+	require.NoError(t, err)
+	return onboarding, payload.Token
+}
+
+func TestDeviceOnboardingUseCase_Create(t *testing.T) {
+	t.Run("should return the provisioning payload", func(t *testing.T) {
+		useCase, onboardingRepo, deviceRepo, _ := newTestUseCase(t)
+		onboarding, token := createOnboarding(t, useCase, onboardingRepo, deviceRepo)
+
+		assert.Equal(t, testMAC, onboarding.MACAddress)
+		assert.Equal(t, entities.OnboardingStepCredentialsDelivered, onboarding.CurrentStep())
+		assert.True(t, onboarding.VerifyToken(token, useCase.now()))
+		payload := onboarding.ProvisioningPayload("tcp://broker:1883", token)
+		assert.Equal(t, "farms/farm-1/devices", payload.TopicPrefix)
+	})
+
+	t.Run("should reject devices already registered or being onboarded", func(t *testing.T) {
+		useCase, onboardingRepo, deviceRepo, _ := newTestUseCase(t)
+		request := entities.OnboardingRequest{MACAddress: testMAC, DeviceName: "Sensor"}
+
+		deviceRepo.EXPECT().Exists(mock.Anything, testMAC).Return(true, nil).Once()
+		_, _, err := useCase.Create(context.Background(), request)
+		assert.ErrorIs(t, err, domainerrors.ErrOnboardingExists)
+
+		deviceRepo.EXPECT().Exists(mock.Anything, testMAC).Return(false, nil).Once()
+		onboardingRepo.EXPECT().ListPending(mock.Anything).Return([]*entities.DeviceOnboarding{{ID: "other", MACAddress: testMAC}}, nil).Once()
+		_, _, err = useCase.Create(context.Background(), request)
+		assert.ErrorIs(t, err, domainerrors.ErrOnboardingExists)
+	})
+
+	t.Run("should reject invalid requests", func(t *testing.T) {
+		useCase, _, _, _ := newTestUseCase(t)
+		_, _, err := useCase.Create(context.Background(), entities.OnboardingRequest{MACAddress: "not-a-mac", DeviceName: "Sensor"})
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidOnboarding)
+	})
+}
+
+func TestDeviceOnboardingUseCase_Steps(t *testing.T) {
+	t.Run("should complete the steps as the device connects and reports", func(t *testing.T) {
+		useCase, onboardingRepo, deviceRepo, _ := newTestUseCase(t)
+		onboarding, token := createOnboarding(t, useCase, onboardingRepo, deviceRepo)
+		onboardingRepo.EXPECT().FindByID(mock.Anything, onboarding.ID).Return(onboarding, nil)
+		onboardingRepo.EXPECT().Update(mock.Anything, onboarding).Return(nil).Twice()
+
+		// Readings before the connection are not the onboarded device's
+		require.NoError(t, useCase.RecordTelemetry(context.Background(), testMAC))
+		assert.Nil(t, onboarding.FirstTelemetryAt)
+
+		require.NoError(t, useCase.RecordConnection(context.Background(), testMAC, token))
+		require.NotNil(t, onboarding.FirstConnectedAt)
+		assert.NotNil(t, onboarding.CredentialsDeliveredAt)
+
+		require.NoError(t, useCase.RecordTelemetry(context.Background(), testMAC))
+		require.NoError(t, useCase.RecordTelemetry(context.Background(), testMAC))
+		assert.Equal(t, entities.OnboardingStepAssigned, onboarding.CurrentStep())
+	})
+
+	t.Run("should not connect with a wrong or expired token", func(t *testing.T) {
+		useCase, onboardingRepo, deviceRepo, now := newTestUseCase(t)
+		onboarding, token := createOnboarding(t, useCase, onboardingRepo, deviceRepo)
+		onboardingRepo.EXPECT().FindByID(mock.Anything, onboarding.ID).Return(onboarding, nil)
+
+		require.NoError(t, useCase.RecordConnection(context.Background(), testMAC, "wrong"))
+		*now = now.Add(time.Hour)
+		require.NoError(t, useCase.RecordConnection(context.Background(), testMAC, token))
+		assert.Nil(t, onboarding.FirstConnectedAt)
+	})
+
+	t.Run("should ignore devices not being onboarded", func(t *testing.T) {
+		useCase, _, _, _ := newTestUseCase(t)
+		assert.NoError(t, useCase.RecordConnection(context.Background(), testMAC, ""))
+		assert.NoError(t, useCase.RecordTelemetry(context.Background(), testMAC))
+	})
+}
+
+func TestDeviceOnboardingUseCase_Assign(t *testing.T) {
+	t.Run("should require the first readings", func(t *testing.T) {
+		useCase, onboardingRepo, _, _ := newTestUseCase(t)
+		onboardingRepo.EXPECT().FindByID(mock.Anything, "onboarding-1").Return(&entities.DeviceOnboarding{ID: "onboarding-1", MACAddress: testMAC}, nil).Once()
+
+		_, err := useCase.Assign(context.Background(), "onboarding-1", "Row 4")
+		assert.ErrorIs(t, err, domainerrors.ErrOnboardingStepNotReady)
+	})
+
+	t.Run("should place the device, keep its token as broker password and complete the onboarding", func(t *testing.T) {
+		useCase, onboardingRepo, deviceRepo, now := newTestUseCase(t)
+		reportedAt := now.Add(-time.Minute)
+		onboarding := &entities.DeviceOnboarding{
+			ID: "onboarding-1", MACAddress: testMAC, LocationDescription: "Greenhouse",
+			CredentialsDeliveredAt: &reportedAt, FirstConnectedAt: &reportedAt, FirstTelemetryAt: &reportedAt,
+		}
+		token, err := onboarding.RenewToken(time.Hour, *now)
+		require.NoError(t, err)
+		device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Pending")
+		require.NoError(t, err)
+		onboardingRepo.EXPECT().FindByID(mock.Anything, "onboarding-1").Return(onboarding, nil).Once()
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+		deviceRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(updated *entities.Device) bool {
+			return updated.LocationDescription == "Row 4" && updated.VerifyCredential(token) && !updated.VerifyCredential("wrong")
+		})).Return(nil).Once()
+		onboardingRepo.EXPECT().Update(mock.Anything, onboarding).Return(nil).Once()
+
+		assigned, err := useCase.Assign(context.Background(), "onboarding-1", " Row 4 ")
+		require.NoError(t, err)
+		assert.True(t, assigned.Completed())
+		assert.Equal(t, "Row 4", assigned.LocationDescription)
+	})
+}
+
+func TestDeviceOnboardingUseCase_Refresh(t *testing.T) {
+	useCase, onboardingRepo, _, _ := newTestUseCase(t)
+	connectedAt := time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC)
+	onboarding := &entities.DeviceOnboarding{ID: "onboarding-1", MACAddress: testMAC, FirstConnectedAt: &connectedAt}
+	onboardingRepo.EXPECT().ListPending(mock.Anything).Return([]*entities.DeviceOnboarding{onboarding}, nil).Once()
+	onboardingRepo.EXPECT().FindByID(mock.Anything, "onboarding-1").Return(onboarding, nil).Once()
+	onboardingRepo.EXPECT().Update(mock.Anything, onboarding).Return(nil).Once()
+
+	require.NoError(t, useCase.refresh(context.Background()))
+	require.NoError(t, useCase.RecordTelemetry(context.Background(), testMAC))
+	assert.NotNil(t, onboarding.FirstTelemetryAt)
+}
+
+func TestOnboardingDeviceRegistrationUseCase(t *testing.T) {
+	inner := mocks.NewMockDeviceRegistrationUseCase(t)
+	onboarding := mocks.NewMockDeviceOnboardingUseCase(t)
+	useCase := NewOnboardingDeviceRegistrationUseCase(inner, onboarding, createTestLoggerFactory(t))
+	message, err := entities.NewDeviceRegistrationMessage(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	message.ProvisioningToken = "token"
+
+	inner.EXPECT().RegisterDevice(mock.Anything, message).Return(nil).Once()
+	onboarding.EXPECT().RecordConnection(mock.Anything, testMAC, "token").Return(assert.AnError).Once()
+	assert.NoError(t, useCase.RegisterDevice(context.Background(), message))
+
+	inner.EXPECT().RegisterDevice(mock.Anything, message).Return(assert.AnError).Once()
+	assert.ErrorIs(t, useCase.RegisterDevice(context.Background(), message), assert.AnError)
+}
@@ -0,0 +1,76 @@
+package deviceonboarding
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// onboardingDeviceRegistrationUseCase records the first connection of devices being onboarded
+type onboardingDeviceRegistrationUseCase struct {
+	deviceregistration.DeviceRegistrationUseCase
+	onboarding    DeviceOnboardingUseCase
+	loggerFactory logger.LoggerFactory
+}
+
+// NewOnboardingDeviceRegistrationUseCase wraps a registration use case with onboarding tracking
+func NewOnboardingDeviceRegistrationUseCase(inner deviceregistration.DeviceRegistrationUseCase, onboarding DeviceOnboardingUseCase, loggerFactory logger.LoggerFactory) deviceregistration.DeviceRegistrationUseCase {
+	return &onboardingDeviceRegistrationUseCase{DeviceRegistrationUseCase: inner, onboarding: onboarding, loggerFactory: loggerFactory}
+}
+
+// RegisterDevice records the connection once the device is registered
+func (uc *onboardingDeviceRegistrationUseCase) RegisterDevice(ctx context.Context, message *entities.DeviceRegistrationMessage) error {
+	if err := uc.DeviceRegistrationUseCase.RegisterDevice(ctx, message); err != nil {
+		return err
+	}
+	logFailure(uc.loggerFactory, message.MACAddress, uc.onboarding.RecordConnection(ctx, message.MACAddress, message.ProvisioningToken))
+	return nil
+}
+
+// onboardingSensorDataUseCase records the first readings of devices being onboarded
+type onboardingSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	onboarding    DeviceOnboardingUseCase
+	loggerFactory logger.LoggerFactory
+}
+
+// NewOnboardingSensorDataUseCase wraps a sensor data use case with onboarding tracking
+func NewOnboardingSensorDataUseCase(inner sensordata.SensorDataUseCase, onboarding DeviceOnboardingUseCase, loggerFactory logger.LoggerFactory) sensordata.SensorDataUseCase {
+	return &onboardingSensorDataUseCase{SensorDataUseCase: inner, onboarding: onboarding, loggerFactory: loggerFactory}
+}
+
+// StoreSensorData records the readings once they are stored
+func (uc *onboardingSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if err := uc.SensorDataUseCase.StoreSensorData(ctx, data); err != nil {
+		return err
+	}
+	logFailure(uc.loggerFactory, data.MacAddress(), uc.onboarding.RecordTelemetry(ctx, data.MacAddress()))
+	return nil
+}
+
+// onboardingMeasurementUseCase records the first measurements of devices being onboarded
+type onboardingMeasurementUseCase struct {
+	measurements.MeasurementUseCase
+	onboarding    DeviceOnboardingUseCase
+	loggerFactory logger.LoggerFactory
+}
+
+// NewOnboardingMeasurementUseCase wraps a measurement use case with onboarding tracking
+func NewOnboardingMeasurementUseCase(inner measurements.MeasurementUseCase, onboarding DeviceOnboardingUseCase, loggerFactory logger.LoggerFactory) measurements.MeasurementUseCase {
+	return &onboardingMeasurementUseCase{MeasurementUseCase: inner, onboarding: onboarding, loggerFactory: loggerFactory}
+}
+
+// StoreMeasurements records the measurements once they are stored; they all come from one device
+func (uc *onboardingMeasurementUseCase) StoreMeasurements(ctx context.Context, values []*entities.Measurement) error {
+	if err := uc.MeasurementUseCase.StoreMeasurements(ctx, values); err != nil {
+		return err
+	}
+	if len(values) > 0 {
+		logFailure(uc.loggerFactory, values[0].MACAddress, uc.onboarding.RecordTelemetry(ctx, values[0].MACAddress))
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
 	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
@@ -36,6 +37,10 @@ func NewDeviceRegistrationUseCase(deviceRepo repositoryports.DeviceRepository, e
 
 // RegisterDevice processes a device registration message
 func (uc *useCaseImpl) RegisterDevice(ctx context.Context, message *entities.DeviceRegistrationMessage) error {
+	if err := domainerrors.RequireInput("registration message", message); err != nil {
+		return err
+	}
+
 	start := time.Now()
 
 	uc.loggerFactory.Core().Info("device_registration_started",
@@ -48,6 +53,16 @@ func (uc *useCaseImpl) RegisterDevice(ctx context.Context, message *entities.Dev
 
 	// Check if device already exists
 	existingDevice, err := uc.deviceRepo.FindByMACAddress(ctx, message.MACAddress)
+	if err == nil && existingDevice != nil && eventports.IsReplay(ctx) && !existingDevice.LastSeen.Before(message.ReceivedAt) {
+		// A replayed registration must not undo what the device reported since
+		uc.loggerFactory.Core().Debug("replayed_registration_superseded",
+			zap.String("mac_address", message.MACAddress),
+			zap.Time("received_at", message.ReceivedAt),
+			zap.Time("last_seen", existingDevice.LastSeen),
+			zap.String("component", "device_registration_usecase"),
+		)
+		return nil
+	}
 	if err == nil && existingDevice != nil {
 		// Device exists, update it
 		uc.loggerFactory.Core().Debug("existing_device_found_for_update",
@@ -129,42 +144,66 @@ func (uc *useCaseImpl) createNewDevice(ctx context.Context, message *entities.De
 
 // updateExistingDevice updates an existing device with new information
 func (uc *useCaseImpl) updateExistingDevice(ctx context.Context, existingDevice *entities.Device, message *entities.DeviceRegistrationMessage) error {
-	// Update device information
-	existingDevice.SetDeviceName(message.DeviceName)
-	existingDevice.SetIPAddress(message.IPAddress)
-	existingDevice.LocationDescription = message.LocationDescription
-	existingDevice.LastSeen = message.ReceivedAt
-
-	// Update status to online when device registers again
-	if err := existingDevice.UpdateStatus("online"); err != nil {
-		return fmt.Errorf("failed to update device status: %w", err)
-	}
+	// Apply the registration to a copy so the device read from the repository is never modified
+	updatedDevice, err := existingDevice.WithChanges(func(device *entities.Device) error {
+		device.SetDeviceName(message.DeviceName)
+		device.SetIPAddress(message.IPAddress)
+		device.LocationDescription = message.LocationDescription
+
+		// Keep the last reported versions when the firmware stops reporting them
+		if message.FirmwareVersion != "" {
+			device.FirmwareVersion = message.FirmwareVersion
+		}
+		if message.HardwareVersion != "" {
+			device.HardwareVersion = message.HardwareVersion
+		}
 
-	// Validate updated device
-	if err := existingDevice.Validate(); err != nil {
-		return fmt.Errorf("updated device validation failed: %w", err)
+		// Bind devices registering in a farm namespace to that farm
+		if message.FarmID != "" {
+			if err := device.AssignFarm(message.FarmID); err != nil {
+				return fmt.Errorf("%w: %v", domainerrors.ErrDeviceFarmMismatch, err)
+			}
+		}
+
+		// Update status to online when device registers again
+		if err := device.UpdateStatus("online"); err != nil {
+			return fmt.Errorf("failed to update device status: %w", err)
+		}
+		if eventports.IsReplay(ctx) {
+			device.LastSeen = message.ReceivedAt
+		}
+
+		// Keep the broker metadata of the last live registration for debugging
+		if message.Broker != nil {
+			broker := *message.Broker
+			device.LastRegistration = &broker
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Update existing device
-	if err := uc.deviceRepo.Update(ctx, existingDevice); err != nil {
+	if err := uc.deviceRepo.Update(ctx, updatedDevice); err != nil {
 		uc.loggerFactory.Core().Error("failed_to_update_existing_device",
 			zap.Error(err),
-			zap.String("mac_address", existingDevice.GetID()),
-			zap.String("device_name", existingDevice.GetDeviceName()),
+			zap.String("mac_address", updatedDevice.GetID()),
+			zap.String("device_name", updatedDevice.GetDeviceName()),
 			zap.String("component", "device_registration_usecase"),
 		)
 		return fmt.Errorf("failed to update existing device: %w", err)
 	}
 
 	uc.loggerFactory.Core().Info("existing_device_updated_successfully",
-		zap.String("mac_address", existingDevice.GetID()),
-		zap.String("device_name", existingDevice.GetDeviceName()),
-		zap.String("ip_address", existingDevice.GetIPAddress()),
+		zap.String("mac_address", updatedDevice.GetID()),
+		zap.String("device_name", updatedDevice.GetDeviceName()),
+		zap.String("ip_address", updatedDevice.GetIPAddress()),
 		zap.String("component", "device_registration_usecase"),
 	)
 
 	// Publish device detected event AFTER successful database operation
-	uc.publishDeviceDetectedEvent(ctx, existingDevice.GetID(), existingDevice.GetIPAddress())
+	uc.publishDeviceDetectedEvent(ctx, updatedDevice.GetID(), updatedDevice.GetIPAddress())
 
 	return nil
 }
@@ -172,6 +211,11 @@ func (uc *useCaseImpl) updateExistingDevice(ctx context.Context, existingDevice
 // publishDeviceDetectedEvent publishes a device detected event
 // This method logs errors but does not return them to avoid breaking the registration flow
 func (uc *useCaseImpl) publishDeviceDetectedEvent(ctx context.Context, macAddress, ipAddress string) {
+	// Replayed registrations were announced when first received
+	if eventports.IsReplay(ctx) {
+		return
+	}
+
 	// Skip if no event publisher is configured
 	if uc.eventPublisher == nil {
 		uc.loggerFactory.Core().Warn("no_event_publisher_configured",
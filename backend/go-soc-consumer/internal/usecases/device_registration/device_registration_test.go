@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
 )
@@ -230,6 +232,115 @@ func TestUseCase_RegisterDevice_ExistingDevice(t *testing.T) {
 	}
 }
 
+func TestUseCase_RegisterDevice_Replay(t *testing.T) {
+	receivedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	message := &entities.DeviceRegistrationMessage{
+		MACAddress:          "AA:BB:CC:DD:EE:FF",
+		DeviceName:          "Replayed Device",
+		IPAddress:           "192.168.1.101",
+		LocationDescription: "Garden Zone 2",
+		ReceivedAt:          receivedAt,
+	}
+	ctx := eventports.ContextWithReplay(context.Background(), receivedAt)
+
+	t.Run("applies a registration newer than the stored one", func(t *testing.T) {
+		mockRepo := mocks.NewMockDeviceRepository(t)
+		mockRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").
+			Return(&entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", DeviceName: "Old Device", IPAddress: "192.168.1.100", LastSeen: receivedAt.Add(-time.Hour), Status: "offline"}, nil).
+			Once()
+		mockRepo.EXPECT().
+			Update(mock.Anything, mock.MatchedBy(func(device *entities.Device) bool {
+				return device.DeviceName == "Replayed Device" && device.LastSeen.Equal(receivedAt)
+			})).
+			Return(nil).
+			Once()
+
+		useCase := NewDeviceRegistrationUseCase(mockRepo, nil, createTestLoggerFactory(t))
+
+		assert.NoError(t, useCase.RegisterDevice(ctx, message))
+	})
+
+	t.Run("skips a registration superseded since", func(t *testing.T) {
+		mockRepo := mocks.NewMockDeviceRepository(t)
+		mockRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").
+			Return(&entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", DeviceName: "Current Device", IPAddress: "192.168.1.100", LastSeen: receivedAt.Add(time.Hour), Status: "online"}, nil).
+			Once()
+
+		useCase := NewDeviceRegistrationUseCase(mockRepo, nil, createTestLoggerFactory(t))
+
+		assert.NoError(t, useCase.RegisterDevice(ctx, message))
+	})
+}
+
+func TestUseCase_RegisterDevice_Versions(t *testing.T) {
+	message := &entities.DeviceRegistrationMessage{
+		MACAddress:          "AA:BB:CC:DD:EE:FF",
+		DeviceName:          "Probe",
+		IPAddress:           "192.168.1.101",
+		LocationDescription: "Garden Zone 2",
+		ReceivedAt:          time.Now(),
+		FirmwareVersion:     "1.5.0",
+	}
+
+	t.Run("stores the reported versions of a new device", func(t *testing.T) {
+		mockRepo := mocks.NewMockDeviceRepository(t)
+		mockRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(nil, errors.New("not found")).Once()
+		mockRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.FirmwareVersion == "1.5.0" && device.HardwareVersion == ""
+		})).Return(nil).Once()
+
+		assert.NoError(t, NewDeviceRegistrationUseCase(mockRepo, nil, createTestLoggerFactory(t)).RegisterDevice(context.Background(), message))
+	})
+
+	t.Run("keeps the versions the device no longer reports", func(t *testing.T) {
+		mockRepo := mocks.NewMockDeviceRepository(t)
+		mockRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").
+			Return(&entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", DeviceName: "Probe", IPAddress: "192.168.1.100", LocationDescription: "Garden Zone 2", Status: "online", FirmwareVersion: "1.4.2", HardwareVersion: "rev-b"}, nil).
+			Once()
+		mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.FirmwareVersion == "1.5.0" && device.HardwareVersion == "rev-b"
+		})).Return(nil).Once()
+
+		assert.NoError(t, NewDeviceRegistrationUseCase(mockRepo, nil, createTestLoggerFactory(t)).RegisterDevice(context.Background(), message))
+	})
+}
+
+func TestUseCase_RegisterDevice_BrokerMetadata(t *testing.T) {
+	previous := &entities.BrokerMetadata{Topic: "/liwaisi/iot/smart-irrigation/device/registration", QoS: 0, ClientID: "probe-old"}
+	existing := func() *entities.Device {
+		return &entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", DeviceName: "Probe", IPAddress: "192.168.1.100", LocationDescription: "Garden Zone 2", Status: "online", LastRegistration: previous}
+	}
+	message := &entities.DeviceRegistrationMessage{
+		MACAddress:          "AA:BB:CC:DD:EE:FF",
+		DeviceName:          "Probe",
+		IPAddress:           "192.168.1.101",
+		LocationDescription: "Garden Zone 2",
+		ReceivedAt:          time.Now(),
+	}
+
+	t.Run("records the metadata of a live registration", func(t *testing.T) {
+		live := *message
+		live.Broker = &entities.BrokerMetadata{Topic: "farms/farm-7/devices/registration", QoS: 1, Retained: true, ClientID: "probe-7"}
+		mockRepo := mocks.NewMockDeviceRepository(t)
+		mockRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(existing(), nil).Once()
+		mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.LastRegistration != nil && *device.LastRegistration == *live.Broker
+		})).Return(nil).Once()
+
+		assert.NoError(t, NewDeviceRegistrationUseCase(mockRepo, nil, createTestLoggerFactory(t)).RegisterDevice(context.Background(), &live))
+	})
+
+	t.Run("keeps the metadata when the registration has none", func(t *testing.T) {
+		mockRepo := mocks.NewMockDeviceRepository(t)
+		mockRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(existing(), nil).Once()
+		mockRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.LastRegistration != nil && *device.LastRegistration == *previous
+		})).Return(nil).Once()
+
+		assert.NoError(t, NewDeviceRegistrationUseCase(mockRepo, nil, createTestLoggerFactory(t)).RegisterDevice(context.Background(), message))
+	})
+}
+
 func TestUseCase_createNewDevice(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -366,6 +477,55 @@ func TestUseCase_updateExistingDevice(t *testing.T) {
 			wantErr: true,
 			errMsg:  "failed to update existing device",
 		},
+		{
+			name: "binds device to the farm of the message",
+			existingDevice: &entities.Device{
+				MACAddress:          "AA:BB:CC:DD:EE:FF",
+				DeviceName:          "Old Device",
+				IPAddress:           "192.168.1.100",
+				LocationDescription: "Garden Zone 1",
+				Status:              "offline",
+			},
+			message: &entities.DeviceRegistrationMessage{
+				MACAddress:          "AA:BB:CC:DD:EE:FF",
+				DeviceName:          "Updated Device",
+				IPAddress:           "192.168.1.101",
+				LocationDescription: "Garden Zone 2",
+				ReceivedAt:          time.Now(),
+				FarmID:              "farm-a",
+			},
+			setup: func(mockRepo *mocks.MockDeviceRepository) {
+				mockRepo.EXPECT().
+					Update(mock.Anything, mock.MatchedBy(func(device *entities.Device) bool {
+						return device.FarmID == "farm-a"
+					})).
+					Return(nil).
+					Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects devices of another farm",
+			existingDevice: &entities.Device{
+				MACAddress:          "AA:BB:CC:DD:EE:FF",
+				DeviceName:          "Old Device",
+				IPAddress:           "192.168.1.100",
+				LocationDescription: "Garden Zone 1",
+				Status:              "offline",
+				FarmID:              "farm-b",
+			},
+			message: &entities.DeviceRegistrationMessage{
+				MACAddress:          "AA:BB:CC:DD:EE:FF",
+				DeviceName:          "Updated Device",
+				IPAddress:           "192.168.1.101",
+				LocationDescription: "Garden Zone 2",
+				ReceivedAt:          time.Now(),
+				FarmID:              "farm-a",
+			},
+			setup:   func(mockRepo *mocks.MockDeviceRepository) {},
+			wantErr: true,
+			errMsg:  "Device belongs to another farm",
+		},
 	}
 
 	for _, tt := range tests {
@@ -485,13 +645,9 @@ func TestUseCase_RegisterDevice_EdgeCases(t *testing.T) {
 		mockRepo := mocks.NewMockDeviceRepository(t)
 		useCase := NewDeviceRegistrationUseCase(mockRepo, nil, createTestLoggerFactory(t))
 
-		// This should panic or be handled gracefully depending on implementation
-		// Since the current implementation doesn't check for nil, this is more of a documentation test
-		// We're intentionally not checking the error return value here to test the panic behavior
-		//nolint:errcheck // This is an intentional test of panic behavior with nil message
-		assert.Panics(t, func() {
-			_ = useCase.RegisterDevice(context.Background(), nil) // This should panic
-		})
+		err := useCase.RegisterDevice(context.Background(), nil)
+
+		assert.ErrorIs(t, err, domainerrors.ErrMissingInput)
 	})
 
 	t.Run("context cancellation", func(t *testing.T) {
@@ -0,0 +1,78 @@
+package devicestatus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// DeviceStatusUseCase answers status queries for a set of devices
+type DeviceStatusUseCase interface {
+	// QueryStatus returns the known devices among the given MAC addresses, keyed by the uppercased address.
+	// The query is rejected when it is empty, holds an invalid address or more addresses than allowed.
+	QueryStatus(ctx context.Context, macAddresses []string) (map[string]*entities.Device, error)
+}
+
+// useCaseImpl implements the DeviceStatusUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	maxDevices    int
+	loggerFactory logger.LoggerFactory
+}
+
+// NewDeviceStatusUseCase creates a device status use case accepting up to maxDevices addresses per query
+func NewDeviceStatusUseCase(deviceRepo repositoryports.DeviceRepository, maxDevices int, loggerFactory logger.LoggerFactory) DeviceStatusUseCase {
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		maxDevices:    maxDevices,
+		loggerFactory: loggerFactory,
+	}
+}
+
+// QueryStatus loads the requested devices with a single repository query
+func (uc *useCaseImpl) QueryStatus(ctx context.Context, macAddresses []string) (map[string]*entities.Device, error) {
+	if len(macAddresses) == 0 {
+		return nil, fmt.Errorf("%w: at least one mac address is required", domainerrors.ErrInvalidStatusQuery)
+	}
+	if len(macAddresses) > uc.maxDevices {
+		return nil, fmt.Errorf("%w: at most %d mac addresses can be queried at once", domainerrors.ErrInvalidStatusQuery, uc.maxDevices)
+	}
+
+	normalized := make([]string, 0, len(macAddresses))
+	seen := make(map[string]bool, len(macAddresses))
+	for _, macAddress := range macAddresses {
+		if err := validation.ValidateMACAddress(macAddress); err != nil {
+			return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidStatusQuery, err)
+		}
+		macAddress = strings.ToUpper(strings.TrimSpace(macAddress))
+		if !seen[macAddress] {
+			seen[macAddress] = true
+			normalized = append(normalized, macAddress)
+		}
+	}
+
+	devices, err := uc.deviceRepo.FindByMACAddresses(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query device status: %w", err)
+	}
+
+	result := make(map[string]*entities.Device, len(devices))
+	for _, device := range devices {
+		result[device.GetID()] = device
+	}
+
+	uc.loggerFactory.Core().Debug("device_status_queried",
+		zap.Int("requested", len(normalized)),
+		zap.Int("found", len(result)),
+		zap.String("component", "device_status_usecase"),
+	)
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package devicestatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func TestDeviceStatusUseCase_QueryStatus(t *testing.T) {
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+
+	t.Run("should query normalized unique addresses once", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceStatusUseCase(repo, 3, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddresses(mock.Anything, []string{"AA:BB:CC:DD:EE:FF", "11:22:33:44:55:66"}).Return([]*entities.Device{device}, nil).Once()
+
+		devices, err := useCase.QueryStatus(context.Background(), []string{"aa:bb:cc:dd:ee:ff", "11:22:33:44:55:66", "AA:BB:CC:DD:EE:FF"})
+		require.NoError(t, err)
+		assert.Len(t, devices, 1)
+		assert.Same(t, device, devices["AA:BB:CC:DD:EE:FF"])
+	})
+
+	t.Run("should reject invalid queries", func(t *testing.T) {
+		useCase := NewDeviceStatusUseCase(mocks.NewMockDeviceRepository(t), 2, createTestLoggerFactory(t))
+
+		for _, macAddresses := range [][]string{
+			nil,
+			{"AA:BB:CC:DD:EE:FF", "11:22:33:44:55:66", "22:33:44:55:66:77"},
+			{"not-a-mac"},
+		} {
+			_, err := useCase.QueryStatus(context.Background(), macAddresses)
+			assert.ErrorIs(t, err, domainerrors.ErrInvalidStatusQuery)
+		}
+	})
+
+	t.Run("should return repository errors", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewDeviceStatusUseCase(repo, 2, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddresses(mock.Anything, mock.Anything).Return(nil, errors.New("query failed")).Once()
+
+		_, err := useCase.QueryStatus(context.Background(), []string{"AA:BB:CC:DD:EE:FF"})
+		assert.ErrorContains(t, err, "query failed")
+	})
+}
@@ -0,0 +1,265 @@
+package devicetransfers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// DeviceTransfersUseCase moves devices between farms when hardware moves between sites. A transfer
+// is initiated by the source farm, accepted by the target farm and completed once the device is
+// installed; every step is recorded in the audit trail of the transfer and published as an event.
+type DeviceTransfersUseCase interface {
+	// Initiate starts the transfer of a device to another farm. It fails with ErrDeviceNotFound,
+	// ErrFarmNotFound for an unknown target farm, ErrInvalidDeviceTransfer, or
+	// ErrDeviceTransferInProgress when the device already has an open transfer.
+	Initiate(ctx context.Context, macAddress string, request entities.DeviceTransferRequest, actor string) (*entities.DeviceTransfer, error)
+
+	// Accept records the target farm agreeing to take the device, failing with
+	// ErrDeviceTransferNotFound or ErrDeviceTransferStatusChange unless the transfer is pending
+	Accept(ctx context.Context, id, actor string) (*entities.DeviceTransfer, error)
+
+	// Complete moves the device to the target farm and its zone, archiving the readings taken so far
+	// under the source farm when the history policy asks for it. It fails with
+	// ErrDeviceTransferNotFound, ErrDeviceTransferStatusChange unless the transfer is accepted, or
+	// ErrInvalidDeviceTransfer when the device changed farm since the transfer was initiated.
+	Complete(ctx context.Context, id, actor string) (*entities.DeviceTransfer, error)
+
+	// Cancel abandons an open transfer, failing with ErrDeviceTransferNotFound or
+	// ErrDeviceTransferStatusChange
+	Cancel(ctx context.Context, id, actor, reason string) (*entities.DeviceTransfer, error)
+
+	// Get returns a transfer with its audit trail, oldest entry first
+	Get(ctx context.Context, id string) (*entities.DeviceTransfer, []entities.DeviceTransferAuditEntry, error)
+
+	// List returns the transfers of the device, or of every device when empty, with the status, or
+	// any status when empty, most recently initiated first
+	List(ctx context.Context, macAddress, status string, limit int) ([]*entities.DeviceTransfer, error)
+}
+
+// useCaseImpl implements the DeviceTransfersUseCase interface
+type useCaseImpl struct {
+	transferRepo   repositoryports.DeviceTransferRepository
+	deviceRepo     repositoryports.DeviceRepository
+	farmRepo       repositoryports.FarmRepository
+	zoneRepo       repositoryports.ZoneRepository
+	eventPublisher eventports.EventPublisher
+	loggerFactory  logger.LoggerFactory
+	now            func() time.Time
+}
+
+// NewDeviceTransfersUseCase creates a device transfers use case; transfers are not published
+// without an event publisher
+func NewDeviceTransfersUseCase(
+	transferRepo repositoryports.DeviceTransferRepository,
+	deviceRepo repositoryports.DeviceRepository,
+	farmRepo repositoryports.FarmRepository,
+	zoneRepo repositoryports.ZoneRepository,
+	eventPublisher eventports.EventPublisher,
+	loggerFactory logger.LoggerFactory,
+) DeviceTransfersUseCase {
+	return &useCaseImpl{
+		transferRepo:   transferRepo,
+		deviceRepo:     deviceRepo,
+		farmRepo:       farmRepo,
+		zoneRepo:       zoneRepo,
+		eventPublisher: eventPublisher,
+		loggerFactory:  loggerFactory,
+		now:            time.Now,
+	}
+}
+
+// Initiate validates the target farm and zone and stores the pending transfer
+func (uc *useCaseImpl) Initiate(ctx context.Context, macAddress string, request entities.DeviceTransferRequest, actor string) (*entities.DeviceTransfer, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, entry, err := entities.NewDeviceTransfer(device, request, actor, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDeviceTransfer, err)
+	}
+	if _, err := uc.farmRepo.FindByID(ctx, transfer.ToFarmID); err != nil {
+		return nil, err
+	}
+	if transfer.ToZoneID != "" {
+		if err := uc.validateTargetZone(ctx, transfer); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.transferRepo.Create(ctx, transfer, entry); err != nil {
+		return nil, err
+	}
+	uc.recorded(ctx, transfer, entry)
+	return transfer, nil
+}
+
+// Accept moves a pending transfer to accepted
+func (uc *useCaseImpl) Accept(ctx context.Context, id, actor string) (*entities.DeviceTransfer, error) {
+	transfer, err := uc.transferRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := transfer.Accept(actor, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrDeviceTransferStatusChange, err)
+	}
+	if err := uc.transferRepo.Update(ctx, transfer, entry); err != nil {
+		return nil, err
+	}
+	uc.recorded(ctx, transfer, entry)
+	return transfer, nil
+}
+
+// Complete archives the history per policy, rebinds the device and moves it between zones
+func (uc *useCaseImpl) Complete(ctx context.Context, id, actor string) (*entities.DeviceTransfer, error) {
+	transfer, err := uc.transferRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != entities.DeviceTransferAccepted {
+		return nil, fmt.Errorf("%w: only accepted transfers can be completed, transfer is %s", domainerrors.ErrDeviceTransferStatusChange, transfer.Status)
+	}
+
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, transfer.MACAddress)
+	if err != nil {
+		return nil, err
+	}
+	if device.FarmID != transfer.FromFarmID {
+		return nil, fmt.Errorf("%w: device %s moved to farm %q since the transfer was initiated", domainerrors.ErrInvalidDeviceTransfer, device.MACAddress, device.FarmID)
+	}
+
+	if err := device.TransferFarm(transfer.ToFarmID); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidDeviceTransfer, err)
+	}
+	// Zone assignments are idempotent, so they go first and a failed completion can be retried
+	if err := uc.moveZone(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	entry, err := uc.transferRepo.Complete(ctx, transfer, device, actor, uc.now())
+	if err != nil {
+		return nil, err
+	}
+	uc.recorded(ctx, transfer, entry)
+	return transfer, nil
+}
+
+// Cancel moves an open transfer to cancelled
+func (uc *useCaseImpl) Cancel(ctx context.Context, id, actor, reason string) (*entities.DeviceTransfer, error) {
+	transfer, err := uc.transferRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := transfer.Cancel(actor, reason, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrDeviceTransferStatusChange, err)
+	}
+	if err := uc.transferRepo.Update(ctx, transfer, entry); err != nil {
+		return nil, err
+	}
+	uc.recorded(ctx, transfer, entry)
+	return transfer, nil
+}
+
+// Get loads a transfer with its audit trail
+func (uc *useCaseImpl) Get(ctx context.Context, id string) (*entities.DeviceTransfer, []entities.DeviceTransferAuditEntry, error) {
+	transfer, err := uc.transferRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := uc.transferRepo.AuditEntries(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return transfer, entries, nil
+}
+
+// List loads the matching transfers
+func (uc *useCaseImpl) List(ctx context.Context, macAddress, status string, limit int) ([]*entities.DeviceTransfer, error) {
+	return uc.transferRepo.List(ctx, strings.ToUpper(strings.TrimSpace(macAddress)), status, limit)
+}
+
+// validateTargetZone checks that the zone of the transfer is a zone of the target farm
+func (uc *useCaseImpl) validateTargetZone(ctx context.Context, transfer *entities.DeviceTransfer) error {
+	zone, err := uc.zoneRepo.FindByID(ctx, transfer.ToZoneID)
+	if errors.Is(err, domainerrors.ErrZoneNotFound) {
+		return fmt.Errorf("%w: unknown zone %s", domainerrors.ErrInvalidDeviceTransfer, transfer.ToZoneID)
+	}
+	if err != nil {
+		return err
+	}
+	if zone.Kind != entities.ZoneKindZone || zone.FarmID != transfer.ToFarmID {
+		return fmt.Errorf("%w: %s is not a zone of farm %s", domainerrors.ErrInvalidDeviceTransfer, zone.ID, transfer.ToFarmID)
+	}
+	return nil
+}
+
+// moveZone assigns the device to the zone of the transfer, or takes it out of the zones of the
+// source farm when the transfer names none
+func (uc *useCaseImpl) moveZone(ctx context.Context, transfer *entities.DeviceTransfer) error {
+	if transfer.ToZoneID != "" {
+		return uc.zoneRepo.AssignDevice(ctx, transfer.ToZoneID, transfer.MACAddress)
+	}
+	if transfer.FromFarmID == "" {
+		return nil
+	}
+
+	zones, err := uc.zoneRepo.ListByFarm(ctx, transfer.FromFarmID)
+	if err != nil {
+		return err
+	}
+	for _, zone := range zones {
+		if zone.Kind != entities.ZoneKindZone {
+			continue
+		}
+		if err := uc.zoneRepo.UnassignDevice(ctx, zone.ID, transfer.MACAddress); err != nil && !errors.Is(err, domainerrors.ErrDeviceNotInZone) {
+			return err
+		}
+	}
+	return nil
+}
+
+// recorded logs the audit entry and publishes the transfer event, logging publishing failures
+// without returning them
+func (uc *useCaseImpl) recorded(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) {
+	uc.loggerFactory.Core().Info("device_transfer_"+entry.Action,
+		zap.String("transfer_id", transfer.ID),
+		zap.String("mac_address", transfer.MACAddress),
+		zap.String("from_farm_id", transfer.FromFarmID),
+		zap.String("to_farm_id", transfer.ToFarmID),
+		zap.String("actor", entry.Actor),
+		zap.String("component", "device_transfers_usecase"),
+	)
+
+	if uc.eventPublisher == nil || !uc.eventPublisher.IsConnected() {
+		return
+	}
+	event, err := transfer.Event(entry)
+	if err != nil {
+		uc.loggerFactory.Core().Error("failed_to_create_device_transfer_event",
+			zap.Error(err),
+			zap.String("transfer_id", transfer.ID),
+			zap.String("component", "device_transfers_usecase"),
+		)
+		return
+	}
+
+	subject := event.GetSubject()
+	err = uc.eventPublisher.Publish(ctx, subject, event)
+	uc.loggerFactory.Messaging().LogEventPublishing("device_transfer", subject, event.EventID, err == nil, err)
+}
@@ -0,0 +1,178 @@
+package devicetransfers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+type testDeps struct {
+	transfers *mocks.MockDeviceTransferRepository
+	devices   *mocks.MockDeviceRepository
+	farms     *mocks.MockFarmRepository
+	zones     *mocks.MockZoneRepository
+	publisher *mocks.MockEventPublisher
+}
+
+func newTestUseCase(t *testing.T) (*useCaseImpl, testDeps) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	deps := testDeps{
+		transfers: mocks.NewMockDeviceTransferRepository(t),
+		devices:   mocks.NewMockDeviceRepository(t),
+		farms:     mocks.NewMockFarmRepository(t),
+		zones:     mocks.NewMockZoneRepository(t),
+		publisher: mocks.NewMockEventPublisher(t),
+	}
+	impl := NewDeviceTransfersUseCase(deps.transfers, deps.devices, deps.farms, deps.zones, deps.publisher, loggerFactory).(*useCaseImpl)
+	impl.now = func() time.Time { return testNow }
+	return impl, deps
+}
+
+func TestDeviceTransfersUseCase_Initiate(t *testing.T) {
+	device := &entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "finca-norte"}
+	request := entities.DeviceTransferRequest{ToFarmID: "finca-sur", ToZoneID: "zone-1", HistoryPolicy: entities.TransferHistoryArchive}
+
+	t.Run("should store and publish the pending transfer", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil).Once()
+		deps.farms.EXPECT().FindByID(mock.Anything, "finca-sur").Return(&entities.Farm{ID: "finca-sur"}, nil).Once()
+		deps.zones.EXPECT().FindByID(mock.Anything, "zone-1").Return(&entities.Zone{ID: "zone-1", FarmID: "finca-sur", Kind: entities.ZoneKindZone}, nil).Once()
+		deps.transfers.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.DeviceTransfer"), mock.MatchedBy(func(entry entities.DeviceTransferAuditEntry) bool {
+			return entry.Action == entities.DeviceTransferInitiated && entry.Actor == "maria"
+		})).Return(nil).Once()
+		deps.publisher.EXPECT().IsConnected().Return(true).Once()
+		deps.publisher.EXPECT().Publish(mock.Anything, "liwaisi.iot.smart-irrigation.device.transfer", mock.MatchedBy(func(event *entities.DeviceTransferEvent) bool {
+			return event.Status == entities.DeviceTransferPending && event.ToFarmID == "finca-sur"
+		})).Return(nil).Once()
+
+		transfer, err := useCase.Initiate(context.Background(), "aa:bb:cc:dd:ee:ff", request, "maria")
+		require.NoError(t, err)
+		assert.Equal(t, "finca-norte", transfer.FromFarmID)
+		assert.Equal(t, testNow, transfer.CreatedAt)
+	})
+
+	t.Run("should reject zones of other farms", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil).Once()
+		deps.farms.EXPECT().FindByID(mock.Anything, "finca-sur").Return(&entities.Farm{ID: "finca-sur"}, nil).Once()
+		deps.zones.EXPECT().FindByID(mock.Anything, "zone-1").Return(&entities.Zone{ID: "zone-1", FarmID: "finca-norte", Kind: entities.ZoneKindZone}, nil).Once()
+
+		_, err := useCase.Initiate(context.Background(), "AA:BB:CC:DD:EE:FF", request, "maria")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeviceTransfer)
+	})
+
+	t.Run("should reject unknown target farms", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil).Once()
+		deps.farms.EXPECT().FindByID(mock.Anything, "finca-sur").Return(nil, domainerrors.ErrFarmNotFound).Once()
+
+		_, err := useCase.Initiate(context.Background(), "AA:BB:CC:DD:EE:FF", request, "maria")
+		assert.ErrorIs(t, err, domainerrors.ErrFarmNotFound)
+	})
+}
+
+func TestDeviceTransfersUseCase_Complete(t *testing.T) {
+	newTransfer := func(policy, zoneID string) *entities.DeviceTransfer {
+		acceptedAt := testNow.Add(-time.Hour)
+		return &entities.DeviceTransfer{
+			ID: "transfer-1", MACAddress: "AA:BB:CC:DD:EE:FF", FromFarmID: "finca-norte", ToFarmID: "finca-sur", ToZoneID: zoneID,
+			HistoryPolicy: policy, Status: entities.DeviceTransferAccepted, AcceptedAt: &acceptedAt,
+		}
+	}
+
+	t.Run("should archive the history and move the device to the target zone", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		transfer := newTransfer(entities.TransferHistoryArchive, "zone-1")
+		deps.transfers.EXPECT().FindByID(mock.Anything, "transfer-1").Return(transfer, nil).Once()
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(&entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "finca-norte"}, nil).Once()
+		deps.zones.EXPECT().AssignDevice(mock.Anything, "zone-1", "AA:BB:CC:DD:EE:FF").Return(nil).Once()
+		deps.transfers.EXPECT().Complete(mock.Anything, transfer, mock.MatchedBy(func(device *entities.Device) bool { return device.FarmID == "finca-sur" }), "pedro", testNow).
+			RunAndReturn(func(ctx context.Context, transfer *entities.DeviceTransfer, device *entities.Device, actor string, at time.Time) (entities.DeviceTransferAuditEntry, error) {
+				return transfer.Complete(actor, 1440, at)
+			}).Once()
+		deps.publisher.EXPECT().IsConnected().Return(false).Once()
+
+		completed, err := useCase.Complete(context.Background(), "transfer-1", "pedro")
+		require.NoError(t, err)
+		assert.Equal(t, entities.DeviceTransferCompleted, completed.Status)
+		assert.Equal(t, 1440, completed.ArchivedReadings)
+	})
+
+	t.Run("should keep the history and leave the zones of the source farm", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		transfer := newTransfer(entities.TransferHistoryKeep, "")
+		deps.transfers.EXPECT().FindByID(mock.Anything, "transfer-1").Return(transfer, nil).Once()
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(&entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "finca-norte"}, nil).Once()
+		deps.zones.EXPECT().ListByFarm(mock.Anything, "finca-norte").Return([]*entities.Zone{
+			{ID: "field-1", Kind: entities.ZoneKindField},
+			{ID: "zone-a", Kind: entities.ZoneKindZone},
+			{ID: "zone-b", Kind: entities.ZoneKindZone},
+		}, nil).Once()
+		deps.zones.EXPECT().UnassignDevice(mock.Anything, "zone-a", "AA:BB:CC:DD:EE:FF").Return(domainerrors.ErrDeviceNotInZone).Once()
+		deps.zones.EXPECT().UnassignDevice(mock.Anything, "zone-b", "AA:BB:CC:DD:EE:FF").Return(nil).Once()
+		deps.transfers.EXPECT().Complete(mock.Anything, transfer, mock.Anything, "pedro", testNow).
+			RunAndReturn(func(ctx context.Context, transfer *entities.DeviceTransfer, device *entities.Device, actor string, at time.Time) (entities.DeviceTransferAuditEntry, error) {
+				return transfer.Complete(actor, 0, at)
+			}).Once()
+		deps.publisher.EXPECT().IsConnected().Return(false).Once()
+
+		completed, err := useCase.Complete(context.Background(), "transfer-1", "pedro")
+		require.NoError(t, err)
+		assert.Zero(t, completed.ArchivedReadings)
+	})
+
+	t.Run("should leave the transfer accepted when the completion fails", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		transfer := newTransfer(entities.TransferHistoryArchive, "zone-1")
+		deps.transfers.EXPECT().FindByID(mock.Anything, "transfer-1").Return(transfer, nil).Once()
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(&entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "finca-norte"}, nil).Once()
+		deps.zones.EXPECT().AssignDevice(mock.Anything, "zone-1", "AA:BB:CC:DD:EE:FF").Return(nil).Once()
+		deps.transfers.EXPECT().Complete(mock.Anything, transfer, mock.Anything, "pedro", testNow).Return(entities.DeviceTransferAuditEntry{}, errors.New("connection reset")).Once()
+
+		_, err := useCase.Complete(context.Background(), "transfer-1", "pedro")
+		assert.EqualError(t, err, "connection reset")
+		assert.Equal(t, entities.DeviceTransferAccepted, transfer.Status)
+	})
+
+	t.Run("should require an accepted transfer", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		transfer := newTransfer(entities.TransferHistoryKeep, "")
+		transfer.Status = entities.DeviceTransferPending
+		deps.transfers.EXPECT().FindByID(mock.Anything, "transfer-1").Return(transfer, nil).Once()
+
+		_, err := useCase.Complete(context.Background(), "transfer-1", "pedro")
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceTransferStatusChange)
+	})
+
+	t.Run("should reject devices that changed farm meanwhile", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.transfers.EXPECT().FindByID(mock.Anything, "transfer-1").Return(newTransfer(entities.TransferHistoryKeep, ""), nil).Once()
+		deps.devices.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(&entities.Device{MACAddress: "AA:BB:CC:DD:EE:FF", FarmID: "finca-este"}, nil).Once()
+
+		_, err := useCase.Complete(context.Background(), "transfer-1", "pedro")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDeviceTransfer)
+	})
+}
+
+func TestDeviceTransfersUseCase_Cancel(t *testing.T) {
+	useCase, deps := newTestUseCase(t)
+	completedAt := testNow.Add(-time.Hour)
+	deps.transfers.EXPECT().FindByID(mock.Anything, "transfer-1").Return(&entities.DeviceTransfer{ID: "transfer-1", Status: entities.DeviceTransferCompleted, CompletedAt: &completedAt}, nil).Once()
+
+	_, err := useCase.Cancel(context.Background(), "transfer-1", "maria", "wrong device")
+	assert.ErrorIs(t, err, domainerrors.ErrDeviceTransferStatusChange)
+}
@@ -0,0 +1,312 @@
+package driftreconciliation
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	devicecommands "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_commands"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// Drift states counted by the device_config_drift_devices gauge
+const (
+	stateDrifting   = "drifting"
+	statePersistent = "persistent"
+)
+
+// DriftConfig holds configuration for the drift reconciler
+type DriftConfig struct {
+	Interval       time.Duration // how often devices are compared with their shadows
+	PushBackoff    time.Duration // wait before the first re-push, doubled on every push
+	MaxPushBackoff time.Duration // longest wait between two pushes
+	AlertAfter     time.Duration // drift lasting this long is reported as persistent
+}
+
+// DefaultDriftConfig returns default configuration
+func DefaultDriftConfig() *DriftConfig {
+	return &DriftConfig{
+		Interval:       time.Minute,
+		PushBackoff:    2 * time.Minute,
+		MaxPushBackoff: time.Hour,
+		AlertAfter:     30 * time.Minute,
+	}
+}
+
+// DriftReconciliationUseCase keeps devices in line with their declared configuration the way a
+// controller does: every pass compares each shadow with what the device last reported, pushes the
+// shadow again with a configure command while they differ, backing off between pushes, and reports
+// drift that outlasts the alert threshold. The drift is tracked in memory, so a restart starts
+// counting it again.
+type DriftReconciliationUseCase interface {
+	// Reconcile runs one pass over every shadow
+	Reconcile(ctx context.Context) error
+
+	// Drifts returns the devices drifting at the last pass, by MAC address
+	Drifts() []*entities.DeviceDrift
+
+	// Run reconciles periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// driftState is the drift tracked for a device between passes
+type driftState struct {
+	fields     []string
+	since      time.Time
+	pushes     int
+	lastPush   *time.Time
+	nextPush   time.Time
+	persistent bool
+}
+
+// useCaseImpl implements the DriftReconciliationUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	shadowRepo    repositoryports.DeviceShadowRepository
+	commands      devicecommands.DeviceCommandsUseCase
+	config        *DriftConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+
+	// states is only touched by Reconcile, which passes hold one at a time
+	reconcileMu sync.Mutex
+	states      map[string]*driftState
+
+	mu     sync.RWMutex
+	drifts []*entities.DeviceDrift
+
+	devices *metrics.Vec
+	pushes  *metrics.Vec
+}
+
+// NewDriftReconciliationUseCase creates a new drift reconciliation use case
+func NewDriftReconciliationUseCase(
+	deviceRepo repositoryports.DeviceRepository,
+	shadowRepo repositoryports.DeviceShadowRepository,
+	commands devicecommands.DeviceCommandsUseCase,
+	config *DriftConfig,
+	loggerFactory logger.LoggerFactory,
+) DriftReconciliationUseCase {
+	if config == nil {
+		config = DefaultDriftConfig()
+	}
+
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		shadowRepo:    shadowRepo,
+		commands:      commands,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		states:        make(map[string]*driftState),
+		drifts:        []*entities.DeviceDrift{},
+		devices:       metrics.NewGaugeVec("device_config_drift_devices", "Devices reporting a configuration other than the declared one", "state"),
+		pushes:        metrics.NewCounterVec("device_config_pushes_total", "Declared configurations pushed again to drifting devices", "result"),
+	}
+}
+
+// Reconcile compares every shadow with its device
+func (uc *useCaseImpl) Reconcile(ctx context.Context) error {
+	uc.reconcileMu.Lock()
+	defer uc.reconcileMu.Unlock()
+
+	shadows, err := uc.shadowRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := uc.now().UTC()
+	declared := make(map[string]bool, len(shadows))
+	for _, shadow := range shadows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		declared[shadow.MACAddress] = true
+
+		device, err := uc.deviceRepo.FindByMACAddress(ctx, shadow.MACAddress)
+		if errors.Is(err, domainerrors.ErrDeviceNotFound) {
+			delete(uc.states, shadow.MACAddress)
+			continue
+		}
+		if err != nil {
+			// Keep the drift as it was until the device can be read again
+			uc.loggerFactory.Core().Error("device_drift_check_failed",
+				zap.Error(err),
+				zap.String("mac_address", shadow.MACAddress),
+				zap.String("component", "drift_reconciliation_usecase"),
+			)
+			continue
+		}
+		uc.reconcileDevice(ctx, shadow, shadow.Drift(device), now)
+	}
+
+	// Devices no longer declared are not reconciled anymore
+	for macAddress := range uc.states {
+		if !declared[macAddress] {
+			delete(uc.states, macAddress)
+		}
+	}
+	uc.publish()
+	return nil
+}
+
+// reconcileDevice tracks the drift of one device and pushes its shadow when due
+func (uc *useCaseImpl) reconcileDevice(ctx context.Context, shadow *entities.DeviceShadow, fields []string, now time.Time) {
+	state, drifting := uc.states[shadow.MACAddress]
+	if len(fields) == 0 {
+		if drifting {
+			delete(uc.states, shadow.MACAddress)
+			uc.loggerFactory.Core().Info("device_config_converged",
+				zap.String("mac_address", shadow.MACAddress),
+				zap.Duration("drift", now.Sub(state.since)),
+				zap.Int("pushes", state.pushes),
+				zap.String("component", "drift_reconciliation_usecase"),
+			)
+		}
+		return
+	}
+
+	if !drifting {
+		state = &driftState{since: now}
+		uc.states[shadow.MACAddress] = state
+		uc.loggerFactory.Core().Info("device_config_drift_detected",
+			zap.String("mac_address", shadow.MACAddress),
+			zap.Strings("fields", fields),
+			zap.String("component", "drift_reconciliation_usecase"),
+		)
+	}
+	state.fields = fields
+
+	if !now.Before(state.nextPush) {
+		uc.push(ctx, shadow, state, now)
+	}
+
+	if !state.persistent && now.Sub(state.since) >= uc.config.AlertAfter {
+		state.persistent = true
+		uc.loggerFactory.Core().Warn("device_config_drift_persistent",
+			zap.String("mac_address", shadow.MACAddress),
+			zap.Strings("fields", fields),
+			zap.Time("since", state.since),
+			zap.Int("pushes", state.pushes),
+			zap.String("component", "drift_reconciliation_usecase"),
+		)
+	}
+}
+
+// push sends the shadow to the device and schedules the next push with exponential backoff
+func (uc *useCaseImpl) push(ctx context.Context, shadow *entities.DeviceShadow, state *driftState, now time.Time) {
+	backoff := uc.config.PushBackoff
+	for i := 0; i < state.pushes && backoff < uc.config.MaxPushBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > uc.config.MaxPushBackoff {
+		backoff = uc.config.MaxPushBackoff
+	}
+	state.pushes++
+	state.lastPush = &now
+	state.nextPush = now.Add(backoff)
+
+	command, err := uc.commands.Send(ctx, shadow.MACAddress, shadow.ConfigureRequest())
+	if err != nil {
+		uc.pushes.Inc("failed")
+		uc.loggerFactory.Core().Error("device_config_push_failed",
+			zap.Error(err),
+			zap.String("mac_address", shadow.MACAddress),
+			zap.String("component", "drift_reconciliation_usecase"),
+		)
+		return
+	}
+	uc.pushes.Inc("sent")
+	uc.loggerFactory.Core().Info("device_config_pushed",
+		zap.String("mac_address", shadow.MACAddress),
+		zap.String("command_id", command.ID),
+		zap.Int("pushes", state.pushes),
+		zap.Duration("next_push_in", backoff),
+		zap.String("component", "drift_reconciliation_usecase"),
+	)
+}
+
+// publish snapshots the tracked drift for Drifts and the metrics
+func (uc *useCaseImpl) publish() {
+	drifts := make([]*entities.DeviceDrift, 0, len(uc.states))
+	persistent := 0
+	for macAddress, state := range uc.states {
+		drift := &entities.DeviceDrift{
+			MACAddress: macAddress,
+			Fields:     append([]string(nil), state.fields...),
+			Since:      state.since,
+			Pushes:     state.pushes,
+			Persistent: state.persistent,
+		}
+		if state.lastPush != nil {
+			lastPush := *state.lastPush
+			drift.LastPushAt = &lastPush
+		}
+		if state.persistent {
+			persistent++
+		}
+		drifts = append(drifts, drift)
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].MACAddress < drifts[j].MACAddress })
+
+	uc.mu.Lock()
+	uc.drifts = drifts
+	uc.mu.Unlock()
+	uc.devices.Set(float64(len(drifts)-persistent), stateDrifting)
+	uc.devices.Set(float64(persistent), statePersistent)
+}
+
+// Drifts returns the drift found by the last pass
+func (uc *useCaseImpl) Drifts() []*entities.DeviceDrift {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	return uc.drifts
+}
+
+// Run reconciles periodically until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("drift_reconciliation_started", "drift_reconciliation_usecase",
+		zap.Duration("interval", uc.config.Interval),
+		zap.Duration("alert_after", uc.config.AlertAfter),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := uc.Reconcile(ctx); err != nil && ctx.Err() == nil {
+			uc.loggerFactory.Core().Error("drift_reconciliation_failed",
+				zap.Error(err),
+				zap.String("component", "drift_reconciliation_usecase"),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("drift_reconciliation_stopped", "drift_reconciliation_usecase")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return append(uc.devices.Collect(), uc.pushes.Collect()...)
+}
+
+// MetricsCollector returns the drift reconciliation metrics collector
+func MetricsCollector(useCase DriftReconciliationUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
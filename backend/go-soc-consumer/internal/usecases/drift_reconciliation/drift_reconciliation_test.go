@@ -0,0 +1,123 @@
+package driftreconciliation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+type testDeps struct {
+	deviceRepo *mocks.MockDeviceRepository
+	shadowRepo *mocks.MockDeviceShadowRepository
+	commands   *mocks.MockDeviceCommandsUseCase
+	now        time.Time
+}
+
+func newTestUseCase(t *testing.T) (*useCaseImpl, *testDeps) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	deps := &testDeps{
+		deviceRepo: mocks.NewMockDeviceRepository(t),
+		shadowRepo: mocks.NewMockDeviceShadowRepository(t),
+		commands:   mocks.NewMockDeviceCommandsUseCase(t),
+		now:        time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC),
+	}
+	config := &DriftConfig{Interval: time.Minute, PushBackoff: 2 * time.Minute, MaxPushBackoff: 5 * time.Minute, AlertAfter: 10 * time.Minute}
+	uc := NewDriftReconciliationUseCase(deps.deviceRepo, deps.shadowRepo, deps.commands, config, loggerFactory).(*useCaseImpl)
+	uc.now = func() time.Time { return deps.now }
+	return uc, deps
+}
+
+func reportedDevice(t *testing.T, name string) *entities.Device {
+	device, err := entities.NewDevice(testMAC, name, "10.0.0.1", "Greenhouse 2")
+	require.NoError(t, err)
+	return device
+}
+
+func TestDriftReconciliationUseCase_Reconcile(t *testing.T) {
+	shadow := &entities.DeviceShadow{MACAddress: testMAC, DeviceName: "North valve", LocationDescription: "Greenhouse 2"}
+	configure := entities.DeviceCommandRequest{Command: entities.DeviceCommandConfigure, DeviceName: "North valve", LocationDescription: "Greenhouse 2"}
+
+	t.Run("should push the shadow with backoff until the device converges", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.shadowRepo.EXPECT().List(mock.Anything).Return([]*entities.DeviceShadow{shadow}, nil)
+		drifted := reportedDevice(t, "ESP32-1234")
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(drifted, nil).Times(5)
+		deps.commands.EXPECT().Send(mock.Anything, testMAC, configure).Return(&entities.DeviceCommand{ID: "command-1"}, nil).Times(3)
+
+		// Pushed at 0, 2 and 6 minutes: the backoff doubles from two minutes
+		for _, minute := range []int{0, 1, 2, 5, 6} {
+			deps.now = time.Date(2025, 6, 1, 6, minute, 0, 0, time.UTC)
+			require.NoError(t, uc.Reconcile(context.Background()))
+		}
+
+		drifts := uc.Drifts()
+		require.Len(t, drifts, 1)
+		assert.Equal(t, []string{"device_name"}, drifts[0].Fields)
+		assert.Equal(t, 3, drifts[0].Pushes)
+		assert.Equal(t, time.Date(2025, 6, 1, 6, 0, 0, 0, time.UTC), drifts[0].Since)
+		assert.False(t, drifts[0].Persistent)
+		assert.Equal(t, float64(1), uc.devices.Value(stateDrifting))
+
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Unset()
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(reportedDevice(t, "North valve"), nil).Once()
+		require.NoError(t, uc.Reconcile(context.Background()))
+		assert.Empty(t, uc.Drifts())
+		assert.Equal(t, float64(0), uc.devices.Value(stateDrifting))
+	})
+
+	t.Run("should report drift that outlasts the alert threshold", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.shadowRepo.EXPECT().List(mock.Anything).Return([]*entities.DeviceShadow{shadow}, nil)
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(reportedDevice(t, "ESP32-1234"), nil)
+		deps.commands.EXPECT().Send(mock.Anything, testMAC, configure).Return(nil, errors.New("broker unavailable"))
+
+		require.NoError(t, uc.Reconcile(context.Background()))
+		deps.now = deps.now.Add(10 * time.Minute)
+		require.NoError(t, uc.Reconcile(context.Background()))
+
+		drifts := uc.Drifts()
+		require.Len(t, drifts, 1)
+		assert.True(t, drifts[0].Persistent)
+		assert.Equal(t, float64(1), uc.devices.Value(statePersistent))
+		assert.Equal(t, float64(2), uc.pushes.Value("failed"))
+	})
+
+	t.Run("should forget devices that are gone or no longer declared", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.shadowRepo.EXPECT().List(mock.Anything).Return([]*entities.DeviceShadow{shadow}, nil).Once()
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(reportedDevice(t, "ESP32-1234"), nil).Once()
+		deps.commands.EXPECT().Send(mock.Anything, testMAC, configure).Return(&entities.DeviceCommand{ID: "command-1"}, nil).Once()
+		require.NoError(t, uc.Reconcile(context.Background()))
+		require.Len(t, uc.Drifts(), 1)
+
+		deps.shadowRepo.EXPECT().List(mock.Anything).Return(nil, nil).Once()
+		require.NoError(t, uc.Reconcile(context.Background()))
+		assert.Empty(t, uc.Drifts())
+
+		deps.shadowRepo.EXPECT().List(mock.Anything).Return([]*entities.DeviceShadow{shadow}, nil).Once()
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+		require.NoError(t, uc.Reconcile(context.Background()))
+		assert.Empty(t, uc.Drifts())
+	})
+
+	t.Run("should fail when the shadows cannot be listed", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.shadowRepo.EXPECT().List(mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		assert.Error(t, uc.Reconcile(context.Background()))
+	})
+}
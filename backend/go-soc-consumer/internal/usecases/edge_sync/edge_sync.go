@@ -0,0 +1,285 @@
+package edgesync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// Sync modes of an instance
+const (
+	ModeDisabled = "disabled"
+	ModeEdge     = "edge"
+	ModeCloud    = "cloud"
+)
+
+// EdgeSyncConfig holds configuration for the edge replication loop
+type EdgeSyncConfig struct {
+	BatchSize  int
+	Interval   time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultEdgeSyncConfig returns default configuration
+func DefaultEdgeSyncConfig() *EdgeSyncConfig {
+	return &EdgeSyncConfig{
+		BatchSize:  200,
+		Interval:   30 * time.Second,
+		MaxBackoff: 10 * time.Minute,
+	}
+}
+
+// EdgeSyncUseCase records local changes and replicates them to the cloud when online
+type EdgeSyncUseCase interface {
+	// RecordDevice stores a device snapshot in the outbox
+	RecordDevice(ctx context.Context, device *entities.Device) error
+
+	// RecordSensorReading stores a sensor reading in the outbox
+	RecordSensorReading(ctx context.Context, reading *entities.SensorTemperatureHumidity) error
+
+	// RecordDeviceDetected stores a device detected event in the outbox
+	RecordDeviceDetected(ctx context.Context, event *entities.DeviceDetectedEvent) error
+
+	// SyncOnce pushes pending changes until the outbox is drained or an error occurs
+	SyncOnce(ctx context.Context) (int, error)
+
+	// Run replicates periodically until the context is cancelled
+	Run(ctx context.Context)
+
+	// Status returns the current replication state
+	Status(ctx context.Context) (*entities.SyncStatus, error)
+}
+
+// edgeSyncUseCase implements the EdgeSyncUseCase interface
+type edgeSyncUseCase struct {
+	outbox        repositoryports.SyncOutboxRepository
+	remote        ports.SyncRemote
+	config        *EdgeSyncConfig
+	loggerFactory logger.LoggerFactory
+
+	mu     sync.RWMutex
+	status entities.SyncStatus
+}
+
+// NewEdgeSyncUseCase creates a new edge sync use case
+func NewEdgeSyncUseCase(
+	outbox repositoryports.SyncOutboxRepository,
+	remote ports.SyncRemote,
+	config *EdgeSyncConfig,
+	loggerFactory logger.LoggerFactory,
+) EdgeSyncUseCase {
+	if config == nil {
+		config = DefaultEdgeSyncConfig()
+	}
+
+	return &edgeSyncUseCase{
+		outbox:        outbox,
+		remote:        remote,
+		config:        config,
+		loggerFactory: loggerFactory,
+		status:        entities.SyncStatus{Mode: ModeEdge},
+	}
+}
+
+// RecordDevice stores a device snapshot in the outbox
+func (uc *edgeSyncUseCase) RecordDevice(ctx context.Context, device *entities.Device) error {
+	if err := domainerrors.RequireInput("device", device); err != nil {
+		return err
+	}
+
+	payload := entities.DeviceSyncPayload{
+		MACAddress:          device.GetID(),
+		DeviceName:          device.GetDeviceName(),
+		IPAddress:           device.GetIPAddress(),
+		LocationDescription: device.LocationDescription,
+		RegisteredAt:        device.RegisteredAt,
+		LastSeen:            device.GetLastSeen(),
+		Status:              device.GetStatus(),
+	}
+	return uc.record(ctx, entities.SyncKindDevice, payload.MACAddress, payload, payload.LastSeen)
+}
+
+// RecordSensorReading stores a sensor reading in the outbox
+func (uc *edgeSyncUseCase) RecordSensorReading(ctx context.Context, reading *entities.SensorTemperatureHumidity) error {
+	if err := domainerrors.RequireInput("sensor reading", reading); err != nil {
+		return err
+	}
+
+	payload := entities.SensorTemperatureHumiditySyncPayload{
+		MACAddress:         reading.MacAddress(),
+		TemperatureCelsius: reading.Temperature(),
+		HumidityPercent:    reading.Humidity(),
+		Timestamp:          reading.Timestamp(),
+	}
+	return uc.record(ctx, entities.SyncKindSensorTemperatureHumidity, payload.MACAddress, payload, payload.Timestamp)
+}
+
+// RecordDeviceDetected stores a device detected event in the outbox
+func (uc *edgeSyncUseCase) RecordDeviceDetected(ctx context.Context, event *entities.DeviceDetectedEvent) error {
+	if err := domainerrors.RequireInput("device detected event", event); err != nil {
+		return err
+	}
+
+	payload := entities.DeviceDetectedEventSyncPayload{
+		EventID:    event.EventID,
+		MACAddress: event.MACAddress,
+		IPAddress:  event.IPAddress,
+		DetectedAt: event.DetectedAt,
+	}
+	return uc.record(ctx, entities.SyncKindDeviceDetectedEvent, payload.MACAddress, payload, payload.DetectedAt)
+}
+
+func (uc *edgeSyncUseCase) record(ctx context.Context, kind, key string, payload interface{}, changedAt time.Time) error {
+	item, err := entities.NewSyncItem(kind, key, payload, changedAt)
+	if err != nil {
+		return fmt.Errorf("failed to build sync item: %w", err)
+	}
+	if err := uc.outbox.Enqueue(ctx, item); err != nil {
+		return fmt.Errorf("failed to record %s change: %w", kind, err)
+	}
+	return nil
+}
+
+// SyncOnce pushes pending changes until the outbox is drained or an error occurs
+func (uc *edgeSyncUseCase) SyncOnce(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		items, err := uc.outbox.ListPending(ctx, uc.config.BatchSize)
+		if err != nil {
+			uc.recordAttempt(0, err)
+			return total, fmt.Errorf("failed to list pending sync items: %w", err)
+		}
+		if len(items) == 0 {
+			uc.recordAttempt(total, nil)
+			return total, nil
+		}
+
+		result, err := uc.remote.Push(ctx, items)
+		if err != nil {
+			ids := make([]string, 0, len(items))
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			if markErr := uc.outbox.MarkFailed(ctx, ids, err.Error()); markErr != nil {
+				uc.loggerFactory.Core().Error("sync_mark_failed_error",
+					zap.Error(markErr),
+					zap.String("component", "edge_sync_usecase"),
+				)
+			}
+			uc.recordAttempt(total, err)
+			return total, fmt.Errorf("failed to push sync batch: %w", err)
+		}
+
+		// Applied and skipped items are settled; rejected ones stay pending for a later retry
+		settled := append(append([]string{}, result.Applied...), result.Skipped...)
+		if err := uc.outbox.MarkSynced(ctx, settled, time.Now()); err != nil {
+			uc.recordAttempt(total, err)
+			return total, fmt.Errorf("failed to mark sync items: %w", err)
+		}
+		if len(result.Rejected) > 0 {
+			if err := uc.outbox.MarkFailed(ctx, result.Rejected, "rejected by cloud"); err != nil {
+				uc.recordAttempt(total, err)
+				return total, fmt.Errorf("failed to mark rejected sync items: %w", err)
+			}
+			uc.loggerFactory.Core().Warn("sync_items_rejected",
+				zap.Int("rejected", len(result.Rejected)),
+				zap.String("component", "edge_sync_usecase"),
+			)
+		}
+
+		total += len(settled)
+
+		// Stop when nothing progressed to avoid spinning on rejected items
+		if len(settled) == 0 || len(items) < uc.config.BatchSize {
+			uc.recordAttempt(total, nil)
+			return total, nil
+		}
+	}
+}
+
+// Run replicates periodically until the context is cancelled, backing off while offline
+func (uc *edgeSyncUseCase) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("edge_sync_started", "edge_sync_usecase",
+		zap.Duration("interval", uc.config.Interval),
+		zap.Int("batch_size", uc.config.BatchSize),
+	)
+
+	delay := uc.config.Interval
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("edge_sync_stopped", "edge_sync_usecase")
+			return
+		case <-timer.C:
+		}
+
+		synced, err := uc.SyncOnce(ctx)
+		if err != nil {
+			delay *= 2
+			if delay > uc.config.MaxBackoff {
+				delay = uc.config.MaxBackoff
+			}
+			uc.loggerFactory.Core().Warn("edge_sync_failed",
+				zap.Error(err),
+				zap.Int("synced_items", synced),
+				zap.Duration("retry_in", delay),
+				zap.String("component", "edge_sync_usecase"),
+			)
+		} else {
+			delay = uc.config.Interval
+			if synced > 0 {
+				uc.loggerFactory.Core().Info("edge_sync_completed",
+					zap.Int("synced_items", synced),
+					zap.String("component", "edge_sync_usecase"),
+				)
+			}
+		}
+		timer.Reset(delay)
+	}
+}
+
+// Status returns the current replication state
+func (uc *edgeSyncUseCase) Status(ctx context.Context) (*entities.SyncStatus, error) {
+	pending, err := uc.outbox.CountPending(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending sync items: %w", err)
+	}
+
+	uc.mu.RLock()
+	status := uc.status
+	uc.mu.RUnlock()
+
+	status.PendingItems = pending
+	return &status, nil
+}
+
+func (uc *edgeSyncUseCase) recordAttempt(synced int, err error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := time.Now()
+	uc.status.LastSyncAt = &now
+	uc.status.LastBatchSize = synced
+	uc.status.TotalReplicated += int64(synced)
+	if err != nil {
+		uc.status.Online = false
+		uc.status.FailedAttempts++
+		uc.status.LastError = err.Error()
+		return
+	}
+	uc.status.Online = true
+	uc.status.LastSuccessAt = &now
+	uc.status.LastError = ""
+}
@@ -0,0 +1,144 @@
+package edgesync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// createTestLoggerFactory creates a test logger factory for use in tests
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopment()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestSyncItem(t *testing.T, kind, key string, payload interface{}, changedAt time.Time) *entities.SyncItem {
+	item, err := entities.NewSyncItem(kind, key, payload, changedAt)
+	require.NoError(t, err)
+	return item
+}
+
+func TestEdgeSyncUseCase_RecordDevice(t *testing.T) {
+	outbox := mocks.NewMockSyncOutboxRepository(t)
+	useCase := NewEdgeSyncUseCase(outbox, mocks.NewMockSyncRemote(t), nil, createTestLoggerFactory(t))
+
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Sensor 1", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+
+	outbox.EXPECT().Enqueue(mock.Anything, mock.MatchedBy(func(item *entities.SyncItem) bool {
+		var payload entities.DeviceSyncPayload
+		require.NoError(t, json.Unmarshal(item.Payload, &payload))
+		return item.Kind == entities.SyncKindDevice &&
+			item.Key == "AA:BB:CC:DD:EE:FF" &&
+			payload.DeviceName == "Sensor 1" &&
+			item.ChangedAt.Equal(device.GetLastSeen().UTC())
+	})).Return(nil).Once()
+
+	require.NoError(t, useCase.RecordDevice(context.Background(), device))
+}
+
+func TestEdgeSyncUseCase_SyncOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("should mark applied and skipped items as synced", func(t *testing.T) {
+		outbox := mocks.NewMockSyncOutboxRepository(t)
+		remote := mocks.NewMockSyncRemote(t)
+		useCase := NewEdgeSyncUseCase(outbox, remote, &EdgeSyncConfig{BatchSize: 10, Interval: time.Second, MaxBackoff: time.Minute}, createTestLoggerFactory(t))
+
+		items := []*entities.SyncItem{
+			newTestSyncItem(t, entities.SyncKindDevice, "AA:BB:CC:DD:EE:FF", map[string]string{}, now),
+			newTestSyncItem(t, entities.SyncKindDevice, "AA:BB:CC:DD:EE:00", map[string]string{}, now),
+		}
+		outbox.EXPECT().ListPending(ctx, 10).Return(items, nil).Once()
+		remote.EXPECT().Push(ctx, items).Return(&entities.SyncApplyResult{
+			Applied: []string{items[0].ID},
+			Skipped: []string{items[1].ID},
+		}, nil).Once()
+		outbox.EXPECT().MarkSynced(ctx, []string{items[0].ID, items[1].ID}, mock.Anything).Return(nil).Once()
+		outbox.EXPECT().CountPending(ctx).Return(int64(0), nil).Once()
+
+		synced, err := useCase.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, synced)
+
+		status, err := useCase.Status(ctx)
+		require.NoError(t, err)
+		assert.True(t, status.Online)
+		assert.Equal(t, int64(2), status.TotalReplicated)
+		assert.NotNil(t, status.LastSuccessAt)
+	})
+
+	t.Run("should keep items pending when the cloud is unreachable", func(t *testing.T) {
+		outbox := mocks.NewMockSyncOutboxRepository(t)
+		remote := mocks.NewMockSyncRemote(t)
+		useCase := NewEdgeSyncUseCase(outbox, remote, nil, createTestLoggerFactory(t))
+
+		items := []*entities.SyncItem{newTestSyncItem(t, entities.SyncKindDevice, "AA:BB:CC:DD:EE:FF", map[string]string{}, now)}
+		outbox.EXPECT().ListPending(ctx, 200).Return(items, nil).Once()
+		remote.EXPECT().Push(ctx, items).Return(nil, errors.New("connection refused")).Once()
+		outbox.EXPECT().MarkFailed(ctx, []string{items[0].ID}, "connection refused").Return(nil).Once()
+		outbox.EXPECT().CountPending(ctx).Return(int64(1), nil).Once()
+
+		synced, err := useCase.SyncOnce(ctx)
+		assert.Error(t, err)
+		assert.Zero(t, synced)
+
+		status, err := useCase.Status(ctx)
+		require.NoError(t, err)
+		assert.False(t, status.Online)
+		assert.Equal(t, int64(1), status.PendingItems)
+		assert.Equal(t, int64(1), status.FailedAttempts)
+		assert.Contains(t, status.LastError, "connection refused")
+	})
+
+	t.Run("should record rejected items as failed", func(t *testing.T) {
+		outbox := mocks.NewMockSyncOutboxRepository(t)
+		remote := mocks.NewMockSyncRemote(t)
+		useCase := NewEdgeSyncUseCase(outbox, remote, nil, createTestLoggerFactory(t))
+
+		items := []*entities.SyncItem{newTestSyncItem(t, entities.SyncKindDevice, "AA:BB:CC:DD:EE:FF", map[string]string{}, now)}
+		outbox.EXPECT().ListPending(ctx, 200).Return(items, nil).Once()
+		remote.EXPECT().Push(ctx, items).Return(&entities.SyncApplyResult{Rejected: []string{items[0].ID}}, nil).Once()
+		outbox.EXPECT().MarkSynced(ctx, []string{}, mock.Anything).Return(nil).Once()
+		outbox.EXPECT().MarkFailed(ctx, []string{items[0].ID}, "rejected by cloud").Return(nil).Once()
+
+		synced, err := useCase.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, synced)
+	})
+}
+
+func TestSyncingDeviceRepository(t *testing.T) {
+	ctx := context.Background()
+	inner := mocks.NewMockDeviceRepository(t)
+	sync := mocks.NewMockEdgeSyncUseCase(t)
+	repo := NewSyncingDeviceRepository(inner, sync, createTestLoggerFactory(t))
+
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Sensor 1", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+
+	t.Run("should record created devices even when the outbox fails", func(t *testing.T) {
+		inner.EXPECT().Create(ctx, device).Return(nil).Once()
+		sync.EXPECT().RecordDevice(ctx, device).Return(errors.New("outbox unavailable")).Once()
+
+		assert.NoError(t, repo.Create(ctx, device))
+	})
+
+	t.Run("should not record failed updates", func(t *testing.T) {
+		inner.EXPECT().Update(ctx, device).Return(errors.New("update failed")).Once()
+
+		assert.Error(t, repo.Update(ctx, device))
+	})
+}
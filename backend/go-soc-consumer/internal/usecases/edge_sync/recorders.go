@@ -0,0 +1,98 @@
+package edgesync
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// The decorators below record successful local writes in the sync outbox.
+// Recording failures are logged and never fail the local write, which stays the source of truth on the edge.
+
+// syncingDeviceRepository records device changes for replication
+type syncingDeviceRepository struct {
+	repositoryports.DeviceRepository
+	sync          EdgeSyncUseCase
+	loggerFactory logger.LoggerFactory
+}
+
+// NewSyncingDeviceRepository wraps a device repository so creates and updates are replicated
+func NewSyncingDeviceRepository(inner repositoryports.DeviceRepository, sync EdgeSyncUseCase, loggerFactory logger.LoggerFactory) repositoryports.DeviceRepository {
+	return &syncingDeviceRepository{DeviceRepository: inner, sync: sync, loggerFactory: loggerFactory}
+}
+
+// Create persists the device and records it for replication
+func (r *syncingDeviceRepository) Create(ctx context.Context, device *entities.Device) error {
+	if err := r.DeviceRepository.Create(ctx, device); err != nil {
+		return err
+	}
+	logRecordError(r.loggerFactory, entities.SyncKindDevice, r.sync.RecordDevice(ctx, device))
+	return nil
+}
+
+// Update updates the device and records it for replication
+func (r *syncingDeviceRepository) Update(ctx context.Context, device *entities.Device) error {
+	if err := r.DeviceRepository.Update(ctx, device); err != nil {
+		return err
+	}
+	logRecordError(r.loggerFactory, entities.SyncKindDevice, r.sync.RecordDevice(ctx, device))
+	return nil
+}
+
+// syncingSensorRepository records sensor readings for replication
+type syncingSensorRepository struct {
+	repositoryports.SensorTemperatureHumidityRepository
+	sync          EdgeSyncUseCase
+	loggerFactory logger.LoggerFactory
+}
+
+// NewSyncingSensorRepository wraps a sensor repository so new readings are replicated
+func NewSyncingSensorRepository(inner repositoryports.SensorTemperatureHumidityRepository, sync EdgeSyncUseCase, loggerFactory logger.LoggerFactory) repositoryports.SensorTemperatureHumidityRepository {
+	return &syncingSensorRepository{SensorTemperatureHumidityRepository: inner, sync: sync, loggerFactory: loggerFactory}
+}
+
+// Create persists the reading and records it for replication
+func (r *syncingSensorRepository) Create(ctx context.Context, sensorData *entities.SensorTemperatureHumidity) error {
+	if err := r.SensorTemperatureHumidityRepository.Create(ctx, sensorData); err != nil {
+		return err
+	}
+	logRecordError(r.loggerFactory, entities.SyncKindSensorTemperatureHumidity, r.sync.RecordSensorReading(ctx, sensorData))
+	return nil
+}
+
+// syncingEventPublisher records device detected events for replication
+type syncingEventPublisher struct {
+	eventports.EventPublisher
+	sync          EdgeSyncUseCase
+	loggerFactory logger.LoggerFactory
+}
+
+// NewSyncingEventPublisher wraps an event publisher so device detected events are replicated.
+// Events are recorded even when publishing fails, since the edge may be running without NATS.
+func NewSyncingEventPublisher(inner eventports.EventPublisher, sync EdgeSyncUseCase, loggerFactory logger.LoggerFactory) eventports.EventPublisher {
+	return &syncingEventPublisher{EventPublisher: inner, sync: sync, loggerFactory: loggerFactory}
+}
+
+// Publish publishes the event and records device detected events for replication
+func (p *syncingEventPublisher) Publish(ctx context.Context, subject string, data interface{}) error {
+	if event, ok := data.(*entities.DeviceDetectedEvent); ok {
+		logRecordError(p.loggerFactory, entities.SyncKindDeviceDetectedEvent, p.sync.RecordDeviceDetected(ctx, event))
+	}
+	return p.EventPublisher.Publish(ctx, subject, data)
+}
+
+func logRecordError(loggerFactory logger.LoggerFactory, kind string, err error) {
+	if err == nil {
+		return
+	}
+	loggerFactory.Core().Error("sync_record_failed",
+		zap.Error(err),
+		zap.String("kind", kind),
+		zap.String("component", "edge_sync_usecase"),
+	)
+}
@@ -0,0 +1,231 @@
+package edgesync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// SyncApplyUseCase applies batches replicated from edge instances on the cloud instance.
+// Delivery is at-least-once, so applying the same item twice must be harmless:
+// device state uses last-writer-wins on the edge change time and older changes are skipped.
+type SyncApplyUseCase interface {
+	ApplyBatch(ctx context.Context, items []*entities.SyncItem) (*entities.SyncApplyResult, error)
+}
+
+// syncApplyUseCase implements the SyncApplyUseCase interface
+type syncApplyUseCase struct {
+	deviceRepo    repositoryports.DeviceRepository
+	sensorRepo    repositoryports.SensorTemperatureHumidityRepository
+	loggerFactory logger.LoggerFactory
+}
+
+// NewSyncApplyUseCase creates a new sync apply use case
+func NewSyncApplyUseCase(
+	deviceRepo repositoryports.DeviceRepository,
+	sensorRepo repositoryports.SensorTemperatureHumidityRepository,
+	loggerFactory logger.LoggerFactory,
+) SyncApplyUseCase {
+	return &syncApplyUseCase{
+		deviceRepo:    deviceRepo,
+		sensorRepo:    sensorRepo,
+		loggerFactory: loggerFactory,
+	}
+}
+
+// itemOutcome is the result of applying a single item
+type itemOutcome int
+
+const (
+	outcomeApplied itemOutcome = iota
+	outcomeSkipped
+	outcomeConflict
+)
+
+// ApplyBatch applies the items in order, devices first so readings and events find their device
+func (uc *syncApplyUseCase) ApplyBatch(ctx context.Context, items []*entities.SyncItem) (*entities.SyncApplyResult, error) {
+	if err := domainerrors.RequireInputs("sync items", items); err != nil {
+		return nil, err
+	}
+
+	ordered := make([]*entities.SyncItem, len(items))
+	copy(ordered, items)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		iDevice := ordered[i].Kind == entities.SyncKindDevice
+		jDevice := ordered[j].Kind == entities.SyncKindDevice
+		if iDevice != jDevice {
+			return iDevice
+		}
+		return ordered[i].ChangedAt.Before(ordered[j].ChangedAt)
+	})
+
+	result := &entities.SyncApplyResult{
+		Applied:  []string{},
+		Skipped:  []string{},
+		Rejected: []string{},
+	}
+
+	for _, item := range ordered {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		outcome, err := uc.apply(ctx, item)
+		if err != nil {
+			uc.loggerFactory.Core().Warn("sync_item_rejected",
+				zap.Error(err),
+				zap.String("item_id", item.ID),
+				zap.String("kind", item.Kind),
+				zap.String("key", item.Key),
+				zap.String("component", "sync_apply_usecase"),
+			)
+			result.Rejected = append(result.Rejected, item.ID)
+			continue
+		}
+
+		switch outcome {
+		case outcomeApplied:
+			result.Applied = append(result.Applied, item.ID)
+		case outcomeConflict:
+			result.Conflicts++
+			result.Skipped = append(result.Skipped, item.ID)
+		default:
+			result.Skipped = append(result.Skipped, item.ID)
+		}
+	}
+
+	uc.loggerFactory.Core().Info("sync_batch_applied",
+		zap.Int("items", len(items)),
+		zap.Int("applied", len(result.Applied)),
+		zap.Int("skipped", len(result.Skipped)),
+		zap.Int("rejected", len(result.Rejected)),
+		zap.Int("conflicts", result.Conflicts),
+		zap.String("component", "sync_apply_usecase"),
+	)
+
+	return result, nil
+}
+
+func (uc *syncApplyUseCase) apply(ctx context.Context, item *entities.SyncItem) (itemOutcome, error) {
+	if err := item.Validate(); err != nil {
+		return outcomeSkipped, fmt.Errorf("%w: %v", domainerrors.ErrInvalidSyncItem, err)
+	}
+
+	switch item.Kind {
+	case entities.SyncKindDevice:
+		var payload entities.DeviceSyncPayload
+		if err := json.Unmarshal(item.Payload, &payload); err != nil {
+			return outcomeSkipped, fmt.Errorf("%w: %v", domainerrors.ErrInvalidSyncItem, err)
+		}
+		return uc.applyDevice(ctx, &payload)
+	case entities.SyncKindSensorTemperatureHumidity:
+		var payload entities.SensorTemperatureHumiditySyncPayload
+		if err := json.Unmarshal(item.Payload, &payload); err != nil {
+			return outcomeSkipped, fmt.Errorf("%w: %v", domainerrors.ErrInvalidSyncItem, err)
+		}
+		return uc.applySensorReading(ctx, &payload)
+	default:
+		var payload entities.DeviceDetectedEventSyncPayload
+		if err := json.Unmarshal(item.Payload, &payload); err != nil {
+			return outcomeSkipped, fmt.Errorf("%w: %v", domainerrors.ErrInvalidSyncItem, err)
+		}
+		return uc.applyDeviceDetected(ctx, &payload)
+	}
+}
+
+// applyDevice creates or updates the device unless the cloud already holds a newer change
+func (uc *syncApplyUseCase) applyDevice(ctx context.Context, payload *entities.DeviceSyncPayload) (itemOutcome, error) {
+	existing, err := uc.deviceRepo.FindByMACAddress(ctx, payload.MACAddress)
+	if err != nil && !errors.Is(err, domainerrors.ErrDeviceNotFound) {
+		return outcomeSkipped, err
+	}
+
+	if existing == nil {
+		device, err := entities.NewDevice(payload.MACAddress, payload.DeviceName, payload.IPAddress, payload.LocationDescription)
+		if err != nil {
+			return outcomeSkipped, err
+		}
+		device.RegisteredAt = payload.RegisteredAt
+		if err := device.UpdateStatus(payload.Status); err != nil {
+			return outcomeSkipped, err
+		}
+		// UpdateStatus stamps the current time, keep the edge change time instead
+		device.LastSeen = payload.LastSeen
+		if err := uc.deviceRepo.Create(ctx, device); err != nil {
+			return outcomeSkipped, err
+		}
+		return outcomeApplied, nil
+	}
+
+	if !payload.LastSeen.After(existing.GetLastSeen()) {
+		if payload.LastSeen.Equal(existing.GetLastSeen()) {
+			return outcomeSkipped, nil
+		}
+		return outcomeConflict, nil
+	}
+
+	updated, err := existing.WithChanges(func(device *entities.Device) error {
+		device.SetDeviceName(payload.DeviceName)
+		device.SetIPAddress(payload.IPAddress)
+		device.LocationDescription = payload.LocationDescription
+		if err := device.UpdateStatus(payload.Status); err != nil {
+			return err
+		}
+		device.LastSeen = payload.LastSeen
+		return nil
+	})
+	if err != nil {
+		return outcomeSkipped, err
+	}
+	if err := uc.deviceRepo.Update(ctx, updated); err != nil {
+		return outcomeSkipped, err
+	}
+	return outcomeApplied, nil
+}
+
+// applySensorReading stores the reading with its original timestamp
+func (uc *syncApplyUseCase) applySensorReading(ctx context.Context, payload *entities.SensorTemperatureHumiditySyncPayload) (itemOutcome, error) {
+	reading, err := entities.NewSensorTemperatureHumidityAt(payload.MACAddress, payload.TemperatureCelsius, payload.HumidityPercent, payload.Timestamp)
+	if err != nil {
+		return outcomeSkipped, err
+	}
+	if err := uc.sensorRepo.Create(ctx, reading); err != nil {
+		return outcomeSkipped, err
+	}
+	return outcomeApplied, nil
+}
+
+// applyDeviceDetected refreshes the device presence when the detection is newer than the cloud state
+func (uc *syncApplyUseCase) applyDeviceDetected(ctx context.Context, payload *entities.DeviceDetectedEventSyncPayload) (itemOutcome, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, payload.MACAddress)
+	if err != nil {
+		return outcomeSkipped, err
+	}
+
+	if !payload.DetectedAt.After(device.GetLastSeen()) {
+		return outcomeSkipped, nil
+	}
+
+	updated, err := device.WithChanges(func(device *entities.Device) error {
+		device.SetIPAddress(payload.IPAddress)
+		device.MarkOnline()
+		device.LastSeen = payload.DetectedAt
+		return nil
+	})
+	if err != nil {
+		return outcomeSkipped, err
+	}
+	if err := uc.deviceRepo.Update(ctx, updated); err != nil {
+		return outcomeSkipped, err
+	}
+	return outcomeApplied, nil
+}
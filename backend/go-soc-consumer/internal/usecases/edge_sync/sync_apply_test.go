@@ -0,0 +1,115 @@
+package edgesync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func TestSyncApplyUseCase_ApplyBatch(t *testing.T) {
+	ctx := context.Background()
+	edgeTime := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	mac := "AA:BB:CC:DD:EE:FF"
+
+	devicePayload := entities.DeviceSyncPayload{
+		MACAddress:          mac,
+		DeviceName:          "Sensor 1",
+		IPAddress:           "192.168.1.10",
+		LocationDescription: "Greenhouse",
+		RegisteredAt:        edgeTime.Add(-time.Hour),
+		LastSeen:            edgeTime,
+		Status:              "online",
+	}
+
+	t.Run("should create unknown devices before applying readings", func(t *testing.T) {
+		deviceRepo := mocks.NewMockDeviceRepository(t)
+		sensorRepo := mocks.NewMockSensorTemperatureHumidityRepository(t)
+		useCase := NewSyncApplyUseCase(deviceRepo, sensorRepo, createTestLoggerFactory(t))
+
+		reading := newTestSyncItem(t, entities.SyncKindSensorTemperatureHumidity, mac, entities.SensorTemperatureHumiditySyncPayload{
+			MACAddress: mac, TemperatureCelsius: 22.5, HumidityPercent: 55, Timestamp: edgeTime.Add(time.Minute),
+		}, edgeTime.Add(time.Minute))
+		device := newTestSyncItem(t, entities.SyncKindDevice, mac, devicePayload, edgeTime)
+
+		var calls []string
+		deviceRepo.EXPECT().FindByMACAddress(ctx, mac).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+		deviceRepo.EXPECT().Create(ctx, mock.AnythingOfType("*entities.Device")).
+			Run(func(ctx context.Context, d *entities.Device) {
+				calls = append(calls, "device")
+				assert.Equal(t, edgeTime, d.GetLastSeen())
+				assert.Equal(t, "online", d.GetStatus())
+			}).Return(nil).Once()
+		sensorRepo.EXPECT().Create(ctx, mock.AnythingOfType("*entities.SensorTemperatureHumidity")).
+			Run(func(ctx context.Context, s *entities.SensorTemperatureHumidity) {
+				calls = append(calls, "reading")
+				assert.Equal(t, edgeTime.Add(time.Minute), s.Timestamp())
+			}).Return(nil).Once()
+
+		result, err := useCase.ApplyBatch(ctx, []*entities.SyncItem{reading, device})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"device", "reading"}, calls)
+		assert.ElementsMatch(t, []string{reading.ID, device.ID}, result.Applied)
+		assert.Empty(t, result.Rejected)
+	})
+
+	t.Run("should keep newer cloud state on conflict", func(t *testing.T) {
+		deviceRepo := mocks.NewMockDeviceRepository(t)
+		useCase := NewSyncApplyUseCase(deviceRepo, mocks.NewMockSensorTemperatureHumidityRepository(t), createTestLoggerFactory(t))
+
+		existing, err := entities.NewDevice(mac, "Renamed in cloud", "192.168.1.20", "Field A")
+		require.NoError(t, err)
+		existing.LastSeen = edgeTime.Add(time.Hour)
+
+		item := newTestSyncItem(t, entities.SyncKindDevice, mac, devicePayload, edgeTime)
+		deviceRepo.EXPECT().FindByMACAddress(ctx, mac).Return(existing, nil).Once()
+
+		result, err := useCase.ApplyBatch(ctx, []*entities.SyncItem{item})
+		require.NoError(t, err)
+		assert.Equal(t, []string{item.ID}, result.Skipped)
+		assert.Equal(t, 1, result.Conflicts)
+	})
+
+	t.Run("should update older cloud state", func(t *testing.T) {
+		deviceRepo := mocks.NewMockDeviceRepository(t)
+		useCase := NewSyncApplyUseCase(deviceRepo, mocks.NewMockSensorTemperatureHumidityRepository(t), createTestLoggerFactory(t))
+
+		existing, err := entities.NewDevice(mac, "Old name", "192.168.1.20", "Field A")
+		require.NoError(t, err)
+		existing.LastSeen = edgeTime.Add(-time.Hour)
+
+		item := newTestSyncItem(t, entities.SyncKindDevice, mac, devicePayload, edgeTime)
+		deviceRepo.EXPECT().FindByMACAddress(ctx, mac).Return(existing, nil).Once()
+		deviceRepo.EXPECT().Update(ctx, mock.MatchedBy(func(device *entities.Device) bool {
+			return device.GetDeviceName() == "Sensor 1" && device.GetLastSeen().Equal(edgeTime)
+		})).Return(nil).Once()
+
+		result, err := useCase.ApplyBatch(ctx, []*entities.SyncItem{item})
+		require.NoError(t, err)
+		assert.Equal(t, []string{item.ID}, result.Applied)
+		assert.Equal(t, "Old name", existing.GetDeviceName(), "the device read from the repository is not modified")
+	})
+
+	t.Run("should reject invalid and failing items", func(t *testing.T) {
+		sensorRepo := mocks.NewMockSensorTemperatureHumidityRepository(t)
+		useCase := NewSyncApplyUseCase(mocks.NewMockDeviceRepository(t), sensorRepo, createTestLoggerFactory(t))
+
+		invalid := &entities.SyncItem{ID: "invalid", Kind: "unknown", Key: mac, Payload: []byte("{}"), ChangedAt: edgeTime}
+		reading := newTestSyncItem(t, entities.SyncKindSensorTemperatureHumidity, mac, entities.SensorTemperatureHumiditySyncPayload{
+			MACAddress: mac, TemperatureCelsius: 22.5, HumidityPercent: 55, Timestamp: edgeTime,
+		}, edgeTime)
+		sensorRepo.EXPECT().Create(ctx, mock.Anything).Return(errors.New("insert failed")).Once()
+
+		result, err := useCase.ApplyBatch(ctx, []*entities.SyncItem{invalid, reading})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"invalid", reading.ID}, result.Rejected)
+	})
+}
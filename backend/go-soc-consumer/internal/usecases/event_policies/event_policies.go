@@ -0,0 +1,216 @@
+package eventpolicies
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/lru"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// maxThrottledKeys bounds the publishing times remembered for throttling; past it the least
+// recently published devices are forgotten and their next event is published
+const maxThrottledKeys = 50000
+
+// knownEventTypes are listed with their policy even when none is configured
+var knownEventTypes = []string{
+	events.DeviceDetectedEventType,
+	events.SecurityAlertEventType,
+	events.AlertEventType,
+	events.SystemHealthEventType,
+}
+
+// EventPoliciesUseCase decides which outbound events are published. Policies start from the
+// configuration and can be changed at runtime; changes are lost on restart.
+type EventPoliciesUseCase interface {
+	// Admit applies the policy of the event type to an event about the device, empty for events
+	// not about a device, and returns the outcome. Only published events count towards the
+	// minimum interval.
+	Admit(eventType, macAddress string) string
+
+	// List returns the policy of every known or configured event type, sorted by event type
+	List() []*entities.EventPolicy
+
+	// Set replaces the policy of its event type, failing with ErrInvalidEventPolicy when invalid
+	Set(policy *entities.EventPolicy) error
+
+	// Reset restores the configured policy of the event type and returns it
+	Reset(eventType string) *entities.EventPolicy
+}
+
+// useCaseImpl implements the EventPoliciesUseCase interface
+type useCaseImpl struct {
+	mu            sync.Mutex
+	configured    map[string]*entities.EventPolicy
+	policies      map[string]*entities.EventPolicy
+	lastPublished *lru.Cache[string, time.Time] // by event type and device
+	loggerFactory logger.LoggerFactory
+	decisions     *metrics.Vec
+	now           func() time.Time
+	random        func() float64
+}
+
+// NewEventPoliciesUseCase creates an event policies use case starting from the configured
+// policies; event types without one are all published
+func NewEventPoliciesUseCase(configured []*entities.EventPolicy, loggerFactory logger.LoggerFactory) (EventPoliciesUseCase, error) {
+	uc := &useCaseImpl{
+		configured:    make(map[string]*entities.EventPolicy, len(configured)),
+		policies:      make(map[string]*entities.EventPolicy, len(configured)),
+		lastPublished: lru.New[string, time.Time](maxThrottledKeys),
+		loggerFactory: loggerFactory,
+		decisions:     metrics.NewCounterVec("event_policy_decisions_total", "Outbound events by event type and policy outcome", "event_type", "outcome"),
+		now:           time.Now,
+		random:        rand.Float64,
+	}
+	for _, policy := range configured {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidEventPolicy, err)
+		}
+		uc.configured[policy.EventType] = policy
+		copied := *policy
+		uc.policies[policy.EventType] = &copied
+	}
+	return uc, nil
+}
+
+// Admit decides whether the event is published
+func (uc *useCaseImpl) Admit(eventType, macAddress string) string {
+	uc.mu.Lock()
+	outcome := uc.admitLocked(eventType, macAddress)
+	uc.mu.Unlock()
+
+	uc.decisions.Inc(eventType, outcome)
+	return outcome
+}
+
+func (uc *useCaseImpl) admitLocked(eventType, macAddress string) string {
+	policy, ok := uc.policies[eventType]
+	if !ok {
+		return entities.EventPolicyPublished
+	}
+	if !policy.Enabled {
+		return entities.EventPolicyDisabled
+	}
+	if policy.SampleRate < 1 && uc.random() >= policy.SampleRate {
+		return entities.EventPolicySampledOut
+	}
+	if policy.MinInterval > 0 {
+		key := eventType + "|" + macAddress
+		now := uc.now()
+		if last, ok := uc.lastPublished.Get(key); ok && now.Sub(last) < policy.MinInterval {
+			return entities.EventPolicyThrottled
+		}
+		uc.lastPublished.Put(key, now)
+	}
+	return entities.EventPolicyPublished
+}
+
+// List returns the current policies
+func (uc *useCaseImpl) List() []*entities.EventPolicy {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	policies := make([]*entities.EventPolicy, 0, len(uc.policies)+len(knownEventTypes))
+	for _, policy := range uc.policies {
+		copied := *policy
+		policies = append(policies, &copied)
+	}
+	for _, eventType := range knownEventTypes {
+		if _, ok := uc.policies[eventType]; !ok {
+			policies = append(policies, entities.DefaultEventPolicy(eventType))
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].EventType < policies[j].EventType })
+	return policies
+}
+
+// Set replaces the policy of an event type
+func (uc *useCaseImpl) Set(policy *entities.EventPolicy) error {
+	if err := domainerrors.RequireInput("event policy", policy); err != nil {
+		return err
+	}
+
+	policy.EventType = strings.TrimSpace(policy.EventType)
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", domainerrors.ErrInvalidEventPolicy, err)
+	}
+
+	uc.mu.Lock()
+	copied := *policy
+	uc.policies[policy.EventType] = &copied
+	uc.forgetLocked(policy.EventType)
+	uc.mu.Unlock()
+
+	uc.loggerFactory.Core().Info("event_policy_changed",
+		zap.String("event_type", policy.EventType),
+		zap.Bool("enabled", policy.Enabled),
+		zap.Float64("sample_rate", policy.SampleRate),
+		zap.Duration("min_interval", policy.MinInterval),
+		zap.String("component", "event_policies_usecase"),
+	)
+	return nil
+}
+
+// Reset restores the configured policy of an event type
+func (uc *useCaseImpl) Reset(eventType string) *entities.EventPolicy {
+	eventType = strings.TrimSpace(eventType)
+
+	uc.mu.Lock()
+	policy := entities.DefaultEventPolicy(eventType)
+	if configured, ok := uc.configured[eventType]; ok {
+		copied := *configured
+		uc.policies[eventType] = &copied
+		policy = &copied
+	} else {
+		delete(uc.policies, eventType)
+	}
+	uc.forgetLocked(eventType)
+	result := *policy
+	uc.mu.Unlock()
+
+	uc.loggerFactory.Core().Info("event_policy_reset",
+		zap.String("event_type", eventType),
+		zap.String("component", "event_policies_usecase"),
+	)
+	return &result
+}
+
+// forgetLocked drops the publishing times of an event type whose policy changed
+func (uc *useCaseImpl) forgetLocked(eventType string) {
+	prefix := eventType + "|"
+	uc.lastPublished.DeleteFunc(func(key string, _ time.Time) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	uc.mu.Lock()
+	tracked, evictions := uc.lastPublished.Len(), uc.lastPublished.Evictions()
+	uc.mu.Unlock()
+
+	return append(uc.decisions.Collect(),
+		metrics.Family{Name: "event_policy_throttle_entries", Help: "Publishing times remembered for event throttling", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(tracked)}}},
+		metrics.Family{Name: "event_policy_throttle_evictions_total", Help: "Publishing times forgotten to stay within the throttling size limit", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(evictions)}}},
+	)
+}
+
+// MetricsCollector returns the event policies metrics collector
+func MetricsCollector(useCase EventPoliciesUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
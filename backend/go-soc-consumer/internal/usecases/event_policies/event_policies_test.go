@@ -0,0 +1,122 @@
+package eventpolicies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestUseCase(t *testing.T, configured ...*entities.EventPolicy) *useCaseImpl {
+	useCase, err := NewEventPoliciesUseCase(configured, createTestLoggerFactory(t))
+	require.NoError(t, err)
+	return useCase.(*useCaseImpl)
+}
+
+func TestEventPoliciesUseCase_Admit(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should publish event types without a policy", func(t *testing.T) {
+		useCase := newTestUseCase(t)
+
+		assert.Equal(t, entities.EventPolicyPublished, useCase.Admit(events.AlertEventType, testMAC))
+		assert.Equal(t, float64(1), useCase.decisions.Value(events.AlertEventType, entities.EventPolicyPublished))
+	})
+
+	t.Run("should drop disabled event types", func(t *testing.T) {
+		useCase := newTestUseCase(t, &entities.EventPolicy{EventType: events.SystemHealthEventType, SampleRate: 1})
+
+		assert.Equal(t, entities.EventPolicyDisabled, useCase.Admit(events.SystemHealthEventType, ""))
+	})
+
+	t.Run("should sample events", func(t *testing.T) {
+		useCase := newTestUseCase(t, &entities.EventPolicy{EventType: events.DeviceDetectedEventType, Enabled: true, SampleRate: 0.25})
+		draws := []float64{0.1, 0.5}
+		useCase.random = func() float64 {
+			draw := draws[0]
+			draws = draws[1:]
+			return draw
+		}
+
+		assert.Equal(t, entities.EventPolicyPublished, useCase.Admit(events.DeviceDetectedEventType, testMAC))
+		assert.Equal(t, entities.EventPolicySampledOut, useCase.Admit(events.DeviceDetectedEventType, testMAC))
+	})
+
+	t.Run("should throttle each device separately", func(t *testing.T) {
+		useCase := newTestUseCase(t, &entities.EventPolicy{EventType: events.DeviceDetectedEventType, Enabled: true, SampleRate: 1, MinInterval: time.Minute})
+		current := now
+		useCase.now = func() time.Time { return current }
+
+		assert.Equal(t, entities.EventPolicyPublished, useCase.Admit(events.DeviceDetectedEventType, testMAC))
+		assert.Equal(t, entities.EventPolicyPublished, useCase.Admit(events.DeviceDetectedEventType, "11:22:33:44:55:66"))
+		current = now.Add(30 * time.Second)
+		assert.Equal(t, entities.EventPolicyThrottled, useCase.Admit(events.DeviceDetectedEventType, testMAC))
+		current = now.Add(time.Minute)
+		assert.Equal(t, entities.EventPolicyPublished, useCase.Admit(events.DeviceDetectedEventType, testMAC))
+		assert.Equal(t, float64(1), useCase.decisions.Value(events.DeviceDetectedEventType, entities.EventPolicyThrottled))
+	})
+}
+
+func TestEventPoliciesUseCase_SetAndReset(t *testing.T) {
+	configured := &entities.EventPolicy{EventType: events.AlertEventType, Enabled: true, SampleRate: 1, MinInterval: time.Minute}
+	useCase := newTestUseCase(t, configured)
+
+	err := useCase.Set(&entities.EventPolicy{EventType: events.AlertEventType, SampleRate: 2})
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidEventPolicy)
+
+	require.NoError(t, useCase.Set(&entities.EventPolicy{EventType: " " + events.AlertEventType + " ", SampleRate: 1}))
+	assert.Equal(t, entities.EventPolicyDisabled, useCase.Admit(events.AlertEventType, testMAC))
+
+	require.NoError(t, useCase.Set(&entities.EventPolicy{EventType: "telemetry", Enabled: true, SampleRate: 0.1}))
+	policies := useCase.List()
+	types := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		types = append(types, policy.EventType)
+	}
+	assert.Equal(t, []string{events.AlertEventType, events.DeviceDetectedEventType, events.SecurityAlertEventType, events.SystemHealthEventType, "telemetry"}, types)
+	assert.False(t, policies[0].Enabled)
+
+	assert.Equal(t, configured, useCase.Reset(events.AlertEventType), "restores the configured policy")
+	assert.Equal(t, entities.EventPolicyPublished, useCase.Admit(events.AlertEventType, testMAC))
+	assert.Equal(t, entities.DefaultEventPolicy("telemetry"), useCase.Reset("telemetry"))
+	assert.Len(t, useCase.List(), 4)
+}
+
+func TestNewEventPoliciesUseCase_InvalidPolicy(t *testing.T) {
+	_, err := NewEventPoliciesUseCase([]*entities.EventPolicy{{EventType: events.AlertEventType, SampleRate: -1}}, createTestLoggerFactory(t))
+
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidEventPolicy)
+}
+
+func TestPolicyEventPublisher(t *testing.T) {
+	ctx := context.Background()
+	inner := mocks.NewMockEventPublisher(t)
+	useCase := newTestUseCase(t, &entities.EventPolicy{EventType: events.SystemHealthEventType, SampleRate: 1})
+	publisher := NewPolicyEventPublisher(inner, useCase, createTestLoggerFactory(t))
+
+	detected := &entities.DeviceDetectedEvent{MACAddress: testMAC, EventType: events.DeviceDetectedEventType}
+	inner.EXPECT().Publish(mock.Anything, events.DeviceDetectedSubject, detected).Return(nil).Once()
+	require.NoError(t, publisher.Publish(ctx, events.DeviceDetectedSubject, detected))
+
+	require.NoError(t, publisher.Publish(ctx, events.SystemHealthSubject, &entities.HealthReport{EventType: events.SystemHealthEventType}), "dropped events are not errors")
+
+	inner.EXPECT().Publish(mock.Anything, "custom", "payload").Return(nil).Once()
+	require.NoError(t, publisher.Publish(ctx, "custom", "payload"), "unknown events are published unfiltered")
+}
@@ -0,0 +1,61 @@
+package eventpolicies
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// policyEventPublisher applies the event policies before publishing
+type policyEventPublisher struct {
+	eventports.EventPublisher
+	policies      EventPoliciesUseCase
+	loggerFactory logger.LoggerFactory
+}
+
+// NewPolicyEventPublisher wraps an event publisher so events left out by their policy are dropped.
+// Dropped events are not errors for the caller.
+func NewPolicyEventPublisher(inner eventports.EventPublisher, policies EventPoliciesUseCase, loggerFactory logger.LoggerFactory) eventports.EventPublisher {
+	return &policyEventPublisher{EventPublisher: inner, policies: policies, loggerFactory: loggerFactory}
+}
+
+// Publish publishes the event when its policy admits it
+func (p *policyEventPublisher) Publish(ctx context.Context, subject string, data interface{}) error {
+	eventType, macAddress, ok := eventKey(data)
+	if !ok {
+		return p.EventPublisher.Publish(ctx, subject, data)
+	}
+
+	if outcome := p.policies.Admit(eventType, macAddress); outcome != entities.EventPolicyPublished {
+		p.loggerFactory.Core().Debug("event_dropped_by_policy",
+			zap.String("subject", subject),
+			zap.String("event_type", eventType),
+			zap.String("mac_address", macAddress),
+			zap.String("outcome", outcome),
+			zap.String("component", "event_policies_usecase"),
+		)
+		return nil
+	}
+	return p.EventPublisher.Publish(ctx, subject, data)
+}
+
+// eventKey returns the event type of a published event and the device it is about, empty for
+// events not about a device; ok is false for unknown events, which are published unfiltered
+func eventKey(data interface{}) (eventType, macAddress string, ok bool) {
+	switch event := data.(type) {
+	case *entities.DeviceDetectedEvent:
+		return event.EventType, event.MACAddress, true
+	case *entities.Alert:
+		return event.EventType, event.MACAddress, true
+	case *entities.SecurityAlert:
+		return event.EventType, "", true
+	case *entities.HealthReport:
+		return event.EventType, "", true
+	default:
+		return "", "", false
+	}
+}
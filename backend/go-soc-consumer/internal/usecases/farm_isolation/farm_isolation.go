@@ -0,0 +1,80 @@
+package farmisolation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// FarmIsolationUseCase keeps devices of one farm from publishing in the namespace of another
+type FarmIsolationUseCase interface {
+	// Verify checks the farm namespace carried by the context against the farm the device registered under.
+	// Messages on the shared topics, unknown devices and devices without a farm are accepted.
+	Verify(ctx context.Context, macAddress string) error
+}
+
+// useCaseImpl implements the FarmIsolationUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	loggerFactory logger.LoggerFactory
+	rejections    *metrics.Vec
+}
+
+// NewFarmIsolationUseCase creates a new farm isolation use case
+func NewFarmIsolationUseCase(deviceRepo repositoryports.DeviceRepository, loggerFactory logger.LoggerFactory) FarmIsolationUseCase {
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		loggerFactory: loggerFactory,
+		rejections:    metrics.NewCounterVec("farm_isolation_rejections_total", "Messages rejected because the device belongs to another farm", "farm_id"),
+	}
+}
+
+// Verify checks the farm namespace of the message against the device's farm
+func (uc *useCaseImpl) Verify(ctx context.Context, macAddress string) error {
+	farmID := eventports.FarmIDFromContext(ctx)
+	if farmID == "" {
+		return nil
+	}
+
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrDeviceNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to load device farm: %w", err)
+	}
+
+	if err := device.VerifyFarm(farmID); err != nil {
+		uc.rejections.Inc(farmID)
+		uc.loggerFactory.Core().Warn("device_farm_rejected",
+			zap.String("mac_address", device.GetID()),
+			zap.String("farm_id", farmID),
+			zap.String("device_farm_id", device.FarmID),
+			zap.String("component", "farm_isolation_usecase"),
+		)
+		return fmt.Errorf("%w: %v", domainerrors.ErrDeviceFarmMismatch, err)
+	}
+	return nil
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return uc.rejections.Collect()
+}
+
+// MetricsCollector returns the farm isolation metrics collector
+func MetricsCollector(useCase FarmIsolationUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package farmisolation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func farmDevice(t *testing.T, farmID string) *entities.Device {
+	device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	require.NoError(t, device.AssignFarm(farmID))
+	return device
+}
+
+func TestFarmIsolationUseCase_Verify(t *testing.T) {
+	farmCtx := eventports.ContextWithFarmID(context.Background(), "farm-a")
+
+	t.Run("should accept messages on the shared topics", func(t *testing.T) {
+		useCase := NewFarmIsolationUseCase(mocks.NewMockDeviceRepository(t), createTestLoggerFactory(t))
+
+		assert.NoError(t, useCase.Verify(context.Background(), testMAC))
+	})
+
+	t.Run("should accept unknown devices", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewFarmIsolationUseCase(repo, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+		assert.NoError(t, useCase.Verify(farmCtx, "aa:bb:cc:dd:ee:ff"))
+	})
+
+	t.Run("should accept devices of the same farm", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewFarmIsolationUseCase(repo, createTestLoggerFactory(t))
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(farmDevice(t, "farm-a"), nil).Once()
+
+		assert.NoError(t, useCase.Verify(farmCtx, testMAC))
+	})
+
+	t.Run("should reject devices of another farm and count the rejection", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := NewFarmIsolationUseCase(repo, createTestLoggerFactory(t)).(*useCaseImpl)
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(farmDevice(t, "farm-b"), nil).Once()
+
+		assert.ErrorIs(t, useCase.Verify(farmCtx, testMAC), domainerrors.ErrDeviceFarmMismatch)
+		assert.Equal(t, float64(1), useCase.rejections.Value("farm-a"))
+	})
+}
+
+func TestFarmScopedSensorDataUseCase(t *testing.T) {
+	farms := mocks.NewMockFarmIsolationUseCase(t)
+	inner := mocks.NewMockSensorDataUseCase(t)
+	useCase := NewFarmScopedSensorDataUseCase(inner, farms)
+	data, err := entities.NewSensorTemperatureHumidity(testMAC, 21.5, 60)
+	require.NoError(t, err)
+
+	farms.EXPECT().Verify(mock.Anything, testMAC).Return(domainerrors.ErrDeviceFarmMismatch).Once()
+	assert.ErrorIs(t, useCase.StoreSensorData(context.Background(), data), domainerrors.ErrDeviceFarmMismatch)
+
+	farms.EXPECT().Verify(mock.Anything, testMAC).Return(nil).Once()
+	inner.EXPECT().StoreSensorData(mock.Anything, data).Return(nil).Once()
+	assert.NoError(t, useCase.StoreSensorData(context.Background(), data))
+}
@@ -0,0 +1,78 @@
+package farmisolation
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// farmScopedDeviceRegistrationUseCase rejects registrations published in the namespace of another farm
+type farmScopedDeviceRegistrationUseCase struct {
+	deviceregistration.DeviceRegistrationUseCase
+	farms FarmIsolationUseCase
+}
+
+// NewFarmScopedDeviceRegistrationUseCase wraps a registration use case with farm isolation
+func NewFarmScopedDeviceRegistrationUseCase(inner deviceregistration.DeviceRegistrationUseCase, farms FarmIsolationUseCase) deviceregistration.DeviceRegistrationUseCase {
+	return &farmScopedDeviceRegistrationUseCase{DeviceRegistrationUseCase: inner, farms: farms}
+}
+
+// RegisterDevice verifies the farm before registering
+func (uc *farmScopedDeviceRegistrationUseCase) RegisterDevice(ctx context.Context, message *entities.DeviceRegistrationMessage) error {
+	if err := domainerrors.RequireInput("registration message", message); err != nil {
+		return err
+	}
+
+	if err := uc.farms.Verify(ctx, message.MACAddress); err != nil {
+		return err
+	}
+	return uc.DeviceRegistrationUseCase.RegisterDevice(ctx, message)
+}
+
+// farmScopedSensorDataUseCase rejects readings published in the namespace of another farm
+type farmScopedSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	farms FarmIsolationUseCase
+}
+
+// NewFarmScopedSensorDataUseCase wraps a sensor data use case with farm isolation
+func NewFarmScopedSensorDataUseCase(inner sensordata.SensorDataUseCase, farms FarmIsolationUseCase) sensordata.SensorDataUseCase {
+	return &farmScopedSensorDataUseCase{SensorDataUseCase: inner, farms: farms}
+}
+
+// StoreSensorData verifies the farm before storing the reading
+func (uc *farmScopedSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if err := domainerrors.RequireInput("sensor data", data); err != nil {
+		return err
+	}
+
+	if err := uc.farms.Verify(ctx, data.MacAddress()); err != nil {
+		return err
+	}
+	return uc.SensorDataUseCase.StoreSensorData(ctx, data)
+}
+
+// farmScopedMeasurementUseCase rejects measurements published in the namespace of another farm
+type farmScopedMeasurementUseCase struct {
+	measurements.MeasurementUseCase
+	farms FarmIsolationUseCase
+}
+
+// NewFarmScopedMeasurementUseCase wraps a measurement use case with farm isolation
+func NewFarmScopedMeasurementUseCase(inner measurements.MeasurementUseCase, farms FarmIsolationUseCase) measurements.MeasurementUseCase {
+	return &farmScopedMeasurementUseCase{MeasurementUseCase: inner, farms: farms}
+}
+
+// StoreMeasurements verifies the farm before storing the measurements, which all come from one device
+func (uc *farmScopedMeasurementUseCase) StoreMeasurements(ctx context.Context, values []*entities.Measurement) error {
+	if len(values) > 0 {
+		if err := uc.farms.Verify(ctx, values[0].MACAddress); err != nil {
+			return err
+		}
+	}
+	return uc.MeasurementUseCase.StoreMeasurements(ctx, values)
+}
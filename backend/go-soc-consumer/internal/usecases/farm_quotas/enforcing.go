@@ -0,0 +1,79 @@
+package farmquotas
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// quotaDeviceRegistrationUseCase rejects registrations that would exceed the device quota of their farm
+type quotaDeviceRegistrationUseCase struct {
+	deviceregistration.DeviceRegistrationUseCase
+	quotas FarmQuotasUseCase
+}
+
+// NewQuotaDeviceRegistrationUseCase wraps a registration use case with the device quota of the farms
+func NewQuotaDeviceRegistrationUseCase(inner deviceregistration.DeviceRegistrationUseCase, quotas FarmQuotasUseCase) deviceregistration.DeviceRegistrationUseCase {
+	return &quotaDeviceRegistrationUseCase{DeviceRegistrationUseCase: inner, quotas: quotas}
+}
+
+// RegisterDevice checks the device quota before registering
+func (uc *quotaDeviceRegistrationUseCase) RegisterDevice(ctx context.Context, message *entities.DeviceRegistrationMessage) error {
+	if err := domainerrors.RequireInput("registration message", message); err != nil {
+		return err
+	}
+
+	if err := uc.quotas.AdmitDevice(ctx, message.MACAddress); err != nil {
+		return err
+	}
+	return uc.DeviceRegistrationUseCase.RegisterDevice(ctx, message)
+}
+
+// quotaSensorDataUseCase rejects readings past the message quota of their farm
+type quotaSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	quotas FarmQuotasUseCase
+}
+
+// NewQuotaSensorDataUseCase wraps a sensor data use case with the message quota of the farms
+func NewQuotaSensorDataUseCase(inner sensordata.SensorDataUseCase, quotas FarmQuotasUseCase) sensordata.SensorDataUseCase {
+	return &quotaSensorDataUseCase{SensorDataUseCase: inner, quotas: quotas}
+}
+
+// StoreSensorData counts the reading against the message quota before storing it
+func (uc *quotaSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if err := domainerrors.RequireInput("sensor data", data); err != nil {
+		return err
+	}
+
+	if err := uc.quotas.AdmitMessage(ctx); err != nil {
+		return err
+	}
+	return uc.SensorDataUseCase.StoreSensorData(ctx, data)
+}
+
+// quotaMeasurementUseCase rejects measurements past the message quota of their farm
+type quotaMeasurementUseCase struct {
+	measurements.MeasurementUseCase
+	quotas FarmQuotasUseCase
+}
+
+// NewQuotaMeasurementUseCase wraps a measurement use case with the message quota of the farms
+func NewQuotaMeasurementUseCase(inner measurements.MeasurementUseCase, quotas FarmQuotasUseCase) measurements.MeasurementUseCase {
+	return &quotaMeasurementUseCase{MeasurementUseCase: inner, quotas: quotas}
+}
+
+// StoreMeasurements counts the message, which carries all the measurements, against the message
+// quota before storing them
+func (uc *quotaMeasurementUseCase) StoreMeasurements(ctx context.Context, values []*entities.Measurement) error {
+	if len(values) > 0 {
+		if err := uc.quotas.AdmitMessage(ctx); err != nil {
+			return err
+		}
+	}
+	return uc.MeasurementUseCase.StoreMeasurements(ctx, values)
+}
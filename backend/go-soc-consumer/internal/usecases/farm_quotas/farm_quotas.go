@@ -0,0 +1,275 @@
+package farmquotas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/lru"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// maxTrackedFarms bounds the farms whose messages are counted; past it the least recently active
+// farms are forgotten and start the minute with a fresh count
+const maxTrackedFarms = 10000
+
+// FarmQuotasUseCase enforces the quotas of the farms on the messages published in their namespace.
+// Quotas start from the configuration and can be overridden at runtime; overrides are lost on restart.
+type FarmQuotasUseCase interface {
+	// AdmitDevice checks a registration published in the farm namespace carried by the context,
+	// failing with ErrFarmQuotaExceeded when it would add a device to a farm at its device quota.
+	// Devices already registered under the farm and messages on the shared topics are admitted.
+	AdmitDevice(ctx context.Context, macAddress string) error
+
+	// AdmitMessage counts a telemetry message published in the farm namespace carried by the
+	// context, failing with ErrFarmQuotaExceeded once the farm used up its messages of the minute
+	AdmitMessage(ctx context.Context) error
+
+	// Get returns the quota applied to the farm and its current usage
+	Get(ctx context.Context, farmID string) (*entities.FarmQuotaUsage, error)
+
+	// Set overrides the quota of its farm, failing with ErrInvalidFarmQuota when invalid
+	Set(quota *entities.FarmQuota) error
+
+	// Reset removes the override of the farm and returns the quota applied again
+	Reset(farmID string) *entities.FarmQuota
+}
+
+// Config holds the farm quotas
+type Config struct {
+	Default    entities.FarmQuota    // applied to farms without a quota of their own, its farm ID is ignored
+	Configured []*entities.FarmQuota // quotas of individual farms
+}
+
+// messageWindow counts the messages of a farm in one minute
+type messageWindow struct {
+	start time.Time
+	count int
+}
+
+// useCaseImpl implements the FarmQuotasUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	loggerFactory logger.LoggerFactory
+	rejections    *metrics.Vec
+	now           func() time.Time
+
+	mu         sync.Mutex
+	defaults   entities.FarmQuota
+	configured map[string]*entities.FarmQuota
+	overrides  map[string]*entities.FarmQuota
+	windows    *lru.Cache[string, *messageWindow]
+}
+
+// NewFarmQuotasUseCase creates a farm quotas use case, failing with ErrInvalidFarmQuota on an invalid configured quota
+func NewFarmQuotasUseCase(config *Config, deviceRepo repositoryports.DeviceRepository, loggerFactory logger.LoggerFactory) (FarmQuotasUseCase, error) {
+	uc := &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		loggerFactory: loggerFactory,
+		rejections:    metrics.NewCounterVec("farm_quota_rejections_total", "Messages rejected because their farm exceeded a quota", "farm_id", "quota"),
+		now:           time.Now,
+		defaults:      config.Default,
+		configured:    make(map[string]*entities.FarmQuota, len(config.Configured)),
+		overrides:     make(map[string]*entities.FarmQuota),
+		windows:       lru.New[string, *messageWindow](maxTrackedFarms),
+	}
+	if uc.defaults.MaxDevices < 0 || uc.defaults.MaxMessagesPerMinute < 0 {
+		return nil, fmt.Errorf("%w: default limits cannot be negative", domainerrors.ErrInvalidFarmQuota)
+	}
+	for _, quota := range config.Configured {
+		if err := quota.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidFarmQuota, err)
+		}
+		copied := *quota
+		uc.configured[quota.FarmID] = &copied
+	}
+	return uc, nil
+}
+
+// AdmitDevice checks the device quota of the farm before a registration
+func (uc *useCaseImpl) AdmitDevice(ctx context.Context, macAddress string) error {
+	farmID := eventports.FarmIDFromContext(ctx)
+	if farmID == "" {
+		return nil
+	}
+	uc.mu.Lock()
+	quota, _ := uc.quotaLocked(farmID)
+	uc.mu.Unlock()
+	if quota.MaxDevices == 0 {
+		return nil
+	}
+
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	switch {
+	case err == nil && device.FarmID == farmID:
+		return nil
+	case err != nil && !errors.Is(err, domainerrors.ErrDeviceNotFound):
+		return fmt.Errorf("failed to load device farm: %w", err)
+	}
+
+	devices, err := uc.deviceRepo.CountByFarm(ctx, farmID)
+	if err != nil {
+		return fmt.Errorf("failed to count farm devices: %w", err)
+	}
+	if devices >= quota.MaxDevices {
+		uc.reject(farmID, entities.FarmQuotaDevices, quota.MaxDevices, zap.String("mac_address", macAddress))
+		return fmt.Errorf("%w: farm %s already has %d of %d devices", domainerrors.ErrFarmQuotaExceeded, farmID, devices, quota.MaxDevices)
+	}
+	return nil
+}
+
+// AdmitMessage counts a telemetry message against the message quota of the farm
+func (uc *useCaseImpl) AdmitMessage(ctx context.Context) error {
+	farmID := eventports.FarmIDFromContext(ctx)
+	if farmID == "" {
+		return nil
+	}
+
+	uc.mu.Lock()
+	quota, _ := uc.quotaLocked(farmID)
+	window := uc.windowLocked(farmID)
+	window.count++
+	count := window.count
+	uc.mu.Unlock()
+
+	if quota.MaxMessagesPerMinute == 0 || count <= quota.MaxMessagesPerMinute {
+		return nil
+	}
+	// Only the first rejection of the minute is logged, the others are counted
+	if count == quota.MaxMessagesPerMinute+1 {
+		uc.reject(farmID, entities.FarmQuotaMessages, quota.MaxMessagesPerMinute)
+	} else {
+		uc.rejections.Inc(farmID, entities.FarmQuotaMessages)
+	}
+	return fmt.Errorf("%w: farm %s exceeded %d messages per minute", domainerrors.ErrFarmQuotaExceeded, farmID, quota.MaxMessagesPerMinute)
+}
+
+// Get returns the quota of the farm and its usage
+func (uc *useCaseImpl) Get(ctx context.Context, farmID string) (*entities.FarmQuotaUsage, error) {
+	if err := entities.ValidateFarmID(farmID); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidFarmQuota, err)
+	}
+	devices, err := uc.deviceRepo.CountByFarm(ctx, farmID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count farm devices: %w", err)
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	quota, source := uc.quotaLocked(farmID)
+	usage := &entities.FarmQuotaUsage{Quota: &quota, Source: source, Devices: devices}
+	if window, ok := uc.windows.Get(farmID); ok && window.start.Equal(uc.now().Truncate(time.Minute)) {
+		usage.MessagesThisMinute = window.count
+	}
+	return usage, nil
+}
+
+// Set overrides the quota of a farm
+func (uc *useCaseImpl) Set(quota *entities.FarmQuota) error {
+	if err := domainerrors.RequireInput("farm quota", quota); err != nil {
+		return err
+	}
+
+	quota.FarmID = strings.TrimSpace(quota.FarmID)
+	if err := quota.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", domainerrors.ErrInvalidFarmQuota, err)
+	}
+
+	uc.mu.Lock()
+	copied := *quota
+	uc.overrides[quota.FarmID] = &copied
+	uc.mu.Unlock()
+
+	uc.loggerFactory.Core().Info("farm_quota_changed",
+		zap.String("farm_id", quota.FarmID),
+		zap.Int("max_devices", quota.MaxDevices),
+		zap.Int("max_messages_per_minute", quota.MaxMessagesPerMinute),
+		zap.String("component", "farm_quotas_usecase"),
+	)
+	return nil
+}
+
+// Reset removes the override of a farm
+func (uc *useCaseImpl) Reset(farmID string) *entities.FarmQuota {
+	farmID = strings.TrimSpace(farmID)
+
+	uc.mu.Lock()
+	delete(uc.overrides, farmID)
+	quota, _ := uc.quotaLocked(farmID)
+	uc.mu.Unlock()
+
+	uc.loggerFactory.Core().Info("farm_quota_reset",
+		zap.String("farm_id", farmID),
+		zap.String("component", "farm_quotas_usecase"),
+	)
+	return &quota
+}
+
+// quotaLocked returns a copy of the quota applied to the farm and where it comes from
+func (uc *useCaseImpl) quotaLocked(farmID string) (entities.FarmQuota, string) {
+	if quota, ok := uc.overrides[farmID]; ok {
+		return *quota, entities.FarmQuotaSourceOverride
+	}
+	if quota, ok := uc.configured[farmID]; ok {
+		return *quota, entities.FarmQuotaSourceConfigured
+	}
+	quota := uc.defaults
+	quota.FarmID = farmID
+	return quota, entities.FarmQuotaSourceDefault
+}
+
+// windowLocked returns the message count of the farm for the current minute
+func (uc *useCaseImpl) windowLocked(farmID string) *messageWindow {
+	start := uc.now().Truncate(time.Minute)
+	window, ok := uc.windows.Get(farmID)
+	if !ok {
+		window = &messageWindow{start: start}
+		uc.windows.Put(farmID, window)
+	} else if !window.start.Equal(start) {
+		window.start, window.count = start, 0
+	}
+	return window
+}
+
+// reject counts and logs a message rejected by a quota of its farm
+func (uc *useCaseImpl) reject(farmID, quota string, limit int, fields ...zap.Field) {
+	uc.rejections.Inc(farmID, quota)
+	uc.loggerFactory.Core().Warn("farm_quota_exceeded", append(fields,
+		zap.String("farm_id", farmID),
+		zap.String("quota", quota),
+		zap.Int("limit", limit),
+		zap.String("component", "farm_quotas_usecase"),
+	)...)
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	uc.mu.Lock()
+	tracked, evictions := uc.windows.Len(), uc.windows.Evictions()
+	uc.mu.Unlock()
+
+	return append(uc.rejections.Collect(),
+		metrics.Family{Name: "farm_quota_tracked_farms", Help: "Farms whose telemetry messages are counted against their quota", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(tracked)}}},
+		metrics.Family{Name: "farm_quota_evictions_total", Help: "Farms forgotten to stay within the message counting size limit", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(evictions)}}},
+	)
+}
+
+// MetricsCollector returns the farm quotas metrics collector
+func MetricsCollector(useCase FarmQuotasUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
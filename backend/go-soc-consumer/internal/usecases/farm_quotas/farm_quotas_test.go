@@ -0,0 +1,180 @@
+package farmquotas
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestUseCase(t *testing.T, repo *mocks.MockDeviceRepository, config *Config) *useCaseImpl {
+	useCase, err := NewFarmQuotasUseCase(config, repo, createTestLoggerFactory(t))
+	require.NoError(t, err)
+	return useCase.(*useCaseImpl)
+}
+
+func farmDevice(t *testing.T, farmID string) *entities.Device {
+	device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	if farmID != "" {
+		require.NoError(t, device.AssignFarm(farmID))
+	}
+	return device
+}
+
+func TestFarmQuotasUseCase_AdmitDevice(t *testing.T) {
+	farmCtx := eventports.ContextWithFarmID(context.Background(), "farm-a")
+	config := &Config{Default: entities.FarmQuota{MaxDevices: 2}}
+
+	t.Run("should admit registrations on the shared topics", func(t *testing.T) {
+		useCase := newTestUseCase(t, mocks.NewMockDeviceRepository(t), config)
+
+		assert.NoError(t, useCase.AdmitDevice(context.Background(), testMAC))
+	})
+
+	t.Run("should admit devices already in the farm", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := newTestUseCase(t, repo, config)
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(farmDevice(t, "farm-a"), nil).Once()
+
+		assert.NoError(t, useCase.AdmitDevice(farmCtx, "aa:bb:cc:dd:ee:ff"))
+	})
+
+	t.Run("should admit new devices below the quota", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := newTestUseCase(t, repo, config)
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+		repo.EXPECT().CountByFarm(mock.Anything, "farm-a").Return(1, nil).Once()
+
+		assert.NoError(t, useCase.AdmitDevice(farmCtx, testMAC))
+	})
+
+	t.Run("should reject devices joining a farm at its quota", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := newTestUseCase(t, repo, config)
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(farmDevice(t, ""), nil).Once()
+		repo.EXPECT().CountByFarm(mock.Anything, "farm-a").Return(2, nil).Once()
+
+		err := useCase.AdmitDevice(farmCtx, testMAC)
+		assert.ErrorIs(t, err, domainerrors.ErrFarmQuotaExceeded)
+		assert.ErrorContains(t, err, "farm farm-a already has 2 of 2 devices")
+		assert.Equal(t, float64(1), useCase.rejections.Value("farm-a", entities.FarmQuotaDevices))
+	})
+
+	t.Run("should not query farms without a device quota", func(t *testing.T) {
+		useCase := newTestUseCase(t, mocks.NewMockDeviceRepository(t), &Config{})
+
+		assert.NoError(t, useCase.AdmitDevice(farmCtx, testMAC))
+	})
+
+	t.Run("should fail when the devices cannot be counted", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase := newTestUseCase(t, repo, config)
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+		repo.EXPECT().CountByFarm(mock.Anything, "farm-a").Return(0, errors.New("connection refused")).Once()
+
+		err := useCase.AdmitDevice(farmCtx, testMAC)
+		assert.ErrorContains(t, err, "failed to count farm devices")
+		assert.NotErrorIs(t, err, domainerrors.ErrFarmQuotaExceeded)
+	})
+}
+
+func TestFarmQuotasUseCase_AdmitMessage(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 10, 0, time.UTC)
+	farmA := eventports.ContextWithFarmID(context.Background(), "farm-a")
+	farmB := eventports.ContextWithFarmID(context.Background(), "farm-b")
+	useCase := newTestUseCase(t, mocks.NewMockDeviceRepository(t), &Config{
+		Default:    entities.FarmQuota{MaxMessagesPerMinute: 2},
+		Configured: []*entities.FarmQuota{{FarmID: "farm-b"}},
+	})
+	current := now
+	useCase.now = func() time.Time { return current }
+
+	assert.NoError(t, useCase.AdmitMessage(farmA))
+	assert.NoError(t, useCase.AdmitMessage(farmA))
+	assert.ErrorIs(t, useCase.AdmitMessage(farmA), domainerrors.ErrFarmQuotaExceeded)
+	assert.ErrorIs(t, useCase.AdmitMessage(farmA), domainerrors.ErrFarmQuotaExceeded)
+	assert.Equal(t, float64(2), useCase.rejections.Value("farm-a", entities.FarmQuotaMessages))
+
+	for range 5 {
+		assert.NoError(t, useCase.AdmitMessage(farmB), "the configured quota of the farm is unlimited")
+	}
+	assert.NoError(t, useCase.AdmitMessage(context.Background()), "shared topics are not limited")
+
+	current = now.Add(50 * time.Second)
+	assert.NoError(t, useCase.AdmitMessage(farmA), "the count restarts with the minute")
+}
+
+func TestFarmQuotasUseCase_SetGetAndReset(t *testing.T) {
+	repo := mocks.NewMockDeviceRepository(t)
+	useCase := newTestUseCase(t, repo, &Config{
+		Default:    entities.FarmQuota{MaxDevices: 10, MaxMessagesPerMinute: 100},
+		Configured: []*entities.FarmQuota{{FarmID: "farm-a", MaxDevices: 20, MaxMessagesPerMinute: 200}},
+	})
+	repo.EXPECT().CountByFarm(mock.Anything, mock.Anything).Return(4, nil)
+
+	usage, err := useCase.Get(context.Background(), "farm-b")
+	require.NoError(t, err)
+	assert.Equal(t, &entities.FarmQuotaUsage{
+		Quota:   &entities.FarmQuota{FarmID: "farm-b", MaxDevices: 10, MaxMessagesPerMinute: 100},
+		Source:  entities.FarmQuotaSourceDefault,
+		Devices: 4,
+	}, usage)
+
+	assert.ErrorIs(t, useCase.Set(&entities.FarmQuota{FarmID: "farm-a", MaxDevices: -1}), domainerrors.ErrInvalidFarmQuota)
+	assert.ErrorIs(t, useCase.Set(nil), domainerrors.ErrMissingInput)
+	require.NoError(t, useCase.Set(&entities.FarmQuota{FarmID: " farm-a ", MaxDevices: 50}))
+	require.NoError(t, useCase.AdmitMessage(eventports.ContextWithFarmID(context.Background(), "farm-a")))
+
+	usage, err = useCase.Get(context.Background(), "farm-a")
+	require.NoError(t, err)
+	assert.Equal(t, entities.FarmQuotaSourceOverride, usage.Source)
+	assert.Equal(t, &entities.FarmQuota{FarmID: "farm-a", MaxDevices: 50}, usage.Quota)
+	assert.Equal(t, 1, usage.MessagesThisMinute)
+
+	assert.Equal(t, &entities.FarmQuota{FarmID: "farm-a", MaxDevices: 20, MaxMessagesPerMinute: 200}, useCase.Reset("farm-a"), "restores the configured quota")
+	assert.Equal(t, &entities.FarmQuota{FarmID: "farm-c", MaxDevices: 10, MaxMessagesPerMinute: 100}, useCase.Reset("farm-c"))
+
+	_, err = useCase.Get(context.Background(), "farm a")
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidFarmQuota)
+}
+
+func TestNewFarmQuotasUseCase_InvalidQuota(t *testing.T) {
+	_, err := NewFarmQuotasUseCase(&Config{Configured: []*entities.FarmQuota{{FarmID: "farm-a", MaxMessagesPerMinute: -5}}}, mocks.NewMockDeviceRepository(t), createTestLoggerFactory(t))
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidFarmQuota)
+
+	_, err = NewFarmQuotasUseCase(&Config{Default: entities.FarmQuota{MaxDevices: -1}}, mocks.NewMockDeviceRepository(t), createTestLoggerFactory(t))
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidFarmQuota)
+}
+
+func TestQuotaMeasurementUseCase(t *testing.T) {
+	quotas := mocks.NewMockFarmQuotasUseCase(t)
+	inner := mocks.NewMockMeasurementUseCase(t)
+	useCase := NewQuotaMeasurementUseCase(inner, quotas)
+	values := []*entities.Measurement{{MACAddress: testMAC, SensorType: "soil_moisture", Value: 41}}
+
+	quotas.EXPECT().AdmitMessage(mock.Anything).Return(domainerrors.ErrFarmQuotaExceeded).Once()
+	assert.ErrorIs(t, useCase.StoreMeasurements(context.Background(), values), domainerrors.ErrFarmQuotaExceeded)
+
+	quotas.EXPECT().AdmitMessage(mock.Anything).Return(nil).Once()
+	inner.EXPECT().StoreMeasurements(mock.Anything, values).Return(nil).Once()
+	assert.NoError(t, useCase.StoreMeasurements(context.Background(), values))
+}
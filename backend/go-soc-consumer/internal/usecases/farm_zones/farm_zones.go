@@ -0,0 +1,360 @@
+package farmzones
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// DefaultMeasurementWindow is how far back the latest measurements of a zone are looked for when
+// the query gives no start
+const DefaultMeasurementWindow = time.Hour
+
+// FarmZonesUseCase manages the farm → field → zone hierarchy devices are grouped in. Devices are
+// assigned to zones; a field groups the devices of its zones.
+type FarmZonesUseCase interface {
+	// ListFarms returns every farm sorted by name
+	ListFarms(ctx context.Context) ([]*entities.Farm, error)
+
+	// GetFarm returns a farm with its fields and zones, oldest first, failing with ErrFarmNotFound
+	GetFarm(ctx context.Context, id string) (*entities.Farm, []*entities.Zone, error)
+
+	// CreateFarm creates a farm whose ID is the farm namespace of its devices, failing with
+	// ErrInvalidFarm or ErrFarmAlreadyExists
+	CreateFarm(ctx context.Context, id, name string) (*entities.Farm, error)
+
+	// RenameFarm changes the name of a farm, failing with ErrFarmNotFound or ErrInvalidFarm
+	RenameFarm(ctx context.Context, id, name string) (*entities.Farm, error)
+
+	// DeleteFarm removes a farm without fields, failing with ErrFarmNotFound or ErrFarmNotEmpty
+	DeleteFarm(ctx context.Context, id string) error
+
+	// CreateZone creates a field of the farm, or a zone of one of its fields, failing with
+	// ErrFarmNotFound or ErrInvalidZone
+	CreateZone(ctx context.Context, farmID string, request entities.ZoneRequest) (*entities.Zone, error)
+
+	// GetZone returns a field or zone with the devices assigned to it, or to the zones of the field,
+	// failing with ErrZoneNotFound
+	GetZone(ctx context.Context, id string) (*entities.Zone, []*entities.Device, error)
+
+	// RenameZone changes the name of a field or zone, failing with ErrZoneNotFound or ErrInvalidZone
+	RenameZone(ctx context.Context, id, name string) (*entities.Zone, error)
+
+	// DeleteZone removes a field without zones or a zone without devices, failing with
+	// ErrZoneNotFound or ErrZoneNotEmpty
+	DeleteZone(ctx context.Context, id string) error
+
+	// AssignDevice assigns a device to a zone, moving it out of its previous zone. It fails with
+	// ErrZoneNotFound, ErrDeviceNotFound, or ErrInvalidZone for a field or a zone of another farm
+	// than the device's.
+	AssignDevice(ctx context.Context, zoneID, macAddress string) error
+
+	// UnassignDevice removes a device from a zone, failing with ErrDeviceNotInZone
+	UnassignDevice(ctx context.Context, zoneID, macAddress string) error
+
+	// Measurements aggregates by sensor type the latest measurements, stamped at or after since, of
+	// the devices of a field or zone, together with since resolved; a zero since is
+	// DefaultMeasurementWindow ago. It fails with ErrZoneNotFound or ErrInvalidReadingsQuery.
+	Measurements(ctx context.Context, zoneID string, since time.Time) ([]*entities.ZoneSensorSummary, time.Time, error)
+}
+
+// useCaseImpl implements the FarmZonesUseCase interface
+type useCaseImpl struct {
+	farmRepo        repositoryports.FarmRepository
+	zoneRepo        repositoryports.ZoneRepository
+	deviceRepo      repositoryports.DeviceRepository
+	measurementRepo repositoryports.MeasurementRepository
+	loggerFactory   logger.LoggerFactory
+	now             func() time.Time
+}
+
+// NewFarmZonesUseCase creates a new farm zones use case
+func NewFarmZonesUseCase(
+	farmRepo repositoryports.FarmRepository,
+	zoneRepo repositoryports.ZoneRepository,
+	deviceRepo repositoryports.DeviceRepository,
+	measurementRepo repositoryports.MeasurementRepository,
+	loggerFactory logger.LoggerFactory,
+) FarmZonesUseCase {
+	return &useCaseImpl{
+		farmRepo:        farmRepo,
+		zoneRepo:        zoneRepo,
+		deviceRepo:      deviceRepo,
+		measurementRepo: measurementRepo,
+		loggerFactory:   loggerFactory,
+		now:             time.Now,
+	}
+}
+
+// ListFarms loads every farm
+func (uc *useCaseImpl) ListFarms(ctx context.Context) ([]*entities.Farm, error) {
+	return uc.farmRepo.List(ctx)
+}
+
+// GetFarm loads a farm and its hierarchy
+func (uc *useCaseImpl) GetFarm(ctx context.Context, id string) (*entities.Farm, []*entities.Zone, error) {
+	farm, err := uc.farmRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	zones, err := uc.zoneRepo.ListByFarm(ctx, farm.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return farm, zones, nil
+}
+
+// CreateFarm validates and stores a new farm
+func (uc *useCaseImpl) CreateFarm(ctx context.Context, id, name string) (*entities.Farm, error) {
+	farm, err := entities.NewFarm(id, name, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidFarm, err)
+	}
+	if err := uc.farmRepo.Create(ctx, farm); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("farm_created",
+		zap.String("farm_id", farm.ID),
+		zap.String("component", "farm_zones_usecase"),
+	)
+	return farm, nil
+}
+
+// RenameFarm renames a stored farm
+func (uc *useCaseImpl) RenameFarm(ctx context.Context, id, name string) (*entities.Farm, error) {
+	farm, err := uc.farmRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := farm.Rename(name, uc.now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidFarm, err)
+	}
+	if err := uc.farmRepo.Update(ctx, farm); err != nil {
+		return nil, err
+	}
+	return farm, nil
+}
+
+// DeleteFarm removes a farm once its fields are gone
+func (uc *useCaseImpl) DeleteFarm(ctx context.Context, id string) error {
+	zones, err := uc.zoneRepo.ListByFarm(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(zones) > 0 {
+		return fmt.Errorf("%w: farm %s has %d fields and zones", domainerrors.ErrFarmNotEmpty, id, len(zones))
+	}
+	if err := uc.farmRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.loggerFactory.Core().Info("farm_deleted",
+		zap.String("farm_id", id),
+		zap.String("component", "farm_zones_usecase"),
+	)
+	return nil
+}
+
+// CreateZone validates the place of the field or zone in the hierarchy and stores it
+func (uc *useCaseImpl) CreateZone(ctx context.Context, farmID string, request entities.ZoneRequest) (*entities.Zone, error) {
+	farm, err := uc.farmRepo.FindByID(ctx, farmID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *entities.Zone
+	if request.ParentID != "" {
+		parent, err = uc.zoneRepo.FindByID(ctx, request.ParentID)
+		if errors.Is(err, domainerrors.ErrZoneNotFound) {
+			return nil, fmt.Errorf("%w: parent %s not found", domainerrors.ErrInvalidZone, request.ParentID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	zone, err := entities.NewZone(farm.ID, request, parent, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidZone, err)
+	}
+	if err := uc.zoneRepo.Create(ctx, zone); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("zone_created",
+		zap.String("zone_id", zone.ID),
+		zap.String("farm_id", zone.FarmID),
+		zap.String("kind", zone.Kind),
+		zap.String("component", "farm_zones_usecase"),
+	)
+	return zone, nil
+}
+
+// GetZone loads a field or zone and its devices
+func (uc *useCaseImpl) GetZone(ctx context.Context, id string) (*entities.Zone, []*entities.Device, error) {
+	zone, err := uc.zoneRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	devices, err := uc.devices(ctx, zone.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return zone, devices, nil
+}
+
+// RenameZone renames a stored field or zone
+func (uc *useCaseImpl) RenameZone(ctx context.Context, id, name string) (*entities.Zone, error) {
+	zone, err := uc.zoneRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := zone.Rename(name, uc.now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidZone, err)
+	}
+	if err := uc.zoneRepo.Update(ctx, zone); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// DeleteZone removes a field or zone once nothing is left in it
+func (uc *useCaseImpl) DeleteZone(ctx context.Context, id string) error {
+	zone, err := uc.zoneRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if zone.Kind == entities.ZoneKindField {
+		zones, err := uc.zoneRepo.ListByFarm(ctx, zone.FarmID)
+		if err != nil {
+			return err
+		}
+		for _, child := range zones {
+			if child.ParentID == zone.ID {
+				return fmt.Errorf("%w: field %s has zones", domainerrors.ErrZoneNotEmpty, zone.Name)
+			}
+		}
+	} else {
+		macAddresses, err := uc.zoneRepo.DeviceMACAddresses(ctx, zone.ID)
+		if err != nil {
+			return err
+		}
+		if len(macAddresses) > 0 {
+			return fmt.Errorf("%w: zone %s has %d devices", domainerrors.ErrZoneNotEmpty, zone.Name, len(macAddresses))
+		}
+	}
+
+	if err := uc.zoneRepo.Delete(ctx, zone.ID); err != nil {
+		return err
+	}
+
+	uc.loggerFactory.Core().Info("zone_deleted",
+		zap.String("zone_id", zone.ID),
+		zap.String("farm_id", zone.FarmID),
+		zap.String("component", "farm_zones_usecase"),
+	)
+	return nil
+}
+
+// AssignDevice checks that the device may belong to the zone and assigns it
+func (uc *useCaseImpl) AssignDevice(ctx context.Context, zoneID, macAddress string) error {
+	macAddress, err := normalizeMACAddress(macAddress)
+	if err != nil {
+		return err
+	}
+	zone, err := uc.zoneRepo.FindByID(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+	if !zone.HoldsDevices() {
+		return fmt.Errorf("%w: devices are assigned to the zones of field %s, not to the field", domainerrors.ErrInvalidZone, zone.Name)
+	}
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, macAddress)
+	if err != nil {
+		return err
+	}
+	if device.FarmID != "" && device.FarmID != zone.FarmID {
+		return fmt.Errorf("%w: device %s belongs to farm %s, not %s", domainerrors.ErrInvalidZone, macAddress, device.FarmID, zone.FarmID)
+	}
+	if err := uc.zoneRepo.AssignDevice(ctx, zone.ID, macAddress); err != nil {
+		return err
+	}
+
+	uc.loggerFactory.Core().Info("zone_device_assigned",
+		zap.String("zone_id", zone.ID),
+		zap.String("mac_address", macAddress),
+		zap.String("component", "farm_zones_usecase"),
+	)
+	return nil
+}
+
+// UnassignDevice removes the device from the zone
+func (uc *useCaseImpl) UnassignDevice(ctx context.Context, zoneID, macAddress string) error {
+	macAddress, err := normalizeMACAddress(macAddress)
+	if err != nil {
+		return err
+	}
+	return uc.zoneRepo.UnassignDevice(ctx, zoneID, macAddress)
+}
+
+// Measurements reads the latest measurements of each device of the zone and aggregates them
+func (uc *useCaseImpl) Measurements(ctx context.Context, zoneID string, since time.Time) ([]*entities.ZoneSensorSummary, time.Time, error) {
+	now := uc.now()
+	if since.IsZero() {
+		since = now.Add(-DefaultMeasurementWindow)
+	}
+	if !since.Before(now) {
+		return nil, since, fmt.Errorf("%w: since must be in the past", domainerrors.ErrInvalidReadingsQuery)
+	}
+
+	zone, err := uc.zoneRepo.FindByID(ctx, zoneID)
+	if err != nil {
+		return nil, since, err
+	}
+	macAddresses, err := uc.zoneRepo.DeviceMACAddresses(ctx, zone.ID)
+	if err != nil {
+		return nil, since, err
+	}
+
+	var measurements []*entities.Measurement
+	for _, macAddress := range macAddresses {
+		latest, err := uc.measurementRepo.LatestByDevice(ctx, macAddress, since)
+		if err != nil {
+			return nil, since, fmt.Errorf("failed to load the measurements of %s: %w", macAddress, err)
+		}
+		measurements = append(measurements, latest...)
+	}
+	return entities.SummarizeZoneMeasurements(measurements), since, nil
+}
+
+// devices loads the devices of a field or zone; assignments of deleted devices are left out
+func (uc *useCaseImpl) devices(ctx context.Context, zoneID string) ([]*entities.Device, error) {
+	macAddresses, err := uc.zoneRepo.DeviceMACAddresses(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	if len(macAddresses) == 0 {
+		return []*entities.Device{}, nil
+	}
+	return uc.deviceRepo.FindByMACAddresses(ctx, macAddresses)
+}
+
+// normalizeMACAddress validates a MAC address taken from a request and uppercases it
+func normalizeMACAddress(macAddress string) (string, error) {
+	if err := validation.ValidateMACAddress(macAddress); err != nil {
+		return "", fmt.Errorf("%w: %v", domainerrors.ErrInvalidDevice, err)
+	}
+	return strings.ToUpper(strings.TrimSpace(macAddress)), nil
+}
@@ -0,0 +1,244 @@
+package farmzones
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+type testDeps struct {
+	farmRepo        *mocks.MockFarmRepository
+	zoneRepo        *mocks.MockZoneRepository
+	deviceRepo      *mocks.MockDeviceRepository
+	measurementRepo *mocks.MockMeasurementRepository
+}
+
+func newTestUseCase(t *testing.T) (*useCaseImpl, *testDeps) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	deps := &testDeps{
+		farmRepo:        mocks.NewMockFarmRepository(t),
+		zoneRepo:        mocks.NewMockZoneRepository(t),
+		deviceRepo:      mocks.NewMockDeviceRepository(t),
+		measurementRepo: mocks.NewMockMeasurementRepository(t),
+	}
+	useCase := NewFarmZonesUseCase(deps.farmRepo, deps.zoneRepo, deps.deviceRepo, deps.measurementRepo, loggerFactory).(*useCaseImpl)
+	useCase.now = func() time.Time { return testNow }
+	return useCase, deps
+}
+
+func testHierarchy(t *testing.T) (*entities.Zone, *entities.Zone) {
+	field, err := entities.NewZone("la-esperanza", entities.ZoneRequest{Name: "North field", Kind: entities.ZoneKindField}, nil, testNow)
+	require.NoError(t, err)
+	zone, err := entities.NewZone("la-esperanza", entities.ZoneRequest{Name: "Tomatoes", Kind: entities.ZoneKindZone}, field, testNow)
+	require.NoError(t, err)
+	return field, zone
+}
+
+func TestFarmZonesUseCase_CreateFarm(t *testing.T) {
+	t.Run("should store a valid farm", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.farmRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(farm *entities.Farm) bool {
+			return farm.ID == "la-esperanza" && farm.Name == "La Esperanza"
+		})).Return(nil).Once()
+
+		farm, err := useCase.CreateFarm(context.Background(), "la-esperanza", "La Esperanza")
+		require.NoError(t, err)
+		assert.Equal(t, testNow, farm.CreatedAt)
+	})
+
+	t.Run("should reject an invalid farm ID", func(t *testing.T) {
+		useCase, _ := newTestUseCase(t)
+
+		_, err := useCase.CreateFarm(context.Background(), "la/esperanza", "La Esperanza")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidFarm)
+	})
+}
+
+func TestFarmZonesUseCase_DeleteFarm(t *testing.T) {
+	t.Run("should refuse to delete a farm with fields", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		field, _ := testHierarchy(t)
+		deps.zoneRepo.EXPECT().ListByFarm(mock.Anything, "la-esperanza").Return([]*entities.Zone{field}, nil).Once()
+
+		err := useCase.DeleteFarm(context.Background(), "la-esperanza")
+		assert.ErrorIs(t, err, domainerrors.ErrFarmNotEmpty)
+	})
+
+	t.Run("should delete an empty farm", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.zoneRepo.EXPECT().ListByFarm(mock.Anything, "la-esperanza").Return([]*entities.Zone{}, nil).Once()
+		deps.farmRepo.EXPECT().Delete(mock.Anything, "la-esperanza").Return(nil).Once()
+
+		assert.NoError(t, useCase.DeleteFarm(context.Background(), "la-esperanza"))
+	})
+}
+
+func TestFarmZonesUseCase_CreateZone(t *testing.T) {
+	farm := &entities.Farm{ID: "la-esperanza", Name: "La Esperanza"}
+
+	t.Run("should create a zone in a field of the farm", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		field, _ := testHierarchy(t)
+		deps.farmRepo.EXPECT().FindByID(mock.Anything, "la-esperanza").Return(farm, nil).Once()
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, field.ID).Return(field, nil).Once()
+		deps.zoneRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.Zone")).Return(nil).Once()
+
+		zone, err := useCase.CreateZone(context.Background(), "la-esperanza", entities.ZoneRequest{Name: "Peppers", Kind: entities.ZoneKindZone, ParentID: field.ID})
+		require.NoError(t, err)
+		assert.Equal(t, field.ID, zone.ParentID)
+	})
+
+	t.Run("should reject an unknown parent", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.farmRepo.EXPECT().FindByID(mock.Anything, "la-esperanza").Return(farm, nil).Once()
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, "missing").Return(nil, domainerrors.ErrZoneNotFound).Once()
+
+		_, err := useCase.CreateZone(context.Background(), "la-esperanza", entities.ZoneRequest{Name: "Peppers", Kind: entities.ZoneKindZone, ParentID: "missing"})
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidZone)
+	})
+
+	t.Run("should reject a zone nested in a zone", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		_, zone := testHierarchy(t)
+		deps.farmRepo.EXPECT().FindByID(mock.Anything, "la-esperanza").Return(farm, nil).Once()
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, zone.ID).Return(zone, nil).Once()
+
+		_, err := useCase.CreateZone(context.Background(), "la-esperanza", entities.ZoneRequest{Name: "Peppers", Kind: entities.ZoneKindZone, ParentID: zone.ID})
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidZone)
+	})
+}
+
+func TestFarmZonesUseCase_DeleteZone(t *testing.T) {
+	t.Run("should refuse to delete a field with zones", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		field, zone := testHierarchy(t)
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, field.ID).Return(field, nil).Once()
+		deps.zoneRepo.EXPECT().ListByFarm(mock.Anything, "la-esperanza").Return([]*entities.Zone{field, zone}, nil).Once()
+
+		err := useCase.DeleteZone(context.Background(), field.ID)
+		assert.ErrorIs(t, err, domainerrors.ErrZoneNotEmpty)
+	})
+
+	t.Run("should refuse to delete a zone with devices", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		_, zone := testHierarchy(t)
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, zone.ID).Return(zone, nil).Once()
+		deps.zoneRepo.EXPECT().DeviceMACAddresses(mock.Anything, zone.ID).Return([]string{testMAC}, nil).Once()
+
+		err := useCase.DeleteZone(context.Background(), zone.ID)
+		assert.ErrorIs(t, err, domainerrors.ErrZoneNotEmpty)
+	})
+
+	t.Run("should delete an empty zone", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		_, zone := testHierarchy(t)
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, zone.ID).Return(zone, nil).Once()
+		deps.zoneRepo.EXPECT().DeviceMACAddresses(mock.Anything, zone.ID).Return([]string{}, nil).Once()
+		deps.zoneRepo.EXPECT().Delete(mock.Anything, zone.ID).Return(nil).Once()
+
+		assert.NoError(t, useCase.DeleteZone(context.Background(), zone.ID))
+	})
+}
+
+func TestFarmZonesUseCase_AssignDevice(t *testing.T) {
+	newDevice := func(t *testing.T, farmID string) *entities.Device {
+		device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+		require.NoError(t, err)
+		if farmID != "" {
+			require.NoError(t, device.AssignFarm(farmID))
+		}
+		return device
+	}
+
+	t.Run("should assign a device of the farm", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		_, zone := testHierarchy(t)
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, zone.ID).Return(zone, nil).Once()
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(newDevice(t, "la-esperanza"), nil).Once()
+		deps.zoneRepo.EXPECT().AssignDevice(mock.Anything, zone.ID, testMAC).Return(nil).Once()
+
+		assert.NoError(t, useCase.AssignDevice(context.Background(), zone.ID, "aa:bb:cc:dd:ee:ff"))
+	})
+
+	t.Run("should assign a device on the shared topics", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		_, zone := testHierarchy(t)
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, zone.ID).Return(zone, nil).Once()
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(newDevice(t, ""), nil).Once()
+		deps.zoneRepo.EXPECT().AssignDevice(mock.Anything, zone.ID, testMAC).Return(nil).Once()
+
+		assert.NoError(t, useCase.AssignDevice(context.Background(), zone.ID, testMAC))
+	})
+
+	t.Run("should reject a device of another farm", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		_, zone := testHierarchy(t)
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, zone.ID).Return(zone, nil).Once()
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(newDevice(t, "el-paraiso"), nil).Once()
+
+		err := useCase.AssignDevice(context.Background(), zone.ID, testMAC)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidZone)
+		assert.ErrorContains(t, err, "belongs to farm el-paraiso")
+	})
+
+	t.Run("should reject a field", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		field, _ := testHierarchy(t)
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, field.ID).Return(field, nil).Once()
+
+		err := useCase.AssignDevice(context.Background(), field.ID, testMAC)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidZone)
+	})
+
+	t.Run("should reject an invalid MAC address", func(t *testing.T) {
+		useCase, _ := newTestUseCase(t)
+
+		err := useCase.AssignDevice(context.Background(), "zone", "not-a-mac")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidDevice)
+	})
+}
+
+func TestFarmZonesUseCase_Measurements(t *testing.T) {
+	t.Run("should aggregate the latest measurements of the devices of a field", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		field, _ := testHierarchy(t)
+		since := testNow.Add(-DefaultMeasurementWindow)
+		deps.zoneRepo.EXPECT().FindByID(mock.Anything, field.ID).Return(field, nil).Once()
+		deps.zoneRepo.EXPECT().DeviceMACAddresses(mock.Anything, field.ID).Return([]string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"}, nil).Once()
+		deps.measurementRepo.EXPECT().LatestByDevice(mock.Anything, "AA:BB:CC:DD:EE:01", since).Return([]*entities.Measurement{
+			{MACAddress: "AA:BB:CC:DD:EE:01", SensorType: "soil_moisture", Value: 30, Unit: "%", Quality: entities.MeasurementQualityGood, Timestamp: testNow},
+		}, nil).Once()
+		deps.measurementRepo.EXPECT().LatestByDevice(mock.Anything, "AA:BB:CC:DD:EE:02", since).Return([]*entities.Measurement{
+			{MACAddress: "AA:BB:CC:DD:EE:02", SensorType: "soil_moisture", Value: 50, Unit: "%", Quality: entities.MeasurementQualityGood, Timestamp: testNow},
+		}, nil).Once()
+
+		summaries, resolved, err := useCase.Measurements(context.Background(), field.ID, time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, since, resolved)
+		require.Len(t, summaries, 1)
+		assert.Equal(t, 2, summaries[0].Devices)
+		assert.Equal(t, float64(40), summaries[0].Average)
+	})
+
+	t.Run("should reject a start in the future", func(t *testing.T) {
+		useCase, _ := newTestUseCase(t)
+
+		_, _, err := useCase.Measurements(context.Background(), "zone", testNow.Add(time.Minute))
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidReadingsQuery)
+	})
+}
@@ -0,0 +1,136 @@
+package fleetversions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// FleetVersionsUseCase summarizes the firmware and hardware versions the fleet runs, to follow upgrades
+type FleetVersionsUseCase interface {
+	// Report counts the devices by firmware version, hardware version and farm. When a target version
+	// is given, the report includes how many devices already run it; an overlong target fails with
+	// ErrInvalidTargetVersion.
+	Report(ctx context.Context, targetVersion string) (*entities.FleetVersionReport, error)
+}
+
+// useCaseImpl implements the FleetVersionsUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	loggerFactory logger.LoggerFactory
+}
+
+// NewFleetVersionsUseCase creates a fleet versions use case
+func NewFleetVersionsUseCase(deviceRepo repositoryports.DeviceRepository, loggerFactory logger.LoggerFactory) FleetVersionsUseCase {
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		loggerFactory: loggerFactory,
+	}
+}
+
+// Report aggregates the version counts of the repository
+func (uc *useCaseImpl) Report(ctx context.Context, targetVersion string) (*entities.FleetVersionReport, error) {
+	targetVersion = strings.TrimSpace(targetVersion)
+	if len(targetVersion) > entities.MaxFirmwareVersionLength {
+		return nil, fmt.Errorf("%w: must be at most %d characters", domainerrors.ErrInvalidTargetVersion, entities.MaxFirmwareVersionLength)
+	}
+
+	counts, err := uc.deviceRepo.CountByVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count devices by version: %w", err)
+	}
+
+	report := &entities.FleetVersionReport{}
+	if targetVersion != "" {
+		report.Progress = &entities.UpgradeProgress{TargetVersion: targetVersion}
+	}
+	firmware := make(map[string]*entities.VersionCount)
+	hardware := make(map[string]*entities.VersionCount)
+	farms := make(map[string]*entities.FarmVersions)
+	farmFirmware := make(map[string]map[string]*entities.VersionCount)
+
+	for _, count := range counts {
+		report.Devices += count.Devices
+		report.Online += count.Online
+		add(firmware, count.FirmwareVersion, count)
+		add(hardware, count.HardwareVersion, count)
+
+		farm, ok := farms[count.FarmID]
+		if !ok {
+			farm = &entities.FarmVersions{FarmID: count.FarmID}
+			farms[count.FarmID] = farm
+			farmFirmware[count.FarmID] = make(map[string]*entities.VersionCount)
+		}
+		farm.Devices += count.Devices
+		add(farmFirmware[count.FarmID], count.FirmwareVersion, count)
+
+		if report.Progress == nil {
+			continue
+		}
+		switch count.FirmwareVersion {
+		case targetVersion:
+			report.Progress.Upgraded += count.Devices
+			farm.Upgraded += count.Devices
+		case "":
+			report.Progress.Unknown += count.Devices
+		default:
+			report.Progress.Pending += count.Devices
+		}
+	}
+
+	report.Firmware = sorted(firmware)
+	report.Hardware = sorted(hardware)
+	report.Farms = make([]entities.FarmVersions, 0, len(farms))
+	for farmID, farm := range farms {
+		farm.Firmware = sorted(farmFirmware[farmID])
+		report.Farms = append(report.Farms, *farm)
+	}
+	sort.Slice(report.Farms, func(i, j int) bool {
+		if report.Farms[i].Devices != report.Farms[j].Devices {
+			return report.Farms[i].Devices > report.Farms[j].Devices
+		}
+		return report.Farms[i].FarmID < report.Farms[j].FarmID
+	})
+
+	uc.loggerFactory.Core().Debug("fleet_versions_reported",
+		zap.Int("devices", report.Devices),
+		zap.Int("firmware_versions", len(report.Firmware)),
+		zap.String("target_version", targetVersion),
+		zap.String("component", "fleet_versions_usecase"),
+	)
+	return report, nil
+}
+
+// add accumulates the devices of a count under a version
+func add(versions map[string]*entities.VersionCount, version string, count *entities.FleetVersionCount) {
+	entry, ok := versions[version]
+	if !ok {
+		entry = &entities.VersionCount{Version: version}
+		versions[version] = entry
+	}
+	entry.Devices += count.Devices
+	entry.Online += count.Online
+}
+
+// sorted lists the versions with the most devices first
+func sorted(versions map[string]*entities.VersionCount) []entities.VersionCount {
+	list := make([]entities.VersionCount, 0, len(versions))
+	for _, entry := range versions {
+		list = append(list, *entry)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Devices != list[j].Devices {
+			return list[i].Devices > list[j].Devices
+		}
+		return list[i].Version < list[j].Version
+	})
+	return list
+}
@@ -0,0 +1,76 @@
+package fleetversions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func TestFleetVersionsUseCase_Report(t *testing.T) {
+	counts := []*entities.FleetVersionCount{
+		{FarmID: "farm-1", FirmwareVersion: "1.5.0", HardwareVersion: "rev-b", Devices: 8, Online: 7},
+		{FarmID: "farm-1", FirmwareVersion: "1.4.2", HardwareVersion: "rev-a", Devices: 2, Online: 2},
+		{FarmID: "farm-2", FirmwareVersion: "1.4.2", HardwareVersion: "rev-b", Devices: 4, Online: 1},
+		{FarmID: "", FirmwareVersion: "", HardwareVersion: "", Devices: 1},
+	}
+
+	t.Run("should count devices by version and farm", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().CountByVersion(mock.Anything).Return(counts, nil).Once()
+
+		report, err := NewFleetVersionsUseCase(repo, createTestLoggerFactory(t)).Report(context.Background(), "")
+
+		require.NoError(t, err)
+		assert.Equal(t, 15, report.Devices)
+		assert.Equal(t, 10, report.Online)
+		assert.Equal(t, []entities.VersionCount{
+			{Version: "1.5.0", Devices: 8, Online: 7},
+			{Version: "1.4.2", Devices: 6, Online: 3},
+			{Version: "", Devices: 1},
+		}, report.Firmware)
+		assert.Equal(t, []entities.VersionCount{
+			{Version: "rev-b", Devices: 12, Online: 8},
+			{Version: "rev-a", Devices: 2, Online: 2},
+			{Version: "", Devices: 1},
+		}, report.Hardware)
+		require.Len(t, report.Farms, 3)
+		assert.Equal(t, "farm-1", report.Farms[0].FarmID)
+		assert.Equal(t, 10, report.Farms[0].Devices)
+		assert.Len(t, report.Farms[0].Firmware, 2)
+		assert.Nil(t, report.Progress)
+	})
+
+	t.Run("should compare the fleet against the target version", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		repo.EXPECT().CountByVersion(mock.Anything).Return(counts, nil).Once()
+
+		report, err := NewFleetVersionsUseCase(repo, createTestLoggerFactory(t)).Report(context.Background(), " 1.5.0 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, &entities.UpgradeProgress{TargetVersion: "1.5.0", Upgraded: 8, Pending: 6, Unknown: 1}, report.Progress)
+		assert.InDelta(t, 53.3, report.Progress.Percent(), 0.1)
+		assert.Equal(t, 8, report.Farms[0].Upgraded)
+		assert.Zero(t, report.Farms[1].Upgraded)
+	})
+
+	t.Run("should reject an overlong target version", func(t *testing.T) {
+		_, err := NewFleetVersionsUseCase(mocks.NewMockDeviceRepository(t), createTestLoggerFactory(t)).Report(context.Background(), strings.Repeat("1", 33))
+
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidTargetVersion)
+	})
+}
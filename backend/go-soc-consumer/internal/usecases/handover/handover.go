@@ -0,0 +1,109 @@
+package handover
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/alerting"
+	sensorhealth "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_health"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+// maxScheduledJobs bounds the jobs listed in a report
+const maxScheduledJobs = 100
+
+// HandoverUseCase generates the shift handover report from the current state of the server
+type HandoverUseCase interface {
+	// Report summarizes the open alerts, offline devices, degraded sensors, operator overrides in
+	// effect and scheduled background jobs
+	Report(ctx context.Context) (*entities.HandoverReport, error)
+}
+
+// useCaseImpl implements the HandoverUseCase interface
+type useCaseImpl struct {
+	alerting      alerting.AlertingUseCase         // nil when alert rules are disabled
+	sensorHealth  sensorhealth.SensorHealthUseCase // nil when sensor health tracking is disabled
+	deviceRepo    repositoryports.DeviceRepository
+	jobRepo       repositoryports.JobRepository
+	overrides     []ports.OverrideSource
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+}
+
+// NewHandoverUseCase creates a handover use case; the alerting and sensor health use cases may be nil,
+// leaving their sections empty
+func NewHandoverUseCase(
+	alertingUseCase alerting.AlertingUseCase,
+	sensorHealthUseCase sensorhealth.SensorHealthUseCase,
+	deviceRepo repositoryports.DeviceRepository,
+	jobRepo repositoryports.JobRepository,
+	overrides []ports.OverrideSource,
+	loggerFactory logger.LoggerFactory,
+) HandoverUseCase {
+	return &useCaseImpl{
+		alerting:      alertingUseCase,
+		sensorHealth:  sensorHealthUseCase,
+		deviceRepo:    deviceRepo,
+		jobRepo:       jobRepo,
+		overrides:     overrides,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+	}
+}
+
+// Report gathers every section of the handover report
+func (uc *useCaseImpl) Report(ctx context.Context) (*entities.HandoverReport, error) {
+	report := &entities.HandoverReport{GeneratedAt: uc.now().UTC()}
+
+	if uc.alerting != nil {
+		report.OpenAlerts = uc.alerting.ActiveAlerts()
+		sort.SliceStable(report.OpenAlerts, func(i, j int) bool {
+			return report.OpenAlerts[i].SeverityRank() > report.OpenAlerts[j].SeverityRank()
+		})
+	}
+
+	devices, err := uc.deviceRepo.List(ctx, 0, pagination.Unlimited)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load devices: %w", err)
+	}
+	for _, device := range devices {
+		if device.IsOffline() {
+			report.OfflineDevices = append(report.OfflineDevices, device)
+		}
+	}
+	sort.Slice(report.OfflineDevices, func(i, j int) bool {
+		return report.OfflineDevices[i].LastSeen.Before(report.OfflineDevices[j].LastSeen)
+	})
+
+	if uc.sensorHealth != nil {
+		if report.DegradedSensors, err = uc.sensorHealth.Degraded(ctx); err != nil {
+			return nil, fmt.Errorf("failed to load sensor health: %w", err)
+		}
+	}
+
+	for _, source := range uc.overrides {
+		report.Overrides = append(report.Overrides, source.ActiveOverrides()...)
+	}
+
+	if report.ScheduledJobs, err = uc.jobRepo.ListUnfinished(ctx, maxScheduledJobs); err != nil {
+		return nil, fmt.Errorf("failed to load scheduled jobs: %w", err)
+	}
+
+	uc.loggerFactory.Core().Info("handover_report_generated",
+		zap.Int("open_alerts", len(report.OpenAlerts)),
+		zap.Int("offline_devices", len(report.OfflineDevices)),
+		zap.Int("degraded_sensors", len(report.DegradedSensors)),
+		zap.Int("overrides", len(report.Overrides)),
+		zap.Int("scheduled_jobs", len(report.ScheduledJobs)),
+		zap.String("component", "handover_usecase"),
+	)
+	return report, nil
+}
@@ -0,0 +1,102 @@
+package handover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestAlert(t *testing.T, name, severity string) *entities.Alert {
+	rule, err := entities.NewAlertRule(name, "soil_moisture", entities.AlertOperatorBelow, 20, 0, 0, 0, severity)
+	require.NoError(t, err)
+	alert, err := entities.NewAlert(rule, entities.AlertStatusFiring, "AA:BB:CC:DD:EE:FF", 0, 15, time.Now(), time.Now(), "dry")
+	require.NoError(t, err)
+	return alert
+}
+
+func newTestDevice(t *testing.T, macAddress string, offlineSince time.Time) *entities.Device {
+	device, err := entities.NewDevice(macAddress, "probe", "192.168.1.10", "greenhouse")
+	require.NoError(t, err)
+	if !offlineSince.IsZero() {
+		device.MarkOffline()
+		device.LastSeen = offlineSince
+	}
+	return device
+}
+
+func TestHandoverUseCase_Report(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+
+	t.Run("should gather every section", func(t *testing.T) {
+		alertingUseCase := mocks.NewMockAlertingUseCase(t)
+		sensorHealthUseCase := mocks.NewMockSensorHealthUseCase(t)
+		deviceRepo := mocks.NewMockDeviceRepository(t)
+		jobRepo := mocks.NewMockJobRepository(t)
+		overrides := mocks.NewMockOverrideSource(t)
+
+		info := newTestAlert(t, "humid_air", entities.AlertSeverityInfo)
+		critical := newTestAlert(t, "dry_soil", entities.AlertSeverityCritical)
+		alertingUseCase.EXPECT().ActiveAlerts().Return([]*entities.Alert{info, critical}).Once()
+		deviceRepo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return([]*entities.Device{
+			newTestDevice(t, "AA:BB:CC:DD:EE:01", now.Add(-time.Hour)),
+			newTestDevice(t, "AA:BB:CC:DD:EE:02", time.Time{}),
+			newTestDevice(t, "AA:BB:CC:DD:EE:03", now.Add(-3*time.Hour)),
+		}, nil).Once()
+		degraded := []*entities.SensorStreamHealth{{MACAddress: "AA:BB:CC:DD:EE:04", Measurement: entities.MeasurementHumidity, Score: 20, Degraded: true}}
+		sensorHealthUseCase.EXPECT().Degraded(mock.Anything).Return(degraded, nil).Once()
+		overrides.EXPECT().ActiveOverrides().Return([]*entities.OperatorOverride{{Kind: entities.OverrideKindConsumptionPaused}}).Once()
+		jobs := []*entities.Job{{ID: "job-1", Type: "reprocess", Status: entities.JobStatusQueued}}
+		jobRepo.EXPECT().ListUnfinished(mock.Anything, maxScheduledJobs).Return(jobs, nil).Once()
+
+		useCase := NewHandoverUseCase(alertingUseCase, sensorHealthUseCase, deviceRepo, jobRepo, []ports.OverrideSource{overrides}, createTestLoggerFactory(t)).(*useCaseImpl)
+		useCase.now = func() time.Time { return now }
+
+		report, err := useCase.Report(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, now, report.GeneratedAt)
+		assert.Equal(t, []*entities.Alert{critical, info}, report.OpenAlerts)
+		require.Len(t, report.OfflineDevices, 2)
+		assert.Equal(t, "AA:BB:CC:DD:EE:03", report.OfflineDevices[0].MACAddress, "longest silent first")
+		assert.Equal(t, degraded, report.DegradedSensors)
+		require.Len(t, report.Overrides, 1)
+		assert.Equal(t, jobs, report.ScheduledJobs)
+	})
+
+	t.Run("should leave out the sections of disabled features", func(t *testing.T) {
+		deviceRepo := mocks.NewMockDeviceRepository(t)
+		jobRepo := mocks.NewMockJobRepository(t)
+		deviceRepo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return(nil, nil).Once()
+		jobRepo.EXPECT().ListUnfinished(mock.Anything, maxScheduledJobs).Return(nil, nil).Once()
+
+		report, err := NewHandoverUseCase(nil, nil, deviceRepo, jobRepo, nil, createTestLoggerFactory(t)).Report(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, report.OpenAlerts)
+		assert.Empty(t, report.DegradedSensors)
+	})
+
+	t.Run("should fail when devices cannot be loaded", func(t *testing.T) {
+		deviceRepo := mocks.NewMockDeviceRepository(t)
+		deviceRepo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := NewHandoverUseCase(nil, nil, deviceRepo, mocks.NewMockJobRepository(t), nil, createTestLoggerFactory(t)).Report(ctx)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,119 @@
+package ingestionlatency
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// Latency stages of a message, from the device clock to the database commit
+const (
+	StageDeviceToReceipt = "device_to_receipt" // device timestamp to broker receipt
+	StageReceiptToCommit = "receipt_to_commit" // broker receipt to database commit
+	StageDeviceToCommit  = "device_to_commit"  // device timestamp to database commit
+)
+
+// DefaultBuckets are the latency histogram bucket upper bounds in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// IngestionLatencyConfig holds the ingestion latency settings
+type IngestionLatencyConfig struct {
+	// ClockDriftTolerance is how far a device timestamp may be ahead of the receipt time before
+	// the device clock is counted as drifted rather than slightly out of sync
+	ClockDriftTolerance time.Duration
+	Buckets             []float64 // histogram bucket upper bounds in seconds
+}
+
+// DefaultIngestionLatencyConfig returns default configuration
+func DefaultIngestionLatencyConfig() *IngestionLatencyConfig {
+	return &IngestionLatencyConfig{
+		ClockDriftTolerance: 2 * time.Second,
+		Buckets:             DefaultBuckets,
+	}
+}
+
+// IngestionLatencyUseCase records how long device messages take to be persisted, per topic and
+// stage, so a broker backlog (device_to_receipt grows), a slow database (receipt_to_commit
+// grows) and device clock drift (device timestamps ahead of the receipt) can be told apart
+type IngestionLatencyUseCase interface {
+	// Record records the latencies of a message on the topic, which should be a topic template
+	// rather than a farm topic to keep the number of series bounded. deviceTime is the timestamp
+	// reported by the device, zero when it sent none; only the receipt to commit latency is
+	// recorded then.
+	Record(topic, macAddress string, deviceTime, receivedAt, committedAt time.Time)
+}
+
+// useCaseImpl implements the IngestionLatencyUseCase interface
+type useCaseImpl struct {
+	config        *IngestionLatencyConfig
+	loggerFactory logger.LoggerFactory
+	latency       *metrics.Histogram
+	clockDrift    *metrics.Vec
+	untimestamped *metrics.Vec
+}
+
+// NewIngestionLatencyUseCase creates an ingestion latency use case
+func NewIngestionLatencyUseCase(config *IngestionLatencyConfig, loggerFactory logger.LoggerFactory) IngestionLatencyUseCase {
+	if config == nil {
+		config = DefaultIngestionLatencyConfig()
+	}
+	return &useCaseImpl{
+		config:        config,
+		loggerFactory: loggerFactory,
+		latency:       metrics.NewHistogramVec("ingestion_latency_seconds", "Latency of device messages by topic and stage", config.Buckets, "topic", "stage"),
+		clockDrift:    metrics.NewCounterVec("ingestion_device_clock_drift_total", "Device messages timestamped ahead of their receipt beyond the tolerance", "topic"),
+		untimestamped: metrics.NewCounterVec("ingestion_untimestamped_messages_total", "Device messages without a device timestamp", "topic"),
+	}
+}
+
+// Record records the latencies of a persisted message
+func (uc *useCaseImpl) Record(topic, macAddress string, deviceTime, receivedAt, committedAt time.Time) {
+	uc.latency.Observe(nonNegativeSeconds(committedAt.Sub(receivedAt)), topic, StageReceiptToCommit)
+
+	if deviceTime.IsZero() {
+		uc.untimestamped.Inc(topic)
+		return
+	}
+
+	// A device slightly ahead of the server is within clock sync error and counts as no delay;
+	// further ahead its clock has drifted and the device latencies would be meaningless
+	deviceLag := receivedAt.Sub(deviceTime)
+	if deviceLag < -uc.config.ClockDriftTolerance {
+		uc.clockDrift.Inc(topic)
+		uc.loggerFactory.Core().Debug("device_clock_drift_detected",
+			zap.String("topic", topic),
+			zap.String("mac_address", macAddress),
+			zap.Duration("ahead_by", -deviceLag),
+			zap.String("component", "ingestion_latency_usecase"),
+		)
+		return
+	}
+	uc.latency.Observe(nonNegativeSeconds(deviceLag), topic, StageDeviceToReceipt)
+	uc.latency.Observe(nonNegativeSeconds(committedAt.Sub(deviceTime)), topic, StageDeviceToCommit)
+}
+
+// nonNegativeSeconds returns the duration in seconds, clamping negative durations to zero
+func nonNegativeSeconds(d time.Duration) float64 {
+	if d < 0 {
+		return 0
+	}
+	return d.Seconds()
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	families := uc.latency.Collect()
+	families = append(families, uc.clockDrift.Collect()...)
+	return append(families, uc.untimestamped.Collect()...)
+}
+
+// MetricsCollector returns the ingestion latency metrics collector
+func MetricsCollector(useCase IngestionLatencyUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package ingestionlatency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const (
+	testTopic = "farms/{farmID}/devices/sensors/measurements"
+	testMAC   = "AA:BB:CC:DD:EE:FF"
+)
+
+func newTestUseCase(t *testing.T) *useCaseImpl {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return NewIngestionLatencyUseCase(nil, loggerFactory).(*useCaseImpl)
+}
+
+func TestIngestionLatencyUseCase_Record(t *testing.T) {
+	receivedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	committedAt := receivedAt.Add(40 * time.Millisecond)
+
+	t.Run("should record every stage of timestamped messages", func(t *testing.T) {
+		useCase := newTestUseCase(t)
+
+		useCase.Record(testTopic, testMAC, receivedAt.Add(-3*time.Second), receivedAt, committedAt)
+
+		assert.Equal(t, uint64(1), useCase.latency.Count(testTopic, StageDeviceToReceipt))
+		assert.Equal(t, uint64(1), useCase.latency.Count(testTopic, StageReceiptToCommit))
+		assert.Equal(t, uint64(1), useCase.latency.Count(testTopic, StageDeviceToCommit))
+	})
+
+	t.Run("should only record the commit latency of untimestamped messages", func(t *testing.T) {
+		useCase := newTestUseCase(t)
+
+		useCase.Record(testTopic, testMAC, time.Time{}, receivedAt, committedAt)
+
+		assert.Equal(t, uint64(1), useCase.latency.Count(testTopic, StageReceiptToCommit))
+		assert.Equal(t, uint64(0), useCase.latency.Count(testTopic, StageDeviceToReceipt))
+		assert.Equal(t, float64(1), useCase.untimestamped.Value(testTopic))
+	})
+
+	t.Run("should tolerate devices slightly ahead", func(t *testing.T) {
+		useCase := newTestUseCase(t)
+
+		useCase.Record(testTopic, testMAC, receivedAt.Add(time.Second), receivedAt, committedAt)
+
+		assert.Equal(t, uint64(1), useCase.latency.Count(testTopic, StageDeviceToReceipt))
+		assert.Equal(t, float64(0), useCase.clockDrift.Value(testTopic))
+	})
+
+	t.Run("should count drifted device clocks", func(t *testing.T) {
+		useCase := newTestUseCase(t)
+
+		useCase.Record(testTopic, testMAC, receivedAt.Add(time.Hour), receivedAt, committedAt)
+
+		assert.Equal(t, float64(1), useCase.clockDrift.Value(testTopic))
+		assert.Equal(t, uint64(0), useCase.latency.Count(testTopic, StageDeviceToReceipt))
+		assert.Equal(t, uint64(1), useCase.latency.Count(testTopic, StageReceiptToCommit))
+	})
+}
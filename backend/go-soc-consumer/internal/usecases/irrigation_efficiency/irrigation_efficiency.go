@@ -0,0 +1,159 @@
+package irrigationefficiency
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+)
+
+// EfficiencyConfig holds the settings of the irrigation efficiency analytics
+type EfficiencyConfig struct {
+	SensorType       string        // sensor type read as soil moisture
+	TargetMoisture   float64       // moisture an irrigation aims for, in the unit of the sensor type
+	SettleWindow     time.Duration // readings are looked for this long before an opening and after a closing
+	DeclineThreshold float64       // relative drop of the gain per liter flagged as a decline
+	DefaultWindow    time.Duration // range analyzed when the query gives no start
+	MaxRange         time.Duration // longest range a query may cover
+}
+
+// DefaultEfficiencyConfig returns default configuration
+func DefaultEfficiencyConfig() *EfficiencyConfig {
+	return &EfficiencyConfig{
+		SensorType:       "soil_moisture",
+		TargetMoisture:   35,
+		SettleWindow:     2 * time.Hour,
+		DeclineThreshold: 0.3,
+		DefaultWindow:    30 * 24 * time.Hour,
+		MaxRange:         90 * 24 * time.Hour,
+	}
+}
+
+// IrrigationEfficiencyUseCase measures how well the irrigations of each zone moisten the soil, from
+// the recorded valve transitions and the soil moisture readings of the devices of the zone
+type IrrigationEfficiencyUseCase interface {
+	// Zones returns the efficiency of every configured zone over [from, to), sorted by zone name.
+	// to defaults to now and from to DefaultWindow before to; invalid ranges fail with
+	// ErrInvalidAnalyticsQuery.
+	Zones(ctx context.Context, from, to time.Time) ([]*entities.IrrigationZoneEfficiency, error)
+
+	// Zone returns the efficiency of one zone over [from, to), with the same range defaults as
+	// Zones. Unknown zones fail with ErrIrrigationZoneNotFound.
+	Zone(ctx context.Context, zone string, from, to time.Time) (*entities.IrrigationZoneEfficiency, error)
+}
+
+// useCaseImpl implements the IrrigationEfficiencyUseCase interface
+type useCaseImpl struct {
+	valveStateRepo  repositoryports.ValveStateRepository
+	measurementRepo repositoryports.MeasurementRepository
+	zones           map[string]entities.IrrigationZone
+	config          *EfficiencyConfig
+	now             func() time.Time
+}
+
+// NewIrrigationEfficiencyUseCase creates an irrigation efficiency use case for the given zones
+func NewIrrigationEfficiencyUseCase(
+	valveStateRepo repositoryports.ValveStateRepository,
+	measurementRepo repositoryports.MeasurementRepository,
+	zones []entities.IrrigationZone,
+	config *EfficiencyConfig,
+) IrrigationEfficiencyUseCase {
+	if config == nil {
+		config = DefaultEfficiencyConfig()
+	}
+
+	byName := make(map[string]entities.IrrigationZone, len(zones))
+	for _, zone := range zones {
+		byName[strings.ToLower(zone.Name)] = zone
+	}
+
+	return &useCaseImpl{
+		valveStateRepo:  valveStateRepo,
+		measurementRepo: measurementRepo,
+		zones:           byName,
+		config:          config,
+		now:             time.Now,
+	}
+}
+
+// Zones analyzes every zone over the resolved range
+func (uc *useCaseImpl) Zones(ctx context.Context, from, to time.Time) ([]*entities.IrrigationZoneEfficiency, error) {
+	from, to, err := uc.resolveRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(uc.zones))
+	for name := range uc.zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	efficiencies := make([]*entities.IrrigationZoneEfficiency, 0, len(names))
+	for _, name := range names {
+		efficiency, err := uc.analyze(ctx, uc.zones[name], from, to)
+		if err != nil {
+			return nil, err
+		}
+		efficiencies = append(efficiencies, efficiency)
+	}
+	return efficiencies, nil
+}
+
+// Zone analyzes one zone over the resolved range
+func (uc *useCaseImpl) Zone(ctx context.Context, zone string, from, to time.Time) (*entities.IrrigationZoneEfficiency, error) {
+	irrigationZone, ok := uc.zones[strings.ToLower(strings.TrimSpace(zone))]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domainerrors.ErrIrrigationZoneNotFound, zone)
+	}
+
+	from, to, err := uc.resolveRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return uc.analyze(ctx, irrigationZone, from, to)
+}
+
+// analyze builds the irrigation events of every device of the zone. Only irrigations opened and
+// closed within the range are counted.
+func (uc *useCaseImpl) analyze(ctx context.Context, zone entities.IrrigationZone, from, to time.Time) (*entities.IrrigationZoneEfficiency, error) {
+	var events []*entities.IrrigationEvent
+	for _, macAddress := range zone.MACAddresses {
+		transitions, err := uc.valveStateRepo.ListBetween(ctx, macAddress, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load valve transitions of %s: %w", macAddress, err)
+		}
+		if len(transitions) == 0 {
+			continue
+		}
+
+		readings, err := uc.measurementRepo.History(ctx, macAddress, uc.config.SensorType, from.Add(-uc.config.SettleWindow), to.Add(uc.config.SettleWindow))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s readings of %s: %w", uc.config.SensorType, macAddress, err)
+		}
+		events = append(events, entities.IrrigationEventsFrom(transitions, readings, zone.FlowRate, uc.config.TargetMoisture, uc.config.SettleWindow)...)
+	}
+	return entities.NewIrrigationZoneEfficiency(zone.Name, from, to, events, uc.config.DeclineThreshold), nil
+}
+
+// resolveRange applies the range defaults and checks the range
+func (uc *useCaseImpl) resolveRange(from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = uc.now()
+	}
+	if from.IsZero() {
+		from = to.Add(-uc.config.DefaultWindow)
+	}
+	switch {
+	case !from.Before(to):
+		return from, to, fmt.Errorf("%w: from must be before to", domainerrors.ErrInvalidAnalyticsQuery)
+	case to.Sub(from) > uc.config.MaxRange:
+		return from, to, fmt.Errorf("%w: range exceeds %s", domainerrors.ErrInvalidAnalyticsQuery, uc.config.MaxRange)
+	}
+	return from, to, nil
+}
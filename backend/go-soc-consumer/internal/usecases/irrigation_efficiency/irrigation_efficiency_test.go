@@ -0,0 +1,96 @@
+package irrigationefficiency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+var testZones = []entities.IrrigationZone{
+	{Name: "zone_b", MACAddresses: []string{"AA:BB:CC:DD:EE:03"}},
+	{Name: "zone_a", MACAddresses: []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"}, FlowRate: 10},
+}
+
+func newTestUseCase(t *testing.T, now time.Time) (*useCaseImpl, *mocks.MockValveStateRepository, *mocks.MockMeasurementRepository) {
+	valveStateRepo := mocks.NewMockValveStateRepository(t)
+	measurementRepo := mocks.NewMockMeasurementRepository(t)
+	useCase := NewIrrigationEfficiencyUseCase(valveStateRepo, measurementRepo, testZones, nil).(*useCaseImpl)
+	useCase.now = func() time.Time { return now }
+	return useCase, valveStateRepo, measurementRepo
+}
+
+func TestIrrigationEfficiencyUseCase_Zone(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	from := now.Add(-30 * 24 * time.Hour)
+	openedAt := time.Date(2025, 6, 10, 6, 0, 0, 0, time.UTC)
+
+	t.Run("should measure the irrigations of every device of the zone", func(t *testing.T) {
+		useCase, valveStateRepo, measurementRepo := newTestUseCase(t, now)
+		valveStateRepo.EXPECT().ListBetween(mock.Anything, "AA:BB:CC:DD:EE:01", from, now).Return([]*entities.ValveTransition{
+			{MACAddress: "AA:BB:CC:DD:EE:01", State: entities.ValveStateOpen, ChangedAt: openedAt},
+			{MACAddress: "AA:BB:CC:DD:EE:01", State: entities.ValveStateClosed, ChangedAt: openedAt.Add(30 * time.Minute)},
+		}, nil).Once()
+		valveStateRepo.EXPECT().ListBetween(mock.Anything, "AA:BB:CC:DD:EE:02", from, now).Return(nil, nil).Once()
+		measurementRepo.EXPECT().History(mock.Anything, "AA:BB:CC:DD:EE:01", "soil_moisture", from.Add(-2*time.Hour), now.Add(2*time.Hour)).Return([]*entities.Measurement{
+			{MACAddress: "AA:BB:CC:DD:EE:01", SensorType: "soil_moisture", Value: 24, Timestamp: openedAt.Add(-time.Minute)},
+			{MACAddress: "AA:BB:CC:DD:EE:01", SensorType: "soil_moisture", Value: 36, Timestamp: openedAt.Add(45 * time.Minute)},
+		}, nil).Once()
+
+		efficiency, err := useCase.Zone(context.Background(), "Zone_A", time.Time{}, time.Time{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "zone_a", efficiency.Zone)
+		assert.Equal(t, from, efficiency.From)
+		assert.Equal(t, now, efficiency.To)
+		require.Len(t, efficiency.Events, 1)
+		assert.Equal(t, 300.0, efficiency.TotalLiters)
+		require.NotNil(t, efficiency.AverageGainPerLiter)
+		assert.InDelta(t, 12.0/300, *efficiency.AverageGainPerLiter, 1e-9)
+		require.NotNil(t, efficiency.AverageTimeToTarget)
+		assert.Equal(t, 45*time.Minute, *efficiency.AverageTimeToTarget)
+	})
+
+	t.Run("should reject unknown zones and invalid ranges", func(t *testing.T) {
+		useCase, _, _ := newTestUseCase(t, now)
+
+		_, err := useCase.Zone(context.Background(), "zone_z", time.Time{}, time.Time{})
+		assert.ErrorIs(t, err, domainerrors.ErrIrrigationZoneNotFound)
+
+		_, err = useCase.Zone(context.Background(), "zone_a", now, now.Add(-time.Hour))
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidAnalyticsQuery)
+		_, err = useCase.Zone(context.Background(), "zone_a", now.Add(-100*24*time.Hour), now)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidAnalyticsQuery)
+	})
+
+	t.Run("should fail when the history cannot be read", func(t *testing.T) {
+		useCase, valveStateRepo, _ := newTestUseCase(t, now)
+		valveStateRepo.EXPECT().ListBetween(mock.Anything, "AA:BB:CC:DD:EE:01", from, now).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := useCase.Zone(context.Background(), "zone_a", time.Time{}, time.Time{})
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
+
+func TestIrrigationEfficiencyUseCase_Zones(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	from := now.Add(-7 * 24 * time.Hour)
+	useCase, valveStateRepo, _ := newTestUseCase(t, now)
+	valveStateRepo.EXPECT().ListBetween(mock.Anything, mock.Anything, from, now).Return(nil, nil).Times(3)
+
+	efficiencies, err := useCase.Zones(context.Background(), from, time.Time{})
+
+	require.NoError(t, err)
+	require.Len(t, efficiencies, 2)
+	assert.Equal(t, "zone_a", efficiencies[0].Zone)
+	assert.Equal(t, "zone_b", efficiencies[1].Zone)
+	assert.Empty(t, efficiencies[1].Events)
+}
@@ -0,0 +1,193 @@
+package irrigationexperiments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	irrigationefficiency "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/irrigation_efficiency"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// IrrigationExperimentsUseCase runs A/B experiments comparing irrigation strategies. Irrigation zones
+// are assigned to the variants of an experiment, and the outcome of each variant is measured by the
+// irrigation efficiency analytics of its zones.
+type IrrigationExperimentsUseCase interface {
+	// Create starts an experiment. It fails with ErrInvalidIrrigationExperiment for invalid requests
+	// and unknown zones, and with ErrIrrigationExperimentConflict when a zone is already in an
+	// experiment that has not ended.
+	Create(ctx context.Context, request entities.IrrigationExperimentRequest) (*entities.IrrigationExperiment, error)
+
+	// List returns every experiment, most recently started first
+	List(ctx context.Context) ([]*entities.IrrigationExperiment, error)
+
+	// Get returns an experiment, failing with ErrIrrigationExperimentNotFound
+	Get(ctx context.Context, id string) (*entities.IrrigationExperiment, error)
+
+	// End stops an experiment now, releasing its zones. It fails with
+	// ErrIrrigationExperimentNotFound, or ErrIrrigationExperimentEnded when it already ended.
+	End(ctx context.Context, id string) (*entities.IrrigationExperiment, error)
+
+	// Report compares the outcomes of the variants over [from, to), clipped to the time the
+	// experiment ran; zero bounds default to its start and to its end or now. Ranges outside the
+	// experiment or longer than the analytics allow fail with ErrInvalidAnalyticsQuery.
+	Report(ctx context.Context, id string, from, to time.Time) (*entities.IrrigationExperimentReport, error)
+}
+
+// useCaseImpl implements the IrrigationExperimentsUseCase interface
+type useCaseImpl struct {
+	experimentRepo    repositoryports.IrrigationExperimentRepository
+	efficiencyUseCase irrigationefficiency.IrrigationEfficiencyUseCase
+	zones             map[string]bool
+	loggerFactory     logger.LoggerFactory
+	now               func() time.Time
+}
+
+// NewIrrigationExperimentsUseCase creates an irrigation experiments use case over the given zones
+func NewIrrigationExperimentsUseCase(
+	experimentRepo repositoryports.IrrigationExperimentRepository,
+	efficiencyUseCase irrigationefficiency.IrrigationEfficiencyUseCase,
+	zones []entities.IrrigationZone,
+	loggerFactory logger.LoggerFactory,
+) IrrigationExperimentsUseCase {
+	known := make(map[string]bool, len(zones))
+	for _, zone := range zones {
+		known[strings.ToLower(zone.Name)] = true
+	}
+
+	return &useCaseImpl{
+		experimentRepo:    experimentRepo,
+		efficiencyUseCase: efficiencyUseCase,
+		zones:             known,
+		loggerFactory:     loggerFactory,
+		now:               time.Now,
+	}
+}
+
+// Create validates the experiment, checks that its zones are free and stores it
+func (uc *useCaseImpl) Create(ctx context.Context, request entities.IrrigationExperimentRequest) (*entities.IrrigationExperiment, error) {
+	experiment, err := entities.NewIrrigationExperiment(request, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidIrrigationExperiment, err)
+	}
+	for _, zone := range experiment.Zones() {
+		if !uc.zones[zone] {
+			return nil, fmt.Errorf("%w: unknown irrigation zone %s", domainerrors.ErrInvalidIrrigationExperiment, zone)
+		}
+	}
+
+	existing, err := uc.experimentRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.Ended() {
+			continue
+		}
+		for _, zone := range other.Zones() {
+			for _, requested := range experiment.Zones() {
+				if zone == requested {
+					return nil, fmt.Errorf("%w: zone %s is in experiment %s", domainerrors.ErrIrrigationExperimentConflict, zone, other.Name)
+				}
+			}
+		}
+	}
+
+	if err := uc.experimentRepo.Create(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("irrigation_experiment_created",
+		zap.String("experiment_id", experiment.ID),
+		zap.String("name", experiment.Name),
+		zap.Int("variants", len(experiment.Variants)),
+		zap.String("component", "irrigation_experiments_usecase"),
+	)
+	return experiment, nil
+}
+
+// List loads every experiment
+func (uc *useCaseImpl) List(ctx context.Context) ([]*entities.IrrigationExperiment, error) {
+	return uc.experimentRepo.List(ctx)
+}
+
+// Get loads an experiment
+func (uc *useCaseImpl) Get(ctx context.Context, id string) (*entities.IrrigationExperiment, error) {
+	return uc.experimentRepo.FindByID(ctx, id)
+}
+
+// End stops a running experiment
+func (uc *useCaseImpl) End(ctx context.Context, id string) (*entities.IrrigationExperiment, error) {
+	experiment, err := uc.experimentRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Ended() {
+		return nil, fmt.Errorf("%w: ended at %s", domainerrors.ErrIrrigationExperimentEnded, experiment.EndedAt.Format(time.RFC3339))
+	}
+	if err := experiment.End(uc.now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidIrrigationExperiment, err)
+	}
+	if err := uc.experimentRepo.Update(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("irrigation_experiment_ended",
+		zap.String("experiment_id", experiment.ID),
+		zap.String("name", experiment.Name),
+		zap.String("component", "irrigation_experiments_usecase"),
+	)
+	return experiment, nil
+}
+
+// Report analyzes every zone of the experiment over the resolved range and compares the variants
+func (uc *useCaseImpl) Report(ctx context.Context, id string, from, to time.Time) (*entities.IrrigationExperimentReport, error) {
+	experiment, err := uc.experimentRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err = uc.resolveRange(experiment, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	efficiencies := make(map[string]*entities.IrrigationZoneEfficiency)
+	for _, zone := range experiment.Zones() {
+		efficiency, err := uc.efficiencyUseCase.Zone(ctx, zone, from, to)
+		// A zone removed from the configuration since the experiment started counts as not irrigated
+		if errors.Is(err, domainerrors.ErrIrrigationZoneNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze zone %s: %w", zone, err)
+		}
+		efficiencies[zone] = efficiency
+	}
+	return entities.NewIrrigationExperimentReport(experiment, from, to, efficiencies), nil
+}
+
+// resolveRange applies the experiment bounds to the requested range
+func (uc *useCaseImpl) resolveRange(experiment *entities.IrrigationExperiment, from, to time.Time) (time.Time, time.Time, error) {
+	end := uc.now()
+	if experiment.EndedAt != nil {
+		end = *experiment.EndedAt
+	}
+	if from.IsZero() || from.Before(experiment.StartedAt) {
+		from = experiment.StartedAt
+	}
+	if to.IsZero() || to.After(end) {
+		to = end
+	}
+	if !from.Before(to) {
+		return from, to, fmt.Errorf("%w: the range does not overlap the experiment", domainerrors.ErrInvalidAnalyticsQuery)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,128 @@
+package irrigationexperiments
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+var testZones = []entities.IrrigationZone{{Name: "North"}, {Name: "South"}, {Name: "East"}}
+
+func newTestUseCase(t *testing.T) (*useCaseImpl, *mocks.MockIrrigationExperimentRepository, *mocks.MockIrrigationEfficiencyUseCase) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	repo := mocks.NewMockIrrigationExperimentRepository(t)
+	efficiency := mocks.NewMockIrrigationEfficiencyUseCase(t)
+	useCase := NewIrrigationExperimentsUseCase(repo, efficiency, testZones, loggerFactory).(*useCaseImpl)
+	useCase.now = func() time.Time { return testNow }
+	return useCase, repo, efficiency
+}
+
+func testRequest() entities.IrrigationExperimentRequest {
+	return entities.IrrigationExperimentRequest{
+		Name: "Tomato season",
+		Variants: []entities.ExperimentVariant{
+			{Name: "Control", Strategy: entities.IrrigationStrategyRuleBased, Zones: []string{"north"}},
+			{Name: "ML", Strategy: entities.IrrigationStrategyML, Zones: []string{"south"}},
+		},
+	}
+}
+
+func TestIrrigationExperimentsUseCase_Create(t *testing.T) {
+	t.Run("should store an experiment over free zones", func(t *testing.T) {
+		useCase, repo, _ := newTestUseCase(t)
+		ended, err := entities.NewIrrigationExperiment(testRequest(), testNow.Add(-48*time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, ended.End(testNow.Add(-24*time.Hour)))
+		repo.EXPECT().List(mock.Anything).Return([]*entities.IrrigationExperiment{ended}, nil).Once()
+		repo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.IrrigationExperiment")).Return(nil).Once()
+
+		experiment, err := useCase.Create(context.Background(), testRequest())
+		require.NoError(t, err)
+		assert.Equal(t, testNow, experiment.StartedAt)
+	})
+
+	t.Run("should reject zones of a running experiment", func(t *testing.T) {
+		useCase, repo, _ := newTestUseCase(t)
+		running, err := entities.NewIrrigationExperiment(testRequest(), testNow.Add(-48*time.Hour))
+		require.NoError(t, err)
+		repo.EXPECT().List(mock.Anything).Return([]*entities.IrrigationExperiment{running}, nil).Once()
+
+		_, err = useCase.Create(context.Background(), testRequest())
+		assert.ErrorIs(t, err, domainerrors.ErrIrrigationExperimentConflict)
+		assert.ErrorContains(t, err, "zone north is in experiment Tomato season")
+	})
+
+	t.Run("should reject unknown zones", func(t *testing.T) {
+		useCase, _, _ := newTestUseCase(t)
+		request := testRequest()
+		request.Variants[1].Zones = []string{"west"}
+
+		_, err := useCase.Create(context.Background(), request)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidIrrigationExperiment)
+		assert.ErrorContains(t, err, "unknown irrigation zone west")
+	})
+}
+
+func TestIrrigationExperimentsUseCase_End(t *testing.T) {
+	useCase, repo, _ := newTestUseCase(t)
+	experiment, err := entities.NewIrrigationExperiment(testRequest(), testNow.Add(-time.Hour))
+	require.NoError(t, err)
+	repo.EXPECT().FindByID(mock.Anything, experiment.ID).Return(experiment, nil).Twice()
+	repo.EXPECT().Update(mock.Anything, experiment).Return(nil).Once()
+
+	ended, err := useCase.End(context.Background(), experiment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, testNow, *ended.EndedAt)
+
+	_, err = useCase.End(context.Background(), experiment.ID)
+	assert.ErrorIs(t, err, domainerrors.ErrIrrigationExperimentEnded)
+}
+
+func TestIrrigationExperimentsUseCase_Report(t *testing.T) {
+	startedAt := testNow.Add(-7 * 24 * time.Hour)
+
+	t.Run("should clip the range to the experiment and compare the variants", func(t *testing.T) {
+		useCase, repo, efficiency := newTestUseCase(t)
+		experiment, err := entities.NewIrrigationExperiment(testRequest(), startedAt)
+		require.NoError(t, err)
+		repo.EXPECT().FindByID(mock.Anything, experiment.ID).Return(experiment, nil).Once()
+
+		gain := func(value float64) *float64 { return &value }
+		efficiency.EXPECT().Zone(mock.Anything, "north", startedAt, testNow).Return(&entities.IrrigationZoneEfficiency{
+			Zone: "north", Events: []*entities.IrrigationEvent{{Liters: 100, MoistureBefore: gain(20), MoistureAfter: gain(30)}},
+		}, nil).Once()
+		efficiency.EXPECT().Zone(mock.Anything, "south", startedAt, testNow).Return(nil, domainerrors.ErrIrrigationZoneNotFound).Once()
+
+		report, err := useCase.Report(context.Background(), experiment.ID, startedAt.Add(-time.Hour), time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, startedAt, report.From)
+		assert.Equal(t, testNow, report.To)
+		require.Len(t, report.Outcomes, 2)
+		assert.Equal(t, 1, report.Outcomes[0].Irrigations)
+		assert.Equal(t, 0, report.Outcomes[1].Irrigations)
+		assert.Equal(t, "Control", report.Leader)
+	})
+
+	t.Run("should reject a range outside the experiment", func(t *testing.T) {
+		useCase, repo, _ := newTestUseCase(t)
+		experiment, err := entities.NewIrrigationExperiment(testRequest(), startedAt)
+		require.NoError(t, err)
+		repo.EXPECT().FindByID(mock.Anything, experiment.ID).Return(experiment, nil).Once()
+
+		_, err = useCase.Report(context.Background(), experiment.ID, time.Time{}, startedAt.Add(-time.Hour))
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidAnalyticsQuery)
+	})
+}
@@ -0,0 +1,584 @@
+package irrigationscheduling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	valvecontrol "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/valve_control"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// Run outcomes counted by the irrigation_schedule_runs_total counter
+const (
+	runStarted    = "started"
+	runCompleted  = "completed"
+	runOverridden = "overridden"
+	runFailed     = "failed"
+	runAdvanced   = "advanced"
+)
+
+// SchedulerConfig holds configuration for the irrigation scheduler
+type SchedulerConfig struct {
+	Interval time.Duration  // how often the schedules are evaluated
+	Location *time.Location // time zone the cron expressions are evaluated in
+	// OverlapHorizon is how far ahead the runs of the schedules of a device are checked for overlaps
+	OverlapHorizon time.Duration
+	// ProactiveLead advances the next run of a device whose soil is predicted to dry within this
+	// lead and before the run starts; 0 never advances runs
+	ProactiveLead time.Duration
+}
+
+// DefaultSchedulerConfig returns default configuration
+func DefaultSchedulerConfig() *SchedulerConfig {
+	return &SchedulerConfig{
+		Interval:       30 * time.Second,
+		Location:       time.UTC,
+		OverlapHorizon: 31 * 24 * time.Hour,
+	}
+}
+
+// MoistureForecast reports the predicted soil moisture of the zone of a device
+type MoistureForecast interface {
+	// ForDevice returns the latest prediction of the zone of the device, and false when there is none
+	ForDevice(macAddress string) (*entities.MoisturePrediction, bool)
+}
+
+// IrrigationSchedulingUseCase manages the irrigation schedules of devices and runs them: every
+// pass opens the valve of each schedule with a run due and closes it once the run is over. The run
+// state is stored with the schedules, so a run in progress is still closed after a restart.
+// Operators put a device under manual control with an override, which suspends its schedules.
+// With a moisture forecast, the next run of a device whose soil is about to dry starts early.
+type IrrigationSchedulingUseCase interface {
+	// Create adds a schedule to a device, failing with ErrDeviceNotFound for an unknown device,
+	// ErrInvalidIrrigationSchedule for invalid fields and ErrIrrigationScheduleOverlap when its runs
+	// would overlap those of the other schedules of the device
+	Create(ctx context.Context, macAddress string, request entities.IrrigationScheduleRequest) (*entities.IrrigationSchedule, error)
+
+	// Update replaces the fields of a schedule, failing as Create does and with
+	// ErrIrrigationScheduleNotFound for an unknown schedule. A run in progress is not affected.
+	Update(ctx context.Context, id string, request entities.IrrigationScheduleRequest) (*entities.IrrigationSchedule, error)
+
+	// Delete removes a schedule, closing the valve when one of its runs is in progress
+	Delete(ctx context.Context, id string) error
+
+	// List returns the schedules of a device, or of every device when macAddress is empty
+	List(ctx context.Context, macAddress string) ([]*entities.IrrigationSchedule, error)
+
+	// SetOverride suspends the schedules of a device until the given time. Runs in progress are
+	// abandoned with the valve left as it is.
+	SetOverride(ctx context.Context, macAddress, reason string, until time.Time) (*entities.IrrigationOverride, error)
+
+	// ClearOverride resumes the schedules of a device, failing with ErrIrrigationOverrideNotFound
+	// when it has no override
+	ClearOverride(ctx context.Context, macAddress string) error
+
+	// Overrides returns the overrides in effect, by MAC address
+	Overrides(ctx context.Context) ([]*entities.IrrigationOverride, error)
+
+	// ActiveOverrides reports the overrides found in effect by the last pass as operator overrides
+	ActiveOverrides() []*entities.OperatorOverride
+
+	// Evaluate runs one pass over every schedule
+	Evaluate(ctx context.Context) error
+
+	// Run evaluates the schedules periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// useCaseImpl implements the IrrigationSchedulingUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	scheduleRepo  repositoryports.IrrigationScheduleRepository
+	overrideRepo  repositoryports.IrrigationOverrideRepository
+	valves        valvecontrol.ValveControlUseCase
+	forecast      MoistureForecast // nil when runs are never advanced
+	config        *SchedulerConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+
+	// evaluateMu holds passes one at a time
+	evaluateMu sync.Mutex
+	// replaced holds, by schedule ID, the start of the scheduled run an advanced run replaced. It
+	// lives in memory, so a replaced run still happens after a restart.
+	replaced map[string]time.Time
+
+	mu        sync.RWMutex
+	overrides []*entities.IrrigationOverride
+
+	runs *metrics.Vec
+}
+
+// NewIrrigationSchedulingUseCase creates a new irrigation scheduling use case; a nil forecast never
+// advances runs
+func NewIrrigationSchedulingUseCase(
+	deviceRepo repositoryports.DeviceRepository,
+	scheduleRepo repositoryports.IrrigationScheduleRepository,
+	overrideRepo repositoryports.IrrigationOverrideRepository,
+	valves valvecontrol.ValveControlUseCase,
+	forecast MoistureForecast,
+	config *SchedulerConfig,
+	loggerFactory logger.LoggerFactory,
+) IrrigationSchedulingUseCase {
+	if config == nil {
+		config = DefaultSchedulerConfig()
+	}
+
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		scheduleRepo:  scheduleRepo,
+		overrideRepo:  overrideRepo,
+		valves:        valves,
+		forecast:      forecast,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		replaced:      make(map[string]time.Time),
+		runs:          metrics.NewCounterVec("irrigation_schedule_runs_total", "Scheduled irrigation runs by outcome", "result"),
+	}
+}
+
+// Create validates the schedule against the other schedules of the device and stores it
+func (uc *useCaseImpl) Create(ctx context.Context, macAddress string, request entities.IrrigationScheduleRequest) (*entities.IrrigationSchedule, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := entities.NewIrrigationSchedule(device.GetID(), request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidIrrigationSchedule, err)
+	}
+	if err := uc.checkOverlap(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := uc.scheduleRepo.Create(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("irrigation_schedule_created",
+		zap.String("schedule_id", schedule.ID),
+		zap.String("mac_address", schedule.MACAddress),
+		zap.String("cron", schedule.Cron),
+		zap.Duration("duration", schedule.Duration),
+		zap.String("component", "irrigation_scheduling_usecase"),
+	)
+	return schedule, nil
+}
+
+// Update validates the changed schedule against the other schedules of the device and stores it
+func (uc *useCaseImpl) Update(ctx context.Context, id string, request entities.IrrigationScheduleRequest) (*entities.IrrigationSchedule, error) {
+	schedule, err := uc.scheduleRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := schedule.Apply(request, uc.now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidIrrigationSchedule, err)
+	}
+	if err := uc.checkOverlap(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := uc.scheduleRepo.Update(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("irrigation_schedule_updated",
+		zap.String("schedule_id", schedule.ID),
+		zap.String("mac_address", schedule.MACAddress),
+		zap.String("cron", schedule.Cron),
+		zap.Duration("duration", schedule.Duration),
+		zap.Bool("enabled", schedule.Enabled),
+		zap.String("component", "irrigation_scheduling_usecase"),
+	)
+	return schedule, nil
+}
+
+// checkOverlap fails when the runs of the enabled schedules of the device overlap within the horizon
+func (uc *useCaseImpl) checkOverlap(ctx context.Context, schedule *entities.IrrigationSchedule) error {
+	if !schedule.Enabled {
+		return nil
+	}
+
+	existing, err := uc.scheduleRepo.List(ctx, schedule.MACAddress)
+	if err != nil {
+		return fmt.Errorf("failed to load irrigation schedules: %w", err)
+	}
+	schedules := []*entities.IrrigationSchedule{schedule}
+	for _, other := range existing {
+		if other.ID != schedule.ID {
+			schedules = append(schedules, other)
+		}
+	}
+
+	from := uc.now()
+	first, second, found := entities.FindScheduleOverlap(schedules, from, from.Add(uc.config.OverlapHorizon), uc.config.Location)
+	if !found {
+		return nil
+	}
+	at := second.Start.In(uc.config.Location).Format(time.RFC3339)
+	if first.Schedule == second.Schedule {
+		return fmt.Errorf("%w: a run of %q starts at %s before the previous one ends", domainerrors.ErrIrrigationScheduleOverlap, first.Schedule.Name, at)
+	}
+	return fmt.Errorf("%w: a run of %q starts at %s while %q is running", domainerrors.ErrIrrigationScheduleOverlap, second.Schedule.Name, at, first.Schedule.Name)
+}
+
+// Delete removes the schedule and closes the valve it left open
+func (uc *useCaseImpl) Delete(ctx context.Context, id string) error {
+	schedule, err := uc.scheduleRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.scheduleRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if schedule.Running() {
+		if _, err := uc.valves.Close(ctx, schedule.MACAddress); err != nil {
+			uc.loggerFactory.Core().Error("irrigation_run_close_failed",
+				zap.Error(err),
+				zap.String("schedule_id", schedule.ID),
+				zap.String("mac_address", schedule.MACAddress),
+				zap.String("component", "irrigation_scheduling_usecase"),
+			)
+		}
+	}
+	uc.loggerFactory.Core().Info("irrigation_schedule_deleted",
+		zap.String("schedule_id", schedule.ID),
+		zap.String("mac_address", schedule.MACAddress),
+		zap.Bool("was_running", schedule.Running()),
+		zap.String("component", "irrigation_scheduling_usecase"),
+	)
+	return nil
+}
+
+// List returns the schedules of the device, or of every device
+func (uc *useCaseImpl) List(ctx context.Context, macAddress string) ([]*entities.IrrigationSchedule, error) {
+	return uc.scheduleRepo.List(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+}
+
+// SetOverride stores the override of a known device
+func (uc *useCaseImpl) SetOverride(ctx context.Context, macAddress, reason string, until time.Time) (*entities.IrrigationOverride, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, err
+	}
+
+	override, err := entities.NewIrrigationOverride(device.GetID(), reason, until, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidIrrigationOverride, err)
+	}
+	if err := uc.overrideRepo.Upsert(ctx, override); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("irrigation_override_set",
+		zap.String("mac_address", override.MACAddress),
+		zap.Time("until", override.Until),
+		zap.String("reason", override.Reason),
+		zap.String("component", "irrigation_scheduling_usecase"),
+	)
+	return override, nil
+}
+
+// ClearOverride removes the override of the device
+func (uc *useCaseImpl) ClearOverride(ctx context.Context, macAddress string) error {
+	macAddress = strings.ToUpper(strings.TrimSpace(macAddress))
+	if err := uc.overrideRepo.Delete(ctx, macAddress); err != nil {
+		return err
+	}
+
+	uc.loggerFactory.Core().Info("irrigation_override_cleared",
+		zap.String("mac_address", macAddress),
+		zap.String("component", "irrigation_scheduling_usecase"),
+	)
+	return nil
+}
+
+// Overrides returns the stored overrides still in effect
+func (uc *useCaseImpl) Overrides(ctx context.Context) ([]*entities.IrrigationOverride, error) {
+	overrides, err := uc.overrideRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	active := make([]*entities.IrrigationOverride, 0, len(overrides))
+	for _, override := range overrides {
+		if override.Active(now) {
+			active = append(active, override)
+		}
+	}
+	return active, nil
+}
+
+// ActiveOverrides reports the overrides in effect at the last pass
+func (uc *useCaseImpl) ActiveOverrides() []*entities.OperatorOverride {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	now := uc.now()
+	var overrides []*entities.OperatorOverride
+	for _, override := range uc.overrides {
+		if override.Active(now) {
+			overrides = append(overrides, override.OperatorOverride())
+		}
+	}
+	return overrides
+}
+
+// Evaluate closes the runs that are over before opening the valves of the runs that are due, so a
+// run starting as another one of the device ends leaves the valve open
+func (uc *useCaseImpl) Evaluate(ctx context.Context) error {
+	uc.evaluateMu.Lock()
+	defer uc.evaluateMu.Unlock()
+
+	overrides, err := uc.Overrides(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load irrigation overrides: %w", err)
+	}
+	uc.mu.Lock()
+	uc.overrides = overrides
+	uc.mu.Unlock()
+	overridden := make(map[string]bool, len(overrides))
+	for _, override := range overrides {
+		overridden[override.MACAddress] = true
+	}
+
+	schedules, err := uc.scheduleRepo.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to load irrigation schedules: %w", err)
+	}
+
+	now := uc.now().UTC()
+	for _, schedule := range schedules {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		switch {
+		case !schedule.Running():
+		case overridden[schedule.MACAddress]:
+			uc.abandon(ctx, schedule)
+		case !now.Before(*schedule.RunEndsAt):
+			uc.finish(ctx, schedule)
+		}
+	}
+
+	for _, schedule := range schedules {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !schedule.Enabled || schedule.Running() || overridden[schedule.MACAddress] {
+			continue
+		}
+		start, due := schedule.DueRun(now, uc.config.Location)
+		if replaced, ok := uc.replaced[schedule.ID]; due && ok && replaced.Equal(start) {
+			continue
+		}
+		if due {
+			uc.start(ctx, schedule, start)
+		}
+	}
+
+	if uc.forecast != nil && uc.config.ProactiveLead > 0 {
+		uc.advance(ctx, schedules, overridden, now)
+	}
+	return nil
+}
+
+// advance starts now the next run of the devices whose soil is predicted to reach the threshold
+// within ProactiveLead and before that run starts. Predictions made before the last run of a device
+// ended are ignored, since they do not account for its water. The advanced run replaces the
+// scheduled one.
+func (uc *useCaseImpl) advance(ctx context.Context, schedules []*entities.IrrigationSchedule, overridden map[string]bool, now time.Time) {
+	byDevice := make(map[string][]*entities.IrrigationSchedule)
+	for _, schedule := range schedules {
+		byDevice[schedule.MACAddress] = append(byDevice[schedule.MACAddress], schedule)
+	}
+
+	for _, schedule := range schedules {
+		// Replaced runs are forgotten once over
+		if replaced, ok := uc.replaced[schedule.ID]; ok && !now.Before(replaced.Add(schedule.Duration)) {
+			delete(uc.replaced, schedule.ID)
+		}
+	}
+
+	for macAddress, deviceSchedules := range byDevice {
+		if err := ctx.Err(); err != nil {
+			return
+		}
+		if overridden[macAddress] {
+			continue
+		}
+
+		var next entities.IrrigationRun
+		var lastEnd time.Time
+		busy := false
+		for _, schedule := range deviceSchedules {
+			busy = busy || schedule.Running()
+			if schedule.LastRunAt != nil && schedule.LastRunAt.Add(schedule.Duration).After(lastEnd) {
+				lastEnd = schedule.LastRunAt.Add(schedule.Duration)
+			}
+			if _, ok := uc.replaced[schedule.ID]; ok || !schedule.Enabled {
+				continue
+			}
+			if run, ok := schedule.NextRun(now, uc.config.Location); ok && (next.Schedule == nil || run.Start.Before(next.Start)) {
+				next = run
+			}
+		}
+		if busy || next.Schedule == nil {
+			continue
+		}
+
+		prediction, ok := uc.forecast.ForDevice(macAddress)
+		if !ok || !prediction.PredictedAt.After(lastEnd) {
+			continue
+		}
+		thresholdAt, ok := prediction.ThresholdAt()
+		if !ok || thresholdAt.After(now.Add(uc.config.ProactiveLead)) || !thresholdAt.Before(next.Start) {
+			continue
+		}
+
+		if !uc.start(ctx, next.Schedule, now) {
+			continue
+		}
+		uc.replaced[next.Schedule.ID] = next.Start
+		uc.runs.Inc(runAdvanced)
+		uc.loggerFactory.Core().Info("irrigation_run_advanced",
+			zap.String("schedule_id", next.Schedule.ID),
+			zap.String("mac_address", macAddress),
+			zap.String("zone", prediction.Zone),
+			zap.String("model", prediction.Model),
+			zap.Time("threshold_at", thresholdAt),
+			zap.Time("replaced_start", next.Start),
+			zap.String("component", "irrigation_scheduling_usecase"),
+		)
+	}
+}
+
+// start opens the valve for a due run and reports whether it started; a failed run is tried again
+// on the next pass while it lasts
+func (uc *useCaseImpl) start(ctx context.Context, schedule *entities.IrrigationSchedule, start time.Time) bool {
+	command, err := uc.valves.Open(ctx, schedule.MACAddress)
+	if err != nil {
+		uc.runs.Inc(runFailed)
+		uc.logRunFailure("irrigation_run_start_failed", schedule, err)
+		return false
+	}
+
+	end := start.Add(schedule.Duration)
+	if err := uc.scheduleRepo.RecordRun(ctx, schedule.ID, &start, &end); err != nil {
+		uc.logRunFailure("irrigation_run_record_failed", schedule, err)
+		return false
+	}
+	schedule.LastRunAt, schedule.RunEndsAt = &start, &end
+	uc.runs.Inc(runStarted)
+	uc.loggerFactory.Core().Info("irrigation_run_started",
+		zap.String("schedule_id", schedule.ID),
+		zap.String("mac_address", schedule.MACAddress),
+		zap.String("command_id", command.ID),
+		zap.Time("ends_at", end),
+		zap.String("component", "irrigation_scheduling_usecase"),
+	)
+	return true
+}
+
+// finish closes the valve of a run that is over; a failed close is tried again on the next pass
+func (uc *useCaseImpl) finish(ctx context.Context, schedule *entities.IrrigationSchedule) {
+	command, err := uc.valves.Close(ctx, schedule.MACAddress)
+	if err != nil {
+		uc.runs.Inc(runFailed)
+		uc.logRunFailure("irrigation_run_close_failed", schedule, err)
+		return
+	}
+
+	if err := uc.scheduleRepo.RecordRun(ctx, schedule.ID, schedule.LastRunAt, nil); err != nil {
+		uc.logRunFailure("irrigation_run_record_failed", schedule, err)
+		return
+	}
+	schedule.RunEndsAt = nil
+	uc.runs.Inc(runCompleted)
+	uc.loggerFactory.Core().Info("irrigation_run_completed",
+		zap.String("schedule_id", schedule.ID),
+		zap.String("mac_address", schedule.MACAddress),
+		zap.String("command_id", command.ID),
+		zap.String("component", "irrigation_scheduling_usecase"),
+	)
+}
+
+// abandon stops tracking a run of an overridden device, leaving its valve to the operator
+func (uc *useCaseImpl) abandon(ctx context.Context, schedule *entities.IrrigationSchedule) {
+	if err := uc.scheduleRepo.RecordRun(ctx, schedule.ID, schedule.LastRunAt, nil); err != nil {
+		uc.logRunFailure("irrigation_run_record_failed", schedule, err)
+		return
+	}
+	schedule.RunEndsAt = nil
+	uc.runs.Inc(runOverridden)
+	uc.loggerFactory.Core().Info("irrigation_run_overridden",
+		zap.String("schedule_id", schedule.ID),
+		zap.String("mac_address", schedule.MACAddress),
+		zap.String("component", "irrigation_scheduling_usecase"),
+	)
+}
+
+// logRunFailure logs a failure to act on a run; a deleted schedule is not worth an error
+func (uc *useCaseImpl) logRunFailure(event string, schedule *entities.IrrigationSchedule, err error) {
+	if errors.Is(err, domainerrors.ErrIrrigationScheduleNotFound) {
+		return
+	}
+	uc.loggerFactory.Core().Error(event,
+		zap.Error(err),
+		zap.String("schedule_id", schedule.ID),
+		zap.String("mac_address", schedule.MACAddress),
+		zap.String("component", "irrigation_scheduling_usecase"),
+	)
+}
+
+// Run evaluates the schedules periodically until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("irrigation_scheduler_started", "irrigation_scheduling_usecase",
+		zap.Duration("interval", uc.config.Interval),
+		zap.String("time_zone", uc.config.Location.String()),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := uc.Evaluate(ctx); err != nil && ctx.Err() == nil {
+			uc.loggerFactory.Core().Error("irrigation_schedule_evaluation_failed",
+				zap.Error(err),
+				zap.String("component", "irrigation_scheduling_usecase"),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("irrigation_scheduler_stopped", "irrigation_scheduling_usecase")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return uc.runs.Collect()
+}
+
+// MetricsCollector returns the irrigation scheduling metrics collector
+func MetricsCollector(useCase IrrigationSchedulingUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package irrigationscheduling
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+type testDeps struct {
+	deviceRepo   *mocks.MockDeviceRepository
+	scheduleRepo *mocks.MockIrrigationScheduleRepository
+	overrideRepo *mocks.MockIrrigationOverrideRepository
+	valves       *mocks.MockValveControlUseCase
+	forecast     *mocks.MockMoistureForecast
+	now          time.Time
+}
+
+func newTestUseCase(t *testing.T) (*useCaseImpl, *testDeps) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	deps := &testDeps{
+		deviceRepo:   mocks.NewMockDeviceRepository(t),
+		scheduleRepo: mocks.NewMockIrrigationScheduleRepository(t),
+		overrideRepo: mocks.NewMockIrrigationOverrideRepository(t),
+		valves:       mocks.NewMockValveControlUseCase(t),
+		forecast:     mocks.NewMockMoistureForecast(t),
+		now:          time.Date(2025, 6, 2, 6, 10, 0, 0, time.UTC),
+	}
+	config := &SchedulerConfig{Interval: time.Minute, Location: time.UTC, OverlapHorizon: 7 * 24 * time.Hour}
+	uc := NewIrrigationSchedulingUseCase(deps.deviceRepo, deps.scheduleRepo, deps.overrideRepo, deps.valves, deps.forecast, config, loggerFactory).(*useCaseImpl)
+	uc.now = func() time.Time { return deps.now }
+	return uc, deps
+}
+
+func testDevice(t *testing.T) *entities.Device {
+	device, err := entities.NewDevice(testMAC, "North valve", "10.0.0.1", "Greenhouse 2")
+	require.NoError(t, err)
+	return device
+}
+
+func testSchedule(id, cron string, duration time.Duration) *entities.IrrigationSchedule {
+	return &entities.IrrigationSchedule{
+		ID:         id,
+		MACAddress: testMAC,
+		Name:       id,
+		Cron:       cron,
+		Duration:   duration,
+		Enabled:    true,
+		CreatedAt:  time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestIrrigationSchedulingUseCase_Create(t *testing.T) {
+	morning := entities.IrrigationScheduleRequest{Name: "Morning", Cron: "0 6 * * *", Duration: 30 * time.Minute, Enabled: true}
+
+	t.Run("should store a schedule of a known device", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(testDevice(t), nil).Once()
+		deps.scheduleRepo.EXPECT().List(mock.Anything, testMAC).Return([]*entities.IrrigationSchedule{testSchedule("evening", "0 18 * * *", time.Hour)}, nil).Once()
+		deps.scheduleRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+
+		schedule, err := uc.Create(context.Background(), "aa:bb:cc:dd:ee:ff", morning)
+		require.NoError(t, err)
+		assert.Equal(t, testMAC, schedule.MACAddress)
+		assert.Equal(t, "0 6 * * *", schedule.Cron)
+	})
+
+	t.Run("should reject overlapping schedules", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(testDevice(t), nil).Once()
+		deps.scheduleRepo.EXPECT().List(mock.Anything, testMAC).Return([]*entities.IrrigationSchedule{testSchedule("weekend", "15 6 * * 0,6", 10*time.Minute)}, nil).Once()
+
+		_, err := uc.Create(context.Background(), testMAC, morning)
+		assert.ErrorIs(t, err, domainerrors.ErrIrrigationScheduleOverlap)
+		assert.ErrorContains(t, err, `a run of "weekend" starts at 2025-06-07T06:15:00Z while "Morning" is running`)
+	})
+
+	t.Run("should reject invalid schedules and unknown devices", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(testDevice(t), nil).Once()
+		_, err := uc.Create(context.Background(), testMAC, entities.IrrigationScheduleRequest{Name: "Morning", Cron: "0 6 * *", Duration: time.Minute})
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidIrrigationSchedule)
+
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+		_, err = uc.Create(context.Background(), testMAC, morning)
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+	})
+}
+
+func TestIrrigationSchedulingUseCase_Update(t *testing.T) {
+	t.Run("should not check disabled schedules for overlaps", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.scheduleRepo.EXPECT().FindByID(mock.Anything, "morning").Return(testSchedule("morning", "0 6 * * *", 30*time.Minute), nil).Once()
+		deps.scheduleRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(schedule *entities.IrrigationSchedule) bool {
+			return !schedule.Enabled && schedule.Cron == "*/5 * * * *" && schedule.UpdatedAt.Equal(deps.now)
+		})).Return(nil).Once()
+
+		_, err := uc.Update(context.Background(), "morning", entities.IrrigationScheduleRequest{Name: "Morning", Cron: "*/5 * * * *", Duration: time.Hour})
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject a schedule overlapping its own runs", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.scheduleRepo.EXPECT().FindByID(mock.Anything, "morning").Return(testSchedule("morning", "0 6 * * *", 30*time.Minute), nil).Once()
+		deps.scheduleRepo.EXPECT().List(mock.Anything, testMAC).Return([]*entities.IrrigationSchedule{testSchedule("morning", "0 6 * * *", 30*time.Minute)}, nil).Once()
+
+		_, err := uc.Update(context.Background(), "morning", entities.IrrigationScheduleRequest{Name: "Morning", Cron: "*/5 * * * *", Duration: time.Hour, Enabled: true})
+		assert.ErrorIs(t, err, domainerrors.ErrIrrigationScheduleOverlap)
+		assert.ErrorContains(t, err, "before the previous one ends")
+	})
+}
+
+func TestIrrigationSchedulingUseCase_Delete(t *testing.T) {
+	uc, deps := newTestUseCase(t)
+	running := testSchedule("morning", "0 6 * * *", 30*time.Minute)
+	endsAt := deps.now.Add(20 * time.Minute)
+	running.RunEndsAt = &endsAt
+	deps.scheduleRepo.EXPECT().FindByID(mock.Anything, "morning").Return(running, nil).Once()
+	deps.scheduleRepo.EXPECT().Delete(mock.Anything, "morning").Return(nil).Once()
+	deps.valves.EXPECT().Close(mock.Anything, testMAC).Return(&entities.DeviceCommand{ID: "command-1"}, nil).Once()
+
+	require.NoError(t, uc.Delete(context.Background(), "morning"))
+}
+
+func TestIrrigationSchedulingUseCase_Evaluate(t *testing.T) {
+	t.Run("should close finished runs before opening due ones", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		early := testSchedule("early", "40 5 * * *", 30*time.Minute)
+		earlyStart := time.Date(2025, 6, 2, 5, 40, 0, 0, time.UTC)
+		earlyEnd := earlyStart.Add(30 * time.Minute)
+		early.LastRunAt, early.RunEndsAt = &earlyStart, &earlyEnd
+		morning := testSchedule("morning", "10 6 * * *", 30*time.Minute)
+		disabled := testSchedule("disabled", "0 6 * * *", time.Hour)
+		disabled.Enabled = false
+
+		deps.overrideRepo.EXPECT().List(mock.Anything).Return(nil, nil).Once()
+		deps.scheduleRepo.EXPECT().List(mock.Anything, "").Return([]*entities.IrrigationSchedule{morning, early, disabled}, nil).Once()
+		var calls []string
+		deps.valves.EXPECT().Close(mock.Anything, testMAC).RunAndReturn(func(context.Context, string) (*entities.DeviceCommand, error) {
+			calls = append(calls, "close")
+			return &entities.DeviceCommand{ID: "command-1"}, nil
+		}).Once()
+		deps.valves.EXPECT().Open(mock.Anything, testMAC).RunAndReturn(func(context.Context, string) (*entities.DeviceCommand, error) {
+			calls = append(calls, "open")
+			return &entities.DeviceCommand{ID: "command-2"}, nil
+		}).Once()
+		deps.scheduleRepo.EXPECT().RecordRun(mock.Anything, "early", &earlyStart, (*time.Time)(nil)).Return(nil).Once()
+		morningStart := deps.now
+		morningEnd := deps.now.Add(30 * time.Minute)
+		deps.scheduleRepo.EXPECT().RecordRun(mock.Anything, "morning", &morningStart, &morningEnd).Return(nil).Once()
+
+		require.NoError(t, uc.Evaluate(context.Background()))
+		assert.Equal(t, []string{"close", "open"}, calls)
+		assert.Equal(t, float64(1), uc.runs.Value(runStarted))
+		assert.Equal(t, float64(1), uc.runs.Value(runCompleted))
+	})
+
+	t.Run("should retry a run that failed to start", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		morning := testSchedule("morning", "0 6 * * *", 30*time.Minute)
+		deps.overrideRepo.EXPECT().List(mock.Anything).Return(nil, nil).Twice()
+		deps.scheduleRepo.EXPECT().List(mock.Anything, "").Return([]*entities.IrrigationSchedule{morning}, nil).Twice()
+		deps.valves.EXPECT().Open(mock.Anything, testMAC).Return(nil, errors.New("broker unavailable")).Once()
+		deps.valves.EXPECT().Open(mock.Anything, testMAC).Return(&entities.DeviceCommand{ID: "command-1"}, nil).Once()
+		deps.scheduleRepo.EXPECT().RecordRun(mock.Anything, "morning", mock.Anything, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, uc.Evaluate(context.Background()))
+		require.NoError(t, uc.Evaluate(context.Background()))
+		assert.Equal(t, float64(1), uc.runs.Value(runFailed))
+		assert.Equal(t, float64(1), uc.runs.Value(runStarted))
+	})
+
+	t.Run("should leave overridden devices to the operator", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		running := testSchedule("running", "0 6 * * *", 30*time.Minute)
+		start := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+		end := start.Add(30 * time.Minute)
+		running.LastRunAt, running.RunEndsAt = &start, &end
+		due := testSchedule("due", "10 6 * * *", 10*time.Minute)
+		override := &entities.IrrigationOverride{MACAddress: testMAC, Reason: "Rain", Until: deps.now.Add(time.Hour), CreatedAt: deps.now}
+		expired := &entities.IrrigationOverride{MACAddress: "AA:BB:CC:DD:EE:01", Until: deps.now}
+
+		deps.overrideRepo.EXPECT().List(mock.Anything).Return([]*entities.IrrigationOverride{override, expired}, nil).Once()
+		deps.scheduleRepo.EXPECT().List(mock.Anything, "").Return([]*entities.IrrigationSchedule{running, due}, nil).Once()
+		deps.scheduleRepo.EXPECT().RecordRun(mock.Anything, "running", &start, (*time.Time)(nil)).Return(nil).Once()
+
+		require.NoError(t, uc.Evaluate(context.Background()))
+		assert.Equal(t, float64(1), uc.runs.Value(runOverridden))
+		overrides := uc.ActiveOverrides()
+		require.Len(t, overrides, 1)
+		assert.Equal(t, entities.OverrideKindIrrigationManual, overrides[0].Kind)
+	})
+
+	t.Run("should advance the next run of a device predicted to dry before it", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		uc.config.ProactiveLead = 2 * time.Hour
+		evening := testSchedule("evening", "0 18 * * *", 30*time.Minute)
+		morning := testSchedule("morning", "0 6 * * *", 20*time.Minute)
+		lastStart := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+		lastEnd := lastStart.Add(20 * time.Minute)
+		morning.LastRunAt = &lastStart
+		hours := 1.5
+		prediction := &entities.MoisturePrediction{Zone: "north", Model: "baseline", MACAddress: testMAC, PredictedAt: deps.now.Add(15 * time.Minute), HoursUntilThreshold: &hours}
+
+		deps.now = lastEnd.Add(10 * time.Minute)
+		deps.overrideRepo.EXPECT().List(mock.Anything).Return(nil, nil).Twice()
+		deps.scheduleRepo.EXPECT().List(mock.Anything, "").Return([]*entities.IrrigationSchedule{evening, morning}, nil).Twice()
+		deps.forecast.EXPECT().ForDevice(testMAC).Return(prediction, true).Once()
+		deps.valves.EXPECT().Open(mock.Anything, testMAC).Return(&entities.DeviceCommand{ID: "command-1"}, nil).Once()
+		start := deps.now
+		end := deps.now.Add(30 * time.Minute)
+		deps.scheduleRepo.EXPECT().RecordRun(mock.Anything, "evening", &start, &end).Return(nil).Once()
+
+		require.NoError(t, uc.Evaluate(context.Background()))
+		assert.Equal(t, float64(1), uc.runs.Value(runAdvanced))
+		assert.Equal(t, time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC), uc.replaced["evening"])
+
+		// The replaced evening run does not start
+		deps.now = time.Date(2025, 6, 2, 18, 0, 0, 0, time.UTC)
+		evening.LastRunAt, evening.RunEndsAt = &start, nil
+		deps.forecast.EXPECT().ForDevice(testMAC).Return(nil, false).Once()
+		require.NoError(t, uc.Evaluate(context.Background()))
+		assert.Equal(t, float64(1), uc.runs.Value(runStarted))
+	})
+
+	t.Run("should ignore predictions made before the last run ended", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		uc.config.ProactiveLead = 2 * time.Hour
+		morning := testSchedule("morning", "0 6 * * *", 20*time.Minute)
+		lastStart := time.Date(2025, 6, 2, 6, 0, 0, 0, time.UTC)
+		morning.LastRunAt = &lastStart
+		deps.now = time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)
+		hours := 0.0
+		prediction := &entities.MoisturePrediction{Zone: "north", MACAddress: testMAC, PredictedAt: lastStart.Add(10 * time.Minute), HoursUntilThreshold: &hours}
+
+		deps.overrideRepo.EXPECT().List(mock.Anything).Return(nil, nil).Once()
+		deps.scheduleRepo.EXPECT().List(mock.Anything, "").Return([]*entities.IrrigationSchedule{morning}, nil).Once()
+		deps.forecast.EXPECT().ForDevice(testMAC).Return(prediction, true).Once()
+
+		require.NoError(t, uc.Evaluate(context.Background()))
+		assert.Equal(t, float64(0), uc.runs.Value(runAdvanced))
+	})
+}
+
+func TestIrrigationSchedulingUseCase_Overrides(t *testing.T) {
+	t.Run("should set an override of a known device", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(testDevice(t), nil).Once()
+		deps.overrideRepo.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(override *entities.IrrigationOverride) bool {
+			return override.MACAddress == testMAC && override.Reason == "Rain"
+		})).Return(nil).Once()
+
+		_, err := uc.SetOverride(context.Background(), testMAC, "Rain", deps.now.Add(time.Hour))
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject overrides ending in the past", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(testDevice(t), nil).Once()
+
+		_, err := uc.SetOverride(context.Background(), testMAC, "", deps.now.Add(-time.Minute))
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidIrrigationOverride)
+	})
+
+	t.Run("should clear an override", func(t *testing.T) {
+		uc, deps := newTestUseCase(t)
+		deps.overrideRepo.EXPECT().Delete(mock.Anything, testMAC).Return(domainerrors.ErrIrrigationOverrideNotFound).Once()
+
+		assert.ErrorIs(t, uc.ClearOverride(context.Background(), "aa:bb:cc:dd:ee:ff"), domainerrors.ErrIrrigationOverrideNotFound)
+	})
+}
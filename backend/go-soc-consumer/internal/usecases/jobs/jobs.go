@@ -0,0 +1,166 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// JobQueueConfig holds configuration for the job queue workers
+type JobQueueConfig struct {
+	Workers       int
+	PollInterval  time.Duration // how often idle workers look for jobs
+	LeaseDuration time.Duration // how long a claimed job stays leased without a heartbeat
+	MaxAttempts   int           // default attempts for enqueued jobs
+	RetryBackoff  time.Duration // delay before the first retry, doubled on each attempt
+}
+
+// DefaultJobQueueConfig returns default configuration
+func DefaultJobQueueConfig() *JobQueueConfig {
+	return &JobQueueConfig{
+		Workers:       2,
+		PollInterval:  2 * time.Second,
+		LeaseDuration: time.Minute,
+		MaxAttempts:   3,
+		RetryBackoff:  10 * time.Second,
+	}
+}
+
+// JobQueueUseCase runs long-running work in the background with retries, progress and cancellation
+type JobQueueUseCase interface {
+	// Register associates a handler with a job type; must be called before Run
+	Register(jobType string, handler ports.JobHandler)
+
+	// Enqueue creates a queued job of a registered type
+	Enqueue(ctx context.Context, jobType string, payload interface{}) (*entities.Job, error)
+
+	// Get returns a job by ID
+	Get(ctx context.Context, id string) (*entities.Job, error)
+
+	// Cancel requests cancellation of a queued or running job
+	Cancel(ctx context.Context, id string) (*entities.Job, error)
+
+	// Run starts the workers and blocks until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// useCaseImpl implements the JobQueueUseCase interface
+type useCaseImpl struct {
+	repo          repositoryports.JobRepository
+	config        *JobQueueConfig
+	loggerFactory logger.LoggerFactory
+
+	mu       sync.RWMutex
+	handlers map[string]ports.JobHandler
+
+	finished *metrics.Vec
+	running  *metrics.Vec
+}
+
+// NewJobQueueUseCase creates a new job queue use case
+func NewJobQueueUseCase(repo repositoryports.JobRepository, config *JobQueueConfig, loggerFactory logger.LoggerFactory) JobQueueUseCase {
+	if config == nil {
+		config = DefaultJobQueueConfig()
+	}
+
+	return &useCaseImpl{
+		repo:          repo,
+		config:        config,
+		loggerFactory: loggerFactory,
+		handlers:      make(map[string]ports.JobHandler),
+		finished:      metrics.NewCounterVec("jobs_finished_total", "Job attempts finished, by type and outcome", "type", "status"),
+		running:       metrics.NewGaugeVec("jobs_running", "Jobs currently running, by type", "type"),
+	}
+}
+
+// Register associates a handler with a job type
+func (uc *useCaseImpl) Register(jobType string, handler ports.JobHandler) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.handlers[jobType] = handler
+}
+
+func (uc *useCaseImpl) handler(jobType string) (ports.JobHandler, bool) {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	handler, ok := uc.handlers[jobType]
+	return handler, ok
+}
+
+func (uc *useCaseImpl) types() []string {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	types := make([]string, 0, len(uc.handlers))
+	for jobType := range uc.handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Enqueue creates a queued job of a registered type
+func (uc *useCaseImpl) Enqueue(ctx context.Context, jobType string, payload interface{}) (*entities.Job, error) {
+	if _, ok := uc.handler(jobType); !ok {
+		return nil, fmt.Errorf("%w: %s", domainerrors.ErrUnknownJobType, jobType)
+	}
+
+	job, err := entities.NewJob(jobType, payload, uc.config.MaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	uc.loggerFactory.Core().Info("job_enqueued",
+		zap.String("job_id", job.ID),
+		zap.String("job_type", job.Type),
+		zap.String("component", "job_queue_usecase"),
+	)
+	return job, nil
+}
+
+// Get returns a job by ID
+func (uc *useCaseImpl) Get(ctx context.Context, id string) (*entities.Job, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+// Cancel requests cancellation; a running job stops at its next heartbeat
+func (uc *useCaseImpl) Cancel(ctx context.Context, id string) (*entities.Job, error) {
+	job, err := uc.repo.RequestCancel(ctx, id)
+	if err != nil {
+		return job, err
+	}
+
+	uc.loggerFactory.Core().Info("job_cancel_requested",
+		zap.String("job_id", job.ID),
+		zap.String("job_type", job.Type),
+		zap.String("status", job.Status),
+		zap.String("component", "job_queue_usecase"),
+	)
+	return job, nil
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return append(uc.finished.Collect(), uc.running.Collect()...)
+}
+
+// MetricsCollector returns the job queue metrics collector
+func MetricsCollector(useCase JobQueueUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func testConfig() *JobQueueConfig {
+	return &JobQueueConfig{
+		Workers:       1,
+		PollInterval:  5 * time.Millisecond,
+		LeaseDuration: time.Second,
+		MaxAttempts:   2,
+		RetryBackoff:  time.Minute,
+	}
+}
+
+func claimedJob(t *testing.T, attempts int) *entities.Job {
+	job, err := entities.NewJob("export", map[string]string{"format": "csv"}, 2)
+	require.NoError(t, err)
+	job.Status = entities.JobStatusRunning
+	job.Attempts = attempts
+	return job
+}
+
+func TestJobQueueUseCase_Enqueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should reject unknown job types", func(t *testing.T) {
+		repo := mocks.NewMockJobRepository(t)
+		useCase := NewJobQueueUseCase(repo, testConfig(), createTestLoggerFactory(t))
+
+		job, err := useCase.Enqueue(ctx, "export", nil)
+		assert.ErrorIs(t, err, domainerrors.ErrUnknownJobType)
+		assert.Nil(t, job)
+	})
+
+	t.Run("should persist queued job", func(t *testing.T) {
+		repo := mocks.NewMockJobRepository(t)
+		useCase := NewJobQueueUseCase(repo, testConfig(), createTestLoggerFactory(t))
+		useCase.Register("export", func(ctx context.Context, job *entities.Job, progress ports.JobProgressFunc) (interface{}, error) {
+			return nil, nil
+		})
+
+		repo.EXPECT().Create(ctx, mock.AnythingOfType("*entities.Job")).Return(nil).Once()
+
+		job, err := useCase.Enqueue(ctx, "export", map[string]string{"format": "csv"})
+		require.NoError(t, err)
+		assert.Equal(t, entities.JobStatusQueued, job.Status)
+		assert.Equal(t, 2, job.MaxAttempts)
+		assert.JSONEq(t, `{"format":"csv"}`, string(job.Payload))
+	})
+}
+
+func TestJobQueueUseCase_ProcessNext(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should report idle queue", func(t *testing.T) {
+		repo := mocks.NewMockJobRepository(t)
+		useCase := NewJobQueueUseCase(repo, testConfig(), createTestLoggerFactory(t)).(*useCaseImpl)
+
+		repo.EXPECT().ClaimNext(ctx, []string{}, mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+
+		processed, err := useCase.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.False(t, processed)
+	})
+
+	t.Run("should record result and progress on success", func(t *testing.T) {
+		repo := mocks.NewMockJobRepository(t)
+		useCase := NewJobQueueUseCase(repo, testConfig(), createTestLoggerFactory(t)).(*useCaseImpl)
+		useCase.Register("export", func(ctx context.Context, job *entities.Job, progress ports.JobProgressFunc) (interface{}, error) {
+			progress(50, "half way")
+			return map[string]int{"rows": 10}, nil
+		})
+
+		job := claimedJob(t, 1)
+		repo.EXPECT().ClaimNext(ctx, []string{"export"}, mock.AnythingOfType("time.Time")).Return(job, nil).Once()
+		repo.EXPECT().UpdateProgress(mock.Anything, job.ID, 50, "half way").Return(nil).Once()
+		repo.EXPECT().Finish(mock.Anything, job).Return(nil).Once()
+
+		processed, err := useCase.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, processed)
+		assert.Equal(t, entities.JobStatusSucceeded, job.Status)
+		assert.Equal(t, 100, job.Progress)
+		assert.JSONEq(t, `{"rows":10}`, string(job.Result))
+		assert.NotNil(t, job.FinishedAt)
+	})
+
+	t.Run("should requeue failed job with backoff while attempts remain", func(t *testing.T) {
+		repo := mocks.NewMockJobRepository(t)
+		useCase := NewJobQueueUseCase(repo, testConfig(), createTestLoggerFactory(t)).(*useCaseImpl)
+		useCase.Register("export", func(ctx context.Context, job *entities.Job, progress ports.JobProgressFunc) (interface{}, error) {
+			return nil, errors.New("storage unavailable")
+		})
+
+		job := claimedJob(t, 1)
+		repo.EXPECT().ClaimNext(ctx, []string{"export"}, mock.AnythingOfType("time.Time")).Return(job, nil).Once()
+		repo.EXPECT().Finish(mock.Anything, job).Return(nil).Once()
+
+		_, err := useCase.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, entities.JobStatusQueued, job.Status)
+		assert.Equal(t, "storage unavailable", job.LastError)
+		assert.True(t, job.RunAt.After(time.Now().Add(30*time.Second)))
+		assert.Nil(t, job.FinishedAt)
+	})
+
+	t.Run("should fail job on last attempt and recover panics", func(t *testing.T) {
+		repo := mocks.NewMockJobRepository(t)
+		useCase := NewJobQueueUseCase(repo, testConfig(), createTestLoggerFactory(t)).(*useCaseImpl)
+		useCase.Register("export", func(ctx context.Context, job *entities.Job, progress ports.JobProgressFunc) (interface{}, error) {
+			panic("boom")
+		})
+
+		job := claimedJob(t, 2)
+		repo.EXPECT().ClaimNext(ctx, []string{"export"}, mock.AnythingOfType("time.Time")).Return(job, nil).Once()
+		repo.EXPECT().Finish(mock.Anything, job).Return(nil).Once()
+
+		_, err := useCase.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, entities.JobStatusFailed, job.Status)
+		assert.Contains(t, job.LastError, "boom")
+		assert.NotNil(t, job.FinishedAt)
+	})
+
+	t.Run("should cancel running job when cancellation is requested", func(t *testing.T) {
+		repo := mocks.NewMockJobRepository(t)
+		useCase := NewJobQueueUseCase(repo, testConfig(), createTestLoggerFactory(t)).(*useCaseImpl)
+		useCase.Register("export", func(ctx context.Context, job *entities.Job, progress ports.JobProgressFunc) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+		job := claimedJob(t, 1)
+		repo.EXPECT().ClaimNext(ctx, []string{"export"}, mock.AnythingOfType("time.Time")).Return(job, nil).Once()
+		repo.EXPECT().Heartbeat(mock.Anything, job.ID, mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+		repo.EXPECT().Finish(mock.Anything, job).Return(nil).Once()
+
+		_, err := useCase.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, entities.JobStatusCancelled, job.Status)
+		assert.NotNil(t, job.FinishedAt)
+	})
+
+	t.Run("should hand job back without counting the attempt when stopping", func(t *testing.T) {
+		repo := mocks.NewMockJobRepository(t)
+		useCase := NewJobQueueUseCase(repo, testConfig(), createTestLoggerFactory(t)).(*useCaseImpl)
+		runCtx, cancel := context.WithCancel(ctx)
+		useCase.Register("export", func(ctx context.Context, job *entities.Job, progress ports.JobProgressFunc) (interface{}, error) {
+			cancel()
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+		job := claimedJob(t, 1)
+		repo.EXPECT().ClaimNext(runCtx, []string{"export"}, mock.AnythingOfType("time.Time")).Return(job, nil).Once()
+		repo.EXPECT().Finish(mock.Anything, job).Return(nil).Once()
+
+		_, err := useCase.ProcessNext(runCtx)
+		require.NoError(t, err)
+		assert.Equal(t, entities.JobStatusQueued, job.Status)
+		assert.Equal(t, 0, job.Attempts)
+	})
+}
+
+func TestJobQueueUseCase_Cancel(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewMockJobRepository(t)
+	useCase := NewJobQueueUseCase(repo, testConfig(), createTestLoggerFactory(t))
+
+	repo.EXPECT().RequestCancel(ctx, "missing").Return(nil, domainerrors.ErrJobNotFound).Once()
+
+	_, err := useCase.Cancel(ctx, "missing")
+	assert.ErrorIs(t, err, domainerrors.ErrJobNotFound)
+}
@@ -0,0 +1,233 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+)
+
+// errCancelRequested is the cancellation cause of a job whose cancellation was requested through the API
+var errCancelRequested = errors.New("job cancellation requested")
+
+// Run starts the workers and blocks until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("job_queue_started", "job_queue_usecase",
+		zap.Int("workers", uc.config.Workers),
+		zap.Strings("job_types", uc.types()),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < uc.config.Workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			uc.work(ctx, worker)
+		}(i)
+	}
+	wg.Wait()
+
+	uc.loggerFactory.Application().LogApplicationEvent("job_queue_stopped", "job_queue_usecase")
+}
+
+// work claims and runs jobs until the context is cancelled, sleeping when the queue is empty
+func (uc *useCaseImpl) work(ctx context.Context, worker int) {
+	for {
+		processed, err := uc.ProcessNext(ctx)
+		if err != nil && ctx.Err() == nil {
+			uc.loggerFactory.Core().Error("job_worker_error",
+				zap.Error(err),
+				zap.Int("worker", worker),
+				zap.String("component", "job_queue_usecase"),
+			)
+		}
+		if processed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(uc.config.PollInterval):
+		}
+	}
+}
+
+// ProcessNext claims and runs a single job, returning false when no job was runnable
+func (uc *useCaseImpl) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := uc.repo.ClaimNext(ctx, uc.types(), time.Now().UTC().Add(uc.config.LeaseDuration))
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	uc.execute(ctx, job)
+	return true, nil
+}
+
+// execute runs the job handler while keeping the lease alive, then records the outcome
+func (uc *useCaseImpl) execute(ctx context.Context, job *entities.Job) {
+	start := time.Now()
+	uc.running.Add(1, job.Type)
+	defer uc.running.Add(-1, job.Type)
+
+	uc.loggerFactory.Core().Info("job_started",
+		zap.String("job_id", job.ID),
+		zap.String("job_type", job.Type),
+		zap.Int("attempt", job.Attempts),
+		zap.String("component", "job_queue_usecase"),
+	)
+
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	stopHeartbeat := make(chan struct{})
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		uc.heartbeat(jobCtx, job.ID, cancel, stopHeartbeat)
+	}()
+
+	result, err := uc.runHandler(jobCtx, job)
+	close(stopHeartbeat)
+	<-heartbeatDone
+
+	now := time.Now().UTC()
+	job.LeaseUntil = nil
+	switch {
+	case err != nil && errors.Is(context.Cause(jobCtx), errCancelRequested):
+		job.Status = entities.JobStatusCancelled
+		job.LastError = "cancelled"
+		job.FinishedAt = &now
+	case err != nil && ctx.Err() != nil:
+		// The queue is stopping, hand the job back without counting the attempt
+		job.Status = entities.JobStatusQueued
+		job.Attempts--
+		job.RunAt = now
+	case err != nil && job.CanRetry():
+		job.Status = entities.JobStatusQueued
+		job.LastError = err.Error()
+		job.RunAt = now.Add(uc.retryDelay(job.Attempts))
+	case err != nil:
+		job.Status = entities.JobStatusFailed
+		job.LastError = err.Error()
+		job.FinishedAt = &now
+	default:
+		job.Status = entities.JobStatusSucceeded
+		job.Progress = 100
+		job.FinishedAt = &now
+		if result != nil {
+			data, marshalErr := json.Marshal(result)
+			if marshalErr != nil {
+				job.Status = entities.JobStatusFailed
+				job.LastError = fmt.Sprintf("failed to encode job result: %v", marshalErr)
+			} else {
+				job.Result = data
+			}
+		}
+	}
+
+	// Record the outcome even if the queue is stopping
+	if finishErr := uc.repo.Finish(context.WithoutCancel(ctx), job); finishErr != nil {
+		uc.loggerFactory.Core().Error("job_finish_failed",
+			zap.Error(finishErr),
+			zap.String("job_id", job.ID),
+			zap.String("component", "job_queue_usecase"),
+		)
+	}
+
+	uc.finished.Inc(job.Type, job.Status)
+	uc.loggerFactory.Core().Info("job_attempt_finished",
+		zap.String("job_id", job.ID),
+		zap.String("job_type", job.Type),
+		zap.String("status", job.Status),
+		zap.Int("attempt", job.Attempts),
+		zap.String("error", job.LastError),
+		zap.Duration("duration", time.Since(start)),
+		zap.String("component", "job_queue_usecase"),
+	)
+}
+
+// runHandler invokes the registered handler, converting panics into errors
+func (uc *useCaseImpl) runHandler(ctx context.Context, job *entities.Job) (result interface{}, err error) {
+	handler, ok := uc.handler(job.Type)
+	if !ok {
+		return nil, fmt.Errorf("no handler registered for job type %s", job.Type)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job handler panicked: %v", recovered)
+		}
+	}()
+
+	progress := func(percent int, message string) {
+		if percent < 0 {
+			percent = 0
+		}
+		if percent > 100 {
+			percent = 100
+		}
+		job.Progress = percent
+		job.ProgressMessage = message
+		if err := uc.repo.UpdateProgress(ctx, job.ID, percent, message); err != nil && ctx.Err() == nil {
+			uc.loggerFactory.Core().Warn("job_progress_update_failed",
+				zap.Error(err),
+				zap.String("job_id", job.ID),
+				zap.String("component", "job_queue_usecase"),
+			)
+		}
+	}
+
+	return handler(ctx, job, progress)
+}
+
+// heartbeat extends the job lease periodically and cancels the job when cancellation was requested
+func (uc *useCaseImpl) heartbeat(ctx context.Context, jobID string, cancel context.CancelCauseFunc, stop <-chan struct{}) {
+	interval := uc.config.LeaseDuration / 3
+	if interval > uc.config.PollInterval {
+		interval = uc.config.PollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cancelRequested, err := uc.repo.Heartbeat(ctx, jobID, time.Now().UTC().Add(uc.config.LeaseDuration))
+		if err != nil {
+			if ctx.Err() == nil {
+				uc.loggerFactory.Core().Warn("job_heartbeat_failed",
+					zap.Error(err),
+					zap.String("job_id", jobID),
+					zap.String("component", "job_queue_usecase"),
+				)
+			}
+			continue
+		}
+		if cancelRequested {
+			cancel(errCancelRequested)
+			return
+		}
+	}
+}
+
+// retryDelay returns the exponential backoff before the given attempt is retried
+func (uc *useCaseImpl) retryDelay(attempt int) time.Duration {
+	delay := uc.config.RetryBackoff
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	return delay
+}
@@ -0,0 +1,262 @@
+package measurements
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// replayMatchWindow is how far from a replayed measurement a stored measurement may be stamped and still
+// be replaced by it, as for temperature and humidity readings
+const replayMatchWindow = 2 * time.Second
+
+// MeasurementUseCase stores measurements of the registered sensor types
+type MeasurementUseCase interface {
+	// StoreMeasurements checks every measurement against the registry and stores them together;
+	// a single unknown or implausible measurement rejects the whole message
+	StoreMeasurements(ctx context.Context, measurements []*entities.Measurement) error
+
+	// SensorTypes returns the registered sensor types sorted by name
+	SensorTypes() []*entities.SensorType
+
+	// EnsureViews creates or replaces the typed view of every registered sensor type
+	EnsureViews(ctx context.Context) error
+
+	// ListChannels returns the channels a device reported or that were configured for it
+	ListChannels(ctx context.Context, macAddress string) ([]*entities.SensorChannel, error)
+
+	// ConfigureChannel sets the name, scale and offset of the given channel of a device, registering
+	// it when the device has not reported it yet
+	ConfigureChannel(ctx context.Context, settings *entities.SensorChannel) (*entities.SensorChannel, error)
+}
+
+// measurementUseCase is the implementation of MeasurementUseCase
+type measurementUseCase struct {
+	repo        ports.MeasurementRepository
+	channels    ports.SensorChannelRepository
+	sensorTypes map[string]*entities.SensorType
+	coreLogger  logger.CoreLogger
+}
+
+// NewMeasurementUseCase creates a measurement use case for the built-in sensor types and the given ones;
+// a given type replaces the built-in type of the same name
+func NewMeasurementUseCase(repo ports.MeasurementRepository, channels ports.SensorChannelRepository, sensorTypes []*entities.SensorType, loggerFactory logger.LoggerFactory) (MeasurementUseCase, error) {
+	registry := make(map[string]*entities.SensorType)
+	for _, sensorType := range entities.DefaultSensorTypes() {
+		registry[sensorType.Name] = sensorType
+	}
+
+	configured := make(map[string]bool, len(sensorTypes))
+	for _, sensorType := range sensorTypes {
+		sensorType.Normalize()
+		if err := sensorType.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid sensor type: %w", err)
+		}
+		if configured[sensorType.Name] {
+			return nil, fmt.Errorf("sensor type %s is defined twice", sensorType.Name)
+		}
+		configured[sensorType.Name] = true
+		registry[sensorType.Name] = sensorType
+	}
+
+	return &measurementUseCase{
+		repo:        repo,
+		channels:    channels,
+		sensorTypes: registry,
+		coreLogger:  loggerFactory.Core(),
+	}, nil
+}
+
+// StoreMeasurements applies the calibration of each measurement's channel, stamps it with the unit of
+// its type and stores them. Channels reported for the first time are registered uncalibrated. Replayed
+// measurements replace those stored when the message was first handled.
+func (uc *measurementUseCase) StoreMeasurements(ctx context.Context, measurements []*entities.Measurement) error {
+	if len(measurements) == 0 {
+		return fmt.Errorf("%w: at least one measurement is required", domainerrors.ErrInvalidMeasurement)
+	}
+	if err := domainerrors.RequireInputs("measurements", measurements); err != nil {
+		return err
+	}
+
+	known, err := uc.deviceChannels(ctx, measurements)
+	if err != nil {
+		return err
+	}
+
+	var unseen []*entities.SensorChannel
+	for _, measurement := range measurements {
+		sensorType, ok := uc.sensorTypes[measurement.SensorType]
+		if !ok {
+			return fmt.Errorf("%w: %s", domainerrors.ErrUnknownSensorType, measurement.SensorType)
+		}
+		key := channelKey(measurement.MACAddress, measurement.SensorType, measurement.Channel)
+		if channel, ok := known[key]; ok {
+			measurement.Value = channel.Apply(measurement.Value)
+		} else {
+			channel, err := entities.NewSensorChannel(measurement.MACAddress, measurement.SensorType, measurement.Channel)
+			if err != nil {
+				return fmt.Errorf("%w: %v", domainerrors.ErrInvalidMeasurement, err)
+			}
+			known[key] = channel
+			unseen = append(unseen, channel)
+		}
+		if !sensorType.Accepts(measurement.Value) {
+			return fmt.Errorf("%w: %s value %v is outside [%v, %v]", domainerrors.ErrInvalidMeasurement, sensorType.Name, measurement.Value, sensorType.MinValue, sensorType.MaxValue)
+		}
+		measurement.Unit = sensorType.Unit
+	}
+
+	if eventports.IsReplay(ctx) {
+		err = uc.repo.Upsert(ctx, measurements, replayMatchWindow)
+	} else {
+		err = uc.repo.Create(ctx, measurements)
+	}
+	if err != nil {
+		uc.coreLogger.Error("failed_to_store_measurements",
+			zap.Error(err),
+			zap.String("mac_address", measurements[0].MACAddress),
+			zap.Int("count", len(measurements)),
+			zap.String("component", "measurement_use_case"),
+		)
+		return fmt.Errorf("failed to store measurements: %w", err)
+	}
+
+	// A channel that cannot be registered only misses from the channel list until it is reported again
+	if err := uc.channels.CreateMissing(ctx, unseen); err != nil {
+		uc.coreLogger.Warn("failed_to_register_sensor_channels",
+			zap.Error(err),
+			zap.String("mac_address", measurements[0].MACAddress),
+			zap.Int("count", len(unseen)),
+			zap.String("component", "measurement_use_case"),
+		)
+	}
+	return nil
+}
+
+// deviceChannels returns the stored channels of the devices of the measurements keyed by channelKey
+func (uc *measurementUseCase) deviceChannels(ctx context.Context, measurements []*entities.Measurement) (map[string]*entities.SensorChannel, error) {
+	known := make(map[string]*entities.SensorChannel)
+	loaded := make(map[string]bool)
+	for _, measurement := range measurements {
+		if loaded[measurement.MACAddress] {
+			continue
+		}
+		loaded[measurement.MACAddress] = true
+
+		channels, err := uc.channels.FindByDevice(ctx, measurement.MACAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load sensor channels: %w", err)
+		}
+		for _, channel := range channels {
+			known[channelKey(channel.MACAddress, channel.SensorType, channel.Channel)] = channel
+		}
+	}
+	return known, nil
+}
+
+// channelKey identifies a channel of a device
+func channelKey(macAddress, sensorType string, channel int) string {
+	return fmt.Sprintf("%s/%s/%d", macAddress, sensorType, channel)
+}
+
+// SensorTypes returns the registered sensor types sorted by name
+func (uc *measurementUseCase) SensorTypes() []*entities.SensorType {
+	sensorTypes := make([]*entities.SensorType, 0, len(uc.sensorTypes))
+	for _, sensorType := range uc.sensorTypes {
+		sensorTypes = append(sensorTypes, sensorType)
+	}
+	sort.Slice(sensorTypes, func(i, j int) bool {
+		return sensorTypes[i].Name < sensorTypes[j].Name
+	})
+	return sensorTypes
+}
+
+// EnsureViews creates or replaces the typed view of every registered sensor type
+func (uc *measurementUseCase) EnsureViews(ctx context.Context) error {
+	sensorTypes := uc.SensorTypes()
+	if err := uc.repo.EnsureTypeViews(ctx, sensorTypes); err != nil {
+		return fmt.Errorf("failed to create sensor type views: %w", err)
+	}
+
+	names := make([]string, 0, len(sensorTypes))
+	for _, sensorType := range sensorTypes {
+		names = append(names, sensorType.Name)
+	}
+	uc.coreLogger.Info("sensor_type_views_ensured",
+		zap.Strings("sensor_types", names),
+		zap.String("component", "measurement_use_case"),
+	)
+	return nil
+}
+
+// ListChannels returns the channels of a device ordered by sensor type and channel
+func (uc *measurementUseCase) ListChannels(ctx context.Context, macAddress string) ([]*entities.SensorChannel, error) {
+	channels, err := uc.channels.FindByDevice(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sensor channels: %w", err)
+	}
+	return channels, nil
+}
+
+// ConfigureChannel names and calibrates a channel of a registered sensor type; the calibration time
+// only changes when the scale or offset does
+func (uc *measurementUseCase) ConfigureChannel(ctx context.Context, settings *entities.SensorChannel) (*entities.SensorChannel, error) {
+	if err := domainerrors.RequireInput("channel settings", settings); err != nil {
+		return nil, err
+	}
+
+	channel, err := entities.NewSensorChannel(settings.MACAddress, settings.SensorType, settings.Channel)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidSensorChannel, err)
+	}
+	if _, ok := uc.sensorTypes[channel.SensorType]; !ok {
+		return nil, fmt.Errorf("%w: %s", domainerrors.ErrUnknownSensorType, channel.SensorType)
+	}
+
+	existing, err := uc.channels.FindByDevice(ctx, channel.MACAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sensor channels: %w", err)
+	}
+	for _, stored := range existing {
+		if stored.SensorType == channel.SensorType && stored.Channel == channel.Channel {
+			channel = stored
+			break
+		}
+	}
+
+	now := time.Now().UTC()
+	channel.Name = settings.Name
+	channel.Normalize()
+	if channel.Scale != settings.Scale || channel.Offset != settings.Offset || channel.CalibratedAt == nil {
+		if err := channel.Calibrate(settings.Scale, settings.Offset, now); err != nil {
+			return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidSensorChannel, err)
+		}
+	}
+	if err := channel.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidSensorChannel, err)
+	}
+	channel.UpdatedAt = now
+
+	if err := uc.channels.Save(ctx, channel); err != nil {
+		return nil, fmt.Errorf("failed to save sensor channel: %w", err)
+	}
+	uc.coreLogger.Info("sensor_channel_configured",
+		zap.String("mac_address", channel.MACAddress),
+		zap.String("sensor_type", channel.SensorType),
+		zap.Int("channel", channel.Channel),
+		zap.Float64("scale", channel.Scale),
+		zap.Float64("offset", channel.Offset),
+		zap.String("component", "measurement_use_case"),
+	)
+	return channel, nil
+}
@@ -0,0 +1,254 @@
+package measurements
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func newTestUseCase(t *testing.T, repo *mocks.MockMeasurementRepository, channels *mocks.MockSensorChannelRepository) MeasurementUseCase {
+	loggerFactory, err := logger.NewDevelopment()
+	require.NoError(t, err)
+
+	useCase, err := NewMeasurementUseCase(repo, channels, []*entities.SensorType{
+		{Name: "PH", Unit: "pH", MinValue: 0, MaxValue: 14},
+		{Name: "temperature", Unit: "fahrenheit", MinValue: -40, MaxValue: 185},
+	}, loggerFactory)
+	require.NoError(t, err)
+	return useCase
+}
+
+func newTestMeasurement(t *testing.T, sensorType string, value float64) *entities.Measurement {
+	return newTestChannelMeasurement(t, sensorType, 0, value)
+}
+
+func newTestChannelMeasurement(t *testing.T, sensorType string, channel int, value float64) *entities.Measurement {
+	measurement, err := entities.NewMeasurement("AA:BB:CC:DD:EE:FF", sensorType, channel, value, "", time.Now())
+	require.NoError(t, err)
+	return measurement
+}
+
+func TestNewMeasurementUseCase(t *testing.T) {
+	loggerFactory, err := logger.NewDevelopment()
+	require.NoError(t, err)
+	repo := mocks.NewMockMeasurementRepository(t)
+	channels := mocks.NewMockSensorChannelRepository(t)
+
+	t.Run("registers the built-in and configured types", func(t *testing.T) {
+		useCase := newTestUseCase(t, repo, channels)
+
+		var names []string
+		for _, sensorType := range useCase.SensorTypes() {
+			names = append(names, sensorType.Name)
+			if sensorType.Name == "temperature" {
+				assert.Equal(t, "fahrenheit", sensorType.Unit)
+			}
+		}
+		assert.Equal(t, []string{"humidity", "leaf_wetness", "ph", "soil_temperature", "solar_radiation", "temperature"}, names)
+	})
+
+	t.Run("rejects invalid types", func(t *testing.T) {
+		_, err := NewMeasurementUseCase(repo, channels, []*entities.SensorType{{Name: "ph", MinValue: 0, MaxValue: 14}}, loggerFactory)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects types defined twice", func(t *testing.T) {
+		_, err := NewMeasurementUseCase(repo, channels, []*entities.SensorType{
+			{Name: "ec", Unit: "mS/cm", MinValue: 0, MaxValue: 20},
+			{Name: "EC", Unit: "uS/cm", MinValue: 0, MaxValue: 20000},
+		}, loggerFactory)
+		assert.Error(t, err)
+	})
+}
+
+func TestMeasurementUseCase_StoreMeasurements(t *testing.T) {
+	ctx := context.Background()
+	const macAddress = "AA:BB:CC:DD:EE:FF"
+
+	t.Run("stamps the unit, creates the measurements and registers new channels", func(t *testing.T) {
+		repo := mocks.NewMockMeasurementRepository(t)
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, repo, channels)
+		measurements := []*entities.Measurement{newTestMeasurement(t, "ph", 6.5), newTestMeasurement(t, "humidity", 40)}
+		channels.EXPECT().FindByDevice(ctx, macAddress).Return(nil, nil).Once()
+		repo.EXPECT().Create(ctx, measurements).Return(nil).Once()
+		channels.EXPECT().CreateMissing(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, registered []*entities.SensorChannel) error {
+			require.Len(t, registered, 2)
+			assert.Equal(t, "ph", registered[0].SensorType)
+			assert.Equal(t, 1.0, registered[0].Scale)
+			return nil
+		}).Once()
+
+		require.NoError(t, useCase.StoreMeasurements(ctx, measurements))
+
+		assert.Equal(t, "pH", measurements[0].Unit)
+		assert.Equal(t, "percent", measurements[1].Unit)
+	})
+
+	t.Run("applies the calibration of known channels", func(t *testing.T) {
+		repo := mocks.NewMockMeasurementRepository(t)
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, repo, channels)
+		calibrated, err := entities.NewSensorChannel(macAddress, "ph", 2)
+		require.NoError(t, err)
+		require.NoError(t, calibrated.Calibrate(1, 0.5, time.Now()))
+		measurements := []*entities.Measurement{newTestChannelMeasurement(t, "ph", 2, 6.5), newTestChannelMeasurement(t, "ph", 1, 6.5)}
+		channels.EXPECT().FindByDevice(ctx, macAddress).Return([]*entities.SensorChannel{calibrated}, nil).Once()
+		repo.EXPECT().Create(ctx, measurements).Return(nil).Once()
+		channels.EXPECT().CreateMissing(ctx, mock.Anything).RunAndReturn(func(ctx context.Context, registered []*entities.SensorChannel) error {
+			require.Len(t, registered, 1)
+			assert.Equal(t, 1, registered[0].Channel)
+			return nil
+		}).Once()
+
+		require.NoError(t, useCase.StoreMeasurements(ctx, measurements))
+
+		assert.Equal(t, 7.0, measurements[0].Value)
+		assert.Equal(t, 6.5, measurements[1].Value)
+	})
+
+	t.Run("checks the calibrated value", func(t *testing.T) {
+		repo := mocks.NewMockMeasurementRepository(t)
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, repo, channels)
+		calibrated, err := entities.NewSensorChannel(macAddress, "ph", 0)
+		require.NoError(t, err)
+		require.NoError(t, calibrated.Calibrate(2, 0, time.Now()))
+		channels.EXPECT().FindByDevice(ctx, macAddress).Return([]*entities.SensorChannel{calibrated}, nil).Once()
+
+		err = useCase.StoreMeasurements(ctx, []*entities.Measurement{newTestMeasurement(t, "ph", 8)})
+
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidMeasurement)
+	})
+
+	t.Run("rejects unknown sensor types", func(t *testing.T) {
+		repo := mocks.NewMockMeasurementRepository(t)
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, repo, channels)
+		channels.EXPECT().FindByDevice(ctx, macAddress).Return(nil, nil).Once()
+
+		err := useCase.StoreMeasurements(ctx, []*entities.Measurement{newTestMeasurement(t, "ph", 6.5), newTestMeasurement(t, "ec", 1.2)})
+
+		assert.ErrorIs(t, err, domainerrors.ErrUnknownSensorType)
+	})
+
+	t.Run("rejects implausible values", func(t *testing.T) {
+		repo := mocks.NewMockMeasurementRepository(t)
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, repo, channels)
+		channels.EXPECT().FindByDevice(ctx, macAddress).Return(nil, nil).Once()
+
+		err := useCase.StoreMeasurements(ctx, []*entities.Measurement{newTestMeasurement(t, "ph", 15)})
+
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidMeasurement)
+	})
+
+	t.Run("replays upsert", func(t *testing.T) {
+		repo := mocks.NewMockMeasurementRepository(t)
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, repo, channels)
+		measurements := []*entities.Measurement{newTestMeasurement(t, "ph", 6.5)}
+		replayCtx := eventports.ContextWithReplay(ctx, measurements[0].Timestamp)
+		channels.EXPECT().FindByDevice(replayCtx, macAddress).Return(nil, nil).Once()
+		repo.EXPECT().Upsert(replayCtx, measurements, replayMatchWindow).Return(nil).Once()
+		channels.EXPECT().CreateMissing(replayCtx, mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, useCase.StoreMeasurements(replayCtx, measurements))
+	})
+
+	t.Run("keeps the measurements when channels cannot be registered", func(t *testing.T) {
+		repo := mocks.NewMockMeasurementRepository(t)
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, repo, channels)
+		channels.EXPECT().FindByDevice(ctx, macAddress).Return(nil, nil).Once()
+		repo.EXPECT().Create(ctx, mock.Anything).Return(nil).Once()
+		channels.EXPECT().CreateMissing(ctx, mock.Anything).Return(errors.New("connection refused")).Once()
+
+		assert.NoError(t, useCase.StoreMeasurements(ctx, []*entities.Measurement{newTestMeasurement(t, "ph", 6.5)}))
+	})
+
+	t.Run("wraps repository failures", func(t *testing.T) {
+		repo := mocks.NewMockMeasurementRepository(t)
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, repo, channels)
+		channels.EXPECT().FindByDevice(ctx, macAddress).Return(nil, nil).Once()
+		repo.EXPECT().Create(ctx, mock.Anything).Return(errors.New("connection refused")).Once()
+
+		err := useCase.StoreMeasurements(ctx, []*entities.Measurement{newTestMeasurement(t, "ph", 6.5)})
+
+		assert.ErrorContains(t, err, "failed to store measurements")
+	})
+}
+
+func TestMeasurementUseCase_EnsureViews(t *testing.T) {
+	repo := mocks.NewMockMeasurementRepository(t)
+	useCase := newTestUseCase(t, repo, mocks.NewMockSensorChannelRepository(t))
+	repo.EXPECT().EnsureTypeViews(mock.Anything, useCase.SensorTypes()).Return(nil).Once()
+
+	assert.NoError(t, useCase.EnsureViews(context.Background()))
+}
+
+func TestMeasurementUseCase_ConfigureChannel(t *testing.T) {
+	ctx := context.Background()
+	const macAddress = "AA:BB:CC:DD:EE:FF"
+
+	t.Run("registers and calibrates an unreported channel", func(t *testing.T) {
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, mocks.NewMockMeasurementRepository(t), channels)
+		channels.EXPECT().FindByDevice(ctx, macAddress).Return(nil, nil).Once()
+		channels.EXPECT().Save(ctx, mock.Anything).Return(nil).Once()
+
+		channel, err := useCase.ConfigureChannel(ctx, &entities.SensorChannel{MACAddress: "aa:bb:cc:dd:ee:ff", SensorType: "PH", Channel: 2, Name: " bed 3 ", Scale: 1.02, Offset: -0.1})
+
+		require.NoError(t, err)
+		assert.Equal(t, "bed 3", channel.Name)
+		assert.Equal(t, 1.02, channel.Scale)
+		assert.NotNil(t, channel.CalibratedAt)
+	})
+
+	t.Run("keeps the calibration time when only the name changes", func(t *testing.T) {
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, mocks.NewMockMeasurementRepository(t), channels)
+		calibratedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+		stored, err := entities.NewSensorChannel(macAddress, "ph", 2)
+		require.NoError(t, err)
+		require.NoError(t, stored.Calibrate(1.02, -0.1, calibratedAt))
+		channels.EXPECT().FindByDevice(ctx, macAddress).Return([]*entities.SensorChannel{stored}, nil).Once()
+		channels.EXPECT().Save(ctx, stored).Return(nil).Once()
+
+		channel, err := useCase.ConfigureChannel(ctx, &entities.SensorChannel{MACAddress: macAddress, SensorType: "ph", Channel: 2, Name: "bed 4", Scale: 1.02, Offset: -0.1})
+
+		require.NoError(t, err)
+		assert.Equal(t, "bed 4", channel.Name)
+		assert.Equal(t, calibratedAt, *channel.CalibratedAt)
+	})
+
+	t.Run("rejects unknown sensor types", func(t *testing.T) {
+		useCase := newTestUseCase(t, mocks.NewMockMeasurementRepository(t), mocks.NewMockSensorChannelRepository(t))
+
+		_, err := useCase.ConfigureChannel(ctx, &entities.SensorChannel{MACAddress: macAddress, SensorType: "ec", Scale: 1})
+
+		assert.ErrorIs(t, err, domainerrors.ErrUnknownSensorType)
+	})
+
+	t.Run("rejects invalid calibrations", func(t *testing.T) {
+		channels := mocks.NewMockSensorChannelRepository(t)
+		useCase := newTestUseCase(t, mocks.NewMockMeasurementRepository(t), channels)
+		channels.EXPECT().FindByDevice(ctx, macAddress).Return(nil, nil).Once()
+
+		_, err := useCase.ConfigureChannel(ctx, &entities.SensorChannel{MACAddress: macAddress, SensorType: "ph", Scale: 0})
+
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidSensorChannel)
+	})
+}
@@ -0,0 +1,347 @@
+package messagetracing
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// TracingConfig holds configuration for message tracing
+type TracingConfig struct {
+	MaxSessions int           // sessions kept at once, active or ended; the oldest ended one makes room for a new one
+	Retention   time.Duration // how long the traces of an ended session are kept
+}
+
+// DefaultTracingConfig returns default configuration
+func DefaultTracingConfig() *TracingConfig {
+	return &TracingConfig{
+		MaxSessions: 5,
+		Retention:   6 * time.Hour,
+	}
+}
+
+// MessageTracingUseCase captures the full payload of the messages matching the trace sessions
+// started by operators, with the path each one took through the ingestion pipeline, so a topic or a
+// device can be debugged without verbose logging. Traces are kept in memory only.
+type MessageTracingUseCase interface {
+	// StartSession starts capturing the messages matching the request
+	StartSession(ctx context.Context, request entities.TraceSessionRequest) (*entities.TraceSession, error)
+
+	// StopSession stops capturing messages for the session, keeping the traces it captured
+	StopSession(ctx context.Context, id string) (*entities.TraceSession, error)
+
+	// DeleteSession removes the session and its traces
+	DeleteSession(ctx context.Context, id string) error
+
+	// Sessions returns the sessions kept, oldest first
+	Sessions(ctx context.Context) []*entities.TraceSession
+
+	// Traces returns the session and the messages it captured, oldest first
+	Traces(ctx context.Context, id string) (*entities.TraceSession, []*entities.MessageTrace, error)
+
+	// Tracing reports whether any session is active, so untraced messages skip matching
+	Tracing() bool
+
+	// Begin captures the message for the first active session matching and sampling it, and
+	// returns the context carrying the trace. The context is returned unchanged when the message
+	// is not captured.
+	Begin(ctx context.Context, topic, macAddress string, payload []byte) context.Context
+
+	// Complete records the outcome of the message traced in the context, making its trace visible
+	Complete(ctx context.Context, outcome, stage, reason string)
+
+	// ActiveOverrides reports the active sessions as operator overrides
+	ActiveOverrides() []*entities.OperatorOverride
+}
+
+// traceKey is the context key of the message being traced
+type traceKey struct{}
+
+// tracedMessage is the trace of a message being handled, with the monotonic time it was received at
+type tracedMessage struct {
+	trace   *entities.MessageTrace
+	started time.Time
+}
+
+// RecordStep records that the message traced in the context reached the stage; messages that are
+// not traced are left alone
+func RecordStep(ctx context.Context, stage string) {
+	if traced, ok := ctx.Value(traceKey{}).(*tracedMessage); ok {
+		traced.trace.Steps = append(traced.trace.Steps, entities.TraceStep{Stage: stage, Elapsed: time.Since(traced.started)})
+	}
+}
+
+// session is a trace session with the traces it captured
+type session struct {
+	session *entities.TraceSession
+	traces  []*entities.MessageTrace
+}
+
+// useCaseImpl implements the MessageTracingUseCase interface
+type useCaseImpl struct {
+	config        *TracingConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+	sample        func() float64
+
+	mu       sync.Mutex
+	sessions []*session // oldest first
+
+	activeUntil atomic.Int64 // unix nanoseconds until which a session may capture messages
+
+	captured *metrics.Vec
+}
+
+// NewMessageTracingUseCase creates a new message tracing use case
+func NewMessageTracingUseCase(config *TracingConfig, loggerFactory logger.LoggerFactory) MessageTracingUseCase {
+	if config == nil {
+		config = DefaultTracingConfig()
+	}
+
+	return &useCaseImpl{
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		sample:        rand.Float64,
+		captured:      metrics.NewCounterVec("message_traces_captured_total", "Messages captured by trace sessions", "outcome"),
+	}
+}
+
+// StartSession validates the request and starts the session, making room by removing the oldest
+// ended session when the limit is reached
+func (uc *useCaseImpl) StartSession(ctx context.Context, request entities.TraceSessionRequest) (*entities.TraceSession, error) {
+	now := uc.now()
+	traceSession, err := entities.NewTraceSession(request, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidTraceSession, err)
+	}
+
+	uc.mu.Lock()
+	uc.pruneLocked(now)
+	if len(uc.sessions) >= uc.config.MaxSessions {
+		evicted := false
+		for i, kept := range uc.sessions {
+			if !kept.session.Active(now) {
+				uc.sessions = append(uc.sessions[:i], uc.sessions[i+1:]...)
+				evicted = true
+				break
+			}
+		}
+		if !evicted {
+			uc.mu.Unlock()
+			return nil, fmt.Errorf("%w: %d sessions are active", domainerrors.ErrTraceSessionLimitReached, len(uc.sessions))
+		}
+	}
+	uc.sessions = append(uc.sessions, &session{session: traceSession})
+	uc.refreshActiveLocked(now)
+	started := *traceSession
+	uc.mu.Unlock()
+
+	uc.loggerFactory.Core().Info("trace_session_started",
+		zap.String("session_id", started.ID),
+		zap.String("topic", started.Topic),
+		zap.String("mac_address", started.MACAddress),
+		zap.Float64("sample_rate", started.SampleRate),
+		zap.Time("expires_at", started.ExpiresAt),
+		zap.String("component", "message_tracing_usecase"),
+	)
+	return &started, nil
+}
+
+// StopSession stops the session; stopping an ended session only returns it
+func (uc *useCaseImpl) StopSession(ctx context.Context, id string) (*entities.TraceSession, error) {
+	now := uc.now()
+
+	uc.mu.Lock()
+	kept := uc.findLocked(id)
+	if kept == nil {
+		uc.mu.Unlock()
+		return nil, domainerrors.ErrTraceSessionNotFound
+	}
+	wasActive := kept.session.Active(now)
+	if wasActive {
+		stoppedAt := now.UTC()
+		kept.session.StoppedAt = &stoppedAt
+		uc.refreshActiveLocked(now)
+	}
+	stopped := *kept.session
+	uc.mu.Unlock()
+
+	if wasActive {
+		uc.loggerFactory.Core().Info("trace_session_stopped",
+			zap.String("session_id", stopped.ID),
+			zap.Int("captured", stopped.Captured),
+			zap.String("component", "message_tracing_usecase"),
+		)
+	}
+	return &stopped, nil
+}
+
+// DeleteSession removes the session and its traces
+func (uc *useCaseImpl) DeleteSession(ctx context.Context, id string) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for i, kept := range uc.sessions {
+		if kept.session.ID == id {
+			uc.sessions = append(uc.sessions[:i], uc.sessions[i+1:]...)
+			uc.refreshActiveLocked(uc.now())
+			return nil
+		}
+	}
+	return domainerrors.ErrTraceSessionNotFound
+}
+
+// Sessions returns copies of the sessions kept
+func (uc *useCaseImpl) Sessions(ctx context.Context) []*entities.TraceSession {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.pruneLocked(uc.now())
+	sessions := make([]*entities.TraceSession, 0, len(uc.sessions))
+	for _, kept := range uc.sessions {
+		copied := *kept.session
+		sessions = append(sessions, &copied)
+	}
+	return sessions
+}
+
+// Traces returns a copy of the session and its completed traces
+func (uc *useCaseImpl) Traces(ctx context.Context, id string) (*entities.TraceSession, []*entities.MessageTrace, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.pruneLocked(uc.now())
+	kept := uc.findLocked(id)
+	if kept == nil {
+		return nil, nil, domainerrors.ErrTraceSessionNotFound
+	}
+	copied := *kept.session
+	return &copied, append([]*entities.MessageTrace(nil), kept.traces...), nil
+}
+
+// Tracing reports whether a session may capture messages, without locking
+func (uc *useCaseImpl) Tracing() bool {
+	return uc.now().UnixNano() < uc.activeUntil.Load()
+}
+
+// Begin offers the message to the active sessions in order; each matching session counts it, and
+// the first one sampling it captures it
+func (uc *useCaseImpl) Begin(ctx context.Context, topic, macAddress string, payload []byte) context.Context {
+	now := uc.now()
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for _, kept := range uc.sessions {
+		if !kept.session.Active(now) || !kept.session.Matches(topic, macAddress) {
+			continue
+		}
+		kept.session.Matched++
+		if uc.sample() >= kept.session.SampleRate {
+			continue
+		}
+
+		kept.session.Captured++
+		if kept.session.Captured == kept.session.MaxMessages {
+			uc.refreshActiveLocked(now)
+		}
+		trace := entities.NewMessageTrace(kept.session.ID, topic, macAddress, payload, now)
+		return context.WithValue(ctx, traceKey{}, &tracedMessage{trace: trace, started: time.Now()})
+	}
+	return ctx
+}
+
+// Complete stores the trace of the message under its session, unless the session was deleted
+// while the message was handled
+func (uc *useCaseImpl) Complete(ctx context.Context, outcome, stage, reason string) {
+	traced, ok := ctx.Value(traceKey{}).(*tracedMessage)
+	if !ok {
+		return
+	}
+	trace := traced.trace
+	trace.Outcome, trace.Stage, trace.Reason = outcome, stage, reason
+	trace.Duration = time.Since(traced.started)
+
+	uc.mu.Lock()
+	if kept := uc.findLocked(trace.SessionID); kept != nil {
+		kept.traces = append(kept.traces, trace)
+	}
+	uc.mu.Unlock()
+
+	uc.captured.Inc(outcome)
+}
+
+// ActiveOverrides reports the sessions still capturing messages
+func (uc *useCaseImpl) ActiveOverrides() []*entities.OperatorOverride {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := uc.now()
+	var overrides []*entities.OperatorOverride
+	for _, kept := range uc.sessions {
+		if kept.session.Active(now) {
+			overrides = append(overrides, kept.session.OperatorOverride())
+		}
+	}
+	return overrides
+}
+
+// findLocked returns the session with the id, nil when it is not kept. The caller holds mu.
+func (uc *useCaseImpl) findLocked(id string) *session {
+	for _, kept := range uc.sessions {
+		if kept.session.ID == id {
+			return kept
+		}
+	}
+	return nil
+}
+
+// pruneLocked removes the sessions that ended longer than the retention ago. The caller holds mu.
+func (uc *useCaseImpl) pruneLocked(now time.Time) {
+	kept := uc.sessions[:0]
+	for _, candidate := range uc.sessions {
+		endedAt := candidate.session.ExpiresAt
+		if candidate.session.StoppedAt != nil {
+			endedAt = *candidate.session.StoppedAt
+		}
+		if now.Sub(endedAt) < uc.config.Retention {
+			kept = append(kept, candidate)
+		}
+	}
+	uc.sessions = kept
+}
+
+// refreshActiveLocked recomputes until when a session may capture messages. The caller holds mu.
+func (uc *useCaseImpl) refreshActiveLocked(now time.Time) {
+	var until int64
+	for _, kept := range uc.sessions {
+		if kept.session.Active(now) && kept.session.ExpiresAt.UnixNano() > until {
+			until = kept.session.ExpiresAt.UnixNano()
+		}
+	}
+	uc.activeUntil.Store(until)
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return uc.captured.Collect()
+}
+
+// MetricsCollector returns the message tracing metrics collector
+func MetricsCollector(useCase MessageTracingUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
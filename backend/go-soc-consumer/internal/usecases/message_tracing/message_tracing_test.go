@@ -0,0 +1,166 @@
+package messagetracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testTopic = "/liwaisi/iot/smart-irrigation/sensors/temperature-and-humidity"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+// newTestUseCase creates the use case with a clock the test moves and sampling the test decides
+func newTestUseCase(t *testing.T, config *TracingConfig) (*useCaseImpl, *time.Time, *float64) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	sample := 0.0
+	useCase := NewMessageTracingUseCase(config, createTestLoggerFactory(t)).(*useCaseImpl)
+	useCase.now = func() time.Time { return now }
+	useCase.sample = func() float64 { return sample }
+	return useCase, &now, &sample
+}
+
+// trace handles a message through Begin, RecordStep and Complete as the pipeline does
+func trace(useCase *useCaseImpl, topic, macAddress string) {
+	ctx := useCase.Begin(context.Background(), topic, macAddress, []byte(`{"mac_address":"`+macAddress+`"}`))
+	RecordStep(ctx, "schema")
+	RecordStep(ctx, entities.TraceStageHandler)
+	useCase.Complete(ctx, entities.TraceOutcomeHandled, "", "")
+}
+
+func TestMessageTracingUseCase_StartSession(t *testing.T) {
+	t.Run("should trace only while a session is active", func(t *testing.T) {
+		useCase, now, _ := newTestUseCase(t, nil)
+		assert.False(t, useCase.Tracing())
+
+		session, err := useCase.StartSession(context.Background(), entities.TraceSessionRequest{MACAddress: "aa:bb:cc:dd:ee:ff", Duration: 10 * time.Minute})
+		require.NoError(t, err)
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", session.MACAddress)
+		assert.Equal(t, 1.0, session.SampleRate)
+		assert.Equal(t, entities.DefaultTraceMaxMessages, session.MaxMessages)
+		assert.True(t, useCase.Tracing())
+		assert.Len(t, useCase.ActiveOverrides(), 1)
+
+		*now = now.Add(10 * time.Minute)
+		assert.False(t, useCase.Tracing())
+		assert.Empty(t, useCase.ActiveOverrides())
+	})
+
+	t.Run("should reject invalid sessions", func(t *testing.T) {
+		useCase, _, _ := newTestUseCase(t, nil)
+		requests := []entities.TraceSessionRequest{
+			{Duration: time.Minute},
+			{Topic: testTopic},
+			{Topic: testTopic, Duration: 2 * time.Hour},
+			{Topic: testTopic, Duration: time.Minute, SampleRate: 1.5},
+			{Topic: testTopic, Duration: time.Minute, MaxMessages: entities.MaxTraceMessages + 1},
+		}
+		for _, request := range requests {
+			_, err := useCase.StartSession(context.Background(), request)
+			assert.ErrorIs(t, err, domainerrors.ErrInvalidTraceSession)
+		}
+	})
+
+	t.Run("should make room by removing the oldest ended session", func(t *testing.T) {
+		useCase, now, _ := newTestUseCase(t, &TracingConfig{MaxSessions: 2, Retention: time.Hour})
+		first, err := useCase.StartSession(context.Background(), entities.TraceSessionRequest{Topic: testTopic, Duration: time.Minute})
+		require.NoError(t, err)
+		_, err = useCase.StartSession(context.Background(), entities.TraceSessionRequest{Topic: testTopic, Duration: 30 * time.Minute})
+		require.NoError(t, err)
+
+		_, err = useCase.StartSession(context.Background(), entities.TraceSessionRequest{Topic: testTopic, Duration: time.Minute})
+		assert.ErrorIs(t, err, domainerrors.ErrTraceSessionLimitReached)
+
+		*now = now.Add(2 * time.Minute)
+		_, err = useCase.StartSession(context.Background(), entities.TraceSessionRequest{Topic: testTopic, Duration: time.Minute})
+		require.NoError(t, err)
+		_, _, err = useCase.Traces(context.Background(), first.ID)
+		assert.ErrorIs(t, err, domainerrors.ErrTraceSessionNotFound)
+	})
+}
+
+func TestMessageTracingUseCase_Capture(t *testing.T) {
+	t.Run("should capture the matching messages with their steps and outcome", func(t *testing.T) {
+		useCase, _, _ := newTestUseCase(t, nil)
+		session, err := useCase.StartSession(context.Background(), entities.TraceSessionRequest{Topic: "/liwaisi/iot/smart-irrigation/sensors/+", MACAddress: "AA:BB:CC:DD:EE:FF", Duration: time.Minute})
+		require.NoError(t, err)
+
+		trace(useCase, testTopic, "aa:bb:cc:dd:ee:ff")
+		trace(useCase, testTopic, "11:22:33:44:55:66")
+		trace(useCase, "/liwaisi/iot/smart-irrigation/device/registration", "AA:BB:CC:DD:EE:FF")
+
+		stored, traces, err := useCase.Traces(context.Background(), session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stored.Matched)
+		assert.Equal(t, 1, stored.Captured)
+		require.Len(t, traces, 1)
+		assert.Equal(t, "AA:BB:CC:DD:EE:FF", traces[0].MACAddress)
+		assert.JSONEq(t, `{"mac_address":"aa:bb:cc:dd:ee:ff"}`, string(traces[0].Payload))
+		assert.Equal(t, entities.TraceOutcomeHandled, traces[0].Outcome)
+		require.Len(t, traces[0].Steps, 2)
+		assert.Equal(t, "schema", traces[0].Steps[0].Stage)
+		assert.Equal(t, entities.TraceStageHandler, traces[0].Steps[1].Stage)
+	})
+
+	t.Run("should sample and stop at the maximum number of messages", func(t *testing.T) {
+		useCase, _, sample := newTestUseCase(t, nil)
+		session, err := useCase.StartSession(context.Background(), entities.TraceSessionRequest{Topic: testTopic, Duration: time.Minute, SampleRate: 0.5, MaxMessages: 2})
+		require.NoError(t, err)
+
+		*sample = 0.7
+		trace(useCase, testTopic, "AA:BB:CC:DD:EE:FF")
+		*sample = 0.2
+		trace(useCase, testTopic, "AA:BB:CC:DD:EE:FF")
+		trace(useCase, testTopic, "AA:BB:CC:DD:EE:FF")
+		assert.False(t, useCase.Tracing())
+		trace(useCase, testTopic, "AA:BB:CC:DD:EE:FF")
+
+		stored, traces, err := useCase.Traces(context.Background(), session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, stored.Matched)
+		assert.Len(t, traces, 2)
+	})
+
+	t.Run("should keep the traces of a stopped session until it is deleted", func(t *testing.T) {
+		useCase, _, _ := newTestUseCase(t, nil)
+		session, err := useCase.StartSession(context.Background(), entities.TraceSessionRequest{Topic: testTopic, Duration: time.Minute})
+		require.NoError(t, err)
+		trace(useCase, testTopic, "AA:BB:CC:DD:EE:FF")
+
+		stopped, err := useCase.StopSession(context.Background(), session.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stopped.StoppedAt)
+		assert.False(t, useCase.Tracing())
+		trace(useCase, testTopic, "AA:BB:CC:DD:EE:FF")
+
+		_, traces, err := useCase.Traces(context.Background(), session.ID)
+		require.NoError(t, err)
+		assert.Len(t, traces, 1)
+
+		require.NoError(t, useCase.DeleteSession(context.Background(), session.ID))
+		assert.Empty(t, useCase.Sessions(context.Background()))
+		assert.ErrorIs(t, useCase.DeleteSession(context.Background(), session.ID), domainerrors.ErrTraceSessionNotFound)
+	})
+
+	t.Run("should forget ended sessions after the retention", func(t *testing.T) {
+		useCase, now, _ := newTestUseCase(t, &TracingConfig{MaxSessions: 5, Retention: time.Hour})
+		_, err := useCase.StartSession(context.Background(), entities.TraceSessionRequest{Topic: testTopic, Duration: time.Minute})
+		require.NoError(t, err)
+
+		*now = now.Add(time.Hour)
+		assert.Len(t, useCase.Sessions(context.Background()), 1)
+		*now = now.Add(time.Minute)
+		assert.Empty(t, useCase.Sessions(context.Background()))
+	})
+}
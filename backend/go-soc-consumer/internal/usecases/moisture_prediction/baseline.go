@@ -0,0 +1,149 @@
+package moistureprediction
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+)
+
+// BaselineModel names the predictions of the baseline predictor
+const BaselineModel = "baseline"
+
+// Baseline fit requirements
+const (
+	baselineMinSamples = 3
+	baselineMinSpan    = 30 * time.Minute
+)
+
+// baselinePredictor fits a straight line to the moisture each device of the zone measured since its
+// last peak, the decay since the soil was last wetted, and extends it over the horizon
+type baselinePredictor struct{}
+
+// NewBaselinePredictor creates the baseline moisture predictor, used when no external model is
+// configured and when the external model fails
+func NewBaselinePredictor() ports.MoisturePredictor {
+	return baselinePredictor{}
+}
+
+// Name implements ports.MoisturePredictor
+func (baselinePredictor) Name() string {
+	return BaselineModel
+}
+
+// decayFit is the moisture decay of one device: moisture = intercept + slope·hours from the
+// prediction time
+type decayFit struct {
+	macAddress string
+	intercept  float64
+	slope      float64
+	hours      *float64 // until the threshold, nil when not reached within the horizon
+}
+
+// Predict follows the device expected to reach the threshold first, or the driest one when none is
+func (baselinePredictor) Predict(ctx context.Context, request *entities.MoisturePredictionRequest) (*entities.MoisturePrediction, error) {
+	byDevice := make(map[string][]*entities.Measurement)
+	for _, reading := range request.Readings {
+		byDevice[reading.MACAddress] = append(byDevice[reading.MACAddress], reading)
+	}
+
+	macAddresses := make([]string, 0, len(byDevice))
+	for macAddress := range byDevice {
+		macAddresses = append(macAddresses, macAddress)
+	}
+	sort.Strings(macAddresses)
+
+	var chosen *decayFit
+	for _, macAddress := range macAddresses {
+		fit, ok := fitDecay(byDevice[macAddress], request.At)
+		if !ok {
+			continue
+		}
+		fit.macAddress = macAddress
+		fit.hours = hoursUntil(fit, request.Threshold, request.Horizon)
+		if chosen == nil || sooner(fit, chosen) {
+			chosen = fit
+		}
+	}
+	if chosen == nil {
+		return nil, fmt.Errorf("%w: zone %s needs %d readings over %s since the last peak of a device", domainerrors.ErrInsufficientReadings, request.Zone, baselineMinSamples, baselineMinSpan)
+	}
+
+	prediction := &entities.MoisturePrediction{
+		Zone:                request.Zone,
+		Model:               BaselineModel,
+		MACAddress:          chosen.macAddress,
+		PredictedAt:         request.At,
+		Moisture:            chosen.intercept,
+		DryingRate:          -chosen.slope,
+		HoursUntilThreshold: chosen.hours,
+	}
+	for hour := 0; time.Duration(hour)*time.Hour <= request.Horizon; hour++ {
+		prediction.Forecast = append(prediction.Forecast, entities.MoistureForecastPoint{
+			At:       request.At.Add(time.Duration(hour) * time.Hour),
+			Moisture: math.Max(chosen.intercept+chosen.slope*float64(hour), 0),
+		})
+	}
+	return prediction, nil
+}
+
+// fitDecay fits a least squares line to the readings of a device after their latest peak, and
+// false when too few readings or too short a span are left
+func fitDecay(readings []*entities.Measurement, at time.Time) (*decayFit, bool) {
+	sort.SliceStable(readings, func(i, j int) bool { return readings[i].Timestamp.Before(readings[j].Timestamp) })
+	peak := 0
+	for i, reading := range readings {
+		if reading.Value >= readings[peak].Value {
+			peak = i
+		}
+	}
+	readings = readings[peak:]
+	if len(readings) < baselineMinSamples || readings[len(readings)-1].Timestamp.Sub(readings[0].Timestamp) < baselineMinSpan {
+		return nil, false
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for _, reading := range readings {
+		x := reading.Timestamp.Sub(at).Hours()
+		sumX += x
+		sumY += reading.Value
+		sumXY += x * reading.Value
+		sumXX += x * x
+	}
+	n := float64(len(readings))
+	slope := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	return &decayFit{intercept: (sumY - slope*sumX) / n, slope: slope}, true
+}
+
+// hoursUntil returns the hours until the fitted moisture reaches the threshold, nil when it does
+// not within the horizon
+func hoursUntil(fit *decayFit, threshold float64, horizon time.Duration) *float64 {
+	hours := 0.0
+	if fit.intercept > threshold {
+		if fit.slope >= 0 {
+			return nil
+		}
+		hours = (threshold - fit.intercept) / fit.slope
+	}
+	if hours > horizon.Hours() {
+		return nil
+	}
+	return &hours
+}
+
+// sooner reports whether the fit reaches the threshold before the other, or is drier when neither does
+func sooner(fit, other *decayFit) bool {
+	switch {
+	case fit.hours != nil && other.hours != nil:
+		return *fit.hours < *other.hours
+	case fit.hours != nil || other.hours != nil:
+		return fit.hours != nil
+	default:
+		return fit.intercept < other.intercept
+	}
+}
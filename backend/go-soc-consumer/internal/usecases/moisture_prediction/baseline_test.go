@@ -0,0 +1,69 @@
+package moistureprediction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+)
+
+func reading(macAddress string, value float64, at time.Time) *entities.Measurement {
+	return &entities.Measurement{MACAddress: macAddress, SensorType: "soil_moisture", Value: value, Timestamp: at}
+}
+
+func TestBaselinePredictor_Predict(t *testing.T) {
+	at := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	request := func(readings ...*entities.Measurement) *entities.MoisturePredictionRequest {
+		return &entities.MoisturePredictionRequest{Zone: "north", SensorType: "soil_moisture", Threshold: 25, Horizon: 6 * time.Hour, At: at, Readings: readings}
+	}
+
+	t.Run("should follow the device reaching the threshold first", func(t *testing.T) {
+		prediction, err := NewBaselinePredictor().Predict(context.Background(), request(
+			reading("AA:BB:CC:DD:EE:01", 20, at.Add(-5*time.Hour)),
+			reading("AA:BB:CC:DD:EE:01", 40, at.Add(-4*time.Hour)),
+			reading("AA:BB:CC:DD:EE:01", 39, at.Add(-3*time.Hour)),
+			reading("AA:BB:CC:DD:EE:01", 38, at.Add(-2*time.Hour)),
+			reading("AA:BB:CC:DD:EE:01", 37, at.Add(-time.Hour)),
+			reading("AA:BB:CC:DD:EE:02", 35, at.Add(-2*time.Hour)),
+			reading("AA:BB:CC:DD:EE:02", 33, at.Add(-time.Hour)),
+			reading("AA:BB:CC:DD:EE:02", 31, at),
+		))
+
+		require.NoError(t, err)
+		assert.Equal(t, BaselineModel, prediction.Model)
+		assert.Equal(t, "AA:BB:CC:DD:EE:02", prediction.MACAddress)
+		assert.InDelta(t, 31, prediction.Moisture, 1e-9)
+		assert.InDelta(t, 2, prediction.DryingRate, 1e-9)
+		require.NotNil(t, prediction.HoursUntilThreshold)
+		assert.InDelta(t, 3, *prediction.HoursUntilThreshold, 1e-9)
+		require.Len(t, prediction.Forecast, 7)
+		assert.InDelta(t, 19, prediction.Forecast[6].Moisture, 1e-9)
+	})
+
+	t.Run("should not expect the threshold when the soil is not drying within the horizon", func(t *testing.T) {
+		prediction, err := NewBaselinePredictor().Predict(context.Background(), request(
+			reading("AA:BB:CC:DD:EE:01", 40, at.Add(-2*time.Hour)),
+			reading("AA:BB:CC:DD:EE:01", 39.5, at.Add(-time.Hour)),
+			reading("AA:BB:CC:DD:EE:01", 39, at),
+		))
+
+		require.NoError(t, err)
+		assert.Nil(t, prediction.HoursUntilThreshold)
+	})
+
+	t.Run("should need readings since the last peak", func(t *testing.T) {
+		_, err := NewBaselinePredictor().Predict(context.Background(), request(
+			reading("AA:BB:CC:DD:EE:01", 30, at.Add(-2*time.Hour)),
+			reading("AA:BB:CC:DD:EE:01", 29, at.Add(-time.Hour)),
+			reading("AA:BB:CC:DD:EE:01", 40, at.Add(-10*time.Minute)),
+			reading("AA:BB:CC:DD:EE:01", 39, at),
+		))
+
+		assert.ErrorIs(t, err, domainerrors.ErrInsufficientReadings)
+	})
+}
@@ -0,0 +1,276 @@
+package moistureprediction
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// Prediction outcomes counted by the moisture_predictions_total counter
+const (
+	resultPredicted    = "predicted"
+	resultInsufficient = "insufficient"
+	resultFailed       = "failed"
+)
+
+// PredictionConfig holds configuration for the moisture predictions
+type PredictionConfig struct {
+	SensorType string        // sensor type read as soil moisture
+	Threshold  float64       // moisture below which the soil needs irrigation
+	Window     time.Duration // readings given to the model, before the prediction time
+	Horizon    time.Duration // how far ahead the moisture is forecast
+	Interval   time.Duration // how often the zones are predicted
+}
+
+// DefaultPredictionConfig returns default configuration
+func DefaultPredictionConfig() *PredictionConfig {
+	return &PredictionConfig{
+		SensorType: "soil_moisture",
+		Threshold:  25,
+		Window:     12 * time.Hour,
+		Horizon:    48 * time.Hour,
+		Interval:   15 * time.Minute,
+	}
+}
+
+// MoisturePredictionUseCase periodically predicts, for every zone, the hours until its soil dries
+// to the irrigation threshold. Predictions are made by the configured model; when it fails, the
+// baseline model is used instead.
+type MoisturePredictionUseCase interface {
+	// Predict refreshes the prediction of every zone. A zone that cannot be predicted keeps no
+	// prediction, so the scheduler does not act on an outdated one.
+	Predict(ctx context.Context) error
+
+	// Predictions returns the latest prediction of every predicted zone, sorted by zone
+	Predictions() []*entities.MoisturePrediction
+
+	// ForDevice returns the latest prediction of the zone of the device, and false when there is
+	// none or it is older than two intervals
+	ForDevice(macAddress string) (*entities.MoisturePrediction, bool)
+
+	// Run predicts the zones periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// useCaseImpl implements the MoisturePredictionUseCase interface
+type useCaseImpl struct {
+	measurementRepo repositoryports.MeasurementRepository
+	predictor       ports.MoisturePredictor
+	fallback        ports.MoisturePredictor // nil when the predictor is the baseline
+	zones           []entities.IrrigationZone
+	zoneOf          map[string]string // zone name by MAC address
+	config          *PredictionConfig
+	loggerFactory   logger.LoggerFactory
+	now             func() time.Time
+
+	mu          sync.RWMutex
+	predictions map[string]*entities.MoisturePrediction // by zone name
+
+	predicted *metrics.Vec
+}
+
+// NewMoisturePredictionUseCase creates a moisture prediction use case for the given zones; a nil
+// predictor uses the baseline model
+func NewMoisturePredictionUseCase(
+	measurementRepo repositoryports.MeasurementRepository,
+	predictor ports.MoisturePredictor,
+	zones []entities.IrrigationZone,
+	config *PredictionConfig,
+	loggerFactory logger.LoggerFactory,
+) MoisturePredictionUseCase {
+	if config == nil {
+		config = DefaultPredictionConfig()
+	}
+
+	var fallback ports.MoisturePredictor
+	if predictor == nil {
+		predictor = NewBaselinePredictor()
+	} else if predictor.Name() != BaselineModel {
+		fallback = NewBaselinePredictor()
+	}
+
+	zoneOf := make(map[string]string)
+	for _, zone := range zones {
+		for _, macAddress := range zone.MACAddresses {
+			zoneOf[strings.ToUpper(macAddress)] = zone.Name
+		}
+	}
+
+	return &useCaseImpl{
+		measurementRepo: measurementRepo,
+		predictor:       predictor,
+		fallback:        fallback,
+		zones:           zones,
+		zoneOf:          zoneOf,
+		config:          config,
+		loggerFactory:   loggerFactory,
+		now:             time.Now,
+		predictions:     make(map[string]*entities.MoisturePrediction),
+		predicted:       metrics.NewCounterVec("moisture_predictions_total", "Zone moisture predictions by model and result", "model", "result"),
+	}
+}
+
+// Predict predicts the zones one at a time
+func (uc *useCaseImpl) Predict(ctx context.Context) error {
+	for _, zone := range uc.zones {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		prediction, err := uc.predictZone(ctx, zone)
+		uc.mu.Lock()
+		if err != nil {
+			delete(uc.predictions, zone.Name)
+		} else {
+			uc.predictions[zone.Name] = prediction
+		}
+		uc.mu.Unlock()
+	}
+	return nil
+}
+
+// predictZone reads the recent moisture of the zone and asks the model, then the fallback, for
+// a prediction
+func (uc *useCaseImpl) predictZone(ctx context.Context, zone entities.IrrigationZone) (*entities.MoisturePrediction, error) {
+	at := uc.now().UTC()
+	request := &entities.MoisturePredictionRequest{
+		Zone:         zone.Name,
+		MACAddresses: zone.MACAddresses,
+		SensorType:   uc.config.SensorType,
+		Threshold:    uc.config.Threshold,
+		Horizon:      uc.config.Horizon,
+		At:           at,
+	}
+	for _, macAddress := range zone.MACAddresses {
+		readings, err := uc.measurementRepo.History(ctx, macAddress, uc.config.SensorType, at.Add(-uc.config.Window), at)
+		if err != nil {
+			uc.logFailure(zone, uc.predictor, err)
+			return nil, err
+		}
+		request.Readings = append(request.Readings, readings...)
+	}
+
+	prediction, err := uc.predictor.Predict(ctx, request)
+	if err != nil && uc.fallback != nil {
+		uc.logFailure(zone, uc.predictor, err)
+		prediction, err = uc.fallback.Predict(ctx, request)
+		if err != nil {
+			uc.logFailure(zone, uc.fallback, err)
+			return nil, err
+		}
+	} else if err != nil {
+		uc.logFailure(zone, uc.predictor, err)
+		return nil, err
+	}
+
+	prediction.Zone = zone.Name
+	uc.predicted.Inc(prediction.Model, resultPredicted)
+	uc.loggerFactory.Core().Debug("moisture_predicted",
+		zap.String("zone", zone.Name),
+		zap.String("model", prediction.Model),
+		zap.Float64("moisture", prediction.Moisture),
+		zap.Float64("drying_rate", prediction.DryingRate),
+		zap.Bool("threshold_expected", prediction.HoursUntilThreshold != nil),
+		zap.String("component", "moisture_prediction_usecase"),
+	)
+	return prediction, nil
+}
+
+// logFailure counts and logs a failed prediction; a zone without enough readings is expected while
+// its devices start reporting
+func (uc *useCaseImpl) logFailure(zone entities.IrrigationZone, predictor ports.MoisturePredictor, err error) {
+	if errors.Is(err, domainerrors.ErrInsufficientReadings) {
+		uc.predicted.Inc(predictor.Name(), resultInsufficient)
+		uc.loggerFactory.Core().Debug("moisture_prediction_skipped",
+			zap.String("zone", zone.Name),
+			zap.String("model", predictor.Name()),
+			zap.String("reason", err.Error()),
+			zap.String("component", "moisture_prediction_usecase"),
+		)
+		return
+	}
+	uc.predicted.Inc(predictor.Name(), resultFailed)
+	uc.loggerFactory.Core().Error("moisture_prediction_failed",
+		zap.Error(err),
+		zap.String("zone", zone.Name),
+		zap.String("model", predictor.Name()),
+		zap.String("component", "moisture_prediction_usecase"),
+	)
+}
+
+// Predictions returns the latest predictions sorted by zone
+func (uc *useCaseImpl) Predictions() []*entities.MoisturePrediction {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	predictions := make([]*entities.MoisturePrediction, 0, len(uc.predictions))
+	for _, prediction := range uc.predictions {
+		predictions = append(predictions, prediction)
+	}
+	sort.Slice(predictions, func(i, j int) bool { return predictions[i].Zone < predictions[j].Zone })
+	return predictions
+}
+
+// ForDevice returns the fresh prediction of the zone of the device
+func (uc *useCaseImpl) ForDevice(macAddress string) (*entities.MoisturePrediction, bool) {
+	zone, ok := uc.zoneOf[strings.ToUpper(strings.TrimSpace(macAddress))]
+	if !ok {
+		return nil, false
+	}
+
+	uc.mu.RLock()
+	prediction, ok := uc.predictions[zone]
+	uc.mu.RUnlock()
+	if !ok || uc.now().Sub(prediction.PredictedAt) > 2*uc.config.Interval {
+		return nil, false
+	}
+	return prediction, true
+}
+
+// Run predicts the zones periodically until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("moisture_prediction_started", "moisture_prediction_usecase",
+		zap.String("model", uc.predictor.Name()),
+		zap.Int("zones", len(uc.zones)),
+		zap.Duration("interval", uc.config.Interval),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		_ = uc.Predict(ctx)
+
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("moisture_prediction_stopped", "moisture_prediction_usecase")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return uc.predicted.Collect()
+}
+
+// MetricsCollector returns the moisture prediction metrics collector
+func MetricsCollector(useCase MoisturePredictionUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package moistureprediction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+var testZones = []entities.IrrigationZone{
+	{Name: "north", MACAddresses: []string{"AA:BB:CC:DD:EE:01"}},
+	{Name: "south", MACAddresses: []string{"AA:BB:CC:DD:EE:02"}},
+}
+
+func newTestUseCase(t *testing.T, now time.Time) (*useCaseImpl, *mocks.MockMeasurementRepository, *mocks.MockMoisturePredictor) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	measurementRepo := mocks.NewMockMeasurementRepository(t)
+	predictor := mocks.NewMockMoisturePredictor(t)
+	predictor.EXPECT().Name().Return("external").Maybe()
+	useCase := NewMoisturePredictionUseCase(measurementRepo, predictor, testZones, nil, loggerFactory).(*useCaseImpl)
+	useCase.now = func() time.Time { return now }
+	return useCase, measurementRepo, predictor
+}
+
+func TestMoisturePredictionUseCase_Predict(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	from := now.Add(-12 * time.Hour)
+	drying := []*entities.Measurement{
+		reading("AA:BB:CC:DD:EE:02", 35, now.Add(-2*time.Hour)),
+		reading("AA:BB:CC:DD:EE:02", 33, now.Add(-time.Hour)),
+		reading("AA:BB:CC:DD:EE:02", 31, now),
+	}
+
+	t.Run("should predict every zone with the model", func(t *testing.T) {
+		useCase, measurementRepo, predictor := newTestUseCase(t, now)
+		hours := 4.0
+		measurementRepo.EXPECT().History(mock.Anything, "AA:BB:CC:DD:EE:01", "soil_moisture", from, now).Return(nil, nil).Once()
+		measurementRepo.EXPECT().History(mock.Anything, "AA:BB:CC:DD:EE:02", "soil_moisture", from, now).Return(drying, nil).Once()
+		predictor.EXPECT().Predict(mock.Anything, mock.MatchedBy(func(request *entities.MoisturePredictionRequest) bool {
+			return request.Zone == "north"
+		})).Return(&entities.MoisturePrediction{Model: "external", MACAddress: "AA:BB:CC:DD:EE:01", PredictedAt: now, HoursUntilThreshold: &hours}, nil).Once()
+		predictor.EXPECT().Predict(mock.Anything, mock.MatchedBy(func(request *entities.MoisturePredictionRequest) bool {
+			return request.Zone == "south" && len(request.Readings) == 3 && request.Threshold == 25
+		})).Return(&entities.MoisturePrediction{Model: "external", PredictedAt: now}, nil).Once()
+
+		require.NoError(t, useCase.Predict(context.Background()))
+
+		predictions := useCase.Predictions()
+		require.Len(t, predictions, 2)
+		assert.Equal(t, "north", predictions[0].Zone)
+		assert.Equal(t, "south", predictions[1].Zone)
+		prediction, ok := useCase.ForDevice("aa:bb:cc:dd:ee:01")
+		require.True(t, ok)
+		assert.Equal(t, &hours, prediction.HoursUntilThreshold)
+		assert.Equal(t, float64(2), useCase.predicted.Value("external", resultPredicted))
+	})
+
+	t.Run("should fall back to the baseline when the model fails", func(t *testing.T) {
+		useCase, measurementRepo, predictor := newTestUseCase(t, now)
+		measurementRepo.EXPECT().History(mock.Anything, "AA:BB:CC:DD:EE:01", "soil_moisture", from, now).Return(nil, nil).Once()
+		measurementRepo.EXPECT().History(mock.Anything, "AA:BB:CC:DD:EE:02", "soil_moisture", from, now).Return(drying, nil).Once()
+		predictor.EXPECT().Predict(mock.Anything, mock.Anything).Return(nil, errors.New("model unavailable")).Twice()
+
+		require.NoError(t, useCase.Predict(context.Background()))
+
+		predictions := useCase.Predictions()
+		require.Len(t, predictions, 1)
+		assert.Equal(t, "south", predictions[0].Zone)
+		assert.Equal(t, BaselineModel, predictions[0].Model)
+		assert.Equal(t, float64(2), useCase.predicted.Value("external", resultFailed))
+		assert.Equal(t, float64(1), useCase.predicted.Value(BaselineModel, resultInsufficient))
+	})
+
+	t.Run("should drop the prediction of a zone that cannot be predicted", func(t *testing.T) {
+		useCase, measurementRepo, predictor := newTestUseCase(t, now)
+		useCase.zones = testZones[:1]
+		useCase.predictions["north"] = &entities.MoisturePrediction{Zone: "north", PredictedAt: now.Add(-15 * time.Minute)}
+		measurementRepo.EXPECT().History(mock.Anything, "AA:BB:CC:DD:EE:01", "soil_moisture", from, now).Return(nil, nil).Once()
+		predictor.EXPECT().Predict(mock.Anything, mock.Anything).Return(nil, domainerrors.ErrInsufficientReadings).Once()
+
+		require.NoError(t, useCase.Predict(context.Background()))
+
+		assert.Empty(t, useCase.Predictions())
+		_, ok := useCase.ForDevice("AA:BB:CC:DD:EE:01")
+		assert.False(t, ok)
+	})
+}
+
+func TestMoisturePredictionUseCase_ForDevice(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	useCase, _, _ := newTestUseCase(t, now)
+	useCase.predictions["north"] = &entities.MoisturePrediction{Zone: "north", PredictedAt: now.Add(-time.Hour)}
+	useCase.predictions["south"] = &entities.MoisturePrediction{Zone: "south", PredictedAt: now.Add(-10 * time.Minute)}
+
+	_, ok := useCase.ForDevice("AA:BB:CC:DD:EE:01")
+	assert.False(t, ok, "outdated predictions are ignored")
+	prediction, ok := useCase.ForDevice("AA:BB:CC:DD:EE:02")
+	require.True(t, ok)
+	assert.Equal(t, "south", prediction.Zone)
+	_, ok = useCase.ForDevice("AA:BB:CC:DD:EE:99")
+	assert.False(t, ok)
+}
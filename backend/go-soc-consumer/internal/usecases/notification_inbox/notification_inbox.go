@@ -0,0 +1,196 @@
+package notificationinbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// InboxConfig holds configuration for the in-app notification inbox
+type InboxConfig struct {
+	Retention time.Duration // notifications older than this are pruned, read or not
+	Interval  time.Duration // how often old notifications are pruned
+}
+
+// DefaultInboxConfig returns default configuration
+func DefaultInboxConfig() *InboxConfig {
+	return &InboxConfig{
+		Retention: 30 * 24 * time.Hour,
+		Interval:  time.Hour,
+	}
+}
+
+// NotificationInboxUseCase keeps the in-app inbox of each notification user. It is the sender of the
+// inbox channel, so the notification dispatcher fills it with every alert as it is raised.
+type NotificationInboxUseCase interface {
+	// Channel returns the inbox channel
+	Channel() string
+
+	// Send stores a notification about a single alert in the inbox of its user
+	Send(ctx context.Context, notification *entities.Notification) error
+
+	// List returns up to limit notifications of the user created before the given time, or any time
+	// when it is zero, newest first and only the unread ones when unreadOnly is set
+	List(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int) ([]*entities.InboxNotification, error)
+
+	// UnreadCounts returns the number of unread notifications of the user by severity
+	UnreadCounts(ctx context.Context, user string) (map[string]int, error)
+
+	// MarkRead flags a notification of the user as read
+	MarkRead(ctx context.Context, user, id string) error
+
+	// MarkAllRead flags every notification of the user as read and returns how many were unread
+	MarkAllRead(ctx context.Context, user string) (int, error)
+
+	// Run prunes notifications past the retention periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// useCaseImpl implements the NotificationInboxUseCase interface
+type useCaseImpl struct {
+	users         map[string]bool
+	repo          repositoryports.NotificationInboxRepository
+	config        *InboxConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+}
+
+// NewNotificationInboxUseCase creates the inbox of the given notification users
+func NewNotificationInboxUseCase(
+	users []string,
+	repo repositoryports.NotificationInboxRepository,
+	config *InboxConfig,
+	loggerFactory logger.LoggerFactory,
+) NotificationInboxUseCase {
+	if config == nil {
+		config = DefaultInboxConfig()
+	}
+
+	known := make(map[string]bool, len(users))
+	for _, user := range users {
+		known[user] = true
+	}
+
+	return &useCaseImpl{
+		users:         known,
+		repo:          repo,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+	}
+}
+
+// Channel returns the inbox channel
+func (uc *useCaseImpl) Channel() string {
+	return entities.NotificationChannelInbox
+}
+
+// Send stores the notification in the inbox of its user
+func (uc *useCaseImpl) Send(ctx context.Context, notification *entities.Notification) error {
+	if err := domainerrors.RequireInput("notification", notification); err != nil {
+		return err
+	}
+
+	inboxNotification, err := entities.NewInboxNotification(notification)
+	if err != nil {
+		return err
+	}
+	if err := uc.repo.Create(ctx, inboxNotification); err != nil {
+		return fmt.Errorf("failed to store inbox notification: %w", err)
+	}
+	return nil
+}
+
+// List returns the notifications of a known user
+func (uc *useCaseImpl) List(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int) ([]*entities.InboxNotification, error) {
+	if !uc.users[user] {
+		return nil, domainerrors.ErrNotificationUserNotFound
+	}
+	return uc.repo.List(ctx, user, unreadOnly, before, limit)
+}
+
+// UnreadCounts returns the unread notifications of a known user by severity, with every severity present
+func (uc *useCaseImpl) UnreadCounts(ctx context.Context, user string) (map[string]int, error) {
+	if !uc.users[user] {
+		return nil, domainerrors.ErrNotificationUserNotFound
+	}
+
+	counts, err := uc.repo.CountUnread(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	for _, severity := range []string{entities.AlertSeverityInfo, entities.AlertSeverityWarning, entities.AlertSeverityCritical} {
+		if _, ok := counts[severity]; !ok {
+			counts[severity] = 0
+		}
+	}
+	return counts, nil
+}
+
+// MarkRead flags a notification of a known user as read
+func (uc *useCaseImpl) MarkRead(ctx context.Context, user, id string) error {
+	if !uc.users[user] {
+		return domainerrors.ErrNotificationUserNotFound
+	}
+	return uc.repo.MarkRead(ctx, user, id, uc.now().UTC())
+}
+
+// MarkAllRead flags every notification of a known user as read
+func (uc *useCaseImpl) MarkAllRead(ctx context.Context, user string) (int, error) {
+	if !uc.users[user] {
+		return 0, domainerrors.ErrNotificationUserNotFound
+	}
+	return uc.repo.MarkAllRead(ctx, user, uc.now().UTC())
+}
+
+// Run prunes notifications past the retention periodically until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("notification_inbox_started", "notification_inbox_usecase",
+		zap.Int("users", len(uc.users)),
+		zap.Duration("retention", uc.config.Retention),
+		zap.Duration("interval", uc.config.Interval),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		uc.prune(ctx)
+
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("notification_inbox_stopped", "notification_inbox_usecase")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prune removes the notifications past the retention
+func (uc *useCaseImpl) prune(ctx context.Context) {
+	cutoff := uc.now().Add(-uc.config.Retention)
+	removed, err := uc.repo.DeleteCreatedBefore(ctx, cutoff)
+	if err != nil {
+		if ctx.Err() == nil {
+			uc.loggerFactory.Core().Error("notification_inbox_prune_failed",
+				zap.Error(err),
+				zap.String("component", "notification_inbox_usecase"),
+			)
+		}
+		return
+	}
+	if removed > 0 {
+		uc.loggerFactory.Core().Info("notification_inbox_pruned",
+			zap.Int("notifications", removed),
+			zap.Time("cutoff", cutoff),
+			zap.String("component", "notification_inbox_usecase"),
+		)
+	}
+}
@@ -0,0 +1,95 @@
+package notificationinbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestInbox(t *testing.T) (*useCaseImpl, *mocks.MockNotificationInboxRepository) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	repo := mocks.NewMockNotificationInboxRepository(t)
+	useCase := NewNotificationInboxUseCase([]string{"ana"}, repo, nil, loggerFactory).(*useCaseImpl)
+	useCase.now = func() time.Time { return testNow }
+	return useCase, repo
+}
+
+func TestNotificationInbox_Send(t *testing.T) {
+	useCase, repo := newTestInbox(t)
+	rule, err := entities.NewAlertRule("dry_soil", "soil_moisture", entities.AlertOperatorBelow, 20, 0, 0, 0, entities.AlertSeverityWarning)
+	require.NoError(t, err)
+	alert, err := entities.NewAlert(rule, entities.AlertStatusFiring, "AA:BB:CC:DD:EE:FF", 0, 15, testNow, testNow, "dry")
+	require.NoError(t, err)
+
+	repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(notification *entities.InboxNotification) bool {
+		return notification.User == "ana" && notification.AlertID == alert.EventID &&
+			notification.Severity == entities.AlertSeverityWarning && !notification.Read()
+	})).Return(nil).Once()
+
+	require.Equal(t, entities.NotificationChannelInbox, useCase.Channel())
+	require.NoError(t, useCase.Send(context.Background(), &entities.Notification{
+		User:      "ana",
+		Channel:   entities.NotificationChannelInbox,
+		Subject:   "[warning] alert dry_soil is firing",
+		Body:      "dry",
+		Alerts:    []*entities.Alert{alert},
+		CreatedAt: testNow,
+	}))
+
+	assert.Error(t, useCase.Send(context.Background(), &entities.Notification{User: "ana"}), "digests are not stored in the inbox")
+}
+
+func TestNotificationInbox_Read(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should reject unknown users", func(t *testing.T) {
+		useCase, _ := newTestInbox(t)
+
+		_, err := useCase.List(ctx, "bob", false, time.Time{}, 10)
+		assert.ErrorIs(t, err, domainerrors.ErrNotificationUserNotFound)
+		_, err = useCase.UnreadCounts(ctx, "bob")
+		assert.ErrorIs(t, err, domainerrors.ErrNotificationUserNotFound)
+		assert.ErrorIs(t, useCase.MarkRead(ctx, "bob", "id"), domainerrors.ErrNotificationUserNotFound)
+		_, err = useCase.MarkAllRead(ctx, "bob")
+		assert.ErrorIs(t, err, domainerrors.ErrNotificationUserNotFound)
+	})
+
+	t.Run("should report every severity in the unread counts", func(t *testing.T) {
+		useCase, repo := newTestInbox(t)
+		repo.EXPECT().CountUnread(ctx, "ana").Return(map[string]int{entities.AlertSeverityCritical: 2}, nil).Once()
+
+		counts, err := useCase.UnreadCounts(ctx, "ana")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{entities.AlertSeverityInfo: 0, entities.AlertSeverityWarning: 0, entities.AlertSeverityCritical: 2}, counts)
+	})
+
+	t.Run("should mark notifications read at the current time", func(t *testing.T) {
+		useCase, repo := newTestInbox(t)
+		repo.EXPECT().MarkRead(ctx, "ana", "id-1", testNow).Return(nil).Once()
+		repo.EXPECT().MarkAllRead(ctx, "ana", testNow).Return(3, nil).Once()
+
+		require.NoError(t, useCase.MarkRead(ctx, "ana", "id-1"))
+		marked, err := useCase.MarkAllRead(ctx, "ana")
+		require.NoError(t, err)
+		assert.Equal(t, 3, marked)
+	})
+}
+
+func TestNotificationInbox_Prune(t *testing.T) {
+	useCase, repo := newTestInbox(t)
+	repo.EXPECT().DeleteCreatedBefore(mock.Anything, testNow.Add(-30*24*time.Hour)).Return(4, nil).Once()
+
+	useCase.prune(context.Background())
+}
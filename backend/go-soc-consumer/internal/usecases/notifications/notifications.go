@@ -0,0 +1,315 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// DispatcherConfig holds configuration for delivering alert notifications
+type DispatcherConfig struct {
+	Location      *time.Location // time zone of quiet hours and daily digests
+	DailyDigestAt time.Duration  // time of day daily digests are sent, as an offset from midnight
+	Interval      time.Duration  // how often held alerts are checked for delivery
+	QueueSize     int            // notifications waiting for delivery; new ones are dropped once it is full
+	MaxHeldAlerts int            // alerts held for a user on one channel; the oldest are dropped past it
+}
+
+// DefaultDispatcherConfig returns default configuration
+func DefaultDispatcherConfig() *DispatcherConfig {
+	return &DispatcherConfig{
+		Location:      time.UTC,
+		DailyDigestAt: 7 * time.Hour,
+		Interval:      time.Minute,
+		QueueSize:     1000,
+		MaxHeldAlerts: 100,
+	}
+}
+
+// NotificationDispatcherUseCase notifies users of alerts following their preferences: each severity
+// is routed to its channels, non-critical alerts are batched in digests and alerts raised during quiet
+// hours are held until the quiet hours end unless their severity overrides them. Held alerts are kept
+// in memory and lost on restart. When an inbox sender is registered every alert is also delivered to
+// the inbox of every user right away.
+type NotificationDispatcherUseCase interface {
+	ports.AlertNotifier
+
+	// Flush queues the held alerts that are due for delivery
+	Flush()
+
+	// Run delivers queued notifications and held alerts once due until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// heldAlerts are the alerts waiting for delivery to a user on one channel
+type heldAlerts struct {
+	user    *entities.NotificationPreferences
+	channel string
+	alerts  []*entities.Alert
+	due     time.Time
+}
+
+// useCaseImpl implements the NotificationDispatcherUseCase interface
+type useCaseImpl struct {
+	users         []*entities.NotificationPreferences
+	senders       map[string]ports.NotificationSender // channel to its sender
+	config        *DispatcherConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+
+	queue chan *entities.Notification
+
+	mu   sync.Mutex
+	held map[string]*heldAlerts // user and channel to the alerts held for them
+
+	notifications *metrics.Vec
+	heldTotal     *metrics.Vec
+	heldDropped   *metrics.Vec
+}
+
+// NewNotificationDispatcherUseCase creates a dispatcher notifying the users over the senders of their channels
+func NewNotificationDispatcherUseCase(
+	users []*entities.NotificationPreferences,
+	senders []ports.NotificationSender,
+	config *DispatcherConfig,
+	loggerFactory logger.LoggerFactory,
+) NotificationDispatcherUseCase {
+	if config == nil {
+		config = DefaultDispatcherConfig()
+	}
+
+	byChannel := make(map[string]ports.NotificationSender, len(senders))
+	for _, sender := range senders {
+		byChannel[sender.Channel()] = sender
+	}
+
+	return &useCaseImpl{
+		users:         users,
+		senders:       byChannel,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		queue:         make(chan *entities.Notification, config.QueueSize),
+		held:          make(map[string]*heldAlerts),
+		notifications: metrics.NewCounterVec("notifications_total", "Alert notifications by channel and outcome", "channel", "outcome"),
+		heldTotal:     metrics.NewCounterVec("notification_alerts_held_total", "Alerts held back from users by reason", "reason"),
+		heldDropped:   metrics.NewCounterVec("notification_held_alerts_dropped_total", "Held alerts dropped to stay within the held alerts limit", "channel"),
+	}
+}
+
+// Notify delivers the alert right away on every channel of its severity, or holds it for the digest
+// or until the quiet hours of the user end
+func (uc *useCaseImpl) Notify(ctx context.Context, alert *entities.Alert) {
+	now := uc.now()
+	local := now.In(uc.config.Location)
+
+	var ready []*entities.Notification
+	uc.mu.Lock()
+	for _, user := range uc.users {
+		if _, ok := uc.senders[entities.NotificationChannelInbox]; ok {
+			ready = append(ready, uc.compose(user, entities.NotificationChannelInbox, []*entities.Alert{alert}, now))
+		}
+		for _, channel := range user.Channels(alert.Severity) {
+			if _, ok := uc.senders[channel]; !ok {
+				continue
+			}
+			batch := user.Batches(alert.Severity)
+			if !batch && !user.Quiet(alert.Severity, local) {
+				ready = append(ready, uc.compose(user, channel, []*entities.Alert{alert}, now))
+				continue
+			}
+
+			due, reason := local, "quiet_hours"
+			if batch {
+				due, reason = user.NextDigest(local, uc.config.DailyDigestAt), "digest"
+			}
+			if user.Quiet(alert.Severity, due) {
+				due = user.QuietHours.NextEnd(due)
+			}
+			uc.holdLocked(user, channel, alert, due)
+			uc.heldTotal.Inc(reason)
+		}
+	}
+	uc.mu.Unlock()
+
+	uc.enqueue(ready)
+}
+
+// Flush queues every group of held alerts whose delivery time has come, as a single notification
+func (uc *useCaseImpl) Flush() {
+	now := uc.now()
+
+	var ready []*entities.Notification
+	uc.mu.Lock()
+	for key, held := range uc.held {
+		if held.due.After(now) {
+			continue
+		}
+		ready = append(ready, uc.compose(held.user, held.channel, held.alerts, now))
+		delete(uc.held, key)
+	}
+	uc.mu.Unlock()
+
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i].User+ready[i].Channel < ready[j].User+ready[j].Channel
+	})
+	uc.enqueue(ready)
+}
+
+// Run delivers queued notifications as they come and flushes held alerts every interval
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	if len(uc.users) == 0 {
+		return
+	}
+
+	uc.loggerFactory.Application().LogApplicationEvent("notification_dispatcher_started", "notifications_usecase",
+		zap.Int("users", len(uc.users)),
+		zap.Int("channels", len(uc.senders)),
+		zap.Duration("interval", uc.config.Interval),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("notification_dispatcher_stopped", "notifications_usecase")
+			return
+		case <-ticker.C:
+			uc.Flush()
+		case notification := <-uc.queue:
+			uc.send(ctx, notification)
+		}
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	uc.mu.Lock()
+	held := 0
+	for _, alerts := range uc.held {
+		held += len(alerts.alerts)
+	}
+	uc.mu.Unlock()
+
+	families := append(uc.notifications.Collect(), uc.heldTotal.Collect()...)
+	families = append(families, uc.heldDropped.Collect()...)
+	return append(families,
+		metrics.Family{Name: "notification_queue_length", Help: "Notifications waiting for delivery", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(len(uc.queue))}}},
+		metrics.Family{Name: "notification_held_alerts", Help: "Alerts currently held back from users", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(held)}}},
+	)
+}
+
+// MetricsCollector returns the notification dispatcher metrics collector
+func MetricsCollector(useCase NotificationDispatcherUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
+
+// holdLocked adds an alert to the alerts held for a user on a channel, which are delivered at the
+// earliest due time of any of them. Past MaxHeldAlerts the oldest held alert is dropped.
+func (uc *useCaseImpl) holdLocked(user *entities.NotificationPreferences, channel string, alert *entities.Alert, due time.Time) {
+	key := user.User + "|" + channel
+	held, ok := uc.held[key]
+	if !ok {
+		held = &heldAlerts{user: user, channel: channel, due: due}
+		uc.held[key] = held
+	}
+	held.alerts = append(held.alerts, alert)
+	if limit := uc.config.MaxHeldAlerts; limit > 0 && len(held.alerts) > limit {
+		dropped := len(held.alerts) - limit
+		held.alerts = append(held.alerts[:0:0], held.alerts[dropped:]...)
+		uc.heldDropped.Add(float64(dropped), channel)
+	}
+	if due.Before(held.due) {
+		held.due = due
+	}
+}
+
+// enqueue queues notifications for delivery without blocking, dropping them once the queue is full
+func (uc *useCaseImpl) enqueue(notifications []*entities.Notification) {
+	for _, notification := range notifications {
+		select {
+		case uc.queue <- notification:
+		default:
+			uc.notifications.Inc(notification.Channel, "dropped")
+			uc.loggerFactory.Core().Warn("notification_dropped",
+				zap.String("user", notification.User),
+				zap.String("channel", notification.Channel),
+				zap.Int("alerts", len(notification.Alerts)),
+				zap.String("component", "notifications_usecase"),
+			)
+		}
+	}
+}
+
+// send delivers a notification on its channel
+func (uc *useCaseImpl) send(ctx context.Context, notification *entities.Notification) {
+	if err := uc.senders[notification.Channel].Send(ctx, notification); err != nil {
+		uc.notifications.Inc(notification.Channel, "failed")
+		uc.loggerFactory.Core().Error("notification_failed",
+			zap.Error(err),
+			zap.String("user", notification.User),
+			zap.String("channel", notification.Channel),
+			zap.Int("alerts", len(notification.Alerts)),
+			zap.String("component", "notifications_usecase"),
+		)
+		return
+	}
+	uc.notifications.Inc(notification.Channel, "sent")
+}
+
+// compose writes the notification of one alert, or the digest of several, in the language of the user
+func (uc *useCaseImpl) compose(user *entities.NotificationPreferences, channel string, alerts []*entities.Alert, now time.Time) *entities.Notification {
+	language, ok := i18n.ParseLanguage(user.Language)
+	if !ok {
+		language = i18n.English
+	}
+	describe := func(alert *entities.Alert) string {
+		if description, ok := alert.LocalizedDescriptions[string(language)]; ok {
+			return description
+		}
+		return alert.Description
+	}
+
+	notification := &entities.Notification{
+		User:      user.User,
+		Channel:   channel,
+		Address:   user.Address(channel),
+		Alerts:    alerts,
+		CreatedAt: now,
+	}
+	if len(alerts) == 1 {
+		format := "[%s] alert %s is firing"
+		if alerts[0].Status == entities.AlertStatusResolved {
+			format = "[%s] alert %s resolved"
+		}
+		notification.Subject = i18n.Sprintf(language, format, alerts[0].Severity, alerts[0].Rule)
+		notification.Body = describe(alerts[0])
+		return notification
+	}
+
+	lines := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		lines = append(lines, fmt.Sprintf("%s [%s] %s", alert.RaisedAt.In(uc.config.Location).Format("2006-01-02 15:04"), alert.Severity, describe(alert)))
+	}
+	notification.Subject = i18n.Sprintf(language, "%d alerts since %s", len(alerts), alerts[0].RaisedAt.In(uc.config.Location).Format("2006-01-02 15:04"))
+	notification.Body = strings.Join(lines, "\n")
+	return notification
+}
@@ -0,0 +1,254 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// testStart is 10:00 in the UTC-5 test time zone
+var (
+	testLocation = time.FixedZone("COT", -5*60*60)
+	testStart    = time.Date(2025, 6, 1, 10, 0, 0, 0, testLocation)
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestUser(t *testing.T, preferences entities.NotificationPreferences) *entities.NotificationPreferences {
+	preferences.Normalize()
+	require.NoError(t, preferences.Validate())
+	return &preferences
+}
+
+func newTestAlert(t *testing.T, severity string, raisedAt time.Time) *entities.Alert {
+	rule, err := entities.NewAlertRule("dry_soil", "soil_moisture", entities.AlertOperatorBelow, 20, 0, 0, 0, severity)
+	require.NoError(t, err)
+	alert, err := entities.NewAlert(rule, entities.AlertStatusFiring, "AA:BB:CC:DD:EE:FF", 0, 15, raisedAt, raisedAt, "dry")
+	require.NoError(t, err)
+	alert.LocalizedDescriptions = i18n.SprintfAll("%s of %s channel %d is %v, below %v for %s", "soil_moisture", "AA:BB:CC:DD:EE:FF", 0, 15, 20, "0s")
+	return alert
+}
+
+func newTestSender(t *testing.T, channel string) *mocks.MockNotificationSender {
+	sender := mocks.NewMockNotificationSender(t)
+	sender.EXPECT().Channel().Return(channel).Once()
+	return sender
+}
+
+func newTestDispatcher(t *testing.T, users ...*entities.NotificationPreferences) (*useCaseImpl, *time.Time) {
+	senders := []ports.NotificationSender{
+		newTestSender(t, entities.NotificationChannelEmail),
+		newTestSender(t, entities.NotificationChannelSMS),
+	}
+	config := &DispatcherConfig{Location: testLocation, DailyDigestAt: 7 * time.Hour, Interval: time.Minute, QueueSize: 10, MaxHeldAlerts: 3}
+	useCase := NewNotificationDispatcherUseCase(users, senders, config, createTestLoggerFactory(t)).(*useCaseImpl)
+	now := testStart
+	useCase.now = func() time.Time { return now }
+	return useCase, &now
+}
+
+// queued drains the notifications waiting for delivery
+func queued(useCase *useCaseImpl) []*entities.Notification {
+	var notifications []*entities.Notification
+	for {
+		select {
+		case notification := <-useCase.queue:
+			notifications = append(notifications, notification)
+		default:
+			return notifications
+		}
+	}
+}
+
+func TestNotificationDispatcher_Notify(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should route each severity to its channels", func(t *testing.T) {
+		user := newTestUser(t, entities.NotificationPreferences{User: "ana", Email: "ana@example.com", Phone: "+573001234567", Language: "es"})
+		useCase, _ := newTestDispatcher(t, user)
+
+		useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityCritical, testStart))
+		useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityInfo, testStart))
+
+		notifications := queued(useCase)
+		require.Len(t, notifications, 3)
+		assert.Equal(t, entities.NotificationChannelSMS, notifications[0].Channel)
+		assert.Equal(t, "+573001234567", notifications[0].Address)
+		assert.Equal(t, "[critical] la alerta dry_soil está activa", notifications[0].Subject)
+		assert.Contains(t, notifications[0].Body, "por debajo de 20")
+		assert.Equal(t, entities.NotificationChannelEmail, notifications[1].Channel)
+		assert.Equal(t, entities.NotificationChannelEmail, notifications[2].Channel)
+		assert.Equal(t, "[info] la alerta dry_soil está activa", notifications[2].Subject)
+	})
+
+	t.Run("should batch non-critical alerts in an hourly digest", func(t *testing.T) {
+		user := newTestUser(t, entities.NotificationPreferences{User: "ana", Email: "ana@example.com", Digest: entities.NotificationDigestHourly})
+		useCase, now := newTestDispatcher(t, user)
+
+		*now = testStart.Add(10 * time.Minute)
+		useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityWarning, *now))
+		useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityCritical, *now))
+		*now = testStart.Add(20 * time.Minute)
+		useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityInfo, *now))
+
+		require.Len(t, queued(useCase), 1, "critical alerts are never batched")
+		useCase.Flush()
+		assert.Empty(t, queued(useCase))
+
+		*now = testStart.Add(time.Hour)
+		useCase.Flush()
+		notifications := queued(useCase)
+		require.Len(t, notifications, 1)
+		assert.Len(t, notifications[0].Alerts, 2)
+		assert.Equal(t, "2 alerts since 2025-06-01 10:10", notifications[0].Subject)
+		assert.Contains(t, notifications[0].Body, "2025-06-01 10:20 [info]")
+	})
+
+	t.Run("should send daily digests at the digest time", func(t *testing.T) {
+		user := newTestUser(t, entities.NotificationPreferences{User: "ana", Email: "ana@example.com", Digest: entities.NotificationDigestDaily})
+		useCase, now := newTestDispatcher(t, user)
+
+		useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityWarning, testStart))
+
+		*now = time.Date(2025, 6, 2, 6, 59, 0, 0, testLocation)
+		useCase.Flush()
+		assert.Empty(t, queued(useCase))
+		*now = time.Date(2025, 6, 2, 7, 0, 0, 0, testLocation)
+		useCase.Flush()
+		assert.Len(t, queued(useCase), 1)
+	})
+
+	t.Run("should hold alerts during quiet hours unless their severity overrides them", func(t *testing.T) {
+		user := newTestUser(t, entities.NotificationPreferences{
+			User:       "ana",
+			Email:      "ana@example.com",
+			QuietHours: &entities.QuietHours{Start: 22 * time.Hour, End: 6 * time.Hour},
+		})
+		useCase, now := newTestDispatcher(t, user)
+
+		*now = time.Date(2025, 6, 1, 23, 0, 0, 0, testLocation)
+		useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityWarning, *now))
+		useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityCritical, *now))
+		require.Len(t, queued(useCase), 1)
+
+		*now = time.Date(2025, 6, 2, 5, 59, 0, 0, testLocation)
+		useCase.Flush()
+		assert.Empty(t, queued(useCase))
+		*now = time.Date(2025, 6, 2, 6, 0, 0, 0, testLocation)
+		useCase.Flush()
+		notifications := queued(useCase)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, "[warning] alert dry_soil is firing", notifications[0].Subject)
+	})
+
+	t.Run("should push a digest falling in quiet hours to their end", func(t *testing.T) {
+		user := newTestUser(t, entities.NotificationPreferences{
+			User:          "ana",
+			Email:         "ana@example.com",
+			Digest:        entities.NotificationDigestHourly,
+			QuietHours:    &entities.QuietHours{Start: 11 * time.Hour, End: 13 * time.Hour},
+			QuietOverride: entities.NotificationQuietOverrideNone,
+		})
+		useCase, now := newTestDispatcher(t, user)
+
+		*now = testStart.Add(30 * time.Minute)
+		useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityInfo, *now))
+
+		*now = testStart.Add(time.Hour)
+		useCase.Flush()
+		assert.Empty(t, queued(useCase))
+		*now = testStart.Add(3 * time.Hour)
+		useCase.Flush()
+		assert.Len(t, queued(useCase), 1)
+	})
+
+	t.Run("should deliver every alert to the inbox right away", func(t *testing.T) {
+		user := newTestUser(t, entities.NotificationPreferences{
+			User:       "ana",
+			Email:      "ana@example.com",
+			Digest:     entities.NotificationDigestDaily,
+			QuietHours: &entities.QuietHours{Start: 9 * time.Hour, End: 11 * time.Hour},
+		})
+		useCase, _ := newTestDispatcher(t, user)
+		useCase.senders[entities.NotificationChannelInbox] = mocks.NewMockNotificationSender(t)
+
+		useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityInfo, testStart))
+
+		notifications := queued(useCase)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, entities.NotificationChannelInbox, notifications[0].Channel)
+		assert.Equal(t, "ana", notifications[0].Address)
+		assert.Equal(t, "[info] alert dry_soil is firing", notifications[0].Subject)
+	})
+
+	t.Run("should drop notifications once the queue is full", func(t *testing.T) {
+		user := newTestUser(t, entities.NotificationPreferences{User: "ana", Email: "ana@example.com"})
+		useCase, _ := newTestDispatcher(t, user)
+
+		for range 12 {
+			useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityWarning, testStart))
+		}
+
+		assert.Len(t, queued(useCase), 10)
+	})
+
+	t.Run("should drop the oldest held alerts past the limit", func(t *testing.T) {
+		user := newTestUser(t, entities.NotificationPreferences{User: "ana", Email: "ana@example.com", Digest: entities.NotificationDigestHourly})
+		useCase, now := newTestDispatcher(t, user)
+
+		for minute := range 5 {
+			useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityInfo, testStart.Add(time.Duration(minute)*time.Minute)))
+		}
+		assert.Equal(t, float64(2), useCase.heldDropped.Value(entities.NotificationChannelEmail))
+
+		*now = testStart.Add(time.Hour)
+		useCase.Flush()
+		notifications := queued(useCase)
+		require.Len(t, notifications, 1)
+		require.Len(t, notifications[0].Alerts, 3)
+		assert.True(t, testStart.Add(2*time.Minute).Equal(notifications[0].Alerts[0].RaisedAt), "the two oldest alerts were dropped")
+	})
+}
+
+func TestNotificationDispatcher_Run(t *testing.T) {
+	user := newTestUser(t, entities.NotificationPreferences{User: "ana", Email: "ana@example.com"})
+	email := mocks.NewMockNotificationSender(t)
+	email.EXPECT().Channel().Return(entities.NotificationChannelEmail).Once()
+	delivered := make(chan *entities.Notification, 1)
+	email.EXPECT().Send(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, notification *entities.Notification) error {
+		delivered <- notification
+		return nil
+	}).Once()
+	useCase := NewNotificationDispatcherUseCase([]*entities.NotificationPreferences{user}, []ports.NotificationSender{email}, nil, createTestLoggerFactory(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		useCase.Run(ctx)
+		close(done)
+	}()
+	useCase.Notify(ctx, newTestAlert(t, entities.AlertSeverityWarning, testStart))
+
+	select {
+	case notification := <-delivered:
+		assert.Equal(t, "ana@example.com", notification.Address)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification was not delivered")
+	}
+	cancel()
+	<-done
+}
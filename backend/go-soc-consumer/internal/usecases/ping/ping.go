@@ -1,21 +1,154 @@
 package ping
 
-import "context"
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/version"
+)
 
 // PingUseCase defines the contract for ping use case operations
 type PingUseCase interface {
 	Ping(ctx context.Context) string
+
+	// Report checks every dependency and returns the health of the service
+	Report(ctx context.Context) *entities.HealthReport
+
+	// Run publishes a health report every publish interval until ctx is cancelled.
+	// It returns immediately when publishing is disabled.
+	Run(ctx context.Context)
+}
+
+// DependencyCheck probes one dependency of the service
+type DependencyCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// ReportConfig holds configuration for health reports
+type ReportConfig struct {
+	Version         string
+	CheckTimeout    time.Duration // bound on each dependency check
+	PublishInterval time.Duration // how often reports are published on NATS, 0 disables publishing
+}
+
+// DefaultReportConfig returns the default health report configuration
+func DefaultReportConfig() *ReportConfig {
+	return &ReportConfig{
+		Version:      version.Version,
+		CheckTimeout: 5 * time.Second,
+	}
 }
 
 // UseCaseImpl implements the UseCase interface
-type useCaseImpl struct{}
+type useCaseImpl struct {
+	config        *ReportConfig
+	checks        []DependencyCheck
+	publisher     eventports.EventPublisher
+	loggerFactory logger.LoggerFactory
+	startedAt     time.Time
+	now           func() time.Time
+}
 
-// NewUseCase creates a new ping use case implementation
-func NewUseCase() PingUseCase {
-	return &useCaseImpl{}
+// NewUseCase creates a new ping use case implementation reporting the health of the given dependencies.
+// The publisher and logger factory are only used by Run and may be nil when reports are not published.
+func NewUseCase(config *ReportConfig, checks []DependencyCheck, publisher eventports.EventPublisher, loggerFactory logger.LoggerFactory) PingUseCase {
+	if config == nil {
+		config = DefaultReportConfig()
+	}
+
+	return &useCaseImpl{
+		config:        config,
+		checks:        checks,
+		publisher:     publisher,
+		loggerFactory: loggerFactory,
+		startedAt:     time.Now().UTC(),
+		now:           func() time.Time { return time.Now().UTC() },
+	}
 }
 
 // Ping returns "pong" response
 func (uc *useCaseImpl) Ping(ctx context.Context) string {
 	return "pong"
 }
+
+// Report runs the dependency checks concurrently, each bounded by the check timeout
+func (uc *useCaseImpl) Report(ctx context.Context) *entities.HealthReport {
+	dependencies := make(map[string]entities.DependencyHealth, len(uc.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, check := range uc.checks {
+		wg.Add(1)
+		go func(check DependencyCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, uc.config.CheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Check(checkCtx)
+			health := entities.DependencyHealth{Status: entities.DependencyStatusOK, Latency: time.Since(start)}
+			if err != nil {
+				health.Status = entities.DependencyStatusFailing
+				health.Error = err.Error()
+			}
+
+			mu.Lock()
+			dependencies[check.Name] = health
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	return entities.NewHealthReport(uc.config.Version, uc.startedAt, uc.now(), dependencies)
+}
+
+// Run publishes health reports on the system health subject for fleet monitoring
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	if uc.publisher == nil || uc.config.PublishInterval <= 0 {
+		return
+	}
+
+	uc.loggerFactory.Application().LogApplicationEvent("health_report_publishing_started", "ping_usecase",
+		zap.Duration("publish_interval", uc.config.PublishInterval),
+		zap.String("subject", events.SystemHealthSubject),
+	)
+
+	ticker := time.NewTicker(uc.config.PublishInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("health_report_publishing_stopped", "ping_usecase")
+			return
+		case <-ticker.C:
+			uc.publish(ctx)
+		}
+	}
+}
+
+// publish publishes one report, skipping it while NATS is disconnected
+func (uc *useCaseImpl) publish(ctx context.Context) {
+	if !uc.publisher.IsConnected() {
+		uc.loggerFactory.Core().Debug("health_report_skipped_nats_disconnected", zap.String("component", "ping_usecase"))
+		return
+	}
+
+	report := uc.Report(ctx)
+	if err := uc.publisher.Publish(ctx, events.SystemHealthSubject, report); err != nil {
+		uc.loggerFactory.Core().Warn("health_report_publish_failed",
+			zap.Error(err),
+			zap.String("status", report.Status),
+			zap.String("component", "ping_usecase"),
+		)
+	}
+}
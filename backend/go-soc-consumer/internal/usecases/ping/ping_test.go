@@ -2,16 +2,25 @@ package ping
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
 )
 
 // contextKey is a custom type for context keys to avoid collisions
 type contextKey string
 
 func TestNewUseCase(t *testing.T) {
-	useCase := NewUseCase()
+	useCase := NewUseCase(nil, nil, nil, nil)
 
 	assert.NotNil(t, useCase)
 
@@ -49,7 +58,7 @@ func TestUseCaseImpl_Execute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			useCase := NewUseCase()
+			useCase := NewUseCase(nil, nil, nil, nil)
 
 			result := useCase.Ping(tt.ctx)
 
@@ -60,7 +69,7 @@ func TestUseCaseImpl_Execute(t *testing.T) {
 
 func TestUseCaseImpl_Execute_ConsistentBehavior(t *testing.T) {
 	t.Run("multiple calls return same result", func(t *testing.T) {
-		useCase := NewUseCase()
+		useCase := NewUseCase(nil, nil, nil, nil)
 
 		// Call multiple times to ensure consistent behavior
 		for i := 0; i < 5; i++ {
@@ -70,7 +79,7 @@ func TestUseCaseImpl_Execute_ConsistentBehavior(t *testing.T) {
 	})
 
 	t.Run("concurrent calls return same result", func(t *testing.T) {
-		useCase := NewUseCase()
+		useCase := NewUseCase(nil, nil, nil, nil)
 
 		results := make(chan string, 10)
 
@@ -92,7 +101,7 @@ func TestUseCaseImpl_Execute_ConsistentBehavior(t *testing.T) {
 
 func TestUseCaseImpl_Execute_ImplementsInterface(t *testing.T) {
 	t.Run("implements UseCase interface correctly", func(t *testing.T) {
-		var useCase PingUseCase = NewUseCase()
+		var useCase PingUseCase = NewUseCase(nil, nil, nil, nil)
 
 		result := useCase.Ping(context.Background())
 		assert.Equal(t, "pong", result)
@@ -101,7 +110,7 @@ func TestUseCaseImpl_Execute_ImplementsInterface(t *testing.T) {
 
 func TestUseCaseImpl_Execute_ContextHandling(t *testing.T) {
 	t.Run("context is accepted but not used", func(t *testing.T) {
-		useCase := NewUseCase()
+		useCase := NewUseCase(nil, nil, nil, nil)
 
 		// Test with different context types
 		contexts := []context.Context{
@@ -119,7 +128,7 @@ func TestUseCaseImpl_Execute_ContextHandling(t *testing.T) {
 
 // Benchmark tests
 func BenchmarkUseCaseImpl_Execute(b *testing.B) {
-	useCase := NewUseCase()
+	useCase := NewUseCase(nil, nil, nil, nil)
 	ctx := context.Background()
 
 	b.ResetTimer()
@@ -129,7 +138,7 @@ func BenchmarkUseCaseImpl_Execute(b *testing.B) {
 }
 
 func BenchmarkUseCaseImpl_Execute_WithContext(b *testing.B) {
-	useCase := NewUseCase()
+	useCase := NewUseCase(nil, nil, nil, nil)
 	ctx := context.WithValue(context.Background(), contextKey("benchmark-key"), "benchmark-value")
 
 	b.ResetTimer()
@@ -139,7 +148,7 @@ func BenchmarkUseCaseImpl_Execute_WithContext(b *testing.B) {
 }
 
 func BenchmarkUseCaseImpl_Execute_Concurrent(b *testing.B) {
-	useCase := NewUseCase()
+	useCase := NewUseCase(nil, nil, nil, nil)
 	ctx := context.Background()
 
 	b.ResetTimer()
@@ -194,7 +203,7 @@ func TestUseCaseImpl_Execute_EdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			useCase := NewUseCase()
+			useCase := NewUseCase(nil, nil, nil, nil)
 			ctx := tt.setupCtx()
 
 			result := useCase.Ping(ctx)
@@ -207,7 +216,7 @@ func TestUseCaseImpl_Execute_EdgeCases(t *testing.T) {
 // Test to ensure the use case is stateless
 func TestUseCaseImpl_Stateless(t *testing.T) {
 	t.Run("use case is stateless between calls", func(t *testing.T) {
-		useCase := NewUseCase()
+		useCase := NewUseCase(nil, nil, nil, nil)
 
 		// Multiple calls should not affect each other
 		result1 := useCase.Ping(context.Background())
@@ -227,8 +236,8 @@ func TestUseCaseImpl_Stateless(t *testing.T) {
 // Test multiple instances
 func TestUseCaseImpl_MultipleInstances(t *testing.T) {
 	t.Run("multiple instances behave identically", func(t *testing.T) {
-		useCase1 := NewUseCase()
-		useCase2 := NewUseCase()
+		useCase1 := NewUseCase(nil, nil, nil, nil)
+		useCase2 := NewUseCase(nil, nil, nil, nil)
 
 		result1 := useCase1.Ping(context.Background())
 		result2 := useCase2.Ping(context.Background())
@@ -238,3 +247,93 @@ func TestUseCaseImpl_MultipleInstances(t *testing.T) {
 		assert.Equal(t, result1, result2)
 	})
 }
+
+func TestUseCaseImpl_Report(t *testing.T) {
+	config := &ReportConfig{Version: "1.4.0", CheckTimeout: 50 * time.Millisecond}
+
+	t.Run("should report every dependency with its status and latency", func(t *testing.T) {
+		checks := []DependencyCheck{
+			{Name: "database", Check: func(ctx context.Context) error { return nil }},
+			{Name: "mqtt", Check: func(ctx context.Context) error { return errors.New("MQTT client is not connected") }},
+			{Name: "nats", Check: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+		}
+		useCase := NewUseCase(config, checks, nil, nil).(*useCaseImpl)
+		useCase.now = func() time.Time { return useCase.startedAt.Add(time.Hour) }
+
+		report := useCase.Report(context.Background())
+
+		assert.Equal(t, entities.HealthStatusDegraded, report.Status)
+		assert.Equal(t, "1.4.0", report.Version)
+		assert.Equal(t, time.Hour, report.Uptime)
+		require.Len(t, report.Dependencies, 3)
+		assert.Equal(t, entities.DependencyStatusOK, report.Dependencies["database"].Status)
+		assert.Equal(t, "MQTT client is not connected", report.Dependencies["mqtt"].Error)
+		assert.Equal(t, entities.DependencyStatusFailing, report.Dependencies["nats"].Status, "checks are bounded by the check timeout")
+		assert.GreaterOrEqual(t, report.Dependencies["nats"].Latency, config.CheckTimeout)
+	})
+
+	t.Run("should be ok without failing dependencies", func(t *testing.T) {
+		report := NewUseCase(config, nil, nil, nil).Report(context.Background())
+		assert.Equal(t, entities.HealthStatusOK, report.Status)
+		assert.Empty(t, report.Dependencies)
+	})
+}
+
+func TestUseCaseImpl_Run(t *testing.T) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	t.Run("should return immediately when publishing is disabled", func(t *testing.T) {
+		publisher := mocks.NewMockEventPublisher(t)
+		useCase := NewUseCase(&ReportConfig{CheckTimeout: time.Second}, nil, publisher, loggerFactory)
+
+		done := make(chan struct{})
+		go func() {
+			useCase.Run(context.Background())
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run did not return with publishing disabled")
+		}
+	})
+
+	t.Run("should publish reports on the system health subject", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		published := make(chan *entities.HealthReport, 1)
+		publisher := mocks.NewMockEventPublisher(t)
+		publisher.EXPECT().IsConnected().Return(true)
+		publisher.EXPECT().Publish(mock.Anything, events.SystemHealthSubject, mock.AnythingOfType("*entities.HealthReport")).
+			RunAndReturn(func(_ context.Context, _ string, data interface{}) error {
+				select {
+				case published <- data.(*entities.HealthReport):
+				default:
+				}
+				return nil
+			})
+
+		config := &ReportConfig{Version: "1.4.0", CheckTimeout: time.Second, PublishInterval: 10 * time.Millisecond}
+		useCase := NewUseCase(config, nil, publisher, loggerFactory)
+		stopped := make(chan struct{})
+		go func() {
+			useCase.Run(ctx)
+			close(stopped)
+		}()
+
+		select {
+		case report := <-published:
+			assert.Equal(t, entities.HealthStatusOK, report.Status)
+			assert.Equal(t, "1.4.0", report.Version)
+		case <-time.After(2 * time.Second):
+			t.Error("no health report was published")
+		}
+		cancel()
+		<-stopped
+	})
+}
@@ -0,0 +1,302 @@
+package rawarchive
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// ArchiveConfig holds configuration for archiving raw inbound messages
+type ArchiveConfig struct {
+	MaxBundleBytes    int           // payload bytes after which a bundle is uploaded before its hour ends
+	MaxPendingBundles int           // bundles kept while uploads fail; the oldest are dropped beyond it
+	MaxPendingBytes   int           // payload bytes of the bundles kept while uploads fail; the oldest are dropped beyond it
+	UploadInterval    time.Duration // how often finished bundles are uploaded
+	Retention         time.Duration // bundles of older hours are deleted, zero keeps them forever
+	RetentionInterval time.Duration // how often expired bundles are deleted
+}
+
+// DefaultArchiveConfig returns default configuration
+func DefaultArchiveConfig() *ArchiveConfig {
+	return &ArchiveConfig{
+		MaxBundleBytes:    32 << 20,
+		MaxPendingBundles: 24,
+		MaxPendingBytes:   64 << 20,
+		UploadInterval:    time.Minute,
+		Retention:         90 * 24 * time.Hour,
+		RetentionInterval: time.Hour,
+	}
+}
+
+// RawArchiveUseCase archives every inbound message as received, in hourly bundles, so it can be
+// reprocessed after a bug in decoding or handling is fixed. Uploads happen in the background and
+// never delay ingestion.
+type RawArchiveUseCase interface {
+	// Record adds a message, with the publisher identity found in the context, to the bundle
+	// of the current hour without blocking
+	Record(ctx context.Context, topic string, payload []byte)
+
+	// Run uploads finished bundles and deletes expired ones until the context is cancelled
+	Run(ctx context.Context)
+
+	// Flush uploads every bundle, including the one of the current hour
+	Flush(ctx context.Context) error
+}
+
+// bundle collects the messages of one hour
+type bundle struct {
+	hour     time.Time
+	messages []*entities.RawMessage
+	bytes    int
+}
+
+// useCaseImpl implements the RawArchiveUseCase interface
+type useCaseImpl struct {
+	archive       ports.RawMessageArchive
+	config        *ArchiveConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+
+	mu       sync.Mutex
+	current  *bundle
+	finished []*bundle     // waiting for upload, oldest first
+	pending  int           // payload bytes of the finished bundles
+	ready    chan struct{} // signalled when a bundle is finished
+
+	uploadMu sync.Mutex // serialises uploads from Run and Flush
+
+	archived *metrics.Vec
+	uploaded *metrics.Vec
+	failures *metrics.Vec
+	dropped  *metrics.Vec
+	deleted  *metrics.Vec
+}
+
+// NewRawArchiveUseCase creates a new raw archive use case
+func NewRawArchiveUseCase(archive ports.RawMessageArchive, config *ArchiveConfig, loggerFactory logger.LoggerFactory) RawArchiveUseCase {
+	if config == nil {
+		config = DefaultArchiveConfig()
+	}
+
+	return &useCaseImpl{
+		archive:       archive,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		ready:         make(chan struct{}, 1),
+		archived:      metrics.NewCounterVec("raw_archive_messages_total", "Inbound MQTT messages added to the raw archive"),
+		uploaded:      metrics.NewCounterVec("raw_archive_bundles_uploaded_total", "Raw message bundles uploaded to object storage"),
+		failures:      metrics.NewCounterVec("raw_archive_upload_failures_total", "Failed uploads of raw message bundles"),
+		dropped:       metrics.NewCounterVec("raw_archive_messages_dropped_total", "Raw messages dropped because too many bundles were waiting for upload"),
+		deleted:       metrics.NewCounterVec("raw_archive_bundles_deleted_total", "Raw message bundles deleted after the retention period"),
+	}
+}
+
+// Record adds the message to the bundle of its hour, finishing the previous bundle when the hour
+// changed or the bundle grew past the size limit
+func (uc *useCaseImpl) Record(ctx context.Context, topic string, payload []byte) {
+	message, err := entities.NewRawMessage(topic, payload, uc.now())
+	if err != nil {
+		return
+	}
+	message.Identity = eventports.ClientIdentityFromContext(ctx)
+
+	uc.mu.Lock()
+	finished := false
+	if uc.current != nil && !uc.current.hour.Equal(message.Hour()) {
+		uc.finishLocked()
+		finished = true
+	}
+	if uc.current == nil {
+		uc.current = &bundle{hour: message.Hour()}
+	}
+	uc.current.messages = append(uc.current.messages, message)
+	uc.current.bytes += len(message.Payload)
+	if uc.current.bytes >= uc.config.MaxBundleBytes {
+		uc.finishLocked()
+		finished = true
+	}
+	uc.mu.Unlock()
+
+	uc.archived.Inc()
+	if finished {
+		select {
+		case uc.ready <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// finishLocked moves the current bundle to the upload queue, dropping the oldest waiting bundles
+// while the queue holds too many bundles or bytes. The caller holds mu.
+func (uc *useCaseImpl) finishLocked() {
+	if uc.current == nil {
+		return
+	}
+	uc.finished = append(uc.finished, uc.current)
+	uc.pending += uc.current.bytes
+	uc.current = nil
+
+	for len(uc.finished) > 1 && (len(uc.finished) > uc.config.MaxPendingBundles || uc.pending > uc.config.MaxPendingBytes) {
+		oldest := uc.finished[0]
+		uc.finished[0] = nil
+		uc.finished = uc.finished[1:]
+		uc.pending -= oldest.bytes
+		uc.dropped.Add(float64(len(oldest.messages)))
+		uc.loggerFactory.Core().Warn("raw_archive_bundle_dropped",
+			zap.Time("hour", oldest.hour),
+			zap.Int("messages", len(oldest.messages)),
+			zap.Int("bytes", oldest.bytes),
+			zap.String("component", "raw_archive_usecase"),
+		)
+	}
+}
+
+// Run uploads finished bundles as soon as they are finished and on every upload interval, when
+// the bundle of a past hour is finished too
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("raw_archive_started", "raw_archive_usecase",
+		zap.Duration("upload_interval", uc.config.UploadInterval),
+		zap.Duration("retention", uc.config.Retention),
+	)
+
+	uploadTicker := time.NewTicker(uc.config.UploadInterval)
+	defer uploadTicker.Stop()
+
+	var retention <-chan time.Time
+	if uc.config.Retention > 0 {
+		retentionTicker := time.NewTicker(uc.config.RetentionInterval)
+		defer retentionTicker.Stop()
+		retention = retentionTicker.C
+		uc.deleteExpired(ctx)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("raw_archive_stopped", "raw_archive_usecase")
+			return
+		case <-retention:
+			uc.deleteExpired(ctx)
+			continue
+		case <-uploadTicker.C:
+			uc.mu.Lock()
+			if uc.current != nil && uc.current.hour.Before(uc.now().Truncate(time.Hour)) {
+				uc.finishLocked()
+			}
+			uc.mu.Unlock()
+		case <-uc.ready:
+		}
+
+		if err := uc.upload(ctx); err != nil && ctx.Err() == nil {
+			uc.loggerFactory.Core().Warn("raw_archive_upload_failed",
+				zap.Error(err),
+				zap.Duration("retry_in", uc.config.UploadInterval),
+				zap.String("component", "raw_archive_usecase"),
+			)
+		}
+	}
+}
+
+// Flush finishes the current bundle and uploads every waiting bundle
+func (uc *useCaseImpl) Flush(ctx context.Context) error {
+	uc.mu.Lock()
+	uc.finishLocked()
+	uc.mu.Unlock()
+
+	return uc.upload(ctx)
+}
+
+// upload stores the waiting bundles oldest first, stopping at the first failure so the
+// bundle is retried on the next attempt
+func (uc *useCaseImpl) upload(ctx context.Context) error {
+	uc.uploadMu.Lock()
+	defer uc.uploadMu.Unlock()
+
+	for {
+		uc.mu.Lock()
+		if len(uc.finished) == 0 {
+			uc.mu.Unlock()
+			return nil
+		}
+		next := uc.finished[0]
+		uc.mu.Unlock()
+
+		if err := uc.archive.Store(ctx, next.hour, next.messages); err != nil {
+			uc.failures.Inc()
+			return fmt.Errorf("failed to upload raw message bundle of %s: %w", next.hour.Format(time.RFC3339), err)
+		}
+		uc.uploaded.Inc()
+
+		uc.mu.Lock()
+		// the bundle may have been dropped for space while it was uploading
+		if len(uc.finished) > 0 && uc.finished[0] == next {
+			uc.finished[0] = nil
+			uc.finished = uc.finished[1:]
+			uc.pending -= next.bytes
+		}
+		uc.mu.Unlock()
+	}
+}
+
+// deleteExpired removes the bundles of the hours older than the retention period
+func (uc *useCaseImpl) deleteExpired(ctx context.Context) {
+	cutoff := uc.now().Add(-uc.config.Retention).Truncate(time.Hour)
+	deleted, err := uc.archive.DeleteBefore(ctx, cutoff)
+	uc.deleted.Add(float64(deleted))
+	if err != nil {
+		if ctx.Err() == nil {
+			uc.loggerFactory.Core().Warn("raw_archive_retention_failed",
+				zap.Error(err),
+				zap.Time("cutoff", cutoff),
+				zap.String("component", "raw_archive_usecase"),
+			)
+		}
+		return
+	}
+	if deleted > 0 {
+		uc.loggerFactory.Application().LogApplicationEvent("raw_archive_bundles_expired", "raw_archive_usecase",
+			zap.Int("deleted", deleted),
+			zap.Time("cutoff", cutoff),
+		)
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	uc.mu.Lock()
+	bundles, pending := len(uc.finished), uc.pending
+	if uc.current != nil {
+		pending += uc.current.bytes
+	}
+	uc.mu.Unlock()
+
+	families := []metrics.Family{
+		{Name: "raw_archive_pending_bundles", Help: "Raw message bundles waiting for upload", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(bundles)}}},
+		{Name: "raw_archive_pending_bytes", Help: "Payload bytes of raw messages held in memory, including the bundle of the current hour", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(pending)}}},
+	}
+	families = append(families, uc.archived.Collect()...)
+	families = append(families, uc.uploaded.Collect()...)
+	families = append(families, uc.failures.Collect()...)
+	families = append(families, uc.dropped.Collect()...)
+	return append(families, uc.deleted.Collect()...)
+}
+
+// MetricsCollector returns the raw archive metrics collector
+func MetricsCollector(useCase RawArchiveUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
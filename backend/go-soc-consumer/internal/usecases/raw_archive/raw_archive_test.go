@@ -0,0 +1,158 @@
+package rawarchive
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testTopic = "/liwaisi/iot/smart-irrigation/sensors/temperature-humidity"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+// newTestUseCase creates the use case with a clock the test moves
+func newTestUseCase(t *testing.T, archive *mocks.MockRawMessageArchive, config *ArchiveConfig) (*useCaseImpl, *time.Time) {
+	now := time.Date(2025, 3, 1, 14, 10, 0, 0, time.UTC)
+	useCase := NewRawArchiveUseCase(archive, config, createTestLoggerFactory(t)).(*useCaseImpl)
+	useCase.now = func() time.Time { return now }
+	return useCase, &now
+}
+
+// topics returns the topics of the messages in a bundle
+func topics(messages []*entities.RawMessage) []string {
+	result := make([]string, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, message.Topic)
+	}
+	return result
+}
+
+func TestRawArchiveUseCase_Record(t *testing.T) {
+	hour := time.Date(2025, 3, 1, 14, 0, 0, 0, time.UTC)
+
+	t.Run("should bundle messages by hour", func(t *testing.T) {
+		archive := mocks.NewMockRawMessageArchive(t)
+		useCase, now := newTestUseCase(t, archive, nil)
+
+		var stored [][]string
+		archive.EXPECT().Store(mock.Anything, mock.Anything, mock.Anything).Run(func(ctx context.Context, bundleHour time.Time, messages []*entities.RawMessage) {
+			stored = append(stored, append([]string{bundleHour.Format("15")}, topics(messages)...))
+		}).Return(nil)
+
+		useCase.Record(context.Background(), "a", []byte("1"))
+		useCase.Record(context.Background(), "b", []byte("2"))
+		*now = now.Add(time.Hour)
+		useCase.Record(context.Background(), "c", []byte("3"))
+		require.NoError(t, useCase.Flush(context.Background()))
+
+		assert.Equal(t, [][]string{{"14", "a", "b"}, {"15", "c"}}, stored)
+		assert.Equal(t, float64(3), useCase.archived.Value())
+		assert.Equal(t, float64(2), useCase.uploaded.Value())
+	})
+
+	t.Run("should finish a bundle that reached the size limit", func(t *testing.T) {
+		archive := mocks.NewMockRawMessageArchive(t)
+		useCase, _ := newTestUseCase(t, archive, &ArchiveConfig{MaxBundleBytes: 4, MaxPendingBundles: 10, MaxPendingBytes: 1 << 20, UploadInterval: time.Minute})
+		archive.EXPECT().Store(mock.Anything, hour, mock.Anything).Return(nil).Times(2)
+
+		useCase.Record(context.Background(), testTopic, []byte("1234"))
+		useCase.Record(context.Background(), testTopic, []byte("5"))
+
+		assert.Len(t, useCase.finished, 1)
+		require.NoError(t, useCase.Flush(context.Background()))
+	})
+
+	t.Run("should drop the oldest bundle when too many wait for upload", func(t *testing.T) {
+		archive := mocks.NewMockRawMessageArchive(t)
+		useCase, _ := newTestUseCase(t, archive, &ArchiveConfig{MaxBundleBytes: 1, MaxPendingBundles: 2, MaxPendingBytes: 1 << 20, UploadInterval: time.Minute})
+
+		useCase.Record(context.Background(), "a", []byte("1"))
+		useCase.Record(context.Background(), "b", []byte("2"))
+		useCase.Record(context.Background(), "c", []byte("3"))
+
+		require.Len(t, useCase.finished, 2)
+		assert.Equal(t, "b", useCase.finished[0].messages[0].Topic)
+		assert.Equal(t, float64(1), useCase.dropped.Value())
+	})
+
+	t.Run("should drop the oldest bundles when too many bytes wait for upload", func(t *testing.T) {
+		archive := mocks.NewMockRawMessageArchive(t)
+		useCase, _ := newTestUseCase(t, archive, &ArchiveConfig{MaxBundleBytes: 4, MaxPendingBundles: 10, MaxPendingBytes: 8, UploadInterval: time.Minute})
+
+		useCase.Record(context.Background(), "a", []byte("1234"))
+		useCase.Record(context.Background(), "b", []byte("5678"))
+		useCase.Record(context.Background(), "c", []byte("9012"))
+		useCase.Record(context.Background(), "d", []byte("34"))
+
+		require.Len(t, useCase.finished, 2)
+		assert.Equal(t, "b", useCase.finished[0].messages[0].Topic)
+		assert.Equal(t, 8, useCase.pending)
+		assert.Equal(t, float64(1), useCase.dropped.Value())
+		families := useCase.Collect()
+		assert.Equal(t, float64(10), families[1].Samples[0].Value, "pending bytes include the current bundle")
+	})
+}
+
+func TestRawArchiveUseCase_Flush(t *testing.T) {
+	archive := mocks.NewMockRawMessageArchive(t)
+	useCase, _ := newTestUseCase(t, archive, nil)
+	archive.EXPECT().Store(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+	archive.EXPECT().Store(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	useCase.Record(context.Background(), testTopic, []byte("{}"))
+	assert.Error(t, useCase.Flush(context.Background()))
+	assert.Len(t, useCase.finished, 1, "a failed bundle is kept for the next attempt")
+	assert.Equal(t, float64(1), useCase.failures.Value())
+
+	require.NoError(t, useCase.Flush(context.Background()))
+	assert.Empty(t, useCase.finished)
+}
+
+func TestRawArchiveUseCase_Run(t *testing.T) {
+	archive := mocks.NewMockRawMessageArchive(t)
+	config := &ArchiveConfig{MaxBundleBytes: 1 << 20, MaxPendingBundles: 10, MaxPendingBytes: 1 << 20, UploadInterval: 10 * time.Millisecond, Retention: 48 * time.Hour, RetentionInterval: time.Hour}
+	useCase, now := newTestUseCase(t, archive, config)
+
+	archive.EXPECT().DeleteBefore(mock.Anything, time.Date(2025, 2, 27, 15, 0, 0, 0, time.UTC)).Return(3, nil).Once()
+	uploaded := make(chan []*entities.RawMessage, 1)
+	archive.EXPECT().Store(mock.Anything, time.Date(2025, 3, 1, 14, 0, 0, 0, time.UTC), mock.Anything).Run(func(ctx context.Context, hour time.Time, messages []*entities.RawMessage) {
+		uploaded <- messages
+	}).Return(nil).Once()
+
+	useCase.Record(context.Background(), testTopic, []byte("{}"))
+	// the clock passes the hour before the next upload tick, which finishes the bundle
+	useCase.mu.Lock()
+	*now = now.Add(time.Hour)
+	useCase.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		useCase.Run(ctx)
+		close(stopped)
+	}()
+
+	select {
+	case messages := <-uploaded:
+		assert.Equal(t, []string{testTopic}, topics(messages))
+	case <-time.After(5 * time.Second):
+		t.Fatal("bundle was not uploaded")
+	}
+
+	cancel()
+	<-stopped
+	assert.Equal(t, float64(3), useCase.deleted.Value())
+}
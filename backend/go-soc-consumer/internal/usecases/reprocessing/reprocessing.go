@@ -0,0 +1,193 @@
+package reprocessing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/jobs"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// JobTypeReprocess is the job type replaying a range of the raw archive
+const JobTypeReprocess = "reprocess_raw_messages"
+
+// ReprocessingConfig holds configuration for replaying archived messages
+type ReprocessingConfig struct {
+	MaxRange time.Duration // longest time range a single job may replay
+}
+
+// DefaultReprocessingConfig returns default configuration
+func DefaultReprocessingConfig() *ReprocessingConfig {
+	return &ReprocessingConfig{
+		MaxRange: 31 * 24 * time.Hour,
+	}
+}
+
+// ReprocessingUseCase replays the raw messages archived for a time range through the current
+// handlers, to backfill data after a bug in decoding or handling is fixed. Replayed messages carry
+// their original receive time and publisher identity, and the handlers store them idempotently.
+type ReprocessingUseCase interface {
+	// Start validates the range and queues a background job replaying it
+	Start(ctx context.Context, from, to time.Time) (*entities.Job, error)
+
+	// Reprocess replays the messages received in [from, to), reporting progress hour by hour
+	Reprocess(ctx context.Context, from, to time.Time, progress ports.JobProgressFunc) (*entities.ReprocessResult, error)
+}
+
+// reprocessPayload is the payload of a reprocessing job
+type reprocessPayload struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// useCaseImpl implements the ReprocessingUseCase interface
+type useCaseImpl struct {
+	archive       ports.RawMessageArchive
+	handler       eventports.MessageHandler
+	jobQueue      jobs.JobQueueUseCase
+	config        *ReprocessingConfig
+	loggerFactory logger.LoggerFactory
+}
+
+// NewReprocessingUseCase creates a new reprocessing use case and registers its job type with the queue
+func NewReprocessingUseCase(
+	archive ports.RawMessageArchive,
+	handler eventports.MessageHandler,
+	jobQueue jobs.JobQueueUseCase,
+	config *ReprocessingConfig,
+	loggerFactory logger.LoggerFactory,
+) ReprocessingUseCase {
+	if config == nil {
+		config = DefaultReprocessingConfig()
+	}
+
+	uc := &useCaseImpl{
+		archive:       archive,
+		handler:       handler,
+		jobQueue:      jobQueue,
+		config:        config,
+		loggerFactory: loggerFactory,
+	}
+	jobQueue.Register(JobTypeReprocess, uc.runJob)
+	return uc
+}
+
+// Start validates the range and queues a reprocessing job
+func (uc *useCaseImpl) Start(ctx context.Context, from, to time.Time) (*entities.Job, error) {
+	if err := uc.validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	job, err := uc.jobQueue.Enqueue(ctx, JobTypeReprocess, reprocessPayload{From: from.UTC(), To: to.UTC()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue reprocessing job: %w", err)
+	}
+
+	uc.loggerFactory.Application().LogApplicationEvent("reprocessing_queued", "reprocessing_usecase",
+		zap.String("job_id", job.ID),
+		zap.Time("from", from),
+		zap.Time("to", to),
+	)
+	return job, nil
+}
+
+// validateRange rejects empty, reversed, future and oversized ranges
+func (uc *useCaseImpl) validateRange(from, to time.Time) error {
+	switch {
+	case from.IsZero() || to.IsZero():
+		return fmt.Errorf("%w: from and to are required", domainerrors.ErrInvalidReprocessRange)
+	case !from.Before(to):
+		return fmt.Errorf("%w: from must be before to", domainerrors.ErrInvalidReprocessRange)
+	case from.After(time.Now()):
+		return fmt.Errorf("%w: from is in the future", domainerrors.ErrInvalidReprocessRange)
+	case uc.config.MaxRange > 0 && to.Sub(from) > uc.config.MaxRange:
+		return fmt.Errorf("%w: range exceeds %s", domainerrors.ErrInvalidReprocessRange, uc.config.MaxRange)
+	}
+	return nil
+}
+
+// runJob is the job handler of JobTypeReprocess
+func (uc *useCaseImpl) runJob(ctx context.Context, job *entities.Job, progress ports.JobProgressFunc) (interface{}, error) {
+	var payload reprocessPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("invalid reprocessing job payload: %w", err)
+	}
+	return uc.Reprocess(ctx, payload.From, payload.To, progress)
+}
+
+// Reprocess replays the range one hour at a time. A message the handlers reject is counted and
+// logged without stopping the replay; failing to read the archive stops it.
+func (uc *useCaseImpl) Reprocess(ctx context.Context, from, to time.Time, progress ports.JobProgressFunc) (*entities.ReprocessResult, error) {
+	if err := uc.validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	from, to = from.UTC(), to.UTC()
+	result := &entities.ReprocessResult{From: from, To: to}
+	start := time.Now()
+
+	firstHour := from.Truncate(time.Hour)
+	hours := int((to.Sub(firstHour) + time.Hour - 1) / time.Hour)
+	for i, hour := 0, firstHour; hour.Before(to); i, hour = i+1, hour.Add(time.Hour) {
+		hourFrom, hourTo := hour, hour.Add(time.Hour)
+		if hourFrom.Before(from) {
+			hourFrom = from
+		}
+		if hourTo.After(to) {
+			hourTo = to
+		}
+
+		err := uc.archive.Read(ctx, hourFrom, hourTo, func(message *entities.RawMessage) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			result.Messages++
+			if err := uc.replay(ctx, message); err != nil {
+				result.Failed++
+				uc.loggerFactory.Core().Warn("reprocessing_message_failed",
+					zap.Error(err),
+					zap.String("topic", message.Topic),
+					zap.Time("received_at", message.ReceivedAt),
+					zap.String("component", "reprocessing_usecase"),
+				)
+				return nil
+			}
+			result.Replayed++
+			return nil
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to replay raw messages of %s: %w", hour.Format(time.RFC3339), err)
+		}
+
+		if progress != nil {
+			progress((i+1)*100/hours, fmt.Sprintf("replayed %d of %d messages up to %s", result.Replayed, result.Messages, hourTo.Format(time.RFC3339)))
+		}
+	}
+
+	uc.loggerFactory.Application().LogApplicationEvent("reprocessing_completed", "reprocessing_usecase",
+		zap.Time("from", from),
+		zap.Time("to", to),
+		zap.Int("messages", result.Messages),
+		zap.Int("replayed", result.Replayed),
+		zap.Int("failed", result.Failed),
+		zap.Duration("duration", time.Since(start)),
+	)
+	return result, nil
+}
+
+// replay hands the message to the handler with its original publisher identity and receive time
+func (uc *useCaseImpl) replay(ctx context.Context, message *entities.RawMessage) error {
+	if message.Identity != nil {
+		ctx = eventports.ContextWithClientIdentity(ctx, message.Identity)
+	}
+	ctx = eventports.ContextWithReplay(ctx, message.ReceivedAt)
+	return uc.handler(ctx, message.Topic, message.Payload)
+}
@@ -0,0 +1,157 @@
+package reprocessing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+// newTestUseCase creates the use case with a job queue expecting the job type registration
+func newTestUseCase(t *testing.T, archive *mocks.MockRawMessageArchive, handler eventports.MessageHandler) (*useCaseImpl, *mocks.MockJobQueueUseCase) {
+	jobQueue := mocks.NewMockJobQueueUseCase(t)
+	jobQueue.EXPECT().Register(JobTypeReprocess, mock.Anything).Once()
+	useCase := NewReprocessingUseCase(archive, handler, jobQueue, nil, createTestLoggerFactory(t)).(*useCaseImpl)
+	return useCase, jobQueue
+}
+
+// replayedMessage is a message seen by the test handler
+type replayedMessage struct {
+	topic      string
+	receivedAt time.Time
+	clientID   string
+}
+
+func TestReprocessingUseCase_Reprocess(t *testing.T) {
+	from := time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := &entities.RawMessage{Topic: "a", Payload: []byte("1"), ReceivedAt: from.Add(time.Minute), Identity: &entities.ClientIdentity{ClientID: "device-1"}}
+	second := &entities.RawMessage{Topic: "b", Payload: []byte("2"), ReceivedAt: from.Add(40 * time.Minute)}
+	third := &entities.RawMessage{Topic: "bad", Payload: []byte("3"), ReceivedAt: from.Add(50 * time.Minute)}
+
+	archive := mocks.NewMockRawMessageArchive(t)
+	archive.EXPECT().Read(mock.Anything, from, time.Date(2025, 3, 1, 11, 0, 0, 0, time.UTC), mock.Anything).
+		RunAndReturn(func(ctx context.Context, from, to time.Time, fn func(*entities.RawMessage) error) error {
+			return fn(first)
+		}).Once()
+	archive.EXPECT().Read(mock.Anything, time.Date(2025, 3, 1, 11, 0, 0, 0, time.UTC), to, mock.Anything).
+		RunAndReturn(func(ctx context.Context, from, to time.Time, fn func(*entities.RawMessage) error) error {
+			if err := fn(second); err != nil {
+				return err
+			}
+			return fn(third)
+		}).Once()
+
+	var replayed []replayedMessage
+	handler := func(ctx context.Context, topic string, payload []byte) error {
+		if topic == "bad" {
+			return errors.New("invalid payload")
+		}
+		receivedAt, ok := eventports.ReplayFromContext(ctx)
+		require.True(t, ok)
+		message := replayedMessage{topic: topic, receivedAt: receivedAt}
+		if identity := eventports.ClientIdentityFromContext(ctx); identity != nil {
+			message.clientID = identity.ClientID
+		}
+		replayed = append(replayed, message)
+		return nil
+	}
+	useCase, _ := newTestUseCase(t, archive, handler)
+
+	var percents []int
+	result, err := useCase.Reprocess(context.Background(), from, to, func(percent int, message string) {
+		percents = append(percents, percent)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Messages)
+	assert.Equal(t, 2, result.Replayed)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, []int{50, 100}, percents)
+	assert.Equal(t, []replayedMessage{
+		{topic: "a", receivedAt: first.ReceivedAt, clientID: "device-1"},
+		{topic: "b", receivedAt: second.ReceivedAt},
+	}, replayed)
+}
+
+func TestReprocessingUseCase_Reprocess_ReadFailure(t *testing.T) {
+	from := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	archive := mocks.NewMockRawMessageArchive(t)
+	archive.EXPECT().Read(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("bucket unavailable")).Once()
+	useCase, _ := newTestUseCase(t, archive, func(ctx context.Context, topic string, payload []byte) error { return nil })
+
+	_, err := useCase.Reprocess(context.Background(), from, from.Add(3*time.Hour), nil)
+
+	assert.ErrorContains(t, err, "bucket unavailable")
+}
+
+func TestReprocessingUseCase_Start(t *testing.T) {
+	from := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should queue a job for the range", func(t *testing.T) {
+		useCase, jobQueue := newTestUseCase(t, mocks.NewMockRawMessageArchive(t), nil)
+		job := &entities.Job{ID: "job-1", Type: JobTypeReprocess}
+		jobQueue.EXPECT().Enqueue(mock.Anything, JobTypeReprocess, reprocessPayload{From: from, To: from.Add(time.Hour)}).Return(job, nil).Once()
+
+		queued, err := useCase.Start(context.Background(), from, from.Add(time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, "job-1", queued.ID)
+	})
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+	}{
+		{name: "missing bounds", from: from},
+		{name: "reversed range", from: from, to: from.Add(-time.Hour)},
+		{name: "future range", from: time.Now().Add(time.Hour), to: time.Now().Add(2 * time.Hour)},
+		{name: "range too long", from: from, to: from.Add(32 * 24 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			useCase, _ := newTestUseCase(t, mocks.NewMockRawMessageArchive(t), nil)
+
+			_, err := useCase.Start(context.Background(), tt.from, tt.to)
+
+			assert.ErrorIs(t, err, domainerrors.ErrInvalidReprocessRange)
+		})
+	}
+}
+
+func TestReprocessingUseCase_RunJob(t *testing.T) {
+	from := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	archive := mocks.NewMockRawMessageArchive(t)
+	archive.EXPECT().Read(mock.Anything, from, from.Add(time.Hour), mock.Anything).Return(nil).Once()
+	jobQueue := mocks.NewMockJobQueueUseCase(t)
+	var handler ports.JobHandler
+	jobQueue.EXPECT().Register(JobTypeReprocess, mock.Anything).Run(func(jobType string, h ports.JobHandler) {
+		handler = h
+	}).Once()
+	NewReprocessingUseCase(archive, nil, jobQueue, nil, createTestLoggerFactory(t))
+
+	payload, err := json.Marshal(reprocessPayload{From: from, To: from.Add(time.Hour)})
+	require.NoError(t, err)
+	result, err := handler(context.Background(), &entities.Job{ID: "job-1", Type: JobTypeReprocess, Payload: payload}, func(int, string) {})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.(*entities.ReprocessResult).Messages)
+}
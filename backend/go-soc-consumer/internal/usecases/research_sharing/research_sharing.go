@@ -0,0 +1,278 @@
+package researchsharing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// SharingConfig holds the limits of the research tokens
+type SharingConfig struct {
+	MaxTokenTTL   time.Duration // longest a token may stay valid
+	MaxRange      time.Duration // longest range a telemetry query may cover
+	DefaultWindow time.Duration // range served when the query gives no start
+}
+
+// DefaultSharingConfig returns default configuration
+func DefaultSharingConfig() *SharingConfig {
+	return &SharingConfig{
+		MaxTokenTTL:   365 * 24 * time.Hour,
+		MaxRange:      31 * 24 * time.Hour,
+		DefaultWindow: 24 * time.Hour,
+	}
+}
+
+// ResearchSharingUseCase shares the telemetry of selected fields and zones with research partners.
+// Partners read it with expiring read-only tokens instead of user accounts, the devices are
+// replaced by pseudonyms, and every request is accounted per token and day.
+type ResearchSharingUseCase interface {
+	// CreateToken creates a token and returns it with its secret, which is only shown once. It
+	// fails with ErrInvalidResearchToken for invalid requests and unknown zones.
+	CreateToken(ctx context.Context, request entities.ResearchTokenRequest) (*entities.ResearchToken, string, error)
+
+	// ListTokens returns every token, most recently created first
+	ListTokens(ctx context.Context) ([]*entities.ResearchToken, error)
+
+	// RevokeToken stops a token from being accepted, failing with ErrResearchTokenNotFound, or
+	// ErrResearchTokenRevoked when it was already revoked
+	RevokeToken(ctx context.Context, id string) (*entities.ResearchToken, error)
+
+	// Usage returns the daily usage of a token over [from, to); zero bounds default to the 30 days
+	// up to today included
+	Usage(ctx context.Context, id string, from, to time.Time) ([]*entities.ResearchTokenUsage, error)
+
+	// Authenticate returns the token with the given secret, failing with ErrInvalidToken for unknown
+	// and revoked tokens and with ErrTokenExpired
+	Authenticate(ctx context.Context, secret string) (*entities.ResearchToken, error)
+
+	// Zones returns the fields and zones the token may read that still exist
+	Zones(ctx context.Context, token *entities.ResearchToken) ([]*entities.Zone, error)
+
+	// Telemetry returns the anonymized readings of the sensor type of the devices of a field or
+	// zone stamped in [from, to), oldest first. to defaults to now and from to DefaultWindow
+	// before to; invalid ranges fail with ErrInvalidReadingsQuery. Zones outside the token fail
+	// with ErrZoneNotFound, as unknown ones do.
+	Telemetry(ctx context.Context, token *entities.ResearchToken, zoneID, sensorType string, from, to time.Time) ([]*entities.AnonymizedReading, error)
+}
+
+// useCaseImpl implements the ResearchSharingUseCase interface
+type useCaseImpl struct {
+	tokenRepo       repositoryports.ResearchTokenRepository
+	zoneRepo        repositoryports.ZoneRepository
+	measurementRepo repositoryports.MeasurementRepository
+	config          *SharingConfig
+	loggerFactory   logger.LoggerFactory
+	now             func() time.Time
+}
+
+// NewResearchSharingUseCase creates a research sharing use case
+func NewResearchSharingUseCase(
+	tokenRepo repositoryports.ResearchTokenRepository,
+	zoneRepo repositoryports.ZoneRepository,
+	measurementRepo repositoryports.MeasurementRepository,
+	config *SharingConfig,
+	loggerFactory logger.LoggerFactory,
+) ResearchSharingUseCase {
+	if config == nil {
+		config = DefaultSharingConfig()
+	}
+
+	return &useCaseImpl{
+		tokenRepo:       tokenRepo,
+		zoneRepo:        zoneRepo,
+		measurementRepo: measurementRepo,
+		config:          config,
+		loggerFactory:   loggerFactory,
+		now:             time.Now,
+	}
+}
+
+// CreateToken validates the token, checks that its zones exist and stores it
+func (uc *useCaseImpl) CreateToken(ctx context.Context, request entities.ResearchTokenRequest) (*entities.ResearchToken, string, error) {
+	token, secret, err := entities.NewResearchToken(request, uc.now(), uc.config.MaxTokenTTL)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", domainerrors.ErrInvalidResearchToken, err)
+	}
+	for _, zoneID := range token.Zones {
+		if _, err := uc.zoneRepo.FindByID(ctx, zoneID); err != nil {
+			if errors.Is(err, domainerrors.ErrZoneNotFound) {
+				return nil, "", fmt.Errorf("%w: unknown zone %s", domainerrors.ErrInvalidResearchToken, zoneID)
+			}
+			return nil, "", err
+		}
+	}
+
+	if err := uc.tokenRepo.Create(ctx, token); err != nil {
+		return nil, "", err
+	}
+
+	uc.loggerFactory.Core().Info("research_token_created",
+		zap.String("token_id", token.ID),
+		zap.String("name", token.Name),
+		zap.Strings("zones", token.Zones),
+		zap.Time("expires_at", token.ExpiresAt),
+		zap.String("component", "research_sharing_usecase"),
+	)
+	return token, secret, nil
+}
+
+// ListTokens loads every token
+func (uc *useCaseImpl) ListTokens(ctx context.Context) ([]*entities.ResearchToken, error) {
+	return uc.tokenRepo.List(ctx)
+}
+
+// RevokeToken revokes a token now
+func (uc *useCaseImpl) RevokeToken(ctx context.Context, id string) (*entities.ResearchToken, error) {
+	token, err := uc.tokenRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := token.Revoke(uc.now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrResearchTokenRevoked, err)
+	}
+	if err := uc.tokenRepo.Update(ctx, token); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("research_token_revoked",
+		zap.String("token_id", token.ID),
+		zap.String("name", token.Name),
+		zap.String("component", "research_sharing_usecase"),
+	)
+	return token, nil
+}
+
+// Usage loads the daily usage of a token
+func (uc *useCaseImpl) Usage(ctx context.Context, id string, from, to time.Time) ([]*entities.ResearchTokenUsage, error) {
+	if _, err := uc.tokenRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	if to.IsZero() {
+		to = entities.ResearchUsageDay(uc.now()).Add(24 * time.Hour)
+	}
+	if from.IsZero() {
+		from = to.Add(-30 * 24 * time.Hour)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", domainerrors.ErrInvalidReadingsQuery)
+	}
+	return uc.tokenRepo.Usage(ctx, id, from, to)
+}
+
+// Authenticate looks the token up by the hash of its secret
+func (uc *useCaseImpl) Authenticate(ctx context.Context, secret string) (*entities.ResearchToken, error) {
+	if !strings.HasPrefix(secret, entities.ResearchTokenPrefix) {
+		return nil, fmt.Errorf("%w: not a research token", domainerrors.ErrInvalidToken)
+	}
+
+	token, err := uc.tokenRepo.FindByHash(ctx, entities.HashResearchToken(secret))
+	if errors.Is(err, domainerrors.ErrResearchTokenNotFound) {
+		return nil, fmt.Errorf("%w: unknown research token", domainerrors.ErrInvalidToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if token.Revoked() {
+		return nil, fmt.Errorf("%w: research token revoked", domainerrors.ErrInvalidToken)
+	}
+	if token.Expired(uc.now()) {
+		return nil, domainerrors.ErrTokenExpired
+	}
+	return token, nil
+}
+
+// Zones loads the zones of the token; zones deleted since the token was created are left out
+func (uc *useCaseImpl) Zones(ctx context.Context, token *entities.ResearchToken) ([]*entities.Zone, error) {
+	zones := make([]*entities.Zone, 0, len(token.Zones))
+	for _, zoneID := range token.Zones {
+		zone, err := uc.zoneRepo.FindByID(ctx, zoneID)
+		if errors.Is(err, domainerrors.ErrZoneNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+
+	uc.recordUsage(ctx, token, 0)
+	return zones, nil
+}
+
+// Telemetry loads the history of every device of the zone and anonymizes it
+func (uc *useCaseImpl) Telemetry(ctx context.Context, token *entities.ResearchToken, zoneID, sensorType string, from, to time.Time) ([]*entities.AnonymizedReading, error) {
+	if !token.AllowsZone(zoneID) {
+		return nil, domainerrors.ErrZoneNotFound
+	}
+	sensorType = strings.TrimSpace(sensorType)
+	if sensorType == "" {
+		return nil, fmt.Errorf("%w: type is required", domainerrors.ErrInvalidReadingsQuery)
+	}
+	from, to, err := uc.resolveRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	macAddresses, err := uc.zoneRepo.DeviceMACAddresses(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	var measurements []*entities.Measurement
+	for _, macAddress := range macAddresses {
+		history, err := uc.measurementRepo.History(ctx, macAddress, sensorType, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the measurements of a device of zone %s: %w", zoneID, err)
+		}
+		measurements = append(measurements, history...)
+	}
+
+	readings := token.Anonymize(measurements)
+	sort.SliceStable(readings, func(i, j int) bool {
+		if !readings[i].Timestamp.Equal(readings[j].Timestamp) {
+			return readings[i].Timestamp.Before(readings[j].Timestamp)
+		}
+		return readings[i].Device < readings[j].Device
+	})
+
+	uc.recordUsage(ctx, token, len(readings))
+	return readings, nil
+}
+
+// resolveRange applies the defaults and limits to the requested range
+func (uc *useCaseImpl) resolveRange(from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = uc.now()
+	}
+	if from.IsZero() {
+		from = to.Add(-uc.config.DefaultWindow)
+	}
+	if !from.Before(to) {
+		return from, to, fmt.Errorf("%w: from must be before to", domainerrors.ErrInvalidReadingsQuery)
+	}
+	if to.Sub(from) > uc.config.MaxRange {
+		return from, to, fmt.Errorf("%w: the range cannot exceed %s", domainerrors.ErrInvalidReadingsQuery, uc.config.MaxRange)
+	}
+	return from, to, nil
+}
+
+// recordUsage accounts a request of the token; the data is still served when accounting fails
+func (uc *useCaseImpl) recordUsage(ctx context.Context, token *entities.ResearchToken, readings int) {
+	if err := uc.tokenRepo.RecordUsage(ctx, token.ID, uc.now(), readings); err != nil {
+		uc.loggerFactory.Core().Warn("research_token_usage_not_recorded",
+			zap.String("token_id", token.ID),
+			zap.Int("readings", readings),
+			zap.Error(err),
+			zap.String("component", "research_sharing_usecase"),
+		)
+	}
+}
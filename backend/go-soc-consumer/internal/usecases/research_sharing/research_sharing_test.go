@@ -0,0 +1,166 @@
+package researchsharing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestUseCase(t *testing.T) (*useCaseImpl, *mocks.MockResearchTokenRepository, *mocks.MockZoneRepository, *mocks.MockMeasurementRepository) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	tokenRepo := mocks.NewMockResearchTokenRepository(t)
+	zoneRepo := mocks.NewMockZoneRepository(t)
+	measurementRepo := mocks.NewMockMeasurementRepository(t)
+	impl := NewResearchSharingUseCase(tokenRepo, zoneRepo, measurementRepo, nil, loggerFactory).(*useCaseImpl)
+	impl.now = func() time.Time { return testNow }
+	return impl, tokenRepo, zoneRepo, measurementRepo
+}
+
+func TestResearchSharingUseCase_CreateToken(t *testing.T) {
+	request := entities.ResearchTokenRequest{Name: "Universidad Nacional", Zones: []string{"zone-1"}, ExpiresAt: testNow.Add(30 * 24 * time.Hour)}
+
+	t.Run("should store the token and return its secret", func(t *testing.T) {
+		useCase, tokenRepo, zoneRepo, _ := newTestUseCase(t)
+		zoneRepo.EXPECT().FindByID(mock.Anything, "zone-1").Return(&entities.Zone{ID: "zone-1"}, nil).Once()
+		tokenRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.ResearchToken")).Return(nil).Once()
+
+		token, secret, err := useCase.CreateToken(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, entities.HashResearchToken(secret), token.TokenHash)
+		assert.Equal(t, testNow, token.CreatedAt)
+	})
+
+	t.Run("should reject unknown zones", func(t *testing.T) {
+		useCase, _, zoneRepo, _ := newTestUseCase(t)
+		zoneRepo.EXPECT().FindByID(mock.Anything, "zone-1").Return(nil, domainerrors.ErrZoneNotFound).Once()
+
+		_, _, err := useCase.CreateToken(context.Background(), request)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidResearchToken)
+	})
+
+	t.Run("should reject tokens outliving the maximum TTL", func(t *testing.T) {
+		useCase, _, _, _ := newTestUseCase(t)
+		tooLong := request
+		tooLong.ExpiresAt = testNow.Add(2 * 365 * 24 * time.Hour)
+
+		_, _, err := useCase.CreateToken(context.Background(), tooLong)
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidResearchToken)
+	})
+}
+
+func TestResearchSharingUseCase_RevokeToken(t *testing.T) {
+	useCase, tokenRepo, _, _ := newTestUseCase(t)
+	token := &entities.ResearchToken{ID: "token-1", ExpiresAt: testNow.Add(time.Hour)}
+	tokenRepo.EXPECT().FindByID(mock.Anything, "token-1").Return(token, nil).Twice()
+	tokenRepo.EXPECT().Update(mock.Anything, token).Return(nil).Once()
+
+	revoked, err := useCase.RevokeToken(context.Background(), "token-1")
+	require.NoError(t, err)
+	assert.True(t, revoked.Revoked())
+
+	_, err = useCase.RevokeToken(context.Background(), "token-1")
+	assert.ErrorIs(t, err, domainerrors.ErrResearchTokenRevoked)
+}
+
+func TestResearchSharingUseCase_Authenticate(t *testing.T) {
+	secret := entities.ResearchTokenPrefix + "abc"
+	revokedAt := testNow.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		token   *entities.ResearchToken
+		findErr error
+		wantErr error
+	}{
+		{name: "valid", token: &entities.ResearchToken{ID: "token-1", ExpiresAt: testNow.Add(time.Hour)}},
+		{name: "unknown", findErr: domainerrors.ErrResearchTokenNotFound, wantErr: domainerrors.ErrInvalidToken},
+		{name: "revoked", token: &entities.ResearchToken{ID: "token-1", ExpiresAt: testNow.Add(time.Hour), RevokedAt: &revokedAt}, wantErr: domainerrors.ErrInvalidToken},
+		{name: "expired", token: &entities.ResearchToken{ID: "token-1", ExpiresAt: testNow}, wantErr: domainerrors.ErrTokenExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase, tokenRepo, _, _ := newTestUseCase(t)
+			tokenRepo.EXPECT().FindByHash(mock.Anything, entities.HashResearchToken(secret)).Return(tt.token, tt.findErr).Once()
+
+			token, err := useCase.Authenticate(context.Background(), secret)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.token, token)
+		})
+	}
+
+	t.Run("should reject other tokens without a lookup", func(t *testing.T) {
+		useCase, _, _, _ := newTestUseCase(t)
+
+		_, err := useCase.Authenticate(context.Background(), "eyJhbGciOi")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidToken)
+	})
+}
+
+func TestResearchSharingUseCase_Telemetry(t *testing.T) {
+	token := &entities.ResearchToken{ID: "token-1", Zones: []string{"zone-1"}, ExpiresAt: testNow.Add(time.Hour)}
+	from := testNow.Add(-2 * time.Hour)
+
+	t.Run("should anonymize the readings of the zone devices and account them", func(t *testing.T) {
+		useCase, tokenRepo, zoneRepo, measurementRepo := newTestUseCase(t)
+		zoneRepo.EXPECT().DeviceMACAddresses(mock.Anything, "zone-1").Return([]string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"}, nil).Once()
+		measurementRepo.EXPECT().History(mock.Anything, "AA:BB:CC:DD:EE:01", "soil_moisture", from, testNow).Return([]*entities.Measurement{
+			{MACAddress: "AA:BB:CC:DD:EE:01", SensorType: "soil_moisture", Value: 31, Timestamp: testNow.Add(-time.Minute)},
+		}, nil).Once()
+		measurementRepo.EXPECT().History(mock.Anything, "AA:BB:CC:DD:EE:02", "soil_moisture", from, testNow).Return([]*entities.Measurement{
+			{MACAddress: "AA:BB:CC:DD:EE:02", SensorType: "soil_moisture", Value: 28, Timestamp: testNow.Add(-time.Hour)},
+		}, nil).Once()
+		tokenRepo.EXPECT().RecordUsage(mock.Anything, "token-1", testNow, 2).Return(nil).Once()
+
+		readings, err := useCase.Telemetry(context.Background(), token, "zone-1", "soil_moisture", from, time.Time{})
+		require.NoError(t, err)
+		require.Len(t, readings, 2)
+		assert.Equal(t, 28.0, readings[0].Value)
+		assert.Equal(t, token.Pseudonym("AA:BB:CC:DD:EE:02"), readings[0].Device)
+		assert.NotContains(t, readings[1].Device, "AA:BB")
+	})
+
+	t.Run("should still serve the readings when accounting fails", func(t *testing.T) {
+		useCase, tokenRepo, zoneRepo, _ := newTestUseCase(t)
+		zoneRepo.EXPECT().DeviceMACAddresses(mock.Anything, "zone-1").Return(nil, nil).Once()
+		tokenRepo.EXPECT().RecordUsage(mock.Anything, "token-1", testNow, 0).Return(errors.New("connection refused")).Once()
+
+		readings, err := useCase.Telemetry(context.Background(), token, "zone-1", "soil_moisture", from, time.Time{})
+		require.NoError(t, err)
+		assert.Empty(t, readings)
+	})
+
+	t.Run("should hide zones outside the token", func(t *testing.T) {
+		useCase, _, _, _ := newTestUseCase(t)
+
+		_, err := useCase.Telemetry(context.Background(), token, "zone-2", "soil_moisture", from, time.Time{})
+		assert.ErrorIs(t, err, domainerrors.ErrZoneNotFound)
+	})
+
+	t.Run("should reject invalid ranges", func(t *testing.T) {
+		useCase, _, _, _ := newTestUseCase(t)
+
+		_, err := useCase.Telemetry(context.Background(), token, "zone-1", "soil_moisture", testNow.Add(-60*24*time.Hour), time.Time{})
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidReadingsQuery)
+
+		_, err = useCase.Telemetry(context.Background(), token, "zone-1", "", from, time.Time{})
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidReadingsQuery)
+	})
+}
@@ -0,0 +1,153 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// RetentionConfig holds configuration for pruning old data
+type RetentionConfig struct {
+	ReadingsMaxAge time.Duration // readings older than this are deleted
+	Interval       time.Duration // how often pruning runs
+	BatchSize      int           // rows deleted per statement, keeping each transaction short
+}
+
+// DefaultRetentionConfig returns default configuration
+func DefaultRetentionConfig() *RetentionConfig {
+	return &RetentionConfig{
+		ReadingsMaxAge: 365 * 24 * time.Hour,
+		Interval:       time.Hour,
+		BatchSize:      10000,
+	}
+}
+
+// RetentionUseCase deletes the sensor readings that outlived the retention policy, raw and compacted
+type RetentionUseCase interface {
+	// PruneOnce deletes the readings older than ReadingsMaxAge and returns the rows removed by dataset.
+	// A dataset failing to prune is logged and skipped so it does not block the others.
+	PruneOnce(ctx context.Context) (map[string]int, error)
+
+	// Run prunes periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// useCaseImpl implements the RetentionUseCase interface
+type useCaseImpl struct {
+	retentionRepo repositoryports.RetentionRepository
+	config        *RetentionConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+	pruned        *metrics.Vec
+	failures      *metrics.Vec
+}
+
+// NewRetentionUseCase creates a new retention use case
+func NewRetentionUseCase(
+	retentionRepo repositoryports.RetentionRepository,
+	config *RetentionConfig,
+	loggerFactory logger.LoggerFactory,
+) RetentionUseCase {
+	if config == nil {
+		config = DefaultRetentionConfig()
+	}
+
+	return &useCaseImpl{
+		retentionRepo: retentionRepo,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		pruned:        metrics.NewCounterVec("retention_rows_pruned_total", "Rows deleted by the retention policy", "dataset"),
+		failures:      metrics.NewCounterVec("retention_failures_total", "Retention runs that failed to prune a dataset", "dataset"),
+	}
+}
+
+// PruneOnce deletes each dataset batch by batch until a batch comes back short
+func (uc *useCaseImpl) PruneOnce(ctx context.Context) (map[string]int, error) {
+	cutoff := uc.now().Add(-uc.config.ReadingsMaxAge)
+	start := time.Now()
+
+	pruned := make(map[string]int, len(entities.RetentionDatasets))
+	total := 0
+	for _, dataset := range entities.RetentionDatasets {
+		for {
+			if err := ctx.Err(); err != nil {
+				return pruned, err
+			}
+			rows, err := uc.retentionRepo.Prune(ctx, dataset, cutoff, uc.config.BatchSize)
+			if err != nil {
+				uc.failures.Inc(dataset)
+				uc.loggerFactory.Core().Warn("retention_dataset_skipped",
+					zap.Error(err),
+					zap.String("dataset", dataset),
+					zap.String("component", "retention_usecase"),
+				)
+				break
+			}
+			pruned[dataset] += rows
+			total += rows
+			uc.pruned.Add(float64(rows), dataset)
+			if rows < uc.config.BatchSize {
+				break
+			}
+		}
+	}
+
+	if total > 0 {
+		uc.loggerFactory.Core().Info("retention_prune_completed",
+			zap.Int("temperature_humidity", pruned[entities.RetentionTemperatureHumidity]),
+			zap.Int("measurements", pruned[entities.RetentionMeasurements]),
+			zap.Int("telemetry_archive", pruned[entities.RetentionTelemetryArchive]),
+			zap.Time("cutoff", cutoff),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("component", "retention_usecase"),
+		)
+	}
+	return pruned, nil
+}
+
+// Run prunes periodically until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("retention_started", "retention_usecase",
+		zap.Duration("readings_max_age", uc.config.ReadingsMaxAge),
+		zap.Duration("interval", uc.config.Interval),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := uc.PruneOnce(ctx); err != nil && ctx.Err() == nil {
+			uc.loggerFactory.Core().Error("retention_prune_failed",
+				zap.Error(err),
+				zap.String("component", "retention_usecase"),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("retention_stopped", "retention_usecase")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return append(uc.pruned.Collect(), uc.failures.Collect()...)
+}
+
+// MetricsCollector returns the retention metrics collector
+func MetricsCollector(useCase RetentionUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
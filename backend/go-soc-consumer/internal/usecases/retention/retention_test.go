@@ -0,0 +1,60 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// createTestLoggerFactory creates a test logger factory for use in tests
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopment()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func TestRetentionUseCase_PruneOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 7, 31, 10, 0, 0, 0, time.UTC)
+	config := &RetentionConfig{ReadingsMaxAge: 90 * 24 * time.Hour, Interval: time.Hour, BatchSize: 100}
+	cutoff := now.Add(-config.ReadingsMaxAge)
+
+	t.Run("should prune in batches and skip failing datasets", func(t *testing.T) {
+		retentionRepo := mocks.NewMockRetentionRepository(t)
+		useCase := NewRetentionUseCase(retentionRepo, config, createTestLoggerFactory(t)).(*useCaseImpl)
+		useCase.now = func() time.Time { return now }
+
+		retentionRepo.EXPECT().Prune(ctx, entities.RetentionTemperatureHumidity, cutoff, 100).Return(100, nil).Once()
+		retentionRepo.EXPECT().Prune(ctx, entities.RetentionTemperatureHumidity, cutoff, 100).Return(40, nil).Once()
+		retentionRepo.EXPECT().Prune(ctx, entities.RetentionMeasurements, cutoff, 100).Return(0, errors.New("lock timeout")).Once()
+		retentionRepo.EXPECT().Prune(ctx, entities.RetentionTelemetryArchive, cutoff, 100).Return(3, nil).Once()
+
+		pruned, err := useCase.PruneOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{
+			entities.RetentionTemperatureHumidity: 140,
+			entities.RetentionTelemetryArchive:    3,
+		}, pruned)
+		assert.Equal(t, 140.0, useCase.pruned.Value(entities.RetentionTemperatureHumidity))
+		assert.Equal(t, 1.0, useCase.failures.Value(entities.RetentionMeasurements))
+	})
+
+	t.Run("should stop when the context is cancelled", func(t *testing.T) {
+		retentionRepo := mocks.NewMockRetentionRepository(t)
+		useCase := NewRetentionUseCase(retentionRepo, config, createTestLoggerFactory(t))
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := useCase.PruneOnce(cancelled)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
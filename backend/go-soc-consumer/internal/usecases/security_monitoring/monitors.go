@@ -0,0 +1,50 @@
+package securitymonitoring
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	deviceregistration "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_registration"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// monitoredDeviceRegistrationUseCase reports every registration attempt to the security monitor
+type monitoredDeviceRegistrationUseCase struct {
+	deviceregistration.DeviceRegistrationUseCase
+	monitor SecurityMonitorUseCase
+}
+
+// NewMonitoredDeviceRegistrationUseCase wraps a registration use case with anomaly detection
+func NewMonitoredDeviceRegistrationUseCase(inner deviceregistration.DeviceRegistrationUseCase, monitor SecurityMonitorUseCase) deviceregistration.DeviceRegistrationUseCase {
+	return &monitoredDeviceRegistrationUseCase{DeviceRegistrationUseCase: inner, monitor: monitor}
+}
+
+// RegisterDevice observes the registration, including rejected ones, before registering.
+// Replayed registrations were observed when first received.
+func (uc *monitoredDeviceRegistrationUseCase) RegisterDevice(ctx context.Context, message *entities.DeviceRegistrationMessage) error {
+	if !eventports.IsReplay(ctx) {
+		uc.monitor.ObserveRegistration(ctx, message)
+	}
+	return uc.DeviceRegistrationUseCase.RegisterDevice(ctx, message)
+}
+
+// monitoredSensorDataUseCase reports every reading to the security monitor
+type monitoredSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	monitor SecurityMonitorUseCase
+}
+
+// NewMonitoredSensorDataUseCase wraps a sensor data use case with anomaly detection
+func NewMonitoredSensorDataUseCase(inner sensordata.SensorDataUseCase, monitor SecurityMonitorUseCase) sensordata.SensorDataUseCase {
+	return &monitoredSensorDataUseCase{SensorDataUseCase: inner, monitor: monitor}
+}
+
+// StoreSensorData observes the reading, including rejected ones, before storing it.
+// Replayed readings were observed when first received.
+func (uc *monitoredSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if !eventports.IsReplay(ctx) {
+		uc.monitor.ObserveSensorData(ctx, data)
+	}
+	return uc.SensorDataUseCase.StoreSensorData(ctx, data)
+}
@@ -0,0 +1,445 @@
+package securitymonitoring
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/lru"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+const (
+	// baselineWeight is the weight of the latest window in a device's telemetry baseline
+	baselineWeight = 0.2
+	// profileIdleTTL is how long the telemetry profile of a silent device is kept
+	profileIdleTTL = 24 * time.Hour
+	// commandAlertCooldown limits repeated alerts for the same untrusted publisher
+	commandAlertCooldown = time.Minute
+	// cloneAlertCooldown limits repeated alerts for the same suspected clone
+	cloneAlertCooldown = 10 * time.Minute
+	// maxMACsPerIPTracked bounds the MAC addresses remembered for one IP address; the least recently
+	// registered are forgotten first, which never hides a fan-in since the limit is far above MaxMACsPerIP
+	maxMACsPerIPTracked = 256
+)
+
+// MonitorConfig holds the thresholds used to detect suspicious traffic
+type MonitorConfig struct {
+	RegistrationWindow    time.Duration // window in which registrations from one IP are counted
+	MaxMACsPerIP          int           // distinct MAC addresses allowed from one IP within the window
+	TrustedCommandClients []string      // client IDs allowed to publish on command topics
+	TelemetryWindow       time.Duration // window in which readings of a device are counted
+	TelemetryBurstFactor  int           // readings above this multiple of the device baseline are a burst
+	TelemetryBurstMinimum int           // readings in a window below this never count as a burst
+	AlertHistory          int           // number of recent alerts kept in memory
+	MaxTracked            int           // IP addresses, devices and alert cooldowns each remembered; the least recently seen are forgotten
+}
+
+// DefaultMonitorConfig returns default configuration
+func DefaultMonitorConfig() *MonitorConfig {
+	return &MonitorConfig{
+		RegistrationWindow:    10 * time.Minute,
+		MaxMACsPerIP:          5,
+		TelemetryWindow:       time.Minute,
+		TelemetryBurstFactor:  5,
+		TelemetryBurstMinimum: 30,
+		AlertHistory:          100,
+		MaxTracked:            50000,
+	}
+}
+
+// SecurityMonitorUseCase detects suspicious patterns in device traffic and raises security alerts
+type SecurityMonitorUseCase interface {
+	// ObserveRegistration raises an alert when too many MAC addresses register from one IP address
+	ObserveRegistration(ctx context.Context, message *entities.DeviceRegistrationMessage)
+
+	// ObserveSensorData raises an alert when a device sends far more readings than its baseline
+	ObserveSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity)
+
+	// HandleCommandMessage handles messages on command topics and raises an alert when the publisher is not trusted.
+	// Messages without a publisher identity cannot be attributed and are ignored.
+	HandleCommandMessage(ctx context.Context, topic string, payload []byte) error
+
+	// ObserveConnectionMismatch raises an alert when several client IDs publish messages of one device
+	ObserveConnectionMismatch(ctx context.Context, connection *entities.DeviceConnection)
+
+	// RecentAlerts returns up to limit of the most recent alerts, newest first
+	RecentAlerts(limit int) []*entities.SecurityAlert
+}
+
+// telemetryProfile tracks the reading rate of a device
+type telemetryProfile struct {
+	windowStart time.Time
+	count       int
+	baseline    float64 // average readings per window, 0 until the first window completes
+	alerted     bool
+}
+
+// useCaseImpl implements the SecurityMonitorUseCase interface
+type useCaseImpl struct {
+	config         *MonitorConfig
+	trustedClients map[string]bool
+	eventPublisher eventports.EventPublisher
+	loggerFactory  logger.LoggerFactory
+	now            func() time.Time
+
+	mu            sync.Mutex
+	registrations *lru.Cache[string, map[string]time.Time] // IP address to MAC addresses and when they last registered
+	telemetry     *lru.Cache[string, *telemetryProfile]
+	lastAlerted   *lru.Cache[string, time.Time]
+	lastSweep     time.Time
+	alerts        []*entities.SecurityAlert
+
+	alertsTotal *metrics.Vec
+}
+
+// NewSecurityMonitorUseCase creates a new security monitor; eventPublisher is optional
+func NewSecurityMonitorUseCase(
+	config *MonitorConfig,
+	eventPublisher eventports.EventPublisher,
+	loggerFactory logger.LoggerFactory,
+) SecurityMonitorUseCase {
+	if config == nil {
+		config = DefaultMonitorConfig()
+	}
+
+	trustedClients := make(map[string]bool, len(config.TrustedCommandClients))
+	for _, clientID := range config.TrustedCommandClients {
+		trustedClients[clientID] = true
+	}
+
+	return &useCaseImpl{
+		config:         config,
+		trustedClients: trustedClients,
+		eventPublisher: eventPublisher,
+		loggerFactory:  loggerFactory,
+		now:            time.Now,
+		registrations:  lru.New[string, map[string]time.Time](config.MaxTracked),
+		telemetry:      lru.New[string, *telemetryProfile](config.MaxTracked),
+		lastAlerted:    lru.New[string, time.Time](config.MaxTracked),
+		alertsTotal:    metrics.NewCounterVec("security_alerts_total", "Security alerts raised by anomaly detection", "kind"),
+	}
+}
+
+// ObserveRegistration counts the distinct MAC addresses registering from the publisher's IP address.
+// The address reported by the broker is preferred over the one in the payload, which the publisher controls.
+func (uc *useCaseImpl) ObserveRegistration(ctx context.Context, message *entities.DeviceRegistrationMessage) {
+	if message == nil {
+		return
+	}
+
+	identity := eventports.ClientIdentityFromContext(ctx)
+	ipAddress, ipSource := message.IPAddress, "payload"
+	if identity != nil && identity.RemoteAddress != "" {
+		ipAddress, ipSource = identity.RemoteAddress, "broker"
+	}
+	if ipAddress == "" {
+		return
+	}
+
+	uc.mu.Lock()
+	now := uc.now()
+	uc.sweepLocked(now)
+
+	macs, ok := uc.registrations.Get(ipAddress)
+	if !ok {
+		macs = make(map[string]time.Time)
+		uc.registrations.Put(ipAddress, macs)
+	}
+	for mac, seen := range macs {
+		if now.Sub(seen) > uc.config.RegistrationWindow {
+			delete(macs, mac)
+		}
+	}
+	macs[message.MACAddress] = now
+	if len(macs) > maxMACsPerIPTracked {
+		forgetOldest(macs)
+	}
+
+	if len(macs) <= uc.config.MaxMACsPerIP || !uc.shouldAlertLocked(entities.SecurityAlertRegistrationFanIn, ipAddress, now, uc.config.RegistrationWindow) {
+		uc.mu.Unlock()
+		return
+	}
+	macAddresses := make([]string, 0, len(macs))
+	for mac := range macs {
+		macAddresses = append(macAddresses, mac)
+	}
+	uc.mu.Unlock()
+
+	sort.Strings(macAddresses)
+	details := identityContext(identity)
+	details["ip_address"] = ipAddress
+	details["ip_source"] = ipSource
+	details["mac_addresses"] = macAddresses
+	details["window"] = uc.config.RegistrationWindow.String()
+	uc.raise(ctx, entities.SecurityAlertRegistrationFanIn, entities.SecuritySeverityWarning, ipAddress, details,
+		"%d MAC addresses registered from %s within %s", len(macAddresses), ipAddress, uc.config.RegistrationWindow,
+	)
+}
+
+// ObserveSensorData counts the readings of the device in the current window and compares them with its baseline
+func (uc *useCaseImpl) ObserveSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) {
+	if data == nil {
+		return
+	}
+
+	macAddress := data.MacAddress()
+
+	uc.mu.Lock()
+	now := uc.now()
+	uc.sweepLocked(now)
+
+	profile, ok := uc.telemetry.Get(macAddress)
+	if !ok {
+		profile = &telemetryProfile{windowStart: now}
+		uc.telemetry.Put(macAddress, profile)
+	}
+	if now.Sub(profile.windowStart) >= uc.config.TelemetryWindow {
+		// Bursts are left out of the baseline so an attacker cannot raise it
+		if !profile.alerted {
+			if profile.baseline == 0 {
+				profile.baseline = float64(profile.count)
+			} else {
+				profile.baseline = (1-baselineWeight)*profile.baseline + baselineWeight*float64(profile.count)
+			}
+		}
+		profile.windowStart, profile.count, profile.alerted = now, 0, false
+	}
+	profile.count++
+
+	threshold := math.Max(float64(uc.config.TelemetryBurstMinimum), float64(uc.config.TelemetryBurstFactor)*profile.baseline)
+	if profile.alerted || float64(profile.count) < threshold {
+		uc.mu.Unlock()
+		return
+	}
+	profile.alerted = true
+	count, baseline := profile.count, profile.baseline
+	uc.mu.Unlock()
+
+	details := identityContext(eventports.ClientIdentityFromContext(ctx))
+	details["mac_address"] = macAddress
+	details["readings_in_window"] = count
+	details["baseline_per_window"] = math.Round(baseline*100) / 100
+	details["threshold"] = int(math.Ceil(threshold))
+	details["window"] = uc.config.TelemetryWindow.String()
+	uc.raise(ctx, entities.SecurityAlertTelemetryBurst, entities.SecuritySeverityWarning, macAddress, details,
+		"device %s sent %d readings within %s, baseline is %.1f", macAddress, count, uc.config.TelemetryWindow, baseline,
+	)
+}
+
+// HandleCommandMessage raises an alert when a command topic is published by a client that is not trusted
+func (uc *useCaseImpl) HandleCommandMessage(ctx context.Context, topic string, payload []byte) error {
+	identity := eventports.ClientIdentityFromContext(ctx)
+	if identity == nil || uc.trustedClients[identity.ClientID] {
+		return nil
+	}
+
+	uc.mu.Lock()
+	now := uc.now()
+	uc.sweepLocked(now)
+	alert := uc.shouldAlertLocked(entities.SecurityAlertUntrustedCommandPublisher, identity.ClientID, now, commandAlertCooldown)
+	uc.mu.Unlock()
+	if !alert {
+		return nil
+	}
+
+	details := identityContext(identity)
+	details["topic"] = topic
+	details["payload_size_bytes"] = len(payload)
+	uc.raise(ctx, entities.SecurityAlertUntrustedCommandPublisher, entities.SecuritySeverityCritical, identity.ClientID, details,
+		"client %q published on command topic %s", identity.ClientID, topic,
+	)
+	return nil
+}
+
+// ObserveConnectionMismatch raises an alert for a device published by several clients
+func (uc *useCaseImpl) ObserveConnectionMismatch(ctx context.Context, connection *entities.DeviceConnection) {
+	if connection == nil || !connection.Mismatch() {
+		return
+	}
+
+	uc.mu.Lock()
+	now := uc.now()
+	uc.sweepLocked(now)
+	alert := uc.shouldAlertLocked(entities.SecurityAlertDeviceCloneSuspected, connection.MACAddress, now, cloneAlertCooldown)
+	uc.mu.Unlock()
+	if !alert {
+		return
+	}
+
+	clientIDs := make([]string, 0, len(connection.Clients))
+	remoteAddresses := make([]string, 0, len(connection.Clients))
+	for _, client := range connection.Clients {
+		clientIDs = append(clientIDs, client.ClientID)
+		if client.RemoteAddress != "" {
+			remoteAddresses = append(remoteAddresses, client.RemoteAddress)
+		}
+	}
+	details := identityContext(eventports.ClientIdentityFromContext(ctx))
+	details["mac_address"] = connection.MACAddress
+	details["client_ids"] = clientIDs
+	details["remote_addresses"] = remoteAddresses
+	uc.raise(ctx, entities.SecurityAlertDeviceCloneSuspected, entities.SecuritySeverityCritical, connection.MACAddress, details,
+		"%d clients published as device %s", len(clientIDs), connection.MACAddress,
+	)
+}
+
+// RecentAlerts returns up to limit of the most recent alerts, newest first
+func (uc *useCaseImpl) RecentAlerts(limit int) []*entities.SecurityAlert {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if limit <= 0 || limit > len(uc.alerts) {
+		limit = len(uc.alerts)
+	}
+	alerts := make([]*entities.SecurityAlert, 0, limit)
+	for i := len(uc.alerts) - 1; i >= 0 && len(alerts) < limit; i-- {
+		alerts = append(alerts, uc.alerts[i])
+	}
+	return alerts
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	tracked := metrics.Family{Name: "security_monitor_tracked", Help: "Entries remembered by the security monitor by kind", Type: metrics.TypeGauge}
+	evictions := metrics.Family{Name: "security_monitor_evictions_total", Help: "Entries forgotten by the security monitor to stay within its size limit by kind", Type: metrics.TypeCounter}
+	uc.mu.Lock()
+	for _, cache := range []struct {
+		kind      string
+		len       int
+		evictions uint64
+	}{
+		{"registration_ips", uc.registrations.Len(), uc.registrations.Evictions()},
+		{"telemetry_devices", uc.telemetry.Len(), uc.telemetry.Evictions()},
+		{"alert_cooldowns", uc.lastAlerted.Len(), uc.lastAlerted.Evictions()},
+	} {
+		labels := map[string]string{"kind": cache.kind}
+		tracked.Samples = append(tracked.Samples, metrics.Sample{Labels: labels, Value: float64(cache.len)})
+		evictions.Samples = append(evictions.Samples, metrics.Sample{Labels: labels, Value: float64(cache.evictions)})
+	}
+	uc.mu.Unlock()
+
+	return append(uc.alertsTotal.Collect(), tracked, evictions)
+}
+
+// MetricsCollector returns the security monitoring metrics collector
+func MetricsCollector(useCase SecurityMonitorUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
+
+// raise records, logs and publishes an alert; the description format is one of the i18n catalog messages
+func (uc *useCaseImpl) raise(ctx context.Context, kind, severity, subject string, details map[string]interface{}, format string, args ...interface{}) {
+	description := fmt.Sprintf(format, args...)
+	alert, err := entities.NewSecurityAlert(kind, severity, subject, description, details)
+	if err != nil {
+		uc.loggerFactory.Core().Error("failed_to_create_security_alert",
+			zap.Error(err),
+			zap.String("kind", kind),
+			zap.String("component", "security_monitoring_usecase"),
+		)
+		return
+	}
+	alert.LocalizedDescriptions = i18n.SprintfAll(format, args...)
+
+	uc.mu.Lock()
+	uc.alerts = append(uc.alerts, alert)
+	if len(uc.alerts) > uc.config.AlertHistory {
+		uc.alerts = uc.alerts[len(uc.alerts)-uc.config.AlertHistory:]
+	}
+	uc.mu.Unlock()
+
+	uc.alertsTotal.Inc(kind)
+	uc.loggerFactory.Core().Warn("security_alert",
+		zap.String("event_id", alert.EventID),
+		zap.String("kind", kind),
+		zap.String("severity", severity),
+		zap.String("subject", subject),
+		zap.String("description", description),
+		zap.Any("context", details),
+		zap.String("component", "security_monitoring_usecase"),
+	)
+
+	if uc.eventPublisher == nil || !uc.eventPublisher.IsConnected() {
+		return
+	}
+	if err := uc.eventPublisher.Publish(ctx, alert.GetSubject(), alert); err != nil {
+		uc.loggerFactory.Messaging().LogEventPublishing("security_alert", alert.GetSubject(), alert.EventID, false, err)
+		return
+	}
+	uc.loggerFactory.Messaging().LogEventPublishing("security_alert", alert.GetSubject(), alert.EventID, true, nil)
+}
+
+// shouldAlertLocked reports whether an alert for the subject is due and, if so, starts its cooldown
+func (uc *useCaseImpl) shouldAlertLocked(kind, subject string, now time.Time, cooldown time.Duration) bool {
+	key := kind + "/" + subject
+	if last, ok := uc.lastAlerted.Get(key); ok && now.Sub(last) < cooldown {
+		return false
+	}
+	uc.lastAlerted.Put(key, now)
+	return true
+}
+
+// sweepLocked forgets state that can no longer trigger an alert so memory stays bounded
+func (uc *useCaseImpl) sweepLocked(now time.Time) {
+	if now.Sub(uc.lastSweep) < uc.config.RegistrationWindow {
+		return
+	}
+	uc.lastSweep = now
+
+	uc.registrations.DeleteFunc(func(_ string, macs map[string]time.Time) bool {
+		for mac, seen := range macs {
+			if now.Sub(seen) > uc.config.RegistrationWindow {
+				delete(macs, mac)
+			}
+		}
+		return len(macs) == 0
+	})
+	uc.telemetry.DeleteFunc(func(_ string, profile *telemetryProfile) bool {
+		return now.Sub(profile.windowStart) > profileIdleTTL
+	})
+	uc.lastAlerted.DeleteFunc(func(_ string, last time.Time) bool {
+		return now.Sub(last) > uc.config.RegistrationWindow && now.Sub(last) > uc.config.TelemetryWindow && now.Sub(last) > cloneAlertCooldown
+	})
+}
+
+// forgetOldest removes the MAC address that registered least recently
+func forgetOldest(macs map[string]time.Time) {
+	oldestMAC, oldest := "", time.Time{}
+	for mac, seen := range macs {
+		if oldestMAC == "" || seen.Before(oldest) {
+			oldestMAC, oldest = mac, seen
+		}
+	}
+	delete(macs, oldestMAC)
+}
+
+// identityContext returns the publisher identity as alert context
+func identityContext(identity *entities.ClientIdentity) map[string]interface{} {
+	details := make(map[string]interface{})
+	if identity == nil {
+		return details
+	}
+	details["client_id"] = identity.ClientID
+	if identity.Username != "" {
+		details["username"] = identity.Username
+	}
+	if identity.RemoteAddress != "" {
+		details["remote_address"] = identity.RemoteAddress
+	}
+	if identity.CertificateFingerprint != "" {
+		details["certificate_fingerprint"] = identity.CertificateFingerprint
+	}
+	return details
+}
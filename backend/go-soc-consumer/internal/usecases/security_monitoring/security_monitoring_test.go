@@ -0,0 +1,277 @@
+package securitymonitoring
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/events"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+// newTestMonitor returns a monitor whose clock is advanced by the returned function
+func newTestMonitor(t *testing.T, config *MonitorConfig, publisher eventports.EventPublisher) (*useCaseImpl, func(time.Duration)) {
+	useCase := NewSecurityMonitorUseCase(config, publisher, createTestLoggerFactory(t)).(*useCaseImpl)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase.now = func() time.Time { return now }
+	return useCase, func(d time.Duration) { now = now.Add(d) }
+}
+
+func registration(t *testing.T, mac, ip string) *entities.DeviceRegistrationMessage {
+	message, err := entities.NewDeviceRegistrationMessage(mac, "Sensor", ip, "Greenhouse")
+	require.NoError(t, err)
+	return message
+}
+
+func reading(t *testing.T, mac string) *entities.SensorTemperatureHumidity {
+	data, err := entities.NewSensorTemperatureHumidity(mac, 22.5, 60)
+	require.NoError(t, err)
+	return data
+}
+
+func TestSecurityMonitor_ObserveRegistration(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.MaxMACsPerIP = 2
+
+	t.Run("should alert once when too many MAC addresses register from one IP", func(t *testing.T) {
+		useCase, _ := newTestMonitor(t, config, nil)
+		ctx := context.Background()
+
+		for i := 1; i <= 4; i++ {
+			useCase.ObserveRegistration(ctx, registration(t, fmt.Sprintf("AA:BB:CC:DD:EE:0%d", i), "192.168.1.50"))
+		}
+
+		alerts := useCase.RecentAlerts(0)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, entities.SecurityAlertRegistrationFanIn, alerts[0].Kind)
+		assert.Equal(t, "192.168.1.50", alerts[0].Subject)
+		assert.Equal(t, "payload", alerts[0].Context["ip_source"])
+		assert.Len(t, alerts[0].Context["mac_addresses"], 3)
+		assert.Equal(t, "3 MAC addresses registered from 192.168.1.50 within 10m0s", alerts[0].Description)
+		assert.Equal(t, "3 direcciones MAC se registraron desde 192.168.1.50 en 10m0s", alerts[0].LocalizedDescriptions["es"])
+	})
+
+	t.Run("should prefer the address reported by the broker", func(t *testing.T) {
+		useCase, _ := newTestMonitor(t, config, nil)
+		ctx := eventports.ContextWithClientIdentity(context.Background(), &entities.ClientIdentity{ClientID: "spoofer", RemoteAddress: "10.0.0.9"})
+
+		for i := 1; i <= 3; i++ {
+			useCase.ObserveRegistration(ctx, registration(t, fmt.Sprintf("AA:BB:CC:DD:EE:0%d", i), fmt.Sprintf("192.168.1.%d", i)))
+		}
+
+		alerts := useCase.RecentAlerts(0)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "10.0.0.9", alerts[0].Subject)
+		assert.Equal(t, "broker", alerts[0].Context["ip_source"])
+		assert.Equal(t, "spoofer", alerts[0].Context["client_id"])
+	})
+
+	t.Run("should forget registrations outside the window", func(t *testing.T) {
+		useCase, advance := newTestMonitor(t, config, nil)
+		ctx := context.Background()
+
+		for i := 1; i <= 3; i++ {
+			useCase.ObserveRegistration(ctx, registration(t, fmt.Sprintf("AA:BB:CC:DD:EE:0%d", i), "192.168.1.50"))
+			advance(config.RegistrationWindow)
+		}
+
+		assert.Empty(t, useCase.RecentAlerts(0))
+	})
+}
+
+func TestSecurityMonitor_ObserveSensorData(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.TelemetryBurstFactor = 3
+	config.TelemetryBurstMinimum = 5
+	const mac = "AA:BB:CC:DD:EE:FF"
+
+	t.Run("should alert when readings exceed the baseline", func(t *testing.T) {
+		useCase, advance := newTestMonitor(t, config, nil)
+		ctx := context.Background()
+
+		// Establish a baseline of 4 readings per window
+		for window := 0; window < 3; window++ {
+			for i := 0; i < 4; i++ {
+				useCase.ObserveSensorData(ctx, reading(t, mac))
+			}
+			advance(config.TelemetryWindow)
+		}
+		assert.Empty(t, useCase.RecentAlerts(0))
+
+		for i := 0; i < 20; i++ {
+			useCase.ObserveSensorData(ctx, reading(t, mac))
+		}
+
+		alerts := useCase.RecentAlerts(0)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, entities.SecurityAlertTelemetryBurst, alerts[0].Kind)
+		assert.Equal(t, mac, alerts[0].Subject)
+		assert.Equal(t, 12, alerts[0].Context["readings_in_window"])
+		assert.Equal(t, 12, alerts[0].Context["threshold"])
+	})
+
+	t.Run("should not learn bursts into the baseline", func(t *testing.T) {
+		useCase, advance := newTestMonitor(t, config, nil)
+		ctx := context.Background()
+
+		for i := 0; i < 4; i++ {
+			useCase.ObserveSensorData(ctx, reading(t, mac))
+		}
+		advance(config.TelemetryWindow)
+		for window := 0; window < 2; window++ {
+			for i := 0; i < 12; i++ {
+				useCase.ObserveSensorData(ctx, reading(t, mac))
+			}
+			advance(config.TelemetryWindow)
+		}
+
+		assert.Len(t, useCase.RecentAlerts(0), 2)
+		profile, ok := useCase.telemetry.Get(mac)
+		require.True(t, ok)
+		assert.Equal(t, 4.0, profile.baseline)
+	})
+
+	t.Run("should not alert below the minimum", func(t *testing.T) {
+		useCase, _ := newTestMonitor(t, config, nil)
+
+		for i := 0; i < config.TelemetryBurstMinimum-1; i++ {
+			useCase.ObserveSensorData(context.Background(), reading(t, mac))
+		}
+
+		assert.Empty(t, useCase.RecentAlerts(0))
+	})
+}
+
+func TestSecurityMonitor_HandleCommandMessage(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.TrustedCommandClients = []string{"iot-go-soc-consumer"}
+	const topic = "/liwaisi/iot/smart-irrigation/commands/AA:BB:CC:DD:EE:FF"
+
+	tests := []struct {
+		name        string
+		identity    *entities.ClientIdentity
+		expectAlert bool
+	}{
+		{name: "trusted publisher", identity: &entities.ClientIdentity{ClientID: "iot-go-soc-consumer"}},
+		{name: "unattributed publisher", identity: nil},
+		{name: "untrusted publisher", identity: &entities.ClientIdentity{ClientID: "esp32-7", RemoteAddress: "192.168.1.77"}, expectAlert: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase, _ := newTestMonitor(t, config, nil)
+			ctx := eventports.ContextWithClientIdentity(context.Background(), tt.identity)
+
+			require.NoError(t, useCase.HandleCommandMessage(ctx, topic, []byte(`{"command":"reboot"}`)))
+			require.NoError(t, useCase.HandleCommandMessage(ctx, topic, []byte(`{"command":"reboot"}`)))
+
+			alerts := useCase.RecentAlerts(0)
+			if !tt.expectAlert {
+				assert.Empty(t, alerts)
+				return
+			}
+			require.Len(t, alerts, 1, "repeated publishes within the cooldown raise one alert")
+			assert.Equal(t, entities.SecuritySeverityCritical, alerts[0].Severity)
+			assert.Equal(t, topic, alerts[0].Context["topic"])
+			assert.Equal(t, "192.168.1.77", alerts[0].Context["remote_address"])
+		})
+	}
+}
+
+func TestSecurityMonitor_Alerts(t *testing.T) {
+	t.Run("should publish alerts when the publisher is connected", func(t *testing.T) {
+		publisher := mocks.NewMockEventPublisher(t)
+		publisher.EXPECT().IsConnected().Return(true).Once()
+		publisher.EXPECT().Publish(mock.Anything, events.SecurityAlertSubject, mock.AnythingOfType("*entities.SecurityAlert")).Return(nil).Once()
+		useCase, _ := newTestMonitor(t, DefaultMonitorConfig(), publisher)
+
+		ctx := eventports.ContextWithClientIdentity(context.Background(), &entities.ClientIdentity{ClientID: "intruder"})
+		require.NoError(t, useCase.HandleCommandMessage(ctx, "/liwaisi/iot/smart-irrigation/commands/x", nil))
+	})
+
+	t.Run("should keep the most recent alerts newest first", func(t *testing.T) {
+		config := DefaultMonitorConfig()
+		config.AlertHistory = 2
+		useCase, _ := newTestMonitor(t, config, nil)
+
+		for i := 1; i <= 3; i++ {
+			ctx := eventports.ContextWithClientIdentity(context.Background(), &entities.ClientIdentity{ClientID: fmt.Sprintf("client-%d", i)})
+			require.NoError(t, useCase.HandleCommandMessage(ctx, "/liwaisi/iot/smart-irrigation/commands/x", nil))
+		}
+
+		alerts := useCase.RecentAlerts(0)
+		require.Len(t, alerts, 2)
+		assert.Equal(t, "client-3", alerts[0].Subject)
+		assert.Equal(t, "client-2", alerts[1].Subject)
+		assert.Len(t, useCase.RecentAlerts(1), 1)
+	})
+}
+
+func TestSecurityMonitor_MaxTracked(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.MaxTracked = 2
+	useCase, _ := newTestMonitor(t, config, nil)
+
+	for _, mac := range []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02", "AA:BB:CC:DD:EE:03"} {
+		useCase.ObserveSensorData(context.Background(), reading(t, mac))
+	}
+
+	assert.Equal(t, 2, useCase.telemetry.Len())
+	_, ok := useCase.telemetry.Get("AA:BB:CC:DD:EE:01")
+	assert.False(t, ok, "the least recently seen device was forgotten")
+	assert.Equal(t, uint64(1), useCase.telemetry.Evictions())
+}
+
+func TestSecurityMonitor_ObserveConnectionMismatch(t *testing.T) {
+	connection := &entities.DeviceConnection{
+		MACAddress: "AA:BB:CC:DD:EE:01",
+		Clients: []entities.DeviceClient{
+			{ClientID: "clone", RemoteAddress: "10.0.0.9"},
+			{ClientID: "sensor-01", RemoteAddress: "192.168.1.20"},
+		},
+	}
+
+	t.Run("should alert once per cooldown for a device published by several clients", func(t *testing.T) {
+		useCase, advance := newTestMonitor(t, DefaultMonitorConfig(), nil)
+		ctx := context.Background()
+
+		useCase.ObserveConnectionMismatch(ctx, connection)
+		useCase.ObserveConnectionMismatch(ctx, connection)
+
+		alerts := useCase.RecentAlerts(0)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, entities.SecurityAlertDeviceCloneSuspected, alerts[0].Kind)
+		assert.Equal(t, entities.SecuritySeverityCritical, alerts[0].Severity)
+		assert.Equal(t, "AA:BB:CC:DD:EE:01", alerts[0].Subject)
+		assert.Equal(t, []string{"clone", "sensor-01"}, alerts[0].Context["client_ids"])
+
+		advance(cloneAlertCooldown + time.Second)
+		useCase.ObserveConnectionMismatch(ctx, connection)
+		assert.Len(t, useCase.RecentAlerts(0), 2)
+	})
+
+	t.Run("should ignore devices with a single client", func(t *testing.T) {
+		useCase, _ := newTestMonitor(t, DefaultMonitorConfig(), nil)
+
+		useCase.ObserveConnectionMismatch(context.Background(), &entities.DeviceConnection{
+			MACAddress: "AA:BB:CC:DD:EE:01",
+			Clients:    connection.Clients[:1],
+		})
+
+		assert.Empty(t, useCase.RecentAlerts(0))
+	})
+}
@@ -3,13 +3,20 @@ package sensordata
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	ports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
 	"go.uber.org/zap"
 )
 
+// replayMatchWindow is how far from a replayed reading a stored reading may be stamped and still be
+// replaced by it. Live readings are stamped when handled, shortly after the archive received them.
+const replayMatchWindow = 2 * time.Second
+
 // SensorDataUseCase defines the interface for sensor data operations
 type SensorDataUseCase interface {
 	StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error
@@ -29,11 +36,22 @@ func NewSensorDataUseCase(loggerFactory logger.LoggerFactory, repo ports.SensorT
 	}
 }
 
-// StoreSensorData stores the sensor data using the repository
+// StoreSensorData stores the sensor data using the repository. Replayed readings replace the
+// reading stored when the message was first handled, so reprocessing a range twice is harmless.
 func (uc *sensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if err := domainerrors.RequireInput("sensor data", data); err != nil {
+		return err
+	}
+
 	uc.coreLogger.Info("storing_sensor_data", zap.String("mac_address", data.MacAddress()), zap.String("component", "sensor_data_use_case"))
 
-	if err := uc.repo.Create(ctx, data); err != nil {
+	var err error
+	if eventports.IsReplay(ctx) {
+		err = uc.repo.Upsert(ctx, data, replayMatchWindow)
+	} else {
+		err = uc.repo.Create(ctx, data)
+	}
+	if err != nil {
 		uc.coreLogger.Error("failed_to_store_sensor_data", zap.Error(err), zap.String("component", "sensor_data_use_case"))
 		return fmt.Errorf("failed to store sensor data: %w", err)
 	}
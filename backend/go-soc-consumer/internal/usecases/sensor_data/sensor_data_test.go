@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
 )
@@ -46,4 +48,19 @@ func TestSensorDataUseCase_StoreSensorData(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to store sensor data")
 	})
+
+	t.Run("Replay upserts", func(t *testing.T) {
+		replayCtx := eventports.ContextWithReplay(ctx, sensorData.Timestamp())
+		mockRepo.On("Upsert", replayCtx, sensorData, replayMatchWindow).Return(nil).Once()
+
+		err := useCase.StoreSensorData(replayCtx, sensorData)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Nil data", func(t *testing.T) {
+		err := useCase.StoreSensorData(ctx, nil)
+
+		assert.ErrorIs(t, err, domainerrors.ErrMissingInput)
+	})
 }
@@ -0,0 +1,30 @@
+package sensorhealth
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// observedSensorDataUseCase reports stored readings to the sensor health use case
+type observedSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	health SensorHealthUseCase
+}
+
+// NewObservedSensorDataUseCase wraps a sensor data use case with sensor health tracking
+func NewObservedSensorDataUseCase(inner sensordata.SensorDataUseCase, health SensorHealthUseCase) sensordata.SensorDataUseCase {
+	return &observedSensorDataUseCase{SensorDataUseCase: inner, health: health}
+}
+
+// StoreSensorData stores the reading and observes it once stored. Replayed readings are old and
+// would distort the stream's intervals, so they are not observed.
+func (uc *observedSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if err := uc.SensorDataUseCase.StoreSensorData(ctx, data); err != nil || eventports.IsReplay(ctx) {
+		return err
+	}
+	uc.health.ObserveSensorData(ctx, data)
+	return nil
+}
@@ -0,0 +1,378 @@
+package sensorhealth
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+const (
+	// intervalWeight is the weight of the latest gap in the learned reporting interval of a stream
+	intervalWeight = 0.2
+	// irregularVariation is the interval variation above which a stream is reported irregular
+	irregularVariation = 0.5
+	// flatlineSamples is the number of recent values that must be identical to report a flatline
+	flatlineSamples = 20
+	// streamIdleTTL is how long a silent stream is kept before it is forgotten
+	streamIdleTTL = 7 * 24 * time.Hour
+)
+
+// Weights of the score components; they add up to one
+const (
+	regularityWeight  = 0.4
+	variationWeight   = 0.4
+	calibrationWeight = 0.2
+)
+
+// HealthConfig holds the thresholds used to score sensor streams
+type HealthConfig struct {
+	StaleFactor       int           // a stream is stale once silent for this multiple of its learned interval
+	StaleMinimum      time.Duration // streams are never stale before this much silence
+	CalibrationMaxAge time.Duration // calibrations older than this lower the score
+	DegradedScore     int           // streams scoring below this are degraded
+	SweepInterval     time.Duration // how often silent streams are checked
+}
+
+// DefaultHealthConfig returns default configuration
+func DefaultHealthConfig() *HealthConfig {
+	return &HealthConfig{
+		StaleFactor:       3,
+		StaleMinimum:      5 * time.Minute,
+		CalibrationMaxAge: 180 * 24 * time.Hour,
+		DegradedScore:     60,
+		SweepInterval:     time.Minute,
+	}
+}
+
+// SensorHealthUseCase scores sensor streams and detects streams that stopped reporting
+type SensorHealthUseCase interface {
+	// ObserveSensorData records a stored reading in the temperature and humidity streams of its device
+	ObserveSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity)
+
+	// Streams returns the health of every tracked stream, ordered by MAC address and measurement
+	Streams(ctx context.Context) ([]*entities.SensorStreamHealth, error)
+
+	// Degraded returns the streams that are stale or score below the degraded threshold, least healthy first
+	Degraded(ctx context.Context) ([]*entities.SensorStreamHealth, error)
+
+	// RecordCalibration stores when the sensors of a device were calibrated
+	RecordCalibration(ctx context.Context, macAddress string, calibratedAt time.Time) (*entities.Device, error)
+
+	// Run marks streams stale as they fall silent until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// streamKey identifies a measurement stream of a device
+type streamKey struct {
+	macAddress  string
+	measurement string
+}
+
+// streamState tracks the reporting pattern and recent values of a stream
+type streamState struct {
+	readings  int
+	lastAt    time.Time
+	interval  float64   // learned reporting interval in seconds
+	deviation float64   // average deviation from the learned interval in seconds
+	recent    []float64 // ring buffer of the latest values
+	next      int
+	stale     bool
+}
+
+// useCaseImpl implements the SensorHealthUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	config        *HealthConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+
+	mu      sync.Mutex
+	streams map[streamKey]*streamState
+
+	staleStreams *metrics.Vec
+}
+
+// NewSensorHealthUseCase creates a new sensor health use case
+func NewSensorHealthUseCase(deviceRepo repositoryports.DeviceRepository, config *HealthConfig, loggerFactory logger.LoggerFactory) SensorHealthUseCase {
+	if config == nil {
+		config = DefaultHealthConfig()
+	}
+
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		streams:       make(map[streamKey]*streamState),
+		staleStreams:  metrics.NewGaugeVec("sensor_streams_stale", "Sensor streams that stopped reporting"),
+	}
+}
+
+// ObserveSensorData records the reading in the streams of its device
+func (uc *useCaseImpl) ObserveSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) {
+	if data == nil {
+		return
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := uc.now()
+	uc.observeLocked(streamKey{data.MacAddress(), entities.MeasurementTemperature}, data.Temperature(), now)
+	uc.observeLocked(streamKey{data.MacAddress(), entities.MeasurementHumidity}, data.Humidity(), now)
+}
+
+// observeLocked updates the learned interval and recent values of a stream; uc.mu must be held
+func (uc *useCaseImpl) observeLocked(key streamKey, value float64, now time.Time) {
+	state, ok := uc.streams[key]
+	if !ok {
+		state = &streamState{recent: make([]float64, 0, flatlineSamples)}
+		uc.streams[key] = state
+	}
+
+	if state.readings > 0 {
+		gap := now.Sub(state.lastAt).Seconds()
+		if state.readings == 1 {
+			state.interval = gap
+		} else {
+			state.deviation = (1-intervalWeight)*state.deviation + intervalWeight*math.Abs(gap-state.interval)
+			state.interval = (1-intervalWeight)*state.interval + intervalWeight*gap
+		}
+	}
+	state.readings++
+	state.lastAt = now
+
+	if len(state.recent) < flatlineSamples {
+		state.recent = append(state.recent, value)
+	} else {
+		state.recent[state.next] = value
+		state.next = (state.next + 1) % flatlineSamples
+	}
+
+	if state.stale {
+		state.stale = false
+		uc.staleStreams.Add(-1)
+		uc.loggerFactory.Core().Info("sensor_stream_recovered",
+			zap.String("mac_address", key.macAddress),
+			zap.String("measurement", key.measurement),
+			zap.String("component", "sensor_health_usecase"),
+		)
+	}
+}
+
+// Streams returns the health of every tracked stream
+func (uc *useCaseImpl) Streams(ctx context.Context) ([]*entities.SensorStreamHealth, error) {
+	devices, err := uc.deviceRepo.List(ctx, 0, pagination.Unlimited)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load device calibrations: %w", err)
+	}
+	calibrations := make(map[string]*time.Time, len(devices))
+	for _, device := range devices {
+		calibrations[device.GetID()] = device.CalibratedAt
+	}
+
+	uc.mu.Lock()
+	now := uc.now()
+	streams := make([]*entities.SensorStreamHealth, 0, len(uc.streams))
+	for key, state := range uc.streams {
+		streams = append(streams, uc.score(key, state, calibrations[key.macAddress], now))
+	}
+	uc.mu.Unlock()
+
+	sort.Slice(streams, func(i, j int) bool {
+		if streams[i].MACAddress != streams[j].MACAddress {
+			return streams[i].MACAddress < streams[j].MACAddress
+		}
+		return streams[i].Measurement < streams[j].Measurement
+	})
+	return streams, nil
+}
+
+// Degraded returns the degraded streams, least healthy first
+func (uc *useCaseImpl) Degraded(ctx context.Context) ([]*entities.SensorStreamHealth, error) {
+	streams, err := uc.Streams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	degraded := make([]*entities.SensorStreamHealth, 0)
+	for _, stream := range streams {
+		if stream.Degraded {
+			degraded = append(degraded, stream)
+		}
+	}
+	sort.SliceStable(degraded, func(i, j int) bool {
+		return degraded[i].Score < degraded[j].Score
+	})
+	return degraded, nil
+}
+
+// staleAfter returns the silence after which a stream is stale
+func (uc *useCaseImpl) staleAfter(state *streamState) time.Duration {
+	expected := time.Duration(state.interval * float64(uc.config.StaleFactor) * float64(time.Second))
+	if state.readings < 2 || expected < uc.config.StaleMinimum {
+		return uc.config.StaleMinimum
+	}
+	return expected
+}
+
+// score computes the health of a stream from its reporting regularity, value variation and calibration age
+func (uc *useCaseImpl) score(key streamKey, state *streamState, calibratedAt *time.Time, now time.Time) *entities.SensorStreamHealth {
+	health := &entities.SensorStreamHealth{
+		MACAddress:    key.macAddress,
+		Measurement:   key.measurement,
+		Readings:      state.readings,
+		LastReadingAt: state.lastAt,
+		CalibratedAt:  calibratedAt,
+		Issues:        make([]string, 0),
+	}
+	if state.readings > 1 {
+		health.ExpectedInterval = time.Duration(state.interval * float64(time.Second)).Round(time.Second)
+	}
+
+	health.Stale = now.Sub(state.lastAt) > uc.staleAfter(state)
+	if health.Stale {
+		health.Issues = append(health.Issues, entities.SensorIssueStale)
+	}
+
+	regularity := 1.0
+	if state.readings > 2 && state.interval > 0 {
+		variation := state.deviation / state.interval
+		regularity = math.Max(0, 1-variation)
+		if variation > irregularVariation {
+			health.Issues = append(health.Issues, entities.SensorIssueIrregular)
+		}
+	}
+
+	variation := 1.0
+	if len(state.recent) == flatlineSamples && isFlat(state.recent) {
+		variation = 0
+		health.Issues = append(health.Issues, entities.SensorIssueFlatline)
+	}
+
+	calibration := 1.0
+	switch {
+	case calibratedAt == nil:
+		calibration = 0.5
+		health.Issues = append(health.Issues, entities.SensorIssueUncalibrated)
+	case now.Sub(*calibratedAt) > uc.config.CalibrationMaxAge:
+		overdue := now.Sub(*calibratedAt) - uc.config.CalibrationMaxAge
+		calibration = math.Max(0, 1-overdue.Seconds()/uc.config.CalibrationMaxAge.Seconds())
+		health.Issues = append(health.Issues, entities.SensorIssueCalibrationOverdue)
+	}
+
+	health.Score = int(math.Round(100 * (regularityWeight*regularity + variationWeight*variation + calibrationWeight*calibration)))
+	health.Degraded = health.Stale || health.Score < uc.config.DegradedScore
+	return health
+}
+
+// isFlat returns true if all values are identical
+func isFlat(values []float64) bool {
+	for _, value := range values[1:] {
+		if value != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// RecordCalibration stores when the sensors of a device were calibrated
+func (uc *useCaseImpl) RecordCalibration(ctx context.Context, macAddress string, calibratedAt time.Time) (*entities.Device, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, err
+	}
+
+	device, err = device.WithChanges(func(device *entities.Device) error {
+		return device.RecordCalibration(calibratedAt)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidCalibration, err)
+	}
+	if err := uc.deviceRepo.Update(ctx, device); err != nil {
+		return nil, fmt.Errorf("failed to record device calibration: %w", err)
+	}
+
+	uc.loggerFactory.Core().Info("device_calibration_recorded",
+		zap.String("mac_address", device.GetID()),
+		zap.Time("calibrated_at", *device.CalibratedAt),
+		zap.String("component", "sensor_health_usecase"),
+	)
+	return device, nil
+}
+
+// Run marks streams stale as they fall silent until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("sensor_health_monitoring_started", "sensor_health_usecase",
+		zap.Duration("sweep_interval", uc.config.SweepInterval),
+	)
+
+	ticker := time.NewTicker(uc.config.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("sensor_health_monitoring_stopped", "sensor_health_usecase")
+			return
+		case <-ticker.C:
+			uc.sweep()
+		}
+	}
+}
+
+// sweep marks silent streams stale and forgets streams silent for longer than streamIdleTTL
+func (uc *useCaseImpl) sweep() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := uc.now()
+	for key, state := range uc.streams {
+		silence := now.Sub(state.lastAt)
+		if silence > streamIdleTTL {
+			if state.stale {
+				uc.staleStreams.Add(-1)
+			}
+			delete(uc.streams, key)
+			continue
+		}
+
+		if !state.stale && silence > uc.staleAfter(state) {
+			state.stale = true
+			uc.staleStreams.Inc()
+			uc.loggerFactory.Core().Warn("sensor_stream_stale",
+				zap.String("mac_address", key.macAddress),
+				zap.String("measurement", key.measurement),
+				zap.Duration("silence", silence.Round(time.Second)),
+				zap.Time("last_reading_at", state.lastAt),
+				zap.String("component", "sensor_health_usecase"),
+			)
+		}
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return uc.staleStreams.Collect()
+}
+
+// MetricsCollector returns the sensor health metrics collector
+func MetricsCollector(useCase SensorHealthUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
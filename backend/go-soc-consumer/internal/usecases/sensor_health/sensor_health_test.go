@@ -0,0 +1,140 @@
+package sensorhealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+// newTestUseCase returns a use case with a controllable clock
+func newTestUseCase(t *testing.T, repo *mocks.MockDeviceRepository) (*useCaseImpl, *time.Time) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase := NewSensorHealthUseCase(repo, nil, createTestLoggerFactory(t)).(*useCaseImpl)
+	useCase.now = func() time.Time { return now }
+	return useCase, &now
+}
+
+func calibratedDevice(t *testing.T, calibratedAt time.Time) *entities.Device {
+	device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	require.NoError(t, device.RecordCalibration(calibratedAt))
+	return device
+}
+
+// observe reports count readings every interval, with temperatures produced by value
+func observe(t *testing.T, useCase *useCaseImpl, now *time.Time, count int, interval time.Duration, value func(i int) float64) {
+	for i := 0; i < count; i++ {
+		data, err := entities.NewSensorTemperatureHumidity(testMAC, value(i), 60+float64(i%5))
+		require.NoError(t, err)
+		useCase.ObserveSensorData(context.Background(), data)
+		*now = now.Add(interval)
+	}
+}
+
+func TestSensorHealthUseCase_Streams(t *testing.T) {
+	t.Run("should score regular calibrated streams as healthy", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase, now := newTestUseCase(t, repo)
+		repo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return([]*entities.Device{calibratedDevice(t, now.AddDate(0, -1, 0))}, nil).Once()
+
+		observe(t, useCase, now, 30, time.Minute, func(i int) float64 { return 20 + float64(i%3) })
+
+		streams, err := useCase.Streams(context.Background())
+		require.NoError(t, err)
+		require.Len(t, streams, 2)
+		assert.Equal(t, entities.MeasurementHumidity, streams[0].Measurement)
+		assert.Equal(t, entities.MeasurementTemperature, streams[1].Measurement)
+		assert.Equal(t, 100, streams[1].Score)
+		assert.Equal(t, time.Minute, streams[1].ExpectedInterval)
+		assert.False(t, streams[1].Degraded)
+		assert.Empty(t, streams[1].Issues)
+	})
+
+	t.Run("should report flatlined and uncalibrated streams", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase, now := newTestUseCase(t, repo)
+		repo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return(nil, nil).Once()
+
+		observe(t, useCase, now, 30, time.Minute, func(int) float64 { return 21.5 })
+
+		degraded, err := useCase.Degraded(context.Background())
+		require.NoError(t, err)
+		require.Len(t, degraded, 1)
+		assert.Equal(t, entities.MeasurementTemperature, degraded[0].Measurement)
+		assert.True(t, degraded[0].HasIssue(entities.SensorIssueFlatline))
+		assert.True(t, degraded[0].HasIssue(entities.SensorIssueUncalibrated))
+		assert.Equal(t, 50, degraded[0].Score)
+	})
+
+	t.Run("should mark silent streams stale", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase, now := newTestUseCase(t, repo)
+		repo.EXPECT().List(mock.Anything, 0, pagination.Unlimited).Return([]*entities.Device{calibratedDevice(t, now.AddDate(0, -1, 0))}, nil).Twice()
+
+		observe(t, useCase, now, 10, 2*time.Minute, func(i int) float64 { return 20 + float64(i%3) })
+		*now = now.Add(3 * time.Minute) // five minutes since the last reading
+		useCase.sweep()
+		degraded, err := useCase.Degraded(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, degraded, "silence below the stale threshold")
+
+		*now = now.Add(2 * time.Minute)
+		useCase.sweep()
+		degraded, err = useCase.Degraded(context.Background())
+		require.NoError(t, err)
+		require.Len(t, degraded, 2)
+		assert.True(t, degraded[0].Stale)
+		assert.Equal(t, float64(2), useCase.staleStreams.Value())
+
+		observe(t, useCase, now, 1, time.Minute, func(int) float64 { return 20 })
+		assert.Equal(t, float64(0), useCase.staleStreams.Value())
+	})
+}
+
+func TestSensorHealthUseCase_RecordCalibration(t *testing.T) {
+	t.Run("should store the calibration time", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase, now := newTestUseCase(t, repo)
+		device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+		require.NoError(t, err)
+
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+		repo.EXPECT().Update(mock.Anything, mock.AnythingOfType("*entities.Device")).Return(nil).Once()
+
+		updated, err := useCase.RecordCalibration(context.Background(), "aa:bb:cc:dd:ee:ff", *now)
+		require.NoError(t, err)
+		require.NotNil(t, updated.CalibratedAt)
+		assert.True(t, updated.CalibratedAt.Equal(*now))
+		assert.Nil(t, device.CalibratedAt, "the device read from the repository is not modified")
+	})
+
+	t.Run("should reject calibrations in the future", func(t *testing.T) {
+		repo := mocks.NewMockDeviceRepository(t)
+		useCase, _ := newTestUseCase(t, repo)
+		device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+		require.NoError(t, err)
+
+		repo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+
+		_, err = useCase.RecordCalibration(context.Background(), testMAC, time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidCalibration)
+	})
+}
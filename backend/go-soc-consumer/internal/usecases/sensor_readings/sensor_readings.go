@@ -0,0 +1,113 @@
+package sensorreadings
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/validation"
+)
+
+// ReadingsConfig holds the limits of readings queries
+type ReadingsConfig struct {
+	DefaultWindow time.Duration // range read when the query gives no start
+	MaxRange      time.Duration // longest range a query may cover
+}
+
+// DefaultReadingsConfig returns default configuration
+func DefaultReadingsConfig() *ReadingsConfig {
+	return &ReadingsConfig{
+		DefaultWindow: 24 * time.Hour,
+		MaxRange:      31 * 24 * time.Hour,
+	}
+}
+
+// SensorReadingsUseCase serves the stored temperature and humidity readings
+type SensorReadingsUseCase interface {
+	// List returns a page of the readings of the device, newest first, together with the query
+	// with its range resolved; the range defaults to DefaultWindow before now. Invalid ranges and
+	// MAC addresses fail with ErrInvalidReadingsQuery.
+	List(ctx context.Context, macAddress string, query entities.SensorReadingsQuery) ([]*entities.SensorTemperatureHumidity, entities.SensorReadingsQuery, error)
+
+	// Latest returns the latest reading of each of the given devices, or of every device when
+	// none are given
+	Latest(ctx context.Context, macAddresses []string) ([]*entities.SensorTemperatureHumidity, error)
+}
+
+// useCaseImpl implements the SensorReadingsUseCase interface
+type useCaseImpl struct {
+	readingsRepo repositoryports.SensorTemperatureHumidityRepository
+	config       *ReadingsConfig
+	now          func() time.Time
+}
+
+// NewSensorReadingsUseCase creates a sensor readings use case
+func NewSensorReadingsUseCase(readingsRepo repositoryports.SensorTemperatureHumidityRepository, config *ReadingsConfig) SensorReadingsUseCase {
+	if config == nil {
+		config = DefaultReadingsConfig()
+	}
+
+	return &useCaseImpl{
+		readingsRepo: readingsRepo,
+		config:       config,
+		now:          time.Now,
+	}
+}
+
+// List resolves the range of the query and reads the page
+func (uc *useCaseImpl) List(ctx context.Context, macAddress string, query entities.SensorReadingsQuery) ([]*entities.SensorTemperatureHumidity, entities.SensorReadingsQuery, error) {
+	macAddress, err := normalizeMACAddress(macAddress)
+	if err != nil {
+		return nil, query, err
+	}
+
+	if query.To.IsZero() {
+		query.To = uc.now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-uc.config.DefaultWindow)
+	}
+	switch {
+	case !query.From.Before(query.To):
+		return nil, query, fmt.Errorf("%w: from must be before to", domainerrors.ErrInvalidReadingsQuery)
+	case query.To.Sub(query.From) > uc.config.MaxRange:
+		return nil, query, fmt.Errorf("%w: range exceeds %s", domainerrors.ErrInvalidReadingsQuery, uc.config.MaxRange)
+	case query.Offset < 0:
+		return nil, query, fmt.Errorf("%w: offset must not be negative", domainerrors.ErrInvalidReadingsQuery)
+	}
+
+	readings, err := uc.readingsRepo.ListByMACAddress(ctx, macAddress, query.From, query.To, query.Offset, query.Limit)
+	if err != nil {
+		return nil, query, fmt.Errorf("failed to list readings: %w", err)
+	}
+	return readings, query, nil
+}
+
+// Latest reads the latest reading of the devices
+func (uc *useCaseImpl) Latest(ctx context.Context, macAddresses []string) ([]*entities.SensorTemperatureHumidity, error) {
+	normalized := make([]string, 0, len(macAddresses))
+	for _, macAddress := range macAddresses {
+		macAddress, err := normalizeMACAddress(macAddress)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, macAddress)
+	}
+
+	readings, err := uc.readingsRepo.FindLatest(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load latest readings: %w", err)
+	}
+	return readings, nil
+}
+
+func normalizeMACAddress(macAddress string) (string, error) {
+	if err := validation.ValidateMACAddress(macAddress); err != nil {
+		return "", fmt.Errorf("%w: %v", domainerrors.ErrInvalidReadingsQuery, err)
+	}
+	return strings.ToUpper(strings.TrimSpace(macAddress)), nil
+}
@@ -0,0 +1,69 @@
+package sensorreadings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+)
+
+func newTestUseCase(t *testing.T, now time.Time) (*useCaseImpl, *mocks.MockSensorTemperatureHumidityRepository) {
+	readingsRepo := mocks.NewMockSensorTemperatureHumidityRepository(t)
+	useCase := NewSensorReadingsUseCase(readingsRepo, nil).(*useCaseImpl)
+	useCase.now = func() time.Time { return now }
+	return useCase, readingsRepo
+}
+
+func TestSensorReadingsUseCase_List(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should default to the last day", func(t *testing.T) {
+		useCase, readingsRepo := newTestUseCase(t, now)
+		reading, err := entities.NewSensorTemperatureHumidityAt("AA:BB:CC:DD:EE:FF", 24, 60, now.Add(-time.Hour))
+		require.NoError(t, err)
+		readingsRepo.EXPECT().ListByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF", now.Add(-24*time.Hour), now, 0, 50).
+			Return([]*entities.SensorTemperatureHumidity{reading}, nil).Once()
+
+		readings, query, err := useCase.List(context.Background(), "aa:bb:cc:dd:ee:ff", entities.SensorReadingsQuery{Limit: 50})
+
+		require.NoError(t, err)
+		assert.Len(t, readings, 1)
+		assert.Equal(t, now.Add(-24*time.Hour), query.From)
+		assert.Equal(t, now, query.To)
+	})
+
+	t.Run("should reject invalid queries", func(t *testing.T) {
+		useCase, _ := newTestUseCase(t, now)
+
+		queries := map[string]entities.SensorReadingsQuery{
+			"reversed range":  {From: now, To: now.Add(-time.Hour), Limit: 50},
+			"range too long":  {From: now.Add(-60 * 24 * time.Hour), To: now, Limit: 50},
+			"negative offset": {Offset: -1, Limit: 50},
+		}
+		for name, query := range queries {
+			_, _, err := useCase.List(context.Background(), "AA:BB:CC:DD:EE:FF", query)
+			assert.ErrorIs(t, err, domainerrors.ErrInvalidReadingsQuery, name)
+		}
+
+		_, _, err := useCase.List(context.Background(), "not-a-mac", entities.SensorReadingsQuery{Limit: 50})
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidReadingsQuery)
+	})
+}
+
+func TestSensorReadingsUseCase_Latest(t *testing.T) {
+	useCase, readingsRepo := newTestUseCase(t, time.Now())
+	readingsRepo.EXPECT().FindLatest(mock.Anything, []string{"AA:BB:CC:DD:EE:01"}).Return(nil, nil).Once()
+
+	_, err := useCase.Latest(context.Background(), []string{" aa:bb:cc:dd:ee:01"})
+	require.NoError(t, err)
+
+	_, err = useCase.Latest(context.Background(), []string{"AA:BB"})
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidReadingsQuery)
+}
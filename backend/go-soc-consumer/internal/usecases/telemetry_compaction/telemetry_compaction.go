@@ -0,0 +1,134 @@
+package telemetrycompaction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// CompactionConfig holds configuration for compressing old telemetry
+type CompactionConfig struct {
+	CompressAfter time.Duration // raw readings older than this are compressed
+	Interval      time.Duration // how often compaction runs
+	BatchDays     int           // device-days compacted per run
+}
+
+// DefaultCompactionConfig returns default configuration
+func DefaultCompactionConfig() *CompactionConfig {
+	return &CompactionConfig{
+		CompressAfter: 30 * 24 * time.Hour,
+		Interval:      time.Hour,
+		BatchDays:     100,
+	}
+}
+
+// TelemetryCompactionUseCase moves old raw readings into compressed per device-day archives
+type TelemetryCompactionUseCase interface {
+	// CompactOnce compresses up to BatchDays device-days and returns the number of raw rows compacted
+	CompactOnce(ctx context.Context) (int, error)
+
+	// Run compacts periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// useCaseImpl implements the TelemetryCompactionUseCase interface
+type useCaseImpl struct {
+	archiveRepo   repositoryports.TelemetryArchiveRepository
+	config        *CompactionConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+}
+
+// NewTelemetryCompactionUseCase creates a new telemetry compaction use case
+func NewTelemetryCompactionUseCase(
+	archiveRepo repositoryports.TelemetryArchiveRepository,
+	config *CompactionConfig,
+	loggerFactory logger.LoggerFactory,
+) TelemetryCompactionUseCase {
+	if config == nil {
+		config = DefaultCompactionConfig()
+	}
+
+	return &useCaseImpl{
+		archiveRepo:   archiveRepo,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+	}
+}
+
+// CompactOnce compresses up to BatchDays device-days older than CompressAfter.
+// A failing device-day is logged and skipped so it does not block the others.
+func (uc *useCaseImpl) CompactOnce(ctx context.Context) (int, error) {
+	cutoff := uc.now().Add(-uc.config.CompressAfter)
+
+	days, err := uc.archiveRepo.ListCompactableDays(ctx, cutoff, uc.config.BatchDays)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list compactable telemetry: %w", err)
+	}
+
+	start := time.Now()
+	total, failed := 0, 0
+	for _, day := range days {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		rows, err := uc.archiveRepo.CompactDay(ctx, day)
+		if err != nil {
+			failed++
+			uc.loggerFactory.Core().Warn("telemetry_day_compaction_skipped",
+				zap.Error(err),
+				zap.String("device_day", day.String()),
+				zap.String("component", "telemetry_compaction_usecase"),
+			)
+			continue
+		}
+		total += rows
+	}
+
+	if len(days) > 0 {
+		uc.loggerFactory.Core().Info("telemetry_compaction_completed",
+			zap.Int("device_days", len(days)),
+			zap.Int("failed_device_days", failed),
+			zap.Int("rows_compacted", total),
+			zap.Time("cutoff", cutoff),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("component", "telemetry_compaction_usecase"),
+		)
+	}
+
+	return total, nil
+}
+
+// Run compacts periodically until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("telemetry_compaction_started", "telemetry_compaction_usecase",
+		zap.Duration("compress_after", uc.config.CompressAfter),
+		zap.Duration("interval", uc.config.Interval),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := uc.CompactOnce(ctx); err != nil && ctx.Err() == nil {
+			uc.loggerFactory.Core().Error("telemetry_compaction_failed",
+				zap.Error(err),
+				zap.String("component", "telemetry_compaction_usecase"),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("telemetry_compaction_stopped", "telemetry_compaction_usecase")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package telemetrycompaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// createTestLoggerFactory creates a test logger factory for use in tests
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopment()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func TestTelemetryCompactionUseCase_CompactOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 7, 31, 10, 0, 0, 0, time.UTC)
+	config := &CompactionConfig{CompressAfter: 30 * 24 * time.Hour, Interval: time.Hour, BatchDays: 10}
+
+	t.Run("should compact listed days and skip failing ones", func(t *testing.T) {
+		archiveRepo := mocks.NewMockTelemetryArchiveRepository(t)
+		useCase := NewTelemetryCompactionUseCase(archiveRepo, config, createTestLoggerFactory(t)).(*useCaseImpl)
+		useCase.now = func() time.Time { return now }
+
+		first := entities.NewTelemetryDay("AA:BB:CC:DD:EE:FF", time.Date(2025, 6, 1, 15, 0, 0, 0, time.UTC))
+		second := entities.NewTelemetryDay("AA:BB:CC:DD:EE:00", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		archiveRepo.EXPECT().ListCompactableDays(ctx, now.Add(-config.CompressAfter), 10).Return([]entities.TelemetryDay{first, second}, nil).Once()
+		archiveRepo.EXPECT().CompactDay(ctx, first).Return(0, errors.New("archive write failed")).Once()
+		archiveRepo.EXPECT().CompactDay(ctx, second).Return(1440, nil).Once()
+
+		rows, err := useCase.CompactOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1440, rows)
+	})
+
+	t.Run("should return listing errors", func(t *testing.T) {
+		archiveRepo := mocks.NewMockTelemetryArchiveRepository(t)
+		useCase := NewTelemetryCompactionUseCase(archiveRepo, config, createTestLoggerFactory(t))
+
+		archiveRepo.EXPECT().ListCompactableDays(ctx, mock.Anything, 10).Return(nil, errors.New("db down")).Once()
+
+		_, err := useCase.CompactOnce(ctx)
+		assert.ErrorContains(t, err, "db down")
+	})
+}
@@ -0,0 +1,30 @@
+package telemetryforwarding
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// forwardingSensorDataUseCase forwards readings once they are stored
+type forwardingSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	forwarding TelemetryForwardingUseCase
+}
+
+// NewForwardingSensorDataUseCase wraps a sensor data use case so accepted readings are mirrored to the external sinks
+func NewForwardingSensorDataUseCase(inner sensordata.SensorDataUseCase, forwarding TelemetryForwardingUseCase) sensordata.SensorDataUseCase {
+	return &forwardingSensorDataUseCase{SensorDataUseCase: inner, forwarding: forwarding}
+}
+
+// StoreSensorData stores the reading and queues it for forwarding once stored. Replayed readings
+// are not forwarded again.
+func (uc *forwardingSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if err := uc.SensorDataUseCase.StoreSensorData(ctx, data); err != nil || eventports.IsReplay(ctx) {
+		return err
+	}
+	uc.forwarding.Forward(data)
+	return nil
+}
@@ -0,0 +1,245 @@
+package telemetryforwarding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// ForwardingConfig holds configuration for mirroring telemetry to external sinks
+type ForwardingConfig struct {
+	BufferSize    int           // readings buffered per sink; the oldest are dropped once it is full
+	BatchSize     int           // readings sent per write
+	FlushInterval time.Duration // how often buffered readings are sent
+	MaxBackoff    time.Duration // longest wait between retries of a failing sink
+}
+
+// DefaultForwardingConfig returns default configuration
+func DefaultForwardingConfig() *ForwardingConfig {
+	return &ForwardingConfig{
+		BufferSize:    10000,
+		BatchSize:     500,
+		FlushInterval: 5 * time.Second,
+		MaxBackoff:    5 * time.Minute,
+	}
+}
+
+// TelemetryForwardingUseCase mirrors accepted telemetry to external systems. Readings are buffered per
+// sink and sent in the background, so a slow or unreachable sink never delays ingestion.
+type TelemetryForwardingUseCase interface {
+	// Forward queues a reading for every sink without blocking
+	Forward(reading *entities.SensorTemperatureHumidity)
+
+	// Run sends buffered readings to the sinks until the context is cancelled
+	Run(ctx context.Context)
+
+	// Flush sends everything that is buffered, stopping at the first failure of each sink
+	Flush(ctx context.Context) error
+}
+
+// sinkQueue buffers the readings waiting for one sink
+type sinkQueue struct {
+	sink ports.TelemetrySink
+
+	mu      sync.Mutex
+	pending []*entities.SensorTemperatureHumidity
+	evicted int           // readings dropped from the head of pending so far
+	ready   chan struct{} // signalled when a full batch is waiting
+}
+
+// useCaseImpl implements the TelemetryForwardingUseCase interface
+type useCaseImpl struct {
+	config        *ForwardingConfig
+	queues        []*sinkQueue
+	loggerFactory logger.LoggerFactory
+
+	forwarded *metrics.Vec
+	failures  *metrics.Vec
+	dropped   *metrics.Vec
+}
+
+// NewTelemetryForwardingUseCase creates a use case forwarding readings to the given sinks
+func NewTelemetryForwardingUseCase(sinks []ports.TelemetrySink, config *ForwardingConfig, loggerFactory logger.LoggerFactory) TelemetryForwardingUseCase {
+	if config == nil {
+		config = DefaultForwardingConfig()
+	}
+
+	queues := make([]*sinkQueue, 0, len(sinks))
+	for _, sink := range sinks {
+		queues = append(queues, &sinkQueue{sink: sink, ready: make(chan struct{}, 1)})
+	}
+
+	return &useCaseImpl{
+		config:        config,
+		queues:        queues,
+		loggerFactory: loggerFactory,
+		forwarded:     metrics.NewCounterVec("telemetry_forwarded_total", "Readings delivered to external telemetry sinks", "sink"),
+		failures:      metrics.NewCounterVec("telemetry_forward_failures_total", "Failed writes to external telemetry sinks", "sink"),
+		dropped:       metrics.NewCounterVec("telemetry_forward_dropped_total", "Readings dropped because a sink buffer was full", "sink"),
+	}
+}
+
+// Forward queues the reading for every sink, dropping the oldest buffered reading of a full sink
+func (uc *useCaseImpl) Forward(reading *entities.SensorTemperatureHumidity) {
+	if reading == nil {
+		return
+	}
+
+	for _, queue := range uc.queues {
+		queue.mu.Lock()
+		if len(queue.pending) >= uc.config.BufferSize {
+			queue.pending[0] = nil
+			queue.pending = queue.pending[1:]
+			queue.evicted++
+			uc.dropped.Inc(queue.sink.Name())
+		}
+		queue.pending = append(queue.pending, reading)
+		full := len(queue.pending) >= uc.config.BatchSize
+		queue.mu.Unlock()
+
+		if full {
+			select {
+			case queue.ready <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// Run starts one sender per sink and waits for them to stop
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	if len(uc.queues) == 0 {
+		return
+	}
+
+	uc.loggerFactory.Application().LogApplicationEvent("telemetry_forwarding_started", "telemetry_forwarding_usecase",
+		zap.Int("sinks", len(uc.queues)),
+		zap.Duration("flush_interval", uc.config.FlushInterval),
+	)
+
+	var wg sync.WaitGroup
+	for _, queue := range uc.queues {
+		wg.Add(1)
+		go func(queue *sinkQueue) {
+			defer wg.Done()
+			uc.runSink(ctx, queue)
+		}(queue)
+	}
+	wg.Wait()
+
+	uc.loggerFactory.Application().LogApplicationEvent("telemetry_forwarding_stopped", "telemetry_forwarding_usecase")
+}
+
+// runSink sends batches every flush interval, or as soon as a full batch is waiting, backing off while the sink fails
+func (uc *useCaseImpl) runSink(ctx context.Context, queue *sinkQueue) {
+	delay := uc.config.FlushInterval
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-queue.ready:
+			if delay > uc.config.FlushInterval {
+				// the sink is failing, wait for the backoff instead of retrying on every batch
+				continue
+			}
+			if !timer.Stop() {
+				<-timer.C
+			}
+		}
+
+		if err := uc.drain(ctx, queue); err != nil {
+			delay *= 2
+			if delay > uc.config.MaxBackoff {
+				delay = uc.config.MaxBackoff
+			}
+			uc.loggerFactory.Core().Warn("telemetry_forward_failed",
+				zap.Error(err),
+				zap.String("sink", queue.sink.Name()),
+				zap.Duration("retry_in", delay),
+				zap.String("component", "telemetry_forwarding_usecase"),
+			)
+		} else {
+			delay = uc.config.FlushInterval
+		}
+		timer.Reset(delay)
+	}
+}
+
+// Flush drains every sink
+func (uc *useCaseImpl) Flush(ctx context.Context) error {
+	var firstErr error
+	for _, queue := range uc.queues {
+		if err := uc.drain(ctx, queue); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", queue.sink.Name(), err)
+		}
+	}
+	return firstErr
+}
+
+// drain sends batches until the queue is empty. A failed batch stays at the head of the queue.
+func (uc *useCaseImpl) drain(ctx context.Context, queue *sinkQueue) error {
+	for {
+		queue.mu.Lock()
+		size := min(len(queue.pending), uc.config.BatchSize)
+		batch := make([]*entities.SensorTemperatureHumidity, size)
+		copy(batch, queue.pending)
+		evicted := queue.evicted
+		queue.mu.Unlock()
+
+		if size == 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := queue.sink.Write(ctx, batch); err != nil {
+			uc.failures.Inc(queue.sink.Name())
+			return err
+		}
+		uc.forwarded.Add(float64(size), queue.sink.Name())
+
+		queue.mu.Lock()
+		// readings of the batch evicted while it was in flight are already gone from the queue
+		removed := max(size-(queue.evicted-evicted), 0)
+		clear(queue.pending[:removed])
+		queue.pending = queue.pending[removed:]
+		queue.mu.Unlock()
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	buffered := metrics.Family{Name: "telemetry_forward_buffered", Help: "Readings buffered for external telemetry sinks", Type: metrics.TypeGauge}
+	for _, queue := range uc.queues {
+		queue.mu.Lock()
+		size := len(queue.pending)
+		queue.mu.Unlock()
+		buffered.Samples = append(buffered.Samples, metrics.Sample{Labels: map[string]string{"sink": queue.sink.Name()}, Value: float64(size)})
+	}
+
+	families := []metrics.Family{buffered}
+	families = append(families, uc.forwarded.Collect()...)
+	families = append(families, uc.failures.Collect()...)
+	return append(families, uc.dropped.Collect()...)
+}
+
+// MetricsCollector returns the telemetry forwarding metrics collector
+func MetricsCollector(useCase TelemetryForwardingUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
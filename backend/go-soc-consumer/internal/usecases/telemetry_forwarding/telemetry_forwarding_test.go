@@ -0,0 +1,149 @@
+package telemetryforwarding
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func reading(t *testing.T, temperature float64) *entities.SensorTemperatureHumidity {
+	data, err := entities.NewSensorTemperatureHumidity("AA:BB:CC:DD:EE:FF", temperature, 60)
+	require.NoError(t, err)
+	return data
+}
+
+func newSink(t *testing.T) *mocks.MockTelemetrySink {
+	sink := mocks.NewMockTelemetrySink(t)
+	sink.EXPECT().Name().Return("influxdb").Maybe()
+	return sink
+}
+
+func TestTelemetryForwardingUseCase_Flush(t *testing.T) {
+	config := &ForwardingConfig{BufferSize: 3, BatchSize: 2, FlushInterval: time.Minute, MaxBackoff: time.Minute}
+
+	t.Run("should send buffered readings in batches", func(t *testing.T) {
+		sink := newSink(t)
+		useCase := NewTelemetryForwardingUseCase([]ports.TelemetrySink{sink}, config, createTestLoggerFactory(t)).(*useCaseImpl)
+		first, second, third := reading(t, 20), reading(t, 21), reading(t, 22)
+		sink.EXPECT().Write(mock.Anything, []*entities.SensorTemperatureHumidity{first, second}).Return(nil).Once()
+		sink.EXPECT().Write(mock.Anything, []*entities.SensorTemperatureHumidity{third}).Return(nil).Once()
+
+		useCase.Forward(first)
+		useCase.Forward(second)
+		useCase.Forward(third)
+		require.NoError(t, useCase.Flush(context.Background()))
+		assert.Equal(t, float64(3), useCase.forwarded.Value("influxdb"))
+	})
+
+	t.Run("should keep a failed batch for the next attempt", func(t *testing.T) {
+		sink := newSink(t)
+		useCase := NewTelemetryForwardingUseCase([]ports.TelemetrySink{sink}, config, createTestLoggerFactory(t)).(*useCaseImpl)
+		data := reading(t, 20)
+		sink.EXPECT().Write(mock.Anything, []*entities.SensorTemperatureHumidity{data}).Return(errors.New("connection refused")).Once()
+		sink.EXPECT().Write(mock.Anything, []*entities.SensorTemperatureHumidity{data}).Return(nil).Once()
+
+		useCase.Forward(data)
+		assert.Error(t, useCase.Flush(context.Background()))
+		assert.Equal(t, float64(1), useCase.failures.Value("influxdb"))
+		require.NoError(t, useCase.Flush(context.Background()))
+	})
+
+	t.Run("should drop the oldest readings when the buffer is full", func(t *testing.T) {
+		sink := newSink(t)
+		useCase := NewTelemetryForwardingUseCase([]ports.TelemetrySink{sink}, config, createTestLoggerFactory(t)).(*useCaseImpl)
+		readings := []*entities.SensorTemperatureHumidity{reading(t, 20), reading(t, 21), reading(t, 22), reading(t, 23)}
+		sink.EXPECT().Write(mock.Anything, readings[1:3]).Return(nil).Once()
+		sink.EXPECT().Write(mock.Anything, readings[3:]).Return(nil).Once()
+
+		for _, data := range readings {
+			useCase.Forward(data)
+		}
+		require.NoError(t, useCase.Flush(context.Background()))
+		assert.Equal(t, float64(1), useCase.dropped.Value("influxdb"))
+	})
+
+	t.Run("should not resend readings evicted while a batch was in flight", func(t *testing.T) {
+		sink := newSink(t)
+		useCase := NewTelemetryForwardingUseCase([]ports.TelemetrySink{sink}, config, createTestLoggerFactory(t)).(*useCaseImpl)
+		readings := []*entities.SensorTemperatureHumidity{reading(t, 20), reading(t, 21), reading(t, 22), reading(t, 23), reading(t, 24)}
+		sink.EXPECT().Write(mock.Anything, readings[:2]).Run(func(ctx context.Context, batch []*entities.SensorTemperatureHumidity) {
+			// two new readings arrive and evict both readings of the batch being sent
+			useCase.Forward(readings[3])
+			useCase.Forward(readings[4])
+		}).Return(nil).Once()
+		sink.EXPECT().Write(mock.Anything, readings[2:4]).Return(nil).Once()
+		sink.EXPECT().Write(mock.Anything, readings[4:]).Return(nil).Once()
+
+		useCase.Forward(readings[0])
+		useCase.Forward(readings[1])
+		useCase.Forward(readings[2])
+		require.NoError(t, useCase.Flush(context.Background()))
+	})
+}
+
+func TestTelemetryForwardingUseCase_Run(t *testing.T) {
+	sink := newSink(t)
+	config := &ForwardingConfig{BufferSize: 10, BatchSize: 2, FlushInterval: time.Hour, MaxBackoff: time.Hour}
+	useCase := NewTelemetryForwardingUseCase([]ports.TelemetrySink{sink}, config, createTestLoggerFactory(t))
+	first, second := reading(t, 20), reading(t, 21)
+
+	written := make(chan struct{})
+	sink.EXPECT().Write(mock.Anything, []*entities.SensorTemperatureHumidity{first, second}).Run(func(ctx context.Context, batch []*entities.SensorTemperatureHumidity) {
+		close(written)
+	}).Return(nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		useCase.Run(ctx)
+		close(stopped)
+	}()
+
+	// a full batch is sent without waiting for the flush interval
+	useCase.Forward(first)
+	useCase.Forward(second)
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+		t.Fatal("batch was not sent")
+	}
+
+	cancel()
+	<-stopped
+}
+
+func TestForwardingSensorDataUseCase(t *testing.T) {
+	data := reading(t, 20)
+
+	t.Run("should forward stored readings", func(t *testing.T) {
+		inner := mocks.NewMockSensorDataUseCase(t)
+		forwarding := mocks.NewMockTelemetryForwardingUseCase(t)
+		inner.EXPECT().StoreSensorData(mock.Anything, data).Return(nil).Once()
+		forwarding.EXPECT().Forward(data).Once()
+
+		require.NoError(t, NewForwardingSensorDataUseCase(inner, forwarding).StoreSensorData(context.Background(), data))
+	})
+
+	t.Run("should not forward readings that were not stored", func(t *testing.T) {
+		inner := mocks.NewMockSensorDataUseCase(t)
+		inner.EXPECT().StoreSensorData(mock.Anything, data).Return(errors.New("db down")).Once()
+
+		assert.Error(t, NewForwardingSensorDataUseCase(inner, mocks.NewMockTelemetryForwardingUseCase(t)).StoreSensorData(context.Background(), data))
+	})
+}
@@ -0,0 +1,72 @@
+package usagemetering
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/measurements"
+	sensordata "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/sensor_data"
+)
+
+// meteredSensorDataUseCase meters the readings once they are stored
+type meteredSensorDataUseCase struct {
+	sensordata.SensorDataUseCase
+	metering UsageMeteringUseCase
+}
+
+// NewMeteredSensorDataUseCase wraps a sensor data use case so every stored reading is metered
+func NewMeteredSensorDataUseCase(inner sensordata.SensorDataUseCase, metering UsageMeteringUseCase) sensordata.SensorDataUseCase {
+	return &meteredSensorDataUseCase{SensorDataUseCase: inner, metering: metering}
+}
+
+// StoreSensorData stores the reading and meters it when stored
+func (uc *meteredSensorDataUseCase) StoreSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) error {
+	if err := uc.SensorDataUseCase.StoreSensorData(ctx, data); err != nil {
+		return err
+	}
+	uc.metering.RecordMessage(ctx, 1)
+	return nil
+}
+
+// meteredMeasurementUseCase meters the measurements once they are stored
+type meteredMeasurementUseCase struct {
+	measurements.MeasurementUseCase
+	metering UsageMeteringUseCase
+}
+
+// NewMeteredMeasurementUseCase wraps a measurement use case so every stored message is metered
+func NewMeteredMeasurementUseCase(inner measurements.MeasurementUseCase, metering UsageMeteringUseCase) measurements.MeasurementUseCase {
+	return &meteredMeasurementUseCase{MeasurementUseCase: inner, metering: metering}
+}
+
+// StoreMeasurements stores the measurements of a message and meters them when stored
+func (uc *meteredMeasurementUseCase) StoreMeasurements(ctx context.Context, values []*entities.Measurement) error {
+	if err := uc.MeasurementUseCase.StoreMeasurements(ctx, values); err != nil {
+		return err
+	}
+	if len(values) > 0 {
+		uc.metering.RecordMessage(ctx, len(values))
+	}
+	return nil
+}
+
+// meteredNotificationSender meters the notifications once delivered
+type meteredNotificationSender struct {
+	ports.NotificationSender
+	metering UsageMeteringUseCase
+}
+
+// NewMeteredNotificationSender wraps a notification sender so every delivered notification is metered
+func NewMeteredNotificationSender(inner ports.NotificationSender, metering UsageMeteringUseCase) ports.NotificationSender {
+	return &meteredNotificationSender{NotificationSender: inner, metering: metering}
+}
+
+// Send delivers the notification and meters it when delivered
+func (s *meteredNotificationSender) Send(ctx context.Context, notification *entities.Notification) error {
+	if err := s.NotificationSender.Send(ctx, notification); err != nil {
+		return err
+	}
+	s.metering.RecordNotification(ctx, notification)
+	return nil
+}
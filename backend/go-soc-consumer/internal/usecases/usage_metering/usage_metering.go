@@ -0,0 +1,285 @@
+package usagemetering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// MeteringConfig holds configuration for metering the usage of the farms
+type MeteringConfig struct {
+	FlushInterval       time.Duration // how often metered usage is added to the stored monthly records
+	DeviceCountInterval time.Duration // how often the devices of each farm are counted for the day
+	StorageBytesPerRow  int64         // estimated bytes a stored reading or measurement takes, indexes included
+}
+
+// DefaultMeteringConfig returns default configuration
+func DefaultMeteringConfig() *MeteringConfig {
+	return &MeteringConfig{
+		FlushInterval:       time.Minute,
+		DeviceCountInterval: time.Hour,
+		StorageBytesPerRow:  100,
+	}
+}
+
+// UsageMeteringUseCase meters what each farm uses of the server into monthly usage records, so
+// hosting costs can be allocated across farms. Usage is counted in memory and added to the stored
+// records periodically; usage counted since the last flush is lost if the process is killed.
+type UsageMeteringUseCase interface {
+	// RecordMessage meters a telemetry message ingested in the farm namespace carried by the
+	// context, which stored the given number of rows
+	RecordMessage(ctx context.Context, rows int)
+
+	// RecordNotification meters a notification delivered by email or SMS, charged to the farm of
+	// the device of its first alert
+	RecordNotification(ctx context.Context, notification *entities.Notification)
+
+	// CountDevices stores how many devices each farm has today, from which device-days are summed
+	CountDevices(ctx context.Context) error
+
+	// Flush adds the usage metered since the last flush to the stored monthly records
+	Flush(ctx context.Context) error
+
+	// Export returns the usage of every farm in the month of the given time, sorted by farm ID
+	Export(ctx context.Context, month time.Time) ([]*entities.UsageRecord, error)
+
+	// Run flushes metered usage and counts devices periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// usageKey identifies the usage of a farm in a month
+type usageKey struct {
+	farmID string
+	month  time.Time
+}
+
+// useCaseImpl implements the UsageMeteringUseCase interface
+type useCaseImpl struct {
+	usageRepo     repositoryports.UsageRepository
+	deviceRepo    repositoryports.DeviceRepository
+	config        *MeteringConfig
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+
+	mu      sync.Mutex
+	pending map[usageKey]*entities.UsageRecord
+
+	metered  *metrics.Vec
+	failures *metrics.Vec
+}
+
+// NewUsageMeteringUseCase creates a new usage metering use case
+func NewUsageMeteringUseCase(
+	usageRepo repositoryports.UsageRepository,
+	deviceRepo repositoryports.DeviceRepository,
+	config *MeteringConfig,
+	loggerFactory logger.LoggerFactory,
+) UsageMeteringUseCase {
+	if config == nil {
+		config = DefaultMeteringConfig()
+	}
+
+	return &useCaseImpl{
+		usageRepo:     usageRepo,
+		deviceRepo:    deviceRepo,
+		config:        config,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		pending:       make(map[usageKey]*entities.UsageRecord),
+		metered:       metrics.NewCounterVec("usage_metered_total", "Usage metered for the farms by kind", "kind"),
+		failures:      metrics.NewCounterVec("usage_metering_failures_total", "Failed attempts to store metered usage by operation", "operation"),
+	}
+}
+
+// RecordMessage meters a telemetry message and the rows it stored
+func (uc *useCaseImpl) RecordMessage(ctx context.Context, rows int) {
+	storage := int64(rows) * uc.config.StorageBytesPerRow
+	uc.add(eventports.FarmIDFromContext(ctx), func(record *entities.UsageRecord) {
+		record.Messages++
+		record.StorageBytes += storage
+	})
+	uc.metered.Inc("messages")
+	uc.metered.Add(float64(storage), "storage_bytes")
+}
+
+// RecordNotification meters a delivered notification
+func (uc *useCaseImpl) RecordNotification(ctx context.Context, notification *entities.Notification) {
+	if notification == nil || notification.Channel == entities.NotificationChannelInbox {
+		return
+	}
+
+	farmID := ""
+	if len(notification.Alerts) > 0 && notification.Alerts[0].MACAddress != "" {
+		device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(notification.Alerts[0].MACAddress))
+		switch {
+		case err == nil:
+			farmID = device.FarmID
+		case !errors.Is(err, domainerrors.ErrDeviceNotFound):
+			uc.loggerFactory.Core().Warn("usage_notification_farm_unknown",
+				zap.Error(err),
+				zap.String("mac_address", notification.Alerts[0].MACAddress),
+				zap.String("component", "usage_metering_usecase"),
+			)
+		}
+	}
+
+	uc.add(farmID, func(record *entities.UsageRecord) { record.Notifications++ })
+	uc.metered.Inc("notifications")
+}
+
+// CountDevices stores today's device count of every farm
+func (uc *useCaseImpl) CountDevices(ctx context.Context) error {
+	counts, err := uc.deviceRepo.CountByVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count devices: %w", err)
+	}
+
+	devices := make(map[string]int)
+	for _, count := range counts {
+		devices[count.FarmID] += count.Devices
+	}
+	if err := uc.usageRepo.SaveDeviceCounts(ctx, uc.now(), devices); err != nil {
+		return fmt.Errorf("failed to store device counts: %w", err)
+	}
+	return nil
+}
+
+// Flush stores the pending usage; on failure it stays pending for the next flush
+func (uc *useCaseImpl) Flush(ctx context.Context) error {
+	uc.mu.Lock()
+	pending := uc.pending
+	uc.pending = make(map[usageKey]*entities.UsageRecord)
+	uc.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	records := make([]*entities.UsageRecord, 0, len(pending))
+	for _, record := range pending {
+		records = append(records, record)
+	}
+	// A stable order keeps concurrent flushes from deadlocking on the same rows
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].Month.Equal(records[j].Month) {
+			return records[i].Month.Before(records[j].Month)
+		}
+		return records[i].FarmID < records[j].FarmID
+	})
+
+	if err := uc.usageRepo.AddUsage(ctx, records); err != nil {
+		uc.mu.Lock()
+		for key, record := range pending {
+			if current, ok := uc.pending[key]; ok {
+				record.Add(current)
+			}
+			uc.pending[key] = record
+		}
+		uc.mu.Unlock()
+		return fmt.Errorf("failed to store usage: %w", err)
+	}
+	return nil
+}
+
+// Export flushes the pending usage and returns the records of the month
+func (uc *useCaseImpl) Export(ctx context.Context, month time.Time) ([]*entities.UsageRecord, error) {
+	if err := uc.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return uc.usageRepo.ListByMonth(ctx, entities.UsageMonth(month))
+}
+
+// Run flushes and counts devices periodically
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("usage_metering_started", "usage_metering_usecase",
+		zap.Duration("flush_interval", uc.config.FlushInterval),
+		zap.Duration("device_count_interval", uc.config.DeviceCountInterval),
+	)
+
+	ticker := time.NewTicker(uc.config.FlushInterval)
+	defer ticker.Stop()
+
+	var lastCount time.Time
+	for {
+		// Count at least once a day so every day has its device-days
+		now := uc.now()
+		if now.Sub(lastCount) >= uc.config.DeviceCountInterval || lastCount.Before(now.UTC().Truncate(24*time.Hour)) {
+			if err := uc.CountDevices(ctx); err != nil {
+				uc.logFailure(ctx, "count_devices", err)
+			} else {
+				lastCount = now
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("usage_metering_stopped", "usage_metering_usecase")
+			return
+		case <-ticker.C:
+		}
+
+		if err := uc.Flush(ctx); err != nil {
+			uc.logFailure(ctx, "flush", err)
+		}
+	}
+}
+
+// add applies a change to the pending usage of the farm in the current month
+func (uc *useCaseImpl) add(farmID string, change func(record *entities.UsageRecord)) {
+	key := usageKey{farmID: farmID, month: entities.UsageMonth(uc.now())}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	record, ok := uc.pending[key]
+	if !ok {
+		record = &entities.UsageRecord{FarmID: farmID, Month: key.month}
+		uc.pending[key] = record
+	}
+	change(record)
+}
+
+// logFailure counts and logs a failed background operation, unless it failed because of shutdown
+func (uc *useCaseImpl) logFailure(ctx context.Context, operation string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	uc.failures.Inc(operation)
+	uc.loggerFactory.Core().Error("usage_metering_failed",
+		zap.Error(err),
+		zap.String("operation", operation),
+		zap.String("component", "usage_metering_usecase"),
+	)
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	uc.mu.Lock()
+	pending := len(uc.pending)
+	uc.mu.Unlock()
+
+	return append(append(uc.metered.Collect(), uc.failures.Collect()...),
+		metrics.Family{Name: "usage_metering_pending_records", Help: "Farm and month usage records metered but not stored yet", Type: metrics.TypeGauge,
+			Samples: []metrics.Sample{{Value: float64(pending)}}},
+	)
+}
+
+// MetricsCollector returns the usage metering metrics collector
+func MetricsCollector(useCase UsageMeteringUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package usagemetering
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+const testMAC = "AA:BB:CC:DD:EE:FF"
+
+var june = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestUseCase(t *testing.T, usageRepo *mocks.MockUsageRepository, deviceRepo *mocks.MockDeviceRepository) *useCaseImpl {
+	useCase := NewUsageMeteringUseCase(usageRepo, deviceRepo, nil, createTestLoggerFactory(t)).(*useCaseImpl)
+	useCase.now = func() time.Time { return june.Add(14*24*time.Hour + 10*time.Hour) }
+	return useCase
+}
+
+func TestUsageMeteringUseCase_Flush(t *testing.T) {
+	farmCtx := eventports.ContextWithFarmID(context.Background(), "farm-a")
+
+	t.Run("should store the messages and storage of each farm", func(t *testing.T) {
+		usageRepo := mocks.NewMockUsageRepository(t)
+		useCase := newTestUseCase(t, usageRepo, mocks.NewMockDeviceRepository(t))
+
+		useCase.RecordMessage(farmCtx, 1)
+		useCase.RecordMessage(farmCtx, 3)
+		useCase.RecordMessage(context.Background(), 1)
+		usageRepo.EXPECT().AddUsage(mock.Anything, []*entities.UsageRecord{
+			{Month: june, Messages: 1, StorageBytes: 100},
+			{FarmID: "farm-a", Month: june, Messages: 2, StorageBytes: 400},
+		}).Return(nil).Once()
+
+		require.NoError(t, useCase.Flush(context.Background()))
+		require.NoError(t, useCase.Flush(context.Background()), "nothing is left to store")
+		assert.Equal(t, float64(3), useCase.metered.Value("messages"))
+	})
+
+	t.Run("should keep the usage pending when it cannot be stored", func(t *testing.T) {
+		usageRepo := mocks.NewMockUsageRepository(t)
+		useCase := newTestUseCase(t, usageRepo, mocks.NewMockDeviceRepository(t))
+
+		useCase.RecordMessage(farmCtx, 1)
+		usageRepo.EXPECT().AddUsage(mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+		assert.ErrorContains(t, useCase.Flush(context.Background()), "failed to store usage")
+
+		useCase.RecordMessage(farmCtx, 1)
+		usageRepo.EXPECT().AddUsage(mock.Anything, []*entities.UsageRecord{
+			{FarmID: "farm-a", Month: june, Messages: 2, StorageBytes: 200},
+		}).Return(nil).Once()
+		require.NoError(t, useCase.Flush(context.Background()))
+	})
+}
+
+func TestUsageMeteringUseCase_RecordNotification(t *testing.T) {
+	usageRepo := mocks.NewMockUsageRepository(t)
+	deviceRepo := mocks.NewMockDeviceRepository(t)
+	useCase := newTestUseCase(t, usageRepo, deviceRepo)
+	device, err := entities.NewDevice(testMAC, "Sensor", "192.168.1.10", "Greenhouse")
+	require.NoError(t, err)
+	require.NoError(t, device.AssignFarm("farm-a"))
+
+	deviceRepo.EXPECT().FindByMACAddress(mock.Anything, testMAC).Return(device, nil).Once()
+	useCase.RecordNotification(context.Background(), &entities.Notification{Channel: entities.NotificationChannelEmail, Alerts: []*entities.Alert{{MACAddress: "aa:bb:cc:dd:ee:ff"}}})
+	deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "11:22:33:44:55:66").Return(nil, domainerrors.ErrDeviceNotFound).Once()
+	useCase.RecordNotification(context.Background(), &entities.Notification{Channel: entities.NotificationChannelSMS, Alerts: []*entities.Alert{{MACAddress: "11:22:33:44:55:66"}}})
+	useCase.RecordNotification(context.Background(), &entities.Notification{Channel: entities.NotificationChannelSMS, Alerts: []*entities.Alert{{Rule: "composite"}}})
+	useCase.RecordNotification(context.Background(), &entities.Notification{Channel: entities.NotificationChannelInbox, Alerts: []*entities.Alert{{MACAddress: testMAC}}})
+
+	usageRepo.EXPECT().AddUsage(mock.Anything, []*entities.UsageRecord{
+		{Month: june, Notifications: 2},
+		{FarmID: "farm-a", Month: june, Notifications: 1},
+	}).Return(nil).Once()
+	require.NoError(t, useCase.Flush(context.Background()))
+}
+
+func TestUsageMeteringUseCase_CountDevices(t *testing.T) {
+	usageRepo := mocks.NewMockUsageRepository(t)
+	deviceRepo := mocks.NewMockDeviceRepository(t)
+	useCase := newTestUseCase(t, usageRepo, deviceRepo)
+
+	deviceRepo.EXPECT().CountByVersion(mock.Anything).Return([]*entities.FleetVersionCount{
+		{FarmID: "farm-a", FirmwareVersion: "1.4.0", Devices: 10},
+		{FarmID: "farm-a", FirmwareVersion: "1.5.0", Devices: 2},
+		{Devices: 3},
+	}, nil).Once()
+	usageRepo.EXPECT().SaveDeviceCounts(mock.Anything, useCase.now(), map[string]int{"farm-a": 12, "": 3}).Return(nil).Once()
+
+	require.NoError(t, useCase.CountDevices(context.Background()))
+}
+
+func TestUsageMeteringUseCase_Export(t *testing.T) {
+	usageRepo := mocks.NewMockUsageRepository(t)
+	useCase := newTestUseCase(t, usageRepo, mocks.NewMockDeviceRepository(t))
+	records := []*entities.UsageRecord{{FarmID: "farm-a", Month: june, DeviceDays: 360, Messages: 1}}
+
+	useCase.RecordMessage(eventports.ContextWithFarmID(context.Background(), "farm-a"), 1)
+	usageRepo.EXPECT().AddUsage(mock.Anything, mock.Anything).Return(nil).Once()
+	usageRepo.EXPECT().ListByMonth(mock.Anything, june).Return(records, nil).Once()
+
+	exported, err := useCase.Export(context.Background(), june.Add(72*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, records, exported)
+}
+
+func TestMeteredMeasurementUseCase(t *testing.T) {
+	metering := mocks.NewMockUsageMeteringUseCase(t)
+	inner := mocks.NewMockMeasurementUseCase(t)
+	useCase := NewMeteredMeasurementUseCase(inner, metering)
+	values := []*entities.Measurement{
+		{MACAddress: testMAC, SensorType: "soil_moisture", Channel: 0, Value: 41},
+		{MACAddress: testMAC, SensorType: "soil_moisture", Channel: 1, Value: 38},
+	}
+
+	inner.EXPECT().StoreMeasurements(mock.Anything, values).Return(errors.New("database unavailable")).Once()
+	assert.Error(t, useCase.StoreMeasurements(context.Background(), values), "failed messages are not metered")
+
+	inner.EXPECT().StoreMeasurements(mock.Anything, values).Return(nil).Once()
+	metering.EXPECT().RecordMessage(mock.Anything, 2).Return().Once()
+	assert.NoError(t, useCase.StoreMeasurements(context.Background(), values))
+}
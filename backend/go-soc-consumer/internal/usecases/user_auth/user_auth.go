@@ -0,0 +1,151 @@
+package userauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// UserAuthUseCase authenticates the users of the HTTP API. Users log in with their password in
+// exchange for an access token, which then authenticates their requests.
+type UserAuthUseCase interface {
+	// Login checks the password of the user and issues an access token, failing with
+	// ErrInvalidCredentials for unknown users and wrong passwords alike
+	Login(ctx context.Context, username, password string) (*entities.User, *entities.AuthToken, error)
+
+	// Authenticate returns the claims of a valid access token, failing with ErrInvalidToken or
+	// ErrTokenExpired
+	Authenticate(token string) (*entities.AuthClaims, error)
+
+	// ListUsers returns every user sorted by username
+	ListUsers(ctx context.Context) ([]*entities.User, error)
+
+	// CreateUser adds a user, failing with ErrInvalidUser or ErrUserAlreadyExists
+	CreateUser(ctx context.Context, request entities.UserRequest) (*entities.User, error)
+
+	// DeleteUser removes a user, failing with ErrUserNotFound. Tokens already issued to it stay
+	// valid until they expire.
+	DeleteUser(ctx context.Context, username string) error
+}
+
+// useCaseImpl implements the UserAuthUseCase interface
+type useCaseImpl struct {
+	userRepo      repositoryports.UserRepository
+	tokenIssuer   ports.TokenIssuer
+	loggerFactory logger.LoggerFactory
+	now           func() time.Time
+
+	// decoy is checked when the user does not exist, so unknown usernames take as long as wrong passwords
+	decoy *entities.User
+}
+
+// NewUserAuthUseCase creates a user authentication use case issuing tokens with the given issuer
+func NewUserAuthUseCase(
+	userRepo repositoryports.UserRepository,
+	tokenIssuer ports.TokenIssuer,
+	loggerFactory logger.LoggerFactory,
+) (UserAuthUseCase, error) {
+	decoy, err := entities.NewUser(entities.UserRequest{Username: "decoy", Password: uuid.New().String()}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decoy user: %w", err)
+	}
+
+	return &useCaseImpl{
+		userRepo:      userRepo,
+		tokenIssuer:   tokenIssuer,
+		loggerFactory: loggerFactory,
+		now:           time.Now,
+		decoy:         decoy,
+	}, nil
+}
+
+// Login checks the credentials and issues a token
+func (uc *useCaseImpl) Login(ctx context.Context, username, password string) (*entities.User, *entities.AuthToken, error) {
+	username = strings.ToLower(strings.TrimSpace(username))
+	user, err := uc.userRepo.FindByUsername(ctx, username)
+	if errors.Is(err, domainerrors.ErrUserNotFound) {
+		uc.decoy.CheckPassword(password)
+		uc.logFailedLogin(username, "unknown_user")
+		return nil, nil, domainerrors.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !user.CheckPassword(password) {
+		uc.logFailedLogin(username, "wrong_password")
+		return nil, nil, domainerrors.ErrInvalidCredentials
+	}
+
+	token, err := uc.tokenIssuer.Issue(user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uc.loggerFactory.Core().Info("user_logged_in",
+		zap.String("username", user.Username),
+		zap.String("role", user.Role),
+		zap.String("component", "user_auth_usecase"),
+	)
+	return user, token, nil
+}
+
+// Authenticate verifies the token
+func (uc *useCaseImpl) Authenticate(token string) (*entities.AuthClaims, error) {
+	return uc.tokenIssuer.Verify(token)
+}
+
+// ListUsers loads every user
+func (uc *useCaseImpl) ListUsers(ctx context.Context) ([]*entities.User, error) {
+	return uc.userRepo.List(ctx)
+}
+
+// CreateUser validates and stores a user
+func (uc *useCaseImpl) CreateUser(ctx context.Context, request entities.UserRequest) (*entities.User, error) {
+	user, err := entities.NewUser(request, uc.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidUser, err)
+	}
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	uc.loggerFactory.Core().Info("user_created",
+		zap.String("username", user.Username),
+		zap.String("role", user.Role),
+		zap.String("component", "user_auth_usecase"),
+	)
+	return user, nil
+}
+
+// DeleteUser removes a user
+func (uc *useCaseImpl) DeleteUser(ctx context.Context, username string) error {
+	username = strings.ToLower(strings.TrimSpace(username))
+	if err := uc.userRepo.Delete(ctx, username); err != nil {
+		return err
+	}
+
+	uc.loggerFactory.Core().Info("user_deleted",
+		zap.String("username", username),
+		zap.String("component", "user_auth_usecase"),
+	)
+	return nil
+}
+
+func (uc *useCaseImpl) logFailedLogin(username, reason string) {
+	uc.loggerFactory.Core().Warn("user_login_failed",
+		zap.String("username", username),
+		zap.String("reason", reason),
+		zap.String("component", "user_auth_usecase"),
+	)
+}
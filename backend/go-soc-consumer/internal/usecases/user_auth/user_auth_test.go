@@ -0,0 +1,80 @@
+package userauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestUseCase(t *testing.T) (*useCaseImpl, *mocks.MockUserRepository, *mocks.MockTokenIssuer) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	repo := mocks.NewMockUserRepository(t)
+	tokenIssuer := mocks.NewMockTokenIssuer(t)
+	useCase, err := NewUserAuthUseCase(repo, tokenIssuer, loggerFactory)
+	require.NoError(t, err)
+
+	impl := useCase.(*useCaseImpl)
+	impl.now = func() time.Time { return testNow }
+	return impl, repo, tokenIssuer
+}
+
+func TestUserAuthUseCase_Login(t *testing.T) {
+	user, err := entities.NewUser(entities.UserRequest{Username: "maria", Password: "regando-2025", Role: entities.UserRoleAdmin}, testNow)
+	require.NoError(t, err)
+
+	t.Run("should issue a token for valid credentials", func(t *testing.T) {
+		useCase, repo, tokenIssuer := newTestUseCase(t)
+		token := &entities.AuthToken{Token: "signed", ExpiresAt: testNow.Add(time.Hour)}
+		repo.EXPECT().FindByUsername(mock.Anything, "maria").Return(user, nil).Once()
+		tokenIssuer.EXPECT().Issue(user).Return(token, nil).Once()
+
+		loggedIn, issued, err := useCase.Login(context.Background(), " Maria ", "regando-2025")
+		require.NoError(t, err)
+		assert.Equal(t, user, loggedIn)
+		assert.Equal(t, token, issued)
+	})
+
+	t.Run("should reject wrong passwords and unknown users alike", func(t *testing.T) {
+		useCase, repo, _ := newTestUseCase(t)
+		repo.EXPECT().FindByUsername(mock.Anything, "maria").Return(user, nil).Once()
+		repo.EXPECT().FindByUsername(mock.Anything, "pedro").Return(nil, domainerrors.ErrUserNotFound).Once()
+
+		_, _, err := useCase.Login(context.Background(), "maria", "regando-2024")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidCredentials)
+
+		_, _, err = useCase.Login(context.Background(), "pedro", "regando-2025")
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidCredentials)
+	})
+}
+
+func TestUserAuthUseCase_CreateUser(t *testing.T) {
+	t.Run("should store the user", func(t *testing.T) {
+		useCase, repo, _ := newTestUseCase(t)
+		repo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*entities.User")).Return(nil).Once()
+
+		user, err := useCase.CreateUser(context.Background(), entities.UserRequest{Username: "maria", Password: "regando-2025"})
+		require.NoError(t, err)
+		assert.Equal(t, entities.UserRoleViewer, user.Role)
+		assert.Equal(t, testNow, user.CreatedAt)
+	})
+
+	t.Run("should reject invalid users", func(t *testing.T) {
+		useCase, _, _ := newTestUseCase(t)
+
+		_, err := useCase.CreateUser(context.Background(), entities.UserRequest{Username: "maria", Password: "short"})
+		assert.ErrorIs(t, err, domainerrors.ErrInvalidUser)
+	})
+}
@@ -0,0 +1,28 @@
+package valvecontrol
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	devicecommands "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_commands"
+)
+
+// valveTrackingCommandsUseCase records the valve transitions of the acknowledged commands
+type valveTrackingCommandsUseCase struct {
+	devicecommands.DeviceCommandsUseCase
+	valves ValveControlUseCase
+}
+
+// NewValveTrackingCommandsUseCase wraps a device commands use case so acknowledged valve commands
+// update the valve state, whichever endpoint sent them
+func NewValveTrackingCommandsUseCase(inner devicecommands.DeviceCommandsUseCase, valves ValveControlUseCase) devicecommands.DeviceCommandsUseCase {
+	return &valveTrackingCommandsUseCase{DeviceCommandsUseCase: inner, valves: valves}
+}
+
+// Acknowledge completes the command and records the valve transition it caused
+func (uc *valveTrackingCommandsUseCase) Acknowledge(ctx context.Context, ack *entities.DeviceCommandAck) error {
+	if err := uc.DeviceCommandsUseCase.Acknowledge(ctx, ack); err != nil {
+		return err
+	}
+	return uc.valves.RecordAcknowledgement(ctx, ack.CommandID)
+}
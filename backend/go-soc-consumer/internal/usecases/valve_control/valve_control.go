@@ -0,0 +1,121 @@
+package valvecontrol
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	devicecommands "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/usecases/device_commands"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+// ValveControlUseCase opens and closes the irrigation valves of devices. The commands go out as
+// device commands, and the valve state only changes once the device acknowledges them.
+type ValveControlUseCase interface {
+	// Open sends the valve_open command to the device, failing with ErrDeviceNotFound for an
+	// unknown device
+	Open(ctx context.Context, macAddress string) (*entities.DeviceCommand, error)
+
+	// Close sends the valve_close command to the device, failing with ErrDeviceNotFound for an
+	// unknown device
+	Close(ctx context.Context, macAddress string) (*entities.DeviceCommand, error)
+
+	// State returns the last acknowledged transition of the valve of the device, in the unknown
+	// state when it was never actuated. An unknown device fails with ErrDeviceNotFound.
+	State(ctx context.Context, macAddress string) (*entities.ValveTransition, error)
+
+	// History returns up to limit transitions of the valve of the device, newest first
+	History(ctx context.Context, macAddress string, limit int) ([]*entities.ValveTransition, error)
+
+	// RecordAcknowledgement records the transition caused by an acknowledged valve command. Other
+	// commands, and valve commands that failed or expired, are ignored.
+	RecordAcknowledgement(ctx context.Context, commandID string) error
+}
+
+// useCaseImpl implements the ValveControlUseCase interface
+type useCaseImpl struct {
+	deviceRepo    repositoryports.DeviceRepository
+	commandRepo   repositoryports.DeviceCommandRepository
+	valveRepo     repositoryports.ValveStateRepository
+	commands      devicecommands.DeviceCommandsUseCase
+	loggerFactory logger.LoggerFactory
+}
+
+// NewValveControlUseCase creates a new valve control use case
+func NewValveControlUseCase(
+	deviceRepo repositoryports.DeviceRepository,
+	commandRepo repositoryports.DeviceCommandRepository,
+	valveRepo repositoryports.ValveStateRepository,
+	commands devicecommands.DeviceCommandsUseCase,
+	loggerFactory logger.LoggerFactory,
+) ValveControlUseCase {
+	return &useCaseImpl{
+		deviceRepo:    deviceRepo,
+		commandRepo:   commandRepo,
+		valveRepo:     valveRepo,
+		commands:      commands,
+		loggerFactory: loggerFactory,
+	}
+}
+
+// Open sends the valve_open command
+func (uc *useCaseImpl) Open(ctx context.Context, macAddress string) (*entities.DeviceCommand, error) {
+	return uc.commands.Send(ctx, macAddress, entities.DeviceCommandRequest{Command: entities.DeviceCommandValveOpen})
+}
+
+// Close sends the valve_close command
+func (uc *useCaseImpl) Close(ctx context.Context, macAddress string) (*entities.DeviceCommand, error) {
+	return uc.commands.Send(ctx, macAddress, entities.DeviceCommandRequest{Command: entities.DeviceCommandValveClose})
+}
+
+// State returns the current state of the valve of a known device
+func (uc *useCaseImpl) State(ctx context.Context, macAddress string) (*entities.ValveTransition, error) {
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, strings.ToUpper(strings.TrimSpace(macAddress)))
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := uc.valveRepo.Latest(ctx, device.GetID())
+	if err != nil {
+		return nil, err
+	}
+	if transition == nil {
+		return &entities.ValveTransition{MACAddress: device.GetID(), State: entities.ValveStateUnknown}, nil
+	}
+	return transition, nil
+}
+
+// History returns the latest transitions of the valve of the device
+func (uc *useCaseImpl) History(ctx context.Context, macAddress string, limit int) ([]*entities.ValveTransition, error) {
+	return uc.valveRepo.ListByDevice(ctx, strings.ToUpper(strings.TrimSpace(macAddress)), limit)
+}
+
+// RecordAcknowledgement records the transition of an acknowledged valve command
+func (uc *useCaseImpl) RecordAcknowledgement(ctx context.Context, commandID string) error {
+	command, err := uc.commandRepo.FindByID(ctx, commandID)
+	if err != nil {
+		return err
+	}
+	transition, ok := entities.ValveTransitionFor(command)
+	if !ok {
+		return nil
+	}
+
+	recorded, err := uc.valveRepo.Record(ctx, transition)
+	if err != nil {
+		return fmt.Errorf("failed to record valve state: %w", err)
+	}
+	if recorded {
+		uc.loggerFactory.Core().Info("valve_state_changed",
+			zap.String("mac_address", transition.MACAddress),
+			zap.String("state", transition.State),
+			zap.String("command_id", transition.CommandID),
+			zap.String("component", "valve_control_usecase"),
+		)
+	}
+	return nil
+}
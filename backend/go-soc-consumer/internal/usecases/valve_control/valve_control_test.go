@@ -0,0 +1,140 @@
+package valvecontrol
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+type testDeps struct {
+	deviceRepo  *mocks.MockDeviceRepository
+	commandRepo *mocks.MockDeviceCommandRepository
+	valveRepo   *mocks.MockValveStateRepository
+	commands    *mocks.MockDeviceCommandsUseCase
+}
+
+func newTestUseCase(t *testing.T) (ValveControlUseCase, testDeps) {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+
+	deps := testDeps{
+		deviceRepo:  mocks.NewMockDeviceRepository(t),
+		commandRepo: mocks.NewMockDeviceCommandRepository(t),
+		valveRepo:   mocks.NewMockValveStateRepository(t),
+		commands:    mocks.NewMockDeviceCommandsUseCase(t),
+	}
+	return NewValveControlUseCase(deps.deviceRepo, deps.commandRepo, deps.valveRepo, deps.commands, loggerFactory), deps
+}
+
+func TestValveControlUseCase_OpenClose(t *testing.T) {
+	useCase, deps := newTestUseCase(t)
+	opened := &entities.DeviceCommand{ID: "command-1", Command: entities.DeviceCommandValveOpen, Status: entities.DeviceCommandStatusSent}
+	deps.commands.EXPECT().Send(mock.Anything, "AA:BB:CC:DD:EE:FF", entities.DeviceCommandRequest{Command: entities.DeviceCommandValveOpen}).Return(opened, nil).Once()
+	deps.commands.EXPECT().Send(mock.Anything, "AA:BB:CC:DD:EE:FF", entities.DeviceCommandRequest{Command: entities.DeviceCommandValveClose}).Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+	command, err := useCase.Open(context.Background(), "AA:BB:CC:DD:EE:FF")
+	require.NoError(t, err)
+	assert.Equal(t, opened, command)
+
+	_, err = useCase.Close(context.Background(), "AA:BB:CC:DD:EE:FF")
+	assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+}
+
+func TestValveControlUseCase_State(t *testing.T) {
+	device, err := entities.NewDevice("AA:BB:CC:DD:EE:FF", "Valve", "10.0.0.1", "Greenhouse")
+	require.NoError(t, err)
+
+	t.Run("should return the last transition", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		last := &entities.ValveTransition{CommandID: "command-1", MACAddress: "AA:BB:CC:DD:EE:FF", State: entities.ValveStateOpen, ChangedAt: time.Now()}
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil).Once()
+		deps.valveRepo.EXPECT().Latest(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(last, nil).Once()
+
+		state, err := useCase.State(context.Background(), "aa:bb:cc:dd:ee:ff")
+		require.NoError(t, err)
+		assert.Equal(t, last, state)
+	})
+
+	t.Run("should report an unknown state for a valve never actuated", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(device, nil).Once()
+		deps.valveRepo.EXPECT().Latest(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(nil, nil).Once()
+
+		state, err := useCase.State(context.Background(), "AA:BB:CC:DD:EE:FF")
+		require.NoError(t, err)
+		assert.Equal(t, &entities.ValveTransition{MACAddress: "AA:BB:CC:DD:EE:FF", State: entities.ValveStateUnknown}, state)
+	})
+
+	t.Run("should fail for an unknown device", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:FF").Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+		_, err := useCase.State(context.Background(), "AA:BB:CC:DD:EE:FF")
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceNotFound)
+	})
+}
+
+func TestValveControlUseCase_RecordAcknowledgement(t *testing.T) {
+	ackedAt := time.Date(2025, 6, 1, 6, 0, 5, 0, time.UTC)
+	command := func(name, status string) *entities.DeviceCommand {
+		return &entities.DeviceCommand{ID: "command-1", MACAddress: "AA:BB:CC:DD:EE:FF", Command: name, Status: status, AckedAt: &ackedAt}
+	}
+
+	t.Run("should record the transition of an acknowledged valve command", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.commandRepo.EXPECT().FindByID(mock.Anything, "command-1").Return(command(entities.DeviceCommandValveClose, entities.DeviceCommandStatusAcknowledged), nil).Once()
+		deps.valveRepo.EXPECT().Record(mock.Anything, &entities.ValveTransition{
+			CommandID: "command-1", MACAddress: "AA:BB:CC:DD:EE:FF", State: entities.ValveStateClosed, ChangedAt: ackedAt,
+		}).Return(true, nil).Once()
+
+		assert.NoError(t, useCase.RecordAcknowledgement(context.Background(), "command-1"))
+	})
+
+	t.Run("should ignore failed valve commands and other commands", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.commandRepo.EXPECT().FindByID(mock.Anything, "command-1").Return(command(entities.DeviceCommandValveOpen, entities.DeviceCommandStatusExpired), nil).Once()
+		deps.commandRepo.EXPECT().FindByID(mock.Anything, "command-2").Return(command(entities.DeviceCommandIdentify, entities.DeviceCommandStatusAcknowledged), nil).Once()
+
+		assert.NoError(t, useCase.RecordAcknowledgement(context.Background(), "command-1"))
+		assert.NoError(t, useCase.RecordAcknowledgement(context.Background(), "command-2"))
+	})
+
+	t.Run("should fail when the transition cannot be stored", func(t *testing.T) {
+		useCase, deps := newTestUseCase(t)
+		deps.commandRepo.EXPECT().FindByID(mock.Anything, "command-1").Return(command(entities.DeviceCommandValveOpen, entities.DeviceCommandStatusAcknowledged), nil).Once()
+		deps.valveRepo.EXPECT().Record(mock.Anything, mock.Anything).Return(false, errors.New("connection refused")).Once()
+
+		assert.ErrorContains(t, useCase.RecordAcknowledgement(context.Background(), "command-1"), "failed to record valve state")
+	})
+}
+
+func TestValveTrackingCommandsUseCase_Acknowledge(t *testing.T) {
+	ack := &entities.DeviceCommandAck{CommandID: "command-1", MACAddress: "AA:BB:CC:DD:EE:FF", Success: true}
+
+	t.Run("should record the valve state once acknowledged", func(t *testing.T) {
+		inner := mocks.NewMockDeviceCommandsUseCase(t)
+		valves := mocks.NewMockValveControlUseCase(t)
+		inner.EXPECT().Acknowledge(mock.Anything, ack).Return(nil).Once()
+		valves.EXPECT().RecordAcknowledgement(mock.Anything, "command-1").Return(nil).Once()
+
+		assert.NoError(t, NewValveTrackingCommandsUseCase(inner, valves).Acknowledge(context.Background(), ack))
+	})
+
+	t.Run("should not record rejected acknowledgements", func(t *testing.T) {
+		inner := mocks.NewMockDeviceCommandsUseCase(t)
+		inner.EXPECT().Acknowledge(mock.Anything, ack).Return(domainerrors.ErrDeviceCommandNotFound).Once()
+
+		err := NewValveTrackingCommandsUseCase(inner, mocks.NewMockValveControlUseCase(t)).Acknowledge(context.Background(), ack)
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceCommandNotFound)
+	})
+}
@@ -0,0 +1,413 @@
+package wifidiagnostics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	repositoryports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/repositories"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
+)
+
+// WifiDiagnosticsConfig holds the thresholds of the Wi-Fi advisories
+type WifiDiagnosticsConfig struct {
+	RSSIThreshold      int           // devices below this RSSI in dBm have a weak signal
+	ReconnectThreshold int           // devices reconnecting this many times between two reports have an unstable link
+	MinDevices         int           // devices of a location that must be concerned before an advisory is raised
+	MaxAge             time.Duration // reports older than this are forgotten
+	Interval           time.Duration // how often advisories are evaluated
+}
+
+// DefaultWifiDiagnosticsConfig returns default configuration
+func DefaultWifiDiagnosticsConfig() *WifiDiagnosticsConfig {
+	return &WifiDiagnosticsConfig{
+		RSSIThreshold:      -80,
+		ReconnectThreshold: 5,
+		MinDevices:         1,
+		MaxAge:             24 * time.Hour,
+		Interval:           time.Minute,
+	}
+}
+
+// WifiDiagnosticsUseCase aggregates the Wi-Fi diagnostics of devices by location and raises advisories
+// to guide the placement of access points. Reports are kept in memory and lost on restart.
+type WifiDiagnosticsUseCase interface {
+	// Ingest keeps the diagnostics as the latest of a registered device. An unknown device fails with
+	// ErrDeviceNotFound and a device reporting from the namespace of another farm with ErrDeviceFarmMismatch.
+	Ingest(ctx context.Context, diagnostics *entities.WifiDiagnostics) error
+
+	// Locations returns the Wi-Fi link of the devices of every location, ordered by farm and location
+	Locations() []*entities.WifiLocationStats
+
+	// Advisories returns the advisories of the last evaluation, most devices first
+	Advisories() []*entities.WifiAdvisory
+
+	// Evaluate refreshes the advisories and raises an alert for every advisory appearing or clearing
+	Evaluate(ctx context.Context)
+
+	// Run evaluates the advisories periodically until the context is cancelled
+	Run(ctx context.Context)
+}
+
+// deviceReport is the latest diagnostics of a device with where the device is
+type deviceReport struct {
+	diagnostics *entities.WifiDiagnostics
+	farmID      string
+	location    string
+}
+
+// locationKey identifies the location of a farm devices are grouped by
+type locationKey struct {
+	farmID   string
+	location string
+}
+
+// useCaseImpl implements the WifiDiagnosticsUseCase interface
+type useCaseImpl struct {
+	deviceRepo     repositoryports.DeviceRepository
+	config         *WifiDiagnosticsConfig
+	eventPublisher eventports.EventPublisher
+	notifier       ports.AlertNotifier
+	loggerFactory  logger.LoggerFactory
+	now            func() time.Time
+
+	mu         sync.Mutex
+	reports    map[string]*deviceReport          // MAC address to the latest report of the device
+	advisories map[string]*entities.WifiAdvisory // key to the advisory raised
+
+	reportsTotal     *metrics.Vec
+	advisoriesActive *metrics.Vec
+}
+
+// NewWifiDiagnosticsUseCase creates a Wi-Fi diagnostics use case; eventPublisher and notifier are optional
+func NewWifiDiagnosticsUseCase(
+	deviceRepo repositoryports.DeviceRepository,
+	config *WifiDiagnosticsConfig,
+	eventPublisher eventports.EventPublisher,
+	notifier ports.AlertNotifier,
+	loggerFactory logger.LoggerFactory,
+) WifiDiagnosticsUseCase {
+	if config == nil {
+		config = DefaultWifiDiagnosticsConfig()
+	}
+
+	return &useCaseImpl{
+		deviceRepo:       deviceRepo,
+		config:           config,
+		eventPublisher:   eventPublisher,
+		notifier:         notifier,
+		loggerFactory:    loggerFactory,
+		now:              time.Now,
+		reports:          make(map[string]*deviceReport),
+		advisories:       make(map[string]*entities.WifiAdvisory),
+		reportsTotal:     metrics.NewCounterVec("wifi_diagnostics_reports_total", "Wi-Fi diagnostics received from devices"),
+		advisoriesActive: metrics.NewGaugeVec("wifi_advisories_active", "Locations with a Wi-Fi advisory by kind", "kind"),
+	}
+}
+
+// Ingest keeps the diagnostics of a known device along with its farm and location
+func (uc *useCaseImpl) Ingest(ctx context.Context, diagnostics *entities.WifiDiagnostics) error {
+	if err := domainerrors.RequireInput("wifi diagnostics", diagnostics); err != nil {
+		return err
+	}
+
+	device, err := uc.deviceRepo.FindByMACAddress(ctx, diagnostics.MACAddress)
+	if err != nil {
+		return err
+	}
+	if err := device.VerifyFarm(eventports.FarmIDFromContext(ctx)); err != nil {
+		return fmt.Errorf("%w: %v", domainerrors.ErrDeviceFarmMismatch, err)
+	}
+
+	uc.mu.Lock()
+	uc.reports[diagnostics.MACAddress] = &deviceReport{
+		diagnostics: diagnostics,
+		farmID:      device.FarmID,
+		location:    device.LocationDescription,
+	}
+	uc.mu.Unlock()
+	uc.reportsTotal.Inc()
+	return nil
+}
+
+// Locations aggregates the latest report of every device by location
+func (uc *useCaseImpl) Locations() []*entities.WifiLocationStats {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	groups := uc.groupLocked(uc.now())
+	stats := make([]*entities.WifiLocationStats, 0, len(groups))
+	for key, reports := range groups {
+		stats = append(stats, uc.locationStats(key, reports))
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].FarmID != stats[j].FarmID {
+			return stats[i].FarmID < stats[j].FarmID
+		}
+		return stats[i].Location < stats[j].Location
+	})
+	return stats
+}
+
+// Advisories returns the advisories raised by the last evaluation
+func (uc *useCaseImpl) Advisories() []*entities.WifiAdvisory {
+	uc.mu.Lock()
+	advisories := make([]*entities.WifiAdvisory, 0, len(uc.advisories))
+	for _, advisory := range uc.advisories {
+		advisories = append(advisories, advisory)
+	}
+	uc.mu.Unlock()
+
+	sort.Slice(advisories, func(i, j int) bool {
+		if len(advisories[i].Devices) != len(advisories[j].Devices) {
+			return len(advisories[i].Devices) > len(advisories[j].Devices)
+		}
+		return advisories[i].Key() < advisories[j].Key()
+	})
+	return advisories
+}
+
+// Evaluate compares the advisories of the current reports with the ones raised before, raising a
+// firing alert for the new ones and a resolved alert for the ones that cleared
+func (uc *useCaseImpl) Evaluate(ctx context.Context) {
+	now := uc.now()
+	var raised []*entities.Alert
+
+	uc.mu.Lock()
+	current := make(map[string]*entities.WifiAdvisory)
+	for key, reports := range uc.groupLocked(now) {
+		for _, advisory := range uc.advisoriesOf(key, reports, now) {
+			current[advisory.Key()] = advisory
+		}
+	}
+	for key, advisory := range current {
+		if previous, ok := uc.advisories[key]; ok {
+			advisory.Since = previous.Since
+			continue
+		}
+		if alert := uc.newAlert(advisory, entities.AlertStatusFiring, now, advisory.Description); alert != nil {
+			alert.LocalizedDescriptions = advisory.LocalizedDescriptions
+			raised = append(raised, alert)
+		}
+	}
+	for key, advisory := range uc.advisories {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		format := "Wi-Fi advisory %s in %s cleared"
+		if alert := uc.newAlert(advisory, entities.AlertStatusResolved, now, fmt.Sprintf(format, advisory.Kind, advisory.Location)); alert != nil {
+			alert.LocalizedDescriptions = i18n.SprintfAll(format, advisory.Kind, advisory.Location)
+			raised = append(raised, alert)
+		}
+	}
+	uc.advisories = current
+
+	counts := map[string]int{
+		entities.WifiAdvisoryWeakSignal:         0,
+		entities.WifiAdvisoryUnstableConnection: 0,
+		entities.WifiAdvisoryOverlappingChannel: 0,
+	}
+	for _, advisory := range current {
+		counts[advisory.Kind]++
+	}
+	for kind, count := range counts {
+		uc.advisoriesActive.Set(float64(count), kind)
+	}
+	uc.mu.Unlock()
+
+	for _, alert := range raised {
+		uc.publish(ctx, alert)
+	}
+}
+
+// Run evaluates the advisories periodically until the context is cancelled
+func (uc *useCaseImpl) Run(ctx context.Context) {
+	uc.loggerFactory.Application().LogApplicationEvent("wifi_advisories_started", "wifi_diagnostics_usecase",
+		zap.Duration("interval", uc.config.Interval),
+		zap.Int("rssi_threshold", uc.config.RSSIThreshold),
+	)
+
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			uc.loggerFactory.Application().LogApplicationEvent("wifi_advisories_stopped", "wifi_diagnostics_usecase")
+			return
+		case <-ticker.C:
+			uc.Evaluate(ctx)
+		}
+	}
+}
+
+// Collect implements metrics.Collector
+func (uc *useCaseImpl) Collect() []metrics.Family {
+	return append(uc.reportsTotal.Collect(), uc.advisoriesActive.Collect()...)
+}
+
+// MetricsCollector returns the Wi-Fi diagnostics metrics collector
+func MetricsCollector(useCase WifiDiagnosticsUseCase) metrics.Collector {
+	if collector, ok := useCase.(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
+
+// groupLocked forgets the reports older than the maximum age and groups the others by location,
+// ordered by MAC address; uc.mu must be held
+func (uc *useCaseImpl) groupLocked(now time.Time) map[locationKey][]*deviceReport {
+	groups := make(map[locationKey][]*deviceReport)
+	for macAddress, report := range uc.reports {
+		if now.Sub(report.diagnostics.ReportedAt) > uc.config.MaxAge {
+			delete(uc.reports, macAddress)
+			continue
+		}
+		key := locationKey{farmID: report.farmID, location: report.location}
+		groups[key] = append(groups[key], report)
+	}
+	for _, reports := range groups {
+		sort.Slice(reports, func(i, j int) bool {
+			return reports[i].diagnostics.MACAddress < reports[j].diagnostics.MACAddress
+		})
+	}
+	return groups
+}
+
+// locationStats aggregates the reports of a location
+func (uc *useCaseImpl) locationStats(key locationKey, reports []*deviceReport) *entities.WifiLocationStats {
+	stats := &entities.WifiLocationStats{
+		FarmID:            key.farmID,
+		Location:          key.location,
+		Devices:           len(reports),
+		WeakSignalDevices: make([]string, 0),
+		SSIDs:             make([]string, 0),
+		Channels:          make([]int, 0),
+	}
+	seenSSIDs, seenChannels := make(map[string]bool), make(map[int]bool)
+	total := 0
+	for _, report := range reports {
+		diagnostics := report.diagnostics
+		total += diagnostics.RSSI
+		if diagnostics.RSSI < stats.WeakestRSSI {
+			stats.WeakestRSSI = diagnostics.RSSI
+		}
+		if diagnostics.RSSI < uc.config.RSSIThreshold {
+			stats.WeakSignalDevices = append(stats.WeakSignalDevices, diagnostics.MACAddress)
+		}
+		stats.Reconnects += diagnostics.ReconnectCount
+		if diagnostics.SSID != "" && !seenSSIDs[diagnostics.SSID] {
+			seenSSIDs[diagnostics.SSID] = true
+			stats.SSIDs = append(stats.SSIDs, diagnostics.SSID)
+		}
+		if diagnostics.Channel != 0 && !seenChannels[diagnostics.Channel] {
+			seenChannels[diagnostics.Channel] = true
+			stats.Channels = append(stats.Channels, diagnostics.Channel)
+		}
+		if diagnostics.ReportedAt.After(stats.LastReportAt) {
+			stats.LastReportAt = diagnostics.ReportedAt
+		}
+	}
+	stats.AverageRSSI = float64(total) / float64(len(reports))
+	sort.Strings(stats.SSIDs)
+	sort.Ints(stats.Channels)
+	return stats
+}
+
+// advisoriesOf returns the advisories of a location whose devices cross a threshold
+func (uc *useCaseImpl) advisoriesOf(key locationKey, reports []*deviceReport, now time.Time) []*entities.WifiAdvisory {
+	var weak, unstable, overlapping []string
+	for _, report := range reports {
+		diagnostics := report.diagnostics
+		if diagnostics.RSSI < uc.config.RSSIThreshold {
+			weak = append(weak, diagnostics.MACAddress)
+		}
+		if diagnostics.ReconnectCount >= uc.config.ReconnectThreshold {
+			unstable = append(unstable, diagnostics.MACAddress)
+		}
+		if diagnostics.OverlappingChannel() {
+			overlapping = append(overlapping, diagnostics.MACAddress)
+		}
+	}
+
+	var advisories []*entities.WifiAdvisory
+	add := func(kind string, devices []string, threshold int, condition, format string, args ...interface{}) {
+		if len(devices) == 0 || len(devices) < uc.config.MinDevices {
+			return
+		}
+		args = append([]interface{}{key.location, len(devices), len(reports)}, args...)
+		advisories = append(advisories, &entities.WifiAdvisory{
+			Kind:                  kind,
+			FarmID:                key.farmID,
+			Location:              key.location,
+			Devices:               devices,
+			Threshold:             threshold,
+			Condition:             condition + " in " + key.location,
+			Description:           fmt.Sprintf(format, args...),
+			LocalizedDescriptions: i18n.SprintfAll(format, args...),
+			Since:                 now,
+		})
+	}
+	add(entities.WifiAdvisoryWeakSignal, weak, uc.config.RSSIThreshold,
+		fmt.Sprintf("rssi < %d dBm", uc.config.RSSIThreshold),
+		"%s has %d of %d devices with RSSI below %d dBm", uc.config.RSSIThreshold)
+	add(entities.WifiAdvisoryUnstableConnection, unstable, uc.config.ReconnectThreshold,
+		fmt.Sprintf("reconnects >= %d", uc.config.ReconnectThreshold),
+		"%s has %d of %d devices reconnecting to Wi-Fi %d or more times between two reports", uc.config.ReconnectThreshold)
+	add(entities.WifiAdvisoryOverlappingChannel, overlapping, 0,
+		"channel not in (1, 6, 11)",
+		"%s has %d of %d devices on a 2.4 GHz channel other than 1, 6 and 11")
+	return advisories
+}
+
+// newAlert creates the alert of an advisory; links losing data are warnings and channel choices info
+func (uc *useCaseImpl) newAlert(advisory *entities.WifiAdvisory, status string, now time.Time, description string) *entities.Alert {
+	severity := entities.AlertSeverityWarning
+	if advisory.Kind == entities.WifiAdvisoryOverlappingChannel {
+		severity = entities.AlertSeverityInfo
+	}
+	alert, err := entities.NewWifiAdvisoryAlert(advisory, status, severity, now, description)
+	if err != nil {
+		uc.loggerFactory.Core().Error("failed_to_create_alert",
+			zap.Error(err),
+			zap.String("kind", advisory.Kind),
+			zap.String("component", "wifi_diagnostics_usecase"),
+		)
+		return nil
+	}
+	return alert
+}
+
+// publish logs an alert, notifies users of it and publishes it
+func (uc *useCaseImpl) publish(ctx context.Context, alert *entities.Alert) {
+	uc.loggerFactory.Core().Warn("wifi_advisory",
+		zap.String("event_id", alert.EventID),
+		zap.String("rule", alert.Rule),
+		zap.String("status", alert.Status),
+		zap.String("condition", alert.Condition),
+		zap.String("description", alert.Description),
+		zap.String("component", "wifi_diagnostics_usecase"),
+	)
+
+	if uc.notifier != nil {
+		uc.notifier.Notify(ctx, alert)
+	}
+	if uc.eventPublisher == nil || !uc.eventPublisher.IsConnected() {
+		return
+	}
+	if err := uc.eventPublisher.Publish(ctx, alert.GetSubject(), alert); err != nil {
+		uc.loggerFactory.Messaging().LogEventPublishing("alert", alert.GetSubject(), alert.EventID, false, err)
+		return
+	}
+	uc.loggerFactory.Messaging().LogEventPublishing("alert", alert.GetSubject(), alert.EventID, true, nil)
+}
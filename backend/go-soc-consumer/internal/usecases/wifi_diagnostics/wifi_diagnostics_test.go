@@ -0,0 +1,153 @@
+package wifidiagnostics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	domainerrors "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/errors"
+	eventports "github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports/events"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/mocks"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/logger"
+)
+
+func createTestLoggerFactory(t *testing.T) logger.LoggerFactory {
+	loggerFactory, err := logger.NewDevelopmentLoggerFactory()
+	require.NoError(t, err)
+	return loggerFactory
+}
+
+func newTestUseCase(t *testing.T, now *time.Time) (*useCaseImpl, *mocks.MockDeviceRepository, *mocks.MockAlertNotifier) {
+	deviceRepo := mocks.NewMockDeviceRepository(t)
+	notifier := mocks.NewMockAlertNotifier(t)
+	useCase := NewWifiDiagnosticsUseCase(deviceRepo, nil, nil, notifier, createTestLoggerFactory(t)).(*useCaseImpl)
+	useCase.now = func() time.Time { return *now }
+	return useCase, deviceRepo, notifier
+}
+
+// ingest reports diagnostics of a device at the given location
+func ingest(t *testing.T, useCase *useCaseImpl, deviceRepo *mocks.MockDeviceRepository, macAddress, location string, rssi, channel, reconnects int, at time.Time) {
+	device, err := entities.NewDevice(macAddress, "probe", "192.168.1.10", location)
+	require.NoError(t, err)
+	deviceRepo.EXPECT().FindByMACAddress(mock.Anything, macAddress).Return(device, nil).Once()
+	diagnostics, err := entities.NewWifiDiagnostics(macAddress, "finca", rssi, channel, reconnects, at)
+	require.NoError(t, err)
+	require.NoError(t, useCase.Ingest(context.Background(), diagnostics))
+}
+
+func TestWifiDiagnosticsUseCase_Ingest(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	diagnostics, err := entities.NewWifiDiagnostics("AA:BB:CC:DD:EE:01", "finca", -70, 6, 0, now)
+	require.NoError(t, err)
+
+	t.Run("should reject reports from the namespace of another farm", func(t *testing.T) {
+		useCase, deviceRepo, _ := newTestUseCase(t, &now)
+		device, err := entities.NewDevice("AA:BB:CC:DD:EE:01", "probe", "192.168.1.10", "Zone B")
+		require.NoError(t, err)
+		device.FarmID = "farm-1"
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(device, nil).Once()
+
+		err = useCase.Ingest(eventports.ContextWithFarmID(context.Background(), "farm-2"), diagnostics)
+		assert.ErrorIs(t, err, domainerrors.ErrDeviceFarmMismatch)
+		assert.Empty(t, useCase.Locations())
+	})
+
+	t.Run("should reject reports of unknown devices", func(t *testing.T) {
+		useCase, deviceRepo, _ := newTestUseCase(t, &now)
+		deviceRepo.EXPECT().FindByMACAddress(mock.Anything, "AA:BB:CC:DD:EE:01").Return(nil, domainerrors.ErrDeviceNotFound).Once()
+
+		assert.ErrorIs(t, useCase.Ingest(context.Background(), diagnostics), domainerrors.ErrDeviceNotFound)
+	})
+}
+
+func TestWifiDiagnosticsUseCase_Locations(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase, deviceRepo, _ := newTestUseCase(t, &now)
+	ingest(t, useCase, deviceRepo, "AA:BB:CC:DD:EE:02", "Zone B", -84, 6, 1, now.Add(-time.Minute))
+	ingest(t, useCase, deviceRepo, "AA:BB:CC:DD:EE:01", "Zone B", -70, 11, 2, now)
+	ingest(t, useCase, deviceRepo, "AA:BB:CC:DD:EE:03", "Zone A", -60, 6, 0, now.Add(-25*time.Hour))
+	ingest(t, useCase, deviceRepo, "AA:BB:CC:DD:EE:04", "Greenhouse", -65, 6, 0, now)
+
+	locations := useCase.Locations()
+	require.Len(t, locations, 2, "the report of Zone A is too old")
+	assert.Equal(t, "Greenhouse", locations[0].Location)
+	zone := locations[1]
+	assert.Equal(t, "Zone B", zone.Location)
+	assert.Equal(t, 2, zone.Devices)
+	assert.Equal(t, -77.0, zone.AverageRSSI)
+	assert.Equal(t, -84, zone.WeakestRSSI)
+	assert.Equal(t, []string{"AA:BB:CC:DD:EE:02"}, zone.WeakSignalDevices)
+	assert.Equal(t, 3, zone.Reconnects)
+	assert.Equal(t, []string{"finca"}, zone.SSIDs)
+	assert.Equal(t, []int{6, 11}, zone.Channels)
+	assert.Equal(t, now, zone.LastReportAt)
+}
+
+func TestWifiDiagnosticsUseCase_Evaluate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	useCase, deviceRepo, notifier := newTestUseCase(t, &now)
+	ingest(t, useCase, deviceRepo, "AA:BB:CC:DD:EE:01", "Zone B", -85, 6, 0, now)
+	ingest(t, useCase, deviceRepo, "AA:BB:CC:DD:EE:02", "Zone B", -82, 3, 7, now)
+	ingest(t, useCase, deviceRepo, "AA:BB:CC:DD:EE:03", "Zone A", -60, 1, 0, now)
+
+	t.Run("should raise an alert for every new advisory", func(t *testing.T) {
+		var alerts []*entities.Alert
+		notifier.EXPECT().Notify(mock.Anything, mock.Anything).Run(func(_ context.Context, alert *entities.Alert) {
+			alerts = append(alerts, alert)
+		}).Times(3)
+
+		useCase.Evaluate(context.Background())
+
+		advisories := useCase.Advisories()
+		require.Len(t, advisories, 3)
+		weak := advisories[0]
+		assert.Equal(t, entities.WifiAdvisoryWeakSignal, weak.Kind)
+		assert.Equal(t, []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"}, weak.Devices)
+		assert.Equal(t, "Zone B has 2 of 2 devices with RSSI below -80 dBm", weak.Description)
+		assert.Equal(t, "Zone B tiene 2 de 2 dispositivos con RSSI por debajo de -80 dBm", weak.LocalizedDescriptions["es"])
+
+		require.Len(t, alerts, 3)
+		rules := map[string]string{}
+		for _, alert := range alerts {
+			assert.Equal(t, entities.AlertStatusFiring, alert.Status)
+			rules[alert.Rule] = alert.Severity
+		}
+		assert.Equal(t, map[string]string{
+			"wifi_weak_signal":         entities.AlertSeverityWarning,
+			"wifi_unstable_connection": entities.AlertSeverityWarning,
+			"wifi_overlapping_channel": entities.AlertSeverityInfo,
+		}, rules)
+		assert.Equal(t, float64(1), useCase.advisoriesActive.Value(entities.WifiAdvisoryWeakSignal))
+	})
+
+	t.Run("should keep advisories that still hold without alerting again", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		useCase.Evaluate(context.Background())
+
+		advisories := useCase.Advisories()
+		require.Len(t, advisories, 3)
+		assert.Equal(t, now.Add(-time.Minute), advisories[0].Since)
+	})
+
+	t.Run("should resolve the advisories that cleared", func(t *testing.T) {
+		ingest(t, useCase, deviceRepo, "AA:BB:CC:DD:EE:02", "Zone B", -70, 6, 0, now)
+		var alerts []*entities.Alert
+		notifier.EXPECT().Notify(mock.Anything, mock.Anything).Run(func(_ context.Context, alert *entities.Alert) {
+			alerts = append(alerts, alert)
+		}).Times(2)
+
+		useCase.Evaluate(context.Background())
+
+		require.Len(t, useCase.Advisories(), 1)
+		require.Len(t, alerts, 2)
+		for _, alert := range alerts {
+			assert.Equal(t, entities.AlertStatusResolved, alert.Status)
+		}
+		assert.Zero(t, useCase.advisoriesActive.Value(entities.WifiAdvisoryOverlappingChannel))
+	})
+}
@@ -0,0 +1,275 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAlertingUseCase creates a new instance of MockAlertingUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAlertingUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAlertingUseCase {
+	mock := &MockAlertingUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAlertingUseCase is an autogenerated mock type for the AlertingUseCase type
+type MockAlertingUseCase struct {
+	mock.Mock
+}
+
+type MockAlertingUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAlertingUseCase) EXPECT() *MockAlertingUseCase_Expecter {
+	return &MockAlertingUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ActiveAlerts provides a mock function for the type MockAlertingUseCase
+func (_mock *MockAlertingUseCase) ActiveAlerts() []*entities.Alert {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActiveAlerts")
+	}
+
+	var r0 []*entities.Alert
+	if returnFunc, ok := ret.Get(0).(func() []*entities.Alert); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Alert)
+		}
+	}
+	return r0
+}
+
+// MockAlertingUseCase_ActiveAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveAlerts'
+type MockAlertingUseCase_ActiveAlerts_Call struct {
+	*mock.Call
+}
+
+// ActiveAlerts is a helper method to define mock.On call
+func (_e *MockAlertingUseCase_Expecter) ActiveAlerts() *MockAlertingUseCase_ActiveAlerts_Call {
+	return &MockAlertingUseCase_ActiveAlerts_Call{Call: _e.mock.On("ActiveAlerts")}
+}
+
+func (_c *MockAlertingUseCase_ActiveAlerts_Call) Run(run func()) *MockAlertingUseCase_ActiveAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAlertingUseCase_ActiveAlerts_Call) Return(alerts []*entities.Alert) *MockAlertingUseCase_ActiveAlerts_Call {
+	_c.Call.Return(alerts)
+	return _c
+}
+
+func (_c *MockAlertingUseCase_ActiveAlerts_Call) RunAndReturn(run func() []*entities.Alert) *MockAlertingUseCase_ActiveAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EvaluateComposites provides a mock function for the type MockAlertingUseCase
+func (_mock *MockAlertingUseCase) EvaluateComposites(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateComposites")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAlertingUseCase_EvaluateComposites_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvaluateComposites'
+type MockAlertingUseCase_EvaluateComposites_Call struct {
+	*mock.Call
+}
+
+// EvaluateComposites is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAlertingUseCase_Expecter) EvaluateComposites(ctx interface{}) *MockAlertingUseCase_EvaluateComposites_Call {
+	return &MockAlertingUseCase_EvaluateComposites_Call{Call: _e.mock.On("EvaluateComposites", ctx)}
+}
+
+func (_c *MockAlertingUseCase_EvaluateComposites_Call) Run(run func(ctx context.Context)) *MockAlertingUseCase_EvaluateComposites_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertingUseCase_EvaluateComposites_Call) Return(err error) *MockAlertingUseCase_EvaluateComposites_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAlertingUseCase_EvaluateComposites_Call) RunAndReturn(run func(ctx context.Context) error) *MockAlertingUseCase_EvaluateComposites_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ObserveMeasurements provides a mock function for the type MockAlertingUseCase
+func (_mock *MockAlertingUseCase) ObserveMeasurements(ctx context.Context, values []*entities.Measurement) {
+	_mock.Called(ctx, values)
+	return
+}
+
+// MockAlertingUseCase_ObserveMeasurements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveMeasurements'
+type MockAlertingUseCase_ObserveMeasurements_Call struct {
+	*mock.Call
+}
+
+// ObserveMeasurements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - values []*entities.Measurement
+func (_e *MockAlertingUseCase_Expecter) ObserveMeasurements(ctx interface{}, values interface{}) *MockAlertingUseCase_ObserveMeasurements_Call {
+	return &MockAlertingUseCase_ObserveMeasurements_Call{Call: _e.mock.On("ObserveMeasurements", ctx, values)}
+}
+
+func (_c *MockAlertingUseCase_ObserveMeasurements_Call) Run(run func(ctx context.Context, values []*entities.Measurement)) *MockAlertingUseCase_ObserveMeasurements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.Measurement
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.Measurement)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertingUseCase_ObserveMeasurements_Call) Return() *MockAlertingUseCase_ObserveMeasurements_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAlertingUseCase_ObserveMeasurements_Call) RunAndReturn(run func(ctx context.Context, values []*entities.Measurement)) *MockAlertingUseCase_ObserveMeasurements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecentAlerts provides a mock function for the type MockAlertingUseCase
+func (_mock *MockAlertingUseCase) RecentAlerts(limit int) []*entities.Alert {
+	ret := _mock.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecentAlerts")
+	}
+
+	var r0 []*entities.Alert
+	if returnFunc, ok := ret.Get(0).(func(int) []*entities.Alert); ok {
+		r0 = returnFunc(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Alert)
+		}
+	}
+	return r0
+}
+
+// MockAlertingUseCase_RecentAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecentAlerts'
+type MockAlertingUseCase_RecentAlerts_Call struct {
+	*mock.Call
+}
+
+// RecentAlerts is a helper method to define mock.On call
+//   - limit int
+func (_e *MockAlertingUseCase_Expecter) RecentAlerts(limit interface{}) *MockAlertingUseCase_RecentAlerts_Call {
+	return &MockAlertingUseCase_RecentAlerts_Call{Call: _e.mock.On("RecentAlerts", limit)}
+}
+
+func (_c *MockAlertingUseCase_RecentAlerts_Call) Run(run func(limit int)) *MockAlertingUseCase_RecentAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertingUseCase_RecentAlerts_Call) Return(alerts []*entities.Alert) *MockAlertingUseCase_RecentAlerts_Call {
+	_c.Call.Return(alerts)
+	return _c
+}
+
+func (_c *MockAlertingUseCase_RecentAlerts_Call) RunAndReturn(run func(limit int) []*entities.Alert) *MockAlertingUseCase_RecentAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockAlertingUseCase
+func (_mock *MockAlertingUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockAlertingUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockAlertingUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAlertingUseCase_Expecter) Run(ctx interface{}) *MockAlertingUseCase_Run_Call {
+	return &MockAlertingUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockAlertingUseCase_Run_Call) Run(run func(ctx context.Context)) *MockAlertingUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAlertingUseCase_Run_Call) Return() *MockAlertingUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAlertingUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockAlertingUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
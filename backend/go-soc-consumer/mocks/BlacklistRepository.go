@@ -0,0 +1,215 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBlacklistRepository creates a new instance of MockBlacklistRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBlacklistRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBlacklistRepository {
+	mock := &MockBlacklistRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBlacklistRepository is an autogenerated mock type for the BlacklistRepository type
+type MockBlacklistRepository struct {
+	mock.Mock
+}
+
+type MockBlacklistRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBlacklistRepository) EXPECT() *MockBlacklistRepository_Expecter {
+	return &MockBlacklistRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockBlacklistRepository
+func (_mock *MockBlacklistRepository) Create(ctx context.Context, entry *entities.BlacklistEntry) error {
+	ret := _mock.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.BlacklistEntry) error); ok {
+		r0 = returnFunc(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlacklistRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockBlacklistRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *entities.BlacklistEntry
+func (_e *MockBlacklistRepository_Expecter) Create(ctx interface{}, entry interface{}) *MockBlacklistRepository_Create_Call {
+	return &MockBlacklistRepository_Create_Call{Call: _e.mock.On("Create", ctx, entry)}
+}
+
+func (_c *MockBlacklistRepository_Create_Call) Run(run func(ctx context.Context, entry *entities.BlacklistEntry)) *MockBlacklistRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.BlacklistEntry
+		if args[1] != nil {
+			arg1 = args[1].(*entities.BlacklistEntry)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlacklistRepository_Create_Call) Return(err error) *MockBlacklistRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlacklistRepository_Create_Call) RunAndReturn(run func(ctx context.Context, entry *entities.BlacklistEntry) error) *MockBlacklistRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockBlacklistRepository
+func (_mock *MockBlacklistRepository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlacklistRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockBlacklistRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockBlacklistRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockBlacklistRepository_Delete_Call {
+	return &MockBlacklistRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockBlacklistRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockBlacklistRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlacklistRepository_Delete_Call) Return(err error) *MockBlacklistRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlacklistRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockBlacklistRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockBlacklistRepository
+func (_mock *MockBlacklistRepository) List(ctx context.Context) ([]*entities.BlacklistEntry, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.BlacklistEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.BlacklistEntry, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.BlacklistEntry); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.BlacklistEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlacklistRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockBlacklistRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBlacklistRepository_Expecter) List(ctx interface{}) *MockBlacklistRepository_List_Call {
+	return &MockBlacklistRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockBlacklistRepository_List_Call) Run(run func(ctx context.Context)) *MockBlacklistRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlacklistRepository_List_Call) Return(blacklistEntrys []*entities.BlacklistEntry, err error) *MockBlacklistRepository_List_Call {
+	_c.Call.Return(blacklistEntrys, err)
+	return _c
+}
+
+func (_c *MockBlacklistRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.BlacklistEntry, error)) *MockBlacklistRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
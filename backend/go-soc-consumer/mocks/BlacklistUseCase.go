@@ -0,0 +1,388 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBlacklistUseCase creates a new instance of MockBlacklistUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBlacklistUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBlacklistUseCase {
+	mock := &MockBlacklistUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBlacklistUseCase is an autogenerated mock type for the BlacklistUseCase type
+type MockBlacklistUseCase struct {
+	mock.Mock
+}
+
+type MockBlacklistUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBlacklistUseCase) EXPECT() *MockBlacklistUseCase_Expecter {
+	return &MockBlacklistUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function for the type MockBlacklistUseCase
+func (_mock *MockBlacklistUseCase) Add(ctx context.Context, kind string, value string, reason string) (*entities.BlacklistEntry, error) {
+	ret := _mock.Called(ctx, kind, value, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 *entities.BlacklistEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*entities.BlacklistEntry, error)); ok {
+		return returnFunc(ctx, kind, value, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *entities.BlacklistEntry); ok {
+		r0 = returnFunc(ctx, kind, value, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.BlacklistEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, kind, value, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlacklistUseCase_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockBlacklistUseCase_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - kind string
+//   - value string
+//   - reason string
+func (_e *MockBlacklistUseCase_Expecter) Add(ctx interface{}, kind interface{}, value interface{}, reason interface{}) *MockBlacklistUseCase_Add_Call {
+	return &MockBlacklistUseCase_Add_Call{Call: _e.mock.On("Add", ctx, kind, value, reason)}
+}
+
+func (_c *MockBlacklistUseCase_Add_Call) Run(run func(ctx context.Context, kind string, value string, reason string)) *MockBlacklistUseCase_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_Add_Call) Return(blacklistEntry *entities.BlacklistEntry, err error) *MockBlacklistUseCase_Add_Call {
+	_c.Call.Return(blacklistEntry, err)
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_Add_Call) RunAndReturn(run func(ctx context.Context, kind string, value string, reason string) (*entities.BlacklistEntry, error)) *MockBlacklistUseCase_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockBlacklistUseCase
+func (_mock *MockBlacklistUseCase) List(ctx context.Context) ([]*entities.BlacklistEntry, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.BlacklistEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.BlacklistEntry, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.BlacklistEntry); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.BlacklistEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBlacklistUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockBlacklistUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBlacklistUseCase_Expecter) List(ctx interface{}) *MockBlacklistUseCase_List_Call {
+	return &MockBlacklistUseCase_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockBlacklistUseCase_List_Call) Run(run func(ctx context.Context)) *MockBlacklistUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_List_Call) Return(blacklistEntrys []*entities.BlacklistEntry, err error) *MockBlacklistUseCase_List_Call {
+	_c.Call.Return(blacklistEntrys, err)
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_List_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.BlacklistEntry, error)) *MockBlacklistUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Match provides a mock function for the type MockBlacklistUseCase
+func (_mock *MockBlacklistUseCase) Match(macAddress string, ipAddresses []string) *entities.BlacklistEntry {
+	ret := _mock.Called(macAddress, ipAddresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Match")
+	}
+
+	var r0 *entities.BlacklistEntry
+	if returnFunc, ok := ret.Get(0).(func(string, []string) *entities.BlacklistEntry); ok {
+		r0 = returnFunc(macAddress, ipAddresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.BlacklistEntry)
+		}
+	}
+	return r0
+}
+
+// MockBlacklistUseCase_Match_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Match'
+type MockBlacklistUseCase_Match_Call struct {
+	*mock.Call
+}
+
+// Match is a helper method to define mock.On call
+//   - macAddress string
+//   - ipAddresses []string
+func (_e *MockBlacklistUseCase_Expecter) Match(macAddress interface{}, ipAddresses interface{}) *MockBlacklistUseCase_Match_Call {
+	return &MockBlacklistUseCase_Match_Call{Call: _e.mock.On("Match", macAddress, ipAddresses)}
+}
+
+func (_c *MockBlacklistUseCase_Match_Call) Run(run func(macAddress string, ipAddresses []string)) *MockBlacklistUseCase_Match_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_Match_Call) Return(blacklistEntry *entities.BlacklistEntry) *MockBlacklistUseCase_Match_Call {
+	_c.Call.Return(blacklistEntry)
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_Match_Call) RunAndReturn(run func(macAddress string, ipAddresses []string) *entities.BlacklistEntry) *MockBlacklistUseCase_Match_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function for the type MockBlacklistUseCase
+func (_mock *MockBlacklistUseCase) Refresh(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlacklistUseCase_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockBlacklistUseCase_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBlacklistUseCase_Expecter) Refresh(ctx interface{}) *MockBlacklistUseCase_Refresh_Call {
+	return &MockBlacklistUseCase_Refresh_Call{Call: _e.mock.On("Refresh", ctx)}
+}
+
+func (_c *MockBlacklistUseCase_Refresh_Call) Run(run func(ctx context.Context)) *MockBlacklistUseCase_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_Refresh_Call) Return(err error) *MockBlacklistUseCase_Refresh_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_Refresh_Call) RunAndReturn(run func(ctx context.Context) error) *MockBlacklistUseCase_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function for the type MockBlacklistUseCase
+func (_mock *MockBlacklistUseCase) Remove(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBlacklistUseCase_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type MockBlacklistUseCase_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockBlacklistUseCase_Expecter) Remove(ctx interface{}, id interface{}) *MockBlacklistUseCase_Remove_Call {
+	return &MockBlacklistUseCase_Remove_Call{Call: _e.mock.On("Remove", ctx, id)}
+}
+
+func (_c *MockBlacklistUseCase_Remove_Call) Run(run func(ctx context.Context, id string)) *MockBlacklistUseCase_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_Remove_Call) Return(err error) *MockBlacklistUseCase_Remove_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_Remove_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockBlacklistUseCase_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockBlacklistUseCase
+func (_mock *MockBlacklistUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockBlacklistUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockBlacklistUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockBlacklistUseCase_Expecter) Run(ctx interface{}) *MockBlacklistUseCase_Run_Call {
+	return &MockBlacklistUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockBlacklistUseCase_Run_Call) Run(run func(ctx context.Context)) *MockBlacklistUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_Run_Call) Return() *MockBlacklistUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockBlacklistUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockBlacklistUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,162 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCommandSigner creates a new instance of MockCommandSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCommandSigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCommandSigner {
+	mock := &MockCommandSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCommandSigner is an autogenerated mock type for the CommandSigner type
+type MockCommandSigner struct {
+	mock.Mock
+}
+
+type MockCommandSigner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCommandSigner) EXPECT() *MockCommandSigner_Expecter {
+	return &MockCommandSigner_Expecter{mock: &_m.Mock}
+}
+
+// Sign provides a mock function for the type MockCommandSigner
+func (_mock *MockCommandSigner) Sign(target string, command string, payload interface{}) (*entities.CommandEnvelope, error) {
+	ret := _mock.Called(target, command, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sign")
+	}
+
+	var r0 *entities.CommandEnvelope
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, interface{}) (*entities.CommandEnvelope, error)); ok {
+		return returnFunc(target, command, payload)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, string, interface{}) *entities.CommandEnvelope); ok {
+		r0 = returnFunc(target, command, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.CommandEnvelope)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, string, interface{}) error); ok {
+		r1 = returnFunc(target, command, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCommandSigner_Sign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sign'
+type MockCommandSigner_Sign_Call struct {
+	*mock.Call
+}
+
+// Sign is a helper method to define mock.On call
+//   - target string
+//   - command string
+//   - payload interface{}
+func (_e *MockCommandSigner_Expecter) Sign(target interface{}, command interface{}, payload interface{}) *MockCommandSigner_Sign_Call {
+	return &MockCommandSigner_Sign_Call{Call: _e.mock.On("Sign", target, command, payload)}
+}
+
+func (_c *MockCommandSigner_Sign_Call) Run(run func(target string, command string, payload interface{})) *MockCommandSigner_Sign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 interface{}
+		if args[2] != nil {
+			arg2 = args[2].(interface{})
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCommandSigner_Sign_Call) Return(commandEnvelope *entities.CommandEnvelope, err error) *MockCommandSigner_Sign_Call {
+	_c.Call.Return(commandEnvelope, err)
+	return _c
+}
+
+func (_c *MockCommandSigner_Sign_Call) RunAndReturn(run func(target string, command string, payload interface{}) (*entities.CommandEnvelope, error)) *MockCommandSigner_Sign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function for the type MockCommandSigner
+func (_mock *MockCommandSigner) Verify(envelope *entities.CommandEnvelope) error {
+	ret := _mock.Called(envelope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*entities.CommandEnvelope) error); ok {
+		r0 = returnFunc(envelope)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCommandSigner_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockCommandSigner_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - envelope *entities.CommandEnvelope
+func (_e *MockCommandSigner_Expecter) Verify(envelope interface{}) *MockCommandSigner_Verify_Call {
+	return &MockCommandSigner_Verify_Call{Call: _e.mock.On("Verify", envelope)}
+}
+
+func (_c *MockCommandSigner_Verify_Call) Run(run func(envelope *entities.CommandEnvelope)) *MockCommandSigner_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *entities.CommandEnvelope
+		if args[0] != nil {
+			arg0 = args[0].(*entities.CommandEnvelope)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCommandSigner_Verify_Call) Return(err error) *MockCommandSigner_Verify_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCommandSigner_Verify_Call) RunAndReturn(run func(envelope *entities.CommandEnvelope) error) *MockCommandSigner_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
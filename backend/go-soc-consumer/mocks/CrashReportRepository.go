@@ -0,0 +1,242 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCrashReportRepository creates a new instance of MockCrashReportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCrashReportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCrashReportRepository {
+	mock := &MockCrashReportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCrashReportRepository is an autogenerated mock type for the CrashReportRepository type
+type MockCrashReportRepository struct {
+	mock.Mock
+}
+
+type MockCrashReportRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCrashReportRepository) EXPECT() *MockCrashReportRepository_Expecter {
+	return &MockCrashReportRepository_Expecter{mock: &_m.Mock}
+}
+
+// FirmwareStats provides a mock function for the type MockCrashReportRepository
+func (_mock *MockCrashReportRepository) FirmwareStats(ctx context.Context, since time.Time) ([]*entities.FirmwareCrashStats, error) {
+	ret := _mock.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirmwareStats")
+	}
+
+	var r0 []*entities.FirmwareCrashStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]*entities.FirmwareCrashStats, error)); ok {
+		return returnFunc(ctx, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []*entities.FirmwareCrashStats); ok {
+		r0 = returnFunc(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.FirmwareCrashStats)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCrashReportRepository_FirmwareStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FirmwareStats'
+type MockCrashReportRepository_FirmwareStats_Call struct {
+	*mock.Call
+}
+
+// FirmwareStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+func (_e *MockCrashReportRepository_Expecter) FirmwareStats(ctx interface{}, since interface{}) *MockCrashReportRepository_FirmwareStats_Call {
+	return &MockCrashReportRepository_FirmwareStats_Call{Call: _e.mock.On("FirmwareStats", ctx, since)}
+}
+
+func (_c *MockCrashReportRepository_FirmwareStats_Call) Run(run func(ctx context.Context, since time.Time)) *MockCrashReportRepository_FirmwareStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCrashReportRepository_FirmwareStats_Call) Return(firmwareCrashStatss []*entities.FirmwareCrashStats, err error) *MockCrashReportRepository_FirmwareStats_Call {
+	_c.Call.Return(firmwareCrashStatss, err)
+	return _c
+}
+
+func (_c *MockCrashReportRepository_FirmwareStats_Call) RunAndReturn(run func(ctx context.Context, since time.Time) ([]*entities.FirmwareCrashStats, error)) *MockCrashReportRepository_FirmwareStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function for the type MockCrashReportRepository
+func (_mock *MockCrashReportRepository) Record(ctx context.Context, report *entities.CrashReport) (bool, error) {
+	ret := _mock.Called(ctx, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.CrashReport) (bool, error)); ok {
+		return returnFunc(ctx, report)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.CrashReport) bool); ok {
+		r0 = returnFunc(ctx, report)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.CrashReport) error); ok {
+		r1 = returnFunc(ctx, report)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCrashReportRepository_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockCrashReportRepository_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - report *entities.CrashReport
+func (_e *MockCrashReportRepository_Expecter) Record(ctx interface{}, report interface{}) *MockCrashReportRepository_Record_Call {
+	return &MockCrashReportRepository_Record_Call{Call: _e.mock.On("Record", ctx, report)}
+}
+
+func (_c *MockCrashReportRepository_Record_Call) Run(run func(ctx context.Context, report *entities.CrashReport)) *MockCrashReportRepository_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.CrashReport
+		if args[1] != nil {
+			arg1 = args[1].(*entities.CrashReport)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCrashReportRepository_Record_Call) Return(b bool, err error) *MockCrashReportRepository_Record_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockCrashReportRepository_Record_Call) RunAndReturn(run func(ctx context.Context, report *entities.CrashReport) (bool, error)) *MockCrashReportRepository_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignatureStats provides a mock function for the type MockCrashReportRepository
+func (_mock *MockCrashReportRepository) SignatureStats(ctx context.Context, since time.Time) ([]*entities.CrashSignature, error) {
+	ret := _mock.Called(ctx, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignatureStats")
+	}
+
+	var r0 []*entities.CrashSignature
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]*entities.CrashSignature, error)); ok {
+		return returnFunc(ctx, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []*entities.CrashSignature); ok {
+		r0 = returnFunc(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.CrashSignature)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCrashReportRepository_SignatureStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignatureStats'
+type MockCrashReportRepository_SignatureStats_Call struct {
+	*mock.Call
+}
+
+// SignatureStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+func (_e *MockCrashReportRepository_Expecter) SignatureStats(ctx interface{}, since interface{}) *MockCrashReportRepository_SignatureStats_Call {
+	return &MockCrashReportRepository_SignatureStats_Call{Call: _e.mock.On("SignatureStats", ctx, since)}
+}
+
+func (_c *MockCrashReportRepository_SignatureStats_Call) Run(run func(ctx context.Context, since time.Time)) *MockCrashReportRepository_SignatureStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCrashReportRepository_SignatureStats_Call) Return(crashSignatures []*entities.CrashSignature, err error) *MockCrashReportRepository_SignatureStats_Call {
+	_c.Call.Return(crashSignatures, err)
+	return _c
+}
+
+func (_c *MockCrashReportRepository_SignatureStats_Call) RunAndReturn(run func(ctx context.Context, since time.Time) ([]*entities.CrashSignature, error)) *MockCrashReportRepository_SignatureStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,185 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDerivedSensorUseCase creates a new instance of MockDerivedSensorUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDerivedSensorUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDerivedSensorUseCase {
+	mock := &MockDerivedSensorUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDerivedSensorUseCase is an autogenerated mock type for the DerivedSensorUseCase type
+type MockDerivedSensorUseCase struct {
+	mock.Mock
+}
+
+type MockDerivedSensorUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDerivedSensorUseCase) EXPECT() *MockDerivedSensorUseCase_Expecter {
+	return &MockDerivedSensorUseCase_Expecter{mock: &_m.Mock}
+}
+
+// DeriveFrom provides a mock function for the type MockDerivedSensorUseCase
+func (_mock *MockDerivedSensorUseCase) DeriveFrom(ctx context.Context, stored []*entities.Measurement) {
+	_mock.Called(ctx, stored)
+	return
+}
+
+// MockDerivedSensorUseCase_DeriveFrom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeriveFrom'
+type MockDerivedSensorUseCase_DeriveFrom_Call struct {
+	*mock.Call
+}
+
+// DeriveFrom is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stored []*entities.Measurement
+func (_e *MockDerivedSensorUseCase_Expecter) DeriveFrom(ctx interface{}, stored interface{}) *MockDerivedSensorUseCase_DeriveFrom_Call {
+	return &MockDerivedSensorUseCase_DeriveFrom_Call{Call: _e.mock.On("DeriveFrom", ctx, stored)}
+}
+
+func (_c *MockDerivedSensorUseCase_DeriveFrom_Call) Run(run func(ctx context.Context, stored []*entities.Measurement)) *MockDerivedSensorUseCase_DeriveFrom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.Measurement
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.Measurement)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDerivedSensorUseCase_DeriveFrom_Call) Return() *MockDerivedSensorUseCase_DeriveFrom_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDerivedSensorUseCase_DeriveFrom_Call) RunAndReturn(run func(ctx context.Context, stored []*entities.Measurement)) *MockDerivedSensorUseCase_DeriveFrom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EvaluateOnce provides a mock function for the type MockDerivedSensorUseCase
+func (_mock *MockDerivedSensorUseCase) EvaluateOnce(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateOnce")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDerivedSensorUseCase_EvaluateOnce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvaluateOnce'
+type MockDerivedSensorUseCase_EvaluateOnce_Call struct {
+	*mock.Call
+}
+
+// EvaluateOnce is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDerivedSensorUseCase_Expecter) EvaluateOnce(ctx interface{}) *MockDerivedSensorUseCase_EvaluateOnce_Call {
+	return &MockDerivedSensorUseCase_EvaluateOnce_Call{Call: _e.mock.On("EvaluateOnce", ctx)}
+}
+
+func (_c *MockDerivedSensorUseCase_EvaluateOnce_Call) Run(run func(ctx context.Context)) *MockDerivedSensorUseCase_EvaluateOnce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDerivedSensorUseCase_EvaluateOnce_Call) Return(n int, err error) *MockDerivedSensorUseCase_EvaluateOnce_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDerivedSensorUseCase_EvaluateOnce_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockDerivedSensorUseCase_EvaluateOnce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockDerivedSensorUseCase
+func (_mock *MockDerivedSensorUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockDerivedSensorUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockDerivedSensorUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDerivedSensorUseCase_Expecter) Run(ctx interface{}) *MockDerivedSensorUseCase_Run_Call {
+	return &MockDerivedSensorUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockDerivedSensorUseCase_Run_Call) Run(run func(ctx context.Context)) *MockDerivedSensorUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDerivedSensorUseCase_Run_Call) Return() *MockDerivedSensorUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDerivedSensorUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockDerivedSensorUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
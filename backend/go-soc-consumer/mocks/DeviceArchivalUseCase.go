@@ -0,0 +1,335 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceArchivalUseCase creates a new instance of MockDeviceArchivalUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceArchivalUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceArchivalUseCase {
+	mock := &MockDeviceArchivalUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceArchivalUseCase is an autogenerated mock type for the DeviceArchivalUseCase type
+type MockDeviceArchivalUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceArchivalUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceArchivalUseCase) EXPECT() *MockDeviceArchivalUseCase_Expecter {
+	return &MockDeviceArchivalUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Decommission provides a mock function for the type MockDeviceArchivalUseCase
+func (_mock *MockDeviceArchivalUseCase) Decommission(ctx context.Context, macAddress string, reason string, actor string) (*entities.DeviceArchive, error) {
+	ret := _mock.Called(ctx, macAddress, reason, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decommission")
+	}
+
+	var r0 *entities.DeviceArchive
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*entities.DeviceArchive, error)); ok {
+		return returnFunc(ctx, macAddress, reason, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *entities.DeviceArchive); ok {
+		r0 = returnFunc(ctx, macAddress, reason, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceArchive)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, macAddress, reason, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceArchivalUseCase_Decommission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decommission'
+type MockDeviceArchivalUseCase_Decommission_Call struct {
+	*mock.Call
+}
+
+// Decommission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - reason string
+//   - actor string
+func (_e *MockDeviceArchivalUseCase_Expecter) Decommission(ctx interface{}, macAddress interface{}, reason interface{}, actor interface{}) *MockDeviceArchivalUseCase_Decommission_Call {
+	return &MockDeviceArchivalUseCase_Decommission_Call{Call: _e.mock.On("Decommission", ctx, macAddress, reason, actor)}
+}
+
+func (_c *MockDeviceArchivalUseCase_Decommission_Call) Run(run func(ctx context.Context, macAddress string, reason string, actor string)) *MockDeviceArchivalUseCase_Decommission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchivalUseCase_Decommission_Call) Return(deviceArchive *entities.DeviceArchive, err error) *MockDeviceArchivalUseCase_Decommission_Call {
+	_c.Call.Return(deviceArchive, err)
+	return _c
+}
+
+func (_c *MockDeviceArchivalUseCase_Decommission_Call) RunAndReturn(run func(ctx context.Context, macAddress string, reason string, actor string) (*entities.DeviceArchive, error)) *MockDeviceArchivalUseCase_Decommission_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockDeviceArchivalUseCase
+func (_mock *MockDeviceArchivalUseCase) Get(ctx context.Context, id string) (*entities.DeviceArchive, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entities.DeviceArchive
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceArchive, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceArchive); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceArchive)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceArchivalUseCase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDeviceArchivalUseCase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceArchivalUseCase_Expecter) Get(ctx interface{}, id interface{}) *MockDeviceArchivalUseCase_Get_Call {
+	return &MockDeviceArchivalUseCase_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockDeviceArchivalUseCase_Get_Call) Run(run func(ctx context.Context, id string)) *MockDeviceArchivalUseCase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchivalUseCase_Get_Call) Return(deviceArchive *entities.DeviceArchive, err error) *MockDeviceArchivalUseCase_Get_Call {
+	_c.Call.Return(deviceArchive, err)
+	return _c
+}
+
+func (_c *MockDeviceArchivalUseCase_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceArchive, error)) *MockDeviceArchivalUseCase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockDeviceArchivalUseCase
+func (_mock *MockDeviceArchivalUseCase) List(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceArchive, error) {
+	ret := _mock.Called(ctx, macAddress, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.DeviceArchive
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]*entities.DeviceArchive, error)); ok {
+		return returnFunc(ctx, macAddress, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []*entities.DeviceArchive); ok {
+		r0 = returnFunc(ctx, macAddress, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceArchive)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, macAddress, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceArchivalUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeviceArchivalUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - limit int
+func (_e *MockDeviceArchivalUseCase_Expecter) List(ctx interface{}, macAddress interface{}, limit interface{}) *MockDeviceArchivalUseCase_List_Call {
+	return &MockDeviceArchivalUseCase_List_Call{Call: _e.mock.On("List", ctx, macAddress, limit)}
+}
+
+func (_c *MockDeviceArchivalUseCase_List_Call) Run(run func(ctx context.Context, macAddress string, limit int)) *MockDeviceArchivalUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchivalUseCase_List_Call) Return(deviceArchives []*entities.DeviceArchive, err error) *MockDeviceArchivalUseCase_List_Call {
+	_c.Call.Return(deviceArchives, err)
+	return _c
+}
+
+func (_c *MockDeviceArchivalUseCase_List_Call) RunAndReturn(run func(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceArchive, error)) *MockDeviceArchivalUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockDeviceArchivalUseCase
+func (_mock *MockDeviceArchivalUseCase) Restore(ctx context.Context, id string, actor string) (*entities.DeviceArchive, error) {
+	ret := _mock.Called(ctx, id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *entities.DeviceArchive
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entities.DeviceArchive, error)); ok {
+		return returnFunc(ctx, id, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entities.DeviceArchive); ok {
+		r0 = returnFunc(ctx, id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceArchive)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceArchivalUseCase_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockDeviceArchivalUseCase_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - actor string
+func (_e *MockDeviceArchivalUseCase_Expecter) Restore(ctx interface{}, id interface{}, actor interface{}) *MockDeviceArchivalUseCase_Restore_Call {
+	return &MockDeviceArchivalUseCase_Restore_Call{Call: _e.mock.On("Restore", ctx, id, actor)}
+}
+
+func (_c *MockDeviceArchivalUseCase_Restore_Call) Run(run func(ctx context.Context, id string, actor string)) *MockDeviceArchivalUseCase_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchivalUseCase_Restore_Call) Return(deviceArchive *entities.DeviceArchive, err error) *MockDeviceArchivalUseCase_Restore_Call {
+	_c.Call.Return(deviceArchive, err)
+	return _c
+}
+
+func (_c *MockDeviceArchivalUseCase_Restore_Call) RunAndReturn(run func(ctx context.Context, id string, actor string) (*entities.DeviceArchive, error)) *MockDeviceArchivalUseCase_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,426 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceArchiveRepository creates a new instance of MockDeviceArchiveRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceArchiveRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceArchiveRepository {
+	mock := &MockDeviceArchiveRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceArchiveRepository is an autogenerated mock type for the DeviceArchiveRepository type
+type MockDeviceArchiveRepository struct {
+	mock.Mock
+}
+
+type MockDeviceArchiveRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceArchiveRepository) EXPECT() *MockDeviceArchiveRepository_Expecter {
+	return &MockDeviceArchiveRepository_Expecter{mock: &_m.Mock}
+}
+
+// Archive provides a mock function for the type MockDeviceArchiveRepository
+func (_mock *MockDeviceArchiveRepository) Archive(ctx context.Context, archive *entities.DeviceArchive) error {
+	ret := _mock.Called(ctx, archive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceArchive) error); ok {
+		r0 = returnFunc(ctx, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceArchiveRepository_Archive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Archive'
+type MockDeviceArchiveRepository_Archive_Call struct {
+	*mock.Call
+}
+
+// Archive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - archive *entities.DeviceArchive
+func (_e *MockDeviceArchiveRepository_Expecter) Archive(ctx interface{}, archive interface{}) *MockDeviceArchiveRepository_Archive_Call {
+	return &MockDeviceArchiveRepository_Archive_Call{Call: _e.mock.On("Archive", ctx, archive)}
+}
+
+func (_c *MockDeviceArchiveRepository_Archive_Call) Run(run func(ctx context.Context, archive *entities.DeviceArchive)) *MockDeviceArchiveRepository_Archive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceArchive
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceArchive)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_Archive_Call) Return(err error) *MockDeviceArchiveRepository_Archive_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_Archive_Call) RunAndReturn(run func(ctx context.Context, archive *entities.DeviceArchive) error) *MockDeviceArchiveRepository_Archive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockDeviceArchiveRepository
+func (_mock *MockDeviceArchiveRepository) FindByID(ctx context.Context, id string) (*entities.DeviceArchive, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.DeviceArchive
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceArchive, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceArchive); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceArchive)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceArchiveRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockDeviceArchiveRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceArchiveRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockDeviceArchiveRepository_FindByID_Call {
+	return &MockDeviceArchiveRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockDeviceArchiveRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockDeviceArchiveRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_FindByID_Call) Return(deviceArchive *entities.DeviceArchive, err error) *MockDeviceArchiveRepository_FindByID_Call {
+	_c.Call.Return(deviceArchive, err)
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceArchive, error)) *MockDeviceArchiveRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockDeviceArchiveRepository
+func (_mock *MockDeviceArchiveRepository) List(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceArchive, error) {
+	ret := _mock.Called(ctx, macAddress, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.DeviceArchive
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]*entities.DeviceArchive, error)); ok {
+		return returnFunc(ctx, macAddress, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []*entities.DeviceArchive); ok {
+		r0 = returnFunc(ctx, macAddress, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceArchive)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, macAddress, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceArchiveRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeviceArchiveRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - limit int
+func (_e *MockDeviceArchiveRepository_Expecter) List(ctx interface{}, macAddress interface{}, limit interface{}) *MockDeviceArchiveRepository_List_Call {
+	return &MockDeviceArchiveRepository_List_Call{Call: _e.mock.On("List", ctx, macAddress, limit)}
+}
+
+func (_c *MockDeviceArchiveRepository_List_Call) Run(run func(ctx context.Context, macAddress string, limit int)) *MockDeviceArchiveRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_List_Call) Return(deviceArchives []*entities.DeviceArchive, err error) *MockDeviceArchiveRepository_List_Call {
+	_c.Call.Return(deviceArchives, err)
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_List_Call) RunAndReturn(run func(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceArchive, error)) *MockDeviceArchiveRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Measurements provides a mock function for the type MockDeviceArchiveRepository
+func (_mock *MockDeviceArchiveRepository) Measurements(ctx context.Context, archiveID string) ([]*entities.Measurement, error) {
+	ret := _mock.Called(ctx, archiveID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Measurements")
+	}
+
+	var r0 []*entities.Measurement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entities.Measurement, error)); ok {
+		return returnFunc(ctx, archiveID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entities.Measurement); ok {
+		r0 = returnFunc(ctx, archiveID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Measurement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, archiveID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceArchiveRepository_Measurements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Measurements'
+type MockDeviceArchiveRepository_Measurements_Call struct {
+	*mock.Call
+}
+
+// Measurements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - archiveID string
+func (_e *MockDeviceArchiveRepository_Expecter) Measurements(ctx interface{}, archiveID interface{}) *MockDeviceArchiveRepository_Measurements_Call {
+	return &MockDeviceArchiveRepository_Measurements_Call{Call: _e.mock.On("Measurements", ctx, archiveID)}
+}
+
+func (_c *MockDeviceArchiveRepository_Measurements_Call) Run(run func(ctx context.Context, archiveID string)) *MockDeviceArchiveRepository_Measurements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_Measurements_Call) Return(measurements []*entities.Measurement, err error) *MockDeviceArchiveRepository_Measurements_Call {
+	_c.Call.Return(measurements, err)
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_Measurements_Call) RunAndReturn(run func(ctx context.Context, archiveID string) ([]*entities.Measurement, error)) *MockDeviceArchiveRepository_Measurements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Offload provides a mock function for the type MockDeviceArchiveRepository
+func (_mock *MockDeviceArchiveRepository) Offload(ctx context.Context, archive *entities.DeviceArchive) error {
+	ret := _mock.Called(ctx, archive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Offload")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceArchive) error); ok {
+		r0 = returnFunc(ctx, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceArchiveRepository_Offload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Offload'
+type MockDeviceArchiveRepository_Offload_Call struct {
+	*mock.Call
+}
+
+// Offload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - archive *entities.DeviceArchive
+func (_e *MockDeviceArchiveRepository_Expecter) Offload(ctx interface{}, archive interface{}) *MockDeviceArchiveRepository_Offload_Call {
+	return &MockDeviceArchiveRepository_Offload_Call{Call: _e.mock.On("Offload", ctx, archive)}
+}
+
+func (_c *MockDeviceArchiveRepository_Offload_Call) Run(run func(ctx context.Context, archive *entities.DeviceArchive)) *MockDeviceArchiveRepository_Offload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceArchive
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceArchive)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_Offload_Call) Return(err error) *MockDeviceArchiveRepository_Offload_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_Offload_Call) RunAndReturn(run func(ctx context.Context, archive *entities.DeviceArchive) error) *MockDeviceArchiveRepository_Offload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockDeviceArchiveRepository
+func (_mock *MockDeviceArchiveRepository) Restore(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement) error {
+	ret := _mock.Called(ctx, archive, measurements)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceArchive, []*entities.Measurement) error); ok {
+		r0 = returnFunc(ctx, archive, measurements)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceArchiveRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockDeviceArchiveRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - archive *entities.DeviceArchive
+//   - measurements []*entities.Measurement
+func (_e *MockDeviceArchiveRepository_Expecter) Restore(ctx interface{}, archive interface{}, measurements interface{}) *MockDeviceArchiveRepository_Restore_Call {
+	return &MockDeviceArchiveRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, archive, measurements)}
+}
+
+func (_c *MockDeviceArchiveRepository_Restore_Call) Run(run func(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement)) *MockDeviceArchiveRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceArchive
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceArchive)
+		}
+		var arg2 []*entities.Measurement
+		if args[2] != nil {
+			arg2 = args[2].([]*entities.Measurement)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_Restore_Call) Return(err error) *MockDeviceArchiveRepository_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceArchiveRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement) error) *MockDeviceArchiveRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,236 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceArchiveStore creates a new instance of MockDeviceArchiveStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceArchiveStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceArchiveStore {
+	mock := &MockDeviceArchiveStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceArchiveStore is an autogenerated mock type for the DeviceArchiveStore type
+type MockDeviceArchiveStore struct {
+	mock.Mock
+}
+
+type MockDeviceArchiveStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceArchiveStore) EXPECT() *MockDeviceArchiveStore_Expecter {
+	return &MockDeviceArchiveStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockDeviceArchiveStore
+func (_mock *MockDeviceArchiveStore) Delete(ctx context.Context, archive *entities.DeviceArchive) error {
+	ret := _mock.Called(ctx, archive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceArchive) error); ok {
+		r0 = returnFunc(ctx, archive)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceArchiveStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockDeviceArchiveStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - archive *entities.DeviceArchive
+func (_e *MockDeviceArchiveStore_Expecter) Delete(ctx interface{}, archive interface{}) *MockDeviceArchiveStore_Delete_Call {
+	return &MockDeviceArchiveStore_Delete_Call{Call: _e.mock.On("Delete", ctx, archive)}
+}
+
+func (_c *MockDeviceArchiveStore_Delete_Call) Run(run func(ctx context.Context, archive *entities.DeviceArchive)) *MockDeviceArchiveStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceArchive
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceArchive)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchiveStore_Delete_Call) Return(err error) *MockDeviceArchiveStore_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceArchiveStore_Delete_Call) RunAndReturn(run func(ctx context.Context, archive *entities.DeviceArchive) error) *MockDeviceArchiveStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Load provides a mock function for the type MockDeviceArchiveStore
+func (_mock *MockDeviceArchiveStore) Load(ctx context.Context, archive *entities.DeviceArchive) ([]*entities.Measurement, error) {
+	ret := _mock.Called(ctx, archive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Load")
+	}
+
+	var r0 []*entities.Measurement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceArchive) ([]*entities.Measurement, error)); ok {
+		return returnFunc(ctx, archive)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceArchive) []*entities.Measurement); ok {
+		r0 = returnFunc(ctx, archive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Measurement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.DeviceArchive) error); ok {
+		r1 = returnFunc(ctx, archive)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceArchiveStore_Load_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Load'
+type MockDeviceArchiveStore_Load_Call struct {
+	*mock.Call
+}
+
+// Load is a helper method to define mock.On call
+//   - ctx context.Context
+//   - archive *entities.DeviceArchive
+func (_e *MockDeviceArchiveStore_Expecter) Load(ctx interface{}, archive interface{}) *MockDeviceArchiveStore_Load_Call {
+	return &MockDeviceArchiveStore_Load_Call{Call: _e.mock.On("Load", ctx, archive)}
+}
+
+func (_c *MockDeviceArchiveStore_Load_Call) Run(run func(ctx context.Context, archive *entities.DeviceArchive)) *MockDeviceArchiveStore_Load_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceArchive
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceArchive)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchiveStore_Load_Call) Return(measurements []*entities.Measurement, err error) *MockDeviceArchiveStore_Load_Call {
+	_c.Call.Return(measurements, err)
+	return _c
+}
+
+func (_c *MockDeviceArchiveStore_Load_Call) RunAndReturn(run func(ctx context.Context, archive *entities.DeviceArchive) ([]*entities.Measurement, error)) *MockDeviceArchiveStore_Load_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Store provides a mock function for the type MockDeviceArchiveStore
+func (_mock *MockDeviceArchiveStore) Store(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement) (string, error) {
+	ret := _mock.Called(ctx, archive, measurements)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Store")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceArchive, []*entities.Measurement) (string, error)); ok {
+		return returnFunc(ctx, archive, measurements)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceArchive, []*entities.Measurement) string); ok {
+		r0 = returnFunc(ctx, archive, measurements)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.DeviceArchive, []*entities.Measurement) error); ok {
+		r1 = returnFunc(ctx, archive, measurements)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceArchiveStore_Store_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Store'
+type MockDeviceArchiveStore_Store_Call struct {
+	*mock.Call
+}
+
+// Store is a helper method to define mock.On call
+//   - ctx context.Context
+//   - archive *entities.DeviceArchive
+//   - measurements []*entities.Measurement
+func (_e *MockDeviceArchiveStore_Expecter) Store(ctx interface{}, archive interface{}, measurements interface{}) *MockDeviceArchiveStore_Store_Call {
+	return &MockDeviceArchiveStore_Store_Call{Call: _e.mock.On("Store", ctx, archive, measurements)}
+}
+
+func (_c *MockDeviceArchiveStore_Store_Call) Run(run func(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement)) *MockDeviceArchiveStore_Store_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceArchive
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceArchive)
+		}
+		var arg2 []*entities.Measurement
+		if args[2] != nil {
+			arg2 = args[2].([]*entities.Measurement)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceArchiveStore_Store_Call) Return(s string, err error) *MockDeviceArchiveStore_Store_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockDeviceArchiveStore_Store_Call) RunAndReturn(run func(ctx context.Context, archive *entities.DeviceArchive, measurements []*entities.Measurement) (string, error)) *MockDeviceArchiveStore_Store_Call {
+	_c.Call.Return(run)
+	return _c
+}
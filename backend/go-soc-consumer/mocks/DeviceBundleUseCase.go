@@ -0,0 +1,175 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceBundleUseCase creates a new instance of MockDeviceBundleUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceBundleUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceBundleUseCase {
+	mock := &MockDeviceBundleUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceBundleUseCase is an autogenerated mock type for the DeviceBundleUseCase type
+type MockDeviceBundleUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceBundleUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceBundleUseCase) EXPECT() *MockDeviceBundleUseCase_Expecter {
+	return &MockDeviceBundleUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Export provides a mock function for the type MockDeviceBundleUseCase
+func (_mock *MockDeviceBundleUseCase) Export(ctx context.Context) (*entities.DeviceBundle, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 *entities.DeviceBundle
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*entities.DeviceBundle, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *entities.DeviceBundle); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceBundle)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceBundleUseCase_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockDeviceBundleUseCase_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceBundleUseCase_Expecter) Export(ctx interface{}) *MockDeviceBundleUseCase_Export_Call {
+	return &MockDeviceBundleUseCase_Export_Call{Call: _e.mock.On("Export", ctx)}
+}
+
+func (_c *MockDeviceBundleUseCase_Export_Call) Run(run func(ctx context.Context)) *MockDeviceBundleUseCase_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceBundleUseCase_Export_Call) Return(deviceBundle *entities.DeviceBundle, err error) *MockDeviceBundleUseCase_Export_Call {
+	_c.Call.Return(deviceBundle, err)
+	return _c
+}
+
+func (_c *MockDeviceBundleUseCase_Export_Call) RunAndReturn(run func(ctx context.Context) (*entities.DeviceBundle, error)) *MockDeviceBundleUseCase_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Import provides a mock function for the type MockDeviceBundleUseCase
+func (_mock *MockDeviceBundleUseCase) Import(ctx context.Context, bundle *entities.DeviceBundle, strategy string) (*entities.DeviceBundleImportResult, error) {
+	ret := _mock.Called(ctx, bundle, strategy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 *entities.DeviceBundleImportResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceBundle, string) (*entities.DeviceBundleImportResult, error)); ok {
+		return returnFunc(ctx, bundle, strategy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceBundle, string) *entities.DeviceBundleImportResult); ok {
+		r0 = returnFunc(ctx, bundle, strategy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceBundleImportResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.DeviceBundle, string) error); ok {
+		r1 = returnFunc(ctx, bundle, strategy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceBundleUseCase_Import_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Import'
+type MockDeviceBundleUseCase_Import_Call struct {
+	*mock.Call
+}
+
+// Import is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bundle *entities.DeviceBundle
+//   - strategy string
+func (_e *MockDeviceBundleUseCase_Expecter) Import(ctx interface{}, bundle interface{}, strategy interface{}) *MockDeviceBundleUseCase_Import_Call {
+	return &MockDeviceBundleUseCase_Import_Call{Call: _e.mock.On("Import", ctx, bundle, strategy)}
+}
+
+func (_c *MockDeviceBundleUseCase_Import_Call) Run(run func(ctx context.Context, bundle *entities.DeviceBundle, strategy string)) *MockDeviceBundleUseCase_Import_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceBundle
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceBundle)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceBundleUseCase_Import_Call) Return(deviceBundleImportResult *entities.DeviceBundleImportResult, err error) *MockDeviceBundleUseCase_Import_Call {
+	_c.Call.Return(deviceBundleImportResult, err)
+	return _c
+}
+
+func (_c *MockDeviceBundleUseCase_Import_Call) RunAndReturn(run func(ctx context.Context, bundle *entities.DeviceBundle, strategy string) (*entities.DeviceBundleImportResult, error)) *MockDeviceBundleUseCase_Import_Call {
+	_c.Call.Return(run)
+	return _c
+}
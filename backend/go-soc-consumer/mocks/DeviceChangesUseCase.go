@@ -0,0 +1,263 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceChangesUseCase creates a new instance of MockDeviceChangesUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceChangesUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceChangesUseCase {
+	mock := &MockDeviceChangesUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceChangesUseCase is an autogenerated mock type for the DeviceChangesUseCase type
+type MockDeviceChangesUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceChangesUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceChangesUseCase) EXPECT() *MockDeviceChangesUseCase_Expecter {
+	return &MockDeviceChangesUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Changes provides a mock function for the type MockDeviceChangesUseCase
+func (_mock *MockDeviceChangesUseCase) Changes(ctx context.Context, since uint64, timeout time.Duration) ([]entities.DeviceChange, uint64, error) {
+	ret := _mock.Called(ctx, since, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Changes")
+	}
+
+	var r0 []entities.DeviceChange
+	var r1 uint64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, time.Duration) ([]entities.DeviceChange, uint64, error)); ok {
+		return returnFunc(ctx, since, timeout)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, time.Duration) []entities.DeviceChange); ok {
+		r0 = returnFunc(ctx, since, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.DeviceChange)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, time.Duration) uint64); ok {
+		r1 = returnFunc(ctx, since, timeout)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, uint64, time.Duration) error); ok {
+		r2 = returnFunc(ctx, since, timeout)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockDeviceChangesUseCase_Changes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Changes'
+type MockDeviceChangesUseCase_Changes_Call struct {
+	*mock.Call
+}
+
+// Changes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since uint64
+//   - timeout time.Duration
+func (_e *MockDeviceChangesUseCase_Expecter) Changes(ctx interface{}, since interface{}, timeout interface{}) *MockDeviceChangesUseCase_Changes_Call {
+	return &MockDeviceChangesUseCase_Changes_Call{Call: _e.mock.On("Changes", ctx, since, timeout)}
+}
+
+func (_c *MockDeviceChangesUseCase_Changes_Call) Run(run func(ctx context.Context, since uint64, timeout time.Duration)) *MockDeviceChangesUseCase_Changes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceChangesUseCase_Changes_Call) Return(deviceChanges []entities.DeviceChange, v uint64, err error) *MockDeviceChangesUseCase_Changes_Call {
+	_c.Call.Return(deviceChanges, v, err)
+	return _c
+}
+
+func (_c *MockDeviceChangesUseCase_Changes_Call) RunAndReturn(run func(ctx context.Context, since uint64, timeout time.Duration) ([]entities.DeviceChange, uint64, error)) *MockDeviceChangesUseCase_Changes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Cursor provides a mock function for the type MockDeviceChangesUseCase
+func (_mock *MockDeviceChangesUseCase) Cursor() uint64 {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cursor")
+	}
+
+	var r0 uint64
+	if returnFunc, ok := ret.Get(0).(func() uint64); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	return r0
+}
+
+// MockDeviceChangesUseCase_Cursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cursor'
+type MockDeviceChangesUseCase_Cursor_Call struct {
+	*mock.Call
+}
+
+// Cursor is a helper method to define mock.On call
+func (_e *MockDeviceChangesUseCase_Expecter) Cursor() *MockDeviceChangesUseCase_Cursor_Call {
+	return &MockDeviceChangesUseCase_Cursor_Call{Call: _e.mock.On("Cursor")}
+}
+
+func (_c *MockDeviceChangesUseCase_Cursor_Call) Run(run func()) *MockDeviceChangesUseCase_Cursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDeviceChangesUseCase_Cursor_Call) Return(v uint64) *MockDeviceChangesUseCase_Cursor_Call {
+	_c.Call.Return(v)
+	return _c
+}
+
+func (_c *MockDeviceChangesUseCase_Cursor_Call) RunAndReturn(run func() uint64) *MockDeviceChangesUseCase_Cursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function for the type MockDeviceChangesUseCase
+func (_mock *MockDeviceChangesUseCase) Record(device *entities.Device) {
+	_mock.Called(device)
+	return
+}
+
+// MockDeviceChangesUseCase_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockDeviceChangesUseCase_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - device *entities.Device
+func (_e *MockDeviceChangesUseCase_Expecter) Record(device interface{}) *MockDeviceChangesUseCase_Record_Call {
+	return &MockDeviceChangesUseCase_Record_Call{Call: _e.mock.On("Record", device)}
+}
+
+func (_c *MockDeviceChangesUseCase_Record_Call) Run(run func(device *entities.Device)) *MockDeviceChangesUseCase_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *entities.Device
+		if args[0] != nil {
+			arg0 = args[0].(*entities.Device)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceChangesUseCase_Record_Call) Return() *MockDeviceChangesUseCase_Record_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDeviceChangesUseCase_Record_Call) RunAndReturn(run func(device *entities.Device)) *MockDeviceChangesUseCase_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transitions provides a mock function for the type MockDeviceChangesUseCase
+func (_mock *MockDeviceChangesUseCase) Transitions(macAddress string, limit int) []entities.DeviceChange {
+	ret := _mock.Called(macAddress, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transitions")
+	}
+
+	var r0 []entities.DeviceChange
+	if returnFunc, ok := ret.Get(0).(func(string, int) []entities.DeviceChange); ok {
+		r0 = returnFunc(macAddress, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.DeviceChange)
+		}
+	}
+	return r0
+}
+
+// MockDeviceChangesUseCase_Transitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transitions'
+type MockDeviceChangesUseCase_Transitions_Call struct {
+	*mock.Call
+}
+
+// Transitions is a helper method to define mock.On call
+//   - macAddress string
+//   - limit int
+func (_e *MockDeviceChangesUseCase_Expecter) Transitions(macAddress interface{}, limit interface{}) *MockDeviceChangesUseCase_Transitions_Call {
+	return &MockDeviceChangesUseCase_Transitions_Call{Call: _e.mock.On("Transitions", macAddress, limit)}
+}
+
+func (_c *MockDeviceChangesUseCase_Transitions_Call) Run(run func(macAddress string, limit int)) *MockDeviceChangesUseCase_Transitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceChangesUseCase_Transitions_Call) Return(deviceChanges []entities.DeviceChange) *MockDeviceChangesUseCase_Transitions_Call {
+	_c.Call.Return(deviceChanges)
+	return _c
+}
+
+func (_c *MockDeviceChangesUseCase_Transitions_Call) RunAndReturn(run func(macAddress string, limit int) []entities.DeviceChange) *MockDeviceChangesUseCase_Transitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
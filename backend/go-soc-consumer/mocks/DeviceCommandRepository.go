@@ -0,0 +1,467 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceCommandRepository creates a new instance of MockDeviceCommandRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceCommandRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceCommandRepository {
+	mock := &MockDeviceCommandRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceCommandRepository is an autogenerated mock type for the DeviceCommandRepository type
+type MockDeviceCommandRepository struct {
+	mock.Mock
+}
+
+type MockDeviceCommandRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceCommandRepository) EXPECT() *MockDeviceCommandRepository_Expecter {
+	return &MockDeviceCommandRepository_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function for the type MockDeviceCommandRepository
+func (_mock *MockDeviceCommandRepository) Complete(ctx context.Context, id string, status string, message string, ackedAt time.Time) (bool, error) {
+	ret := _mock.Called(ctx, id, status, message, ackedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, id, status, message, ackedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time) bool); ok {
+		r0 = returnFunc(ctx, id, status, message, ackedAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, status, message, ackedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceCommandRepository_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockDeviceCommandRepository_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status string
+//   - message string
+//   - ackedAt time.Time
+func (_e *MockDeviceCommandRepository_Expecter) Complete(ctx interface{}, id interface{}, status interface{}, message interface{}, ackedAt interface{}) *MockDeviceCommandRepository_Complete_Call {
+	return &MockDeviceCommandRepository_Complete_Call{Call: _e.mock.On("Complete", ctx, id, status, message, ackedAt)}
+}
+
+func (_c *MockDeviceCommandRepository_Complete_Call) Run(run func(ctx context.Context, id string, status string, message string, ackedAt time.Time)) *MockDeviceCommandRepository_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_Complete_Call) Return(b bool, err error) *MockDeviceCommandRepository_Complete_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_Complete_Call) RunAndReturn(run func(ctx context.Context, id string, status string, message string, ackedAt time.Time) (bool, error)) *MockDeviceCommandRepository_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockDeviceCommandRepository
+func (_mock *MockDeviceCommandRepository) Create(ctx context.Context, command *entities.DeviceCommand) error {
+	ret := _mock.Called(ctx, command)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceCommand) error); ok {
+		r0 = returnFunc(ctx, command)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceCommandRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockDeviceCommandRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - command *entities.DeviceCommand
+func (_e *MockDeviceCommandRepository_Expecter) Create(ctx interface{}, command interface{}) *MockDeviceCommandRepository_Create_Call {
+	return &MockDeviceCommandRepository_Create_Call{Call: _e.mock.On("Create", ctx, command)}
+}
+
+func (_c *MockDeviceCommandRepository_Create_Call) Run(run func(ctx context.Context, command *entities.DeviceCommand)) *MockDeviceCommandRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceCommand
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceCommand)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_Create_Call) Return(err error) *MockDeviceCommandRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_Create_Call) RunAndReturn(run func(ctx context.Context, command *entities.DeviceCommand) error) *MockDeviceCommandRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpirePending provides a mock function for the type MockDeviceCommandRepository
+func (_mock *MockDeviceCommandRepository) ExpirePending(ctx context.Context, now time.Time) (int, error) {
+	ret := _mock.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpirePending")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return returnFunc(ctx, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = returnFunc(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceCommandRepository_ExpirePending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpirePending'
+type MockDeviceCommandRepository_ExpirePending_Call struct {
+	*mock.Call
+}
+
+// ExpirePending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockDeviceCommandRepository_Expecter) ExpirePending(ctx interface{}, now interface{}) *MockDeviceCommandRepository_ExpirePending_Call {
+	return &MockDeviceCommandRepository_ExpirePending_Call{Call: _e.mock.On("ExpirePending", ctx, now)}
+}
+
+func (_c *MockDeviceCommandRepository_ExpirePending_Call) Run(run func(ctx context.Context, now time.Time)) *MockDeviceCommandRepository_ExpirePending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_ExpirePending_Call) Return(n int, err error) *MockDeviceCommandRepository_ExpirePending_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_ExpirePending_Call) RunAndReturn(run func(ctx context.Context, now time.Time) (int, error)) *MockDeviceCommandRepository_ExpirePending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockDeviceCommandRepository
+func (_mock *MockDeviceCommandRepository) FindByID(ctx context.Context, id string) (*entities.DeviceCommand, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.DeviceCommand
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceCommand, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceCommand); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceCommand)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceCommandRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockDeviceCommandRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceCommandRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockDeviceCommandRepository_FindByID_Call {
+	return &MockDeviceCommandRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockDeviceCommandRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockDeviceCommandRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_FindByID_Call) Return(deviceCommand *entities.DeviceCommand, err error) *MockDeviceCommandRepository_FindByID_Call {
+	_c.Call.Return(deviceCommand, err)
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceCommand, error)) *MockDeviceCommandRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByDevice provides a mock function for the type MockDeviceCommandRepository
+func (_mock *MockDeviceCommandRepository) ListByDevice(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceCommand, error) {
+	ret := _mock.Called(ctx, macAddress, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByDevice")
+	}
+
+	var r0 []*entities.DeviceCommand
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]*entities.DeviceCommand, error)); ok {
+		return returnFunc(ctx, macAddress, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []*entities.DeviceCommand); ok {
+		r0 = returnFunc(ctx, macAddress, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceCommand)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, macAddress, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceCommandRepository_ListByDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByDevice'
+type MockDeviceCommandRepository_ListByDevice_Call struct {
+	*mock.Call
+}
+
+// ListByDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - limit int
+func (_e *MockDeviceCommandRepository_Expecter) ListByDevice(ctx interface{}, macAddress interface{}, limit interface{}) *MockDeviceCommandRepository_ListByDevice_Call {
+	return &MockDeviceCommandRepository_ListByDevice_Call{Call: _e.mock.On("ListByDevice", ctx, macAddress, limit)}
+}
+
+func (_c *MockDeviceCommandRepository_ListByDevice_Call) Run(run func(ctx context.Context, macAddress string, limit int)) *MockDeviceCommandRepository_ListByDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_ListByDevice_Call) Return(deviceCommands []*entities.DeviceCommand, err error) *MockDeviceCommandRepository_ListByDevice_Call {
+	_c.Call.Return(deviceCommands, err)
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_ListByDevice_Call) RunAndReturn(run func(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceCommand, error)) *MockDeviceCommandRepository_ListByDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordAttempt provides a mock function for the type MockDeviceCommandRepository
+func (_mock *MockDeviceCommandRepository) RecordAttempt(ctx context.Context, id string, attempts int, expiresAt time.Time) (bool, error) {
+	ret := _mock.Called(ctx, id, attempts, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordAttempt")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, id, attempts, expiresAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, time.Time) bool); ok {
+		r0 = returnFunc(ctx, id, attempts, expiresAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, attempts, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceCommandRepository_RecordAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAttempt'
+type MockDeviceCommandRepository_RecordAttempt_Call struct {
+	*mock.Call
+}
+
+// RecordAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - attempts int
+//   - expiresAt time.Time
+func (_e *MockDeviceCommandRepository_Expecter) RecordAttempt(ctx interface{}, id interface{}, attempts interface{}, expiresAt interface{}) *MockDeviceCommandRepository_RecordAttempt_Call {
+	return &MockDeviceCommandRepository_RecordAttempt_Call{Call: _e.mock.On("RecordAttempt", ctx, id, attempts, expiresAt)}
+}
+
+func (_c *MockDeviceCommandRepository_RecordAttempt_Call) Run(run func(ctx context.Context, id string, attempts int, expiresAt time.Time)) *MockDeviceCommandRepository_RecordAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_RecordAttempt_Call) Return(b bool, err error) *MockDeviceCommandRepository_RecordAttempt_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockDeviceCommandRepository_RecordAttempt_Call) RunAndReturn(run func(ctx context.Context, id string, attempts int, expiresAt time.Time) (bool, error)) *MockDeviceCommandRepository_RecordAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
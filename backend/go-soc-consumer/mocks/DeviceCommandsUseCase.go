@@ -0,0 +1,358 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceCommandsUseCase creates a new instance of MockDeviceCommandsUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceCommandsUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceCommandsUseCase {
+	mock := &MockDeviceCommandsUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceCommandsUseCase is an autogenerated mock type for the DeviceCommandsUseCase type
+type MockDeviceCommandsUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceCommandsUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceCommandsUseCase) EXPECT() *MockDeviceCommandsUseCase_Expecter {
+	return &MockDeviceCommandsUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Acknowledge provides a mock function for the type MockDeviceCommandsUseCase
+func (_mock *MockDeviceCommandsUseCase) Acknowledge(ctx context.Context, ack *entities.DeviceCommandAck) error {
+	ret := _mock.Called(ctx, ack)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Acknowledge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceCommandAck) error); ok {
+		r0 = returnFunc(ctx, ack)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceCommandsUseCase_Acknowledge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Acknowledge'
+type MockDeviceCommandsUseCase_Acknowledge_Call struct {
+	*mock.Call
+}
+
+// Acknowledge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ack *entities.DeviceCommandAck
+func (_e *MockDeviceCommandsUseCase_Expecter) Acknowledge(ctx interface{}, ack interface{}) *MockDeviceCommandsUseCase_Acknowledge_Call {
+	return &MockDeviceCommandsUseCase_Acknowledge_Call{Call: _e.mock.On("Acknowledge", ctx, ack)}
+}
+
+func (_c *MockDeviceCommandsUseCase_Acknowledge_Call) Run(run func(ctx context.Context, ack *entities.DeviceCommandAck)) *MockDeviceCommandsUseCase_Acknowledge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceCommandAck
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceCommandAck)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandsUseCase_Acknowledge_Call) Return(err error) *MockDeviceCommandsUseCase_Acknowledge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceCommandsUseCase_Acknowledge_Call) RunAndReturn(run func(ctx context.Context, ack *entities.DeviceCommandAck) error) *MockDeviceCommandsUseCase_Acknowledge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockDeviceCommandsUseCase
+func (_mock *MockDeviceCommandsUseCase) Get(ctx context.Context, macAddress string, id string) (*entities.DeviceCommand, error) {
+	ret := _mock.Called(ctx, macAddress, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entities.DeviceCommand
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entities.DeviceCommand, error)); ok {
+		return returnFunc(ctx, macAddress, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entities.DeviceCommand); ok {
+		r0 = returnFunc(ctx, macAddress, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceCommand)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, macAddress, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceCommandsUseCase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDeviceCommandsUseCase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - id string
+func (_e *MockDeviceCommandsUseCase_Expecter) Get(ctx interface{}, macAddress interface{}, id interface{}) *MockDeviceCommandsUseCase_Get_Call {
+	return &MockDeviceCommandsUseCase_Get_Call{Call: _e.mock.On("Get", ctx, macAddress, id)}
+}
+
+func (_c *MockDeviceCommandsUseCase_Get_Call) Run(run func(ctx context.Context, macAddress string, id string)) *MockDeviceCommandsUseCase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandsUseCase_Get_Call) Return(deviceCommand *entities.DeviceCommand, err error) *MockDeviceCommandsUseCase_Get_Call {
+	_c.Call.Return(deviceCommand, err)
+	return _c
+}
+
+func (_c *MockDeviceCommandsUseCase_Get_Call) RunAndReturn(run func(ctx context.Context, macAddress string, id string) (*entities.DeviceCommand, error)) *MockDeviceCommandsUseCase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockDeviceCommandsUseCase
+func (_mock *MockDeviceCommandsUseCase) List(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceCommand, error) {
+	ret := _mock.Called(ctx, macAddress, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.DeviceCommand
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]*entities.DeviceCommand, error)); ok {
+		return returnFunc(ctx, macAddress, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []*entities.DeviceCommand); ok {
+		r0 = returnFunc(ctx, macAddress, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceCommand)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, macAddress, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceCommandsUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeviceCommandsUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - limit int
+func (_e *MockDeviceCommandsUseCase_Expecter) List(ctx interface{}, macAddress interface{}, limit interface{}) *MockDeviceCommandsUseCase_List_Call {
+	return &MockDeviceCommandsUseCase_List_Call{Call: _e.mock.On("List", ctx, macAddress, limit)}
+}
+
+func (_c *MockDeviceCommandsUseCase_List_Call) Run(run func(ctx context.Context, macAddress string, limit int)) *MockDeviceCommandsUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandsUseCase_List_Call) Return(deviceCommands []*entities.DeviceCommand, err error) *MockDeviceCommandsUseCase_List_Call {
+	_c.Call.Return(deviceCommands, err)
+	return _c
+}
+
+func (_c *MockDeviceCommandsUseCase_List_Call) RunAndReturn(run func(ctx context.Context, macAddress string, limit int) ([]*entities.DeviceCommand, error)) *MockDeviceCommandsUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockDeviceCommandsUseCase
+func (_mock *MockDeviceCommandsUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockDeviceCommandsUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockDeviceCommandsUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceCommandsUseCase_Expecter) Run(ctx interface{}) *MockDeviceCommandsUseCase_Run_Call {
+	return &MockDeviceCommandsUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockDeviceCommandsUseCase_Run_Call) Run(run func(ctx context.Context)) *MockDeviceCommandsUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandsUseCase_Run_Call) Return() *MockDeviceCommandsUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDeviceCommandsUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockDeviceCommandsUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function for the type MockDeviceCommandsUseCase
+func (_mock *MockDeviceCommandsUseCase) Send(ctx context.Context, macAddress string, request entities.DeviceCommandRequest) (*entities.DeviceCommand, error) {
+	ret := _mock.Called(ctx, macAddress, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 *entities.DeviceCommand
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.DeviceCommandRequest) (*entities.DeviceCommand, error)); ok {
+		return returnFunc(ctx, macAddress, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.DeviceCommandRequest) *entities.DeviceCommand); ok {
+		r0 = returnFunc(ctx, macAddress, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceCommand)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, entities.DeviceCommandRequest) error); ok {
+		r1 = returnFunc(ctx, macAddress, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceCommandsUseCase_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockDeviceCommandsUseCase_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - request entities.DeviceCommandRequest
+func (_e *MockDeviceCommandsUseCase_Expecter) Send(ctx interface{}, macAddress interface{}, request interface{}) *MockDeviceCommandsUseCase_Send_Call {
+	return &MockDeviceCommandsUseCase_Send_Call{Call: _e.mock.On("Send", ctx, macAddress, request)}
+}
+
+func (_c *MockDeviceCommandsUseCase_Send_Call) Run(run func(ctx context.Context, macAddress string, request entities.DeviceCommandRequest)) *MockDeviceCommandsUseCase_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 entities.DeviceCommandRequest
+		if args[2] != nil {
+			arg2 = args[2].(entities.DeviceCommandRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceCommandsUseCase_Send_Call) Return(deviceCommand *entities.DeviceCommand, err error) *MockDeviceCommandsUseCase_Send_Call {
+	_c.Call.Return(deviceCommand, err)
+	return _c
+}
+
+func (_c *MockDeviceCommandsUseCase_Send_Call) RunAndReturn(run func(ctx context.Context, macAddress string, request entities.DeviceCommandRequest) (*entities.DeviceCommand, error)) *MockDeviceCommandsUseCase_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
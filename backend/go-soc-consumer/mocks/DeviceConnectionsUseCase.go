@@ -0,0 +1,199 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceConnectionsUseCase creates a new instance of MockDeviceConnectionsUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceConnectionsUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceConnectionsUseCase {
+	mock := &MockDeviceConnectionsUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceConnectionsUseCase is an autogenerated mock type for the DeviceConnectionsUseCase type
+type MockDeviceConnectionsUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceConnectionsUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceConnectionsUseCase) EXPECT() *MockDeviceConnectionsUseCase_Expecter {
+	return &MockDeviceConnectionsUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function for the type MockDeviceConnectionsUseCase
+func (_mock *MockDeviceConnectionsUseCase) Get(ctx context.Context, macAddress string) (*entities.DeviceConnection, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entities.DeviceConnection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceConnection, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceConnection); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceConnection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceConnectionsUseCase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDeviceConnectionsUseCase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockDeviceConnectionsUseCase_Expecter) Get(ctx interface{}, macAddress interface{}) *MockDeviceConnectionsUseCase_Get_Call {
+	return &MockDeviceConnectionsUseCase_Get_Call{Call: _e.mock.On("Get", ctx, macAddress)}
+}
+
+func (_c *MockDeviceConnectionsUseCase_Get_Call) Run(run func(ctx context.Context, macAddress string)) *MockDeviceConnectionsUseCase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceConnectionsUseCase_Get_Call) Return(deviceConnection *entities.DeviceConnection, err error) *MockDeviceConnectionsUseCase_Get_Call {
+	_c.Call.Return(deviceConnection, err)
+	return _c
+}
+
+func (_c *MockDeviceConnectionsUseCase_Get_Call) RunAndReturn(run func(ctx context.Context, macAddress string) (*entities.DeviceConnection, error)) *MockDeviceConnectionsUseCase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Mismatches provides a mock function for the type MockDeviceConnectionsUseCase
+func (_mock *MockDeviceConnectionsUseCase) Mismatches() []*entities.DeviceConnection {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Mismatches")
+	}
+
+	var r0 []*entities.DeviceConnection
+	if returnFunc, ok := ret.Get(0).(func() []*entities.DeviceConnection); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceConnection)
+		}
+	}
+	return r0
+}
+
+// MockDeviceConnectionsUseCase_Mismatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Mismatches'
+type MockDeviceConnectionsUseCase_Mismatches_Call struct {
+	*mock.Call
+}
+
+// Mismatches is a helper method to define mock.On call
+func (_e *MockDeviceConnectionsUseCase_Expecter) Mismatches() *MockDeviceConnectionsUseCase_Mismatches_Call {
+	return &MockDeviceConnectionsUseCase_Mismatches_Call{Call: _e.mock.On("Mismatches")}
+}
+
+func (_c *MockDeviceConnectionsUseCase_Mismatches_Call) Run(run func()) *MockDeviceConnectionsUseCase_Mismatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDeviceConnectionsUseCase_Mismatches_Call) Return(deviceConnections []*entities.DeviceConnection) *MockDeviceConnectionsUseCase_Mismatches_Call {
+	_c.Call.Return(deviceConnections)
+	return _c
+}
+
+func (_c *MockDeviceConnectionsUseCase_Mismatches_Call) RunAndReturn(run func() []*entities.DeviceConnection) *MockDeviceConnectionsUseCase_Mismatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Observe provides a mock function for the type MockDeviceConnectionsUseCase
+func (_mock *MockDeviceConnectionsUseCase) Observe(ctx context.Context, macAddress string) {
+	_mock.Called(ctx, macAddress)
+	return
+}
+
+// MockDeviceConnectionsUseCase_Observe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Observe'
+type MockDeviceConnectionsUseCase_Observe_Call struct {
+	*mock.Call
+}
+
+// Observe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockDeviceConnectionsUseCase_Expecter) Observe(ctx interface{}, macAddress interface{}) *MockDeviceConnectionsUseCase_Observe_Call {
+	return &MockDeviceConnectionsUseCase_Observe_Call{Call: _e.mock.On("Observe", ctx, macAddress)}
+}
+
+func (_c *MockDeviceConnectionsUseCase_Observe_Call) Run(run func(ctx context.Context, macAddress string)) *MockDeviceConnectionsUseCase_Observe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceConnectionsUseCase_Observe_Call) Return() *MockDeviceConnectionsUseCase_Observe_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDeviceConnectionsUseCase_Observe_Call) RunAndReturn(run func(ctx context.Context, macAddress string)) *MockDeviceConnectionsUseCase_Observe_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -38,6 +38,74 @@ func (_m *MockDeviceHealthUseCase) EXPECT() *MockDeviceHealthUseCase_Expecter {
 	return &MockDeviceHealthUseCase_Expecter{mock: &_m.Mock}
 }
 
+// CheckDevice provides a mock function for the type MockDeviceHealthUseCase
+func (_mock *MockDeviceHealthUseCase) CheckDevice(ctx context.Context, macAddress string) (*entities.DeviceProbe, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckDevice")
+	}
+
+	var r0 *entities.DeviceProbe
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceProbe, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceProbe); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceProbe)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceHealthUseCase_CheckDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckDevice'
+type MockDeviceHealthUseCase_CheckDevice_Call struct {
+	*mock.Call
+}
+
+// CheckDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockDeviceHealthUseCase_Expecter) CheckDevice(ctx interface{}, macAddress interface{}) *MockDeviceHealthUseCase_CheckDevice_Call {
+	return &MockDeviceHealthUseCase_CheckDevice_Call{Call: _e.mock.On("CheckDevice", ctx, macAddress)}
+}
+
+func (_c *MockDeviceHealthUseCase_CheckDevice_Call) Run(run func(ctx context.Context, macAddress string)) *MockDeviceHealthUseCase_CheckDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceHealthUseCase_CheckDevice_Call) Return(deviceProbe *entities.DeviceProbe, err error) *MockDeviceHealthUseCase_CheckDevice_Call {
+	_c.Call.Return(deviceProbe, err)
+	return _c
+}
+
+func (_c *MockDeviceHealthUseCase_CheckDevice_Call) RunAndReturn(run func(ctx context.Context, macAddress string) (*entities.DeviceProbe, error)) *MockDeviceHealthUseCase_CheckDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ProcessDeviceDetectedEvent provides a mock function for the type MockDeviceHealthUseCase
 func (_mock *MockDeviceHealthUseCase) ProcessDeviceDetectedEvent(ctx context.Context, event *entities.DeviceDetectedEvent) error {
 	ret := _mock.Called(ctx, event)
@@ -94,3 +162,103 @@ func (_c *MockDeviceHealthUseCase_ProcessDeviceDetectedEvent_Call) RunAndReturn(
 	_c.Call.Return(run)
 	return _c
 }
+
+// Run provides a mock function for the type MockDeviceHealthUseCase
+func (_mock *MockDeviceHealthUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockDeviceHealthUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockDeviceHealthUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceHealthUseCase_Expecter) Run(ctx interface{}) *MockDeviceHealthUseCase_Run_Call {
+	return &MockDeviceHealthUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockDeviceHealthUseCase_Run_Call) Run(run func(ctx context.Context)) *MockDeviceHealthUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceHealthUseCase_Run_Call) Return() *MockDeviceHealthUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDeviceHealthUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockDeviceHealthUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SweepOffline provides a mock function for the type MockDeviceHealthUseCase
+func (_mock *MockDeviceHealthUseCase) SweepOffline(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SweepOffline")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceHealthUseCase_SweepOffline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SweepOffline'
+type MockDeviceHealthUseCase_SweepOffline_Call struct {
+	*mock.Call
+}
+
+// SweepOffline is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceHealthUseCase_Expecter) SweepOffline(ctx interface{}) *MockDeviceHealthUseCase_SweepOffline_Call {
+	return &MockDeviceHealthUseCase_SweepOffline_Call{Call: _e.mock.On("SweepOffline", ctx)}
+}
+
+func (_c *MockDeviceHealthUseCase_SweepOffline_Call) Run(run func(ctx context.Context)) *MockDeviceHealthUseCase_SweepOffline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceHealthUseCase_SweepOffline_Call) Return(n int, err error) *MockDeviceHealthUseCase_SweepOffline_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDeviceHealthUseCase_SweepOffline_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockDeviceHealthUseCase_SweepOffline_Call {
+	_c.Call.Return(run)
+	return _c
+}
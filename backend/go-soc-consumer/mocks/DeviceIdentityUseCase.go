@@ -0,0 +1,170 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceIdentityUseCase creates a new instance of MockDeviceIdentityUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceIdentityUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceIdentityUseCase {
+	mock := &MockDeviceIdentityUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceIdentityUseCase is an autogenerated mock type for the DeviceIdentityUseCase type
+type MockDeviceIdentityUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceIdentityUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceIdentityUseCase) EXPECT() *MockDeviceIdentityUseCase_Expecter {
+	return &MockDeviceIdentityUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ProvisionCertificate provides a mock function for the type MockDeviceIdentityUseCase
+func (_mock *MockDeviceIdentityUseCase) ProvisionCertificate(ctx context.Context, macAddress string, fingerprint string) (*entities.Device, error) {
+	ret := _mock.Called(ctx, macAddress, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProvisionCertificate")
+	}
+
+	var r0 *entities.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entities.Device, error)); ok {
+		return returnFunc(ctx, macAddress, fingerprint)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entities.Device); ok {
+		r0 = returnFunc(ctx, macAddress, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, macAddress, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceIdentityUseCase_ProvisionCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProvisionCertificate'
+type MockDeviceIdentityUseCase_ProvisionCertificate_Call struct {
+	*mock.Call
+}
+
+// ProvisionCertificate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - fingerprint string
+func (_e *MockDeviceIdentityUseCase_Expecter) ProvisionCertificate(ctx interface{}, macAddress interface{}, fingerprint interface{}) *MockDeviceIdentityUseCase_ProvisionCertificate_Call {
+	return &MockDeviceIdentityUseCase_ProvisionCertificate_Call{Call: _e.mock.On("ProvisionCertificate", ctx, macAddress, fingerprint)}
+}
+
+func (_c *MockDeviceIdentityUseCase_ProvisionCertificate_Call) Run(run func(ctx context.Context, macAddress string, fingerprint string)) *MockDeviceIdentityUseCase_ProvisionCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceIdentityUseCase_ProvisionCertificate_Call) Return(device *entities.Device, err error) *MockDeviceIdentityUseCase_ProvisionCertificate_Call {
+	_c.Call.Return(device, err)
+	return _c
+}
+
+func (_c *MockDeviceIdentityUseCase_ProvisionCertificate_Call) RunAndReturn(run func(ctx context.Context, macAddress string, fingerprint string) (*entities.Device, error)) *MockDeviceIdentityUseCase_ProvisionCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function for the type MockDeviceIdentityUseCase
+func (_mock *MockDeviceIdentityUseCase) Verify(ctx context.Context, macAddress string) error {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceIdentityUseCase_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockDeviceIdentityUseCase_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockDeviceIdentityUseCase_Expecter) Verify(ctx interface{}, macAddress interface{}) *MockDeviceIdentityUseCase_Verify_Call {
+	return &MockDeviceIdentityUseCase_Verify_Call{Call: _e.mock.On("Verify", ctx, macAddress)}
+}
+
+func (_c *MockDeviceIdentityUseCase_Verify_Call) Run(run func(ctx context.Context, macAddress string)) *MockDeviceIdentityUseCase_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceIdentityUseCase_Verify_Call) Return(err error) *MockDeviceIdentityUseCase_Verify_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceIdentityUseCase_Verify_Call) RunAndReturn(run func(ctx context.Context, macAddress string) error) *MockDeviceIdentityUseCase_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
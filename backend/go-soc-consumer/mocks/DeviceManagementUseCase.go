@@ -0,0 +1,392 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceManagementUseCase creates a new instance of MockDeviceManagementUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceManagementUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceManagementUseCase {
+	mock := &MockDeviceManagementUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceManagementUseCase is an autogenerated mock type for the DeviceManagementUseCase type
+type MockDeviceManagementUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceManagementUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceManagementUseCase) EXPECT() *MockDeviceManagementUseCase_Expecter {
+	return &MockDeviceManagementUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockDeviceManagementUseCase
+func (_mock *MockDeviceManagementUseCase) Create(ctx context.Context, configuration entities.DeviceConfiguration) (*entities.Device, error) {
+	ret := _mock.Called(ctx, configuration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entities.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.DeviceConfiguration) (*entities.Device, error)); ok {
+		return returnFunc(ctx, configuration)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.DeviceConfiguration) *entities.Device); ok {
+		r0 = returnFunc(ctx, configuration)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.DeviceConfiguration) error); ok {
+		r1 = returnFunc(ctx, configuration)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceManagementUseCase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockDeviceManagementUseCase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - configuration entities.DeviceConfiguration
+func (_e *MockDeviceManagementUseCase_Expecter) Create(ctx interface{}, configuration interface{}) *MockDeviceManagementUseCase_Create_Call {
+	return &MockDeviceManagementUseCase_Create_Call{Call: _e.mock.On("Create", ctx, configuration)}
+}
+
+func (_c *MockDeviceManagementUseCase_Create_Call) Run(run func(ctx context.Context, configuration entities.DeviceConfiguration)) *MockDeviceManagementUseCase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 entities.DeviceConfiguration
+		if args[1] != nil {
+			arg1 = args[1].(entities.DeviceConfiguration)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceManagementUseCase_Create_Call) Return(device *entities.Device, err error) *MockDeviceManagementUseCase_Create_Call {
+	_c.Call.Return(device, err)
+	return _c
+}
+
+func (_c *MockDeviceManagementUseCase_Create_Call) RunAndReturn(run func(ctx context.Context, configuration entities.DeviceConfiguration) (*entities.Device, error)) *MockDeviceManagementUseCase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockDeviceManagementUseCase
+func (_mock *MockDeviceManagementUseCase) Delete(ctx context.Context, macAddress string) error {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceManagementUseCase_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockDeviceManagementUseCase_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockDeviceManagementUseCase_Expecter) Delete(ctx interface{}, macAddress interface{}) *MockDeviceManagementUseCase_Delete_Call {
+	return &MockDeviceManagementUseCase_Delete_Call{Call: _e.mock.On("Delete", ctx, macAddress)}
+}
+
+func (_c *MockDeviceManagementUseCase_Delete_Call) Run(run func(ctx context.Context, macAddress string)) *MockDeviceManagementUseCase_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceManagementUseCase_Delete_Call) Return(err error) *MockDeviceManagementUseCase_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceManagementUseCase_Delete_Call) RunAndReturn(run func(ctx context.Context, macAddress string) error) *MockDeviceManagementUseCase_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockDeviceManagementUseCase
+func (_mock *MockDeviceManagementUseCase) Get(ctx context.Context, macAddress string) (*entities.Device, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entities.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.Device, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.Device); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceManagementUseCase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDeviceManagementUseCase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockDeviceManagementUseCase_Expecter) Get(ctx interface{}, macAddress interface{}) *MockDeviceManagementUseCase_Get_Call {
+	return &MockDeviceManagementUseCase_Get_Call{Call: _e.mock.On("Get", ctx, macAddress)}
+}
+
+func (_c *MockDeviceManagementUseCase_Get_Call) Run(run func(ctx context.Context, macAddress string)) *MockDeviceManagementUseCase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceManagementUseCase_Get_Call) Return(device *entities.Device, err error) *MockDeviceManagementUseCase_Get_Call {
+	_c.Call.Return(device, err)
+	return _c
+}
+
+func (_c *MockDeviceManagementUseCase_Get_Call) RunAndReturn(run func(ctx context.Context, macAddress string) (*entities.Device, error)) *MockDeviceManagementUseCase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockDeviceManagementUseCase
+func (_mock *MockDeviceManagementUseCase) List(ctx context.Context, status string, zoneID string, offset int, limit int) ([]*entities.Device, error) {
+	ret := _mock.Called(ctx, status, zoneID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, int) ([]*entities.Device, error)); ok {
+		return returnFunc(ctx, status, zoneID, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, int) []*entities.Device); ok {
+		r0 = returnFunc(ctx, status, zoneID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int, int) error); ok {
+		r1 = returnFunc(ctx, status, zoneID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceManagementUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeviceManagementUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status string
+//   - zoneID string
+//   - offset int
+//   - limit int
+func (_e *MockDeviceManagementUseCase_Expecter) List(ctx interface{}, status interface{}, zoneID interface{}, offset interface{}, limit interface{}) *MockDeviceManagementUseCase_List_Call {
+	return &MockDeviceManagementUseCase_List_Call{Call: _e.mock.On("List", ctx, status, zoneID, offset, limit)}
+}
+
+func (_c *MockDeviceManagementUseCase_List_Call) Run(run func(ctx context.Context, status string, zoneID string, offset int, limit int)) *MockDeviceManagementUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceManagementUseCase_List_Call) Return(devices []*entities.Device, err error) *MockDeviceManagementUseCase_List_Call {
+	_c.Call.Return(devices, err)
+	return _c
+}
+
+func (_c *MockDeviceManagementUseCase_List_Call) RunAndReturn(run func(ctx context.Context, status string, zoneID string, offset int, limit int) ([]*entities.Device, error)) *MockDeviceManagementUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockDeviceManagementUseCase
+func (_mock *MockDeviceManagementUseCase) Update(ctx context.Context, macAddress string, configuration entities.DeviceConfiguration) (*entities.Device, error) {
+	ret := _mock.Called(ctx, macAddress, configuration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *entities.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.DeviceConfiguration) (*entities.Device, error)); ok {
+		return returnFunc(ctx, macAddress, configuration)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.DeviceConfiguration) *entities.Device); ok {
+		r0 = returnFunc(ctx, macAddress, configuration)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, entities.DeviceConfiguration) error); ok {
+		r1 = returnFunc(ctx, macAddress, configuration)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceManagementUseCase_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockDeviceManagementUseCase_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - configuration entities.DeviceConfiguration
+func (_e *MockDeviceManagementUseCase_Expecter) Update(ctx interface{}, macAddress interface{}, configuration interface{}) *MockDeviceManagementUseCase_Update_Call {
+	return &MockDeviceManagementUseCase_Update_Call{Call: _e.mock.On("Update", ctx, macAddress, configuration)}
+}
+
+func (_c *MockDeviceManagementUseCase_Update_Call) Run(run func(ctx context.Context, macAddress string, configuration entities.DeviceConfiguration)) *MockDeviceManagementUseCase_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 entities.DeviceConfiguration
+		if args[2] != nil {
+			arg2 = args[2].(entities.DeviceConfiguration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceManagementUseCase_Update_Call) Return(device *entities.Device, err error) *MockDeviceManagementUseCase_Update_Call {
+	_c.Call.Return(device, err)
+	return _c
+}
+
+func (_c *MockDeviceManagementUseCase_Update_Call) RunAndReturn(run func(ctx context.Context, macAddress string, configuration entities.DeviceConfiguration) (*entities.Device, error)) *MockDeviceManagementUseCase_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
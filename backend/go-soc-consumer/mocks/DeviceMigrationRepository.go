@@ -0,0 +1,567 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceMigrationRepository creates a new instance of MockDeviceMigrationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceMigrationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceMigrationRepository {
+	mock := &MockDeviceMigrationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceMigrationRepository is an autogenerated mock type for the DeviceMigrationRepository type
+type MockDeviceMigrationRepository struct {
+	mock.Mock
+}
+
+type MockDeviceMigrationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceMigrationRepository) EXPECT() *MockDeviceMigrationRepository_Expecter {
+	return &MockDeviceMigrationRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockDeviceMigrationRepository
+func (_mock *MockDeviceMigrationRepository) Create(ctx context.Context, migration *entities.DeviceMigration, targets []*entities.DeviceMigrationTarget) error {
+	ret := _mock.Called(ctx, migration, targets)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceMigration, []*entities.DeviceMigrationTarget) error); ok {
+		r0 = returnFunc(ctx, migration, targets)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceMigrationRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockDeviceMigrationRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - migration *entities.DeviceMigration
+//   - targets []*entities.DeviceMigrationTarget
+func (_e *MockDeviceMigrationRepository_Expecter) Create(ctx interface{}, migration interface{}, targets interface{}) *MockDeviceMigrationRepository_Create_Call {
+	return &MockDeviceMigrationRepository_Create_Call{Call: _e.mock.On("Create", ctx, migration, targets)}
+}
+
+func (_c *MockDeviceMigrationRepository_Create_Call) Run(run func(ctx context.Context, migration *entities.DeviceMigration, targets []*entities.DeviceMigrationTarget)) *MockDeviceMigrationRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceMigration
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceMigration)
+		}
+		var arg2 []*entities.DeviceMigrationTarget
+		if args[2] != nil {
+			arg2 = args[2].([]*entities.DeviceMigrationTarget)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_Create_Call) Return(err error) *MockDeviceMigrationRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_Create_Call) RunAndReturn(run func(ctx context.Context, migration *entities.DeviceMigration, targets []*entities.DeviceMigrationTarget) error) *MockDeviceMigrationRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockDeviceMigrationRepository
+func (_mock *MockDeviceMigrationRepository) FindByID(ctx context.Context, id string) (*entities.DeviceMigration, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.DeviceMigration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceMigration, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceMigration); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceMigration)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceMigrationRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockDeviceMigrationRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceMigrationRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockDeviceMigrationRepository_FindByID_Call {
+	return &MockDeviceMigrationRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockDeviceMigrationRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockDeviceMigrationRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_FindByID_Call) Return(deviceMigration *entities.DeviceMigration, err error) *MockDeviceMigrationRepository_FindByID_Call {
+	_c.Call.Return(deviceMigration, err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceMigration, error)) *MockDeviceMigrationRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRunning provides a mock function for the type MockDeviceMigrationRepository
+func (_mock *MockDeviceMigrationRepository) FindRunning(ctx context.Context) (*entities.DeviceMigration, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRunning")
+	}
+
+	var r0 *entities.DeviceMigration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*entities.DeviceMigration, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *entities.DeviceMigration); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceMigration)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceMigrationRepository_FindRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRunning'
+type MockDeviceMigrationRepository_FindRunning_Call struct {
+	*mock.Call
+}
+
+// FindRunning is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceMigrationRepository_Expecter) FindRunning(ctx interface{}) *MockDeviceMigrationRepository_FindRunning_Call {
+	return &MockDeviceMigrationRepository_FindRunning_Call{Call: _e.mock.On("FindRunning", ctx)}
+}
+
+func (_c *MockDeviceMigrationRepository_FindRunning_Call) Run(run func(ctx context.Context)) *MockDeviceMigrationRepository_FindRunning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_FindRunning_Call) Return(deviceMigration *entities.DeviceMigration, err error) *MockDeviceMigrationRepository_FindRunning_Call {
+	_c.Call.Return(deviceMigration, err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_FindRunning_Call) RunAndReturn(run func(ctx context.Context) (*entities.DeviceMigration, error)) *MockDeviceMigrationRepository_FindRunning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockDeviceMigrationRepository
+func (_mock *MockDeviceMigrationRepository) List(ctx context.Context, limit int) ([]*entities.DeviceMigration, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.DeviceMigration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]*entities.DeviceMigration, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []*entities.DeviceMigration); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceMigration)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceMigrationRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeviceMigrationRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockDeviceMigrationRepository_Expecter) List(ctx interface{}, limit interface{}) *MockDeviceMigrationRepository_List_Call {
+	return &MockDeviceMigrationRepository_List_Call{Call: _e.mock.On("List", ctx, limit)}
+}
+
+func (_c *MockDeviceMigrationRepository_List_Call) Run(run func(ctx context.Context, limit int)) *MockDeviceMigrationRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_List_Call) Return(deviceMigrations []*entities.DeviceMigration, err error) *MockDeviceMigrationRepository_List_Call {
+	_c.Call.Return(deviceMigrations, err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_List_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]*entities.DeviceMigration, error)) *MockDeviceMigrationRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkReconnected provides a mock function for the type MockDeviceMigrationRepository
+func (_mock *MockDeviceMigrationRepository) MarkReconnected(ctx context.Context, migrationID string, macAddress string, at time.Time) (bool, error) {
+	ret := _mock.Called(ctx, migrationID, macAddress, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkReconnected")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, migrationID, macAddress, at)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) bool); ok {
+		r0 = returnFunc(ctx, migrationID, macAddress, at)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, migrationID, macAddress, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceMigrationRepository_MarkReconnected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkReconnected'
+type MockDeviceMigrationRepository_MarkReconnected_Call struct {
+	*mock.Call
+}
+
+// MarkReconnected is a helper method to define mock.On call
+//   - ctx context.Context
+//   - migrationID string
+//   - macAddress string
+//   - at time.Time
+func (_e *MockDeviceMigrationRepository_Expecter) MarkReconnected(ctx interface{}, migrationID interface{}, macAddress interface{}, at interface{}) *MockDeviceMigrationRepository_MarkReconnected_Call {
+	return &MockDeviceMigrationRepository_MarkReconnected_Call{Call: _e.mock.On("MarkReconnected", ctx, migrationID, macAddress, at)}
+}
+
+func (_c *MockDeviceMigrationRepository_MarkReconnected_Call) Run(run func(ctx context.Context, migrationID string, macAddress string, at time.Time)) *MockDeviceMigrationRepository_MarkReconnected_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_MarkReconnected_Call) Return(b bool, err error) *MockDeviceMigrationRepository_MarkReconnected_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_MarkReconnected_Call) RunAndReturn(run func(ctx context.Context, migrationID string, macAddress string, at time.Time) (bool, error)) *MockDeviceMigrationRepository_MarkReconnected_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Targets provides a mock function for the type MockDeviceMigrationRepository
+func (_mock *MockDeviceMigrationRepository) Targets(ctx context.Context, migrationID string, status string) ([]*entities.DeviceMigrationTarget, error) {
+	ret := _mock.Called(ctx, migrationID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Targets")
+	}
+
+	var r0 []*entities.DeviceMigrationTarget
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]*entities.DeviceMigrationTarget, error)); ok {
+		return returnFunc(ctx, migrationID, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []*entities.DeviceMigrationTarget); ok {
+		r0 = returnFunc(ctx, migrationID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceMigrationTarget)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, migrationID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceMigrationRepository_Targets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Targets'
+type MockDeviceMigrationRepository_Targets_Call struct {
+	*mock.Call
+}
+
+// Targets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - migrationID string
+//   - status string
+func (_e *MockDeviceMigrationRepository_Expecter) Targets(ctx interface{}, migrationID interface{}, status interface{}) *MockDeviceMigrationRepository_Targets_Call {
+	return &MockDeviceMigrationRepository_Targets_Call{Call: _e.mock.On("Targets", ctx, migrationID, status)}
+}
+
+func (_c *MockDeviceMigrationRepository_Targets_Call) Run(run func(ctx context.Context, migrationID string, status string)) *MockDeviceMigrationRepository_Targets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_Targets_Call) Return(deviceMigrationTargets []*entities.DeviceMigrationTarget, err error) *MockDeviceMigrationRepository_Targets_Call {
+	_c.Call.Return(deviceMigrationTargets, err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_Targets_Call) RunAndReturn(run func(ctx context.Context, migrationID string, status string) ([]*entities.DeviceMigrationTarget, error)) *MockDeviceMigrationRepository_Targets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockDeviceMigrationRepository
+func (_mock *MockDeviceMigrationRepository) Update(ctx context.Context, migration *entities.DeviceMigration) error {
+	ret := _mock.Called(ctx, migration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceMigration) error); ok {
+		r0 = returnFunc(ctx, migration)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceMigrationRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockDeviceMigrationRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - migration *entities.DeviceMigration
+func (_e *MockDeviceMigrationRepository_Expecter) Update(ctx interface{}, migration interface{}) *MockDeviceMigrationRepository_Update_Call {
+	return &MockDeviceMigrationRepository_Update_Call{Call: _e.mock.On("Update", ctx, migration)}
+}
+
+func (_c *MockDeviceMigrationRepository_Update_Call) Run(run func(ctx context.Context, migration *entities.DeviceMigration)) *MockDeviceMigrationRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceMigration
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceMigration)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_Update_Call) Return(err error) *MockDeviceMigrationRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_Update_Call) RunAndReturn(run func(ctx context.Context, migration *entities.DeviceMigration) error) *MockDeviceMigrationRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTarget provides a mock function for the type MockDeviceMigrationRepository
+func (_mock *MockDeviceMigrationRepository) UpdateTarget(ctx context.Context, target *entities.DeviceMigrationTarget) error {
+	ret := _mock.Called(ctx, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTarget")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceMigrationTarget) error); ok {
+		r0 = returnFunc(ctx, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceMigrationRepository_UpdateTarget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTarget'
+type MockDeviceMigrationRepository_UpdateTarget_Call struct {
+	*mock.Call
+}
+
+// UpdateTarget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - target *entities.DeviceMigrationTarget
+func (_e *MockDeviceMigrationRepository_Expecter) UpdateTarget(ctx interface{}, target interface{}) *MockDeviceMigrationRepository_UpdateTarget_Call {
+	return &MockDeviceMigrationRepository_UpdateTarget_Call{Call: _e.mock.On("UpdateTarget", ctx, target)}
+}
+
+func (_c *MockDeviceMigrationRepository_UpdateTarget_Call) Run(run func(ctx context.Context, target *entities.DeviceMigrationTarget)) *MockDeviceMigrationRepository_UpdateTarget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceMigrationTarget
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceMigrationTarget)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_UpdateTarget_Call) Return(err error) *MockDeviceMigrationRepository_UpdateTarget_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationRepository_UpdateTarget_Call) RunAndReturn(run func(ctx context.Context, target *entities.DeviceMigrationTarget) error) *MockDeviceMigrationRepository_UpdateTarget_Call {
+	_c.Call.Return(run)
+	return _c
+}
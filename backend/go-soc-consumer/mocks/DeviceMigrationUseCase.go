@@ -0,0 +1,443 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceMigrationUseCase creates a new instance of MockDeviceMigrationUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceMigrationUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceMigrationUseCase {
+	mock := &MockDeviceMigrationUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceMigrationUseCase is an autogenerated mock type for the DeviceMigrationUseCase type
+type MockDeviceMigrationUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceMigrationUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceMigrationUseCase) EXPECT() *MockDeviceMigrationUseCase_Expecter {
+	return &MockDeviceMigrationUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Cancel provides a mock function for the type MockDeviceMigrationUseCase
+func (_mock *MockDeviceMigrationUseCase) Cancel(ctx context.Context, id string) (*entities.DeviceMigration, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *entities.DeviceMigration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceMigration, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceMigration); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceMigration)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceMigrationUseCase_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockDeviceMigrationUseCase_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceMigrationUseCase_Expecter) Cancel(ctx interface{}, id interface{}) *MockDeviceMigrationUseCase_Cancel_Call {
+	return &MockDeviceMigrationUseCase_Cancel_Call{Call: _e.mock.On("Cancel", ctx, id)}
+}
+
+func (_c *MockDeviceMigrationUseCase_Cancel_Call) Run(run func(ctx context.Context, id string)) *MockDeviceMigrationUseCase_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_Cancel_Call) Return(deviceMigration *entities.DeviceMigration, err error) *MockDeviceMigrationUseCase_Cancel_Call {
+	_c.Call.Return(deviceMigration, err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_Cancel_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceMigration, error)) *MockDeviceMigrationUseCase_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockDeviceMigrationUseCase
+func (_mock *MockDeviceMigrationUseCase) Get(ctx context.Context, id string) (*entities.DeviceMigration, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entities.DeviceMigration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceMigration, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceMigration); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceMigration)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceMigrationUseCase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDeviceMigrationUseCase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceMigrationUseCase_Expecter) Get(ctx interface{}, id interface{}) *MockDeviceMigrationUseCase_Get_Call {
+	return &MockDeviceMigrationUseCase_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockDeviceMigrationUseCase_Get_Call) Run(run func(ctx context.Context, id string)) *MockDeviceMigrationUseCase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_Get_Call) Return(deviceMigration *entities.DeviceMigration, err error) *MockDeviceMigrationUseCase_Get_Call {
+	_c.Call.Return(deviceMigration, err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceMigration, error)) *MockDeviceMigrationUseCase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockDeviceMigrationUseCase
+func (_mock *MockDeviceMigrationUseCase) List(ctx context.Context, limit int) ([]*entities.DeviceMigration, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.DeviceMigration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]*entities.DeviceMigration, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []*entities.DeviceMigration); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceMigration)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceMigrationUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeviceMigrationUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockDeviceMigrationUseCase_Expecter) List(ctx interface{}, limit interface{}) *MockDeviceMigrationUseCase_List_Call {
+	return &MockDeviceMigrationUseCase_List_Call{Call: _e.mock.On("List", ctx, limit)}
+}
+
+func (_c *MockDeviceMigrationUseCase_List_Call) Run(run func(ctx context.Context, limit int)) *MockDeviceMigrationUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_List_Call) Return(deviceMigrations []*entities.DeviceMigration, err error) *MockDeviceMigrationUseCase_List_Call {
+	_c.Call.Return(deviceMigrations, err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_List_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]*entities.DeviceMigration, error)) *MockDeviceMigrationUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Observe provides a mock function for the type MockDeviceMigrationUseCase
+func (_mock *MockDeviceMigrationUseCase) Observe(ctx context.Context, macAddress string) {
+	_mock.Called(ctx, macAddress)
+	return
+}
+
+// MockDeviceMigrationUseCase_Observe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Observe'
+type MockDeviceMigrationUseCase_Observe_Call struct {
+	*mock.Call
+}
+
+// Observe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockDeviceMigrationUseCase_Expecter) Observe(ctx interface{}, macAddress interface{}) *MockDeviceMigrationUseCase_Observe_Call {
+	return &MockDeviceMigrationUseCase_Observe_Call{Call: _e.mock.On("Observe", ctx, macAddress)}
+}
+
+func (_c *MockDeviceMigrationUseCase_Observe_Call) Run(run func(ctx context.Context, macAddress string)) *MockDeviceMigrationUseCase_Observe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_Observe_Call) Return() *MockDeviceMigrationUseCase_Observe_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_Observe_Call) RunAndReturn(run func(ctx context.Context, macAddress string)) *MockDeviceMigrationUseCase_Observe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Report provides a mock function for the type MockDeviceMigrationUseCase
+func (_mock *MockDeviceMigrationUseCase) Report(ctx context.Context, id string) (*entities.DeviceMigrationReport, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Report")
+	}
+
+	var r0 *entities.DeviceMigrationReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceMigrationReport, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceMigrationReport); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceMigrationReport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceMigrationUseCase_Report_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Report'
+type MockDeviceMigrationUseCase_Report_Call struct {
+	*mock.Call
+}
+
+// Report is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceMigrationUseCase_Expecter) Report(ctx interface{}, id interface{}) *MockDeviceMigrationUseCase_Report_Call {
+	return &MockDeviceMigrationUseCase_Report_Call{Call: _e.mock.On("Report", ctx, id)}
+}
+
+func (_c *MockDeviceMigrationUseCase_Report_Call) Run(run func(ctx context.Context, id string)) *MockDeviceMigrationUseCase_Report_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_Report_Call) Return(deviceMigrationReport *entities.DeviceMigrationReport, err error) *MockDeviceMigrationUseCase_Report_Call {
+	_c.Call.Return(deviceMigrationReport, err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_Report_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceMigrationReport, error)) *MockDeviceMigrationUseCase_Report_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function for the type MockDeviceMigrationUseCase
+func (_mock *MockDeviceMigrationUseCase) Start(ctx context.Context, brokerURL string, layout string, farmID string, actor string) (*entities.DeviceMigration, error) {
+	ret := _mock.Called(ctx, brokerURL, layout, farmID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 *entities.DeviceMigration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*entities.DeviceMigration, error)); ok {
+		return returnFunc(ctx, brokerURL, layout, farmID, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) *entities.DeviceMigration); ok {
+		r0 = returnFunc(ctx, brokerURL, layout, farmID, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceMigration)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, brokerURL, layout, farmID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceMigrationUseCase_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockDeviceMigrationUseCase_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - brokerURL string
+//   - layout string
+//   - farmID string
+//   - actor string
+func (_e *MockDeviceMigrationUseCase_Expecter) Start(ctx interface{}, brokerURL interface{}, layout interface{}, farmID interface{}, actor interface{}) *MockDeviceMigrationUseCase_Start_Call {
+	return &MockDeviceMigrationUseCase_Start_Call{Call: _e.mock.On("Start", ctx, brokerURL, layout, farmID, actor)}
+}
+
+func (_c *MockDeviceMigrationUseCase_Start_Call) Run(run func(ctx context.Context, brokerURL string, layout string, farmID string, actor string)) *MockDeviceMigrationUseCase_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_Start_Call) Return(deviceMigration *entities.DeviceMigration, err error) *MockDeviceMigrationUseCase_Start_Call {
+	_c.Call.Return(deviceMigration, err)
+	return _c
+}
+
+func (_c *MockDeviceMigrationUseCase_Start_Call) RunAndReturn(run func(ctx context.Context, brokerURL string, layout string, farmID string, actor string) (*entities.DeviceMigration, error)) *MockDeviceMigrationUseCase_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
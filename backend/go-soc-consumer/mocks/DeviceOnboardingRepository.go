@@ -0,0 +1,340 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceOnboardingRepository creates a new instance of MockDeviceOnboardingRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceOnboardingRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceOnboardingRepository {
+	mock := &MockDeviceOnboardingRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceOnboardingRepository is an autogenerated mock type for the DeviceOnboardingRepository type
+type MockDeviceOnboardingRepository struct {
+	mock.Mock
+}
+
+type MockDeviceOnboardingRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceOnboardingRepository) EXPECT() *MockDeviceOnboardingRepository_Expecter {
+	return &MockDeviceOnboardingRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockDeviceOnboardingRepository
+func (_mock *MockDeviceOnboardingRepository) Create(ctx context.Context, onboarding *entities.DeviceOnboarding) error {
+	ret := _mock.Called(ctx, onboarding)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceOnboarding) error); ok {
+		r0 = returnFunc(ctx, onboarding)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceOnboardingRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockDeviceOnboardingRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - onboarding *entities.DeviceOnboarding
+func (_e *MockDeviceOnboardingRepository_Expecter) Create(ctx interface{}, onboarding interface{}) *MockDeviceOnboardingRepository_Create_Call {
+	return &MockDeviceOnboardingRepository_Create_Call{Call: _e.mock.On("Create", ctx, onboarding)}
+}
+
+func (_c *MockDeviceOnboardingRepository_Create_Call) Run(run func(ctx context.Context, onboarding *entities.DeviceOnboarding)) *MockDeviceOnboardingRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceOnboarding
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceOnboarding)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingRepository_Create_Call) Return(err error) *MockDeviceOnboardingRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingRepository_Create_Call) RunAndReturn(run func(ctx context.Context, onboarding *entities.DeviceOnboarding) error) *MockDeviceOnboardingRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockDeviceOnboardingRepository
+func (_mock *MockDeviceOnboardingRepository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceOnboardingRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockDeviceOnboardingRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceOnboardingRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockDeviceOnboardingRepository_Delete_Call {
+	return &MockDeviceOnboardingRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockDeviceOnboardingRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockDeviceOnboardingRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingRepository_Delete_Call) Return(err error) *MockDeviceOnboardingRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockDeviceOnboardingRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockDeviceOnboardingRepository
+func (_mock *MockDeviceOnboardingRepository) FindByID(ctx context.Context, id string) (*entities.DeviceOnboarding, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.DeviceOnboarding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceOnboarding, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceOnboarding); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceOnboarding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceOnboardingRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockDeviceOnboardingRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceOnboardingRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockDeviceOnboardingRepository_FindByID_Call {
+	return &MockDeviceOnboardingRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockDeviceOnboardingRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockDeviceOnboardingRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingRepository_FindByID_Call) Return(deviceOnboarding *entities.DeviceOnboarding, err error) *MockDeviceOnboardingRepository_FindByID_Call {
+	_c.Call.Return(deviceOnboarding, err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceOnboarding, error)) *MockDeviceOnboardingRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPending provides a mock function for the type MockDeviceOnboardingRepository
+func (_mock *MockDeviceOnboardingRepository) ListPending(ctx context.Context) ([]*entities.DeviceOnboarding, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPending")
+	}
+
+	var r0 []*entities.DeviceOnboarding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.DeviceOnboarding, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.DeviceOnboarding); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceOnboarding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceOnboardingRepository_ListPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPending'
+type MockDeviceOnboardingRepository_ListPending_Call struct {
+	*mock.Call
+}
+
+// ListPending is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceOnboardingRepository_Expecter) ListPending(ctx interface{}) *MockDeviceOnboardingRepository_ListPending_Call {
+	return &MockDeviceOnboardingRepository_ListPending_Call{Call: _e.mock.On("ListPending", ctx)}
+}
+
+func (_c *MockDeviceOnboardingRepository_ListPending_Call) Run(run func(ctx context.Context)) *MockDeviceOnboardingRepository_ListPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingRepository_ListPending_Call) Return(deviceOnboardings []*entities.DeviceOnboarding, err error) *MockDeviceOnboardingRepository_ListPending_Call {
+	_c.Call.Return(deviceOnboardings, err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingRepository_ListPending_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.DeviceOnboarding, error)) *MockDeviceOnboardingRepository_ListPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockDeviceOnboardingRepository
+func (_mock *MockDeviceOnboardingRepository) Update(ctx context.Context, onboarding *entities.DeviceOnboarding) error {
+	ret := _mock.Called(ctx, onboarding)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceOnboarding) error); ok {
+		r0 = returnFunc(ctx, onboarding)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceOnboardingRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockDeviceOnboardingRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - onboarding *entities.DeviceOnboarding
+func (_e *MockDeviceOnboardingRepository_Expecter) Update(ctx interface{}, onboarding interface{}) *MockDeviceOnboardingRepository_Update_Call {
+	return &MockDeviceOnboardingRepository_Update_Call{Call: _e.mock.On("Update", ctx, onboarding)}
+}
+
+func (_c *MockDeviceOnboardingRepository_Update_Call) Run(run func(ctx context.Context, onboarding *entities.DeviceOnboarding)) *MockDeviceOnboardingRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceOnboarding
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceOnboarding)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingRepository_Update_Call) Return(err error) *MockDeviceOnboardingRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingRepository_Update_Call) RunAndReturn(run func(ctx context.Context, onboarding *entities.DeviceOnboarding) error) *MockDeviceOnboardingRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
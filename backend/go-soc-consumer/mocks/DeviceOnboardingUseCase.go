@@ -0,0 +1,680 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceOnboardingUseCase creates a new instance of MockDeviceOnboardingUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceOnboardingUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceOnboardingUseCase {
+	mock := &MockDeviceOnboardingUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceOnboardingUseCase is an autogenerated mock type for the DeviceOnboardingUseCase type
+type MockDeviceOnboardingUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceOnboardingUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceOnboardingUseCase) EXPECT() *MockDeviceOnboardingUseCase_Expecter {
+	return &MockDeviceOnboardingUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Assign provides a mock function for the type MockDeviceOnboardingUseCase
+func (_mock *MockDeviceOnboardingUseCase) Assign(ctx context.Context, id string, locationDescription string) (*entities.DeviceOnboarding, error) {
+	ret := _mock.Called(ctx, id, locationDescription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Assign")
+	}
+
+	var r0 *entities.DeviceOnboarding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entities.DeviceOnboarding, error)); ok {
+		return returnFunc(ctx, id, locationDescription)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entities.DeviceOnboarding); ok {
+		r0 = returnFunc(ctx, id, locationDescription)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceOnboarding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, locationDescription)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceOnboardingUseCase_Assign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Assign'
+type MockDeviceOnboardingUseCase_Assign_Call struct {
+	*mock.Call
+}
+
+// Assign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - locationDescription string
+func (_e *MockDeviceOnboardingUseCase_Expecter) Assign(ctx interface{}, id interface{}, locationDescription interface{}) *MockDeviceOnboardingUseCase_Assign_Call {
+	return &MockDeviceOnboardingUseCase_Assign_Call{Call: _e.mock.On("Assign", ctx, id, locationDescription)}
+}
+
+func (_c *MockDeviceOnboardingUseCase_Assign_Call) Run(run func(ctx context.Context, id string, locationDescription string)) *MockDeviceOnboardingUseCase_Assign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_Assign_Call) Return(deviceOnboarding *entities.DeviceOnboarding, err error) *MockDeviceOnboardingUseCase_Assign_Call {
+	_c.Call.Return(deviceOnboarding, err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_Assign_Call) RunAndReturn(run func(ctx context.Context, id string, locationDescription string) (*entities.DeviceOnboarding, error)) *MockDeviceOnboardingUseCase_Assign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockDeviceOnboardingUseCase
+func (_mock *MockDeviceOnboardingUseCase) Create(ctx context.Context, request entities.OnboardingRequest) (*entities.DeviceOnboarding, *entities.ProvisioningPayload, error) {
+	ret := _mock.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entities.DeviceOnboarding
+	var r1 *entities.ProvisioningPayload
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.OnboardingRequest) (*entities.DeviceOnboarding, *entities.ProvisioningPayload, error)); ok {
+		return returnFunc(ctx, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.OnboardingRequest) *entities.DeviceOnboarding); ok {
+		r0 = returnFunc(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceOnboarding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.OnboardingRequest) *entities.ProvisioningPayload); ok {
+		r1 = returnFunc(ctx, request)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entities.ProvisioningPayload)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, entities.OnboardingRequest) error); ok {
+		r2 = returnFunc(ctx, request)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockDeviceOnboardingUseCase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockDeviceOnboardingUseCase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request entities.OnboardingRequest
+func (_e *MockDeviceOnboardingUseCase_Expecter) Create(ctx interface{}, request interface{}) *MockDeviceOnboardingUseCase_Create_Call {
+	return &MockDeviceOnboardingUseCase_Create_Call{Call: _e.mock.On("Create", ctx, request)}
+}
+
+func (_c *MockDeviceOnboardingUseCase_Create_Call) Run(run func(ctx context.Context, request entities.OnboardingRequest)) *MockDeviceOnboardingUseCase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 entities.OnboardingRequest
+		if args[1] != nil {
+			arg1 = args[1].(entities.OnboardingRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_Create_Call) Return(deviceOnboarding *entities.DeviceOnboarding, provisioningPayload *entities.ProvisioningPayload, err error) *MockDeviceOnboardingUseCase_Create_Call {
+	_c.Call.Return(deviceOnboarding, provisioningPayload, err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_Create_Call) RunAndReturn(run func(ctx context.Context, request entities.OnboardingRequest) (*entities.DeviceOnboarding, *entities.ProvisioningPayload, error)) *MockDeviceOnboardingUseCase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockDeviceOnboardingUseCase
+func (_mock *MockDeviceOnboardingUseCase) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceOnboardingUseCase_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockDeviceOnboardingUseCase_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceOnboardingUseCase_Expecter) Delete(ctx interface{}, id interface{}) *MockDeviceOnboardingUseCase_Delete_Call {
+	return &MockDeviceOnboardingUseCase_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockDeviceOnboardingUseCase_Delete_Call) Run(run func(ctx context.Context, id string)) *MockDeviceOnboardingUseCase_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_Delete_Call) Return(err error) *MockDeviceOnboardingUseCase_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockDeviceOnboardingUseCase_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockDeviceOnboardingUseCase
+func (_mock *MockDeviceOnboardingUseCase) Get(ctx context.Context, id string) (*entities.DeviceOnboarding, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entities.DeviceOnboarding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceOnboarding, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceOnboarding); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceOnboarding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceOnboardingUseCase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDeviceOnboardingUseCase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceOnboardingUseCase_Expecter) Get(ctx interface{}, id interface{}) *MockDeviceOnboardingUseCase_Get_Call {
+	return &MockDeviceOnboardingUseCase_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockDeviceOnboardingUseCase_Get_Call) Run(run func(ctx context.Context, id string)) *MockDeviceOnboardingUseCase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_Get_Call) Return(deviceOnboarding *entities.DeviceOnboarding, err error) *MockDeviceOnboardingUseCase_Get_Call {
+	_c.Call.Return(deviceOnboarding, err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceOnboarding, error)) *MockDeviceOnboardingUseCase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockDeviceOnboardingUseCase
+func (_mock *MockDeviceOnboardingUseCase) List(ctx context.Context) ([]*entities.DeviceOnboarding, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.DeviceOnboarding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.DeviceOnboarding, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.DeviceOnboarding); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceOnboarding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceOnboardingUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeviceOnboardingUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceOnboardingUseCase_Expecter) List(ctx interface{}) *MockDeviceOnboardingUseCase_List_Call {
+	return &MockDeviceOnboardingUseCase_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockDeviceOnboardingUseCase_List_Call) Run(run func(ctx context.Context)) *MockDeviceOnboardingUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_List_Call) Return(deviceOnboardings []*entities.DeviceOnboarding, err error) *MockDeviceOnboardingUseCase_List_Call {
+	_c.Call.Return(deviceOnboardings, err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_List_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.DeviceOnboarding, error)) *MockDeviceOnboardingUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkCredentialsDelivered provides a mock function for the type MockDeviceOnboardingUseCase
+func (_mock *MockDeviceOnboardingUseCase) MarkCredentialsDelivered(ctx context.Context, id string) (*entities.DeviceOnboarding, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkCredentialsDelivered")
+	}
+
+	var r0 *entities.DeviceOnboarding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceOnboarding, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceOnboarding); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceOnboarding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceOnboardingUseCase_MarkCredentialsDelivered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkCredentialsDelivered'
+type MockDeviceOnboardingUseCase_MarkCredentialsDelivered_Call struct {
+	*mock.Call
+}
+
+// MarkCredentialsDelivered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceOnboardingUseCase_Expecter) MarkCredentialsDelivered(ctx interface{}, id interface{}) *MockDeviceOnboardingUseCase_MarkCredentialsDelivered_Call {
+	return &MockDeviceOnboardingUseCase_MarkCredentialsDelivered_Call{Call: _e.mock.On("MarkCredentialsDelivered", ctx, id)}
+}
+
+func (_c *MockDeviceOnboardingUseCase_MarkCredentialsDelivered_Call) Run(run func(ctx context.Context, id string)) *MockDeviceOnboardingUseCase_MarkCredentialsDelivered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_MarkCredentialsDelivered_Call) Return(deviceOnboarding *entities.DeviceOnboarding, err error) *MockDeviceOnboardingUseCase_MarkCredentialsDelivered_Call {
+	_c.Call.Return(deviceOnboarding, err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_MarkCredentialsDelivered_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceOnboarding, error)) *MockDeviceOnboardingUseCase_MarkCredentialsDelivered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordConnection provides a mock function for the type MockDeviceOnboardingUseCase
+func (_mock *MockDeviceOnboardingUseCase) RecordConnection(ctx context.Context, macAddress string, token string) error {
+	ret := _mock.Called(ctx, macAddress, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordConnection")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, macAddress, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceOnboardingUseCase_RecordConnection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordConnection'
+type MockDeviceOnboardingUseCase_RecordConnection_Call struct {
+	*mock.Call
+}
+
+// RecordConnection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - token string
+func (_e *MockDeviceOnboardingUseCase_Expecter) RecordConnection(ctx interface{}, macAddress interface{}, token interface{}) *MockDeviceOnboardingUseCase_RecordConnection_Call {
+	return &MockDeviceOnboardingUseCase_RecordConnection_Call{Call: _e.mock.On("RecordConnection", ctx, macAddress, token)}
+}
+
+func (_c *MockDeviceOnboardingUseCase_RecordConnection_Call) Run(run func(ctx context.Context, macAddress string, token string)) *MockDeviceOnboardingUseCase_RecordConnection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_RecordConnection_Call) Return(err error) *MockDeviceOnboardingUseCase_RecordConnection_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_RecordConnection_Call) RunAndReturn(run func(ctx context.Context, macAddress string, token string) error) *MockDeviceOnboardingUseCase_RecordConnection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordTelemetry provides a mock function for the type MockDeviceOnboardingUseCase
+func (_mock *MockDeviceOnboardingUseCase) RecordTelemetry(ctx context.Context, macAddress string) error {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordTelemetry")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceOnboardingUseCase_RecordTelemetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordTelemetry'
+type MockDeviceOnboardingUseCase_RecordTelemetry_Call struct {
+	*mock.Call
+}
+
+// RecordTelemetry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockDeviceOnboardingUseCase_Expecter) RecordTelemetry(ctx interface{}, macAddress interface{}) *MockDeviceOnboardingUseCase_RecordTelemetry_Call {
+	return &MockDeviceOnboardingUseCase_RecordTelemetry_Call{Call: _e.mock.On("RecordTelemetry", ctx, macAddress)}
+}
+
+func (_c *MockDeviceOnboardingUseCase_RecordTelemetry_Call) Run(run func(ctx context.Context, macAddress string)) *MockDeviceOnboardingUseCase_RecordTelemetry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_RecordTelemetry_Call) Return(err error) *MockDeviceOnboardingUseCase_RecordTelemetry_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_RecordTelemetry_Call) RunAndReturn(run func(ctx context.Context, macAddress string) error) *MockDeviceOnboardingUseCase_RecordTelemetry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewToken provides a mock function for the type MockDeviceOnboardingUseCase
+func (_mock *MockDeviceOnboardingUseCase) RenewToken(ctx context.Context, id string) (*entities.DeviceOnboarding, *entities.ProvisioningPayload, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewToken")
+	}
+
+	var r0 *entities.DeviceOnboarding
+	var r1 *entities.ProvisioningPayload
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceOnboarding, *entities.ProvisioningPayload, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceOnboarding); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceOnboarding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) *entities.ProvisioningPayload); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entities.ProvisioningPayload)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockDeviceOnboardingUseCase_RenewToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewToken'
+type MockDeviceOnboardingUseCase_RenewToken_Call struct {
+	*mock.Call
+}
+
+// RenewToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceOnboardingUseCase_Expecter) RenewToken(ctx interface{}, id interface{}) *MockDeviceOnboardingUseCase_RenewToken_Call {
+	return &MockDeviceOnboardingUseCase_RenewToken_Call{Call: _e.mock.On("RenewToken", ctx, id)}
+}
+
+func (_c *MockDeviceOnboardingUseCase_RenewToken_Call) Run(run func(ctx context.Context, id string)) *MockDeviceOnboardingUseCase_RenewToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_RenewToken_Call) Return(deviceOnboarding *entities.DeviceOnboarding, provisioningPayload *entities.ProvisioningPayload, err error) *MockDeviceOnboardingUseCase_RenewToken_Call {
+	_c.Call.Return(deviceOnboarding, provisioningPayload, err)
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_RenewToken_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceOnboarding, *entities.ProvisioningPayload, error)) *MockDeviceOnboardingUseCase_RenewToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockDeviceOnboardingUseCase
+func (_mock *MockDeviceOnboardingUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockDeviceOnboardingUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockDeviceOnboardingUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceOnboardingUseCase_Expecter) Run(ctx interface{}) *MockDeviceOnboardingUseCase_Run_Call {
+	return &MockDeviceOnboardingUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockDeviceOnboardingUseCase_Run_Call) Run(run func(ctx context.Context)) *MockDeviceOnboardingUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_Run_Call) Return() *MockDeviceOnboardingUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDeviceOnboardingUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockDeviceOnboardingUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
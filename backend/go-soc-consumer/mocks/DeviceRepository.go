@@ -38,6 +38,134 @@ func (_m *MockDeviceRepository) EXPECT() *MockDeviceRepository_Expecter {
 	return &MockDeviceRepository_Expecter{mock: &_m.Mock}
 }
 
+// CountByFarm provides a mock function for the type MockDeviceRepository
+func (_mock *MockDeviceRepository) CountByFarm(ctx context.Context, farmID string) (int, error) {
+	ret := _mock.Called(ctx, farmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByFarm")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return returnFunc(ctx, farmID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = returnFunc(ctx, farmID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, farmID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceRepository_CountByFarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByFarm'
+type MockDeviceRepository_CountByFarm_Call struct {
+	*mock.Call
+}
+
+// CountByFarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - farmID string
+func (_e *MockDeviceRepository_Expecter) CountByFarm(ctx interface{}, farmID interface{}) *MockDeviceRepository_CountByFarm_Call {
+	return &MockDeviceRepository_CountByFarm_Call{Call: _e.mock.On("CountByFarm", ctx, farmID)}
+}
+
+func (_c *MockDeviceRepository_CountByFarm_Call) Run(run func(ctx context.Context, farmID string)) *MockDeviceRepository_CountByFarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceRepository_CountByFarm_Call) Return(n int, err error) *MockDeviceRepository_CountByFarm_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDeviceRepository_CountByFarm_Call) RunAndReturn(run func(ctx context.Context, farmID string) (int, error)) *MockDeviceRepository_CountByFarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByVersion provides a mock function for the type MockDeviceRepository
+func (_mock *MockDeviceRepository) CountByVersion(ctx context.Context) ([]*entities.FleetVersionCount, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByVersion")
+	}
+
+	var r0 []*entities.FleetVersionCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.FleetVersionCount, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.FleetVersionCount); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.FleetVersionCount)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceRepository_CountByVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByVersion'
+type MockDeviceRepository_CountByVersion_Call struct {
+	*mock.Call
+}
+
+// CountByVersion is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceRepository_Expecter) CountByVersion(ctx interface{}) *MockDeviceRepository_CountByVersion_Call {
+	return &MockDeviceRepository_CountByVersion_Call{Call: _e.mock.On("CountByVersion", ctx)}
+}
+
+func (_c *MockDeviceRepository_CountByVersion_Call) Run(run func(ctx context.Context)) *MockDeviceRepository_CountByVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceRepository_CountByVersion_Call) Return(fleetVersionCounts []*entities.FleetVersionCount, err error) *MockDeviceRepository_CountByVersion_Call {
+	_c.Call.Return(fleetVersionCounts, err)
+	return _c
+}
+
+func (_c *MockDeviceRepository_CountByVersion_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.FleetVersionCount, error)) *MockDeviceRepository_CountByVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockDeviceRepository
 func (_mock *MockDeviceRepository) Create(ctx context.Context, device *entities.Device) error {
 	ret := _mock.Called(ctx, device)
@@ -286,6 +414,74 @@ func (_c *MockDeviceRepository_FindByMACAddress_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// FindByMACAddresses provides a mock function for the type MockDeviceRepository
+func (_mock *MockDeviceRepository) FindByMACAddresses(ctx context.Context, macAddresses []string) ([]*entities.Device, error) {
+	ret := _mock.Called(ctx, macAddresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByMACAddresses")
+	}
+
+	var r0 []*entities.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*entities.Device, error)); ok {
+		return returnFunc(ctx, macAddresses)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*entities.Device); ok {
+		r0 = returnFunc(ctx, macAddresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, macAddresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceRepository_FindByMACAddresses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByMACAddresses'
+type MockDeviceRepository_FindByMACAddresses_Call struct {
+	*mock.Call
+}
+
+// FindByMACAddresses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddresses []string
+func (_e *MockDeviceRepository_Expecter) FindByMACAddresses(ctx interface{}, macAddresses interface{}) *MockDeviceRepository_FindByMACAddresses_Call {
+	return &MockDeviceRepository_FindByMACAddresses_Call{Call: _e.mock.On("FindByMACAddresses", ctx, macAddresses)}
+}
+
+func (_c *MockDeviceRepository_FindByMACAddresses_Call) Run(run func(ctx context.Context, macAddresses []string)) *MockDeviceRepository_FindByMACAddresses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceRepository_FindByMACAddresses_Call) Return(devices []*entities.Device, err error) *MockDeviceRepository_FindByMACAddresses_Call {
+	_c.Call.Return(devices, err)
+	return _c
+}
+
+func (_c *MockDeviceRepository_FindByMACAddresses_Call) RunAndReturn(run func(ctx context.Context, macAddresses []string) ([]*entities.Device, error)) *MockDeviceRepository_FindByMACAddresses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function for the type MockDeviceRepository
 func (_mock *MockDeviceRepository) List(ctx context.Context, offset int, limit int) ([]*entities.Device, error) {
 	ret := _mock.Called(ctx, offset, limit)
@@ -360,6 +556,86 @@ func (_c *MockDeviceRepository_List_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// ListByStatus provides a mock function for the type MockDeviceRepository
+func (_mock *MockDeviceRepository) ListByStatus(ctx context.Context, status string, offset int, limit int) ([]*entities.Device, error) {
+	ret := _mock.Called(ctx, status, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByStatus")
+	}
+
+	var r0 []*entities.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*entities.Device, error)); ok {
+		return returnFunc(ctx, status, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) []*entities.Device); ok {
+		r0 = returnFunc(ctx, status, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = returnFunc(ctx, status, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceRepository_ListByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByStatus'
+type MockDeviceRepository_ListByStatus_Call struct {
+	*mock.Call
+}
+
+// ListByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status string
+//   - offset int
+//   - limit int
+func (_e *MockDeviceRepository_Expecter) ListByStatus(ctx interface{}, status interface{}, offset interface{}, limit interface{}) *MockDeviceRepository_ListByStatus_Call {
+	return &MockDeviceRepository_ListByStatus_Call{Call: _e.mock.On("ListByStatus", ctx, status, offset, limit)}
+}
+
+func (_c *MockDeviceRepository_ListByStatus_Call) Run(run func(ctx context.Context, status string, offset int, limit int)) *MockDeviceRepository_ListByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceRepository_ListByStatus_Call) Return(devices []*entities.Device, err error) *MockDeviceRepository_ListByStatus_Call {
+	_c.Call.Return(devices, err)
+	return _c
+}
+
+func (_c *MockDeviceRepository_ListByStatus_Call) RunAndReturn(run func(ctx context.Context, status string, offset int, limit int) ([]*entities.Device, error)) *MockDeviceRepository_ListByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type MockDeviceRepository
 func (_mock *MockDeviceRepository) Update(ctx context.Context, device *entities.Device) error {
 	ret := _mock.Called(ctx, device)
@@ -0,0 +1,215 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceShadowRepository creates a new instance of MockDeviceShadowRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceShadowRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceShadowRepository {
+	mock := &MockDeviceShadowRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceShadowRepository is an autogenerated mock type for the DeviceShadowRepository type
+type MockDeviceShadowRepository struct {
+	mock.Mock
+}
+
+type MockDeviceShadowRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceShadowRepository) EXPECT() *MockDeviceShadowRepository_Expecter {
+	return &MockDeviceShadowRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockDeviceShadowRepository
+func (_mock *MockDeviceShadowRepository) Delete(ctx context.Context, macAddress string) error {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceShadowRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockDeviceShadowRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockDeviceShadowRepository_Expecter) Delete(ctx interface{}, macAddress interface{}) *MockDeviceShadowRepository_Delete_Call {
+	return &MockDeviceShadowRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, macAddress)}
+}
+
+func (_c *MockDeviceShadowRepository_Delete_Call) Run(run func(ctx context.Context, macAddress string)) *MockDeviceShadowRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceShadowRepository_Delete_Call) Return(err error) *MockDeviceShadowRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceShadowRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, macAddress string) error) *MockDeviceShadowRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockDeviceShadowRepository
+func (_mock *MockDeviceShadowRepository) List(ctx context.Context) ([]*entities.DeviceShadow, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.DeviceShadow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.DeviceShadow, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.DeviceShadow); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceShadow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceShadowRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeviceShadowRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDeviceShadowRepository_Expecter) List(ctx interface{}) *MockDeviceShadowRepository_List_Call {
+	return &MockDeviceShadowRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockDeviceShadowRepository_List_Call) Run(run func(ctx context.Context)) *MockDeviceShadowRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceShadowRepository_List_Call) Return(deviceShadows []*entities.DeviceShadow, err error) *MockDeviceShadowRepository_List_Call {
+	_c.Call.Return(deviceShadows, err)
+	return _c
+}
+
+func (_c *MockDeviceShadowRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.DeviceShadow, error)) *MockDeviceShadowRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockDeviceShadowRepository
+func (_mock *MockDeviceShadowRepository) Upsert(ctx context.Context, shadow *entities.DeviceShadow) error {
+	ret := _mock.Called(ctx, shadow)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceShadow) error); ok {
+		r0 = returnFunc(ctx, shadow)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceShadowRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockDeviceShadowRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shadow *entities.DeviceShadow
+func (_e *MockDeviceShadowRepository_Expecter) Upsert(ctx interface{}, shadow interface{}) *MockDeviceShadowRepository_Upsert_Call {
+	return &MockDeviceShadowRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, shadow)}
+}
+
+func (_c *MockDeviceShadowRepository_Upsert_Call) Run(run func(ctx context.Context, shadow *entities.DeviceShadow)) *MockDeviceShadowRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceShadow
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceShadow)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceShadowRepository_Upsert_Call) Return(err error) *MockDeviceShadowRepository_Upsert_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceShadowRepository_Upsert_Call) RunAndReturn(run func(ctx context.Context, shadow *entities.DeviceShadow) error) *MockDeviceShadowRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,107 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceStatusUseCase creates a new instance of MockDeviceStatusUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceStatusUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceStatusUseCase {
+	mock := &MockDeviceStatusUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceStatusUseCase is an autogenerated mock type for the DeviceStatusUseCase type
+type MockDeviceStatusUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceStatusUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceStatusUseCase) EXPECT() *MockDeviceStatusUseCase_Expecter {
+	return &MockDeviceStatusUseCase_Expecter{mock: &_m.Mock}
+}
+
+// QueryStatus provides a mock function for the type MockDeviceStatusUseCase
+func (_mock *MockDeviceStatusUseCase) QueryStatus(ctx context.Context, macAddresses []string) (map[string]*entities.Device, error) {
+	ret := _mock.Called(ctx, macAddresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryStatus")
+	}
+
+	var r0 map[string]*entities.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string]*entities.Device, error)); ok {
+		return returnFunc(ctx, macAddresses)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string]*entities.Device); ok {
+		r0 = returnFunc(ctx, macAddresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*entities.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, macAddresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceStatusUseCase_QueryStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryStatus'
+type MockDeviceStatusUseCase_QueryStatus_Call struct {
+	*mock.Call
+}
+
+// QueryStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddresses []string
+func (_e *MockDeviceStatusUseCase_Expecter) QueryStatus(ctx interface{}, macAddresses interface{}) *MockDeviceStatusUseCase_QueryStatus_Call {
+	return &MockDeviceStatusUseCase_QueryStatus_Call{Call: _e.mock.On("QueryStatus", ctx, macAddresses)}
+}
+
+func (_c *MockDeviceStatusUseCase_QueryStatus_Call) Run(run func(ctx context.Context, macAddresses []string)) *MockDeviceStatusUseCase_QueryStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceStatusUseCase_QueryStatus_Call) Return(mp map[string]*entities.Device, err error) *MockDeviceStatusUseCase_QueryStatus_Call {
+	_c.Call.Return(mp, err)
+	return _c
+}
+
+func (_c *MockDeviceStatusUseCase_QueryStatus_Call) RunAndReturn(run func(ctx context.Context, macAddresses []string) (map[string]*entities.Device, error)) *MockDeviceStatusUseCase_QueryStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
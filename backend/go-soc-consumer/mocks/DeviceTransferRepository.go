@@ -0,0 +1,466 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceTransferRepository creates a new instance of MockDeviceTransferRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceTransferRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceTransferRepository {
+	mock := &MockDeviceTransferRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceTransferRepository is an autogenerated mock type for the DeviceTransferRepository type
+type MockDeviceTransferRepository struct {
+	mock.Mock
+}
+
+type MockDeviceTransferRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceTransferRepository) EXPECT() *MockDeviceTransferRepository_Expecter {
+	return &MockDeviceTransferRepository_Expecter{mock: &_m.Mock}
+}
+
+// AuditEntries provides a mock function for the type MockDeviceTransferRepository
+func (_mock *MockDeviceTransferRepository) AuditEntries(ctx context.Context, transferID string) ([]entities.DeviceTransferAuditEntry, error) {
+	ret := _mock.Called(ctx, transferID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditEntries")
+	}
+
+	var r0 []entities.DeviceTransferAuditEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]entities.DeviceTransferAuditEntry, error)); ok {
+		return returnFunc(ctx, transferID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []entities.DeviceTransferAuditEntry); ok {
+		r0 = returnFunc(ctx, transferID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.DeviceTransferAuditEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, transferID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceTransferRepository_AuditEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditEntries'
+type MockDeviceTransferRepository_AuditEntries_Call struct {
+	*mock.Call
+}
+
+// AuditEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transferID string
+func (_e *MockDeviceTransferRepository_Expecter) AuditEntries(ctx interface{}, transferID interface{}) *MockDeviceTransferRepository_AuditEntries_Call {
+	return &MockDeviceTransferRepository_AuditEntries_Call{Call: _e.mock.On("AuditEntries", ctx, transferID)}
+}
+
+func (_c *MockDeviceTransferRepository_AuditEntries_Call) Run(run func(ctx context.Context, transferID string)) *MockDeviceTransferRepository_AuditEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_AuditEntries_Call) Return(deviceTransferAuditEntrys []entities.DeviceTransferAuditEntry, err error) *MockDeviceTransferRepository_AuditEntries_Call {
+	_c.Call.Return(deviceTransferAuditEntrys, err)
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_AuditEntries_Call) RunAndReturn(run func(ctx context.Context, transferID string) ([]entities.DeviceTransferAuditEntry, error)) *MockDeviceTransferRepository_AuditEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Complete provides a mock function for the type MockDeviceTransferRepository
+func (_mock *MockDeviceTransferRepository) Complete(ctx context.Context, transfer *entities.DeviceTransfer, device *entities.Device, actor string, at time.Time) (entities.DeviceTransferAuditEntry, error) {
+	ret := _mock.Called(ctx, transfer, device, actor, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 entities.DeviceTransferAuditEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceTransfer, *entities.Device, string, time.Time) (entities.DeviceTransferAuditEntry, error)); ok {
+		return returnFunc(ctx, transfer, device, actor, at)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceTransfer, *entities.Device, string, time.Time) entities.DeviceTransferAuditEntry); ok {
+		r0 = returnFunc(ctx, transfer, device, actor, at)
+	} else {
+		r0 = ret.Get(0).(entities.DeviceTransferAuditEntry)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.DeviceTransfer, *entities.Device, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, transfer, device, actor, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceTransferRepository_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockDeviceTransferRepository_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transfer *entities.DeviceTransfer
+//   - device *entities.Device
+//   - actor string
+//   - at time.Time
+func (_e *MockDeviceTransferRepository_Expecter) Complete(ctx interface{}, transfer interface{}, device interface{}, actor interface{}, at interface{}) *MockDeviceTransferRepository_Complete_Call {
+	return &MockDeviceTransferRepository_Complete_Call{Call: _e.mock.On("Complete", ctx, transfer, device, actor, at)}
+}
+
+func (_c *MockDeviceTransferRepository_Complete_Call) Run(run func(ctx context.Context, transfer *entities.DeviceTransfer, device *entities.Device, actor string, at time.Time)) *MockDeviceTransferRepository_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceTransfer
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceTransfer)
+		}
+		var arg2 *entities.Device
+		if args[2] != nil {
+			arg2 = args[2].(*entities.Device)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_Complete_Call) Return(deviceTransferAuditEntry entities.DeviceTransferAuditEntry, err error) *MockDeviceTransferRepository_Complete_Call {
+	_c.Call.Return(deviceTransferAuditEntry, err)
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_Complete_Call) RunAndReturn(run func(ctx context.Context, transfer *entities.DeviceTransfer, device *entities.Device, actor string, at time.Time) (entities.DeviceTransferAuditEntry, error)) *MockDeviceTransferRepository_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockDeviceTransferRepository
+func (_mock *MockDeviceTransferRepository) Create(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error {
+	ret := _mock.Called(ctx, transfer, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceTransfer, entities.DeviceTransferAuditEntry) error); ok {
+		r0 = returnFunc(ctx, transfer, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceTransferRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockDeviceTransferRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transfer *entities.DeviceTransfer
+//   - entry entities.DeviceTransferAuditEntry
+func (_e *MockDeviceTransferRepository_Expecter) Create(ctx interface{}, transfer interface{}, entry interface{}) *MockDeviceTransferRepository_Create_Call {
+	return &MockDeviceTransferRepository_Create_Call{Call: _e.mock.On("Create", ctx, transfer, entry)}
+}
+
+func (_c *MockDeviceTransferRepository_Create_Call) Run(run func(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry)) *MockDeviceTransferRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceTransfer
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceTransfer)
+		}
+		var arg2 entities.DeviceTransferAuditEntry
+		if args[2] != nil {
+			arg2 = args[2].(entities.DeviceTransferAuditEntry)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_Create_Call) Return(err error) *MockDeviceTransferRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_Create_Call) RunAndReturn(run func(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error) *MockDeviceTransferRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockDeviceTransferRepository
+func (_mock *MockDeviceTransferRepository) FindByID(ctx context.Context, id string) (*entities.DeviceTransfer, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.DeviceTransfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceTransfer, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceTransfer); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceTransfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceTransferRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockDeviceTransferRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceTransferRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockDeviceTransferRepository_FindByID_Call {
+	return &MockDeviceTransferRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockDeviceTransferRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockDeviceTransferRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_FindByID_Call) Return(deviceTransfer *entities.DeviceTransfer, err error) *MockDeviceTransferRepository_FindByID_Call {
+	_c.Call.Return(deviceTransfer, err)
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceTransfer, error)) *MockDeviceTransferRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockDeviceTransferRepository
+func (_mock *MockDeviceTransferRepository) List(ctx context.Context, macAddress string, status string, limit int) ([]*entities.DeviceTransfer, error) {
+	ret := _mock.Called(ctx, macAddress, status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.DeviceTransfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) ([]*entities.DeviceTransfer, error)); ok {
+		return returnFunc(ctx, macAddress, status, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) []*entities.DeviceTransfer); ok {
+		r0 = returnFunc(ctx, macAddress, status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceTransfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = returnFunc(ctx, macAddress, status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceTransferRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeviceTransferRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - status string
+//   - limit int
+func (_e *MockDeviceTransferRepository_Expecter) List(ctx interface{}, macAddress interface{}, status interface{}, limit interface{}) *MockDeviceTransferRepository_List_Call {
+	return &MockDeviceTransferRepository_List_Call{Call: _e.mock.On("List", ctx, macAddress, status, limit)}
+}
+
+func (_c *MockDeviceTransferRepository_List_Call) Run(run func(ctx context.Context, macAddress string, status string, limit int)) *MockDeviceTransferRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_List_Call) Return(deviceTransfers []*entities.DeviceTransfer, err error) *MockDeviceTransferRepository_List_Call {
+	_c.Call.Return(deviceTransfers, err)
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_List_Call) RunAndReturn(run func(ctx context.Context, macAddress string, status string, limit int) ([]*entities.DeviceTransfer, error)) *MockDeviceTransferRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockDeviceTransferRepository
+func (_mock *MockDeviceTransferRepository) Update(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error {
+	ret := _mock.Called(ctx, transfer, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceTransfer, entities.DeviceTransferAuditEntry) error); ok {
+		r0 = returnFunc(ctx, transfer, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeviceTransferRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockDeviceTransferRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transfer *entities.DeviceTransfer
+//   - entry entities.DeviceTransferAuditEntry
+func (_e *MockDeviceTransferRepository_Expecter) Update(ctx interface{}, transfer interface{}, entry interface{}) *MockDeviceTransferRepository_Update_Call {
+	return &MockDeviceTransferRepository_Update_Call{Call: _e.mock.On("Update", ctx, transfer, entry)}
+}
+
+func (_c *MockDeviceTransferRepository_Update_Call) Run(run func(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry)) *MockDeviceTransferRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceTransfer
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceTransfer)
+		}
+		var arg2 entities.DeviceTransferAuditEntry
+		if args[2] != nil {
+			arg2 = args[2].(entities.DeviceTransferAuditEntry)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_Update_Call) Return(err error) *MockDeviceTransferRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeviceTransferRepository_Update_Call) RunAndReturn(run func(ctx context.Context, transfer *entities.DeviceTransfer, entry entities.DeviceTransferAuditEntry) error) *MockDeviceTransferRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,503 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeviceTransfersUseCase creates a new instance of MockDeviceTransfersUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeviceTransfersUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeviceTransfersUseCase {
+	mock := &MockDeviceTransfersUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeviceTransfersUseCase is an autogenerated mock type for the DeviceTransfersUseCase type
+type MockDeviceTransfersUseCase struct {
+	mock.Mock
+}
+
+type MockDeviceTransfersUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeviceTransfersUseCase) EXPECT() *MockDeviceTransfersUseCase_Expecter {
+	return &MockDeviceTransfersUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Accept provides a mock function for the type MockDeviceTransfersUseCase
+func (_mock *MockDeviceTransfersUseCase) Accept(ctx context.Context, id string, actor string) (*entities.DeviceTransfer, error) {
+	ret := _mock.Called(ctx, id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Accept")
+	}
+
+	var r0 *entities.DeviceTransfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entities.DeviceTransfer, error)); ok {
+		return returnFunc(ctx, id, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entities.DeviceTransfer); ok {
+		r0 = returnFunc(ctx, id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceTransfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceTransfersUseCase_Accept_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Accept'
+type MockDeviceTransfersUseCase_Accept_Call struct {
+	*mock.Call
+}
+
+// Accept is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - actor string
+func (_e *MockDeviceTransfersUseCase_Expecter) Accept(ctx interface{}, id interface{}, actor interface{}) *MockDeviceTransfersUseCase_Accept_Call {
+	return &MockDeviceTransfersUseCase_Accept_Call{Call: _e.mock.On("Accept", ctx, id, actor)}
+}
+
+func (_c *MockDeviceTransfersUseCase_Accept_Call) Run(run func(ctx context.Context, id string, actor string)) *MockDeviceTransfersUseCase_Accept_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_Accept_Call) Return(deviceTransfer *entities.DeviceTransfer, err error) *MockDeviceTransfersUseCase_Accept_Call {
+	_c.Call.Return(deviceTransfer, err)
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_Accept_Call) RunAndReturn(run func(ctx context.Context, id string, actor string) (*entities.DeviceTransfer, error)) *MockDeviceTransfersUseCase_Accept_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Cancel provides a mock function for the type MockDeviceTransfersUseCase
+func (_mock *MockDeviceTransfersUseCase) Cancel(ctx context.Context, id string, actor string, reason string) (*entities.DeviceTransfer, error) {
+	ret := _mock.Called(ctx, id, actor, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *entities.DeviceTransfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*entities.DeviceTransfer, error)); ok {
+		return returnFunc(ctx, id, actor, reason)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *entities.DeviceTransfer); ok {
+		r0 = returnFunc(ctx, id, actor, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceTransfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, id, actor, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceTransfersUseCase_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockDeviceTransfersUseCase_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - actor string
+//   - reason string
+func (_e *MockDeviceTransfersUseCase_Expecter) Cancel(ctx interface{}, id interface{}, actor interface{}, reason interface{}) *MockDeviceTransfersUseCase_Cancel_Call {
+	return &MockDeviceTransfersUseCase_Cancel_Call{Call: _e.mock.On("Cancel", ctx, id, actor, reason)}
+}
+
+func (_c *MockDeviceTransfersUseCase_Cancel_Call) Run(run func(ctx context.Context, id string, actor string, reason string)) *MockDeviceTransfersUseCase_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_Cancel_Call) Return(deviceTransfer *entities.DeviceTransfer, err error) *MockDeviceTransfersUseCase_Cancel_Call {
+	_c.Call.Return(deviceTransfer, err)
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_Cancel_Call) RunAndReturn(run func(ctx context.Context, id string, actor string, reason string) (*entities.DeviceTransfer, error)) *MockDeviceTransfersUseCase_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Complete provides a mock function for the type MockDeviceTransfersUseCase
+func (_mock *MockDeviceTransfersUseCase) Complete(ctx context.Context, id string, actor string) (*entities.DeviceTransfer, error) {
+	ret := _mock.Called(ctx, id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 *entities.DeviceTransfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entities.DeviceTransfer, error)); ok {
+		return returnFunc(ctx, id, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entities.DeviceTransfer); ok {
+		r0 = returnFunc(ctx, id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceTransfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceTransfersUseCase_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockDeviceTransfersUseCase_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - actor string
+func (_e *MockDeviceTransfersUseCase_Expecter) Complete(ctx interface{}, id interface{}, actor interface{}) *MockDeviceTransfersUseCase_Complete_Call {
+	return &MockDeviceTransfersUseCase_Complete_Call{Call: _e.mock.On("Complete", ctx, id, actor)}
+}
+
+func (_c *MockDeviceTransfersUseCase_Complete_Call) Run(run func(ctx context.Context, id string, actor string)) *MockDeviceTransfersUseCase_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_Complete_Call) Return(deviceTransfer *entities.DeviceTransfer, err error) *MockDeviceTransfersUseCase_Complete_Call {
+	_c.Call.Return(deviceTransfer, err)
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_Complete_Call) RunAndReturn(run func(ctx context.Context, id string, actor string) (*entities.DeviceTransfer, error)) *MockDeviceTransfersUseCase_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockDeviceTransfersUseCase
+func (_mock *MockDeviceTransfersUseCase) Get(ctx context.Context, id string) (*entities.DeviceTransfer, []entities.DeviceTransferAuditEntry, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entities.DeviceTransfer
+	var r1 []entities.DeviceTransferAuditEntry
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceTransfer, []entities.DeviceTransferAuditEntry, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceTransfer); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceTransfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) []entities.DeviceTransferAuditEntry); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]entities.DeviceTransferAuditEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockDeviceTransfersUseCase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDeviceTransfersUseCase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDeviceTransfersUseCase_Expecter) Get(ctx interface{}, id interface{}) *MockDeviceTransfersUseCase_Get_Call {
+	return &MockDeviceTransfersUseCase_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockDeviceTransfersUseCase_Get_Call) Run(run func(ctx context.Context, id string)) *MockDeviceTransfersUseCase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_Get_Call) Return(deviceTransfer *entities.DeviceTransfer, deviceTransferAuditEntrys []entities.DeviceTransferAuditEntry, err error) *MockDeviceTransfersUseCase_Get_Call {
+	_c.Call.Return(deviceTransfer, deviceTransferAuditEntrys, err)
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.DeviceTransfer, []entities.DeviceTransferAuditEntry, error)) *MockDeviceTransfersUseCase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Initiate provides a mock function for the type MockDeviceTransfersUseCase
+func (_mock *MockDeviceTransfersUseCase) Initiate(ctx context.Context, macAddress string, request entities.DeviceTransferRequest, actor string) (*entities.DeviceTransfer, error) {
+	ret := _mock.Called(ctx, macAddress, request, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Initiate")
+	}
+
+	var r0 *entities.DeviceTransfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.DeviceTransferRequest, string) (*entities.DeviceTransfer, error)); ok {
+		return returnFunc(ctx, macAddress, request, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.DeviceTransferRequest, string) *entities.DeviceTransfer); ok {
+		r0 = returnFunc(ctx, macAddress, request, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceTransfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, entities.DeviceTransferRequest, string) error); ok {
+		r1 = returnFunc(ctx, macAddress, request, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceTransfersUseCase_Initiate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Initiate'
+type MockDeviceTransfersUseCase_Initiate_Call struct {
+	*mock.Call
+}
+
+// Initiate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - request entities.DeviceTransferRequest
+//   - actor string
+func (_e *MockDeviceTransfersUseCase_Expecter) Initiate(ctx interface{}, macAddress interface{}, request interface{}, actor interface{}) *MockDeviceTransfersUseCase_Initiate_Call {
+	return &MockDeviceTransfersUseCase_Initiate_Call{Call: _e.mock.On("Initiate", ctx, macAddress, request, actor)}
+}
+
+func (_c *MockDeviceTransfersUseCase_Initiate_Call) Run(run func(ctx context.Context, macAddress string, request entities.DeviceTransferRequest, actor string)) *MockDeviceTransfersUseCase_Initiate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 entities.DeviceTransferRequest
+		if args[2] != nil {
+			arg2 = args[2].(entities.DeviceTransferRequest)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_Initiate_Call) Return(deviceTransfer *entities.DeviceTransfer, err error) *MockDeviceTransfersUseCase_Initiate_Call {
+	_c.Call.Return(deviceTransfer, err)
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_Initiate_Call) RunAndReturn(run func(ctx context.Context, macAddress string, request entities.DeviceTransferRequest, actor string) (*entities.DeviceTransfer, error)) *MockDeviceTransfersUseCase_Initiate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockDeviceTransfersUseCase
+func (_mock *MockDeviceTransfersUseCase) List(ctx context.Context, macAddress string, status string, limit int) ([]*entities.DeviceTransfer, error) {
+	ret := _mock.Called(ctx, macAddress, status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.DeviceTransfer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) ([]*entities.DeviceTransfer, error)); ok {
+		return returnFunc(ctx, macAddress, status, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) []*entities.DeviceTransfer); ok {
+		r0 = returnFunc(ctx, macAddress, status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceTransfer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = returnFunc(ctx, macAddress, status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeviceTransfersUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockDeviceTransfersUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - status string
+//   - limit int
+func (_e *MockDeviceTransfersUseCase_Expecter) List(ctx interface{}, macAddress interface{}, status interface{}, limit interface{}) *MockDeviceTransfersUseCase_List_Call {
+	return &MockDeviceTransfersUseCase_List_Call{Call: _e.mock.On("List", ctx, macAddress, status, limit)}
+}
+
+func (_c *MockDeviceTransfersUseCase_List_Call) Run(run func(ctx context.Context, macAddress string, status string, limit int)) *MockDeviceTransfersUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_List_Call) Return(deviceTransfers []*entities.DeviceTransfer, err error) *MockDeviceTransfersUseCase_List_Call {
+	_c.Call.Return(deviceTransfers, err)
+	return _c
+}
+
+func (_c *MockDeviceTransfersUseCase_List_Call) RunAndReturn(run func(ctx context.Context, macAddress string, status string, limit int) ([]*entities.DeviceTransfer, error)) *MockDeviceTransfersUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,176 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDriftReconciliationUseCase creates a new instance of MockDriftReconciliationUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDriftReconciliationUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDriftReconciliationUseCase {
+	mock := &MockDriftReconciliationUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDriftReconciliationUseCase is an autogenerated mock type for the DriftReconciliationUseCase type
+type MockDriftReconciliationUseCase struct {
+	mock.Mock
+}
+
+type MockDriftReconciliationUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDriftReconciliationUseCase) EXPECT() *MockDriftReconciliationUseCase_Expecter {
+	return &MockDriftReconciliationUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Drifts provides a mock function for the type MockDriftReconciliationUseCase
+func (_mock *MockDriftReconciliationUseCase) Drifts() []*entities.DeviceDrift {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Drifts")
+	}
+
+	var r0 []*entities.DeviceDrift
+	if returnFunc, ok := ret.Get(0).(func() []*entities.DeviceDrift); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.DeviceDrift)
+		}
+	}
+	return r0
+}
+
+// MockDriftReconciliationUseCase_Drifts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Drifts'
+type MockDriftReconciliationUseCase_Drifts_Call struct {
+	*mock.Call
+}
+
+// Drifts is a helper method to define mock.On call
+func (_e *MockDriftReconciliationUseCase_Expecter) Drifts() *MockDriftReconciliationUseCase_Drifts_Call {
+	return &MockDriftReconciliationUseCase_Drifts_Call{Call: _e.mock.On("Drifts")}
+}
+
+func (_c *MockDriftReconciliationUseCase_Drifts_Call) Run(run func()) *MockDriftReconciliationUseCase_Drifts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDriftReconciliationUseCase_Drifts_Call) Return(deviceDrifts []*entities.DeviceDrift) *MockDriftReconciliationUseCase_Drifts_Call {
+	_c.Call.Return(deviceDrifts)
+	return _c
+}
+
+func (_c *MockDriftReconciliationUseCase_Drifts_Call) RunAndReturn(run func() []*entities.DeviceDrift) *MockDriftReconciliationUseCase_Drifts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reconcile provides a mock function for the type MockDriftReconciliationUseCase
+func (_mock *MockDriftReconciliationUseCase) Reconcile(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reconcile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDriftReconciliationUseCase_Reconcile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reconcile'
+type MockDriftReconciliationUseCase_Reconcile_Call struct {
+	*mock.Call
+}
+
+// Reconcile is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDriftReconciliationUseCase_Expecter) Reconcile(ctx interface{}) *MockDriftReconciliationUseCase_Reconcile_Call {
+	return &MockDriftReconciliationUseCase_Reconcile_Call{Call: _e.mock.On("Reconcile", ctx)}
+}
+
+func (_c *MockDriftReconciliationUseCase_Reconcile_Call) Run(run func(ctx context.Context)) *MockDriftReconciliationUseCase_Reconcile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDriftReconciliationUseCase_Reconcile_Call) Return(err error) *MockDriftReconciliationUseCase_Reconcile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDriftReconciliationUseCase_Reconcile_Call) RunAndReturn(run func(ctx context.Context) error) *MockDriftReconciliationUseCase_Reconcile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockDriftReconciliationUseCase
+func (_mock *MockDriftReconciliationUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockDriftReconciliationUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockDriftReconciliationUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDriftReconciliationUseCase_Expecter) Run(ctx interface{}) *MockDriftReconciliationUseCase_Run_Call {
+	return &MockDriftReconciliationUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockDriftReconciliationUseCase_Run_Call) Run(run func(ctx context.Context)) *MockDriftReconciliationUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDriftReconciliationUseCase_Run_Call) Return() *MockDriftReconciliationUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockDriftReconciliationUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockDriftReconciliationUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,372 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockEdgeSyncUseCase creates a new instance of MockEdgeSyncUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEdgeSyncUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEdgeSyncUseCase {
+	mock := &MockEdgeSyncUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEdgeSyncUseCase is an autogenerated mock type for the EdgeSyncUseCase type
+type MockEdgeSyncUseCase struct {
+	mock.Mock
+}
+
+type MockEdgeSyncUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEdgeSyncUseCase) EXPECT() *MockEdgeSyncUseCase_Expecter {
+	return &MockEdgeSyncUseCase_Expecter{mock: &_m.Mock}
+}
+
+// RecordDevice provides a mock function for the type MockEdgeSyncUseCase
+func (_mock *MockEdgeSyncUseCase) RecordDevice(ctx context.Context, device *entities.Device) error {
+	ret := _mock.Called(ctx, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.Device) error); ok {
+		r0 = returnFunc(ctx, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEdgeSyncUseCase_RecordDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDevice'
+type MockEdgeSyncUseCase_RecordDevice_Call struct {
+	*mock.Call
+}
+
+// RecordDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - device *entities.Device
+func (_e *MockEdgeSyncUseCase_Expecter) RecordDevice(ctx interface{}, device interface{}) *MockEdgeSyncUseCase_RecordDevice_Call {
+	return &MockEdgeSyncUseCase_RecordDevice_Call{Call: _e.mock.On("RecordDevice", ctx, device)}
+}
+
+func (_c *MockEdgeSyncUseCase_RecordDevice_Call) Run(run func(ctx context.Context, device *entities.Device)) *MockEdgeSyncUseCase_RecordDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.Device
+		if args[1] != nil {
+			arg1 = args[1].(*entities.Device)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_RecordDevice_Call) Return(err error) *MockEdgeSyncUseCase_RecordDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_RecordDevice_Call) RunAndReturn(run func(ctx context.Context, device *entities.Device) error) *MockEdgeSyncUseCase_RecordDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordDeviceDetected provides a mock function for the type MockEdgeSyncUseCase
+func (_mock *MockEdgeSyncUseCase) RecordDeviceDetected(ctx context.Context, event *entities.DeviceDetectedEvent) error {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDeviceDetected")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.DeviceDetectedEvent) error); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEdgeSyncUseCase_RecordDeviceDetected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDeviceDetected'
+type MockEdgeSyncUseCase_RecordDeviceDetected_Call struct {
+	*mock.Call
+}
+
+// RecordDeviceDetected is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *entities.DeviceDetectedEvent
+func (_e *MockEdgeSyncUseCase_Expecter) RecordDeviceDetected(ctx interface{}, event interface{}) *MockEdgeSyncUseCase_RecordDeviceDetected_Call {
+	return &MockEdgeSyncUseCase_RecordDeviceDetected_Call{Call: _e.mock.On("RecordDeviceDetected", ctx, event)}
+}
+
+func (_c *MockEdgeSyncUseCase_RecordDeviceDetected_Call) Run(run func(ctx context.Context, event *entities.DeviceDetectedEvent)) *MockEdgeSyncUseCase_RecordDeviceDetected_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceDetectedEvent
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceDetectedEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_RecordDeviceDetected_Call) Return(err error) *MockEdgeSyncUseCase_RecordDeviceDetected_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_RecordDeviceDetected_Call) RunAndReturn(run func(ctx context.Context, event *entities.DeviceDetectedEvent) error) *MockEdgeSyncUseCase_RecordDeviceDetected_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordSensorReading provides a mock function for the type MockEdgeSyncUseCase
+func (_mock *MockEdgeSyncUseCase) RecordSensorReading(ctx context.Context, reading *entities.SensorTemperatureHumidity) error {
+	ret := _mock.Called(ctx, reading)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordSensorReading")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.SensorTemperatureHumidity) error); ok {
+		r0 = returnFunc(ctx, reading)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEdgeSyncUseCase_RecordSensorReading_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordSensorReading'
+type MockEdgeSyncUseCase_RecordSensorReading_Call struct {
+	*mock.Call
+}
+
+// RecordSensorReading is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reading *entities.SensorTemperatureHumidity
+func (_e *MockEdgeSyncUseCase_Expecter) RecordSensorReading(ctx interface{}, reading interface{}) *MockEdgeSyncUseCase_RecordSensorReading_Call {
+	return &MockEdgeSyncUseCase_RecordSensorReading_Call{Call: _e.mock.On("RecordSensorReading", ctx, reading)}
+}
+
+func (_c *MockEdgeSyncUseCase_RecordSensorReading_Call) Run(run func(ctx context.Context, reading *entities.SensorTemperatureHumidity)) *MockEdgeSyncUseCase_RecordSensorReading_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.SensorTemperatureHumidity
+		if args[1] != nil {
+			arg1 = args[1].(*entities.SensorTemperatureHumidity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_RecordSensorReading_Call) Return(err error) *MockEdgeSyncUseCase_RecordSensorReading_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_RecordSensorReading_Call) RunAndReturn(run func(ctx context.Context, reading *entities.SensorTemperatureHumidity) error) *MockEdgeSyncUseCase_RecordSensorReading_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockEdgeSyncUseCase
+func (_mock *MockEdgeSyncUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockEdgeSyncUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockEdgeSyncUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEdgeSyncUseCase_Expecter) Run(ctx interface{}) *MockEdgeSyncUseCase_Run_Call {
+	return &MockEdgeSyncUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockEdgeSyncUseCase_Run_Call) Run(run func(ctx context.Context)) *MockEdgeSyncUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_Run_Call) Return() *MockEdgeSyncUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockEdgeSyncUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Status provides a mock function for the type MockEdgeSyncUseCase
+func (_mock *MockEdgeSyncUseCase) Status(ctx context.Context) (*entities.SyncStatus, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 *entities.SyncStatus
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*entities.SyncStatus, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *entities.SyncStatus); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SyncStatus)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEdgeSyncUseCase_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type MockEdgeSyncUseCase_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEdgeSyncUseCase_Expecter) Status(ctx interface{}) *MockEdgeSyncUseCase_Status_Call {
+	return &MockEdgeSyncUseCase_Status_Call{Call: _e.mock.On("Status", ctx)}
+}
+
+func (_c *MockEdgeSyncUseCase_Status_Call) Run(run func(ctx context.Context)) *MockEdgeSyncUseCase_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_Status_Call) Return(syncStatus *entities.SyncStatus, err error) *MockEdgeSyncUseCase_Status_Call {
+	_c.Call.Return(syncStatus, err)
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_Status_Call) RunAndReturn(run func(ctx context.Context) (*entities.SyncStatus, error)) *MockEdgeSyncUseCase_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SyncOnce provides a mock function for the type MockEdgeSyncUseCase
+func (_mock *MockEdgeSyncUseCase) SyncOnce(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncOnce")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEdgeSyncUseCase_SyncOnce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncOnce'
+type MockEdgeSyncUseCase_SyncOnce_Call struct {
+	*mock.Call
+}
+
+// SyncOnce is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEdgeSyncUseCase_Expecter) SyncOnce(ctx interface{}) *MockEdgeSyncUseCase_SyncOnce_Call {
+	return &MockEdgeSyncUseCase_SyncOnce_Call{Call: _e.mock.On("SyncOnce", ctx)}
+}
+
+func (_c *MockEdgeSyncUseCase_SyncOnce_Call) Run(run func(ctx context.Context)) *MockEdgeSyncUseCase_SyncOnce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_SyncOnce_Call) Return(n int, err error) *MockEdgeSyncUseCase_SyncOnce_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockEdgeSyncUseCase_SyncOnce_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockEdgeSyncUseCase_SyncOnce_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,244 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockEventPoliciesUseCase creates a new instance of MockEventPoliciesUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEventPoliciesUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEventPoliciesUseCase {
+	mock := &MockEventPoliciesUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEventPoliciesUseCase is an autogenerated mock type for the EventPoliciesUseCase type
+type MockEventPoliciesUseCase struct {
+	mock.Mock
+}
+
+type MockEventPoliciesUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEventPoliciesUseCase) EXPECT() *MockEventPoliciesUseCase_Expecter {
+	return &MockEventPoliciesUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Admit provides a mock function for the type MockEventPoliciesUseCase
+func (_mock *MockEventPoliciesUseCase) Admit(eventType string, macAddress string) string {
+	ret := _mock.Called(eventType, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Admit")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = returnFunc(eventType, macAddress)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockEventPoliciesUseCase_Admit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Admit'
+type MockEventPoliciesUseCase_Admit_Call struct {
+	*mock.Call
+}
+
+// Admit is a helper method to define mock.On call
+//   - eventType string
+//   - macAddress string
+func (_e *MockEventPoliciesUseCase_Expecter) Admit(eventType interface{}, macAddress interface{}) *MockEventPoliciesUseCase_Admit_Call {
+	return &MockEventPoliciesUseCase_Admit_Call{Call: _e.mock.On("Admit", eventType, macAddress)}
+}
+
+func (_c *MockEventPoliciesUseCase_Admit_Call) Run(run func(eventType string, macAddress string)) *MockEventPoliciesUseCase_Admit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventPoliciesUseCase_Admit_Call) Return(s string) *MockEventPoliciesUseCase_Admit_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockEventPoliciesUseCase_Admit_Call) RunAndReturn(run func(eventType string, macAddress string) string) *MockEventPoliciesUseCase_Admit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockEventPoliciesUseCase
+func (_mock *MockEventPoliciesUseCase) List() []*entities.EventPolicy {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.EventPolicy
+	if returnFunc, ok := ret.Get(0).(func() []*entities.EventPolicy); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.EventPolicy)
+		}
+	}
+	return r0
+}
+
+// MockEventPoliciesUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockEventPoliciesUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+func (_e *MockEventPoliciesUseCase_Expecter) List() *MockEventPoliciesUseCase_List_Call {
+	return &MockEventPoliciesUseCase_List_Call{Call: _e.mock.On("List")}
+}
+
+func (_c *MockEventPoliciesUseCase_List_Call) Run(run func()) *MockEventPoliciesUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockEventPoliciesUseCase_List_Call) Return(eventPolicys []*entities.EventPolicy) *MockEventPoliciesUseCase_List_Call {
+	_c.Call.Return(eventPolicys)
+	return _c
+}
+
+func (_c *MockEventPoliciesUseCase_List_Call) RunAndReturn(run func() []*entities.EventPolicy) *MockEventPoliciesUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function for the type MockEventPoliciesUseCase
+func (_mock *MockEventPoliciesUseCase) Reset(eventType string) *entities.EventPolicy {
+	ret := _mock.Called(eventType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 *entities.EventPolicy
+	if returnFunc, ok := ret.Get(0).(func(string) *entities.EventPolicy); ok {
+		r0 = returnFunc(eventType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.EventPolicy)
+		}
+	}
+	return r0
+}
+
+// MockEventPoliciesUseCase_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type MockEventPoliciesUseCase_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+//   - eventType string
+func (_e *MockEventPoliciesUseCase_Expecter) Reset(eventType interface{}) *MockEventPoliciesUseCase_Reset_Call {
+	return &MockEventPoliciesUseCase_Reset_Call{Call: _e.mock.On("Reset", eventType)}
+}
+
+func (_c *MockEventPoliciesUseCase_Reset_Call) Run(run func(eventType string)) *MockEventPoliciesUseCase_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventPoliciesUseCase_Reset_Call) Return(eventPolicy *entities.EventPolicy) *MockEventPoliciesUseCase_Reset_Call {
+	_c.Call.Return(eventPolicy)
+	return _c
+}
+
+func (_c *MockEventPoliciesUseCase_Reset_Call) RunAndReturn(run func(eventType string) *entities.EventPolicy) *MockEventPoliciesUseCase_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type MockEventPoliciesUseCase
+func (_mock *MockEventPoliciesUseCase) Set(policy *entities.EventPolicy) error {
+	ret := _mock.Called(policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*entities.EventPolicy) error); ok {
+		r0 = returnFunc(policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventPoliciesUseCase_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockEventPoliciesUseCase_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - policy *entities.EventPolicy
+func (_e *MockEventPoliciesUseCase_Expecter) Set(policy interface{}) *MockEventPoliciesUseCase_Set_Call {
+	return &MockEventPoliciesUseCase_Set_Call{Call: _e.mock.On("Set", policy)}
+}
+
+func (_c *MockEventPoliciesUseCase_Set_Call) Run(run func(policy *entities.EventPolicy)) *MockEventPoliciesUseCase_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *entities.EventPolicy
+		if args[0] != nil {
+			arg0 = args[0].(*entities.EventPolicy)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventPoliciesUseCase_Set_Call) Return(err error) *MockEventPoliciesUseCase_Set_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventPoliciesUseCase_Set_Call) RunAndReturn(run func(policy *entities.EventPolicy) error) *MockEventPoliciesUseCase_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
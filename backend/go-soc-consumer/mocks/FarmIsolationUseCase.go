@@ -0,0 +1,95 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockFarmIsolationUseCase creates a new instance of MockFarmIsolationUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFarmIsolationUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFarmIsolationUseCase {
+	mock := &MockFarmIsolationUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFarmIsolationUseCase is an autogenerated mock type for the FarmIsolationUseCase type
+type MockFarmIsolationUseCase struct {
+	mock.Mock
+}
+
+type MockFarmIsolationUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFarmIsolationUseCase) EXPECT() *MockFarmIsolationUseCase_Expecter {
+	return &MockFarmIsolationUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Verify provides a mock function for the type MockFarmIsolationUseCase
+func (_mock *MockFarmIsolationUseCase) Verify(ctx context.Context, macAddress string) error {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmIsolationUseCase_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockFarmIsolationUseCase_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockFarmIsolationUseCase_Expecter) Verify(ctx interface{}, macAddress interface{}) *MockFarmIsolationUseCase_Verify_Call {
+	return &MockFarmIsolationUseCase_Verify_Call{Call: _e.mock.On("Verify", ctx, macAddress)}
+}
+
+func (_c *MockFarmIsolationUseCase_Verify_Call) Run(run func(ctx context.Context, macAddress string)) *MockFarmIsolationUseCase_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmIsolationUseCase_Verify_Call) Return(err error) *MockFarmIsolationUseCase_Verify_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmIsolationUseCase_Verify_Call) RunAndReturn(run func(ctx context.Context, macAddress string) error) *MockFarmIsolationUseCase_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,319 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockFarmQuotasUseCase creates a new instance of MockFarmQuotasUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFarmQuotasUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFarmQuotasUseCase {
+	mock := &MockFarmQuotasUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFarmQuotasUseCase is an autogenerated mock type for the FarmQuotasUseCase type
+type MockFarmQuotasUseCase struct {
+	mock.Mock
+}
+
+type MockFarmQuotasUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFarmQuotasUseCase) EXPECT() *MockFarmQuotasUseCase_Expecter {
+	return &MockFarmQuotasUseCase_Expecter{mock: &_m.Mock}
+}
+
+// AdmitDevice provides a mock function for the type MockFarmQuotasUseCase
+func (_mock *MockFarmQuotasUseCase) AdmitDevice(ctx context.Context, macAddress string) error {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdmitDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmQuotasUseCase_AdmitDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdmitDevice'
+type MockFarmQuotasUseCase_AdmitDevice_Call struct {
+	*mock.Call
+}
+
+// AdmitDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockFarmQuotasUseCase_Expecter) AdmitDevice(ctx interface{}, macAddress interface{}) *MockFarmQuotasUseCase_AdmitDevice_Call {
+	return &MockFarmQuotasUseCase_AdmitDevice_Call{Call: _e.mock.On("AdmitDevice", ctx, macAddress)}
+}
+
+func (_c *MockFarmQuotasUseCase_AdmitDevice_Call) Run(run func(ctx context.Context, macAddress string)) *MockFarmQuotasUseCase_AdmitDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmQuotasUseCase_AdmitDevice_Call) Return(err error) *MockFarmQuotasUseCase_AdmitDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmQuotasUseCase_AdmitDevice_Call) RunAndReturn(run func(ctx context.Context, macAddress string) error) *MockFarmQuotasUseCase_AdmitDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdmitMessage provides a mock function for the type MockFarmQuotasUseCase
+func (_mock *MockFarmQuotasUseCase) AdmitMessage(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdmitMessage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmQuotasUseCase_AdmitMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdmitMessage'
+type MockFarmQuotasUseCase_AdmitMessage_Call struct {
+	*mock.Call
+}
+
+// AdmitMessage is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockFarmQuotasUseCase_Expecter) AdmitMessage(ctx interface{}) *MockFarmQuotasUseCase_AdmitMessage_Call {
+	return &MockFarmQuotasUseCase_AdmitMessage_Call{Call: _e.mock.On("AdmitMessage", ctx)}
+}
+
+func (_c *MockFarmQuotasUseCase_AdmitMessage_Call) Run(run func(ctx context.Context)) *MockFarmQuotasUseCase_AdmitMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmQuotasUseCase_AdmitMessage_Call) Return(err error) *MockFarmQuotasUseCase_AdmitMessage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmQuotasUseCase_AdmitMessage_Call) RunAndReturn(run func(ctx context.Context) error) *MockFarmQuotasUseCase_AdmitMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockFarmQuotasUseCase
+func (_mock *MockFarmQuotasUseCase) Get(ctx context.Context, farmID string) (*entities.FarmQuotaUsage, error) {
+	ret := _mock.Called(ctx, farmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entities.FarmQuotaUsage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.FarmQuotaUsage, error)); ok {
+		return returnFunc(ctx, farmID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.FarmQuotaUsage); ok {
+		r0 = returnFunc(ctx, farmID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.FarmQuotaUsage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, farmID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFarmQuotasUseCase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockFarmQuotasUseCase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - farmID string
+func (_e *MockFarmQuotasUseCase_Expecter) Get(ctx interface{}, farmID interface{}) *MockFarmQuotasUseCase_Get_Call {
+	return &MockFarmQuotasUseCase_Get_Call{Call: _e.mock.On("Get", ctx, farmID)}
+}
+
+func (_c *MockFarmQuotasUseCase_Get_Call) Run(run func(ctx context.Context, farmID string)) *MockFarmQuotasUseCase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmQuotasUseCase_Get_Call) Return(farmQuotaUsage *entities.FarmQuotaUsage, err error) *MockFarmQuotasUseCase_Get_Call {
+	_c.Call.Return(farmQuotaUsage, err)
+	return _c
+}
+
+func (_c *MockFarmQuotasUseCase_Get_Call) RunAndReturn(run func(ctx context.Context, farmID string) (*entities.FarmQuotaUsage, error)) *MockFarmQuotasUseCase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function for the type MockFarmQuotasUseCase
+func (_mock *MockFarmQuotasUseCase) Reset(farmID string) *entities.FarmQuota {
+	ret := _mock.Called(farmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 *entities.FarmQuota
+	if returnFunc, ok := ret.Get(0).(func(string) *entities.FarmQuota); ok {
+		r0 = returnFunc(farmID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.FarmQuota)
+		}
+	}
+	return r0
+}
+
+// MockFarmQuotasUseCase_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type MockFarmQuotasUseCase_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+//   - farmID string
+func (_e *MockFarmQuotasUseCase_Expecter) Reset(farmID interface{}) *MockFarmQuotasUseCase_Reset_Call {
+	return &MockFarmQuotasUseCase_Reset_Call{Call: _e.mock.On("Reset", farmID)}
+}
+
+func (_c *MockFarmQuotasUseCase_Reset_Call) Run(run func(farmID string)) *MockFarmQuotasUseCase_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmQuotasUseCase_Reset_Call) Return(farmQuota *entities.FarmQuota) *MockFarmQuotasUseCase_Reset_Call {
+	_c.Call.Return(farmQuota)
+	return _c
+}
+
+func (_c *MockFarmQuotasUseCase_Reset_Call) RunAndReturn(run func(farmID string) *entities.FarmQuota) *MockFarmQuotasUseCase_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type MockFarmQuotasUseCase
+func (_mock *MockFarmQuotasUseCase) Set(quota *entities.FarmQuota) error {
+	ret := _mock.Called(quota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*entities.FarmQuota) error); ok {
+		r0 = returnFunc(quota)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmQuotasUseCase_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockFarmQuotasUseCase_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - quota *entities.FarmQuota
+func (_e *MockFarmQuotasUseCase_Expecter) Set(quota interface{}) *MockFarmQuotasUseCase_Set_Call {
+	return &MockFarmQuotasUseCase_Set_Call{Call: _e.mock.On("Set", quota)}
+}
+
+func (_c *MockFarmQuotasUseCase_Set_Call) Run(run func(quota *entities.FarmQuota)) *MockFarmQuotasUseCase_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *entities.FarmQuota
+		if args[0] != nil {
+			arg0 = args[0].(*entities.FarmQuota)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmQuotasUseCase_Set_Call) Return(err error) *MockFarmQuotasUseCase_Set_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmQuotasUseCase_Set_Call) RunAndReturn(run func(quota *entities.FarmQuota) error) *MockFarmQuotasUseCase_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
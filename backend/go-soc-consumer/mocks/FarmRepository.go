@@ -0,0 +1,340 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockFarmRepository creates a new instance of MockFarmRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFarmRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFarmRepository {
+	mock := &MockFarmRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFarmRepository is an autogenerated mock type for the FarmRepository type
+type MockFarmRepository struct {
+	mock.Mock
+}
+
+type MockFarmRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFarmRepository) EXPECT() *MockFarmRepository_Expecter {
+	return &MockFarmRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockFarmRepository
+func (_mock *MockFarmRepository) Create(ctx context.Context, farm *entities.Farm) error {
+	ret := _mock.Called(ctx, farm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.Farm) error); ok {
+		r0 = returnFunc(ctx, farm)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockFarmRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - farm *entities.Farm
+func (_e *MockFarmRepository_Expecter) Create(ctx interface{}, farm interface{}) *MockFarmRepository_Create_Call {
+	return &MockFarmRepository_Create_Call{Call: _e.mock.On("Create", ctx, farm)}
+}
+
+func (_c *MockFarmRepository_Create_Call) Run(run func(ctx context.Context, farm *entities.Farm)) *MockFarmRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.Farm
+		if args[1] != nil {
+			arg1 = args[1].(*entities.Farm)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmRepository_Create_Call) Return(err error) *MockFarmRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmRepository_Create_Call) RunAndReturn(run func(ctx context.Context, farm *entities.Farm) error) *MockFarmRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockFarmRepository
+func (_mock *MockFarmRepository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockFarmRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockFarmRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockFarmRepository_Delete_Call {
+	return &MockFarmRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockFarmRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockFarmRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmRepository_Delete_Call) Return(err error) *MockFarmRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockFarmRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockFarmRepository
+func (_mock *MockFarmRepository) FindByID(ctx context.Context, id string) (*entities.Farm, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.Farm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.Farm, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.Farm); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Farm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFarmRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockFarmRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockFarmRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockFarmRepository_FindByID_Call {
+	return &MockFarmRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockFarmRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockFarmRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmRepository_FindByID_Call) Return(farm *entities.Farm, err error) *MockFarmRepository_FindByID_Call {
+	_c.Call.Return(farm, err)
+	return _c
+}
+
+func (_c *MockFarmRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.Farm, error)) *MockFarmRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockFarmRepository
+func (_mock *MockFarmRepository) List(ctx context.Context) ([]*entities.Farm, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.Farm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.Farm, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.Farm); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Farm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFarmRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockFarmRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockFarmRepository_Expecter) List(ctx interface{}) *MockFarmRepository_List_Call {
+	return &MockFarmRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockFarmRepository_List_Call) Run(run func(ctx context.Context)) *MockFarmRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmRepository_List_Call) Return(farms []*entities.Farm, err error) *MockFarmRepository_List_Call {
+	_c.Call.Return(farms, err)
+	return _c
+}
+
+func (_c *MockFarmRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.Farm, error)) *MockFarmRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockFarmRepository
+func (_mock *MockFarmRepository) Update(ctx context.Context, farm *entities.Farm) error {
+	ret := _mock.Called(ctx, farm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.Farm) error); ok {
+		r0 = returnFunc(ctx, farm)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockFarmRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - farm *entities.Farm
+func (_e *MockFarmRepository_Expecter) Update(ctx interface{}, farm interface{}) *MockFarmRepository_Update_Call {
+	return &MockFarmRepository_Update_Call{Call: _e.mock.On("Update", ctx, farm)}
+}
+
+func (_c *MockFarmRepository_Update_Call) Run(run func(ctx context.Context, farm *entities.Farm)) *MockFarmRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.Farm
+		if args[1] != nil {
+			arg1 = args[1].(*entities.Farm)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmRepository_Update_Call) Return(err error) *MockFarmRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmRepository_Update_Call) RunAndReturn(run func(ctx context.Context, farm *entities.Farm) error) *MockFarmRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,870 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockFarmZonesUseCase creates a new instance of MockFarmZonesUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFarmZonesUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFarmZonesUseCase {
+	mock := &MockFarmZonesUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFarmZonesUseCase is an autogenerated mock type for the FarmZonesUseCase type
+type MockFarmZonesUseCase struct {
+	mock.Mock
+}
+
+type MockFarmZonesUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFarmZonesUseCase) EXPECT() *MockFarmZonesUseCase_Expecter {
+	return &MockFarmZonesUseCase_Expecter{mock: &_m.Mock}
+}
+
+// AssignDevice provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) AssignDevice(ctx context.Context, zoneID string, macAddress string) error {
+	ret := _mock.Called(ctx, zoneID, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, zoneID, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmZonesUseCase_AssignDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignDevice'
+type MockFarmZonesUseCase_AssignDevice_Call struct {
+	*mock.Call
+}
+
+// AssignDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zoneID string
+//   - macAddress string
+func (_e *MockFarmZonesUseCase_Expecter) AssignDevice(ctx interface{}, zoneID interface{}, macAddress interface{}) *MockFarmZonesUseCase_AssignDevice_Call {
+	return &MockFarmZonesUseCase_AssignDevice_Call{Call: _e.mock.On("AssignDevice", ctx, zoneID, macAddress)}
+}
+
+func (_c *MockFarmZonesUseCase_AssignDevice_Call) Run(run func(ctx context.Context, zoneID string, macAddress string)) *MockFarmZonesUseCase_AssignDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_AssignDevice_Call) Return(err error) *MockFarmZonesUseCase_AssignDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_AssignDevice_Call) RunAndReturn(run func(ctx context.Context, zoneID string, macAddress string) error) *MockFarmZonesUseCase_AssignDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateFarm provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) CreateFarm(ctx context.Context, id string, name string) (*entities.Farm, error) {
+	ret := _mock.Called(ctx, id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFarm")
+	}
+
+	var r0 *entities.Farm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entities.Farm, error)); ok {
+		return returnFunc(ctx, id, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entities.Farm); ok {
+		r0 = returnFunc(ctx, id, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Farm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFarmZonesUseCase_CreateFarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFarm'
+type MockFarmZonesUseCase_CreateFarm_Call struct {
+	*mock.Call
+}
+
+// CreateFarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - name string
+func (_e *MockFarmZonesUseCase_Expecter) CreateFarm(ctx interface{}, id interface{}, name interface{}) *MockFarmZonesUseCase_CreateFarm_Call {
+	return &MockFarmZonesUseCase_CreateFarm_Call{Call: _e.mock.On("CreateFarm", ctx, id, name)}
+}
+
+func (_c *MockFarmZonesUseCase_CreateFarm_Call) Run(run func(ctx context.Context, id string, name string)) *MockFarmZonesUseCase_CreateFarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_CreateFarm_Call) Return(farm *entities.Farm, err error) *MockFarmZonesUseCase_CreateFarm_Call {
+	_c.Call.Return(farm, err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_CreateFarm_Call) RunAndReturn(run func(ctx context.Context, id string, name string) (*entities.Farm, error)) *MockFarmZonesUseCase_CreateFarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateZone provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) CreateZone(ctx context.Context, farmID string, request entities.ZoneRequest) (*entities.Zone, error) {
+	ret := _mock.Called(ctx, farmID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateZone")
+	}
+
+	var r0 *entities.Zone
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.ZoneRequest) (*entities.Zone, error)); ok {
+		return returnFunc(ctx, farmID, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.ZoneRequest) *entities.Zone); ok {
+		r0 = returnFunc(ctx, farmID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Zone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, entities.ZoneRequest) error); ok {
+		r1 = returnFunc(ctx, farmID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFarmZonesUseCase_CreateZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateZone'
+type MockFarmZonesUseCase_CreateZone_Call struct {
+	*mock.Call
+}
+
+// CreateZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - farmID string
+//   - request entities.ZoneRequest
+func (_e *MockFarmZonesUseCase_Expecter) CreateZone(ctx interface{}, farmID interface{}, request interface{}) *MockFarmZonesUseCase_CreateZone_Call {
+	return &MockFarmZonesUseCase_CreateZone_Call{Call: _e.mock.On("CreateZone", ctx, farmID, request)}
+}
+
+func (_c *MockFarmZonesUseCase_CreateZone_Call) Run(run func(ctx context.Context, farmID string, request entities.ZoneRequest)) *MockFarmZonesUseCase_CreateZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 entities.ZoneRequest
+		if args[2] != nil {
+			arg2 = args[2].(entities.ZoneRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_CreateZone_Call) Return(zone *entities.Zone, err error) *MockFarmZonesUseCase_CreateZone_Call {
+	_c.Call.Return(zone, err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_CreateZone_Call) RunAndReturn(run func(ctx context.Context, farmID string, request entities.ZoneRequest) (*entities.Zone, error)) *MockFarmZonesUseCase_CreateZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFarm provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) DeleteFarm(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFarm")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmZonesUseCase_DeleteFarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFarm'
+type MockFarmZonesUseCase_DeleteFarm_Call struct {
+	*mock.Call
+}
+
+// DeleteFarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockFarmZonesUseCase_Expecter) DeleteFarm(ctx interface{}, id interface{}) *MockFarmZonesUseCase_DeleteFarm_Call {
+	return &MockFarmZonesUseCase_DeleteFarm_Call{Call: _e.mock.On("DeleteFarm", ctx, id)}
+}
+
+func (_c *MockFarmZonesUseCase_DeleteFarm_Call) Run(run func(ctx context.Context, id string)) *MockFarmZonesUseCase_DeleteFarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_DeleteFarm_Call) Return(err error) *MockFarmZonesUseCase_DeleteFarm_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_DeleteFarm_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockFarmZonesUseCase_DeleteFarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteZone provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) DeleteZone(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteZone")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmZonesUseCase_DeleteZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteZone'
+type MockFarmZonesUseCase_DeleteZone_Call struct {
+	*mock.Call
+}
+
+// DeleteZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockFarmZonesUseCase_Expecter) DeleteZone(ctx interface{}, id interface{}) *MockFarmZonesUseCase_DeleteZone_Call {
+	return &MockFarmZonesUseCase_DeleteZone_Call{Call: _e.mock.On("DeleteZone", ctx, id)}
+}
+
+func (_c *MockFarmZonesUseCase_DeleteZone_Call) Run(run func(ctx context.Context, id string)) *MockFarmZonesUseCase_DeleteZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_DeleteZone_Call) Return(err error) *MockFarmZonesUseCase_DeleteZone_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_DeleteZone_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockFarmZonesUseCase_DeleteZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFarm provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) GetFarm(ctx context.Context, id string) (*entities.Farm, []*entities.Zone, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFarm")
+	}
+
+	var r0 *entities.Farm
+	var r1 []*entities.Zone
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.Farm, []*entities.Zone, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.Farm); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Farm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) []*entities.Zone); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*entities.Zone)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockFarmZonesUseCase_GetFarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFarm'
+type MockFarmZonesUseCase_GetFarm_Call struct {
+	*mock.Call
+}
+
+// GetFarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockFarmZonesUseCase_Expecter) GetFarm(ctx interface{}, id interface{}) *MockFarmZonesUseCase_GetFarm_Call {
+	return &MockFarmZonesUseCase_GetFarm_Call{Call: _e.mock.On("GetFarm", ctx, id)}
+}
+
+func (_c *MockFarmZonesUseCase_GetFarm_Call) Run(run func(ctx context.Context, id string)) *MockFarmZonesUseCase_GetFarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_GetFarm_Call) Return(farm *entities.Farm, zones []*entities.Zone, err error) *MockFarmZonesUseCase_GetFarm_Call {
+	_c.Call.Return(farm, zones, err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_GetFarm_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.Farm, []*entities.Zone, error)) *MockFarmZonesUseCase_GetFarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetZone provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) GetZone(ctx context.Context, id string) (*entities.Zone, []*entities.Device, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetZone")
+	}
+
+	var r0 *entities.Zone
+	var r1 []*entities.Device
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.Zone, []*entities.Device, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.Zone); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Zone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) []*entities.Device); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*entities.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockFarmZonesUseCase_GetZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetZone'
+type MockFarmZonesUseCase_GetZone_Call struct {
+	*mock.Call
+}
+
+// GetZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockFarmZonesUseCase_Expecter) GetZone(ctx interface{}, id interface{}) *MockFarmZonesUseCase_GetZone_Call {
+	return &MockFarmZonesUseCase_GetZone_Call{Call: _e.mock.On("GetZone", ctx, id)}
+}
+
+func (_c *MockFarmZonesUseCase_GetZone_Call) Run(run func(ctx context.Context, id string)) *MockFarmZonesUseCase_GetZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_GetZone_Call) Return(zone *entities.Zone, devices []*entities.Device, err error) *MockFarmZonesUseCase_GetZone_Call {
+	_c.Call.Return(zone, devices, err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_GetZone_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.Zone, []*entities.Device, error)) *MockFarmZonesUseCase_GetZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFarms provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) ListFarms(ctx context.Context) ([]*entities.Farm, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFarms")
+	}
+
+	var r0 []*entities.Farm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.Farm, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.Farm); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Farm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFarmZonesUseCase_ListFarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFarms'
+type MockFarmZonesUseCase_ListFarms_Call struct {
+	*mock.Call
+}
+
+// ListFarms is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockFarmZonesUseCase_Expecter) ListFarms(ctx interface{}) *MockFarmZonesUseCase_ListFarms_Call {
+	return &MockFarmZonesUseCase_ListFarms_Call{Call: _e.mock.On("ListFarms", ctx)}
+}
+
+func (_c *MockFarmZonesUseCase_ListFarms_Call) Run(run func(ctx context.Context)) *MockFarmZonesUseCase_ListFarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_ListFarms_Call) Return(farms []*entities.Farm, err error) *MockFarmZonesUseCase_ListFarms_Call {
+	_c.Call.Return(farms, err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_ListFarms_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.Farm, error)) *MockFarmZonesUseCase_ListFarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Measurements provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) Measurements(ctx context.Context, zoneID string, since time.Time) ([]*entities.ZoneSensorSummary, time.Time, error) {
+	ret := _mock.Called(ctx, zoneID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Measurements")
+	}
+
+	var r0 []*entities.ZoneSensorSummary
+	var r1 time.Time
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]*entities.ZoneSensorSummary, time.Time, error)); ok {
+		return returnFunc(ctx, zoneID, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) []*entities.ZoneSensorSummary); ok {
+		r0 = returnFunc(ctx, zoneID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ZoneSensorSummary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) time.Time); ok {
+		r1 = returnFunc(ctx, zoneID, since)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, time.Time) error); ok {
+		r2 = returnFunc(ctx, zoneID, since)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockFarmZonesUseCase_Measurements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Measurements'
+type MockFarmZonesUseCase_Measurements_Call struct {
+	*mock.Call
+}
+
+// Measurements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zoneID string
+//   - since time.Time
+func (_e *MockFarmZonesUseCase_Expecter) Measurements(ctx interface{}, zoneID interface{}, since interface{}) *MockFarmZonesUseCase_Measurements_Call {
+	return &MockFarmZonesUseCase_Measurements_Call{Call: _e.mock.On("Measurements", ctx, zoneID, since)}
+}
+
+func (_c *MockFarmZonesUseCase_Measurements_Call) Run(run func(ctx context.Context, zoneID string, since time.Time)) *MockFarmZonesUseCase_Measurements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_Measurements_Call) Return(zoneSensorSummarys []*entities.ZoneSensorSummary, time time.Time, err error) *MockFarmZonesUseCase_Measurements_Call {
+	_c.Call.Return(zoneSensorSummarys, time, err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_Measurements_Call) RunAndReturn(run func(ctx context.Context, zoneID string, since time.Time) ([]*entities.ZoneSensorSummary, time.Time, error)) *MockFarmZonesUseCase_Measurements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameFarm provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) RenameFarm(ctx context.Context, id string, name string) (*entities.Farm, error) {
+	ret := _mock.Called(ctx, id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameFarm")
+	}
+
+	var r0 *entities.Farm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entities.Farm, error)); ok {
+		return returnFunc(ctx, id, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entities.Farm); ok {
+		r0 = returnFunc(ctx, id, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Farm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFarmZonesUseCase_RenameFarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameFarm'
+type MockFarmZonesUseCase_RenameFarm_Call struct {
+	*mock.Call
+}
+
+// RenameFarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - name string
+func (_e *MockFarmZonesUseCase_Expecter) RenameFarm(ctx interface{}, id interface{}, name interface{}) *MockFarmZonesUseCase_RenameFarm_Call {
+	return &MockFarmZonesUseCase_RenameFarm_Call{Call: _e.mock.On("RenameFarm", ctx, id, name)}
+}
+
+func (_c *MockFarmZonesUseCase_RenameFarm_Call) Run(run func(ctx context.Context, id string, name string)) *MockFarmZonesUseCase_RenameFarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_RenameFarm_Call) Return(farm *entities.Farm, err error) *MockFarmZonesUseCase_RenameFarm_Call {
+	_c.Call.Return(farm, err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_RenameFarm_Call) RunAndReturn(run func(ctx context.Context, id string, name string) (*entities.Farm, error)) *MockFarmZonesUseCase_RenameFarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameZone provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) RenameZone(ctx context.Context, id string, name string) (*entities.Zone, error) {
+	ret := _mock.Called(ctx, id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameZone")
+	}
+
+	var r0 *entities.Zone
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entities.Zone, error)); ok {
+		return returnFunc(ctx, id, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entities.Zone); ok {
+		r0 = returnFunc(ctx, id, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Zone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFarmZonesUseCase_RenameZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameZone'
+type MockFarmZonesUseCase_RenameZone_Call struct {
+	*mock.Call
+}
+
+// RenameZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - name string
+func (_e *MockFarmZonesUseCase_Expecter) RenameZone(ctx interface{}, id interface{}, name interface{}) *MockFarmZonesUseCase_RenameZone_Call {
+	return &MockFarmZonesUseCase_RenameZone_Call{Call: _e.mock.On("RenameZone", ctx, id, name)}
+}
+
+func (_c *MockFarmZonesUseCase_RenameZone_Call) Run(run func(ctx context.Context, id string, name string)) *MockFarmZonesUseCase_RenameZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_RenameZone_Call) Return(zone *entities.Zone, err error) *MockFarmZonesUseCase_RenameZone_Call {
+	_c.Call.Return(zone, err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_RenameZone_Call) RunAndReturn(run func(ctx context.Context, id string, name string) (*entities.Zone, error)) *MockFarmZonesUseCase_RenameZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnassignDevice provides a mock function for the type MockFarmZonesUseCase
+func (_mock *MockFarmZonesUseCase) UnassignDevice(ctx context.Context, zoneID string, macAddress string) error {
+	ret := _mock.Called(ctx, zoneID, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnassignDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, zoneID, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFarmZonesUseCase_UnassignDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnassignDevice'
+type MockFarmZonesUseCase_UnassignDevice_Call struct {
+	*mock.Call
+}
+
+// UnassignDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zoneID string
+//   - macAddress string
+func (_e *MockFarmZonesUseCase_Expecter) UnassignDevice(ctx interface{}, zoneID interface{}, macAddress interface{}) *MockFarmZonesUseCase_UnassignDevice_Call {
+	return &MockFarmZonesUseCase_UnassignDevice_Call{Call: _e.mock.On("UnassignDevice", ctx, zoneID, macAddress)}
+}
+
+func (_c *MockFarmZonesUseCase_UnassignDevice_Call) Run(run func(ctx context.Context, zoneID string, macAddress string)) *MockFarmZonesUseCase_UnassignDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_UnassignDevice_Call) Return(err error) *MockFarmZonesUseCase_UnassignDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFarmZonesUseCase_UnassignDevice_Call) RunAndReturn(run func(ctx context.Context, zoneID string, macAddress string) error) *MockFarmZonesUseCase_UnassignDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,194 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockIrrigationEfficiencyUseCase creates a new instance of MockIrrigationEfficiencyUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIrrigationEfficiencyUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIrrigationEfficiencyUseCase {
+	mock := &MockIrrigationEfficiencyUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIrrigationEfficiencyUseCase is an autogenerated mock type for the IrrigationEfficiencyUseCase type
+type MockIrrigationEfficiencyUseCase struct {
+	mock.Mock
+}
+
+type MockIrrigationEfficiencyUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIrrigationEfficiencyUseCase) EXPECT() *MockIrrigationEfficiencyUseCase_Expecter {
+	return &MockIrrigationEfficiencyUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Zone provides a mock function for the type MockIrrigationEfficiencyUseCase
+func (_mock *MockIrrigationEfficiencyUseCase) Zone(ctx context.Context, zone string, from time.Time, to time.Time) (*entities.IrrigationZoneEfficiency, error) {
+	ret := _mock.Called(ctx, zone, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Zone")
+	}
+
+	var r0 *entities.IrrigationZoneEfficiency
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (*entities.IrrigationZoneEfficiency, error)); ok {
+		return returnFunc(ctx, zone, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) *entities.IrrigationZoneEfficiency); ok {
+		r0 = returnFunc(ctx, zone, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IrrigationZoneEfficiency)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, zone, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationEfficiencyUseCase_Zone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Zone'
+type MockIrrigationEfficiencyUseCase_Zone_Call struct {
+	*mock.Call
+}
+
+// Zone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zone string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockIrrigationEfficiencyUseCase_Expecter) Zone(ctx interface{}, zone interface{}, from interface{}, to interface{}) *MockIrrigationEfficiencyUseCase_Zone_Call {
+	return &MockIrrigationEfficiencyUseCase_Zone_Call{Call: _e.mock.On("Zone", ctx, zone, from, to)}
+}
+
+func (_c *MockIrrigationEfficiencyUseCase_Zone_Call) Run(run func(ctx context.Context, zone string, from time.Time, to time.Time)) *MockIrrigationEfficiencyUseCase_Zone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationEfficiencyUseCase_Zone_Call) Return(irrigationZoneEfficiency *entities.IrrigationZoneEfficiency, err error) *MockIrrigationEfficiencyUseCase_Zone_Call {
+	_c.Call.Return(irrigationZoneEfficiency, err)
+	return _c
+}
+
+func (_c *MockIrrigationEfficiencyUseCase_Zone_Call) RunAndReturn(run func(ctx context.Context, zone string, from time.Time, to time.Time) (*entities.IrrigationZoneEfficiency, error)) *MockIrrigationEfficiencyUseCase_Zone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Zones provides a mock function for the type MockIrrigationEfficiencyUseCase
+func (_mock *MockIrrigationEfficiencyUseCase) Zones(ctx context.Context, from time.Time, to time.Time) ([]*entities.IrrigationZoneEfficiency, error) {
+	ret := _mock.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Zones")
+	}
+
+	var r0 []*entities.IrrigationZoneEfficiency
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]*entities.IrrigationZoneEfficiency, error)); ok {
+		return returnFunc(ctx, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*entities.IrrigationZoneEfficiency); ok {
+		r0 = returnFunc(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.IrrigationZoneEfficiency)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationEfficiencyUseCase_Zones_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Zones'
+type MockIrrigationEfficiencyUseCase_Zones_Call struct {
+	*mock.Call
+}
+
+// Zones is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from time.Time
+//   - to time.Time
+func (_e *MockIrrigationEfficiencyUseCase_Expecter) Zones(ctx interface{}, from interface{}, to interface{}) *MockIrrigationEfficiencyUseCase_Zones_Call {
+	return &MockIrrigationEfficiencyUseCase_Zones_Call{Call: _e.mock.On("Zones", ctx, from, to)}
+}
+
+func (_c *MockIrrigationEfficiencyUseCase_Zones_Call) Run(run func(ctx context.Context, from time.Time, to time.Time)) *MockIrrigationEfficiencyUseCase_Zones_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationEfficiencyUseCase_Zones_Call) Return(irrigationZoneEfficiencys []*entities.IrrigationZoneEfficiency, err error) *MockIrrigationEfficiencyUseCase_Zones_Call {
+	_c.Call.Return(irrigationZoneEfficiencys, err)
+	return _c
+}
+
+func (_c *MockIrrigationEfficiencyUseCase_Zones_Call) RunAndReturn(run func(ctx context.Context, from time.Time, to time.Time) ([]*entities.IrrigationZoneEfficiency, error)) *MockIrrigationEfficiencyUseCase_Zones_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,283 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockIrrigationExperimentRepository creates a new instance of MockIrrigationExperimentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIrrigationExperimentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIrrigationExperimentRepository {
+	mock := &MockIrrigationExperimentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIrrigationExperimentRepository is an autogenerated mock type for the IrrigationExperimentRepository type
+type MockIrrigationExperimentRepository struct {
+	mock.Mock
+}
+
+type MockIrrigationExperimentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIrrigationExperimentRepository) EXPECT() *MockIrrigationExperimentRepository_Expecter {
+	return &MockIrrigationExperimentRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockIrrigationExperimentRepository
+func (_mock *MockIrrigationExperimentRepository) Create(ctx context.Context, experiment *entities.IrrigationExperiment) error {
+	ret := _mock.Called(ctx, experiment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.IrrigationExperiment) error); ok {
+		r0 = returnFunc(ctx, experiment)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationExperimentRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockIrrigationExperimentRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - experiment *entities.IrrigationExperiment
+func (_e *MockIrrigationExperimentRepository_Expecter) Create(ctx interface{}, experiment interface{}) *MockIrrigationExperimentRepository_Create_Call {
+	return &MockIrrigationExperimentRepository_Create_Call{Call: _e.mock.On("Create", ctx, experiment)}
+}
+
+func (_c *MockIrrigationExperimentRepository_Create_Call) Run(run func(ctx context.Context, experiment *entities.IrrigationExperiment)) *MockIrrigationExperimentRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.IrrigationExperiment
+		if args[1] != nil {
+			arg1 = args[1].(*entities.IrrigationExperiment)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationExperimentRepository_Create_Call) Return(err error) *MockIrrigationExperimentRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationExperimentRepository_Create_Call) RunAndReturn(run func(ctx context.Context, experiment *entities.IrrigationExperiment) error) *MockIrrigationExperimentRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockIrrigationExperimentRepository
+func (_mock *MockIrrigationExperimentRepository) FindByID(ctx context.Context, id string) (*entities.IrrigationExperiment, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.IrrigationExperiment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.IrrigationExperiment, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.IrrigationExperiment); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IrrigationExperiment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationExperimentRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockIrrigationExperimentRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockIrrigationExperimentRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockIrrigationExperimentRepository_FindByID_Call {
+	return &MockIrrigationExperimentRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockIrrigationExperimentRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockIrrigationExperimentRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationExperimentRepository_FindByID_Call) Return(irrigationExperiment *entities.IrrigationExperiment, err error) *MockIrrigationExperimentRepository_FindByID_Call {
+	_c.Call.Return(irrigationExperiment, err)
+	return _c
+}
+
+func (_c *MockIrrigationExperimentRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.IrrigationExperiment, error)) *MockIrrigationExperimentRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockIrrigationExperimentRepository
+func (_mock *MockIrrigationExperimentRepository) List(ctx context.Context) ([]*entities.IrrigationExperiment, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.IrrigationExperiment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.IrrigationExperiment, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.IrrigationExperiment); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.IrrigationExperiment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationExperimentRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockIrrigationExperimentRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIrrigationExperimentRepository_Expecter) List(ctx interface{}) *MockIrrigationExperimentRepository_List_Call {
+	return &MockIrrigationExperimentRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockIrrigationExperimentRepository_List_Call) Run(run func(ctx context.Context)) *MockIrrigationExperimentRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationExperimentRepository_List_Call) Return(irrigationExperiments []*entities.IrrigationExperiment, err error) *MockIrrigationExperimentRepository_List_Call {
+	_c.Call.Return(irrigationExperiments, err)
+	return _c
+}
+
+func (_c *MockIrrigationExperimentRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.IrrigationExperiment, error)) *MockIrrigationExperimentRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockIrrigationExperimentRepository
+func (_mock *MockIrrigationExperimentRepository) Update(ctx context.Context, experiment *entities.IrrigationExperiment) error {
+	ret := _mock.Called(ctx, experiment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.IrrigationExperiment) error); ok {
+		r0 = returnFunc(ctx, experiment)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationExperimentRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockIrrigationExperimentRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - experiment *entities.IrrigationExperiment
+func (_e *MockIrrigationExperimentRepository_Expecter) Update(ctx interface{}, experiment interface{}) *MockIrrigationExperimentRepository_Update_Call {
+	return &MockIrrigationExperimentRepository_Update_Call{Call: _e.mock.On("Update", ctx, experiment)}
+}
+
+func (_c *MockIrrigationExperimentRepository_Update_Call) Run(run func(ctx context.Context, experiment *entities.IrrigationExperiment)) *MockIrrigationExperimentRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.IrrigationExperiment
+		if args[1] != nil {
+			arg1 = args[1].(*entities.IrrigationExperiment)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationExperimentRepository_Update_Call) Return(err error) *MockIrrigationExperimentRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationExperimentRepository_Update_Call) RunAndReturn(run func(ctx context.Context, experiment *entities.IrrigationExperiment) error) *MockIrrigationExperimentRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,386 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockIrrigationExperimentsUseCase creates a new instance of MockIrrigationExperimentsUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIrrigationExperimentsUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIrrigationExperimentsUseCase {
+	mock := &MockIrrigationExperimentsUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIrrigationExperimentsUseCase is an autogenerated mock type for the IrrigationExperimentsUseCase type
+type MockIrrigationExperimentsUseCase struct {
+	mock.Mock
+}
+
+type MockIrrigationExperimentsUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIrrigationExperimentsUseCase) EXPECT() *MockIrrigationExperimentsUseCase_Expecter {
+	return &MockIrrigationExperimentsUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockIrrigationExperimentsUseCase
+func (_mock *MockIrrigationExperimentsUseCase) Create(ctx context.Context, request entities.IrrigationExperimentRequest) (*entities.IrrigationExperiment, error) {
+	ret := _mock.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entities.IrrigationExperiment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.IrrigationExperimentRequest) (*entities.IrrigationExperiment, error)); ok {
+		return returnFunc(ctx, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.IrrigationExperimentRequest) *entities.IrrigationExperiment); ok {
+		r0 = returnFunc(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IrrigationExperiment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.IrrigationExperimentRequest) error); ok {
+		r1 = returnFunc(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationExperimentsUseCase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockIrrigationExperimentsUseCase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request entities.IrrigationExperimentRequest
+func (_e *MockIrrigationExperimentsUseCase_Expecter) Create(ctx interface{}, request interface{}) *MockIrrigationExperimentsUseCase_Create_Call {
+	return &MockIrrigationExperimentsUseCase_Create_Call{Call: _e.mock.On("Create", ctx, request)}
+}
+
+func (_c *MockIrrigationExperimentsUseCase_Create_Call) Run(run func(ctx context.Context, request entities.IrrigationExperimentRequest)) *MockIrrigationExperimentsUseCase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 entities.IrrigationExperimentRequest
+		if args[1] != nil {
+			arg1 = args[1].(entities.IrrigationExperimentRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationExperimentsUseCase_Create_Call) Return(irrigationExperiment *entities.IrrigationExperiment, err error) *MockIrrigationExperimentsUseCase_Create_Call {
+	_c.Call.Return(irrigationExperiment, err)
+	return _c
+}
+
+func (_c *MockIrrigationExperimentsUseCase_Create_Call) RunAndReturn(run func(ctx context.Context, request entities.IrrigationExperimentRequest) (*entities.IrrigationExperiment, error)) *MockIrrigationExperimentsUseCase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// End provides a mock function for the type MockIrrigationExperimentsUseCase
+func (_mock *MockIrrigationExperimentsUseCase) End(ctx context.Context, id string) (*entities.IrrigationExperiment, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for End")
+	}
+
+	var r0 *entities.IrrigationExperiment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.IrrigationExperiment, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.IrrigationExperiment); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IrrigationExperiment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationExperimentsUseCase_End_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'End'
+type MockIrrigationExperimentsUseCase_End_Call struct {
+	*mock.Call
+}
+
+// End is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockIrrigationExperimentsUseCase_Expecter) End(ctx interface{}, id interface{}) *MockIrrigationExperimentsUseCase_End_Call {
+	return &MockIrrigationExperimentsUseCase_End_Call{Call: _e.mock.On("End", ctx, id)}
+}
+
+func (_c *MockIrrigationExperimentsUseCase_End_Call) Run(run func(ctx context.Context, id string)) *MockIrrigationExperimentsUseCase_End_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationExperimentsUseCase_End_Call) Return(irrigationExperiment *entities.IrrigationExperiment, err error) *MockIrrigationExperimentsUseCase_End_Call {
+	_c.Call.Return(irrigationExperiment, err)
+	return _c
+}
+
+func (_c *MockIrrigationExperimentsUseCase_End_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.IrrigationExperiment, error)) *MockIrrigationExperimentsUseCase_End_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockIrrigationExperimentsUseCase
+func (_mock *MockIrrigationExperimentsUseCase) Get(ctx context.Context, id string) (*entities.IrrigationExperiment, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entities.IrrigationExperiment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.IrrigationExperiment, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.IrrigationExperiment); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IrrigationExperiment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationExperimentsUseCase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockIrrigationExperimentsUseCase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockIrrigationExperimentsUseCase_Expecter) Get(ctx interface{}, id interface{}) *MockIrrigationExperimentsUseCase_Get_Call {
+	return &MockIrrigationExperimentsUseCase_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockIrrigationExperimentsUseCase_Get_Call) Run(run func(ctx context.Context, id string)) *MockIrrigationExperimentsUseCase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationExperimentsUseCase_Get_Call) Return(irrigationExperiment *entities.IrrigationExperiment, err error) *MockIrrigationExperimentsUseCase_Get_Call {
+	_c.Call.Return(irrigationExperiment, err)
+	return _c
+}
+
+func (_c *MockIrrigationExperimentsUseCase_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.IrrigationExperiment, error)) *MockIrrigationExperimentsUseCase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockIrrigationExperimentsUseCase
+func (_mock *MockIrrigationExperimentsUseCase) List(ctx context.Context) ([]*entities.IrrigationExperiment, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.IrrigationExperiment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.IrrigationExperiment, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.IrrigationExperiment); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.IrrigationExperiment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationExperimentsUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockIrrigationExperimentsUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIrrigationExperimentsUseCase_Expecter) List(ctx interface{}) *MockIrrigationExperimentsUseCase_List_Call {
+	return &MockIrrigationExperimentsUseCase_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockIrrigationExperimentsUseCase_List_Call) Run(run func(ctx context.Context)) *MockIrrigationExperimentsUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationExperimentsUseCase_List_Call) Return(irrigationExperiments []*entities.IrrigationExperiment, err error) *MockIrrigationExperimentsUseCase_List_Call {
+	_c.Call.Return(irrigationExperiments, err)
+	return _c
+}
+
+func (_c *MockIrrigationExperimentsUseCase_List_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.IrrigationExperiment, error)) *MockIrrigationExperimentsUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Report provides a mock function for the type MockIrrigationExperimentsUseCase
+func (_mock *MockIrrigationExperimentsUseCase) Report(ctx context.Context, id string, from time.Time, to time.Time) (*entities.IrrigationExperimentReport, error) {
+	ret := _mock.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Report")
+	}
+
+	var r0 *entities.IrrigationExperimentReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (*entities.IrrigationExperimentReport, error)); ok {
+		return returnFunc(ctx, id, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) *entities.IrrigationExperimentReport); ok {
+		r0 = returnFunc(ctx, id, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IrrigationExperimentReport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationExperimentsUseCase_Report_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Report'
+type MockIrrigationExperimentsUseCase_Report_Call struct {
+	*mock.Call
+}
+
+// Report is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockIrrigationExperimentsUseCase_Expecter) Report(ctx interface{}, id interface{}, from interface{}, to interface{}) *MockIrrigationExperimentsUseCase_Report_Call {
+	return &MockIrrigationExperimentsUseCase_Report_Call{Call: _e.mock.On("Report", ctx, id, from, to)}
+}
+
+func (_c *MockIrrigationExperimentsUseCase_Report_Call) Run(run func(ctx context.Context, id string, from time.Time, to time.Time)) *MockIrrigationExperimentsUseCase_Report_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationExperimentsUseCase_Report_Call) Return(irrigationExperimentReport *entities.IrrigationExperimentReport, err error) *MockIrrigationExperimentsUseCase_Report_Call {
+	_c.Call.Return(irrigationExperimentReport, err)
+	return _c
+}
+
+func (_c *MockIrrigationExperimentsUseCase_Report_Call) RunAndReturn(run func(ctx context.Context, id string, from time.Time, to time.Time) (*entities.IrrigationExperimentReport, error)) *MockIrrigationExperimentsUseCase_Report_Call {
+	_c.Call.Return(run)
+	return _c
+}
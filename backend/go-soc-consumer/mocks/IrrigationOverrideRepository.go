@@ -0,0 +1,215 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockIrrigationOverrideRepository creates a new instance of MockIrrigationOverrideRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIrrigationOverrideRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIrrigationOverrideRepository {
+	mock := &MockIrrigationOverrideRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIrrigationOverrideRepository is an autogenerated mock type for the IrrigationOverrideRepository type
+type MockIrrigationOverrideRepository struct {
+	mock.Mock
+}
+
+type MockIrrigationOverrideRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIrrigationOverrideRepository) EXPECT() *MockIrrigationOverrideRepository_Expecter {
+	return &MockIrrigationOverrideRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockIrrigationOverrideRepository
+func (_mock *MockIrrigationOverrideRepository) Delete(ctx context.Context, macAddress string) error {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationOverrideRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIrrigationOverrideRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockIrrigationOverrideRepository_Expecter) Delete(ctx interface{}, macAddress interface{}) *MockIrrigationOverrideRepository_Delete_Call {
+	return &MockIrrigationOverrideRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, macAddress)}
+}
+
+func (_c *MockIrrigationOverrideRepository_Delete_Call) Run(run func(ctx context.Context, macAddress string)) *MockIrrigationOverrideRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationOverrideRepository_Delete_Call) Return(err error) *MockIrrigationOverrideRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationOverrideRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, macAddress string) error) *MockIrrigationOverrideRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockIrrigationOverrideRepository
+func (_mock *MockIrrigationOverrideRepository) List(ctx context.Context) ([]*entities.IrrigationOverride, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.IrrigationOverride
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.IrrigationOverride, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.IrrigationOverride); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.IrrigationOverride)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationOverrideRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockIrrigationOverrideRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIrrigationOverrideRepository_Expecter) List(ctx interface{}) *MockIrrigationOverrideRepository_List_Call {
+	return &MockIrrigationOverrideRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockIrrigationOverrideRepository_List_Call) Run(run func(ctx context.Context)) *MockIrrigationOverrideRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationOverrideRepository_List_Call) Return(irrigationOverrides []*entities.IrrigationOverride, err error) *MockIrrigationOverrideRepository_List_Call {
+	_c.Call.Return(irrigationOverrides, err)
+	return _c
+}
+
+func (_c *MockIrrigationOverrideRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.IrrigationOverride, error)) *MockIrrigationOverrideRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockIrrigationOverrideRepository
+func (_mock *MockIrrigationOverrideRepository) Upsert(ctx context.Context, override *entities.IrrigationOverride) error {
+	ret := _mock.Called(ctx, override)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.IrrigationOverride) error); ok {
+		r0 = returnFunc(ctx, override)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationOverrideRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockIrrigationOverrideRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - override *entities.IrrigationOverride
+func (_e *MockIrrigationOverrideRepository_Expecter) Upsert(ctx interface{}, override interface{}) *MockIrrigationOverrideRepository_Upsert_Call {
+	return &MockIrrigationOverrideRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, override)}
+}
+
+func (_c *MockIrrigationOverrideRepository_Upsert_Call) Run(run func(ctx context.Context, override *entities.IrrigationOverride)) *MockIrrigationOverrideRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.IrrigationOverride
+		if args[1] != nil {
+			arg1 = args[1].(*entities.IrrigationOverride)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationOverrideRepository_Upsert_Call) Return(err error) *MockIrrigationOverrideRepository_Upsert_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationOverrideRepository_Upsert_Call) RunAndReturn(run func(ctx context.Context, override *entities.IrrigationOverride) error) *MockIrrigationOverrideRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
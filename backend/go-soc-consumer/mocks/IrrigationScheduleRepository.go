@@ -0,0 +1,416 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockIrrigationScheduleRepository creates a new instance of MockIrrigationScheduleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIrrigationScheduleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIrrigationScheduleRepository {
+	mock := &MockIrrigationScheduleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIrrigationScheduleRepository is an autogenerated mock type for the IrrigationScheduleRepository type
+type MockIrrigationScheduleRepository struct {
+	mock.Mock
+}
+
+type MockIrrigationScheduleRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIrrigationScheduleRepository) EXPECT() *MockIrrigationScheduleRepository_Expecter {
+	return &MockIrrigationScheduleRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockIrrigationScheduleRepository
+func (_mock *MockIrrigationScheduleRepository) Create(ctx context.Context, schedule *entities.IrrigationSchedule) error {
+	ret := _mock.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.IrrigationSchedule) error); ok {
+		r0 = returnFunc(ctx, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationScheduleRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockIrrigationScheduleRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - schedule *entities.IrrigationSchedule
+func (_e *MockIrrigationScheduleRepository_Expecter) Create(ctx interface{}, schedule interface{}) *MockIrrigationScheduleRepository_Create_Call {
+	return &MockIrrigationScheduleRepository_Create_Call{Call: _e.mock.On("Create", ctx, schedule)}
+}
+
+func (_c *MockIrrigationScheduleRepository_Create_Call) Run(run func(ctx context.Context, schedule *entities.IrrigationSchedule)) *MockIrrigationScheduleRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.IrrigationSchedule
+		if args[1] != nil {
+			arg1 = args[1].(*entities.IrrigationSchedule)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_Create_Call) Return(err error) *MockIrrigationScheduleRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_Create_Call) RunAndReturn(run func(ctx context.Context, schedule *entities.IrrigationSchedule) error) *MockIrrigationScheduleRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockIrrigationScheduleRepository
+func (_mock *MockIrrigationScheduleRepository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationScheduleRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIrrigationScheduleRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockIrrigationScheduleRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockIrrigationScheduleRepository_Delete_Call {
+	return &MockIrrigationScheduleRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockIrrigationScheduleRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockIrrigationScheduleRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_Delete_Call) Return(err error) *MockIrrigationScheduleRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockIrrigationScheduleRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockIrrigationScheduleRepository
+func (_mock *MockIrrigationScheduleRepository) FindByID(ctx context.Context, id string) (*entities.IrrigationSchedule, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.IrrigationSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.IrrigationSchedule, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.IrrigationSchedule); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IrrigationSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationScheduleRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockIrrigationScheduleRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockIrrigationScheduleRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockIrrigationScheduleRepository_FindByID_Call {
+	return &MockIrrigationScheduleRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockIrrigationScheduleRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockIrrigationScheduleRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_FindByID_Call) Return(irrigationSchedule *entities.IrrigationSchedule, err error) *MockIrrigationScheduleRepository_FindByID_Call {
+	_c.Call.Return(irrigationSchedule, err)
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.IrrigationSchedule, error)) *MockIrrigationScheduleRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockIrrigationScheduleRepository
+func (_mock *MockIrrigationScheduleRepository) List(ctx context.Context, macAddress string) ([]*entities.IrrigationSchedule, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.IrrigationSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entities.IrrigationSchedule, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entities.IrrigationSchedule); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.IrrigationSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationScheduleRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockIrrigationScheduleRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockIrrigationScheduleRepository_Expecter) List(ctx interface{}, macAddress interface{}) *MockIrrigationScheduleRepository_List_Call {
+	return &MockIrrigationScheduleRepository_List_Call{Call: _e.mock.On("List", ctx, macAddress)}
+}
+
+func (_c *MockIrrigationScheduleRepository_List_Call) Run(run func(ctx context.Context, macAddress string)) *MockIrrigationScheduleRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_List_Call) Return(irrigationSchedules []*entities.IrrigationSchedule, err error) *MockIrrigationScheduleRepository_List_Call {
+	_c.Call.Return(irrigationSchedules, err)
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_List_Call) RunAndReturn(run func(ctx context.Context, macAddress string) ([]*entities.IrrigationSchedule, error)) *MockIrrigationScheduleRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordRun provides a mock function for the type MockIrrigationScheduleRepository
+func (_mock *MockIrrigationScheduleRepository) RecordRun(ctx context.Context, id string, lastRunAt *time.Time, runEndsAt *time.Time) error {
+	ret := _mock.Called(ctx, id, lastRunAt, runEndsAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordRun")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *time.Time, *time.Time) error); ok {
+		r0 = returnFunc(ctx, id, lastRunAt, runEndsAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationScheduleRepository_RecordRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordRun'
+type MockIrrigationScheduleRepository_RecordRun_Call struct {
+	*mock.Call
+}
+
+// RecordRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - lastRunAt *time.Time
+//   - runEndsAt *time.Time
+func (_e *MockIrrigationScheduleRepository_Expecter) RecordRun(ctx interface{}, id interface{}, lastRunAt interface{}, runEndsAt interface{}) *MockIrrigationScheduleRepository_RecordRun_Call {
+	return &MockIrrigationScheduleRepository_RecordRun_Call{Call: _e.mock.On("RecordRun", ctx, id, lastRunAt, runEndsAt)}
+}
+
+func (_c *MockIrrigationScheduleRepository_RecordRun_Call) Run(run func(ctx context.Context, id string, lastRunAt *time.Time, runEndsAt *time.Time)) *MockIrrigationScheduleRepository_RecordRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *time.Time
+		if args[2] != nil {
+			arg2 = args[2].(*time.Time)
+		}
+		var arg3 *time.Time
+		if args[3] != nil {
+			arg3 = args[3].(*time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_RecordRun_Call) Return(err error) *MockIrrigationScheduleRepository_RecordRun_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_RecordRun_Call) RunAndReturn(run func(ctx context.Context, id string, lastRunAt *time.Time, runEndsAt *time.Time) error) *MockIrrigationScheduleRepository_RecordRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockIrrigationScheduleRepository
+func (_mock *MockIrrigationScheduleRepository) Update(ctx context.Context, schedule *entities.IrrigationSchedule) error {
+	ret := _mock.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.IrrigationSchedule) error); ok {
+		r0 = returnFunc(ctx, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationScheduleRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockIrrigationScheduleRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - schedule *entities.IrrigationSchedule
+func (_e *MockIrrigationScheduleRepository_Expecter) Update(ctx interface{}, schedule interface{}) *MockIrrigationScheduleRepository_Update_Call {
+	return &MockIrrigationScheduleRepository_Update_Call{Call: _e.mock.On("Update", ctx, schedule)}
+}
+
+func (_c *MockIrrigationScheduleRepository_Update_Call) Run(run func(ctx context.Context, schedule *entities.IrrigationSchedule)) *MockIrrigationScheduleRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.IrrigationSchedule
+		if args[1] != nil {
+			arg1 = args[1].(*entities.IrrigationSchedule)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_Update_Call) Return(err error) *MockIrrigationScheduleRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationScheduleRepository_Update_Call) RunAndReturn(run func(ctx context.Context, schedule *entities.IrrigationSchedule) error) *MockIrrigationScheduleRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
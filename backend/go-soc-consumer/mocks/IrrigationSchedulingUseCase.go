@@ -0,0 +1,649 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockIrrigationSchedulingUseCase creates a new instance of MockIrrigationSchedulingUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIrrigationSchedulingUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIrrigationSchedulingUseCase {
+	mock := &MockIrrigationSchedulingUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIrrigationSchedulingUseCase is an autogenerated mock type for the IrrigationSchedulingUseCase type
+type MockIrrigationSchedulingUseCase struct {
+	mock.Mock
+}
+
+type MockIrrigationSchedulingUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIrrigationSchedulingUseCase) EXPECT() *MockIrrigationSchedulingUseCase_Expecter {
+	return &MockIrrigationSchedulingUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ActiveOverrides provides a mock function for the type MockIrrigationSchedulingUseCase
+func (_mock *MockIrrigationSchedulingUseCase) ActiveOverrides() []*entities.OperatorOverride {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActiveOverrides")
+	}
+
+	var r0 []*entities.OperatorOverride
+	if returnFunc, ok := ret.Get(0).(func() []*entities.OperatorOverride); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.OperatorOverride)
+		}
+	}
+	return r0
+}
+
+// MockIrrigationSchedulingUseCase_ActiveOverrides_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveOverrides'
+type MockIrrigationSchedulingUseCase_ActiveOverrides_Call struct {
+	*mock.Call
+}
+
+// ActiveOverrides is a helper method to define mock.On call
+func (_e *MockIrrigationSchedulingUseCase_Expecter) ActiveOverrides() *MockIrrigationSchedulingUseCase_ActiveOverrides_Call {
+	return &MockIrrigationSchedulingUseCase_ActiveOverrides_Call{Call: _e.mock.On("ActiveOverrides")}
+}
+
+func (_c *MockIrrigationSchedulingUseCase_ActiveOverrides_Call) Run(run func()) *MockIrrigationSchedulingUseCase_ActiveOverrides_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_ActiveOverrides_Call) Return(operatorOverrides []*entities.OperatorOverride) *MockIrrigationSchedulingUseCase_ActiveOverrides_Call {
+	_c.Call.Return(operatorOverrides)
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_ActiveOverrides_Call) RunAndReturn(run func() []*entities.OperatorOverride) *MockIrrigationSchedulingUseCase_ActiveOverrides_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClearOverride provides a mock function for the type MockIrrigationSchedulingUseCase
+func (_mock *MockIrrigationSchedulingUseCase) ClearOverride(ctx context.Context, macAddress string) error {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearOverride")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationSchedulingUseCase_ClearOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearOverride'
+type MockIrrigationSchedulingUseCase_ClearOverride_Call struct {
+	*mock.Call
+}
+
+// ClearOverride is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockIrrigationSchedulingUseCase_Expecter) ClearOverride(ctx interface{}, macAddress interface{}) *MockIrrigationSchedulingUseCase_ClearOverride_Call {
+	return &MockIrrigationSchedulingUseCase_ClearOverride_Call{Call: _e.mock.On("ClearOverride", ctx, macAddress)}
+}
+
+func (_c *MockIrrigationSchedulingUseCase_ClearOverride_Call) Run(run func(ctx context.Context, macAddress string)) *MockIrrigationSchedulingUseCase_ClearOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_ClearOverride_Call) Return(err error) *MockIrrigationSchedulingUseCase_ClearOverride_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_ClearOverride_Call) RunAndReturn(run func(ctx context.Context, macAddress string) error) *MockIrrigationSchedulingUseCase_ClearOverride_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockIrrigationSchedulingUseCase
+func (_mock *MockIrrigationSchedulingUseCase) Create(ctx context.Context, macAddress string, request entities.IrrigationScheduleRequest) (*entities.IrrigationSchedule, error) {
+	ret := _mock.Called(ctx, macAddress, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entities.IrrigationSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.IrrigationScheduleRequest) (*entities.IrrigationSchedule, error)); ok {
+		return returnFunc(ctx, macAddress, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.IrrigationScheduleRequest) *entities.IrrigationSchedule); ok {
+		r0 = returnFunc(ctx, macAddress, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IrrigationSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, entities.IrrigationScheduleRequest) error); ok {
+		r1 = returnFunc(ctx, macAddress, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationSchedulingUseCase_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockIrrigationSchedulingUseCase_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - request entities.IrrigationScheduleRequest
+func (_e *MockIrrigationSchedulingUseCase_Expecter) Create(ctx interface{}, macAddress interface{}, request interface{}) *MockIrrigationSchedulingUseCase_Create_Call {
+	return &MockIrrigationSchedulingUseCase_Create_Call{Call: _e.mock.On("Create", ctx, macAddress, request)}
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Create_Call) Run(run func(ctx context.Context, macAddress string, request entities.IrrigationScheduleRequest)) *MockIrrigationSchedulingUseCase_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 entities.IrrigationScheduleRequest
+		if args[2] != nil {
+			arg2 = args[2].(entities.IrrigationScheduleRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Create_Call) Return(irrigationSchedule *entities.IrrigationSchedule, err error) *MockIrrigationSchedulingUseCase_Create_Call {
+	_c.Call.Return(irrigationSchedule, err)
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Create_Call) RunAndReturn(run func(ctx context.Context, macAddress string, request entities.IrrigationScheduleRequest) (*entities.IrrigationSchedule, error)) *MockIrrigationSchedulingUseCase_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockIrrigationSchedulingUseCase
+func (_mock *MockIrrigationSchedulingUseCase) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationSchedulingUseCase_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIrrigationSchedulingUseCase_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockIrrigationSchedulingUseCase_Expecter) Delete(ctx interface{}, id interface{}) *MockIrrigationSchedulingUseCase_Delete_Call {
+	return &MockIrrigationSchedulingUseCase_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Delete_Call) Run(run func(ctx context.Context, id string)) *MockIrrigationSchedulingUseCase_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Delete_Call) Return(err error) *MockIrrigationSchedulingUseCase_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockIrrigationSchedulingUseCase_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Evaluate provides a mock function for the type MockIrrigationSchedulingUseCase
+func (_mock *MockIrrigationSchedulingUseCase) Evaluate(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Evaluate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIrrigationSchedulingUseCase_Evaluate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Evaluate'
+type MockIrrigationSchedulingUseCase_Evaluate_Call struct {
+	*mock.Call
+}
+
+// Evaluate is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIrrigationSchedulingUseCase_Expecter) Evaluate(ctx interface{}) *MockIrrigationSchedulingUseCase_Evaluate_Call {
+	return &MockIrrigationSchedulingUseCase_Evaluate_Call{Call: _e.mock.On("Evaluate", ctx)}
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Evaluate_Call) Run(run func(ctx context.Context)) *MockIrrigationSchedulingUseCase_Evaluate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Evaluate_Call) Return(err error) *MockIrrigationSchedulingUseCase_Evaluate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Evaluate_Call) RunAndReturn(run func(ctx context.Context) error) *MockIrrigationSchedulingUseCase_Evaluate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockIrrigationSchedulingUseCase
+func (_mock *MockIrrigationSchedulingUseCase) List(ctx context.Context, macAddress string) ([]*entities.IrrigationSchedule, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.IrrigationSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entities.IrrigationSchedule, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entities.IrrigationSchedule); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.IrrigationSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationSchedulingUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockIrrigationSchedulingUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockIrrigationSchedulingUseCase_Expecter) List(ctx interface{}, macAddress interface{}) *MockIrrigationSchedulingUseCase_List_Call {
+	return &MockIrrigationSchedulingUseCase_List_Call{Call: _e.mock.On("List", ctx, macAddress)}
+}
+
+func (_c *MockIrrigationSchedulingUseCase_List_Call) Run(run func(ctx context.Context, macAddress string)) *MockIrrigationSchedulingUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_List_Call) Return(irrigationSchedules []*entities.IrrigationSchedule, err error) *MockIrrigationSchedulingUseCase_List_Call {
+	_c.Call.Return(irrigationSchedules, err)
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_List_Call) RunAndReturn(run func(ctx context.Context, macAddress string) ([]*entities.IrrigationSchedule, error)) *MockIrrigationSchedulingUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Overrides provides a mock function for the type MockIrrigationSchedulingUseCase
+func (_mock *MockIrrigationSchedulingUseCase) Overrides(ctx context.Context) ([]*entities.IrrigationOverride, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Overrides")
+	}
+
+	var r0 []*entities.IrrigationOverride
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.IrrigationOverride, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.IrrigationOverride); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.IrrigationOverride)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationSchedulingUseCase_Overrides_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Overrides'
+type MockIrrigationSchedulingUseCase_Overrides_Call struct {
+	*mock.Call
+}
+
+// Overrides is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIrrigationSchedulingUseCase_Expecter) Overrides(ctx interface{}) *MockIrrigationSchedulingUseCase_Overrides_Call {
+	return &MockIrrigationSchedulingUseCase_Overrides_Call{Call: _e.mock.On("Overrides", ctx)}
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Overrides_Call) Run(run func(ctx context.Context)) *MockIrrigationSchedulingUseCase_Overrides_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Overrides_Call) Return(irrigationOverrides []*entities.IrrigationOverride, err error) *MockIrrigationSchedulingUseCase_Overrides_Call {
+	_c.Call.Return(irrigationOverrides, err)
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Overrides_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.IrrigationOverride, error)) *MockIrrigationSchedulingUseCase_Overrides_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockIrrigationSchedulingUseCase
+func (_mock *MockIrrigationSchedulingUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockIrrigationSchedulingUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockIrrigationSchedulingUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIrrigationSchedulingUseCase_Expecter) Run(ctx interface{}) *MockIrrigationSchedulingUseCase_Run_Call {
+	return &MockIrrigationSchedulingUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Run_Call) Run(run func(ctx context.Context)) *MockIrrigationSchedulingUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Run_Call) Return() *MockIrrigationSchedulingUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockIrrigationSchedulingUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetOverride provides a mock function for the type MockIrrigationSchedulingUseCase
+func (_mock *MockIrrigationSchedulingUseCase) SetOverride(ctx context.Context, macAddress string, reason string, until time.Time) (*entities.IrrigationOverride, error) {
+	ret := _mock.Called(ctx, macAddress, reason, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOverride")
+	}
+
+	var r0 *entities.IrrigationOverride
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*entities.IrrigationOverride, error)); ok {
+		return returnFunc(ctx, macAddress, reason, until)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *entities.IrrigationOverride); ok {
+		r0 = returnFunc(ctx, macAddress, reason, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IrrigationOverride)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, macAddress, reason, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationSchedulingUseCase_SetOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOverride'
+type MockIrrigationSchedulingUseCase_SetOverride_Call struct {
+	*mock.Call
+}
+
+// SetOverride is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - reason string
+//   - until time.Time
+func (_e *MockIrrigationSchedulingUseCase_Expecter) SetOverride(ctx interface{}, macAddress interface{}, reason interface{}, until interface{}) *MockIrrigationSchedulingUseCase_SetOverride_Call {
+	return &MockIrrigationSchedulingUseCase_SetOverride_Call{Call: _e.mock.On("SetOverride", ctx, macAddress, reason, until)}
+}
+
+func (_c *MockIrrigationSchedulingUseCase_SetOverride_Call) Run(run func(ctx context.Context, macAddress string, reason string, until time.Time)) *MockIrrigationSchedulingUseCase_SetOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_SetOverride_Call) Return(irrigationOverride *entities.IrrigationOverride, err error) *MockIrrigationSchedulingUseCase_SetOverride_Call {
+	_c.Call.Return(irrigationOverride, err)
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_SetOverride_Call) RunAndReturn(run func(ctx context.Context, macAddress string, reason string, until time.Time) (*entities.IrrigationOverride, error)) *MockIrrigationSchedulingUseCase_SetOverride_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockIrrigationSchedulingUseCase
+func (_mock *MockIrrigationSchedulingUseCase) Update(ctx context.Context, id string, request entities.IrrigationScheduleRequest) (*entities.IrrigationSchedule, error) {
+	ret := _mock.Called(ctx, id, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *entities.IrrigationSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.IrrigationScheduleRequest) (*entities.IrrigationSchedule, error)); ok {
+		return returnFunc(ctx, id, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.IrrigationScheduleRequest) *entities.IrrigationSchedule); ok {
+		r0 = returnFunc(ctx, id, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.IrrigationSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, entities.IrrigationScheduleRequest) error); ok {
+		r1 = returnFunc(ctx, id, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIrrigationSchedulingUseCase_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockIrrigationSchedulingUseCase_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - request entities.IrrigationScheduleRequest
+func (_e *MockIrrigationSchedulingUseCase_Expecter) Update(ctx interface{}, id interface{}, request interface{}) *MockIrrigationSchedulingUseCase_Update_Call {
+	return &MockIrrigationSchedulingUseCase_Update_Call{Call: _e.mock.On("Update", ctx, id, request)}
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Update_Call) Run(run func(ctx context.Context, id string, request entities.IrrigationScheduleRequest)) *MockIrrigationSchedulingUseCase_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 entities.IrrigationScheduleRequest
+		if args[2] != nil {
+			arg2 = args[2].(entities.IrrigationScheduleRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Update_Call) Return(irrigationSchedule *entities.IrrigationSchedule, err error) *MockIrrigationSchedulingUseCase_Update_Call {
+	_c.Call.Return(irrigationSchedule, err)
+	return _c
+}
+
+func (_c *MockIrrigationSchedulingUseCase_Update_Call) RunAndReturn(run func(ctx context.Context, id string, request entities.IrrigationScheduleRequest) (*entities.IrrigationSchedule, error)) *MockIrrigationSchedulingUseCase_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,336 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockJobQueueUseCase creates a new instance of MockJobQueueUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJobQueueUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJobQueueUseCase {
+	mock := &MockJobQueueUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockJobQueueUseCase is an autogenerated mock type for the JobQueueUseCase type
+type MockJobQueueUseCase struct {
+	mock.Mock
+}
+
+type MockJobQueueUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJobQueueUseCase) EXPECT() *MockJobQueueUseCase_Expecter {
+	return &MockJobQueueUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Cancel provides a mock function for the type MockJobQueueUseCase
+func (_mock *MockJobQueueUseCase) Cancel(ctx context.Context, id string) (*entities.Job, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *entities.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.Job, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.Job); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobQueueUseCase_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockJobQueueUseCase_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockJobQueueUseCase_Expecter) Cancel(ctx interface{}, id interface{}) *MockJobQueueUseCase_Cancel_Call {
+	return &MockJobQueueUseCase_Cancel_Call{Call: _e.mock.On("Cancel", ctx, id)}
+}
+
+func (_c *MockJobQueueUseCase_Cancel_Call) Run(run func(ctx context.Context, id string)) *MockJobQueueUseCase_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobQueueUseCase_Cancel_Call) Return(job *entities.Job, err error) *MockJobQueueUseCase_Cancel_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockJobQueueUseCase_Cancel_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.Job, error)) *MockJobQueueUseCase_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enqueue provides a mock function for the type MockJobQueueUseCase
+func (_mock *MockJobQueueUseCase) Enqueue(ctx context.Context, jobType string, payload interface{}) (*entities.Job, error) {
+	ret := _mock.Called(ctx, jobType, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 *entities.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, interface{}) (*entities.Job, error)); ok {
+		return returnFunc(ctx, jobType, payload)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, interface{}) *entities.Job); ok {
+		r0 = returnFunc(ctx, jobType, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = returnFunc(ctx, jobType, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobQueueUseCase_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type MockJobQueueUseCase_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobType string
+//   - payload interface{}
+func (_e *MockJobQueueUseCase_Expecter) Enqueue(ctx interface{}, jobType interface{}, payload interface{}) *MockJobQueueUseCase_Enqueue_Call {
+	return &MockJobQueueUseCase_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, jobType, payload)}
+}
+
+func (_c *MockJobQueueUseCase_Enqueue_Call) Run(run func(ctx context.Context, jobType string, payload interface{})) *MockJobQueueUseCase_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 interface{}
+		if args[2] != nil {
+			arg2 = args[2].(interface{})
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobQueueUseCase_Enqueue_Call) Return(job *entities.Job, err error) *MockJobQueueUseCase_Enqueue_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockJobQueueUseCase_Enqueue_Call) RunAndReturn(run func(ctx context.Context, jobType string, payload interface{}) (*entities.Job, error)) *MockJobQueueUseCase_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockJobQueueUseCase
+func (_mock *MockJobQueueUseCase) Get(ctx context.Context, id string) (*entities.Job, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *entities.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.Job, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.Job); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobQueueUseCase_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockJobQueueUseCase_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockJobQueueUseCase_Expecter) Get(ctx interface{}, id interface{}) *MockJobQueueUseCase_Get_Call {
+	return &MockJobQueueUseCase_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockJobQueueUseCase_Get_Call) Run(run func(ctx context.Context, id string)) *MockJobQueueUseCase_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobQueueUseCase_Get_Call) Return(job *entities.Job, err error) *MockJobQueueUseCase_Get_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockJobQueueUseCase_Get_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.Job, error)) *MockJobQueueUseCase_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function for the type MockJobQueueUseCase
+func (_mock *MockJobQueueUseCase) Register(jobType string, handler ports.JobHandler) {
+	_mock.Called(jobType, handler)
+	return
+}
+
+// MockJobQueueUseCase_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockJobQueueUseCase_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - jobType string
+//   - handler ports.JobHandler
+func (_e *MockJobQueueUseCase_Expecter) Register(jobType interface{}, handler interface{}) *MockJobQueueUseCase_Register_Call {
+	return &MockJobQueueUseCase_Register_Call{Call: _e.mock.On("Register", jobType, handler)}
+}
+
+func (_c *MockJobQueueUseCase_Register_Call) Run(run func(jobType string, handler ports.JobHandler)) *MockJobQueueUseCase_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 ports.JobHandler
+		if args[1] != nil {
+			arg1 = args[1].(ports.JobHandler)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobQueueUseCase_Register_Call) Return() *MockJobQueueUseCase_Register_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockJobQueueUseCase_Register_Call) RunAndReturn(run func(jobType string, handler ports.JobHandler)) *MockJobQueueUseCase_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockJobQueueUseCase
+func (_mock *MockJobQueueUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockJobQueueUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockJobQueueUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockJobQueueUseCase_Expecter) Run(ctx interface{}) *MockJobQueueUseCase_Run_Call {
+	return &MockJobQueueUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockJobQueueUseCase_Run_Call) Run(run func(ctx context.Context)) *MockJobQueueUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobQueueUseCase_Run_Call) Return() *MockJobQueueUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockJobQueueUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockJobQueueUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
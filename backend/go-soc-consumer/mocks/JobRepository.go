@@ -0,0 +1,573 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockJobRepository creates a new instance of MockJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJobRepository {
+	mock := &MockJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockJobRepository is an autogenerated mock type for the JobRepository type
+type MockJobRepository struct {
+	mock.Mock
+}
+
+type MockJobRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJobRepository) EXPECT() *MockJobRepository_Expecter {
+	return &MockJobRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimNext provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) ClaimNext(ctx context.Context, types []string, leaseUntil time.Time) (*entities.Job, error) {
+	ret := _mock.Called(ctx, types, leaseUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimNext")
+	}
+
+	var r0 *entities.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Time) (*entities.Job, error)); ok {
+		return returnFunc(ctx, types, leaseUntil)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Time) *entities.Job); ok {
+		r0 = returnFunc(ctx, types, leaseUntil)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, time.Time) error); ok {
+		r1 = returnFunc(ctx, types, leaseUntil)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_ClaimNext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimNext'
+type MockJobRepository_ClaimNext_Call struct {
+	*mock.Call
+}
+
+// ClaimNext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - types []string
+//   - leaseUntil time.Time
+func (_e *MockJobRepository_Expecter) ClaimNext(ctx interface{}, types interface{}, leaseUntil interface{}) *MockJobRepository_ClaimNext_Call {
+	return &MockJobRepository_ClaimNext_Call{Call: _e.mock.On("ClaimNext", ctx, types, leaseUntil)}
+}
+
+func (_c *MockJobRepository_ClaimNext_Call) Run(run func(ctx context.Context, types []string, leaseUntil time.Time)) *MockJobRepository_ClaimNext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_ClaimNext_Call) Return(job *entities.Job, err error) *MockJobRepository_ClaimNext_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockJobRepository_ClaimNext_Call) RunAndReturn(run func(ctx context.Context, types []string, leaseUntil time.Time) (*entities.Job, error)) *MockJobRepository_ClaimNext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) Create(ctx context.Context, job *entities.Job) error {
+	ret := _mock.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.Job) error); ok {
+		r0 = returnFunc(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockJobRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *entities.Job
+func (_e *MockJobRepository_Expecter) Create(ctx interface{}, job interface{}) *MockJobRepository_Create_Call {
+	return &MockJobRepository_Create_Call{Call: _e.mock.On("Create", ctx, job)}
+}
+
+func (_c *MockJobRepository_Create_Call) Run(run func(ctx context.Context, job *entities.Job)) *MockJobRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.Job
+		if args[1] != nil {
+			arg1 = args[1].(*entities.Job)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_Create_Call) Return(err error) *MockJobRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepository_Create_Call) RunAndReturn(run func(ctx context.Context, job *entities.Job) error) *MockJobRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) FindByID(ctx context.Context, id string) (*entities.Job, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.Job, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.Job); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockJobRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockJobRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockJobRepository_FindByID_Call {
+	return &MockJobRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockJobRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockJobRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_FindByID_Call) Return(job *entities.Job, err error) *MockJobRepository_FindByID_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockJobRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.Job, error)) *MockJobRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Finish provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) Finish(ctx context.Context, job *entities.Job) error {
+	ret := _mock.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Finish")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.Job) error); ok {
+		r0 = returnFunc(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepository_Finish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Finish'
+type MockJobRepository_Finish_Call struct {
+	*mock.Call
+}
+
+// Finish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *entities.Job
+func (_e *MockJobRepository_Expecter) Finish(ctx interface{}, job interface{}) *MockJobRepository_Finish_Call {
+	return &MockJobRepository_Finish_Call{Call: _e.mock.On("Finish", ctx, job)}
+}
+
+func (_c *MockJobRepository_Finish_Call) Run(run func(ctx context.Context, job *entities.Job)) *MockJobRepository_Finish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.Job
+		if args[1] != nil {
+			arg1 = args[1].(*entities.Job)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_Finish_Call) Return(err error) *MockJobRepository_Finish_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepository_Finish_Call) RunAndReturn(run func(ctx context.Context, job *entities.Job) error) *MockJobRepository_Finish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Heartbeat provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) Heartbeat(ctx context.Context, id string, leaseUntil time.Time) (bool, error) {
+	ret := _mock.Called(ctx, id, leaseUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Heartbeat")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, id, leaseUntil)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = returnFunc(ctx, id, leaseUntil)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, leaseUntil)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_Heartbeat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Heartbeat'
+type MockJobRepository_Heartbeat_Call struct {
+	*mock.Call
+}
+
+// Heartbeat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - leaseUntil time.Time
+func (_e *MockJobRepository_Expecter) Heartbeat(ctx interface{}, id interface{}, leaseUntil interface{}) *MockJobRepository_Heartbeat_Call {
+	return &MockJobRepository_Heartbeat_Call{Call: _e.mock.On("Heartbeat", ctx, id, leaseUntil)}
+}
+
+func (_c *MockJobRepository_Heartbeat_Call) Run(run func(ctx context.Context, id string, leaseUntil time.Time)) *MockJobRepository_Heartbeat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_Heartbeat_Call) Return(b bool, err error) *MockJobRepository_Heartbeat_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockJobRepository_Heartbeat_Call) RunAndReturn(run func(ctx context.Context, id string, leaseUntil time.Time) (bool, error)) *MockJobRepository_Heartbeat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnfinished provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) ListUnfinished(ctx context.Context, limit int) ([]*entities.Job, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnfinished")
+	}
+
+	var r0 []*entities.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]*entities.Job, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []*entities.Job); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_ListUnfinished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnfinished'
+type MockJobRepository_ListUnfinished_Call struct {
+	*mock.Call
+}
+
+// ListUnfinished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockJobRepository_Expecter) ListUnfinished(ctx interface{}, limit interface{}) *MockJobRepository_ListUnfinished_Call {
+	return &MockJobRepository_ListUnfinished_Call{Call: _e.mock.On("ListUnfinished", ctx, limit)}
+}
+
+func (_c *MockJobRepository_ListUnfinished_Call) Run(run func(ctx context.Context, limit int)) *MockJobRepository_ListUnfinished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_ListUnfinished_Call) Return(jobs []*entities.Job, err error) *MockJobRepository_ListUnfinished_Call {
+	_c.Call.Return(jobs, err)
+	return _c
+}
+
+func (_c *MockJobRepository_ListUnfinished_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]*entities.Job, error)) *MockJobRepository_ListUnfinished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestCancel provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) RequestCancel(ctx context.Context, id string) (*entities.Job, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestCancel")
+	}
+
+	var r0 *entities.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.Job, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.Job); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_RequestCancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestCancel'
+type MockJobRepository_RequestCancel_Call struct {
+	*mock.Call
+}
+
+// RequestCancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockJobRepository_Expecter) RequestCancel(ctx interface{}, id interface{}) *MockJobRepository_RequestCancel_Call {
+	return &MockJobRepository_RequestCancel_Call{Call: _e.mock.On("RequestCancel", ctx, id)}
+}
+
+func (_c *MockJobRepository_RequestCancel_Call) Run(run func(ctx context.Context, id string)) *MockJobRepository_RequestCancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_RequestCancel_Call) Return(job *entities.Job, err error) *MockJobRepository_RequestCancel_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockJobRepository_RequestCancel_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.Job, error)) *MockJobRepository_RequestCancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProgress provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) UpdateProgress(ctx context.Context, id string, progress int, message string) error {
+	ret := _mock.Called(ctx, id, progress, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProgress")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string) error); ok {
+		r0 = returnFunc(ctx, id, progress, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepository_UpdateProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProgress'
+type MockJobRepository_UpdateProgress_Call struct {
+	*mock.Call
+}
+
+// UpdateProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - progress int
+//   - message string
+func (_e *MockJobRepository_Expecter) UpdateProgress(ctx interface{}, id interface{}, progress interface{}, message interface{}) *MockJobRepository_UpdateProgress_Call {
+	return &MockJobRepository_UpdateProgress_Call{Call: _e.mock.On("UpdateProgress", ctx, id, progress, message)}
+}
+
+func (_c *MockJobRepository_UpdateProgress_Call) Run(run func(ctx context.Context, id string, progress int, message string)) *MockJobRepository_UpdateProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_UpdateProgress_Call) Return(err error) *MockJobRepository_UpdateProgress_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepository_UpdateProgress_Call) RunAndReturn(run func(ctx context.Context, id string, progress int, message string) error) *MockJobRepository_UpdateProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
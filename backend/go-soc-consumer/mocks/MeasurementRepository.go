@@ -0,0 +1,543 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockMeasurementRepository creates a new instance of MockMeasurementRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMeasurementRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMeasurementRepository {
+	mock := &MockMeasurementRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMeasurementRepository is an autogenerated mock type for the MeasurementRepository type
+type MockMeasurementRepository struct {
+	mock.Mock
+}
+
+type MockMeasurementRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMeasurementRepository) EXPECT() *MockMeasurementRepository_Expecter {
+	return &MockMeasurementRepository_Expecter{mock: &_m.Mock}
+}
+
+// Averages provides a mock function for the type MockMeasurementRepository
+func (_mock *MockMeasurementRepository) Averages(ctx context.Context, macAddress string, sensorType string, resolution string, from time.Time, to time.Time) ([]*entities.MeasurementAverage, error) {
+	ret := _mock.Called(ctx, macAddress, sensorType, resolution, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Averages")
+	}
+
+	var r0 []*entities.MeasurementAverage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time, time.Time) ([]*entities.MeasurementAverage, error)); ok {
+		return returnFunc(ctx, macAddress, sensorType, resolution, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time, time.Time) []*entities.MeasurementAverage); ok {
+		r0 = returnFunc(ctx, macAddress, sensorType, resolution, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.MeasurementAverage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, macAddress, sensorType, resolution, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMeasurementRepository_Averages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Averages'
+type MockMeasurementRepository_Averages_Call struct {
+	*mock.Call
+}
+
+// Averages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - sensorType string
+//   - resolution string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockMeasurementRepository_Expecter) Averages(ctx interface{}, macAddress interface{}, sensorType interface{}, resolution interface{}, from interface{}, to interface{}) *MockMeasurementRepository_Averages_Call {
+	return &MockMeasurementRepository_Averages_Call{Call: _e.mock.On("Averages", ctx, macAddress, sensorType, resolution, from, to)}
+}
+
+func (_c *MockMeasurementRepository_Averages_Call) Run(run func(ctx context.Context, macAddress string, sensorType string, resolution string, from time.Time, to time.Time)) *MockMeasurementRepository_Averages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		var arg5 time.Time
+		if args[5] != nil {
+			arg5 = args[5].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementRepository_Averages_Call) Return(measurementAverages []*entities.MeasurementAverage, err error) *MockMeasurementRepository_Averages_Call {
+	_c.Call.Return(measurementAverages, err)
+	return _c
+}
+
+func (_c *MockMeasurementRepository_Averages_Call) RunAndReturn(run func(ctx context.Context, macAddress string, sensorType string, resolution string, from time.Time, to time.Time) ([]*entities.MeasurementAverage, error)) *MockMeasurementRepository_Averages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockMeasurementRepository
+func (_mock *MockMeasurementRepository) Create(ctx context.Context, measurements []*entities.Measurement) error {
+	ret := _mock.Called(ctx, measurements)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.Measurement) error); ok {
+		r0 = returnFunc(ctx, measurements)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMeasurementRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockMeasurementRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - measurements []*entities.Measurement
+func (_e *MockMeasurementRepository_Expecter) Create(ctx interface{}, measurements interface{}) *MockMeasurementRepository_Create_Call {
+	return &MockMeasurementRepository_Create_Call{Call: _e.mock.On("Create", ctx, measurements)}
+}
+
+func (_c *MockMeasurementRepository_Create_Call) Run(run func(ctx context.Context, measurements []*entities.Measurement)) *MockMeasurementRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.Measurement
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.Measurement)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementRepository_Create_Call) Return(err error) *MockMeasurementRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMeasurementRepository_Create_Call) RunAndReturn(run func(ctx context.Context, measurements []*entities.Measurement) error) *MockMeasurementRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureTypeViews provides a mock function for the type MockMeasurementRepository
+func (_mock *MockMeasurementRepository) EnsureTypeViews(ctx context.Context, sensorTypes []*entities.SensorType) error {
+	ret := _mock.Called(ctx, sensorTypes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureTypeViews")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.SensorType) error); ok {
+		r0 = returnFunc(ctx, sensorTypes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMeasurementRepository_EnsureTypeViews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureTypeViews'
+type MockMeasurementRepository_EnsureTypeViews_Call struct {
+	*mock.Call
+}
+
+// EnsureTypeViews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sensorTypes []*entities.SensorType
+func (_e *MockMeasurementRepository_Expecter) EnsureTypeViews(ctx interface{}, sensorTypes interface{}) *MockMeasurementRepository_EnsureTypeViews_Call {
+	return &MockMeasurementRepository_EnsureTypeViews_Call{Call: _e.mock.On("EnsureTypeViews", ctx, sensorTypes)}
+}
+
+func (_c *MockMeasurementRepository_EnsureTypeViews_Call) Run(run func(ctx context.Context, sensorTypes []*entities.SensorType)) *MockMeasurementRepository_EnsureTypeViews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.SensorType
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.SensorType)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementRepository_EnsureTypeViews_Call) Return(err error) *MockMeasurementRepository_EnsureTypeViews_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMeasurementRepository_EnsureTypeViews_Call) RunAndReturn(run func(ctx context.Context, sensorTypes []*entities.SensorType) error) *MockMeasurementRepository_EnsureTypeViews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function for the type MockMeasurementRepository
+func (_mock *MockMeasurementRepository) History(ctx context.Context, macAddress string, sensorType string, from time.Time, to time.Time) ([]*entities.Measurement, error) {
+	ret := _mock.Called(ctx, macAddress, sensorType, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []*entities.Measurement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) ([]*entities.Measurement, error)); ok {
+		return returnFunc(ctx, macAddress, sensorType, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) []*entities.Measurement); ok {
+		r0 = returnFunc(ctx, macAddress, sensorType, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Measurement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, macAddress, sensorType, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMeasurementRepository_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type MockMeasurementRepository_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - sensorType string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockMeasurementRepository_Expecter) History(ctx interface{}, macAddress interface{}, sensorType interface{}, from interface{}, to interface{}) *MockMeasurementRepository_History_Call {
+	return &MockMeasurementRepository_History_Call{Call: _e.mock.On("History", ctx, macAddress, sensorType, from, to)}
+}
+
+func (_c *MockMeasurementRepository_History_Call) Run(run func(ctx context.Context, macAddress string, sensorType string, from time.Time, to time.Time)) *MockMeasurementRepository_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementRepository_History_Call) Return(measurements []*entities.Measurement, err error) *MockMeasurementRepository_History_Call {
+	_c.Call.Return(measurements, err)
+	return _c
+}
+
+func (_c *MockMeasurementRepository_History_Call) RunAndReturn(run func(ctx context.Context, macAddress string, sensorType string, from time.Time, to time.Time) ([]*entities.Measurement, error)) *MockMeasurementRepository_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Latest provides a mock function for the type MockMeasurementRepository
+func (_mock *MockMeasurementRepository) Latest(ctx context.Context, sensorTypes []string, since time.Time) ([]*entities.Measurement, error) {
+	ret := _mock.Called(ctx, sensorTypes, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Latest")
+	}
+
+	var r0 []*entities.Measurement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Time) ([]*entities.Measurement, error)); ok {
+		return returnFunc(ctx, sensorTypes, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Time) []*entities.Measurement); ok {
+		r0 = returnFunc(ctx, sensorTypes, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Measurement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, time.Time) error); ok {
+		r1 = returnFunc(ctx, sensorTypes, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMeasurementRepository_Latest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Latest'
+type MockMeasurementRepository_Latest_Call struct {
+	*mock.Call
+}
+
+// Latest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sensorTypes []string
+//   - since time.Time
+func (_e *MockMeasurementRepository_Expecter) Latest(ctx interface{}, sensorTypes interface{}, since interface{}) *MockMeasurementRepository_Latest_Call {
+	return &MockMeasurementRepository_Latest_Call{Call: _e.mock.On("Latest", ctx, sensorTypes, since)}
+}
+
+func (_c *MockMeasurementRepository_Latest_Call) Run(run func(ctx context.Context, sensorTypes []string, since time.Time)) *MockMeasurementRepository_Latest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementRepository_Latest_Call) Return(measurements []*entities.Measurement, err error) *MockMeasurementRepository_Latest_Call {
+	_c.Call.Return(measurements, err)
+	return _c
+}
+
+func (_c *MockMeasurementRepository_Latest_Call) RunAndReturn(run func(ctx context.Context, sensorTypes []string, since time.Time) ([]*entities.Measurement, error)) *MockMeasurementRepository_Latest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LatestByDevice provides a mock function for the type MockMeasurementRepository
+func (_mock *MockMeasurementRepository) LatestByDevice(ctx context.Context, macAddress string, since time.Time) ([]*entities.Measurement, error) {
+	ret := _mock.Called(ctx, macAddress, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LatestByDevice")
+	}
+
+	var r0 []*entities.Measurement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]*entities.Measurement, error)); ok {
+		return returnFunc(ctx, macAddress, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) []*entities.Measurement); ok {
+		r0 = returnFunc(ctx, macAddress, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Measurement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, macAddress, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMeasurementRepository_LatestByDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LatestByDevice'
+type MockMeasurementRepository_LatestByDevice_Call struct {
+	*mock.Call
+}
+
+// LatestByDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - since time.Time
+func (_e *MockMeasurementRepository_Expecter) LatestByDevice(ctx interface{}, macAddress interface{}, since interface{}) *MockMeasurementRepository_LatestByDevice_Call {
+	return &MockMeasurementRepository_LatestByDevice_Call{Call: _e.mock.On("LatestByDevice", ctx, macAddress, since)}
+}
+
+func (_c *MockMeasurementRepository_LatestByDevice_Call) Run(run func(ctx context.Context, macAddress string, since time.Time)) *MockMeasurementRepository_LatestByDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementRepository_LatestByDevice_Call) Return(measurements []*entities.Measurement, err error) *MockMeasurementRepository_LatestByDevice_Call {
+	_c.Call.Return(measurements, err)
+	return _c
+}
+
+func (_c *MockMeasurementRepository_LatestByDevice_Call) RunAndReturn(run func(ctx context.Context, macAddress string, since time.Time) ([]*entities.Measurement, error)) *MockMeasurementRepository_LatestByDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockMeasurementRepository
+func (_mock *MockMeasurementRepository) Upsert(ctx context.Context, measurements []*entities.Measurement, window time.Duration) error {
+	ret := _mock.Called(ctx, measurements, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.Measurement, time.Duration) error); ok {
+		r0 = returnFunc(ctx, measurements, window)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMeasurementRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockMeasurementRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - measurements []*entities.Measurement
+//   - window time.Duration
+func (_e *MockMeasurementRepository_Expecter) Upsert(ctx interface{}, measurements interface{}, window interface{}) *MockMeasurementRepository_Upsert_Call {
+	return &MockMeasurementRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, measurements, window)}
+}
+
+func (_c *MockMeasurementRepository_Upsert_Call) Run(run func(ctx context.Context, measurements []*entities.Measurement, window time.Duration)) *MockMeasurementRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.Measurement
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.Measurement)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementRepository_Upsert_Call) Return(err error) *MockMeasurementRepository_Upsert_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMeasurementRepository_Upsert_Call) RunAndReturn(run func(ctx context.Context, measurements []*entities.Measurement, window time.Duration) error) *MockMeasurementRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,329 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockMeasurementUseCase creates a new instance of MockMeasurementUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMeasurementUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMeasurementUseCase {
+	mock := &MockMeasurementUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMeasurementUseCase is an autogenerated mock type for the MeasurementUseCase type
+type MockMeasurementUseCase struct {
+	mock.Mock
+}
+
+type MockMeasurementUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMeasurementUseCase) EXPECT() *MockMeasurementUseCase_Expecter {
+	return &MockMeasurementUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ConfigureChannel provides a mock function for the type MockMeasurementUseCase
+func (_mock *MockMeasurementUseCase) ConfigureChannel(ctx context.Context, settings *entities.SensorChannel) (*entities.SensorChannel, error) {
+	ret := _mock.Called(ctx, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfigureChannel")
+	}
+
+	var r0 *entities.SensorChannel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.SensorChannel) (*entities.SensorChannel, error)); ok {
+		return returnFunc(ctx, settings)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.SensorChannel) *entities.SensorChannel); ok {
+		r0 = returnFunc(ctx, settings)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SensorChannel)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.SensorChannel) error); ok {
+		r1 = returnFunc(ctx, settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMeasurementUseCase_ConfigureChannel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfigureChannel'
+type MockMeasurementUseCase_ConfigureChannel_Call struct {
+	*mock.Call
+}
+
+// ConfigureChannel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - settings *entities.SensorChannel
+func (_e *MockMeasurementUseCase_Expecter) ConfigureChannel(ctx interface{}, settings interface{}) *MockMeasurementUseCase_ConfigureChannel_Call {
+	return &MockMeasurementUseCase_ConfigureChannel_Call{Call: _e.mock.On("ConfigureChannel", ctx, settings)}
+}
+
+func (_c *MockMeasurementUseCase_ConfigureChannel_Call) Run(run func(ctx context.Context, settings *entities.SensorChannel)) *MockMeasurementUseCase_ConfigureChannel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.SensorChannel
+		if args[1] != nil {
+			arg1 = args[1].(*entities.SensorChannel)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementUseCase_ConfigureChannel_Call) Return(sensorChannel *entities.SensorChannel, err error) *MockMeasurementUseCase_ConfigureChannel_Call {
+	_c.Call.Return(sensorChannel, err)
+	return _c
+}
+
+func (_c *MockMeasurementUseCase_ConfigureChannel_Call) RunAndReturn(run func(ctx context.Context, settings *entities.SensorChannel) (*entities.SensorChannel, error)) *MockMeasurementUseCase_ConfigureChannel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureViews provides a mock function for the type MockMeasurementUseCase
+func (_mock *MockMeasurementUseCase) EnsureViews(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureViews")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMeasurementUseCase_EnsureViews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureViews'
+type MockMeasurementUseCase_EnsureViews_Call struct {
+	*mock.Call
+}
+
+// EnsureViews is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMeasurementUseCase_Expecter) EnsureViews(ctx interface{}) *MockMeasurementUseCase_EnsureViews_Call {
+	return &MockMeasurementUseCase_EnsureViews_Call{Call: _e.mock.On("EnsureViews", ctx)}
+}
+
+func (_c *MockMeasurementUseCase_EnsureViews_Call) Run(run func(ctx context.Context)) *MockMeasurementUseCase_EnsureViews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementUseCase_EnsureViews_Call) Return(err error) *MockMeasurementUseCase_EnsureViews_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMeasurementUseCase_EnsureViews_Call) RunAndReturn(run func(ctx context.Context) error) *MockMeasurementUseCase_EnsureViews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListChannels provides a mock function for the type MockMeasurementUseCase
+func (_mock *MockMeasurementUseCase) ListChannels(ctx context.Context, macAddress string) ([]*entities.SensorChannel, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListChannels")
+	}
+
+	var r0 []*entities.SensorChannel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entities.SensorChannel, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entities.SensorChannel); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SensorChannel)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMeasurementUseCase_ListChannels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListChannels'
+type MockMeasurementUseCase_ListChannels_Call struct {
+	*mock.Call
+}
+
+// ListChannels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockMeasurementUseCase_Expecter) ListChannels(ctx interface{}, macAddress interface{}) *MockMeasurementUseCase_ListChannels_Call {
+	return &MockMeasurementUseCase_ListChannels_Call{Call: _e.mock.On("ListChannels", ctx, macAddress)}
+}
+
+func (_c *MockMeasurementUseCase_ListChannels_Call) Run(run func(ctx context.Context, macAddress string)) *MockMeasurementUseCase_ListChannels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementUseCase_ListChannels_Call) Return(sensorChannels []*entities.SensorChannel, err error) *MockMeasurementUseCase_ListChannels_Call {
+	_c.Call.Return(sensorChannels, err)
+	return _c
+}
+
+func (_c *MockMeasurementUseCase_ListChannels_Call) RunAndReturn(run func(ctx context.Context, macAddress string) ([]*entities.SensorChannel, error)) *MockMeasurementUseCase_ListChannels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SensorTypes provides a mock function for the type MockMeasurementUseCase
+func (_mock *MockMeasurementUseCase) SensorTypes() []*entities.SensorType {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SensorTypes")
+	}
+
+	var r0 []*entities.SensorType
+	if returnFunc, ok := ret.Get(0).(func() []*entities.SensorType); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SensorType)
+		}
+	}
+	return r0
+}
+
+// MockMeasurementUseCase_SensorTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SensorTypes'
+type MockMeasurementUseCase_SensorTypes_Call struct {
+	*mock.Call
+}
+
+// SensorTypes is a helper method to define mock.On call
+func (_e *MockMeasurementUseCase_Expecter) SensorTypes() *MockMeasurementUseCase_SensorTypes_Call {
+	return &MockMeasurementUseCase_SensorTypes_Call{Call: _e.mock.On("SensorTypes")}
+}
+
+func (_c *MockMeasurementUseCase_SensorTypes_Call) Run(run func()) *MockMeasurementUseCase_SensorTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMeasurementUseCase_SensorTypes_Call) Return(sensorTypes []*entities.SensorType) *MockMeasurementUseCase_SensorTypes_Call {
+	_c.Call.Return(sensorTypes)
+	return _c
+}
+
+func (_c *MockMeasurementUseCase_SensorTypes_Call) RunAndReturn(run func() []*entities.SensorType) *MockMeasurementUseCase_SensorTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreMeasurements provides a mock function for the type MockMeasurementUseCase
+func (_mock *MockMeasurementUseCase) StoreMeasurements(ctx context.Context, measurements []*entities.Measurement) error {
+	ret := _mock.Called(ctx, measurements)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreMeasurements")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.Measurement) error); ok {
+		r0 = returnFunc(ctx, measurements)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMeasurementUseCase_StoreMeasurements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreMeasurements'
+type MockMeasurementUseCase_StoreMeasurements_Call struct {
+	*mock.Call
+}
+
+// StoreMeasurements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - measurements []*entities.Measurement
+func (_e *MockMeasurementUseCase_Expecter) StoreMeasurements(ctx interface{}, measurements interface{}) *MockMeasurementUseCase_StoreMeasurements_Call {
+	return &MockMeasurementUseCase_StoreMeasurements_Call{Call: _e.mock.On("StoreMeasurements", ctx, measurements)}
+}
+
+func (_c *MockMeasurementUseCase_StoreMeasurements_Call) Run(run func(ctx context.Context, measurements []*entities.Measurement)) *MockMeasurementUseCase_StoreMeasurements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.Measurement
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.Measurement)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMeasurementUseCase_StoreMeasurements_Call) Return(err error) *MockMeasurementUseCase_StoreMeasurements_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMeasurementUseCase_StoreMeasurements_Call) RunAndReturn(run func(ctx context.Context, measurements []*entities.Measurement) error) *MockMeasurementUseCase_StoreMeasurements_Call {
+	_c.Call.Return(run)
+	return _c
+}
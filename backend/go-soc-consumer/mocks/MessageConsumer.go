@@ -247,6 +247,82 @@ func (_c *MockMessageConsumer_Subscribe_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// SubscribeHandlers provides a mock function for the type MockMessageConsumer
+func (_mock *MockMessageConsumer) SubscribeHandlers(ctx context.Context, topic string, policy ports.HandlerErrorPolicy, handlers ...ports.MessageHandler) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, topic, policy)
+	for _, _va := range handlers {
+		_ca = append(_ca, _va)
+	}
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeHandlers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, ports.HandlerErrorPolicy, ...ports.MessageHandler) error); ok {
+		r0 = returnFunc(ctx, topic, policy, handlers...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMessageConsumer_SubscribeHandlers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeHandlers'
+type MockMessageConsumer_SubscribeHandlers_Call struct {
+	*mock.Call
+}
+
+// SubscribeHandlers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - topic string
+//   - policy ports.HandlerErrorPolicy
+//   - handlers ...ports.MessageHandler
+func (_e *MockMessageConsumer_Expecter) SubscribeHandlers(ctx interface{}, topic interface{}, policy interface{}, handlers ...interface{}) *MockMessageConsumer_SubscribeHandlers_Call {
+	return &MockMessageConsumer_SubscribeHandlers_Call{Call: _e.mock.On("SubscribeHandlers", append([]interface{}{ctx, topic, policy}, handlers...)...)}
+}
+
+func (_c *MockMessageConsumer_SubscribeHandlers_Call) Run(run func(ctx context.Context, topic string, policy ports.HandlerErrorPolicy, handlers ...ports.MessageHandler)) *MockMessageConsumer_SubscribeHandlers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 ports.HandlerErrorPolicy
+		if args[2] != nil {
+			arg2 = args[2].(ports.HandlerErrorPolicy)
+		}
+		var arg3 []ports.MessageHandler
+		for _, a := range args[3:] {
+			if a != nil {
+				arg3 = append(arg3, a.(ports.MessageHandler))
+			}
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMessageConsumer_SubscribeHandlers_Call) Return(err error) *MockMessageConsumer_SubscribeHandlers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMessageConsumer_SubscribeHandlers_Call) RunAndReturn(run func(ctx context.Context, topic string, policy ports.HandlerErrorPolicy, handlers ...ports.MessageHandler) error) *MockMessageConsumer_SubscribeHandlers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Unsubscribe provides a mock function for the type MockMessageConsumer
 func (_mock *MockMessageConsumer) Unsubscribe(topic string) error {
 	ret := _mock.Called(topic)
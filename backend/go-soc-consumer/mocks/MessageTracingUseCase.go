@@ -0,0 +1,578 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockMessageTracingUseCase creates a new instance of MockMessageTracingUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMessageTracingUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMessageTracingUseCase {
+	mock := &MockMessageTracingUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMessageTracingUseCase is an autogenerated mock type for the MessageTracingUseCase type
+type MockMessageTracingUseCase struct {
+	mock.Mock
+}
+
+type MockMessageTracingUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMessageTracingUseCase) EXPECT() *MockMessageTracingUseCase_Expecter {
+	return &MockMessageTracingUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ActiveOverrides provides a mock function for the type MockMessageTracingUseCase
+func (_mock *MockMessageTracingUseCase) ActiveOverrides() []*entities.OperatorOverride {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActiveOverrides")
+	}
+
+	var r0 []*entities.OperatorOverride
+	if returnFunc, ok := ret.Get(0).(func() []*entities.OperatorOverride); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.OperatorOverride)
+		}
+	}
+	return r0
+}
+
+// MockMessageTracingUseCase_ActiveOverrides_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveOverrides'
+type MockMessageTracingUseCase_ActiveOverrides_Call struct {
+	*mock.Call
+}
+
+// ActiveOverrides is a helper method to define mock.On call
+func (_e *MockMessageTracingUseCase_Expecter) ActiveOverrides() *MockMessageTracingUseCase_ActiveOverrides_Call {
+	return &MockMessageTracingUseCase_ActiveOverrides_Call{Call: _e.mock.On("ActiveOverrides")}
+}
+
+func (_c *MockMessageTracingUseCase_ActiveOverrides_Call) Run(run func()) *MockMessageTracingUseCase_ActiveOverrides_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_ActiveOverrides_Call) Return(operatorOverrides []*entities.OperatorOverride) *MockMessageTracingUseCase_ActiveOverrides_Call {
+	_c.Call.Return(operatorOverrides)
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_ActiveOverrides_Call) RunAndReturn(run func() []*entities.OperatorOverride) *MockMessageTracingUseCase_ActiveOverrides_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Begin provides a mock function for the type MockMessageTracingUseCase
+func (_mock *MockMessageTracingUseCase) Begin(ctx context.Context, topic string, macAddress string, payload []byte) context.Context {
+	ret := _mock.Called(ctx, topic, macAddress, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Begin")
+	}
+
+	var r0 context.Context
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) context.Context); ok {
+		r0 = returnFunc(ctx, topic, macAddress, payload)
+	} else {
+		r0 = ret.Get(0).(context.Context)
+	}
+	return r0
+}
+
+// MockMessageTracingUseCase_Begin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Begin'
+type MockMessageTracingUseCase_Begin_Call struct {
+	*mock.Call
+}
+
+// Begin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - topic string
+//   - macAddress string
+//   - payload []byte
+func (_e *MockMessageTracingUseCase_Expecter) Begin(ctx interface{}, topic interface{}, macAddress interface{}, payload interface{}) *MockMessageTracingUseCase_Begin_Call {
+	return &MockMessageTracingUseCase_Begin_Call{Call: _e.mock.On("Begin", ctx, topic, macAddress, payload)}
+}
+
+func (_c *MockMessageTracingUseCase_Begin_Call) Run(run func(ctx context.Context, topic string, macAddress string, payload []byte)) *MockMessageTracingUseCase_Begin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_Begin_Call) Return(context context.Context) *MockMessageTracingUseCase_Begin_Call {
+	_c.Call.Return(context)
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_Begin_Call) RunAndReturn(run func(ctx context.Context, topic string, macAddress string, payload []byte) context.Context) *MockMessageTracingUseCase_Begin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Complete provides a mock function for the type MockMessageTracingUseCase
+func (_mock *MockMessageTracingUseCase) Complete(ctx context.Context, outcome string, stage string, reason string) {
+	_mock.Called(ctx, outcome, stage, reason)
+	return
+}
+
+// MockMessageTracingUseCase_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockMessageTracingUseCase_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - outcome string
+//   - stage string
+//   - reason string
+func (_e *MockMessageTracingUseCase_Expecter) Complete(ctx interface{}, outcome interface{}, stage interface{}, reason interface{}) *MockMessageTracingUseCase_Complete_Call {
+	return &MockMessageTracingUseCase_Complete_Call{Call: _e.mock.On("Complete", ctx, outcome, stage, reason)}
+}
+
+func (_c *MockMessageTracingUseCase_Complete_Call) Run(run func(ctx context.Context, outcome string, stage string, reason string)) *MockMessageTracingUseCase_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_Complete_Call) Return() *MockMessageTracingUseCase_Complete_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_Complete_Call) RunAndReturn(run func(ctx context.Context, outcome string, stage string, reason string)) *MockMessageTracingUseCase_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSession provides a mock function for the type MockMessageTracingUseCase
+func (_mock *MockMessageTracingUseCase) DeleteSession(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSession")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMessageTracingUseCase_DeleteSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSession'
+type MockMessageTracingUseCase_DeleteSession_Call struct {
+	*mock.Call
+}
+
+// DeleteSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockMessageTracingUseCase_Expecter) DeleteSession(ctx interface{}, id interface{}) *MockMessageTracingUseCase_DeleteSession_Call {
+	return &MockMessageTracingUseCase_DeleteSession_Call{Call: _e.mock.On("DeleteSession", ctx, id)}
+}
+
+func (_c *MockMessageTracingUseCase_DeleteSession_Call) Run(run func(ctx context.Context, id string)) *MockMessageTracingUseCase_DeleteSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_DeleteSession_Call) Return(err error) *MockMessageTracingUseCase_DeleteSession_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_DeleteSession_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockMessageTracingUseCase_DeleteSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sessions provides a mock function for the type MockMessageTracingUseCase
+func (_mock *MockMessageTracingUseCase) Sessions(ctx context.Context) []*entities.TraceSession {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sessions")
+	}
+
+	var r0 []*entities.TraceSession
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.TraceSession); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.TraceSession)
+		}
+	}
+	return r0
+}
+
+// MockMessageTracingUseCase_Sessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sessions'
+type MockMessageTracingUseCase_Sessions_Call struct {
+	*mock.Call
+}
+
+// Sessions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMessageTracingUseCase_Expecter) Sessions(ctx interface{}) *MockMessageTracingUseCase_Sessions_Call {
+	return &MockMessageTracingUseCase_Sessions_Call{Call: _e.mock.On("Sessions", ctx)}
+}
+
+func (_c *MockMessageTracingUseCase_Sessions_Call) Run(run func(ctx context.Context)) *MockMessageTracingUseCase_Sessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_Sessions_Call) Return(traceSessions []*entities.TraceSession) *MockMessageTracingUseCase_Sessions_Call {
+	_c.Call.Return(traceSessions)
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_Sessions_Call) RunAndReturn(run func(ctx context.Context) []*entities.TraceSession) *MockMessageTracingUseCase_Sessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartSession provides a mock function for the type MockMessageTracingUseCase
+func (_mock *MockMessageTracingUseCase) StartSession(ctx context.Context, request entities.TraceSessionRequest) (*entities.TraceSession, error) {
+	ret := _mock.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartSession")
+	}
+
+	var r0 *entities.TraceSession
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.TraceSessionRequest) (*entities.TraceSession, error)); ok {
+		return returnFunc(ctx, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.TraceSessionRequest) *entities.TraceSession); ok {
+		r0 = returnFunc(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.TraceSession)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.TraceSessionRequest) error); ok {
+		r1 = returnFunc(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMessageTracingUseCase_StartSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartSession'
+type MockMessageTracingUseCase_StartSession_Call struct {
+	*mock.Call
+}
+
+// StartSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request entities.TraceSessionRequest
+func (_e *MockMessageTracingUseCase_Expecter) StartSession(ctx interface{}, request interface{}) *MockMessageTracingUseCase_StartSession_Call {
+	return &MockMessageTracingUseCase_StartSession_Call{Call: _e.mock.On("StartSession", ctx, request)}
+}
+
+func (_c *MockMessageTracingUseCase_StartSession_Call) Run(run func(ctx context.Context, request entities.TraceSessionRequest)) *MockMessageTracingUseCase_StartSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 entities.TraceSessionRequest
+		if args[1] != nil {
+			arg1 = args[1].(entities.TraceSessionRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_StartSession_Call) Return(traceSession *entities.TraceSession, err error) *MockMessageTracingUseCase_StartSession_Call {
+	_c.Call.Return(traceSession, err)
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_StartSession_Call) RunAndReturn(run func(ctx context.Context, request entities.TraceSessionRequest) (*entities.TraceSession, error)) *MockMessageTracingUseCase_StartSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StopSession provides a mock function for the type MockMessageTracingUseCase
+func (_mock *MockMessageTracingUseCase) StopSession(ctx context.Context, id string) (*entities.TraceSession, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopSession")
+	}
+
+	var r0 *entities.TraceSession
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.TraceSession, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.TraceSession); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.TraceSession)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMessageTracingUseCase_StopSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopSession'
+type MockMessageTracingUseCase_StopSession_Call struct {
+	*mock.Call
+}
+
+// StopSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockMessageTracingUseCase_Expecter) StopSession(ctx interface{}, id interface{}) *MockMessageTracingUseCase_StopSession_Call {
+	return &MockMessageTracingUseCase_StopSession_Call{Call: _e.mock.On("StopSession", ctx, id)}
+}
+
+func (_c *MockMessageTracingUseCase_StopSession_Call) Run(run func(ctx context.Context, id string)) *MockMessageTracingUseCase_StopSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_StopSession_Call) Return(traceSession *entities.TraceSession, err error) *MockMessageTracingUseCase_StopSession_Call {
+	_c.Call.Return(traceSession, err)
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_StopSession_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.TraceSession, error)) *MockMessageTracingUseCase_StopSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Traces provides a mock function for the type MockMessageTracingUseCase
+func (_mock *MockMessageTracingUseCase) Traces(ctx context.Context, id string) (*entities.TraceSession, []*entities.MessageTrace, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Traces")
+	}
+
+	var r0 *entities.TraceSession
+	var r1 []*entities.MessageTrace
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.TraceSession, []*entities.MessageTrace, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.TraceSession); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.TraceSession)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) []*entities.MessageTrace); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*entities.MessageTrace)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockMessageTracingUseCase_Traces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Traces'
+type MockMessageTracingUseCase_Traces_Call struct {
+	*mock.Call
+}
+
+// Traces is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockMessageTracingUseCase_Expecter) Traces(ctx interface{}, id interface{}) *MockMessageTracingUseCase_Traces_Call {
+	return &MockMessageTracingUseCase_Traces_Call{Call: _e.mock.On("Traces", ctx, id)}
+}
+
+func (_c *MockMessageTracingUseCase_Traces_Call) Run(run func(ctx context.Context, id string)) *MockMessageTracingUseCase_Traces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_Traces_Call) Return(traceSession *entities.TraceSession, messageTraces []*entities.MessageTrace, err error) *MockMessageTracingUseCase_Traces_Call {
+	_c.Call.Return(traceSession, messageTraces, err)
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_Traces_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.TraceSession, []*entities.MessageTrace, error)) *MockMessageTracingUseCase_Traces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tracing provides a mock function for the type MockMessageTracingUseCase
+func (_mock *MockMessageTracingUseCase) Tracing() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tracing")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockMessageTracingUseCase_Tracing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tracing'
+type MockMessageTracingUseCase_Tracing_Call struct {
+	*mock.Call
+}
+
+// Tracing is a helper method to define mock.On call
+func (_e *MockMessageTracingUseCase_Expecter) Tracing() *MockMessageTracingUseCase_Tracing_Call {
+	return &MockMessageTracingUseCase_Tracing_Call{Call: _e.mock.On("Tracing")}
+}
+
+func (_c *MockMessageTracingUseCase_Tracing_Call) Run(run func()) *MockMessageTracingUseCase_Tracing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_Tracing_Call) Return(b bool) *MockMessageTracingUseCase_Tracing_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockMessageTracingUseCase_Tracing_Call) RunAndReturn(run func() bool) *MockMessageTracingUseCase_Tracing_Call {
+	_c.Call.Return(run)
+	return _c
+}
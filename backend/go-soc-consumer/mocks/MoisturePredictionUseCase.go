@@ -0,0 +1,238 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockMoisturePredictionUseCase creates a new instance of MockMoisturePredictionUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMoisturePredictionUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMoisturePredictionUseCase {
+	mock := &MockMoisturePredictionUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMoisturePredictionUseCase is an autogenerated mock type for the MoisturePredictionUseCase type
+type MockMoisturePredictionUseCase struct {
+	mock.Mock
+}
+
+type MockMoisturePredictionUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMoisturePredictionUseCase) EXPECT() *MockMoisturePredictionUseCase_Expecter {
+	return &MockMoisturePredictionUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ForDevice provides a mock function for the type MockMoisturePredictionUseCase
+func (_mock *MockMoisturePredictionUseCase) ForDevice(macAddress string) (*entities.MoisturePrediction, bool) {
+	ret := _mock.Called(macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForDevice")
+	}
+
+	var r0 *entities.MoisturePrediction
+	var r1 bool
+	if returnFunc, ok := ret.Get(0).(func(string) (*entities.MoisturePrediction, bool)); ok {
+		return returnFunc(macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *entities.MoisturePrediction); ok {
+		r0 = returnFunc(macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.MoisturePrediction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = returnFunc(macAddress)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	return r0, r1
+}
+
+// MockMoisturePredictionUseCase_ForDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForDevice'
+type MockMoisturePredictionUseCase_ForDevice_Call struct {
+	*mock.Call
+}
+
+// ForDevice is a helper method to define mock.On call
+//   - macAddress string
+func (_e *MockMoisturePredictionUseCase_Expecter) ForDevice(macAddress interface{}) *MockMoisturePredictionUseCase_ForDevice_Call {
+	return &MockMoisturePredictionUseCase_ForDevice_Call{Call: _e.mock.On("ForDevice", macAddress)}
+}
+
+func (_c *MockMoisturePredictionUseCase_ForDevice_Call) Run(run func(macAddress string)) *MockMoisturePredictionUseCase_ForDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMoisturePredictionUseCase_ForDevice_Call) Return(moisturePrediction *entities.MoisturePrediction, b bool) *MockMoisturePredictionUseCase_ForDevice_Call {
+	_c.Call.Return(moisturePrediction, b)
+	return _c
+}
+
+func (_c *MockMoisturePredictionUseCase_ForDevice_Call) RunAndReturn(run func(macAddress string) (*entities.MoisturePrediction, bool)) *MockMoisturePredictionUseCase_ForDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Predict provides a mock function for the type MockMoisturePredictionUseCase
+func (_mock *MockMoisturePredictionUseCase) Predict(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Predict")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMoisturePredictionUseCase_Predict_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Predict'
+type MockMoisturePredictionUseCase_Predict_Call struct {
+	*mock.Call
+}
+
+// Predict is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMoisturePredictionUseCase_Expecter) Predict(ctx interface{}) *MockMoisturePredictionUseCase_Predict_Call {
+	return &MockMoisturePredictionUseCase_Predict_Call{Call: _e.mock.On("Predict", ctx)}
+}
+
+func (_c *MockMoisturePredictionUseCase_Predict_Call) Run(run func(ctx context.Context)) *MockMoisturePredictionUseCase_Predict_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMoisturePredictionUseCase_Predict_Call) Return(err error) *MockMoisturePredictionUseCase_Predict_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMoisturePredictionUseCase_Predict_Call) RunAndReturn(run func(ctx context.Context) error) *MockMoisturePredictionUseCase_Predict_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Predictions provides a mock function for the type MockMoisturePredictionUseCase
+func (_mock *MockMoisturePredictionUseCase) Predictions() []*entities.MoisturePrediction {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Predictions")
+	}
+
+	var r0 []*entities.MoisturePrediction
+	if returnFunc, ok := ret.Get(0).(func() []*entities.MoisturePrediction); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.MoisturePrediction)
+		}
+	}
+	return r0
+}
+
+// MockMoisturePredictionUseCase_Predictions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Predictions'
+type MockMoisturePredictionUseCase_Predictions_Call struct {
+	*mock.Call
+}
+
+// Predictions is a helper method to define mock.On call
+func (_e *MockMoisturePredictionUseCase_Expecter) Predictions() *MockMoisturePredictionUseCase_Predictions_Call {
+	return &MockMoisturePredictionUseCase_Predictions_Call{Call: _e.mock.On("Predictions")}
+}
+
+func (_c *MockMoisturePredictionUseCase_Predictions_Call) Run(run func()) *MockMoisturePredictionUseCase_Predictions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMoisturePredictionUseCase_Predictions_Call) Return(moisturePredictions []*entities.MoisturePrediction) *MockMoisturePredictionUseCase_Predictions_Call {
+	_c.Call.Return(moisturePredictions)
+	return _c
+}
+
+func (_c *MockMoisturePredictionUseCase_Predictions_Call) RunAndReturn(run func() []*entities.MoisturePrediction) *MockMoisturePredictionUseCase_Predictions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockMoisturePredictionUseCase
+func (_mock *MockMoisturePredictionUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockMoisturePredictionUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockMoisturePredictionUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMoisturePredictionUseCase_Expecter) Run(ctx interface{}) *MockMoisturePredictionUseCase_Run_Call {
+	return &MockMoisturePredictionUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockMoisturePredictionUseCase_Run_Call) Run(run func(ctx context.Context)) *MockMoisturePredictionUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMoisturePredictionUseCase_Run_Call) Return() *MockMoisturePredictionUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockMoisturePredictionUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockMoisturePredictionUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,458 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockNotificationInboxRepository creates a new instance of MockNotificationInboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationInboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationInboxRepository {
+	mock := &MockNotificationInboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNotificationInboxRepository is an autogenerated mock type for the NotificationInboxRepository type
+type MockNotificationInboxRepository struct {
+	mock.Mock
+}
+
+type MockNotificationInboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationInboxRepository) EXPECT() *MockNotificationInboxRepository_Expecter {
+	return &MockNotificationInboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountUnread provides a mock function for the type MockNotificationInboxRepository
+func (_mock *MockNotificationInboxRepository) CountUnread(ctx context.Context, user string) (map[string]int, error) {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUnread")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (map[string]int, error)); ok {
+		return returnFunc(ctx, user)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) map[string]int); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotificationInboxRepository_CountUnread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUnread'
+type MockNotificationInboxRepository_CountUnread_Call struct {
+	*mock.Call
+}
+
+// CountUnread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+func (_e *MockNotificationInboxRepository_Expecter) CountUnread(ctx interface{}, user interface{}) *MockNotificationInboxRepository_CountUnread_Call {
+	return &MockNotificationInboxRepository_CountUnread_Call{Call: _e.mock.On("CountUnread", ctx, user)}
+}
+
+func (_c *MockNotificationInboxRepository_CountUnread_Call) Run(run func(ctx context.Context, user string)) *MockNotificationInboxRepository_CountUnread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_CountUnread_Call) Return(mp map[string]int, err error) *MockNotificationInboxRepository_CountUnread_Call {
+	_c.Call.Return(mp, err)
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_CountUnread_Call) RunAndReturn(run func(ctx context.Context, user string) (map[string]int, error)) *MockNotificationInboxRepository_CountUnread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockNotificationInboxRepository
+func (_mock *MockNotificationInboxRepository) Create(ctx context.Context, notification *entities.InboxNotification) error {
+	ret := _mock.Called(ctx, notification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.InboxNotification) error); ok {
+		r0 = returnFunc(ctx, notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotificationInboxRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockNotificationInboxRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notification *entities.InboxNotification
+func (_e *MockNotificationInboxRepository_Expecter) Create(ctx interface{}, notification interface{}) *MockNotificationInboxRepository_Create_Call {
+	return &MockNotificationInboxRepository_Create_Call{Call: _e.mock.On("Create", ctx, notification)}
+}
+
+func (_c *MockNotificationInboxRepository_Create_Call) Run(run func(ctx context.Context, notification *entities.InboxNotification)) *MockNotificationInboxRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.InboxNotification
+		if args[1] != nil {
+			arg1 = args[1].(*entities.InboxNotification)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_Create_Call) Return(err error) *MockNotificationInboxRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_Create_Call) RunAndReturn(run func(ctx context.Context, notification *entities.InboxNotification) error) *MockNotificationInboxRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCreatedBefore provides a mock function for the type MockNotificationInboxRepository
+func (_mock *MockNotificationInboxRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCreatedBefore")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotificationInboxRepository_DeleteCreatedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCreatedBefore'
+type MockNotificationInboxRepository_DeleteCreatedBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteCreatedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockNotificationInboxRepository_Expecter) DeleteCreatedBefore(ctx interface{}, before interface{}) *MockNotificationInboxRepository_DeleteCreatedBefore_Call {
+	return &MockNotificationInboxRepository_DeleteCreatedBefore_Call{Call: _e.mock.On("DeleteCreatedBefore", ctx, before)}
+}
+
+func (_c *MockNotificationInboxRepository_DeleteCreatedBefore_Call) Run(run func(ctx context.Context, before time.Time)) *MockNotificationInboxRepository_DeleteCreatedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_DeleteCreatedBefore_Call) Return(n int, err error) *MockNotificationInboxRepository_DeleteCreatedBefore_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_DeleteCreatedBefore_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int, error)) *MockNotificationInboxRepository_DeleteCreatedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockNotificationInboxRepository
+func (_mock *MockNotificationInboxRepository) List(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int) ([]*entities.InboxNotification, error) {
+	ret := _mock.Called(ctx, user, unreadOnly, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.InboxNotification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool, time.Time, int) ([]*entities.InboxNotification, error)); ok {
+		return returnFunc(ctx, user, unreadOnly, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool, time.Time, int) []*entities.InboxNotification); ok {
+		r0 = returnFunc(ctx, user, unreadOnly, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.InboxNotification)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, user, unreadOnly, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotificationInboxRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockNotificationInboxRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - unreadOnly bool
+//   - before time.Time
+//   - limit int
+func (_e *MockNotificationInboxRepository_Expecter) List(ctx interface{}, user interface{}, unreadOnly interface{}, before interface{}, limit interface{}) *MockNotificationInboxRepository_List_Call {
+	return &MockNotificationInboxRepository_List_Call{Call: _e.mock.On("List", ctx, user, unreadOnly, before, limit)}
+}
+
+func (_c *MockNotificationInboxRepository_List_Call) Run(run func(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int)) *MockNotificationInboxRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_List_Call) Return(inboxNotifications []*entities.InboxNotification, err error) *MockNotificationInboxRepository_List_Call {
+	_c.Call.Return(inboxNotifications, err)
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_List_Call) RunAndReturn(run func(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int) ([]*entities.InboxNotification, error)) *MockNotificationInboxRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAllRead provides a mock function for the type MockNotificationInboxRepository
+func (_mock *MockNotificationInboxRepository) MarkAllRead(ctx context.Context, user string, readAt time.Time) (int, error) {
+	ret := _mock.Called(ctx, user, readAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAllRead")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (int, error)); ok {
+		return returnFunc(ctx, user, readAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) int); ok {
+		r0 = returnFunc(ctx, user, readAt)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, user, readAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotificationInboxRepository_MarkAllRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAllRead'
+type MockNotificationInboxRepository_MarkAllRead_Call struct {
+	*mock.Call
+}
+
+// MarkAllRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - readAt time.Time
+func (_e *MockNotificationInboxRepository_Expecter) MarkAllRead(ctx interface{}, user interface{}, readAt interface{}) *MockNotificationInboxRepository_MarkAllRead_Call {
+	return &MockNotificationInboxRepository_MarkAllRead_Call{Call: _e.mock.On("MarkAllRead", ctx, user, readAt)}
+}
+
+func (_c *MockNotificationInboxRepository_MarkAllRead_Call) Run(run func(ctx context.Context, user string, readAt time.Time)) *MockNotificationInboxRepository_MarkAllRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_MarkAllRead_Call) Return(n int, err error) *MockNotificationInboxRepository_MarkAllRead_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_MarkAllRead_Call) RunAndReturn(run func(ctx context.Context, user string, readAt time.Time) (int, error)) *MockNotificationInboxRepository_MarkAllRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRead provides a mock function for the type MockNotificationInboxRepository
+func (_mock *MockNotificationInboxRepository) MarkRead(ctx context.Context, user string, id string, readAt time.Time) error {
+	ret := _mock.Called(ctx, user, id, readAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, user, id, readAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotificationInboxRepository_MarkRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRead'
+type MockNotificationInboxRepository_MarkRead_Call struct {
+	*mock.Call
+}
+
+// MarkRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - id string
+//   - readAt time.Time
+func (_e *MockNotificationInboxRepository_Expecter) MarkRead(ctx interface{}, user interface{}, id interface{}, readAt interface{}) *MockNotificationInboxRepository_MarkRead_Call {
+	return &MockNotificationInboxRepository_MarkRead_Call{Call: _e.mock.On("MarkRead", ctx, user, id, readAt)}
+}
+
+func (_c *MockNotificationInboxRepository_MarkRead_Call) Run(run func(ctx context.Context, user string, id string, readAt time.Time)) *MockNotificationInboxRepository_MarkRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_MarkRead_Call) Return(err error) *MockNotificationInboxRepository_MarkRead_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotificationInboxRepository_MarkRead_Call) RunAndReturn(run func(ctx context.Context, user string, id string, readAt time.Time) error) *MockNotificationInboxRepository_MarkRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
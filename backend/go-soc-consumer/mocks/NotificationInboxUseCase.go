@@ -0,0 +1,464 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockNotificationInboxUseCase creates a new instance of MockNotificationInboxUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationInboxUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationInboxUseCase {
+	mock := &MockNotificationInboxUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNotificationInboxUseCase is an autogenerated mock type for the NotificationInboxUseCase type
+type MockNotificationInboxUseCase struct {
+	mock.Mock
+}
+
+type MockNotificationInboxUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationInboxUseCase) EXPECT() *MockNotificationInboxUseCase_Expecter {
+	return &MockNotificationInboxUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Channel provides a mock function for the type MockNotificationInboxUseCase
+func (_mock *MockNotificationInboxUseCase) Channel() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Channel")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockNotificationInboxUseCase_Channel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Channel'
+type MockNotificationInboxUseCase_Channel_Call struct {
+	*mock.Call
+}
+
+// Channel is a helper method to define mock.On call
+func (_e *MockNotificationInboxUseCase_Expecter) Channel() *MockNotificationInboxUseCase_Channel_Call {
+	return &MockNotificationInboxUseCase_Channel_Call{Call: _e.mock.On("Channel")}
+}
+
+func (_c *MockNotificationInboxUseCase_Channel_Call) Run(run func()) *MockNotificationInboxUseCase_Channel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_Channel_Call) Return(s string) *MockNotificationInboxUseCase_Channel_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_Channel_Call) RunAndReturn(run func() string) *MockNotificationInboxUseCase_Channel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockNotificationInboxUseCase
+func (_mock *MockNotificationInboxUseCase) List(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int) ([]*entities.InboxNotification, error) {
+	ret := _mock.Called(ctx, user, unreadOnly, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.InboxNotification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool, time.Time, int) ([]*entities.InboxNotification, error)); ok {
+		return returnFunc(ctx, user, unreadOnly, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool, time.Time, int) []*entities.InboxNotification); ok {
+		r0 = returnFunc(ctx, user, unreadOnly, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.InboxNotification)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, user, unreadOnly, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotificationInboxUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockNotificationInboxUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - unreadOnly bool
+//   - before time.Time
+//   - limit int
+func (_e *MockNotificationInboxUseCase_Expecter) List(ctx interface{}, user interface{}, unreadOnly interface{}, before interface{}, limit interface{}) *MockNotificationInboxUseCase_List_Call {
+	return &MockNotificationInboxUseCase_List_Call{Call: _e.mock.On("List", ctx, user, unreadOnly, before, limit)}
+}
+
+func (_c *MockNotificationInboxUseCase_List_Call) Run(run func(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int)) *MockNotificationInboxUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_List_Call) Return(inboxNotifications []*entities.InboxNotification, err error) *MockNotificationInboxUseCase_List_Call {
+	_c.Call.Return(inboxNotifications, err)
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_List_Call) RunAndReturn(run func(ctx context.Context, user string, unreadOnly bool, before time.Time, limit int) ([]*entities.InboxNotification, error)) *MockNotificationInboxUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAllRead provides a mock function for the type MockNotificationInboxUseCase
+func (_mock *MockNotificationInboxUseCase) MarkAllRead(ctx context.Context, user string) (int, error) {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAllRead")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return returnFunc(ctx, user)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotificationInboxUseCase_MarkAllRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAllRead'
+type MockNotificationInboxUseCase_MarkAllRead_Call struct {
+	*mock.Call
+}
+
+// MarkAllRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+func (_e *MockNotificationInboxUseCase_Expecter) MarkAllRead(ctx interface{}, user interface{}) *MockNotificationInboxUseCase_MarkAllRead_Call {
+	return &MockNotificationInboxUseCase_MarkAllRead_Call{Call: _e.mock.On("MarkAllRead", ctx, user)}
+}
+
+func (_c *MockNotificationInboxUseCase_MarkAllRead_Call) Run(run func(ctx context.Context, user string)) *MockNotificationInboxUseCase_MarkAllRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_MarkAllRead_Call) Return(n int, err error) *MockNotificationInboxUseCase_MarkAllRead_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_MarkAllRead_Call) RunAndReturn(run func(ctx context.Context, user string) (int, error)) *MockNotificationInboxUseCase_MarkAllRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRead provides a mock function for the type MockNotificationInboxUseCase
+func (_mock *MockNotificationInboxUseCase) MarkRead(ctx context.Context, user string, id string) error {
+	ret := _mock.Called(ctx, user, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, user, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotificationInboxUseCase_MarkRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRead'
+type MockNotificationInboxUseCase_MarkRead_Call struct {
+	*mock.Call
+}
+
+// MarkRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+//   - id string
+func (_e *MockNotificationInboxUseCase_Expecter) MarkRead(ctx interface{}, user interface{}, id interface{}) *MockNotificationInboxUseCase_MarkRead_Call {
+	return &MockNotificationInboxUseCase_MarkRead_Call{Call: _e.mock.On("MarkRead", ctx, user, id)}
+}
+
+func (_c *MockNotificationInboxUseCase_MarkRead_Call) Run(run func(ctx context.Context, user string, id string)) *MockNotificationInboxUseCase_MarkRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_MarkRead_Call) Return(err error) *MockNotificationInboxUseCase_MarkRead_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_MarkRead_Call) RunAndReturn(run func(ctx context.Context, user string, id string) error) *MockNotificationInboxUseCase_MarkRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockNotificationInboxUseCase
+func (_mock *MockNotificationInboxUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockNotificationInboxUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockNotificationInboxUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockNotificationInboxUseCase_Expecter) Run(ctx interface{}) *MockNotificationInboxUseCase_Run_Call {
+	return &MockNotificationInboxUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockNotificationInboxUseCase_Run_Call) Run(run func(ctx context.Context)) *MockNotificationInboxUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_Run_Call) Return() *MockNotificationInboxUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockNotificationInboxUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function for the type MockNotificationInboxUseCase
+func (_mock *MockNotificationInboxUseCase) Send(ctx context.Context, notification *entities.Notification) error {
+	ret := _mock.Called(ctx, notification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.Notification) error); ok {
+		r0 = returnFunc(ctx, notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotificationInboxUseCase_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockNotificationInboxUseCase_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notification *entities.Notification
+func (_e *MockNotificationInboxUseCase_Expecter) Send(ctx interface{}, notification interface{}) *MockNotificationInboxUseCase_Send_Call {
+	return &MockNotificationInboxUseCase_Send_Call{Call: _e.mock.On("Send", ctx, notification)}
+}
+
+func (_c *MockNotificationInboxUseCase_Send_Call) Run(run func(ctx context.Context, notification *entities.Notification)) *MockNotificationInboxUseCase_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.Notification
+		if args[1] != nil {
+			arg1 = args[1].(*entities.Notification)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_Send_Call) Return(err error) *MockNotificationInboxUseCase_Send_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_Send_Call) RunAndReturn(run func(ctx context.Context, notification *entities.Notification) error) *MockNotificationInboxUseCase_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnreadCounts provides a mock function for the type MockNotificationInboxUseCase
+func (_mock *MockNotificationInboxUseCase) UnreadCounts(ctx context.Context, user string) (map[string]int, error) {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnreadCounts")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (map[string]int, error)); ok {
+		return returnFunc(ctx, user)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) map[string]int); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotificationInboxUseCase_UnreadCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnreadCounts'
+type MockNotificationInboxUseCase_UnreadCounts_Call struct {
+	*mock.Call
+}
+
+// UnreadCounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user string
+func (_e *MockNotificationInboxUseCase_Expecter) UnreadCounts(ctx interface{}, user interface{}) *MockNotificationInboxUseCase_UnreadCounts_Call {
+	return &MockNotificationInboxUseCase_UnreadCounts_Call{Call: _e.mock.On("UnreadCounts", ctx, user)}
+}
+
+func (_c *MockNotificationInboxUseCase_UnreadCounts_Call) Run(run func(ctx context.Context, user string)) *MockNotificationInboxUseCase_UnreadCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_UnreadCounts_Call) Return(mp map[string]int, err error) *MockNotificationInboxUseCase_UnreadCounts_Call {
+	_c.Call.Return(mp, err)
+	return _c
+}
+
+func (_c *MockNotificationInboxUseCase_UnreadCounts_Call) RunAndReturn(run func(ctx context.Context, user string) (map[string]int, error)) *MockNotificationInboxUseCase_UnreadCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,181 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockRawArchiveUseCase creates a new instance of MockRawArchiveUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRawArchiveUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRawArchiveUseCase {
+	mock := &MockRawArchiveUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRawArchiveUseCase is an autogenerated mock type for the RawArchiveUseCase type
+type MockRawArchiveUseCase struct {
+	mock.Mock
+}
+
+type MockRawArchiveUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRawArchiveUseCase) EXPECT() *MockRawArchiveUseCase_Expecter {
+	return &MockRawArchiveUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Flush provides a mock function for the type MockRawArchiveUseCase
+func (_mock *MockRawArchiveUseCase) Flush(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRawArchiveUseCase_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type MockRawArchiveUseCase_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRawArchiveUseCase_Expecter) Flush(ctx interface{}) *MockRawArchiveUseCase_Flush_Call {
+	return &MockRawArchiveUseCase_Flush_Call{Call: _e.mock.On("Flush", ctx)}
+}
+
+func (_c *MockRawArchiveUseCase_Flush_Call) Run(run func(ctx context.Context)) *MockRawArchiveUseCase_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRawArchiveUseCase_Flush_Call) Return(err error) *MockRawArchiveUseCase_Flush_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRawArchiveUseCase_Flush_Call) RunAndReturn(run func(ctx context.Context) error) *MockRawArchiveUseCase_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function for the type MockRawArchiveUseCase
+func (_mock *MockRawArchiveUseCase) Record(ctx context.Context, topic string, payload []byte) {
+	_mock.Called(ctx, topic, payload)
+	return
+}
+
+// MockRawArchiveUseCase_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockRawArchiveUseCase_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - topic string
+//   - payload []byte
+func (_e *MockRawArchiveUseCase_Expecter) Record(ctx interface{}, topic interface{}, payload interface{}) *MockRawArchiveUseCase_Record_Call {
+	return &MockRawArchiveUseCase_Record_Call{Call: _e.mock.On("Record", ctx, topic, payload)}
+}
+
+func (_c *MockRawArchiveUseCase_Record_Call) Run(run func(ctx context.Context, topic string, payload []byte)) *MockRawArchiveUseCase_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRawArchiveUseCase_Record_Call) Return() *MockRawArchiveUseCase_Record_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRawArchiveUseCase_Record_Call) RunAndReturn(run func(ctx context.Context, topic string, payload []byte)) *MockRawArchiveUseCase_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockRawArchiveUseCase
+func (_mock *MockRawArchiveUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockRawArchiveUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockRawArchiveUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRawArchiveUseCase_Expecter) Run(ctx interface{}) *MockRawArchiveUseCase_Run_Call {
+	return &MockRawArchiveUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockRawArchiveUseCase_Run_Call) Run(run func(ctx context.Context)) *MockRawArchiveUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRawArchiveUseCase_Run_Call) Return() *MockRawArchiveUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRawArchiveUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockRawArchiveUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,238 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockRawMessageArchive creates a new instance of MockRawMessageArchive. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRawMessageArchive(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRawMessageArchive {
+	mock := &MockRawMessageArchive{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRawMessageArchive is an autogenerated mock type for the RawMessageArchive type
+type MockRawMessageArchive struct {
+	mock.Mock
+}
+
+type MockRawMessageArchive_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRawMessageArchive) EXPECT() *MockRawMessageArchive_Expecter {
+	return &MockRawMessageArchive_Expecter{mock: &_m.Mock}
+}
+
+// DeleteBefore provides a mock function for the type MockRawMessageArchive
+func (_mock *MockRawMessageArchive) DeleteBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	ret := _mock.Called(ctx, cutoff)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBefore")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return returnFunc(ctx, cutoff)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = returnFunc(ctx, cutoff)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, cutoff)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRawMessageArchive_DeleteBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBefore'
+type MockRawMessageArchive_DeleteBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cutoff time.Time
+func (_e *MockRawMessageArchive_Expecter) DeleteBefore(ctx interface{}, cutoff interface{}) *MockRawMessageArchive_DeleteBefore_Call {
+	return &MockRawMessageArchive_DeleteBefore_Call{Call: _e.mock.On("DeleteBefore", ctx, cutoff)}
+}
+
+func (_c *MockRawMessageArchive_DeleteBefore_Call) Run(run func(ctx context.Context, cutoff time.Time)) *MockRawMessageArchive_DeleteBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRawMessageArchive_DeleteBefore_Call) Return(n int, err error) *MockRawMessageArchive_DeleteBefore_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRawMessageArchive_DeleteBefore_Call) RunAndReturn(run func(ctx context.Context, cutoff time.Time) (int, error)) *MockRawMessageArchive_DeleteBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Read provides a mock function for the type MockRawMessageArchive
+func (_mock *MockRawMessageArchive) Read(ctx context.Context, from time.Time, to time.Time, fn func(message *entities.RawMessage) error) error {
+	ret := _mock.Called(ctx, from, to, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Read")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, func(message *entities.RawMessage) error) error); ok {
+		r0 = returnFunc(ctx, from, to, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRawMessageArchive_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
+type MockRawMessageArchive_Read_Call struct {
+	*mock.Call
+}
+
+// Read is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from time.Time
+//   - to time.Time
+//   - fn func(message *entities.RawMessage) error
+func (_e *MockRawMessageArchive_Expecter) Read(ctx interface{}, from interface{}, to interface{}, fn interface{}) *MockRawMessageArchive_Read_Call {
+	return &MockRawMessageArchive_Read_Call{Call: _e.mock.On("Read", ctx, from, to, fn)}
+}
+
+func (_c *MockRawMessageArchive_Read_Call) Run(run func(ctx context.Context, from time.Time, to time.Time, fn func(message *entities.RawMessage) error)) *MockRawMessageArchive_Read_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 func(message *entities.RawMessage) error
+		if args[3] != nil {
+			arg3 = args[3].(func(message *entities.RawMessage) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRawMessageArchive_Read_Call) Return(err error) *MockRawMessageArchive_Read_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRawMessageArchive_Read_Call) RunAndReturn(run func(ctx context.Context, from time.Time, to time.Time, fn func(message *entities.RawMessage) error) error) *MockRawMessageArchive_Read_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Store provides a mock function for the type MockRawMessageArchive
+func (_mock *MockRawMessageArchive) Store(ctx context.Context, hour time.Time, messages []*entities.RawMessage) error {
+	ret := _mock.Called(ctx, hour, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Store")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, []*entities.RawMessage) error); ok {
+		r0 = returnFunc(ctx, hour, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRawMessageArchive_Store_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Store'
+type MockRawMessageArchive_Store_Call struct {
+	*mock.Call
+}
+
+// Store is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hour time.Time
+//   - messages []*entities.RawMessage
+func (_e *MockRawMessageArchive_Expecter) Store(ctx interface{}, hour interface{}, messages interface{}) *MockRawMessageArchive_Store_Call {
+	return &MockRawMessageArchive_Store_Call{Call: _e.mock.On("Store", ctx, hour, messages)}
+}
+
+func (_c *MockRawMessageArchive_Store_Call) Run(run func(ctx context.Context, hour time.Time, messages []*entities.RawMessage)) *MockRawMessageArchive_Store_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 []*entities.RawMessage
+		if args[2] != nil {
+			arg2 = args[2].([]*entities.RawMessage)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRawMessageArchive_Store_Call) Return(err error) *MockRawMessageArchive_Store_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRawMessageArchive_Store_Call) RunAndReturn(run func(ctx context.Context, hour time.Time, messages []*entities.RawMessage) error) *MockRawMessageArchive_Store_Call {
+	_c.Call.Return(run)
+	return _c
+}
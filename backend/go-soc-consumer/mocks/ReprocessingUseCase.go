@@ -0,0 +1,195 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/ports"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockReprocessingUseCase creates a new instance of MockReprocessingUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReprocessingUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReprocessingUseCase {
+	mock := &MockReprocessingUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockReprocessingUseCase is an autogenerated mock type for the ReprocessingUseCase type
+type MockReprocessingUseCase struct {
+	mock.Mock
+}
+
+type MockReprocessingUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReprocessingUseCase) EXPECT() *MockReprocessingUseCase_Expecter {
+	return &MockReprocessingUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Reprocess provides a mock function for the type MockReprocessingUseCase
+func (_mock *MockReprocessingUseCase) Reprocess(ctx context.Context, from time.Time, to time.Time, progress ports.JobProgressFunc) (*entities.ReprocessResult, error) {
+	ret := _mock.Called(ctx, from, to, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reprocess")
+	}
+
+	var r0 *entities.ReprocessResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, ports.JobProgressFunc) (*entities.ReprocessResult, error)); ok {
+		return returnFunc(ctx, from, to, progress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, ports.JobProgressFunc) *entities.ReprocessResult); ok {
+		r0 = returnFunc(ctx, from, to, progress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ReprocessResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, ports.JobProgressFunc) error); ok {
+		r1 = returnFunc(ctx, from, to, progress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockReprocessingUseCase_Reprocess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reprocess'
+type MockReprocessingUseCase_Reprocess_Call struct {
+	*mock.Call
+}
+
+// Reprocess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from time.Time
+//   - to time.Time
+//   - progress ports.JobProgressFunc
+func (_e *MockReprocessingUseCase_Expecter) Reprocess(ctx interface{}, from interface{}, to interface{}, progress interface{}) *MockReprocessingUseCase_Reprocess_Call {
+	return &MockReprocessingUseCase_Reprocess_Call{Call: _e.mock.On("Reprocess", ctx, from, to, progress)}
+}
+
+func (_c *MockReprocessingUseCase_Reprocess_Call) Run(run func(ctx context.Context, from time.Time, to time.Time, progress ports.JobProgressFunc)) *MockReprocessingUseCase_Reprocess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 ports.JobProgressFunc
+		if args[3] != nil {
+			arg3 = args[3].(ports.JobProgressFunc)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReprocessingUseCase_Reprocess_Call) Return(reprocessResult *entities.ReprocessResult, err error) *MockReprocessingUseCase_Reprocess_Call {
+	_c.Call.Return(reprocessResult, err)
+	return _c
+}
+
+func (_c *MockReprocessingUseCase_Reprocess_Call) RunAndReturn(run func(ctx context.Context, from time.Time, to time.Time, progress ports.JobProgressFunc) (*entities.ReprocessResult, error)) *MockReprocessingUseCase_Reprocess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function for the type MockReprocessingUseCase
+func (_mock *MockReprocessingUseCase) Start(ctx context.Context, from time.Time, to time.Time) (*entities.Job, error) {
+	ret := _mock.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 *entities.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) (*entities.Job, error)); ok {
+		return returnFunc(ctx, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) *entities.Job); ok {
+		r0 = returnFunc(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockReprocessingUseCase_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockReprocessingUseCase_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from time.Time
+//   - to time.Time
+func (_e *MockReprocessingUseCase_Expecter) Start(ctx interface{}, from interface{}, to interface{}) *MockReprocessingUseCase_Start_Call {
+	return &MockReprocessingUseCase_Start_Call{Call: _e.mock.On("Start", ctx, from, to)}
+}
+
+func (_c *MockReprocessingUseCase_Start_Call) Run(run func(ctx context.Context, from time.Time, to time.Time)) *MockReprocessingUseCase_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReprocessingUseCase_Start_Call) Return(job *entities.Job, err error) *MockReprocessingUseCase_Start_Call {
+	_c.Call.Return(job, err)
+	return _c
+}
+
+func (_c *MockReprocessingUseCase_Start_Call) RunAndReturn(run func(ctx context.Context, from time.Time, to time.Time) (*entities.Job, error)) *MockReprocessingUseCase_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,552 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockResearchSharingUseCase creates a new instance of MockResearchSharingUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockResearchSharingUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockResearchSharingUseCase {
+	mock := &MockResearchSharingUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockResearchSharingUseCase is an autogenerated mock type for the ResearchSharingUseCase type
+type MockResearchSharingUseCase struct {
+	mock.Mock
+}
+
+type MockResearchSharingUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockResearchSharingUseCase) EXPECT() *MockResearchSharingUseCase_Expecter {
+	return &MockResearchSharingUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function for the type MockResearchSharingUseCase
+func (_mock *MockResearchSharingUseCase) Authenticate(ctx context.Context, secret string) (*entities.ResearchToken, error) {
+	ret := _mock.Called(ctx, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *entities.ResearchToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.ResearchToken, error)); ok {
+		return returnFunc(ctx, secret)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.ResearchToken); ok {
+		r0 = returnFunc(ctx, secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResearchToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResearchSharingUseCase_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockResearchSharingUseCase_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secret string
+func (_e *MockResearchSharingUseCase_Expecter) Authenticate(ctx interface{}, secret interface{}) *MockResearchSharingUseCase_Authenticate_Call {
+	return &MockResearchSharingUseCase_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, secret)}
+}
+
+func (_c *MockResearchSharingUseCase_Authenticate_Call) Run(run func(ctx context.Context, secret string)) *MockResearchSharingUseCase_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_Authenticate_Call) Return(researchToken *entities.ResearchToken, err error) *MockResearchSharingUseCase_Authenticate_Call {
+	_c.Call.Return(researchToken, err)
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_Authenticate_Call) RunAndReturn(run func(ctx context.Context, secret string) (*entities.ResearchToken, error)) *MockResearchSharingUseCase_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateToken provides a mock function for the type MockResearchSharingUseCase
+func (_mock *MockResearchSharingUseCase) CreateToken(ctx context.Context, request entities.ResearchTokenRequest) (*entities.ResearchToken, string, error) {
+	ret := _mock.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateToken")
+	}
+
+	var r0 *entities.ResearchToken
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.ResearchTokenRequest) (*entities.ResearchToken, string, error)); ok {
+		return returnFunc(ctx, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.ResearchTokenRequest) *entities.ResearchToken); ok {
+		r0 = returnFunc(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResearchToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.ResearchTokenRequest) string); ok {
+		r1 = returnFunc(ctx, request)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, entities.ResearchTokenRequest) error); ok {
+		r2 = returnFunc(ctx, request)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockResearchSharingUseCase_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
+type MockResearchSharingUseCase_CreateToken_Call struct {
+	*mock.Call
+}
+
+// CreateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request entities.ResearchTokenRequest
+func (_e *MockResearchSharingUseCase_Expecter) CreateToken(ctx interface{}, request interface{}) *MockResearchSharingUseCase_CreateToken_Call {
+	return &MockResearchSharingUseCase_CreateToken_Call{Call: _e.mock.On("CreateToken", ctx, request)}
+}
+
+func (_c *MockResearchSharingUseCase_CreateToken_Call) Run(run func(ctx context.Context, request entities.ResearchTokenRequest)) *MockResearchSharingUseCase_CreateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 entities.ResearchTokenRequest
+		if args[1] != nil {
+			arg1 = args[1].(entities.ResearchTokenRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_CreateToken_Call) Return(researchToken *entities.ResearchToken, s string, err error) *MockResearchSharingUseCase_CreateToken_Call {
+	_c.Call.Return(researchToken, s, err)
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_CreateToken_Call) RunAndReturn(run func(ctx context.Context, request entities.ResearchTokenRequest) (*entities.ResearchToken, string, error)) *MockResearchSharingUseCase_CreateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTokens provides a mock function for the type MockResearchSharingUseCase
+func (_mock *MockResearchSharingUseCase) ListTokens(ctx context.Context) ([]*entities.ResearchToken, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTokens")
+	}
+
+	var r0 []*entities.ResearchToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.ResearchToken, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.ResearchToken); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ResearchToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResearchSharingUseCase_ListTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTokens'
+type MockResearchSharingUseCase_ListTokens_Call struct {
+	*mock.Call
+}
+
+// ListTokens is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockResearchSharingUseCase_Expecter) ListTokens(ctx interface{}) *MockResearchSharingUseCase_ListTokens_Call {
+	return &MockResearchSharingUseCase_ListTokens_Call{Call: _e.mock.On("ListTokens", ctx)}
+}
+
+func (_c *MockResearchSharingUseCase_ListTokens_Call) Run(run func(ctx context.Context)) *MockResearchSharingUseCase_ListTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_ListTokens_Call) Return(researchTokens []*entities.ResearchToken, err error) *MockResearchSharingUseCase_ListTokens_Call {
+	_c.Call.Return(researchTokens, err)
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_ListTokens_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.ResearchToken, error)) *MockResearchSharingUseCase_ListTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeToken provides a mock function for the type MockResearchSharingUseCase
+func (_mock *MockResearchSharingUseCase) RevokeToken(ctx context.Context, id string) (*entities.ResearchToken, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 *entities.ResearchToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.ResearchToken, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.ResearchToken); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResearchToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResearchSharingUseCase_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type MockResearchSharingUseCase_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockResearchSharingUseCase_Expecter) RevokeToken(ctx interface{}, id interface{}) *MockResearchSharingUseCase_RevokeToken_Call {
+	return &MockResearchSharingUseCase_RevokeToken_Call{Call: _e.mock.On("RevokeToken", ctx, id)}
+}
+
+func (_c *MockResearchSharingUseCase_RevokeToken_Call) Run(run func(ctx context.Context, id string)) *MockResearchSharingUseCase_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_RevokeToken_Call) Return(researchToken *entities.ResearchToken, err error) *MockResearchSharingUseCase_RevokeToken_Call {
+	_c.Call.Return(researchToken, err)
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_RevokeToken_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.ResearchToken, error)) *MockResearchSharingUseCase_RevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Telemetry provides a mock function for the type MockResearchSharingUseCase
+func (_mock *MockResearchSharingUseCase) Telemetry(ctx context.Context, token *entities.ResearchToken, zoneID string, sensorType string, from time.Time, to time.Time) ([]*entities.AnonymizedReading, error) {
+	ret := _mock.Called(ctx, token, zoneID, sensorType, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Telemetry")
+	}
+
+	var r0 []*entities.AnonymizedReading
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.ResearchToken, string, string, time.Time, time.Time) ([]*entities.AnonymizedReading, error)); ok {
+		return returnFunc(ctx, token, zoneID, sensorType, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.ResearchToken, string, string, time.Time, time.Time) []*entities.AnonymizedReading); ok {
+		r0 = returnFunc(ctx, token, zoneID, sensorType, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.AnonymizedReading)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.ResearchToken, string, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, token, zoneID, sensorType, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResearchSharingUseCase_Telemetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Telemetry'
+type MockResearchSharingUseCase_Telemetry_Call struct {
+	*mock.Call
+}
+
+// Telemetry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *entities.ResearchToken
+//   - zoneID string
+//   - sensorType string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockResearchSharingUseCase_Expecter) Telemetry(ctx interface{}, token interface{}, zoneID interface{}, sensorType interface{}, from interface{}, to interface{}) *MockResearchSharingUseCase_Telemetry_Call {
+	return &MockResearchSharingUseCase_Telemetry_Call{Call: _e.mock.On("Telemetry", ctx, token, zoneID, sensorType, from, to)}
+}
+
+func (_c *MockResearchSharingUseCase_Telemetry_Call) Run(run func(ctx context.Context, token *entities.ResearchToken, zoneID string, sensorType string, from time.Time, to time.Time)) *MockResearchSharingUseCase_Telemetry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.ResearchToken
+		if args[1] != nil {
+			arg1 = args[1].(*entities.ResearchToken)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		var arg5 time.Time
+		if args[5] != nil {
+			arg5 = args[5].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_Telemetry_Call) Return(anonymizedReadings []*entities.AnonymizedReading, err error) *MockResearchSharingUseCase_Telemetry_Call {
+	_c.Call.Return(anonymizedReadings, err)
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_Telemetry_Call) RunAndReturn(run func(ctx context.Context, token *entities.ResearchToken, zoneID string, sensorType string, from time.Time, to time.Time) ([]*entities.AnonymizedReading, error)) *MockResearchSharingUseCase_Telemetry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Usage provides a mock function for the type MockResearchSharingUseCase
+func (_mock *MockResearchSharingUseCase) Usage(ctx context.Context, id string, from time.Time, to time.Time) ([]*entities.ResearchTokenUsage, error) {
+	ret := _mock.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Usage")
+	}
+
+	var r0 []*entities.ResearchTokenUsage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]*entities.ResearchTokenUsage, error)); ok {
+		return returnFunc(ctx, id, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []*entities.ResearchTokenUsage); ok {
+		r0 = returnFunc(ctx, id, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ResearchTokenUsage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResearchSharingUseCase_Usage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Usage'
+type MockResearchSharingUseCase_Usage_Call struct {
+	*mock.Call
+}
+
+// Usage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockResearchSharingUseCase_Expecter) Usage(ctx interface{}, id interface{}, from interface{}, to interface{}) *MockResearchSharingUseCase_Usage_Call {
+	return &MockResearchSharingUseCase_Usage_Call{Call: _e.mock.On("Usage", ctx, id, from, to)}
+}
+
+func (_c *MockResearchSharingUseCase_Usage_Call) Run(run func(ctx context.Context, id string, from time.Time, to time.Time)) *MockResearchSharingUseCase_Usage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_Usage_Call) Return(researchTokenUsages []*entities.ResearchTokenUsage, err error) *MockResearchSharingUseCase_Usage_Call {
+	_c.Call.Return(researchTokenUsages, err)
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_Usage_Call) RunAndReturn(run func(ctx context.Context, id string, from time.Time, to time.Time) ([]*entities.ResearchTokenUsage, error)) *MockResearchSharingUseCase_Usage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Zones provides a mock function for the type MockResearchSharingUseCase
+func (_mock *MockResearchSharingUseCase) Zones(ctx context.Context, token *entities.ResearchToken) ([]*entities.Zone, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Zones")
+	}
+
+	var r0 []*entities.Zone
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.ResearchToken) ([]*entities.Zone, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.ResearchToken) []*entities.Zone); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Zone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.ResearchToken) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResearchSharingUseCase_Zones_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Zones'
+type MockResearchSharingUseCase_Zones_Call struct {
+	*mock.Call
+}
+
+// Zones is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *entities.ResearchToken
+func (_e *MockResearchSharingUseCase_Expecter) Zones(ctx interface{}, token interface{}) *MockResearchSharingUseCase_Zones_Call {
+	return &MockResearchSharingUseCase_Zones_Call{Call: _e.mock.On("Zones", ctx, token)}
+}
+
+func (_c *MockResearchSharingUseCase_Zones_Call) Run(run func(ctx context.Context, token *entities.ResearchToken)) *MockResearchSharingUseCase_Zones_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.ResearchToken
+		if args[1] != nil {
+			arg1 = args[1].(*entities.ResearchToken)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_Zones_Call) Return(zones []*entities.Zone, err error) *MockResearchSharingUseCase_Zones_Call {
+	_c.Call.Return(zones, err)
+	return _c
+}
+
+func (_c *MockResearchSharingUseCase_Zones_Call) RunAndReturn(run func(ctx context.Context, token *entities.ResearchToken) ([]*entities.Zone, error)) *MockResearchSharingUseCase_Zones_Call {
+	_c.Call.Return(run)
+	return _c
+}
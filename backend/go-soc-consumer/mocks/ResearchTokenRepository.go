@@ -0,0 +1,501 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockResearchTokenRepository creates a new instance of MockResearchTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockResearchTokenRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockResearchTokenRepository {
+	mock := &MockResearchTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockResearchTokenRepository is an autogenerated mock type for the ResearchTokenRepository type
+type MockResearchTokenRepository struct {
+	mock.Mock
+}
+
+type MockResearchTokenRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockResearchTokenRepository) EXPECT() *MockResearchTokenRepository_Expecter {
+	return &MockResearchTokenRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockResearchTokenRepository
+func (_mock *MockResearchTokenRepository) Create(ctx context.Context, token *entities.ResearchToken) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.ResearchToken) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockResearchTokenRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockResearchTokenRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *entities.ResearchToken
+func (_e *MockResearchTokenRepository_Expecter) Create(ctx interface{}, token interface{}) *MockResearchTokenRepository_Create_Call {
+	return &MockResearchTokenRepository_Create_Call{Call: _e.mock.On("Create", ctx, token)}
+}
+
+func (_c *MockResearchTokenRepository_Create_Call) Run(run func(ctx context.Context, token *entities.ResearchToken)) *MockResearchTokenRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.ResearchToken
+		if args[1] != nil {
+			arg1 = args[1].(*entities.ResearchToken)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_Create_Call) Return(err error) *MockResearchTokenRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_Create_Call) RunAndReturn(run func(ctx context.Context, token *entities.ResearchToken) error) *MockResearchTokenRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByHash provides a mock function for the type MockResearchTokenRepository
+func (_mock *MockResearchTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entities.ResearchToken, error) {
+	ret := _mock.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByHash")
+	}
+
+	var r0 *entities.ResearchToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.ResearchToken, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.ResearchToken); ok {
+		r0 = returnFunc(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResearchToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResearchTokenRepository_FindByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByHash'
+type MockResearchTokenRepository_FindByHash_Call struct {
+	*mock.Call
+}
+
+// FindByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockResearchTokenRepository_Expecter) FindByHash(ctx interface{}, tokenHash interface{}) *MockResearchTokenRepository_FindByHash_Call {
+	return &MockResearchTokenRepository_FindByHash_Call{Call: _e.mock.On("FindByHash", ctx, tokenHash)}
+}
+
+func (_c *MockResearchTokenRepository_FindByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *MockResearchTokenRepository_FindByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_FindByHash_Call) Return(researchToken *entities.ResearchToken, err error) *MockResearchTokenRepository_FindByHash_Call {
+	_c.Call.Return(researchToken, err)
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_FindByHash_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (*entities.ResearchToken, error)) *MockResearchTokenRepository_FindByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockResearchTokenRepository
+func (_mock *MockResearchTokenRepository) FindByID(ctx context.Context, id string) (*entities.ResearchToken, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.ResearchToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.ResearchToken, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.ResearchToken); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ResearchToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResearchTokenRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockResearchTokenRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockResearchTokenRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockResearchTokenRepository_FindByID_Call {
+	return &MockResearchTokenRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockResearchTokenRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockResearchTokenRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_FindByID_Call) Return(researchToken *entities.ResearchToken, err error) *MockResearchTokenRepository_FindByID_Call {
+	_c.Call.Return(researchToken, err)
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.ResearchToken, error)) *MockResearchTokenRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockResearchTokenRepository
+func (_mock *MockResearchTokenRepository) List(ctx context.Context) ([]*entities.ResearchToken, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.ResearchToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.ResearchToken, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.ResearchToken); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ResearchToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResearchTokenRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockResearchTokenRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockResearchTokenRepository_Expecter) List(ctx interface{}) *MockResearchTokenRepository_List_Call {
+	return &MockResearchTokenRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *MockResearchTokenRepository_List_Call) Run(run func(ctx context.Context)) *MockResearchTokenRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_List_Call) Return(researchTokens []*entities.ResearchToken, err error) *MockResearchTokenRepository_List_Call {
+	_c.Call.Return(researchTokens, err)
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.ResearchToken, error)) *MockResearchTokenRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordUsage provides a mock function for the type MockResearchTokenRepository
+func (_mock *MockResearchTokenRepository) RecordUsage(ctx context.Context, tokenID string, day time.Time, readings int) error {
+	ret := _mock.Called(ctx, tokenID, day, readings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordUsage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, int) error); ok {
+		r0 = returnFunc(ctx, tokenID, day, readings)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockResearchTokenRepository_RecordUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordUsage'
+type MockResearchTokenRepository_RecordUsage_Call struct {
+	*mock.Call
+}
+
+// RecordUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenID string
+//   - day time.Time
+//   - readings int
+func (_e *MockResearchTokenRepository_Expecter) RecordUsage(ctx interface{}, tokenID interface{}, day interface{}, readings interface{}) *MockResearchTokenRepository_RecordUsage_Call {
+	return &MockResearchTokenRepository_RecordUsage_Call{Call: _e.mock.On("RecordUsage", ctx, tokenID, day, readings)}
+}
+
+func (_c *MockResearchTokenRepository_RecordUsage_Call) Run(run func(ctx context.Context, tokenID string, day time.Time, readings int)) *MockResearchTokenRepository_RecordUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_RecordUsage_Call) Return(err error) *MockResearchTokenRepository_RecordUsage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_RecordUsage_Call) RunAndReturn(run func(ctx context.Context, tokenID string, day time.Time, readings int) error) *MockResearchTokenRepository_RecordUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockResearchTokenRepository
+func (_mock *MockResearchTokenRepository) Update(ctx context.Context, token *entities.ResearchToken) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.ResearchToken) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockResearchTokenRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockResearchTokenRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *entities.ResearchToken
+func (_e *MockResearchTokenRepository_Expecter) Update(ctx interface{}, token interface{}) *MockResearchTokenRepository_Update_Call {
+	return &MockResearchTokenRepository_Update_Call{Call: _e.mock.On("Update", ctx, token)}
+}
+
+func (_c *MockResearchTokenRepository_Update_Call) Run(run func(ctx context.Context, token *entities.ResearchToken)) *MockResearchTokenRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.ResearchToken
+		if args[1] != nil {
+			arg1 = args[1].(*entities.ResearchToken)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_Update_Call) Return(err error) *MockResearchTokenRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_Update_Call) RunAndReturn(run func(ctx context.Context, token *entities.ResearchToken) error) *MockResearchTokenRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Usage provides a mock function for the type MockResearchTokenRepository
+func (_mock *MockResearchTokenRepository) Usage(ctx context.Context, tokenID string, from time.Time, to time.Time) ([]*entities.ResearchTokenUsage, error) {
+	ret := _mock.Called(ctx, tokenID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Usage")
+	}
+
+	var r0 []*entities.ResearchTokenUsage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]*entities.ResearchTokenUsage, error)); ok {
+		return returnFunc(ctx, tokenID, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []*entities.ResearchTokenUsage); ok {
+		r0 = returnFunc(ctx, tokenID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ResearchTokenUsage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, tokenID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResearchTokenRepository_Usage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Usage'
+type MockResearchTokenRepository_Usage_Call struct {
+	*mock.Call
+}
+
+// Usage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenID string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockResearchTokenRepository_Expecter) Usage(ctx interface{}, tokenID interface{}, from interface{}, to interface{}) *MockResearchTokenRepository_Usage_Call {
+	return &MockResearchTokenRepository_Usage_Call{Call: _e.mock.On("Usage", ctx, tokenID, from, to)}
+}
+
+func (_c *MockResearchTokenRepository_Usage_Call) Run(run func(ctx context.Context, tokenID string, from time.Time, to time.Time)) *MockResearchTokenRepository_Usage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_Usage_Call) Return(researchTokenUsages []*entities.ResearchTokenUsage, err error) *MockResearchTokenRepository_Usage_Call {
+	_c.Call.Return(researchTokenUsages, err)
+	return _c
+}
+
+func (_c *MockResearchTokenRepository_Usage_Call) RunAndReturn(run func(ctx context.Context, tokenID string, from time.Time, to time.Time) ([]*entities.ResearchTokenUsage, error)) *MockResearchTokenRepository_Usage_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,117 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockRetentionRepository creates a new instance of MockRetentionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRetentionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRetentionRepository {
+	mock := &MockRetentionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRetentionRepository is an autogenerated mock type for the RetentionRepository type
+type MockRetentionRepository struct {
+	mock.Mock
+}
+
+type MockRetentionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRetentionRepository) EXPECT() *MockRetentionRepository_Expecter {
+	return &MockRetentionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Prune provides a mock function for the type MockRetentionRepository
+func (_mock *MockRetentionRepository) Prune(ctx context.Context, dataset string, before time.Time, limit int) (int, error) {
+	ret := _mock.Called(ctx, dataset, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Prune")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, int) (int, error)); ok {
+		return returnFunc(ctx, dataset, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, int) int); ok {
+		r0 = returnFunc(ctx, dataset, before, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, dataset, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRetentionRepository_Prune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prune'
+type MockRetentionRepository_Prune_Call struct {
+	*mock.Call
+}
+
+// Prune is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dataset string
+//   - before time.Time
+//   - limit int
+func (_e *MockRetentionRepository_Expecter) Prune(ctx interface{}, dataset interface{}, before interface{}, limit interface{}) *MockRetentionRepository_Prune_Call {
+	return &MockRetentionRepository_Prune_Call{Call: _e.mock.On("Prune", ctx, dataset, before, limit)}
+}
+
+func (_c *MockRetentionRepository_Prune_Call) Run(run func(ctx context.Context, dataset string, before time.Time, limit int)) *MockRetentionRepository_Prune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionRepository_Prune_Call) Return(n int, err error) *MockRetentionRepository_Prune_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRetentionRepository_Prune_Call) RunAndReturn(run func(ctx context.Context, dataset string, before time.Time, limit int) (int, error)) *MockRetentionRepository_Prune_Call {
+	_c.Call.Return(run)
+	return _c
+}
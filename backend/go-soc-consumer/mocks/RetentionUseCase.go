@@ -0,0 +1,140 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockRetentionUseCase creates a new instance of MockRetentionUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRetentionUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRetentionUseCase {
+	mock := &MockRetentionUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRetentionUseCase is an autogenerated mock type for the RetentionUseCase type
+type MockRetentionUseCase struct {
+	mock.Mock
+}
+
+type MockRetentionUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRetentionUseCase) EXPECT() *MockRetentionUseCase_Expecter {
+	return &MockRetentionUseCase_Expecter{mock: &_m.Mock}
+}
+
+// PruneOnce provides a mock function for the type MockRetentionUseCase
+func (_mock *MockRetentionUseCase) PruneOnce(ctx context.Context) (map[string]int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneOnce")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string]int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string]int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRetentionUseCase_PruneOnce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneOnce'
+type MockRetentionUseCase_PruneOnce_Call struct {
+	*mock.Call
+}
+
+// PruneOnce is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRetentionUseCase_Expecter) PruneOnce(ctx interface{}) *MockRetentionUseCase_PruneOnce_Call {
+	return &MockRetentionUseCase_PruneOnce_Call{Call: _e.mock.On("PruneOnce", ctx)}
+}
+
+func (_c *MockRetentionUseCase_PruneOnce_Call) Run(run func(ctx context.Context)) *MockRetentionUseCase_PruneOnce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionUseCase_PruneOnce_Call) Return(mp map[string]int, err error) *MockRetentionUseCase_PruneOnce_Call {
+	_c.Call.Return(mp, err)
+	return _c
+}
+
+func (_c *MockRetentionUseCase_PruneOnce_Call) RunAndReturn(run func(ctx context.Context) (map[string]int, error)) *MockRetentionUseCase_PruneOnce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockRetentionUseCase
+func (_mock *MockRetentionUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockRetentionUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockRetentionUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRetentionUseCase_Expecter) Run(ctx interface{}) *MockRetentionUseCase_Run_Call {
+	return &MockRetentionUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockRetentionUseCase_Run_Call) Run(run func(ctx context.Context)) *MockRetentionUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRetentionUseCase_Run_Call) Return() *MockRetentionUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRetentionUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockRetentionUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
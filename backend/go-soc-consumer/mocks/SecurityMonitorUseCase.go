@@ -0,0 +1,293 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSecurityMonitorUseCase creates a new instance of MockSecurityMonitorUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecurityMonitorUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecurityMonitorUseCase {
+	mock := &MockSecurityMonitorUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecurityMonitorUseCase is an autogenerated mock type for the SecurityMonitorUseCase type
+type MockSecurityMonitorUseCase struct {
+	mock.Mock
+}
+
+type MockSecurityMonitorUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecurityMonitorUseCase) EXPECT() *MockSecurityMonitorUseCase_Expecter {
+	return &MockSecurityMonitorUseCase_Expecter{mock: &_m.Mock}
+}
+
+// HandleCommandMessage provides a mock function for the type MockSecurityMonitorUseCase
+func (_mock *MockSecurityMonitorUseCase) HandleCommandMessage(ctx context.Context, topic string, payload []byte) error {
+	ret := _mock.Called(ctx, topic, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleCommandMessage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, topic, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecurityMonitorUseCase_HandleCommandMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleCommandMessage'
+type MockSecurityMonitorUseCase_HandleCommandMessage_Call struct {
+	*mock.Call
+}
+
+// HandleCommandMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - topic string
+//   - payload []byte
+func (_e *MockSecurityMonitorUseCase_Expecter) HandleCommandMessage(ctx interface{}, topic interface{}, payload interface{}) *MockSecurityMonitorUseCase_HandleCommandMessage_Call {
+	return &MockSecurityMonitorUseCase_HandleCommandMessage_Call{Call: _e.mock.On("HandleCommandMessage", ctx, topic, payload)}
+}
+
+func (_c *MockSecurityMonitorUseCase_HandleCommandMessage_Call) Run(run func(ctx context.Context, topic string, payload []byte)) *MockSecurityMonitorUseCase_HandleCommandMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecurityMonitorUseCase_HandleCommandMessage_Call) Return(err error) *MockSecurityMonitorUseCase_HandleCommandMessage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecurityMonitorUseCase_HandleCommandMessage_Call) RunAndReturn(run func(ctx context.Context, topic string, payload []byte) error) *MockSecurityMonitorUseCase_HandleCommandMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ObserveConnectionMismatch provides a mock function for the type MockSecurityMonitorUseCase
+func (_mock *MockSecurityMonitorUseCase) ObserveConnectionMismatch(ctx context.Context, connection *entities.DeviceConnection) {
+	_mock.Called(ctx, connection)
+	return
+}
+
+// MockSecurityMonitorUseCase_ObserveConnectionMismatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveConnectionMismatch'
+type MockSecurityMonitorUseCase_ObserveConnectionMismatch_Call struct {
+	*mock.Call
+}
+
+// ObserveConnectionMismatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - connection *entities.DeviceConnection
+func (_e *MockSecurityMonitorUseCase_Expecter) ObserveConnectionMismatch(ctx interface{}, connection interface{}) *MockSecurityMonitorUseCase_ObserveConnectionMismatch_Call {
+	return &MockSecurityMonitorUseCase_ObserveConnectionMismatch_Call{Call: _e.mock.On("ObserveConnectionMismatch", ctx, connection)}
+}
+
+func (_c *MockSecurityMonitorUseCase_ObserveConnectionMismatch_Call) Run(run func(ctx context.Context, connection *entities.DeviceConnection)) *MockSecurityMonitorUseCase_ObserveConnectionMismatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceConnection
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceConnection)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecurityMonitorUseCase_ObserveConnectionMismatch_Call) Return() *MockSecurityMonitorUseCase_ObserveConnectionMismatch_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSecurityMonitorUseCase_ObserveConnectionMismatch_Call) RunAndReturn(run func(ctx context.Context, connection *entities.DeviceConnection)) *MockSecurityMonitorUseCase_ObserveConnectionMismatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ObserveRegistration provides a mock function for the type MockSecurityMonitorUseCase
+func (_mock *MockSecurityMonitorUseCase) ObserveRegistration(ctx context.Context, message *entities.DeviceRegistrationMessage) {
+	_mock.Called(ctx, message)
+	return
+}
+
+// MockSecurityMonitorUseCase_ObserveRegistration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveRegistration'
+type MockSecurityMonitorUseCase_ObserveRegistration_Call struct {
+	*mock.Call
+}
+
+// ObserveRegistration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *entities.DeviceRegistrationMessage
+func (_e *MockSecurityMonitorUseCase_Expecter) ObserveRegistration(ctx interface{}, message interface{}) *MockSecurityMonitorUseCase_ObserveRegistration_Call {
+	return &MockSecurityMonitorUseCase_ObserveRegistration_Call{Call: _e.mock.On("ObserveRegistration", ctx, message)}
+}
+
+func (_c *MockSecurityMonitorUseCase_ObserveRegistration_Call) Run(run func(ctx context.Context, message *entities.DeviceRegistrationMessage)) *MockSecurityMonitorUseCase_ObserveRegistration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.DeviceRegistrationMessage
+		if args[1] != nil {
+			arg1 = args[1].(*entities.DeviceRegistrationMessage)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecurityMonitorUseCase_ObserveRegistration_Call) Return() *MockSecurityMonitorUseCase_ObserveRegistration_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSecurityMonitorUseCase_ObserveRegistration_Call) RunAndReturn(run func(ctx context.Context, message *entities.DeviceRegistrationMessage)) *MockSecurityMonitorUseCase_ObserveRegistration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ObserveSensorData provides a mock function for the type MockSecurityMonitorUseCase
+func (_mock *MockSecurityMonitorUseCase) ObserveSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) {
+	_mock.Called(ctx, data)
+	return
+}
+
+// MockSecurityMonitorUseCase_ObserveSensorData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveSensorData'
+type MockSecurityMonitorUseCase_ObserveSensorData_Call struct {
+	*mock.Call
+}
+
+// ObserveSensorData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *entities.SensorTemperatureHumidity
+func (_e *MockSecurityMonitorUseCase_Expecter) ObserveSensorData(ctx interface{}, data interface{}) *MockSecurityMonitorUseCase_ObserveSensorData_Call {
+	return &MockSecurityMonitorUseCase_ObserveSensorData_Call{Call: _e.mock.On("ObserveSensorData", ctx, data)}
+}
+
+func (_c *MockSecurityMonitorUseCase_ObserveSensorData_Call) Run(run func(ctx context.Context, data *entities.SensorTemperatureHumidity)) *MockSecurityMonitorUseCase_ObserveSensorData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.SensorTemperatureHumidity
+		if args[1] != nil {
+			arg1 = args[1].(*entities.SensorTemperatureHumidity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecurityMonitorUseCase_ObserveSensorData_Call) Return() *MockSecurityMonitorUseCase_ObserveSensorData_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSecurityMonitorUseCase_ObserveSensorData_Call) RunAndReturn(run func(ctx context.Context, data *entities.SensorTemperatureHumidity)) *MockSecurityMonitorUseCase_ObserveSensorData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecentAlerts provides a mock function for the type MockSecurityMonitorUseCase
+func (_mock *MockSecurityMonitorUseCase) RecentAlerts(limit int) []*entities.SecurityAlert {
+	ret := _mock.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecentAlerts")
+	}
+
+	var r0 []*entities.SecurityAlert
+	if returnFunc, ok := ret.Get(0).(func(int) []*entities.SecurityAlert); ok {
+		r0 = returnFunc(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SecurityAlert)
+		}
+	}
+	return r0
+}
+
+// MockSecurityMonitorUseCase_RecentAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecentAlerts'
+type MockSecurityMonitorUseCase_RecentAlerts_Call struct {
+	*mock.Call
+}
+
+// RecentAlerts is a helper method to define mock.On call
+//   - limit int
+func (_e *MockSecurityMonitorUseCase_Expecter) RecentAlerts(limit interface{}) *MockSecurityMonitorUseCase_RecentAlerts_Call {
+	return &MockSecurityMonitorUseCase_RecentAlerts_Call{Call: _e.mock.On("RecentAlerts", limit)}
+}
+
+func (_c *MockSecurityMonitorUseCase_RecentAlerts_Call) Run(run func(limit int)) *MockSecurityMonitorUseCase_RecentAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecurityMonitorUseCase_RecentAlerts_Call) Return(securityAlerts []*entities.SecurityAlert) *MockSecurityMonitorUseCase_RecentAlerts_Call {
+	_c.Call.Return(securityAlerts)
+	return _c
+}
+
+func (_c *MockSecurityMonitorUseCase_RecentAlerts_Call) RunAndReturn(run func(limit int) []*entities.SecurityAlert) *MockSecurityMonitorUseCase_RecentAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
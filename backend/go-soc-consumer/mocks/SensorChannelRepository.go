@@ -0,0 +1,221 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSensorChannelRepository creates a new instance of MockSensorChannelRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSensorChannelRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSensorChannelRepository {
+	mock := &MockSensorChannelRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSensorChannelRepository is an autogenerated mock type for the SensorChannelRepository type
+type MockSensorChannelRepository struct {
+	mock.Mock
+}
+
+type MockSensorChannelRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSensorChannelRepository) EXPECT() *MockSensorChannelRepository_Expecter {
+	return &MockSensorChannelRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateMissing provides a mock function for the type MockSensorChannelRepository
+func (_mock *MockSensorChannelRepository) CreateMissing(ctx context.Context, channels []*entities.SensorChannel) error {
+	ret := _mock.Called(ctx, channels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMissing")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.SensorChannel) error); ok {
+		r0 = returnFunc(ctx, channels)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSensorChannelRepository_CreateMissing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMissing'
+type MockSensorChannelRepository_CreateMissing_Call struct {
+	*mock.Call
+}
+
+// CreateMissing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channels []*entities.SensorChannel
+func (_e *MockSensorChannelRepository_Expecter) CreateMissing(ctx interface{}, channels interface{}) *MockSensorChannelRepository_CreateMissing_Call {
+	return &MockSensorChannelRepository_CreateMissing_Call{Call: _e.mock.On("CreateMissing", ctx, channels)}
+}
+
+func (_c *MockSensorChannelRepository_CreateMissing_Call) Run(run func(ctx context.Context, channels []*entities.SensorChannel)) *MockSensorChannelRepository_CreateMissing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.SensorChannel
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.SensorChannel)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorChannelRepository_CreateMissing_Call) Return(err error) *MockSensorChannelRepository_CreateMissing_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSensorChannelRepository_CreateMissing_Call) RunAndReturn(run func(ctx context.Context, channels []*entities.SensorChannel) error) *MockSensorChannelRepository_CreateMissing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByDevice provides a mock function for the type MockSensorChannelRepository
+func (_mock *MockSensorChannelRepository) FindByDevice(ctx context.Context, macAddress string) ([]*entities.SensorChannel, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByDevice")
+	}
+
+	var r0 []*entities.SensorChannel
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entities.SensorChannel, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entities.SensorChannel); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SensorChannel)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSensorChannelRepository_FindByDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByDevice'
+type MockSensorChannelRepository_FindByDevice_Call struct {
+	*mock.Call
+}
+
+// FindByDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockSensorChannelRepository_Expecter) FindByDevice(ctx interface{}, macAddress interface{}) *MockSensorChannelRepository_FindByDevice_Call {
+	return &MockSensorChannelRepository_FindByDevice_Call{Call: _e.mock.On("FindByDevice", ctx, macAddress)}
+}
+
+func (_c *MockSensorChannelRepository_FindByDevice_Call) Run(run func(ctx context.Context, macAddress string)) *MockSensorChannelRepository_FindByDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorChannelRepository_FindByDevice_Call) Return(sensorChannels []*entities.SensorChannel, err error) *MockSensorChannelRepository_FindByDevice_Call {
+	_c.Call.Return(sensorChannels, err)
+	return _c
+}
+
+func (_c *MockSensorChannelRepository_FindByDevice_Call) RunAndReturn(run func(ctx context.Context, macAddress string) ([]*entities.SensorChannel, error)) *MockSensorChannelRepository_FindByDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockSensorChannelRepository
+func (_mock *MockSensorChannelRepository) Save(ctx context.Context, channel *entities.SensorChannel) error {
+	ret := _mock.Called(ctx, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.SensorChannel) error); ok {
+		r0 = returnFunc(ctx, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSensorChannelRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockSensorChannelRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel *entities.SensorChannel
+func (_e *MockSensorChannelRepository_Expecter) Save(ctx interface{}, channel interface{}) *MockSensorChannelRepository_Save_Call {
+	return &MockSensorChannelRepository_Save_Call{Call: _e.mock.On("Save", ctx, channel)}
+}
+
+func (_c *MockSensorChannelRepository_Save_Call) Run(run func(ctx context.Context, channel *entities.SensorChannel)) *MockSensorChannelRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.SensorChannel
+		if args[1] != nil {
+			arg1 = args[1].(*entities.SensorChannel)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorChannelRepository_Save_Call) Return(err error) *MockSensorChannelRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSensorChannelRepository_Save_Call) RunAndReturn(run func(ctx context.Context, channel *entities.SensorChannel) error) *MockSensorChannelRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
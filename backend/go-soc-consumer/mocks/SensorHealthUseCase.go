@@ -0,0 +1,324 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSensorHealthUseCase creates a new instance of MockSensorHealthUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSensorHealthUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSensorHealthUseCase {
+	mock := &MockSensorHealthUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSensorHealthUseCase is an autogenerated mock type for the SensorHealthUseCase type
+type MockSensorHealthUseCase struct {
+	mock.Mock
+}
+
+type MockSensorHealthUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSensorHealthUseCase) EXPECT() *MockSensorHealthUseCase_Expecter {
+	return &MockSensorHealthUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Degraded provides a mock function for the type MockSensorHealthUseCase
+func (_mock *MockSensorHealthUseCase) Degraded(ctx context.Context) ([]*entities.SensorStreamHealth, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Degraded")
+	}
+
+	var r0 []*entities.SensorStreamHealth
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.SensorStreamHealth, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.SensorStreamHealth); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SensorStreamHealth)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSensorHealthUseCase_Degraded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Degraded'
+type MockSensorHealthUseCase_Degraded_Call struct {
+	*mock.Call
+}
+
+// Degraded is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSensorHealthUseCase_Expecter) Degraded(ctx interface{}) *MockSensorHealthUseCase_Degraded_Call {
+	return &MockSensorHealthUseCase_Degraded_Call{Call: _e.mock.On("Degraded", ctx)}
+}
+
+func (_c *MockSensorHealthUseCase_Degraded_Call) Run(run func(ctx context.Context)) *MockSensorHealthUseCase_Degraded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorHealthUseCase_Degraded_Call) Return(sensorStreamHealths []*entities.SensorStreamHealth, err error) *MockSensorHealthUseCase_Degraded_Call {
+	_c.Call.Return(sensorStreamHealths, err)
+	return _c
+}
+
+func (_c *MockSensorHealthUseCase_Degraded_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.SensorStreamHealth, error)) *MockSensorHealthUseCase_Degraded_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ObserveSensorData provides a mock function for the type MockSensorHealthUseCase
+func (_mock *MockSensorHealthUseCase) ObserveSensorData(ctx context.Context, data *entities.SensorTemperatureHumidity) {
+	_mock.Called(ctx, data)
+	return
+}
+
+// MockSensorHealthUseCase_ObserveSensorData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveSensorData'
+type MockSensorHealthUseCase_ObserveSensorData_Call struct {
+	*mock.Call
+}
+
+// ObserveSensorData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *entities.SensorTemperatureHumidity
+func (_e *MockSensorHealthUseCase_Expecter) ObserveSensorData(ctx interface{}, data interface{}) *MockSensorHealthUseCase_ObserveSensorData_Call {
+	return &MockSensorHealthUseCase_ObserveSensorData_Call{Call: _e.mock.On("ObserveSensorData", ctx, data)}
+}
+
+func (_c *MockSensorHealthUseCase_ObserveSensorData_Call) Run(run func(ctx context.Context, data *entities.SensorTemperatureHumidity)) *MockSensorHealthUseCase_ObserveSensorData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.SensorTemperatureHumidity
+		if args[1] != nil {
+			arg1 = args[1].(*entities.SensorTemperatureHumidity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorHealthUseCase_ObserveSensorData_Call) Return() *MockSensorHealthUseCase_ObserveSensorData_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSensorHealthUseCase_ObserveSensorData_Call) RunAndReturn(run func(ctx context.Context, data *entities.SensorTemperatureHumidity)) *MockSensorHealthUseCase_ObserveSensorData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordCalibration provides a mock function for the type MockSensorHealthUseCase
+func (_mock *MockSensorHealthUseCase) RecordCalibration(ctx context.Context, macAddress string, calibratedAt time.Time) (*entities.Device, error) {
+	ret := _mock.Called(ctx, macAddress, calibratedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordCalibration")
+	}
+
+	var r0 *entities.Device
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (*entities.Device, error)); ok {
+		return returnFunc(ctx, macAddress, calibratedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) *entities.Device); ok {
+		r0 = returnFunc(ctx, macAddress, calibratedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Device)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, macAddress, calibratedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSensorHealthUseCase_RecordCalibration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordCalibration'
+type MockSensorHealthUseCase_RecordCalibration_Call struct {
+	*mock.Call
+}
+
+// RecordCalibration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - calibratedAt time.Time
+func (_e *MockSensorHealthUseCase_Expecter) RecordCalibration(ctx interface{}, macAddress interface{}, calibratedAt interface{}) *MockSensorHealthUseCase_RecordCalibration_Call {
+	return &MockSensorHealthUseCase_RecordCalibration_Call{Call: _e.mock.On("RecordCalibration", ctx, macAddress, calibratedAt)}
+}
+
+func (_c *MockSensorHealthUseCase_RecordCalibration_Call) Run(run func(ctx context.Context, macAddress string, calibratedAt time.Time)) *MockSensorHealthUseCase_RecordCalibration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorHealthUseCase_RecordCalibration_Call) Return(device *entities.Device, err error) *MockSensorHealthUseCase_RecordCalibration_Call {
+	_c.Call.Return(device, err)
+	return _c
+}
+
+func (_c *MockSensorHealthUseCase_RecordCalibration_Call) RunAndReturn(run func(ctx context.Context, macAddress string, calibratedAt time.Time) (*entities.Device, error)) *MockSensorHealthUseCase_RecordCalibration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockSensorHealthUseCase
+func (_mock *MockSensorHealthUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockSensorHealthUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockSensorHealthUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSensorHealthUseCase_Expecter) Run(ctx interface{}) *MockSensorHealthUseCase_Run_Call {
+	return &MockSensorHealthUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockSensorHealthUseCase_Run_Call) Run(run func(ctx context.Context)) *MockSensorHealthUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorHealthUseCase_Run_Call) Return() *MockSensorHealthUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockSensorHealthUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockSensorHealthUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Streams provides a mock function for the type MockSensorHealthUseCase
+func (_mock *MockSensorHealthUseCase) Streams(ctx context.Context) ([]*entities.SensorStreamHealth, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Streams")
+	}
+
+	var r0 []*entities.SensorStreamHealth
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.SensorStreamHealth, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.SensorStreamHealth); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SensorStreamHealth)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSensorHealthUseCase_Streams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Streams'
+type MockSensorHealthUseCase_Streams_Call struct {
+	*mock.Call
+}
+
+// Streams is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSensorHealthUseCase_Expecter) Streams(ctx interface{}) *MockSensorHealthUseCase_Streams_Call {
+	return &MockSensorHealthUseCase_Streams_Call{Call: _e.mock.On("Streams", ctx)}
+}
+
+func (_c *MockSensorHealthUseCase_Streams_Call) Run(run func(ctx context.Context)) *MockSensorHealthUseCase_Streams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorHealthUseCase_Streams_Call) Return(sensorStreamHealths []*entities.SensorStreamHealth, err error) *MockSensorHealthUseCase_Streams_Call {
+	_c.Call.Return(sensorStreamHealths, err)
+	return _c
+}
+
+func (_c *MockSensorHealthUseCase_Streams_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.SensorStreamHealth, error)) *MockSensorHealthUseCase_Streams_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,187 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSensorReadingsUseCase creates a new instance of MockSensorReadingsUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSensorReadingsUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSensorReadingsUseCase {
+	mock := &MockSensorReadingsUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSensorReadingsUseCase is an autogenerated mock type for the SensorReadingsUseCase type
+type MockSensorReadingsUseCase struct {
+	mock.Mock
+}
+
+type MockSensorReadingsUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSensorReadingsUseCase) EXPECT() *MockSensorReadingsUseCase_Expecter {
+	return &MockSensorReadingsUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Latest provides a mock function for the type MockSensorReadingsUseCase
+func (_mock *MockSensorReadingsUseCase) Latest(ctx context.Context, macAddresses []string) ([]*entities.SensorTemperatureHumidity, error) {
+	ret := _mock.Called(ctx, macAddresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Latest")
+	}
+
+	var r0 []*entities.SensorTemperatureHumidity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*entities.SensorTemperatureHumidity, error)); ok {
+		return returnFunc(ctx, macAddresses)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*entities.SensorTemperatureHumidity); ok {
+		r0 = returnFunc(ctx, macAddresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SensorTemperatureHumidity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, macAddresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSensorReadingsUseCase_Latest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Latest'
+type MockSensorReadingsUseCase_Latest_Call struct {
+	*mock.Call
+}
+
+// Latest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddresses []string
+func (_e *MockSensorReadingsUseCase_Expecter) Latest(ctx interface{}, macAddresses interface{}) *MockSensorReadingsUseCase_Latest_Call {
+	return &MockSensorReadingsUseCase_Latest_Call{Call: _e.mock.On("Latest", ctx, macAddresses)}
+}
+
+func (_c *MockSensorReadingsUseCase_Latest_Call) Run(run func(ctx context.Context, macAddresses []string)) *MockSensorReadingsUseCase_Latest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorReadingsUseCase_Latest_Call) Return(sensorTemperatureHumiditys []*entities.SensorTemperatureHumidity, err error) *MockSensorReadingsUseCase_Latest_Call {
+	_c.Call.Return(sensorTemperatureHumiditys, err)
+	return _c
+}
+
+func (_c *MockSensorReadingsUseCase_Latest_Call) RunAndReturn(run func(ctx context.Context, macAddresses []string) ([]*entities.SensorTemperatureHumidity, error)) *MockSensorReadingsUseCase_Latest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockSensorReadingsUseCase
+func (_mock *MockSensorReadingsUseCase) List(ctx context.Context, macAddress string, query entities.SensorReadingsQuery) ([]*entities.SensorTemperatureHumidity, entities.SensorReadingsQuery, error) {
+	ret := _mock.Called(ctx, macAddress, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*entities.SensorTemperatureHumidity
+	var r1 entities.SensorReadingsQuery
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.SensorReadingsQuery) ([]*entities.SensorTemperatureHumidity, entities.SensorReadingsQuery, error)); ok {
+		return returnFunc(ctx, macAddress, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, entities.SensorReadingsQuery) []*entities.SensorTemperatureHumidity); ok {
+		r0 = returnFunc(ctx, macAddress, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SensorTemperatureHumidity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, entities.SensorReadingsQuery) entities.SensorReadingsQuery); ok {
+		r1 = returnFunc(ctx, macAddress, query)
+	} else {
+		r1 = ret.Get(1).(entities.SensorReadingsQuery)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, entities.SensorReadingsQuery) error); ok {
+		r2 = returnFunc(ctx, macAddress, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockSensorReadingsUseCase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockSensorReadingsUseCase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - query entities.SensorReadingsQuery
+func (_e *MockSensorReadingsUseCase_Expecter) List(ctx interface{}, macAddress interface{}, query interface{}) *MockSensorReadingsUseCase_List_Call {
+	return &MockSensorReadingsUseCase_List_Call{Call: _e.mock.On("List", ctx, macAddress, query)}
+}
+
+func (_c *MockSensorReadingsUseCase_List_Call) Run(run func(ctx context.Context, macAddress string, query entities.SensorReadingsQuery)) *MockSensorReadingsUseCase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 entities.SensorReadingsQuery
+		if args[2] != nil {
+			arg2 = args[2].(entities.SensorReadingsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorReadingsUseCase_List_Call) Return(sensorTemperatureHumiditys []*entities.SensorTemperatureHumidity, sensorReadingsQuery entities.SensorReadingsQuery, err error) *MockSensorReadingsUseCase_List_Call {
+	_c.Call.Return(sensorTemperatureHumiditys, sensorReadingsQuery, err)
+	return _c
+}
+
+func (_c *MockSensorReadingsUseCase_List_Call) RunAndReturn(run func(ctx context.Context, macAddress string, query entities.SensorReadingsQuery) ([]*entities.SensorTemperatureHumidity, entities.SensorReadingsQuery, error)) *MockSensorReadingsUseCase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
 	mock "github.com/stretchr/testify/mock"
@@ -94,3 +95,306 @@ func (_c *MockSensorTemperatureHumidityRepository_Create_Call) RunAndReturn(run
 	_c.Call.Return(run)
 	return _c
 }
+
+// FindByMACAddress provides a mock function for the type MockSensorTemperatureHumidityRepository
+func (_mock *MockSensorTemperatureHumidityRepository) FindByMACAddress(ctx context.Context, macAddress string, from time.Time, to time.Time) ([]*entities.SensorTemperatureHumidity, error) {
+	ret := _mock.Called(ctx, macAddress, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByMACAddress")
+	}
+
+	var r0 []*entities.SensorTemperatureHumidity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]*entities.SensorTemperatureHumidity, error)); ok {
+		return returnFunc(ctx, macAddress, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []*entities.SensorTemperatureHumidity); ok {
+		r0 = returnFunc(ctx, macAddress, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SensorTemperatureHumidity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, macAddress, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSensorTemperatureHumidityRepository_FindByMACAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByMACAddress'
+type MockSensorTemperatureHumidityRepository_FindByMACAddress_Call struct {
+	*mock.Call
+}
+
+// FindByMACAddress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockSensorTemperatureHumidityRepository_Expecter) FindByMACAddress(ctx interface{}, macAddress interface{}, from interface{}, to interface{}) *MockSensorTemperatureHumidityRepository_FindByMACAddress_Call {
+	return &MockSensorTemperatureHumidityRepository_FindByMACAddress_Call{Call: _e.mock.On("FindByMACAddress", ctx, macAddress, from, to)}
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_FindByMACAddress_Call) Run(run func(ctx context.Context, macAddress string, from time.Time, to time.Time)) *MockSensorTemperatureHumidityRepository_FindByMACAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_FindByMACAddress_Call) Return(sensorTemperatureHumiditys []*entities.SensorTemperatureHumidity, err error) *MockSensorTemperatureHumidityRepository_FindByMACAddress_Call {
+	_c.Call.Return(sensorTemperatureHumiditys, err)
+	return _c
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_FindByMACAddress_Call) RunAndReturn(run func(ctx context.Context, macAddress string, from time.Time, to time.Time) ([]*entities.SensorTemperatureHumidity, error)) *MockSensorTemperatureHumidityRepository_FindByMACAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindLatest provides a mock function for the type MockSensorTemperatureHumidityRepository
+func (_mock *MockSensorTemperatureHumidityRepository) FindLatest(ctx context.Context, macAddresses []string) ([]*entities.SensorTemperatureHumidity, error) {
+	ret := _mock.Called(ctx, macAddresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatest")
+	}
+
+	var r0 []*entities.SensorTemperatureHumidity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*entities.SensorTemperatureHumidity, error)); ok {
+		return returnFunc(ctx, macAddresses)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*entities.SensorTemperatureHumidity); ok {
+		r0 = returnFunc(ctx, macAddresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SensorTemperatureHumidity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, macAddresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSensorTemperatureHumidityRepository_FindLatest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindLatest'
+type MockSensorTemperatureHumidityRepository_FindLatest_Call struct {
+	*mock.Call
+}
+
+// FindLatest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddresses []string
+func (_e *MockSensorTemperatureHumidityRepository_Expecter) FindLatest(ctx interface{}, macAddresses interface{}) *MockSensorTemperatureHumidityRepository_FindLatest_Call {
+	return &MockSensorTemperatureHumidityRepository_FindLatest_Call{Call: _e.mock.On("FindLatest", ctx, macAddresses)}
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_FindLatest_Call) Run(run func(ctx context.Context, macAddresses []string)) *MockSensorTemperatureHumidityRepository_FindLatest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_FindLatest_Call) Return(sensorTemperatureHumiditys []*entities.SensorTemperatureHumidity, err error) *MockSensorTemperatureHumidityRepository_FindLatest_Call {
+	_c.Call.Return(sensorTemperatureHumiditys, err)
+	return _c
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_FindLatest_Call) RunAndReturn(run func(ctx context.Context, macAddresses []string) ([]*entities.SensorTemperatureHumidity, error)) *MockSensorTemperatureHumidityRepository_FindLatest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByMACAddress provides a mock function for the type MockSensorTemperatureHumidityRepository
+func (_mock *MockSensorTemperatureHumidityRepository) ListByMACAddress(ctx context.Context, macAddress string, from time.Time, to time.Time, offset int, limit int) ([]*entities.SensorTemperatureHumidity, error) {
+	ret := _mock.Called(ctx, macAddress, from, to, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByMACAddress")
+	}
+
+	var r0 []*entities.SensorTemperatureHumidity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, int, int) ([]*entities.SensorTemperatureHumidity, error)); ok {
+		return returnFunc(ctx, macAddress, from, to, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, int, int) []*entities.SensorTemperatureHumidity); ok {
+		r0 = returnFunc(ctx, macAddress, from, to, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SensorTemperatureHumidity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time, int, int) error); ok {
+		r1 = returnFunc(ctx, macAddress, from, to, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSensorTemperatureHumidityRepository_ListByMACAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByMACAddress'
+type MockSensorTemperatureHumidityRepository_ListByMACAddress_Call struct {
+	*mock.Call
+}
+
+// ListByMACAddress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - from time.Time
+//   - to time.Time
+//   - offset int
+//   - limit int
+func (_e *MockSensorTemperatureHumidityRepository_Expecter) ListByMACAddress(ctx interface{}, macAddress interface{}, from interface{}, to interface{}, offset interface{}, limit interface{}) *MockSensorTemperatureHumidityRepository_ListByMACAddress_Call {
+	return &MockSensorTemperatureHumidityRepository_ListByMACAddress_Call{Call: _e.mock.On("ListByMACAddress", ctx, macAddress, from, to, offset, limit)}
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_ListByMACAddress_Call) Run(run func(ctx context.Context, macAddress string, from time.Time, to time.Time, offset int, limit int)) *MockSensorTemperatureHumidityRepository_ListByMACAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		var arg5 int
+		if args[5] != nil {
+			arg5 = args[5].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_ListByMACAddress_Call) Return(sensorTemperatureHumiditys []*entities.SensorTemperatureHumidity, err error) *MockSensorTemperatureHumidityRepository_ListByMACAddress_Call {
+	_c.Call.Return(sensorTemperatureHumiditys, err)
+	return _c
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_ListByMACAddress_Call) RunAndReturn(run func(ctx context.Context, macAddress string, from time.Time, to time.Time, offset int, limit int) ([]*entities.SensorTemperatureHumidity, error)) *MockSensorTemperatureHumidityRepository_ListByMACAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function for the type MockSensorTemperatureHumidityRepository
+func (_mock *MockSensorTemperatureHumidityRepository) Upsert(ctx context.Context, sensorData *entities.SensorTemperatureHumidity, window time.Duration) error {
+	ret := _mock.Called(ctx, sensorData, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.SensorTemperatureHumidity, time.Duration) error); ok {
+		r0 = returnFunc(ctx, sensorData, window)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSensorTemperatureHumidityRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type MockSensorTemperatureHumidityRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sensorData *entities.SensorTemperatureHumidity
+//   - window time.Duration
+func (_e *MockSensorTemperatureHumidityRepository_Expecter) Upsert(ctx interface{}, sensorData interface{}, window interface{}) *MockSensorTemperatureHumidityRepository_Upsert_Call {
+	return &MockSensorTemperatureHumidityRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, sensorData, window)}
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_Upsert_Call) Run(run func(ctx context.Context, sensorData *entities.SensorTemperatureHumidity, window time.Duration)) *MockSensorTemperatureHumidityRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.SensorTemperatureHumidity
+		if args[1] != nil {
+			arg1 = args[1].(*entities.SensorTemperatureHumidity)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_Upsert_Call) Return(err error) *MockSensorTemperatureHumidityRepository_Upsert_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSensorTemperatureHumidityRepository_Upsert_Call) RunAndReturn(run func(ctx context.Context, sensorData *entities.SensorTemperatureHumidity, window time.Duration) error) *MockSensorTemperatureHumidityRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
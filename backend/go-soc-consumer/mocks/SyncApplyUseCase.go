@@ -0,0 +1,107 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSyncApplyUseCase creates a new instance of MockSyncApplyUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSyncApplyUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSyncApplyUseCase {
+	mock := &MockSyncApplyUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSyncApplyUseCase is an autogenerated mock type for the SyncApplyUseCase type
+type MockSyncApplyUseCase struct {
+	mock.Mock
+}
+
+type MockSyncApplyUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSyncApplyUseCase) EXPECT() *MockSyncApplyUseCase_Expecter {
+	return &MockSyncApplyUseCase_Expecter{mock: &_m.Mock}
+}
+
+// ApplyBatch provides a mock function for the type MockSyncApplyUseCase
+func (_mock *MockSyncApplyUseCase) ApplyBatch(ctx context.Context, items []*entities.SyncItem) (*entities.SyncApplyResult, error) {
+	ret := _mock.Called(ctx, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyBatch")
+	}
+
+	var r0 *entities.SyncApplyResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.SyncItem) (*entities.SyncApplyResult, error)); ok {
+		return returnFunc(ctx, items)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.SyncItem) *entities.SyncApplyResult); ok {
+		r0 = returnFunc(ctx, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SyncApplyResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []*entities.SyncItem) error); ok {
+		r1 = returnFunc(ctx, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSyncApplyUseCase_ApplyBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyBatch'
+type MockSyncApplyUseCase_ApplyBatch_Call struct {
+	*mock.Call
+}
+
+// ApplyBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items []*entities.SyncItem
+func (_e *MockSyncApplyUseCase_Expecter) ApplyBatch(ctx interface{}, items interface{}) *MockSyncApplyUseCase_ApplyBatch_Call {
+	return &MockSyncApplyUseCase_ApplyBatch_Call{Call: _e.mock.On("ApplyBatch", ctx, items)}
+}
+
+func (_c *MockSyncApplyUseCase_ApplyBatch_Call) Run(run func(ctx context.Context, items []*entities.SyncItem)) *MockSyncApplyUseCase_ApplyBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.SyncItem
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.SyncItem)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSyncApplyUseCase_ApplyBatch_Call) Return(syncApplyResult *entities.SyncApplyResult, err error) *MockSyncApplyUseCase_ApplyBatch_Call {
+	_c.Call.Return(syncApplyResult, err)
+	return _c
+}
+
+func (_c *MockSyncApplyUseCase_ApplyBatch_Call) RunAndReturn(run func(ctx context.Context, items []*entities.SyncItem) (*entities.SyncApplyResult, error)) *MockSyncApplyUseCase_ApplyBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,351 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSyncOutboxRepository creates a new instance of MockSyncOutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSyncOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSyncOutboxRepository {
+	mock := &MockSyncOutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSyncOutboxRepository is an autogenerated mock type for the SyncOutboxRepository type
+type MockSyncOutboxRepository struct {
+	mock.Mock
+}
+
+type MockSyncOutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSyncOutboxRepository) EXPECT() *MockSyncOutboxRepository_Expecter {
+	return &MockSyncOutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountPending provides a mock function for the type MockSyncOutboxRepository
+func (_mock *MockSyncOutboxRepository) CountPending(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPending")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSyncOutboxRepository_CountPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountPending'
+type MockSyncOutboxRepository_CountPending_Call struct {
+	*mock.Call
+}
+
+// CountPending is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSyncOutboxRepository_Expecter) CountPending(ctx interface{}) *MockSyncOutboxRepository_CountPending_Call {
+	return &MockSyncOutboxRepository_CountPending_Call{Call: _e.mock.On("CountPending", ctx)}
+}
+
+func (_c *MockSyncOutboxRepository_CountPending_Call) Run(run func(ctx context.Context)) *MockSyncOutboxRepository_CountPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSyncOutboxRepository_CountPending_Call) Return(n int64, err error) *MockSyncOutboxRepository_CountPending_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockSyncOutboxRepository_CountPending_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockSyncOutboxRepository_CountPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enqueue provides a mock function for the type MockSyncOutboxRepository
+func (_mock *MockSyncOutboxRepository) Enqueue(ctx context.Context, item *entities.SyncItem) error {
+	ret := _mock.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.SyncItem) error); ok {
+		r0 = returnFunc(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSyncOutboxRepository_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type MockSyncOutboxRepository_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *entities.SyncItem
+func (_e *MockSyncOutboxRepository_Expecter) Enqueue(ctx interface{}, item interface{}) *MockSyncOutboxRepository_Enqueue_Call {
+	return &MockSyncOutboxRepository_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, item)}
+}
+
+func (_c *MockSyncOutboxRepository_Enqueue_Call) Run(run func(ctx context.Context, item *entities.SyncItem)) *MockSyncOutboxRepository_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.SyncItem
+		if args[1] != nil {
+			arg1 = args[1].(*entities.SyncItem)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSyncOutboxRepository_Enqueue_Call) Return(err error) *MockSyncOutboxRepository_Enqueue_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSyncOutboxRepository_Enqueue_Call) RunAndReturn(run func(ctx context.Context, item *entities.SyncItem) error) *MockSyncOutboxRepository_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPending provides a mock function for the type MockSyncOutboxRepository
+func (_mock *MockSyncOutboxRepository) ListPending(ctx context.Context, limit int) ([]*entities.SyncItem, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPending")
+	}
+
+	var r0 []*entities.SyncItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]*entities.SyncItem, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []*entities.SyncItem); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.SyncItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSyncOutboxRepository_ListPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPending'
+type MockSyncOutboxRepository_ListPending_Call struct {
+	*mock.Call
+}
+
+// ListPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockSyncOutboxRepository_Expecter) ListPending(ctx interface{}, limit interface{}) *MockSyncOutboxRepository_ListPending_Call {
+	return &MockSyncOutboxRepository_ListPending_Call{Call: _e.mock.On("ListPending", ctx, limit)}
+}
+
+func (_c *MockSyncOutboxRepository_ListPending_Call) Run(run func(ctx context.Context, limit int)) *MockSyncOutboxRepository_ListPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSyncOutboxRepository_ListPending_Call) Return(syncItems []*entities.SyncItem, err error) *MockSyncOutboxRepository_ListPending_Call {
+	_c.Call.Return(syncItems, err)
+	return _c
+}
+
+func (_c *MockSyncOutboxRepository_ListPending_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]*entities.SyncItem, error)) *MockSyncOutboxRepository_ListPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function for the type MockSyncOutboxRepository
+func (_mock *MockSyncOutboxRepository) MarkFailed(ctx context.Context, ids []string, reason string) error {
+	ret := _mock.Called(ctx, ids, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string) error); ok {
+		r0 = returnFunc(ctx, ids, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSyncOutboxRepository_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type MockSyncOutboxRepository_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+//   - reason string
+func (_e *MockSyncOutboxRepository_Expecter) MarkFailed(ctx interface{}, ids interface{}, reason interface{}) *MockSyncOutboxRepository_MarkFailed_Call {
+	return &MockSyncOutboxRepository_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, ids, reason)}
+}
+
+func (_c *MockSyncOutboxRepository_MarkFailed_Call) Run(run func(ctx context.Context, ids []string, reason string)) *MockSyncOutboxRepository_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSyncOutboxRepository_MarkFailed_Call) Return(err error) *MockSyncOutboxRepository_MarkFailed_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSyncOutboxRepository_MarkFailed_Call) RunAndReturn(run func(ctx context.Context, ids []string, reason string) error) *MockSyncOutboxRepository_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSynced provides a mock function for the type MockSyncOutboxRepository
+func (_mock *MockSyncOutboxRepository) MarkSynced(ctx context.Context, ids []string, syncedAt time.Time) error {
+	ret := _mock.Called(ctx, ids, syncedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSynced")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Time) error); ok {
+		r0 = returnFunc(ctx, ids, syncedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSyncOutboxRepository_MarkSynced_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSynced'
+type MockSyncOutboxRepository_MarkSynced_Call struct {
+	*mock.Call
+}
+
+// MarkSynced is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+//   - syncedAt time.Time
+func (_e *MockSyncOutboxRepository_Expecter) MarkSynced(ctx interface{}, ids interface{}, syncedAt interface{}) *MockSyncOutboxRepository_MarkSynced_Call {
+	return &MockSyncOutboxRepository_MarkSynced_Call{Call: _e.mock.On("MarkSynced", ctx, ids, syncedAt)}
+}
+
+func (_c *MockSyncOutboxRepository_MarkSynced_Call) Run(run func(ctx context.Context, ids []string, syncedAt time.Time)) *MockSyncOutboxRepository_MarkSynced_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSyncOutboxRepository_MarkSynced_Call) Return(err error) *MockSyncOutboxRepository_MarkSynced_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSyncOutboxRepository_MarkSynced_Call) RunAndReturn(run func(ctx context.Context, ids []string, syncedAt time.Time) error) *MockSyncOutboxRepository_MarkSynced_Call {
+	_c.Call.Return(run)
+	return _c
+}
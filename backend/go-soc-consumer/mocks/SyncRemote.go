@@ -0,0 +1,107 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSyncRemote creates a new instance of MockSyncRemote. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSyncRemote(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSyncRemote {
+	mock := &MockSyncRemote{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSyncRemote is an autogenerated mock type for the SyncRemote type
+type MockSyncRemote struct {
+	mock.Mock
+}
+
+type MockSyncRemote_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSyncRemote) EXPECT() *MockSyncRemote_Expecter {
+	return &MockSyncRemote_Expecter{mock: &_m.Mock}
+}
+
+// Push provides a mock function for the type MockSyncRemote
+func (_mock *MockSyncRemote) Push(ctx context.Context, items []*entities.SyncItem) (*entities.SyncApplyResult, error) {
+	ret := _mock.Called(ctx, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Push")
+	}
+
+	var r0 *entities.SyncApplyResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.SyncItem) (*entities.SyncApplyResult, error)); ok {
+		return returnFunc(ctx, items)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.SyncItem) *entities.SyncApplyResult); ok {
+		r0 = returnFunc(ctx, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SyncApplyResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []*entities.SyncItem) error); ok {
+		r1 = returnFunc(ctx, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSyncRemote_Push_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Push'
+type MockSyncRemote_Push_Call struct {
+	*mock.Call
+}
+
+// Push is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items []*entities.SyncItem
+func (_e *MockSyncRemote_Expecter) Push(ctx interface{}, items interface{}) *MockSyncRemote_Push_Call {
+	return &MockSyncRemote_Push_Call{Call: _e.mock.On("Push", ctx, items)}
+}
+
+func (_c *MockSyncRemote_Push_Call) Run(run func(ctx context.Context, items []*entities.SyncItem)) *MockSyncRemote_Push_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.SyncItem
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.SyncItem)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSyncRemote_Push_Call) Return(syncApplyResult *entities.SyncApplyResult, err error) *MockSyncRemote_Push_Call {
+	_c.Call.Return(syncApplyResult, err)
+	return _c
+}
+
+func (_c *MockSyncRemote_Push_Call) RunAndReturn(run func(ctx context.Context, items []*entities.SyncItem) (*entities.SyncApplyResult, error)) *MockSyncRemote_Push_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,180 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTelemetryArchiveRepository creates a new instance of MockTelemetryArchiveRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTelemetryArchiveRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTelemetryArchiveRepository {
+	mock := &MockTelemetryArchiveRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTelemetryArchiveRepository is an autogenerated mock type for the TelemetryArchiveRepository type
+type MockTelemetryArchiveRepository struct {
+	mock.Mock
+}
+
+type MockTelemetryArchiveRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTelemetryArchiveRepository) EXPECT() *MockTelemetryArchiveRepository_Expecter {
+	return &MockTelemetryArchiveRepository_Expecter{mock: &_m.Mock}
+}
+
+// CompactDay provides a mock function for the type MockTelemetryArchiveRepository
+func (_mock *MockTelemetryArchiveRepository) CompactDay(ctx context.Context, day entities.TelemetryDay) (int, error) {
+	ret := _mock.Called(ctx, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompactDay")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.TelemetryDay) (int, error)); ok {
+		return returnFunc(ctx, day)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.TelemetryDay) int); ok {
+		r0 = returnFunc(ctx, day)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.TelemetryDay) error); ok {
+		r1 = returnFunc(ctx, day)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTelemetryArchiveRepository_CompactDay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompactDay'
+type MockTelemetryArchiveRepository_CompactDay_Call struct {
+	*mock.Call
+}
+
+// CompactDay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - day entities.TelemetryDay
+func (_e *MockTelemetryArchiveRepository_Expecter) CompactDay(ctx interface{}, day interface{}) *MockTelemetryArchiveRepository_CompactDay_Call {
+	return &MockTelemetryArchiveRepository_CompactDay_Call{Call: _e.mock.On("CompactDay", ctx, day)}
+}
+
+func (_c *MockTelemetryArchiveRepository_CompactDay_Call) Run(run func(ctx context.Context, day entities.TelemetryDay)) *MockTelemetryArchiveRepository_CompactDay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 entities.TelemetryDay
+		if args[1] != nil {
+			arg1 = args[1].(entities.TelemetryDay)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTelemetryArchiveRepository_CompactDay_Call) Return(n int, err error) *MockTelemetryArchiveRepository_CompactDay_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTelemetryArchiveRepository_CompactDay_Call) RunAndReturn(run func(ctx context.Context, day entities.TelemetryDay) (int, error)) *MockTelemetryArchiveRepository_CompactDay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCompactableDays provides a mock function for the type MockTelemetryArchiveRepository
+func (_mock *MockTelemetryArchiveRepository) ListCompactableDays(ctx context.Context, before time.Time, limit int) ([]entities.TelemetryDay, error) {
+	ret := _mock.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCompactableDays")
+	}
+
+	var r0 []entities.TelemetryDay
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]entities.TelemetryDay, error)); ok {
+		return returnFunc(ctx, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []entities.TelemetryDay); ok {
+		r0 = returnFunc(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.TelemetryDay)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTelemetryArchiveRepository_ListCompactableDays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCompactableDays'
+type MockTelemetryArchiveRepository_ListCompactableDays_Call struct {
+	*mock.Call
+}
+
+// ListCompactableDays is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *MockTelemetryArchiveRepository_Expecter) ListCompactableDays(ctx interface{}, before interface{}, limit interface{}) *MockTelemetryArchiveRepository_ListCompactableDays_Call {
+	return &MockTelemetryArchiveRepository_ListCompactableDays_Call{Call: _e.mock.On("ListCompactableDays", ctx, before, limit)}
+}
+
+func (_c *MockTelemetryArchiveRepository_ListCompactableDays_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *MockTelemetryArchiveRepository_ListCompactableDays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTelemetryArchiveRepository_ListCompactableDays_Call) Return(telemetryDays []entities.TelemetryDay, err error) *MockTelemetryArchiveRepository_ListCompactableDays_Call {
+	_c.Call.Return(telemetryDays, err)
+	return _c
+}
+
+func (_c *MockTelemetryArchiveRepository_ListCompactableDays_Call) RunAndReturn(run func(ctx context.Context, before time.Time, limit int) ([]entities.TelemetryDay, error)) *MockTelemetryArchiveRepository_ListCompactableDays_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,138 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTelemetryCompactionUseCase creates a new instance of MockTelemetryCompactionUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTelemetryCompactionUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTelemetryCompactionUseCase {
+	mock := &MockTelemetryCompactionUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTelemetryCompactionUseCase is an autogenerated mock type for the TelemetryCompactionUseCase type
+type MockTelemetryCompactionUseCase struct {
+	mock.Mock
+}
+
+type MockTelemetryCompactionUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTelemetryCompactionUseCase) EXPECT() *MockTelemetryCompactionUseCase_Expecter {
+	return &MockTelemetryCompactionUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CompactOnce provides a mock function for the type MockTelemetryCompactionUseCase
+func (_mock *MockTelemetryCompactionUseCase) CompactOnce(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompactOnce")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTelemetryCompactionUseCase_CompactOnce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompactOnce'
+type MockTelemetryCompactionUseCase_CompactOnce_Call struct {
+	*mock.Call
+}
+
+// CompactOnce is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTelemetryCompactionUseCase_Expecter) CompactOnce(ctx interface{}) *MockTelemetryCompactionUseCase_CompactOnce_Call {
+	return &MockTelemetryCompactionUseCase_CompactOnce_Call{Call: _e.mock.On("CompactOnce", ctx)}
+}
+
+func (_c *MockTelemetryCompactionUseCase_CompactOnce_Call) Run(run func(ctx context.Context)) *MockTelemetryCompactionUseCase_CompactOnce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTelemetryCompactionUseCase_CompactOnce_Call) Return(n int, err error) *MockTelemetryCompactionUseCase_CompactOnce_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTelemetryCompactionUseCase_CompactOnce_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockTelemetryCompactionUseCase_CompactOnce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockTelemetryCompactionUseCase
+func (_mock *MockTelemetryCompactionUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockTelemetryCompactionUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockTelemetryCompactionUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTelemetryCompactionUseCase_Expecter) Run(ctx interface{}) *MockTelemetryCompactionUseCase_Run_Call {
+	return &MockTelemetryCompactionUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockTelemetryCompactionUseCase_Run_Call) Run(run func(ctx context.Context)) *MockTelemetryCompactionUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTelemetryCompactionUseCase_Run_Call) Return() *MockTelemetryCompactionUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTelemetryCompactionUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockTelemetryCompactionUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,170 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTelemetryForwardingUseCase creates a new instance of MockTelemetryForwardingUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTelemetryForwardingUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTelemetryForwardingUseCase {
+	mock := &MockTelemetryForwardingUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTelemetryForwardingUseCase is an autogenerated mock type for the TelemetryForwardingUseCase type
+type MockTelemetryForwardingUseCase struct {
+	mock.Mock
+}
+
+type MockTelemetryForwardingUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTelemetryForwardingUseCase) EXPECT() *MockTelemetryForwardingUseCase_Expecter {
+	return &MockTelemetryForwardingUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Flush provides a mock function for the type MockTelemetryForwardingUseCase
+func (_mock *MockTelemetryForwardingUseCase) Flush(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTelemetryForwardingUseCase_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type MockTelemetryForwardingUseCase_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTelemetryForwardingUseCase_Expecter) Flush(ctx interface{}) *MockTelemetryForwardingUseCase_Flush_Call {
+	return &MockTelemetryForwardingUseCase_Flush_Call{Call: _e.mock.On("Flush", ctx)}
+}
+
+func (_c *MockTelemetryForwardingUseCase_Flush_Call) Run(run func(ctx context.Context)) *MockTelemetryForwardingUseCase_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTelemetryForwardingUseCase_Flush_Call) Return(err error) *MockTelemetryForwardingUseCase_Flush_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTelemetryForwardingUseCase_Flush_Call) RunAndReturn(run func(ctx context.Context) error) *MockTelemetryForwardingUseCase_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Forward provides a mock function for the type MockTelemetryForwardingUseCase
+func (_mock *MockTelemetryForwardingUseCase) Forward(reading *entities.SensorTemperatureHumidity) {
+	_mock.Called(reading)
+	return
+}
+
+// MockTelemetryForwardingUseCase_Forward_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Forward'
+type MockTelemetryForwardingUseCase_Forward_Call struct {
+	*mock.Call
+}
+
+// Forward is a helper method to define mock.On call
+//   - reading *entities.SensorTemperatureHumidity
+func (_e *MockTelemetryForwardingUseCase_Expecter) Forward(reading interface{}) *MockTelemetryForwardingUseCase_Forward_Call {
+	return &MockTelemetryForwardingUseCase_Forward_Call{Call: _e.mock.On("Forward", reading)}
+}
+
+func (_c *MockTelemetryForwardingUseCase_Forward_Call) Run(run func(reading *entities.SensorTemperatureHumidity)) *MockTelemetryForwardingUseCase_Forward_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *entities.SensorTemperatureHumidity
+		if args[0] != nil {
+			arg0 = args[0].(*entities.SensorTemperatureHumidity)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTelemetryForwardingUseCase_Forward_Call) Return() *MockTelemetryForwardingUseCase_Forward_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTelemetryForwardingUseCase_Forward_Call) RunAndReturn(run func(reading *entities.SensorTemperatureHumidity)) *MockTelemetryForwardingUseCase_Forward_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockTelemetryForwardingUseCase
+func (_mock *MockTelemetryForwardingUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockTelemetryForwardingUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockTelemetryForwardingUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTelemetryForwardingUseCase_Expecter) Run(ctx interface{}) *MockTelemetryForwardingUseCase_Run_Call {
+	return &MockTelemetryForwardingUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockTelemetryForwardingUseCase_Run_Call) Run(run func(ctx context.Context)) *MockTelemetryForwardingUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTelemetryForwardingUseCase_Run_Call) Return() *MockTelemetryForwardingUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTelemetryForwardingUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockTelemetryForwardingUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
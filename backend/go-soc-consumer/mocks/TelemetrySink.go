@@ -0,0 +1,140 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTelemetrySink creates a new instance of MockTelemetrySink. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTelemetrySink(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTelemetrySink {
+	mock := &MockTelemetrySink{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTelemetrySink is an autogenerated mock type for the TelemetrySink type
+type MockTelemetrySink struct {
+	mock.Mock
+}
+
+type MockTelemetrySink_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTelemetrySink) EXPECT() *MockTelemetrySink_Expecter {
+	return &MockTelemetrySink_Expecter{mock: &_m.Mock}
+}
+
+// Name provides a mock function for the type MockTelemetrySink
+func (_mock *MockTelemetrySink) Name() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockTelemetrySink_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type MockTelemetrySink_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *MockTelemetrySink_Expecter) Name() *MockTelemetrySink_Name_Call {
+	return &MockTelemetrySink_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *MockTelemetrySink_Name_Call) Run(run func()) *MockTelemetrySink_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTelemetrySink_Name_Call) Return(s string) *MockTelemetrySink_Name_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockTelemetrySink_Name_Call) RunAndReturn(run func() string) *MockTelemetrySink_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Write provides a mock function for the type MockTelemetrySink
+func (_mock *MockTelemetrySink) Write(ctx context.Context, readings []*entities.SensorTemperatureHumidity) error {
+	ret := _mock.Called(ctx, readings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Write")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.SensorTemperatureHumidity) error); ok {
+		r0 = returnFunc(ctx, readings)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTelemetrySink_Write_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Write'
+type MockTelemetrySink_Write_Call struct {
+	*mock.Call
+}
+
+// Write is a helper method to define mock.On call
+//   - ctx context.Context
+//   - readings []*entities.SensorTemperatureHumidity
+func (_e *MockTelemetrySink_Expecter) Write(ctx interface{}, readings interface{}) *MockTelemetrySink_Write_Call {
+	return &MockTelemetrySink_Write_Call{Call: _e.mock.On("Write", ctx, readings)}
+}
+
+func (_c *MockTelemetrySink_Write_Call) Run(run func(ctx context.Context, readings []*entities.SensorTemperatureHumidity)) *MockTelemetrySink_Write_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.SensorTemperatureHumidity
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.SensorTemperatureHumidity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTelemetrySink_Write_Call) Return(err error) *MockTelemetrySink_Write_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTelemetrySink_Write_Call) RunAndReturn(run func(ctx context.Context, readings []*entities.SensorTemperatureHumidity) error) *MockTelemetrySink_Write_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,342 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockUsageMeteringUseCase creates a new instance of MockUsageMeteringUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUsageMeteringUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUsageMeteringUseCase {
+	mock := &MockUsageMeteringUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUsageMeteringUseCase is an autogenerated mock type for the UsageMeteringUseCase type
+type MockUsageMeteringUseCase struct {
+	mock.Mock
+}
+
+type MockUsageMeteringUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUsageMeteringUseCase) EXPECT() *MockUsageMeteringUseCase_Expecter {
+	return &MockUsageMeteringUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CountDevices provides a mock function for the type MockUsageMeteringUseCase
+func (_mock *MockUsageMeteringUseCase) CountDevices(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDevices")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUsageMeteringUseCase_CountDevices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDevices'
+type MockUsageMeteringUseCase_CountDevices_Call struct {
+	*mock.Call
+}
+
+// CountDevices is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUsageMeteringUseCase_Expecter) CountDevices(ctx interface{}) *MockUsageMeteringUseCase_CountDevices_Call {
+	return &MockUsageMeteringUseCase_CountDevices_Call{Call: _e.mock.On("CountDevices", ctx)}
+}
+
+func (_c *MockUsageMeteringUseCase_CountDevices_Call) Run(run func(ctx context.Context)) *MockUsageMeteringUseCase_CountDevices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_CountDevices_Call) Return(err error) *MockUsageMeteringUseCase_CountDevices_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_CountDevices_Call) RunAndReturn(run func(ctx context.Context) error) *MockUsageMeteringUseCase_CountDevices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function for the type MockUsageMeteringUseCase
+func (_mock *MockUsageMeteringUseCase) Export(ctx context.Context, month time.Time) ([]*entities.UsageRecord, error) {
+	ret := _mock.Called(ctx, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 []*entities.UsageRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]*entities.UsageRecord, error)); ok {
+		return returnFunc(ctx, month)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []*entities.UsageRecord); ok {
+		r0 = returnFunc(ctx, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.UsageRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsageMeteringUseCase_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockUsageMeteringUseCase_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - month time.Time
+func (_e *MockUsageMeteringUseCase_Expecter) Export(ctx interface{}, month interface{}) *MockUsageMeteringUseCase_Export_Call {
+	return &MockUsageMeteringUseCase_Export_Call{Call: _e.mock.On("Export", ctx, month)}
+}
+
+func (_c *MockUsageMeteringUseCase_Export_Call) Run(run func(ctx context.Context, month time.Time)) *MockUsageMeteringUseCase_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_Export_Call) Return(usageRecords []*entities.UsageRecord, err error) *MockUsageMeteringUseCase_Export_Call {
+	_c.Call.Return(usageRecords, err)
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_Export_Call) RunAndReturn(run func(ctx context.Context, month time.Time) ([]*entities.UsageRecord, error)) *MockUsageMeteringUseCase_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Flush provides a mock function for the type MockUsageMeteringUseCase
+func (_mock *MockUsageMeteringUseCase) Flush(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Flush")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUsageMeteringUseCase_Flush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Flush'
+type MockUsageMeteringUseCase_Flush_Call struct {
+	*mock.Call
+}
+
+// Flush is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUsageMeteringUseCase_Expecter) Flush(ctx interface{}) *MockUsageMeteringUseCase_Flush_Call {
+	return &MockUsageMeteringUseCase_Flush_Call{Call: _e.mock.On("Flush", ctx)}
+}
+
+func (_c *MockUsageMeteringUseCase_Flush_Call) Run(run func(ctx context.Context)) *MockUsageMeteringUseCase_Flush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_Flush_Call) Return(err error) *MockUsageMeteringUseCase_Flush_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_Flush_Call) RunAndReturn(run func(ctx context.Context) error) *MockUsageMeteringUseCase_Flush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordMessage provides a mock function for the type MockUsageMeteringUseCase
+func (_mock *MockUsageMeteringUseCase) RecordMessage(ctx context.Context, rows int) {
+	_mock.Called(ctx, rows)
+	return
+}
+
+// MockUsageMeteringUseCase_RecordMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordMessage'
+type MockUsageMeteringUseCase_RecordMessage_Call struct {
+	*mock.Call
+}
+
+// RecordMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rows int
+func (_e *MockUsageMeteringUseCase_Expecter) RecordMessage(ctx interface{}, rows interface{}) *MockUsageMeteringUseCase_RecordMessage_Call {
+	return &MockUsageMeteringUseCase_RecordMessage_Call{Call: _e.mock.On("RecordMessage", ctx, rows)}
+}
+
+func (_c *MockUsageMeteringUseCase_RecordMessage_Call) Run(run func(ctx context.Context, rows int)) *MockUsageMeteringUseCase_RecordMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_RecordMessage_Call) Return() *MockUsageMeteringUseCase_RecordMessage_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_RecordMessage_Call) RunAndReturn(run func(ctx context.Context, rows int)) *MockUsageMeteringUseCase_RecordMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordNotification provides a mock function for the type MockUsageMeteringUseCase
+func (_mock *MockUsageMeteringUseCase) RecordNotification(ctx context.Context, notification *entities.Notification) {
+	_mock.Called(ctx, notification)
+	return
+}
+
+// MockUsageMeteringUseCase_RecordNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordNotification'
+type MockUsageMeteringUseCase_RecordNotification_Call struct {
+	*mock.Call
+}
+
+// RecordNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notification *entities.Notification
+func (_e *MockUsageMeteringUseCase_Expecter) RecordNotification(ctx interface{}, notification interface{}) *MockUsageMeteringUseCase_RecordNotification_Call {
+	return &MockUsageMeteringUseCase_RecordNotification_Call{Call: _e.mock.On("RecordNotification", ctx, notification)}
+}
+
+func (_c *MockUsageMeteringUseCase_RecordNotification_Call) Run(run func(ctx context.Context, notification *entities.Notification)) *MockUsageMeteringUseCase_RecordNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.Notification
+		if args[1] != nil {
+			arg1 = args[1].(*entities.Notification)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_RecordNotification_Call) Return() *MockUsageMeteringUseCase_RecordNotification_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_RecordNotification_Call) RunAndReturn(run func(ctx context.Context, notification *entities.Notification)) *MockUsageMeteringUseCase_RecordNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockUsageMeteringUseCase
+func (_mock *MockUsageMeteringUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockUsageMeteringUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockUsageMeteringUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUsageMeteringUseCase_Expecter) Run(ctx interface{}) *MockUsageMeteringUseCase_Run_Call {
+	return &MockUsageMeteringUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockUsageMeteringUseCase_Run_Call) Run(run func(ctx context.Context)) *MockUsageMeteringUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_Run_Call) Return() *MockUsageMeteringUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockUsageMeteringUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockUsageMeteringUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,228 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockUsageRepository creates a new instance of MockUsageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUsageRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUsageRepository {
+	mock := &MockUsageRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUsageRepository is an autogenerated mock type for the UsageRepository type
+type MockUsageRepository struct {
+	mock.Mock
+}
+
+type MockUsageRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUsageRepository) EXPECT() *MockUsageRepository_Expecter {
+	return &MockUsageRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddUsage provides a mock function for the type MockUsageRepository
+func (_mock *MockUsageRepository) AddUsage(ctx context.Context, records []*entities.UsageRecord) error {
+	ret := _mock.Called(ctx, records)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddUsage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*entities.UsageRecord) error); ok {
+		r0 = returnFunc(ctx, records)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUsageRepository_AddUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUsage'
+type MockUsageRepository_AddUsage_Call struct {
+	*mock.Call
+}
+
+// AddUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - records []*entities.UsageRecord
+func (_e *MockUsageRepository_Expecter) AddUsage(ctx interface{}, records interface{}) *MockUsageRepository_AddUsage_Call {
+	return &MockUsageRepository_AddUsage_Call{Call: _e.mock.On("AddUsage", ctx, records)}
+}
+
+func (_c *MockUsageRepository_AddUsage_Call) Run(run func(ctx context.Context, records []*entities.UsageRecord)) *MockUsageRepository_AddUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*entities.UsageRecord
+		if args[1] != nil {
+			arg1 = args[1].([]*entities.UsageRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageRepository_AddUsage_Call) Return(err error) *MockUsageRepository_AddUsage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUsageRepository_AddUsage_Call) RunAndReturn(run func(ctx context.Context, records []*entities.UsageRecord) error) *MockUsageRepository_AddUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByMonth provides a mock function for the type MockUsageRepository
+func (_mock *MockUsageRepository) ListByMonth(ctx context.Context, month time.Time) ([]*entities.UsageRecord, error) {
+	ret := _mock.Called(ctx, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByMonth")
+	}
+
+	var r0 []*entities.UsageRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]*entities.UsageRecord, error)); ok {
+		return returnFunc(ctx, month)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []*entities.UsageRecord); ok {
+		r0 = returnFunc(ctx, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.UsageRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUsageRepository_ListByMonth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByMonth'
+type MockUsageRepository_ListByMonth_Call struct {
+	*mock.Call
+}
+
+// ListByMonth is a helper method to define mock.On call
+//   - ctx context.Context
+//   - month time.Time
+func (_e *MockUsageRepository_Expecter) ListByMonth(ctx interface{}, month interface{}) *MockUsageRepository_ListByMonth_Call {
+	return &MockUsageRepository_ListByMonth_Call{Call: _e.mock.On("ListByMonth", ctx, month)}
+}
+
+func (_c *MockUsageRepository_ListByMonth_Call) Run(run func(ctx context.Context, month time.Time)) *MockUsageRepository_ListByMonth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageRepository_ListByMonth_Call) Return(usageRecords []*entities.UsageRecord, err error) *MockUsageRepository_ListByMonth_Call {
+	_c.Call.Return(usageRecords, err)
+	return _c
+}
+
+func (_c *MockUsageRepository_ListByMonth_Call) RunAndReturn(run func(ctx context.Context, month time.Time) ([]*entities.UsageRecord, error)) *MockUsageRepository_ListByMonth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveDeviceCounts provides a mock function for the type MockUsageRepository
+func (_mock *MockUsageRepository) SaveDeviceCounts(ctx context.Context, day time.Time, devices map[string]int) error {
+	ret := _mock.Called(ctx, day, devices)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveDeviceCounts")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, map[string]int) error); ok {
+		r0 = returnFunc(ctx, day, devices)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUsageRepository_SaveDeviceCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveDeviceCounts'
+type MockUsageRepository_SaveDeviceCounts_Call struct {
+	*mock.Call
+}
+
+// SaveDeviceCounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - day time.Time
+//   - devices map[string]int
+func (_e *MockUsageRepository_Expecter) SaveDeviceCounts(ctx interface{}, day interface{}, devices interface{}) *MockUsageRepository_SaveDeviceCounts_Call {
+	return &MockUsageRepository_SaveDeviceCounts_Call{Call: _e.mock.On("SaveDeviceCounts", ctx, day, devices)}
+}
+
+func (_c *MockUsageRepository_SaveDeviceCounts_Call) Run(run func(ctx context.Context, day time.Time, devices map[string]int)) *MockUsageRepository_SaveDeviceCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 map[string]int
+		if args[2] != nil {
+			arg2 = args[2].(map[string]int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsageRepository_SaveDeviceCounts_Call) Return(err error) *MockUsageRepository_SaveDeviceCounts_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUsageRepository_SaveDeviceCounts_Call) RunAndReturn(run func(ctx context.Context, day time.Time, devices map[string]int) error) *MockUsageRepository_SaveDeviceCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,370 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockUserAuthUseCase creates a new instance of MockUserAuthUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserAuthUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserAuthUseCase {
+	mock := &MockUserAuthUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUserAuthUseCase is an autogenerated mock type for the UserAuthUseCase type
+type MockUserAuthUseCase struct {
+	mock.Mock
+}
+
+type MockUserAuthUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserAuthUseCase) EXPECT() *MockUserAuthUseCase_Expecter {
+	return &MockUserAuthUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function for the type MockUserAuthUseCase
+func (_mock *MockUserAuthUseCase) Authenticate(token string) (*entities.AuthClaims, error) {
+	ret := _mock.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *entities.AuthClaims
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*entities.AuthClaims, error)); ok {
+		return returnFunc(token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *entities.AuthClaims); ok {
+		r0 = returnFunc(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.AuthClaims)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserAuthUseCase_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockUserAuthUseCase_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - token string
+func (_e *MockUserAuthUseCase_Expecter) Authenticate(token interface{}) *MockUserAuthUseCase_Authenticate_Call {
+	return &MockUserAuthUseCase_Authenticate_Call{Call: _e.mock.On("Authenticate", token)}
+}
+
+func (_c *MockUserAuthUseCase_Authenticate_Call) Run(run func(token string)) *MockUserAuthUseCase_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserAuthUseCase_Authenticate_Call) Return(authClaims *entities.AuthClaims, err error) *MockUserAuthUseCase_Authenticate_Call {
+	_c.Call.Return(authClaims, err)
+	return _c
+}
+
+func (_c *MockUserAuthUseCase_Authenticate_Call) RunAndReturn(run func(token string) (*entities.AuthClaims, error)) *MockUserAuthUseCase_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function for the type MockUserAuthUseCase
+func (_mock *MockUserAuthUseCase) CreateUser(ctx context.Context, request entities.UserRequest) (*entities.User, error) {
+	ret := _mock.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 *entities.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.UserRequest) (*entities.User, error)); ok {
+		return returnFunc(ctx, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, entities.UserRequest) *entities.User); ok {
+		r0 = returnFunc(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, entities.UserRequest) error); ok {
+		r1 = returnFunc(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserAuthUseCase_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockUserAuthUseCase_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request entities.UserRequest
+func (_e *MockUserAuthUseCase_Expecter) CreateUser(ctx interface{}, request interface{}) *MockUserAuthUseCase_CreateUser_Call {
+	return &MockUserAuthUseCase_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, request)}
+}
+
+func (_c *MockUserAuthUseCase_CreateUser_Call) Run(run func(ctx context.Context, request entities.UserRequest)) *MockUserAuthUseCase_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 entities.UserRequest
+		if args[1] != nil {
+			arg1 = args[1].(entities.UserRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserAuthUseCase_CreateUser_Call) Return(user *entities.User, err error) *MockUserAuthUseCase_CreateUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserAuthUseCase_CreateUser_Call) RunAndReturn(run func(ctx context.Context, request entities.UserRequest) (*entities.User, error)) *MockUserAuthUseCase_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function for the type MockUserAuthUseCase
+func (_mock *MockUserAuthUseCase) DeleteUser(ctx context.Context, username string) error {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserAuthUseCase_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockUserAuthUseCase_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockUserAuthUseCase_Expecter) DeleteUser(ctx interface{}, username interface{}) *MockUserAuthUseCase_DeleteUser_Call {
+	return &MockUserAuthUseCase_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, username)}
+}
+
+func (_c *MockUserAuthUseCase_DeleteUser_Call) Run(run func(ctx context.Context, username string)) *MockUserAuthUseCase_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserAuthUseCase_DeleteUser_Call) Return(err error) *MockUserAuthUseCase_DeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserAuthUseCase_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, username string) error) *MockUserAuthUseCase_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUsers provides a mock function for the type MockUserAuthUseCase
+func (_mock *MockUserAuthUseCase) ListUsers(ctx context.Context) ([]*entities.User, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []*entities.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*entities.User, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*entities.User); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserAuthUseCase_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type MockUserAuthUseCase_ListUsers_Call struct {
+	*mock.Call
+}
+
+// ListUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUserAuthUseCase_Expecter) ListUsers(ctx interface{}) *MockUserAuthUseCase_ListUsers_Call {
+	return &MockUserAuthUseCase_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx)}
+}
+
+func (_c *MockUserAuthUseCase_ListUsers_Call) Run(run func(ctx context.Context)) *MockUserAuthUseCase_ListUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserAuthUseCase_ListUsers_Call) Return(users []*entities.User, err error) *MockUserAuthUseCase_ListUsers_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserAuthUseCase_ListUsers_Call) RunAndReturn(run func(ctx context.Context) ([]*entities.User, error)) *MockUserAuthUseCase_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function for the type MockUserAuthUseCase
+func (_mock *MockUserAuthUseCase) Login(ctx context.Context, username string, password string) (*entities.User, *entities.AuthToken, error) {
+	ret := _mock.Called(ctx, username, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *entities.User
+	var r1 *entities.AuthToken
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*entities.User, *entities.AuthToken, error)); ok {
+		return returnFunc(ctx, username, password)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *entities.User); ok {
+		r0 = returnFunc(ctx, username, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) *entities.AuthToken); ok {
+		r1 = returnFunc(ctx, username, password)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entities.AuthToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = returnFunc(ctx, username, password)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockUserAuthUseCase_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type MockUserAuthUseCase_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - password string
+func (_e *MockUserAuthUseCase_Expecter) Login(ctx interface{}, username interface{}, password interface{}) *MockUserAuthUseCase_Login_Call {
+	return &MockUserAuthUseCase_Login_Call{Call: _e.mock.On("Login", ctx, username, password)}
+}
+
+func (_c *MockUserAuthUseCase_Login_Call) Run(run func(ctx context.Context, username string, password string)) *MockUserAuthUseCase_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserAuthUseCase_Login_Call) Return(user *entities.User, authToken *entities.AuthToken, err error) *MockUserAuthUseCase_Login_Call {
+	_c.Call.Return(user, authToken, err)
+	return _c
+}
+
+func (_c *MockUserAuthUseCase_Login_Call) RunAndReturn(run func(ctx context.Context, username string, password string) (*entities.User, *entities.AuthToken, error)) *MockUserAuthUseCase_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
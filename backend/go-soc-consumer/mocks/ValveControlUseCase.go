@@ -0,0 +1,374 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockValveControlUseCase creates a new instance of MockValveControlUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockValveControlUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockValveControlUseCase {
+	mock := &MockValveControlUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockValveControlUseCase is an autogenerated mock type for the ValveControlUseCase type
+type MockValveControlUseCase struct {
+	mock.Mock
+}
+
+type MockValveControlUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockValveControlUseCase) EXPECT() *MockValveControlUseCase_Expecter {
+	return &MockValveControlUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function for the type MockValveControlUseCase
+func (_mock *MockValveControlUseCase) Close(ctx context.Context, macAddress string) (*entities.DeviceCommand, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 *entities.DeviceCommand
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceCommand, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceCommand); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceCommand)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockValveControlUseCase_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockValveControlUseCase_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockValveControlUseCase_Expecter) Close(ctx interface{}, macAddress interface{}) *MockValveControlUseCase_Close_Call {
+	return &MockValveControlUseCase_Close_Call{Call: _e.mock.On("Close", ctx, macAddress)}
+}
+
+func (_c *MockValveControlUseCase_Close_Call) Run(run func(ctx context.Context, macAddress string)) *MockValveControlUseCase_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockValveControlUseCase_Close_Call) Return(deviceCommand *entities.DeviceCommand, err error) *MockValveControlUseCase_Close_Call {
+	_c.Call.Return(deviceCommand, err)
+	return _c
+}
+
+func (_c *MockValveControlUseCase_Close_Call) RunAndReturn(run func(ctx context.Context, macAddress string) (*entities.DeviceCommand, error)) *MockValveControlUseCase_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function for the type MockValveControlUseCase
+func (_mock *MockValveControlUseCase) History(ctx context.Context, macAddress string, limit int) ([]*entities.ValveTransition, error) {
+	ret := _mock.Called(ctx, macAddress, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []*entities.ValveTransition
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]*entities.ValveTransition, error)); ok {
+		return returnFunc(ctx, macAddress, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []*entities.ValveTransition); ok {
+		r0 = returnFunc(ctx, macAddress, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ValveTransition)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, macAddress, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockValveControlUseCase_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type MockValveControlUseCase_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - limit int
+func (_e *MockValveControlUseCase_Expecter) History(ctx interface{}, macAddress interface{}, limit interface{}) *MockValveControlUseCase_History_Call {
+	return &MockValveControlUseCase_History_Call{Call: _e.mock.On("History", ctx, macAddress, limit)}
+}
+
+func (_c *MockValveControlUseCase_History_Call) Run(run func(ctx context.Context, macAddress string, limit int)) *MockValveControlUseCase_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockValveControlUseCase_History_Call) Return(valveTransitions []*entities.ValveTransition, err error) *MockValveControlUseCase_History_Call {
+	_c.Call.Return(valveTransitions, err)
+	return _c
+}
+
+func (_c *MockValveControlUseCase_History_Call) RunAndReturn(run func(ctx context.Context, macAddress string, limit int) ([]*entities.ValveTransition, error)) *MockValveControlUseCase_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function for the type MockValveControlUseCase
+func (_mock *MockValveControlUseCase) Open(ctx context.Context, macAddress string) (*entities.DeviceCommand, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 *entities.DeviceCommand
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.DeviceCommand, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.DeviceCommand); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.DeviceCommand)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockValveControlUseCase_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockValveControlUseCase_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockValveControlUseCase_Expecter) Open(ctx interface{}, macAddress interface{}) *MockValveControlUseCase_Open_Call {
+	return &MockValveControlUseCase_Open_Call{Call: _e.mock.On("Open", ctx, macAddress)}
+}
+
+func (_c *MockValveControlUseCase_Open_Call) Run(run func(ctx context.Context, macAddress string)) *MockValveControlUseCase_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockValveControlUseCase_Open_Call) Return(deviceCommand *entities.DeviceCommand, err error) *MockValveControlUseCase_Open_Call {
+	_c.Call.Return(deviceCommand, err)
+	return _c
+}
+
+func (_c *MockValveControlUseCase_Open_Call) RunAndReturn(run func(ctx context.Context, macAddress string) (*entities.DeviceCommand, error)) *MockValveControlUseCase_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordAcknowledgement provides a mock function for the type MockValveControlUseCase
+func (_mock *MockValveControlUseCase) RecordAcknowledgement(ctx context.Context, commandID string) error {
+	ret := _mock.Called(ctx, commandID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordAcknowledgement")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, commandID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockValveControlUseCase_RecordAcknowledgement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordAcknowledgement'
+type MockValveControlUseCase_RecordAcknowledgement_Call struct {
+	*mock.Call
+}
+
+// RecordAcknowledgement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commandID string
+func (_e *MockValveControlUseCase_Expecter) RecordAcknowledgement(ctx interface{}, commandID interface{}) *MockValveControlUseCase_RecordAcknowledgement_Call {
+	return &MockValveControlUseCase_RecordAcknowledgement_Call{Call: _e.mock.On("RecordAcknowledgement", ctx, commandID)}
+}
+
+func (_c *MockValveControlUseCase_RecordAcknowledgement_Call) Run(run func(ctx context.Context, commandID string)) *MockValveControlUseCase_RecordAcknowledgement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockValveControlUseCase_RecordAcknowledgement_Call) Return(err error) *MockValveControlUseCase_RecordAcknowledgement_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockValveControlUseCase_RecordAcknowledgement_Call) RunAndReturn(run func(ctx context.Context, commandID string) error) *MockValveControlUseCase_RecordAcknowledgement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// State provides a mock function for the type MockValveControlUseCase
+func (_mock *MockValveControlUseCase) State(ctx context.Context, macAddress string) (*entities.ValveTransition, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for State")
+	}
+
+	var r0 *entities.ValveTransition
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.ValveTransition, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.ValveTransition); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ValveTransition)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockValveControlUseCase_State_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'State'
+type MockValveControlUseCase_State_Call struct {
+	*mock.Call
+}
+
+// State is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockValveControlUseCase_Expecter) State(ctx interface{}, macAddress interface{}) *MockValveControlUseCase_State_Call {
+	return &MockValveControlUseCase_State_Call{Call: _e.mock.On("State", ctx, macAddress)}
+}
+
+func (_c *MockValveControlUseCase_State_Call) Run(run func(ctx context.Context, macAddress string)) *MockValveControlUseCase_State_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockValveControlUseCase_State_Call) Return(valveTransition *entities.ValveTransition, err error) *MockValveControlUseCase_State_Call {
+	_c.Call.Return(valveTransition, err)
+	return _c
+}
+
+func (_c *MockValveControlUseCase_State_Call) RunAndReturn(run func(ctx context.Context, macAddress string) (*entities.ValveTransition, error)) *MockValveControlUseCase_State_Call {
+	_c.Call.Return(run)
+	return _c
+}
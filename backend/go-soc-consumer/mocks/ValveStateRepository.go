@@ -0,0 +1,328 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockValveStateRepository creates a new instance of MockValveStateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockValveStateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockValveStateRepository {
+	mock := &MockValveStateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockValveStateRepository is an autogenerated mock type for the ValveStateRepository type
+type MockValveStateRepository struct {
+	mock.Mock
+}
+
+type MockValveStateRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockValveStateRepository) EXPECT() *MockValveStateRepository_Expecter {
+	return &MockValveStateRepository_Expecter{mock: &_m.Mock}
+}
+
+// Latest provides a mock function for the type MockValveStateRepository
+func (_mock *MockValveStateRepository) Latest(ctx context.Context, macAddress string) (*entities.ValveTransition, error) {
+	ret := _mock.Called(ctx, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Latest")
+	}
+
+	var r0 *entities.ValveTransition
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.ValveTransition, error)); ok {
+		return returnFunc(ctx, macAddress)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.ValveTransition); ok {
+		r0 = returnFunc(ctx, macAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ValveTransition)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, macAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockValveStateRepository_Latest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Latest'
+type MockValveStateRepository_Latest_Call struct {
+	*mock.Call
+}
+
+// Latest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+func (_e *MockValveStateRepository_Expecter) Latest(ctx interface{}, macAddress interface{}) *MockValveStateRepository_Latest_Call {
+	return &MockValveStateRepository_Latest_Call{Call: _e.mock.On("Latest", ctx, macAddress)}
+}
+
+func (_c *MockValveStateRepository_Latest_Call) Run(run func(ctx context.Context, macAddress string)) *MockValveStateRepository_Latest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockValveStateRepository_Latest_Call) Return(valveTransition *entities.ValveTransition, err error) *MockValveStateRepository_Latest_Call {
+	_c.Call.Return(valveTransition, err)
+	return _c
+}
+
+func (_c *MockValveStateRepository_Latest_Call) RunAndReturn(run func(ctx context.Context, macAddress string) (*entities.ValveTransition, error)) *MockValveStateRepository_Latest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBetween provides a mock function for the type MockValveStateRepository
+func (_mock *MockValveStateRepository) ListBetween(ctx context.Context, macAddress string, from time.Time, to time.Time) ([]*entities.ValveTransition, error) {
+	ret := _mock.Called(ctx, macAddress, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBetween")
+	}
+
+	var r0 []*entities.ValveTransition
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]*entities.ValveTransition, error)); ok {
+		return returnFunc(ctx, macAddress, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []*entities.ValveTransition); ok {
+		r0 = returnFunc(ctx, macAddress, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ValveTransition)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, macAddress, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockValveStateRepository_ListBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBetween'
+type MockValveStateRepository_ListBetween_Call struct {
+	*mock.Call
+}
+
+// ListBetween is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockValveStateRepository_Expecter) ListBetween(ctx interface{}, macAddress interface{}, from interface{}, to interface{}) *MockValveStateRepository_ListBetween_Call {
+	return &MockValveStateRepository_ListBetween_Call{Call: _e.mock.On("ListBetween", ctx, macAddress, from, to)}
+}
+
+func (_c *MockValveStateRepository_ListBetween_Call) Run(run func(ctx context.Context, macAddress string, from time.Time, to time.Time)) *MockValveStateRepository_ListBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockValveStateRepository_ListBetween_Call) Return(valveTransitions []*entities.ValveTransition, err error) *MockValveStateRepository_ListBetween_Call {
+	_c.Call.Return(valveTransitions, err)
+	return _c
+}
+
+func (_c *MockValveStateRepository_ListBetween_Call) RunAndReturn(run func(ctx context.Context, macAddress string, from time.Time, to time.Time) ([]*entities.ValveTransition, error)) *MockValveStateRepository_ListBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByDevice provides a mock function for the type MockValveStateRepository
+func (_mock *MockValveStateRepository) ListByDevice(ctx context.Context, macAddress string, limit int) ([]*entities.ValveTransition, error) {
+	ret := _mock.Called(ctx, macAddress, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByDevice")
+	}
+
+	var r0 []*entities.ValveTransition
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]*entities.ValveTransition, error)); ok {
+		return returnFunc(ctx, macAddress, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []*entities.ValveTransition); ok {
+		r0 = returnFunc(ctx, macAddress, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ValveTransition)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, macAddress, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockValveStateRepository_ListByDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByDevice'
+type MockValveStateRepository_ListByDevice_Call struct {
+	*mock.Call
+}
+
+// ListByDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - macAddress string
+//   - limit int
+func (_e *MockValveStateRepository_Expecter) ListByDevice(ctx interface{}, macAddress interface{}, limit interface{}) *MockValveStateRepository_ListByDevice_Call {
+	return &MockValveStateRepository_ListByDevice_Call{Call: _e.mock.On("ListByDevice", ctx, macAddress, limit)}
+}
+
+func (_c *MockValveStateRepository_ListByDevice_Call) Run(run func(ctx context.Context, macAddress string, limit int)) *MockValveStateRepository_ListByDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockValveStateRepository_ListByDevice_Call) Return(valveTransitions []*entities.ValveTransition, err error) *MockValveStateRepository_ListByDevice_Call {
+	_c.Call.Return(valveTransitions, err)
+	return _c
+}
+
+func (_c *MockValveStateRepository_ListByDevice_Call) RunAndReturn(run func(ctx context.Context, macAddress string, limit int) ([]*entities.ValveTransition, error)) *MockValveStateRepository_ListByDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function for the type MockValveStateRepository
+func (_mock *MockValveStateRepository) Record(ctx context.Context, transition *entities.ValveTransition) (bool, error) {
+	ret := _mock.Called(ctx, transition)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.ValveTransition) (bool, error)); ok {
+		return returnFunc(ctx, transition)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.ValveTransition) bool); ok {
+		r0 = returnFunc(ctx, transition)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entities.ValveTransition) error); ok {
+		r1 = returnFunc(ctx, transition)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockValveStateRepository_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockValveStateRepository_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transition *entities.ValveTransition
+func (_e *MockValveStateRepository_Expecter) Record(ctx interface{}, transition interface{}) *MockValveStateRepository_Record_Call {
+	return &MockValveStateRepository_Record_Call{Call: _e.mock.On("Record", ctx, transition)}
+}
+
+func (_c *MockValveStateRepository_Record_Call) Run(run func(ctx context.Context, transition *entities.ValveTransition)) *MockValveStateRepository_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.ValveTransition
+		if args[1] != nil {
+			arg1 = args[1].(*entities.ValveTransition)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockValveStateRepository_Record_Call) Return(b bool, err error) *MockValveStateRepository_Record_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockValveStateRepository_Record_Call) RunAndReturn(run func(ctx context.Context, transition *entities.ValveTransition) (bool, error)) *MockValveStateRepository_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,268 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWifiDiagnosticsUseCase creates a new instance of MockWifiDiagnosticsUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWifiDiagnosticsUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWifiDiagnosticsUseCase {
+	mock := &MockWifiDiagnosticsUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWifiDiagnosticsUseCase is an autogenerated mock type for the WifiDiagnosticsUseCase type
+type MockWifiDiagnosticsUseCase struct {
+	mock.Mock
+}
+
+type MockWifiDiagnosticsUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWifiDiagnosticsUseCase) EXPECT() *MockWifiDiagnosticsUseCase_Expecter {
+	return &MockWifiDiagnosticsUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Advisories provides a mock function for the type MockWifiDiagnosticsUseCase
+func (_mock *MockWifiDiagnosticsUseCase) Advisories() []*entities.WifiAdvisory {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Advisories")
+	}
+
+	var r0 []*entities.WifiAdvisory
+	if returnFunc, ok := ret.Get(0).(func() []*entities.WifiAdvisory); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.WifiAdvisory)
+		}
+	}
+	return r0
+}
+
+// MockWifiDiagnosticsUseCase_Advisories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Advisories'
+type MockWifiDiagnosticsUseCase_Advisories_Call struct {
+	*mock.Call
+}
+
+// Advisories is a helper method to define mock.On call
+func (_e *MockWifiDiagnosticsUseCase_Expecter) Advisories() *MockWifiDiagnosticsUseCase_Advisories_Call {
+	return &MockWifiDiagnosticsUseCase_Advisories_Call{Call: _e.mock.On("Advisories")}
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Advisories_Call) Run(run func()) *MockWifiDiagnosticsUseCase_Advisories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Advisories_Call) Return(wifiAdvisorys []*entities.WifiAdvisory) *MockWifiDiagnosticsUseCase_Advisories_Call {
+	_c.Call.Return(wifiAdvisorys)
+	return _c
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Advisories_Call) RunAndReturn(run func() []*entities.WifiAdvisory) *MockWifiDiagnosticsUseCase_Advisories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Evaluate provides a mock function for the type MockWifiDiagnosticsUseCase
+func (_mock *MockWifiDiagnosticsUseCase) Evaluate(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockWifiDiagnosticsUseCase_Evaluate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Evaluate'
+type MockWifiDiagnosticsUseCase_Evaluate_Call struct {
+	*mock.Call
+}
+
+// Evaluate is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWifiDiagnosticsUseCase_Expecter) Evaluate(ctx interface{}) *MockWifiDiagnosticsUseCase_Evaluate_Call {
+	return &MockWifiDiagnosticsUseCase_Evaluate_Call{Call: _e.mock.On("Evaluate", ctx)}
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Evaluate_Call) Run(run func(ctx context.Context)) *MockWifiDiagnosticsUseCase_Evaluate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Evaluate_Call) Return() *MockWifiDiagnosticsUseCase_Evaluate_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Evaluate_Call) RunAndReturn(run func(ctx context.Context)) *MockWifiDiagnosticsUseCase_Evaluate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ingest provides a mock function for the type MockWifiDiagnosticsUseCase
+func (_mock *MockWifiDiagnosticsUseCase) Ingest(ctx context.Context, diagnostics *entities.WifiDiagnostics) error {
+	ret := _mock.Called(ctx, diagnostics)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ingest")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.WifiDiagnostics) error); ok {
+		r0 = returnFunc(ctx, diagnostics)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWifiDiagnosticsUseCase_Ingest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ingest'
+type MockWifiDiagnosticsUseCase_Ingest_Call struct {
+	*mock.Call
+}
+
+// Ingest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - diagnostics *entities.WifiDiagnostics
+func (_e *MockWifiDiagnosticsUseCase_Expecter) Ingest(ctx interface{}, diagnostics interface{}) *MockWifiDiagnosticsUseCase_Ingest_Call {
+	return &MockWifiDiagnosticsUseCase_Ingest_Call{Call: _e.mock.On("Ingest", ctx, diagnostics)}
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Ingest_Call) Run(run func(ctx context.Context, diagnostics *entities.WifiDiagnostics)) *MockWifiDiagnosticsUseCase_Ingest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.WifiDiagnostics
+		if args[1] != nil {
+			arg1 = args[1].(*entities.WifiDiagnostics)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Ingest_Call) Return(err error) *MockWifiDiagnosticsUseCase_Ingest_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Ingest_Call) RunAndReturn(run func(ctx context.Context, diagnostics *entities.WifiDiagnostics) error) *MockWifiDiagnosticsUseCase_Ingest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Locations provides a mock function for the type MockWifiDiagnosticsUseCase
+func (_mock *MockWifiDiagnosticsUseCase) Locations() []*entities.WifiLocationStats {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Locations")
+	}
+
+	var r0 []*entities.WifiLocationStats
+	if returnFunc, ok := ret.Get(0).(func() []*entities.WifiLocationStats); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.WifiLocationStats)
+		}
+	}
+	return r0
+}
+
+// MockWifiDiagnosticsUseCase_Locations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Locations'
+type MockWifiDiagnosticsUseCase_Locations_Call struct {
+	*mock.Call
+}
+
+// Locations is a helper method to define mock.On call
+func (_e *MockWifiDiagnosticsUseCase_Expecter) Locations() *MockWifiDiagnosticsUseCase_Locations_Call {
+	return &MockWifiDiagnosticsUseCase_Locations_Call{Call: _e.mock.On("Locations")}
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Locations_Call) Run(run func()) *MockWifiDiagnosticsUseCase_Locations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Locations_Call) Return(wifiLocationStatss []*entities.WifiLocationStats) *MockWifiDiagnosticsUseCase_Locations_Call {
+	_c.Call.Return(wifiLocationStatss)
+	return _c
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Locations_Call) RunAndReturn(run func() []*entities.WifiLocationStats) *MockWifiDiagnosticsUseCase_Locations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockWifiDiagnosticsUseCase
+func (_mock *MockWifiDiagnosticsUseCase) Run(ctx context.Context) {
+	_mock.Called(ctx)
+	return
+}
+
+// MockWifiDiagnosticsUseCase_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockWifiDiagnosticsUseCase_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWifiDiagnosticsUseCase_Expecter) Run(ctx interface{}) *MockWifiDiagnosticsUseCase_Run_Call {
+	return &MockWifiDiagnosticsUseCase_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Run_Call) Run(run func(ctx context.Context)) *MockWifiDiagnosticsUseCase_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Run_Call) Return() *MockWifiDiagnosticsUseCase_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockWifiDiagnosticsUseCase_Run_Call) RunAndReturn(run func(ctx context.Context)) *MockWifiDiagnosticsUseCase_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
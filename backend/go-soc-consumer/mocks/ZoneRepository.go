@@ -0,0 +1,540 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/domain/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockZoneRepository creates a new instance of MockZoneRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockZoneRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockZoneRepository {
+	mock := &MockZoneRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockZoneRepository is an autogenerated mock type for the ZoneRepository type
+type MockZoneRepository struct {
+	mock.Mock
+}
+
+type MockZoneRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockZoneRepository) EXPECT() *MockZoneRepository_Expecter {
+	return &MockZoneRepository_Expecter{mock: &_m.Mock}
+}
+
+// AssignDevice provides a mock function for the type MockZoneRepository
+func (_mock *MockZoneRepository) AssignDevice(ctx context.Context, zoneID string, macAddress string) error {
+	ret := _mock.Called(ctx, zoneID, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, zoneID, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockZoneRepository_AssignDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignDevice'
+type MockZoneRepository_AssignDevice_Call struct {
+	*mock.Call
+}
+
+// AssignDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zoneID string
+//   - macAddress string
+func (_e *MockZoneRepository_Expecter) AssignDevice(ctx interface{}, zoneID interface{}, macAddress interface{}) *MockZoneRepository_AssignDevice_Call {
+	return &MockZoneRepository_AssignDevice_Call{Call: _e.mock.On("AssignDevice", ctx, zoneID, macAddress)}
+}
+
+func (_c *MockZoneRepository_AssignDevice_Call) Run(run func(ctx context.Context, zoneID string, macAddress string)) *MockZoneRepository_AssignDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneRepository_AssignDevice_Call) Return(err error) *MockZoneRepository_AssignDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockZoneRepository_AssignDevice_Call) RunAndReturn(run func(ctx context.Context, zoneID string, macAddress string) error) *MockZoneRepository_AssignDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockZoneRepository
+func (_mock *MockZoneRepository) Create(ctx context.Context, zone *entities.Zone) error {
+	ret := _mock.Called(ctx, zone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.Zone) error); ok {
+		r0 = returnFunc(ctx, zone)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockZoneRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockZoneRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zone *entities.Zone
+func (_e *MockZoneRepository_Expecter) Create(ctx interface{}, zone interface{}) *MockZoneRepository_Create_Call {
+	return &MockZoneRepository_Create_Call{Call: _e.mock.On("Create", ctx, zone)}
+}
+
+func (_c *MockZoneRepository_Create_Call) Run(run func(ctx context.Context, zone *entities.Zone)) *MockZoneRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.Zone
+		if args[1] != nil {
+			arg1 = args[1].(*entities.Zone)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneRepository_Create_Call) Return(err error) *MockZoneRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockZoneRepository_Create_Call) RunAndReturn(run func(ctx context.Context, zone *entities.Zone) error) *MockZoneRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockZoneRepository
+func (_mock *MockZoneRepository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockZoneRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockZoneRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockZoneRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockZoneRepository_Delete_Call {
+	return &MockZoneRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockZoneRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *MockZoneRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneRepository_Delete_Call) Return(err error) *MockZoneRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockZoneRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockZoneRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeviceMACAddresses provides a mock function for the type MockZoneRepository
+func (_mock *MockZoneRepository) DeviceMACAddresses(ctx context.Context, zoneID string) ([]string, error) {
+	ret := _mock.Called(ctx, zoneID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceMACAddresses")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, zoneID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, zoneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, zoneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockZoneRepository_DeviceMACAddresses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeviceMACAddresses'
+type MockZoneRepository_DeviceMACAddresses_Call struct {
+	*mock.Call
+}
+
+// DeviceMACAddresses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zoneID string
+func (_e *MockZoneRepository_Expecter) DeviceMACAddresses(ctx interface{}, zoneID interface{}) *MockZoneRepository_DeviceMACAddresses_Call {
+	return &MockZoneRepository_DeviceMACAddresses_Call{Call: _e.mock.On("DeviceMACAddresses", ctx, zoneID)}
+}
+
+func (_c *MockZoneRepository_DeviceMACAddresses_Call) Run(run func(ctx context.Context, zoneID string)) *MockZoneRepository_DeviceMACAddresses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneRepository_DeviceMACAddresses_Call) Return(ss []string, err error) *MockZoneRepository_DeviceMACAddresses_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockZoneRepository_DeviceMACAddresses_Call) RunAndReturn(run func(ctx context.Context, zoneID string) ([]string, error)) *MockZoneRepository_DeviceMACAddresses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockZoneRepository
+func (_mock *MockZoneRepository) FindByID(ctx context.Context, id string) (*entities.Zone, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *entities.Zone
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entities.Zone, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entities.Zone); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Zone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockZoneRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockZoneRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockZoneRepository_Expecter) FindByID(ctx interface{}, id interface{}) *MockZoneRepository_FindByID_Call {
+	return &MockZoneRepository_FindByID_Call{Call: _e.mock.On("FindByID", ctx, id)}
+}
+
+func (_c *MockZoneRepository_FindByID_Call) Run(run func(ctx context.Context, id string)) *MockZoneRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneRepository_FindByID_Call) Return(zone *entities.Zone, err error) *MockZoneRepository_FindByID_Call {
+	_c.Call.Return(zone, err)
+	return _c
+}
+
+func (_c *MockZoneRepository_FindByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entities.Zone, error)) *MockZoneRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByFarm provides a mock function for the type MockZoneRepository
+func (_mock *MockZoneRepository) ListByFarm(ctx context.Context, farmID string) ([]*entities.Zone, error) {
+	ret := _mock.Called(ctx, farmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByFarm")
+	}
+
+	var r0 []*entities.Zone
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entities.Zone, error)); ok {
+		return returnFunc(ctx, farmID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entities.Zone); ok {
+		r0 = returnFunc(ctx, farmID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Zone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, farmID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockZoneRepository_ListByFarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByFarm'
+type MockZoneRepository_ListByFarm_Call struct {
+	*mock.Call
+}
+
+// ListByFarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - farmID string
+func (_e *MockZoneRepository_Expecter) ListByFarm(ctx interface{}, farmID interface{}) *MockZoneRepository_ListByFarm_Call {
+	return &MockZoneRepository_ListByFarm_Call{Call: _e.mock.On("ListByFarm", ctx, farmID)}
+}
+
+func (_c *MockZoneRepository_ListByFarm_Call) Run(run func(ctx context.Context, farmID string)) *MockZoneRepository_ListByFarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneRepository_ListByFarm_Call) Return(zones []*entities.Zone, err error) *MockZoneRepository_ListByFarm_Call {
+	_c.Call.Return(zones, err)
+	return _c
+}
+
+func (_c *MockZoneRepository_ListByFarm_Call) RunAndReturn(run func(ctx context.Context, farmID string) ([]*entities.Zone, error)) *MockZoneRepository_ListByFarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnassignDevice provides a mock function for the type MockZoneRepository
+func (_mock *MockZoneRepository) UnassignDevice(ctx context.Context, zoneID string, macAddress string) error {
+	ret := _mock.Called(ctx, zoneID, macAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnassignDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, zoneID, macAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockZoneRepository_UnassignDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnassignDevice'
+type MockZoneRepository_UnassignDevice_Call struct {
+	*mock.Call
+}
+
+// UnassignDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zoneID string
+//   - macAddress string
+func (_e *MockZoneRepository_Expecter) UnassignDevice(ctx interface{}, zoneID interface{}, macAddress interface{}) *MockZoneRepository_UnassignDevice_Call {
+	return &MockZoneRepository_UnassignDevice_Call{Call: _e.mock.On("UnassignDevice", ctx, zoneID, macAddress)}
+}
+
+func (_c *MockZoneRepository_UnassignDevice_Call) Run(run func(ctx context.Context, zoneID string, macAddress string)) *MockZoneRepository_UnassignDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneRepository_UnassignDevice_Call) Return(err error) *MockZoneRepository_UnassignDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockZoneRepository_UnassignDevice_Call) RunAndReturn(run func(ctx context.Context, zoneID string, macAddress string) error) *MockZoneRepository_UnassignDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockZoneRepository
+func (_mock *MockZoneRepository) Update(ctx context.Context, zone *entities.Zone) error {
+	ret := _mock.Called(ctx, zone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entities.Zone) error); ok {
+		r0 = returnFunc(ctx, zone)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockZoneRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockZoneRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zone *entities.Zone
+func (_e *MockZoneRepository_Expecter) Update(ctx interface{}, zone interface{}) *MockZoneRepository_Update_Call {
+	return &MockZoneRepository_Update_Call{Call: _e.mock.On("Update", ctx, zone)}
+}
+
+func (_c *MockZoneRepository_Update_Call) Run(run func(ctx context.Context, zone *entities.Zone)) *MockZoneRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entities.Zone
+		if args[1] != nil {
+			arg1 = args[1].(*entities.Zone)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneRepository_Update_Call) Return(err error) *MockZoneRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockZoneRepository_Update_Call) RunAndReturn(run func(ctx context.Context, zone *entities.Zone) error) *MockZoneRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
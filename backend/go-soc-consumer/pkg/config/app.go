@@ -1,18 +1,61 @@
 package config
 
 import (
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	_ "time/tzdata" // notification time zones must load on hosts without a zoneinfo database
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/i18n"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/pagination"
 )
 
 // AppConfig holds all application configuration
 type AppConfig struct {
-	Server      ServerConfig      `json:"server"`
-	Database    DatabaseConfig    `json:"database"`
-	MQTT        MQTTConfig        `json:"mqtt"`
-	NATS        NATSConfig        `json:"nats"`
-	HealthCheck HealthCheckConfig `json:"health_check"`
-	Logging     LoggingConfig     `json:"logging"`
+	Server        ServerConfig        `json:"server"`
+	Database      DatabaseConfig      `json:"database"`
+	MQTT          MQTTConfig          `json:"mqtt"`
+	NATS          NATSConfig          `json:"nats"`
+	HealthCheck   HealthCheckConfig   `json:"health_check"`
+	Logging       LoggingConfig       `json:"logging"`
+	Sync          SyncConfig          `json:"sync"`
+	Telemetry     TelemetryConfig     `json:"telemetry"`
+	Jobs          JobsConfig          `json:"jobs"`
+	Commands      CommandsConfig      `json:"commands"`
+	Security      SecurityConfig      `json:"security"`
+	Pipeline      PipelineConfig      `json:"pipeline"`
+	SensorHealth  SensorHealthConfig  `json:"sensor_health"`
+	DeviceChanges DeviceChangesConfig `json:"device_changes"`
+	Deadlines     DeadlinesConfig     `json:"deadlines"`
+	Observability ObservabilityConfig `json:"observability"`
+	HealthReport  HealthReportConfig  `json:"health_report"`
+	Shutdown      ShutdownConfig      `json:"shutdown"`
+	Pagination    PaginationConfig    `json:"pagination"`
+	Forwarding    ForwardingConfig    `json:"forwarding"`
+	RawArchive    RawArchiveConfig    `json:"raw_archive"`
+	Measurements  MeasurementsConfig  `json:"measurements"`
+	Alerts        AlertsConfig        `json:"alerts"`
+	Notifications NotificationsConfig `json:"notifications"`
+	Events        EventsConfig        `json:"events"`
+	FarmQuotas    FarmQuotasConfig    `json:"farm_quotas"`
+	Usage         UsageConfig         `json:"usage"`
+	Drift         DriftConfig         `json:"drift"`
+	Scheduler     SchedulerConfig     `json:"scheduler"`
+	Onboarding    OnboardingConfig    `json:"onboarding"`
+	Wifi          WifiConfig          `json:"wifi"`
+	BrokerAuth    BrokerAuthConfig    `json:"broker_auth"`
+	BrokerStats   BrokerStatsConfig   `json:"broker_stats"`
+	DeviceStatus  DeviceStatusConfig  `json:"device_status"`
+	Analytics     AnalyticsConfig     `json:"analytics"`
+	Prediction    PredictionConfig    `json:"prediction"`
+	Auth          AuthConfig          `json:"auth"`
+	Research      ResearchConfig      `json:"research"`
+	DeviceArchive DeviceArchiveConfig `json:"device_archive"`
+	Migration     MigrationConfig     `json:"migration"`
+	Retention     RetentionConfig     `json:"retention"`
 }
 
 // ServerConfig holds HTTP server configuration
@@ -22,19 +65,65 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `json:"read_timeout"`
 	WriteTimeout time.Duration `json:"write_timeout"`
 	IdleTimeout  time.Duration `json:"idle_timeout"`
+	AdminToken   string        `json:"-"`
+	// StatusQueryLimit caps the MAC addresses accepted by one bulk device status query
+	StatusQueryLimit int `json:"status_query_limit"`
+	// DefaultLanguage is used for clients whose Accept-Language header names no supported language
+	DefaultLanguage string `json:"default_language"`
 }
 
 // MQTTConfig holds MQTT configuration
 type MQTTConfig struct {
-	BrokerURL            string        `json:"broker_url"`
-	ClientID             string        `json:"client_id"`
-	Username             string        `json:"username"`
-	Password             string        `json:"password"`
-	CleanSession         bool          `json:"clean_session"`
-	AutoReconnect        bool          `json:"auto_reconnect"`
-	ConnectTimeout       time.Duration `json:"connect_timeout"`
-	KeepAlive            time.Duration `json:"keep_alive"`
-	MaxReconnectInterval time.Duration `json:"max_reconnect_interval"`
+	BrokerURL            string               `json:"broker_url"`
+	BrokerURLs           []string             `json:"broker_urls"`
+	ClientID             string               `json:"client_id"`
+	Username             string               `json:"username"`
+	Password             string               `json:"password"`
+	CleanSession         bool                 `json:"clean_session"`
+	AutoReconnect        bool                 `json:"auto_reconnect"`
+	ConnectTimeout       time.Duration        `json:"connect_timeout"`
+	KeepAlive            time.Duration        `json:"keep_alive"`
+	MaxReconnectInterval time.Duration        `json:"max_reconnect_interval"`
+	FailbackInterval     time.Duration        `json:"failback_interval"`
+	TLS                  MQTTTLSConfig        `json:"tls"`
+	Embedded             EmbeddedBrokerConfig `json:"embedded"`
+	// IdentityForwardPrefix reads device topics republished by the broker under this prefix with the publisher identity
+	IdentityForwardPrefix string `json:"identity_forward_prefix"`
+	// VerifyDeviceIdentity rejects messages for devices with a provisioned certificate unless published with it
+	VerifyDeviceIdentity bool `json:"verify_device_identity"`
+	// FarmNamespaces subscribes to the per-farm device topics instead of the shared ones and keeps devices in their farm
+	FarmNamespaces bool `json:"farm_namespaces"`
+	// LegacyTopics keeps the shared device topics subscribed next to the per-farm ones while devices migrate
+	LegacyTopics bool `json:"legacy_topics"`
+}
+
+// MQTTTLSConfig holds the TLS settings of the connection to the MQTT brokers, which need TLS broker URLs
+type MQTTTLSConfig struct {
+	CAFile             string `json:"ca_file"` // empty trusts the system roots
+	CertFile           string `json:"cert_file"`
+	KeyFile            string `json:"key_file"` // with the certificate, enables mutual TLS
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+}
+
+// Enabled reports whether any TLS option is set
+func (c MQTTTLSConfig) Enabled() bool {
+	return c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" || c.InsecureSkipVerify
+}
+
+// EmbeddedBrokerConfig holds configuration for the optional in-process MQTT broker
+type EmbeddedBrokerConfig struct {
+	Enabled        bool     `json:"enabled"`
+	Address        string   `json:"address"`
+	Users          []string `json:"-"` // username:password pairs, empty allows anonymous clients
+	BridgeURL      string   `json:"bridge_url"`
+	BridgeClientID string   `json:"bridge_client_id"`
+	BridgeUsername string   `json:"bridge_username"`
+	BridgePassword string   `json:"-"`
+	BridgeTopics   []string `json:"bridge_topics"`
+	TLSAddress     string   `json:"tls_address"` // optional TLS listener, devices authenticate with client certificates
+	TLSCertFile    string   `json:"tls_cert_file"`
+	TLSKeyFile     string   `json:"tls_key_file"`
+	TLSClientCA    string   `json:"tls_client_ca"`
 }
 
 // NATSConfig holds NATS configuration
@@ -48,6 +137,16 @@ type NATSConfig struct {
 	PingInterval    time.Duration `json:"ping_interval"`
 	MaxPingsOut     int           `json:"max_pings_out"`
 	ReconnectBufSize int          `json:"reconnect_buf_size"`
+
+	// Each connection authenticates as its own user so the server can restrict the subjects it may use
+	Publisher  NATSCredentials `json:"publisher"`
+	Subscriber NATSCredentials `json:"subscriber"`
+}
+
+// NATSCredentials authenticate a NATS connection, anonymous when neither file is set
+type NATSCredentials struct {
+	CredsFile    string `json:"creds_file"`     // JWT and NKey seed of a decentralized auth user
+	NKeySeedFile string `json:"nkey_seed_file"` // NKey seed of a user listed in the server configuration
 }
 
 // HealthCheckConfig holds health check configuration
@@ -56,27 +155,458 @@ type HealthCheckConfig struct {
 	RetryAttempts int           `json:"retry_attempts"`
 	InitialDelay  time.Duration `json:"initial_delay"`
 	UserAgent     string        `json:"user_agent"`
+
+	// OnDemandTimeout bounds a health check requested through the API, retries included
+	OnDemandTimeout time.Duration `json:"on_demand_timeout"`
+
+	// OfflineThreshold is how long an online device may go unseen before it is marked offline
+	OfflineThreshold     time.Duration `json:"offline_threshold"`
+	OfflineSweepInterval time.Duration `json:"offline_sweep_interval"`
 }
 
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
-	Level  string `json:"level"`
-	Format string `json:"format"`
+	Level    string            `json:"level"`
+	Format   string            `json:"format"`
+	Shipping LogShippingConfig `json:"shipping"`
+}
+
+// LogShippingConfig holds configuration for shipping logs to a remote backend
+type LogShippingConfig struct {
+	Enabled        bool          `json:"enabled"`
+	Backend        string        `json:"backend"`
+	URL            string        `json:"url"`
+	Index          string        `json:"index"`
+	Username       string        `json:"username"`
+	Password       string        `json:"-"`
+	BufferDir      string        `json:"buffer_dir"`
+	MaxBufferBytes int64         `json:"max_buffer_bytes"`
+	BatchSize      int           `json:"batch_size"`
+	FlushInterval  time.Duration `json:"flush_interval"`
+	MaxBackoff     time.Duration `json:"max_backoff"`
+}
+
+// SyncConfig holds configuration for store-and-forward replication between edge and cloud instances
+type SyncConfig struct {
+	Mode       string        `json:"mode"` // disabled, edge or cloud
+	CloudURL   string        `json:"cloud_url"`
+	Token      string        `json:"-"`
+	InstanceID string        `json:"instance_id"`
+	Interval   time.Duration `json:"interval"`
+	BatchSize  int           `json:"batch_size"`
+	MaxBackoff time.Duration `json:"max_backoff"`
+	Timeout    time.Duration `json:"timeout"`
+}
+
+// TelemetryConfig holds configuration for telemetry storage
+type TelemetryConfig struct {
+	CompressionEnabled  bool          `json:"compression_enabled"`
+	CompressAfterDays   int           `json:"compress_after_days"`
+	CompactionInterval  time.Duration `json:"compaction_interval"`
+	CompactionBatchDays int           `json:"compaction_batch_days"`
+}
+
+// JobsConfig holds configuration for the background job queue
+type JobsConfig struct {
+	Workers       int           `json:"workers"`
+	PollInterval  time.Duration `json:"poll_interval"`
+	LeaseDuration time.Duration `json:"lease_duration"`
+	MaxAttempts   int           `json:"max_attempts"`
+	RetryBackoff  time.Duration `json:"retry_backoff"`
+}
+
+// CommandsConfig holds configuration for signing commands published to devices
+type CommandsConfig struct {
+	SigningKeys  []string      `json:"-"` // keyID:hexsecret pairs, retired keys are kept until devices are updated
+	SigningKeyID string        `json:"signing_key_id"`
+	ReplayWindow time.Duration `json:"replay_window"`
+	AckTimeout   time.Duration `json:"ack_timeout"`  // wait for the acknowledgement of the first delivery, doubled for every retry
+	MaxAttempts  int           `json:"max_attempts"` // deliveries of a remote command before it expires
+}
+
+// SecurityConfig holds configuration for anomaly based security monitoring
+type SecurityConfig struct {
+	MonitoringEnabled     bool          `json:"monitoring_enabled"`
+	RegistrationWindow    time.Duration `json:"registration_window"`
+	MaxMACsPerIP          int           `json:"max_macs_per_ip"`
+	CommandTopics         []string      `json:"command_topics"`
+	TrustedCommandClients []string      `json:"trusted_command_clients"` // client IDs allowed to publish commands besides MQTT_CLIENT_ID
+	TelemetryWindow       time.Duration `json:"telemetry_window"`
+	TelemetryBurstFactor  int           `json:"telemetry_burst_factor"`
+	TelemetryBurstMinimum int           `json:"telemetry_burst_minimum"`
+	AlertHistory          int           `json:"alert_history"`
+	MaxTracked            int           `json:"max_tracked"` // IP addresses, devices and alert cooldowns each remembered by the monitor
+	// ClientMismatchWindow is how long a client that published messages of a device is remembered;
+	// another client ID publishing for the device within it is flagged as a suspected clone
+	ClientMismatchWindow time.Duration `json:"client_mismatch_window"`
+	// BlacklistRefreshInterval is how often the blacklist cache is reloaded to pick up changes made by other instances
+	BlacklistRefreshInterval time.Duration `json:"blacklist_refresh_interval"`
+}
+
+// PipelineStageNames lists the ingestion pipeline stages that can be configured
+var PipelineStageNames = []string{"dedup", "blacklist", "schema", "rate_limit"}
+
+// PipelineConfig holds configuration for the MQTT ingestion pipeline
+type PipelineConfig struct {
+	Stages              []string      `json:"stages"` // enabled stages in execution order
+	DedupWindow         time.Duration `json:"dedup_window"`
+	RateLimitPerMinute  int           `json:"rate_limit_per_minute"`
+	RateLimitBurst      int           `json:"rate_limit_burst"`
+	DedupMaxEntries     int           `json:"dedup_max_entries"`      // messages remembered by the dedup stage
+	RateLimitMaxDevices int           `json:"rate_limit_max_devices"` // devices tracked by the rate limiting stage
+	// ClockDriftTolerance is how far ahead of the receipt a device timestamp may be before the
+	// device clock counts as drifted in the ingestion latency metrics
+	ClockDriftTolerance time.Duration `json:"clock_drift_tolerance"`
+}
+
+// SensorHealthConfig holds configuration for sensor health scoring
+type SensorHealthConfig struct {
+	Enabled               bool          `json:"enabled"`
+	StaleFactor           int           `json:"stale_factor"`  // silence, as a multiple of the usual interval, after which a stream is stale
+	StaleMinimum          time.Duration `json:"stale_minimum"` // silence below this never marks a stream stale
+	CalibrationMaxAgeDays int           `json:"calibration_max_age_days"`
+	DegradedScore         int           `json:"degraded_score"` // streams scoring below this are listed as degraded
+}
+
+// DeviceChangesConfig holds configuration for the device change journal behind the long-poll endpoint
+type DeviceChangesConfig struct {
+	JournalSize int           `json:"journal_size"` // changes kept; clients further behind must reload the devices
+	DefaultWait time.Duration `json:"default_wait"` // wait when the request has no timeout
+	MaxWait     time.Duration `json:"max_wait"`     // upper bound for the requested timeout
+}
+
+// DeadlinesConfig holds the deadline of each operation class; zero leaves the class unbounded
+type DeadlinesConfig struct {
+	Message  time.Duration `json:"message"`  // handling of one MQTT or NATS message
+	Database time.Duration `json:"database"` // one database statement
+	Publish  time.Duration `json:"publish"`  // publication of one event
+}
+
+// ShutdownConfig holds the timeout of each graceful shutdown phase; the phases run in field order
+type ShutdownConfig struct {
+	StopIntake      time.Duration `json:"stop_intake"`      // stop consumers and the HTTP server, draining in-flight work
+	DrainWorkers    time.Duration `json:"drain_workers"`    // wait for background workers to stop
+	Flush           time.Duration `json:"flush"`            // push buffered data such as the edge sync outbox
+	ClosePublishers time.Duration `json:"close_publishers"` // close NATS and the embedded broker
+	CloseDatabase   time.Duration `json:"close_database"`   // close the database connections
+}
+
+// HealthReportConfig holds configuration for the dependency-aware health report
+type HealthReportConfig struct {
+	CheckTimeout    time.Duration `json:"check_timeout"`    // bound on each dependency check
+	PublishInterval time.Duration `json:"publish_interval"` // how often the report is published on NATS, zero disables publishing
+}
+
+// ObservabilityConfig holds configuration for the observed repository and publisher ports
+type ObservabilityConfig struct {
+	SlowCallThreshold time.Duration `json:"slow_call_threshold"` // calls at least this slow are logged as warnings, zero disables
+}
+
+// PaginationConfig holds the page sizes applied by list endpoints and repositories
+type PaginationConfig struct {
+	DefaultLimit int `json:"default_limit"` // used when no limit is requested
+	MaxLimit     int `json:"max_limit"`     // largest limit accepted, larger requests are rejected
+}
+
+// ForwardingConfig holds configuration for mirroring accepted telemetry to external time-series
+// databases; a sink is enabled by setting its URL
+type ForwardingConfig struct {
+	InfluxURL        string        `json:"influx_url"` // full write endpoint including org, bucket and precision=ns
+	InfluxToken      string        `json:"-"`
+	RemoteWriteURL   string        `json:"remote_write_url"`
+	RemoteWriteToken string        `json:"-"`
+	MQTTBrokerURL    string        `json:"mqtt_broker_url"`
+	MQTTClientID     string        `json:"mqtt_client_id"`
+	MQTTUsername     string        `json:"mqtt_username"`
+	MQTTPassword     string        `json:"-"`
+	MQTTTopicPrefix  string        `json:"mqtt_topic_prefix"`
+	MQTTQoS          byte          `json:"mqtt_qos"`
+	BufferSize       int           `json:"buffer_size"` // readings buffered per sink, the oldest are dropped beyond it
+	BatchSize        int           `json:"batch_size"`
+	FlushInterval    time.Duration `json:"flush_interval"`
+	MaxBackoff       time.Duration `json:"max_backoff"`
+	RequestTimeout   time.Duration `json:"request_timeout"`
+}
+
+// RawArchiveConfig holds configuration for archiving raw inbound MQTT messages in S3 compatible storage
+type RawArchiveConfig struct {
+	Enabled              bool          `json:"enabled"`
+	Endpoint             string        `json:"endpoint"`
+	Region               string        `json:"region"`
+	Bucket               string        `json:"bucket"`
+	Prefix               string        `json:"prefix"`
+	AccessKeyID          string        `json:"access_key_id"`
+	SecretAccessKey      string        `json:"-"`
+	ServerSideEncryption string        `json:"server_side_encryption"` // AES256, aws:kms or empty
+	KMSKeyID             string        `json:"kms_key_id"`
+	RetentionDays        int           `json:"retention_days"` // zero keeps bundles forever
+	MaxBundleBytes       int           `json:"max_bundle_bytes"`
+	MaxPendingBundles    int           `json:"max_pending_bundles"`
+	MaxPendingBytes      int           `json:"max_pending_bytes"`
+	UploadInterval       time.Duration `json:"upload_interval"`
+	Timeout              time.Duration `json:"timeout"`
+}
+
+// MeasurementsConfig holds the sensor types stored in the generic measurements table
+type MeasurementsConfig struct {
+	SensorTypes     []string      `json:"sensor_types"`     // name:unit:min:max definitions added to the built-in types, replacing those of the same name
+	DerivedSensors  string        `json:"derived_sensors"`  // semicolon-separated name:unit:min:max:mode=expression definitions
+	DerivedInterval time.Duration `json:"derived_interval"` // how often scheduled derived sensors are evaluated
+	DerivedMaxAge   time.Duration `json:"derived_max_age"`  // inputs older than this are ignored by scheduled derived sensors
+}
+
+// SensorTypeDefinition is a sensor type parsed from SENSOR_TYPES
+type SensorTypeDefinition struct {
+	Name     string
+	Unit     string
+	MinValue float64
+	MaxValue float64
+}
+
+// DerivedSensorDefinition is a derived sensor parsed from DERIVED_SENSORS
+type DerivedSensorDefinition struct {
+	SensorTypeDefinition
+	Mode       string
+	Expression string
+}
+
+// AlertsConfig holds the alert rules evaluated against stored values
+type AlertsConfig struct {
+	Rules        string `json:"rules"`         // semicolon-separated name:sensor_type:below|above:threshold[:key=value...] definitions
+	AlertHistory int    `json:"alert_history"` // number of recent alerts kept in memory
+
+	Conditions         string        `json:"conditions"`          // semicolon-separated name[:severity[:for[:cooldown]]]=condition definitions
+	DeviceGroups       string        `json:"device_groups"`       // semicolon-separated group=MAC,MAC... definitions read by conditions
+	ConditionsInterval time.Duration `json:"conditions_interval"` // how often conditions are evaluated
+	ConditionsMaxAge   time.Duration `json:"conditions_max_age"`  // oldest value a condition reads
+}
+
+// AlertRuleDefinition is an alert rule parsed from ALERT_RULES
+type AlertRuleDefinition struct {
+	Name       string
+	SensorType string
+	Operator   string
+	Threshold  float64
+	Hysteresis float64
+	For        time.Duration
+	Cooldown   time.Duration
+	Severity   string
+}
+
+// AlertConditionDefinition is a composite alert rule parsed from ALERT_CONDITIONS
+type AlertConditionDefinition struct {
+	Name      string
+	Severity  string
+	For       time.Duration
+	Cooldown  time.Duration
+	Condition string
+}
+
+// NotificationsConfig holds the notification preferences of users and the channels alerts are delivered on
+type NotificationsConfig struct {
+	Users         string        `json:"users"`           // semicolon-separated user:key=value... preferences
+	TimeZone      string        `json:"time_zone"`       // time zone of quiet hours and daily digests
+	DailyDigestAt time.Duration `json:"daily_digest_at"` // time of day daily digests are sent
+	Interval      time.Duration `json:"interval"`        // how often held alerts are checked for delivery
+	QueueSize     int           `json:"queue_size"`
+	MaxHeldAlerts int           `json:"max_held_alerts"` // alerts held for a user on one channel before the oldest are dropped
+	Timeout       time.Duration `json:"timeout"`
+	// InboxRetention is how long notifications are kept in the in-app inbox of each user
+	InboxRetention time.Duration `json:"inbox_retention"`
+
+	SMTPHost     string `json:"smtp_host"`
+	SMTPPort     int    `json:"smtp_port"`
+	SMTPUsername string `json:"smtp_username"`
+	SMTPPassword string `json:"-"`
+	SMTPFrom     string `json:"smtp_from"`
+
+	SMSGatewayURL   string `json:"sms_gateway_url"`
+	SMSGatewayToken string `json:"-"`
+}
+
+// EventsConfig holds the publishing policies of outbound events
+type EventsConfig struct {
+	Policies string `json:"policies"` // semicolon-separated event_type[:key=value...] definitions
+}
+
+// FarmQuotasConfig holds the quotas of the farms, enforced with MQTT_FARM_NAMESPACES; zero limits are unlimited
+type FarmQuotasConfig struct {
+	MaxDevices           int    `json:"max_devices"`             // default device quota of a farm
+	MaxMessagesPerMinute int    `json:"max_messages_per_minute"` // default telemetry message quota of a farm
+	Farms                string `json:"farms"`                   // semicolon-separated farm_id[:key=value...] definitions
+}
+
+// UsageConfig holds the metering of the usage of each farm into monthly usage records
+type UsageConfig struct {
+	Enabled             bool          `json:"enabled"`
+	FlushInterval       time.Duration `json:"flush_interval"`        // how often metered usage is stored
+	DeviceCountInterval time.Duration `json:"device_count_interval"` // how often the devices of each farm are counted
+	StorageBytesPerRow  int64         `json:"storage_bytes_per_row"` // estimated bytes a stored reading or measurement takes
+}
+
+// DriftConfig holds the reconciliation of devices with the configuration declared for them
+type DriftConfig struct {
+	Enabled     bool          `json:"enabled"`
+	Interval    time.Duration `json:"interval"`     // how often devices are compared with their declaration
+	PushBackoff time.Duration `json:"push_backoff"` // wait before pushing the declaration again, doubled on every push
+	AlertAfter  time.Duration `json:"alert_after"`  // drift lasting this long is reported as persistent
+}
+
+// SchedulerConfig holds the scheduler opening and closing valves on the irrigation schedules of devices
+type SchedulerConfig struct {
+	Enabled       bool          `json:"enabled"`
+	Interval      time.Duration `json:"interval"`       // how often the schedules are evaluated
+	TimeZone      string        `json:"time_zone"`      // time zone the cron expressions of the schedules are evaluated in
+	ProactiveLead time.Duration `json:"proactive_lead"` // runs start early when the soil is predicted to dry within this lead, 0 disables
+}
+
+// OnboardingConfig holds the onboarding of new devices by installers
+type OnboardingConfig struct {
+	BrokerURL string        `json:"broker_url"` // broker URL handed to devices, empty for the primary MQTT broker
+	TokenTTL  time.Duration `json:"token_ttl"`  // how long a provisioning token is accepted
+}
+
+// WifiConfig holds the thresholds of the Wi-Fi advisories raised from device diagnostics
+type WifiConfig struct {
+	RSSIThreshold      int           `json:"rssi_threshold"`      // devices below this RSSI in dBm have a weak signal
+	ReconnectThreshold int           `json:"reconnect_threshold"` // reconnections between two reports making a link unstable
+	MinDevices         int           `json:"min_devices"`         // devices of a location concerned before an advisory is raised
+	MaxAge             time.Duration `json:"max_age"`             // reports older than this are forgotten
+	Interval           time.Duration `json:"interval"`            // how often advisories are evaluated
+}
+
+// BrokerAuthConfig holds the settings of the authentication and authorization hooks of external MQTT brokers
+type BrokerAuthConfig struct {
+	Token               string   `json:"-"`                     // bearer token the broker calls the hooks with, empty disables them
+	ServiceUsers        []string `json:"-"`                     // username:password pairs allowed every topic, besides the server's own account
+	RequireCertificate  bool     `json:"require_certificate"`   // registered devices need a provisioned certificate
+	AllowUnknownDevices bool     `json:"allow_unknown_devices"` // devices neither registered nor being onboarded may connect
+}
+
+// BrokerStatsConfig holds the settings of the statistics read from the $SYS topics of an external broker
+type BrokerStatsConfig struct {
+	Enabled bool   `json:"enabled"`
+	Topic   string `json:"topic"` // filter of the $SYS topics subscribed to
+}
+
+// DeviceStatusConfig holds the settings of the WebSocket pushing device status updates to dashboards
+type DeviceStatusConfig struct {
+	MaxClients     int           `json:"max_clients"`     // dashboards connected at once
+	SendBuffer     int           `json:"send_buffer"`     // updates queued per dashboard before it is disconnected
+	PingInterval   time.Duration `json:"ping_interval"`   // keepalive pings; silent dashboards are dropped after two
+	AllowedOrigins []string      `json:"allowed_origins"` // cross-origin dashboards accepted besides same-origin ones
+}
+
+// AnalyticsConfig holds the irrigation efficiency analytics computed per zone
+type AnalyticsConfig struct {
+	Zones            string        `json:"zones"`             // semicolon-separated zone=MAC,MAC definitions
+	ZoneFlowRates    string        `json:"zone_flow_rates"`   // semicolon-separated zone=liters definitions, the liters per minute of each valve of the zone
+	MoistureSensor   string        `json:"moisture_sensor"`   // sensor type read as soil moisture
+	TargetMoisture   float64       `json:"target_moisture"`   // moisture irrigations aim for, in the unit of the sensor type
+	SettleWindow     time.Duration `json:"settle_window"`     // readings are looked for this long before an opening and after a closing
+	DeclineThreshold float64       `json:"decline_threshold"` // relative drop of the gain per liter flagged as a decline
+	MaxRange         time.Duration `json:"max_range"`         // longest range a query may cover
+}
+
+// PredictionConfig holds the moisture predictions made per zone of IRRIGATION_ZONES
+type PredictionConfig struct {
+	Enabled      bool          `json:"enabled"`
+	Interval     time.Duration `json:"interval"`      // how often the zones are predicted
+	Window       time.Duration `json:"window"`        // recent readings the predictions are made from
+	Horizon      time.Duration `json:"horizon"`       // how far ahead the moisture is forecast
+	Threshold    float64       `json:"threshold"`     // moisture below which the soil needs irrigation
+	ModelURL     string        `json:"model_url"`     // external model endpoint, empty uses the baseline model
+	ModelToken   string        `json:"-"`             // bearer token sent to the external model
+	ModelTimeout time.Duration `json:"model_timeout"` // timeout of the external model requests
+}
+
+// AuthConfig holds the access tokens of the HTTP API users; the API stays unauthenticated without a signing key
+type AuthConfig struct {
+	JWTSecret string        `json:"-"`         // HS256 signing key, at least 32 bytes
+	Issuer    string        `json:"issuer"`    // iss claim issued and required
+	TokenTTL  time.Duration `json:"token_ttl"` // lifetime of the issued tokens
+}
+
+// ResearchConfig holds the limits of the read-only tokens handed to research partners
+type ResearchConfig struct {
+	MaxTokenTTL time.Duration `json:"max_token_ttl"` // longest a research token may stay valid
+	MaxRange    time.Duration `json:"max_range"`     // longest range a telemetry query may cover
+}
+
+// DeviceArchiveConfig holds where the measurements of decommissioned devices are archived. They
+// are kept in the archive tables of the database, or moved on to the raw archive bucket.
+type DeviceArchiveConfig struct {
+	ObjectStorage bool   `json:"object_storage"` // requires the raw archive
+	Prefix        string `json:"prefix"`         // key prefix in the raw archive bucket
+}
+
+// MigrationConfig holds how devices are moved to another broker or topic layout
+type MigrationConfig struct {
+	StragglerAfter  time.Duration `json:"straggler_after"`  // devices not reconnected this long after being sent the configuration are stragglers
+	PublishInterval time.Duration `json:"publish_interval"` // pause between devices, sparing the broker a reconnect storm
+}
+
+// RetentionConfig holds how long sensor readings are kept before the retention job deletes them
+type RetentionConfig struct {
+	Enabled        bool          `json:"enabled"`
+	ReadingsMaxAge time.Duration `json:"readings_max_age"` // raw and compacted readings older than this are deleted
+	Interval       time.Duration `json:"interval"`
+	BatchSize      int           `json:"batch_size"` // rows deleted per statement
+}
+
+// IrrigationZoneDefinition is a zone parsed from IRRIGATION_ZONES with its flow rate from IRRIGATION_ZONE_FLOW_RATES
+type IrrigationZoneDefinition struct {
+	Name         string
+	MACAddresses []string
+	FlowRate     float64 // liters per minute, 0 when unknown
+}
+
+// FarmQuotaDefinition is the quota of a farm parsed from FARM_QUOTAS
+type FarmQuotaDefinition struct {
+	FarmID               string
+	MaxDevices           int
+	MaxMessagesPerMinute int
+}
+
+// EventPolicyDefinition is a publishing policy parsed from EVENT_POLICIES
+type EventPolicyDefinition struct {
+	EventType   string
+	Enabled     bool
+	SampleRate  float64
+	MinInterval time.Duration
+}
+
+// NotificationUserDefinition is the notification preferences of a user parsed from NOTIFICATION_USERS
+type NotificationUserDefinition struct {
+	User          string
+	Email         string
+	Phone         string
+	Language      string
+	Digest        string
+	QuietStart    time.Duration
+	QuietEnd      time.Duration
+	HasQuietHours bool
+	QuietOverride string
+	Routes        map[string][]string // severity to its channels
 }
 
 // NewAppConfig creates a new application configuration from environment variables
 func NewAppConfig() (*AppConfig, error) {
 	config := &AppConfig{
 		Server: ServerConfig{
-			Host:         getEnv("SERVER_HOST", "0.0.0.0"),
-			Port:         getEnv("SERVER_PORT", "8080"),
-			ReadTimeout:  getEnvDuration("SERVER_READ_TIMEOUT", 10*time.Second),
-			WriteTimeout: getEnvDuration("SERVER_WRITE_TIMEOUT", 10*time.Second),
-			IdleTimeout:  getEnvDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			Host:             getEnv("SERVER_HOST", "0.0.0.0"),
+			Port:             getEnv("SERVER_PORT", "8080"),
+			ReadTimeout:      getEnvDuration("SERVER_READ_TIMEOUT", 10*time.Second),
+			WriteTimeout:     getEnvDuration("SERVER_WRITE_TIMEOUT", 10*time.Second),
+			IdleTimeout:      getEnvDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			AdminToken:       getEnv("ADMIN_TOKEN", ""),
+			StatusQueryLimit: getEnvInt("DEVICE_STATUS_QUERY_LIMIT", 100),
+			DefaultLanguage:  getEnv("DEFAULT_LANGUAGE", string(i18n.English)),
 		},
 		Database: *NewDatabaseConfig(),
 		MQTT: MQTTConfig{
 			BrokerURL:            getEnv("MQTT_BROKER_URL", "tcp://localhost:1883"),
+			BrokerURLs:           getEnvStringSlice("MQTT_BROKER_URLS", nil),
 			ClientID:             getEnv("MQTT_CLIENT_ID", "iot-go-soc-consumer"),
 			Username:             getEnv("MQTT_USERNAME", ""),
 			Password:             getEnv("MQTT_PASSWORD", ""),
@@ -85,6 +615,31 @@ func NewAppConfig() (*AppConfig, error) {
 			ConnectTimeout:       getEnvDuration("MQTT_CONNECT_TIMEOUT", 30*time.Second),
 			KeepAlive:            getEnvDuration("MQTT_KEEP_ALIVE", 60*time.Second),
 			MaxReconnectInterval: getEnvDuration("MQTT_MAX_RECONNECT_INTERVAL", 10*time.Minute),
+			FailbackInterval:     getEnvDuration("MQTT_FAILBACK_INTERVAL", 30*time.Second),
+			TLS: MQTTTLSConfig{
+				CAFile:             getEnv("MQTT_TLS_CA_FILE", ""),
+				CertFile:           getEnv("MQTT_TLS_CERT_FILE", ""),
+				KeyFile:            getEnv("MQTT_TLS_KEY_FILE", ""),
+				InsecureSkipVerify: getEnvBool("MQTT_TLS_INSECURE_SKIP_VERIFY", false),
+			},
+			Embedded: EmbeddedBrokerConfig{
+				Enabled:        getEnvBool("MQTT_EMBEDDED_BROKER_ENABLED", false),
+				Address:        getEnv("MQTT_EMBEDDED_BROKER_ADDRESS", ":1883"),
+				Users:          getEnvStringSlice("MQTT_EMBEDDED_BROKER_USERS", nil),
+				BridgeURL:      getEnv("MQTT_BRIDGE_URL", ""),
+				BridgeClientID: getEnv("MQTT_BRIDGE_CLIENT_ID", "iot-go-soc-consumer-bridge"),
+				BridgeUsername: getEnv("MQTT_BRIDGE_USERNAME", ""),
+				BridgePassword: getEnv("MQTT_BRIDGE_PASSWORD", ""),
+				BridgeTopics:   getEnvStringSlice("MQTT_BRIDGE_TOPICS", []string{"/liwaisi/iot/smart-irrigation/#"}),
+				TLSAddress:     getEnv("MQTT_EMBEDDED_BROKER_TLS_ADDRESS", ""),
+				TLSCertFile:    getEnv("MQTT_EMBEDDED_BROKER_TLS_CERT_FILE", ""),
+				TLSKeyFile:     getEnv("MQTT_EMBEDDED_BROKER_TLS_KEY_FILE", ""),
+				TLSClientCA:    getEnv("MQTT_EMBEDDED_BROKER_TLS_CLIENT_CA_FILE", ""),
+			},
+			IdentityForwardPrefix: getEnv("MQTT_IDENTITY_FORWARD_PREFIX", ""),
+			VerifyDeviceIdentity:  getEnvBool("MQTT_VERIFY_DEVICE_IDENTITY", false),
+			FarmNamespaces:        getEnvBool("MQTT_FARM_NAMESPACES", false),
+			LegacyTopics:          getEnvBool("MQTT_LEGACY_TOPICS", false),
 		},
 		NATS: NATSConfig{
 			URLs:            getEnvStringSlice("NATS_URLS", []string{"nats://localhost:4222"}),
@@ -96,16 +651,291 @@ func NewAppConfig() (*AppConfig, error) {
 			PingInterval:    getEnvDuration("NATS_PING_INTERVAL", 2*time.Minute),
 			MaxPingsOut:     getEnvInt("NATS_MAX_PINGS_OUT", 2),
 			ReconnectBufSize: getEnvInt("NATS_RECONNECT_BUF_SIZE", 8*1024*1024),
+			Publisher: NATSCredentials{
+				CredsFile:    getEnv("NATS_PUBLISHER_CREDS_FILE", getEnv("NATS_CREDS_FILE", "")),
+				NKeySeedFile: getEnv("NATS_PUBLISHER_NKEY_SEED_FILE", getEnv("NATS_NKEY_SEED_FILE", "")),
+			},
+			Subscriber: NATSCredentials{
+				CredsFile:    getEnv("NATS_SUBSCRIBER_CREDS_FILE", getEnv("NATS_CREDS_FILE", "")),
+				NKeySeedFile: getEnv("NATS_SUBSCRIBER_NKEY_SEED_FILE", getEnv("NATS_NKEY_SEED_FILE", "")),
+			},
 		},
 		HealthCheck: HealthCheckConfig{
 			Timeout:       getEnvDuration("HEALTH_CHECK_TIMEOUT", 15*time.Second),
 			RetryAttempts: getEnvInt("HEALTH_CHECK_RETRY_ATTEMPTS", 3),
 			InitialDelay:  getEnvDuration("HEALTH_CHECK_INITIAL_DELAY", 3*time.Second),
 			UserAgent:     getEnv("HEALTH_CHECK_USER_AGENT", "iot-soc-consumer/1.0"),
+
+			OnDemandTimeout: getEnvDuration("HEALTH_CHECK_ON_DEMAND_TIMEOUT", 10*time.Second),
+
+			OfflineThreshold:     getEnvDuration("DEVICE_OFFLINE_THRESHOLD", 15*time.Minute),
+			OfflineSweepInterval: getEnvDuration("DEVICE_OFFLINE_SWEEP_INTERVAL", time.Minute),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
+			Shipping: LogShippingConfig{
+				Enabled:        getEnvBool("LOG_SHIPPING_ENABLED", false),
+				Backend:        getEnv("LOG_SHIPPING_BACKEND", "loki"),
+				URL:            getEnv("LOG_SHIPPING_URL", ""),
+				Index:          getEnv("LOG_SHIPPING_INDEX", "iot-go-soc-consumer"),
+				Username:       getEnv("LOG_SHIPPING_USERNAME", ""),
+				Password:       getEnv("LOG_SHIPPING_PASSWORD", ""),
+				BufferDir:      getEnv("LOG_SHIPPING_BUFFER_DIR", "./data/log-buffer"),
+				MaxBufferBytes: int64(getEnvInt("LOG_SHIPPING_MAX_BUFFER_BYTES", 64*1024*1024)),
+				BatchSize:      getEnvInt("LOG_SHIPPING_BATCH_SIZE", 500),
+				FlushInterval:  getEnvDuration("LOG_SHIPPING_FLUSH_INTERVAL", 5*time.Second),
+				MaxBackoff:     getEnvDuration("LOG_SHIPPING_MAX_BACKOFF", time.Minute),
+			},
+		},
+		Sync: SyncConfig{
+			Mode:       getEnv("SYNC_MODE", "disabled"),
+			CloudURL:   getEnv("SYNC_CLOUD_URL", ""),
+			Token:      getEnv("SYNC_TOKEN", ""),
+			InstanceID: getEnv("SYNC_INSTANCE_ID", ""),
+			Interval:   getEnvDuration("SYNC_INTERVAL", 30*time.Second),
+			BatchSize:  getEnvInt("SYNC_BATCH_SIZE", 200),
+			MaxBackoff: getEnvDuration("SYNC_MAX_BACKOFF", 10*time.Minute),
+			Timeout:    getEnvDuration("SYNC_TIMEOUT", 30*time.Second),
+		},
+		Telemetry: TelemetryConfig{
+			CompressionEnabled:  getEnvBool("TELEMETRY_COMPRESSION_ENABLED", false),
+			CompressAfterDays:   getEnvInt("TELEMETRY_COMPRESS_AFTER_DAYS", 30),
+			CompactionInterval:  getEnvDuration("TELEMETRY_COMPACTION_INTERVAL", time.Hour),
+			CompactionBatchDays: getEnvInt("TELEMETRY_COMPACTION_BATCH_DAYS", 100),
+		},
+		Jobs: JobsConfig{
+			Workers:       getEnvInt("JOBS_WORKERS", 2),
+			PollInterval:  getEnvDuration("JOBS_POLL_INTERVAL", 2*time.Second),
+			LeaseDuration: getEnvDuration("JOBS_LEASE_DURATION", time.Minute),
+			MaxAttempts:   getEnvInt("JOBS_MAX_ATTEMPTS", 3),
+			RetryBackoff:  getEnvDuration("JOBS_RETRY_BACKOFF", 10*time.Second),
+		},
+		Commands: CommandsConfig{
+			SigningKeys:  getEnvStringSlice("COMMAND_SIGNING_KEYS", nil),
+			SigningKeyID: getEnv("COMMAND_SIGNING_KEY_ID", ""),
+			ReplayWindow: getEnvDuration("COMMAND_REPLAY_WINDOW", 30*time.Second),
+			AckTimeout:   getEnvDuration("COMMAND_ACK_TIMEOUT", 2*time.Minute),
+			MaxAttempts:  getEnvInt("COMMAND_MAX_ATTEMPTS", 3),
+		},
+		Security: SecurityConfig{
+			MonitoringEnabled:        getEnvBool("SECURITY_MONITORING_ENABLED", true),
+			RegistrationWindow:       getEnvDuration("SECURITY_REGISTRATION_WINDOW", 10*time.Minute),
+			MaxMACsPerIP:             getEnvInt("SECURITY_MAX_MACS_PER_IP", 5),
+			CommandTopics:            getEnvStringSlice("SECURITY_COMMAND_TOPICS", []string{"/liwaisi/iot/smart-irrigation/commands/#"}),
+			TrustedCommandClients:    getEnvStringSlice("SECURITY_TRUSTED_COMMAND_CLIENTS", nil),
+			TelemetryWindow:          getEnvDuration("SECURITY_TELEMETRY_WINDOW", time.Minute),
+			TelemetryBurstFactor:     getEnvInt("SECURITY_TELEMETRY_BURST_FACTOR", 5),
+			TelemetryBurstMinimum:    getEnvInt("SECURITY_TELEMETRY_BURST_MINIMUM", 30),
+			AlertHistory:             getEnvInt("SECURITY_ALERT_HISTORY", 100),
+			MaxTracked:               getEnvInt("SECURITY_MAX_TRACKED", 50000),
+			ClientMismatchWindow:     getEnvDuration("SECURITY_CLIENT_MISMATCH_WINDOW", time.Hour),
+			BlacklistRefreshInterval: getEnvDuration("BLACKLIST_REFRESH_INTERVAL", time.Minute),
+		},
+		Pipeline: PipelineConfig{
+			Stages:              getEnvStringSlice("PIPELINE_STAGES", []string{"dedup", "blacklist", "schema", "rate_limit"}),
+			DedupWindow:         getEnvDuration("PIPELINE_DEDUP_WINDOW", 2*time.Second),
+			RateLimitPerMinute:  getEnvInt("PIPELINE_RATE_LIMIT_PER_MINUTE", 60),
+			RateLimitBurst:      getEnvInt("PIPELINE_RATE_LIMIT_BURST", 10),
+			DedupMaxEntries:     getEnvInt("PIPELINE_DEDUP_MAX_ENTRIES", 100000),
+			RateLimitMaxDevices: getEnvInt("PIPELINE_RATE_LIMIT_MAX_DEVICES", 50000),
+			ClockDriftTolerance: getEnvDuration("PIPELINE_CLOCK_DRIFT_TOLERANCE", 2*time.Second),
+		},
+		SensorHealth: SensorHealthConfig{
+			Enabled:               getEnvBool("SENSOR_HEALTH_ENABLED", true),
+			StaleFactor:           getEnvInt("SENSOR_HEALTH_STALE_FACTOR", 3),
+			StaleMinimum:          getEnvDuration("SENSOR_HEALTH_STALE_MINIMUM", 5*time.Minute),
+			CalibrationMaxAgeDays: getEnvInt("SENSOR_HEALTH_CALIBRATION_MAX_AGE_DAYS", 180),
+			DegradedScore:         getEnvInt("SENSOR_HEALTH_DEGRADED_SCORE", 60),
+		},
+		DeviceChanges: DeviceChangesConfig{
+			JournalSize: getEnvInt("DEVICE_CHANGES_JOURNAL_SIZE", 1000),
+			DefaultWait: getEnvDuration("DEVICE_CHANGES_DEFAULT_WAIT", 30*time.Second),
+			MaxWait:     getEnvDuration("DEVICE_CHANGES_MAX_WAIT", 60*time.Second),
+		},
+		Deadlines: DeadlinesConfig{
+			Message:  getEnvDuration("DEADLINE_MESSAGE", 30*time.Second),
+			Database: getEnvDuration("DEADLINE_DATABASE", 30*time.Second),
+			Publish:  getEnvDuration("DEADLINE_PUBLISH", 5*time.Second),
+		},
+		Observability: ObservabilityConfig{
+			SlowCallThreshold: getEnvDuration("PORT_SLOW_CALL_THRESHOLD", 500*time.Millisecond),
+		},
+		HealthReport: HealthReportConfig{
+			CheckTimeout:    getEnvDuration("HEALTH_REPORT_CHECK_TIMEOUT", 5*time.Second),
+			PublishInterval: getEnvDuration("HEALTH_REPORT_PUBLISH_INTERVAL", 0),
+		},
+		Shutdown: ShutdownConfig{
+			StopIntake:      getEnvDuration("SHUTDOWN_STOP_INTAKE_TIMEOUT", 10*time.Second),
+			DrainWorkers:    getEnvDuration("SHUTDOWN_DRAIN_WORKERS_TIMEOUT", 10*time.Second),
+			Flush:           getEnvDuration("SHUTDOWN_FLUSH_TIMEOUT", 5*time.Second),
+			ClosePublishers: getEnvDuration("SHUTDOWN_CLOSE_PUBLISHERS_TIMEOUT", 3*time.Second),
+			CloseDatabase:   getEnvDuration("SHUTDOWN_CLOSE_DATABASE_TIMEOUT", 2*time.Second),
+		},
+		Pagination: PaginationConfig{
+			DefaultLimit: getEnvInt("PAGINATION_DEFAULT_LIMIT", 50),
+			MaxLimit:     getEnvInt("PAGINATION_MAX_LIMIT", 500),
+		},
+		Forwarding: ForwardingConfig{
+			InfluxURL:        getEnv("FORWARD_INFLUX_URL", ""),
+			InfluxToken:      getEnv("FORWARD_INFLUX_TOKEN", ""),
+			RemoteWriteURL:   getEnv("FORWARD_REMOTE_WRITE_URL", ""),
+			RemoteWriteToken: getEnv("FORWARD_REMOTE_WRITE_TOKEN", ""),
+			MQTTBrokerURL:    getEnv("FORWARD_MQTT_BROKER_URL", ""),
+			MQTTClientID:     getEnv("FORWARD_MQTT_CLIENT_ID", "go-soc-consumer-forwarder"),
+			MQTTUsername:     getEnv("FORWARD_MQTT_USERNAME", ""),
+			MQTTPassword:     getEnv("FORWARD_MQTT_PASSWORD", ""),
+			MQTTTopicPrefix:  getEnv("FORWARD_MQTT_TOPIC_PREFIX", "telemetry/temperature-humidity"),
+			MQTTQoS:          byte(getEnvInt("FORWARD_MQTT_QOS", 1)),
+			BufferSize:       getEnvInt("FORWARD_BUFFER_SIZE", 10000),
+			BatchSize:        getEnvInt("FORWARD_BATCH_SIZE", 500),
+			FlushInterval:    getEnvDuration("FORWARD_FLUSH_INTERVAL", 5*time.Second),
+			MaxBackoff:       getEnvDuration("FORWARD_MAX_BACKOFF", 5*time.Minute),
+			RequestTimeout:   getEnvDuration("FORWARD_REQUEST_TIMEOUT", 10*time.Second),
+		},
+		RawArchive: RawArchiveConfig{
+			Enabled:              getEnvBool("RAW_ARCHIVE_ENABLED", false),
+			Endpoint:             getEnv("RAW_ARCHIVE_ENDPOINT", ""),
+			Region:               getEnv("RAW_ARCHIVE_REGION", "us-east-1"),
+			Bucket:               getEnv("RAW_ARCHIVE_BUCKET", ""),
+			Prefix:               getEnv("RAW_ARCHIVE_PREFIX", "raw"),
+			AccessKeyID:          getEnv("RAW_ARCHIVE_ACCESS_KEY_ID", ""),
+			SecretAccessKey:      getEnv("RAW_ARCHIVE_SECRET_ACCESS_KEY", ""),
+			ServerSideEncryption: getEnv("RAW_ARCHIVE_SSE", ""),
+			KMSKeyID:             getEnv("RAW_ARCHIVE_SSE_KMS_KEY_ID", ""),
+			RetentionDays:        getEnvInt("RAW_ARCHIVE_RETENTION_DAYS", 90),
+			MaxBundleBytes:       getEnvInt("RAW_ARCHIVE_MAX_BUNDLE_BYTES", 32*1024*1024),
+			MaxPendingBundles:    getEnvInt("RAW_ARCHIVE_MAX_PENDING_BUNDLES", 24),
+			MaxPendingBytes:      getEnvInt("RAW_ARCHIVE_MAX_PENDING_BYTES", 64*1024*1024),
+			UploadInterval:       getEnvDuration("RAW_ARCHIVE_UPLOAD_INTERVAL", time.Minute),
+			Timeout:              getEnvDuration("RAW_ARCHIVE_TIMEOUT", time.Minute),
+		},
+		Measurements: MeasurementsConfig{
+			SensorTypes:     getEnvStringSlice("SENSOR_TYPES", nil),
+			DerivedSensors:  getEnv("DERIVED_SENSORS", ""),
+			DerivedInterval: getEnvDuration("DERIVED_SENSORS_INTERVAL", 5*time.Minute),
+			DerivedMaxAge:   getEnvDuration("DERIVED_SENSORS_MAX_AGE", 15*time.Minute),
+		},
+		Alerts: AlertsConfig{
+			Rules:        getEnv("ALERT_RULES", ""),
+			AlertHistory: getEnvInt("ALERT_HISTORY", 100),
+
+			Conditions:         getEnv("ALERT_CONDITIONS", ""),
+			DeviceGroups:       getEnv("ALERT_DEVICE_GROUPS", ""),
+			ConditionsInterval: getEnvDuration("ALERT_CONDITIONS_INTERVAL", time.Minute),
+			ConditionsMaxAge:   getEnvDuration("ALERT_CONDITIONS_MAX_AGE", 15*time.Minute),
+		},
+		Notifications: NotificationsConfig{
+			Users:           getEnv("NOTIFICATION_USERS", ""),
+			TimeZone:        getEnv("NOTIFICATION_TIMEZONE", "America/Bogota"),
+			DailyDigestAt:   getEnvDuration("NOTIFICATION_DAILY_DIGEST_AT", 7*time.Hour),
+			Interval:        getEnvDuration("NOTIFICATION_INTERVAL", time.Minute),
+			QueueSize:       getEnvInt("NOTIFICATION_QUEUE_SIZE", 1000),
+			MaxHeldAlerts:   getEnvInt("NOTIFICATION_MAX_HELD_ALERTS", 100),
+			Timeout:         getEnvDuration("NOTIFICATION_TIMEOUT", 10*time.Second),
+			InboxRetention:  getEnvDuration("NOTIFICATION_INBOX_RETENTION", 30*24*time.Hour),
+			SMTPHost:        getEnv("NOTIFICATION_SMTP_HOST", ""),
+			SMTPPort:        getEnvInt("NOTIFICATION_SMTP_PORT", 587),
+			SMTPUsername:    getEnv("NOTIFICATION_SMTP_USERNAME", ""),
+			SMTPPassword:    getEnv("NOTIFICATION_SMTP_PASSWORD", ""),
+			SMTPFrom:        getEnv("NOTIFICATION_SMTP_FROM", ""),
+			SMSGatewayURL:   getEnv("NOTIFICATION_SMS_GATEWAY_URL", ""),
+			SMSGatewayToken: getEnv("NOTIFICATION_SMS_GATEWAY_TOKEN", ""),
+		},
+		Events: EventsConfig{
+			Policies: getEnv("EVENT_POLICIES", ""),
+		},
+		FarmQuotas: FarmQuotasConfig{
+			MaxDevices:           getEnvInt("FARM_QUOTA_MAX_DEVICES", 0),
+			MaxMessagesPerMinute: getEnvInt("FARM_QUOTA_MAX_MESSAGES_PER_MINUTE", 0),
+			Farms:                getEnv("FARM_QUOTAS", ""),
+		},
+		Usage: UsageConfig{
+			Enabled:             getEnvBool("USAGE_METERING_ENABLED", false),
+			FlushInterval:       getEnvDuration("USAGE_FLUSH_INTERVAL", time.Minute),
+			DeviceCountInterval: getEnvDuration("USAGE_DEVICE_COUNT_INTERVAL", time.Hour),
+			StorageBytesPerRow:  int64(getEnvInt("USAGE_STORAGE_BYTES_PER_ROW", 100)),
+		},
+		Drift: DriftConfig{
+			Enabled:     getEnvBool("DRIFT_RECONCILIATION_ENABLED", false),
+			Interval:    getEnvDuration("DRIFT_RECONCILE_INTERVAL", time.Minute),
+			PushBackoff: getEnvDuration("DRIFT_PUSH_BACKOFF", 2*time.Minute),
+			AlertAfter:  getEnvDuration("DRIFT_ALERT_AFTER", 30*time.Minute),
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:       getEnvBool("IRRIGATION_SCHEDULER_ENABLED", false),
+			Interval:      getEnvDuration("IRRIGATION_SCHEDULER_INTERVAL", 30*time.Second),
+			TimeZone:      getEnv("IRRIGATION_SCHEDULER_TIMEZONE", "America/Bogota"),
+			ProactiveLead: getEnvDuration("IRRIGATION_PROACTIVE_LEAD", 2*time.Hour),
+		},
+		Onboarding: OnboardingConfig{
+			BrokerURL: getEnv("ONBOARDING_BROKER_URL", ""),
+			TokenTTL:  getEnvDuration("ONBOARDING_TOKEN_TTL", 24*time.Hour),
+		},
+		Wifi: WifiConfig{
+			RSSIThreshold:      getEnvInt("WIFI_ADVISORY_RSSI_THRESHOLD", -80),
+			ReconnectThreshold: getEnvInt("WIFI_ADVISORY_RECONNECT_THRESHOLD", 5),
+			MinDevices:         getEnvInt("WIFI_ADVISORY_MIN_DEVICES", 1),
+			MaxAge:             getEnvDuration("WIFI_DIAGNOSTICS_MAX_AGE", 24*time.Hour),
+			Interval:           getEnvDuration("WIFI_ADVISORY_INTERVAL", time.Minute),
+		},
+		BrokerAuth: BrokerAuthConfig{
+			Token:               getEnv("BROKER_AUTH_TOKEN", ""),
+			ServiceUsers:        getEnvStringSlice("BROKER_AUTH_SERVICE_USERS", nil),
+			RequireCertificate:  getEnvBool("BROKER_AUTH_REQUIRE_CERTIFICATE", false),
+			AllowUnknownDevices: getEnvBool("BROKER_AUTH_ALLOW_UNKNOWN_DEVICES", false),
+		},
+		BrokerStats: BrokerStatsConfig{
+			Enabled: getEnvBool("BROKER_STATS_ENABLED", true),
+			Topic:   getEnv("BROKER_STATS_TOPIC", "$SYS/#"),
+		},
+		DeviceStatus: DeviceStatusConfig{
+			MaxClients:     getEnvInt("DEVICE_STATUS_WS_MAX_CLIENTS", 100),
+			SendBuffer:     getEnvInt("DEVICE_STATUS_WS_SEND_BUFFER", 32),
+			PingInterval:   getEnvDuration("DEVICE_STATUS_WS_PING_INTERVAL", 30*time.Second),
+			AllowedOrigins: getEnvStringSlice("DEVICE_STATUS_WS_ALLOWED_ORIGINS", nil),
+		},
+		Analytics: AnalyticsConfig{
+			Zones:            getEnv("IRRIGATION_ZONES", ""),
+			ZoneFlowRates:    getEnv("IRRIGATION_ZONE_FLOW_RATES", ""),
+			MoistureSensor:   getEnv("IRRIGATION_MOISTURE_SENSOR", "soil_moisture"),
+			TargetMoisture:   getEnvFloat("IRRIGATION_TARGET_MOISTURE", 35),
+			SettleWindow:     getEnvDuration("IRRIGATION_SETTLE_WINDOW", 2*time.Hour),
+			DeclineThreshold: getEnvFloat("IRRIGATION_DECLINE_THRESHOLD", 0.3),
+			MaxRange:         getEnvDuration("IRRIGATION_ANALYTICS_MAX_RANGE", 90*24*time.Hour),
+		},
+		Prediction: PredictionConfig{
+			Enabled:      getEnvBool("MOISTURE_PREDICTION_ENABLED", false),
+			Interval:     getEnvDuration("MOISTURE_PREDICTION_INTERVAL", 15*time.Minute),
+			Window:       getEnvDuration("MOISTURE_PREDICTION_WINDOW", 12*time.Hour),
+			Horizon:      getEnvDuration("MOISTURE_PREDICTION_HORIZON", 48*time.Hour),
+			Threshold:    getEnvFloat("MOISTURE_THRESHOLD", 25),
+			ModelURL:     getEnv("MOISTURE_MODEL_URL", ""),
+			ModelToken:   getEnv("MOISTURE_MODEL_TOKEN", ""),
+			ModelTimeout: getEnvDuration("MOISTURE_MODEL_TIMEOUT", 10*time.Second),
+		},
+		Auth: AuthConfig{
+			JWTSecret: getEnv("AUTH_JWT_SECRET", ""),
+			Issuer:    getEnv("AUTH_JWT_ISSUER", "go-soc-consumer"),
+			TokenTTL:  getEnvDuration("AUTH_TOKEN_TTL", 12*time.Hour),
+		},
+		Research: ResearchConfig{
+			MaxTokenTTL: getEnvDuration("RESEARCH_TOKEN_MAX_TTL", 8760*time.Hour),
+			MaxRange:    getEnvDuration("RESEARCH_MAX_RANGE", 744*time.Hour),
+		},
+		DeviceArchive: DeviceArchiveConfig{
+			ObjectStorage: getEnvBool("DEVICE_ARCHIVE_OBJECT_STORAGE", false),
+			Prefix:        getEnv("DEVICE_ARCHIVE_PREFIX", "devices"),
+		},
+		Migration: MigrationConfig{
+			StragglerAfter:  getEnvDuration("DEVICE_MIGRATION_STRAGGLER_AFTER", time.Hour),
+			PublishInterval: getEnvDuration("DEVICE_MIGRATION_PUBLISH_INTERVAL", 50*time.Millisecond),
+		},
+		Retention: RetentionConfig{
+			Enabled:        getEnvBool("RETENTION_ENABLED", false),
+			ReadingsMaxAge: getEnvDuration("RETENTION_READINGS_MAX_AGE", 8760*time.Hour),
+			Interval:       getEnvDuration("RETENTION_INTERVAL", time.Hour),
+			BatchSize:      getEnvInt("RETENTION_BATCH_SIZE", 10000),
 		},
 	}
 
@@ -126,48 +956,1297 @@ func (c *AppConfig) Validate() error {
 		return fmt.Errorf("server config: %w", err)
 	}
 
+	if err := c.GetPaginationPolicy().Validate(); err != nil {
+		return fmt.Errorf("pagination config: %w", err)
+	}
+
 	if err := c.validateMQTT(); err != nil {
 		return fmt.Errorf("mqtt config: %w", err)
 	}
 
+	if err := c.validateNATS(); err != nil {
+		return fmt.Errorf("nats config: %w", err)
+	}
+
 	if err := c.validateHealthCheck(); err != nil {
 		return fmt.Errorf("health check config: %w", err)
 	}
 
+	if err := c.validateLogging(); err != nil {
+		return fmt.Errorf("logging config: %w", err)
+	}
+
+	if err := c.validateSync(); err != nil {
+		return fmt.Errorf("sync config: %w", err)
+	}
+
+	if err := c.validateTelemetry(); err != nil {
+		return fmt.Errorf("telemetry config: %w", err)
+	}
+
+	if err := c.validateJobs(); err != nil {
+		return fmt.Errorf("jobs config: %w", err)
+	}
+
+	if err := c.validateCommands(); err != nil {
+		return fmt.Errorf("commands config: %w", err)
+	}
+
+	if err := c.validateSecurity(); err != nil {
+		return fmt.Errorf("security config: %w", err)
+	}
+
+	if err := c.validatePipeline(); err != nil {
+		return fmt.Errorf("pipeline config: %w", err)
+	}
+
+	if err := c.validateSensorHealth(); err != nil {
+		return fmt.Errorf("sensor health config: %w", err)
+	}
+
+	if err := c.validateDeviceChanges(); err != nil {
+		return fmt.Errorf("device changes config: %w", err)
+	}
+
+	if c.Deadlines.Message < 0 || c.Deadlines.Database < 0 || c.Deadlines.Publish < 0 {
+		return fmt.Errorf("deadlines config: deadlines cannot be negative")
+	}
+
+	if c.Observability.SlowCallThreshold < 0 {
+		return fmt.Errorf("observability config: slow call threshold cannot be negative")
+	}
+
+	if c.HealthReport.CheckTimeout <= 0 {
+		return fmt.Errorf("health report config: check timeout must be positive")
+	}
+	if c.HealthReport.PublishInterval < 0 {
+		return fmt.Errorf("health report config: publish interval cannot be negative")
+	}
+
+	if err := c.validateShutdown(); err != nil {
+		return fmt.Errorf("shutdown config: %w", err)
+	}
+
+	if err := c.validateForwarding(); err != nil {
+		return fmt.Errorf("forwarding config: %w", err)
+	}
+
+	if err := c.validateRawArchive(); err != nil {
+		return fmt.Errorf("raw archive config: %w", err)
+	}
+
+	if err := c.validateMeasurements(); err != nil {
+		return fmt.Errorf("measurements config: %w", err)
+	}
+
+	if err := c.validateAlerts(); err != nil {
+		return fmt.Errorf("alerts config: %w", err)
+	}
+
+	if err := c.validateNotifications(); err != nil {
+		return fmt.Errorf("notifications config: %w", err)
+	}
+
+	if err := c.validateEvents(); err != nil {
+		return fmt.Errorf("events config: %w", err)
+	}
+
+	if err := c.validateFarmQuotas(); err != nil {
+		return fmt.Errorf("farm quotas config: %w", err)
+	}
+
+	if err := c.validateUsage(); err != nil {
+		return fmt.Errorf("usage config: %w", err)
+	}
+
+	if err := c.validateDrift(); err != nil {
+		return fmt.Errorf("drift config: %w", err)
+	}
+
+	if err := c.validateScheduler(); err != nil {
+		return fmt.Errorf("scheduler config: %w", err)
+	}
+
+	if err := c.validateOnboarding(); err != nil {
+		return fmt.Errorf("onboarding config: %w", err)
+	}
+
+	if err := c.validateWifi(); err != nil {
+		return fmt.Errorf("wifi config: %w", err)
+	}
+
+	if err := c.validateBrokerAuth(); err != nil {
+		return fmt.Errorf("broker auth config: %w", err)
+	}
+
+	if err := c.validateBrokerStats(); err != nil {
+		return fmt.Errorf("broker stats config: %w", err)
+	}
+
+	if err := c.validateDeviceStatus(); err != nil {
+		return fmt.Errorf("device status config: %w", err)
+	}
+
+	if err := c.validateAnalytics(); err != nil {
+		return fmt.Errorf("analytics config: %w", err)
+	}
+
+	if err := c.validatePrediction(); err != nil {
+		return fmt.Errorf("prediction config: %w", err)
+	}
+
+	if err := c.validateAuth(); err != nil {
+		return fmt.Errorf("auth config: %w", err)
+	}
+
+	if err := c.validateResearch(); err != nil {
+		return fmt.Errorf("research config: %w", err)
+	}
+
+	if err := c.validateDeviceArchive(); err != nil {
+		return fmt.Errorf("device archive config: %w", err)
+	}
+
+	if err := c.validateMigration(); err != nil {
+		return fmt.Errorf("device migration config: %w", err)
+	}
+
+	if err := c.validateRetention(); err != nil {
+		return fmt.Errorf("retention config: %w", err)
+	}
+
 	return nil
 }
 
-func (c *AppConfig) validateServer() error {
-	if c.Server.Host == "" {
-		return fmt.Errorf("server host is required")
+func (c *AppConfig) validateMeasurements() error {
+	if _, err := c.GetSensorTypes(); err != nil {
+		return err
 	}
-	if c.Server.Port == "" {
-		return fmt.Errorf("server port is required")
+	if _, err := c.GetDerivedSensors(); err != nil {
+		return err
+	}
+	if c.Measurements.DerivedInterval <= 0 {
+		return fmt.Errorf("derived sensors interval must be positive")
+	}
+	if c.Measurements.DerivedMaxAge <= 0 {
+		return fmt.Errorf("derived sensors max age must be positive")
 	}
 	return nil
 }
 
-func (c *AppConfig) validateMQTT() error {
-	if c.MQTT.BrokerURL == "" {
-		return fmt.Errorf("MQTT broker URL is required")
+func (c *AppConfig) validateAlerts() error {
+	rules, err := c.GetAlertRules()
+	if err != nil {
+		return err
 	}
-	if c.MQTT.ClientID == "" {
-		return fmt.Errorf("MQTT client ID is required")
+	conditions, err := c.GetAlertConditions()
+	if err != nil {
+		return err
+	}
+	if _, err := c.GetAlertDeviceGroups(); err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(rules)+len(conditions))
+	for _, rule := range rules {
+		names[rule.Name] = true
+	}
+	for _, condition := range conditions {
+		if names[condition.Name] {
+			return fmt.Errorf("alert rule %q is defined more than once", condition.Name)
+		}
+		names[condition.Name] = true
+	}
+	if c.Alerts.AlertHistory < 1 {
+		return fmt.Errorf("alert history must be at least 1")
+	}
+	if c.Alerts.ConditionsInterval <= 0 {
+		return fmt.Errorf("alert conditions interval must be positive")
+	}
+	if c.Alerts.ConditionsMaxAge <= 0 {
+		return fmt.Errorf("alert conditions max age must be positive")
 	}
 	return nil
 }
 
-func (c *AppConfig) validateHealthCheck() error {
-	if c.HealthCheck.Timeout <= 0 {
-		return fmt.Errorf("health check timeout must be greater than 0")
+func (c *AppConfig) validateNotifications() error {
+	users, err := c.GetNotificationUsers()
+	if err != nil {
+		return err
 	}
-	if c.HealthCheck.RetryAttempts < 0 {
-		return fmt.Errorf("health check retry attempts must be >= 0")
+	if _, err := c.GetNotificationLocation(); err != nil {
+		return err
+	}
+	for _, user := range users {
+		if user.Email != "" && (c.Notifications.SMTPHost == "" || c.Notifications.SMTPFrom == "") {
+			return fmt.Errorf("user %q has an email address but NOTIFICATION_SMTP_HOST or NOTIFICATION_SMTP_FROM is not set", user.User)
+		}
+		if user.Phone != "" && c.Notifications.SMSGatewayURL == "" {
+			return fmt.Errorf("user %q has a phone number but NOTIFICATION_SMS_GATEWAY_URL is not set", user.User)
+		}
+		if user.Language != "" {
+			if _, ok := i18n.ParseLanguage(user.Language); !ok {
+				return fmt.Errorf("user %q: unsupported language %s", user.User, user.Language)
+			}
+		}
+	}
+	if c.Notifications.DailyDigestAt < 0 || c.Notifications.DailyDigestAt >= 24*time.Hour {
+		return fmt.Errorf("daily digest time must be within a day")
+	}
+	if c.Notifications.Interval <= 0 {
+		return fmt.Errorf("notification interval must be positive")
+	}
+	if c.Notifications.QueueSize < 1 {
+		return fmt.Errorf("notification queue size must be at least 1")
+	}
+	if c.Notifications.MaxHeldAlerts < 1 {
+		return fmt.Errorf("notification max held alerts must be at least 1")
+	}
+	if c.Notifications.InboxRetention <= 0 {
+		return fmt.Errorf("notification inbox retention must be positive")
 	}
 	return nil
 }
 
-// GetServerAddress returns the full server address
-func (c *AppConfig) GetServerAddress() string {
-	return fmt.Sprintf("%s:%s", c.Server.Host, c.Server.Port)
-}
\ No newline at end of file
+func (c *AppConfig) validateEvents() error {
+	policies, err := c.GetEventPolicies()
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(policies))
+	for _, policy := range policies {
+		if seen[policy.EventType] {
+			return fmt.Errorf("duplicate event policy %q", policy.EventType)
+		}
+		seen[policy.EventType] = true
+		if policy.SampleRate < 0 || policy.SampleRate > 1 {
+			return fmt.Errorf("event policy %q: sample rate must be between 0 and 1", policy.EventType)
+		}
+		if policy.MinInterval < 0 {
+			return fmt.Errorf("event policy %q: minimum interval cannot be negative", policy.EventType)
+		}
+	}
+	return nil
+}
+
+func (c *AppConfig) validateFarmQuotas() error {
+	if c.FarmQuotas.MaxDevices < 0 || c.FarmQuotas.MaxMessagesPerMinute < 0 {
+		return fmt.Errorf("default farm quotas cannot be negative")
+	}
+	quotas, err := c.GetFarmQuotas()
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(quotas))
+	for _, quota := range quotas {
+		if seen[quota.FarmID] {
+			return fmt.Errorf("duplicate farm quota %q", quota.FarmID)
+		}
+		seen[quota.FarmID] = true
+		if quota.MaxDevices < 0 || quota.MaxMessagesPerMinute < 0 {
+			return fmt.Errorf("farm quota %q: limits cannot be negative", quota.FarmID)
+		}
+	}
+	return nil
+}
+
+func (c *AppConfig) validateUsage() error {
+	if !c.Usage.Enabled {
+		return nil
+	}
+	if c.Usage.FlushInterval <= 0 || c.Usage.DeviceCountInterval <= 0 {
+		return fmt.Errorf("usage flush and device count intervals must be positive")
+	}
+	if c.Usage.DeviceCountInterval > 24*time.Hour {
+		return fmt.Errorf("usage device count interval cannot exceed a day")
+	}
+	if c.Usage.StorageBytesPerRow < 0 {
+		return fmt.Errorf("usage storage bytes per row cannot be negative")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateDrift() error {
+	if !c.Drift.Enabled {
+		return nil
+	}
+	if c.Drift.Interval <= 0 || c.Drift.PushBackoff <= 0 || c.Drift.AlertAfter <= 0 {
+		return fmt.Errorf("drift interval, push backoff and alert delay must be positive")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateScheduler() error {
+	if !c.Scheduler.Enabled {
+		return nil
+	}
+	if c.Scheduler.Interval <= 0 {
+		return fmt.Errorf("scheduler interval must be positive")
+	}
+	if _, err := c.GetSchedulerLocation(); err != nil {
+		return err
+	}
+	if c.Scheduler.ProactiveLead < 0 {
+		return fmt.Errorf("proactive lead cannot be negative")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateOnboarding() error {
+	if c.Onboarding.TokenTTL <= 0 {
+		return fmt.Errorf("provisioning token TTL must be positive")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateWifi() error {
+	if c.Wifi.RSSIThreshold >= 0 {
+		return fmt.Errorf("wifi advisory RSSI threshold must be negative")
+	}
+	if c.Wifi.ReconnectThreshold < 1 {
+		return fmt.Errorf("wifi advisory reconnect threshold must be at least 1")
+	}
+	if c.Wifi.MinDevices < 1 {
+		return fmt.Errorf("wifi advisory minimum devices must be at least 1")
+	}
+	if c.Wifi.MaxAge <= 0 || c.Wifi.Interval <= 0 {
+		return fmt.Errorf("wifi diagnostics max age and advisory interval must be positive")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateBrokerAuth() error {
+	for _, user := range c.BrokerAuth.ServiceUsers {
+		if username, _, found := strings.Cut(user, ":"); !found || username == "" {
+			return fmt.Errorf("broker auth service users must be username:password pairs")
+		}
+	}
+	return nil
+}
+
+func (c *AppConfig) validateBrokerStats() error {
+	if c.BrokerStats.Enabled && c.BrokerStats.Topic == "" {
+		return fmt.Errorf("broker stats topic is required")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateDeviceStatus() error {
+	if c.DeviceStatus.MaxClients < 1 || c.DeviceStatus.SendBuffer < 1 {
+		return fmt.Errorf("max clients and send buffer must be at least 1")
+	}
+	if c.DeviceStatus.PingInterval <= 0 {
+		return fmt.Errorf("ping interval must be greater than 0")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateAnalytics() error {
+	if _, err := c.GetIrrigationZones(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(c.Analytics.MoistureSensor) == "" {
+		return fmt.Errorf("moisture sensor type is required")
+	}
+	if c.Analytics.SettleWindow <= 0 || c.Analytics.MaxRange <= 0 {
+		return fmt.Errorf("settle window and max range must be greater than 0")
+	}
+	if c.Analytics.DeclineThreshold <= 0 || c.Analytics.DeclineThreshold >= 1 {
+		return fmt.Errorf("decline threshold must be between 0 and 1")
+	}
+	return nil
+}
+
+func (c *AppConfig) validatePrediction() error {
+	if !c.Prediction.Enabled {
+		return nil
+	}
+	if c.Prediction.Interval <= 0 || c.Prediction.Window <= 0 || c.Prediction.Horizon <= 0 {
+		return fmt.Errorf("interval, window and horizon must be greater than 0")
+	}
+	if c.Prediction.ModelURL != "" && !strings.HasPrefix(c.Prediction.ModelURL, "http://") && !strings.HasPrefix(c.Prediction.ModelURL, "https://") {
+		return fmt.Errorf("model url must be an http or https URL")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateAuth() error {
+	if c.Auth.JWTSecret == "" {
+		return nil
+	}
+	if len(c.Auth.JWTSecret) < 32 {
+		return fmt.Errorf("jwt secret must be at least 32 bytes")
+	}
+	if strings.TrimSpace(c.Auth.Issuer) == "" {
+		return fmt.Errorf("jwt issuer is required")
+	}
+	if c.Auth.TokenTTL <= 0 {
+		return fmt.Errorf("token TTL must be greater than 0")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateResearch() error {
+	if c.Research.MaxTokenTTL <= 0 {
+		return fmt.Errorf("max token TTL must be greater than 0")
+	}
+	if c.Research.MaxRange <= 0 {
+		return fmt.Errorf("max range must be greater than 0")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateDeviceArchive() error {
+	if !c.DeviceArchive.ObjectStorage {
+		return nil
+	}
+	if !c.RawArchive.Enabled {
+		return fmt.Errorf("object storage requires the raw archive to be enabled")
+	}
+	prefix := strings.Trim(c.DeviceArchive.Prefix, "/")
+	if prefix == "" || prefix == strings.Trim(c.RawArchive.Prefix, "/") {
+		return fmt.Errorf("prefix is required and must differ from the raw archive prefix")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateMigration() error {
+	if c.Migration.StragglerAfter <= 0 {
+		return fmt.Errorf("straggler after must be greater than 0")
+	}
+	if c.Migration.PublishInterval < 0 {
+		return fmt.Errorf("publish interval cannot be negative")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateRetention() error {
+	if !c.Retention.Enabled {
+		return nil
+	}
+	if c.Retention.ReadingsMaxAge < 24*time.Hour {
+		return fmt.Errorf("readings max age must be at least 24h")
+	}
+	if c.Retention.Interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+	if c.Retention.BatchSize <= 0 {
+		return fmt.Errorf("batch size must be greater than 0")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateServer() error {
+	if c.Server.Host == "" {
+		return fmt.Errorf("server host is required")
+	}
+	if c.Server.Port == "" {
+		return fmt.Errorf("server port is required")
+	}
+	if c.Server.StatusQueryLimit < 1 {
+		return fmt.Errorf("device status query limit must be at least 1")
+	}
+	if _, ok := i18n.ParseLanguage(c.Server.DefaultLanguage); !ok {
+		return fmt.Errorf("unsupported default language: %s", c.Server.DefaultLanguage)
+	}
+	return nil
+}
+
+func (c *AppConfig) validateMQTT() error {
+	if c.MQTT.BrokerURL == "" && len(c.MQTT.BrokerURLs) == 0 {
+		return fmt.Errorf("MQTT broker URL is required")
+	}
+	for _, brokerURL := range c.MQTT.BrokerURLs {
+		if brokerURL == "" {
+			return fmt.Errorf("MQTT broker URLs must not contain empty entries")
+		}
+	}
+	if (c.MQTT.TLS.CertFile == "") != (c.MQTT.TLS.KeyFile == "") {
+		return fmt.Errorf("MQTT TLS client certificate and key must be set together")
+	}
+	if c.MQTT.TLS.Enabled() && !c.MQTT.Embedded.Enabled {
+		for _, brokerURL := range c.GetMQTTBrokerURLs() {
+			if !isTLSBrokerURL(brokerURL) {
+				return fmt.Errorf("MQTT TLS options require TLS broker URLs (mqtts://, ssl:// or tls://), got %s", brokerURL)
+			}
+		}
+	}
+	if c.MQTT.Embedded.Enabled {
+		if c.MQTT.Embedded.Address == "" {
+			return fmt.Errorf("embedded MQTT broker address is required")
+		}
+		if _, err := c.GetEmbeddedBrokerUsers(); err != nil {
+			return err
+		}
+		if c.MQTT.Embedded.TLSAddress != "" && (c.MQTT.Embedded.TLSCertFile == "" || c.MQTT.Embedded.TLSKeyFile == "") {
+			return fmt.Errorf("embedded MQTT broker TLS listener requires a certificate and key")
+		}
+		if c.MQTT.IdentityForwardPrefix != "" {
+			return fmt.Errorf("MQTT identity forwarding is not supported with the embedded broker, which reports identities directly")
+		}
+	}
+	if c.MQTT.LegacyTopics && !c.MQTT.FarmNamespaces {
+		return fmt.Errorf("MQTT legacy topics require farm namespaces, which are the only topics subscribed otherwise")
+	}
+	if c.MQTT.ClientID == "" {
+		return fmt.Errorf("MQTT client ID is required")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateNATS() error {
+	if c.NATS.Publisher.CredsFile != "" && c.NATS.Publisher.NKeySeedFile != "" {
+		return fmt.Errorf("NATS publisher takes either a credentials file or an NKey seed file, not both")
+	}
+	if c.NATS.Subscriber.CredsFile != "" && c.NATS.Subscriber.NKeySeedFile != "" {
+		return fmt.Errorf("NATS subscriber takes either a credentials file or an NKey seed file, not both")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateHealthCheck() error {
+	if c.HealthCheck.Timeout <= 0 {
+		return fmt.Errorf("health check timeout must be greater than 0")
+	}
+	if c.HealthCheck.RetryAttempts < 0 {
+		return fmt.Errorf("health check retry attempts must be >= 0")
+	}
+	if c.HealthCheck.OnDemandTimeout <= 0 {
+		return fmt.Errorf("on-demand health check timeout must be greater than 0")
+	}
+	if c.HealthCheck.OfflineThreshold <= 0 {
+		return fmt.Errorf("device offline threshold must be greater than 0")
+	}
+	if c.HealthCheck.OfflineSweepInterval <= 0 {
+		return fmt.Errorf("device offline sweep interval must be greater than 0")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateLogging() error {
+	if !c.Logging.Shipping.Enabled {
+		return nil
+	}
+	if c.Logging.Shipping.URL == "" {
+		return fmt.Errorf("log shipping URL is required when shipping is enabled")
+	}
+	if c.Logging.Shipping.Backend != "loki" && c.Logging.Shipping.Backend != "elasticsearch" {
+		return fmt.Errorf("log shipping backend must be loki or elasticsearch")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateSync() error {
+	switch c.Sync.Mode {
+	case "disabled":
+		return nil
+	case "edge":
+		if c.Sync.CloudURL == "" {
+			return fmt.Errorf("sync cloud URL is required in edge mode")
+		}
+		if c.Sync.InstanceID == "" {
+			return fmt.Errorf("sync instance ID is required in edge mode")
+		}
+		if c.Sync.BatchSize <= 0 {
+			return fmt.Errorf("sync batch size must be greater than 0")
+		}
+		if c.Sync.BatchSize > c.Pagination.MaxLimit {
+			return fmt.Errorf("sync batch size cannot exceed the pagination max limit")
+		}
+		if c.Sync.Interval <= 0 {
+			return fmt.Errorf("sync interval must be greater than 0")
+		}
+	case "cloud":
+		if c.Sync.Token == "" {
+			return fmt.Errorf("sync token is required in cloud mode")
+		}
+	default:
+		return fmt.Errorf("sync mode must be disabled, edge or cloud")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateTelemetry() error {
+	if !c.Telemetry.CompressionEnabled {
+		return nil
+	}
+	if c.Telemetry.CompressAfterDays < 1 {
+		return fmt.Errorf("telemetry compression delay must be at least 1 day")
+	}
+	if c.Telemetry.CompactionInterval <= 0 {
+		return fmt.Errorf("telemetry compaction interval must be greater than 0")
+	}
+	if c.Telemetry.CompactionBatchDays <= 0 {
+		return fmt.Errorf("telemetry compaction batch size must be greater than 0")
+	}
+	if c.Telemetry.CompactionBatchDays > c.Pagination.MaxLimit {
+		return fmt.Errorf("telemetry compaction batch size cannot exceed the pagination max limit")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateJobs() error {
+	if c.Jobs.Workers < 1 {
+		return fmt.Errorf("job workers must be at least 1")
+	}
+	if c.Jobs.MaxAttempts < 1 {
+		return fmt.Errorf("job max attempts must be at least 1")
+	}
+	if c.Jobs.PollInterval <= 0 || c.Jobs.LeaseDuration <= 0 {
+		return fmt.Errorf("job poll interval and lease duration must be greater than 0")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateCommands() error {
+	if c.Commands.AckTimeout <= 0 {
+		return fmt.Errorf("command ack timeout must be greater than 0")
+	}
+	if c.Commands.MaxAttempts < 1 || c.Commands.MaxAttempts > 10 {
+		return fmt.Errorf("command max attempts must be between 1 and 10")
+	}
+	if len(c.Commands.SigningKeys) == 0 {
+		return nil
+	}
+	keys, err := c.GetCommandSigningKeys()
+	if err != nil {
+		return err
+	}
+	if _, ok := keys[c.Commands.SigningKeyID]; !ok {
+		return fmt.Errorf("command signing key ID %q is not one of the configured keys", c.Commands.SigningKeyID)
+	}
+	if c.Commands.ReplayWindow <= 0 {
+		return fmt.Errorf("command replay window must be greater than 0")
+	}
+	return nil
+}
+
+// validateSecurity validates security monitoring configuration
+func (c *AppConfig) validateSecurity() error {
+	if c.Security.BlacklistRefreshInterval <= 0 {
+		return fmt.Errorf("blacklist refresh interval must be greater than 0")
+	}
+	if c.Security.ClientMismatchWindow <= 0 {
+		return fmt.Errorf("client mismatch window must be greater than 0")
+	}
+	if !c.Security.MonitoringEnabled {
+		return nil
+	}
+	if c.Security.RegistrationWindow <= 0 || c.Security.TelemetryWindow <= 0 {
+		return fmt.Errorf("security monitoring windows must be greater than 0")
+	}
+	if c.Security.MaxMACsPerIP < 1 {
+		return fmt.Errorf("max MACs per IP must be at least 1")
+	}
+	if c.Security.TelemetryBurstFactor < 2 || c.Security.TelemetryBurstMinimum < 1 {
+		return fmt.Errorf("telemetry burst factor must be at least 2 and burst minimum at least 1")
+	}
+	if c.Security.AlertHistory < 1 {
+		return fmt.Errorf("alert history must be at least 1")
+	}
+	if c.Security.MaxTracked < 1 {
+		return fmt.Errorf("security max tracked must be at least 1")
+	}
+	return nil
+}
+
+// validatePipeline validates the ingestion pipeline stages and their settings
+func (c *AppConfig) validatePipeline() error {
+	enabled := make(map[string]bool, len(c.Pipeline.Stages))
+	for _, stage := range c.Pipeline.Stages {
+		known := false
+		for _, name := range PipelineStageNames {
+			known = known || stage == name
+		}
+		if !known {
+			return fmt.Errorf("unknown pipeline stage %q, expected one of %s", stage, strings.Join(PipelineStageNames, ", "))
+		}
+		if enabled[stage] {
+			return fmt.Errorf("pipeline stage %q is listed twice", stage)
+		}
+		enabled[stage] = true
+	}
+
+	if enabled["dedup"] && c.Pipeline.DedupWindow <= 0 {
+		return fmt.Errorf("dedup window must be greater than 0")
+	}
+	if enabled["dedup"] && c.Pipeline.DedupMaxEntries < 1 {
+		return fmt.Errorf("dedup max entries must be at least 1")
+	}
+	if enabled["rate_limit"] && (c.Pipeline.RateLimitPerMinute < 1 || c.Pipeline.RateLimitBurst < 1) {
+		return fmt.Errorf("rate limit and burst must be at least 1")
+	}
+	if enabled["rate_limit"] && c.Pipeline.RateLimitMaxDevices < 1 {
+		return fmt.Errorf("rate limit max devices must be at least 1")
+	}
+	if c.Pipeline.ClockDriftTolerance < 0 {
+		return fmt.Errorf("clock drift tolerance cannot be negative")
+	}
+	return nil
+}
+
+// validateSensorHealth validates the sensor health thresholds
+func (c *AppConfig) validateSensorHealth() error {
+	if !c.SensorHealth.Enabled {
+		return nil
+	}
+	if c.SensorHealth.StaleFactor < 1 {
+		return fmt.Errorf("stale factor must be at least 1")
+	}
+	if c.SensorHealth.StaleMinimum <= 0 {
+		return fmt.Errorf("stale minimum must be greater than 0")
+	}
+	if c.SensorHealth.CalibrationMaxAgeDays < 1 {
+		return fmt.Errorf("calibration max age must be at least 1 day")
+	}
+	if c.SensorHealth.DegradedScore < 1 || c.SensorHealth.DegradedScore > 100 {
+		return fmt.Errorf("degraded score must be between 1 and 100")
+	}
+	return nil
+}
+
+// validateDeviceChanges validates the change journal and long-poll waits
+func (c *AppConfig) validateDeviceChanges() error {
+	if c.DeviceChanges.JournalSize < 1 {
+		return fmt.Errorf("journal size must be at least 1")
+	}
+	if c.DeviceChanges.DefaultWait <= 0 || c.DeviceChanges.MaxWait <= 0 {
+		return fmt.Errorf("long-poll waits must be greater than 0")
+	}
+	if c.DeviceChanges.DefaultWait > c.DeviceChanges.MaxWait {
+		return fmt.Errorf("default wait cannot exceed the max wait")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateShutdown() error {
+	timeouts := []struct {
+		phase   string
+		timeout time.Duration
+	}{
+		{"stop intake", c.Shutdown.StopIntake},
+		{"drain workers", c.Shutdown.DrainWorkers},
+		{"flush", c.Shutdown.Flush},
+		{"close publishers", c.Shutdown.ClosePublishers},
+		{"close database", c.Shutdown.CloseDatabase},
+	}
+	for _, t := range timeouts {
+		if t.timeout <= 0 {
+			return fmt.Errorf("%s timeout must be positive", t.phase)
+		}
+	}
+	return nil
+}
+
+func (c *AppConfig) validateForwarding() error {
+	if !c.ForwardingEnabled() {
+		return nil
+	}
+	if c.Forwarding.BufferSize < 1 {
+		return fmt.Errorf("buffer size must be at least 1")
+	}
+	if c.Forwarding.BatchSize < 1 || c.Forwarding.BatchSize > c.Forwarding.BufferSize {
+		return fmt.Errorf("batch size must be between 1 and the buffer size")
+	}
+	if c.Forwarding.FlushInterval <= 0 || c.Forwarding.MaxBackoff <= 0 || c.Forwarding.RequestTimeout <= 0 {
+		return fmt.Errorf("flush interval, max backoff and request timeout must be greater than 0")
+	}
+	if c.Forwarding.MQTTQoS > 2 {
+		return fmt.Errorf("mqtt QoS must be 0, 1 or 2")
+	}
+	return nil
+}
+
+func (c *AppConfig) validateRawArchive() error {
+	if !c.RawArchive.Enabled {
+		return nil
+	}
+	if c.RawArchive.Endpoint == "" || c.RawArchive.Bucket == "" {
+		return fmt.Errorf("endpoint and bucket are required")
+	}
+	if c.RawArchive.AccessKeyID == "" || c.RawArchive.SecretAccessKey == "" {
+		return fmt.Errorf("access key ID and secret access key are required")
+	}
+	switch c.RawArchive.ServerSideEncryption {
+	case "", "AES256", "aws:kms":
+	default:
+		return fmt.Errorf("server-side encryption must be AES256, aws:kms or empty")
+	}
+	if c.RawArchive.KMSKeyID != "" && c.RawArchive.ServerSideEncryption != "aws:kms" {
+		return fmt.Errorf("a KMS key ID requires aws:kms server-side encryption")
+	}
+	if c.RawArchive.RetentionDays < 0 {
+		return fmt.Errorf("retention days cannot be negative")
+	}
+	if c.RawArchive.MaxBundleBytes < 1 || c.RawArchive.MaxPendingBundles < 1 {
+		return fmt.Errorf("max bundle bytes and max pending bundles must be at least 1")
+	}
+	if c.RawArchive.MaxPendingBytes < c.RawArchive.MaxBundleBytes {
+		return fmt.Errorf("max pending bytes must be at least max bundle bytes")
+	}
+	if c.RawArchive.UploadInterval <= 0 || c.RawArchive.Timeout <= 0 {
+		return fmt.Errorf("upload interval and timeout must be greater than 0")
+	}
+	return nil
+}
+
+// ForwardingEnabled reports whether at least one telemetry forwarding sink is configured
+func (c *AppConfig) ForwardingEnabled() bool {
+	return c.Forwarding.InfluxURL != "" || c.Forwarding.RemoteWriteURL != "" || c.Forwarding.MQTTBrokerURL != ""
+}
+
+// GetPaginationPolicy returns the pagination policy shared by list endpoints and repositories
+func (c *AppConfig) GetPaginationPolicy() pagination.Policy {
+	return pagination.Policy{
+		DefaultLimit: c.Pagination.DefaultLimit,
+		MaxLimit:     c.Pagination.MaxLimit,
+	}
+}
+
+// GetDefaultLanguage returns the configured default response language
+func (c *AppConfig) GetDefaultLanguage() i18n.Language {
+	language, ok := i18n.ParseLanguage(c.Server.DefaultLanguage)
+	if !ok {
+		return i18n.English
+	}
+	return language
+}
+
+// GetCommandSigningKeys parses the keyID:hexsecret pairs of COMMAND_SIGNING_KEYS
+func (c *AppConfig) GetCommandSigningKeys() (map[string][]byte, error) {
+	keys := make(map[string][]byte, len(c.Commands.SigningKeys))
+	for _, pair := range c.Commands.SigningKeys {
+		keyID, secret, ok := strings.Cut(pair, ":")
+		if !ok || keyID == "" {
+			return nil, fmt.Errorf("command signing key must be formatted as keyID:hexsecret")
+		}
+		key, err := hex.DecodeString(secret)
+		if err != nil {
+			return nil, fmt.Errorf("command signing key %q is not valid hex: %w", keyID, err)
+		}
+		if len(key) < 16 {
+			return nil, fmt.Errorf("command signing key %q must be at least 16 bytes", keyID)
+		}
+		keys[keyID] = key
+	}
+	return keys, nil
+}
+
+// GetSensorTypes parses the name:unit:min:max definitions of SENSOR_TYPES
+func (c *AppConfig) GetSensorTypes() ([]SensorTypeDefinition, error) {
+	definitions := make([]SensorTypeDefinition, 0, len(c.Measurements.SensorTypes))
+	for _, entry := range c.Measurements.SensorTypes {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("sensor type must be formatted as name:unit:min:max, got %q", entry)
+		}
+		definition, err := parseSensorTypeDefinition(parts)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// GetDerivedSensors parses the name:unit:min:max:mode=expression definitions of DERIVED_SENSORS
+func (c *AppConfig) GetDerivedSensors() ([]DerivedSensorDefinition, error) {
+	var definitions []DerivedSensorDefinition
+	for _, entry := range strings.Split(c.Measurements.DerivedSensors, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		head, expression, ok := strings.Cut(entry, "=")
+		parts := strings.Split(strings.TrimSpace(head), ":")
+		if !ok || len(parts) != 5 || strings.TrimSpace(expression) == "" {
+			return nil, fmt.Errorf("derived sensor must be formatted as name:unit:min:max:mode=expression, got %q", entry)
+		}
+		sensorType, err := parseSensorTypeDefinition(parts[:4])
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, DerivedSensorDefinition{
+			SensorTypeDefinition: sensorType,
+			Mode:                 parts[4],
+			Expression:           strings.TrimSpace(expression),
+		})
+	}
+	return definitions, nil
+}
+
+// GetAlertRules parses the name:sensor_type:below|above:threshold definitions of ALERT_RULES, each
+// optionally followed by hysteresis=, for=, cooldown= and severity= settings
+func (c *AppConfig) GetAlertRules() ([]AlertRuleDefinition, error) {
+	var definitions []AlertRuleDefinition
+	for _, entry := range strings.Split(c.Alerts.Rules, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) < 4 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("alert rule must be formatted as name:sensor_type:below|above:threshold, got %q", entry)
+		}
+		threshold, err := strconv.ParseFloat(parts[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %q: invalid threshold: %w", parts[0], err)
+		}
+		definition := AlertRuleDefinition{Name: parts[0], SensorType: parts[1], Operator: parts[2], Threshold: threshold}
+
+		for _, setting := range parts[4:] {
+			key, value, ok := strings.Cut(setting, "=")
+			if !ok {
+				return nil, fmt.Errorf("alert rule %q: setting must be formatted as key=value, got %q", parts[0], setting)
+			}
+			switch key {
+			case "hysteresis":
+				definition.Hysteresis, err = strconv.ParseFloat(value, 64)
+			case "for":
+				definition.For, err = time.ParseDuration(value)
+			case "cooldown":
+				definition.Cooldown, err = time.ParseDuration(value)
+			case "severity":
+				definition.Severity = value
+			default:
+				err = fmt.Errorf("unknown setting")
+			}
+			if err != nil {
+				return nil, fmt.Errorf("alert rule %q: invalid %s: %w", parts[0], key, err)
+			}
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// GetAlertConditions parses the name[:severity[:for[:cooldown]]]=condition definitions of
+// ALERT_CONDITIONS
+func (c *AppConfig) GetAlertConditions() ([]AlertConditionDefinition, error) {
+	var definitions []AlertConditionDefinition
+	for _, entry := range strings.Split(c.Alerts.Conditions, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		head, condition, ok := strings.Cut(entry, "=")
+		parts := strings.Split(strings.TrimSpace(head), ":")
+		if !ok || parts[0] == "" || len(parts) > 4 || strings.TrimSpace(condition) == "" {
+			return nil, fmt.Errorf("alert condition must be formatted as name[:severity[:for[:cooldown]]]=condition, got %q", entry)
+		}
+		definition := AlertConditionDefinition{Name: parts[0], Condition: strings.TrimSpace(condition)}
+		if len(parts) > 1 {
+			definition.Severity = parts[1]
+		}
+		var err error
+		if len(parts) > 2 && parts[2] != "" {
+			if definition.For, err = time.ParseDuration(parts[2]); err != nil {
+				return nil, fmt.Errorf("alert condition %q: invalid for: %w", parts[0], err)
+			}
+		}
+		if len(parts) > 3 && parts[3] != "" {
+			if definition.Cooldown, err = time.ParseDuration(parts[3]); err != nil {
+				return nil, fmt.Errorf("alert condition %q: invalid cooldown: %w", parts[0], err)
+			}
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// GetAlertDeviceGroups parses the group=MAC,MAC definitions of ALERT_DEVICE_GROUPS
+func (c *AppConfig) GetAlertDeviceGroups() (map[string][]string, error) {
+	return parseDeviceGroups(c.Alerts.DeviceGroups)
+}
+
+// GetIrrigationZones parses the zone=MAC,MAC definitions of IRRIGATION_ZONES, sorted by name, with
+// the flow rates of IRRIGATION_ZONE_FLOW_RATES
+func (c *AppConfig) GetIrrigationZones() ([]IrrigationZoneDefinition, error) {
+	groups, err := parseDeviceGroups(c.Analytics.Zones)
+	if err != nil {
+		return nil, fmt.Errorf("irrigation zones: %w", err)
+	}
+
+	flowRates := make(map[string]float64)
+	for _, entry := range strings.Split(c.Analytics.ZoneFlowRates, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			return nil, fmt.Errorf("zone flow rate must be formatted as zone=liters_per_minute, got %q", entry)
+		}
+		if _, exists := groups[name]; !exists {
+			return nil, fmt.Errorf("flow rate of unknown irrigation zone %q", name)
+		}
+		flowRate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || flowRate <= 0 {
+			return nil, fmt.Errorf("flow rate of irrigation zone %q must be a positive number, got %q", name, value)
+		}
+		flowRates[name] = flowRate
+	}
+
+	definitions := make([]IrrigationZoneDefinition, 0, len(groups))
+	for name, macAddresses := range groups {
+		definitions = append(definitions, IrrigationZoneDefinition{Name: name, MACAddresses: macAddresses, FlowRate: flowRates[name]})
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name < definitions[j].Name })
+	return definitions, nil
+}
+
+// parseDeviceGroups parses semicolon-separated group=MAC,MAC definitions
+func parseDeviceGroups(value string) (map[string][]string, error) {
+	groups := make(map[string][]string)
+	for _, entry := range strings.Split(value, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, members, ok := strings.Cut(entry, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" || strings.TrimSpace(members) == "" {
+			return nil, fmt.Errorf("device group must be formatted as group=MAC,MAC, got %q", entry)
+		}
+		if _, exists := groups[name]; exists {
+			return nil, fmt.Errorf("device group %q is defined more than once", name)
+		}
+		for _, macAddress := range strings.Split(members, ",") {
+			if macAddress = strings.TrimSpace(macAddress); macAddress != "" {
+				groups[name] = append(groups[name], macAddress)
+			}
+		}
+	}
+	return groups, nil
+}
+
+// GetNotificationUsers parses the user[:key=value...] definitions of NOTIFICATION_USERS; a user without
+// settings only gets the in-app inbox. The settings are
+// email=, sms=, language=, digest=off|hourly|daily, quiet=22h-6h, quiet_override=severity|none and
+// one critical=, warning= or info= route per severity listing its channels, such as critical=sms,email.
+func (c *AppConfig) GetNotificationUsers() ([]NotificationUserDefinition, error) {
+	var definitions []NotificationUserDefinition
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(c.Notifications.Users, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if parts[0] == "" {
+			return nil, fmt.Errorf("notification user must be formatted as user[:key=value...], got %q", entry)
+		}
+		if seen[parts[0]] {
+			return nil, fmt.Errorf("notification user %q is defined more than once", parts[0])
+		}
+		seen[parts[0]] = true
+		definition := NotificationUserDefinition{User: parts[0]}
+
+		for _, setting := range parts[1:] {
+			key, value, ok := strings.Cut(setting, "=")
+			if !ok {
+				return nil, fmt.Errorf("notification user %q: setting must be formatted as key=value, got %q", parts[0], setting)
+			}
+			var err error
+			switch key {
+			case "email":
+				definition.Email = value
+			case "sms":
+				definition.Phone = value
+			case "language":
+				definition.Language = value
+			case "digest":
+				definition.Digest = value
+			case "quiet":
+				start, end, found := strings.Cut(value, "-")
+				if !found {
+					err = fmt.Errorf("must be formatted as start-end, such as 22h-6h30m")
+					break
+				}
+				if definition.QuietStart, err = time.ParseDuration(start); err != nil {
+					break
+				}
+				definition.QuietEnd, err = time.ParseDuration(end)
+				definition.HasQuietHours = true
+			case "quiet_override":
+				definition.QuietOverride = value
+			case "critical", "warning", "info":
+				if definition.Routes == nil {
+					definition.Routes = make(map[string][]string)
+				}
+				definition.Routes[key] = strings.Split(value, ",")
+			default:
+				err = fmt.Errorf("unknown setting")
+			}
+			if err != nil {
+				return nil, fmt.Errorf("notification user %q: invalid %s: %w", parts[0], key, err)
+			}
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// GetEventPolicies parses the event_type definitions of EVENT_POLICIES, each optionally followed by
+// enabled=, sample= and min_interval= settings; omitted settings publish every event
+func (c *AppConfig) GetEventPolicies() ([]EventPolicyDefinition, error) {
+	var definitions []EventPolicyDefinition
+	for _, entry := range strings.Split(c.Events.Policies, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if parts[0] == "" {
+			return nil, fmt.Errorf("event policy must be formatted as event_type[:key=value...], got %q", entry)
+		}
+		definition := EventPolicyDefinition{EventType: parts[0], Enabled: true, SampleRate: 1}
+
+		for _, setting := range parts[1:] {
+			key, value, ok := strings.Cut(setting, "=")
+			if !ok {
+				return nil, fmt.Errorf("event policy %q: setting must be formatted as key=value, got %q", parts[0], setting)
+			}
+			var err error
+			switch key {
+			case "enabled":
+				definition.Enabled, err = strconv.ParseBool(value)
+			case "sample":
+				definition.SampleRate, err = strconv.ParseFloat(value, 64)
+			case "min_interval":
+				definition.MinInterval, err = time.ParseDuration(value)
+			default:
+				err = fmt.Errorf("unknown setting")
+			}
+			if err != nil {
+				return nil, fmt.Errorf("event policy %q: invalid %s: %w", parts[0], key, err)
+			}
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// GetFarmQuotas parses the farm_id definitions of FARM_QUOTAS, each optionally followed by devices=
+// and messages_per_minute= settings; omitted settings take the default quota
+func (c *AppConfig) GetFarmQuotas() ([]FarmQuotaDefinition, error) {
+	var definitions []FarmQuotaDefinition
+	for _, entry := range strings.Split(c.FarmQuotas.Farms, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if parts[0] == "" {
+			return nil, fmt.Errorf("farm quota must be formatted as farm_id[:key=value...], got %q", entry)
+		}
+		definition := FarmQuotaDefinition{
+			FarmID:               parts[0],
+			MaxDevices:           c.FarmQuotas.MaxDevices,
+			MaxMessagesPerMinute: c.FarmQuotas.MaxMessagesPerMinute,
+		}
+
+		for _, setting := range parts[1:] {
+			key, value, ok := strings.Cut(setting, "=")
+			if !ok {
+				return nil, fmt.Errorf("farm quota %q: setting must be formatted as key=value, got %q", parts[0], setting)
+			}
+			var err error
+			switch key {
+			case "devices":
+				definition.MaxDevices, err = strconv.Atoi(value)
+			case "messages_per_minute":
+				definition.MaxMessagesPerMinute, err = strconv.Atoi(value)
+			default:
+				err = fmt.Errorf("unknown setting")
+			}
+			if err != nil {
+				return nil, fmt.Errorf("farm quota %q: invalid %s: %w", parts[0], key, err)
+			}
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// GetNotificationLocation returns the time zone of quiet hours and daily digests
+func (c *AppConfig) GetNotificationLocation() (*time.Location, error) {
+	location, err := time.LoadLocation(c.Notifications.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification time zone %q: %w", c.Notifications.TimeZone, err)
+	}
+	return location, nil
+}
+
+// GetSchedulerLocation returns the time zone the irrigation schedules are evaluated in
+func (c *AppConfig) GetSchedulerLocation() (*time.Location, error) {
+	location, err := time.LoadLocation(c.Scheduler.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduler time zone %q: %w", c.Scheduler.TimeZone, err)
+	}
+	return location, nil
+}
+
+// parseSensorTypeDefinition parses the name, unit, min and max parts of a sensor type definition
+func parseSensorTypeDefinition(parts []string) (SensorTypeDefinition, error) {
+	if parts[0] == "" || parts[1] == "" {
+		return SensorTypeDefinition{}, fmt.Errorf("sensor type name and unit are required, got %q", strings.Join(parts, ":"))
+	}
+	minValue, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return SensorTypeDefinition{}, fmt.Errorf("sensor type %q: invalid min value: %w", parts[0], err)
+	}
+	maxValue, err := strconv.ParseFloat(parts[3], 64)
+	if err != nil {
+		return SensorTypeDefinition{}, fmt.Errorf("sensor type %q: invalid max value: %w", parts[0], err)
+	}
+	return SensorTypeDefinition{
+		Name:     parts[0],
+		Unit:     parts[1],
+		MinValue: minValue,
+		MaxValue: maxValue,
+	}, nil
+}
+
+// GetMQTTBrokerURLs returns the MQTT brokers in failover order, the first one being the primary
+func (c *AppConfig) GetMQTTBrokerURLs() []string {
+	if len(c.MQTT.BrokerURLs) > 0 {
+		return c.MQTT.BrokerURLs
+	}
+	return []string{c.MQTT.BrokerURL}
+}
+
+// GetEmbeddedBrokerUsers parses the username:password pairs of MQTT_EMBEDDED_BROKER_USERS;
+// an empty map leaves the embedded broker open to anonymous clients
+func (c *AppConfig) GetEmbeddedBrokerUsers() (map[string]string, error) {
+	users := make(map[string]string, len(c.MQTT.Embedded.Users))
+	for _, user := range c.MQTT.Embedded.Users {
+		username, password, found := strings.Cut(user, ":")
+		if !found || username == "" || password == "" {
+			return nil, fmt.Errorf("embedded MQTT broker users must be username:password pairs, got %q", username)
+		}
+		if _, exists := users[username]; exists {
+			return nil, fmt.Errorf("embedded MQTT broker user %q is listed more than once", username)
+		}
+		users[username] = password
+	}
+	return users, nil
+}
+
+// isTLSBrokerURL reports whether the broker URL uses one of the TLS schemes of the MQTT client
+func isTLSBrokerURL(brokerURL string) bool {
+	scheme, _, found := strings.Cut(brokerURL, "://")
+	if !found {
+		return false
+	}
+	switch strings.ToLower(scheme) {
+	case "mqtts", "ssl", "tls", "mqtt+ssl", "tcps", "wss":
+		return true
+	}
+	return false
+}
+
+// GetOnboardingBrokerURL returns the broker URL handed to devices being onboarded
+func (c *AppConfig) GetOnboardingBrokerURL() string {
+	if c.Onboarding.BrokerURL != "" {
+		return c.Onboarding.BrokerURL
+	}
+	return c.GetMQTTBrokerURLs()[0]
+}
+
+// GetServerAddress returns the full server address
+func (c *AppConfig) GetServerAddress() string {
+	return fmt.Sprintf("%s:%s", c.Server.Host, c.Server.Port)
+}
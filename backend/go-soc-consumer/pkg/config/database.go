@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -17,8 +19,22 @@ type DatabaseConfig struct {
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
 	ConnMaxIdleTime time.Duration
+	// StatementCacheMode is how each connection reuses query plans: prepare caches prepared
+	// statements, describe caches only their parameter and result types, which works behind
+	// PgBouncer in transaction mode, and off prepares every statement anew
+	StatementCacheMode     string
+	StatementCacheCapacity int // statements cached per connection, zero for the default of 512
+
+	// Timescale turns the measurement tables into TimescaleDB hypertables partitioned by time, with
+	// compression of old chunks and continuous aggregates of the hourly and daily averages
+	Timescale              bool
+	TimescaleChunkInterval time.Duration // time range covered by each chunk of a hypertable
+	TimescaleCompressAfter time.Duration // age of the chunks compressed by the compression policy
 }
 
+// StatementCacheModes lists the statement cache modes that can be configured
+var StatementCacheModes = []string{"prepare", "describe", "off"}
+
 // NewDatabaseConfig creates a new database configuration from environment variables
 func NewDatabaseConfig() *DatabaseConfig {
 	return &DatabaseConfig{
@@ -32,6 +48,13 @@ func NewDatabaseConfig() *DatabaseConfig {
 		MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
 		ConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
 		ConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", 1*time.Minute),
+
+		StatementCacheMode:     getEnv("DB_STATEMENT_CACHE_MODE", "prepare"),
+		StatementCacheCapacity: getEnvInt("DB_STATEMENT_CACHE_CAPACITY", 512),
+
+		Timescale:              getEnvBool("DB_TIMESCALE", false),
+		TimescaleChunkInterval: getEnvDuration("DB_TIMESCALE_CHUNK_INTERVAL", 7*24*time.Hour),
+		TimescaleCompressAfter: getEnvDuration("DB_TIMESCALE_COMPRESS_AFTER", 30*24*time.Hour),
 	}
 }
 
@@ -66,6 +89,19 @@ func (c *DatabaseConfig) Validate() error {
 	if c.MaxIdleConns > c.MaxOpenConns {
 		return fmt.Errorf("max idle connections cannot be greater than max open connections")
 	}
+	if c.StatementCacheMode != "" && !slices.Contains(StatementCacheModes, c.StatementCacheMode) {
+		return fmt.Errorf("unknown statement cache mode %q, expected one of %s", c.StatementCacheMode, strings.Join(StatementCacheModes, ", "))
+	}
+	if c.StatementCacheCapacity < 0 {
+		return fmt.Errorf("statement cache capacity cannot be negative")
+	}
+	if c.Timescale {
+		if c.TimescaleChunkInterval < time.Hour {
+			return fmt.Errorf("timescale chunk interval must be at least an hour")
+		}
+		if c.TimescaleCompressAfter < c.TimescaleChunkInterval {
+			return fmt.Errorf("timescale compression age cannot be shorter than the chunk interval")
+		}
+	}
 	return nil
 }
-
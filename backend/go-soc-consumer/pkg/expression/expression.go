@@ -0,0 +1,151 @@
+// Package expression parses and evaluates arithmetic expressions and conditions over sensor streams,
+// such as "dew_point(temperature, humidity)", "avg(soil_moisture.*)" or
+// "all(soil_moisture@zone_a < 20) and count(soil_moisture@zone_a) == 3".
+//
+// A variable names a sensor type. A bare name refers to the channel being evaluated, "name.2" to
+// channel 2 and "name.*" to every channel of the device. A device group suffix, "name@group" or
+// "name.2@group", refers to the streams of every device of the group instead. Channel wildcards
+// and device groups are lists, only allowed as arguments of the aggregate functions min, max, avg,
+// sum, count, all and any, alone or compared with a value.
+//
+// Comparisons evaluate to 1 when true and 0 when false, and the logical operators and, or and not
+// treat any non-zero value as true. and and or only evaluate their right side when the left one does
+// not decide the result.
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ErrMissingVariable is returned by Evaluate when a variable has no value
+var ErrMissingVariable = errors.New("missing variable")
+
+// Channel selectors of a Variable
+const (
+	CurrentChannel = -1 // the channel being evaluated
+	AllChannels    = -2 // every channel, only allowed in aggregate functions
+)
+
+// Variable is a reference to a sensor stream
+type Variable struct {
+	Name    string
+	Channel int    // a channel number, CurrentChannel or AllChannels
+	Group   string // the device group, empty for the device being evaluated
+}
+
+// String returns the variable as written in expressions
+func (v Variable) String() string {
+	name := v.Name
+	switch v.Channel {
+	case CurrentChannel:
+	case AllChannels:
+		name += ".*"
+	default:
+		name += fmt.Sprintf(".%d", v.Channel)
+	}
+	if v.Group != "" {
+		name += "@" + v.Group
+	}
+	return name
+}
+
+// Resolver returns the values of a variable: a single value for a channel, the values of every
+// channel for AllChannels and of every device for a group. ok is false when the stream has no value.
+type Resolver func(variable Variable) (values []float64, ok bool)
+
+// Expression is a parsed expression
+type Expression struct {
+	source    string
+	root      node
+	variables []Variable
+}
+
+// Parse parses an expression, checking function names and argument counts
+func Parse(source string) (*Expression, error) {
+	p := &parser{lexer: newLexer(source)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseExpression(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.token.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.token.text, p.token.pos)
+	}
+	if err := requireScalar(root, "the result"); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[Variable]bool)
+	var variables []Variable
+	collectVariables(root, func(variable Variable) {
+		if !seen[variable] {
+			seen[variable] = true
+			variables = append(variables, variable)
+		}
+	})
+	sort.Slice(variables, func(i, j int) bool {
+		if variables[i].Name != variables[j].Name {
+			return variables[i].Name < variables[j].Name
+		}
+		if variables[i].Group != variables[j].Group {
+			return variables[i].Group < variables[j].Group
+		}
+		return variables[i].Channel < variables[j].Channel
+	})
+
+	return &Expression{source: strings.TrimSpace(source), root: root, variables: variables}, nil
+}
+
+// String returns the source of the expression
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Variables returns the distinct variables of the expression sorted by name, group and channel
+func (e *Expression) Variables() []Variable {
+	return append([]Variable(nil), e.variables...)
+}
+
+// Evaluate computes the expression, returning ErrMissingVariable when the resolver has no value
+// for a variable and an error when the result is not a finite number
+func (e *Expression) Evaluate(resolve Resolver) (float64, error) {
+	values, err := e.root.eval(resolve)
+	if err != nil {
+		return 0, err
+	}
+	value := values[0]
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("%s is not a finite number", e.source)
+	}
+	return value, nil
+}
+
+// IsCondition reports whether the expression is a condition: a comparison, a logical operation or
+// a call of all or any
+func (e *Expression) IsCondition() bool {
+	switch typed := e.root.(type) {
+	case *logicalNode, *notNode:
+		return true
+	case *binaryNode:
+		_, ok := comparisons[typed.operator]
+		return ok
+	case *callNode:
+		return typed.name == "all" || typed.name == "any"
+	}
+	return false
+}
+
+// EvaluateCondition evaluates the expression as a condition, true when its value is not zero
+func (e *Expression) EvaluateCondition(resolve Resolver) (bool, error) {
+	value, err := e.Evaluate(resolve)
+	if err != nil {
+		return false, err
+	}
+	return value != 0, nil
+}
@@ -0,0 +1,283 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streams resolves variables from values keyed by name and channel
+func streams(values map[string]map[int]float64) Resolver {
+	return func(variable Variable) ([]float64, bool) {
+		channels, ok := values[variable.Name]
+		if !ok {
+			return nil, false
+		}
+		switch variable.Channel {
+		case AllChannels:
+			var all []float64
+			for channel := 0; channel < 64; channel++ {
+				if value, ok := channels[channel]; ok {
+					all = append(all, value)
+				}
+			}
+			return all, len(all) > 0
+		case CurrentChannel:
+			value, ok := channels[0]
+			return []float64{value}, ok
+		default:
+			value, ok := channels[variable.Channel]
+			return []float64{value}, ok
+		}
+	}
+}
+
+func TestExpression_Evaluate(t *testing.T) {
+	resolve := streams(map[string]map[int]float64{
+		"temperature":   {0: 20},
+		"humidity":      {0: 50},
+		"soil_moisture": {0: 30, 1: 40, 2: 50},
+	})
+
+	tests := []struct {
+		name     string
+		source   string
+		expected float64
+	}{
+		{name: "precedence", source: "1 + 2 * 3", expected: 7},
+		{name: "left associative", source: "10 - 4 - 3", expected: 3},
+		{name: "right associative power", source: "2 ^ 3 ^ 2", expected: 512},
+		{name: "unary minus binds looser than power", source: "-2 ^ 2", expected: -4},
+		{name: "parentheses", source: "(1 + 2) * 3", expected: 9},
+		{name: "scientific notation", source: "1.5e2", expected: 150},
+		{name: "variables", source: "temperature * 1.8 + 32", expected: 68},
+		{name: "explicit channel", source: "soil_moisture.2 - soil_moisture.1", expected: 10},
+		{name: "aggregate over channels", source: "avg(soil_moisture.*)", expected: 40},
+		{name: "aggregate over channels and values", source: "max(soil_moisture.*, 45)", expected: 50},
+		{name: "dew point", source: "dew_point(temperature, humidity)", expected: DewPoint(20, 50)},
+		{name: "frost point", source: "frost_point(temperature, humidity)", expected: FrostPoint(20, 50)},
+		{name: "reference evapotranspiration", source: "et0(temperature, humidity, 600)", expected: ReferenceEvapotranspiration(20, 50, 600, 2)},
+		{name: "reference evapotranspiration with wind", source: "et0(temperature, humidity, 600, 4)", expected: ReferenceEvapotranspiration(20, 50, 600, 4)},
+		{name: "case insensitive names", source: "Temperature", expected: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := Parse(tt.source)
+			require.NoError(t, err)
+
+			value, err := expression.Evaluate(resolve)
+
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, value, 1e-9)
+		})
+	}
+
+	t.Run("reports missing variables", func(t *testing.T) {
+		expression, err := Parse("temperature - ph")
+		require.NoError(t, err)
+
+		_, err = expression.Evaluate(resolve)
+
+		assert.ErrorIs(t, err, ErrMissingVariable)
+	})
+
+	t.Run("rejects non finite results", func(t *testing.T) {
+		for _, source := range []string{"temperature / 0", "ln(0)", "sqrt(-1)"} {
+			expression, err := Parse(source)
+			require.NoError(t, err)
+
+			_, err = expression.Evaluate(resolve)
+
+			assert.Error(t, err, source)
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("lists the distinct variables", func(t *testing.T) {
+		expression, err := Parse("dew_point(temperature, humidity) - temperature + avg(ph.*) + ph.1")
+		require.NoError(t, err)
+
+		assert.Equal(t, []Variable{
+			{Name: "humidity", Channel: CurrentChannel},
+			{Name: "ph", Channel: AllChannels},
+			{Name: "ph", Channel: 1},
+			{Name: "temperature", Channel: CurrentChannel},
+		}, expression.Variables())
+	})
+
+	t.Run("lists grouped variables", func(t *testing.T) {
+		expression, err := Parse("all(ph.*@Zone_A < 7) or any(ph.1@pump == 1) or ph < 5")
+		require.NoError(t, err)
+
+		assert.Equal(t, []Variable{
+			{Name: "ph", Channel: CurrentChannel},
+			{Name: "ph", Channel: 1, Group: "pump"},
+			{Name: "ph", Channel: AllChannels, Group: "zone_a"},
+		}, expression.Variables())
+		assert.Equal(t, "ph.*@zone_a", expression.Variables()[2].String())
+	})
+
+	t.Run("tells conditions from values", func(t *testing.T) {
+		for source, condition := range map[string]bool{
+			"temperature > 30":           true,
+			"not temperature > 30":       true,
+			"temperature > 30 or ph < 5": true,
+			"any(ph@zone_a < 5)":         true,
+			"temperature":                false,
+			"count(ph@zone_a)":           false,
+			"(temperature > 30) + 1":     false,
+		} {
+			expression, err := Parse(source)
+			require.NoError(t, err, source)
+			assert.Equal(t, condition, expression.IsCondition(), source)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{name: "empty", source: ""},
+		{name: "dangling operator", source: "1 +"},
+		{name: "unbalanced parentheses", source: "(1 + 2"},
+		{name: "trailing tokens", source: "1 2"},
+		{name: "unknown function", source: "median(ph.*)"},
+		{name: "wrong argument count", source: "dew_point(temperature)"},
+		{name: "too many arguments", source: "et0(temperature, humidity, 600, 2, 1)"},
+		{name: "wildcard in arithmetic", source: "ph.* + 1"},
+		{name: "wildcard outside aggregate", source: "abs(ph.*)"},
+		{name: "wildcard alone", source: "ph.*"},
+		{name: "missing channel", source: "ph."},
+		{name: "unexpected character", source: "ph % 2"},
+		{name: "assignment", source: "ph = 2"},
+		{name: "chained comparison", source: "1 < ph < 3"},
+		{name: "comparison of two lists", source: "all(ph.* < ec.*)"},
+		{name: "group in arithmetic", source: "ph@zone_a + 1"},
+		{name: "list comparison outside aggregate", source: "ph@zone_a < 7 and ec < 2"},
+		{name: "missing group", source: "ph@"},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			_, err := Parse(tt.source)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// groups resolves grouped variables from values keyed by group and name, one value per device
+func groups(values map[string]map[string][]float64) Resolver {
+	return func(variable Variable) ([]float64, bool) {
+		devices, ok := values[variable.Group][variable.Name]
+		return devices, ok && len(devices) > 0
+	}
+}
+
+func TestExpression_EvaluateCondition(t *testing.T) {
+	resolve := groups(map[string]map[string][]float64{
+		"zone_a":  {"soil_moisture": {15, 18, 12}},
+		"zone_b":  {"soil_moisture": {15, 35}},
+		"gateway": {"online": {0}},
+		"pump":    {"pump_running": {1}},
+	})
+
+	tests := []struct {
+		name     string
+		source   string
+		expected bool
+	}{
+		{name: "all below", source: "all(soil_moisture@zone_a < 20)", expected: true},
+		{name: "not all below", source: "all(soil_moisture@zone_b < 20)", expected: false},
+		{name: "any below", source: "any(soil_moisture@zone_b < 20)", expected: true},
+		{name: "value compared with a list", source: "any(20 > soil_moisture@zone_b)", expected: true},
+		{name: "count", source: "count(soil_moisture@zone_a) == 3", expected: true},
+		{name: "aggregate of a group", source: "max(soil_moisture@zone_a) <= 18", expected: true},
+		{name: "and", source: "all(online@gateway == 0) and all(pump_running@pump == 1)", expected: true},
+		{name: "or", source: "any(soil_moisture@zone_b > 40) or any(soil_moisture@zone_a > 40)", expected: false},
+		{name: "not", source: "not any(soil_moisture@zone_a > 20)", expected: true},
+		{name: "and binds tighter than or", source: "1 > 2 and 1 > 2 or 2 > 1", expected: true},
+		{name: "parenthesized or", source: "1 > 2 and (1 > 2 or 2 > 1)", expected: false},
+		{name: "arithmetic before comparison", source: "1 + 2 * 3 == 7", expected: true},
+		{name: "non zero values are true", source: "2 and not 0", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := Parse(tt.source)
+			require.NoError(t, err)
+
+			holds, err := expression.EvaluateCondition(resolve)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, holds)
+		})
+	}
+}
+
+func TestExpression_ShortCircuit(t *testing.T) {
+	// recording resolves x to 0 and y to 1, records the variables it is asked for and has no value for missing
+	recording := func(resolved *[]string) Resolver {
+		values := map[string]float64{"x": 0, "y": 1}
+		return func(variable Variable) ([]float64, bool) {
+			*resolved = append(*resolved, variable.Name)
+			value, ok := values[variable.Name]
+			return []float64{value}, ok
+		}
+	}
+
+	tests := []struct {
+		name     string
+		source   string
+		expected bool
+		resolved []string
+	}{
+		{name: "false and skips the right side", source: "x == 1 and missing > 0", expected: false, resolved: []string{"x"}},
+		{name: "true or skips the right side", source: "y == 1 or missing > 0", expected: true, resolved: []string{"y"}},
+		{name: "true and evaluates the right side", source: "y == 1 and x == 0", expected: true, resolved: []string{"y", "x"}},
+		{name: "false or evaluates the right side", source: "x == 1 or y == 1", expected: true, resolved: []string{"x", "y"}},
+		{name: "nested operators skip whole subtrees", source: "x == 1 and (missing > 0 or missing < 0) or y == 1", expected: true, resolved: []string{"x", "y"}},
+		{name: "not skips inside its operand", source: "not (y == 1 or missing > 0)", expected: false, resolved: []string{"y"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := Parse(tt.source)
+			require.NoError(t, err)
+			var resolved []string
+
+			holds, err := expression.EvaluateCondition(recording(&resolved))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, holds)
+			assert.Equal(t, tt.resolved, resolved)
+		})
+	}
+
+	t.Run("a missing variable that decides the result is an error", func(t *testing.T) {
+		expression, err := Parse("y == 1 and missing > 0")
+		require.NoError(t, err)
+		var resolved []string
+
+		_, err = expression.EvaluateCondition(recording(&resolved))
+
+		assert.ErrorIs(t, err, ErrMissingVariable)
+	})
+}
+
+func TestClimateFunctions(t *testing.T) {
+	t.Run("frost point lies between the air temperature and the dew point below freezing", func(t *testing.T) {
+		frostPoint, dewPoint := FrostPoint(-2, 80), DewPoint(-2, 80)
+		assert.InDelta(t, -4.7, frostPoint, 0.1)
+		assert.Greater(t, frostPoint, dewPoint)
+		assert.Less(t, frostPoint, -2.0)
+		assert.InDelta(t, -2, FrostPoint(-2, 100), 1e-9, "saturated air is at its frost point")
+	})
+
+	t.Run("reference evapotranspiration follows sun, dryness and wind", func(t *testing.T) {
+		midday := ReferenceEvapotranspiration(25, 50, 800, 2)
+		assert.InDelta(t, 0.6, midday, 0.1, "a sunny midday hour")
+		assert.Greater(t, midday, ReferenceEvapotranspiration(25, 50, 200, 2))
+		assert.Greater(t, midday, ReferenceEvapotranspiration(25, 90, 800, 2))
+		assert.Greater(t, ReferenceEvapotranspiration(25, 50, 0, 4), ReferenceEvapotranspiration(25, 50, 0, 1))
+		assert.Zero(t, ReferenceEvapotranspiration(10, 100, 0, 2), "saturated air at night")
+	})
+}
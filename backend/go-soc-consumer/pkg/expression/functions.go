@@ -0,0 +1,141 @@
+package expression
+
+import (
+	"fmt"
+	"math"
+)
+
+// function is a built-in function
+type function struct {
+	minArgs   int
+	maxArgs   int  // -1 for any number of arguments
+	aggregate bool // accepts lists
+	apply     func(args []float64) float64
+}
+
+// arity describes the number of arguments of the function
+func (f function) arity() string {
+	switch {
+	case f.maxArgs < 0:
+		return fmt.Sprintf("at least %d arguments", f.minArgs)
+	case f.minArgs == 1 && f.maxArgs == 1:
+		return "1 argument"
+	case f.minArgs != f.maxArgs:
+		return fmt.Sprintf("%d to %d arguments", f.minArgs, f.maxArgs)
+	default:
+		return fmt.Sprintf("%d arguments", f.maxArgs)
+	}
+}
+
+// functions are the functions available in expressions
+var functions = map[string]function{
+	"abs":  {minArgs: 1, maxArgs: 1, apply: func(args []float64) float64 { return math.Abs(args[0]) }},
+	"sqrt": {minArgs: 1, maxArgs: 1, apply: func(args []float64) float64 { return math.Sqrt(args[0]) }},
+	"exp":  {minArgs: 1, maxArgs: 1, apply: func(args []float64) float64 { return math.Exp(args[0]) }},
+	"ln":   {minArgs: 1, maxArgs: 1, apply: func(args []float64) float64 { return math.Log(args[0]) }},
+	"pow":  {minArgs: 2, maxArgs: 2, apply: func(args []float64) float64 { return math.Pow(args[0], args[1]) }},
+
+	"min": {minArgs: 1, maxArgs: -1, aggregate: true, apply: func(args []float64) float64 {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Min(result, arg)
+		}
+		return result
+	}},
+	"max": {minArgs: 1, maxArgs: -1, aggregate: true, apply: func(args []float64) float64 {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Max(result, arg)
+		}
+		return result
+	}},
+	"sum": {minArgs: 1, maxArgs: -1, aggregate: true, apply: sum},
+	"avg": {minArgs: 1, maxArgs: -1, aggregate: true, apply: func(args []float64) float64 {
+		return sum(args) / float64(len(args))
+	}},
+	"count": {minArgs: 1, maxArgs: -1, aggregate: true, apply: func(args []float64) float64 {
+		return float64(len(args))
+	}},
+	"all": {minArgs: 1, maxArgs: -1, aggregate: true, apply: func(args []float64) float64 {
+		for _, arg := range args {
+			if arg == 0 {
+				return 0
+			}
+		}
+		return 1
+	}},
+	"any": {minArgs: 1, maxArgs: -1, aggregate: true, apply: func(args []float64) float64 {
+		for _, arg := range args {
+			if arg != 0 {
+				return 1
+			}
+		}
+		return 0
+	}},
+
+	"dew_point":   {minArgs: 2, maxArgs: 2, apply: func(args []float64) float64 { return DewPoint(args[0], args[1]) }},
+	"vpd":         {minArgs: 2, maxArgs: 2, apply: func(args []float64) float64 { return VaporPressureDeficit(args[0], args[1]) }},
+	"frost_point": {minArgs: 2, maxArgs: 2, apply: func(args []float64) float64 { return FrostPoint(args[0], args[1]) }},
+	"et0": {minArgs: 3, maxArgs: 4, apply: func(args []float64) float64 {
+		windSpeed := defaultWindSpeed
+		if len(args) == 4 {
+			windSpeed = args[3]
+		}
+		return ReferenceEvapotranspiration(args[0], args[1], args[2], windSpeed)
+	}},
+}
+
+// defaultWindSpeed is the wind speed in m/s at 2 m assumed by et0 without a wind input, the
+// global average recommended by FAO-56 when wind is not measured
+const defaultWindSpeed = 2.0
+
+func sum(args []float64) float64 {
+	total := 0.0
+	for _, arg := range args {
+		total += arg
+	}
+	return total
+}
+
+// DewPoint returns the dew point in celsius of air at the given temperature in celsius and relative
+// humidity in percent, using the Magnus formula
+func DewPoint(temperature, humidity float64) float64 {
+	const a, b = 17.62, 243.12
+	gamma := math.Log(humidity/100) + a*temperature/(b+temperature)
+	return b * gamma / (a - gamma)
+}
+
+// FrostPoint returns the frost point in celsius of air at the given temperature in celsius and
+// relative humidity in percent, using the Magnus formula over ice. Below 0 °C it is the temperature
+// at which surfaces collect frost, above the dew point.
+func FrostPoint(temperature, humidity float64) float64 {
+	const a, b = 22.46, 272.62
+	gamma := math.Log(humidity/100) + a*temperature/(b+temperature)
+	return b * gamma / (a - gamma)
+}
+
+// VaporPressureDeficit returns the vapor pressure deficit in kPa of air at the given temperature in
+// celsius and relative humidity in percent, using the Tetens formula
+func VaporPressureDeficit(temperature, humidity float64) float64 {
+	saturation := 0.6108 * math.Exp(17.27*temperature/(temperature+237.3))
+	return saturation * (1 - humidity/100)
+}
+
+// ReferenceEvapotranspiration returns the hourly reference evapotranspiration in mm/h of grass, using
+// the FAO-56 Penman-Monteith equation at sea level, for air at the given temperature in celsius and
+// relative humidity in percent, global solar radiation in W/m² and wind speed at 2 m in m/s.
+// Net radiation is approximated by the net shortwave radiation, ignoring the longwave loss, which
+// overestimates the rate under clear skies by up to about 15%.
+func ReferenceEvapotranspiration(temperature, humidity, solarRadiation, windSpeed float64) float64 {
+	const albedo, psychrometric = 0.23, 0.0674 // grass albedo; psychrometric constant in kPa/°C at 101.3 kPa
+
+	saturation := 0.6108 * math.Exp(17.27*temperature/(temperature+237.3))
+	actual := saturation * humidity / 100
+	slope := 4098 * saturation / math.Pow(temperature+237.3, 2)
+
+	netRadiation := (1 - albedo) * solarRadiation * 0.0036 // W/m² to MJ/m²/h
+	soilHeat := 0.1 * netRadiation
+
+	numerator := 0.408*slope*(netRadiation-soilHeat) + psychrometric*37/(temperature+273)*windSpeed*(saturation-actual)
+	return math.Max(0, numerator/(slope+psychrometric*(1+0.34*windSpeed)))
+}
@@ -0,0 +1,147 @@
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tokenKind identifies the kind of a token
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenVariable
+	tokenOperator // one of + - * / ^ ( ) , < <= > >= == != and or not
+)
+
+// token is a lexical unit of an expression
+type token struct {
+	kind     tokenKind
+	text     string
+	pos      int
+	number   float64
+	variable Variable
+}
+
+// lexer splits an expression into tokens
+type lexer struct {
+	source []rune
+	pos    int
+}
+
+func newLexer(source string) *lexer {
+	return &lexer{source: []rune(source)}
+}
+
+// next returns the next token, tokenEOF at the end of the source
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.source) && unicode.IsSpace(l.source[l.pos]) {
+		l.pos++
+	}
+	if l.pos >= len(l.source) {
+		return token{kind: tokenEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	r := l.source[l.pos]
+	switch {
+	case strings.ContainsRune("+-*/^(),", r):
+		l.pos++
+		return token{kind: tokenOperator, text: string(r), pos: start}, nil
+	case strings.ContainsRune("<>=!", r):
+		return l.lexComparison(start)
+	case unicode.IsDigit(r) || r == '.':
+		return l.lexNumber(start)
+	case r == '_' || unicode.IsLetter(r):
+		return l.lexVariable(start)
+	default:
+		return token{}, fmt.Errorf("unexpected character %q at position %d", r, start)
+	}
+}
+
+// lexComparison reads a comparison operator; = and ! are only valid as part of one
+func (l *lexer) lexComparison(start int) (token, error) {
+	l.pos++
+	if l.pos < len(l.source) && l.source[l.pos] == '=' {
+		l.pos++
+	}
+	text := string(l.source[start:l.pos])
+	if text == "=" || text == "!" {
+		return token{}, fmt.Errorf("unexpected %q at position %d, comparisons are < <= > >= == !=", text, start)
+	}
+	return token{kind: tokenOperator, text: text, pos: start}, nil
+}
+
+// lexNumber reads a decimal number with an optional exponent
+func (l *lexer) lexNumber(start int) (token, error) {
+	for l.pos < len(l.source) && (unicode.IsDigit(l.source[l.pos]) || l.source[l.pos] == '.') {
+		l.pos++
+	}
+	if l.pos < len(l.source) && (l.source[l.pos] == 'e' || l.source[l.pos] == 'E') {
+		l.pos++
+		if l.pos < len(l.source) && (l.source[l.pos] == '+' || l.source[l.pos] == '-') {
+			l.pos++
+		}
+		for l.pos < len(l.source) && unicode.IsDigit(l.source[l.pos]) {
+			l.pos++
+		}
+	}
+
+	text := string(l.source[start:l.pos])
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return token{}, fmt.Errorf("invalid number %q at position %d", text, start)
+	}
+	return token{kind: tokenNumber, text: text, pos: start, number: number}, nil
+}
+
+// lexVariable reads a name with an optional channel selector and device group, such as ph, ph.2,
+// ph.* or ph.2@bed_3. The words and, or and not are operators.
+func (l *lexer) lexVariable(start int) (token, error) {
+	variable := Variable{Name: l.lexName(), Channel: CurrentChannel}
+	if variable.Name == "and" || variable.Name == "or" || variable.Name == "not" {
+		return token{kind: tokenOperator, text: variable.Name, pos: start}, nil
+	}
+
+	if l.pos < len(l.source) && l.source[l.pos] == '.' {
+		l.pos++
+		switch {
+		case l.pos < len(l.source) && l.source[l.pos] == '*':
+			l.pos++
+			variable.Channel = AllChannels
+		case l.pos < len(l.source) && unicode.IsDigit(l.source[l.pos]):
+			channelStart := l.pos
+			for l.pos < len(l.source) && unicode.IsDigit(l.source[l.pos]) {
+				l.pos++
+			}
+			channel, err := strconv.Atoi(string(l.source[channelStart:l.pos]))
+			if err != nil {
+				return token{}, fmt.Errorf("invalid channel at position %d", channelStart)
+			}
+			variable.Channel = channel
+		default:
+			return token{}, fmt.Errorf("expected a channel number or * after %s. at position %d", variable.Name, l.pos)
+		}
+	}
+
+	if l.pos < len(l.source) && l.source[l.pos] == '@' {
+		l.pos++
+		if variable.Group = l.lexName(); variable.Group == "" {
+			return token{}, fmt.Errorf("expected a device group after %s@ at position %d", variable.Name, l.pos)
+		}
+	}
+
+	return token{kind: tokenVariable, text: string(l.source[start:l.pos]), pos: start, variable: variable}, nil
+}
+
+// lexName reads letters, digits and underscores, lowercased
+func (l *lexer) lexName() string {
+	start := l.pos
+	for l.pos < len(l.source) && (l.source[l.pos] == '_' || unicode.IsLetter(l.source[l.pos]) || unicode.IsDigit(l.source[l.pos])) {
+		l.pos++
+	}
+	return strings.ToLower(string(l.source[start:l.pos]))
+}
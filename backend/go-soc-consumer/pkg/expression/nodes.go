@@ -0,0 +1,190 @@
+package expression
+
+import (
+	"fmt"
+	"math"
+)
+
+// node is an element of the syntax tree. eval returns a single value, except for lists, channel
+// wildcards and device groups, which return the value of every stream, and comparisons of a list,
+// which return a truth value for each.
+type node interface {
+	eval(resolve Resolver) ([]float64, error)
+}
+
+// numberNode is a constant
+type numberNode struct {
+	value float64
+}
+
+func (n *numberNode) eval(Resolver) ([]float64, error) {
+	return []float64{n.value}, nil
+}
+
+// variableNode is a reference to a sensor stream
+type variableNode struct {
+	variable Variable
+}
+
+func (n *variableNode) eval(resolve Resolver) ([]float64, error) {
+	values, ok := resolve(n.variable)
+	if !ok || len(values) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingVariable, n.variable)
+	}
+	if n.variable.Channel != AllChannels && n.variable.Group == "" {
+		return values[:1], nil
+	}
+	return values, nil
+}
+
+// negateNode is a unary minus
+type negateNode struct {
+	operand node
+}
+
+func (n *negateNode) eval(resolve Resolver) ([]float64, error) {
+	values, err := n.operand.eval(resolve)
+	if err != nil {
+		return nil, err
+	}
+	return []float64{-values[0]}, nil
+}
+
+// comparisons are the comparison operators, which evaluate to 1 when true and 0 when false
+var comparisons = map[string]func(a, b float64) bool{
+	"<":  func(a, b float64) bool { return a < b },
+	"<=": func(a, b float64) bool { return a <= b },
+	">":  func(a, b float64) bool { return a > b },
+	">=": func(a, b float64) bool { return a >= b },
+	"==": func(a, b float64) bool { return a == b },
+	"!=": func(a, b float64) bool { return a != b },
+}
+
+// binaryNode is an arithmetic operation or a comparison
+type binaryNode struct {
+	operator    string
+	left, right node
+}
+
+func (n *binaryNode) eval(resolve Resolver) ([]float64, error) {
+	left, err := n.left.eval(resolve)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	if compare, ok := comparisons[n.operator]; ok {
+		// At most one side is a list, the other is compared with each of its values
+		results := make([]float64, 0, max(len(left), len(right)))
+		for i := 0; i < len(left) || i < len(right); i++ {
+			results = append(results, truth(compare(left[min(i, len(left)-1)], right[min(i, len(right)-1)])))
+		}
+		return results, nil
+	}
+
+	a, b := left[0], right[0]
+	switch n.operator {
+	case "+":
+		return []float64{a + b}, nil
+	case "-":
+		return []float64{a - b}, nil
+	case "*":
+		return []float64{a * b}, nil
+	case "/":
+		if b == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return []float64{a / b}, nil
+	case "^":
+		return []float64{math.Pow(a, b)}, nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.operator)
+}
+
+// logicalNode is an and or an or, evaluating its right side only when the left one does not
+// decide the result, so a missing variable there is not an error
+type logicalNode struct {
+	operator    string
+	left, right node
+}
+
+func (n *logicalNode) eval(resolve Resolver) ([]float64, error) {
+	left, err := n.left.eval(resolve)
+	if err != nil {
+		return nil, err
+	}
+	leftTrue := left[0] != 0
+	if (n.operator == "and" && !leftTrue) || (n.operator == "or" && leftTrue) {
+		return []float64{truth(leftTrue)}, nil
+	}
+	right, err := n.right.eval(resolve)
+	if err != nil {
+		return nil, err
+	}
+	return []float64{truth(right[0] != 0)}, nil
+}
+
+// notNode is a logical negation
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) eval(resolve Resolver) ([]float64, error) {
+	values, err := n.operand.eval(resolve)
+	if err != nil {
+		return nil, err
+	}
+	return []float64{truth(values[0] == 0)}, nil
+}
+
+// truth returns 1 for true and 0 for false
+func truth(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// callNode is a function call
+type callNode struct {
+	name string
+	fn   function
+	args []node
+}
+
+func (n *callNode) eval(resolve Resolver) ([]float64, error) {
+	var args []float64
+	for _, arg := range n.args {
+		values, err := arg.eval(resolve)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, values...)
+	}
+	return []float64{n.fn.apply(args)}, nil
+}
+
+// collectVariables calls visit for every variable of the tree
+func collectVariables(n node, visit func(Variable)) {
+	switch typed := n.(type) {
+	case *variableNode:
+		visit(typed.variable)
+	case *negateNode:
+		collectVariables(typed.operand, visit)
+	case *notNode:
+		collectVariables(typed.operand, visit)
+	case *binaryNode:
+		collectVariables(typed.left, visit)
+		collectVariables(typed.right, visit)
+	case *logicalNode:
+		collectVariables(typed.left, visit)
+		collectVariables(typed.right, visit)
+	case *callNode:
+		for _, arg := range typed.args {
+			collectVariables(arg, visit)
+		}
+	}
+}
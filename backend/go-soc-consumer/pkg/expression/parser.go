@@ -0,0 +1,254 @@
+package expression
+
+import (
+	"fmt"
+)
+
+// binaryPrecedence is the precedence of the binary operators, higher binds tighter
+var binaryPrecedence = map[string]int{
+	"or":  1,
+	"and": 2,
+	"<":   3,
+	"<=":  3,
+	">":   3,
+	">=":  3,
+	"==":  3,
+	"!=":  3,
+	"+":   4,
+	"-":   4,
+	"*":   5,
+	"/":   5,
+	"^":   7,
+}
+
+// comparisonPrecedence is the precedence of the comparisons, which do not chain
+const comparisonPrecedence = 3
+
+// notPrecedence makes not bind looser than comparisons, so not x < 1 is not (x < 1)
+const notPrecedence = comparisonPrecedence
+
+// unaryPrecedence binds tighter than multiplication but looser than exponentiation, so -x^2 is -(x^2)
+const unaryPrecedence = 6
+
+// parser builds the syntax tree of an expression by precedence climbing
+type parser struct {
+	lexer *lexer
+	token token
+}
+
+// advance moves to the next token
+func (p *parser) advance() error {
+	next, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.token = next
+	return nil
+}
+
+// expect consumes the given operator
+func (p *parser) expect(operator string) error {
+	if p.token.kind != tokenOperator || p.token.text != operator {
+		return fmt.Errorf("expected %q at position %d", operator, p.token.pos)
+	}
+	return p.advance()
+}
+
+// parseExpression parses operators binding at least as tight as minPrecedence
+func (p *parser) parseExpression(minPrecedence int) (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.token.kind == tokenOperator {
+		operator := p.token.text
+		precedence, ok := binaryPrecedence[operator]
+		if !ok || precedence < minPrecedence {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		// ^ is right associative, the others left associative
+		next := precedence + 1
+		if operator == "^" {
+			next = precedence
+		}
+		right, err := p.parseExpression(next)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := comparisons[operator]; ok {
+			// Comparisons apply to every value of a list, giving a list for all, any or count
+			if isList(left) && isList(right) {
+				return nil, fmt.Errorf("%s cannot compare two lists", operator)
+			}
+			if p.token.kind == tokenOperator && binaryPrecedence[p.token.text] == comparisonPrecedence {
+				return nil, fmt.Errorf("comparisons cannot be chained, unexpected %q at position %d", p.token.text, p.token.pos)
+			}
+			left = &binaryNode{operator: operator, left: left, right: right}
+			continue
+		}
+
+		if err := requireScalar(left, operator); err != nil {
+			return nil, err
+		}
+		if err := requireScalar(right, operator); err != nil {
+			return nil, err
+		}
+		if operator == "and" || operator == "or" {
+			left = &logicalNode{operator: operator, left: left, right: right}
+		} else {
+			left = &binaryNode{operator: operator, left: left, right: right}
+		}
+	}
+	return left, nil
+}
+
+// parseUnary parses a signed or negated operand
+func (p *parser) parseUnary() (node, error) {
+	if p.token.kind == tokenOperator && p.token.text == "not" {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseExpression(notPrecedence)
+		if err != nil {
+			return nil, err
+		}
+		if err := requireScalar(operand, "not"); err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	if p.token.kind == tokenOperator && (p.token.text == "-" || p.token.text == "+") {
+		operator := p.token.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseExpression(unaryPrecedence)
+		if err != nil {
+			return nil, err
+		}
+		if err := requireScalar(operand, operator); err != nil {
+			return nil, err
+		}
+		if operator == "+" {
+			return operand, nil
+		}
+		return &negateNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a number, a variable, a function call or a parenthesized expression
+func (p *parser) parsePrimary() (node, error) {
+	current := p.token
+	switch current.kind {
+	case tokenNumber:
+		return &numberNode{value: current.number}, p.advance()
+	case tokenVariable:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.token.kind == tokenOperator && p.token.text == "(" {
+			return p.parseCall(current)
+		}
+		return &variableNode{variable: current.variable}, nil
+	case tokenOperator:
+		if current.text == "(" {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseExpression(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := requireScalar(inner, "()"); err != nil {
+				return nil, err
+			}
+			return inner, p.expect(")")
+		}
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", current.text, current.pos)
+}
+
+// parseCall parses the arguments of a function call, the current token being the opening parenthesis
+func (p *parser) parseCall(name token) (node, error) {
+	if name.variable.Channel != CurrentChannel {
+		return nil, fmt.Errorf("unknown function %s at position %d", name.text, name.pos)
+	}
+	fn, ok := functions[name.variable.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s at position %d", name.variable.Name, name.pos)
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var args []node
+	if p.token.kind != tokenOperator || p.token.text != ")" {
+		for {
+			arg, err := p.parseExpression(0)
+			if err != nil {
+				return nil, err
+			}
+			if !fn.aggregate {
+				if err := requireScalar(arg, name.variable.Name); err != nil {
+					return nil, err
+				}
+			}
+			args = append(args, arg)
+			if p.token.kind != tokenOperator || p.token.text != "," {
+				break
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		return nil, fmt.Errorf("%s takes %s, got %d", name.variable.Name, fn.arity(), len(args))
+	}
+	return &callNode{name: name.variable.Name, fn: fn, args: args}, nil
+}
+
+// requireScalar rejects lists outside aggregate functions
+func requireScalar(n node, context string) error {
+	if list := listVariable(n); list != nil {
+		return fmt.Errorf("%s can only be used in min, max, avg, sum, count, all or any, not in %s", list, context)
+	}
+	return nil
+}
+
+// isList reports whether a node evaluates to a list of values
+func isList(n node) bool {
+	return listVariable(n) != nil
+}
+
+// listVariable returns the variable making a node a list: a channel wildcard or a device group,
+// alone or compared with a value. It returns nil for single values.
+func listVariable(n node) *Variable {
+	switch typed := n.(type) {
+	case *variableNode:
+		if typed.variable.Channel == AllChannels || typed.variable.Group != "" {
+			return &typed.variable
+		}
+	case *binaryNode:
+		if _, ok := comparisons[typed.operator]; ok {
+			if list := listVariable(typed.left); list != nil {
+				return list
+			}
+			return listVariable(typed.right)
+		}
+	}
+	return nil
+}
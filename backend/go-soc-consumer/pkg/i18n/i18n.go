@@ -0,0 +1,114 @@
+// Package i18n localizes user-facing strings such as API errors and alert texts.
+//
+// Messages are identified by their English text, so English needs no catalog and
+// untranslated messages fall back to English. Messages with arguments use their
+// fmt format string as identifier.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Language is a supported ISO 639-1 language code
+type Language string
+
+// Supported languages
+const (
+	English Language = "en"
+	Spanish Language = "es"
+)
+
+// Supported lists every supported language
+var Supported = []Language{English, Spanish}
+
+// catalogs maps each language to the translation of every English message
+var catalogs = map[Language]map[string]string{
+	Spanish: spanish,
+}
+
+// ParseLanguage returns the supported language of a tag such as "es", "es-CO" or "EN"
+func ParseLanguage(tag string) (Language, bool) {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	for _, language := range Supported {
+		if string(language) == primary {
+			return language, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate picks the supported language the client prefers in an Accept-Language header,
+// or the fallback when it accepts none of them
+func Negotiate(acceptLanguage string, fallback Language) Language {
+	type preference struct {
+		language Language
+		quality  float64
+	}
+
+	var preferences []preference
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(entry, ";")
+		language, ok := ParseLanguage(tag)
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			preferences = append(preferences, preference{language: language, quality: quality})
+		}
+	}
+	if len(preferences) == 0 {
+		return fallback
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].quality > preferences[j].quality })
+	return preferences[0].language
+}
+
+// Translate returns the message in the given language, or the English message when it has no translation
+func Translate(language Language, message string) string {
+	if translated, ok := catalogs[language][message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Sprintf formats the translation of the format string
+func Sprintf(language Language, format string, args ...interface{}) string {
+	return fmt.Sprintf(Translate(language, format), args...)
+}
+
+// SprintfAll formats the message in every supported language, keyed by language code
+func SprintfAll(format string, args ...interface{}) map[string]string {
+	messages := make(map[string]string, len(Supported))
+	for _, language := range Supported {
+		messages[string(language)] = Sprintf(language, format, args...)
+	}
+	return messages
+}
+
+type languageKey struct{}
+
+// ContextWithLanguage returns a context carrying the language to answer in
+func ContextWithLanguage(ctx context.Context, language Language) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// LanguageFromContext returns the language carried by the context, English when there is none
+func LanguageFromContext(ctx context.Context) Language {
+	if language, ok := ctx.Value(languageKey{}).(Language); ok {
+		return language
+	}
+	return English
+}
@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       Language
+	}{
+		{name: "empty header", acceptLanguage: "", expected: English},
+		{name: "regional tag", acceptLanguage: "es-CO", expected: Spanish},
+		{name: "quality order", acceptLanguage: "en;q=0.5, es-CO;q=0.9", expected: Spanish},
+		{name: "unsupported first", acceptLanguage: "fr-FR, en;q=0.8", expected: English},
+		{name: "only unsupported", acceptLanguage: "fr, de", expected: English},
+		{name: "refused language", acceptLanguage: "es;q=0", expected: English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Negotiate(tt.acceptLanguage, English))
+		})
+	}
+
+	assert.Equal(t, Spanish, Negotiate("fr", Spanish))
+}
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "dispositivo no encontrado", Translate(Spanish, "device not found"))
+	assert.Equal(t, "device not found", Translate(English, "device not found"))
+	assert.Equal(t, "not in the catalog", Translate(Spanish, "not in the catalog"))
+	assert.Equal(t, "el cliente \"rogue\" publicó en el tópico de comandos cmd", Sprintf(Spanish, "client %q published on command topic %s", "rogue", "cmd"))
+	assert.Equal(t, map[string]string{"en": "device not found", "es": "dispositivo no encontrado"}, SprintfAll("device not found"))
+}
+
+func TestLanguageContext(t *testing.T) {
+	assert.Equal(t, English, LanguageFromContext(context.Background()))
+	assert.Equal(t, Spanish, LanguageFromContext(ContextWithLanguage(context.Background(), Spanish)))
+}
+
+func TestCatalogsKeepFormatVerbs(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0]*[0-9.]*[a-zA-Z%]`)
+	for language, catalog := range catalogs {
+		for message, translated := range catalog {
+			assert.Equal(t, verbs.FindAllString(message, -1), verbs.FindAllString(translated, -1), "%s: %q", language, message)
+		}
+	}
+}
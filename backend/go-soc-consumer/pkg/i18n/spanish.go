@@ -0,0 +1,218 @@
+package i18n
+
+// spanish translates the English messages to Spanish
+var spanish = map[string]string{
+	// API errors
+	"unauthorized":                        "no autorizado",
+	"forbidden":                           "prohibido",
+	"method not allowed":                  "método no permitido",
+	"invalid request body":                "cuerpo de la solicitud inválido",
+	"device not found":                    "dispositivo no encontrado",
+	"job not found":                       "tarea no encontrada",
+	"job already finished":                "la tarea ya finalizó",
+	"failed to read job":                  "no se pudo leer la tarea",
+	"limit must be a positive integer":    "el límite debe ser un entero positivo",
+	"limit exceeds the maximum page size": "el límite supera el tamaño máximo de página",
+	"invalid since cursor":                "cursor since inválido",
+	"invalid timeout":                     "tiempo de espera inválido",
+	"invalid sync batch":                  "lote de sincronización inválido",
+	"failed to read sync status":          "no se pudo leer el estado de sincronización",
+	"failed to apply sync batch":          "no se pudo aplicar el lote de sincronización",
+	"failed to pause consumption":         "no se pudo pausar el consumo",
+	"failed to resume consumption":        "no se pudo reanudar el consumo",
+	"failed to list blacklist":            "no se pudo listar la lista negra",
+	"failed to add blacklist entry":       "no se pudo agregar la entrada a la lista negra",
+	"failed to remove blacklist entry":    "no se pudo eliminar la entrada de la lista negra",
+	"entry already blacklisted":           "la entrada ya está en la lista negra",
+	"blacklist entry not found":           "entrada de la lista negra no encontrada",
+	"failed to provision certificate":     "no se pudo aprovisionar el certificado",
+	"failed to authenticate client":       "no se pudo autenticar el cliente",
+	"failed to authorize client":          "no se pudo autorizar el cliente",
+	"invalid broker action":               "acción de broker inválida",
+	"failed to load sensor health":        "no se pudo cargar la salud de los sensores",
+	"failed to record calibration":        "no se pudo registrar la calibración",
+	"failed to query device status":       "no se pudo consultar el estado de los dispositivos",
+	"failed to load device changes":       "no se pudieron cargar los cambios de los dispositivos",
+	"format must be json or yaml":         "el formato debe ser json o yaml",
+	"failed to export devices":            "no se pudieron exportar los dispositivos",
+	"failed to import devices":            "no se pudieron importar los dispositivos",
+	"failed to start reprocessing":        "no se pudo iniciar el reprocesamiento",
+	"failed to load sensor channels":      "no se pudieron cargar los canales de sensores",
+	"failed to configure sensor channel":  "no se pudo configurar el canal del sensor",
+	"invalid channel":                     "canal inválido",
+	"invalid before cursor":               "cursor before inválido",
+	"notification user not found":         "usuario de notificaciones no encontrado",
+	"notification not found":              "notificación no encontrada",
+	"failed to list notifications":        "no se pudieron listar las notificaciones",
+	"failed to count notifications":       "no se pudieron contar las notificaciones",
+	"failed to mark notifications read":   "no se pudieron marcar las notificaciones como leídas",
+	"failed to generate handover report":  "no se pudo generar el informe de relevo de turno",
+	"failed to diagnose device":           "no se pudo diagnosticar el dispositivo",
+	"failed to check device health":       "no se pudo verificar la salud del dispositivo",
+	"device command not found":            "comando de dispositivo no encontrado",
+	"failed to send device command":       "no se pudo enviar el comando al dispositivo",
+	"failed to read device command":       "no se pudo leer el comando del dispositivo",
+	"failed to list device commands":      "no se pudieron listar los comandos del dispositivo",
+	"failed to load valve state":          "no se pudo cargar el estado de la válvula",
+	"invalid since time":                  "fecha since inválida",
+	"invalid from time":                   "fecha from inválida",
+	"invalid to time":                     "fecha to inválida",
+	"failed to load readings":             "no se pudieron cargar las lecturas",
+	"failed to load crash report":         "no se pudo cargar el informe de fallos",
+	"failed to load firmware versions":    "no se pudieron cargar las versiones de firmware",
+	"failed to load farm quota":           "no se pudo cargar la cuota de la finca",
+	"format must be json or csv":          "el formato debe ser json o csv",
+	"invalid month":                       "mes inválido",
+	"failed to export usage":              "no se pudo exportar el consumo",
+	"invalid offset":                      "desplazamiento inválido",
+	"device already exists":               "el dispositivo ya existe",
+	"invalid dry_run":                     "dry_run inválido",
+	"failed to apply declaration":         "no se pudo aplicar la declaración",
+	"failed to list devices":              "no se pudieron listar los dispositivos",
+	"failed to load device":               "no se pudo cargar el dispositivo",
+	"failed to create device":             "no se pudo crear el dispositivo",
+	"failed to update device":             "no se pudo actualizar el dispositivo",
+	"failed to delete device":             "no se pudo eliminar el dispositivo",
+	"failed to list irrigation schedules": "no se pudieron listar los programas de riego",
+	"failed to save irrigation schedule":  "no se pudo guardar el programa de riego",
+	"irrigation schedule not found":       "programa de riego no encontrado",
+	"failed to list irrigation overrides": "no se pudieron listar las anulaciones de riego",
+	"failed to set irrigation override":   "no se pudo establecer la anulación de riego",
+	"failed to clear irrigation override": "no se pudo eliminar la anulación de riego",
+	"irrigation override not found":       "anulación de riego no encontrada",
+	"failed to start trace session":       "no se pudo iniciar la sesión de rastreo",
+	"trace session not found":             "sesión de rastreo no encontrada",
+	"failed to load trace session":        "no se pudo cargar la sesión de rastreo",
+	"onboarding not found":                "incorporación no encontrada",
+	"failed to list onboardings":          "no se pudieron listar las incorporaciones",
+	"format must be png or svg":           "el formato debe ser png o svg",
+	"invalid QR code scale":               "escala de código QR inválida",
+	"failed to render QR code":            "no se pudo generar el código QR",
+	"failed to process onboarding":        "no se pudo procesar la incorporación",
+	"failed to list farms":                "no se pudieron listar las fincas",
+	"failed to load farm":                 "no se pudo cargar la finca",
+	"failed to save farm":                 "no se pudo guardar la finca",
+	"failed to delete farm":               "no se pudo eliminar la finca",
+	"failed to load zone":                 "no se pudo cargar la zona",
+	"failed to save zone":                 "no se pudo guardar la zona",
+	"failed to delete zone":               "no se pudo eliminar la zona",
+	"failed to assign device":             "no se pudo asignar el dispositivo",
+	"failed to unassign device":           "no se pudo desasignar el dispositivo",
+	"failed to load zone measurements":    "no se pudieron cargar las mediciones de la zona",
+	"failed to list experiments":          "no se pudieron listar los experimentos",
+	"failed to load experiment":           "no se pudo cargar el experimento",
+	"failed to save experiment":           "no se pudo guardar el experimento",
+	"failed to load experiment report":    "no se pudo cargar el informe del experimento",
+	"failed to log in":                    "no se pudo iniciar sesión",
+	"failed to list users":                "no se pudieron listar los usuarios",
+	"failed to create user":               "no se pudo crear el usuario",
+	"failed to delete user":               "no se pudo eliminar el usuario",
+	"failed to list research zones":       "no se pudieron listar las zonas de investigación",
+	"failed to load research telemetry":   "no se pudo cargar la telemetría de investigación",
+	"failed to check research token":      "no se pudo verificar el token de investigación",
+	"failed to list research tokens":      "no se pudieron listar los tokens de investigación",
+	"failed to create research token":     "no se pudo crear el token de investigación",
+	"failed to revoke research token":     "no se pudo revocar el token de investigación",
+	"failed to load research token usage": "no se pudo cargar el uso del token de investigación",
+	"failed to initiate device transfer":  "no se pudo iniciar la transferencia del dispositivo",
+	"failed to list device transfers":     "no se pudieron listar las transferencias de dispositivos",
+	"failed to read device transfer":      "no se pudo leer la transferencia del dispositivo",
+	"failed to accept device transfer":    "no se pudo aceptar la transferencia del dispositivo",
+	"failed to complete device transfer":  "no se pudo completar la transferencia del dispositivo",
+	"failed to cancel device transfer":    "no se pudo cancelar la transferencia del dispositivo",
+	"failed to decommission device":       "no se pudo dar de baja el dispositivo",
+	"failed to list device archives":      "no se pudieron listar los archivos de dispositivos",
+	"failed to read device archive":       "no se pudo leer el archivo del dispositivo",
+	"failed to restore device archive":    "no se pudo restaurar el archivo del dispositivo",
+	"failed to start device migration":    "no se pudo iniciar la migración de dispositivos",
+	"failed to list device migrations":    "no se pudieron listar las migraciones de dispositivos",
+	"failed to read device migration":     "no se pudo leer la migración de dispositivos",
+	"failed to cancel device migration":   "no se pudo cancelar la migración de dispositivos",
+
+	// Domain errors returned to API clients
+	"Invalid blacklist entry":           "Entrada de lista negra inválida",
+	"Invalid calibration":               "Calibración inválida",
+	"Invalid certificate fingerprint":   "Huella de certificado inválida",
+	"Invalid device status":             "Estado de dispositivo inválido",
+	"Invalid device status query":       "Consulta de estado de dispositivos inválida",
+	"Invalid device bundle":             "Paquete de dispositivos inválido",
+	"Invalid device":                    "Dispositivo inválido",
+	"Invalid device command":            "Comando de dispositivo inválido",
+	"Invalid configuration declaration": "Declaración de configuración inválida",
+	"Invalid event policy":              "Política de eventos inválida",
+	"Invalid farm quota":                "Cuota de finca inválida",
+	"Invalid irrigation override":       "Anulación de riego inválida",
+	"Invalid irrigation schedule":       "Programa de riego inválido",
+	"Irrigation schedules overlap":      "Los programas de riego se superponen",
+	"Irrigation zone not found":         "Zona de riego no encontrada",
+	"Invalid analytics query":           "Consulta de analítica inválida",
+	"Irrigation experiment not found":   "Experimento de riego no encontrado",
+	"Invalid irrigation experiment":     "Experimento de riego inválido",
+	"Zone already in an experiment":     "La zona ya está en un experimento",
+	"Irrigation experiment ended":       "El experimento de riego terminó",
+	"Invalid trace session":             "Sesión de rastreo inválida",
+	"Too many trace sessions":           "Demasiadas sesiones de rastreo",
+	"Invalid onboarding":                "Incorporación inválida",
+	"Device already onboarded":          "El dispositivo ya fue incorporado",
+	"Onboarding step not ready":         "El paso de incorporación aún no está disponible",
+	"Invalid reprocessing range":        "Rango de reprocesamiento inválido",
+	"Invalid readings query":            "Consulta de lecturas inválida",
+	"Invalid sensor channel":            "Canal de sensor inválido",
+	"Device connection not found":       "Conexión de dispositivo no encontrada",
+	"Invalid target version":            "Versión objetivo inválida",
+	"Unknown sensor type":               "Tipo de sensor desconocido",
+	"Not enough readings to predict":    "No hay suficientes lecturas para predecir",
+	"Farm not found":                    "Finca no encontrada",
+	"Farm already exists":               "La finca ya existe",
+	"Invalid farm":                      "Finca inválida",
+	"Farm has fields":                   "La finca tiene campos",
+	"Zone not found":                    "Zona no encontrada",
+	"Invalid zone":                      "Zona inválida",
+	"Zone has zones or devices":         "La zona tiene zonas o dispositivos",
+	"Device not in zone":                "El dispositivo no está en la zona",
+	"Invalid username or password":      "Usuario o contraseña inválidos",
+	"Invalid access token":              "Token de acceso inválido",
+	"Access token expired":              "Token de acceso expirado",
+	"User not found":                    "Usuario no encontrado",
+	"User already exists":               "El usuario ya existe",
+	"Invalid user":                      "Usuario inválido",
+	"Research token not found":          "Token de investigación no encontrado",
+	"Invalid research token":            "Token de investigación inválido",
+	"Research token already revoked":    "El token de investigación ya fue revocado",
+	"Device transfer not found":         "Transferencia de dispositivo no encontrada",
+	"Invalid device transfer":           "Transferencia de dispositivo inválida",
+	"Device has an open transfer":       "El dispositivo tiene una transferencia abierta",
+	"Device transfer step not allowed":  "Paso de transferencia del dispositivo no permitido",
+	"Device archive not found":          "Archivo del dispositivo no encontrado",
+	"Invalid device archive":            "Archivo del dispositivo inválido",
+	"Device archive already restored":   "El archivo del dispositivo ya fue restaurado",
+	"Device is in service again":        "El dispositivo está de nuevo en servicio",
+	"Device migration not found":        "Migración de dispositivos no encontrada",
+	"Invalid device migration":          "Migración de dispositivos inválida",
+	"Device migration already running":  "Ya hay una migración de dispositivos en curso",
+	"Device migration already finished": "La migración de dispositivos ya terminó",
+
+	// Security alerts
+	"%d MAC addresses registered from %s within %s":          "%d direcciones MAC se registraron desde %s en %s",
+	"device %s sent %d readings within %s, baseline is %.1f": "el dispositivo %s envió %d lecturas en %s, la línea base es %.1f",
+	"%d clients published as device %s":                      "%d clientes publicaron como el dispositivo %s",
+	"client %q published on command topic %s":                "el cliente %q publicó en el tópico de comandos %s",
+
+	// Alerts
+	"%s of %s channel %d is %v, below %v for %s": "%s de %s canal %d está en %v, por debajo de %v durante %s",
+	"%s of %s channel %d is %v, above %v for %s": "%s de %s canal %d está en %v, por encima de %v durante %s",
+	"%s of %s channel %d recovered to %v":        "%s de %s canal %d se recuperó a %v",
+	"condition %s of rule %s held for %s":        "la condición %s de la regla %s se cumplió durante %s",
+	"condition %s of rule %s cleared":            "la condición %s de la regla %s dejó de cumplirse",
+
+	// Wi-Fi advisories
+	"%s has %d of %d devices with RSSI below %d dBm":                                     "%s tiene %d de %d dispositivos con RSSI por debajo de %d dBm",
+	"%s has %d of %d devices reconnecting to Wi-Fi %d or more times between two reports": "%s tiene %d de %d dispositivos que se reconectan al Wi-Fi %d o más veces entre dos reportes",
+	"%s has %d of %d devices on a 2.4 GHz channel other than 1, 6 and 11":                "%s tiene %d de %d dispositivos en un canal de 2.4 GHz distinto de 1, 6 y 11",
+	"Wi-Fi advisory %s in %s cleared":                                                    "la recomendación de Wi-Fi %s en %s se resolvió",
+
+	// Notifications
+	"[%s] alert %s is firing": "[%s] la alerta %s está activa",
+	"[%s] alert %s resolved":  "[%s] la alerta %s se resolvió",
+	"%d alerts since %s":      "%d alertas desde %s",
+}
@@ -1,24 +1,29 @@
 package logger
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/metrics"
 )
 
 // LoggerConfig holds logger configuration
 type LoggerConfig struct {
 	Level       string
 	Format      string
-	Environment string // production, development, testing
+	Environment string          // production, development, testing
+	Shipping    *ShippingConfig // optional remote log sink, nil disables shipping
 }
 
 // coreLogger implements the CoreLogger interface and serves as the foundation for all domain loggers
 type coreLogger struct {
 	*zap.Logger
-	sugar *zap.SugaredLogger
+	sugar   *zap.SugaredLogger
+	shipper *logShipper // nil when shipping is disabled
 }
 
 // NewCoreLogger creates a new core logger instance that serves as the foundation for domain loggers
@@ -60,12 +65,24 @@ func NewCoreLogger(config LoggerConfig) (CoreLogger, error) {
 		level,
 	)
 
+	// Tee into the remote log sink when shipping is enabled
+	var shipper *logShipper
+	if config.Shipping != nil {
+		var err error
+		shipper, err = newLogShipper(*config.Shipping)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create log shipper: %w", err)
+		}
+		core = zapcore.NewTee(core, newShippingCore(level, shipper))
+	}
+
 	// Add caller information and stack traces for errors
 	logger := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
 
 	return &coreLogger{
-		Logger: logger,
-		sugar:  logger.Sugar(),
+		Logger:  logger,
+		sugar:   logger.Sugar(),
+		shipper: shipper,
 	}, nil
 }
 
@@ -97,6 +114,34 @@ func (l *coreLogger) Sync() error {
 	return l.Logger.Sync()
 }
 
+// Close stops the remote log sink and seals its disk buffer; lines not shipped yet stay on disk
+// and are sent on the next start
+func (l *coreLogger) Close() error {
+	if l.shipper == nil {
+		return nil
+	}
+	return l.shipper.Close()
+}
+
+// Collect implements metrics.Collector, exposing the lines the log shipping buffer had to drop
+func (l *coreLogger) Collect() []metrics.Family {
+	if l.shipper == nil {
+		return nil
+	}
+	return []metrics.Family{
+		{Name: "log_shipping_dropped_lines_total", Help: "Log lines discarded unshipped because the log shipping buffer was full", Type: metrics.TypeCounter,
+			Samples: []metrics.Sample{{Value: float64(l.shipper.spool.DroppedLines())}}},
+	}
+}
+
+// MetricsCollector returns the log shipping metrics collector, nil for loggers not built by this package
+func MetricsCollector(loggerFactory LoggerFactory) metrics.Collector {
+	if collector, ok := loggerFactory.Core().(metrics.Collector); ok {
+		return collector
+	}
+	return nil
+}
+
 // parseLogLevel converts string level to zapcore.Level
 func parseLogLevel(level string) zapcore.Level {
 	switch strings.ToLower(level) {
@@ -115,4 +160,4 @@ func parseLogLevel(level string) zapcore.Level {
 	default:
 		return zapcore.InfoLevel
 	}
-}
\ No newline at end of file
+}
@@ -14,6 +14,7 @@ type CoreLogger interface {
 	Error(msg string, fields ...zap.Field)
 	Sugar() *zap.SugaredLogger
 	Sync() error
+	Close() error
 }
 
 // DeviceLogger handles device-related logging operations
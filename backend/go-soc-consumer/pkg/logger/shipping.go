@@ -0,0 +1,340 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Supported remote log shipping backends
+const (
+	ShippingBackendLoki          = "loki"
+	ShippingBackendElasticsearch = "elasticsearch"
+)
+
+// ShippingConfig holds configuration for the optional remote log sink
+type ShippingConfig struct {
+	Backend        string            // loki or elasticsearch
+	URL            string            // base URL of the backend, e.g. http://loki:3100
+	Index          string            // Elasticsearch index name
+	Username       string            // optional basic auth user
+	Password       string            // optional basic auth password
+	Labels         map[string]string // Loki stream labels
+	BufferDir      string            // directory used for the on-disk buffer
+	MaxBufferBytes int64             // oldest segments are dropped beyond this size
+	BatchSize      int               // max lines per push request
+	FlushInterval  time.Duration     // how often the active segment is sealed and shipped
+	RequestTimeout time.Duration     // timeout of a single push request
+	MaxBackoff     time.Duration     // upper bound for the retry backoff
+}
+
+// DefaultShippingConfig returns sensible defaults for edge gateways
+func DefaultShippingConfig() ShippingConfig {
+	return ShippingConfig{
+		Backend:        ShippingBackendLoki,
+		Index:          "iot-go-soc-consumer",
+		Labels:         map[string]string{"service": "go-soc-consumer"},
+		BufferDir:      "./data/log-buffer",
+		MaxBufferBytes: 64 * 1024 * 1024,
+		BatchSize:      500,
+		FlushInterval:  5 * time.Second,
+		RequestTimeout: 10 * time.Second,
+		MaxBackoff:     time.Minute,
+	}
+}
+
+// Validate ensures the shipping configuration is usable
+func (c *ShippingConfig) Validate() error {
+	switch c.Backend {
+	case ShippingBackendLoki, ShippingBackendElasticsearch:
+	default:
+		return fmt.Errorf("unsupported log shipping backend: %s", c.Backend)
+	}
+	if c.URL == "" {
+		return fmt.Errorf("log shipping URL is required")
+	}
+	if c.BufferDir == "" {
+		return fmt.Errorf("log shipping buffer directory is required")
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("log shipping batch size must be greater than 0")
+	}
+	if c.FlushInterval <= 0 {
+		return fmt.Errorf("log shipping flush interval must be greater than 0")
+	}
+	return nil
+}
+
+// logShipper periodically seals buffered segments and pushes them to the remote backend.
+// Failed pushes keep the segment on disk and are retried with exponential backoff.
+type logShipper struct {
+	config ShippingConfig
+	spool  *spool
+	client *http.Client
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// newLogShipper creates the disk buffer and starts the background sender
+func newLogShipper(config ShippingConfig) (*logShipper, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid log shipping config: %w", err)
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = 10 * time.Second
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = time.Minute
+	}
+
+	sp, err := newSpool(config.BufferDir, config.MaxBufferBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &logShipper{
+		config: config,
+		spool:  sp,
+		client: &http.Client{Timeout: config.RequestTimeout},
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+
+	return s, nil
+}
+
+// run is the sender loop
+func (s *logShipper) run() {
+	defer close(s.done)
+
+	backoff := s.config.FlushInterval
+	timer := time.NewTimer(s.config.FlushInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-timer.C:
+		}
+
+		if err := s.flush(context.Background()); err != nil {
+			backoff *= 2
+			if backoff > s.config.MaxBackoff {
+				backoff = s.config.MaxBackoff
+			}
+		} else {
+			backoff = s.config.FlushInterval
+		}
+		timer.Reset(backoff)
+	}
+}
+
+// flush seals the active segment and ships every sealed segment in order
+func (s *logShipper) flush(ctx context.Context) error {
+	if err := s.spool.Rotate(); err != nil {
+		return err
+	}
+
+	segments, err := s.spool.Segments()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range segments {
+		records, err := s.spool.ReadSegment(name)
+		if err != nil {
+			return err
+		}
+
+		shipped, err := s.spool.ShippedRecords(name)
+		if err != nil {
+			return err
+		}
+		for start := shipped; start < len(records); start += s.config.BatchSize {
+			end := start + s.config.BatchSize
+			if end > len(records) {
+				end = len(records)
+			}
+			if err := s.push(ctx, records[start:end]); err != nil {
+				return err
+			}
+			if end < len(records) {
+				if err := s.spool.MarkShipped(name, end); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := s.spool.Remove(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// push sends a batch of records to the configured backend
+func (s *logShipper) push(ctx context.Context, records []spoolRecord) error {
+	var (
+		body        []byte
+		endpoint    string
+		contentType string
+		err         error
+	)
+
+	switch s.config.Backend {
+	case ShippingBackendLoki:
+		endpoint = strings.TrimRight(s.config.URL, "/") + "/loki/api/v1/push"
+		contentType = "application/json"
+		body, err = encodeLokiPush(s.config.Labels, records)
+	case ShippingBackendElasticsearch:
+		endpoint = strings.TrimRight(s.config.URL, "/") + "/_bulk"
+		contentType = "application/x-ndjson"
+		body, err = encodeElasticsearchBulk(s.config.Index, records)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode log batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create log push request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if s.config.Username != "" {
+		req.SetBasicAuth(s.config.Username, s.config.Password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("log push request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("log push rejected with HTTP status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sync makes sure buffered lines reach the disk
+func (s *logShipper) Sync() error {
+	return s.spool.Sync()
+}
+
+// Close stops the sender and seals the active segment; unsent lines stay on disk
+func (s *logShipper) Close() error {
+	s.once.Do(func() {
+		close(s.stop)
+		<-s.done
+	})
+	return s.spool.Close()
+}
+
+// encodeLokiPush builds a Loki push API payload for a single stream
+func encodeLokiPush(labels map[string]string, records []spoolRecord) ([]byte, error) {
+	values := make([][2]string, 0, len(records))
+	for _, record := range records {
+		values = append(values, [2]string{strconv.FormatInt(record.Timestamp.UnixNano(), 10), string(record.Line)})
+	}
+
+	payload := map[string]interface{}{
+		"streams": []map[string]interface{}{
+			{
+				"stream": labels,
+				"values": values,
+			},
+		},
+	}
+	return json.Marshal(payload)
+}
+
+// encodeElasticsearchBulk builds an Elasticsearch bulk API payload
+func encodeElasticsearchBulk(index string, records []spoolRecord) ([]byte, error) {
+	action, err := json.Marshal(map[string]interface{}{"index": map[string]string{"_index": index}})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, record := range records {
+		buf.Write(action)
+		buf.WriteByte('\n')
+		buf.Write(record.Line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// shippingCore is a zapcore.Core that encodes entries as JSON into the shipper's disk buffer
+type shippingCore struct {
+	zapcore.LevelEnabler
+	encoder zapcore.Encoder
+	shipper *logShipper
+}
+
+// newShippingCore creates a core writing into the given shipper
+func newShippingCore(level zapcore.LevelEnabler, shipper *logShipper) zapcore.Core {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = "timestamp"
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	return &shippingCore{
+		LevelEnabler: level,
+		encoder:      zapcore.NewJSONEncoder(encoderConfig),
+		shipper:      shipper,
+	}
+}
+
+// With adds structured context to the core
+func (c *shippingCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := c.encoder.Clone()
+	for i := range fields {
+		fields[i].AddTo(clone)
+	}
+	return &shippingCore{
+		LevelEnabler: c.LevelEnabler,
+		encoder:      clone,
+		shipper:      c.shipper,
+	}
+}
+
+// Check adds this core to the checked entry when the level is enabled
+func (c *shippingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write encodes the entry and appends it to the disk buffer
+func (c *shippingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	return c.shipper.spool.Append(entry.Time, bytes.TrimRight(buf.Bytes(), "\n"))
+}
+
+// Sync flushes the disk buffer
+func (c *shippingCore) Sync() error {
+	return c.shipper.Sync()
+}
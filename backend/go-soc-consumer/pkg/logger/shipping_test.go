@@ -0,0 +1,273 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newTestShippingConfig(t *testing.T, backend, url string) ShippingConfig {
+	config := DefaultShippingConfig()
+	config.Backend = backend
+	config.URL = url
+	config.BufferDir = t.TempDir()
+	config.FlushInterval = time.Hour // flushes are triggered manually in tests
+	return config
+}
+
+func TestShippingConfig_Validate(t *testing.T) {
+	t.Run("should accept default config with URL", func(t *testing.T) {
+		config := DefaultShippingConfig()
+		config.URL = "http://loki:3100"
+		assert.NoError(t, config.Validate())
+	})
+
+	t.Run("should reject unknown backend", func(t *testing.T) {
+		config := DefaultShippingConfig()
+		config.URL = "http://loki:3100"
+		config.Backend = "splunk"
+		assert.Error(t, config.Validate())
+	})
+
+	t.Run("should require URL", func(t *testing.T) {
+		config := DefaultShippingConfig()
+		assert.Error(t, config.Validate())
+	})
+}
+
+func TestLogShipper(t *testing.T) {
+	t.Run("should push buffered lines to Loki", func(t *testing.T) {
+		var (
+			mu      sync.Mutex
+			payload map[string]interface{}
+			path    string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			path = r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		shipper, err := newLogShipper(newTestShippingConfig(t, ShippingBackendLoki, server.URL))
+		require.NoError(t, err)
+		defer shipper.Close()
+
+		logger := zap.New(newShippingCore(zapcore.InfoLevel, shipper))
+		logger.Info("device_registered", zap.String("component", "test"))
+		logger.Debug("filtered_out")
+
+		require.NoError(t, shipper.flush(context.Background()))
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "/loki/api/v1/push", path)
+		streams := payload["streams"].([]interface{})
+		require.Len(t, streams, 1)
+		values := streams[0].(map[string]interface{})["values"].([]interface{})
+		require.Len(t, values, 1)
+		assert.Contains(t, values[0].([]interface{})[1], "device_registered")
+
+		segments, err := shipper.spool.Segments()
+		require.NoError(t, err)
+		assert.Empty(t, segments)
+	})
+
+	t.Run("should push buffered lines to Elasticsearch bulk API", func(t *testing.T) {
+		var (
+			mu   sync.Mutex
+			body string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		config := newTestShippingConfig(t, ShippingBackendElasticsearch, server.URL)
+		config.Index = "test-index"
+		shipper, err := newLogShipper(config)
+		require.NoError(t, err)
+		defer shipper.Close()
+
+		logger := zap.New(newShippingCore(zapcore.InfoLevel, shipper))
+		logger.Info("first")
+		logger.Info("second")
+
+		require.NoError(t, shipper.flush(context.Background()))
+
+		mu.Lock()
+		defer mu.Unlock()
+		lines := strings.Split(strings.TrimSpace(body), "\n")
+		require.Len(t, lines, 4)
+		assert.Contains(t, lines[0], "test-index")
+		assert.Contains(t, lines[1], "first")
+		assert.Contains(t, lines[3], "second")
+	})
+
+	t.Run("should not resend delivered batches after a later batch fails", func(t *testing.T) {
+		var (
+			mu       sync.Mutex
+			requests int
+			lines    []string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests++
+			if requests == 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			data, _ := io.ReadAll(r.Body)
+			lines = append(lines, strings.Split(strings.TrimSpace(string(data)), "\n")[1])
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		config := newTestShippingConfig(t, ShippingBackendElasticsearch, server.URL)
+		config.BatchSize = 1
+		shipper, err := newLogShipper(config)
+		require.NoError(t, err)
+		defer shipper.Close()
+
+		logger := zap.New(newShippingCore(zapcore.InfoLevel, shipper))
+		logger.Info("first")
+		logger.Info("second")
+
+		assert.Error(t, shipper.flush(context.Background()))
+		require.NoError(t, shipper.flush(context.Background()))
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], "first")
+		assert.Contains(t, lines[1], "second")
+
+		segments, err := shipper.spool.Segments()
+		require.NoError(t, err)
+		assert.Empty(t, segments)
+	})
+
+	t.Run("should keep lines on disk when backend is unavailable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		config := newTestShippingConfig(t, ShippingBackendLoki, server.URL)
+		shipper, err := newLogShipper(config)
+		require.NoError(t, err)
+
+		logger := zap.New(newShippingCore(zapcore.InfoLevel, shipper))
+		logger.Info("buffered")
+
+		assert.Error(t, shipper.flush(context.Background()))
+		require.NoError(t, shipper.Close())
+
+		// A new shipper on the same directory picks up the pending segment
+		recovered, err := newLogShipper(config)
+		require.NoError(t, err)
+		defer recovered.Close()
+
+		segments, err := recovered.spool.Segments()
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+
+		records, err := recovered.spool.ReadSegment(segments[0])
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Contains(t, string(records[0].Line), "buffered")
+	})
+
+	t.Run("should keep unsent lines on disk when the core logger is closed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		config := newTestShippingConfig(t, ShippingBackendLoki, server.URL)
+		core, err := NewCoreLogger(LoggerConfig{Level: "info", Format: "json", Environment: "testing", Shipping: &config})
+		require.NoError(t, err)
+
+		core.Info("shutting_down")
+		require.NoError(t, core.Close())
+
+		recovered, err := newLogShipper(config)
+		require.NoError(t, err)
+		defer recovered.Close()
+
+		segments, err := recovered.spool.Segments()
+		require.NoError(t, err)
+		require.Len(t, segments, 1)
+	})
+}
+
+func TestSpool(t *testing.T) {
+	t.Run("should drop oldest segments beyond the buffer limit", func(t *testing.T) {
+		sp, err := newSpool(t.TempDir(), 250)
+		require.NoError(t, err)
+
+		line := []byte(strings.Repeat("x", 80))
+		for i := 0; i < 5; i++ {
+			require.NoError(t, sp.Append(time.Now(), line))
+			require.NoError(t, sp.Rotate())
+		}
+
+		segments, err := sp.Segments()
+		require.NoError(t, err)
+		assert.Len(t, segments, 2)
+		assert.Equal(t, int64(3), sp.DroppedLines())
+	})
+
+	t.Run("should expose dropped lines as a metric", func(t *testing.T) {
+		config := newTestShippingConfig(t, ShippingBackendLoki, "http://127.0.0.1:1")
+		config.MaxBufferBytes = 150
+		core, err := NewCoreLogger(LoggerConfig{Level: "info", Format: "json", Environment: "testing", Shipping: &config})
+		require.NoError(t, err)
+		defer core.Close()
+
+		shipper := core.(*coreLogger).shipper
+		for i := 0; i < 3; i++ {
+			core.Info(strings.Repeat("x", 80))
+			require.NoError(t, shipper.spool.Rotate())
+		}
+
+		families := MetricsCollector(&loggerFactory{core: core}).Collect()
+		require.Len(t, families, 1)
+		assert.Equal(t, "log_shipping_dropped_lines_total", families[0].Name)
+		assert.Equal(t, float64(shipper.spool.DroppedLines()), families[0].Samples[0].Value)
+		assert.Positive(t, families[0].Samples[0].Value)
+	})
+
+	t.Run("should recover active segments left by a previous process", func(t *testing.T) {
+		dir := t.TempDir()
+		sp, err := newSpool(dir, 0)
+		require.NoError(t, err)
+		require.NoError(t, sp.Append(time.Now(), []byte(`{"msg":"left behind"}`)))
+		require.NoError(t, sp.Sync())
+
+		reopened, err := newSpool(dir, 0)
+		require.NoError(t, err)
+
+		segments, err := reopened.Segments()
+		require.NoError(t, err)
+		assert.Len(t, segments, 1)
+	})
+}
@@ -0,0 +1,283 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	spoolSegmentPrefix = "segment-"
+	spoolSegmentSuffix = ".log"
+	spoolActiveSuffix  = ".active"
+	spoolShippedSuffix = ".shipped" // records of the segment already delivered, for partially shipped segments
+)
+
+// spoolRecord is a single buffered log line together with its entry timestamp
+type spoolRecord struct {
+	Timestamp time.Time
+	Line      []byte
+}
+
+// spool is a disk-backed FIFO buffer of encoded log lines.
+// Lines are appended to an active segment file which is sealed on rotation;
+// sealed segments are read by the sender and removed once shipped.
+type spool struct {
+	dir            string
+	maxBufferBytes int64
+
+	mu           sync.Mutex
+	active       *os.File
+	activeName   string
+	activeBytes  int64
+	sealedBytes  int64
+	droppedLines int64
+}
+
+// newSpool opens (or creates) a spool in the given directory.
+// Segments left active by a previous process are sealed so they get shipped.
+func newSpool(dir string, maxBufferBytes int64) (*spool, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create log buffer directory: %w", err)
+	}
+
+	s := &spool{
+		dir:            dir,
+		maxBufferBytes: maxBufferBytes,
+	}
+
+	leftovers, err := filepath.Glob(filepath.Join(dir, spoolSegmentPrefix+"*"+spoolActiveSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan log buffer directory: %w", err)
+	}
+	for _, name := range leftovers {
+		if err := os.Rename(name, strings.TrimSuffix(name, spoolActiveSuffix)); err != nil {
+			return nil, fmt.Errorf("failed to recover log buffer segment: %w", err)
+		}
+	}
+
+	segments, err := s.Segments()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range segments {
+		if info, err := os.Stat(name); err == nil {
+			s.sealedBytes += info.Size()
+		}
+	}
+
+	return s, nil
+}
+
+// Append writes a record to the active segment, opening one if needed
+func (s *spool) Append(ts time.Time, line []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active == nil {
+		name := filepath.Join(s.dir, fmt.Sprintf("%s%020d%s", spoolSegmentPrefix, time.Now().UnixNano(), spoolSegmentSuffix+spoolActiveSuffix))
+		f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+		if err != nil {
+			return fmt.Errorf("failed to open log buffer segment: %w", err)
+		}
+		s.active = f
+		s.activeName = name
+		s.activeBytes = 0
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(strconv.FormatInt(ts.UnixNano(), 10))
+	buf.WriteByte('\t')
+	buf.Write(bytes.ReplaceAll(line, []byte("\n"), []byte(" ")))
+	buf.WriteByte('\n')
+
+	n, err := s.active.Write(buf.Bytes())
+	s.activeBytes += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write log buffer segment: %w", err)
+	}
+
+	return nil
+}
+
+// Rotate seals the active segment so it becomes visible to the sender
+func (s *spool) Rotate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active == nil {
+		return nil
+	}
+
+	if err := s.active.Close(); err != nil {
+		return fmt.Errorf("failed to close log buffer segment: %w", err)
+	}
+	if err := os.Rename(s.activeName, strings.TrimSuffix(s.activeName, spoolActiveSuffix)); err != nil {
+		return fmt.Errorf("failed to seal log buffer segment: %w", err)
+	}
+
+	s.sealedBytes += s.activeBytes
+	s.active = nil
+	s.activeName = ""
+	s.activeBytes = 0
+
+	return s.enforceLimitLocked()
+}
+
+// Sync flushes the active segment to stable storage
+func (s *spool) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active == nil {
+		return nil
+	}
+	return s.active.Sync()
+}
+
+// Close seals the active segment
+func (s *spool) Close() error {
+	return s.Rotate()
+}
+
+// Segments returns the sealed segment paths ordered from oldest to newest
+func (s *spool) Segments() ([]string, error) {
+	names, err := filepath.Glob(filepath.Join(s.dir, spoolSegmentPrefix+"*"+spoolSegmentSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list log buffer segments: %w", err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ReadSegment loads all records of a sealed segment
+func (s *spool) ReadSegment(name string) ([]spoolRecord, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log buffer segment: %w", err)
+	}
+	defer f.Close()
+
+	var records []spoolRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		tsPart, linePart, found := bytes.Cut(scanner.Bytes(), []byte("\t"))
+		if !found {
+			continue
+		}
+		nanos, err := strconv.ParseInt(string(tsPart), 10, 64)
+		if err != nil {
+			continue
+		}
+		records = append(records, spoolRecord{
+			Timestamp: time.Unix(0, nanos),
+			Line:      append([]byte(nil), linePart...),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read log buffer segment: %w", err)
+	}
+
+	return records, nil
+}
+
+// ShippedRecords returns how many leading records of a sealed segment were already delivered
+func (s *spool) ShippedRecords(name string) (int, error) {
+	data, err := os.ReadFile(name + spoolShippedSuffix)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read log buffer offset: %w", err)
+	}
+	shipped, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, nil
+	}
+	return shipped, nil
+}
+
+// MarkShipped records that the leading records of a sealed segment were delivered, so a retry
+// after a later batch fails does not send them again
+func (s *spool) MarkShipped(name string, records int) error {
+	tmp := name + spoolShippedSuffix + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(records)), 0o640); err != nil {
+		return fmt.Errorf("failed to write log buffer offset: %w", err)
+	}
+	if err := os.Rename(tmp, name+spoolShippedSuffix); err != nil {
+		return fmt.Errorf("failed to write log buffer offset: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes a shipped segment
+func (s *spool) Remove(name string) error {
+	info, statErr := os.Stat(name)
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove log buffer segment: %w", err)
+	}
+	_ = os.Remove(name + spoolShippedSuffix)
+
+	s.mu.Lock()
+	if statErr == nil {
+		s.sealedBytes -= info.Size()
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// DroppedLines returns how many buffered lines were discarded due to the size limit
+func (s *spool) DroppedLines() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.droppedLines
+}
+
+// enforceLimitLocked drops the oldest sealed segments while the buffer exceeds its limit
+func (s *spool) enforceLimitLocked() error {
+	if s.maxBufferBytes <= 0 || s.sealedBytes <= s.maxBufferBytes {
+		return nil
+	}
+
+	segments, err := s.Segments()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range segments {
+		if s.sealedBytes <= s.maxBufferBytes {
+			break
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			continue
+		}
+		lines, _ := countLines(name)
+		shipped, _ := s.ShippedRecords(name)
+		if err := os.Remove(name); err != nil {
+			return fmt.Errorf("failed to drop log buffer segment: %w", err)
+		}
+		_ = os.Remove(name + spoolShippedSuffix)
+		s.sealedBytes -= info.Size()
+		s.droppedLines += lines - int64(shipped)
+	}
+
+	return nil
+}
+
+// countLines counts newline-terminated records in a file
+func countLines(name string) (int64, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return 0, err
+	}
+	return int64(bytes.Count(data, []byte("\n"))), nil
+}
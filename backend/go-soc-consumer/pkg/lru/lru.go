@@ -0,0 +1,108 @@
+package lru
+
+import "container/list"
+
+// Cache is a map holding at most a fixed number of entries. Once full, adding an entry evicts
+// the least recently used one. It is not safe for concurrent use; callers guard it with the lock
+// protecting the rest of their state.
+type Cache[K comparable, V any] struct {
+	capacity  int
+	items     map[K]*list.Element
+	order     *list.List // most recently used first
+	evictions uint64
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// New creates a cache holding up to capacity entries; a capacity below one holds one entry
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	capacity = max(capacity, 1)
+	return &Cache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the value of a key and marks it as recently used
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	element, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*entry[K, V]).value, true
+}
+
+// Put sets the value of a key and marks it as recently used, evicting the least recently used
+// entry when the cache is full. It reports whether an entry was evicted.
+func (c *Cache[K, V]) Put(key K, value V) bool {
+	if element, ok := c.items[key]; ok {
+		element.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return false
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+	if c.order.Len() <= c.capacity {
+		return false
+	}
+	oldest := c.order.Back()
+	c.order.Remove(oldest)
+	delete(c.items, oldest.Value.(*entry[K, V]).key)
+	c.evictions++
+	return true
+}
+
+// Delete removes a key
+func (c *Cache[K, V]) Delete(key K) {
+	if element, ok := c.items[key]; ok {
+		c.order.Remove(element)
+		delete(c.items, key)
+	}
+}
+
+// DeleteFunc removes the entries for which del returns true and returns how many it removed
+func (c *Cache[K, V]) DeleteFunc(del func(key K, value V) bool) int {
+	removed := 0
+	for element := c.order.Front(); element != nil; {
+		next := element.Next()
+		e := element.Value.(*entry[K, V])
+		if del(e.key, e.value) {
+			c.order.Remove(element)
+			delete(c.items, e.key)
+			removed++
+		}
+		element = next
+	}
+	return removed
+}
+
+// Range calls f for every entry, most recently used first, until f returns false
+func (c *Cache[K, V]) Range(f func(key K, value V) bool) {
+	for element := c.order.Front(); element != nil; element = element.Next() {
+		e := element.Value.(*entry[K, V])
+		if !f(e.key, e.value) {
+			return
+		}
+	}
+}
+
+// Len returns the number of entries
+func (c *Cache[K, V]) Len() int {
+	return c.order.Len()
+}
+
+// Capacity returns the maximum number of entries
+func (c *Cache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Evictions returns how many entries were evicted to make room for new ones
+func (c *Cache[K, V]) Evictions() uint64 {
+	return c.evictions
+}
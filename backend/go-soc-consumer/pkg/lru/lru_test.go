@@ -0,0 +1,61 @@
+package lru
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("should evict the least recently used entry", func(t *testing.T) {
+		cache := New[string, int](2)
+
+		assert.False(t, cache.Put("a", 1))
+		assert.False(t, cache.Put("b", 2))
+		_, _ = cache.Get("a")
+		assert.True(t, cache.Put("c", 3))
+
+		_, ok := cache.Get("b")
+		assert.False(t, ok, "b was the least recently used")
+		value, ok := cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, value)
+		assert.Equal(t, 2, cache.Len())
+		assert.Equal(t, uint64(1), cache.Evictions())
+	})
+
+	t.Run("should update existing entries without evicting", func(t *testing.T) {
+		cache := New[string, int](1)
+		cache.Put("a", 1)
+
+		assert.False(t, cache.Put("a", 2))
+		value, _ := cache.Get("a")
+		assert.Equal(t, 2, value)
+		assert.Equal(t, uint64(0), cache.Evictions())
+	})
+
+	t.Run("should delete entries", func(t *testing.T) {
+		cache := New[string, int](10)
+		for i, key := range []string{"a", "b", "c", "d"} {
+			cache.Put(key, i)
+		}
+
+		cache.Delete("a")
+		assert.Equal(t, 2, cache.DeleteFunc(func(_ string, value int) bool { return value%2 == 1 }))
+
+		var keys []string
+		cache.Range(func(key string, _ int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		assert.Equal(t, []string{"c"}, keys)
+	})
+
+	t.Run("should hold at least one entry", func(t *testing.T) {
+		cache := New[string, int](0)
+		cache.Put("a", 1)
+
+		assert.Equal(t, 1, cache.Len())
+		assert.Equal(t, 1, cache.Capacity())
+	})
+}
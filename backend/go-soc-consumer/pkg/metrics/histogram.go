@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Histogram is a histogram partitioned by a fixed set of label names. Observations are counted
+// in cumulative buckets with the given upper bounds, exposed as the _bucket, _sum and _count
+// series of the Prometheus text format.
+type Histogram struct {
+	name       string
+	help       string
+	buckets    []float64
+	labelNames []string
+
+	mu     sync.Mutex
+	values map[string]*histogramEntry
+}
+
+type histogramEntry struct {
+	labelValues []string
+	counts      []uint64 // per bucket, not cumulative
+	sum         float64
+	count       uint64
+}
+
+// NewHistogramVec creates a histogram with the given bucket upper bounds partitioned by the given labels
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Histogram{
+		name:       name,
+		help:       help,
+		buckets:    sorted,
+		labelNames: labelNames,
+		values:     make(map[string]*histogramEntry),
+	}
+}
+
+// Observe records a value for the given label values
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var buf [keyBufferSize]byte
+	key := appendKey(buf[:0], labelValues)
+	entry, ok := h.values[string(key)]
+	if !ok {
+		entry = &histogramEntry{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.values[string(key)] = entry
+	}
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		entry.counts[i]++
+	}
+	entry.sum += value
+	entry.count++
+}
+
+// Count returns the number of observations for the given label values
+func (h *Histogram) Count(labelValues ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var buf [keyBufferSize]byte
+	if entry, ok := h.values[string(appendKey(buf[:0], labelValues))]; ok {
+		return entry.count
+	}
+	return 0
+}
+
+// Collect implements Collector
+func (h *Histogram) Collect() []Family {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	keys := make([]string, 0, len(h.values))
+	for key := range h.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	family := Family{Name: h.name, Help: h.help, Type: TypeHistogram}
+	for _, key := range keys {
+		entry := h.values[key]
+		var cumulative uint64
+		for i, upperBound := range h.buckets {
+			cumulative += entry.counts[i]
+			family.Samples = append(family.Samples, Sample{
+				Suffix: "_bucket",
+				Labels: h.labels(entry, strconv.FormatFloat(upperBound, 'g', -1, 64)),
+				Value:  float64(cumulative),
+			})
+		}
+		family.Samples = append(family.Samples,
+			Sample{Suffix: "_bucket", Labels: h.labels(entry, "+Inf"), Value: float64(entry.count)},
+			Sample{Suffix: "_sum", Labels: h.labels(entry, ""), Value: entry.sum},
+			Sample{Suffix: "_count", Labels: h.labels(entry, ""), Value: float64(entry.count)},
+		)
+	}
+	return []Family{family}
+}
+
+// labels returns the labels of an entry, with the le label when upperBound is set
+func (h *Histogram) labels(entry *histogramEntry, upperBound string) map[string]string {
+	labels := make(map[string]string, len(h.labelNames)+1)
+	for i, name := range h.labelNames {
+		if i < len(entry.labelValues) {
+			labels[name] = entry.labelValues[i]
+		}
+	}
+	if upperBound != "" {
+		labels["le"] = upperBound
+	}
+	return labels
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Run("should render cumulative buckets, sum and count", func(t *testing.T) {
+		registry := NewRegistry()
+		latency := NewHistogramVec("latency_seconds", "Latency", []float64{1, 0.1}, "topic")
+		registry.Register(latency)
+
+		latency.Observe(0.05, "a")
+		latency.Observe(0.1, "a")
+		latency.Observe(0.5, "a")
+		latency.Observe(3, "a")
+
+		var buf bytes.Buffer
+		require.NoError(t, registry.Write(&buf))
+
+		expected := "# HELP latency_seconds Latency\n" +
+			"# TYPE latency_seconds histogram\n" +
+			"latency_seconds_bucket{le=\"0.1\",topic=\"a\"} 2\n" +
+			"latency_seconds_bucket{le=\"1\",topic=\"a\"} 3\n" +
+			"latency_seconds_bucket{le=\"+Inf\",topic=\"a\"} 4\n" +
+			"latency_seconds_sum{topic=\"a\"} 3.65\n" +
+			"latency_seconds_count{topic=\"a\"} 4\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("Count should return the observations of the labels", func(t *testing.T) {
+		latency := NewHistogramVec("latency_seconds", "", []float64{1}, "topic")
+		latency.Observe(2, "a")
+
+		assert.Equal(t, uint64(1), latency.Count("a"))
+		assert.Equal(t, uint64(0), latency.Count("b"))
+	})
+}
+
+func TestExistingSeriesDoNotAllocate(t *testing.T) {
+	latency := NewHistogramVec("latency_seconds", "Latency", []float64{0.1, 1}, "topic", "stage")
+	messages := NewCounterVec("messages_total", "Messages", "topic")
+	latency.Observe(0.5, "sensors", "device_to_commit")
+	messages.Inc("sensors")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		latency.Observe(0.5, "sensors", "device_to_commit")
+		messages.Inc("sensors")
+	})
+
+	assert.Zero(t, allocs)
+	assert.Equal(t, uint64(102), latency.Count("sensors", "device_to_commit"), "AllocsPerRun makes a warm-up call")
+	assert.Equal(t, float64(102), messages.Value("sensors"))
+}
@@ -0,0 +1,16 @@
+package metrics
+
+// keyBufferSize fits the label values of most series, so their key is built on the stack
+const keyBufferSize = 128
+
+// appendKey appends the map key of a series, its label values separated by 0xff, to buf. Looking
+// a series up with m[string(appendKey(...))] does not allocate; only adding a new series does.
+func appendKey(buf []byte, labelValues []string) []byte {
+	for i, value := range labelValues {
+		if i > 0 {
+			buf = append(buf, 0xff)
+		}
+		buf = append(buf, value...)
+	}
+	return buf
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metric types used in the Prometheus text exposition format
+const (
+	TypeCounter   = "counter"
+	TypeGauge     = "gauge"
+	TypeHistogram = "histogram"
+)
+
+// Sample is a single metric value with its labels
+type Sample struct {
+	Suffix string // appended to the family name, e.g. _bucket for histogram series
+	Labels map[string]string
+	Value  float64
+}
+
+// Family groups all samples of one metric name
+type Family struct {
+	Name    string
+	Help    string
+	Type    string
+	Samples []Sample
+}
+
+// Collector produces metric families at scrape time
+type Collector interface {
+	Collect() []Family
+}
+
+// Registry keeps track of collectors and exposes them in Prometheus text format
+type Registry struct {
+	mu         sync.RWMutex
+	collectors []Collector
+}
+
+// NewRegistry creates an empty metrics registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a collector to the registry
+func (r *Registry) Register(collector Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, collector)
+}
+
+// Gather collects all metric families ordered by name
+func (r *Registry) Gather() []Family {
+	r.mu.RLock()
+	collectors := make([]Collector, len(r.collectors))
+	copy(collectors, r.collectors)
+	r.mu.RUnlock()
+
+	var families []Family
+	for _, collector := range collectors {
+		families = append(families, collector.Collect()...)
+	}
+	sort.SliceStable(families, func(i, j int) bool {
+		return families[i].Name < families[j].Name
+	})
+	return families
+}
+
+// Write renders all metric families in Prometheus text exposition format
+func (r *Registry) Write(w io.Writer) error {
+	for _, family := range r.Gather() {
+		if family.Help != "" {
+			if _, err := fmt.Fprintf(w, "# HELP %s %s\n", family.Name, family.Help); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "# TYPE %s %s\n", family.Name, family.Type); err != nil {
+			return err
+		}
+		for _, sample := range family.Samples {
+			if _, err := fmt.Fprintf(w, "%s%s%s %s\n", family.Name, sample.Suffix, formatLabels(sample.Labels), strconv.FormatFloat(sample.Value, 'g', -1, 64)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Handler returns an HTTP handler serving the registry for Prometheus scrapes
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.WriteHeader(http.StatusOK)
+		_ = r.Write(w)
+	})
+}
+
+// formatLabels renders labels as {key="value",...} with sorted keys
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(key)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabelValue(labels[key]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// escapeLabelValue escapes backslashes, quotes and newlines in label values
+func escapeLabelValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("should render counters and gauges in text format", func(t *testing.T) {
+		registry := NewRegistry()
+		messages := NewCounterVec("mqtt_messages_total", "Processed MQTT messages", "topic")
+		connected := NewGaugeVec("mqtt_connected", "Whether the MQTT client is connected")
+		registry.Register(messages)
+		registry.Register(connected)
+
+		messages.Inc("a")
+		messages.Add(2, "a")
+		messages.Inc("b")
+		connected.Set(1)
+
+		var buf bytes.Buffer
+		require.NoError(t, registry.Write(&buf))
+
+		expected := "# HELP mqtt_connected Whether the MQTT client is connected\n" +
+			"# TYPE mqtt_connected gauge\n" +
+			"mqtt_connected 1\n" +
+			"# HELP mqtt_messages_total Processed MQTT messages\n" +
+			"# TYPE mqtt_messages_total counter\n" +
+			"mqtt_messages_total{topic=\"a\"} 3\n" +
+			"mqtt_messages_total{topic=\"b\"} 1\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("should escape label values", func(t *testing.T) {
+		assert.Equal(t, `{a="x\"y\\z"}`, formatLabels(map[string]string{"a": `x"y\z`}))
+	})
+
+	t.Run("Handler should serve metrics", func(t *testing.T) {
+		registry := NewRegistry()
+		gauge := NewGaugeVec("up", "")
+		gauge.Set(1)
+		registry.Register(gauge)
+
+		rec := httptest.NewRecorder()
+		registry.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "up 1\n")
+	})
+}
+
+func TestVec_Value(t *testing.T) {
+	vec := NewGaugeVec("temperature", "", "device")
+	assert.Equal(t, float64(0), vec.Value("missing"))
+
+	vec.Set(21.5, "dev-1")
+	assert.Equal(t, 21.5, vec.Value("dev-1"))
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+// Vec is a counter or gauge partitioned by a fixed set of label names
+type Vec struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+
+	mu     sync.Mutex
+	values map[string]*vecEntry
+}
+
+type vecEntry struct {
+	labelValues []string
+	value       float64
+}
+
+// NewCounterVec creates a counter partitioned by the given labels
+func NewCounterVec(name, help string, labelNames ...string) *Vec {
+	return newVec(name, help, TypeCounter, labelNames)
+}
+
+// NewGaugeVec creates a gauge partitioned by the given labels
+func NewGaugeVec(name, help string, labelNames ...string) *Vec {
+	return newVec(name, help, TypeGauge, labelNames)
+}
+
+func newVec(name, help, metricType string, labelNames []string) *Vec {
+	return &Vec{
+		name:       name,
+		help:       help,
+		metricType: metricType,
+		labelNames: labelNames,
+		values:     make(map[string]*vecEntry),
+	}
+}
+
+// Inc increments the value for the given label values by one
+func (v *Vec) Inc(labelValues ...string) {
+	v.Add(1, labelValues...)
+}
+
+// Add adds delta to the value for the given label values
+func (v *Vec) Add(delta float64, labelValues ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.entryLocked(labelValues).value += delta
+}
+
+// Set sets the value for the given label values
+func (v *Vec) Set(value float64, labelValues ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.entryLocked(labelValues).value = value
+}
+
+// Value returns the current value for the given label values
+func (v *Vec) Value(labelValues ...string) float64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var buf [keyBufferSize]byte
+	if entry, ok := v.values[string(appendKey(buf[:0], labelValues))]; ok {
+		return entry.value
+	}
+	return 0
+}
+
+// Collect implements Collector
+func (v *Vec) Collect() []Family {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	keys := make([]string, 0, len(v.values))
+	for key := range v.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	family := Family{Name: v.name, Help: v.help, Type: v.metricType}
+	for _, key := range keys {
+		entry := v.values[key]
+		labels := make(map[string]string, len(v.labelNames))
+		for i, name := range v.labelNames {
+			if i < len(entry.labelValues) {
+				labels[name] = entry.labelValues[i]
+			}
+		}
+		family.Samples = append(family.Samples, Sample{Labels: labels, Value: entry.value})
+	}
+	return []Family{family}
+}
+
+func (v *Vec) entryLocked(labelValues []string) *vecEntry {
+	var buf [keyBufferSize]byte
+	key := appendKey(buf[:0], labelValues)
+	entry, ok := v.values[string(key)]
+	if !ok {
+		entry = &vecEntry{labelValues: append([]string(nil), labelValues...)}
+		v.values[string(key)] = entry
+	}
+	return entry
+}
@@ -0,0 +1,76 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Unlimited is the limit internal callers pass to read every record. API clients cannot request it.
+const Unlimited = -1
+
+// Errors returned for limits the policy rejects. The messages are shown to API clients as is.
+var (
+	ErrInvalidLimit  = errors.New("limit must be a positive integer")
+	ErrLimitTooLarge = errors.New("limit exceeds the maximum page size")
+)
+
+// Policy decides how many records a list operation returns
+type Policy struct {
+	DefaultLimit int // applied when the caller passes 0
+	MaxLimit     int // largest limit a caller may request
+}
+
+// DefaultPolicy returns the default pagination policy
+func DefaultPolicy() Policy {
+	return Policy{
+		DefaultLimit: 50,
+		MaxLimit:     500,
+	}
+}
+
+// Validate checks that the policy itself is usable
+func (p Policy) Validate() error {
+	if p.DefaultLimit < 1 {
+		return fmt.Errorf("default limit must be at least 1")
+	}
+	if p.MaxLimit < p.DefaultLimit {
+		return fmt.Errorf("max limit must not be lower than the default limit")
+	}
+	return nil
+}
+
+// Limit resolves the limit requested by an internal caller: 0 selects the default limit, Unlimited
+// is returned as is, and any other value must be between 1 and the maximum limit.
+func (p Policy) Limit(limit int) (int, error) {
+	if limit == Unlimited {
+		return Unlimited, nil
+	}
+	return p.RequestLimit(limit)
+}
+
+// RequestLimit resolves the limit requested by an API client, who cannot ask for unlimited results
+func (p Policy) RequestLimit(limit int) (int, error) {
+	switch {
+	case limit == 0:
+		return p.DefaultLimit, nil
+	case limit < 0:
+		return 0, ErrInvalidLimit
+	case limit > p.MaxLimit:
+		return 0, ErrLimitTooLarge
+	default:
+		return limit, nil
+	}
+}
+
+// ParseRequestLimit resolves the limit query parameter of an API request; an empty value selects the default limit
+func (p Policy) ParseRequestLimit(value string) (int, error) {
+	if value == "" {
+		return p.DefaultLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 {
+		return 0, ErrInvalidLimit
+	}
+	return p.RequestLimit(limit)
+}
@@ -0,0 +1,78 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Limit(t *testing.T) {
+	policy := Policy{DefaultLimit: 20, MaxLimit: 100}
+
+	tests := []struct {
+		name     string
+		limit    int
+		expected int
+		wantErr  error
+	}{
+		{name: "zero selects the default", limit: 0, expected: 20},
+		{name: "limit within range", limit: 75, expected: 75},
+		{name: "maximum limit", limit: 100, expected: 100},
+		{name: "unlimited for internal callers", limit: Unlimited, expected: Unlimited},
+		{name: "above maximum", limit: 101, wantErr: ErrLimitTooLarge},
+		{name: "negative", limit: -5, wantErr: ErrInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, err := policy.Limit(tt.limit)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, limit)
+		})
+	}
+}
+
+func TestPolicy_RequestLimitRejectsUnlimited(t *testing.T) {
+	_, err := DefaultPolicy().RequestLimit(Unlimited)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
+
+func TestPolicy_ParseRequestLimit(t *testing.T) {
+	policy := Policy{DefaultLimit: 20, MaxLimit: 100}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected int
+		wantErr  error
+	}{
+		{name: "missing selects the default", value: "", expected: 20},
+		{name: "valid", value: "40", expected: 40},
+		{name: "zero", value: "0", wantErr: ErrInvalidLimit},
+		{name: "not a number", value: "all", wantErr: ErrInvalidLimit},
+		{name: "above maximum", value: "1000", wantErr: ErrLimitTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, err := policy.ParseRequestLimit(tt.value)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, limit)
+		})
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	assert.NoError(t, DefaultPolicy().Validate())
+	assert.Error(t, Policy{DefaultLimit: 0, MaxLimit: 10}.Validate())
+	assert.Error(t, Policy{DefaultLimit: 50, MaxLimit: 10}.Validate())
+}
@@ -0,0 +1,511 @@
+// Package qrcode encodes bytes as QR codes (ISO/IEC 18004), rendered as PNG or SVG.
+//
+// Only what provisioning payloads need is supported: byte mode, the medium error correction level,
+// which recovers about 15% of a damaged or dirty label, and versions 1 to 40. The version is the
+// smallest one holding the data, and the mask the one with the lowest penalty.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTooLong is returned by Encode when the data does not fit in a version 40 code
+var ErrTooLong = errors.New("data too long for a QR code")
+
+const (
+	minVersion = 1
+	maxVersion = 40
+
+	// formatBitsMedium are the error correction level bits of the format information
+	formatBitsMedium = 0
+)
+
+// eccCodewordsPerBlock and eccBlocks are the error correction codewords of each block and the
+// number of blocks of the medium level, by version
+var (
+	eccCodewordsPerBlock = [maxVersion + 1]int{-1,
+		10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26,
+		26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28}
+	eccBlocks = [maxVersion + 1]int{-1,
+		1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16,
+		17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49}
+)
+
+// Code is an encoded QR code, a square of dark and light modules
+type Code struct {
+	Version int
+	Size    int // modules per side, without the quiet zone
+	Mask    int
+
+	modules    [][]bool // dark modules, by row then column
+	isFunction [][]bool // modules of the function patterns, left alone by the data and the mask
+}
+
+// Encode encodes the data in byte mode at the medium error correction level
+func Encode(data []byte) (*Code, error) {
+	version := minVersion
+	for ; version <= maxVersion; version++ {
+		if dataBits(version, len(data)) <= dataCodewords(version)*8 {
+			break
+		}
+	}
+	if version > maxVersion {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTooLong, len(data))
+	}
+
+	code := newCode(version)
+	code.drawFunctionPatterns()
+	code.drawCodewords(addECCAndInterleave(version, encodeData(version, data)))
+	code.applyBestMask()
+	return code, nil
+}
+
+// Dark reports whether the module at column x and row y is dark
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+func newCode(version int) *Code {
+	size := version*4 + 17
+	code := &Code{Version: version, Size: size, modules: make([][]bool, size), isFunction: make([][]bool, size)}
+	for y := range size {
+		code.modules[y] = make([]bool, size)
+		code.isFunction[y] = make([]bool, size)
+	}
+	return code
+}
+
+// countBits returns the length of the character count of byte mode
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// dataBits returns the bits taken by the mode, the count and the data
+func dataBits(version, length int) int {
+	if length >= 1<<countBits(version) {
+		return 1 << 30
+	}
+	return 4 + countBits(version) + length*8
+}
+
+// rawDataModules returns the modules left for codewords once the function patterns are drawn
+func rawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		alignments := version/7 + 2
+		result -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// dataCodewords returns the codewords holding data, the rest holding error correction
+func dataCodewords(version int) int {
+	return rawDataModules(version)/8 - eccCodewordsPerBlock[version]*eccBlocks[version]
+}
+
+// encodeData returns the data codewords: mode, count, data, terminator and padding
+func encodeData(version int, data []byte) []byte {
+	var bits bitBuffer
+	bits.append(0b0100, 4)
+	bits.append(len(data), countBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacity := dataCodewords(version) * 8
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	codewords := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			codewords[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return codewords
+}
+
+// addECCAndInterleave splits the data in blocks, appends their error correction codewords and
+// interleaves them. The first blocks are one data codeword shorter than the last ones.
+func addECCAndInterleave(version int, data []byte) []byte {
+	blocks := eccBlocks[version]
+	eccLength := eccCodewordsPerBlock[version]
+	rawCodewords := rawDataModules(version) / 8
+	shortBlocks := blocks - rawCodewords%blocks
+	shortBlockLength := rawCodewords / blocks
+
+	divisor := reedSolomonDivisor(eccLength)
+	split := make([][]byte, 0, blocks)
+	for i, k := 0, 0; i < blocks; i++ {
+		dataLength := shortBlockLength - eccLength
+		if i >= shortBlocks {
+			dataLength++
+		}
+		block := append([]byte(nil), data[k:k+dataLength]...)
+		k += dataLength
+		ecc := reedSolomonRemainder(block, divisor)
+		if i < shortBlocks {
+			block = append(block, 0) // placeholder keeping the error correction codewords aligned
+		}
+		split = append(split, append(block, ecc...))
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range split[0] {
+		for j, block := range split {
+			if i != shortBlockLength-eccLength || j >= shortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// drawFunctionPatterns draws the finder, timing and alignment patterns with the format and version
+// information, marking them as function modules
+func (c *Code) drawFunctionPatterns() {
+	for i := range c.Size {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.Size-4, 3)
+	c.drawFinder(3, c.Size-4)
+
+	positions := alignmentPositions(c.Version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// The corners taken by the finders have no alignment pattern
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(x, y)
+		}
+	}
+
+	// Reserve the format information, drawn for real with the mask
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+// drawFinder draws a finder pattern centered on the module with its separator
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			distance := max(abs(dx), abs(dy))
+			if xx, yy := x+dx, y+dy; xx >= 0 && xx < c.Size && yy >= 0 && yy < c.Size {
+				c.setFunction(xx, yy, distance != 2 && distance != 4)
+			}
+		}
+	}
+}
+
+// drawAlignment draws an alignment pattern centered on the module
+func (c *Code) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the error correction level and mask, protected by a BCH code
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+
+	// Around the top left finder
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(bits, i))
+	}
+	c.setFunction(8, 7, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	// Split between the two other finders
+	for i := range 8 {
+		c.setFunction(c.Size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(bits, i))
+	}
+	c.setFunction(8, c.Size-8, true) // always dark
+}
+
+// drawVersion draws both copies of the version, protected by a BCH code, from version 7 on
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	bits := versionBits(c.Version)
+	for i := range 18 {
+		a, b := c.Size-11+i%3, i/3
+		c.setFunction(a, b, bit(bits, i))
+		c.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// formatBits returns the 15 bits of the format information of the mask
+func formatBits(mask int) int {
+	data := formatBitsMedium<<3 | mask
+	remainder := data
+	for range 10 {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	return (data<<10 | remainder) ^ 0x5412
+}
+
+// versionBits returns the 18 bits of the version information
+func versionBits(version int) int {
+	remainder := version
+	for range 12 {
+		remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1F25)
+	}
+	return version<<12 | remainder
+}
+
+// drawCodewords places the codewords in the zigzag of two module wide columns, from the bottom
+// right corner, skipping the function modules
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vertical := range c.Size {
+			for j := range 2 {
+				x := right - j
+				y := vertical
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vertical // upwards
+				}
+				if !c.isFunction[y][x] && i < len(codewords)*8 {
+					c.modules[y][x] = codewords[i>>3]>>(7-i&7)&1 == 1
+					i++
+				}
+				// Remainder modules stay light
+			}
+		}
+	}
+}
+
+// applyBestMask applies the mask with the lowest penalty and draws its format information
+func (c *Code) applyBestMask() {
+	best, bestPenalty := 0, -1
+	for mask := range 8 {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask) // a mask undoes itself
+	}
+	c.Mask = best
+	c.applyMask(best)
+	c.drawFormatBits(best)
+}
+
+// applyMask inverts the data modules selected by the mask
+func (c *Code) applyMask(mask int) {
+	for y := range c.Size {
+		for x := range c.Size {
+			if c.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			c.modules[y][x] = c.modules[y][x] != invert
+		}
+	}
+}
+
+// penalty scores the modules with the four rules of the standard; scanners read codes with a low
+// score more reliably
+func (c *Code) penalty() int {
+	result := 0
+	row := func(y int) func(int) bool { return func(x int) bool { return c.modules[y][x] } }
+	column := func(x int) func(int) bool { return func(y int) bool { return c.modules[y][x] } }
+	for i := range c.Size {
+		result += c.linePenalty(row(i)) + c.linePenalty(column(i))
+	}
+
+	dark := 0
+	for y := range c.Size {
+		for x := range c.Size {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x < c.Size-1 && y < c.Size-1 {
+				color := c.modules[y][x]
+				if color == c.modules[y][x+1] && color == c.modules[y+1][x] && color == c.modules[y+1][x+1] {
+					result += 3
+				}
+			}
+		}
+	}
+
+	// 10 points for every 5% the dark modules are away from half of them
+	total := c.Size * c.Size
+	result += abs(dark*20-total*10) / total * 10
+	return result
+}
+
+// linePenalty scores the runs of five or more modules of the same color and the patterns looking
+// like a finder in a row or a column
+func (c *Code) linePenalty(dark func(int) bool) int {
+	result := 0
+	run := 1
+	for i := 1; i <= c.Size; i++ {
+		if i < c.Size && dark(i) == dark(i-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			result += 3 + run - 5
+		}
+		run = 1
+	}
+
+	finder := []bool{true, false, true, true, true, false, true}
+	for i := 0; i+len(finder) <= c.Size; i++ {
+		matches := true
+		for j, want := range finder {
+			if dark(i+j) != want {
+				matches = false
+				break
+			}
+		}
+		if matches && (c.lightRun(dark, i-4, i) || c.lightRun(dark, i+len(finder), i+len(finder)+4)) {
+			result += 40
+		}
+	}
+	return result
+}
+
+// lightRun reports whether the modules from start to end are light, the ones outside the code
+// counting as light like the quiet zone
+func (c *Code) lightRun(dark func(int) bool, start, end int) bool {
+	for i := start; i < end; i++ {
+		if i >= 0 && i < c.Size && dark(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+// alignmentPositions returns the rows and columns of the centers of the alignment patterns
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	count := version/7 + 2
+	step := (version*4 + count*2 + 1) / (count*2 - 2) * 2
+	if version == 32 {
+		step = 26
+	}
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, position := count-1, version*4+17-7; i >= 1; i, position = i-1, position-step {
+		positions[i] = position
+	}
+	return positions
+}
+
+// reedSolomonDivisor returns the generator polynomial of the degree, highest coefficient first
+// and the leading 1 omitted
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder returns the error correction codewords of the data
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range divisor {
+			result[i] ^= gfMultiply(coefficient, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// bitBuffer is a sequence of bits, most significant first
+type bitBuffer []bool
+
+// append appends the low length bits of the value
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+func bit(value, i int) bool {
+	return (value>>i)&1 == 1
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
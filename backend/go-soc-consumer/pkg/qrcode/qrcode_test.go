@@ -0,0 +1,184 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_Version(t *testing.T) {
+	// Byte capacities of the medium level from the standard
+	capacities := map[int]int{1: 14, 2: 26, 3: 42, 4: 62, 5: 84, 6: 106, 7: 122, 8: 152, 9: 180, 10: 213, 20: 666, 40: 2331}
+	for version, capacity := range capacities {
+		assert.LessOrEqual(t, dataBits(version, capacity), dataCodewords(version)*8, "version %d", version)
+		assert.Greater(t, dataBits(version, capacity+1), dataCodewords(version)*8, "version %d", version)
+	}
+
+	code, err := Encode(bytes.Repeat([]byte("a"), 213))
+	require.NoError(t, err)
+	assert.Equal(t, 10, code.Version)
+	assert.Equal(t, 57, code.Size)
+
+	_, err = Encode(bytes.Repeat([]byte("a"), 2332))
+	assert.ErrorIs(t, err, ErrTooLong)
+}
+
+func TestReedSolomon(t *testing.T) {
+	// "HELLO WORLD" at version 1-M, from the worked example of the standard
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expected := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	assert.Equal(t, expected, reedSolomonRemainder(data, reedSolomonDivisor(10)))
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	assert.Equal(t, 0b101010000010010, formatBits(0))
+	assert.Equal(t, 0b100000011001110, formatBits(5))
+	assert.Equal(t, 0b000111110010010100, versionBits(7))
+	assert.Equal(t, 0b101000110001101001, versionBits(40))
+}
+
+func TestAlignmentPositions(t *testing.T) {
+	assert.Empty(t, alignmentPositions(1))
+	assert.Equal(t, []int{6, 18}, alignmentPositions(2))
+	assert.Equal(t, []int{6, 22, 38}, alignmentPositions(7))
+	assert.Equal(t, []int{6, 34, 60, 86, 112, 138}, alignmentPositions(32))
+	assert.Equal(t, []int{6, 30, 58, 86, 114, 142, 170}, alignmentPositions(40))
+}
+
+// readCodewords reads the codewords back from the modules, undoing the mask
+func readCodewords(code *Code) []byte {
+	code.applyMask(code.Mask)
+	defer code.applyMask(code.Mask)
+
+	var bits bitBuffer
+	for right := code.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := range code.Size {
+			for j := range 2 {
+				x, y := right-j, vertical
+				if (right+1)&2 == 0 {
+					y = code.Size - 1 - vertical
+				}
+				if !code.isFunction[y][x] {
+					bits = append(bits, code.modules[y][x])
+				}
+			}
+		}
+	}
+	codewords := make([]byte, len(bits)/8)
+	for i := range codewords {
+		for _, dark := range bits[i*8 : i*8+8] {
+			codewords[i] <<= 1
+			if dark {
+				codewords[i] |= 1
+			}
+		}
+	}
+	return codewords
+}
+
+func TestEncode_Modules(t *testing.T) {
+	payload := []byte(`{"v":1,"id":"0b6f5a8e-3d1c-4c6b-9a59-2f0e7f3f1d2a","mac":"AA:BB:CC:DD:EE:FF","broker":"tcp://mqtt.example.com:1883","prefix":"farms/farm-1/devices","token":"00112233445566778899aabbccddeeff"}`)
+	code, err := Encode(payload)
+	require.NoError(t, err)
+	assert.Equal(t, 10, code.Version, "%d bytes", len(payload))
+
+	t.Run("should draw the finder patterns", func(t *testing.T) {
+		for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+			assert.True(t, code.Dark(corner[0], corner[1]))
+			assert.True(t, code.Dark(corner[0]+6, corner[1]+6))
+			assert.False(t, code.Dark(corner[0]+1, corner[1]+1))
+			assert.True(t, code.Dark(corner[0]+3, corner[1]+3))
+		}
+		assert.True(t, code.Dark(8, code.Size-8))
+	})
+
+	t.Run("should store the mask in the format information", func(t *testing.T) {
+		bits := 0
+		for i := 14; i >= 9; i-- {
+			bits = bits<<1 | boolBit(code.Dark(14-i, 8))
+		}
+		bits = bits<<1 | boolBit(code.Dark(7, 8))
+		bits = bits<<1 | boolBit(code.Dark(8, 8))
+		bits = bits<<1 | boolBit(code.Dark(8, 7))
+		for i := 5; i >= 0; i-- {
+			bits = bits<<1 | boolBit(code.Dark(8, i))
+		}
+		assert.Equal(t, formatBits(code.Mask), bits)
+	})
+
+	t.Run("should place the codewords with their error correction", func(t *testing.T) {
+		codewords := readCodewords(code)
+		assert.Equal(t, addECCAndInterleave(code.Version, encodeData(code.Version, payload)), codewords)
+
+		// Undo the interleaving; a syndrome of zero at every root of the generator shows each block
+		// is a valid Reed-Solomon codeword
+		blocks := eccBlocks[code.Version]
+		eccLength := eccCodewordsPerBlock[code.Version]
+		shortData := dataCodewords(code.Version) / blocks
+		longBlocks := dataCodewords(code.Version) % blocks
+		split := make([][]byte, blocks)
+		k := 0
+		for i := 0; i <= shortData; i++ {
+			for b := range blocks {
+				if i < shortData || b >= blocks-longBlocks {
+					split[b] = append(split[b], codewords[k])
+					k++
+				}
+			}
+		}
+		for range eccLength {
+			for b := range blocks {
+				split[b] = append(split[b], codewords[k])
+				k++
+			}
+		}
+		require.Equal(t, len(codewords), k)
+
+		for b, block := range split {
+			root := byte(1)
+			for range eccLength {
+				var syndrome byte
+				for _, codeword := range block {
+					syndrome = gfMultiply(syndrome, root) ^ codeword
+				}
+				assert.Zero(t, syndrome, "block %d", b)
+				root = gfMultiply(root, 0x02)
+			}
+		}
+	})
+}
+
+func TestRender(t *testing.T) {
+	code, err := Encode([]byte("https://example.com"))
+	require.NoError(t, err)
+
+	encoded, err := code.PNG(4)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(encoded))
+	require.NoError(t, err)
+	side := (code.Size + 2*QuietZone) * 4
+	assert.Equal(t, side, img.Bounds().Dx())
+	r, _, _, _ := img.At(QuietZone*4, QuietZone*4).RGBA()
+	assert.Zero(t, r, "the top left module is dark")
+	r, _, _, _ = img.At(0, 0).RGBA()
+	assert.NotZero(t, r, "the quiet zone is light")
+
+	svg := code.SVG()
+	assert.Contains(t, svg, `viewBox="0 0 33 33"`)
+	assert.Contains(t, svg, "M4,4h1v1h-1z")
+	assert.True(t, strings.HasSuffix(svg, "</svg>\n"))
+}
+
+func boolBit(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
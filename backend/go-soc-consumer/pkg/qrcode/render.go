@@ -0,0 +1,60 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// QuietZone is the light border around a rendered code, in modules, required by scanners
+const QuietZone = 4
+
+// PNG renders the code as a black and white PNG, each module being scale pixels wide
+func (c *Code) PNG(scale int) ([]byte, error) {
+	scale = max(scale, 1)
+	side := (c.Size + 2*QuietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+	for y := range c.Size {
+		for x := range c.Size {
+			if !c.modules[y][x] {
+				continue
+			}
+			for dy := range scale {
+				offset := img.PixOffset((x+QuietZone)*scale, (y+QuietZone)*scale+dy)
+				for dx := range scale {
+					img.Pix[offset+dx] = 1
+				}
+			}
+		}
+	}
+
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		return nil, fmt.Errorf("failed to encode QR code as PNG: %w", err)
+	}
+	return encoded.Bytes(), nil
+}
+
+// SVG renders the code as an SVG scaling to the size it is displayed at, one unit per module
+func (c *Code) SVG() string {
+	side := c.Size + 2*QuietZone
+	var path strings.Builder
+	for y := range c.Size {
+		for x := range c.Size {
+			if c.modules[y][x] {
+				fmt.Fprintf(&path, "M%d,%dh1v1h-1z", x+QuietZone, y+QuietZone)
+			}
+		}
+	}
+
+	var svg strings.Builder
+	svg.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, side, side)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="#fff"/>`, side, side)
+	fmt.Fprintf(&svg, `<path d="%s" fill="#000"/>`, path.String())
+	svg.WriteString("</svg>\n")
+	return svg.String()
+}
@@ -2,20 +2,22 @@ package validation
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 )
 
+// macAddressLength is the length of a MAC address written as six separated hex octets
+const macAddressLength = 17
+
 // ValidateMACAddress validates the MAC address format
 // It supports both colon (:) and dash (-) separators, but they must be consistent
 // Example valid formats: "01:23:45:67:89:AB" or "01-23-45-67-89-AB"
+// Valid addresses are checked without allocating since every ingested message goes through here.
 func ValidateMACAddress(macAddress string) error {
 	if macAddress == "" {
 		return fmt.Errorf("mac address is required")
 	}
 
-	// Normalize to uppercase for consistency
-	macAddress = strings.ToUpper(strings.TrimSpace(macAddress))
+	macAddress = strings.TrimSpace(macAddress)
 
 	// Check for consistent separator (either all colons or all dashes)
 	hasColon := strings.Contains(macAddress, ":")
@@ -26,15 +28,29 @@ func ValidateMACAddress(macAddress string) error {
 	}
 
 	// MAC address pattern: XX:XX:XX:XX:XX:XX or XX-XX-XX-XX-XX-XX
-	macPattern := `^([0-9A-F]{2}[:-]){5}([0-9A-F]{2})$`
-	matched, err := regexp.MatchString(macPattern, macAddress)
-	if err != nil {
-		return fmt.Errorf("error validating mac address: %w", err)
-	}
-
-	if !matched {
-		return fmt.Errorf("invalid mac address format: %s (expected format: XX:XX:XX:XX:XX:XX or XX-XX-XX-XX-XX-XX)", macAddress)
+	if !isMACAddress(macAddress) {
+		return fmt.Errorf("invalid mac address format: %s (expected format: XX:XX:XX:XX:XX:XX or XX-XX-XX-XX-XX-XX)", strings.ToUpper(macAddress))
 	}
 
 	return nil
 }
+
+// isMACAddress reports whether s is six hex octets, in either case, separated by colons or dashes
+func isMACAddress(s string) bool {
+	if len(s) != macAddressLength {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if i%3 == 2 {
+			if c != ':' && c != '-' {
+				return false
+			}
+			continue
+		}
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
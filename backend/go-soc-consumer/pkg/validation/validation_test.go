@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMACAddress(t *testing.T) {
+	tests := []struct {
+		name       string
+		macAddress string
+		wantErr    string
+	}{
+		{name: "colons", macAddress: "01:23:45:67:89:AB"},
+		{name: "dashes", macAddress: "01-23-45-67-89-AB"},
+		{name: "lowercase", macAddress: "aa:bb:cc:dd:ee:ff"},
+		{name: "surrounding spaces", macAddress: " AA:BB:CC:DD:EE:FF "},
+		{name: "empty", macAddress: "", wantErr: "mac address is required"},
+		{name: "mixed separators", macAddress: "AA:BB-CC:DD:EE:FF", wantErr: "mixed separators"},
+		{name: "too short", macAddress: "AA:BB:CC:DD:EE", wantErr: "invalid mac address format: AA:BB:CC:DD:EE "},
+		{name: "too long", macAddress: "AA:BB:CC:DD:EE:FF:00", wantErr: "invalid mac address format"},
+		{name: "not hex", macAddress: "gg:bb:cc:dd:ee:ff", wantErr: "invalid mac address format: GG:BB:CC:DD:EE:FF "},
+		{name: "no separators", macAddress: "AABBCCDDEEFF00000", wantErr: "invalid mac address format"},
+		{name: "misplaced separator", macAddress: "AAB:BC:CD:DE:EF:F", wantErr: "invalid mac address format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMACAddress(tt.macAddress)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func BenchmarkValidateMACAddress(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := ValidateMACAddress("02:4C:54:00:00:01"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+// Package version holds the version the binary was built as.
+package version
+
+// Version is set at build time with -ldflags "-X github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/pkg/version.Version=<version>"
+var Version = "dev"
@@ -0,0 +1,111 @@
+package loadtest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Benchmark units compared against the baseline
+const (
+	UnitNsPerOp     = "ns/op"
+	UnitAllocsPerOp = "allocs/op"
+)
+
+// Regression is a benchmark measure that grew beyond the tolerance over its baseline
+type Regression struct {
+	Name     string
+	Unit     string
+	Baseline float64
+	Current  float64
+	Change   float64 // relative change, 0.25 is 25% more
+}
+
+// ParseBenchmarks reads the ns/op and allocs/op of every benchmark from `go test -bench` output,
+// keyed by benchmark name and then unit, averaging repeated runs of -count. The GOMAXPROCS
+// suffix is dropped from the names so results of machines with a different number of CPUs compare.
+func ParseBenchmarks(r io.Reader) (map[string]map[string]float64, error) {
+	totals := make(map[string]map[string]float64)
+	runs := make(map[string]map[string]int)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+
+		name := benchmarkName(fields[0])
+		for i := 2; i+1 < len(fields); i += 2 {
+			unit := fields[i+1]
+			if unit != UnitNsPerOp && unit != UnitAllocsPerOp {
+				continue
+			}
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s in %q: %w", unit, scanner.Text(), err)
+			}
+			if totals[name] == nil {
+				totals[name] = make(map[string]float64)
+				runs[name] = make(map[string]int)
+			}
+			totals[name][unit] += value
+			runs[name][unit]++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]map[string]float64, len(totals))
+	for name, units := range totals {
+		results[name] = make(map[string]float64, len(units))
+		for unit, total := range units {
+			results[name][unit] = total / float64(runs[name][unit])
+		}
+	}
+	return results, nil
+}
+
+// benchmarkName drops the -N GOMAXPROCS suffix of a benchmark name
+func benchmarkName(name string) string {
+	if i := strings.LastIndexByte(name, '-'); i > 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			return name[:i]
+		}
+	}
+	return name
+}
+
+// CompareBenchmarks returns the measures that grew over their baseline by more than the
+// tolerance, sorted by name and unit. Measures missing from either side are not compared.
+func CompareBenchmarks(baseline, current map[string]map[string]float64, tolerance float64) []Regression {
+	var regressions []Regression
+	for name, units := range baseline {
+		for unit, base := range units {
+			now, ok := current[name][unit]
+			if !ok {
+				continue
+			}
+			change := 0.0
+			switch {
+			case base > 0:
+				change = now/base - 1
+			case now > 0:
+				change = 1 // from nothing, e.g. allocations appearing on an allocation free path
+			}
+			if change > tolerance {
+				regressions = append(regressions, Regression{Name: name, Unit: unit, Baseline: base, Current: now, Change: change})
+			}
+		}
+	}
+	sort.Slice(regressions, func(i, j int) bool {
+		if regressions[i].Name != regressions[j].Name {
+			return regressions[i].Name < regressions[j].Name
+		}
+		return regressions[i].Unit < regressions[j].Unit
+	})
+	return regressions
+}
@@ -0,0 +1,60 @@
+package loadtest
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBenchmarks(t *testing.T) {
+	output := "goos: linux\n" +
+		"BenchmarkSensorData-8   \t  40000\t     30000 ns/op\t   10793 B/op\t     119 allocs/op\n" +
+		"BenchmarkSensorData-8   \t  40000\t     20000 ns/op\t   10793 B/op\t     119 allocs/op\n" +
+		"BenchmarkRegistration   \t  50000\t     25000 ns/op\n" +
+		"PASS\n"
+
+	results, err := ParseBenchmarks(strings.NewReader(output))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]float64{
+		"BenchmarkSensorData":   {UnitNsPerOp: 25000, UnitAllocsPerOp: 119},
+		"BenchmarkRegistration": {UnitNsPerOp: 25000},
+	}, results)
+
+	_, err = ParseBenchmarks(strings.NewReader("BenchmarkX-8 10 fast ns/op\n"))
+	assert.Error(t, err)
+}
+
+func TestParseBenchmarks_Baseline(t *testing.T) {
+	file, err := os.Open("testdata/baseline.txt")
+	require.NoError(t, err)
+	defer file.Close()
+
+	baseline, err := ParseBenchmarks(file)
+	require.NoError(t, err)
+	for _, name := range []string{"BenchmarkDeviceRegistrationHandler_HandleMessage", "BenchmarkSensorDataHandler_HandleMessage", "BenchmarkMeasurementHandler_HandleMessage"} {
+		assert.Contains(t, baseline, name)
+		assert.Greater(t, baseline[name][UnitNsPerOp], 0.0)
+	}
+}
+
+func TestCompareBenchmarks(t *testing.T) {
+	baseline := map[string]map[string]float64{
+		"BenchmarkA": {UnitNsPerOp: 1000, UnitAllocsPerOp: 10},
+		"BenchmarkB": {UnitNsPerOp: 1000, UnitAllocsPerOp: 0},
+		"BenchmarkC": {UnitNsPerOp: 1000},
+	}
+	current := map[string]map[string]float64{
+		"BenchmarkA": {UnitNsPerOp: 1100, UnitAllocsPerOp: 15},
+		"BenchmarkB": {UnitNsPerOp: 500, UnitAllocsPerOp: 2},
+	}
+
+	regressions := CompareBenchmarks(baseline, current, 0.2)
+
+	assert.Equal(t, []Regression{
+		{Name: "BenchmarkA", Unit: UnitAllocsPerOp, Baseline: 10, Current: 15, Change: 0.5},
+		{Name: "BenchmarkB", Unit: UnitAllocsPerOp, Baseline: 0, Current: 2, Change: 1},
+	}, regressions)
+}
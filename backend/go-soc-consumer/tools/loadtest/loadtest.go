@@ -0,0 +1,125 @@
+// Package loadtest drives synthetic device traffic against a running server over MQTT and
+// reports the throughput, publish latency and errors it observed, together with the ingestion
+// latency the server recorded for the traffic. It also compares Go benchmark results against a
+// baseline to catch performance regressions of the ingestion paths.
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Message kinds sent by the load test
+const (
+	KindRegistration = "registration"
+	KindSensorData   = "sensor_data"
+	KindMeasurement  = "measurement"
+)
+
+// Publisher publishes a message and returns once the broker acknowledged it
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
+
+// Config holds the load test settings
+type Config struct {
+	Devices           int           // synthetic devices; each registers once before sending telemetry
+	Rate              float64       // messages per second across all devices
+	Duration          time.Duration // how long traffic is sent
+	Concurrency       int           // publishes awaiting their acknowledgement at once
+	RegistrationRatio float64       // share of later messages re-registering a device, as after a reboot
+	MeasurementRatio  float64       // share of telemetry sent as measurement rather than sensor data messages
+	FarmID            string        // publish on the farm namespaced topics when set
+	Seed              int64         // seeds the generated values so runs are repeatable
+}
+
+// DefaultConfig returns default configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Devices:           50,
+		Rate:              100,
+		Duration:          time.Minute,
+		Concurrency:       16,
+		RegistrationRatio: 0.01,
+		MeasurementRatio:  0.2,
+		Seed:              1,
+	}
+}
+
+// Validate checks the settings
+func (c *Config) Validate() error {
+	if c.Devices < 1 {
+		return fmt.Errorf("devices must be at least 1")
+	}
+	if c.Rate <= 0 {
+		return fmt.Errorf("rate must be greater than 0")
+	}
+	if c.Duration <= 0 {
+		return fmt.Errorf("duration must be greater than 0")
+	}
+	if c.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+	if c.RegistrationRatio < 0 || c.RegistrationRatio > 1 {
+		return fmt.Errorf("registration ratio must be between 0 and 1")
+	}
+	if c.MeasurementRatio < 0 || c.MeasurementRatio > 1 {
+		return fmt.Errorf("measurement ratio must be between 0 and 1")
+	}
+	return nil
+}
+
+// Run sends traffic at the configured rate until the duration elapses or ctx is cancelled.
+// Messages due while every publish is still awaiting its acknowledgement are skipped and
+// counted, so a saturated broker shows up as a rate below the target rather than as a backlog
+// in the load test itself.
+func Run(ctx context.Context, config *Config, publisher Publisher) (*Report, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	traffic := newTraffic(config)
+	report := newReport(config)
+	jobs := make(chan message, config.Concurrency)
+
+	var workers sync.WaitGroup
+	for i := 0; i < config.Concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for msg := range jobs {
+				start := time.Now()
+				err := publisher.Publish(ctx, msg.topic, msg.payload)
+				report.record(msg.kind, time.Since(start), err)
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / config.Rate))
+	defer ticker.Stop()
+	deadline := time.NewTimer(config.Duration)
+	defer deadline.Stop()
+
+	start := time.Now()
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-deadline.C:
+			break loop
+		case now := <-ticker.C:
+			select {
+			case jobs <- traffic.next(now):
+			default:
+				report.skip()
+			}
+		}
+	}
+	close(jobs)
+	workers.Wait()
+	report.Elapsed = time.Since(start)
+	return report, nil
+}
@@ -0,0 +1,150 @@
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/mqtt/handlers"
+)
+
+// recordingPublisher keeps the published topics and fails those listed in failures
+type recordingPublisher struct {
+	mu       sync.Mutex
+	topics   []string
+	failures map[string]error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, topic string, _ []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.topics = append(p.topics, topic)
+	return p.failures[topic]
+}
+
+func TestConfig_Validate(t *testing.T) {
+	require.NoError(t, DefaultConfig().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{name: "no devices", modify: func(c *Config) { c.Devices = 0 }},
+		{name: "no rate", modify: func(c *Config) { c.Rate = 0 }},
+		{name: "no duration", modify: func(c *Config) { c.Duration = 0 }},
+		{name: "no concurrency", modify: func(c *Config) { c.Concurrency = 0 }},
+		{name: "registration ratio above one", modify: func(c *Config) { c.RegistrationRatio = 1.5 }},
+		{name: "negative measurement ratio", modify: func(c *Config) { c.MeasurementRatio = -0.1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(config)
+			assert.Error(t, config.Validate())
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	config := DefaultConfig()
+	config.Devices = 3
+	config.Rate = 500
+	config.Duration = 200 * time.Millisecond
+	config.MeasurementRatio = 0
+	config.RegistrationRatio = 0
+	publisher := &recordingPublisher{failures: map[string]error{handlers.SensorDataTopic: errors.New("not authorized")}}
+
+	report, err := Run(context.Background(), config, publisher)
+	require.NoError(t, err)
+
+	require.Greater(t, len(publisher.topics), 3)
+	assert.Equal(t, 3, report.Kinds[KindRegistration].Sent, "every device registers first")
+	assert.Equal(t, 0, report.Kinds[KindSensorData].Sent)
+	assert.Equal(t, len(publisher.topics)-3, report.Kinds[KindSensorData].Failed)
+	assert.Equal(t, report.Failed(), report.Kinds[KindSensorData].Errors["not authorized"])
+	assert.GreaterOrEqual(t, report.Elapsed, config.Duration)
+
+	_, err = Run(context.Background(), &Config{}, publisher)
+	assert.Error(t, err)
+}
+
+func TestTraffic(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 250_000_000, time.UTC)
+
+	t.Run("should register every device before sending telemetry", func(t *testing.T) {
+		config := DefaultConfig()
+		config.Devices = 2
+		config.RegistrationRatio = 0
+		config.MeasurementRatio = 0
+		traffic := newTraffic(config)
+
+		first, second, third := traffic.next(now), traffic.next(now), traffic.next(now)
+		assert.Equal(t, KindRegistration, first.kind)
+		assert.Equal(t, KindRegistration, second.kind)
+		assert.Equal(t, KindSensorData, third.kind)
+		assert.Equal(t, handlers.SensorDataTopic, third.topic)
+
+		var registration dtos.DeviceRegistrationMessage
+		require.NoError(t, json.Unmarshal(second.payload, &registration))
+		assert.Equal(t, "02:4C:54:00:00:01", registration.MacAddress)
+		assert.Equal(t, "register", registration.EventType)
+
+		var reading dtos.SensorDataMessage
+		require.NoError(t, json.Unmarshal(third.payload, &reading))
+		assert.Equal(t, "02:4C:54:00:00:00", reading.MacAddress)
+		assert.Equal(t, now, dtos.DeviceTime(reading.Timestamp))
+	})
+
+	t.Run("should publish measurements on farm topics", func(t *testing.T) {
+		config := DefaultConfig()
+		config.Devices = 1
+		config.RegistrationRatio = 0
+		config.MeasurementRatio = 1
+		config.FarmID = "farm-7"
+		traffic := newTraffic(config)
+
+		assert.Equal(t, handlers.FarmTopic(handlers.FarmDeviceRegistrationTopic, "farm-7"), traffic.next(now).topic)
+		measurement := traffic.next(now)
+		assert.Equal(t, KindMeasurement, measurement.kind)
+		assert.Equal(t, handlers.FarmTopic(handlers.FarmMeasurementTopic, "farm-7"), measurement.topic)
+	})
+
+	t.Run("should generate the same traffic for the same seed", func(t *testing.T) {
+		config := DefaultConfig()
+		first, second := newTraffic(config), newTraffic(config)
+		for i := 0; i < 100; i++ {
+			require.Equal(t, first.next(now), second.next(now))
+		}
+	})
+}
+
+func TestReport_WriteText(t *testing.T) {
+	report := newReport(&Config{Rate: 10})
+	report.Elapsed = 2 * time.Second
+	for i := 1; i <= 10; i++ {
+		report.record(KindSensorData, time.Duration(i)*time.Millisecond, nil)
+	}
+	report.record(KindRegistration, 0, errors.New("connection lost"))
+	report.skip()
+	report.Server = []ServerLatency{{Topic: handlers.SensorDataTopic, Stage: "receipt_to_commit", Count: 10, P50: 2 * time.Millisecond, P95: 4 * time.Millisecond, P99: 5 * time.Millisecond}}
+
+	assert.Equal(t, 5*time.Millisecond, report.Kinds[KindSensorData].Latency(0.5))
+	assert.Equal(t, 5.0, report.Throughput())
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+	text := buf.String()
+	assert.True(t, strings.HasPrefix(text, "elapsed 2s, target 10.0 msg/s, achieved 5.0 msg/s, sent 10, failed 1, skipped 1\n"))
+	assert.Contains(t, text, "registration error (1): connection lost")
+	assert.Contains(t, text, "server ingestion latency")
+	assert.Contains(t, text, "receipt_to_commit")
+}
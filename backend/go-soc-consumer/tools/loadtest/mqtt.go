@@ -0,0 +1,59 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// MQTTConfig holds the connection settings of the load test client
+type MQTTConfig struct {
+	BrokerURL string
+	ClientID  string
+	Username  string
+	Password  string
+	QoS       byte
+}
+
+// MQTTPublisher publishes the load test traffic over one MQTT connection
+type MQTTPublisher struct {
+	client mqtt.Client
+	qos    byte
+}
+
+// NewMQTTPublisher connects to the broker
+func NewMQTTPublisher(config MQTTConfig) (*MQTTPublisher, error) {
+	opts := mqtt.NewClientOptions()
+	opts.AddBroker(config.BrokerURL)
+	opts.SetClientID(config.ClientID)
+	opts.SetUsername(config.Username)
+	opts.SetPassword(config.Password)
+	opts.SetConnectTimeout(10 * time.Second)
+	// Publishes are acknowledged in any order so slow acknowledgements do not block others
+	opts.SetOrderMatters(false)
+
+	client := mqtt.NewClient(opts)
+	if token := client.Connect(); token.Wait() && token.Error() != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", config.BrokerURL, token.Error())
+	}
+	return &MQTTPublisher{client: client, qos: config.QoS}, nil
+}
+
+// Publish publishes a message and waits for the broker to acknowledge it; QoS 0 messages return
+// once written to the connection
+func (p *MQTTPublisher) Publish(ctx context.Context, topic string, payload []byte) error {
+	token := p.client.Publish(topic, p.qos, false, payload)
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close disconnects from the broker
+func (p *MQTTPublisher) Close() {
+	p.client.Disconnect(250)
+}
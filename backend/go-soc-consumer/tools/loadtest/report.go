@@ -0,0 +1,155 @@
+package loadtest
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Report holds what a load test observed
+type Report struct {
+	TargetRate float64
+	Elapsed    time.Duration
+	Skipped    int // messages not sent because every publish was awaiting its acknowledgement
+	Kinds      map[string]*KindReport
+	Server     []ServerLatency // ingestion latency recorded by the server during the run, when scraped
+
+	mu sync.Mutex
+}
+
+// KindReport holds the publishes of one message kind
+type KindReport struct {
+	Sent      int
+	Failed    int
+	Errors    map[string]int // failures by error message
+	latencies []time.Duration
+}
+
+func newReport(config *Config) *Report {
+	return &Report{TargetRate: config.Rate, Kinds: make(map[string]*KindReport)}
+}
+
+// record records a publish and its acknowledgement latency
+func (r *Report) record(kind string, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report, ok := r.Kinds[kind]
+	if !ok {
+		report = &KindReport{Errors: make(map[string]int)}
+		r.Kinds[kind] = report
+	}
+	if err != nil {
+		report.Failed++
+		report.Errors[err.Error()]++
+		return
+	}
+	report.Sent++
+	report.latencies = append(report.latencies, latency)
+}
+
+func (r *Report) skip() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Skipped++
+}
+
+// Sent returns the messages acknowledged by the broker
+func (r *Report) Sent() int {
+	sent := 0
+	for _, report := range r.Kinds {
+		sent += report.Sent
+	}
+	return sent
+}
+
+// Failed returns the messages the broker did not acknowledge
+func (r *Report) Failed() int {
+	failed := 0
+	for _, report := range r.Kinds {
+		failed += report.Failed
+	}
+	return failed
+}
+
+// Throughput returns the acknowledged messages per second
+func (r *Report) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Sent()) / r.Elapsed.Seconds()
+}
+
+// Latency returns the q quantile of the acknowledgement latency, zero without publishes
+func (k *KindReport) Latency(q float64) time.Duration {
+	if len(k.latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), k.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+// WriteText renders the report as aligned text
+func (r *Report) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "elapsed %s, target %.1f msg/s, achieved %.1f msg/s, sent %d, failed %d, skipped %d\n\n",
+		r.Elapsed.Round(time.Millisecond), r.TargetRate, r.Throughput(), r.Sent(), r.Failed(), r.Skipped); err != nil {
+		return err
+	}
+
+	kinds := make([]string, 0, len(r.Kinds))
+	for kind := range r.Kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "KIND\tSENT\tFAILED\tACK P50\tACK P95\tACK P99")
+	for _, kind := range kinds {
+		report := r.Kinds[kind]
+		fmt.Fprintf(table, "%s\t%d\t%d\t%s\t%s\t%s\n", kind, report.Sent, report.Failed,
+			formatDuration(report.Latency(0.5)), formatDuration(report.Latency(0.95)), formatDuration(report.Latency(0.99)))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	for _, kind := range kinds {
+		errors := r.Kinds[kind].Errors
+		messages := make([]string, 0, len(errors))
+		for message := range errors {
+			messages = append(messages, message)
+		}
+		sort.Strings(messages)
+		for _, message := range messages {
+			if _, err := fmt.Fprintf(w, "%s error (%d): %s\n", kind, errors[message], message); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(r.Server) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w, "\nserver ingestion latency"); err != nil {
+		return err
+	}
+	table = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "TOPIC\tSTAGE\tCOUNT\tP50\tP95\tP99")
+	for _, latency := range r.Server {
+		fmt.Fprintf(table, "%s\t%s\t%d\t%s\t%s\t%s\n", latency.Topic, latency.Stage, latency.Count,
+			formatDuration(latency.P50), formatDuration(latency.P95), formatDuration(latency.P99))
+	}
+	return table.Flush()
+}
+
+// formatDuration renders latencies with a precision that suits their magnitude
+func formatDuration(d time.Duration) string {
+	if d >= time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(10 * time.Microsecond).String()
+}
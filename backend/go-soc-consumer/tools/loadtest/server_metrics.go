@@ -0,0 +1,189 @@
+package loadtest
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// latencyBucketSeries is the bucket series of the server's ingestion latency histogram
+const latencyBucketSeries = "ingestion_latency_seconds_bucket"
+
+// ServerLatency is the ingestion latency the server recorded for a topic and stage
+type ServerLatency struct {
+	Topic string
+	Stage string
+	Count uint64
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+}
+
+// latencySeries identifies a histogram of the ingestion latency
+type latencySeries struct {
+	topic string
+	stage string
+}
+
+// LatencySnapshot holds the cumulative bucket counts of the server's ingestion latency
+// histograms at one point in time, by upper bound
+type LatencySnapshot map[latencySeries]map[float64]float64
+
+// ScrapeIngestionLatency reads the ingestion latency histograms from the server's metrics endpoint
+func ScrapeIngestionLatency(ctx context.Context, client *http.Client, metricsURL string) (LatencySnapshot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: status %d", metricsURL, resp.StatusCode)
+	}
+	return parseIngestionLatency(resp.Body)
+}
+
+// parseIngestionLatency reads the ingestion latency buckets from the Prometheus text format
+func parseIngestionLatency(r io.Reader) (LatencySnapshot, error) {
+	snapshot := make(LatencySnapshot)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, latencyBucketSeries+"{") {
+			continue
+		}
+
+		labels, rest, err := parseLabels(line[len(latencyBucketSeries):])
+		if err != nil {
+			return nil, fmt.Errorf("invalid series %q: %w", line, err)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(rest), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in %q: %w", line, err)
+		}
+		upperBound, err := strconv.ParseFloat(labels["le"], 64) // accepts +Inf
+		if err != nil {
+			return nil, fmt.Errorf("invalid le label in %q: %w", line, err)
+		}
+
+		series := latencySeries{topic: labels["topic"], stage: labels["stage"]}
+		if snapshot[series] == nil {
+			snapshot[series] = make(map[float64]float64)
+		}
+		snapshot[series][upperBound] = value
+	}
+	return snapshot, scanner.Err()
+}
+
+// parseLabels parses a {name="value",...} label set and returns the text after it
+func parseLabels(text string) (map[string]string, string, error) {
+	if !strings.HasPrefix(text, "{") {
+		return nil, "", fmt.Errorf("missing labels")
+	}
+	labels := make(map[string]string)
+	i := 1
+	for {
+		if i < len(text) && text[i] == '}' {
+			return labels, text[i+1:], nil
+		}
+		eq := strings.Index(text[i:], `="`)
+		if eq < 0 {
+			return nil, "", fmt.Errorf("unterminated labels")
+		}
+		name := text[i : i+eq]
+		i += eq + 2
+
+		var value strings.Builder
+		for ; i < len(text) && text[i] != '"'; i++ {
+			if text[i] == '\\' && i+1 < len(text) {
+				i++
+				if text[i] == 'n' {
+					value.WriteByte('\n')
+					continue
+				}
+			}
+			value.WriteByte(text[i])
+		}
+		if i >= len(text) {
+			return nil, "", fmt.Errorf("unterminated label value")
+		}
+		labels[name] = value.String()
+		i++ // closing quote
+		if i < len(text) && text[i] == ',' {
+			i++
+		}
+	}
+}
+
+// Since returns the latency of the observations recorded after the before snapshot, by topic and stage
+func (s LatencySnapshot) Since(before LatencySnapshot) []ServerLatency {
+	latencies := make([]ServerLatency, 0, len(s))
+	for series, buckets := range s {
+		delta := make(map[float64]float64, len(buckets))
+		for upperBound, count := range buckets {
+			delta[upperBound] = count - before[series][upperBound]
+		}
+		count := delta[math.Inf(1)]
+		if count <= 0 {
+			continue
+		}
+		latencies = append(latencies, ServerLatency{
+			Topic: series.topic,
+			Stage: series.stage,
+			Count: uint64(count),
+			P50:   bucketQuantile(0.5, delta),
+			P95:   bucketQuantile(0.95, delta),
+			P99:   bucketQuantile(0.99, delta),
+		})
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		if latencies[i].Topic != latencies[j].Topic {
+			return latencies[i].Topic < latencies[j].Topic
+		}
+		return latencies[i].Stage < latencies[j].Stage
+	})
+	return latencies
+}
+
+// bucketQuantile estimates a quantile from cumulative buckets by linear interpolation within
+// the bucket holding it, as Prometheus' histogram_quantile does. Quantiles falling in the +Inf
+// bucket are reported as the highest finite upper bound.
+func bucketQuantile(q float64, buckets map[float64]float64) time.Duration {
+	bounds := make([]float64, 0, len(buckets))
+	for upperBound := range buckets {
+		bounds = append(bounds, upperBound)
+	}
+	sort.Float64s(bounds)
+
+	rank := q * buckets[math.Inf(1)]
+	lowerBound, lowerCount := 0.0, 0.0
+	for _, upperBound := range bounds {
+		count := buckets[upperBound]
+		if count >= rank {
+			if math.IsInf(upperBound, 1) {
+				return seconds(lowerBound)
+			}
+			if count == lowerCount {
+				return seconds(upperBound)
+			}
+			return seconds(lowerBound + (upperBound-lowerBound)*(rank-lowerCount)/(count-lowerCount))
+		}
+		lowerBound, lowerCount = upperBound, count
+	}
+	return seconds(lowerBound)
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
@@ -0,0 +1,67 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// latencyExposition renders one ingestion latency histogram with the given cumulative counts
+// for the 0.01, 0.1 and 1 second buckets and the total count
+func latencyExposition(counts ...int) string {
+	return "# TYPE ingestion_latency_seconds histogram\n" +
+		fmt.Sprintf("ingestion_latency_seconds_bucket{le=\"0.01\",stage=\"receipt_to_commit\",topic=\"farms/{farmID}/devices/sensors/measurements\"} %d\n", counts[0]) +
+		fmt.Sprintf("ingestion_latency_seconds_bucket{le=\"0.1\",stage=\"receipt_to_commit\",topic=\"farms/{farmID}/devices/sensors/measurements\"} %d\n", counts[1]) +
+		fmt.Sprintf("ingestion_latency_seconds_bucket{le=\"1\",stage=\"receipt_to_commit\",topic=\"farms/{farmID}/devices/sensors/measurements\"} %d\n", counts[2]) +
+		fmt.Sprintf("ingestion_latency_seconds_bucket{le=\"+Inf\",stage=\"receipt_to_commit\",topic=\"farms/{farmID}/devices/sensors/measurements\"} %d\n", counts[3]) +
+		"ingestion_latency_seconds_sum{stage=\"receipt_to_commit\",topic=\"farms/{farmID}/devices/sensors/measurements\"} 1.5\n" +
+		"mqtt_messages_total{topic=\"x\"} 3\n"
+}
+
+func TestScrapeIngestionLatency(t *testing.T) {
+	exposition := latencyExposition(0, 0, 0, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, exposition)
+	}))
+	defer server.Close()
+
+	before, err := ScrapeIngestionLatency(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+	exposition = latencyExposition(50, 90, 100, 100)
+	after, err := ScrapeIngestionLatency(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+
+	latencies := after.Since(before)
+	require.Len(t, latencies, 1)
+	assert.Equal(t, "farms/{farmID}/devices/sensors/measurements", latencies[0].Topic)
+	assert.Equal(t, "receipt_to_commit", latencies[0].Stage)
+	assert.Equal(t, uint64(100), latencies[0].Count)
+	assert.Equal(t, 10*time.Millisecond, latencies[0].P50)
+	assert.Equal(t, 550*time.Millisecond, latencies[0].P95)
+
+	assert.Empty(t, after.Since(after), "series without new observations are left out")
+}
+
+func TestBucketQuantile(t *testing.T) {
+	buckets := map[float64]float64{0.01: 0, 0.1: 0, 1: 1, math.Inf(1): 10}
+
+	assert.Equal(t, time.Second, bucketQuantile(0.99, buckets), "quantiles in the +Inf bucket report the highest bound")
+	assert.Equal(t, 550*time.Millisecond, bucketQuantile(0.05, buckets))
+}
+
+func TestParseLabels(t *testing.T) {
+	labels, rest, err := parseLabels(`{a="x\"y\\z",b="{c}"} 1`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": `x"y\z`, "b": "{c}"}, labels)
+	assert.Equal(t, " 1", rest)
+
+	_, _, err = parseLabels(`{a="x`)
+	assert.Error(t, err)
+}
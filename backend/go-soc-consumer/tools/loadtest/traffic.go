@@ -0,0 +1,114 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/dtos"
+	"github.com/liwaisi-tech/iot-server-smart-irrigation/backend/go-soc-consumer/internal/infrastructure/messaging/mqtt/handlers"
+)
+
+// message is one message to publish
+type message struct {
+	kind    string
+	topic   string
+	payload []byte
+}
+
+// traffic generates the messages of the synthetic devices, round robin over the devices.
+// It is only used by the goroutine driving the load test.
+type traffic struct {
+	config     *Config
+	random     *rand.Rand
+	macs       []string
+	registered int // devices that sent their first registration
+	cursor     int
+}
+
+func newTraffic(config *Config) *traffic {
+	macs := make([]string, config.Devices)
+	for i := range macs {
+		// Locally administered addresses so synthetic devices never collide with real ones
+		macs[i] = fmt.Sprintf("02:4C:54:%02X:%02X:%02X", (i>>16)&0xff, (i>>8)&0xff, i&0xff)
+	}
+	return &traffic{
+		config: config,
+		random: rand.New(rand.NewPCG(uint64(config.Seed), uint64(config.Devices))),
+		macs:   macs,
+	}
+}
+
+// next returns the message to publish at now
+func (t *traffic) next(now time.Time) message {
+	if t.registered < len(t.macs) {
+		mac := t.macs[t.registered]
+		t.registered++
+		return t.registration(mac)
+	}
+
+	mac := t.macs[t.cursor]
+	t.cursor = (t.cursor + 1) % len(t.macs)
+	switch draw := t.random.Float64(); {
+	case draw < t.config.RegistrationRatio:
+		return t.registration(mac)
+	case draw < t.config.RegistrationRatio+(1-t.config.RegistrationRatio)*t.config.MeasurementRatio:
+		return t.measurement(mac, now)
+	default:
+		return t.sensorData(mac, now)
+	}
+}
+
+func (t *traffic) registration(mac string) message {
+	return t.encode(KindRegistration, handlers.DeviceRegistrationTopic, handlers.FarmDeviceRegistrationTopic, dtos.DeviceRegistrationMessage{
+		EventType:           "register",
+		MacAddress:          mac,
+		DeviceName:          "loadtest-" + strings.ReplaceAll(mac[9:], ":", ""),
+		IPAddress:           fmt.Sprintf("10.%d.%d.%d", t.random.IntN(256), t.random.IntN(256), 1+t.random.IntN(254)),
+		LocationDescription: "Load test",
+		FirmwareVersion:     "loadtest",
+	})
+}
+
+func (t *traffic) sensorData(mac string, now time.Time) message {
+	return t.encode(KindSensorData, handlers.SensorDataTopic, handlers.FarmSensorDataTopic, dtos.SensorDataMessage{
+		EventType:   "sensor_data",
+		MacAddress:  mac,
+		Temperature: t.reading(15, 35),
+		Humidity:    t.reading(30, 90),
+		Timestamp:   unixSeconds(now),
+	})
+}
+
+func (t *traffic) measurement(mac string, now time.Time) message {
+	return t.encode(KindMeasurement, handlers.MeasurementTopic, handlers.FarmMeasurementTopic, dtos.MeasurementMessage{
+		EventType:  "measurement",
+		MacAddress: mac,
+		Measurements: []dtos.MeasurementValue{
+			{Type: "temperature", Value: t.reading(15, 35)},
+			{Type: "humidity", Value: t.reading(30, 90)},
+		},
+		Timestamp: unixSeconds(now),
+	})
+}
+
+// encode builds a message on the shared topic, or on the farm topic when a farm is configured
+func (t *traffic) encode(kind, topic, farmTopic string, body interface{}) message {
+	if t.config.FarmID != "" {
+		topic = handlers.FarmTopic(farmTopic, t.config.FarmID)
+	}
+	payload, _ := json.Marshal(body) // the message DTOs always encode
+	return message{kind: kind, topic: topic, payload: payload}
+}
+
+// reading returns a value between min and max rounded to two decimals, as devices report them
+func (t *traffic) reading(min, max float64) float64 {
+	return float64(int((min+t.random.Float64()*(max-min))*100)) / 100
+}
+
+// unixSeconds returns a device timestamp in unix seconds with millisecond precision
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1000
+}